@@ -12,6 +12,10 @@ die() {
 
 if [[ -z "$USE_BAZEL" || "$USE_BAZEL" -eq "0" ]]; then
   must go test -v ./...
+  if [[ -z "$GOARCH" ]]; then
+    # 32-bit ARM can't run on the CI workers, so only check that it builds.
+    must env GOARCH=arm go build ./...
+  fi
 else
   # On Travis, this will use "$HOME/bin/bazel", but don't assume this
   # for local testing of the CI script.
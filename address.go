@@ -89,6 +89,20 @@ func (sz ObjectSize) totalSize() Size {
 	return sz.DataSize + sz.pointerSize()
 }
 
+// TotalSize returns the number of bytes that an object of size sz
+// occupies, not counting the objects that its pointers refer to.
+func (sz ObjectSize) TotalSize() Size {
+	return sz.totalSize()
+}
+
+// Covers reports whether a struct of size sz has room for every field
+// of a struct of size old.  A struct's size only grows as fields are
+// added to its schema, so a newer version of a schema must cover the
+// sizes of its older versions.
+func (sz ObjectSize) Covers(old ObjectSize) bool {
+	return sz.DataSize >= old.DataSize && sz.PointerCount >= old.PointerCount
+}
+
 // dataWordCount returns the number of words in the data section.
 func (sz ObjectSize) dataWordCount() int32 {
 	if sz.DataSize%wordSize != 0 {
@@ -29,3 +29,22 @@ func TestAddressElement(t *testing.T) {
 		}
 	}
 }
+
+func TestObjectSizeCovers(t *testing.T) {
+	tests := []struct {
+		sz, old ObjectSize
+		ok      bool
+	}{
+		{ObjectSize{}, ObjectSize{}, true},
+		{ObjectSize{8, 1}, ObjectSize{8, 1}, true},
+		{ObjectSize{16, 1}, ObjectSize{8, 1}, true},
+		{ObjectSize{8, 2}, ObjectSize{8, 1}, true},
+		{ObjectSize{8, 1}, ObjectSize{16, 1}, false},
+		{ObjectSize{16, 0}, ObjectSize{8, 1}, false},
+	}
+	for _, test := range tests {
+		if ok := test.sz.Covers(test.old); ok != test.ok {
+			t.Errorf("%v.Covers(%v) = %t; want %t", test.sz, test.old, ok, test.ok)
+		}
+	}
+}
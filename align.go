@@ -0,0 +1,14 @@
+package capnp
+
+import "unsafe"
+
+// isAligned reports whether b starts on a word boundary.  Segment
+// accessors decode values a byte at a time, so they work on any
+// buffer; alignment only matters when DecodeOptions.RequireAligned is
+// set.
+func isAligned(b []byte) bool {
+	if cap(b) == 0 {
+		return true
+	}
+	return uintptr(unsafe.Pointer(&b[:1][0]))%uintptr(wordSize) == 0
+}
@@ -0,0 +1,236 @@
+package capnp
+
+import (
+	"errors"
+	"sync"
+)
+
+// An AnswerStream delivers the calls made on a callback capability to
+// a Go channel.  A program that wants a server to stream results to it
+// passes the stream's Client to the server, which calls a method on it
+// once per result, and then reads the calls from C in the order that
+// they were made.
+//
+// The answer to each call is resolved once the call has been placed in
+// the channel.  While the channel is full, calls are held by the stream
+// and their answers are not resolved, so a caller that limits the
+// number of calls that it has in flight, as Cap'n Proto streaming
+// callers do, slows down to the pace of the reader.
+type AnswerStream struct {
+	c          chan *Call
+	maxPending int
+
+	mu      sync.Mutex
+	pending []streamCall // calls waiting for room in c; the first is being sent
+	closed  bool         // no more calls will be accepted
+	stopped bool         // pending calls are abandoned
+	wake    chan struct{}
+	stop    chan struct{}
+}
+
+// AnswerStreamOptions holds the parameters of an AnswerStream.
+type AnswerStreamOptions struct {
+	// Buffer is the capacity of the stream's channel.  Zero means an
+	// unbuffered channel: each call's answer is resolved when the
+	// reader receives it.
+	Buffer int
+
+	// MaxPending is the number of calls the stream holds while its
+	// channel is full.  Calls past this limit fail immediately.  Zero
+	// means no limit, which is only safe if the caller bounds the
+	// number of calls that it has in flight.
+	MaxPending int
+}
+
+// NewAnswerStream returns a new stream configured by opts.
+func NewAnswerStream(opts AnswerStreamOptions) *AnswerStream {
+	s := &AnswerStream{
+		c:          make(chan *Call, opts.Buffer),
+		maxPending: opts.MaxPending,
+		wake:       make(chan struct{}, 1),
+		stop:       make(chan struct{}),
+	}
+	go s.deliver()
+	return s
+}
+
+// C returns the channel that the stream's calls are delivered on.  Each
+// call's Params are placed, so ParamsFunc is nil.  The channel is
+// closed once the stream's Client has been closed and every call made
+// before then has been delivered, or once Stop is called.
+func (s *AnswerStream) C() <-chan *Call {
+	return s.c
+}
+
+// Client returns the callback capability that feeds the stream.
+// Closing the client ends the stream.  The same client is returned on
+// every call.
+func (s *AnswerStream) Client() Client {
+	return streamClient{s}
+}
+
+// Stop ends the stream without delivering the calls that it holds,
+// whose answers fail, and closes the channel.  A reader that stops
+// reading before the channel is closed must call Stop so that the
+// stream's goroutine exits.
+func (s *AnswerStream) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return
+	}
+	s.closed = true
+	s.stopped = true
+	close(s.stop)
+}
+
+// send queues call for delivery.
+func (s *AnswerStream) send(call *Call) Answer {
+	call, err := call.Copy(nil)
+	if err != nil {
+		return ErrorAnswer(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrorAnswer(errStreamClosed)
+	}
+	if len(s.pending) == 0 {
+		select {
+		case s.c <- call:
+			return ImmediateAnswer(Struct{})
+		default:
+		}
+	}
+	if s.maxPending > 0 && len(s.pending) >= s.maxPending {
+		return ErrorAnswer(errStreamFull)
+	}
+	ans := &streamAnswer{done: make(chan struct{})}
+	s.pending = append(s.pending, streamCall{call, ans})
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return ans
+}
+
+// close stops the stream from accepting calls.
+func (s *AnswerStream) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// deliver sends the pending calls to the channel in order, and closes
+// the channel when the stream ends.  It runs in its own goroutine.
+func (s *AnswerStream) deliver() {
+	for {
+		s.mu.Lock()
+		for len(s.pending) == 0 && !s.closed {
+			s.mu.Unlock()
+			select {
+			case <-s.wake:
+			case <-s.stop:
+			}
+			s.mu.Lock()
+		}
+		if s.stopped || len(s.pending) == 0 {
+			for _, sc := range s.pending {
+				sc.ans.resolve(errStreamClosed)
+			}
+			s.pending = nil
+			close(s.c)
+			s.mu.Unlock()
+			return
+		}
+		sc := s.pending[0]
+		s.mu.Unlock()
+
+		var ctxDone <-chan struct{}
+		if sc.call.Ctx != nil {
+			ctxDone = sc.call.Ctx.Done()
+		}
+		select {
+		case s.c <- sc.call:
+			sc.ans.resolve(nil)
+		case <-ctxDone:
+			sc.ans.resolve(sc.call.Ctx.Err())
+		case <-s.stop:
+			// The call is failed on the next iteration.
+			continue
+		}
+		s.mu.Lock()
+		s.pending[0] = streamCall{}
+		s.pending = s.pending[1:]
+		s.mu.Unlock()
+	}
+}
+
+type streamCall struct {
+	call *Call
+	ans  *streamAnswer
+}
+
+type streamClient struct {
+	s *AnswerStream
+}
+
+func (sc streamClient) Call(call *Call) Answer {
+	return sc.s.send(call)
+}
+
+func (sc streamClient) Close() error {
+	sc.s.close()
+	return nil
+}
+
+// A streamAnswer is the answer to a call held by an AnswerStream.  The
+// results are always empty.
+type streamAnswer struct {
+	done chan struct{}
+	err  error
+}
+
+func (ans *streamAnswer) resolve(err error) {
+	ans.err = err
+	close(ans.done)
+}
+
+// Done returns a channel that is closed once the call has been
+// delivered or has failed.
+func (ans *streamAnswer) Done() <-chan struct{} {
+	return ans.done
+}
+
+func (ans *streamAnswer) Struct() (Struct, error) {
+	<-ans.done
+	return Struct{}, ans.err
+}
+
+func (ans *streamAnswer) PipelineCall(transform []PipelineOp, call *Call) Answer {
+	<-ans.done
+	if ans.err != nil {
+		return ErrorAnswer(ans.err)
+	}
+	return ImmediateAnswer(Struct{}).PipelineCall(transform, call)
+}
+
+func (ans *streamAnswer) PipelineClose(transform []PipelineOp) error {
+	<-ans.done
+	if ans.err != nil {
+		return ans.err
+	}
+	return ImmediateAnswer(Struct{}).PipelineClose(transform)
+}
+
+var (
+	errStreamClosed = errors.New("capnp: answer stream closed")
+	errStreamFull   = errors.New("capnp: answer stream full")
+)
@@ -0,0 +1,105 @@
+package capnp
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func streamCallN(c Client, n uint64) Answer {
+	return c.Call(&Call{
+		Ctx:        context.Background(),
+		Method:     Method{InterfaceID: 0xa7317bd7216570aa, MethodID: 0},
+		ParamsSize: ObjectSize{DataSize: 8},
+		ParamsFunc: func(s Struct) error {
+			s.SetUint64(0, n)
+			return nil
+		},
+	})
+}
+
+func TestAnswerStream(t *testing.T) {
+	s := NewAnswerStream(AnswerStreamOptions{Buffer: 2})
+	c := s.Client()
+	var answers []Answer
+	for i := uint64(0); i < 5; i++ {
+		answers = append(answers, streamCallN(c, i))
+	}
+	for i, ans := range answers[:2] {
+		select {
+		case <-AnswerDone(ans):
+		default:
+			t.Errorf("answer %d not resolved with room in buffer", i)
+		}
+	}
+	for i, ans := range answers[2:] {
+		select {
+		case <-AnswerDone(ans):
+			t.Errorf("answer %d resolved while buffer full", i+2)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if err := c.Close(); err != nil {
+		t.Error("Close:", err)
+	}
+	if _, err := streamCallN(c, 99).Struct(); err == nil {
+		t.Error("call after Close succeeded; want error")
+	}
+
+	var got []uint64
+	for call := range s.C() {
+		if call.ParamsFunc != nil {
+			t.Error("delivered call has ParamsFunc set")
+		}
+		got = append(got, call.Params.Uint64(0))
+	}
+	if len(got) != 5 {
+		t.Fatalf("received %v; want 5 calls", got)
+	}
+	for i, n := range got {
+		if n != uint64(i) {
+			t.Errorf("call %d has param %d; want %d", i, n, i)
+		}
+	}
+	for i, ans := range answers {
+		if _, err := ans.Struct(); err != nil {
+			t.Errorf("answer %d: %v", i, err)
+		}
+	}
+}
+
+func TestAnswerStream_MaxPending(t *testing.T) {
+	s := NewAnswerStream(AnswerStreamOptions{Buffer: 1, MaxPending: 1})
+	defer s.Stop()
+	c := s.Client()
+	streamCallN(c, 0)
+	streamCallN(c, 1)
+	if _, err := streamCallN(c, 2).Struct(); err != errStreamFull {
+		t.Errorf("call past MaxPending = %v; want %v", err, errStreamFull)
+	}
+}
+
+func TestAnswerStream_Stop(t *testing.T) {
+	s := NewAnswerStream(AnswerStreamOptions{})
+	c := s.Client()
+	ans := streamCallN(c, 0)
+	s.Stop()
+	if _, err := ans.Struct(); err == nil {
+		t.Error("held call succeeded after Stop; want error")
+	}
+	if _, ok := <-s.C(); ok {
+		t.Error("received call after Stop")
+	}
+}
+
+func TestAnswerStream_Canceled(t *testing.T) {
+	s := NewAnswerStream(AnswerStreamOptions{})
+	defer s.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	ans := s.Client().Call(&Call{Ctx: ctx, Params: Struct{}})
+	cancel()
+	if _, err := ans.Struct(); err != context.Canceled {
+		t.Errorf("canceled call = %v; want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,117 @@
+package capnp
+
+import "fmt"
+
+// Any is the value of an AnyPointer field: a pointer whose type is only
+// known at runtime.  Its Struct, List, Interface, Text, and Data
+// methods return the zero value on a type mismatch, like Ptr's do.  The
+// As methods return an error instead, so that callers can tell a null
+// or mistyped pointer from an empty object.
+type Any struct{ Ptr }
+
+// NewAny returns p as an Any.
+func NewAny(p Ptr) Any {
+	return Any{p}
+}
+
+// A Kind is the type of object that a pointer refers to.
+type Kind uint8
+
+// Pointer kinds.
+const (
+	KindNull Kind = iota
+	KindStruct
+	KindList
+	KindInterface
+)
+
+// String returns the lowercase name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case KindNull:
+		return "null"
+	case KindStruct:
+		return "struct"
+	case KindList:
+		return "list"
+	case KindInterface:
+		return "interface"
+	default:
+		return fmt.Sprintf("kind(%d)", uint8(k))
+	}
+}
+
+// Kind returns the type of object that a refers to.
+func (a Any) Kind() Kind {
+	if !a.IsValid() {
+		return KindNull
+	}
+	switch a.flags.ptrType() {
+	case structPtrType:
+		return KindStruct
+	case listPtrType:
+		return KindList
+	case interfacePtrType:
+		return KindInterface
+	default:
+		return KindNull
+	}
+}
+
+// AsStruct returns a as a Struct or an error if a is not a struct
+// pointer.
+func (a Any) AsStruct() (Struct, error) {
+	if err := a.checkKind(KindStruct); err != nil {
+		return Struct{}, err
+	}
+	return a.Struct(), nil
+}
+
+// AsList returns a as a List or an error if a is not a list pointer.
+func (a Any) AsList() (List, error) {
+	if err := a.checkKind(KindList); err != nil {
+		return List{}, err
+	}
+	return a.List(), nil
+}
+
+// AsInterface returns a as an Interface or an error if a is not an
+// interface pointer.
+func (a Any) AsInterface() (Interface, error) {
+	if err := a.checkKind(KindInterface); err != nil {
+		return Interface{}, err
+	}
+	return a.Interface(), nil
+}
+
+// AsText returns a as a string or an error if a is not a
+// NUL-terminated byte list.
+func (a Any) AsText() (string, error) {
+	if err := a.checkKind(KindList); err != nil {
+		return "", err
+	}
+	b, ok := a.text()
+	if !ok {
+		return "", errNotText
+	}
+	return a.str(b), nil
+}
+
+// AsData returns a as a byte slice or an error if a is not a byte list.
+// The slice refers to the message's data.
+func (a Any) AsData() ([]byte, error) {
+	if err := a.checkKind(KindList); err != nil {
+		return nil, err
+	}
+	if !isOneByteList(a.Ptr) {
+		return nil, errNotData
+	}
+	return a.Data(), nil
+}
+
+func (a Any) checkKind(want Kind) error {
+	if k := a.Kind(); k != want {
+		return fmt.Errorf("capnp: %v pointer used as %v", k, want)
+	}
+	return nil
+}
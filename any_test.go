@@ -0,0 +1,94 @@
+package capnp
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAny(t *testing.T) {
+	_, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := NewStruct(seg, ObjectSize{DataSize: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	text, err := NewText(seg, "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := NewData(seg, []byte{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	il, err := NewInt32List(seg, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iface := NewInterface(seg, 0)
+
+	tests := []struct {
+		name  string
+		a     Any
+		kind  Kind
+		text  string
+		isTxt bool
+		data  []byte
+	}{
+		{name: "null", a: Any{}, kind: KindNull},
+		{name: "struct", a: NewAny(st.ToPtr()), kind: KindStruct},
+		{name: "text", a: NewAny(text.ToPtr()), kind: KindList, text: "foo", isTxt: true, data: []byte("foo\x00")},
+		{name: "data", a: NewAny(data.ToPtr()), kind: KindList, data: []byte{1, 2}},
+		{name: "int32 list", a: NewAny(il.ToPtr()), kind: KindList},
+		{name: "interface", a: NewAny(iface.ToPtr()), kind: KindInterface},
+	}
+	for _, test := range tests {
+		if k := test.a.Kind(); k != test.kind {
+			t.Errorf("%s: Kind() = %v; want %v", test.name, k, test.kind)
+		}
+		if s, err := test.a.AsStruct(); (err == nil) != (test.kind == KindStruct) {
+			t.Errorf("%s: AsStruct() error = %v", test.name, err)
+		} else if err == nil && !s.IsValid() {
+			t.Errorf("%s: AsStruct() = invalid struct", test.name)
+		}
+		if l, err := test.a.AsList(); (err == nil) != (test.kind == KindList) {
+			t.Errorf("%s: AsList() error = %v", test.name, err)
+		} else if err == nil && !l.IsValid() {
+			t.Errorf("%s: AsList() = invalid list", test.name)
+		}
+		if i, err := test.a.AsInterface(); (err == nil) != (test.kind == KindInterface) {
+			t.Errorf("%s: AsInterface() error = %v", test.name, err)
+		} else if err == nil && !i.IsValid() {
+			t.Errorf("%s: AsInterface() = invalid interface", test.name)
+		}
+		if s, err := test.a.AsText(); (err == nil) != test.isTxt {
+			t.Errorf("%s: AsText() error = %v", test.name, err)
+		} else if s != test.text {
+			t.Errorf("%s: AsText() = %q; want %q", test.name, s, test.text)
+		}
+		if b, err := test.a.AsData(); (err == nil) != (test.data != nil) {
+			t.Errorf("%s: AsData() error = %v", test.name, err)
+		} else if !bytes.Equal(b, test.data) {
+			t.Errorf("%s: AsData() = % 02x; want % 02x", test.name, b, test.data)
+		}
+	}
+}
+
+func TestKindString(t *testing.T) {
+	tests := []struct {
+		k    Kind
+		want string
+	}{
+		{KindNull, "null"},
+		{KindStruct, "struct"},
+		{KindList, "list"},
+		{KindInterface, "interface"},
+		{Kind(9), "kind(9)"},
+	}
+	for _, test := range tests {
+		if s := test.k.String(); s != test.want {
+			t.Errorf("Kind(%d).String() = %q; want %q", uint8(test.k), s, test.want)
+		}
+	}
+}
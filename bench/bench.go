@@ -0,0 +1,231 @@
+// Package bench provides representative workloads for measuring the
+// performance of the capnp package, such as its arenas and the packed
+// codec.  The benchmarks in this package's tests run each workload
+// through building, marshaling, and reading; run.sh runs them in a form
+// suitable for comparison with benchstat.
+package bench
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+// A Workload builds and reads back one shape of message.
+type Workload struct {
+	Name string
+
+	// Build fills in a new message whose root is in seg.
+	Build func(seg *capnp.Segment) error
+
+	// Read reads every field of the message built by Build and checks
+	// that it is what Build wrote.
+	Read func(msg *capnp.Message) error
+}
+
+// Workloads is the list of standard workloads.
+var Workloads = []Workload{
+	{Name: "Flat", Build: BuildFlat, Read: ReadFlat},
+	{Name: "DeepTree", Build: BuildDeepTree, Read: ReadDeepTree},
+	{Name: "BigList", Build: BuildBigList, Read: ReadBigList},
+	{Name: "TextList", Build: BuildTextList, Read: ReadTextList},
+}
+
+// Sizes of the workloads.
+const (
+	// TreeDepth is the number of nested structs in DeepTree, kept
+	// under the default depth limit.
+	TreeDepth = 60
+
+	// ListLen is the number of elements in BigList and TextList.
+	ListLen = 4096
+)
+
+var errMismatch = errors.New("bench: read value does not match built value")
+
+// BuildFlat builds a single struct of scalars and short strings.
+func BuildFlat(seg *capnp.Segment) error {
+	a, err := air.NewRootBenchmarkA(seg)
+	if err != nil {
+		return err
+	}
+	if err := a.SetName("Eugene Wigner"); err != nil {
+		return err
+	}
+	a.SetBirthDay(-2164320000)
+	if err := a.SetPhone("+1 609 555 0100"); err != nil {
+		return err
+	}
+	a.SetSiblings(3)
+	a.SetSpouse(true)
+	a.SetMoney(math.Pi * 1e6)
+	return nil
+}
+
+// ReadFlat reads the message built by BuildFlat.
+func ReadFlat(msg *capnp.Message) error {
+	a, err := air.ReadRootBenchmarkA(msg)
+	if err != nil {
+		return err
+	}
+	name, err := a.Name()
+	if err != nil {
+		return err
+	}
+	phone, err := a.Phone()
+	if err != nil {
+		return err
+	}
+	if name != "Eugene Wigner" || phone != "+1 609 555 0100" || a.BirthDay() != -2164320000 ||
+		a.Siblings() != 3 || !a.Spouse() || a.Money() != math.Pi*1e6 {
+		return errMismatch
+	}
+	return nil
+}
+
+// BuildDeepTree builds a chain of TreeDepth nested structs.
+func BuildDeepTree(seg *capnp.Segment) error {
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < TreeDepth-1; i++ {
+		z, err = z.NewZz()
+		if err != nil {
+			return err
+		}
+	}
+	z.SetI64(TreeDepth)
+	return nil
+}
+
+// ReadDeepTree reads the message built by BuildDeepTree.
+func ReadDeepTree(msg *capnp.Message) error {
+	z, err := air.ReadRootZ(msg)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < TreeDepth-1; i++ {
+		if z.Which() != air.Z_Which_zz {
+			return fmt.Errorf("bench: depth %d: %v, want zz", i, z.Which())
+		}
+		z, err = z.Zz()
+		if err != nil {
+			return err
+		}
+	}
+	if z.Which() != air.Z_Which_i64 || z.I64() != TreeDepth {
+		return errMismatch
+	}
+	return nil
+}
+
+// BuildBigList builds a list of ListLen structs.
+func BuildBigList(seg *capnp.Segment) error {
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		return err
+	}
+	l, err := z.NewZdatevec(ListLen)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < l.Len(); i++ {
+		d := l.At(i)
+		d.SetYear(int16(1900 + i%200))
+		d.SetMonth(uint8(1 + i%12))
+		d.SetDay(uint8(1 + i%28))
+	}
+	return nil
+}
+
+// ReadBigList reads the message built by BuildBigList.
+func ReadBigList(msg *capnp.Message) error {
+	z, err := air.ReadRootZ(msg)
+	if err != nil {
+		return err
+	}
+	l, err := z.Zdatevec()
+	if err != nil {
+		return err
+	}
+	if l.Len() != ListLen {
+		return errMismatch
+	}
+	for i := 0; i < l.Len(); i++ {
+		d := l.At(i)
+		if d.Year() != int16(1900+i%200) || d.Month() != uint8(1+i%12) || d.Day() != uint8(1+i%28) {
+			return errMismatch
+		}
+	}
+	return nil
+}
+
+// BuildTextList builds a list of ListLen short strings, which exercises
+// allocation more than BigList does.
+func BuildTextList(seg *capnp.Segment) error {
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		return err
+	}
+	l, err := z.NewTextvec(ListLen)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < l.Len(); i++ {
+		if err := l.Set(i, textElems[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadTextList reads the message built by BuildTextList.
+func ReadTextList(msg *capnp.Message) error {
+	z, err := air.ReadRootZ(msg)
+	if err != nil {
+		return err
+	}
+	l, err := z.Textvec()
+	if err != nil {
+		return err
+	}
+	if l.Len() != ListLen {
+		return errMismatch
+	}
+	for i := 0; i < l.Len(); i++ {
+		s, err := l.At(i)
+		if err != nil {
+			return err
+		}
+		if s != textElems[i] {
+			return errMismatch
+		}
+	}
+	return nil
+}
+
+// textElems holds the strings in TextList, formatted ahead of time so
+// that formatting doesn't count against the workload.
+var textElems = func() []string {
+	s := make([]string, ListLen)
+	for i := range s {
+		s[i] = fmt.Sprintf("element %d", i)
+	}
+	return s
+}()
+
+// Marshal builds a message for w and returns its serialized form.
+func (w Workload) Marshal() ([]byte, error) {
+	msg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Build(seg); err != nil {
+		return nil, fmt.Errorf("bench: build %s: %v", w.Name, err)
+	}
+	return msg.Marshal()
+}
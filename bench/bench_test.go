@@ -0,0 +1,292 @@
+package bench
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+	"github.com/iguazio/go-capnproto2/rpc"
+	"golang.org/x/net/context"
+)
+
+func TestWorkloads(t *testing.T) {
+	for _, w := range Workloads {
+		data, err := w.Marshal()
+		if err != nil {
+			t.Errorf("%s: %v", w.Name, err)
+			continue
+		}
+		msg, err := capnp.Unmarshal(data)
+		if err != nil {
+			t.Errorf("%s: Unmarshal: %v", w.Name, err)
+			continue
+		}
+		if err := w.Read(msg); err != nil {
+			t.Errorf("%s: Read: %v", w.Name, err)
+		}
+	}
+}
+
+func TestPipelinedEcho(t *testing.T) {
+	e, cleanup := newEchoConn(t)
+	defer cleanup()
+	if err := pipelinedEcho(context.Background(), e); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkBuild(b *testing.B) {
+	arenas := []struct {
+		name  string
+		arena func() capnp.Arena
+	}{
+		{"SingleSegment", func() capnp.Arena { return capnp.SingleSegment(nil) }},
+		{"MultiSegment", func() capnp.Arena { return capnp.MultiSegment(nil) }},
+	}
+	for _, w := range Workloads {
+		for _, a := range arenas {
+			w, a := w, a
+			b.Run(w.Name+"/"+a.name, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					_, seg, err := capnp.NewMessage(a.arena())
+					if err != nil {
+						b.Fatal(err)
+					}
+					if err := w.Build(seg); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	for _, w := range Workloads {
+		w := w
+		msg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := w.Build(seg); err != nil {
+			b.Fatal(err)
+		}
+		b.Run(w.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				data, err := msg.Marshal()
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.SetBytes(int64(len(data)))
+			}
+		})
+		b.Run(w.Name+"/Packed", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				data, err := msg.MarshalPacked()
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.SetBytes(int64(len(data)))
+			}
+		})
+	}
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	for _, w := range Workloads {
+		w := w
+		data, err := w.Marshal()
+		if err != nil {
+			b.Fatal(err)
+		}
+		msg, _ := capnp.Unmarshal(data)
+		packed, err := msg.MarshalPacked()
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(w.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				msg, err := capnp.Unmarshal(data)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if err := w.Read(msg); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(w.Name+"/Packed", func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(packed)))
+			for i := 0; i < b.N; i++ {
+				msg, err := capnp.UnmarshalPacked(packed)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if err := w.Read(msg); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkStream measures the Encoder and Decoder on a stream of 100
+// messages of each workload.
+func BenchmarkStream(b *testing.B) {
+	const count = 100
+	for _, w := range Workloads {
+		for _, packed := range []bool{false, true} {
+			w, packed := w, packed
+			name := w.Name
+			if packed {
+				name += "/Packed"
+			}
+			msg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err := w.Build(seg); err != nil {
+				b.Fatal(err)
+			}
+			stream, err := encodeStream(msg, count, packed)
+			if err != nil {
+				b.Fatal(err)
+			}
+			var buf bytes.Buffer
+			b.Run("Encode/"+name, func(b *testing.B) {
+				b.ReportAllocs()
+				b.SetBytes(int64(len(stream)))
+				for i := 0; i < b.N; i++ {
+					buf.Reset()
+					enc := capnp.NewEncoder(&buf)
+					if packed {
+						enc = capnp.NewPackedEncoder(&buf)
+					}
+					for j := 0; j < count; j++ {
+						if err := enc.Encode(msg); err != nil {
+							b.Fatal(err)
+						}
+					}
+				}
+			})
+			b.Run("Decode/"+name, func(b *testing.B) {
+				b.ReportAllocs()
+				b.SetBytes(int64(len(stream)))
+				for i := 0; i < b.N; i++ {
+					dec := capnp.NewDecoder(bytes.NewReader(stream))
+					if packed {
+						dec = capnp.NewPackedDecoder(bytes.NewReader(stream))
+					}
+					dec.ReuseBuffer()
+					for {
+						msg, err := dec.Decode()
+						if err == io.EOF {
+							break
+						}
+						if err != nil {
+							b.Fatal(err)
+						}
+						if err := w.Read(msg); err != nil {
+							b.Fatal(err)
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+func encodeStream(msg *capnp.Message, count int, packed bool) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := capnp.NewEncoder(&buf)
+	if packed {
+		enc = capnp.NewPackedEncoder(&buf)
+	}
+	for i := 0; i < count; i++ {
+		if err := enc.Encode(msg); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// BenchmarkPipelinedRPC measures a call made on the promised result of
+// another call (here, the bootstrap capability) before it resolves,
+// over a stream transport.
+func BenchmarkPipelinedRPC(b *testing.B) {
+	e, cleanup := newEchoConn(b)
+	defer cleanup()
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := pipelinedEcho(ctx, e); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func pipelinedEcho(ctx context.Context, conn *rpc.Conn) error {
+	e := air.Echo{Client: conn.Bootstrap(ctx)}
+	defer e.Client.Close()
+	res, err := e.Echo(ctx, func(p air.Echo_echo_Params) error {
+		return p.SetIn("ping")
+	}).Struct()
+	if err != nil {
+		return err
+	}
+	out, err := res.Out()
+	if err != nil {
+		return err
+	}
+	if out != "ping" {
+		return errMismatch
+	}
+	return nil
+}
+
+// newEchoConn returns a connection to an Echo server over a pipe.
+func newEchoConn(tb testing.TB) (*rpc.Conn, func()) {
+	p, q := net.Pipe()
+	srv := air.Echo_ServerToClient(echoServer{})
+	log := rpc.ConnLog(testLogger{tb})
+	c := rpc.NewConn(rpc.StreamTransport(p), log)
+	d := rpc.NewConn(rpc.StreamTransport(q), log, rpc.MainInterface(srv.Client))
+	return c, func() {
+		if err := c.Close(); err != nil {
+			tb.Error("client Close:", err)
+		}
+		d.Wait()
+	}
+}
+
+type echoServer struct{}
+
+func (echoServer) Echo(call air.Echo_echo) error {
+	in, err := call.Params.In()
+	if err != nil {
+		return err
+	}
+	return call.Results.SetOut(in)
+}
+
+type testLogger struct {
+	tb testing.TB
+}
+
+func (l testLogger) Infof(ctx context.Context, format string, args ...interface{}) {
+	l.tb.Logf("conn log: "+format, args...)
+}
+
+func (l testLogger) Errorf(ctx context.Context, format string, args ...interface{}) {
+	l.tb.Logf("conn log: "+format, args...)
+}
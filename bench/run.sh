@@ -0,0 +1,29 @@
+#!/bin/bash
+# run.sh - run the workload benchmarks in a form that benchstat can read
+#
+# Usage: bench/run.sh [OUTPUT [BASELINE]]
+#
+# Runs every benchmark in this package COUNT times (default 10) and
+# writes the results to OUTPUT (default bench/new.txt).  If BASELINE is
+# given and benchstat is installed, compares OUTPUT against it.  To get a
+# baseline, run the script on the commit before your change:
+#
+#   git stash && bench/run.sh bench/old.txt && git stash pop
+#   bench/run.sh bench/new.txt bench/old.txt
+#
+# Set BENCH to a regular expression to run a subset, like BENCH=Stream.
+set -euo pipefail
+
+pkgdir="$(cd "$(dirname "$0")" && pwd)"
+out="${1:-$pkgdir/new.txt}"
+base="${2:-}"
+
+go test -run=NONE -bench="${BENCH:-.}" -benchmem -count="${COUNT:-10}" "$pkgdir" | tee "$out"
+
+if [[ -n "$base" ]]; then
+  if ! command -v benchstat >/dev/null; then
+    echo "run.sh: benchstat not found; install with go get golang.org/x/perf/cmd/benchstat" 1>&2
+    exit 1
+  fi
+  benchstat "$base" "$out"
+fi
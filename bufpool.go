@@ -0,0 +1,71 @@
+package capnp
+
+import "sync"
+
+// A BufferPool holds message buffers for reuse, so that code that
+// builds many short-lived messages does not allocate a fresh segment
+// for each one.  It is safe to use from multiple goroutines.
+//
+// Buffers that are never released are only reclaimed by the garbage
+// collector.  Building with the capnp_pooldebug build tag records where
+// each buffer was taken, so that PoolLeaks can report them.
+type BufferPool struct {
+	maxSize int
+	pool    sync.Pool
+}
+
+// NewBufferPool returns an empty pool that retains buffers with a
+// capacity of at most maxSize bytes.  Larger buffers are left to the
+// garbage collector so that one big message does not pin its memory.
+func NewBufferPool(maxSize int) *BufferPool {
+	return &BufferPool{maxSize: maxSize}
+}
+
+// NewMessage returns a new single-segment message whose segment is
+// taken from the pool.  Once nothing refers to the message or any of
+// its objects, pass it to Release to return its buffer to the pool.
+func (bp *BufferPool) NewMessage() (msg *Message, first *Segment, err error) {
+	msg, first, err = NewMessage(SingleSegment(bp.getBuf()))
+	if err != nil {
+		return nil, nil, err
+	}
+	msg.pooled = acquirePoolTicket("message")
+	return msg, first, nil
+}
+
+// Release returns the segment of a message created by NewMessage to
+// the pool and resets the message.  The message and its objects must
+// not be used afterward.
+func (bp *BufferPool) Release(msg *Message) {
+	msg.pooled.release()
+	if msg.NumSegments() != 1 {
+		msg.Reset(nil)
+		return
+	}
+	s, err := msg.Segment(0)
+	if err != nil {
+		msg.Reset(nil)
+		return
+	}
+	buf := s.Data()
+	msg.Reset(nil)
+	bp.putBuf(buf)
+}
+
+// getBuf returns an empty buffer from the pool, or nil if the pool is
+// empty.
+func (bp *BufferPool) getBuf() []byte {
+	if b, ok := bp.pool.Get().(*[]byte); ok {
+		return (*b)[:0]
+	}
+	return nil
+}
+
+// putBuf returns buf to the pool if it is not too large.
+func (bp *BufferPool) putBuf(buf []byte) {
+	if cap(buf) == 0 || cap(buf) > bp.maxSize {
+		return
+	}
+	buf = buf[:0]
+	bp.pool.Put(&buf)
+}
@@ -0,0 +1,62 @@
+package capnp
+
+import "testing"
+
+func TestBufferPool(t *testing.T) {
+	bp := NewBufferPool(1024)
+	reused := false
+	for i := 0; i < 10 && !reused; i++ {
+		msg, seg, err := bp.NewMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := NewRootStruct(seg, ObjectSize{DataSize: 8}); err != nil {
+			t.Fatal(err)
+		}
+		data := seg.Data()
+		bp.Release(msg)
+
+		// sync.Pool may drop items at any time, so try a few times.
+		_, seg, err = bp.NewMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := NewRootStruct(seg, ObjectSize{DataSize: 8})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Uint64(0) != 0 {
+			t.Fatal("struct from pooled buffer is not zeroed")
+		}
+		reused = &seg.Data()[0] == &data[0]
+	}
+	if !reused {
+		t.Error("BufferPool never reused a released buffer")
+	}
+}
+
+func TestBufferPool_MaxSize(t *testing.T) {
+	bp := NewBufferPool(64)
+	msg, seg, err := bp.NewMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewRootStruct(seg, ObjectSize{DataSize: 128}); err != nil {
+		t.Fatal(err)
+	}
+	data := seg.Data()
+	bp.Release(msg)
+	if msg.Arena != nil {
+		t.Error("released message was not reset")
+	}
+	_, seg, err = bp.NewMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewRootStruct(seg, ObjectSize{DataSize: 8}); err != nil {
+		t.Fatal(err)
+	}
+	if &seg.Data()[0] == &data[0] {
+		t.Error("BufferPool reused a buffer larger than its maximum size")
+	}
+}
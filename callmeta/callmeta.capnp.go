@@ -0,0 +1,319 @@
+// Code generated by capnpc-go. DO NOT EDIT.
+
+package callmeta
+
+import (
+	capnp "github.com/iguazio/go-capnproto2"
+	text "github.com/iguazio/go-capnproto2/encoding/text"
+	schemas "github.com/iguazio/go-capnproto2/schemas"
+)
+
+type CallMetadata struct{ capnp.Struct }
+
+// CallMetadata_TypeID is the unique identifier for the type CallMetadata.
+const CallMetadata_TypeID = 0xb97e696ed6078d37
+
+func NewCallMetadata(s *capnp.Segment) (CallMetadata, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4})
+	return CallMetadata{st}, err
+}
+
+func NewRootCallMetadata(s *capnp.Segment) (CallMetadata, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4})
+	return CallMetadata{st}, err
+}
+
+func AllocateRootCallMetadata(msg *capnp.Message) (CallMetadata, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 4})
+	return CallMetadata{st}, err
+}
+
+func ReadRootCallMetadata(msg *capnp.Message) (CallMetadata, error) {
+	root, err := msg.RootPtr()
+	return CallMetadata{root.Struct()}, err
+}
+
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s CallMetadata) Equal(other CallMetadata) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s CallMetadata) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
+func (s CallMetadata) String() string {
+	str, _ := text.Marshal(0xb97e696ed6078d37, s.Struct)
+	return str
+}
+
+func (s CallMetadata) Timeout() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s CallMetadata) SetTimeout(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s CallMetadata) TraceParent() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s CallMetadata) HasTraceParent() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s CallMetadata) TraceParentBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s CallMetadata) SetTraceParent(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s CallMetadata) TraceState() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s CallMetadata) HasTraceState() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s CallMetadata) TraceStateBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s CallMetadata) SetTraceState(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s CallMetadata) TenantId() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s CallMetadata) HasTenantId() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s CallMetadata) TenantIdBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s CallMetadata) SetTenantId(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s CallMetadata) Entries() (CallMetadata_Entry_List, error) {
+	p, err := s.Struct.Ptr(3)
+	return CallMetadata_Entry_List{List: p.List()}, err
+}
+
+func (s CallMetadata) HasEntries() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s CallMetadata) SetEntries(v CallMetadata_Entry_List) error {
+	return s.Struct.SetPtr(3, v.List.ToPtr())
+}
+
+// NewEntries sets the entries field to a newly
+// allocated CallMetadata_Entry_List, preferring placement in s's segment.
+func (s CallMetadata) NewEntries(n int32) (CallMetadata_Entry_List, error) {
+	l, err := NewCallMetadata_Entry_List(s.Struct.Segment(), n)
+	if err != nil {
+		return CallMetadata_Entry_List{}, err
+	}
+	err = s.Struct.SetPtr(3, l.List.ToPtr())
+	return l, err
+}
+
+// CallMetadata_List is a list of CallMetadata.
+type CallMetadata_List struct{ capnp.List }
+
+// NewCallMetadata creates a new list of CallMetadata.
+func NewCallMetadata_List(s *capnp.Segment, sz int32) (CallMetadata_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4}, sz)
+	return CallMetadata_List{l}, err
+}
+
+// NewRootCallMetadataListMessage creates a message whose root is a new
+// list of n CallMetadata.  The message's segment is sized to fit the list.
+func NewRootCallMetadataListMessage(n int32) (*capnp.Message, CallMetadata_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 4}, n)
+	return msg, CallMetadata_List{l}, err
+}
+
+func (s CallMetadata_List) At(i int) CallMetadata { return CallMetadata{s.List.Struct(i)} }
+
+func (s CallMetadata_List) Set(i int, v CallMetadata) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s CallMetadata_List) String() string {
+	str, _ := text.MarshalList(0xb97e696ed6078d37, s.List)
+	return str
+}
+
+// CallMetadata_Promise is a wrapper for a CallMetadata promised by a client call.
+type CallMetadata_Promise struct{ *capnp.Pipeline }
+
+func (p CallMetadata_Promise) Struct() (CallMetadata, error) {
+	return capnp.PipelineStruct[CallMetadata](p.Pipeline)
+}
+
+type CallMetadata_Entry struct{ capnp.Struct }
+
+// CallMetadata_Entry_TypeID is the unique identifier for the type CallMetadata_Entry.
+const CallMetadata_Entry_TypeID = 0xd6480c7f374ec2b2
+
+func NewCallMetadata_Entry(s *capnp.Segment) (CallMetadata_Entry, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return CallMetadata_Entry{st}, err
+}
+
+func NewRootCallMetadata_Entry(s *capnp.Segment) (CallMetadata_Entry, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return CallMetadata_Entry{st}, err
+}
+
+func AllocateRootCallMetadata_Entry(msg *capnp.Message) (CallMetadata_Entry, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return CallMetadata_Entry{st}, err
+}
+
+func ReadRootCallMetadata_Entry(msg *capnp.Message) (CallMetadata_Entry, error) {
+	root, err := msg.RootPtr()
+	return CallMetadata_Entry{root.Struct()}, err
+}
+
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s CallMetadata_Entry) Equal(other CallMetadata_Entry) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s CallMetadata_Entry) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
+func (s CallMetadata_Entry) String() string {
+	str, _ := text.Marshal(0xd6480c7f374ec2b2, s.Struct)
+	return str
+}
+
+func (s CallMetadata_Entry) Key() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s CallMetadata_Entry) HasKey() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s CallMetadata_Entry) KeyBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s CallMetadata_Entry) SetKey(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s CallMetadata_Entry) Value() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s CallMetadata_Entry) HasValue() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s CallMetadata_Entry) ValueBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s CallMetadata_Entry) SetValue(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+// CallMetadata_Entry_List is a list of CallMetadata_Entry.
+type CallMetadata_Entry_List struct{ capnp.List }
+
+// NewCallMetadata_Entry creates a new list of CallMetadata_Entry.
+func NewCallMetadata_Entry_List(s *capnp.Segment, sz int32) (CallMetadata_Entry_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	return CallMetadata_Entry_List{l}, err
+}
+
+// NewRootCallMetadata_EntryListMessage creates a message whose root is a new
+// list of n CallMetadata_Entry.  The message's segment is sized to fit the list.
+func NewRootCallMetadata_EntryListMessage(n int32) (*capnp.Message, CallMetadata_Entry_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 2}, n)
+	return msg, CallMetadata_Entry_List{l}, err
+}
+
+func (s CallMetadata_Entry_List) At(i int) CallMetadata_Entry {
+	return CallMetadata_Entry{s.List.Struct(i)}
+}
+
+func (s CallMetadata_Entry_List) Set(i int, v CallMetadata_Entry) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s CallMetadata_Entry_List) String() string {
+	str, _ := text.MarshalList(0xd6480c7f374ec2b2, s.List)
+	return str
+}
+
+// CallMetadata_Entry_Promise is a wrapper for a CallMetadata_Entry promised by a client call.
+type CallMetadata_Entry_Promise struct{ *capnp.Pipeline }
+
+func (p CallMetadata_Entry_Promise) Struct() (CallMetadata_Entry, error) {
+	return capnp.PipelineStruct[CallMetadata_Entry](p.Pipeline)
+}
+
+const schema_aca0f89238d00249 = "x\xda\\\x90\xbd\xaa\x13Q\x14\x85\xd7\xdag\xc6\xb9E" +
+	"P\x8f\x19\xb0\x11\x12\x82\x85\x17\xf1r\x7f\x84+V\x12" +
+	"\x11\x8c\xa0dkgwLN\x11\x9c\x8ca<QS" +
+	"h\x14,\x14\x14T\xd2\x08\x16>\x80\x85\xd8\xd9\xea\x0b" +
+	"X\xe5\x01\xec|\x00\xc1FF\xa6\x98D\xc2\xee>\xd8" +
+	"k\xefo\xed~\xdf\xba${q\xd2\x05n\x9a\xf8H" +
+	"y\xf8:Y\xe6\xa3'_\xa1\xc7\xc8\xb2'?.\xbc" +
+	"\xfb\xf3\xf1\x13\xe2(\x01\x9a\xbf\xda?\x9b\xbf\xdb'\x81" +
+	"\x83\xbf\xed\x16\xc1\xf2\xcb\xb7\x1b\x87\xf3\xc6\xd5%\xec)" +
+	"\xaeWcI\x80\x83\xed\xce\x09\x82\xcd\xbd\xceC\x94\x1b" +
+	"s\xb6\x1c\xb8,\x1b\xfb\xe0dg\xe0&\xf9\xe4\xe2e" +
+	"\x97e\xd7}p\xc3\xc4\x05\xa7\x11\xff\x8f\xe6~\xebJ" +
+	"\x1e\x8a\x99\xa6&\x02\"\x02\xf6q\x17\xd0G\x86\xfa\\" +
+	"H\xa6\xac\xd8\xb3;\x80>5\xd4WB+L)\x80" +
+	"}y\x1b\xd0\x17\x86\xba\x10Z#)\x0d`\xdf^\x03" +
+	"\xf4\x8d\xa1~\x10\xda\xc8\xa4\x8c\x00\xfb\xbe\x8a\\\x18\xea" +
+	"g\xe1<\x8c\xc6\xfe\xde40\x860\x06\xcbP\xb8\x81" +
+	"\xef\xbb\x02\x89\xcf\x03\x1b\x106jz+8\x98\xe0\xd7" +
+	"\xd0\xe7.\x0f\xbd!\x80\x9a\xcd}\x1e\x8a\x91\xbf\xcf\xa3" +
+	"`\xdf\x90\xc7\xd7r`\x05Wu\x98\xcd:\\p;" +
+	"\x95<g\xba\xb5\xb2\xdf\xee\x00z\xdaPw\x85\xb6\xd6" +
+	"?\xb7\x0f\xe8\x19C=/L\xee\xfaY}\xbc\xf5\xc0" +
+	"e\xd3\xd5{\xff\x06\x00\xc1+u\x95"
+
+func init() {
+	schemas.Register(schema_aca0f89238d00249,
+		0xb97e696ed6078d37,
+		0xd6480c7f374ec2b2)
+}
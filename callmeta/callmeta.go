@@ -0,0 +1,353 @@
+// Package callmeta carries metadata about calls, like their deadlines,
+// trace context, and the tenants that they are made for, between
+// clients and servers.
+//
+// Metadata travels in a field of type CallMetadata (from
+// std/callmeta.capnp) in a method's parameters.  A client wrapped by
+// NewClient fills the field in from the context of each call, and
+// server methods wrapped by Methods are called with a context that
+// carries the metadata and expires at the caller's deadline.  The field
+// is found with the schemas in schemas.DefaultRegistry; calls to
+// methods whose parameters have no such field are passed along as they
+// are.  Write and Read set and read the field directly.
+package callmeta // import "github.com/iguazio/go-capnproto2/callmeta"
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/nodemap"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+	"github.com/iguazio/go-capnproto2/schemas"
+	"github.com/iguazio/go-capnproto2/server"
+)
+
+// Metadata is the metadata of a call.
+type Metadata struct {
+	// Deadline is when the caller stops waiting for the call, or the
+	// zero time if it waits indefinitely.  Calls made with a context
+	// that has an earlier deadline send that one instead.
+	Deadline time.Time
+
+	// TraceParent and TraceState are the W3C Trace Context headers of
+	// the caller's span.
+	TraceParent string
+	TraceState  string
+
+	// TenantID identifies the tenant that the call is made for.
+	TenantID string
+
+	// Entries holds any other metadata.
+	Entries map[string]string
+}
+
+type contextKey struct{}
+
+// NewContext returns a context that carries md.  Calls made with the
+// context send md.
+func NewContext(ctx context.Context, md Metadata) context.Context {
+	return context.WithValue(ctx, contextKey{}, md)
+}
+
+// FromContext returns the metadata attached to ctx by NewContext or
+// by a method wrapped by Methods.
+func FromContext(ctx context.Context) (Metadata, bool) {
+	md, ok := ctx.Value(contextKey{}).(Metadata)
+	return md, ok
+}
+
+// Write sets s to the metadata attached to ctx, with the earlier of
+// its deadline and ctx's deadline.
+func Write(ctx context.Context, s CallMetadata) error {
+	md, _ := FromContext(ctx)
+	if d, ok := ctx.Deadline(); ok && (md.Deadline.IsZero() || d.Before(md.Deadline)) {
+		md.Deadline = d
+	}
+	if !md.Deadline.IsZero() {
+		timeout := md.Deadline.Sub(time.Now())
+		if timeout <= 0 {
+			// Zero means no deadline, so send the shortest one.
+			timeout = 1
+		}
+		s.SetTimeout(int64(timeout))
+	}
+	if err := setText(s.SetTraceParent, md.TraceParent); err != nil {
+		return err
+	}
+	if err := setText(s.SetTraceState, md.TraceState); err != nil {
+		return err
+	}
+	if err := setText(s.SetTenantId, md.TenantID); err != nil {
+		return err
+	}
+	if len(md.Entries) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(md.Entries))
+	for k := range md.Entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	entries, err := s.NewEntries(int32(len(keys)))
+	if err != nil {
+		return err
+	}
+	for i, k := range keys {
+		if err := entries.At(i).SetKey(k); err != nil {
+			return err
+		}
+		if err := entries.At(i).SetValue(md.Entries[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setText(set func(string) error, v string) error {
+	if v == "" {
+		return nil
+	}
+	return set(v)
+}
+
+// Read returns the metadata in s.  The deadline is the time that s's
+// timeout ends, counting from now.
+func Read(s CallMetadata) (Metadata, error) {
+	var md Metadata
+	if t := s.Timeout(); t > 0 {
+		md.Deadline = time.Now().Add(time.Duration(t))
+	}
+	var err error
+	if md.TraceParent, err = s.TraceParent(); err != nil {
+		return Metadata{}, err
+	}
+	if md.TraceState, err = s.TraceState(); err != nil {
+		return Metadata{}, err
+	}
+	if md.TenantID, err = s.TenantId(); err != nil {
+		return Metadata{}, err
+	}
+	entries, err := s.Entries()
+	if err != nil {
+		return Metadata{}, err
+	}
+	if entries.Len() > 0 {
+		md.Entries = make(map[string]string, entries.Len())
+	}
+	for i := 0; i < entries.Len(); i++ {
+		k, err := entries.At(i).Key()
+		if err != nil {
+			return Metadata{}, err
+		}
+		v, err := entries.At(i).Value()
+		if err != nil {
+			return Metadata{}, err
+		}
+		md.Entries[k] = v
+	}
+	return md, nil
+}
+
+// ServerContext returns a context derived from ctx that carries md and
+// is canceled at md.Deadline.
+func ServerContext(ctx context.Context, md Metadata) (context.Context, context.CancelFunc) {
+	ctx = NewContext(ctx, md)
+	if md.Deadline.IsZero() {
+		return context.WithCancel(ctx)
+	}
+	return context.WithDeadline(ctx, md.Deadline)
+}
+
+// NewClient returns a client that sends the metadata from the context
+// of each call through c.  Calls whose parameters already have their
+// CallMetadata field set are not changed.
+func NewClient(c capnp.Client) capnp.Client {
+	return client{c}
+}
+
+type client struct {
+	capnp.Client
+}
+
+func (c client) Call(call *capnp.Call) capnp.Answer {
+	_, hasMeta := FromContext(call.Ctx)
+	_, hasDeadline := call.Ctx.Deadline()
+	if !hasMeta && !hasDeadline {
+		return c.Client.Call(call)
+	}
+	idx, ok, err := metadataField(call.Method.InterfaceID, call.Method.MethodID)
+	if err != nil {
+		return capnp.ErrorAnswer(err)
+	}
+	if !ok {
+		return c.Client.Call(call)
+	}
+	if call.ParamsFunc == nil {
+		if err := writeField(call.Ctx, call.Params, idx); err != nil {
+			return capnp.ErrorAnswer(err)
+		}
+		return c.Client.Call(call)
+	}
+	ctx, f := call.Ctx, call.ParamsFunc
+	cc := *call
+	cc.ParamsFunc = func(s capnp.Struct) error {
+		if err := f(s); err != nil {
+			return err
+		}
+		return writeField(ctx, s, idx)
+	}
+	return c.Client.Call(&cc)
+}
+
+// WrappedClient returns the client that c sends calls through, for
+// capnpclient.Unwrap.
+func (c client) WrappedClient() capnp.Client {
+	return c.Client
+}
+
+// writeField writes the metadata in ctx to the pointer field of params
+// with index idx, unless it is already set.
+func writeField(ctx context.Context, params capnp.Struct, idx uint16) error {
+	if idx >= params.Size().PointerCount {
+		return nil
+	}
+	if p, err := params.Ptr(idx); err != nil || p.IsValid() {
+		return err
+	}
+	s, err := NewCallMetadata(params.Segment())
+	if err != nil {
+		return err
+	}
+	if err := Write(ctx, s); err != nil {
+		return err
+	}
+	return params.SetPtr(idx, s.ToPtr())
+}
+
+// Methods returns methods with their implementations wrapped to be
+// called with a context from ServerContext, using the metadata in their
+// parameters.  Methods whose parameters don't have a CallMetadata field
+// are returned as they are.
+func Methods(methods []server.Method) ([]server.Method, error) {
+	wrapped := make([]server.Method, len(methods))
+	for i, m := range methods {
+		idx, ok, err := metadataField(m.InterfaceID, m.MethodID)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			m.Impl = wrapImpl(m.Impl, idx)
+		}
+		wrapped[i] = m
+	}
+	return wrapped, nil
+}
+
+func wrapImpl(impl server.Func, idx uint16) server.Func {
+	return func(ctx context.Context, opts capnp.CallOptions, params, results capnp.Struct) error {
+		p, err := params.Ptr(idx)
+		if err != nil {
+			return err
+		}
+		if !p.IsValid() {
+			return impl(ctx, opts, params, results)
+		}
+		md, err := Read(CallMetadata{p.Struct()})
+		if err != nil {
+			return err
+		}
+		ctx, cancel := ServerContext(ctx, md)
+		defer cancel()
+		return impl(ctx, opts, params, results)
+	}
+}
+
+// A methodKey identifies a method.
+type methodKey struct {
+	interfaceID uint64
+	methodID    uint16
+}
+
+// A fieldInfo is the result of looking up a method's metadata field.
+type fieldInfo struct {
+	idx uint16
+	ok  bool
+}
+
+var cache struct {
+	mu     sync.Mutex
+	nodes  nodemap.Map
+	fields map[methodKey]fieldInfo
+}
+
+// metadataField returns the pointer index of the CallMetadata field of
+// a method's parameters.  ok is false if the method's interface isn't
+// registered or its parameters have no such field.
+func metadataField(interfaceID uint64, methodID uint16) (idx uint16, ok bool, err error) {
+	k := methodKey{interfaceID, methodID}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if f, found := cache.fields[k]; found {
+		return f.idx, f.ok, nil
+	}
+	idx, ok, err = findMetadataField(interfaceID, methodID)
+	if err != nil {
+		return 0, false, err
+	}
+	if cache.fields == nil {
+		cache.fields = make(map[methodKey]fieldInfo)
+	}
+	cache.fields[k] = fieldInfo{idx, ok}
+	return idx, ok, nil
+}
+
+// findMetadataField looks up a method's metadata field in the schemas.
+// The caller must hold cache.mu.
+func findMetadataField(interfaceID uint64, methodID uint16) (idx uint16, ok bool, err error) {
+	iface, err := cache.nodes.Find(interfaceID)
+	if schemas.IsNotFound(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("callmeta: find interface @%#x: %v", interfaceID, err)
+	}
+	if iface.Which() != schema.Node_Which_interface {
+		return 0, false, fmt.Errorf("callmeta: @%#x is a %v, not an interface", interfaceID, iface.Which())
+	}
+	methods, err := iface.Interface().Methods()
+	if err != nil {
+		return 0, false, fmt.Errorf("callmeta: interface @%#x: %v", interfaceID, err)
+	}
+	if int(methodID) >= methods.Len() {
+		return 0, false, nil
+	}
+	params, err := cache.nodes.Find(methods.At(int(methodID)).ParamStructType())
+	if schemas.IsNotFound(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("callmeta: params of @%#x.@%d: %v", interfaceID, methodID, err)
+	}
+	fields, err := params.StructNode().Fields()
+	if err != nil {
+		return 0, false, fmt.Errorf("callmeta: params of @%#x.@%d: %v", interfaceID, methodID, err)
+	}
+	for i := 0; i < fields.Len(); i++ {
+		f := fields.At(i)
+		if f.Which() != schema.Field_Which_slot || f.DiscriminantValue() != schema.Field_noDiscriminant {
+			continue
+		}
+		t, err := f.Slot().Type()
+		if err != nil {
+			return 0, false, fmt.Errorf("callmeta: params of @%#x.@%d: %v", interfaceID, methodID, err)
+		}
+		if t.Which() == schema.Type_Which_structType && t.StructType().TypeId() == CallMetadata_TypeID {
+			return uint16(f.Slot().Offset()), true, nil
+		}
+	}
+	return 0, false, nil
+}
@@ -0,0 +1,205 @@
+package callmeta
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+	"github.com/iguazio/go-capnproto2/schemas"
+	"github.com/iguazio/go-capnproto2/server"
+)
+
+// IDs of the nodes in the schema registered by registerTestSchema,
+// which is equivalent to:
+//
+//	interface Store {
+//	  put @0 (n :UInt32, meta :CallMetadata);
+//	}
+const (
+	storeID   = 0xe5ad3b4c7d41ce01
+	paramsID  = 0xe5ad3b4c7d41ce02
+	resultsID = 0xe5ad3b4c7d41ce03
+)
+
+func registerTestSchema(t *testing.T) {
+	if schemas.Find(storeID) != nil {
+		return
+	}
+	msg, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+	req, _ := schema.NewRootCodeGeneratorRequest(seg)
+	nodes, _ := req.NewNodes(3)
+	store := nodes.At(0)
+	store.SetId(storeID)
+	store.SetDisplayName("store.capnp:Store")
+	store.SetInterface()
+	methods, _ := store.Interface().NewMethods(1)
+	methods.At(0).SetName("put")
+	methods.At(0).SetParamStructType(paramsID)
+	methods.At(0).SetResultStructType(resultsID)
+
+	params := nodes.At(1)
+	params.SetId(paramsID)
+	params.SetDisplayName("store.capnp:Store.put$Params")
+	params.SetStructNode()
+	params.StructNode().SetDataWordCount(1)
+	params.StructNode().SetPointerCount(1)
+	fields, _ := params.StructNode().NewFields(2)
+	fields.At(0).SetName("n")
+	fields.At(0).SetSlot()
+	typ, _ := fields.At(0).Slot().NewType()
+	typ.SetUint32()
+	fields.At(1).SetName("meta")
+	fields.At(1).SetCodeOrder(1)
+	fields.At(1).SetSlot()
+	typ, _ = fields.At(1).Slot().NewType()
+	typ.SetStructType()
+	typ.StructType().SetTypeId(CallMetadata_TypeID)
+
+	results := nodes.At(2)
+	results.SetId(resultsID)
+	results.SetDisplayName("store.capnp:Store.put$Results")
+	results.SetStructNode()
+
+	data, err := msg.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = schemas.DefaultRegistry.Register(&schemas.Schema{
+		Bytes: data,
+		Nodes: []uint64{storeID, paramsID, resultsID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := Metadata{
+		Deadline:    time.Now().Add(time.Hour),
+		TraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		TraceState:  "congo=t61rcWkgMzE",
+		TenantID:    "acme",
+		Entries:     map[string]string{"b": "2", "a": "1"},
+	}
+	ctx, cancel := context.WithTimeout(NewContext(context.Background(), md), time.Minute)
+	defer cancel()
+	s, err := NewCallMetadata(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Write(ctx, s); err != nil {
+		t.Fatal("Write:", err)
+	}
+	got, err := Read(s)
+	if err != nil {
+		t.Fatal("Read:", err)
+	}
+	// The context's deadline is earlier, so it is the one that is sent.
+	want, _ := ctx.Deadline()
+	if d := got.Deadline.Sub(want); d < -time.Second || d > time.Second {
+		t.Errorf("Deadline = %v; want about %v", got.Deadline, want)
+	}
+	got.Deadline, md.Deadline = time.Time{}, time.Time{}
+	if !reflect.DeepEqual(got, md) {
+		t.Errorf("Read = %+v; want %+v", got, md)
+	}
+	if k, _ := s.Entries(); k.Len() != 2 {
+		t.Errorf("len(entries) = %d; want 2", k.Len())
+	} else if key, _ := k.At(0).Key(); key != "a" {
+		t.Errorf("entries[0].key = %q; want \"a\"", key)
+	}
+
+	s, _ = NewCallMetadata(seg)
+	if err := Write(context.Background(), s); err != nil {
+		t.Fatal("Write(empty):", err)
+	}
+	if got, err := Read(s); err != nil || !reflect.DeepEqual(got, Metadata{}) {
+		t.Errorf("Read(empty) = %+v, %v; want zero Metadata", got, err)
+	}
+}
+
+func TestClientMethods(t *testing.T) {
+	registerTestSchema(t)
+	type received struct {
+		md          Metadata
+		ok          bool
+		deadline    time.Time
+		hasDeadline bool
+		n           uint32
+	}
+	recv := make(chan received, 1)
+	methods, err := Methods([]server.Method{{
+		Method: capnp.Method{InterfaceID: storeID, MethodID: 0},
+		Impl: func(ctx context.Context, opts capnp.CallOptions, params, results capnp.Struct) error {
+			var r received
+			r.md, r.ok = FromContext(ctx)
+			r.deadline, r.hasDeadline = ctx.Deadline()
+			r.n = params.Uint32(0)
+			recv <- r
+			return nil
+		},
+	}})
+	if err != nil {
+		t.Fatal("Methods:", err)
+	}
+	c := NewClient(server.New(methods, nil))
+	defer c.Close()
+
+	md := Metadata{TenantID: "acme", Entries: map[string]string{"k": "v"}}
+	ctx, cancel := context.WithTimeout(NewContext(context.Background(), md), time.Minute)
+	defer cancel()
+	_, err = c.Call(&capnp.Call{
+		Ctx:        ctx,
+		Method:     capnp.Method{InterfaceID: storeID, MethodID: 0},
+		ParamsSize: capnp.ObjectSize{DataSize: 8, PointerCount: 1},
+		ParamsFunc: func(s capnp.Struct) error {
+			s.SetUint32(0, 42)
+			return nil
+		},
+	}).Struct()
+	if err != nil {
+		t.Fatal("call:", err)
+	}
+	r := <-recv
+	if r.n != 42 {
+		t.Errorf("server got n = %d; want 42", r.n)
+	}
+	if !r.ok {
+		t.Fatal("server context has no metadata")
+	}
+	if r.md.TenantID != "acme" || r.md.Entries["k"] != "v" {
+		t.Errorf("server metadata = %+v; want tenant acme and k=v", r.md)
+	}
+	want, _ := ctx.Deadline()
+	if !r.hasDeadline {
+		t.Error("server context has no deadline")
+	} else if d := r.deadline.Sub(want); d < -time.Second || d > time.Second {
+		t.Errorf("server deadline = %v; want about %v", r.deadline, want)
+	}
+
+	// Without metadata or a deadline, nothing is sent.
+	_, err = c.Call(&capnp.Call{
+		Ctx:        context.Background(),
+		Method:     capnp.Method{InterfaceID: storeID, MethodID: 0},
+		ParamsSize: capnp.ObjectSize{DataSize: 8, PointerCount: 1},
+	}).Struct()
+	if err != nil {
+		t.Fatal("call:", err)
+	}
+	if r := <-recv; r.ok || r.hasDeadline {
+		t.Errorf("server got metadata %+v (deadline %v) from a call without any", r.md, r.deadline)
+	}
+}
+
+func TestMetadataField_Unregistered(t *testing.T) {
+	if _, ok, err := metadataField(0xe5ad3b4c7d41cef0, 0); ok || err != nil {
+		t.Errorf("metadataField(unregistered) = _, %t, %v; want false, nil", ok, err)
+	}
+}
@@ -1,8 +1,10 @@
 package capnp
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"hash/fnv"
 )
 
 // Canonicalize encodes a struct into its canonical form: a single-
@@ -27,6 +29,37 @@ func Canonicalize(s Struct) ([]byte, error) {
 	return seg.Data(), nil
 }
 
+// Equal reports whether a and b have the same canonical form: whether
+// they hold the same values, regardless of how they are laid out or
+// which messages they are in.  A field that is absent from one struct
+// because it was written with an older schema is equal to a zero field
+// in the other.  It returns an error if either struct cannot be
+// canonicalized, such as when it contains a capability.
+func Equal(a, b Struct) (bool, error) {
+	ca, err := Canonicalize(a)
+	if err != nil {
+		return false, err
+	}
+	cb, err := Canonicalize(b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(ca, cb), nil
+}
+
+// Hash64 returns the 64-bit FNV-1a hash of the canonical form of s.
+// Structs that are Equal have the same hash, so the hash is stable
+// across messages, processes, and compatible schema changes.
+func Hash64(s Struct) (uint64, error) {
+	c, err := Canonicalize(s)
+	if err != nil {
+		return 0, err
+	}
+	h := fnv.New64a()
+	h.Write(c)
+	return h.Sum64(), nil
+}
+
 func canonicalPtr(dst *Segment, p Ptr) (Ptr, error) {
 	if !p.IsValid() {
 		return Ptr{}, nil
@@ -190,3 +190,59 @@ func TestCanonicalize(t *testing.T) {
 		}
 	}
 }
+
+func TestEqualHash64(t *testing.T) {
+	newStruct := func(sz ObjectSize, x uint64, text string) Struct {
+		_, seg, err := NewMessage(SingleSegment(nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := NewRootStruct(seg, sz)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.SetUint64(0, x)
+		if text != "" {
+			if err := s.SetText(0, text); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return s
+	}
+	small := ObjectSize{DataSize: 8, PointerCount: 1}
+	large := ObjectSize{DataSize: 16, PointerCount: 2}
+	tests := []struct {
+		name  string
+		a, b  Struct
+		equal bool
+	}{
+		{"same", newStruct(small, 42, "foo"), newStruct(small, 42, "foo"), true},
+		{"different sizes", newStruct(small, 42, "foo"), newStruct(large, 42, "foo"), true},
+		{"different data", newStruct(small, 42, "foo"), newStruct(small, 43, "foo"), false},
+		{"different text", newStruct(small, 42, "foo"), newStruct(small, 42, "bar"), false},
+		{"null and zero", Struct{}, newStruct(small, 0, ""), false},
+	}
+	for _, test := range tests {
+		eq, err := Equal(test.a, test.b)
+		if err != nil {
+			t.Errorf("%s: Equal: %v", test.name, err)
+			continue
+		}
+		if eq != test.equal {
+			t.Errorf("%s: Equal = %t; want %t", test.name, eq, test.equal)
+		}
+		ha, err := Hash64(test.a)
+		if err != nil {
+			t.Errorf("%s: Hash64(a): %v", test.name, err)
+			continue
+		}
+		hb, err := Hash64(test.b)
+		if err != nil {
+			t.Errorf("%s: Hash64(b): %v", test.name, err)
+			continue
+		}
+		if (ha == hb) != test.equal {
+			t.Errorf("%s: Hash64(a) = %#x, Hash64(b) = %#x; want equal = %t", test.name, ha, hb, test.equal)
+		}
+	}
+}
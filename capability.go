@@ -2,6 +2,7 @@ package capnp
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
 
 	"golang.org/x/net/context"
@@ -316,6 +317,15 @@ func (p *Pipeline) GetPipelineDefault(off uint16, def []byte) *Pipeline {
 	}
 }
 
+// Apply returns a derived pipeline which applies ops in turn, as if
+// GetPipelineDefault were called for each of them.
+func (p *Pipeline) Apply(ops ...PipelineOp) *Pipeline {
+	for _, op := range ops {
+		p = p.GetPipelineDefault(op.Field, op.DefaultValue)
+	}
+	return p
+}
+
 // PipelineClient implements Client by calling to the pipeline's answer.
 type PipelineClient Pipeline
 
@@ -362,6 +372,11 @@ type Method struct {
 	InterfaceName string
 	// Method name as it appears in the schema.  May be empty.
 	MethodName string
+
+	// Idempotent is true if the method has the $Go.idempotent
+	// annotation, so a failed call may safely be made again.  It is set
+	// by generated clients and isn't sent to the receiver.
+	Idempotent bool
 }
 
 // String returns a formatted string containing the interface name or
@@ -423,6 +438,100 @@ func TransformPtr(p Ptr, transform []PipelineOp) (Ptr, error) {
 	return p, err
 }
 
+// TransformClient follows transform from p to a capability, like a
+// pipelined call does.  Unlike TransformPtr, it reports the first null
+// pointer along the way and a final pointer that isn't a capability.
+// Errors are returned as a *PipelineError that records the op that
+// failed.
+func TransformClient(p Ptr, transform []PipelineOp) (Client, error) {
+	fail := func(step int, err error) (Client, error) {
+		return nil, &PipelineError{Transform: transform, Step: step, Err: err}
+	}
+	for i, op := range transform {
+		kind := NewAny(p).Kind()
+		if kind != KindStruct {
+			if i == 0 {
+				// The root isn't one of the results' fields, so blame
+				// the first op.
+				return fail(0, fmt.Errorf("capnp: results are a %v pointer, not a struct", kind))
+			}
+			if kind == KindNull {
+				return fail(i-1, ErrNullClient)
+			}
+			return fail(i-1, fmt.Errorf("capnp: %v pointer used as struct", kind))
+		}
+		var err error
+		p, err = p.Struct().Ptr(op.Field)
+		if err != nil {
+			return fail(i, err)
+		}
+		if op.DefaultValue != nil {
+			if p, err = p.Default(op.DefaultValue); err != nil {
+				return fail(i, err)
+			}
+		}
+	}
+	last := len(transform) - 1
+	switch kind := NewAny(p).Kind(); kind {
+	case KindInterface:
+	case KindNull:
+		return fail(last, ErrNullClient)
+	default:
+		return fail(last, fmt.Errorf("capnp: %v pointer used as capability", kind))
+	}
+	c := p.Interface().Client()
+	if c == nil {
+		return fail(last, ErrNullClient)
+	}
+	return c, nil
+}
+
+// PipelineError is an error from following a pipeline transform to a
+// capability: a pointer along the way was null, had the wrong type, or
+// couldn't be read.
+type PipelineError struct {
+	// Method is the call whose results were transformed, or nil if it
+	// isn't known.
+	Method *Method
+
+	Transform []PipelineOp
+
+	// Step is the index of the op in Transform that failed, or -1 if
+	// the transform is empty and the results themselves failed.
+	Step int
+
+	// Names holds the schema name of the field that each op selects,
+	// or nil if they aren't known.  See package pipelineop.
+	Names []string
+
+	Err error
+}
+
+// Error returns the error message, which includes the path through the
+// results up to the op that failed, such as "foo.bar".
+func (e *PipelineError) Error() string {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, "capnp: pipeline "...)
+	if e.Method != nil {
+		buf = append(buf, e.Method.String()...)
+		buf = append(buf, " results"...)
+	} else {
+		buf = append(buf, "results"...)
+	}
+	for i := 0; i <= e.Step && i < len(e.Transform); i++ {
+		buf = append(buf, '.')
+		if i < len(e.Names) && e.Names[i] != "" {
+			buf = append(buf, e.Names[i]...)
+		} else {
+			buf = append(buf, "ptr"...)
+			buf = strconv.AppendUint(buf, uint64(e.Transform[i].Field), 10)
+		}
+	}
+	buf = append(buf, ": "...)
+	buf = append(buf, e.Err.Error()...)
+	return string(buf)
+}
+
 type immediateAnswer struct {
 	s Struct
 }
@@ -437,25 +546,25 @@ func (ans immediateAnswer) Struct() (Struct, error) {
 }
 
 func (ans immediateAnswer) findClient(transform []PipelineOp) Client {
-	p, err := TransformPtr(ans.s.ToPtr(), transform)
+	c, err := TransformClient(ans.s.ToPtr(), transform)
 	if err != nil {
 		return ErrorClient(err)
 	}
-	return p.Interface().Client()
+	return c
 }
 
 func (ans immediateAnswer) PipelineCall(transform []PipelineOp, call *Call) Answer {
-	c := ans.findClient(transform)
-	if c == nil {
-		return ErrorAnswer(ErrNullClient)
+	c, err := TransformClient(ans.s.ToPtr(), transform)
+	if err != nil {
+		return ErrorAnswer(err)
 	}
 	return c.Call(call)
 }
 
 func (ans immediateAnswer) PipelineClose(transform []PipelineOp) error {
-	c := ans.findClient(transform)
-	if c == nil {
-		return ErrNullClient
+	c, err := TransformClient(ans.s.ToPtr(), transform)
+	if err != nil {
+		return err
 	}
 	return c.Close()
 }
@@ -494,6 +603,50 @@ func IsFixedAnswer(ans Answer) bool {
 	}
 }
 
+// AnswerDone returns a channel that is closed once ans is resolved, at
+// which point its Struct method returns without blocking.  This lets
+// callers wait on answers in a select statement alongside other
+// channels.
+//
+// Answers created by ImmediateAnswer and ErrorAnswer are already done,
+// and answers with a Done() <-chan struct{} method, like those returned
+// by the rpc and server packages, report their own channel.  For any
+// other answer, AnswerDone starts a goroutine that waits on Struct.
+func AnswerDone(ans Answer) <-chan struct{} {
+	if IsFixedAnswer(ans) {
+		return closedChan
+	}
+	if d, ok := ans.(interface {
+		Done() <-chan struct{}
+	}); ok {
+		return d.Done()
+	}
+	done := make(chan struct{})
+	go func() {
+		ans.Struct()
+		close(done)
+	}()
+	return done
+}
+
+// WaitAnswer waits until ans is resolved or ctx is done, whichever
+// comes first.  It returns the answer's result, or ctx.Err() if ctx was
+// done first.  The call itself is not canceled.
+func WaitAnswer(ctx context.Context, ans Answer) (Struct, error) {
+	select {
+	case <-AnswerDone(ans):
+		return ans.Struct()
+	case <-ctx.Done():
+		return Struct{}, ctx.Err()
+	}
+}
+
+var closedChan = func() chan struct{} {
+	c := make(chan struct{})
+	close(c)
+	return c
+}()
+
 type errorClient struct {
 	e error
 }
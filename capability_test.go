@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"errors"
 	"testing"
+
+	"golang.org/x/net/context"
 )
 
 func TestToInterface(t *testing.T) {
@@ -187,6 +189,86 @@ func TestTransform(t *testing.T) {
 	}
 }
 
+func TestTransformClient(t *testing.T) {
+	_, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := NewRootStruct(seg, ObjectSize{PointerCount: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inner, err := NewStruct(seg, ObjectSize{PointerCount: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := root.SetPtr(0, inner.ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+	text, err := NewText(seg, "hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := root.SetPtr(1, text.ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+	local := &namedClient{name: "local"}
+	if err := inner.SetPtr(1, NewInterface(seg, seg.Message().AddCap(local)).ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := TransformClient(root.ToPtr(), []PipelineOp{{Field: 0}, {Field: 1}})
+	if err != nil || c != local {
+		t.Errorf("TransformClient(root, 0.1) = %v, %v; want local, <nil>", c, err)
+	}
+
+	tests := []struct {
+		transform []PipelineOp
+		step      int
+		errString string
+	}{
+		{nil, -1, "capnp: pipeline results: capnp: struct pointer used as capability"},
+		{[]PipelineOp{{Field: 2}}, 0, "capnp: pipeline results.ptr2: capnp: call on null client"},
+		{[]PipelineOp{{Field: 0}, {Field: 0}}, 1, "capnp: pipeline results.ptr0.ptr0: capnp: call on null client"},
+		{[]PipelineOp{{Field: 0}, {Field: 0}, {Field: 0}}, 1, "capnp: pipeline results.ptr0.ptr0: capnp: call on null client"},
+		{[]PipelineOp{{Field: 1}}, 0, "capnp: pipeline results.ptr1: capnp: list pointer used as capability"},
+		{[]PipelineOp{{Field: 1}, {Field: 0}}, 0, "capnp: pipeline results.ptr1: capnp: list pointer used as struct"},
+	}
+	for _, test := range tests {
+		_, err := TransformClient(root.ToPtr(), test.transform)
+		pe, ok := err.(*PipelineError)
+		if !ok {
+			t.Errorf("TransformClient(root, %v) error = %v; want *PipelineError", test.transform, err)
+			continue
+		}
+		if pe.Step != test.step {
+			t.Errorf("TransformClient(root, %v) failed at step %d; want %d", test.transform, pe.Step, test.step)
+		}
+		if s := pe.Error(); s != test.errString {
+			t.Errorf("TransformClient(root, %v) error = %q; want %q", test.transform, s, test.errString)
+		}
+	}
+}
+
+func TestPipelineErrorString(t *testing.T) {
+	e := &PipelineError{
+		Method: &Method{
+			InterfaceID:   0x8e5322c1e9282534,
+			MethodID:      1,
+			InterfaceName: "aircraftlib:Echo",
+			MethodName:    "foo",
+		},
+		Transform: []PipelineOp{{Field: 0}, {Field: 3}, {Field: 1}},
+		Step:      1,
+		Names:     []string{"bar", "baz"},
+		Err:       ErrNullClient,
+	}
+	want := "capnp: pipeline aircraftlib:Echo.foo results.bar.baz: capnp: call on null client"
+	if s := e.Error(); s != want {
+		t.Errorf("Error() = %q; want %q", s, want)
+	}
+}
+
 func TestMethodString(t *testing.T) {
 	tests := []struct {
 		m *Method
@@ -278,3 +360,71 @@ func deepPointerEqual(a, b Pointer) bool {
 	bbytes, _ := msgB.Marshal()
 	return bytes.Equal(abytes, bbytes)
 }
+
+// chanAnswer is an answer that resolves when its channel is closed.  It
+// has no Done method.
+type chanAnswer struct {
+	c <-chan struct{}
+	s Struct
+}
+
+func (ans chanAnswer) Struct() (Struct, error) {
+	<-ans.c
+	return ans.s, nil
+}
+
+func (ans chanAnswer) PipelineCall([]PipelineOp, *Call) Answer {
+	return ErrorAnswer(errors.New("not implemented"))
+}
+
+func (ans chanAnswer) PipelineClose([]PipelineOp) error {
+	return nil
+}
+
+func TestAnswerDone(t *testing.T) {
+	for _, ans := range []Answer{ImmediateAnswer(Struct{}), ErrorAnswer(errors.New("boom"))} {
+		select {
+		case <-AnswerDone(ans):
+		default:
+			t.Errorf("AnswerDone(%T) not closed", ans)
+		}
+	}
+
+	c := make(chan struct{})
+	done := AnswerDone(chanAnswer{c: c})
+	select {
+	case <-done:
+		t.Fatal("AnswerDone closed before answer resolved")
+	default:
+	}
+	close(c)
+	<-done
+}
+
+func TestWaitAnswer(t *testing.T) {
+	_, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewRootStruct(seg, ObjectSize{DataSize: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetUint64(0, 42)
+	c := make(chan struct{})
+	ans := chanAnswer{c: c, s: s}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := WaitAnswer(ctx, ans); err != context.Canceled {
+		t.Errorf("WaitAnswer with canceled context = %v; want %v", err, context.Canceled)
+	}
+	close(c)
+	got, err := WaitAnswer(context.Background(), ans)
+	if err != nil {
+		t.Fatal("WaitAnswer:", err)
+	}
+	if x := got.Uint64(0); x != 42 {
+		t.Errorf("WaitAnswer result = %d; want 42", x)
+	}
+}
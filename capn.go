@@ -93,17 +93,17 @@ func (s *Segment) writeRawPointer(addr Address, val rawPointer) {
 // root returns a 1-element pointer list that references the first word
 // in the segment.  This only makes sense to call on the first segment
 // in a message.
-func (s *Segment) root() PointerList {
+func (s *Segment) root() (PointerList, error) {
 	sz := ObjectSize{PointerCount: 1}
 	if !s.regionInBounds(0, sz.totalSize()) {
-		return PointerList{}
+		return PointerList{}, errNoRoot
 	}
 	return PointerList{List{
 		seg:        s,
 		length:     1,
 		size:       sz,
 		depthLimit: s.msg.depthLimit(),
-	}}
+	}}, nil
 }
 
 func (s *Segment) lookupSegment(id SegmentID) (*Segment, error) {
@@ -390,7 +390,10 @@ func (s *Segment) writePtr(off Address, src Ptr, forceCopy bool) error {
 		return nil
 	case hasCapacity(src.seg.data, wordSize):
 		// Enough room adjacent to src to write a far pointer landing pad.
-		_, padAddr, _ := alloc(src.seg, wordSize)
+		_, padAddr, err := alloc(src.seg, wordSize)
+		if err != nil {
+			return err
+		}
 		src.seg.writeRawPointer(padAddr, srcRaw.withOffset(nearPointerOffset(padAddr, srcAddr)))
 		s.writeRawPointer(off, rawFarPointer(src.seg.id, padAddr))
 		return nil
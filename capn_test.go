@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"testing"
 )
 
@@ -740,3 +741,165 @@ func catchPanic(f func()) (err error) {
 	f()
 	return nil
 }
+
+func TestClearPtr(t *testing.T) {
+	msg, seg, err := NewMessage(MultiSegment([][]byte{make([]byte, 0, 32)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := NewRootStruct(seg, ObjectSize{DataSize: 8, PointerCount: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	root.SetUint64(0, 0xdeadbeef)
+	child, err := NewStruct(seg, ObjectSize{DataSize: 8, PointerCount: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	child.SetUint64(0, 0xfeedface)
+	if err := child.SetText(0, "stale child"); err != nil {
+		t.Fatal(err)
+	}
+	elems, err := NewCompositeList(seg, ObjectSize{DataSize: 8, PointerCount: 1}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < elems.Len(); i++ {
+		elems.Struct(i).SetUint64(0, 0xcafe)
+		if err := elems.Struct(i).SetText(0, "stale elem"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := child.SetPtr(1, elems.ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+	if err := root.SetPtr(0, child.ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+	if err := root.SetText(1, "keep"); err != nil {
+		t.Fatal(err)
+	}
+	if msg.NumSegments() < 2 {
+		t.Fatal("message has one segment; want far pointers")
+	}
+
+	if err := root.ClearPtr(0); err != nil {
+		t.Fatal("ClearPtr:", err)
+	}
+	if p, err := root.Ptr(0); err != nil || p.IsValid() {
+		t.Errorf("root.Ptr(0) = %v, %v; want null", p, err)
+	}
+	if p, _ := root.Ptr(1); p.Text() != "keep" {
+		t.Errorf("root.Ptr(1).Text() = %q; want \"keep\"", p.Text())
+	}
+	for i := int64(0); i < msg.NumSegments(); i++ {
+		s, _ := msg.Segment(SegmentID(i))
+		for _, stale := range [][]byte{[]byte("stale"), {0xce, 0xfa, 0xed, 0xfe}, {0xfe, 0xca}} {
+			if bytes.Contains(s.Data(), stale) {
+				t.Errorf("segment %d still contains % x after ClearPtr", i, stale)
+			}
+		}
+	}
+
+	root.ZeroData()
+	if root.Uint64(0) != 0 {
+		t.Errorf("root.Uint64(0) = %#x after ZeroData; want 0", root.Uint64(0))
+	}
+	if p, _ := root.Ptr(1); p.Text() != "keep" {
+		t.Error("ZeroData changed a pointer")
+	}
+}
+
+func TestAllocateRoot(t *testing.T) {
+	msg := &Message{Arena: SingleSegment(nil)}
+	root, err := AllocateRoot(msg, ObjectSize{DataSize: 8})
+	if err != nil {
+		t.Fatal("AllocateRoot:", err)
+	}
+	root.SetUint64(0, 42)
+	p, err := msg.RootPtr()
+	if err != nil {
+		t.Fatal("RootPtr:", err)
+	}
+	if got := p.Struct().Uint64(0); got != 42 {
+		t.Errorf("root.Uint64(0) = %d; want 42", got)
+	}
+}
+
+// TestWireByteOrder checks that values are stored little-endian
+// regardless of the host's byte order.
+func TestWireByteOrder(t *testing.T) {
+	_, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewRootStruct(seg, ObjectSize{DataSize: 24})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetUint16(0, 0x0102)
+	s.SetUint32(4, 0x03040506)
+	s.SetUint64(8, 0x0708090a0b0c0d0e)
+	s.SetUint32(16, math.Float32bits(1))
+	want := []byte{
+		0x02, 0x01, 0x00, 0x00, 0x06, 0x05, 0x04, 0x03,
+		0x0e, 0x0d, 0x0c, 0x0b, 0x0a, 0x09, 0x08, 0x07,
+		0x00, 0x00, 0x80, 0x3f, 0x00, 0x00, 0x00, 0x00,
+	}
+	if got := seg.Data()[8:]; !bytes.Equal(got, want) {
+		t.Errorf("struct data =\n% x\nwant\n% x", got, want)
+	}
+	if s.Uint16(0) != 0x0102 || s.Uint32(4) != 0x03040506 || s.Uint64(8) != 0x0708090a0b0c0d0e || math.Float32frombits(s.Uint32(16)) != 1 {
+		t.Error("values did not round-trip")
+	}
+}
+
+func TestClearPtrLandingPad(t *testing.T) {
+	tests := []struct {
+		name    string
+		ptrType pointerType
+		bufs    [][]byte
+	}{
+		{"far", farPointer, [][]byte{make([]byte, 0, 8), make([]byte, 0, 16)}},
+		{"double far", doubleFarPointer, [][]byte{make([]byte, 0, 8), make([]byte, 0, 8)}},
+	}
+	for _, test := range tests {
+		msg := &Message{Arena: MultiSegment(test.bufs)}
+		seg0, err := msg.Segment(0)
+		if err != nil {
+			t.Fatalf("%s: msg.Segment(0): %v", test.name, err)
+		}
+		seg1, err := msg.Segment(1)
+		if err != nil {
+			t.Fatalf("%s: msg.Segment(1): %v", test.name, err)
+		}
+		parent, err := NewStruct(seg0, ObjectSize{PointerCount: 1})
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		child, err := NewStruct(seg1, ObjectSize{DataSize: 8})
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		child.SetUint64(0, 0xfeedface)
+		if err := parent.SetPtr(0, child.ToPtr()); err != nil {
+			t.Fatalf("%s: SetPtr: %v", test.name, err)
+		}
+		if pt := seg0.readRawPointer(parent.pointerAddress(0)).pointerType(); pt != test.ptrType {
+			t.Fatalf("%s: pointer type = %v; want %v", test.name, pt, test.ptrType)
+		}
+
+		if err := parent.ClearPtr(0); err != nil {
+			t.Fatalf("%s: ClearPtr: %v", test.name, err)
+		}
+		for i := int64(0); i < msg.NumSegments(); i++ {
+			s, _ := msg.Segment(SegmentID(i))
+			for j, b := range s.Data() {
+				if b != 0 {
+					t.Errorf("%s: segment %d has byte %#x at %d after ClearPtr; want all zero", test.name, i, b, j)
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,540 @@
+// Package capnparrow converts lists of Cap'n Proto structs to and from
+// columnar record batches in the Apache Arrow memory layout, so that
+// logs of capnp messages can be processed by analytical tools without
+// an accessor call per row.
+//
+// The Arrow schema of a record batch is derived from the struct's node
+// in the schema registry.  Each scalar, enum, Text, or Data field of the
+// struct becomes a column; fields of groups become columns named
+// "group.field".  Members of unions are nullable and are null in rows
+// where another member is set.  Struct, List, interface, AnyPointer, and
+// Void fields have no column.
+//
+// This package does not depend on an Arrow implementation.  Instead,
+// each Column holds the buffers of an Arrow array as the Arrow columnar
+// format specifies them, so that they can be wrapped by an Arrow library
+// without copying.  For example, with the Go Arrow module:
+//
+//	data := array.NewData(arrowType, col.Len,
+//		[]*memory.Buffer{memory.NewBufferBytes(col.Validity), ...},
+//		nil, col.NullCount, 0)
+package capnparrow // import "github.com/iguazio/go-capnproto2/capnparrow"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/nodemap"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+	"github.com/iguazio/go-capnproto2/schemas"
+)
+
+// Type is an Arrow data type.
+type Type int
+
+// Arrow data types used for capnp fields.  Enums are UInt16.
+const (
+	Bool Type = iota + 1
+	Int8
+	Int16
+	Int32
+	Int64
+	Uint8
+	Uint16
+	Uint32
+	Uint64
+	Float32
+	Float64
+	Utf8
+	Binary
+)
+
+var typeNames = [...]string{
+	Bool:    "bool",
+	Int8:    "int8",
+	Int16:   "int16",
+	Int32:   "int32",
+	Int64:   "int64",
+	Uint8:   "uint8",
+	Uint16:  "uint16",
+	Uint32:  "uint32",
+	Uint64:  "uint64",
+	Float32: "float32",
+	Float64: "float64",
+	Utf8:    "utf8",
+	Binary:  "binary",
+}
+
+// String returns the Arrow name of the type.
+func (t Type) String() string {
+	if t <= 0 || int(t) >= len(typeNames) {
+		return fmt.Sprintf("Type(%d)", int(t))
+	}
+	return typeNames[t]
+}
+
+// byteWidth returns the size of a value of a fixed-width type, or 0 for
+// Bool and variable-width types.
+func (t Type) byteWidth() int {
+	switch t {
+	case Int8, Uint8:
+		return 1
+	case Int16, Uint16:
+		return 2
+	case Int32, Uint32, Float32:
+		return 4
+	case Int64, Uint64, Float64:
+		return 8
+	default:
+		return 0
+	}
+}
+
+// A Field is a column of a schema.
+type Field struct {
+	Name     string
+	Type     Type
+	Nullable bool
+}
+
+// A Schema describes the columns of a record batch.
+type Schema struct {
+	Fields []Field
+}
+
+// A Column holds the buffers of an Arrow array.
+type Column struct {
+	Len       int
+	NullCount int
+
+	// Validity is a bitmap with a set bit for each non-null value, in
+	// least-significant bit order.  It is nil if NullCount is zero.
+	Validity []byte
+
+	// Offsets holds the Len+1 offsets into Values of Utf8 and Binary
+	// values.  It is nil for other types.
+	Offsets []int32
+
+	// Values holds little-endian fixed-width values, a bitmap of Bool
+	// values, or the concatenated bytes of Utf8 and Binary values.
+	Values []byte
+}
+
+// IsNull reports whether the i'th value is null.
+func (c *Column) IsNull(i int) bool {
+	return c.Validity != nil && c.Validity[i/8]&(1<<uint(i%8)) == 0
+}
+
+// A RecordBatch is a set of equal-length columns.
+type RecordBatch struct {
+	Schema  *Schema
+	NumRows int
+	Columns []Column
+}
+
+// A Converter converts struct lists of the types in a schema registry.
+// A Converter caches plans for the types it has seen, and is not safe
+// for concurrent use.
+type Converter struct {
+	nodes nodemap.Map
+	plans map[uint64]*structPlan
+}
+
+// NewConverter returns a converter for the types in reg.  If reg is
+// nil, schemas.DefaultRegistry is used.
+func NewConverter(reg *schemas.Registry) *Converter {
+	c := &Converter{plans: make(map[uint64]*structPlan)}
+	if reg != nil {
+		c.nodes.UseRegistry(reg)
+	}
+	return c
+}
+
+// Schema returns the Arrow schema for the struct type with the given ID.
+func (c *Converter) Schema(typeID uint64) (*Schema, error) {
+	p, err := c.plan(typeID)
+	if err != nil {
+		return nil, err
+	}
+	return p.schema, nil
+}
+
+// ToRecordBatch converts a list of structs of the given type to a
+// record batch with one row per element.
+func (c *Converter) ToRecordBatch(typeID uint64, l capnp.List) (*RecordBatch, error) {
+	p, err := c.plan(typeID)
+	if err != nil {
+		return nil, err
+	}
+	n := l.Len()
+	rb := &RecordBatch{
+		Schema:  p.schema,
+		NumRows: n,
+		Columns: make([]Column, len(p.cols)),
+	}
+	for j := range p.cols {
+		rb.Columns[j] = newColumn(p.schema.Fields[j].Type, n)
+	}
+	for i := 0; i < n; i++ {
+		s := l.Struct(i)
+		for j := range p.cols {
+			if err := p.cols[j].read(&rb.Columns[j], i, s); err != nil {
+				return nil, fmt.Errorf("capnparrow: row %d: %s: %v", i, p.schema.Fields[j].Name, err)
+			}
+		}
+	}
+	for j := range rb.Columns {
+		if rb.Columns[j].NullCount == 0 {
+			rb.Columns[j].Validity = nil
+		}
+	}
+	return rb, nil
+}
+
+// FromRecordBatch allocates a list of structs of the given type in seg
+// and fills it in from rb.  Columns are matched to fields by name; a
+// column with no matching field is an error, but fields may be missing
+// from rb.  Null values are left unset.
+func (c *Converter) FromRecordBatch(seg *capnp.Segment, typeID uint64, rb *RecordBatch) (capnp.List, error) {
+	p, err := c.plan(typeID)
+	if err != nil {
+		return capnp.List{}, err
+	}
+	if len(rb.Columns) != len(rb.Schema.Fields) {
+		return capnp.List{}, errors.New("capnparrow: record batch has different numbers of fields and columns")
+	}
+	cols := make([]*colPlan, len(rb.Columns))
+	for j, f := range rb.Schema.Fields {
+		k := p.index[f.Name]
+		if k == 0 {
+			return capnp.List{}, fmt.Errorf("capnparrow: no field %q in struct %#x", f.Name, typeID)
+		}
+		want := p.schema.Fields[k-1]
+		if f.Type != want.Type {
+			return capnp.List{}, fmt.Errorf("capnparrow: column %q is %v; want %v", f.Name, f.Type, want.Type)
+		}
+		if err := checkColumn(&rb.Columns[j], f.Type, rb.NumRows); err != nil {
+			return capnp.List{}, fmt.Errorf("capnparrow: column %q: %v", f.Name, err)
+		}
+		cols[j] = &p.cols[k-1]
+	}
+	if rb.NumRows > math.MaxInt32 {
+		return capnp.List{}, errors.New("capnparrow: too many rows for a list")
+	}
+	l, err := capnp.NewCompositeList(seg, p.size, int32(rb.NumRows))
+	if err != nil {
+		return capnp.List{}, err
+	}
+	for i := 0; i < rb.NumRows; i++ {
+		s := l.Struct(i)
+		for j, cp := range cols {
+			if rb.Columns[j].IsNull(i) {
+				continue
+			}
+			if err := cp.write(&rb.Columns[j], i, s); err != nil {
+				return capnp.List{}, fmt.Errorf("capnparrow: row %d: %s: %v", i, rb.Schema.Fields[j].Name, err)
+			}
+		}
+	}
+	return l, nil
+}
+
+// A structPlan describes how to convert a struct type.
+type structPlan struct {
+	schema *Schema
+	cols   []colPlan
+	index  map[string]int // field name to index in cols, plus one
+	size   capnp.ObjectSize
+}
+
+// A colPlan describes how to convert a field to a column.
+type colPlan struct {
+	typ   Type
+	off   uint32 // data offset in bytes or bits, or pointer index
+	def   uint64 // default value bits of a scalar field
+	defb  []byte // default value of a Text or Data field
+	conds []unionCond
+}
+
+// A unionCond is a union discriminant value that must be set for a
+// field to be active.
+type unionCond struct {
+	off capnp.DataOffset
+	val uint16
+}
+
+func (c *Converter) plan(typeID uint64) (*structPlan, error) {
+	if p := c.plans[typeID]; p != nil {
+		return p, nil
+	}
+	n, err := c.nodes.Find(typeID)
+	if err != nil {
+		return nil, fmt.Errorf("capnparrow: find struct %#x: %v", typeID, err)
+	}
+	if n.Which() != schema.Node_Which_structNode {
+		return nil, fmt.Errorf("capnparrow: %#x is a %v, not a struct", typeID, n.Which())
+	}
+	sn := n.StructNode()
+	p := &structPlan{
+		schema: new(Schema),
+		index:  make(map[string]int),
+		size: capnp.ObjectSize{
+			DataSize:     capnp.Size(sn.DataWordCount()) * 8,
+			PointerCount: sn.PointerCount(),
+		},
+	}
+	if err := c.addFields(p, "", n, nil); err != nil {
+		return nil, fmt.Errorf("capnparrow: struct %#x: %v", typeID, err)
+	}
+	c.plans[typeID] = p
+	return p, nil
+}
+
+// addFields adds the fields of a struct or group node to p.
+func (c *Converter) addFields(p *structPlan, prefix string, n schema.Node, conds []unionCond) error {
+	fields, err := n.StructNode().Fields()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < fields.Len(); i++ {
+		f := fields.At(i)
+		name, err := f.Name()
+		if err != nil {
+			return err
+		}
+		name = prefix + name
+		fconds := conds
+		if dv := f.DiscriminantValue(); dv != schema.Field_noDiscriminant {
+			fconds = make([]unionCond, len(conds), len(conds)+1)
+			copy(fconds, conds)
+			fconds = append(fconds, unionCond{
+				off: capnp.DataOffset(n.StructNode().DiscriminantOffset() * 2),
+				val: dv,
+			})
+		}
+		switch f.Which() {
+		case schema.Field_Which_group:
+			g, err := c.nodes.Find(f.Group().TypeId())
+			if err != nil {
+				return fmt.Errorf("field %s: %v", name, err)
+			}
+			if err := c.addFields(p, name+".", g, fconds); err != nil {
+				return err
+			}
+		case schema.Field_Which_slot:
+			cp, ok, err := newColPlan(f.Slot())
+			if err != nil {
+				return fmt.Errorf("field %s: %v", name, err)
+			}
+			if !ok {
+				continue
+			}
+			cp.conds = fconds
+			p.cols = append(p.cols, cp)
+			p.schema.Fields = append(p.schema.Fields, Field{
+				Name:     name,
+				Type:     cp.typ,
+				Nullable: len(fconds) > 0,
+			})
+			p.index[name] = len(p.cols)
+		}
+	}
+	return nil
+}
+
+// newColPlan returns the plan for a slot, or false if the slot's type
+// has no column.
+func newColPlan(slot schema.Field_slot) (colPlan, bool, error) {
+	t, err := slot.Type()
+	if err != nil {
+		return colPlan{}, false, err
+	}
+	dv, err := slot.DefaultValue()
+	if err != nil {
+		return colPlan{}, false, err
+	}
+	off := slot.Offset()
+	var cp colPlan
+	switch t.Which() {
+	case schema.Type_Which_bool:
+		cp = colPlan{typ: Bool, off: off}
+		if dv.Bool() {
+			cp.def = 1
+		}
+	case schema.Type_Which_int8:
+		cp = colPlan{typ: Int8, off: off, def: uint64(uint8(dv.Int8()))}
+	case schema.Type_Which_int16:
+		cp = colPlan{typ: Int16, off: off * 2, def: uint64(uint16(dv.Int16()))}
+	case schema.Type_Which_int32:
+		cp = colPlan{typ: Int32, off: off * 4, def: uint64(uint32(dv.Int32()))}
+	case schema.Type_Which_int64:
+		cp = colPlan{typ: Int64, off: off * 8, def: uint64(dv.Int64())}
+	case schema.Type_Which_uint8:
+		cp = colPlan{typ: Uint8, off: off, def: uint64(dv.Uint8())}
+	case schema.Type_Which_uint16:
+		cp = colPlan{typ: Uint16, off: off * 2, def: uint64(dv.Uint16())}
+	case schema.Type_Which_enum:
+		cp = colPlan{typ: Uint16, off: off * 2, def: uint64(dv.Enum())}
+	case schema.Type_Which_uint32:
+		cp = colPlan{typ: Uint32, off: off * 4, def: uint64(dv.Uint32())}
+	case schema.Type_Which_uint64:
+		cp = colPlan{typ: Uint64, off: off * 8, def: dv.Uint64()}
+	case schema.Type_Which_float32:
+		cp = colPlan{typ: Float32, off: off * 4, def: uint64(math.Float32bits(dv.Float32()))}
+	case schema.Type_Which_float64:
+		cp = colPlan{typ: Float64, off: off * 8, def: math.Float64bits(dv.Float64())}
+	case schema.Type_Which_text:
+		cp = colPlan{typ: Utf8, off: off}
+		if dv.Which() == schema.Value_Which_text {
+			cp.defb, _ = dv.TextBytes()
+		}
+	case schema.Type_Which_data:
+		cp = colPlan{typ: Binary, off: off}
+		if dv.Which() == schema.Value_Which_data {
+			cp.defb, _ = dv.Data()
+		}
+	default:
+		return colPlan{}, false, nil
+	}
+	return cp, true, nil
+}
+
+// active reports whether the field is set in s, according to the
+// discriminants of the unions that contain it.
+func (cp *colPlan) active(s capnp.Struct) bool {
+	for _, c := range cp.conds {
+		if s.Uint16(c.off) != c.val {
+			return false
+		}
+	}
+	return true
+}
+
+func newColumn(t Type, n int) Column {
+	col := Column{
+		Len:      n,
+		Validity: make([]byte, (n+7)/8),
+	}
+	switch t {
+	case Bool:
+		col.Values = make([]byte, (n+7)/8)
+	case Utf8, Binary:
+		col.Offsets = make([]int32, 1, n+1)
+	default:
+		col.Values = make([]byte, n*t.byteWidth())
+	}
+	return col
+}
+
+// read appends the field's value in s as the i'th value of col.
+func (cp *colPlan) read(col *Column, i int, s capnp.Struct) error {
+	if !cp.active(s) {
+		col.NullCount++
+		if col.Offsets != nil {
+			col.Offsets = append(col.Offsets, int32(len(col.Values)))
+		}
+		return nil
+	}
+	col.Validity[i/8] |= 1 << uint(i%8)
+	switch cp.typ {
+	case Bool:
+		if s.Bit(capnp.BitOffset(cp.off)) != (cp.def != 0) {
+			col.Values[i/8] |= 1 << uint(i%8)
+		}
+	case Int8, Uint8:
+		col.Values[i] = s.Uint8(capnp.DataOffset(cp.off)) ^ uint8(cp.def)
+	case Int16, Uint16:
+		binary.LittleEndian.PutUint16(col.Values[i*2:], s.Uint16(capnp.DataOffset(cp.off))^uint16(cp.def))
+	case Int32, Uint32, Float32:
+		binary.LittleEndian.PutUint32(col.Values[i*4:], s.Uint32(capnp.DataOffset(cp.off))^uint32(cp.def))
+	case Int64, Uint64, Float64:
+		binary.LittleEndian.PutUint64(col.Values[i*8:], s.Uint64(capnp.DataOffset(cp.off))^cp.def)
+	case Utf8, Binary:
+		p, err := s.Ptr(uint16(cp.off))
+		if err != nil {
+			return err
+		}
+		b := cp.defb
+		if p.IsValid() {
+			if cp.typ == Utf8 {
+				b = p.TextBytes()
+			} else {
+				b = p.Data()
+			}
+		}
+		if len(col.Values)+len(b) > math.MaxInt32 {
+			return errors.New("column values overflow 32-bit offsets")
+		}
+		col.Values = append(col.Values, b...)
+		col.Offsets = append(col.Offsets, int32(len(col.Values)))
+	}
+	return nil
+}
+
+// write sets the field in s to the i'th value of col.
+func (cp *colPlan) write(col *Column, i int, s capnp.Struct) error {
+	for _, c := range cp.conds {
+		s.SetUint16(c.off, c.val)
+	}
+	switch cp.typ {
+	case Bool:
+		v := col.Values[i/8]&(1<<uint(i%8)) != 0
+		s.SetBit(capnp.BitOffset(cp.off), v != (cp.def != 0))
+	case Int8, Uint8:
+		s.SetUint8(capnp.DataOffset(cp.off), col.Values[i]^uint8(cp.def))
+	case Int16, Uint16:
+		s.SetUint16(capnp.DataOffset(cp.off), binary.LittleEndian.Uint16(col.Values[i*2:])^uint16(cp.def))
+	case Int32, Uint32, Float32:
+		s.SetUint32(capnp.DataOffset(cp.off), binary.LittleEndian.Uint32(col.Values[i*4:])^uint32(cp.def))
+	case Int64, Uint64, Float64:
+		s.SetUint64(capnp.DataOffset(cp.off), binary.LittleEndian.Uint64(col.Values[i*8:])^cp.def)
+	case Utf8, Binary:
+		b := col.Values[col.Offsets[i]:col.Offsets[i+1]]
+		var l capnp.UInt8List
+		var err error
+		if cp.typ == Utf8 {
+			l, err = capnp.NewTextFromBytes(s.Segment(), b)
+		} else {
+			l, err = capnp.NewData(s.Segment(), b)
+		}
+		if err != nil {
+			return err
+		}
+		return s.SetPtr(uint16(cp.off), l.ToPtr())
+	}
+	return nil
+}
+
+// checkColumn reports whether col's buffers are large enough for n
+// values of type t.
+func checkColumn(col *Column, t Type, n int) error {
+	if col.Len != n {
+		return fmt.Errorf("length %d; want %d", col.Len, n)
+	}
+	if col.Validity != nil && len(col.Validity) < (n+7)/8 {
+		return errors.New("validity bitmap too short")
+	}
+	switch t {
+	case Bool:
+		if len(col.Values) < (n+7)/8 {
+			return errors.New("values too short")
+		}
+	case Utf8, Binary:
+		if len(col.Offsets) < n+1 {
+			return errors.New("offsets too short")
+		}
+		for i := 0; i < n; i++ {
+			if col.Offsets[i] < 0 || col.Offsets[i] > col.Offsets[i+1] || int(col.Offsets[i+1]) > len(col.Values) {
+				return fmt.Errorf("bad offsets for value %d", i)
+			}
+		}
+	default:
+		if len(col.Values) < n*t.byteWidth() {
+			return errors.New("values too short")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package capnparrow
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+func TestSchema(t *testing.T) {
+	c := NewConverter(nil)
+	s, err := c.Schema(air.Zdate_TypeID)
+	if err != nil {
+		t.Fatal("Schema:", err)
+	}
+	want := []Field{
+		{Name: "year", Type: Int16},
+		{Name: "month", Type: Uint8},
+		{Name: "day", Type: Uint8},
+	}
+	if len(s.Fields) != len(want) {
+		t.Fatalf("Schema(Zdate).Fields = %+v; want %+v", s.Fields, want)
+	}
+	for i := range want {
+		if s.Fields[i] != want[i] {
+			t.Errorf("Schema(Zdate).Fields[%d] = %+v; want %+v", i, s.Fields[i], want[i])
+		}
+	}
+
+	if _, err := c.Schema(air.Airport_TypeID); err == nil {
+		t.Error("Schema(Airport) did not return an error for an enum type")
+	}
+}
+
+func TestRoundTrip_Zdate(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := air.NewZdate_List(seg, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < l.Len(); i++ {
+		d := l.At(i)
+		d.SetYear(int16(2000 + i))
+		d.SetMonth(uint8(i + 1))
+		d.SetDay(uint8(10 * (i + 1)))
+	}
+	c := NewConverter(nil)
+	rb, err := c.ToRecordBatch(air.Zdate_TypeID, l.List)
+	if err != nil {
+		t.Fatal("ToRecordBatch:", err)
+	}
+	if rb.NumRows != 3 {
+		t.Errorf("NumRows = %d; want 3", rb.NumRows)
+	}
+	year := rb.Columns[0]
+	if year.NullCount != 0 || year.Validity != nil {
+		t.Errorf("year column has nulls: %+v", year)
+	}
+	for i := 0; i < 3; i++ {
+		if y := int16(binary.LittleEndian.Uint16(year.Values[i*2:])); y != int16(2000+i) {
+			t.Errorf("year[%d] = %d; want %d", i, y, 2000+i)
+		}
+	}
+
+	_, out, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l2, err := c.FromRecordBatch(out, air.Zdate_TypeID, rb)
+	if err != nil {
+		t.Fatal("FromRecordBatch:", err)
+	}
+	dl := air.Zdate_List{List: l2}
+	for i := 0; i < dl.Len(); i++ {
+		d := dl.At(i)
+		if d.Year() != int16(2000+i) || d.Month() != uint8(i+1) || d.Day() != uint8(10*(i+1)) {
+			t.Errorf("row %d = %d-%d-%d", i, d.Year(), d.Month(), d.Day())
+		}
+	}
+}
+
+func TestRoundTrip_Union(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := air.NewZ_List(seg, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.At(0).SetF64(math.Pi)
+	if err := l.At(1).SetText("hello"); err != nil {
+		t.Fatal(err)
+	}
+	l.At(2).SetI8(-7)
+	l.At(3).SetBool(true)
+
+	c := NewConverter(nil)
+	rb, err := c.ToRecordBatch(air.Z_TypeID, l.List)
+	if err != nil {
+		t.Fatal("ToRecordBatch:", err)
+	}
+	col := func(name string) *Column {
+		for i, f := range rb.Schema.Fields {
+			if f.Name == name {
+				if !f.Nullable {
+					t.Errorf("field %s is not nullable", name)
+				}
+				return &rb.Columns[i]
+			}
+		}
+		t.Fatalf("no column %s", name)
+		return nil
+	}
+	f64, text, i8, b := col("f64"), col("text"), col("i8"), col("bool")
+	for _, name := range []string{"zz", "f64vec", "zdate", "void"} {
+		for _, f := range rb.Schema.Fields {
+			if f.Name == name {
+				t.Errorf("unexpected column %s", name)
+			}
+		}
+	}
+	if f64.NullCount != 3 || f64.IsNull(0) || !f64.IsNull(1) {
+		t.Errorf("f64 validity = %08b, nulls = %d; want only row 0", f64.Validity, f64.NullCount)
+	}
+	if v := math.Float64frombits(binary.LittleEndian.Uint64(f64.Values)); v != math.Pi {
+		t.Errorf("f64[0] = %g; want pi", v)
+	}
+	if s := string(text.Values[text.Offsets[1]:text.Offsets[2]]); s != "hello" || !text.IsNull(0) {
+		t.Errorf("text[1] = %q; want \"hello\"", s)
+	}
+	if len(text.Offsets) != 5 {
+		t.Errorf("len(text.Offsets) = %d; want 5", len(text.Offsets))
+	}
+	if i8.Values[2] != uint8(0xf9) {
+		t.Errorf("i8[2] = %d; want -7", int8(i8.Values[2]))
+	}
+	if b.Values[0] != 1<<3 {
+		t.Errorf("bool values = %08b; want row 3 set", b.Values[0])
+	}
+
+	_, out, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l2, err := c.FromRecordBatch(out, air.Z_TypeID, rb)
+	if err != nil {
+		t.Fatal("FromRecordBatch:", err)
+	}
+	zl := air.Z_List{List: l2}
+	if z := zl.At(0); z.Which() != air.Z_Which_f64 || z.F64() != math.Pi {
+		t.Errorf("row 0 = %v %g; want f64 pi", z.Which(), z.F64())
+	}
+	if z := zl.At(1); z.Which() != air.Z_Which_text {
+		t.Errorf("row 1 = %v; want text", z.Which())
+	} else if s, _ := z.Text(); s != "hello" {
+		t.Errorf("row 1 text = %q; want \"hello\"", s)
+	}
+	if z := zl.At(2); z.Which() != air.Z_Which_i8 || z.I8() != -7 {
+		t.Errorf("row 2 = %v %d; want i8 -7", z.Which(), z.I8())
+	}
+	if z := zl.At(3); z.Which() != air.Z_Which_bool || !z.Bool() {
+		t.Errorf("row 3 = %v %t; want bool true", z.Which(), z.Bool())
+	}
+}
+
+func TestToRecordBatch_Defaults(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := air.NewDefaults_List(seg, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rb, err := NewConverter(nil).ToRecordBatch(air.Defaults_TypeID, l.List)
+	if err != nil {
+		t.Fatal("ToRecordBatch:", err)
+	}
+	cols := rb.Columns
+	if s := string(cols[0].Values); s != "foo" {
+		t.Errorf("text = %q; want \"foo\"", s)
+	}
+	if s := string(cols[1].Values); s != "bar" {
+		t.Errorf("data = %q; want \"bar\"", s)
+	}
+	if f := math.Float32frombits(binary.LittleEndian.Uint32(cols[2].Values)); f != 3.14 {
+		t.Errorf("float = %g; want 3.14", f)
+	}
+	if i := int32(binary.LittleEndian.Uint32(cols[3].Values)); i != -123 {
+		t.Errorf("int = %d; want -123", i)
+	}
+	if u := binary.LittleEndian.Uint32(cols[4].Values); u != 42 {
+		t.Errorf("uint = %d; want 42", u)
+	}
+}
+
+func TestFromRecordBatch_Errors(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewConverter(nil)
+	tests := []struct {
+		name string
+		rb   *RecordBatch
+	}{
+		{
+			name: "unknown field",
+			rb: &RecordBatch{
+				Schema:  &Schema{Fields: []Field{{Name: "century", Type: Int16}}},
+				NumRows: 1,
+				Columns: []Column{{Len: 1, Values: make([]byte, 2)}},
+			},
+		},
+		{
+			name: "wrong type",
+			rb: &RecordBatch{
+				Schema:  &Schema{Fields: []Field{{Name: "year", Type: Int32}}},
+				NumRows: 1,
+				Columns: []Column{{Len: 1, Values: make([]byte, 4)}},
+			},
+		},
+		{
+			name: "short values",
+			rb: &RecordBatch{
+				Schema:  &Schema{Fields: []Field{{Name: "year", Type: Int16}}},
+				NumRows: 2,
+				Columns: []Column{{Len: 2, Values: make([]byte, 2)}},
+			},
+		},
+	}
+	for _, test := range tests {
+		if _, err := c.FromRecordBatch(seg, air.Zdate_TypeID, test.rb); err == nil {
+			t.Errorf("%s: FromRecordBatch did not return an error", test.name)
+		}
+	}
+}
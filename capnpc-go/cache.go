@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+)
+
+// An outputCache stores generated files under a hash of everything that
+// determines their content: the generator itself, its options, and the
+// schema nodes of the file and of the files it imports.  A file whose
+// inputs have not changed since the last run is copied from the cache
+// instead of being generated again.
+type outputCache struct {
+	dir string
+	gen []byte // hash of the capnpc-go executable
+}
+
+// openOutputCache creates dir if needed and returns a cache that stores
+// files in it.
+func openOutputCache(dir string) (*outputCache, error) {
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return nil, err
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("find executable: %v", err)
+	}
+	f, err := os.Open(exe)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, fmt.Errorf("hash executable: %v", err)
+	}
+	return &outputCache{dir: dir, gen: h.Sum(nil)}, nil
+}
+
+// key returns the cache key for generating reqf.  src is the schema
+// source, if the output depends on it (see -sourcelines).
+func (c *outputCache) key(reqf schema.CodeGeneratorRequest_RequestedFile, nodes nodeMap, opts genoptions, args []string, src []byte) (string, error) {
+	h := sha256.New()
+	h.Write(c.gen)
+	// The command line is written into the output by -gogenerate.
+	fmt.Fprintf(h, "%+v\x00%q\x00", opts, args)
+	fname, _ := reqf.Filename()
+	fmt.Fprintf(h, "%q\x00%d\x00", fname, len(src))
+	h.Write(src)
+
+	files := []uint64{reqf.Id()}
+	imps, _ := reqf.Imports()
+	for i := 0; i < imps.Len(); i++ {
+		files = append(files, imps.At(i).Id())
+	}
+	for _, id := range files {
+		f := nodes[id]
+		if f == nil {
+			// Imports that the request has no nodes for, like
+			// go.capnp when it is not used, don't affect the output.
+			continue
+		}
+		if err := hashNode(h, f); err != nil {
+			return "", err
+		}
+		for _, n := range f.nodes {
+			if err := hashNode(h, n); err != nil {
+				return "", err
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashNode(w io.Writer, n *node) error {
+	b, err := capnp.Canonicalize(n.Struct)
+	if err != nil {
+		return fmt.Errorf("hash %v: %v", n, err)
+	}
+	fmt.Fprintf(w, "%d\x00", len(b))
+	w.Write(b)
+	return nil
+}
+
+// get returns the file stored under key.
+func (c *outputCache) get(key string) ([]byte, bool) {
+	b, err := ioutil.ReadFile(filepath.Join(c.dir, key))
+	if err != nil {
+		return nil, false
+	}
+	return b, true
+}
+
+// put stores data under key.  The file is renamed into place so that
+// concurrent runs never read a partial file.
+func (c *outputCache) put(key string, data []byte) error {
+	f, err := ioutil.TempFile(c.dir, key+".tmp")
+	if err != nil {
+		return err
+	}
+	_, werr := f.Write(data)
+	cerr := f.Close()
+	if werr == nil {
+		werr = cerr
+	}
+	if werr != nil {
+		os.Remove(f.Name())
+		return werr
+	}
+	return os.Rename(f.Name(), filepath.Join(c.dir, key))
+}
+
+// writeIfChanged writes data to name unless the file already holds
+// data, so that build tools that look at modification times don't
+// rebuild packages whose generated code is the same.
+func writeIfChanged(name string, data []byte) error {
+	if old, err := ioutil.ReadFile(name); err == nil && bytes.Equal(old, data) {
+		return nil
+	}
+	return ioutil.WriteFile(name, data, 0666)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOutputCache(t *testing.T) {
+	req := mustReadGeneratorRequest(t, "util.capnp.out")
+	nodes, err := buildNodeMap(req)
+	if err != nil {
+		t.Fatal("buildNodeMap:", err)
+	}
+	files, _ := req.RequestedFiles()
+	reqf := files.At(0)
+
+	c, err := openOutputCache(filepath.Join(t.TempDir(), "cache"))
+	if err != nil {
+		t.Fatal("openOutputCache:", err)
+	}
+	key := func(opts genoptions, src []byte) string {
+		t.Helper()
+		k, err := c.key(reqf, nodes, opts, nil, src)
+		if err != nil {
+			t.Fatal("key:", err)
+		}
+		return k
+	}
+	k1 := key(genoptions{promises: true}, nil)
+	if k := key(genoptions{promises: true}, nil); k != k1 {
+		t.Errorf("key changed between calls: %s, then %s", k1, k)
+	}
+	if k := key(genoptions{}, nil); k == k1 {
+		t.Error("key did not change with options")
+	}
+	if k := key(genoptions{promises: true}, []byte("# comment\n")); k == k1 {
+		t.Error("key did not change with source")
+	}
+	n := nodes[reqf.Id()].nodes[0]
+	n.SetScopeId(n.ScopeId() + 1)
+	if k := key(genoptions{promises: true}, nil); k == k1 {
+		t.Error("key did not change with schema node")
+	}
+
+	if _, ok := c.get(k1); ok {
+		t.Errorf("get(%s) found an entry in an empty cache", k1)
+	}
+	out := []byte("package util\n")
+	if err := c.put(k1, out); err != nil {
+		t.Fatal("put:", err)
+	}
+	if b, ok := c.get(k1); !ok || !bytes.Equal(b, out) {
+		t.Errorf("get(%s) = %q, %t; want %q, true", k1, b, ok, out)
+	}
+}
+
+func TestWriteIfChanged(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "foo.capnp.go")
+	if err := writeIfChanged(name, []byte("a")); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(name, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeIfChanged(name, []byte("a")); err != nil {
+		t.Fatal(err)
+	}
+	if fi, err := os.Stat(name); err != nil {
+		t.Fatal(err)
+	} else if !fi.ModTime().Equal(old) {
+		t.Errorf("writing the same content changed the modification time to %v", fi.ModTime())
+	}
+	if err := writeIfChanged(name, []byte("b")); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := ioutil.ReadFile(name); err != nil || string(b) != "b" {
+		t.Errorf("after writing new content, file = %q, %v; want \"b\"", b, err)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"flag"
 	"fmt"
 	"go/format"
+	"io/ioutil"
 	"math"
 	"os"
 	"path/filepath"
@@ -22,8 +23,10 @@ import (
 	"strconv"
 	"strings"
 	"text/template"
+	"unicode"
 
 	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/layouthash"
 	"github.com/iguazio/go-capnproto2/internal/schema"
 )
 
@@ -33,6 +36,9 @@ const (
 	textImport    = capnpImport + "/encoding/text"
 	schemasImport = capnpImport + "/schemas"
 	serverImport  = capnpImport + "/server"
+	sqlImport     = capnpImport + "/capnpsql"
+	slogImport    = capnpImport + "/capnpslog"
+	fixtureImport = capnpImport + "/capnpfixture"
 	contextImport = "golang.org/x/net/context"
 )
 
@@ -42,8 +48,30 @@ type genoptions struct {
 	promises      bool
 	schemas       bool
 	structStrings bool
+	sqlMethods    bool
+	logValuer     bool
+	presence      bool
+	goGenerate    bool
+	capnpFlags    string
+	sourceLines   bool
+	ptrReceivers  bool
+	equalMethods  bool
+
+	conflictSuffix  string
+	conflictAliases bool
+	flattenGroups   bool
+	fixtures        bool
+	errorDetails    bool
+	layoutHashes    bool
+	strict          bool
+	lazyConsts      bool
 }
 
+// defaultConflictSuffix is appended to the name of a field whose
+// accessors conflict with a generated method, unless -conflictsuffix
+// is given.
+const defaultConflictSuffix = "_"
+
 type renderer interface {
 	Render(name string, params interface{}) error
 	Bytes() []byte
@@ -72,9 +100,13 @@ type generator struct {
 	imports imports
 	data    staticData
 	opts    genoptions
+	source  *sourceMap // nil unless -sourcelines is set
 }
 
 func newGenerator(fileID uint64, nodes nodeMap, opts genoptions) *generator {
+	if opts.conflictSuffix == "" {
+		opts.conflictSuffix = defaultConflictSuffix
+	}
 	g := &generator{
 		r:      &templateRenderer{t: templates},
 		fileID: fileID,
@@ -110,7 +142,11 @@ func (g *generator) Capnp() string {
 // generate produces unformatted Go source code from the nodes defined in it.
 func (g *generator) generate() []byte {
 	var out bytes.Buffer
-	out.WriteString("// Code generated by capnpc-go. DO NOT EDIT.\n\n")
+	out.WriteString("// Code generated by capnpc-go. DO NOT EDIT.\n")
+	if g.opts.goGenerate {
+		g.writeGoGenerate(&out)
+	}
+	out.WriteString("\n")
 	fmt.Fprintf(&out, "package %s\n\n", g.nodes[g.fileID].pkg)
 	out.WriteString("import (\n")
 	for _, imp := range g.imports.usedImports() {
@@ -124,6 +160,27 @@ func (g *generator) generate() []byte {
 	return out.Bytes()
 }
 
+// writeGoGenerate writes comments that record how the file was
+// generated, followed by a //go:generate directive that regenerates it.
+// capnp runs capnpc-go without arguments for -ogo, so the directive
+// does not pass the flags that capnpc-go was run with.
+func (g *generator) writeGoGenerate(out *bytes.Buffer) {
+	fname := "<unknown>"
+	if f := g.nodes[g.fileID]; f != nil {
+		if dn, err := f.DisplayName(); err == nil {
+			fname = dn
+		}
+	}
+	args := append([]string{"capnpc-go"}, os.Args[1:]...)
+	fmt.Fprintf(out, "// Source: %s\n", fname)
+	fmt.Fprintf(out, "// Command: %s\n", strings.Join(args, " "))
+	out.WriteString("\n//go:generate capnp compile ")
+	if g.opts.capnpFlags != "" {
+		out.WriteString(g.opts.capnpFlags + " ")
+	}
+	fmt.Fprintf(out, "-ogo %s\n", filepath.Base(fname))
+}
+
 func writeByteLiteral(out *bytes.Buffer, name string, data []byte) {
 	fmt.Fprintf(out, "var %s = []byte{", name)
 	for i, b := range data {
@@ -423,43 +480,50 @@ func isGoConstType(t schema.Type) bool {
 		w == schema.Type_Which_enum
 }
 
+// isPointerConstType reports whether a constant of type t refers to
+// an object in a message, which defineConstNodes unmarshals from the
+// file's embedded data.
+func isPointerConstType(t schema.Type) bool {
+	w := t.Which()
+	return w == schema.Type_Which_structType ||
+		w == schema.Type_Which_list ||
+		w == schema.Type_Which_anyPointer
+}
+
 func (g *generator) defineConstNodes(nodes []*node) error {
-	constNodes := make([]*node, 0, len(nodes))
+	var params constantsParams
 	for _, n := range nodes {
 		if n.Which() != schema.Node_Which_const {
 			continue
 		}
 		t, _ := n.Const().Type()
-		if isGoConstType(t) {
-			constNodes = append(constNodes, n)
+		switch {
+		case isGoConstType(t):
+			params.Consts = append(params.Consts, n)
+		case isPointerConstType(t) && g.opts.lazyConsts:
+			params.Lazy = append(params.Lazy, n)
+		case isPointerConstType(t):
+			params.Vars = append(params.Vars, n)
+			params.PtrVars = append(params.PtrVars, n)
+		default:
+			params.Vars = append(params.Vars, n)
 		}
 	}
-	nc := len(constNodes)
-	for _, n := range nodes {
-		if n.Which() != schema.Node_Which_const {
-			continue
-		}
-		t, _ := n.Const().Type()
-		if !isGoConstType(t) {
-			constNodes = append(constNodes, n)
-		}
-	}
-	if len(constNodes) == 0 {
+	if len(params.Consts)+len(params.Vars)+len(params.Lazy) == 0 {
 		// short path
 		return nil
 	}
-	err := renderConstants(g.r, constantsParams{
-		G:      g,
-		Consts: constNodes[:nc],
-		Vars:   constNodes[nc:],
-	})
-	if err != nil {
+	params.G = g
+	if err := renderConstants(g.r, params); err != nil {
 		return fmt.Errorf("file constants: %v", err)
 	}
 	return nil
 }
 
-func (g *generator) defineField(n *node, f field) (err error) {
+// defineField generates the accessors for the field f of n as methods
+// of recv, which is n unless the accessors are flattened into the
+// struct that contains the group n.
+func (g *generator) defineField(recv, n *node, f field) (err error) {
 	defer func() {
 		if err != nil {
 			err = fmt.Errorf("field %s.%s: %v", n.shortDisplayName(), f.Name, err)
@@ -480,6 +544,7 @@ func (g *generator) defineField(n *node, f field) (err error) {
 	params := structFieldParams{
 		G:           g,
 		Node:        n,
+		Recv:        recv,
 		Field:       f,
 		Annotations: ann,
 		FieldType:   ftyp,
@@ -877,33 +942,60 @@ func (g *generator) defineStructEnums(n *node) error {
 }
 
 func (g *generator) defineStructFuncs(n *node) error {
-	err := renderStructFuncs(g.r, structFuncsParams{
+	params := structFuncsParams{
 		G:    g,
 		Node: n,
-	})
+	}
+	fields := g.fields(n)
+	if g.opts.presence {
+		params.Fields = fields
+	}
+	if n.StructNode().DiscriminantCount() > 0 {
+		var err error
+		params.ResetData, params.ResetPointers, err = g.unionSlots(n)
+		if err != nil {
+			return fmt.Errorf("struct funcs for %s: %v", n, err)
+		}
+	}
+	err := renderStructFuncs(g.r, params)
 	if err != nil {
 		return fmt.Errorf("struct funcs for %s: %v", n, err)
 	}
 
-	for _, f := range n.codeOrderFields() {
+	for i, f := range fields {
+		names := []string{f.Name}
+		if alias := g.conflictAlias(n, i, f); alias != "" {
+			names = append(names, alias)
+		}
 		switch f.Which() {
 		case schema.Field_Which_slot:
-			if err := g.defineField(n, f); err != nil {
-				return err
+			for _, name := range names {
+				f.Name = name
+				if err := g.defineField(n, n, f); err != nil {
+					return err
+				}
 			}
 		case schema.Field_Which_group:
 			grp, err := g.nodes.mustFind(f.Group().TypeId())
 			if err != nil {
 				return err
 			}
-			err = renderStructGroup(g.r, structGroupParams{
-				G:     g,
-				Node:  n,
-				Group: grp,
-				Field: f,
-			})
-			if err != nil {
-				return fmt.Errorf("struct group for %s: %v", grp, err)
+			for _, name := range names {
+				f.Name = name
+				err = renderStructGroup(g.r, structGroupParams{
+					G:     g,
+					Node:  n,
+					Group: grp,
+					Field: f,
+				})
+				if err != nil {
+					return fmt.Errorf("struct group for %s: %v", grp, err)
+				}
+			}
+			if g.opts.flattenGroups {
+				if err := g.defineFlatGroup(n, f.Name, grp); err != nil {
+					return err
+				}
 			}
 			if err := g.defineStructFuncs(grp); err != nil {
 				return err
@@ -913,6 +1005,274 @@ func (g *generator) defineStructFuncs(n *node) error {
 	return nil
 }
 
+// defineFlatGroup generates accessors on n for the fields of the group
+// grp, named with prefix, which is the path of field names from n to
+// grp.  The accessors behave the same as getting the group and then
+// calling its accessor.
+func (g *generator) defineFlatGroup(n *node, prefix string, grp *node) error {
+	reserved := g.reservedNames(n)
+	for _, f := range g.fields(n) {
+		for _, m := range accessorNames(f.Name) {
+			reserved[m] = true
+		}
+	}
+	for _, f := range g.fields(grp) {
+		f.Name = prefix + strings.Title(f.Name)
+		if accessorConflicts(f.Name, reserved) {
+			return fmt.Errorf("flattened group field %s.%s conflicts with another method of %s (rename with $Go.name)", n.shortDisplayName(), f.Name, n.Name)
+		}
+		switch f.Which() {
+		case schema.Field_Which_slot:
+			if err := g.defineField(n, grp, f); err != nil {
+				return err
+			}
+		case schema.Field_Which_group:
+			sub, err := g.nodes.mustFind(f.Group().TypeId())
+			if err != nil {
+				return err
+			}
+			if err := g.defineFlatGroup(n, f.Name, sub); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fields returns the fields of the struct n in code order.  A field
+// whose accessors would conflict with a method or embedded field of
+// the generated type has -conflictsuffix appended to its name, as many
+// times as it takes to make the name unique.
+func (g *generator) fields(n *node) []field {
+	return g.renameConflicts(n, g.opts.conflictSuffix)
+}
+
+// conflictAlias returns the name that the i'th field of n would have
+// with the default conflict suffix, or the empty string if the field
+// does not need an alias (see -conflictaliases).
+func (g *generator) conflictAlias(n *node, i int, f field) string {
+	if !g.opts.conflictAliases || g.opts.conflictSuffix == defaultConflictSuffix {
+		return ""
+	}
+	alias := g.renameConflicts(n, defaultConflictSuffix)[i].Name
+	if alias == f.Name || alias == n.codeOrderFields()[i].Name {
+		return ""
+	}
+	return alias
+}
+
+func (g *generator) renameConflicts(n *node, suffix string) []field {
+	fields := n.codeOrderFields()
+	reserved := g.reservedNames(n)
+	taken := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		taken[f.Name] = true
+	}
+	for i := range fields {
+		name := fields[i].Name
+		if !accessorConflicts(name, reserved) {
+			continue
+		}
+		for accessorConflicts(name, reserved) || taken[name] {
+			name += suffix
+		}
+		taken[name] = true
+		fields[i].Name = name
+	}
+	return fields
+}
+
+// reservedNames returns the names of the methods and embedded fields of
+// the types generated for the struct n, other than field accessors.
+func (g *generator) reservedNames(n *node) map[string]bool {
+	reserved := map[string]bool{"Struct": true}
+	if n.StructNode().DiscriminantCount() > 0 {
+		reserved["Which"] = true
+		reserved["ResetUnion"] = true
+	}
+	if g.opts.presence {
+		reserved["MarkSet"] = true
+		reserved["IsSet"] = true
+		reserved["SetFields"] = true
+		reserved["ClearSetFields"] = true
+	}
+	if g.opts.promises {
+		reserved["Pipeline"] = true
+	}
+	if !n.StructNode().IsGroup() {
+		if g.opts.structStrings {
+			reserved["String"] = true
+		}
+		if g.opts.sqlMethods {
+			reserved["Value"] = true
+			reserved["Scan"] = true
+		}
+		if g.opts.logValuer {
+			reserved["LogValue"] = true
+		}
+		if g.opts.equalMethods {
+			reserved["Equal"] = true
+			reserved["Hash64"] = true
+		}
+		if g.opts.errorDetails {
+			reserved["ErrorDetail"] = true
+		}
+	}
+	return reserved
+}
+
+// isIdentSuffix reports whether s can be appended to a Go identifier.
+func isIdentSuffix(s string) bool {
+	for _, r := range s {
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// accessorConflicts reports whether any of the methods generated for a
+// field called name are in reserved.
+func accessorConflicts(name string, reserved map[string]bool) bool {
+	for _, m := range accessorNames(name) {
+		if reserved[m] {
+			return true
+		}
+	}
+	return false
+}
+
+// accessorNames returns the names of the methods that may be generated
+// for a field called name.
+func accessorNames(name string) []string {
+	t := strings.Title(name)
+	return []string{t, "Set" + t, "Has" + t, "New" + t, t + "Ptr", "Set" + t + "Ptr", t + "Bytes"}
+}
+
+// unionSlots returns the data slots and pointer indexes used by the
+// members of n's union, including every field of group members.
+func (g *generator) unionSlots(n *node) ([]dataSlot, []uint32, error) {
+	var us unionSlotSet
+	for _, f := range n.codeOrderFields() {
+		if !f.HasDiscriminant() {
+			continue
+		}
+		if err := us.addField(g, f.Field); err != nil {
+			return nil, nil, err
+		}
+	}
+	return us.data, us.ptrs, nil
+}
+
+type unionSlotSet struct {
+	data    []dataSlot
+	ptrs    []uint32
+	seen    map[dataSlot]bool
+	seenPtr map[uint32]bool
+}
+
+func (us *unionSlotSet) addField(g *generator, f schema.Field) error {
+	switch f.Which() {
+	case schema.Field_Which_slot:
+		t, err := f.Slot().Type()
+		if err != nil {
+			return err
+		}
+		off := f.Slot().Offset()
+		switch t.Which() {
+		case schema.Type_Which_void:
+		case schema.Type_Which_bool:
+			us.addData(dataSlot{Bits: 1, Offset: off})
+		case schema.Type_Which_int8, schema.Type_Which_int16, schema.Type_Which_int32, schema.Type_Which_int64,
+			schema.Type_Which_uint8, schema.Type_Which_uint16, schema.Type_Which_uint32, schema.Type_Which_uint64:
+			bits := intbits(t.Which())
+			us.addData(dataSlot{Bits: bits, Offset: off * uint32(bits/8)})
+		case schema.Type_Which_enum:
+			us.addData(dataSlot{Bits: 16, Offset: off * 2})
+		case schema.Type_Which_float32:
+			us.addData(dataSlot{Bits: 32, Offset: off * 4})
+		case schema.Type_Which_float64:
+			us.addData(dataSlot{Bits: 64, Offset: off * 8})
+		default:
+			if us.seenPtr == nil {
+				us.seenPtr = make(map[uint32]bool)
+			}
+			if !us.seenPtr[off] {
+				us.seenPtr[off] = true
+				us.ptrs = append(us.ptrs, off)
+			}
+		}
+	case schema.Field_Which_group:
+		grp, err := g.nodes.mustFind(f.Group().TypeId())
+		if err != nil {
+			return err
+		}
+		if grp.StructNode().DiscriminantCount() > 0 {
+			us.addData(dataSlot{Bits: 16, Offset: grp.StructNode().DiscriminantOffset() * 2})
+		}
+		for _, gf := range grp.codeOrderFields() {
+			if err := us.addField(g, gf.Field); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (us *unionSlotSet) addData(d dataSlot) {
+	if us.seen == nil {
+		us.seen = make(map[dataSlot]bool)
+	}
+	if !us.seen[d] {
+		us.seen[d] = true
+		us.data = append(us.data, d)
+	}
+}
+
+// Presence reports whether setters should record the fields that they
+// set (see capnp.MarkSet).
+func (g *generator) Presence() bool {
+	return g.opts.presence
+}
+
+// PtrReceivers reports whether struct methods should have pointer
+// receivers.
+func (g *generator) PtrReceivers() bool {
+	return g.opts.ptrReceivers
+}
+
+// Recv returns the receiver of a method on the struct n.
+func (g *generator) Recv(n *node) string {
+	if g.opts.ptrReceivers {
+		return "s *" + n.Name
+	}
+	return "s " + n.Name
+}
+
+// Self returns the expression for the capnp.Struct of a method's
+// receiver.  Pointer receivers go through a nil check.
+func (g *generator) Self() string {
+	if g.opts.ptrReceivers {
+		return "s.capnpStruct()"
+	}
+	return "s.Struct"
+}
+
+// LayoutHash returns the layout hash of the struct n as a Go literal.
+func (g *generator) LayoutHash(n *node) (string, error) {
+	h, err := layouthash.Hash(n.Node, func(id uint64) (schema.Node, error) {
+		n, err := g.nodes.mustFind(id)
+		if err != nil {
+			return schema.Node{}, err
+		}
+		return n.Node, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%#x", h), nil
+}
+
 func (g *generator) ObjectSize(n *node) (string, error) {
 	if n.Which() != schema.Node_Which_structNode {
 		return "", fmt.Errorf("object size called for %v node", n.Which())
@@ -924,10 +1284,30 @@ func (g *generator) ObjectSize(n *node) (string, error) {
 }
 
 func (g *generator) defineBaseStructFuncs(n *node) error {
+	if g.opts.fixtures {
+		for _, m := range g.nodes[g.fileID].nodes {
+			if "Sample"+m.Name == n.Name {
+				return fmt.Errorf("base struct functions for %s: New%s conflicts with NewSample%s (rename with $Go.name)", n, n.Name, m.Name)
+			}
+		}
+	}
+	if g.opts.errorDetails {
+		for _, m := range g.nodes[g.fileID].nodes {
+			if m.Name == n.Name+"FromError" {
+				return fmt.Errorf("base struct functions for %s: %sFromError conflicts with %s (rename with $Go.name)", n, n.Name, m)
+			}
+		}
+	}
 	err := renderBaseStructFuncs(g.r, baseStructFuncsParams{
 		G:            g,
 		Node:         n,
 		StringMethod: g.opts.structStrings,
+		SQLMethods:   g.opts.sqlMethods,
+		LogValuer:    g.opts.logValuer,
+		EqualMethods: g.opts.equalMethods,
+		Fixtures:     g.opts.fixtures,
+		ErrorDetails: g.opts.errorDetails,
+		LayoutHashes: g.opts.layoutHashes,
 	})
 	if err != nil {
 		return fmt.Errorf("base struct functions for %s: %v", n, err)
@@ -936,6 +1316,11 @@ func (g *generator) defineBaseStructFuncs(n *node) error {
 }
 
 func (g *generator) defineStructList(n *node) error {
+	for _, m := range g.nodes[g.fileID].nodes {
+		if m.Name == n.Name+"ListMessage" {
+			return fmt.Errorf("struct list for %s: NewRoot%sListMessage conflicts with the constructor for %s (rename with $Go.name)", n, n.Name, m)
+		}
+	}
 	err := renderStructList(g.r, structListParams{
 		G:            g,
 		Node:         n,
@@ -948,16 +1333,17 @@ func (g *generator) defineStructList(n *node) error {
 }
 
 func (g *generator) defineStructPromise(n *node) error {
+	fields := g.fields(n)
 	err := renderPromise(g.r, promiseParams{
 		G:      g,
 		Node:   n,
-		Fields: n.codeOrderFields(),
+		Fields: fields,
 	})
 	if err != nil {
 		return fmt.Errorf("promise for struct %s: %v", n, err)
 	}
 
-	for _, f := range n.codeOrderFields() {
+	for _, f := range fields {
 		switch f.Which() {
 		case schema.Field_Which_slot:
 			t, _ := f.Slot().Type()
@@ -1054,6 +1440,7 @@ func (g *generator) defineInterface(n *node) error {
 		Node:        n,
 		Annotations: parseAnnotations(nann),
 		Methods:     m,
+		Table:       methodGroups(m),
 	})
 	if err != nil {
 		return fmt.Errorf("interface server %s: %v", n, err)
@@ -1121,14 +1508,32 @@ func (g *generator) defineFile() error {
 	return nil
 }
 
-func generateFile(reqf schema.CodeGeneratorRequest_RequestedFile, nodes nodeMap, opts genoptions) error {
+func generateFile(reqf schema.CodeGeneratorRequest_RequestedFile, nodes nodeMap, opts genoptions, cache *outputCache) error {
 	if opts.structStrings && !opts.schemas {
 		return errors.New("cannot generate struct String() methods without embedding schemas")
 	}
+	if opts.logValuer && !opts.schemas {
+		return errors.New("cannot generate struct LogValue() methods without embedding schemas")
+	}
+	if opts.fixtures && !opts.schemas {
+		return errors.New("cannot generate sample struct functions without embedding schemas")
+	}
+	if !isIdentSuffix(opts.conflictSuffix) {
+		return fmt.Errorf("conflict suffix %q is not a valid part of a Go identifier", opts.conflictSuffix)
+	}
 	id := reqf.Id()
 	fname, _ := reqf.Filename()
 	g := newGenerator(id, nodes, opts)
-	if err := g.defineFile(); err != nil {
+	var src []byte
+	if opts.sourceLines {
+		// capnp runs plugins in the directory that it was run in, where
+		// the schema's file name is valid.
+		if b, err := ioutil.ReadFile(fname); err == nil {
+			src = b
+			g.source = newSourceMap(fname, src)
+		}
+	}
+	if err := g.reportDiagnostics(os.Stderr); err != nil {
 		return err
 	}
 
@@ -1139,6 +1544,25 @@ func generateFile(reqf schema.CodeGeneratorRequest_RequestedFile, nodes nodeMap,
 		}
 	}
 
+	var key string
+	if cache != nil {
+		var args []string
+		if opts.goGenerate {
+			args = os.Args[1:]
+		}
+		var err error
+		key, err = cache.key(reqf, nodes, opts, args, src)
+		if err != nil {
+			return err
+		}
+		if out, ok := cache.get(key); ok {
+			return writeIfChanged(fname+".go", out)
+		}
+	}
+
+	if err := g.defineFile(); err != nil {
+		return err
+	}
 	unformatted := g.generate()
 	formatted, fmtErr := format.Source(unformatted)
 	if fmtErr != nil {
@@ -1160,6 +1584,11 @@ func generateFile(reqf schema.CodeGeneratorRequest_RequestedFile, nodes nodeMap,
 	if cerr != nil {
 		return err
 	}
+	if cache != nil {
+		if err := cache.put(key, formatted); err != nil {
+			return fmt.Errorf("caching output: %v", err)
+		}
+	}
 	return nil
 }
 
@@ -1168,8 +1597,35 @@ func main() {
 	flag.BoolVar(&opts.promises, "promises", true, "generate code for promises")
 	flag.BoolVar(&opts.schemas, "schemas", true, "embed schema information in generated code")
 	flag.BoolVar(&opts.structStrings, "structstrings", true, "generate String() methods for structs (-schemas must be true)")
+	flag.BoolVar(&opts.sqlMethods, "sqlvaluer", false, "generate driver.Valuer and sql.Scanner methods for structs")
+	flag.BoolVar(&opts.logValuer, "logvaluer", false, "generate slog.LogValuer methods for structs, which need Go 1.21 (-schemas must be true)")
+	flag.BoolVar(&opts.equalMethods, "equalmethods", true, "generate Equal and Hash64 methods for structs")
+	flag.StringVar(&opts.conflictSuffix, "conflictsuffix", defaultConflictSuffix, "suffix appended to a field name whose accessors conflict with a generated method, such as Value to turn a string field's String_ into StringValue")
+	flag.BoolVar(&opts.conflictAliases, "conflictaliases", false, "when -conflictsuffix is not _, also generate the accessors with the names that _ would give them")
+	flag.BoolVar(&opts.flattenGroups, "flattengroups", false, "generate accessors for the fields of groups on the struct that contains them, such as PositionX for position.x")
+	flag.BoolVar(&opts.fixtures, "fixtures", false, "generate NewSample functions that create structs filled with deterministic sample data (-schemas must be true)")
+	flag.BoolVar(&opts.errorDetails, "errordetails", false, "generate ErrorDetail methods and FromError functions for attaching structs to errors as details and extracting them")
+	flag.BoolVar(&opts.layoutHashes, "layouthash", false, "generate _LayoutHash constants for structs and register them for capnp.CheckLayout")
+	flag.BoolVar(&opts.strict, "strict", false, "fail instead of warning on schema constructs that the generated code does not fully represent, such as generics")
+	flag.BoolVar(&opts.presence, "presence", false, "generate methods that track which fields of a struct have been set")
+	flag.BoolVar(&opts.goGenerate, "gogenerate", false, "write the schema file, the capnpc-go command line, and a //go:generate directive that recompiles the schema at the top of each file")
+	flag.StringVar(&opts.capnpFlags, "capnpflags", "", "flags for capnp compile in the -gogenerate directive, such as -I include paths")
+	flag.BoolVar(&opts.ptrReceivers, "ptrreceivers", false, "generate struct methods on nil-safe pointer receivers")
+	flag.BoolVar(&opts.lazyConsts, "lazyconsts", false, "generate struct, list, and AnyPointer constants as functions that unmarshal the value on first use instead of package variables")
+	flag.BoolVar(&opts.sourceLines, "sourcelines", false, "comment each declaration with its file:line in the schema")
+	cacheDir := flag.String("cache", "", "directory in which to cache generated files by a hash of their schema nodes and options; files whose inputs are unchanged are not regenerated")
 	flag.Parse()
 
+	var cache *outputCache
+	if *cacheDir != "" {
+		var err error
+		cache, err = openOutputCache(*cacheDir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "capnpc-go: opening cache:", err)
+			os.Exit(1)
+		}
+	}
+
 	msg, err := capnp.NewDecoder(os.Stdin).Decode()
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "capnpc-go: reading input:", err)
@@ -1189,7 +1645,7 @@ func main() {
 	reqFiles, _ := req.RequestedFiles()
 	for i := 0; i < reqFiles.Len(); i++ {
 		reqf := reqFiles.At(i)
-		err := generateFile(reqf, nodes, opts)
+		err := generateFile(reqf, nodes, opts, cache)
 		if err != nil {
 			fname, _ := reqf.Filename()
 			fmt.Fprintf(os.Stderr, "capnpc-go: generating %s: %v\n", fname, err)
@@ -7,6 +7,7 @@ import (
 	"go/token"
 	"io/ioutil"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
@@ -336,7 +337,85 @@ func TestDefineFile(t *testing.T) {
 			schemas:       true,
 			structStrings: true,
 		}},
+		{0x832bcc6686a26d56, "aircraft.capnp.out", genoptions{
+			promises:      true,
+			schemas:       true,
+			structStrings: true,
+			sqlMethods:    true,
+			logValuer:     true,
+		}},
+		{0x832bcc6686a26d56, "aircraft.capnp.out", genoptions{
+			promises: true,
+			schemas:  true,
+			presence: true,
+		}},
+		{0x83c2b5818e83ab19, "group.capnp.out", genoptions{
+			promises: true,
+			schemas:  true,
+			presence: true,
+		}},
+		{0x832bcc6686a26d56, "aircraft.capnp.out", genoptions{
+			promises:      true,
+			schemas:       true,
+			structStrings: true,
+			ptrReceivers:  true,
+		}},
+		{0x83c2b5818e83ab19, "group.capnp.out", genoptions{
+			promises:     true,
+			schemas:      true,
+			ptrReceivers: true,
+		}},
+		{0x832bcc6686a26d56, "aircraft.capnp.out", genoptions{
+			promises:      true,
+			schemas:       true,
+			structStrings: true,
+			equalMethods:  true,
+		}},
 		{0x83c2b5818e83ab19, "group.capnp.out", defaultOptions},
+		{0x83c2b5818e83ab19, "group.capnp.out", genoptions{
+			promises:      true,
+			schemas:       true,
+			flattenGroups: true,
+			presence:      true,
+		}},
+		{0x832bcc6686a26d56, "aircraft.capnp.out", genoptions{
+			promises:      true,
+			schemas:       true,
+			structStrings: true,
+			flattenGroups: true,
+		}},
+		{0x832bcc6686a26d56, "aircraft.capnp.out", genoptions{
+			promises: true,
+			schemas:  true,
+			fixtures: true,
+		}},
+		{0x832bcc6686a26d56, "aircraft.capnp.out", genoptions{
+			promises:   true,
+			schemas:    true,
+			lazyConsts: true,
+		}},
+		{0x832bcc6686a26d56, "aircraft.capnp.out", genoptions{
+			promises:     true,
+			schemas:      true,
+			errorDetails: true,
+		}},
+		{0x83c2b5818e83ab19, "group.capnp.out", genoptions{
+			promises:     true,
+			schemas:      true,
+			errorDetails: true,
+			ptrReceivers: true,
+		}},
+		{0x832bcc6686a26d56, "aircraft.capnp.out", genoptions{
+			promises:     true,
+			schemas:      true,
+			layoutHashes: true,
+		}},
+		{0xb312981b2552a250, "rpc.capnp.out", genoptions{
+			promises:     true,
+			schemas:      true,
+			equalMethods: true,
+			ptrReceivers: true,
+		}},
 		{0xb312981b2552a250, "rpc.capnp.out", defaultOptions},
 		{0xd68755941d99d05e, "scopes.capnp.out", defaultOptions},
 		{0xecd50d792c3d9992, "util.capnp.out", defaultOptions},
@@ -388,6 +467,218 @@ func TestDefineFile(t *testing.T) {
 	}
 }
 
+func TestUnionSlots(t *testing.T) {
+	req := mustReadGeneratorRequest(t, "aircraft.capnp.out")
+	nodes, err := buildNodeMap(req)
+	if err != nil {
+		t.Fatal("buildNodeMap:", err)
+	}
+	g := newGenerator(0x832bcc6686a26d56, nodes, genoptions{})
+	z, err := nodes.mustFind(0xea26e9973bd6a0d9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, ptrs, err := g.unionSlots(z)
+	if err != nil {
+		t.Fatal("unionSlots:", err)
+	}
+	wantData := []dataSlot{{64, 8}, {32, 8}, {16, 8}, {8, 8}, {1, 64}}
+	if fmt.Sprint(data) != fmt.Sprint(wantData) {
+		t.Errorf("data slots = %v; want %v", data, wantData)
+	}
+	if fmt.Sprint(ptrs) != "[0]" {
+		t.Errorf("pointers = %v; want [0]", ptrs)
+	}
+}
+
+func TestRenameConflicts(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sn, err := schema.NewRootNode(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sn.SetId(0x9e5d6e5c6ef24d4f)
+	sn.SetStructNode()
+	names := []string{"num", "string", "struct", "stringValue", "fields"}
+	fl, err := sn.StructNode().NewFields(int32(len(names)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, name := range names {
+		f := fl.At(i)
+		f.SetName(name)
+		f.SetCodeOrder(uint16(i))
+		f.SetDiscriminantValue(schema.Field_noDiscriminant)
+		f.SetSlot()
+	}
+	n := &node{Node: sn, Name: "Foo"}
+
+	tests := []struct {
+		opts    genoptions
+		names   []string
+		aliases []string
+	}{
+		{
+			opts:    genoptions{structStrings: true},
+			names:   []string{"num", "string_", "struct_", "stringValue", "fields"},
+			aliases: []string{"", "", "", "", ""},
+		},
+		{
+			opts:    genoptions{structStrings: true, presence: true, conflictSuffix: "Value", conflictAliases: true},
+			names:   []string{"num", "stringValueValue", "structValue", "stringValue", "fieldsValue"},
+			aliases: []string{"", "string_", "struct_", "", "fields_"},
+		},
+		{
+			opts:    genoptions{conflictSuffix: "Value"},
+			names:   []string{"num", "string", "structValue", "stringValue", "fields"},
+			aliases: []string{"", "", "", "", ""},
+		},
+	}
+	for _, test := range tests {
+		g := newGenerator(0, nodeMap{}, test.opts)
+		fields := g.fields(n)
+		for i, f := range fields {
+			if f.Name != test.names[i] {
+				t.Errorf("%+v: field %d name = %q; want %q", test.opts, i, f.Name, test.names[i])
+			}
+			if alias := g.conflictAlias(n, i, f); alias != test.aliases[i] {
+				t.Errorf("%+v: field %d alias = %q; want %q", test.opts, i, alias, test.aliases[i])
+			}
+		}
+	}
+}
+
+func TestLayoutHash(t *testing.T) {
+	req := mustReadGeneratorRequest(t, "aircraft.capnp.out")
+	nodes, err := buildNodeMap(req)
+	if err != nil {
+		t.Fatal("buildNodeMap:", err)
+	}
+	g := newGenerator(0x832bcc6686a26d56, nodes, genoptions{})
+	seen := make(map[string]uint64)
+	for id, n := range nodes {
+		if n.Which() != schema.Node_Which_structNode || n.StructNode().IsGroup() {
+			continue
+		}
+		h, err := g.LayoutHash(n)
+		if err != nil {
+			t.Errorf("LayoutHash(%v): %v", n, err)
+			continue
+		}
+		if h2, _ := g.LayoutHash(n); h2 != h {
+			t.Errorf("LayoutHash(%v) = %s, then %s", n, h, h2)
+		}
+		if other, dup := seen[h]; dup {
+			t.Errorf("LayoutHash(%v) = LayoutHash(%#x) = %s", n, other, h)
+		}
+		seen[h] = id
+	}
+	if len(seen) == 0 {
+		t.Error("no structs in aircraft.capnp.out")
+	}
+}
+
+func TestLayoutHashOption(t *testing.T) {
+	req := mustReadGeneratorRequest(t, "aircraft.capnp.out")
+	nodes, err := buildNodeMap(req)
+	if err != nil {
+		t.Fatal("buildNodeMap:", err)
+	}
+	for _, layoutHashes := range []bool{false, true} {
+		g := newGenerator(0x832bcc6686a26d56, nodes, genoptions{layoutHashes: layoutHashes})
+		if err := g.defineFile(); err != nil {
+			t.Errorf("defineFile with layoutHashes=%t: %v", layoutHashes, err)
+			continue
+		}
+		src := g.generate()
+		if got := bytes.Contains(src, []byte("RegisterLayout(")); got != layoutHashes {
+			t.Errorf("with layoutHashes=%t, output calls RegisterLayout = %t", layoutHashes, got)
+		}
+	}
+}
+
+func TestIdempotentMethod(t *testing.T) {
+	req := mustReadGeneratorRequest(t, "aircraft.capnp.out")
+	nodes, err := buildNodeMap(req)
+	if err != nil {
+		t.Fatal("buildNodeMap:", err)
+	}
+	echo, err := nodes.mustFind(0x8e5322c1e9282534)
+	if err != nil {
+		t.Fatal(err)
+	}
+	methods, _ := echo.Interface().Methods()
+	anns, err := methods.At(0).NewAnnotations(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	anns.At(0).SetId(capnp.Idempotent)
+	if v, err := anns.At(0).NewValue(); err != nil {
+		t.Fatal(err)
+	} else {
+		v.SetVoid()
+	}
+
+	ms, err := methodSet(nil, echo, nodes)
+	if err != nil {
+		t.Fatal("methodSet:", err)
+	}
+	if len(ms) != 1 || !ms[0].Idempotent {
+		t.Fatalf("methodSet(Echo) = %+v; want one idempotent method", ms)
+	}
+	g := newGenerator(0x832bcc6686a26d56, nodes, genoptions{promises: true, schemas: true})
+	if err := g.defineFile(); err != nil {
+		t.Fatal("defineFile:", err)
+	}
+	src := g.generate()
+	// Once in the client and once in the server's method table.
+	if n := bytes.Count(src, []byte("Idempotent: true")); n != 2 {
+		t.Errorf("generated code sets Idempotent %d times; want 2", n)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "aircraft.capnp.go", src, 0); err != nil {
+		t.Errorf("generated code failed to parse: %v", err)
+	}
+}
+
+func TestMethodGroups(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	iface := func(id uint64) *node {
+		sn, err := schema.NewNode(seg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sn.SetId(id)
+		sn.SetInterface()
+		return &node{Node: sn}
+	}
+	// The method set of A extends(B, C) where B extends(C): C is
+	// inherited twice, back to back.
+	a, b, c := iface(3), iface(1), iface(2)
+	var methods []interfaceMethod
+	for _, m := range []struct {
+		n  *node
+		id int
+	}{{a, 0}, {a, 1}, {b, 0}, {c, 0}, {c, 1}, {c, 0}, {c, 1}} {
+		methods = append(methods, interfaceMethod{Interface: m.n, ID: m.id})
+	}
+
+	got := methodGroups(methods)
+	want := []interfaceGroup{
+		{ID: 1, Start: 2, End: 3},
+		{ID: 2, Start: 3, End: 5},
+		{ID: 3, Start: 0, End: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("methodGroups(...) = %+v; want %+v", got, want)
+	}
+}
+
 func TestSchemaVarLiteral(t *testing.T) {
 	tests := []string{
 		"",
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+)
+
+// streamResultID is the ID of the StreamResult struct from stream.capnp,
+// the result type of methods declared with "-> stream".
+const streamResultID = 0x995f9a3377c0b16e
+
+// A diagnostic describes a schema construct that the generated code
+// does not fully represent.
+type diagnostic struct {
+	id    uint64
+	name  string // display name of the node, plus a member if any
+	pos   string // file:line, if known
+	msg   string
+	fatal bool // the generated code would be wrong, not just incomplete
+}
+
+func (d diagnostic) Error() string {
+	where := d.name
+	if d.pos != "" {
+		where = d.pos + ": " + d.name
+	}
+	return fmt.Sprintf("%s (%#x): %s", where, d.id, d.msg)
+}
+
+// diagnose returns the diagnostics for the nodes in the file being
+// generated, in the order the nodes appear in the request.
+func (g *generator) diagnose() []diagnostic {
+	var diags []diagnostic
+	report := func(n *node, member string, fatal bool, format string, args ...interface{}) {
+		d := diagnostic{
+			id:    n.Id(),
+			name:  n.String(),
+			pos:   g.SourcePos(n),
+			msg:   fmt.Sprintf(format, args...),
+			fatal: fatal,
+		}
+		if member != "" {
+			d.name += "." + member
+		}
+		diags = append(diags, d)
+	}
+	for _, n := range g.nodes[g.fileID].nodes {
+		// Nodes nested in a generic node are generic too, but only
+		// report the node that declares the parameters.
+		if ps, _ := n.Parameters(); ps.Len() > 0 {
+			report(n, "", false, "generic parameters are not supported; fields of a parameter type are generated as capnp.Ptr")
+		}
+		switch n.Which() {
+		case schema.Node_Which_structNode:
+			fields, _ := n.StructNode().Fields()
+			for i := 0; i < fields.Len(); i++ {
+				f := fields.At(i)
+				name, _ := f.Name()
+				anns, _ := f.Annotations()
+				for j := 0; j < anns.Len(); j++ {
+					switch anns.At(j).Id() {
+					case capnp.Customtype:
+						report(n, name, false, "$Go.customtype is obsolete and ignored")
+					case capnp.Doc:
+						report(n, name, false, "$Go.doc is ignored on fields; put the comment in the schema instead")
+					}
+				}
+				if f.Which() != schema.Field_Which_slot {
+					continue
+				}
+				if t, err := f.Slot().Type(); err == nil {
+					if msg := brandMessage(t); msg != "" {
+						report(n, name, false, "%s", msg)
+					}
+				}
+			}
+		case schema.Node_Which_interface:
+			methods, _ := n.Interface().Methods()
+			for i := 0; i < methods.Len(); i++ {
+				m := methods.At(i)
+				name, _ := m.Name()
+				if m.ResultStructType() == streamResultID {
+					report(n, name, true, "streaming methods (-> stream) are not supported; declare a result struct instead")
+				}
+				if ps, _ := m.ImplicitParameters(); ps.Len() > 0 {
+					report(n, name, false, "generic methods are not supported; parameters of an implicit type are generated as capnp.Ptr")
+				}
+				if b, _ := m.ParamBrand(); hasBindings(b) {
+					report(n, name, false, "type arguments to the parameter struct are ignored")
+				}
+				if b, _ := m.ResultBrand(); hasBindings(b) {
+					report(n, name, false, "type arguments to the result struct are ignored")
+				}
+			}
+		}
+	}
+	return diags
+}
+
+// brandMessage returns a diagnostic message if t refers to a generic
+// type with type arguments, which the generated code ignores.
+func brandMessage(t schema.Type) string {
+	var b schema.Brand
+	switch t.Which() {
+	case schema.Type_Which_structType:
+		b, _ = t.StructType().Brand()
+	case schema.Type_Which_interface:
+		b, _ = t.Interface().Brand()
+	case schema.Type_Which_list:
+		et, _ := t.List().ElementType()
+		return brandMessage(et)
+	default:
+		return ""
+	}
+	if !hasBindings(b) {
+		return ""
+	}
+	return "type arguments are ignored; the field is generated with the unparameterized type"
+}
+
+// hasBindings reports whether b binds any type parameters.
+func hasBindings(b schema.Brand) bool {
+	scopes, _ := b.Scopes()
+	for i := 0; i < scopes.Len(); i++ {
+		if s := scopes.At(i); s.Which() == schema.Brand_Scope_Which_bind {
+			if bind, _ := s.Bind(); bind.Len() > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// reportDiagnostics writes the file's diagnostics to w.  It returns an
+// error if any of them are fatal, or if there are any with -strict.
+func (g *generator) reportDiagnostics(w io.Writer) error {
+	nerrs := 0
+	for _, d := range g.diagnose() {
+		if d.fatal || g.opts.strict {
+			fmt.Fprintln(w, "capnpc-go: error:", d)
+			nerrs++
+		} else {
+			fmt.Fprintln(w, "capnpc-go: warning:", d)
+		}
+	}
+	if nerrs > 0 {
+		return fmt.Errorf("unsupported schema constructs (%d)", nerrs)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2/internal/schema"
+)
+
+func TestDiagnose(t *testing.T) {
+	req := mustReadGeneratorRequest(t, "util.capnp.out")
+	nodes, err := buildNodeMap(req)
+	if err != nil {
+		t.Fatal("buildNodeMap:", err)
+	}
+	files, _ := req.RequestedFiles()
+	fileID := files.At(0).Id()
+
+	g := newGenerator(fileID, nodes, genoptions{})
+	diags := g.diagnose()
+	if len(diags) != 1 {
+		t.Fatalf("diagnose() = %v; want 1 diagnostic", diags)
+	}
+	if d := diags[0]; d.fatal || d.id != 0xeaf255b498229199 || !strings.Contains(d.Error(), "util.capnp:Assignable (0xeaf255b498229199): generic") {
+		t.Errorf("diagnostic = %q (fatal = %t); want warning about generic Assignable", d.Error(), d.fatal)
+	}
+	var buf bytes.Buffer
+	if err := g.reportDiagnostics(&buf); err != nil {
+		t.Errorf("reportDiagnostics: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "capnpc-go: warning: ") {
+		t.Errorf("reportDiagnostics wrote %q; want a warning", buf.String())
+	}
+
+	g = newGenerator(fileID, nodes, genoptions{strict: true})
+	buf.Reset()
+	if err := g.reportDiagnostics(&buf); err == nil {
+		t.Error("reportDiagnostics with -strict did not return an error")
+	}
+	if !strings.HasPrefix(buf.String(), "capnpc-go: error: ") {
+		t.Errorf("reportDiagnostics with -strict wrote %q; want an error", buf.String())
+	}
+}
+
+func TestDiagnoseStream(t *testing.T) {
+	req := mustReadGeneratorRequest(t, "util.capnp.out")
+	nodes, err := buildNodeMap(req)
+	if err != nil {
+		t.Fatal("buildNodeMap:", err)
+	}
+	files, _ := req.RequestedFiles()
+	fileID := files.At(0).Id()
+	var iface *node
+	for _, n := range nodes[fileID].nodes {
+		if n.Which() == schema.Node_Which_interface && n.Name == "ByteStream" {
+			iface = n
+		}
+	}
+	if iface == nil {
+		t.Fatal("ByteStream not found")
+	}
+	methods, _ := iface.Interface().Methods()
+	methods.At(0).SetResultStructType(streamResultID)
+
+	g := newGenerator(fileID, nodes, genoptions{})
+	var buf bytes.Buffer
+	if err := g.reportDiagnostics(&buf); err == nil {
+		t.Error("reportDiagnostics did not return an error for a streaming method")
+	}
+	if !strings.Contains(buf.String(), "capnpc-go: error: util.capnp:ByteStream.") || !strings.Contains(buf.String(), "-> stream") {
+		t.Errorf("reportDiagnostics wrote %q; want an error about ByteStream's streaming method", buf.String())
+	}
+}
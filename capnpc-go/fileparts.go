@@ -68,9 +68,15 @@ func (i *imports) init() {
 	i.reserve(importSpec{path: serverImport, name: "server"})
 	i.reserve(importSpec{path: textImport, name: "text"})
 	i.reserve(importSpec{path: contextImport, name: "context"})
+	i.reserve(importSpec{path: sqlImport, name: "capnpsql"})
+	i.reserve(importSpec{path: "database/sql/driver", name: "driver"})
+	i.reserve(importSpec{path: slogImport, name: "capnpslog"})
+	i.reserve(importSpec{path: "log/slog", name: "slog"})
+	i.reserve(importSpec{path: fixtureImport, name: "capnpfixture"})
 
 	i.reserve(importSpec{path: "math", name: "math"})
 	i.reserve(importSpec{path: "strconv", name: "strconv"})
+	i.reserve(importSpec{path: "sync", name: "sync"})
 }
 
 func (i *imports) Capnp() string {
@@ -93,6 +99,26 @@ func (i *imports) Context() string {
 	return i.add(importSpec{path: contextImport, name: "context"})
 }
 
+func (i *imports) CapnpSQL() string {
+	return i.add(importSpec{path: sqlImport, name: "capnpsql"})
+}
+
+func (i *imports) Driver() string {
+	return i.add(importSpec{path: "database/sql/driver", name: "driver"})
+}
+
+func (i *imports) CapnpSlog() string {
+	return i.add(importSpec{path: slogImport, name: "capnpslog"})
+}
+
+func (i *imports) Slog() string {
+	return i.add(importSpec{path: "log/slog", name: "slog"})
+}
+
+func (i *imports) CapnpFixture() string {
+	return i.add(importSpec{path: fixtureImport, name: "capnpfixture"})
+}
+
 func (i *imports) Math() string {
 	return i.add(importSpec{path: "math", name: "math"})
 }
@@ -101,6 +127,10 @@ func (i *imports) Strconv() string {
 	return i.add(importSpec{path: "strconv", name: "strconv"})
 }
 
+func (i *imports) Sync() string {
+	return i.add(importSpec{path: "sync", name: "sync"})
+}
+
 func (i *imports) usedImports() []importSpec {
 	specs := make([]importSpec, 0, len(i.specs))
 	for _, s := range i.specs {
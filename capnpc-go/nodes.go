@@ -3,6 +3,7 @@ package main
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/iguazio/go-capnproto2"
@@ -96,6 +97,7 @@ type interfaceMethod struct {
 	ID           int
 	Name         string
 	OriginalName string
+	Idempotent   bool
 	Params       *node
 	Results      *node
 }
@@ -114,12 +116,14 @@ func methodSet(methods []interfaceMethod, n *node, nodes nodeMap) ([]interfaceMe
 		if err != nil {
 			return methods, fmt.Errorf("could not find result type for %s.%s", n.shortDisplayName(), mname)
 		}
+		ann := parseAnnotations(mann)
 		methods = append(methods, interfaceMethod{
 			Method:       m,
 			Interface:    n,
 			ID:           i,
 			OriginalName: mname,
-			Name:         parseAnnotations(mann).Rename(mname),
+			Idempotent:   ann.Idempotent,
+			Name:         ann.Rename(mname),
 			Params:       pn,
 			Results:      rn,
 		})
@@ -141,6 +145,37 @@ func methodSet(methods []interfaceMethod, n *node, nodes nodeMap) ([]interfaceMe
 	return methods, nil
 }
 
+// An interfaceGroup is the span of a method set that holds the methods
+// of one interface, in method ID order.
+type interfaceGroup struct {
+	ID         uint64
+	Start, End int
+}
+
+// methodGroups returns the spans of the interfaces in a method set built
+// by methodSet, sorted by interface ID.  An interface that is inherited
+// more than once is only listed for its first span.
+func methodGroups(methods []interfaceMethod) []interfaceGroup {
+	var groups []interfaceGroup
+	seen := make(map[uint64]bool)
+	for i := 0; i < len(methods); {
+		id := methods[i].Interface.Id()
+		j := i + 1
+		for j < len(methods) && methods[j].Interface.Id() == id && methods[j].ID > methods[j-1].ID {
+			j++
+		}
+		if !seen[id] {
+			seen[id] = true
+			groups = append(groups, interfaceGroup{ID: id, Start: i, End: j})
+		}
+		i = j
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].ID < groups[j].ID
+	})
+	return groups
+}
+
 // Tag types
 const (
 	defaultTag = iota
@@ -155,6 +190,9 @@ type annotations struct {
 	TagType   int
 	CustomTag string
 	Name      string
+
+	// Idempotent is set by $Go.idempotent on methods.
+	Idempotent bool
 }
 
 func parseAnnotations(list schema.Annotation_List) *annotations {
@@ -177,6 +215,8 @@ func parseAnnotations(list schema.Annotation_List) *annotations {
 			ann.TagType = noTag
 		case capnp.Name:
 			ann.Name = text
+		case capnp.Idempotent:
+			ann.Idempotent = true
 		}
 	}
 	return ann
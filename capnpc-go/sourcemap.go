@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// A sourceMap finds the lines that declarations are on in a schema
+// file.  The code generator request does not include source positions,
+// so they are found by searching the file for each part of a node's
+// display name in turn.
+type sourceMap struct {
+	name  string // file name, relative to the generated file
+	src   []byte
+	depth []int // brace nesting depth at each byte of src
+}
+
+func newSourceMap(fname string, src []byte) *sourceMap {
+	sm := &sourceMap{name: filepath.Base(fname), src: src, depth: make([]int, len(src))}
+	d := 0
+	inComment, inString := false, false
+	for i, c := range src {
+		switch {
+		case inComment:
+			inComment = c != '\n'
+		case inString:
+			inString = c != '"'
+		case c == '#':
+			inComment = true
+		case c == '"':
+			inString = true
+		case c == '{':
+			d++
+		case c == '}':
+			d--
+		}
+		sm.depth[i] = d
+	}
+	return sm
+}
+
+// pos returns the "file:line" of the declaration with the given display
+// name (e.g. "foo.capnp:Outer.Inner"), or "" if it can't be found.
+func (sm *sourceMap) pos(displayName string) string {
+	i := strings.IndexByte(displayName, ':')
+	if sm == nil || i == -1 || i+1 == len(displayName) {
+		return ""
+	}
+	// Each part of the name is declared at the next nesting depth, in the
+	// body of the declaration of the part before it.
+	off := 0
+	for depth, name := range strings.Split(displayName[i+1:], ".") {
+		var ok bool
+		off, ok = sm.find(declPattern(name), off, depth)
+		if !ok {
+			return ""
+		}
+	}
+	line := bytes.Count(sm.src[:off], []byte("\n")) + 1
+	return fmt.Sprintf("%s:%d", sm.name, line)
+}
+
+// find returns the offset of the first match of re after off that is
+// at the given nesting depth, without leaving the scope that off is in.
+func (sm *sourceMap) find(re *regexp.Regexp, off, depth int) (int, bool) {
+	end := len(sm.src)
+	for i := off + 1; i < len(sm.src); i++ {
+		if sm.depth[i] < depth && sm.depth[i-1] >= depth {
+			end = i
+			break
+		}
+	}
+	for _, loc := range re.FindAllIndex(sm.src[off:end], -1) {
+		if start := off + loc[0]; sm.depth[start] == depth {
+			return start, true
+		}
+	}
+	return 0, false
+}
+
+// declPattern matches a declaration of a struct, enum, interface,
+// constant, or annotation, or a group or union field with the given name.
+func declPattern(name string) *regexp.Regexp {
+	q := regexp.QuoteMeta(name)
+	return regexp.MustCompile(`(?m)\b(?:(?:struct|enum|interface|const|annotation)\s+` + q + `\b|` + q + `\s*(?:@\d+\s*)?:\s*(?:group|union)\b)`)
+}
+
+// SourcePos returns the "file:line" of n's declaration if the
+// -sourcelines flag is set and the position could be found, or "".
+func (g *generator) SourcePos(n *node) string {
+	if g.source == nil {
+		return ""
+	}
+	dn, err := n.DisplayName()
+	if err != nil {
+		return ""
+	}
+	return g.source.pos(dn)
+}
@@ -0,0 +1,51 @@
+package main
+
+import "testing"
+
+func TestSourceMap(t *testing.T) {
+	src := []byte(`@0xd4c3b2a190807060;
+
+struct Foo {
+  a @0 :Int32;
+  struct Bar {
+    x @0 :Text;
+  }
+  g :group {
+    b @1 :Bool;
+  }
+  u :union {
+    c @2 :Void;
+    d @3 :Void;
+  }
+}
+
+enum Bar {
+  one @0;
+}
+
+const baz :Int32 = 42;
+`)
+	sm := newSourceMap("schemas/foo.capnp", src)
+	tests := []struct {
+		displayName string
+		want        string
+	}{
+		{"schemas/foo.capnp:Foo", "foo.capnp:3"},
+		{"schemas/foo.capnp:Foo.Bar", "foo.capnp:5"},
+		{"schemas/foo.capnp:Foo.g", "foo.capnp:8"},
+		{"schemas/foo.capnp:Foo.u", "foo.capnp:11"},
+		{"schemas/foo.capnp:Bar", "foo.capnp:17"},
+		{"schemas/foo.capnp:baz", "foo.capnp:21"},
+		{"schemas/foo.capnp:Missing", ""},
+		{"schemas/foo.capnp", ""},
+	}
+	for _, test := range tests {
+		if got := sm.pos(test.displayName); got != test.want {
+			t.Errorf("pos(%q) = %q; want %q", test.displayName, got, test.want)
+		}
+	}
+	var nilMap *sourceMap
+	if got := nilMap.pos("foo.capnp:Foo"); got != "" {
+		t.Errorf("nil sourceMap pos = %q; want \"\"", got)
+	}
+}
@@ -11,9 +11,11 @@ type annotationParams struct {
 }
 
 type constantsParams struct {
-	G      *generator
-	Consts []*node
-	Vars   []*node
+	G       *generator
+	Consts  []*node
+	Vars    []*node
+	PtrVars []*node // subset of Vars that point into a message
+	Lazy    []*node
 }
 
 type enumParams struct {
@@ -38,11 +40,27 @@ type baseStructFuncsParams struct {
 	G            *generator
 	Node         *node
 	StringMethod bool
+	SQLMethods   bool
+	LogValuer    bool
+	EqualMethods bool
+	Fixtures     bool
+	ErrorDetails bool
+	LayoutHashes bool
 }
 
 type structFuncsParams struct {
-	G    *generator
-	Node *node
+	G             *generator
+	Node          *node
+	ResetData     []dataSlot
+	ResetPointers []uint32
+	Fields        []field // in code order; only set for -presence
+}
+
+// dataSlot is a field's location in a struct's data section.  Offset is
+// in bits if Bits is 1 and in bytes otherwise.
+type dataSlot struct {
+	Bits   uint
+	Offset uint32
 }
 
 type structGroupParams struct {
@@ -55,6 +73,7 @@ type structGroupParams struct {
 type structFieldParams struct {
 	G           *generator
 	Node        *node
+	Recv        *node // type that the accessors are methods of
 	Field       field
 	Annotations *annotations
 	FieldType   string
@@ -175,6 +194,7 @@ type interfaceServerParams struct {
 	Node        *node
 	Annotations *annotations
 	Methods     []interfaceMethod
+	Table       []interfaceGroup
 }
 
 type structValueParams struct {
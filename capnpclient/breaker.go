@@ -0,0 +1,157 @@
+package capnpclient
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+)
+
+// ErrBreakerOpen is the error of calls rejected by an open circuit
+// breaker.
+var ErrBreakerOpen = errors.New("capnpclient: circuit breaker open")
+
+// BreakerPolicy controls when a circuit breaker opens and closes.
+type BreakerPolicy struct {
+	// Failures is the number of calls in a row that must fail for the
+	// breaker to open.  Zero means 5.
+	Failures int
+
+	// Cooldown is how long the breaker stays open before it lets a
+	// trial call through.  Zero means 10 seconds.
+	Cooldown time.Duration
+
+	// IsFailure reports whether a call that returned err failed.  If
+	// it is nil, every error except context.Canceled is a failure.
+	// Errors that a server returns for bad parameters, for instance,
+	// usually shouldn't count.
+	IsFailure func(err error) bool
+}
+
+func (p *BreakerPolicy) failures() int {
+	if p.Failures <= 0 {
+		return 5
+	}
+	return p.Failures
+}
+
+func (p *BreakerPolicy) cooldown() time.Duration {
+	if p.Cooldown <= 0 {
+		return 10 * time.Second
+	}
+	return p.Cooldown
+}
+
+func (p *BreakerPolicy) isFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if p.IsFailure != nil {
+		return p.IsFailure(err)
+	}
+	if me, ok := err.(*capnp.MethodError); ok {
+		err = me.Err
+	}
+	return err != context.Canceled
+}
+
+// Breaker returns a client that stops making calls to c while c is
+// failing.  Once p.Failures calls in a row fail, the breaker opens:
+// calls fail with ErrBreakerOpen without reaching c.  After p.Cooldown,
+// the next call is let through as a trial.  If it succeeds, the breaker
+// closes and calls go to c again; if it fails, the breaker stays open
+// for another cooldown.  Calls made while the trial is running are
+// rejected.
+func Breaker(c capnp.Client, p BreakerPolicy) capnp.Client {
+	return &breakerClient{client: c, policy: p}
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+type breakerClient struct {
+	client capnp.Client
+	policy BreakerPolicy
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int       // calls in a row that failed while closed
+	openedAt time.Time // when the breaker last opened
+}
+
+func (bc *breakerClient) Call(call *capnp.Call) capnp.Answer {
+	trial, ok := bc.allow()
+	if !ok {
+		return capnp.ErrorAnswer(ErrBreakerOpen)
+	}
+	ans := bc.client.Call(call)
+	go func() {
+		_, err := ans.Struct()
+		bc.record(trial, bc.policy.isFailure(err))
+	}()
+	return ans
+}
+
+// allow reports whether a call may be made and whether it is the
+// trial call of a half-open breaker.
+func (bc *breakerClient) allow() (trial, ok bool) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	switch bc.state {
+	case breakerClosed:
+		return false, true
+	case breakerOpen:
+		if time.Since(bc.openedAt) < bc.policy.cooldown() {
+			return false, false
+		}
+		bc.state = breakerHalfOpen
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+// record updates the breaker's state with the outcome of a call.
+func (bc *breakerClient) record(trial, failed bool) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	if trial {
+		if failed {
+			bc.state, bc.openedAt = breakerOpen, time.Now()
+		} else {
+			bc.state, bc.failures = breakerClosed, 0
+		}
+		return
+	}
+	if bc.state != breakerClosed {
+		// The call was made before the breaker opened.
+		return
+	}
+	if !failed {
+		bc.failures = 0
+		return
+	}
+	bc.failures++
+	if bc.failures >= bc.policy.failures() {
+		bc.state, bc.openedAt, bc.failures = breakerOpen, time.Now(), 0
+	}
+}
+
+func (bc *breakerClient) WrappedClient() capnp.Client {
+	return bc.client
+}
+
+func (bc *breakerClient) ClientState() (resolved bool, brand interface{}, remote bool) {
+	return clientState(bc, bc.client)
+}
+
+func (bc *breakerClient) Close() error {
+	return bc.client.Close()
+}
@@ -0,0 +1,80 @@
+package capnpclient_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2/capnpclient"
+)
+
+func TestBreaker(t *testing.T) {
+	var (
+		failing int32 = 1
+		calls   int32
+	)
+	errDown := errors.New("down")
+	errBadInput := errors.New("bad input")
+	c := capnpclient.Breaker(newServer(func(n uint64) error {
+		atomic.AddInt32(&calls, 1)
+		if n == 0 {
+			return errBadInput
+		}
+		if atomic.LoadInt32(&failing) != 0 {
+			return errDown
+		}
+		return nil
+	}), capnpclient.BreakerPolicy{
+		Failures: 3,
+		Cooldown: 20 * time.Millisecond,
+		IsFailure: func(err error) bool {
+			return err.Error() != errBadInput.Error()
+		},
+	})
+	defer c.Close()
+	ctx := context.Background()
+
+	// waitErr makes a call and waits for the breaker to see its result.
+	waitErr := func(n uint64) error {
+		_, err := call(ctx, c, n).Struct()
+		time.Sleep(5 * time.Millisecond)
+		return err
+	}
+	for i := 0; i < 5; i++ {
+		if err := waitErr(0); err == nil {
+			t.Fatal("call with bad input succeeded")
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if err := waitErr(1); err == nil || err == capnpclient.ErrBreakerOpen {
+			t.Fatalf("call %d error = %v; want server error", i, err)
+		}
+	}
+	before := atomic.LoadInt32(&calls)
+	if err := waitErr(1); err != capnpclient.ErrBreakerOpen {
+		t.Fatalf("call after failures error = %v; want ErrBreakerOpen", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != before {
+		t.Errorf("open breaker made %d calls", n-before)
+	}
+
+	// A failed trial keeps the breaker open.
+	time.Sleep(30 * time.Millisecond)
+	if err := waitErr(1); err == nil || err == capnpclient.ErrBreakerOpen {
+		t.Fatalf("trial call error = %v; want server error", err)
+	}
+	if err := waitErr(1); err != capnpclient.ErrBreakerOpen {
+		t.Fatalf("call after failed trial error = %v; want ErrBreakerOpen", err)
+	}
+
+	// A successful trial closes it.
+	atomic.StoreInt32(&failing, 0)
+	time.Sleep(30 * time.Millisecond)
+	for i := 0; i < 5; i++ {
+		if err := waitErr(1); err != nil {
+			t.Fatalf("call %d after recovery: %v", i, err)
+		}
+	}
+}
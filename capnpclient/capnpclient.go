@@ -0,0 +1,59 @@
+// Package capnpclient provides clients that wrap other clients to add
+// behavior to their calls, like rate limiting, circuit breaking, and
+// retries.
+//
+// The wrappers compose, each one seeing the calls that the wrapper
+// outside of it lets through:
+//
+//	c = capnpclient.Breaker(c, capnpclient.BreakerPolicy{})
+//	c = capnpclient.Retry(c, capnpclient.RetryPolicy{})
+//	c = capnpclient.RateLimit(c, limiter)
+//
+// Every wrapper implements WrappedClient, and types outside of this
+// package that wrap clients should too, so that Unwrap can find the
+// client underneath.
+package capnpclient // import "github.com/iguazio/go-capnproto2/capnpclient"
+
+import (
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/fulfiller"
+)
+
+// A WrappedClient is a client that forwards calls to another client.
+type WrappedClient interface {
+	capnp.Client
+
+	// WrappedClient returns the client that calls are forwarded to.
+	WrappedClient() capnp.Client
+}
+
+// Unwrap returns the innermost client that c forwards calls to,
+// following WrappedClients.  If c isn't a WrappedClient, Unwrap
+// returns c.
+func Unwrap(c capnp.Client) capnp.Client {
+	for {
+		w, ok := c.(WrappedClient)
+		if !ok {
+			return c
+		}
+		c = w.WrappedClient()
+	}
+}
+
+// clientState reports the resolution state of a wrapper around c.  The
+// wrapper is its own brand, so that the RPC system doesn't route calls
+// around it to c.
+func clientState(wrapper, c capnp.Client) (resolved bool, brand interface{}, remote bool) {
+	resolved, _, remote = capnp.ClientState(c)
+	return resolved, wrapper, remote
+}
+
+// forward resolves f with the result of ans once it's ready.
+func forward(f *fulfiller.Fulfiller, ans capnp.Answer) {
+	s, err := ans.Struct()
+	if err != nil {
+		f.Reject(err)
+	} else {
+		f.Fulfill(s)
+	}
+}
@@ -0,0 +1,67 @@
+package capnpclient_test
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/capnpclient"
+	"github.com/iguazio/go-capnproto2/server"
+)
+
+var testMethod = capnp.Method{InterfaceID: 0xa7317bd7216570aa, MethodID: 0}
+
+// newServer returns a client for a server whose test method calls f
+// with the number in its parameters and sets its result to the number.
+func newServer(f func(n uint64) error) capnp.Client {
+	return server.New([]server.Method{{
+		Method: testMethod,
+		Impl: func(ctx context.Context, opts capnp.CallOptions, params, results capnp.Struct) error {
+			n := params.Uint64(0)
+			if err := f(n); err != nil {
+				return err
+			}
+			results.SetUint64(0, n)
+			return nil
+		},
+		ResultsSize: capnp.ObjectSize{DataSize: 8},
+	}}, nil)
+}
+
+// call starts a call of the test method on c with n as its parameter.
+func call(ctx context.Context, c capnp.Client, n uint64) capnp.Answer {
+	return callMethod(ctx, c, testMethod, n)
+}
+
+// callMethod starts a call of the test method on c as m, which may
+// differ from testMethod in its other fields.
+func callMethod(ctx context.Context, c capnp.Client, m capnp.Method, n uint64) capnp.Answer {
+	return c.Call(&capnp.Call{
+		Ctx:        ctx,
+		Method:     m,
+		ParamsSize: capnp.ObjectSize{DataSize: 8},
+		ParamsFunc: func(s capnp.Struct) error {
+			s.SetUint64(0, n)
+			return nil
+		},
+	})
+}
+
+func TestUnwrap(t *testing.T) {
+	base := newServer(func(uint64) error { return nil })
+	defer base.Close()
+	c := capnpclient.RateLimit(capnpclient.Breaker(base, capnpclient.BreakerPolicy{}), nopLimiter{})
+	if got := capnpclient.Unwrap(c); got != base {
+		t.Errorf("Unwrap(c) = %v; want %v", got, base)
+	}
+	if got := capnpclient.Unwrap(base); got != base {
+		t.Errorf("Unwrap(base) = %v; want base", got)
+	}
+	if _, brand, _ := capnp.ClientState(c); brand != c {
+		t.Errorf("brand of wrapper = %v; want the wrapper", brand)
+	}
+}
+
+type nopLimiter struct{}
+
+func (nopLimiter) Wait(ctx context.Context) error { return nil }
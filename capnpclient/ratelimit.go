@@ -0,0 +1,128 @@
+package capnpclient
+
+import (
+	"errors"
+	"sync"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/fulfiller"
+	"github.com/iguazio/go-capnproto2/queue"
+)
+
+// A Limiter limits the rate of events.  *rate.Limiter from
+// golang.org/x/time/rate implements Limiter.
+type Limiter interface {
+	// Wait blocks until an event is allowed or ctx is done, in which
+	// case it returns an error.
+	Wait(ctx context.Context) error
+}
+
+// rateLimitQueueSize is the maximum number of calls that a rate-limited
+// client holds while waiting on its limiter.
+const rateLimitQueueSize = 64
+
+// RateLimit returns a client that makes calls to c no faster than l
+// allows.  Call doesn't block: calls wait in a queue, in the order they
+// were made, until l lets them through.  A call whose context is done
+// before then fails with the context's error, and calls made while the
+// queue is full fail immediately.  Closing the client fails the calls
+// still waiting and closes c.
+func RateLimit(c capnp.Client, l Limiter) capnp.Client {
+	return &rateLimitClient{
+		client:  c,
+		limiter: l,
+		q:       queue.New[limitedCall](rateLimitQueueSize, nil),
+	}
+}
+
+type rateLimitClient struct {
+	client  capnp.Client
+	limiter Limiter
+
+	mu      sync.Mutex
+	q       *queue.Queue[limitedCall]
+	running bool // a goroutine is running dispatch
+	closed  bool
+}
+
+type limitedCall struct {
+	call *capnp.Call
+	f    *fulfiller.Fulfiller
+}
+
+func (rc *rateLimitClient) Call(call *capnp.Call) capnp.Answer {
+	call, err := call.Copy(nil)
+	if err != nil {
+		return capnp.ErrorAnswer(err)
+	}
+	f := new(fulfiller.Fulfiller)
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.closed {
+		return capnp.ErrorAnswer(errClosed)
+	}
+	if rc.q.Push(limitedCall{call, f}) != nil {
+		return capnp.ErrorAnswer(errRateLimitQueueFull)
+	}
+	if !rc.running {
+		rc.running = true
+		go rc.dispatch()
+	}
+	return f
+}
+
+// dispatch is run in its own goroutine while there are calls in the
+// queue.  Calls are popped before waiting so that Close can't reject
+// a call that is being made.
+func (rc *rateLimitClient) dispatch() {
+	for {
+		rc.mu.Lock()
+		lc, ok := rc.q.Pop()
+		if !ok {
+			rc.running = false
+			rc.mu.Unlock()
+			return
+		}
+		rc.mu.Unlock()
+		if err := rc.limiter.Wait(lc.call.Ctx); err != nil {
+			lc.f.Reject(err)
+			continue
+		}
+		rc.mu.Lock()
+		closed := rc.closed
+		rc.mu.Unlock()
+		if closed {
+			lc.f.Reject(errClosed)
+			continue
+		}
+		go forward(lc.f, rc.client.Call(lc.call))
+	}
+}
+
+func (rc *rateLimitClient) WrappedClient() capnp.Client {
+	return rc.client
+}
+
+func (rc *rateLimitClient) ClientState() (resolved bool, brand interface{}, remote bool) {
+	return clientState(rc, rc.client)
+}
+
+func (rc *rateLimitClient) Close() error {
+	rc.mu.Lock()
+	if rc.closed {
+		rc.mu.Unlock()
+		return errClosed
+	}
+	rc.closed = true
+	for lc, ok := rc.q.Pop(); ok; lc, ok = rc.q.Pop() {
+		lc.f.Reject(errClosed)
+	}
+	rc.mu.Unlock()
+	return rc.client.Close()
+}
+
+var (
+	errClosed             = errors.New("capnpclient: client closed")
+	errRateLimitQueueFull = errors.New("capnpclient: rate limit queue full")
+)
@@ -0,0 +1,90 @@
+package capnpclient_test
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/capnpclient"
+)
+
+// gateLimiter lets one event through for each value sent on it.
+type gateLimiter chan struct{}
+
+func (g gateLimiter) Wait(ctx context.Context) error {
+	select {
+	case <-g:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	got := make(chan uint64, 10)
+	gate := make(gateLimiter)
+	c := capnpclient.RateLimit(newServer(func(n uint64) error {
+		got <- n
+		return nil
+	}), gate)
+	defer c.Close()
+
+	ctx := context.Background()
+	var answers []capnp.Answer
+	for i := uint64(0); i < 3; i++ {
+		answers = append(answers, call(ctx, c, i))
+	}
+	select {
+	case n := <-got:
+		t.Fatalf("call %d made before the limiter allowed it", n)
+	case <-time.After(10 * time.Millisecond):
+	}
+	for i, ans := range answers {
+		gate <- struct{}{}
+		s, err := ans.Struct()
+		if err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+		if n := <-got; n != uint64(i) {
+			t.Errorf("server got call %d; want %d", n, i)
+		}
+		if s.Uint64(0) != uint64(i) {
+			t.Errorf("call %d result = %d", i, s.Uint64(0))
+		}
+	}
+}
+
+func TestRateLimit_Cancel(t *testing.T) {
+	gate := make(gateLimiter)
+	c := capnpclient.RateLimit(newServer(func(uint64) error { return nil }), gate)
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ans := call(ctx, c, 1)
+	cancel()
+	if _, err := ans.Struct(); err != context.Canceled {
+		t.Errorf("canceled call error = %v; want %v", err, context.Canceled)
+	}
+}
+
+func TestRateLimit_Close(t *testing.T) {
+	gate := make(gateLimiter)
+	c := capnpclient.RateLimit(newServer(func(uint64) error { return nil }), gate)
+	ctx := context.Background()
+	first, second := call(ctx, c, 1), call(ctx, c, 2)
+	if err := c.Close(); err != nil {
+		t.Fatal("Close:", err)
+	}
+	// The first call may already be waiting on the limiter.
+	close(gate)
+	if _, err := first.Struct(); err == nil {
+		t.Error("first call succeeded after Close")
+	}
+	if _, err := second.Struct(); err == nil {
+		t.Error("second call succeeded after Close")
+	}
+	if _, err := call(ctx, c, 3).Struct(); err == nil {
+		t.Error("call made after Close succeeded")
+	}
+}
@@ -0,0 +1,152 @@
+package capnpclient
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/fulfiller"
+	"github.com/iguazio/go-capnproto2/rpc"
+)
+
+// RetryPolicy controls how many times a call is made and how long to
+// wait between attempts.
+type RetryPolicy struct {
+	// Attempts is the maximum number of times a call is made,
+	// including the first.  Zero means 3.
+	Attempts int
+
+	// Backoff is about how long to wait before making a call again.
+	// The wait doubles after each attempt, up to MaxBackoff.  Each
+	// wait is picked at random from the upper half of the range so
+	// that clients that failed together don't retry together.
+	// Zero means 100 milliseconds.
+	Backoff time.Duration
+
+	// MaxBackoff is the longest wait between attempts.  Zero means
+	// 5 seconds.
+	MaxBackoff time.Duration
+
+	// IsRetryable reports whether a call that failed with err may be
+	// made again.  If it is nil, calls are retried if err is a
+	// disconnected or overloaded error (see rpc.IsDisconnected and
+	// rpc.IsOverloaded).
+	IsRetryable func(err error) bool
+}
+
+func (p *RetryPolicy) attempts() int {
+	if p.Attempts <= 0 {
+		return 3
+	}
+	return p.Attempts
+}
+
+func (p *RetryPolicy) backoff() time.Duration {
+	if p.Backoff <= 0 {
+		return 100 * time.Millisecond
+	}
+	return p.Backoff
+}
+
+func (p *RetryPolicy) maxBackoff() time.Duration {
+	if p.MaxBackoff <= 0 {
+		return 5 * time.Second
+	}
+	return p.MaxBackoff
+}
+
+func (p *RetryPolicy) isRetryable(err error) bool {
+	if p.IsRetryable != nil {
+		return p.IsRetryable(err)
+	}
+	return rpc.IsDisconnected(err) || rpc.IsOverloaded(err)
+}
+
+// Retry returns a client that makes calls to idempotent methods again
+// when they fail with a retryable error, waiting longer after each
+// attempt.  Methods are idempotent if they have the $Go.idempotent
+// annotation, which generated clients report in Method.Idempotent.
+// Calls to other methods are passed to c as they are.
+//
+// A call's first attempt is made in order, but later attempts are made
+// after any calls that came after it.  The answer to an idempotent call
+// resolves when its last attempt finishes, so calls pipelined on it
+// wait until then instead of going to c right away.
+func Retry(c capnp.Client, p RetryPolicy) capnp.Client {
+	return &retryClient{
+		client: c,
+		policy: p,
+		closed: make(chan struct{}),
+	}
+}
+
+type retryClient struct {
+	client    capnp.Client
+	policy    RetryPolicy
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func (rc *retryClient) Call(call *capnp.Call) capnp.Answer {
+	if !call.Method.Idempotent {
+		return rc.client.Call(call)
+	}
+	// Place the parameters once so that every attempt sends the same
+	// ones.
+	call, err := call.Copy(nil)
+	if err != nil {
+		return capnp.ErrorAnswer(err)
+	}
+	f := new(fulfiller.Fulfiller)
+	go rc.retry(f, call, rc.client.Call(call))
+	return f
+}
+
+// retry is run in its own goroutine for each idempotent call.  It
+// resolves f with the first attempt that succeeds or with the error of
+// the last one.
+func (rc *retryClient) retry(f *fulfiller.Fulfiller, call *capnp.Call, ans capnp.Answer) {
+	backoff := rc.policy.backoff()
+	for attempt := 1; ; attempt++ {
+		s, err := ans.Struct()
+		if err == nil {
+			f.Fulfill(s)
+			return
+		}
+		if attempt >= rc.policy.attempts() || !rc.policy.isRetryable(err) {
+			f.Reject(err)
+			return
+		}
+		t := time.NewTimer(backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1)))
+		select {
+		case <-t.C:
+		case <-call.Ctx.Done():
+			t.Stop()
+			f.Reject(err)
+			return
+		case <-rc.closed:
+			t.Stop()
+			f.Reject(err)
+			return
+		}
+		if backoff *= 2; backoff > rc.policy.maxBackoff() {
+			backoff = rc.policy.maxBackoff()
+		}
+		ans = rc.client.Call(call)
+	}
+}
+
+func (rc *retryClient) WrappedClient() capnp.Client {
+	return rc.client
+}
+
+func (rc *retryClient) ClientState() (resolved bool, brand interface{}, remote bool) {
+	return clientState(rc, rc.client)
+}
+
+// Close stops calls from being retried and closes c.
+func (rc *retryClient) Close() error {
+	rc.closeOnce.Do(func() { close(rc.closed) })
+	return rc.client.Close()
+}
@@ -0,0 +1,80 @@
+package capnpclient_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2/capnpclient"
+	"github.com/iguazio/go-capnproto2/rpc"
+)
+
+func TestRetry(t *testing.T) {
+	idempotent := testMethod
+	idempotent.Idempotent = true
+	errFailed := errors.New("failed")
+	tests := []struct {
+		name      string
+		idem      bool
+		errs      []error // errors returned by the server in turn
+		wantCalls int32
+		wantErr   bool
+	}{
+		{"success", true, nil, 1, false},
+		{"overloaded then success", true, []error{rpc.ErrOverloaded, rpc.ErrOverloaded}, 3, false},
+		{"disconnected then success", true, []error{rpc.ErrConnClosed}, 2, false},
+		{"attempts exhausted", true, []error{rpc.ErrOverloaded, rpc.ErrOverloaded, rpc.ErrOverloaded, nil}, 3, true},
+		{"not retryable", true, []error{errFailed}, 1, true},
+		{"not idempotent", false, []error{rpc.ErrOverloaded}, 1, true},
+	}
+	for _, test := range tests {
+		var calls int32
+		c := capnpclient.Retry(newServer(func(uint64) error {
+			i := atomic.AddInt32(&calls, 1) - 1
+			if int(i) < len(test.errs) {
+				return test.errs[i]
+			}
+			return nil
+		}), capnpclient.RetryPolicy{Backoff: time.Millisecond})
+		m := testMethod
+		if test.idem {
+			m = idempotent
+		}
+		s, err := callMethod(context.Background(), c, m, 7).Struct()
+		if test.wantErr && err == nil {
+			t.Errorf("%s: call succeeded; want error", test.name)
+		} else if !test.wantErr && err != nil {
+			t.Errorf("%s: call error: %v", test.name, err)
+		} else if err == nil && s.Uint64(0) != 7 {
+			t.Errorf("%s: result = %d; want 7", test.name, s.Uint64(0))
+		}
+		if n := atomic.LoadInt32(&calls); n != test.wantCalls {
+			t.Errorf("%s: server got %d calls; want %d", test.name, n, test.wantCalls)
+		}
+		c.Close()
+	}
+}
+
+func TestRetry_Cancel(t *testing.T) {
+	idempotent := testMethod
+	idempotent.Idempotent = true
+	var calls int32
+	c := capnpclient.Retry(newServer(func(uint64) error {
+		atomic.AddInt32(&calls, 1)
+		return rpc.ErrOverloaded
+	}), capnpclient.RetryPolicy{Attempts: 10, Backoff: time.Hour})
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ans := callMethod(ctx, c, idempotent, 1)
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	if _, err := ans.Struct(); !rpc.IsOverloaded(err) {
+		t.Errorf("canceled call error = %v; want the last attempt's error", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("server got %d calls; want 1", n)
+	}
+}
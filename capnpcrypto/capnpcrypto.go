@@ -0,0 +1,142 @@
+// Package capnpcrypto encrypts serialized Cap'n Proto messages for
+// storage, using XChaCha20-Poly1305.
+//
+// An encrypted message, or envelope, starts with a 16-byte header that
+// names the type of the message's root struct.  The header is not
+// encrypted, so that a reader can tell what an envelope holds before
+// choosing a key, but it is authenticated: an envelope whose header was
+// changed fails to decrypt.  This prevents a stored blob of one type
+// from being swapped in for a blob of another type encrypted under the
+// same key.
+//
+// The envelope layout is:
+//
+//	magic     [4]byte  "CPNE"
+//	version   uint8    1
+//	reserved  [3]byte  zero
+//	type ID   uint64   little-endian
+//	nonce     [24]byte random
+//	sealed    []byte   ciphertext of the serialized message, then the 16-byte tag
+//
+// Nonces are chosen at random, which is safe for XChaCha20-Poly1305's
+// 24-byte nonces no matter how many messages are encrypted with a key.
+package capnpcrypto // import "github.com/iguazio/go-capnproto2/capnpcrypto"
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"github.com/iguazio/go-capnproto2"
+)
+
+// KeySize is the size of a key in bytes.
+const KeySize = chacha20poly1305.KeySize
+
+// Overhead is the number of bytes that an envelope adds to the
+// serialized message.
+const Overhead = headerSize + chacha20poly1305.NonceSizeX + chacha20poly1305.Overhead
+
+const (
+	magic      = "CPNE"
+	version    = 1
+	headerSize = 16
+)
+
+// ErrOpen is returned when an envelope fails to decrypt, because the
+// key is wrong or the envelope has been modified.
+var ErrOpen = errors.New("capnpcrypto: message authentication failed")
+
+// Seal marshals msg and encrypts it with key, which must be KeySize
+// bytes.  typeID is the ID of the type of msg's root struct.
+func Seal(key []byte, typeID uint64, msg *capnp.Message) ([]byte, error) {
+	data, err := msg.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("capnpcrypto: %v", err)
+	}
+	return Encrypt(key, typeID, data)
+}
+
+// Open decrypts an envelope with key and reads the message inside.  It
+// returns an error if the envelope does not hold a message of the type
+// with the given ID.  The returned message refers to a new buffer, not
+// to envelope.
+func Open(key []byte, typeID uint64, envelope []byte) (*capnp.Message, error) {
+	data, err := Decrypt(key, typeID, envelope)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := capnp.Unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("capnpcrypto: %v", err)
+	}
+	return msg, nil
+}
+
+// Encrypt encrypts data, a serialized message whose root struct is of
+// the type with the given ID, with key.
+func Encrypt(key []byte, typeID uint64, data []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, fmt.Errorf("capnpcrypto: %v", err)
+	}
+	out := make([]byte, headerSize+aead.NonceSize(), Overhead+len(data))
+	putHeader(out, typeID)
+	nonce := out[headerSize:]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("capnpcrypto: generating nonce: %v", err)
+	}
+	return aead.Seal(out, nonce, data, out[:headerSize]), nil
+}
+
+// Decrypt decrypts an envelope with key and returns the serialized
+// message inside.  It returns an error if the envelope does not hold a
+// message of the type with the given ID.
+func Decrypt(key []byte, typeID uint64, envelope []byte) ([]byte, error) {
+	id, err := TypeID(envelope)
+	if err != nil {
+		return nil, err
+	}
+	if id != typeID {
+		return nil, fmt.Errorf("capnpcrypto: envelope holds type %#x, not %#x", id, typeID)
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, fmt.Errorf("capnpcrypto: %v", err)
+	}
+	if len(envelope) < Overhead {
+		return nil, errors.New("capnpcrypto: envelope too short")
+	}
+	nonce := envelope[headerSize : headerSize+aead.NonceSize()]
+	sealed := envelope[headerSize+aead.NonceSize():]
+	data, err := aead.Open(nil, nonce, sealed, envelope[:headerSize])
+	if err != nil {
+		return nil, ErrOpen
+	}
+	return data, nil
+}
+
+// TypeID returns the type ID in an envelope's header without
+// decrypting it.  The ID is not authenticated until the envelope is
+// decrypted.
+func TypeID(envelope []byte) (uint64, error) {
+	if len(envelope) < headerSize {
+		return 0, errors.New("capnpcrypto: envelope too short")
+	}
+	if string(envelope[:4]) != magic {
+		return 0, errors.New("capnpcrypto: not an encrypted message")
+	}
+	if envelope[4] != version {
+		return 0, fmt.Errorf("capnpcrypto: unknown envelope version %d", envelope[4])
+	}
+	return binary.LittleEndian.Uint64(envelope[8:]), nil
+}
+
+func putHeader(b []byte, typeID uint64) {
+	copy(b, magic)
+	b[4] = version
+	b[5], b[6], b[7] = 0, 0, 0
+	binary.LittleEndian.PutUint64(b[8:], typeID)
+}
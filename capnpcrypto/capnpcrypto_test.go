@@ -0,0 +1,105 @@
+package capnpcrypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+func TestSealOpen(t *testing.T) {
+	key := bytes.Repeat([]byte{0x42}, KeySize)
+	msg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := air.NewRootZdate(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetYear(2018)
+	d.SetMonth(5)
+	d.SetDay(12)
+
+	env, err := Seal(key, air.Zdate_TypeID, msg)
+	if err != nil {
+		t.Fatal("Seal:", err)
+	}
+	if id, err := TypeID(env); err != nil || id != air.Zdate_TypeID {
+		t.Errorf("TypeID = %#x, %v; want %#x, <nil>", id, err, uint64(air.Zdate_TypeID))
+	}
+	data, _ := msg.Marshal()
+	if bytes.Contains(env, data[8:]) {
+		t.Error("envelope contains the message in the clear")
+	}
+
+	msg2, err := Open(key, air.Zdate_TypeID, env)
+	if err != nil {
+		t.Fatal("Open:", err)
+	}
+	d2, err := air.ReadRootZdate(msg2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d2.Year() != 2018 || d2.Month() != 5 || d2.Day() != 12 {
+		t.Errorf("opened date = %d-%d-%d; want 2018-5-12", d2.Year(), d2.Month(), d2.Day())
+	}
+
+	if _, err := Open(key, air.Zdata_TypeID, env); err == nil {
+		t.Error("Open with the wrong type ID succeeded")
+	}
+	wrongKey := bytes.Repeat([]byte{0x43}, KeySize)
+	if _, err := Open(wrongKey, air.Zdate_TypeID, env); err != ErrOpen {
+		t.Errorf("Open with the wrong key = %v; want ErrOpen", err)
+	}
+	tampered := append([]byte(nil), env...)
+	tampered[5] = 1 // reserved header byte
+	if _, err := Open(key, air.Zdate_TypeID, tampered); err != ErrOpen {
+		t.Errorf("Open with a modified header = %v; want ErrOpen", err)
+	}
+	tampered = append([]byte(nil), env...)
+	tampered[len(tampered)-1] ^= 1
+	if _, err := Open(key, air.Zdate_TypeID, tampered); err != ErrOpen {
+		t.Errorf("Open with a modified tag = %v; want ErrOpen", err)
+	}
+	if _, err := Open(key, air.Zdate_TypeID, env[:Overhead-1]); err == nil {
+		t.Error("Open of a truncated envelope succeeded")
+	}
+}
+
+func TestEncrypt_BadKey(t *testing.T) {
+	if _, err := Encrypt(make([]byte, KeySize-1), 1, nil); err == nil {
+		t.Error("Encrypt with a short key succeeded")
+	}
+}
+
+// TestCipher checks the copied cipher against the test vector in
+// draft-irtf-cfrg-xchacha, appendix A.3.1.
+func TestCipher(t *testing.T) {
+	key := unhex("808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f")
+	nonce := unhex("404142434445464748494a4b4c4d4e4f5051525354555657")
+	ad := unhex("50515253c0c1c2c3c4c5c6c7")
+	pt := []byte("Ladies and Gentlemen of the class of '99: If I could offer you only one tip for the future, sunscreen would be it.")
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := aead.Seal(nil, nonce, pt, ad)
+	if want := unhex("bd6d179d3e83d43b9576579493c0e939"); !bytes.HasPrefix(out, want) {
+		t.Errorf("ciphertext starts with %x; want %x", out[:len(want)], want)
+	}
+	if want := unhex("c0875924c1c7987947deafd8780acf49"); !bytes.HasSuffix(out, want) {
+		t.Errorf("tag = %x; want %x", out[len(out)-len(want):], want)
+	}
+}
+
+func unhex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,237 @@
+// Package capnpdelta encodes a Cap'n Proto message as the difference
+// from an earlier message, for state synchronization where successive
+// messages differ in a few fields.
+//
+// A delta lists the words of the new message's segments that differ
+// from the words at the same positions in the base message.  It does
+// not understand the messages' schema, so it is only small when the two
+// messages have the same layout: for example, when the sender updates
+// a message in place, or builds each message the same way so that
+// objects are allocated in the same order.  Resizing a Text or list in
+// the middle of a segment moves every object after it, and the delta is
+// then about as large as the message.  The encoding is always correct,
+// whatever its size.
+//
+// This package is experimental and its encoding may change.
+package capnpdelta // import "github.com/iguazio/go-capnproto2/capnpdelta"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+
+	"github.com/iguazio/go-capnproto2"
+)
+
+// The encoding is a sequence of unsigned varints:
+//
+//	version (1)
+//	CRC-32 (IEEE) of the base message's segments
+//	number of segments
+//	for each segment:
+//		size in words
+//		number of runs
+//		for each run:
+//			words between the end of the previous run (or the
+//			start of the segment) and the start of this run
+//			length of the run in words
+//			the run's words, as raw bytes
+const version = 1
+
+const wordSize = 8
+
+// Encode returns the delta that turns base into update.  Neither
+// message is modified.
+func Encode(base, update *capnp.Message) ([]byte, error) {
+	bsegs, err := segments(base)
+	if err != nil {
+		return nil, fmt.Errorf("capnpdelta: base: %v", err)
+	}
+	usegs, err := segments(update)
+	if err != nil {
+		return nil, fmt.Errorf("capnpdelta: update: %v", err)
+	}
+	out := binary.AppendUvarint(nil, version)
+	out = binary.AppendUvarint(out, uint64(checksum(bsegs)))
+	out = binary.AppendUvarint(out, uint64(len(usegs)))
+	for i, u := range usegs {
+		var b []byte
+		if i < len(bsegs) {
+			b = bsegs[i]
+		}
+		out = appendSegment(out, b, u)
+	}
+	return out, nil
+}
+
+// appendSegment appends the encoding of the changes from b to u.
+func appendSegment(out, b, u []byte) []byte {
+	nwords := len(u) / wordSize
+	out = binary.AppendUvarint(out, uint64(nwords))
+	type run struct{ start, end int }
+	var runs []run
+	bwords := len(b) / wordSize
+	for w := 0; w < nwords; w++ {
+		// Words past the end of the base segment are always sent, even
+		// if they are zero, so that Apply can bound a segment's size
+		// by the size of the delta.
+		if w < bwords && word(b, w) == word(u, w) {
+			continue
+		}
+		if n := len(runs); n > 0 && runs[n-1].end == w {
+			runs[n-1].end++
+		} else {
+			runs = append(runs, run{w, w + 1})
+		}
+	}
+	out = binary.AppendUvarint(out, uint64(len(runs)))
+	prev := 0
+	for _, r := range runs {
+		out = binary.AppendUvarint(out, uint64(r.start-prev))
+		out = binary.AppendUvarint(out, uint64(r.end-r.start))
+		out = append(out, u[r.start*wordSize:r.end*wordSize]...)
+		prev = r.end
+	}
+	return out
+}
+
+func word(b []byte, w int) uint64 {
+	return binary.LittleEndian.Uint64(b[w*wordSize:])
+}
+
+// Apply reconstructs the update message from base and a delta returned
+// by Encode.  It returns an error if base is not the message that the
+// delta was computed from.  The returned message has its own buffers,
+// so base may be reused afterward.
+func Apply(base *capnp.Message, delta []byte) (*capnp.Message, error) {
+	bsegs, err := segments(base)
+	if err != nil {
+		return nil, fmt.Errorf("capnpdelta: base: %v", err)
+	}
+	d := decoder{buf: delta}
+	if v := d.uvarint(); d.err == nil && v != version {
+		return nil, fmt.Errorf("capnpdelta: unknown version %d", v)
+	}
+	sum := d.uvarint()
+	if d.err == nil && sum != uint64(checksum(bsegs)) {
+		return nil, errors.New("capnpdelta: delta does not apply to base message")
+	}
+	nsegs := d.count(1)
+	segs := make([][]byte, 0, nsegs)
+	for i := 0; i < nsegs && d.err == nil; i++ {
+		nwords := d.uvarint()
+		if d.err == nil && nwords > uint64(maxBaseWords(bsegs, i))+uint64(len(d.buf)/wordSize) {
+			// Words beyond the base segment must be in the delta.
+			d.err = errors.New("segment size too large")
+			break
+		}
+		seg := make([]byte, int(nwords)*wordSize)
+		if i < len(bsegs) {
+			copy(seg, bsegs[i])
+		}
+		nruns := d.count(2)
+		w := uint64(0)
+		for j := 0; j < nruns && d.err == nil; j++ {
+			w += d.uvarint()
+			n := d.uvarint()
+			if d.err != nil {
+				break
+			}
+			if w > nwords || n > nwords-w {
+				d.err = errors.New("run out of segment bounds")
+				break
+			}
+			copy(seg[w*wordSize:], d.bytes(int(n)*wordSize))
+			w += n
+		}
+		segs = append(segs, seg)
+	}
+	if d.err == nil && len(d.buf) > 0 {
+		d.err = errors.New("trailing data")
+	}
+	if d.err != nil {
+		return nil, fmt.Errorf("capnpdelta: malformed delta: %v", d.err)
+	}
+	if len(segs) == 1 {
+		return &capnp.Message{Arena: capnp.SingleSegment(segs[0])}, nil
+	}
+	return &capnp.Message{Arena: capnp.MultiSegment(segs)}, nil
+}
+
+func maxBaseWords(bsegs [][]byte, i int) int {
+	if i < len(bsegs) {
+		return len(bsegs[i]) / wordSize
+	}
+	return 0
+}
+
+// segments returns the data of each of msg's segments.
+func segments(msg *capnp.Message) ([][]byte, error) {
+	n := msg.NumSegments()
+	segs := make([][]byte, n)
+	for i := range segs {
+		s, err := msg.Segment(capnp.SegmentID(i))
+		if err != nil {
+			return nil, err
+		}
+		segs[i] = s.Data()
+	}
+	return segs, nil
+}
+
+func checksum(segs [][]byte) uint32 {
+	var sizes [4]byte
+	h := crc32.NewIEEE()
+	for _, s := range segs {
+		// Include the sizes so that moving bytes between segments
+		// changes the checksum.
+		binary.LittleEndian.PutUint32(sizes[:], uint32(len(s)))
+		h.Write(sizes[:])
+		h.Write(s)
+	}
+	return h.Sum32()
+}
+
+// decoder reads the fields of a delta, stopping at the first error.
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		d.err = errors.New("bad varint")
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+// count reads a count of items that each take at least min bytes of
+// the delta, so that a corrupt count can't cause a huge allocation.
+func (d *decoder) count(min int) int {
+	v := d.uvarint()
+	if d.err == nil && v > uint64(len(d.buf)/min) {
+		d.err = errors.New("count too large")
+		return 0
+	}
+	return int(v)
+}
+
+func (d *decoder) bytes(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n > len(d.buf) {
+		d.err = errors.New("unexpected end of delta")
+		return nil
+	}
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+	return b
+}
@@ -0,0 +1,135 @@
+package capnpdelta
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+func TestEncodeApply(t *testing.T) {
+	base := newPlane(t, "Concorde", 100)
+	update := newPlane(t, "Concorde", 120)
+
+	delta, err := Encode(base, update)
+	if err != nil {
+		t.Fatal("Encode:", err)
+	}
+	data, _ := update.Marshal()
+	if len(delta) >= len(data)/2 {
+		t.Errorf("len(delta) = %d for a one-field change to a %d-byte message", len(delta), len(data))
+	}
+	got, err := Apply(base, delta)
+	if err != nil {
+		t.Fatal("Apply:", err)
+	}
+	checkSame(t, got, update)
+	pb, err := air.ReadRootPlaneBase(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pb.Capacity() != 120 {
+		t.Errorf("Capacity = %d; want 120", pb.Capacity())
+	}
+
+	// A different base is detected.
+	if _, err := Apply(update, delta); err == nil {
+		t.Error("Apply to the wrong base succeeded")
+	}
+	if _, err := Apply(base, delta[:len(delta)-1]); err == nil {
+		t.Error("Apply of a truncated delta succeeded")
+	}
+}
+
+func TestEncodeApply_Grow(t *testing.T) {
+	base := newPlane(t, "Dash", 50)
+	update := newPlane(t, "Dash 8 Q400 with a longer name", 50)
+	delta, err := Encode(base, update)
+	if err != nil {
+		t.Fatal("Encode:", err)
+	}
+	got, err := Apply(base, delta)
+	if err != nil {
+		t.Fatal("Apply:", err)
+	}
+	checkSame(t, got, update)
+
+	// And back again, to a smaller message.
+	delta, err = Encode(update, base)
+	if err != nil {
+		t.Fatal("Encode:", err)
+	}
+	got, err = Apply(update, delta)
+	if err != nil {
+		t.Fatal("Apply:", err)
+	}
+	checkSame(t, got, base)
+}
+
+func TestEncodeApply_MultiSegment(t *testing.T) {
+	base := &capnp.Message{Arena: capnp.SingleSegment(nil)}
+	update, seg, err := capnp.NewMessage(capnp.MultiSegment([][]byte{make([]byte, 0, 16)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pb, err := air.NewRootPlaneBase(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pb.SetName("far away"); err != nil {
+		t.Fatal(err)
+	}
+	if update.NumSegments() < 2 {
+		t.Fatal("update has one segment")
+	}
+	delta, err := Encode(base, update)
+	if err != nil {
+		t.Fatal("Encode:", err)
+	}
+	got, err := Apply(base, delta)
+	if err != nil {
+		t.Fatal("Apply:", err)
+	}
+	checkSame(t, got, update)
+}
+
+func newPlane(t *testing.T, name string, capacity int64) *capnp.Message {
+	msg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pb, err := air.NewRootPlaneBase(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pb.SetName(name); err != nil {
+		t.Fatal(err)
+	}
+	homes, err := pb.NewHomes(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	homes.Set(0, air.Airport_jfk)
+	homes.Set(1, air.Airport_lax)
+	homes.Set(2, air.Airport_sfo)
+	pb.SetRating(7)
+	pb.SetCapacity(capacity)
+	pb.SetMaxSpeed(1350)
+	return msg
+}
+
+func checkSame(t *testing.T, got, want *capnp.Message) {
+	t.Helper()
+	gb, err := got.Marshal()
+	if err != nil {
+		t.Fatal("Marshal:", err)
+	}
+	wb, err := want.Marshal()
+	if err != nil {
+		t.Fatal("Marshal:", err)
+	}
+	if !bytes.Equal(gb, wb) {
+		t.Errorf("reconstructed message =\n% x\nwant\n% x", gb, wb)
+	}
+}
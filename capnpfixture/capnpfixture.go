@@ -0,0 +1,396 @@
+// Package capnpfixture fills Cap'n Proto structs with deterministic
+// sample data, for use in round-trip tests, fuzzing seeds, and interop
+// tests.
+//
+// capnpc-go generates a NewSampleFoo function for each struct Foo when
+// run with -fixtures:
+//
+//	foo, err := mypkg.NewSampleFoo(seg)
+//
+// The value of a field depends only on the schema: numbers are the
+// field's code order plus one (plus one half for floats), booleans are
+// true, enums are the enumerant at the same index modulo the number of
+// enumerants, Text and Data fields hold the field's name, and lists
+// hold ListLen elements whose values count up from the field's value.
+// Only the first member of a union is set.  Interface and AnyPointer
+// fields are left null, as are struct fields below the maximum depth,
+// so that recursive types terminate.
+package capnpfixture // import "github.com/iguazio/go-capnproto2/capnpfixture"
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/nodemap"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+	"github.com/iguazio/go-capnproto2/internal/valueconv"
+	"github.com/iguazio/go-capnproto2/schemas"
+)
+
+// Default limits used by a Filler.
+const (
+	DefaultMaxDepth = 3
+	DefaultListLen  = 2
+)
+
+// Fill sets the fields of s, a struct of the type with the given ID, to
+// sample data, using the schemas in schemas.DefaultRegistry.
+func Fill(typeID uint64, s capnp.Struct) error {
+	return defaultFiller.Fill(typeID, s)
+}
+
+var defaultFiller Filler
+
+// A Filler sets struct fields to sample data using the schemas in a
+// registry.  Its fields must not be changed after its first use.  A
+// Filler is safe to use from multiple goroutines.
+type Filler struct {
+	// Registry is the registry to find schemas in.  If nil,
+	// schemas.DefaultRegistry is used.
+	Registry *schemas.Registry
+
+	// MaxDepth is the number of levels of nested structs to fill.
+	// Struct fields and lists of structs deeper than this are left
+	// null.  If zero, DefaultMaxDepth is used.
+	MaxDepth int
+
+	// ListLen is the number of elements in each list.  If zero,
+	// DefaultListLen is used.
+	ListLen int
+
+	mu    sync.Mutex
+	init  bool
+	nodes nodemap.Map
+}
+
+// Fill sets the fields of s, a struct of the type with the given ID, to
+// sample data.
+func (f *Filler) Fill(typeID uint64, s capnp.Struct) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.init {
+		if f.Registry != nil {
+			f.nodes.UseRegistry(f.Registry)
+		}
+		f.init = true
+	}
+	n, err := f.findStruct(typeID)
+	if err != nil {
+		return fmt.Errorf("capnpfixture: %v", err)
+	}
+	if err := f.fillStruct(s, n, 1); err != nil {
+		return fmt.Errorf("capnpfixture: struct %#x: %v", typeID, err)
+	}
+	return nil
+}
+
+func (f *Filler) maxDepth() int {
+	if f.MaxDepth > 0 {
+		return f.MaxDepth
+	}
+	return DefaultMaxDepth
+}
+
+func (f *Filler) listLen() int32 {
+	if f.ListLen > 0 {
+		return int32(f.ListLen)
+	}
+	return DefaultListLen
+}
+
+func (f *Filler) findStruct(id uint64) (schema.Node, error) {
+	n, err := f.nodes.Find(id)
+	if err != nil {
+		return schema.Node{}, fmt.Errorf("find struct %#x: %v", id, err)
+	}
+	if n.Which() != schema.Node_Which_structNode {
+		return schema.Node{}, fmt.Errorf("%#x is a %v, not a struct", id, n.Which())
+	}
+	return n, nil
+}
+
+// fillStruct sets the fields of s from a struct or group node.  depth is
+// the nesting level of s, starting at 1.
+func (f *Filler) fillStruct(s capnp.Struct, n schema.Node, depth int) error {
+	fields, err := n.StructNode().Fields()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < fields.Len(); i++ {
+		fld := fields.At(i)
+		// The discriminant is zero in a new struct, so the first
+		// member of the union is already selected.
+		if dv := fld.DiscriminantValue(); dv != schema.Field_noDiscriminant && dv != 0 {
+			continue
+		}
+		name, err := fld.Name()
+		if err != nil {
+			return err
+		}
+		sample := int(fld.CodeOrder()) + 1
+		switch fld.Which() {
+		case schema.Field_Which_group:
+			g, err := f.nodes.Find(fld.Group().TypeId())
+			if err != nil {
+				return fmt.Errorf("field %s: %v", name, err)
+			}
+			if err := f.fillStruct(s, g, depth); err != nil {
+				return fmt.Errorf("field %s: %v", name, err)
+			}
+		case schema.Field_Which_slot:
+			if err := f.fillSlot(s, fld.Slot(), name, sample, depth); err != nil {
+				return fmt.Errorf("field %s: %v", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (f *Filler) fillSlot(s capnp.Struct, slot schema.Field_slot, name string, sample int, depth int) error {
+	t, err := slot.Type()
+	if err != nil {
+		return err
+	}
+	dv, err := slot.DefaultValue()
+	if err != nil {
+		return err
+	}
+	// Scalars are stored XORed with their default.
+	off := slot.Offset()
+	switch t.Which() {
+	case schema.Type_Which_void, schema.Type_Which_interface, schema.Type_Which_anyPointer:
+	case schema.Type_Which_bool:
+		s.SetBit(capnp.BitOffset(off), !dv.Bool())
+	case schema.Type_Which_int8:
+		s.SetUint8(capnp.DataOffset(off), uint8(sample)^uint8(dv.Int8()))
+	case schema.Type_Which_uint8:
+		s.SetUint8(capnp.DataOffset(off), uint8(sample)^dv.Uint8())
+	case schema.Type_Which_int16:
+		s.SetUint16(capnp.DataOffset(off*2), uint16(sample)^uint16(dv.Int16()))
+	case schema.Type_Which_uint16:
+		s.SetUint16(capnp.DataOffset(off*2), uint16(sample)^dv.Uint16())
+	case schema.Type_Which_enum:
+		e, err := f.enumSample(t, sample)
+		if err != nil {
+			return err
+		}
+		s.SetUint16(capnp.DataOffset(off*2), e^dv.Enum())
+	case schema.Type_Which_int32:
+		s.SetUint32(capnp.DataOffset(off*4), uint32(sample)^uint32(dv.Int32()))
+	case schema.Type_Which_uint32:
+		s.SetUint32(capnp.DataOffset(off*4), uint32(sample)^dv.Uint32())
+	case schema.Type_Which_float32:
+		s.SetUint32(capnp.DataOffset(off*4), math.Float32bits(float32(sample)+0.5)^math.Float32bits(dv.Float32()))
+	case schema.Type_Which_int64:
+		s.SetUint64(capnp.DataOffset(off*8), uint64(sample)^uint64(dv.Int64()))
+	case schema.Type_Which_uint64:
+		s.SetUint64(capnp.DataOffset(off*8), uint64(sample)^dv.Uint64())
+	case schema.Type_Which_float64:
+		s.SetUint64(capnp.DataOffset(off*8), math.Float64bits(float64(sample)+0.5)^math.Float64bits(dv.Float64()))
+	case schema.Type_Which_text:
+		return s.SetText(uint16(off), name)
+	case schema.Type_Which_data:
+		return s.SetData(uint16(off), []byte(name))
+	case schema.Type_Which_structType:
+		p, err := f.newStruct(s.Segment(), t.StructType().TypeId(), depth+1)
+		if err != nil {
+			return err
+		}
+		return s.SetPtr(uint16(off), p)
+	case schema.Type_Which_list:
+		et, err := t.List().ElementType()
+		if err != nil {
+			return err
+		}
+		p, err := f.newList(s.Segment(), et, name, sample, depth)
+		if err != nil {
+			return err
+		}
+		return s.SetPtr(uint16(off), p)
+	default:
+		return fmt.Errorf("unknown type %v", t.Which())
+	}
+	return nil
+}
+
+// newStruct returns a new filled struct of the given type, or a null
+// pointer if depth is beyond the maximum.
+func (f *Filler) newStruct(seg *capnp.Segment, id uint64, depth int) (capnp.Ptr, error) {
+	if depth > f.maxDepth() {
+		return capnp.Ptr{}, nil
+	}
+	n, err := f.findStruct(id)
+	if err != nil {
+		return capnp.Ptr{}, err
+	}
+	ss, err := capnp.NewStruct(seg, valueconv.StructSize(n))
+	if err != nil {
+		return capnp.Ptr{}, err
+	}
+	if err := f.fillStruct(ss, n, depth); err != nil {
+		return capnp.Ptr{}, err
+	}
+	return ss.ToPtr(), nil
+}
+
+// newList returns a new list of sample elements of type et.  The
+// elements of a list are at the same depth as the struct that holds
+// the list.
+func (f *Filler) newList(seg *capnp.Segment, et schema.Type, name string, sample int, depth int) (capnp.Ptr, error) {
+	n := f.listLen()
+	switch et.Which() {
+	case schema.Type_Which_void:
+		return capnp.NewVoidList(seg, n).ToPtr(), nil
+	case schema.Type_Which_bool:
+		l, err := capnp.NewBitList(seg, n)
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		for i := 0; i < l.Len(); i++ {
+			l.Set(i, true)
+		}
+		return l.ToPtr(), nil
+	case schema.Type_Which_int8, schema.Type_Which_uint8:
+		l, err := capnp.NewUInt8List(seg, n)
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		for i := 0; i < l.Len(); i++ {
+			l.Set(i, uint8(sample+i))
+		}
+		return l.ToPtr(), nil
+	case schema.Type_Which_int16, schema.Type_Which_uint16, schema.Type_Which_enum:
+		l, err := capnp.NewUInt16List(seg, n)
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		for i := 0; i < l.Len(); i++ {
+			v := uint16(sample + i)
+			if et.Which() == schema.Type_Which_enum {
+				if v, err = f.enumSample(et, sample+i); err != nil {
+					return capnp.Ptr{}, err
+				}
+			}
+			l.Set(i, v)
+		}
+		return l.ToPtr(), nil
+	case schema.Type_Which_int32, schema.Type_Which_uint32:
+		l, err := capnp.NewUInt32List(seg, n)
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		for i := 0; i < l.Len(); i++ {
+			l.Set(i, uint32(sample+i))
+		}
+		return l.ToPtr(), nil
+	case schema.Type_Which_float32:
+		l, err := capnp.NewFloat32List(seg, n)
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		for i := 0; i < l.Len(); i++ {
+			l.Set(i, float32(sample+i)+0.5)
+		}
+		return l.ToPtr(), nil
+	case schema.Type_Which_int64, schema.Type_Which_uint64:
+		l, err := capnp.NewUInt64List(seg, n)
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		for i := 0; i < l.Len(); i++ {
+			l.Set(i, uint64(sample+i))
+		}
+		return l.ToPtr(), nil
+	case schema.Type_Which_float64:
+		l, err := capnp.NewFloat64List(seg, n)
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		for i := 0; i < l.Len(); i++ {
+			l.Set(i, float64(sample+i)+0.5)
+		}
+		return l.ToPtr(), nil
+	case schema.Type_Which_text:
+		l, err := capnp.NewTextList(seg, n)
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		for i := 0; i < l.Len(); i++ {
+			if err := l.Set(i, name+strconv.Itoa(i)); err != nil {
+				return capnp.Ptr{}, err
+			}
+		}
+		return l.ToPtr(), nil
+	case schema.Type_Which_data:
+		l, err := capnp.NewDataList(seg, n)
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		for i := 0; i < l.Len(); i++ {
+			if err := l.Set(i, []byte(name+strconv.Itoa(i))); err != nil {
+				return capnp.Ptr{}, err
+			}
+		}
+		return l.ToPtr(), nil
+	case schema.Type_Which_structType:
+		if depth+1 > f.maxDepth() {
+			return capnp.Ptr{}, nil
+		}
+		sn, err := f.findStruct(et.StructType().TypeId())
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		l, err := capnp.NewCompositeList(seg, valueconv.StructSize(sn), n)
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		for i := 0; i < l.Len(); i++ {
+			if err := f.fillStruct(l.Struct(i), sn, depth+1); err != nil {
+				return capnp.Ptr{}, err
+			}
+		}
+		return l.ToPtr(), nil
+	case schema.Type_Which_list:
+		inner, err := et.List().ElementType()
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		l, err := capnp.NewPointerList(seg, n)
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		for i := 0; i < l.Len(); i++ {
+			p, err := f.newList(seg, inner, name, sample+i, depth)
+			if err != nil {
+				return capnp.Ptr{}, err
+			}
+			if err := l.SetPtr(i, p); err != nil {
+				return capnp.Ptr{}, err
+			}
+		}
+		return l.ToPtr(), nil
+	default:
+		// Lists of interfaces and AnyPointers are left null.
+		return capnp.Ptr{}, nil
+	}
+}
+
+// enumSample returns the enumerant of the enum type t at index sample,
+// modulo the number of enumerants.
+func (f *Filler) enumSample(t schema.Type, sample int) (uint16, error) {
+	n, err := f.nodes.Find(t.Enum().TypeId())
+	if err != nil {
+		return 0, err
+	}
+	enums, err := n.Enum().Enumerants()
+	if err != nil {
+		return 0, err
+	}
+	if enums.Len() == 0 {
+		return 0, nil
+	}
+	return uint16(sample % enums.Len()), nil
+}
@@ -0,0 +1,116 @@
+package capnpfixture
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+func TestFill(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := air.NewRootRegression(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Fill(air.Regression_TypeID, r.Struct); err != nil {
+		t.Fatal("Fill:", err)
+	}
+	if b0 := r.B0(); b0 != 2.5 {
+		t.Errorf("b0 = %v; want 2.5", b0)
+	}
+	base, err := r.Base()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name, _ := base.Name(); name != "name" {
+		t.Errorf("base.name = %q; want \"name\"", name)
+	}
+	if !base.CanFly() || base.Rating() != 3 || base.MaxSpeed() != 6.5 {
+		t.Errorf("base = {canFly: %t, rating: %d, maxSpeed: %v}; want {true, 3, 6.5}", base.CanFly(), base.Rating(), base.MaxSpeed())
+	}
+	homes, _ := base.Homes()
+	if homes.Len() != DefaultListLen || homes.At(0) != air.Airport_lax || homes.At(1) != air.Airport_sfo {
+		t.Errorf("base.homes = %v; want [lax sfo]", homes)
+	}
+	beta, _ := r.Beta()
+	if beta.Len() != DefaultListLen || beta.At(0) != 3.5 || beta.At(1) != 4.5 {
+		t.Errorf("beta = %v; want [3.5 4.5]", beta)
+	}
+	planes, _ := r.Planes()
+	if planes.Len() != DefaultListLen || planes.At(0).Which() != air.Aircraft_Which_void {
+		t.Errorf("planes = %v; want %d void aircraft", planes, DefaultListLen)
+	}
+}
+
+func TestFillDefaults(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := air.NewRootDefaults(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Fill(air.Defaults_TypeID, d.Struct); err != nil {
+		t.Fatal("Fill:", err)
+	}
+	if text, _ := d.Text(); text != "text" {
+		t.Errorf("text = %q; want \"text\"", text)
+	}
+	if data, _ := d.Data(); !bytes.Equal(data, []byte("data")) {
+		t.Errorf("data = %q; want \"data\"", data)
+	}
+	if d.Float() != 3.5 || d.Int() != 4 || d.Uint() != 5 {
+		t.Errorf("float, int, uint = %v, %d, %d; want 3.5, 4, 5", d.Float(), d.Int(), d.Uint())
+	}
+}
+
+func TestFillDeterministic(t *testing.T) {
+	fill := func() []byte {
+		_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		z, err := air.NewRootZ(seg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := Fill(air.Z_TypeID, z.Struct); err != nil {
+			t.Fatal("Fill:", err)
+		}
+		b, err := capnp.Canonicalize(z.Struct)
+		if err != nil {
+			t.Fatal("Canonicalize:", err)
+		}
+		return b
+	}
+	if a, b := fill(), fill(); !bytes.Equal(a, b) {
+		t.Error("two fills of Z differ")
+	}
+}
+
+func TestFillMaxDepth(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := air.NewRootRegression(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &Filler{MaxDepth: 1, ListLen: 3}
+	if err := f.Fill(air.Regression_TypeID, r.Struct); err != nil {
+		t.Fatal("Fill:", err)
+	}
+	if r.HasBase() || r.HasPlanes() {
+		t.Error("struct fields below MaxDepth were set")
+	}
+	if beta, _ := r.Beta(); beta.Len() != 3 {
+		t.Errorf("len(beta) = %d; want 3", beta.Len())
+	}
+}
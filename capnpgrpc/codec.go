@@ -0,0 +1,128 @@
+// Package capnpgrpc provides a gRPC codec that carries Cap'n Proto
+// messages, so that services on gRPC infrastructure can adopt capnp
+// serialization one method at a time.
+//
+// Codec implements google.golang.org/grpc/encoding.Codec without
+// importing gRPC.  Register it at startup:
+//
+//	encoding.RegisterCodec(capnpgrpc.Codec{})
+//
+// and select it per call with grpc.CallContentSubtype(capnpgrpc.Name).
+//
+// Each gRPC message is framed as the 8-byte little-endian type ID of
+// the root struct followed by the serialized capnp message, so that the
+// receiver can check that it got the type it expected.
+package capnpgrpc // import "github.com/iguazio/go-capnproto2/capnpgrpc"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/iguazio/go-capnproto2"
+)
+
+// Names of the codecs, which are also the gRPC content subtypes.
+const (
+	Name       = "capnp"
+	PackedName = "capnp-packed"
+)
+
+// A Message is a struct along with its type ID.  Codec marshals a
+// Message or *Message and unmarshals into a *Message.
+type Message struct {
+	TypeID uint64
+	Struct capnp.Struct
+}
+
+// Codec marshals Messages for gRPC.
+type Codec struct {
+	// Packed determines whether messages use the packed encoding.
+	Packed bool
+}
+
+// Name returns the name of the codec: Name or PackedName.
+func (c Codec) Name() string {
+	if c.Packed {
+		return PackedName
+	}
+	return Name
+}
+
+// typeIDSize is the size of the type ID that precedes each message.
+const typeIDSize = 8
+
+// Marshal serializes v, which must be a Message or *Message.  If the
+// struct isn't the root of its message, it is copied into a new
+// message.
+func (c Codec) Marshal(v interface{}) ([]byte, error) {
+	var m Message
+	switch v := v.(type) {
+	case Message:
+		m = v
+	case *Message:
+		m = *v
+	default:
+		return nil, fmt.Errorf("capnpgrpc: cannot marshal %T", v)
+	}
+	if m.Struct.Segment() == nil {
+		return nil, errors.New("capnpgrpc: marshal null struct")
+	}
+	msg := m.Struct.Segment().Message()
+	if root, err := msg.RootPtr(); err != nil || !capnp.SamePtr(root, m.Struct.ToPtr()) {
+		msg, _, err = capnp.NewMessage(capnp.MultiSegment(nil))
+		if err != nil {
+			return nil, err
+		}
+		if err := msg.SetRootPtr(m.Struct.ToPtr()); err != nil {
+			return nil, err
+		}
+	}
+	var data []byte
+	var err error
+	if c.Packed {
+		data, err = msg.MarshalPacked()
+	} else {
+		data, err = msg.Marshal()
+	}
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, typeIDSize, typeIDSize+len(data))
+	binary.LittleEndian.PutUint64(buf, m.TypeID)
+	return append(buf, data...), nil
+}
+
+// Unmarshal reads data into v, which must be a *Message.  If v.TypeID
+// is not zero, data must hold a struct of that type; otherwise v.TypeID
+// is set to the type ID in data.  v.Struct reads directly from data.
+func (c Codec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(*Message)
+	if !ok {
+		return fmt.Errorf("capnpgrpc: cannot unmarshal into %T", v)
+	}
+	if len(data) < typeIDSize {
+		return errors.New("capnpgrpc: message too short for type ID")
+	}
+	id := binary.LittleEndian.Uint64(data)
+	if m.TypeID != 0 && id != m.TypeID {
+		return fmt.Errorf("capnpgrpc: message has type %#x; want %#x", id, m.TypeID)
+	}
+	var msg *capnp.Message
+	var err error
+	if c.Packed {
+		msg, err = capnp.UnmarshalPacked(data[typeIDSize:])
+	} else {
+		msg, err = capnp.Unmarshal(data[typeIDSize:])
+	}
+	if err != nil {
+		return fmt.Errorf("capnpgrpc: %v", err)
+	}
+	p, err := msg.RootPtr()
+	if err != nil {
+		return fmt.Errorf("capnpgrpc: %v", err)
+	}
+	m.TypeID = id
+	m.Struct = p.Struct()
+	return nil
+}
@@ -0,0 +1,115 @@
+package capnpgrpc
+
+import (
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+// grpcCodec is google.golang.org/grpc/encoding.Codec.
+type grpcCodec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+	Name() string
+}
+
+var _ grpcCodec = Codec{}
+
+func TestRoundTrip(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := air.NewRootZdate(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonRoot, err := air.NewZdate(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range []air.Zdate{root, nonRoot} {
+		d.SetYear(2017)
+		d.SetMonth(5)
+		d.SetDay(9)
+	}
+	tests := []struct {
+		codec  Codec
+		root   bool
+		typeID uint64
+	}{
+		{Codec{}, true, 0},
+		{Codec{}, false, 0},
+		{Codec{Packed: true}, true, 0},
+		{Codec{}, true, air.Zdate_TypeID},
+		{Codec{Packed: true}, false, air.Zdate_TypeID},
+	}
+	for _, test := range tests {
+		d := nonRoot
+		if test.root {
+			d = root
+		}
+		data, err := test.codec.Marshal(&Message{TypeID: air.Zdate_TypeID, Struct: d.Struct})
+		if err != nil {
+			t.Errorf("%s root=%t: Marshal: %v", test.codec.Name(), test.root, err)
+			continue
+		}
+		m := &Message{TypeID: test.typeID}
+		if err := test.codec.Unmarshal(data, m); err != nil {
+			t.Errorf("%s root=%t: Unmarshal: %v", test.codec.Name(), test.root, err)
+			continue
+		}
+		if m.TypeID != air.Zdate_TypeID {
+			t.Errorf("%s root=%t: TypeID = %#x; want %#x", test.codec.Name(), test.root, m.TypeID, uint64(air.Zdate_TypeID))
+		}
+		got := air.Zdate{Struct: m.Struct}
+		if got.Year() != 2017 || got.Month() != 5 || got.Day() != 9 {
+			t.Errorf("%s root=%t: date = %d-%d-%d; want 2017-5-9", test.codec.Name(), test.root, got.Year(), got.Month(), got.Day())
+		}
+	}
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := air.NewRootZdate(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetYear(2017)
+	var c Codec
+	data, err := c.Marshal(Message{TypeID: air.Zdate_TypeID, Struct: d.Struct})
+	if err != nil {
+		t.Fatal("Marshal:", err)
+	}
+	tests := []struct {
+		name string
+		data []byte
+		v    interface{}
+	}{
+		{"wrong type", data, &Message{TypeID: air.Z_TypeID}},
+		{"not a *Message", data, new(capnp.Struct)},
+		{"short", data[:4], new(Message)},
+		{"truncated", data[:len(data)-1], new(Message)},
+		{"packed", data, new(Message)},
+	}
+	for _, test := range tests {
+		c := Codec{Packed: test.name == "packed"}
+		if err := c.Unmarshal(test.data, test.v); err == nil {
+			t.Errorf("%s: Unmarshal did not return an error", test.name)
+		}
+	}
+}
+
+func TestMarshal_Errors(t *testing.T) {
+	var c Codec
+	if _, err := c.Marshal(capnp.Struct{}); err == nil {
+		t.Error("Marshal(capnp.Struct) did not return an error")
+	}
+	if _, err := c.Marshal(Message{TypeID: air.Zdate_TypeID}); err == nil {
+		t.Error("Marshal of null struct did not return an error")
+	}
+}
@@ -0,0 +1,269 @@
+// Package capnphttp serves and calls simple request/response services
+// that exchange Cap'n Proto messages over HTTP.
+//
+// A request is a POST whose body is a serialized message and whose
+// Content-Type is ContentType or, for a packed message,
+// PackedContentType.  The root of the message is the request struct.
+// The response body is the response struct's message, packed if the
+// request was.  The Capnp-Type-Id header names the type of the request
+// struct, so that a handler can reject requests meant for another
+// endpoint.
+//
+// Services that need capabilities, pipelining, or streaming should use
+// the rpc package instead.
+package capnphttp // import "github.com/iguazio/go-capnproto2/capnphttp"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/iguazio/go-capnproto2"
+	"golang.org/x/net/context"
+)
+
+// Content types of message bodies.
+const (
+	ContentType       = "application/x-capnp"
+	PackedContentType = "application/x-capnp-packed"
+)
+
+// TypeIDHeader is the header that holds the type ID of the request
+// struct, formatted as a hexadecimal number with a 0x prefix.
+const TypeIDHeader = "Capnp-Type-Id"
+
+// A HandlerFunc handles a request struct and returns a response struct.
+// The response may be in any message; it is copied if it isn't the root
+// of its message.
+type HandlerFunc func(ctx context.Context, req capnp.Struct) (capnp.Struct, error)
+
+// Handler returns an HTTP handler that decodes a request struct from
+// the body of a POST, calls f, and writes the struct f returns as the
+// response.  typeID is the type ID of the request struct; requests with
+// a Capnp-Type-Id header for another type are rejected.
+//
+// If f returns an error, the handler responds with 500 Internal Server
+// Error and the error's text.
+func Handler(typeID uint64, f HandlerFunc) http.Handler {
+	return &handler{typeID: typeID, f: f}
+}
+
+type handler struct {
+	typeID uint64
+	f      HandlerFunc
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "capnphttp: method must be POST", http.StatusMethodNotAllowed)
+		return
+	}
+	packed, err := isPacked(r.Header.Get("Content-Type"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
+	if v := r.Header.Get(TypeIDHeader); v != "" {
+		id, err := parseTypeID(v)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if id != h.typeID {
+			http.Error(w, fmt.Sprintf("capnphttp: request type %s; want %s", formatTypeID(id), formatTypeID(h.typeID)), http.StatusBadRequest)
+			return
+		}
+	}
+	req, err := readStruct(r.Body, packed)
+	if err != nil {
+		http.Error(w, "capnphttp: read request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	resp, err := h.f(r.Context(), req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := marshalStruct(resp, packed)
+	if err != nil {
+		http.Error(w, "capnphttp: write response: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if packed {
+		w.Header().Set("Content-Type", PackedContentType)
+	} else {
+		w.Header().Set("Content-Type", ContentType)
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Write(data)
+}
+
+// A Client posts request structs to handlers.  The zero value is a
+// usable client that sends unpacked messages with http.DefaultClient.
+type Client struct {
+	// HTTPClient sends the requests.  If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+
+	// Packed determines whether requests are packed.
+	Packed bool
+}
+
+// Post sends req to the handler at url and returns the response struct.
+// typeID is the type ID of req.  If the handler responds with a status
+// other than 200 OK, Post returns a *StatusError.
+func (c *Client) Post(ctx context.Context, url string, typeID uint64, req capnp.Struct) (capnp.Struct, error) {
+	data, err := marshalStruct(req, c.Packed)
+	if err != nil {
+		return capnp.Struct{}, fmt.Errorf("capnphttp: write request: %v", err)
+	}
+	hreq, err := http.NewRequest("POST", url, bytes.NewReader(data))
+	if err != nil {
+		return capnp.Struct{}, err
+	}
+	hreq = hreq.WithContext(ctx)
+	if c.Packed {
+		hreq.Header.Set("Content-Type", PackedContentType)
+	} else {
+		hreq.Header.Set("Content-Type", ContentType)
+	}
+	hreq.Header.Set(TypeIDHeader, formatTypeID(typeID))
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	hresp, err := hc.Do(hreq)
+	if err != nil {
+		return capnp.Struct{}, err
+	}
+	defer hresp.Body.Close()
+	if hresp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(io.LimitReader(hresp.Body, maxErrorSize))
+		return capnp.Struct{}, &StatusError{
+			StatusCode: hresp.StatusCode,
+			Message:    strings.TrimSpace(string(msg)),
+		}
+	}
+	packed, err := isPacked(hresp.Header.Get("Content-Type"))
+	if err != nil {
+		return capnp.Struct{}, err
+	}
+	resp, err := readStruct(hresp.Body, packed)
+	if err != nil {
+		return capnp.Struct{}, fmt.Errorf("capnphttp: read response: %v", err)
+	}
+	return resp, nil
+}
+
+// Post sends req to the handler at url using the zero Client.
+func Post(ctx context.Context, url string, typeID uint64, req capnp.Struct) (capnp.Struct, error) {
+	var c Client
+	return c.Post(ctx, url, typeID, req)
+}
+
+// maxErrorSize is the most of an error response body that is kept in
+// a StatusError.
+const maxErrorSize = 1 << 10
+
+// A StatusError is returned by Post when a handler doesn't respond with
+// 200 OK.
+type StatusError struct {
+	StatusCode int
+
+	// Message is the body of the response, which is usually the text of
+	// the handler's error.
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	if e.Message == "" {
+		return "capnphttp: " + http.StatusText(e.StatusCode)
+	}
+	return "capnphttp: " + http.StatusText(e.StatusCode) + ": " + e.Message
+}
+
+// isPacked reports whether ct is the packed content type.
+func isPacked(ct string) (bool, error) {
+	mt, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return false, fmt.Errorf("capnphttp: content type %q: %v", ct, err)
+	}
+	switch mt {
+	case ContentType:
+		return false, nil
+	case PackedContentType:
+		return true, nil
+	default:
+		return false, fmt.Errorf("capnphttp: content type is %q; want %s or %s", mt, ContentType, PackedContentType)
+	}
+}
+
+// readStruct decodes a message from r and returns its root struct.
+func readStruct(r io.Reader, packed bool) (capnp.Struct, error) {
+	var dec *capnp.Decoder
+	if packed {
+		dec = capnp.NewPackedDecoder(r)
+	} else {
+		dec = capnp.NewDecoder(r)
+	}
+	msg, err := dec.Decode()
+	if err == io.EOF {
+		return capnp.Struct{}, errEmptyBody
+	}
+	if err != nil {
+		return capnp.Struct{}, err
+	}
+	p, err := msg.RootPtr()
+	if err != nil {
+		return capnp.Struct{}, err
+	}
+	return p.Struct(), nil
+}
+
+// marshalStruct serializes s as the root of a message.
+func marshalStruct(s capnp.Struct, packed bool) ([]byte, error) {
+	if s.Segment() == nil {
+		return nil, errNullStruct
+	}
+	msg := s.Segment().Message()
+	if root, err := msg.RootPtr(); err != nil || !capnp.SamePtr(root, s.ToPtr()) {
+		m, _, err := capnp.NewMessage(capnp.MultiSegment(nil))
+		if err != nil {
+			return nil, err
+		}
+		if err := m.SetRootPtr(s.ToPtr()); err != nil {
+			return nil, err
+		}
+		msg = m
+	}
+	if packed {
+		return msg.MarshalPacked()
+	}
+	return msg.Marshal()
+}
+
+func formatTypeID(id uint64) string {
+	return fmt.Sprintf("0x%016x", id)
+}
+
+func parseTypeID(s string) (uint64, error) {
+	if !strings.HasPrefix(s, "0x") {
+		return 0, fmt.Errorf("capnphttp: type ID %q does not start with 0x", s)
+	}
+	id, err := strconv.ParseUint(s[2:], 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("capnphttp: type ID %q: %v", s, err)
+	}
+	return id, nil
+}
+
+var (
+	errEmptyBody  = errors.New("empty body")
+	errNullStruct = errors.New("null struct")
+)
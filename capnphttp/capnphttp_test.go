@@ -0,0 +1,190 @@
+package capnphttp
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+	"golang.org/x/net/context"
+)
+
+// nextYear returns the date a year after the request date.
+func nextYear(ctx context.Context, req capnp.Struct) (capnp.Struct, error) {
+	d := air.Zdate{Struct: req}
+	if d.Year() < 0 {
+		return capnp.Struct{}, errors.New("year before common era")
+	}
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		return capnp.Struct{}, err
+	}
+	// Allocate the response as a non-root struct to exercise copying.
+	resp, err := air.NewZdate(seg)
+	if err != nil {
+		return capnp.Struct{}, err
+	}
+	resp.SetYear(d.Year() + 1)
+	resp.SetMonth(d.Month())
+	resp.SetDay(d.Day())
+	return resp.Struct, nil
+}
+
+func TestPost(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := air.NewRootZdate(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetYear(2016)
+	d.SetMonth(2)
+	d.SetDay(29)
+	srv := httptest.NewServer(Handler(air.Zdate_TypeID, nextYear))
+	defer srv.Close()
+	for _, packed := range []bool{false, true} {
+		c := &Client{Packed: packed}
+		resp, err := c.Post(context.Background(), srv.URL, air.Zdate_TypeID, d.Struct)
+		if err != nil {
+			t.Errorf("packed=%t: Post: %v", packed, err)
+			continue
+		}
+		got := air.Zdate{Struct: resp}
+		if got.Year() != 2017 || got.Month() != 2 || got.Day() != 29 {
+			t.Errorf("packed=%t: Post returned %d-%d-%d; want 2017-2-29", packed, got.Year(), got.Month(), got.Day())
+		}
+	}
+}
+
+func TestPost_HandlerError(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := air.NewRootZdate(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetYear(-44)
+	srv := httptest.NewServer(Handler(air.Zdate_TypeID, nextYear))
+	defer srv.Close()
+	_, err = Post(context.Background(), srv.URL, air.Zdate_TypeID, d.Struct)
+	se, ok := err.(*StatusError)
+	if !ok {
+		t.Fatalf("Post error = %v; want *StatusError", err)
+	}
+	if se.StatusCode != http.StatusInternalServerError || se.Message != "year before common era" {
+		t.Errorf("Post error = %+v; want 500 with handler error", se)
+	}
+}
+
+func TestPost_WrongType(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := air.NewRootZdate(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetYear(2016)
+	srv := httptest.NewServer(Handler(air.Zdate_TypeID, nextYear))
+	defer srv.Close()
+	_, err = Post(context.Background(), srv.URL, air.Z_TypeID, d.Struct)
+	if se, ok := err.(*StatusError); !ok || se.StatusCode != http.StatusBadRequest {
+		t.Errorf("Post error = %v; want 400 Bad Request", err)
+	}
+}
+
+func TestHandler_BadRequests(t *testing.T) {
+	msg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := air.NewRootZdate(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetYear(2016)
+	d.SetMonth(2)
+	d.SetDay(29)
+	data, err := msg.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		method string
+		header http.Header
+		body   []byte
+		code   int
+	}{
+		{
+			name:   "GET",
+			method: "GET",
+			code:   http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "JSON body",
+			method: "POST",
+			header: http.Header{"Content-Type": {"application/json"}},
+			body:   []byte("{}"),
+			code:   http.StatusUnsupportedMediaType,
+		},
+		{
+			name:   "malformed type ID",
+			method: "POST",
+			header: http.Header{"Content-Type": {ContentType}, TypeIDHeader: {"1234"}},
+			body:   data,
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "empty body",
+			method: "POST",
+			header: http.Header{"Content-Type": {ContentType}},
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "truncated body",
+			method: "POST",
+			header: http.Header{"Content-Type": {ContentType}},
+			body:   data[:len(data)-1],
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "no type ID",
+			method: "POST",
+			header: http.Header{"Content-Type": {ContentType + "; charset=binary"}},
+			body:   data,
+			code:   http.StatusOK,
+		},
+	}
+	h := Handler(air.Zdate_TypeID, nextYear)
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, "/", bytes.NewReader(test.body))
+		for k, v := range test.header {
+			req.Header[k] = v
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != test.code {
+			t.Errorf("%s: status = %d (%s); want %d", test.name, w.Code, strings.TrimSpace(w.Body.String()), test.code)
+		}
+	}
+}
+
+func TestTypeID(t *testing.T) {
+	s := formatTypeID(air.Zdate_TypeID)
+	if s != "0xde50aebbad57549d" {
+		t.Errorf("formatTypeID(Zdate_TypeID) = %q", s)
+	}
+	if id, err := parseTypeID(s); err != nil || id != air.Zdate_TypeID {
+		t.Errorf("parseTypeID(%q) = %#x, %v; want %#x, <nil>", s, id, err, uint64(air.Zdate_TypeID))
+	}
+}
@@ -0,0 +1,69 @@
+// Package capnpjson converts between JSON and Cap'n Proto messages
+// using the schemas in a registry.
+//
+// Both directions are streaming: a Decoder reads JSON tokens and sets
+// the fields of a message as it goes, and an Encoder writes JSON while
+// it walks a struct, so neither builds an intermediate tree of the
+// whole value.  This makes them suitable for converting large payloads,
+// such as in a gateway that accepts JSON and forwards Cap'n Proto.
+//
+// The JSON form of a struct is an object with a member for each field.
+// Only the active member of a union is written, and a group is written
+// as a nested object.  Enums are written as the names of their
+// enumerants, Data as base64 strings, and non-finite floats as the
+// strings "NaN", "Infinity", and "-Infinity".  64-bit integers are
+// written as numbers, which some JSON readers will round.  Interface and
+// AnyPointer fields are written as null.
+//
+// A Decoder accepts the same form.  In addition, it accepts enums given
+// as numbers and integers given as strings, and treats a null member the
+// same as a missing one.
+package capnpjson // import "github.com/iguazio/go-capnproto2/capnpjson"
+
+import (
+	"fmt"
+
+	"github.com/iguazio/go-capnproto2/internal/nodemap"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+)
+
+func structNode(nodes *nodemap.Map, typeID uint64) (schema.Node, error) {
+	n, err := nodes.Find(typeID)
+	if err != nil {
+		return schema.Node{}, err
+	}
+	if !n.IsValid() || n.Which() != schema.Node_Which_structNode {
+		return schema.Node{}, fmt.Errorf("cannot find struct type %#x", typeID)
+	}
+	return n, nil
+}
+
+func enumerants(nodes *nodemap.Map, typeID uint64) (schema.Enumerant_List, error) {
+	n, err := nodes.Find(typeID)
+	if err != nil {
+		return schema.Enumerant_List{}, err
+	}
+	if !n.IsValid() || n.Which() != schema.Node_Which_enum {
+		return schema.Enumerant_List{}, fmt.Errorf("cannot find enum type %#x", typeID)
+	}
+	return n.Enum().Enumerants()
+}
+
+// scalarBits returns the size in bits of a value of a non-pointer type,
+// or zero if the type is a pointer type or void.
+func scalarBits(which schema.Type_Which) uint32 {
+	switch which {
+	case schema.Type_Which_bool:
+		return 1
+	case schema.Type_Which_int8, schema.Type_Which_uint8:
+		return 8
+	case schema.Type_Which_int16, schema.Type_Which_uint16, schema.Type_Which_enum:
+		return 16
+	case schema.Type_Which_int32, schema.Type_Which_uint32, schema.Type_Which_float32:
+		return 32
+	case schema.Type_Which_int64, schema.Type_Which_uint64, schema.Type_Which_float64:
+		return 64
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,152 @@
+package capnpjson_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/capnpjson"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+func encode(t *testing.T, typeID uint64, s capnp.Struct) string {
+	var buf bytes.Buffer
+	if err := capnpjson.NewEncoder(&buf).Encode(typeID, s); err != nil {
+		t.Fatal("Encode:", err)
+	}
+	return buf.String()
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []string{
+		`{"void":null}`,
+		`{"i16vec":[-1,2]}`,
+		`{"u64":18446744073709551615}`,
+		`{"f64vec":[1.5,"NaN","-Infinity"]}`,
+		`{"boolvec":[true,false,true]}`,
+		`{"datavec":["AAE=","/w=="]}`,
+		`{"textvec":["a","b"]}`,
+		`{"airport":"lax"}`,
+		`{"grp":{"first":1,"second":2}}`,
+		`{"zdatevec":[{"year":2017,"month":5,"day":9},{"year":0,"month":0,"day":0}]}`,
+		`{"zvecvec":[[{"i8":-3},{"text":"x"}],[]]}`,
+		`{"planebase":{"name":"foo","homes":["jfk","lax"],"rating":3,"canFly":true,"capacity":100,"maxSpeed":1.5}}`,
+	}
+	for _, test := range tests {
+		msg, err := capnpjson.NewDecoder(strings.NewReader(test)).Decode(air.Z_TypeID)
+		if err != nil {
+			t.Errorf("Decode(%s): %v", test, err)
+			continue
+		}
+		z, err := air.ReadRootZ(msg)
+		if err != nil {
+			t.Errorf("ReadRootZ(%s): %v", test, err)
+			continue
+		}
+		if got := encode(t, air.Z_TypeID, z.Struct); got != test {
+			t.Errorf("Encode(Decode(%s)) = %s", test, got)
+		}
+	}
+}
+
+func TestDecode_Lenient(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`{"i64":"-5"}`, `{"i64":-5}`},
+		{`{"airport":2}`, `{"airport":"lax"}`},
+		{`{"text":null}`, `{"text":""}`},
+		{`{"textvec":["a",null]}`, `{"textvec":["a",""]}`},
+		{`{"zvec":[null,{"u8":1}]}`, `{"zvec":[{"void":null},{"u8":1}]}`},
+	}
+	for _, test := range tests {
+		msg, err := capnpjson.NewDecoder(strings.NewReader(test.in)).Decode(air.Z_TypeID)
+		if err != nil {
+			t.Errorf("Decode(%s): %v", test.in, err)
+			continue
+		}
+		z, _ := air.ReadRootZ(msg)
+		if got := encode(t, air.Z_TypeID, z.Struct); got != test.want {
+			t.Errorf("Decode(%s) encodes as %s; want %s", test.in, got, test.want)
+		}
+	}
+}
+
+func TestDecode_Defaults(t *testing.T) {
+	msg, err := capnpjson.NewDecoder(strings.NewReader(`{"int":7,"data":""}`)).Decode(air.Defaults_TypeID)
+	if err != nil {
+		t.Fatal("Decode:", err)
+	}
+	d, err := air.ReadRootDefaults(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Int() != 7 || d.Uint() != 42 || d.Float() != 3.14 {
+		t.Errorf("int, uint, float = %d, %d, %g; want 7, 42, 3.14", d.Int(), d.Uint(), d.Float())
+	}
+	want := `{"text":"foo","data":"","float":3.14,"int":7,"uint":42}`
+	if got := encode(t, air.Defaults_TypeID, d.Struct); got != want {
+		t.Errorf("Encode = %s; want %s", got, want)
+	}
+}
+
+func TestDecode_Stream(t *testing.T) {
+	dec := capnpjson.NewDecoder(strings.NewReader("{\"i8\":1}\n{\"i8\":2}\n"))
+	for i := int8(1); i <= 2; i++ {
+		msg, err := dec.Decode(air.Z_TypeID)
+		if err != nil {
+			t.Fatalf("Decode #%d: %v", i, err)
+		}
+		z, _ := air.ReadRootZ(msg)
+		if z.I8() != i {
+			t.Errorf("Decode #%d: i8 = %d", i, z.I8())
+		}
+	}
+	if _, err := dec.Decode(air.Z_TypeID); err != io.EOF {
+		t.Errorf("Decode at end = %v; want io.EOF", err)
+	}
+}
+
+func TestDecodeStruct(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := air.NewRootZdate(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetYear(2017)
+	if err := capnpjson.NewDecoder(strings.NewReader(`{"month":5}`)).DecodeStruct(air.Zdate_TypeID, d.Struct); err != nil {
+		t.Fatal("DecodeStruct:", err)
+	}
+	if d.Year() != 2017 || d.Month() != 5 {
+		t.Errorf("year, month = %d, %d; want 2017, 5", d.Year(), d.Month())
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	tests := []struct {
+		in  string
+		err string
+	}{
+		{`{"i8":1,"u8":2}`, "same union"},
+		{`{"nope":1}`, "unknown field nope"},
+		{`{"i8":300}`, "bad int8 300"},
+		{`{"airport":"ord"}`, "no enumerant ord"},
+		{`{"blob":"!!"}`, "illegal base64"},
+		{`{"zvec":[{"i8":1},{"i8":"x"}]}`, "element 1: field i8"},
+		{`{"void":1}`, "expected null"},
+		{`[1]`, "expected object"},
+		{`{"i8":`, "EOF"},
+	}
+	for _, test := range tests {
+		_, err := capnpjson.NewDecoder(strings.NewReader(test.in)).Decode(air.Z_TypeID)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("Decode(%s) = %v; want error containing %q", test.in, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,615 @@
+package capnpjson
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/nodemap"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+	"github.com/iguazio/go-capnproto2/internal/valueconv"
+	"github.com/iguazio/go-capnproto2/schemas"
+)
+
+// A Decoder reads a stream of JSON objects and builds a struct from
+// each one.  The objects may be separated by whitespace, as in
+// newline-delimited JSON.
+//
+// Fields are set as their values are read.  The length of a list is
+// not known until its end, so a Decoder collects the elements of a list
+// before allocating it.  Elements of struct lists are built as separate
+// structs and then copied into the list, which leaves their data and
+// pointer sections unused in the message; the objects they point to
+// are not copied.
+type Decoder struct {
+	dec    *json.Decoder
+	nodes  nodemap.Map
+	fields map[uint64]map[string]schema.Field
+}
+
+// NewDecoder returns a new decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	return &Decoder{dec: dec}
+}
+
+// UseRegistry changes the registry that the decoder consults for
+// schemas.  The default is schemas.DefaultRegistry.
+func (d *Decoder) UseRegistry(reg *schemas.Registry) {
+	d.nodes.UseRegistry(reg)
+	d.fields = nil
+}
+
+// Decode reads the next JSON object from its input and returns a new
+// message whose root is a struct of the type with the given ID.  At the
+// end of the input, Decode returns io.EOF.
+func (d *Decoder) Decode(typeID uint64) (*capnp.Message, error) {
+	n, err := structNode(&d.nodes, typeID)
+	if err != nil {
+		return nil, fmt.Errorf("capnpjson: %v", err)
+	}
+	msg, seg, err := capnp.NewMessage(capnp.MultiSegment(nil))
+	if err != nil {
+		return nil, fmt.Errorf("capnpjson: %v", err)
+	}
+	s, err := capnp.NewRootStruct(seg, valueconv.StructSize(n))
+	if err != nil {
+		return nil, fmt.Errorf("capnpjson: %v", err)
+	}
+	if err := d.DecodeStruct(typeID, s); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// DecodeStruct reads the next JSON object from its input and sets the
+// fields of s, a struct of the type with the given ID.  Fields that are
+// not in the object are left unchanged.  At the end of the input,
+// DecodeStruct returns io.EOF.
+func (d *Decoder) DecodeStruct(typeID uint64, s capnp.Struct) error {
+	n, err := structNode(&d.nodes, typeID)
+	if err != nil {
+		return fmt.Errorf("capnpjson: %v", err)
+	}
+	tok, err := d.dec.Token()
+	if err == io.EOF {
+		return io.EOF
+	}
+	if err != nil {
+		return fmt.Errorf("capnpjson: %v", err)
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("capnpjson: expected object, got %s", valueconv.Describe(tok))
+	}
+	if err := d.readStruct(s, n); err != nil {
+		return fmt.Errorf("capnpjson: %v", err)
+	}
+	return nil
+}
+
+// fieldsByName returns the fields of the struct or group n by name.
+func (d *Decoder) fieldsByName(n schema.Node) (map[string]schema.Field, error) {
+	if m := d.fields[n.Id()]; m != nil {
+		return m, nil
+	}
+	fields, err := n.StructNode().Fields()
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]schema.Field, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		name, err := fields.At(i).Name()
+		if err != nil {
+			return nil, err
+		}
+		m[name] = fields.At(i)
+	}
+	if d.fields == nil {
+		d.fields = make(map[uint64]map[string]schema.Field)
+	}
+	d.fields[n.Id()] = m
+	return m, nil
+}
+
+// readStruct reads the members of an object into s, a struct or group
+// of type n.  The opening brace must already have been read.
+func (d *Decoder) readStruct(s capnp.Struct, n schema.Node) error {
+	fields, err := d.fieldsByName(n)
+	if err != nil {
+		return err
+	}
+	member := ""
+	for d.dec.More() {
+		tok, err := d.dec.Token()
+		if err != nil {
+			return err
+		}
+		// Object keys are always strings.
+		name := tok.(string)
+		f, ok := fields[name]
+		if !ok {
+			dn, _ := n.DisplayName()
+			return fmt.Errorf("unknown field %s in %s", name, dn)
+		}
+		if dv := f.DiscriminantValue(); dv != schema.Field_noDiscriminant {
+			if member != "" {
+				return fmt.Errorf("fields %s and %s are members of the same union", member, name)
+			}
+			member = name
+			s.SetUint16(capnp.DataOffset(n.StructNode().DiscriminantOffset()*2), dv)
+		}
+		switch f.Which() {
+		case schema.Field_Which_slot:
+			err = d.readField(s, f)
+		case schema.Field_Which_group:
+			err = d.readGroup(s, f)
+		default:
+			err = fmt.Errorf("unknown kind %v", f.Which())
+		}
+		if err != nil {
+			return fmt.Errorf("field %s: %v", name, err)
+		}
+	}
+	_, err = d.dec.Token()
+	return err
+}
+
+func (d *Decoder) readGroup(s capnp.Struct, f schema.Field) error {
+	tok, err := d.dec.Token()
+	if err != nil || tok == nil {
+		return err
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("expected object, got %s", valueconv.Describe(tok))
+	}
+	gn, err := structNode(&d.nodes, f.Group().TypeId())
+	if err != nil {
+		return err
+	}
+	return d.readStruct(s, gn)
+}
+
+func (d *Decoder) readField(s capnp.Struct, f schema.Field) error {
+	typ, err := f.Slot().Type()
+	if err != nil {
+		return err
+	}
+	tok, err := d.dec.Token()
+	if err != nil || tok == nil {
+		// A null member leaves the field unset, and null is the only
+		// value of a Void field.
+		return err
+	}
+	off := f.Slot().Offset()
+	if bits := scalarBits(typ.Which()); bits > 0 {
+		v, err := d.scalar(typ, tok)
+		if err != nil {
+			return err
+		}
+		dv, err := f.Slot().DefaultValue()
+		if err != nil {
+			return err
+		}
+		setScalar(s, off, bits, v^defaultBits(dv))
+		return nil
+	}
+	switch typ.Which() {
+	case schema.Type_Which_void:
+		return fmt.Errorf("expected null, got %s", valueconv.Describe(tok))
+	case schema.Type_Which_text:
+		v, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("expected text, got %s", valueconv.Describe(tok))
+		}
+		return s.SetNewText(uint16(off), v)
+	case schema.Type_Which_data:
+		v, err := toData(tok)
+		if err != nil {
+			return err
+		}
+		return s.SetData(uint16(off), v)
+	case schema.Type_Which_structType, schema.Type_Which_list:
+		p, err := d.readPtr(s.Segment(), typ, tok)
+		if err != nil {
+			return err
+		}
+		return s.SetPtr(uint16(off), p)
+	case schema.Type_Which_interface, schema.Type_Which_anyPointer:
+		return fmt.Errorf("can't decode a %v", typ.Which())
+	default:
+		return fmt.Errorf("unknown type %v", typ.Which())
+	}
+}
+
+// readPtr reads a struct or list of type typ, given the first token of
+// its value.
+func (d *Decoder) readPtr(seg *capnp.Segment, typ schema.Type, tok json.Token) (capnp.Ptr, error) {
+	switch typ.Which() {
+	case schema.Type_Which_structType:
+		if tok != json.Delim('{') {
+			return capnp.Ptr{}, fmt.Errorf("expected object, got %s", valueconv.Describe(tok))
+		}
+		n, err := structNode(&d.nodes, typ.StructType().TypeId())
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		s, err := capnp.NewStruct(seg, valueconv.StructSize(n))
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		if err := d.readStruct(s, n); err != nil {
+			return capnp.Ptr{}, err
+		}
+		return s.ToPtr(), nil
+	case schema.Type_Which_list:
+		if tok != json.Delim('[') {
+			return capnp.Ptr{}, fmt.Errorf("expected array, got %s", valueconv.Describe(tok))
+		}
+		elem, err := typ.List().ElementType()
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		l, err := d.readList(seg, elem)
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		return l.ToPtr(), nil
+	default:
+		return capnp.Ptr{}, fmt.Errorf("%v is not a pointer type", typ.Which())
+	}
+}
+
+// readList reads the elements of an array into a new list.  The
+// opening bracket must already have been read.
+func (d *Decoder) readList(seg *capnp.Segment, elem schema.Type) (capnp.List, error) {
+	if bits := scalarBits(elem.Which()); bits > 0 {
+		var vals []uint64
+		for i := 0; d.dec.More(); i++ {
+			tok, err := d.dec.Token()
+			if err != nil {
+				return capnp.List{}, err
+			}
+			v, err := d.scalar(elem, tok)
+			if err != nil {
+				return capnp.List{}, valueconv.ElemError(i, err)
+			}
+			vals = append(vals, v)
+		}
+		if _, err := d.dec.Token(); err != nil {
+			return capnp.List{}, err
+		}
+		return newScalarList(seg, bits, vals)
+	}
+	switch elem.Which() {
+	case schema.Type_Which_void, schema.Type_Which_interface, schema.Type_Which_anyPointer:
+		n := int32(0)
+		for ; d.dec.More(); n++ {
+			tok, err := d.dec.Token()
+			if err != nil {
+				return capnp.List{}, err
+			}
+			if tok != nil {
+				return capnp.List{}, valueconv.ElemError(int(n), fmt.Errorf("expected null, got %s", valueconv.Describe(tok)))
+			}
+		}
+		if _, err := d.dec.Token(); err != nil {
+			return capnp.List{}, err
+		}
+		if elem.Which() == schema.Type_Which_void {
+			return capnp.NewVoidList(seg, n).List, nil
+		}
+		l, err := capnp.NewPointerList(seg, n)
+		return l.List, err
+	case schema.Type_Which_text, schema.Type_Which_data:
+		return d.readBlobList(seg, elem.Which() == schema.Type_Which_data)
+	case schema.Type_Which_structType:
+		return d.readStructList(seg, elem.StructType().TypeId())
+	case schema.Type_Which_list:
+		var ptrs []capnp.Ptr
+		for i := 0; d.dec.More(); i++ {
+			tok, err := d.dec.Token()
+			if err != nil {
+				return capnp.List{}, err
+			}
+			var p capnp.Ptr
+			if tok != nil {
+				p, err = d.readPtr(seg, elem, tok)
+				if err != nil {
+					return capnp.List{}, valueconv.ElemError(i, err)
+				}
+			}
+			ptrs = append(ptrs, p)
+		}
+		if _, err := d.dec.Token(); err != nil {
+			return capnp.List{}, err
+		}
+		l, err := capnp.NewPointerList(seg, int32(len(ptrs)))
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i, p := range ptrs {
+			if err := l.SetPtr(i, p); err != nil {
+				return capnp.List{}, valueconv.ElemError(i, err)
+			}
+		}
+		return l.List, nil
+	default:
+		return capnp.List{}, fmt.Errorf("can't decode a list of %v", elem.Which())
+	}
+}
+
+// readBlobList reads the elements of a list of Text or Data.  Null
+// elements are left null.
+func (d *Decoder) readBlobList(seg *capnp.Segment, data bool) (capnp.List, error) {
+	var (
+		vals  [][]byte
+		nulls []bool
+	)
+	for i := 0; d.dec.More(); i++ {
+		tok, err := d.dec.Token()
+		if err != nil {
+			return capnp.List{}, err
+		}
+		var v []byte
+		switch s, ok := tok.(string); {
+		case tok == nil:
+		case data:
+			v, err = toData(tok)
+		case ok:
+			v = []byte(s)
+		default:
+			err = fmt.Errorf("expected text, got %s", valueconv.Describe(tok))
+		}
+		if err != nil {
+			return capnp.List{}, valueconv.ElemError(i, err)
+		}
+		vals = append(vals, v)
+		nulls = append(nulls, tok == nil)
+	}
+	if _, err := d.dec.Token(); err != nil {
+		return capnp.List{}, err
+	}
+	n := int32(len(vals))
+	if data {
+		l, err := capnp.NewDataList(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i, v := range vals {
+			if nulls[i] {
+				continue
+			}
+			if err := l.Set(i, v); err != nil {
+				return capnp.List{}, valueconv.ElemError(i, err)
+			}
+		}
+		return l.List, nil
+	}
+	l, err := capnp.NewTextList(seg, n)
+	if err != nil {
+		return capnp.List{}, err
+	}
+	for i, v := range vals {
+		if nulls[i] {
+			continue
+		}
+		if err := l.Set(i, string(v)); err != nil {
+			return capnp.List{}, valueconv.ElemError(i, err)
+		}
+	}
+	return l.List, nil
+}
+
+// readStructList reads the elements of a list of structs.  A null
+// element is left as a struct with all fields unset.
+func (d *Decoder) readStructList(seg *capnp.Segment, typeID uint64) (capnp.List, error) {
+	n, err := structNode(&d.nodes, typeID)
+	if err != nil {
+		return capnp.List{}, err
+	}
+	sz := valueconv.StructSize(n)
+	var elems []capnp.Struct
+	for i := 0; d.dec.More(); i++ {
+		tok, err := d.dec.Token()
+		if err != nil {
+			return capnp.List{}, err
+		}
+		var s capnp.Struct
+		switch tok {
+		case nil:
+		case json.Delim('{'):
+			s, err = capnp.NewStruct(seg, sz)
+			if err == nil {
+				err = d.readStruct(s, n)
+			}
+		default:
+			err = fmt.Errorf("expected object, got %s", valueconv.Describe(tok))
+		}
+		if err != nil {
+			return capnp.List{}, valueconv.ElemError(i, err)
+		}
+		elems = append(elems, s)
+	}
+	if _, err := d.dec.Token(); err != nil {
+		return capnp.List{}, err
+	}
+	l, err := capnp.NewCompositeList(seg, sz, int32(len(elems)))
+	if err != nil {
+		return capnp.List{}, err
+	}
+	for i, src := range elems {
+		if !src.IsValid() {
+			continue
+		}
+		dst := l.Struct(i)
+		for off := capnp.Size(0); off < sz.DataSize; off += 8 {
+			dst.SetUint64(capnp.DataOffset(off), src.Uint64(capnp.DataOffset(off)))
+		}
+		for j := uint16(0); j < sz.PointerCount; j++ {
+			p, err := src.Ptr(j)
+			if err != nil {
+				return capnp.List{}, valueconv.ElemError(i, err)
+			}
+			if err := dst.SetPtr(j, p); err != nil {
+				return capnp.List{}, valueconv.ElemError(i, err)
+			}
+		}
+	}
+	return l, nil
+}
+
+// scalar converts a token to the bits of a value of the non-pointer
+// type typ.
+func (d *Decoder) scalar(typ schema.Type, tok json.Token) (uint64, error) {
+	switch which := typ.Which(); which {
+	case schema.Type_Which_bool:
+		v, ok := tok.(bool)
+		if !ok {
+			return 0, fmt.Errorf("expected bool, got %s", valueconv.Describe(tok))
+		}
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case schema.Type_Which_int8, schema.Type_Which_int16, schema.Type_Which_int32, schema.Type_Which_int64:
+		v, err := valueconv.Int(tok, int(scalarBits(which)))
+		return uint64(v), err
+	case schema.Type_Which_uint8, schema.Type_Which_uint16, schema.Type_Which_uint32, schema.Type_Which_uint64:
+		return valueconv.Uint(tok, int(scalarBits(which)))
+	case schema.Type_Which_float32:
+		v, err := valueconv.Float(tok, 32)
+		return uint64(math.Float32bits(float32(v))), err
+	case schema.Type_Which_float64:
+		v, err := valueconv.Float(tok, 64)
+		return math.Float64bits(v), err
+	case schema.Type_Which_enum:
+		v, err := d.toEnum(typ.Enum().TypeId(), tok)
+		return uint64(v), err
+	default:
+		return 0, fmt.Errorf("%v is not a scalar type", which)
+	}
+}
+
+func (d *Decoder) toEnum(typeID uint64, tok json.Token) (uint16, error) {
+	var name string
+	switch tok := tok.(type) {
+	case string:
+		name = tok
+	case json.Number:
+		v, err := valueconv.Uint(tok, 16)
+		return uint16(v), err
+	default:
+		return 0, fmt.Errorf("expected enum, got %s", valueconv.Describe(tok))
+	}
+	enums, err := enumerants(&d.nodes, typeID)
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i < enums.Len(); i++ {
+		if en, _ := enums.At(i).Name(); en == name {
+			return uint16(i), nil
+		}
+	}
+	return 0, fmt.Errorf("no enumerant %s", name)
+}
+
+// setScalar sets the value of a non-pointer field of the given size at
+// the offset off, in multiples of the size.
+func setScalar(s capnp.Struct, off uint32, bits uint32, v uint64) {
+	switch bits {
+	case 1:
+		s.SetBit(capnp.BitOffset(off), v != 0)
+	case 8:
+		s.SetUint8(capnp.DataOffset(off), uint8(v))
+	case 16:
+		s.SetUint16(capnp.DataOffset(off*2), uint16(v))
+	case 32:
+		s.SetUint32(capnp.DataOffset(off*4), uint32(v))
+	case 64:
+		s.SetUint64(capnp.DataOffset(off*8), v)
+	}
+}
+
+// defaultBits returns the bits of a non-pointer default value.
+func defaultBits(dv schema.Value) uint64 {
+	switch dv.Which() {
+	case schema.Value_Which_bool:
+		if dv.Bool() {
+			return 1
+		}
+	case schema.Value_Which_int8:
+		return uint64(uint8(dv.Int8()))
+	case schema.Value_Which_int16:
+		return uint64(uint16(dv.Int16()))
+	case schema.Value_Which_int32:
+		return uint64(uint32(dv.Int32()))
+	case schema.Value_Which_int64:
+		return uint64(dv.Int64())
+	case schema.Value_Which_uint8:
+		return uint64(dv.Uint8())
+	case schema.Value_Which_uint16:
+		return uint64(dv.Uint16())
+	case schema.Value_Which_uint32:
+		return uint64(dv.Uint32())
+	case schema.Value_Which_uint64:
+		return dv.Uint64()
+	case schema.Value_Which_float32:
+		return uint64(math.Float32bits(dv.Float32()))
+	case schema.Value_Which_float64:
+		return math.Float64bits(dv.Float64())
+	case schema.Value_Which_enum:
+		return uint64(dv.Enum())
+	}
+	return 0
+}
+
+// newScalarList returns a new list of values of the given size.  The
+// layout of a list depends only on the size of its elements, so lists
+// of signed integers, floats, and enums are built as unsigned lists.
+func newScalarList(seg *capnp.Segment, bits uint32, vals []uint64) (capnp.List, error) {
+	n := int32(len(vals))
+	switch bits {
+	case 1:
+		l, err := capnp.NewBitList(seg, n)
+		for i, v := range vals {
+			l.Set(i, v != 0)
+		}
+		return l.List, err
+	case 8:
+		l, err := capnp.NewUInt8List(seg, n)
+		for i, v := range vals {
+			l.Set(i, uint8(v))
+		}
+		return l.List, err
+	case 16:
+		l, err := capnp.NewUInt16List(seg, n)
+		for i, v := range vals {
+			l.Set(i, uint16(v))
+		}
+		return l.List, err
+	case 32:
+		l, err := capnp.NewUInt32List(seg, n)
+		for i, v := range vals {
+			l.Set(i, uint32(v))
+		}
+		return l.List, err
+	default:
+		l, err := capnp.NewUInt64List(seg, n)
+		for i, v := range vals {
+			l.Set(i, v)
+		}
+		return l.List, err
+	}
+}
+
+func toData(tok json.Token) ([]byte, error) {
+	s, err := valueconv.String(tok, "data")
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(s)
+}
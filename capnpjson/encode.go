@@ -0,0 +1,343 @@
+package capnpjson
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/nodemap"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+	"github.com/iguazio/go-capnproto2/schemas"
+)
+
+// An Encoder writes structs as JSON objects.
+type Encoder struct {
+	w     errWriter
+	tmp   []byte
+	nodes nodemap.Map
+}
+
+// NewEncoder returns a new encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: errWriter{w: w}}
+}
+
+// UseRegistry changes the registry that the encoder consults for
+// schemas.  The default is schemas.DefaultRegistry.
+func (enc *Encoder) UseRegistry(reg *schemas.Registry) {
+	enc.nodes.UseRegistry(reg)
+}
+
+// Encode writes the JSON representation of s, a struct of the type
+// with the given ID.  Encode does not write a trailing newline.
+func (enc *Encoder) Encode(typeID uint64, s capnp.Struct) error {
+	if enc.w.err != nil {
+		return enc.w.err
+	}
+	if err := enc.writeStruct(typeID, s); err != nil {
+		return fmt.Errorf("capnpjson: %v", err)
+	}
+	return enc.w.err
+}
+
+func (enc *Encoder) writeStruct(typeID uint64, s capnp.Struct) error {
+	n, err := structNode(&enc.nodes, typeID)
+	if err != nil {
+		return err
+	}
+	var discriminant uint16
+	if n.StructNode().DiscriminantCount() > 0 {
+		discriminant = s.Uint16(capnp.DataOffset(n.StructNode().DiscriminantOffset() * 2))
+	}
+	fields, err := n.StructNode().Fields()
+	if err != nil {
+		return err
+	}
+	enc.w.WriteByte('{')
+	first := true
+	for _, f := range codeOrderFields(fields) {
+		if dv := f.DiscriminantValue(); !(dv == schema.Field_noDiscriminant || dv == discriminant) {
+			continue
+		}
+		if !first {
+			enc.w.WriteByte(',')
+		}
+		first = false
+		name, err := f.Name()
+		if err != nil {
+			return err
+		}
+		enc.writeString(name)
+		enc.w.WriteByte(':')
+		switch f.Which() {
+		case schema.Field_Which_slot:
+			err = enc.writeField(s, f)
+		case schema.Field_Which_group:
+			err = enc.writeStruct(f.Group().TypeId(), s)
+		default:
+			err = fmt.Errorf("field %s has unknown kind %v", name, f.Which())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	enc.w.WriteByte('}')
+	return nil
+}
+
+func (enc *Encoder) writeField(s capnp.Struct, f schema.Field) error {
+	typ, err := f.Slot().Type()
+	if err != nil {
+		return err
+	}
+	dv, err := f.Slot().DefaultValue()
+	if err != nil {
+		return err
+	}
+	off := f.Slot().Offset()
+	switch typ.Which() {
+	case schema.Type_Which_void:
+		enc.w.WriteString("null")
+	case schema.Type_Which_bool:
+		enc.writeBool(s.Bit(capnp.BitOffset(off)) != dv.Bool())
+	case schema.Type_Which_int8:
+		enc.writeInt(int64(int8(s.Uint8(capnp.DataOffset(off)) ^ uint8(dv.Int8()))))
+	case schema.Type_Which_int16:
+		enc.writeInt(int64(int16(s.Uint16(capnp.DataOffset(off*2)) ^ uint16(dv.Int16()))))
+	case schema.Type_Which_int32:
+		enc.writeInt(int64(int32(s.Uint32(capnp.DataOffset(off*4)) ^ uint32(dv.Int32()))))
+	case schema.Type_Which_int64:
+		enc.writeInt(int64(s.Uint64(capnp.DataOffset(off*8)) ^ uint64(dv.Int64())))
+	case schema.Type_Which_uint8:
+		enc.writeUint(uint64(s.Uint8(capnp.DataOffset(off)) ^ dv.Uint8()))
+	case schema.Type_Which_uint16:
+		enc.writeUint(uint64(s.Uint16(capnp.DataOffset(off*2)) ^ dv.Uint16()))
+	case schema.Type_Which_uint32:
+		enc.writeUint(uint64(s.Uint32(capnp.DataOffset(off*4)) ^ dv.Uint32()))
+	case schema.Type_Which_uint64:
+		enc.writeUint(s.Uint64(capnp.DataOffset(off*8)) ^ dv.Uint64())
+	case schema.Type_Which_float32:
+		v := s.Uint32(capnp.DataOffset(off*4)) ^ math.Float32bits(dv.Float32())
+		enc.writeFloat(float64(math.Float32frombits(v)), 32)
+	case schema.Type_Which_float64:
+		v := s.Uint64(capnp.DataOffset(off*8)) ^ math.Float64bits(dv.Float64())
+		enc.writeFloat(math.Float64frombits(v), 64)
+	case schema.Type_Which_enum:
+		v := s.Uint16(capnp.DataOffset(off*2)) ^ dv.Uint16()
+		return enc.writeEnum(typ.Enum().TypeId(), v)
+	case schema.Type_Which_text:
+		p, err := s.Ptr(uint16(off))
+		if err != nil {
+			return err
+		}
+		if !p.IsValid() {
+			d, _ := dv.Text()
+			enc.writeString(d)
+			return nil
+		}
+		enc.writeString(p.Text())
+	case schema.Type_Which_data:
+		p, err := s.Ptr(uint16(off))
+		if err != nil {
+			return err
+		}
+		if !p.IsValid() {
+			d, _ := dv.Data()
+			enc.writeData(d)
+			return nil
+		}
+		enc.writeData(p.Data())
+	case schema.Type_Which_structType:
+		p, err := s.Ptr(uint16(off))
+		if err != nil {
+			return err
+		}
+		if !p.IsValid() {
+			p, _ = dv.StructValuePtr()
+		}
+		return enc.writeStruct(typ.StructType().TypeId(), p.Struct())
+	case schema.Type_Which_list:
+		elem, err := typ.List().ElementType()
+		if err != nil {
+			return err
+		}
+		p, err := s.Ptr(uint16(off))
+		if err != nil {
+			return err
+		}
+		if !p.IsValid() {
+			p, _ = dv.ListPtr()
+		}
+		return enc.writeList(elem, p.List())
+	case schema.Type_Which_interface, schema.Type_Which_anyPointer:
+		enc.w.WriteString("null")
+	default:
+		return fmt.Errorf("unknown field type %v", typ.Which())
+	}
+	return nil
+}
+
+func (enc *Encoder) writeList(elem schema.Type, l capnp.List) error {
+	enc.w.WriteByte('[')
+	for i := 0; i < l.Len(); i++ {
+		if i > 0 {
+			enc.w.WriteByte(',')
+		}
+		if err := enc.writeElem(elem, l, i); err != nil {
+			return err
+		}
+	}
+	enc.w.WriteByte(']')
+	return nil
+}
+
+func (enc *Encoder) writeElem(elem schema.Type, l capnp.List, i int) error {
+	switch elem.Which() {
+	case schema.Type_Which_void, schema.Type_Which_interface, schema.Type_Which_anyPointer:
+		enc.w.WriteString("null")
+	case schema.Type_Which_bool:
+		enc.writeBool(capnp.BitList{List: l}.At(i))
+	case schema.Type_Which_int8:
+		enc.writeInt(int64(capnp.Int8List{List: l}.At(i)))
+	case schema.Type_Which_int16:
+		enc.writeInt(int64(capnp.Int16List{List: l}.At(i)))
+	case schema.Type_Which_int32:
+		enc.writeInt(int64(capnp.Int32List{List: l}.At(i)))
+	case schema.Type_Which_int64:
+		enc.writeInt(capnp.Int64List{List: l}.At(i))
+	case schema.Type_Which_uint8:
+		enc.writeUint(uint64(capnp.UInt8List{List: l}.At(i)))
+	case schema.Type_Which_uint16:
+		enc.writeUint(uint64(capnp.UInt16List{List: l}.At(i)))
+	case schema.Type_Which_uint32:
+		enc.writeUint(uint64(capnp.UInt32List{List: l}.At(i)))
+	case schema.Type_Which_uint64:
+		enc.writeUint(capnp.UInt64List{List: l}.At(i))
+	case schema.Type_Which_float32:
+		enc.writeFloat(float64(capnp.Float32List{List: l}.At(i)), 32)
+	case schema.Type_Which_float64:
+		enc.writeFloat(capnp.Float64List{List: l}.At(i), 64)
+	case schema.Type_Which_enum:
+		return enc.writeEnum(elem.Enum().TypeId(), capnp.UInt16List{List: l}.At(i))
+	case schema.Type_Which_text:
+		v, err := capnp.TextList{List: l}.At(i)
+		if err != nil {
+			return err
+		}
+		enc.writeString(v)
+	case schema.Type_Which_data:
+		v, err := capnp.DataList{List: l}.At(i)
+		if err != nil {
+			return err
+		}
+		enc.writeData(v)
+	case schema.Type_Which_structType:
+		return enc.writeStruct(elem.StructType().TypeId(), l.Struct(i))
+	case schema.Type_Which_list:
+		ee, err := elem.List().ElementType()
+		if err != nil {
+			return err
+		}
+		p, err := capnp.PointerList{List: l}.PtrAt(i)
+		if err != nil {
+			return err
+		}
+		return enc.writeList(ee, p.List())
+	default:
+		return fmt.Errorf("unknown list type %v", elem.Which())
+	}
+	return nil
+}
+
+func (enc *Encoder) writeEnum(typeID uint64, v uint16) error {
+	enums, err := enumerants(&enc.nodes, typeID)
+	if err != nil {
+		return err
+	}
+	if int(v) >= enums.Len() {
+		enc.writeUint(uint64(v))
+		return nil
+	}
+	name, err := enums.At(int(v)).Name()
+	if err != nil {
+		return err
+	}
+	enc.writeString(name)
+	return nil
+}
+
+func (enc *Encoder) writeBool(v bool) {
+	enc.w.WriteString(strconv.FormatBool(v))
+}
+
+func (enc *Encoder) writeInt(i int64) {
+	enc.tmp = strconv.AppendInt(enc.tmp[:0], i, 10)
+	enc.w.Write(enc.tmp)
+}
+
+func (enc *Encoder) writeUint(i uint64) {
+	enc.tmp = strconv.AppendUint(enc.tmp[:0], i, 10)
+	enc.w.Write(enc.tmp)
+}
+
+func (enc *Encoder) writeFloat(f float64, bits int) {
+	switch {
+	case math.IsNaN(f):
+		enc.w.WriteString(`"NaN"`)
+	case math.IsInf(f, 1):
+		enc.w.WriteString(`"Infinity"`)
+	case math.IsInf(f, -1):
+		enc.w.WriteString(`"-Infinity"`)
+	default:
+		enc.tmp = strconv.AppendFloat(enc.tmp[:0], f, 'g', -1, bits)
+		enc.w.Write(enc.tmp)
+	}
+}
+
+func (enc *Encoder) writeString(s string) {
+	// Marshaling a string can't fail.
+	b, _ := json.Marshal(s)
+	enc.w.Write(b)
+}
+
+func (enc *Encoder) writeData(b []byte) {
+	enc.w.WriteByte('"')
+	enc.w.WriteString(base64.StdEncoding.EncodeToString(b))
+	enc.w.WriteByte('"')
+}
+
+func codeOrderFields(list schema.Field_List) []schema.Field {
+	fields := make([]schema.Field, list.Len())
+	for i := range fields {
+		f := list.At(i)
+		fields[f.CodeOrder()] = f
+	}
+	return fields
+}
+
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) Write(p []byte) (int, error) {
+	if ew.err != nil {
+		return 0, ew.err
+	}
+	var n int
+	n, ew.err = ew.w.Write(p)
+	return n, ew.err
+}
+
+func (ew *errWriter) WriteString(s string) (int, error) {
+	return ew.Write([]byte(s))
+}
+
+func (ew *errWriter) WriteByte(b byte) error {
+	_, err := ew.Write([]byte{b})
+	return err
+}
@@ -0,0 +1,493 @@
+// Package capnplog stores Cap'n Proto messages in an append-only log
+// file that can be read back sequentially or by message index.
+//
+// A log starts with a 16-byte header:
+//
+//	bytes 0-7   magic "capnplog"
+//	bytes 8-11  version, currently 1, little-endian
+//	bytes 12-15 flags; bit 0 is set if messages are packed
+//
+// Each message is a record: the little-endian 32-bit length of the
+// payload, the little-endian CRC-32C of the payload, and the payload,
+// which is the message in the standard stream encoding (packed if the
+// log is).  Closing a Writer appends an index footer:
+//
+//	count   uint64
+//	offsets count × uint64, the file offset of each record
+//	crc     uint32, the CRC-32C of count and offsets
+//	        uint32, reserved
+//	start   uint64, the file offset of count
+//	magic   "capnpidx"
+//
+// A log without a footer, such as one whose writer crashed, is still
+// readable: readers find the records by scanning, and ignore an
+// incomplete record at the end.
+package capnplog // import "github.com/iguazio/go-capnproto2/capnplog"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"github.com/iguazio/go-capnproto2"
+)
+
+// Version is the log format version written by this package.
+const Version = 1
+
+const (
+	headerSize       = 16
+	recordHeaderSize = 8
+	trailerSize      = 24
+	flagPacked       = 1 << 0
+)
+
+var (
+	logMagic   = []byte("capnplog")
+	indexMagic = []byte("capnpidx")
+	crcTable   = crc32.MakeTable(crc32.Castagnoli)
+)
+
+var (
+	errCorrupt  = errors.New("log is corrupt")
+	errChecksum = errors.New("checksum mismatch")
+)
+
+// A Writer appends messages to a log.
+type Writer struct {
+	w       *bufio.Writer
+	f       *os.File // non-nil if the Writer owns the file
+	packed  bool
+	off     int64
+	offsets []int64
+	buf     []byte
+	err     error
+}
+
+// NewWriter writes the header of a new log to w and returns a Writer
+// that appends unpacked messages to it.
+func NewWriter(w io.Writer) (*Writer, error) {
+	return newWriter(w, nil, false)
+}
+
+// NewPackedWriter writes the header of a new log to w and returns a
+// Writer that appends packed messages to it.
+func NewPackedWriter(w io.Writer) (*Writer, error) {
+	return newWriter(w, nil, true)
+}
+
+func newWriter(w io.Writer, f *os.File, packed bool) (*Writer, error) {
+	lw := &Writer{w: bufio.NewWriter(w), f: f, packed: packed}
+	var hdr [headerSize]byte
+	copy(hdr[:], logMagic)
+	binary.LittleEndian.PutUint32(hdr[8:], Version)
+	if packed {
+		binary.LittleEndian.PutUint32(hdr[12:], flagPacked)
+	}
+	if _, err := lw.w.Write(hdr[:]); err != nil {
+		return nil, err
+	}
+	lw.off = headerSize
+	return lw, nil
+}
+
+// Create creates a new log file, truncating any existing file.
+func Create(name string, packed bool) (*Writer, error) {
+	f, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	w, err := newWriter(f, f, packed)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// OpenWriter opens an existing log file for appending.  If the file has
+// an index footer, the footer is removed and rewritten by Close.  If it
+// doesn't, the records are scanned and an incomplete record at the end
+// is removed.
+func OpenWriter(name string) (*Writer, error) {
+	f, err := os.OpenFile(name, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	idx, err := readIndex(f, fi.Size())
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("capnplog: open %s: %v", name, err)
+	}
+	if err := f.Truncate(idx.end); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if _, err := f.Seek(idx.end, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &Writer{
+		w:       bufio.NewWriter(f),
+		f:       f,
+		packed:  idx.packed,
+		off:     idx.end,
+		offsets: idx.offsets,
+	}, nil
+}
+
+// Append writes msg to the log and returns its index.
+func (w *Writer) Append(msg *capnp.Message) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	var data []byte
+	var err error
+	if w.packed {
+		data, err = msg.MarshalPacked()
+	} else {
+		data, err = msg.Marshal()
+	}
+	if err != nil {
+		return 0, err
+	}
+	if int64(len(data)) > int64(^uint32(0)) {
+		return 0, errors.New("capnplog: message too large")
+	}
+	var hdr [recordHeaderSize]byte
+	binary.LittleEndian.PutUint32(hdr[:], uint32(len(data)))
+	binary.LittleEndian.PutUint32(hdr[4:], crc32.Checksum(data, crcTable))
+	if _, err := w.w.Write(hdr[:]); err != nil {
+		w.err = err
+		return 0, err
+	}
+	if _, err := w.w.Write(data); err != nil {
+		w.err = err
+		return 0, err
+	}
+	w.offsets = append(w.offsets, w.off)
+	w.off += recordHeaderSize + int64(len(data))
+	return len(w.offsets) - 1, nil
+}
+
+// Len returns the number of messages in the log.
+func (w *Writer) Len() int {
+	return len(w.offsets)
+}
+
+// Flush writes buffered records to the underlying writer.  The records
+// are readable, but the log has no index footer until Close.
+func (w *Writer) Flush() error {
+	if w.err != nil {
+		return w.err
+	}
+	if err := w.w.Flush(); err != nil {
+		w.err = err
+	}
+	return w.err
+}
+
+// Close writes the index footer and flushes the log.  If the Writer was
+// returned by Create or OpenWriter, Close also closes the file.
+func (w *Writer) Close() error {
+	err := w.writeIndex()
+	if w.f != nil {
+		if cerr := w.f.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if w.err == nil {
+		w.err = errors.New("capnplog: writer closed")
+	}
+	return err
+}
+
+func (w *Writer) writeIndex() error {
+	if w.err != nil {
+		return w.err
+	}
+	w.buf = w.buf[:0]
+	w.buf = appendUint64(w.buf, uint64(len(w.offsets)))
+	for _, off := range w.offsets {
+		w.buf = appendUint64(w.buf, uint64(off))
+	}
+	crc := crc32.Checksum(w.buf, crcTable)
+	var trailer [trailerSize]byte
+	binary.LittleEndian.PutUint32(trailer[:], crc)
+	binary.LittleEndian.PutUint64(trailer[8:], uint64(w.off))
+	copy(trailer[16:], indexMagic)
+	w.buf = append(w.buf, trailer[:]...)
+	if _, err := w.w.Write(w.buf); err != nil {
+		return err
+	}
+	return w.w.Flush()
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var x [8]byte
+	binary.LittleEndian.PutUint64(x[:], v)
+	return append(b, x[:]...)
+}
+
+// A Reader reads messages from a log.
+type Reader struct {
+	r       io.ReaderAt
+	c       io.Closer
+	packed  bool
+	offsets []int64
+	end     int64 // offset after the last record
+	next    int
+
+	// MaxMessageSize is the largest payload that Next will read.
+	// If zero, it defaults to 64 MiB.
+	MaxMessageSize uint32
+}
+
+const defaultMaxMessageSize = 64 << 20
+
+// NewReader reads the index of the log in r, which is size bytes long.
+// If the log has no footer, NewReader scans its records instead.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	idx, err := readIndex(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("capnplog: %v", err)
+	}
+	return &Reader{r: r, packed: idx.packed, offsets: idx.offsets, end: idx.end}, nil
+}
+
+// Open opens the log file with the given name for reading.
+func Open(name string) (*Reader, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	r, err := NewReader(f, fi.Size())
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("capnplog: open %s: %v", name, err)
+	}
+	r.c = f
+	return r, nil
+}
+
+// Len returns the number of messages in the log.
+func (r *Reader) Len() int {
+	return len(r.offsets)
+}
+
+// Packed reports whether the log's messages are packed.
+func (r *Reader) Packed() bool {
+	return r.packed
+}
+
+// Seek positions the reader so that the next call to Next returns the
+// i'th message.  Seeking to Len is allowed, after which Next returns
+// io.EOF.
+func (r *Reader) Seek(i int) error {
+	if i < 0 || i > len(r.offsets) {
+		return fmt.Errorf("capnplog: seek to message %d of %d", i, len(r.offsets))
+	}
+	r.next = i
+	return nil
+}
+
+// Next reads the next message.  It returns io.EOF after the last
+// message.  The message does not alias the reader's buffers.
+func (r *Reader) Next() (*capnp.Message, error) {
+	if r.next >= len(r.offsets) {
+		return nil, io.EOF
+	}
+	off := r.offsets[r.next]
+	limit := r.MaxMessageSize
+	if limit == 0 {
+		limit = defaultMaxMessageSize
+	}
+	n, err := readRecordHeader(r.r, off, r.end)
+	if err != nil {
+		return nil, fmt.Errorf("capnplog: message %d: %v", r.next, err)
+	}
+	if n.size > limit {
+		return nil, fmt.Errorf("capnplog: message %d: size %d exceeds limit", r.next, n.size)
+	}
+	data := make([]byte, n.size)
+	if _, err := r.r.ReadAt(data, off+recordHeaderSize); err != nil {
+		return nil, fmt.Errorf("capnplog: message %d: %v", r.next, err)
+	}
+	if crc32.Checksum(data, crcTable) != n.crc {
+		return nil, fmt.Errorf("capnplog: message %d: %v", r.next, errChecksum)
+	}
+	var msg *capnp.Message
+	if r.packed {
+		msg, err = capnp.UnmarshalPacked(data)
+	} else {
+		msg, err = capnp.Unmarshal(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("capnplog: message %d: %v", r.next, err)
+	}
+	r.next++
+	return msg, nil
+}
+
+// Close closes the file if the Reader was returned by Open.
+func (r *Reader) Close() error {
+	if r.c == nil {
+		return nil
+	}
+	return r.c.Close()
+}
+
+// An index is the parsed header and record offsets of a log.
+type index struct {
+	packed  bool
+	offsets []int64
+	end     int64 // offset after the last record
+}
+
+// readIndex reads the header of a log and then its footer, or if the log
+// has no valid footer, scans its records.
+func readIndex(r io.ReaderAt, size int64) (*index, error) {
+	var hdr [headerSize]byte
+	if size < headerSize {
+		return nil, errCorrupt
+	}
+	if _, err := r.ReadAt(hdr[:], 0); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(hdr[:8], logMagic) {
+		return nil, errors.New("not a capnp log")
+	}
+	if v := binary.LittleEndian.Uint32(hdr[8:]); v != Version {
+		return nil, fmt.Errorf("unsupported version %d", v)
+	}
+	flags := binary.LittleEndian.Uint32(hdr[12:])
+	if flags&^flagPacked != 0 {
+		return nil, errCorrupt
+	}
+	idx := &index{packed: flags&flagPacked != 0}
+	if ok, err := idx.readFooter(r, size); err != nil {
+		return nil, err
+	} else if ok {
+		return idx, nil
+	}
+	return idx, idx.scan(r, size)
+}
+
+// readFooter reads the index footer, returning false if the log does
+// not end in a valid footer.
+func (idx *index) readFooter(r io.ReaderAt, size int64) (bool, error) {
+	if size < headerSize+8+trailerSize {
+		return false, nil
+	}
+	var trailer [trailerSize]byte
+	if _, err := r.ReadAt(trailer[:], size-trailerSize); err != nil {
+		return false, err
+	}
+	if !bytes.Equal(trailer[16:], indexMagic) {
+		return false, nil
+	}
+	start := int64(binary.LittleEndian.Uint64(trailer[8:]))
+	if start < headerSize || start > size-trailerSize-8 {
+		return false, nil
+	}
+	data := make([]byte, size-trailerSize-start)
+	if _, err := r.ReadAt(data, start); err != nil {
+		return false, err
+	}
+	if crc32.Checksum(data, crcTable) != binary.LittleEndian.Uint32(trailer[:]) {
+		return false, nil
+	}
+	count := binary.LittleEndian.Uint64(data)
+	if count != uint64(len(data)-8)/8 || len(data)%8 != 0 {
+		return false, nil
+	}
+	offsets := make([]int64, count)
+	prev := int64(headerSize) - recordHeaderSize
+	for i := range offsets {
+		off := int64(binary.LittleEndian.Uint64(data[8+8*i:]))
+		if off < prev+recordHeaderSize || off > start-recordHeaderSize {
+			return false, errCorrupt
+		}
+		offsets[i] = off
+		prev = off
+	}
+	idx.offsets = offsets
+	idx.end = start
+	return true, nil
+}
+
+// scan finds the records of a log by reading each record header in
+// turn.  It stops at an incomplete record, but reports an error for a
+// complete record with a bad checksum.
+func (idx *index) scan(r io.ReaderAt, size int64) error {
+	off := int64(headerSize)
+	var buf []byte
+	for {
+		h, err := readRecordHeader(r, off, size)
+		if err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if h.size == 0 {
+			// Writers never write empty records, so this is space that
+			// was allocated but not written before a crash.
+			break
+		}
+		if int64(cap(buf)) < int64(h.size) {
+			buf = make([]byte, h.size)
+		}
+		buf = buf[:h.size]
+		if _, err := r.ReadAt(buf, off+recordHeaderSize); err != nil {
+			return err
+		}
+		if crc32.Checksum(buf, crcTable) != h.crc {
+			return fmt.Errorf("record at offset %d: %v", off, errChecksum)
+		}
+		idx.offsets = append(idx.offsets, off)
+		off += recordHeaderSize + int64(h.size)
+	}
+	idx.end = off
+	return nil
+}
+
+type recordHeader struct {
+	size uint32
+	crc  uint32
+}
+
+// readRecordHeader reads the header of the record at off, returning
+// io.ErrUnexpectedEOF if the record does not fit before end.
+func readRecordHeader(r io.ReaderAt, off, end int64) (recordHeader, error) {
+	if off+recordHeaderSize > end {
+		return recordHeader{}, io.ErrUnexpectedEOF
+	}
+	var b [recordHeaderSize]byte
+	if _, err := r.ReadAt(b[:], off); err != nil {
+		return recordHeader{}, err
+	}
+	h := recordHeader{
+		size: binary.LittleEndian.Uint32(b[:]),
+		crc:  binary.LittleEndian.Uint32(b[4:]),
+	}
+	if off+recordHeaderSize+int64(h.size) > end {
+		return recordHeader{}, io.ErrUnexpectedEOF
+	}
+	return h, nil
+}
@@ -0,0 +1,208 @@
+package capnplog
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+func newMessage(t *testing.T, year int16) *capnp.Message {
+	msg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := air.NewRootZdate(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetYear(year)
+	return msg
+}
+
+func readYear(t *testing.T, r *Reader) int16 {
+	msg, err := r.Next()
+	if err != nil {
+		t.Fatal("Next:", err)
+	}
+	d, err := air.ReadRootZdate(msg)
+	if err != nil {
+		t.Fatal("ReadRootZdate:", err)
+	}
+	return d.Year()
+}
+
+// writeLog writes a log of n messages with years 0 through n-1.
+func writeLog(t *testing.T, n int, packed bool, close bool) []byte {
+	var buf bytes.Buffer
+	var w *Writer
+	var err error
+	if packed {
+		w, err = NewPackedWriter(&buf)
+	} else {
+		w, err = NewWriter(&buf)
+	}
+	if err != nil {
+		t.Fatal("NewWriter:", err)
+	}
+	for i := 0; i < n; i++ {
+		if idx, err := w.Append(newMessage(t, int16(i))); err != nil {
+			t.Fatal("Append:", err)
+		} else if idx != i {
+			t.Fatalf("Append #%d returned index %d", i, idx)
+		}
+	}
+	if close {
+		err = w.Close()
+	} else {
+		err = w.Flush()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadWrite(t *testing.T) {
+	for _, packed := range []bool{false, true} {
+		for _, closed := range []bool{false, true} {
+			data := writeLog(t, 10, packed, closed)
+			r, err := NewReader(bytes.NewReader(data), int64(len(data)))
+			if err != nil {
+				t.Fatalf("packed=%t closed=%t: NewReader: %v", packed, closed, err)
+			}
+			if r.Len() != 10 || r.Packed() != packed {
+				t.Errorf("packed=%t closed=%t: Len() = %d, Packed() = %t", packed, closed, r.Len(), r.Packed())
+			}
+			for i := 0; i < 10; i++ {
+				if y := readYear(t, r); y != int16(i) {
+					t.Errorf("packed=%t closed=%t: message %d has year %d", packed, closed, i, y)
+				}
+			}
+			if _, err := r.Next(); err != io.EOF {
+				t.Errorf("packed=%t closed=%t: Next at end = %v; want EOF", packed, closed, err)
+			}
+			if err := r.Seek(7); err != nil {
+				t.Fatal("Seek:", err)
+			}
+			if y := readYear(t, r); y != 7 {
+				t.Errorf("packed=%t closed=%t: after Seek(7), year = %d", packed, closed, y)
+			}
+		}
+	}
+}
+
+func TestSeek_OutOfRange(t *testing.T) {
+	data := writeLog(t, 2, false, true)
+	r, err := NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal("NewReader:", err)
+	}
+	if err := r.Seek(3); err == nil {
+		t.Error("Seek(3) did not return an error")
+	}
+	if err := r.Seek(2); err != nil {
+		t.Error("Seek(2):", err)
+	}
+}
+
+func TestReader_TornTail(t *testing.T) {
+	data := writeLog(t, 3, false, false)
+	full := len(data)
+	data = append(data, writeLog(t, 1, false, false)[headerSize:]...)
+	data = data[:len(data)-3] // record cut off mid-payload
+	r, err := NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal("NewReader:", err)
+	}
+	if r.Len() != 3 {
+		t.Errorf("Len() = %d; want 3", r.Len())
+	}
+	if r.end != int64(full) {
+		t.Errorf("end = %d; want %d", r.end, full)
+	}
+}
+
+func TestReader_Corrupt(t *testing.T) {
+	data := writeLog(t, 3, false, false)
+	data[len(data)-1] ^= 0xff
+	r, err := NewReader(bytes.NewReader(data), int64(len(data)))
+	if err == nil {
+		t.Fatalf("NewReader of log with bad checksum succeeded with %d messages", r.Len())
+	}
+
+	// With an index, the corruption is found when reading the message.
+	data = writeLog(t, 3, false, true)
+	r, err = NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal("NewReader:", err)
+	}
+	off := r.offsets[1] + recordHeaderSize
+	data[off] ^= 0xff
+	r.Seek(1)
+	if _, err := r.Next(); err == nil {
+		t.Error("Next of corrupt message did not return an error")
+	}
+
+	if _, err := NewReader(bytes.NewReader([]byte("not a log at all")), 16); err == nil {
+		t.Error("NewReader of non-log did not return an error")
+	}
+}
+
+func TestFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capnplog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "log")
+
+	w, err := Create(name, true)
+	if err != nil {
+		t.Fatal("Create:", err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := w.Append(newMessage(t, int16(i))); err != nil {
+			t.Fatal("Append:", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal("Close:", err)
+	}
+
+	// Reopen and append more, replacing the footer.
+	w, err = OpenWriter(name)
+	if err != nil {
+		t.Fatal("OpenWriter:", err)
+	}
+	if w.Len() != 3 {
+		t.Errorf("reopened writer Len() = %d; want 3", w.Len())
+	}
+	if idx, err := w.Append(newMessage(t, 3)); err != nil {
+		t.Fatal("Append:", err)
+	} else if idx != 3 {
+		t.Errorf("Append after reopen returned index %d; want 3", idx)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal("Close:", err)
+	}
+
+	r, err := Open(name)
+	if err != nil {
+		t.Fatal("Open:", err)
+	}
+	defer r.Close()
+	if r.Len() != 4 || !r.Packed() {
+		t.Fatalf("Len() = %d, Packed() = %t; want 4, true", r.Len(), r.Packed())
+	}
+	for i := 0; i < 4; i++ {
+		if y := readYear(t, r); y != int16(i) {
+			t.Errorf("message %d has year %d", i, y)
+		}
+	}
+}
@@ -0,0 +1,555 @@
+// Package capnpredact removes sensitive data from Cap'n Proto messages
+// before they are logged or written to a crash dump.
+//
+// Fields are marked as sensitive in the schema with the $Go.sensitive
+// annotation:
+//
+//	using Go = import "/go.capnp";
+//
+//	struct User {
+//	  name @0 :Text;
+//	  email @1 :Text $Go.sensitive("hash");
+//	  password @2 :Text $Go.sensitive;
+//	}
+//
+// Redact finds the annotations in the schema registry, so the schema
+// must be embedded in the generated code (capnpc-go's default).
+//
+// A field that is redacted with "zero" mode is reset to its default
+// value.  If the field is a pointer, the bytes of the object it points
+// to, and of any objects reachable from it, are overwritten with zeros
+// before the pointer is cleared, so that the data does not linger in
+// the message's segments.  A field that is redacted with "hash" mode is
+// replaced by a hash of its value, so that redacted messages can still
+// be correlated with each other: Text fields become 32 hexadecimal
+// digits, Data fields become 16 bytes, and integer fields hold the
+// leading bits of the hash.  Only Text, Data, and integer fields may
+// be hashed.
+package capnpredact // import "github.com/iguazio/go-capnproto2/capnpredact"
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"sync"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/nodemap"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+	"github.com/iguazio/go-capnproto2/schemas"
+)
+
+// Redact redacts the sensitive fields of s, a struct of the type with
+// the given ID, and of the structs reachable from it.  It uses the
+// schemas in schemas.DefaultRegistry and hashes fields without a key.
+func Redact(typeID uint64, s capnp.Struct) error {
+	return defaultRedactor.Redact(typeID, s)
+}
+
+var defaultRedactor Redactor
+
+// A Redactor redacts messages using the schemas in a registry.
+// Its fields must not be changed after its first use.  A Redactor is
+// safe to use from multiple goroutines.
+type Redactor struct {
+	// Registry is the registry to find schemas in.  If nil,
+	// schemas.DefaultRegistry is used.
+	Registry *schemas.Registry
+
+	// Key is the HMAC-SHA256 key used for hashed fields.  If empty,
+	// hashed fields hold a plain SHA-256 digest, which can be reversed
+	// by guessing when a field has few possible values.
+	Key []byte
+
+	mu    sync.Mutex
+	init  bool
+	nodes nodemap.Map
+	plans map[uint64]*structPlan
+}
+
+// Redact redacts the sensitive fields of s, a struct of the type with
+// the given ID, and of the structs reachable from it.
+func (r *Redactor) Redact(typeID uint64, s capnp.Struct) error {
+	r.mu.Lock()
+	p, err := r.plan(typeID)
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("capnpredact: %v", err)
+	}
+	if !s.IsValid() {
+		return nil
+	}
+	if err := r.redactStruct(p, s); err != nil {
+		return fmt.Errorf("capnpredact: %v", err)
+	}
+	return nil
+}
+
+type mode int
+
+const (
+	keep mode = iota
+	zero
+	hashed
+)
+
+// A structPlan lists the fields of a struct or group that are
+// sensitive or may lead to sensitive fields.
+type structPlan struct {
+	fields []fieldPlan
+
+	// reach is true if the struct has sensitive fields or refers to
+	// structs that might.
+	reach bool
+}
+
+// A fieldPlan describes how to redact a field.
+type fieldPlan struct {
+	name  string
+	mode  mode
+	which schema.Type_Which
+	off   uint32 // slot offset, in units of the type's size
+
+	discOff uint32 // data offset of the union discriminant, in bytes
+	discVal uint16 // schema.Field_noDiscriminant if not in a union
+
+	group *structPlan // for groups
+	ptr   *ptrPlan    // for pointers to descend into
+}
+
+// A ptrPlan describes how to find structs behind a pointer.
+type ptrPlan struct {
+	st   *structPlan // struct type
+	elem *ptrPlan    // list element type
+}
+
+// reaches reports whether the pointer may lead to sensitive fields.
+func (pp *ptrPlan) reaches() bool {
+	for ; pp != nil; pp = pp.elem {
+		if pp.st != nil {
+			return pp.st.reach
+		}
+	}
+	return false
+}
+
+// plan returns the plan for the struct type with the given ID.  The
+// caller must be holding r.mu.  Errors are not prefixed with the
+// package name, since plans are built recursively.
+func (r *Redactor) plan(typeID uint64) (*structPlan, error) {
+	if !r.init {
+		if r.Registry != nil {
+			r.nodes.UseRegistry(r.Registry)
+		}
+		r.plans = make(map[uint64]*structPlan)
+		r.init = true
+	}
+	if p := r.plans[typeID]; p != nil {
+		return p, nil
+	}
+	n, err := r.nodes.Find(typeID)
+	if err != nil {
+		return nil, fmt.Errorf("find struct %#x: %v", typeID, err)
+	}
+	if n.Which() != schema.Node_Which_structNode {
+		return nil, fmt.Errorf("%#x is a %v, not a struct", typeID, n.Which())
+	}
+	// Structs may refer to themselves, so assume that a struct reaches
+	// sensitive fields until its plan is complete.
+	p := &structPlan{reach: true}
+	r.plans[typeID] = p
+	if err := r.addFields(p, "", n); err != nil {
+		// Plans created since this one may refer to it.
+		r.plans = make(map[uint64]*structPlan)
+		return nil, fmt.Errorf("struct %#x: %v", typeID, err)
+	}
+	return p, nil
+}
+
+// addFields fills in p from a struct or group node.
+func (r *Redactor) addFields(p *structPlan, prefix string, n schema.Node) error {
+	fields, err := n.StructNode().Fields()
+	if err != nil {
+		return err
+	}
+	reach := false
+	for i := 0; i < fields.Len(); i++ {
+		f := fields.At(i)
+		name, err := f.Name()
+		if err != nil {
+			return err
+		}
+		fp := fieldPlan{
+			name:    prefix + name,
+			discOff: n.StructNode().DiscriminantOffset() * 2,
+			discVal: f.DiscriminantValue(),
+		}
+		switch f.Which() {
+		case schema.Field_Which_group:
+			g, err := r.nodes.Find(f.Group().TypeId())
+			if err != nil {
+				return fmt.Errorf("field %s: %v", fp.name, err)
+			}
+			fp.group = &structPlan{reach: true}
+			if err := r.addFields(fp.group, fp.name+".", g); err != nil {
+				return err
+			}
+			if !fp.group.reach {
+				continue
+			}
+		case schema.Field_Which_slot:
+			fp.off = f.Slot().Offset()
+			typ, err := f.Slot().Type()
+			if err != nil {
+				return fmt.Errorf("field %s: %v", fp.name, err)
+			}
+			fp.which = typ.Which()
+			fp.mode, err = fieldMode(f)
+			if err != nil {
+				return fmt.Errorf("field %s: %v", fp.name, err)
+			}
+			if fp.mode == hashed && !canHash(fp.which) {
+				return fmt.Errorf("field %s: cannot hash a %v field", fp.name, fp.which)
+			}
+			if fp.mode == keep {
+				fp.ptr, err = r.ptrPlan(typ)
+				if err != nil {
+					return fmt.Errorf("field %s: %v", fp.name, err)
+				}
+				if fp.ptr == nil {
+					continue
+				}
+			}
+		default:
+			continue
+		}
+		p.fields = append(p.fields, fp)
+		if fp.mode != keep || fp.group != nil || fp.ptr.reaches() {
+			reach = true
+		}
+	}
+	p.reach = reach
+	return nil
+}
+
+// ptrPlan returns the plan for descending into a pointer of type t, or
+// nil if t cannot lead to a struct.
+func (r *Redactor) ptrPlan(t schema.Type) (*ptrPlan, error) {
+	switch t.Which() {
+	case schema.Type_Which_structType:
+		st, err := r.plan(t.StructType().TypeId())
+		if err != nil {
+			return nil, err
+		}
+		return &ptrPlan{st: st}, nil
+	case schema.Type_Which_list:
+		et, err := t.List().ElementType()
+		if err != nil {
+			return nil, err
+		}
+		elem, err := r.ptrPlan(et)
+		if elem == nil || err != nil {
+			return nil, err
+		}
+		return &ptrPlan{elem: elem}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// fieldMode returns the redaction mode from a field's annotations.
+func fieldMode(f schema.Field) (mode, error) {
+	anns, err := f.Annotations()
+	if err != nil {
+		return keep, err
+	}
+	for i := 0; i < anns.Len(); i++ {
+		a := anns.At(i)
+		if a.Id() != capnp.Sensitive {
+			continue
+		}
+		v, err := a.Value()
+		if err != nil {
+			return keep, err
+		}
+		m, _ := v.Text()
+		switch m {
+		case "", "zero":
+			return zero, nil
+		case "hash":
+			return hashed, nil
+		default:
+			return keep, fmt.Errorf("unknown redaction mode %q", m)
+		}
+	}
+	return keep, nil
+}
+
+func canHash(w schema.Type_Which) bool {
+	switch w {
+	case schema.Type_Which_text, schema.Type_Which_data,
+		schema.Type_Which_int8, schema.Type_Which_int16, schema.Type_Which_int32, schema.Type_Which_int64,
+		schema.Type_Which_uint8, schema.Type_Which_uint16, schema.Type_Which_uint32, schema.Type_Which_uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+// dataSize returns the size in bytes of a data field, or 0 for bool,
+// void, and pointer fields.
+func dataSize(w schema.Type_Which) uint32 {
+	switch w {
+	case schema.Type_Which_int8, schema.Type_Which_uint8:
+		return 1
+	case schema.Type_Which_int16, schema.Type_Which_uint16, schema.Type_Which_enum:
+		return 2
+	case schema.Type_Which_int32, schema.Type_Which_uint32, schema.Type_Which_float32:
+		return 4
+	case schema.Type_Which_int64, schema.Type_Which_uint64, schema.Type_Which_float64:
+		return 8
+	default:
+		return 0
+	}
+}
+
+func isPointer(w schema.Type_Which) bool {
+	switch w {
+	case schema.Type_Which_text, schema.Type_Which_data, schema.Type_Which_list,
+		schema.Type_Which_structType, schema.Type_Which_interface, schema.Type_Which_anyPointer:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r *Redactor) redactStruct(p *structPlan, s capnp.Struct) error {
+	for i := range p.fields {
+		fp := &p.fields[i]
+		if fp.discVal != schema.Field_noDiscriminant && s.Uint16(capnp.DataOffset(fp.discOff)) != fp.discVal {
+			continue
+		}
+		var err error
+		switch {
+		case fp.group != nil:
+			err = r.redactStruct(fp.group, s)
+		case fp.mode == keep:
+			err = r.descend(fp.ptr, s, fp.off)
+		case fp.mode == zero:
+			err = zeroField(fp, s)
+		case fp.mode == hashed:
+			err = r.hashField(fp, s)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %v", fp.name, err)
+		}
+	}
+	return nil
+}
+
+// descend redacts the structs reachable from the i'th pointer of s.
+func (r *Redactor) descend(pp *ptrPlan, s capnp.Struct, i uint32) error {
+	if !pp.reaches() {
+		return nil
+	}
+	ptr, err := s.Ptr(uint16(i))
+	if err != nil {
+		return err
+	}
+	return r.descendPtr(pp, ptr)
+}
+
+func (r *Redactor) descendPtr(pp *ptrPlan, ptr capnp.Ptr) error {
+	if pp.st != nil {
+		if st := ptr.Struct(); st.IsValid() {
+			return r.redactStruct(pp.st, st)
+		}
+		return nil
+	}
+	l := ptr.List()
+	if !l.IsValid() {
+		return nil
+	}
+	if pp.elem.st != nil {
+		for i := 0; i < l.Len(); i++ {
+			if st := l.Struct(i); st.IsValid() {
+				if err := r.redactStruct(pp.elem.st, st); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	pl := capnp.PointerList{List: l}
+	for i := 0; i < l.Len(); i++ {
+		elem, err := pl.PtrAt(i)
+		if err != nil {
+			return err
+		}
+		if err := r.descendPtr(pp.elem, elem); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func zeroField(fp *fieldPlan, s capnp.Struct) error {
+	switch {
+	case fp.which == schema.Type_Which_bool:
+		if s.Size().DataSize*8 > capnp.Size(fp.off) {
+			s.SetBit(capnp.BitOffset(fp.off), false)
+		}
+	case isPointer(fp.which):
+		ptr, err := s.Ptr(uint16(fp.off))
+		if err != nil {
+			return err
+		}
+		if !ptr.IsValid() {
+			return nil
+		}
+		if err := wipe(ptr); err != nil {
+			return err
+		}
+		return s.SetPtr(uint16(fp.off), capnp.Ptr{})
+	default:
+		sz := dataSize(fp.which)
+		off := capnp.DataOffset(fp.off * sz)
+		if sz == 0 || capnp.Size(off)+capnp.Size(sz) > s.Size().DataSize {
+			return nil
+		}
+		setBits(s, off, sz, 0)
+	}
+	return nil
+}
+
+func (r *Redactor) hashField(fp *fieldPlan, s capnp.Struct) error {
+	h := r.newHash()
+	switch fp.which {
+	case schema.Type_Which_text, schema.Type_Which_data:
+		ptr, err := s.Ptr(uint16(fp.off))
+		if err != nil {
+			return err
+		}
+		if !ptr.IsValid() {
+			return nil
+		}
+		var b []byte
+		if fp.which == schema.Type_Which_text {
+			b = ptr.TextBytes()
+		} else {
+			b = ptr.Data()
+		}
+		h.Write(b)
+		sum := h.Sum(nil)[:16]
+		if err := wipe(ptr); err != nil {
+			return err
+		}
+		if fp.which == schema.Type_Which_text {
+			return s.SetText(uint16(fp.off), hex.EncodeToString(sum))
+		}
+		return s.SetData(uint16(fp.off), sum)
+	default:
+		sz := dataSize(fp.which)
+		off := capnp.DataOffset(fp.off * sz)
+		if capnp.Size(off)+capnp.Size(sz) > s.Size().DataSize {
+			return nil
+		}
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], getBits(s, off, sz))
+		h.Write(buf[:sz])
+		setBits(s, off, sz, binary.LittleEndian.Uint64(h.Sum(nil)))
+	}
+	return nil
+}
+
+func (r *Redactor) newHash() hash.Hash {
+	if len(r.Key) > 0 {
+		return hmac.New(sha256.New, r.Key)
+	}
+	return sha256.New()
+}
+
+func getBits(s capnp.Struct, off capnp.DataOffset, sz uint32) uint64 {
+	switch sz {
+	case 1:
+		return uint64(s.Uint8(off))
+	case 2:
+		return uint64(s.Uint16(off))
+	case 4:
+		return uint64(s.Uint32(off))
+	default:
+		return s.Uint64(off)
+	}
+}
+
+func setBits(s capnp.Struct, off capnp.DataOffset, sz uint32, v uint64) {
+	switch sz {
+	case 1:
+		s.SetUint8(off, uint8(v))
+	case 2:
+		s.SetUint16(off, uint16(v))
+	case 4:
+		s.SetUint32(off, uint32(v))
+	default:
+		s.SetUint64(off, v)
+	}
+}
+
+// wipe overwrites the object that ptr points to, and every object
+// reachable from it, with zeros.  Pointers are cleared after the
+// objects they point to are wiped.
+func wipe(ptr capnp.Ptr) error {
+	if st := ptr.Struct(); st.IsValid() {
+		return wipeStruct(st)
+	}
+	l := ptr.List()
+	if !l.IsValid() || l.Len() == 0 {
+		return nil
+	}
+	if !l.Struct(0).IsValid() {
+		// Bit list.
+		bl := capnp.BitList{List: l}
+		for i := 0; i < l.Len(); i++ {
+			bl.Set(i, false)
+		}
+		return nil
+	}
+	for i := 0; i < l.Len(); i++ {
+		if err := wipeStruct(l.Struct(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// wipeStruct zeros the data and pointer sections of s, which may also be
+// an element of a list of a primitive type.
+func wipeStruct(s capnp.Struct) error {
+	sz := s.Size()
+	for i := uint16(0); i < sz.PointerCount; i++ {
+		ptr, err := s.Ptr(i)
+		if err != nil {
+			return err
+		}
+		if !ptr.IsValid() {
+			continue
+		}
+		if err := wipe(ptr); err != nil {
+			return err
+		}
+		if err := s.SetPtr(i, capnp.Ptr{}); err != nil {
+			return err
+		}
+	}
+	off := capnp.DataOffset(0)
+	for ; capnp.Size(off)+8 <= sz.DataSize; off += 8 {
+		s.SetUint64(off, 0)
+	}
+	for ; capnp.Size(off) < sz.DataSize; off++ {
+		s.SetUint8(off, 0)
+	}
+	return nil
+}
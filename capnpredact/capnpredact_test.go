@@ -0,0 +1,262 @@
+package capnpredact
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+	"github.com/iguazio/go-capnproto2/schemas"
+)
+
+// annotate returns a registry with a copy of the aircraftlib schema
+// where the fields named by the keys of ann ("Struct.field" or
+// "Struct.group.field") have a $Go.sensitive annotation.
+func annotate(t *testing.T, ann map[string]string) *schemas.Registry {
+	data, err := schemas.DefaultRegistry.Find(air.Z_TypeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := capnp.Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := schema.ReadRootCodeGeneratorRequest(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes, _ := req.Nodes()
+
+	newMsg, seg, _ := capnp.NewMessage(capnp.MultiSegment(nil))
+	newReq, _ := schema.NewRootCodeGeneratorRequest(seg)
+	newNodes, _ := newReq.NewNodes(int32(nodes.Len()))
+	ids := make([]uint64, 0, nodes.Len())
+	found := 0
+	for i := 0; i < nodes.Len(); i++ {
+		if err := newNodes.Set(i, nodes.At(i)); err != nil {
+			t.Fatal(err)
+		}
+		n := newNodes.At(i)
+		if n.Id() != 0 {
+			ids = append(ids, n.Id())
+		}
+		if n.Which() != schema.Node_Which_structNode {
+			continue
+		}
+		dn, _ := n.DisplayName()
+		dn = dn[strings.LastIndex(dn, ":")+1:]
+		fields, _ := n.StructNode().Fields()
+		for j := 0; j < fields.Len(); j++ {
+			f := fields.At(j)
+			fname, _ := f.Name()
+			mode, ok := ann[dn+"."+fname]
+			if !ok {
+				continue
+			}
+			found++
+			anns, _ := f.NewAnnotations(1)
+			anns.At(0).SetId(capnp.Sensitive)
+			v, _ := anns.At(0).NewValue()
+			v.SetText(mode)
+		}
+	}
+	if found != len(ann) {
+		t.Fatalf("annotated %d fields; want %d", found, len(ann))
+	}
+	b, err := newMsg.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg := new(schemas.Registry)
+	if err := reg.Register(&schemas.Schema{Bytes: b, Nodes: ids}); err != nil {
+		t.Fatal(err)
+	}
+	return reg
+}
+
+func newZ(t *testing.T) (*capnp.Message, air.Z) {
+	msg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg, z
+}
+
+// segmentContains reports whether the bytes of msg's first segment
+// contain s.
+func segmentContains(t *testing.T, msg *capnp.Message, s string) bool {
+	seg, err := msg.Segment(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bytes.Contains(seg.Data(), []byte(s))
+}
+
+func TestRedact_Zero(t *testing.T) {
+	r := &Redactor{Registry: annotate(t, map[string]string{
+		"Z.text":      "",
+		"Z.planebase": "zero",
+		"Zdate.year":  "zero",
+	})}
+
+	msg, z := newZ(t)
+	z.SetText("hunter2")
+	if err := r.Redact(air.Z_TypeID, z.Struct); err != nil {
+		t.Fatal("Redact:", err)
+	}
+	if z.Which() != air.Z_Which_text {
+		t.Errorf("Which() = %v; want text", z.Which())
+	}
+	if s, _ := z.Text(); s != "" {
+		t.Errorf("Text() = %q; want \"\"", s)
+	}
+	if segmentContains(t, msg, "hunter2") {
+		t.Error("redacted text still in segment")
+	}
+
+	msg, z = newZ(t)
+	pb, _ := z.NewPlanebase()
+	pb.SetName("Air Force One")
+	pb.SetRating(100)
+	homes, _ := pb.NewHomes(2)
+	homes.Set(0, air.Airport_sfo)
+	if err := r.Redact(air.Z_TypeID, z.Struct); err != nil {
+		t.Fatal("Redact:", err)
+	}
+	if z.HasPlanebase() {
+		t.Error("planebase pointer not cleared")
+	}
+	if segmentContains(t, msg, "Air Force One") {
+		t.Error("redacted struct still in segment")
+	}
+
+	// Nested in a list and in another struct.
+	_, z = newZ(t)
+	zvec, _ := z.NewZvec(2)
+	d, _ := zvec.At(0).NewZdate()
+	d.SetYear(1984)
+	d.SetMonth(6)
+	zvec.At(1).SetF64(3.5)
+	if err := r.Redact(air.Z_TypeID, z.Struct); err != nil {
+		t.Fatal("Redact:", err)
+	}
+	d, _ = zvec.At(0).Zdate()
+	if d.Year() != 0 || d.Month() != 6 {
+		t.Errorf("nested date = %d-%d; want 0-6", d.Year(), d.Month())
+	}
+	if zvec.At(1).F64() != 3.5 {
+		t.Errorf("other list element changed: f64 = %g", zvec.At(1).F64())
+	}
+}
+
+func TestRedact_Hash(t *testing.T) {
+	reg := annotate(t, map[string]string{
+		"Z.text":      "hash",
+		"Z.blob":      "hash",
+		"Z.i64":       "hash",
+		"Z.grp.first": "hash",
+	})
+	hashText := func(r *Redactor, s string) string {
+		msg, z := newZ(t)
+		z.SetText(s)
+		if err := r.Redact(air.Z_TypeID, z.Struct); err != nil {
+			t.Fatal("Redact:", err)
+		}
+		if segmentContains(t, msg, s) {
+			t.Errorf("hashed text %q still in segment", s)
+		}
+		h, _ := z.Text()
+		return h
+	}
+	r := &Redactor{Registry: reg}
+	h1, h2, h3 := hashText(r, "alice@example.com"), hashText(r, "alice@example.com"), hashText(r, "bob@example.com")
+	if len(h1) != 32 {
+		t.Errorf("hashed text = %q; want 32 hex digits", h1)
+	}
+	if h1 != h2 || h1 == h3 {
+		t.Errorf("hashes = %q, %q, %q; want first two equal only", h1, h2, h3)
+	}
+	keyed := &Redactor{Registry: reg, Key: []byte("key")}
+	if h := hashText(keyed, "alice@example.com"); h == h1 {
+		t.Error("keyed hash equals unkeyed hash")
+	}
+
+	_, z := newZ(t)
+	z.SetBlob([]byte("secret"))
+	if err := r.Redact(air.Z_TypeID, z.Struct); err != nil {
+		t.Fatal("Redact:", err)
+	}
+	if b, _ := z.Blob(); len(b) != 16 || bytes.Equal(b, []byte("secret")) {
+		t.Errorf("hashed data = %x; want 16 bytes", b)
+	}
+
+	_, z = newZ(t)
+	z.SetI64(42)
+	if err := r.Redact(air.Z_TypeID, z.Struct); err != nil {
+		t.Fatal("Redact:", err)
+	}
+	if z.I64() == 42 {
+		t.Error("integer field not hashed")
+	}
+
+	_, z = newZ(t)
+	z.SetGrp()
+	z.Grp().SetFirst(7)
+	z.Grp().SetSecond(8)
+	if err := r.Redact(air.Z_TypeID, z.Struct); err != nil {
+		t.Fatal("Redact:", err)
+	}
+	if z.Grp().First() == 7 || z.Grp().Second() != 8 {
+		t.Errorf("group = {%d, %d}; want first hashed and second = 8", z.Grp().First(), z.Grp().Second())
+	}
+}
+
+func TestRedact_InactiveUnionMember(t *testing.T) {
+	r := &Redactor{Registry: annotate(t, map[string]string{"Z.i64": "zero"})}
+	_, z := newZ(t)
+	z.SetF64(1.5)
+	if err := r.Redact(air.Z_TypeID, z.Struct); err != nil {
+		t.Fatal("Redact:", err)
+	}
+	if z.F64() != 1.5 {
+		t.Errorf("F64() = %g; want 1.5", z.F64())
+	}
+}
+
+func TestRedact_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		ann  map[string]string
+	}{
+		{"hash bool", map[string]string{"Z.bool": "hash"}},
+		{"hash struct", map[string]string{"Z.zdate": "hash"}},
+		{"unknown mode", map[string]string{"Z.text": "scramble"}},
+	}
+	for _, test := range tests {
+		r := &Redactor{Registry: annotate(t, test.ann)}
+		_, z := newZ(t)
+		if err := r.Redact(air.Z_TypeID, z.Struct); err == nil {
+			t.Errorf("%s: Redact did not return an error", test.name)
+		}
+	}
+	if err := Redact(air.Airport_TypeID, capnp.Struct{}); err == nil {
+		t.Error("Redact of enum type did not return an error")
+	}
+}
+
+func TestRedact_NoAnnotations(t *testing.T) {
+	_, z := newZ(t)
+	z.SetText("public")
+	if err := Redact(air.Z_TypeID, z.Struct); err != nil {
+		t.Fatal("Redact:", err)
+	}
+	if s, _ := z.Text(); s != "public" {
+		t.Errorf("Text() = %q; want \"public\"", s)
+	}
+}
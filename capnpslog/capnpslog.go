@@ -0,0 +1,551 @@
+// Package capnpslog renders Cap'n Proto structs as structured log/slog
+// values.
+//
+// Unlike the String methods of generated types, which format a whole
+// message as text, the values produced by this package are groups of
+// attributes that handlers such as slog.JSONHandler can index, and they
+// are bounded in size:
+//
+//	logger.Info("request", "body", capnpslog.Value(foo.Bar_TypeID, bar.Struct))
+//
+// Each field of a struct becomes an attribute.  Groups and structs
+// become nested groups, and lists become groups with the element index
+// as key, followed by a "more" attribute with the number of elements
+// that were not rendered.  Only the active member of a union is
+// rendered.  A null pointer is rendered as the field's default value
+// from the schema; null pointers without a default and interface and
+// AnyPointer fields are omitted, and fields marked with the $Go.sensitive annotation are
+// rendered as "[redacted]".
+//
+// Like log/slog, the package requires Go 1.21 or later, and so does code
+// that capnpc-go generates with the -logvaluer option.
+package capnpslog // import "github.com/iguazio/go-capnproto2/capnpslog"
+
+import (
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/nodemap"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+	"github.com/iguazio/go-capnproto2/schemas"
+)
+
+// Default limits used by a Renderer.
+const (
+	DefaultMaxDepth   = 4
+	DefaultMaxListLen = 16
+	DefaultMaxBytes   = 256
+)
+
+// Value returns a slog.LogValuer for s, a struct of the type with the
+// given ID, using the schemas in schemas.DefaultRegistry and the
+// default limits.
+func Value(typeID uint64, s capnp.Struct) slog.LogValuer {
+	return defaultRenderer.Value(typeID, s)
+}
+
+var defaultRenderer Renderer
+
+// A Renderer converts structs to slog values using the schemas in a
+// registry.  Its fields must not be changed after its first use.  A
+// Renderer is safe to use from multiple goroutines.
+type Renderer struct {
+	// Registry is the registry to find schemas in.  If nil,
+	// schemas.DefaultRegistry is used.
+	Registry *schemas.Registry
+
+	// MaxDepth is the number of levels of nested structs and lists to
+	// render.  Deeper values are rendered as "...".  If zero,
+	// DefaultMaxDepth is used.
+	MaxDepth int
+
+	// MaxListLen is the number of elements of a list to render.  If
+	// zero, DefaultMaxListLen is used.
+	MaxListLen int
+
+	// MaxBytes is the number of bytes of a Text or Data value to
+	// render.  Longer values are truncated and end in "...".  If zero,
+	// DefaultMaxBytes is used.
+	MaxBytes int
+
+	// Fields is the set of top-level fields to render.  If empty, all
+	// fields are rendered.
+	Fields []string
+
+	mu     sync.Mutex
+	init   bool
+	nodes  nodemap.Map
+	plans  map[uint64]*structPlan
+	fields map[string]bool
+}
+
+// Value returns a slog.LogValuer for s, a struct of the type with the
+// given ID.  The struct is not read until the value is resolved by a
+// handler.
+func (r *Renderer) Value(typeID uint64, s capnp.Struct) slog.LogValuer {
+	return structValuer{r, typeID, s}
+}
+
+type structValuer struct {
+	r      *Renderer
+	typeID uint64
+	s      capnp.Struct
+}
+
+func (sv structValuer) LogValue() slog.Value {
+	sv.r.mu.Lock()
+	p, err := sv.r.plan(sv.typeID)
+	fields := sv.r.fields
+	sv.r.mu.Unlock()
+	if err != nil {
+		return slog.AnyValue(fmt.Errorf("capnpslog: %v", err))
+	}
+	if !sv.s.IsValid() {
+		return slog.GroupValue()
+	}
+	return sv.r.structValue(p, sv.s, 0, fields)
+}
+
+// A structPlan lists the fields of a struct or group.
+type structPlan struct {
+	fields []fieldPlan
+}
+
+// A fieldPlan describes how to render a field.
+type fieldPlan struct {
+	name      string
+	typ       typeInfo
+	off       uint32    // data offset in bytes or bits, or pointer index
+	def       uint64    // default value bits of a scalar field
+	defb      []byte    // default value of a Text or Data field
+	defp      capnp.Ptr // default value of a struct or list field
+	sensitive bool
+
+	discOff capnp.DataOffset
+	discVal uint16 // schema.Field_noDiscriminant if not in a union
+
+	group *structPlan
+}
+
+// typeInfo is the part of a schema type needed to render values.
+type typeInfo struct {
+	which schema.Type_Which
+	st    *structPlan // for structs
+	enum  []string    // enumerant names, for enums
+	elem  *typeInfo   // for lists
+}
+
+// plan returns the plan for the struct type with the given ID.  The
+// caller must be holding r.mu.  Errors are not prefixed with the
+// package name, since plans are built recursively.
+func (r *Renderer) plan(typeID uint64) (*structPlan, error) {
+	if !r.init {
+		if r.Registry != nil {
+			r.nodes.UseRegistry(r.Registry)
+		}
+		r.plans = make(map[uint64]*structPlan)
+		if len(r.Fields) > 0 {
+			r.fields = make(map[string]bool, len(r.Fields))
+			for _, f := range r.Fields {
+				r.fields[f] = true
+			}
+		}
+		r.init = true
+	}
+	if p := r.plans[typeID]; p != nil {
+		return p, nil
+	}
+	n, err := r.nodes.Find(typeID)
+	if err != nil {
+		return nil, fmt.Errorf("find struct %#x: %v", typeID, err)
+	}
+	if n.Which() != schema.Node_Which_structNode {
+		return nil, fmt.Errorf("%#x is a %v, not a struct", typeID, n.Which())
+	}
+	// Structs may refer to themselves, so record the plan before
+	// filling it in.
+	p := new(structPlan)
+	r.plans[typeID] = p
+	if err := r.addFields(p, n); err != nil {
+		// Plans created since this one may refer to it.
+		r.plans = make(map[uint64]*structPlan)
+		return nil, fmt.Errorf("struct %#x: %v", typeID, err)
+	}
+	return p, nil
+}
+
+// addFields fills in p from a struct or group node.
+func (r *Renderer) addFields(p *structPlan, n schema.Node) error {
+	fields, err := n.StructNode().Fields()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < fields.Len(); i++ {
+		f := fields.At(i)
+		name, err := f.Name()
+		if err != nil {
+			return err
+		}
+		fp := fieldPlan{
+			name:    name,
+			discOff: capnp.DataOffset(n.StructNode().DiscriminantOffset() * 2),
+			discVal: f.DiscriminantValue(),
+		}
+		switch f.Which() {
+		case schema.Field_Which_group:
+			g, err := r.nodes.Find(f.Group().TypeId())
+			if err != nil {
+				return fmt.Errorf("field %s: %v", name, err)
+			}
+			fp.group = new(structPlan)
+			if err := r.addFields(fp.group, g); err != nil {
+				return err
+			}
+		case schema.Field_Which_slot:
+			if err := r.addSlot(&fp, f); err != nil {
+				return fmt.Errorf("field %s: %v", name, err)
+			}
+			switch fp.typ.which {
+			case schema.Type_Which_interface, schema.Type_Which_anyPointer:
+				continue
+			case schema.Type_Which_void:
+				if fp.discVal == schema.Field_noDiscriminant {
+					continue
+				}
+			}
+		default:
+			continue
+		}
+		p.fields = append(p.fields, fp)
+	}
+	return nil
+}
+
+func (r *Renderer) addSlot(fp *fieldPlan, f schema.Field) error {
+	slot := f.Slot()
+	t, err := slot.Type()
+	if err != nil {
+		return err
+	}
+	fp.typ, err = r.typeInfo(t)
+	if err != nil {
+		return err
+	}
+	dv, err := slot.DefaultValue()
+	if err != nil {
+		return err
+	}
+	off := slot.Offset()
+	switch fp.typ.which {
+	case schema.Type_Which_bool:
+		fp.off = off
+		if dv.Bool() {
+			fp.def = 1
+		}
+	case schema.Type_Which_int8:
+		fp.off, fp.def = off, uint64(uint8(dv.Int8()))
+	case schema.Type_Which_uint8:
+		fp.off, fp.def = off, uint64(dv.Uint8())
+	case schema.Type_Which_int16:
+		fp.off, fp.def = off*2, uint64(uint16(dv.Int16()))
+	case schema.Type_Which_uint16:
+		fp.off, fp.def = off*2, uint64(dv.Uint16())
+	case schema.Type_Which_enum:
+		fp.off, fp.def = off*2, uint64(dv.Enum())
+	case schema.Type_Which_int32:
+		fp.off, fp.def = off*4, uint64(uint32(dv.Int32()))
+	case schema.Type_Which_uint32:
+		fp.off, fp.def = off*4, uint64(dv.Uint32())
+	case schema.Type_Which_float32:
+		fp.off, fp.def = off*4, uint64(math.Float32bits(dv.Float32()))
+	case schema.Type_Which_int64:
+		fp.off, fp.def = off*8, uint64(dv.Int64())
+	case schema.Type_Which_uint64:
+		fp.off, fp.def = off*8, dv.Uint64()
+	case schema.Type_Which_float64:
+		fp.off, fp.def = off*8, math.Float64bits(dv.Float64())
+	case schema.Type_Which_text:
+		fp.off = off
+		if dv.Which() == schema.Value_Which_text {
+			fp.defb, _ = dv.TextBytes()
+		}
+	case schema.Type_Which_data:
+		fp.off = off
+		if dv.Which() == schema.Value_Which_data {
+			fp.defb, _ = dv.Data()
+		}
+	case schema.Type_Which_structType:
+		fp.off = off
+		if dv.Which() == schema.Value_Which_structValue {
+			fp.defp, _ = dv.StructValuePtr()
+		}
+	case schema.Type_Which_list:
+		fp.off = off
+		if dv.Which() == schema.Value_Which_list {
+			fp.defp, _ = dv.ListPtr()
+		}
+	default:
+		fp.off = off
+	}
+	anns, err := f.Annotations()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < anns.Len(); i++ {
+		if anns.At(i).Id() == capnp.Sensitive {
+			fp.sensitive = true
+		}
+	}
+	return nil
+}
+
+func (r *Renderer) typeInfo(t schema.Type) (typeInfo, error) {
+	ti := typeInfo{which: t.Which()}
+	switch ti.which {
+	case schema.Type_Which_structType:
+		st, err := r.plan(t.StructType().TypeId())
+		if err != nil {
+			return typeInfo{}, err
+		}
+		ti.st = st
+	case schema.Type_Which_enum:
+		n, err := r.nodes.Find(t.Enum().TypeId())
+		if err != nil {
+			return typeInfo{}, err
+		}
+		enums, err := n.Enum().Enumerants()
+		if err != nil {
+			return typeInfo{}, err
+		}
+		ti.enum = make([]string, enums.Len())
+		for i := range ti.enum {
+			ti.enum[i], _ = enums.At(i).Name()
+		}
+	case schema.Type_Which_list:
+		et, err := t.List().ElementType()
+		if err != nil {
+			return typeInfo{}, err
+		}
+		elem, err := r.typeInfo(et)
+		if err != nil {
+			return typeInfo{}, err
+		}
+		ti.elem = &elem
+	}
+	return ti, nil
+}
+
+func (r *Renderer) maxDepth() int {
+	if r.MaxDepth > 0 {
+		return r.MaxDepth
+	}
+	return DefaultMaxDepth
+}
+
+func (r *Renderer) maxListLen() int {
+	if r.MaxListLen > 0 {
+		return r.MaxListLen
+	}
+	return DefaultMaxListLen
+}
+
+func (r *Renderer) maxBytes() int {
+	if r.MaxBytes > 0 {
+		return r.MaxBytes
+	}
+	return DefaultMaxBytes
+}
+
+var elided = slog.StringValue("...")
+
+// structValue renders the fields of s.  If only is not nil, then only
+// the fields named in it are rendered.
+func (r *Renderer) structValue(p *structPlan, s capnp.Struct, depth int, only map[string]bool) slog.Value {
+	if depth >= r.maxDepth() {
+		return elided
+	}
+	attrs := make([]slog.Attr, 0, len(p.fields))
+	for i := range p.fields {
+		fp := &p.fields[i]
+		if only != nil && !only[fp.name] {
+			continue
+		}
+		if fp.discVal != schema.Field_noDiscriminant && s.Uint16(fp.discOff) != fp.discVal {
+			continue
+		}
+		if fp.group != nil {
+			attrs = append(attrs, slog.Attr{Key: fp.name, Value: r.structValue(fp.group, s, depth, nil)})
+			continue
+		}
+		if fp.sensitive {
+			attrs = append(attrs, slog.String(fp.name, "[redacted]"))
+			continue
+		}
+		v, ok := r.fieldValue(fp, s, depth)
+		if ok {
+			attrs = append(attrs, slog.Attr{Key: fp.name, Value: v})
+		}
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// fieldValue renders a slot field.  It returns false if the field
+// should be omitted.
+func (r *Renderer) fieldValue(fp *fieldPlan, s capnp.Struct, depth int) (slog.Value, bool) {
+	off := capnp.DataOffset(fp.off)
+	switch fp.typ.which {
+	case schema.Type_Which_void:
+		return slog.StringValue("void"), true
+	case schema.Type_Which_bool:
+		return slog.BoolValue(s.Bit(capnp.BitOffset(fp.off)) != (fp.def != 0)), true
+	case schema.Type_Which_int8:
+		return slog.Int64Value(int64(int8(s.Uint8(off) ^ uint8(fp.def)))), true
+	case schema.Type_Which_int16:
+		return slog.Int64Value(int64(int16(s.Uint16(off) ^ uint16(fp.def)))), true
+	case schema.Type_Which_int32:
+		return slog.Int64Value(int64(int32(s.Uint32(off) ^ uint32(fp.def)))), true
+	case schema.Type_Which_int64:
+		return slog.Int64Value(int64(s.Uint64(off) ^ fp.def)), true
+	case schema.Type_Which_uint8:
+		return slog.Uint64Value(uint64(s.Uint8(off) ^ uint8(fp.def))), true
+	case schema.Type_Which_uint16:
+		return slog.Uint64Value(uint64(s.Uint16(off) ^ uint16(fp.def))), true
+	case schema.Type_Which_uint32:
+		return slog.Uint64Value(uint64(s.Uint32(off) ^ uint32(fp.def))), true
+	case schema.Type_Which_uint64:
+		return slog.Uint64Value(s.Uint64(off) ^ fp.def), true
+	case schema.Type_Which_float32:
+		return slog.Float64Value(float64(math.Float32frombits(s.Uint32(off) ^ uint32(fp.def)))), true
+	case schema.Type_Which_float64:
+		return slog.Float64Value(math.Float64frombits(s.Uint64(off) ^ fp.def)), true
+	case schema.Type_Which_enum:
+		return enumValue(fp.typ.enum, s.Uint16(off)^uint16(fp.def)), true
+	}
+	ptr, err := s.Ptr(uint16(fp.off))
+	if err != nil {
+		return slog.AnyValue(err), true
+	}
+	if !ptr.IsValid() {
+		switch {
+		case fp.defb != nil && fp.typ.which == schema.Type_Which_text:
+			return r.textValue(fp.defb), true
+		case fp.defb != nil:
+			return r.dataValue(fp.defb), true
+		case fp.defp.IsValid():
+			return r.ptrValue(&fp.typ, fp.defp, depth), true
+		default:
+			return slog.Value{}, false
+		}
+	}
+	return r.ptrValue(&fp.typ, ptr, depth), true
+}
+
+func (r *Renderer) ptrValue(ti *typeInfo, ptr capnp.Ptr, depth int) slog.Value {
+	switch ti.which {
+	case schema.Type_Which_text:
+		return r.textValue(ptr.TextBytes())
+	case schema.Type_Which_data:
+		return r.dataValue(ptr.Data())
+	case schema.Type_Which_structType:
+		return r.structValue(ti.st, ptr.Struct(), depth+1, nil)
+	case schema.Type_Which_list:
+		return r.listValue(ti.elem, ptr.List(), depth+1)
+	default:
+		return elided
+	}
+}
+
+func (r *Renderer) listValue(elem *typeInfo, l capnp.List, depth int) slog.Value {
+	if depth >= r.maxDepth() {
+		return elided
+	}
+	n := l.Len()
+	if limit := r.maxListLen(); n > limit {
+		n = limit
+	}
+	attrs := make([]slog.Attr, 0, n+1)
+	for i := 0; i < n; i++ {
+		v, err := r.elemValue(elem, l, i, depth)
+		if err != nil {
+			v = slog.AnyValue(err)
+		}
+		attrs = append(attrs, slog.Attr{Key: strconv.Itoa(i), Value: v})
+	}
+	if more := l.Len() - n; more > 0 {
+		attrs = append(attrs, slog.Int("more", more))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+func (r *Renderer) elemValue(elem *typeInfo, l capnp.List, i int, depth int) (slog.Value, error) {
+	switch elem.which {
+	case schema.Type_Which_void:
+		return slog.StringValue("void"), nil
+	case schema.Type_Which_bool:
+		return slog.BoolValue(capnp.BitList{List: l}.At(i)), nil
+	case schema.Type_Which_structType:
+		return r.structValue(elem.st, l.Struct(i), depth, nil), nil
+	case schema.Type_Which_text, schema.Type_Which_data, schema.Type_Which_list,
+		schema.Type_Which_interface, schema.Type_Which_anyPointer:
+		ptr, err := capnp.PointerList{List: l}.PtrAt(i)
+		if err != nil {
+			return slog.Value{}, err
+		}
+		return r.ptrValue(elem, ptr, depth), nil
+	}
+	// Primitive elements are read as the data section of a struct, so
+	// that a list with the wrong element size does not cause a panic.
+	s := l.Struct(i)
+	switch elem.which {
+	case schema.Type_Which_int8:
+		return slog.Int64Value(int64(int8(s.Uint8(0)))), nil
+	case schema.Type_Which_int16:
+		return slog.Int64Value(int64(int16(s.Uint16(0)))), nil
+	case schema.Type_Which_int32:
+		return slog.Int64Value(int64(int32(s.Uint32(0)))), nil
+	case schema.Type_Which_int64:
+		return slog.Int64Value(int64(s.Uint64(0))), nil
+	case schema.Type_Which_uint8:
+		return slog.Uint64Value(uint64(s.Uint8(0))), nil
+	case schema.Type_Which_uint16:
+		return slog.Uint64Value(uint64(s.Uint16(0))), nil
+	case schema.Type_Which_uint32:
+		return slog.Uint64Value(uint64(s.Uint32(0))), nil
+	case schema.Type_Which_uint64:
+		return slog.Uint64Value(s.Uint64(0)), nil
+	case schema.Type_Which_float32:
+		return slog.Float64Value(float64(math.Float32frombits(s.Uint32(0)))), nil
+	case schema.Type_Which_float64:
+		return slog.Float64Value(math.Float64frombits(s.Uint64(0))), nil
+	case schema.Type_Which_enum:
+		return enumValue(elem.enum, s.Uint16(0)), nil
+	default:
+		return elided, nil
+	}
+}
+
+func (r *Renderer) textValue(b []byte) slog.Value {
+	if limit := r.maxBytes(); len(b) > limit {
+		return slog.StringValue(string(b[:limit]) + "...")
+	}
+	return slog.StringValue(string(b))
+}
+
+func (r *Renderer) dataValue(b []byte) slog.Value {
+	if limit := r.maxBytes(); len(b) > limit {
+		return slog.StringValue(hex.EncodeToString(b[:limit]) + "...")
+	}
+	return slog.StringValue(hex.EncodeToString(b))
+}
+
+func enumValue(names []string, v uint16) slog.Value {
+	if int(v) < len(names) {
+		return slog.StringValue(names[v])
+	}
+	return slog.Uint64Value(uint64(v))
+}
@@ -0,0 +1,170 @@
+package capnpslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+// logJSON logs v with a JSON handler and returns the value of its
+// "v" attribute.
+func logJSON(t *testing.T, v slog.LogValuer) string {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key != "v" {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("", "v", v)
+	var out struct{ V json.RawMessage }
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("unmarshal %s: %v", buf.Bytes(), err)
+	}
+	return string(out.V)
+}
+
+func newZ(t *testing.T) air.Z {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return z
+}
+
+func TestValue(t *testing.T) {
+	z := newZ(t)
+	d, _ := z.NewZdate()
+	d.SetYear(2017)
+	d.SetMonth(5)
+	d.SetDay(9)
+	if got, want := logJSON(t, Value(air.Z_TypeID, z.Struct)), `{"zdate":{"year":2017,"month":5,"day":9}}`; got != want {
+		t.Errorf("zdate = %s; want %s", got, want)
+	}
+
+	z = newZ(t)
+	z.SetAirport(air.Airport_lax)
+	if got, want := logJSON(t, Value(air.Z_TypeID, z.Struct)), `{"airport":"lax"}`; got != want {
+		t.Errorf("airport = %s; want %s", got, want)
+	}
+
+	z = newZ(t)
+	z.SetGrp()
+	z.Grp().SetFirst(1)
+	z.Grp().SetSecond(2)
+	if got, want := logJSON(t, Value(air.Z_TypeID, z.Struct)), `{"grp":{"first":1,"second":2}}`; got != want {
+		t.Errorf("grp = %s; want %s", got, want)
+	}
+
+	z = newZ(t)
+	z.SetVoid()
+	if got, want := logJSON(t, Value(air.Z_TypeID, z.Struct)), `{"void":"void"}`; got != want {
+		t.Errorf("void = %s; want %s", got, want)
+	}
+}
+
+func TestValue_Defaults(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := air.NewRootDefaults(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := logJSON(t, Value(air.Defaults_TypeID, d.Struct))
+	for _, want := range []string{`"text":"foo"`, `"data":"626172"`, `"int":-123`, `"uint":42`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("defaults = %s; want to contain %s", got, want)
+		}
+	}
+}
+
+func TestValue_StructDefault(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := air.NewRootStackingRoot(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := logJSON(t, Value(air.StackingRoot_TypeID, root.Struct)), `{"aWithDefault":{"num":42}}`; got != want {
+		t.Errorf("unset aWithDefault = %s; want %s", got, want)
+	}
+	a, err := root.NewAWithDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.SetNum(7)
+	if got, want := logJSON(t, Value(air.StackingRoot_TypeID, root.Struct)), `{"aWithDefault":{"num":7}}`; got != want {
+		t.Errorf("set aWithDefault = %s; want %s", got, want)
+	}
+}
+
+func TestValue_Limits(t *testing.T) {
+	z := newZ(t)
+	l, _ := z.NewF64vec(5)
+	for i := 0; i < l.Len(); i++ {
+		l.Set(i, float64(i))
+	}
+	r := &Renderer{MaxListLen: 2}
+	if got, want := logJSON(t, r.Value(air.Z_TypeID, z.Struct)), `{"f64vec":{"0":0,"1":1,"more":3}}`; got != want {
+		t.Errorf("f64vec = %s; want %s", got, want)
+	}
+
+	z = newZ(t)
+	z.SetText("abcdefgh")
+	r = &Renderer{MaxBytes: 3}
+	if got, want := logJSON(t, r.Value(air.Z_TypeID, z.Struct)), `{"text":"abc..."}`; got != want {
+		t.Errorf("text = %s; want %s", got, want)
+	}
+
+	z = newZ(t)
+	zz, _ := z.NewZz()
+	zz2, _ := zz.NewZz()
+	zz2.SetI8(-3)
+	r = &Renderer{MaxDepth: 2}
+	if got, want := logJSON(t, r.Value(air.Z_TypeID, z.Struct)), `{"zz":{"zz":"..."}}`; got != want {
+		t.Errorf("zz = %s; want %s", got, want)
+	}
+	r = &Renderer{MaxDepth: 3}
+	if got, want := logJSON(t, r.Value(air.Z_TypeID, z.Struct)), `{"zz":{"zz":{"i8":-3}}}`; got != want {
+		t.Errorf("zz = %s; want %s", got, want)
+	}
+}
+
+func TestValue_Fields(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := air.NewRootZdate(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetYear(2017)
+	d.SetMonth(5)
+	r := &Renderer{Fields: []string{"year"}}
+	if got, want := logJSON(t, r.Value(air.Zdate_TypeID, d.Struct)), `{"year":2017}`; got != want {
+		t.Errorf("got %s; want %s", got, want)
+	}
+}
+
+func TestValue_Error(t *testing.T) {
+	got := logJSON(t, Value(air.Airport_TypeID, capnp.Struct{}))
+	if !strings.Contains(got, "capnpslog:") {
+		t.Errorf("got %s; want error", got)
+	}
+}
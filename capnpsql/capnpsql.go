@@ -0,0 +1,74 @@
+// Package capnpsql stores Cap'n Proto structs in SQL BLOB columns.
+//
+// Values are encoded using the standard unpacked stream framing, so a
+// column written by Value can be read back by any Cap'n Proto
+// implementation that understands message streams.
+package capnpsql // import "github.com/iguazio/go-capnproto2/capnpsql"
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	"github.com/iguazio/go-capnproto2"
+)
+
+// Value encodes s as a database/sql/driver value.  The message that
+// contains s is serialized with s as its root; if s is not the root of
+// its message, it is first copied into a new message.  A null struct
+// is stored as SQL NULL.
+func Value(s capnp.Struct) (driver.Value, error) {
+	if s.Segment() == nil {
+		return nil, nil
+	}
+	msg := s.Segment().Message()
+	if root, err := msg.RootPtr(); err != nil || !capnp.SamePtr(root, s.ToPtr()) {
+		m, _, err := capnp.NewMessage(capnp.MultiSegment(nil))
+		if err != nil {
+			return nil, fmt.Errorf("capnpsql: %v", err)
+		}
+		if err := m.SetRootPtr(s.ToPtr()); err != nil {
+			return nil, fmt.Errorf("capnpsql: copy struct: %v", err)
+		}
+		msg = m
+	}
+	data, err := msg.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("capnpsql: %v", err)
+	}
+	return data, nil
+}
+
+// Scan decodes a value produced by Value into *dst.  src may be a
+// []byte, a string, or nil; nil sets *dst to the null struct.  The
+// bytes are copied, since drivers may reuse the buffer after Scan
+// returns.
+func Scan(dst *capnp.Struct, src interface{}) error {
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+		*dst = capnp.Struct{}
+		return nil
+	case []byte:
+		data = append([]byte(nil), src...)
+	case string:
+		data = []byte(src)
+	default:
+		return fmt.Errorf("capnpsql: cannot scan %T into struct", src)
+	}
+	if len(data) == 0 {
+		return errEmpty
+	}
+	msg, err := capnp.Unmarshal(data)
+	if err != nil {
+		return fmt.Errorf("capnpsql: %v", err)
+	}
+	root, err := msg.RootPtr()
+	if err != nil {
+		return fmt.Errorf("capnpsql: %v", err)
+	}
+	*dst = root.Struct()
+	return nil
+}
+
+var errEmpty = errors.New("capnpsql: empty value")
@@ -0,0 +1,115 @@
+package capnpsql
+
+import (
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+func checkDate(t *testing.T, name string, s capnp.Struct) {
+	d := air.Zdate{Struct: s}
+	if d.Year() != 2017 || d.Month() != 5 || d.Day() != 9 {
+		t.Errorf("%s: date = %d-%d-%d; want 2017-5-9", name, d.Year(), d.Month(), d.Day())
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := air.NewRootZdate(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetYear(2017)
+	d.SetMonth(5)
+	d.SetDay(9)
+	v, err := Value(d.Struct)
+	if err != nil {
+		t.Fatal("Value:", err)
+	}
+	b, ok := v.([]byte)
+	if !ok {
+		t.Fatalf("Value returned %T; want []byte", v)
+	}
+	var s capnp.Struct
+	if err := Scan(&s, b); err != nil {
+		t.Fatal("Scan([]byte):", err)
+	}
+	for i := range b {
+		b[i] = 0 // driver reuses its buffer
+	}
+	checkDate(t, "[]byte", s)
+
+	v, _ = Value(d.Struct)
+	s = capnp.Struct{}
+	if err := Scan(&s, string(v.([]byte))); err != nil {
+		t.Fatal("Scan(string):", err)
+	}
+	checkDate(t, "string", s)
+}
+
+func TestValue_NotRoot(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := z.NewZdate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetYear(2017)
+	d.SetMonth(5)
+	d.SetDay(9)
+	v, err := Value(d.Struct)
+	if err != nil {
+		t.Fatal("Value:", err)
+	}
+	var s capnp.Struct
+	if err := Scan(&s, v); err != nil {
+		t.Fatal("Scan:", err)
+	}
+	checkDate(t, "copied", s)
+}
+
+func TestNull(t *testing.T) {
+	v, err := Value(capnp.Struct{})
+	if v != nil || err != nil {
+		t.Errorf("Value(null) = %v, %v; want nil, nil", v, err)
+	}
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := air.NewRootZdate(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := d.Struct
+	if err := Scan(&s, nil); err != nil {
+		t.Fatal("Scan(nil):", err)
+	}
+	if s.IsValid() {
+		t.Error("Scan(nil) did not reset struct")
+	}
+}
+
+func TestScan_Errors(t *testing.T) {
+	tests := []interface{}{
+		int64(42),
+		[]byte{},
+		[]byte{0, 0, 0, 0, 1, 0},
+	}
+	for _, src := range tests {
+		var s capnp.Struct
+		if err := Scan(&s, src); err == nil {
+			t.Errorf("Scan(%#v) did not return an error", src)
+		}
+	}
+}
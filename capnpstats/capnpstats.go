@@ -0,0 +1,494 @@
+// Package capnpstats breaks down the size of Cap'n Proto messages by
+// struct type and field, so that schema owners can see which fields
+// take up space on the wire and which are rarely set.
+//
+// The layout-level totals come from capnp.MessageStats.  The per-type
+// and per-field numbers are gathered by reading the message through
+// its schema, starting from the root struct.  Objects that are referred
+// to by more than one pointer are counted once per reference, and the
+// reads count against the message's read limit.
+//
+// SizeOf works in the other direction: it computes the size of a
+// message from a description of what it will hold, so that fixed
+// buffers can be sized before the message is built.
+package capnpstats // import "github.com/iguazio/go-capnproto2/capnpstats"
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/nodemap"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+	"github.com/iguazio/go-capnproto2/schemas"
+)
+
+// Stats describes how the bytes of a message are used.
+type Stats struct {
+	// Message holds the layout-level totals.
+	Message *capnp.Stats
+
+	// Types holds the usage of each struct type found in the message,
+	// keyed by type ID.
+	Types map[uint64]*TypeStats
+}
+
+// TypeStats describes the usage of all the structs of one type.
+type TypeStats struct {
+	// Name is the type's display name from the schema.
+	Name string
+
+	// Count is the number of structs of this type.
+	Count int
+
+	// DataBytes and PointerBytes are the combined sizes of the data
+	// and pointer sections of the structs.
+	DataBytes    uint64
+	PointerBytes uint64
+
+	// UnusedBytes is the part of DataBytes that no field or union
+	// discriminant of the schema occupies: either padding added to
+	// round the data section up to a word, or space for fields that
+	// were added in a newer version of the schema than the reader's.
+	UnusedBytes uint64
+
+	// Fields holds the usage of each field, keyed by name.  Fields of
+	// groups are named "group.field".
+	Fields map[string]*FieldStats
+}
+
+// FieldStats describes the usage of a field across the structs of a
+// type.  A union member is only counted in structs where it is set.
+type FieldStats struct {
+	// Set is the number of structs where the field has a value other
+	// than its default.  Default is the number of structs where it has
+	// its default value or is a null pointer.
+	Set     int
+	Default int
+
+	// InlineBytes is the space the field takes in the structs' data
+	// or pointer sections, rounded up to whole bytes per struct.
+	InlineBytes uint64
+
+	// ObjectBytes is the space taken by the objects that the field
+	// points to directly: Text and Data bytes, list elements along
+	// with their padding and tag, or the sections of a struct.
+	ObjectBytes uint64
+}
+
+// MessageStats returns the usage of msg, whose root is a struct of the
+// type with the given ID, using the schemas in schemas.DefaultRegistry.
+func MessageStats(typeID uint64, msg *capnp.Message) (*Stats, error) {
+	return NewCollector(nil).MessageStats(typeID, msg)
+}
+
+// A Collector gathers message statistics using the schemas in a
+// registry.  A Collector caches the schema information for the types
+// it has seen, and is not safe for concurrent use.
+type Collector struct {
+	nodes nodemap.Map
+	plans map[uint64]*structPlan
+}
+
+// NewCollector returns a collector for the types in reg.  If reg is
+// nil, schemas.DefaultRegistry is used.
+func NewCollector(reg *schemas.Registry) *Collector {
+	c := &Collector{plans: make(map[uint64]*structPlan)}
+	if reg != nil {
+		c.nodes.UseRegistry(reg)
+	}
+	return c
+}
+
+// MessageStats returns the usage of msg, whose root is a struct of the
+// type with the given ID.
+func (c *Collector) MessageStats(typeID uint64, msg *capnp.Message) (*Stats, error) {
+	p, err := c.plan(typeID)
+	if err != nil {
+		return nil, fmt.Errorf("capnpstats: %v", err)
+	}
+	layout, err := capnp.MessageStats(msg)
+	if err != nil {
+		return nil, fmt.Errorf("capnpstats: %v", err)
+	}
+	stats := &Stats{
+		Message: layout,
+		Types:   make(map[uint64]*TypeStats),
+	}
+	root, err := msg.RootPtr()
+	if err != nil {
+		return nil, fmt.Errorf("capnpstats: %v", err)
+	}
+	if err := stats.addStruct(p, root.Struct()); err != nil {
+		return nil, fmt.Errorf("capnpstats: %v", err)
+	}
+	return stats, nil
+}
+
+// A structPlan lists the fields of a struct type.
+type structPlan struct {
+	id       uint64
+	name     string
+	size     capnp.ObjectSize
+	fields   []fieldPlan
+	usedBits []bool // data section bits occupied by fields
+}
+
+// A fieldPlan describes how to measure a field.
+type fieldPlan struct {
+	name  string
+	typ   typeInfo
+	bits  uint32 // size of a data field in bits, zero for pointers
+	off   uint32 // data offset in bits, or pointer index
+	def   uint64 // default value bits of a data field
+	conds []unionCond
+}
+
+// A unionCond is a union discriminant value that must be set for a
+// field to be active.
+type unionCond struct {
+	off capnp.DataOffset
+	val uint16
+}
+
+// typeInfo is the part of a schema type needed to measure values.
+type typeInfo struct {
+	which schema.Type_Which
+	st    *structPlan // for structs
+	elem  *typeInfo   // for lists
+}
+
+// plan returns the plan for the struct type with the given ID.  Errors
+// are not prefixed with the package name, since plans are built
+// recursively.
+func (c *Collector) plan(typeID uint64) (*structPlan, error) {
+	if p := c.plans[typeID]; p != nil {
+		return p, nil
+	}
+	n, err := c.nodes.Find(typeID)
+	if err != nil {
+		return nil, fmt.Errorf("find struct %#x: %v", typeID, err)
+	}
+	if n.Which() != schema.Node_Which_structNode {
+		return nil, fmt.Errorf("%#x is a %v, not a struct", typeID, n.Which())
+	}
+	name, _ := n.DisplayName()
+	sn := n.StructNode()
+	p := &structPlan{
+		id:   typeID,
+		name: name,
+		size: capnp.ObjectSize{
+			DataSize:     capnp.Size(sn.DataWordCount()) * 8,
+			PointerCount: sn.PointerCount(),
+		},
+		usedBits: make([]bool, int(sn.DataWordCount())*64),
+	}
+	// Structs may refer to themselves, so record the plan before
+	// filling it in.
+	c.plans[typeID] = p
+	if err := c.addFields(p, "", n, nil); err != nil {
+		// Plans created since this one may refer to it.
+		c.plans = make(map[uint64]*structPlan)
+		return nil, fmt.Errorf("struct %#x: %v", typeID, err)
+	}
+	return p, nil
+}
+
+// addFields adds the fields of a struct or group node to p.
+func (c *Collector) addFields(p *structPlan, prefix string, n schema.Node, conds []unionCond) error {
+	sn := n.StructNode()
+	if sn.DiscriminantCount() > 0 {
+		p.use(sn.DiscriminantOffset()*16, 16)
+	}
+	fields, err := sn.Fields()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < fields.Len(); i++ {
+		f := fields.At(i)
+		name, err := f.Name()
+		if err != nil {
+			return err
+		}
+		name = prefix + name
+		fconds := conds
+		if dv := f.DiscriminantValue(); dv != schema.Field_noDiscriminant {
+			fconds = make([]unionCond, len(conds), len(conds)+1)
+			copy(fconds, conds)
+			fconds = append(fconds, unionCond{
+				off: capnp.DataOffset(sn.DiscriminantOffset() * 2),
+				val: dv,
+			})
+		}
+		switch f.Which() {
+		case schema.Field_Which_group:
+			g, err := c.nodes.Find(f.Group().TypeId())
+			if err != nil {
+				return fmt.Errorf("field %s: %v", name, err)
+			}
+			if err := c.addFields(p, name+".", g, fconds); err != nil {
+				return err
+			}
+		case schema.Field_Which_slot:
+			fp, err := c.newFieldPlan(f.Slot())
+			if err != nil {
+				return fmt.Errorf("field %s: %v", name, err)
+			}
+			fp.name = name
+			fp.conds = fconds
+			p.use(fp.off, fp.bits)
+			p.fields = append(p.fields, fp)
+		}
+	}
+	return nil
+}
+
+// use marks the data section bits from off to off+n as occupied.
+func (p *structPlan) use(off, n uint32) {
+	for i := off; i < off+n && int(i) < len(p.usedBits); i++ {
+		p.usedBits[i] = true
+	}
+}
+
+func (c *Collector) newFieldPlan(slot schema.Field_slot) (fieldPlan, error) {
+	t, err := slot.Type()
+	if err != nil {
+		return fieldPlan{}, err
+	}
+	ti, err := c.typeInfo(t)
+	if err != nil {
+		return fieldPlan{}, err
+	}
+	dv, err := slot.DefaultValue()
+	if err != nil {
+		return fieldPlan{}, err
+	}
+	fp := fieldPlan{typ: ti, off: slot.Offset()}
+	switch ti.which {
+	case schema.Type_Which_bool:
+		fp.bits = 1
+		if dv.Bool() {
+			fp.def = 1
+		}
+	case schema.Type_Which_int8:
+		fp.bits, fp.def = 8, uint64(uint8(dv.Int8()))
+	case schema.Type_Which_uint8:
+		fp.bits, fp.def = 8, uint64(dv.Uint8())
+	case schema.Type_Which_int16:
+		fp.bits, fp.def = 16, uint64(uint16(dv.Int16()))
+	case schema.Type_Which_uint16:
+		fp.bits, fp.def = 16, uint64(dv.Uint16())
+	case schema.Type_Which_enum:
+		fp.bits, fp.def = 16, uint64(dv.Enum())
+	case schema.Type_Which_int32, schema.Type_Which_uint32, schema.Type_Which_float32:
+		// Defaults are compared as raw bits, which works for any 32-bit type.
+		fp.bits = 32
+		switch ti.which {
+		case schema.Type_Which_int32:
+			fp.def = uint64(uint32(dv.Int32()))
+		case schema.Type_Which_uint32:
+			fp.def = uint64(dv.Uint32())
+		default:
+			fp.def = uint64(math.Float32bits(dv.Float32()))
+		}
+	case schema.Type_Which_int64, schema.Type_Which_uint64, schema.Type_Which_float64:
+		fp.bits = 64
+		switch ti.which {
+		case schema.Type_Which_int64:
+			fp.def = uint64(dv.Int64())
+		case schema.Type_Which_uint64:
+			fp.def = dv.Uint64()
+		default:
+			fp.def = math.Float64bits(dv.Float64())
+		}
+	}
+	if fp.bits > 0 {
+		fp.off *= fp.bits
+	}
+	return fp, nil
+}
+
+func (c *Collector) typeInfo(t schema.Type) (typeInfo, error) {
+	ti := typeInfo{which: t.Which()}
+	switch ti.which {
+	case schema.Type_Which_structType:
+		st, err := c.plan(t.StructType().TypeId())
+		if err != nil {
+			return typeInfo{}, err
+		}
+		ti.st = st
+	case schema.Type_Which_list:
+		et, err := t.List().ElementType()
+		if err != nil {
+			return typeInfo{}, err
+		}
+		elem, err := c.typeInfo(et)
+		if err != nil {
+			return typeInfo{}, err
+		}
+		ti.elem = &elem
+	}
+	return ti, nil
+}
+
+func (stats *Stats) typeStats(p *structPlan) *TypeStats {
+	ts := stats.Types[p.id]
+	if ts == nil {
+		ts = &TypeStats{
+			Name:   p.name,
+			Fields: make(map[string]*FieldStats, len(p.fields)),
+		}
+		for i := range p.fields {
+			ts.Fields[p.fields[i].name] = new(FieldStats)
+		}
+		stats.Types[p.id] = ts
+	}
+	return ts
+}
+
+func (stats *Stats) addStruct(p *structPlan, s capnp.Struct) error {
+	if !s.IsValid() {
+		return nil
+	}
+	ts := stats.typeStats(p)
+	sz := s.Size()
+	ts.Count++
+	ts.DataBytes += uint64(sz.DataSize)
+	ts.PointerBytes += uint64(sz.PointerCount) * 8
+	unused := 0
+	for i := 0; i < int(sz.DataSize)*8; i++ {
+		if i >= len(p.usedBits) || !p.usedBits[i] {
+			unused++
+		}
+	}
+	ts.UnusedBytes += uint64(unused / 8)
+
+fields:
+	for i := range p.fields {
+		fp := &p.fields[i]
+		for _, cond := range fp.conds {
+			if s.Uint16(cond.off) != cond.val {
+				continue fields
+			}
+		}
+		fs := ts.Fields[fp.name]
+		if fp.typ.which == schema.Type_Which_void {
+			fs.Set++
+			continue
+		}
+		if fp.bits > 0 {
+			fs.InlineBytes += uint64(fp.bits+7) / 8
+			if dataBits(s, fp) != fp.def {
+				fs.Set++
+			} else {
+				fs.Default++
+			}
+			continue
+		}
+		fs.InlineBytes += 8
+		ptr, err := s.Ptr(uint16(fp.off))
+		if err != nil {
+			return fmt.Errorf("%s: %v", fp.name, err)
+		}
+		if !ptr.IsValid() {
+			fs.Default++
+			continue
+		}
+		fs.Set++
+		fs.ObjectBytes += objectSize(&fp.typ, ptr)
+		if err := stats.addPtr(&fp.typ, ptr); err != nil {
+			return fmt.Errorf("%s: %v", fp.name, err)
+		}
+	}
+	return nil
+}
+
+// addPtr adds the structs reachable from ptr to stats.
+func (stats *Stats) addPtr(ti *typeInfo, ptr capnp.Ptr) error {
+	switch ti.which {
+	case schema.Type_Which_structType:
+		return stats.addStruct(ti.st, ptr.Struct())
+	case schema.Type_Which_list:
+		l := ptr.List()
+		switch ti.elem.which {
+		case schema.Type_Which_structType:
+			for i := 0; i < l.Len(); i++ {
+				if err := stats.addStruct(ti.elem.st, l.Struct(i)); err != nil {
+					return fmt.Errorf("[%d]: %v", i, err)
+				}
+			}
+		case schema.Type_Which_list:
+			pl := capnp.PointerList{List: l}
+			for i := 0; i < l.Len(); i++ {
+				elem, err := pl.PtrAt(i)
+				if err != nil {
+					return fmt.Errorf("[%d]: %v", i, err)
+				}
+				if err := stats.addPtr(ti.elem, elem); err != nil {
+					return fmt.Errorf("[%d]: %v", i, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// objectSize returns the size of the object that ptr points to,
+// excluding the objects that it points to.
+func objectSize(ti *typeInfo, ptr capnp.Ptr) uint64 {
+	if s := ptr.Struct(); s.IsValid() {
+		sz := s.Size()
+		return uint64(sz.DataSize) + uint64(sz.PointerCount)*8
+	}
+	l := ptr.List()
+	if !l.IsValid() {
+		return 0
+	}
+	n := uint64(l.Len())
+	var elemWhich schema.Type_Which
+	if ti.which == schema.Type_Which_list {
+		elemWhich = ti.elem.which
+	} else {
+		// Text and Data are lists of bytes.
+		elemWhich = schema.Type_Which_uint8
+	}
+	switch elemWhich {
+	case schema.Type_Which_void:
+		return 0
+	case schema.Type_Which_bool:
+		return padToWord((n + 7) / 8)
+	case schema.Type_Which_structType:
+		if n == 0 {
+			return 8
+		}
+		sz := l.Struct(0).Size()
+		return 8 + n*(uint64(sz.DataSize)+uint64(sz.PointerCount)*8)
+	}
+	if n == 0 {
+		return 0
+	}
+	sz := l.Struct(0).Size()
+	return padToWord(n * (uint64(sz.DataSize) + uint64(sz.PointerCount)*8))
+}
+
+func padToWord(n uint64) uint64 {
+	return (n + 7) &^ 7
+}
+
+func dataBits(s capnp.Struct, fp *fieldPlan) uint64 {
+	switch fp.bits {
+	case 1:
+		if s.Bit(capnp.BitOffset(fp.off)) {
+			return 1
+		}
+		return 0
+	case 8:
+		return uint64(s.Uint8(capnp.DataOffset(fp.off / 8)))
+	case 16:
+		return uint64(s.Uint16(capnp.DataOffset(fp.off / 8)))
+	case 32:
+		return uint64(s.Uint32(capnp.DataOffset(fp.off / 8)))
+	default:
+		return s.Uint64(capnp.DataOffset(fp.off / 8))
+	}
+}
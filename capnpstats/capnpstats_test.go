@@ -0,0 +1,94 @@
+package capnpstats
+
+import (
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+func TestMessageStats(t *testing.T) {
+	msg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dates, err := z.NewZdatevec(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dates.At(0).SetYear(2017)
+	dates.At(1).SetYear(2018)
+	dates.At(1).SetMonth(1)
+
+	st, err := MessageStats(air.Z_TypeID, msg)
+	if err != nil {
+		t.Fatal("MessageStats:", err)
+	}
+	if st.Message.TotalBytes != uint64(len(seg.Data())) {
+		t.Errorf("Message.TotalBytes = %d; want %d", st.Message.TotalBytes, len(seg.Data()))
+	}
+
+	zs := st.Types[air.Z_TypeID]
+	if zs == nil || zs.Count != 1 {
+		t.Fatalf("Types[Z] = %+v; want 1 struct", zs)
+	}
+	if fs := zs.Fields["zdatevec"]; fs.Set != 1 || fs.InlineBytes != 8 || fs.ObjectBytes != 8+3*8 {
+		t.Errorf("Fields[zdatevec] = %+v; want set once, 8 inline bytes, 32 object bytes", *fs)
+	}
+	if fs := zs.Fields["text"]; *fs != (FieldStats{}) {
+		t.Errorf("Fields[text] = %+v; want zero for an inactive union member", *fs)
+	}
+
+	ds := st.Types[air.Zdate_TypeID]
+	if ds == nil || ds.Count != 3 {
+		t.Fatalf("Types[Zdate] = %+v; want 3 structs", ds)
+	}
+	if ds.DataBytes != 24 || ds.UnusedBytes != 3*4 {
+		t.Errorf("Zdate DataBytes = %d, UnusedBytes = %d; want 24, 12", ds.DataBytes, ds.UnusedBytes)
+	}
+	if fs := ds.Fields["year"]; fs.Set != 2 || fs.Default != 1 || fs.InlineBytes != 6 {
+		t.Errorf("Fields[year] = %+v; want 2 set, 1 default, 6 inline bytes", *fs)
+	}
+	if fs := ds.Fields["month"]; fs.Set != 1 || fs.Default != 2 {
+		t.Errorf("Fields[month] = %+v; want 1 set, 2 default", *fs)
+	}
+}
+
+func TestMessageStats_Groups(t *testing.T) {
+	msg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z.SetGrp()
+	z.Grp().SetFirst(1)
+
+	st, err := MessageStats(air.Z_TypeID, msg)
+	if err != nil {
+		t.Fatal("MessageStats:", err)
+	}
+	zs := st.Types[air.Z_TypeID]
+	if fs := zs.Fields["grp.first"]; fs == nil || fs.Set != 1 || fs.InlineBytes != 8 {
+		t.Errorf("Fields[grp.first] = %+v; want set once", fs)
+	}
+	if fs := zs.Fields["grp.second"]; fs == nil || fs.Default != 1 {
+		t.Errorf("Fields[grp.second] = %+v; want default once", fs)
+	}
+}
+
+func TestMessageStats_NotStruct(t *testing.T) {
+	msg, _, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := MessageStats(air.Airport_TypeID, msg); err == nil {
+		t.Error("MessageStats with enum type did not return an error")
+	}
+}
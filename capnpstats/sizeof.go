@@ -0,0 +1,155 @@
+package capnpstats
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/iguazio/go-capnproto2/internal/schema"
+)
+
+// A Plan describes the objects that a message will hold, so that
+// SizeOf can compute the message's size before it is built.  The same
+// Plan type describes structs, lists, Text, and Data; only the fields
+// that apply to the object's type are used.
+type Plan struct {
+	// Len is the number of elements in a list, or the number of bytes
+	// in a Text (not counting its NUL terminator) or Data.  If Len is
+	// zero and Elems is not, the list has len(Elems) elements.
+	Len int
+
+	// Fields holds the plans for the pointer fields of a struct that
+	// will be set, keyed by name as in TypeStats.  Fields that are not
+	// listed are left null.  For a list of structs, Fields applies to
+	// every element.
+	Fields map[string]*Plan
+
+	// Elems holds the plans for the elements of a list of Text, Data,
+	// or lists.  A nil element is left null.
+	Elems []*Plan
+}
+
+func (p *Plan) len() int {
+	if p.Len == 0 {
+		return len(p.Elems)
+	}
+	return p.Len
+}
+
+// SizeOf returns the number of bytes in the segment of a message whose
+// root is a struct of the type with the given ID, built as described by
+// plan, using the schemas in schemas.DefaultRegistry.  The size
+// includes the root pointer but not the stream header that frames the
+// message on the wire.  Objects are assumed to be allocated once each
+// in a single segment, as NewMessage with a SingleSegment arena does.
+func SizeOf(typeID uint64, plan *Plan) (uint64, error) {
+	return NewCollector(nil).SizeOf(typeID, plan)
+}
+
+// SizeOf returns the size of a message built as described by plan.
+// See the package-level SizeOf for details.
+func (c *Collector) SizeOf(typeID uint64, plan *Plan) (uint64, error) {
+	p, err := c.plan(typeID)
+	if err != nil {
+		return 0, fmt.Errorf("capnpstats: %v", err)
+	}
+	if plan == nil {
+		plan = new(Plan)
+	}
+	n, err := structSize(p, plan)
+	if err != nil {
+		return 0, fmt.Errorf("capnpstats: %s: %v", p.name, err)
+	}
+	return 8 + n, nil
+}
+
+// structSize returns the size of a struct and the objects reachable
+// from it.
+func structSize(p *structPlan, plan *Plan) (uint64, error) {
+	n := uint64(p.size.TotalSize())
+	names := make([]string, 0, len(plan.Fields))
+	for name := range plan.Fields {
+		names = append(names, name)
+	}
+	// Sort so that the first error reported doesn't vary.
+	sort.Strings(names)
+	for _, name := range names {
+		fp := p.field(name)
+		if fp == nil {
+			return 0, fmt.Errorf("no field %q", name)
+		}
+		if fp.bits > 0 || fp.typ.which == schema.Type_Which_void {
+			return 0, fmt.Errorf("field %s is not a pointer", name)
+		}
+		sz, err := ptrSize(&fp.typ, plan.Fields[name])
+		if err != nil {
+			return 0, fmt.Errorf("%s: %v", name, err)
+		}
+		n += sz
+	}
+	return n, nil
+}
+
+func (p *structPlan) field(name string) *fieldPlan {
+	for i := range p.fields {
+		if p.fields[i].name == name {
+			return &p.fields[i]
+		}
+	}
+	return nil
+}
+
+// ptrSize returns the size of the object described by plan and the
+// objects reachable from it.
+func ptrSize(ti *typeInfo, plan *Plan) (uint64, error) {
+	if plan == nil {
+		return 0, nil
+	}
+	switch ti.which {
+	case schema.Type_Which_structType:
+		return structSize(ti.st, plan)
+	case schema.Type_Which_text:
+		return padToWord(uint64(plan.Len) + 1), nil
+	case schema.Type_Which_data:
+		return padToWord(uint64(plan.Len)), nil
+	case schema.Type_Which_interface:
+		return 0, nil
+	case schema.Type_Which_list:
+		return listSize(ti.elem, plan)
+	default:
+		return 0, fmt.Errorf("can't size %v value", ti.which)
+	}
+}
+
+func listSize(elem *typeInfo, plan *Plan) (uint64, error) {
+	n := uint64(plan.len())
+	switch elem.which {
+	case schema.Type_Which_void:
+		return 0, nil
+	case schema.Type_Which_bool:
+		return padToWord((n + 7) / 8), nil
+	case schema.Type_Which_int8, schema.Type_Which_uint8:
+		return padToWord(n), nil
+	case schema.Type_Which_int16, schema.Type_Which_uint16, schema.Type_Which_enum:
+		return padToWord(n * 2), nil
+	case schema.Type_Which_int32, schema.Type_Which_uint32, schema.Type_Which_float32:
+		return padToWord(n * 4), nil
+	case schema.Type_Which_int64, schema.Type_Which_uint64, schema.Type_Which_float64:
+		return n * 8, nil
+	case schema.Type_Which_structType:
+		// Every element has the same plan, so size one and multiply.
+		sz, err := structSize(elem.st, &Plan{Fields: plan.Fields})
+		if err != nil {
+			return 0, fmt.Errorf("[]: %v", err)
+		}
+		return 8 + n*sz, nil
+	}
+	sz := n * 8
+	for i, e := range plan.Elems {
+		esz, err := ptrSize(elem, e)
+		if err != nil {
+			return 0, fmt.Errorf("[%d]: %v", i, err)
+		}
+		sz += esz
+	}
+	return sz, nil
+}
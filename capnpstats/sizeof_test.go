@@ -0,0 +1,57 @@
+package capnpstats
+
+import (
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+func TestSizeOf(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bag, err := air.NewRootBag(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter, err := bag.NewCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := counter.SetWords("hello, world"); err != nil {
+		t.Fatal(err)
+	}
+	words, err := counter.NewWordlist(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	words.Set(0, "a")
+	words.Set(2, "eight ch")
+	if _, err := counter.NewBitlist(70); err != nil {
+		t.Fatal(err)
+	}
+
+	plan := &Plan{Fields: map[string]*Plan{
+		"counter": {Fields: map[string]*Plan{
+			"words":    {Len: len("hello, world")},
+			"wordlist": {Elems: []*Plan{{Len: 1}, nil, {Len: 8}}},
+			"bitlist":  {Len: 70},
+		}},
+	}}
+	got, err := SizeOf(air.Bag_TypeID, plan)
+	if err != nil {
+		t.Fatal("SizeOf:", err)
+	}
+	if want := uint64(len(seg.Data())); got != want {
+		t.Errorf("SizeOf = %d; want %d", got, want)
+	}
+
+	if _, err := SizeOf(air.Bag_TypeID, &Plan{Fields: map[string]*Plan{"nope": {}}}); err == nil {
+		t.Error("SizeOf with an unknown field succeeded")
+	}
+	if _, err := SizeOf(air.Counter_TypeID, &Plan{Fields: map[string]*Plan{"size": {}}}); err == nil {
+		t.Error("SizeOf with a plan for a data field succeeded")
+	}
+}
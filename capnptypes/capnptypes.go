@@ -0,0 +1,244 @@
+// Package capnptypes reports the schema types of the objects in
+// Cap'n Proto messages using registered schemas.  A Resolver is a
+// capnp.TypeResolver, so it can be passed to capnp.WalkOptions to find
+// the type of every struct, list, and blob in a message, which is what
+// debuggers and sanitizers that work on arbitrary messages need.
+package capnptypes // import "github.com/iguazio/go-capnproto2/capnptypes"
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/nodemap"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+	"github.com/iguazio/go-capnproto2/schemas"
+)
+
+// Walk calls visit for each object reachable from msg's root pointer,
+// whose root is a struct of the type with the given ID, reporting the
+// types found in schemas.DefaultRegistry.  See capnp.Walk for details.
+func Walk(typeID uint64, msg *capnp.Message, visit func(capnp.Object) error) error {
+	opts := capnp.WalkOptions{
+		RootTypeID: typeID,
+		Types:      NewResolver(nil),
+	}
+	return opts.Walk(msg, visit)
+}
+
+// A Resolver describes the pointer fields of struct types using the
+// schemas in a registry.  It caches the schema information for the
+// types it has seen, and is safe for concurrent use.
+type Resolver struct {
+	mu    sync.Mutex
+	nodes nodemap.Map
+	plans map[uint64]*structPlan
+}
+
+// NewResolver returns a resolver for the types in reg.  If reg is nil,
+// schemas.DefaultRegistry is used.
+func NewResolver(reg *schemas.Registry) *Resolver {
+	r := &Resolver{plans: make(map[uint64]*structPlan)}
+	if reg != nil {
+		r.nodes.UseRegistry(reg)
+	}
+	return r
+}
+
+// PointerFields returns the pointer fields of s, a struct of the type
+// with the given ID.  It returns nil if the type is not in the
+// registry.
+func (r *Resolver) PointerFields(typeID uint64, s capnp.Struct) ([]capnp.PointerField, error) {
+	r.mu.Lock()
+	p, err := r.plan(typeID)
+	r.mu.Unlock()
+	if err != nil || p == nil {
+		return nil, err
+	}
+	n := int(s.Size().PointerCount)
+	if n > p.pointerCount {
+		n = p.pointerCount
+	}
+	fields := make([]capnp.PointerField, n)
+	for i := range p.fields {
+		fp := &p.fields[i]
+		if int(fp.index) < n && fp.active(s) {
+			fields[fp.index] = fp.field
+		}
+	}
+	return fields, nil
+}
+
+// A structPlan lists the pointer fields of a struct type.
+type structPlan struct {
+	pointerCount int
+	fields       []fieldPlan
+}
+
+// A fieldPlan is a pointer field and the union discriminant values
+// that must be set for it to be active.
+type fieldPlan struct {
+	index uint16
+	field capnp.PointerField
+	conds []unionCond
+}
+
+type unionCond struct {
+	off capnp.DataOffset
+	val uint16
+}
+
+func (fp *fieldPlan) active(s capnp.Struct) bool {
+	for _, c := range fp.conds {
+		if s.Uint16(c.off) != c.val {
+			return false
+		}
+	}
+	return true
+}
+
+// plan returns the plan for the struct type with the given ID, or nil
+// if the type is not registered.  The caller must hold r.mu.
+func (r *Resolver) plan(typeID uint64) (*structPlan, error) {
+	if p, ok := r.plans[typeID]; ok {
+		return p, nil
+	}
+	n, err := r.nodes.Find(typeID)
+	if schemas.IsNotFound(err) {
+		r.plans[typeID] = nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("capnptypes: find struct %#x: %v", typeID, err)
+	}
+	if n.Which() != schema.Node_Which_structNode {
+		return nil, fmt.Errorf("capnptypes: %#x is a %v, not a struct", typeID, n.Which())
+	}
+	p := &structPlan{pointerCount: int(n.StructNode().PointerCount())}
+	if err := r.addFields(p, "", n, nil); err != nil {
+		return nil, fmt.Errorf("capnptypes: struct %#x: %v", typeID, err)
+	}
+	r.plans[typeID] = p
+	return p, nil
+}
+
+// addFields adds the pointer fields of a struct or group node to p.
+func (r *Resolver) addFields(p *structPlan, prefix string, n schema.Node, conds []unionCond) error {
+	sn := n.StructNode()
+	fields, err := sn.Fields()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < fields.Len(); i++ {
+		f := fields.At(i)
+		name, err := f.Name()
+		if err != nil {
+			return err
+		}
+		name = prefix + name
+		fconds := conds
+		if dv := f.DiscriminantValue(); dv != schema.Field_noDiscriminant {
+			fconds = make([]unionCond, len(conds), len(conds)+1)
+			copy(fconds, conds)
+			fconds = append(fconds, unionCond{
+				off: capnp.DataOffset(sn.DiscriminantOffset() * 2),
+				val: dv,
+			})
+		}
+		switch f.Which() {
+		case schema.Field_Which_group:
+			g, err := r.nodes.Find(f.Group().TypeId())
+			if err != nil {
+				return fmt.Errorf("field %s: %v", name, err)
+			}
+			if err := r.addFields(p, name+".", g, fconds); err != nil {
+				return err
+			}
+		case schema.Field_Which_slot:
+			t, err := f.Slot().Type()
+			if err != nil {
+				return fmt.Errorf("field %s: %v", name, err)
+			}
+			if !isPointer(t.Which()) {
+				continue
+			}
+			p.fields = append(p.fields, fieldPlan{
+				index: uint16(f.Slot().Offset()),
+				field: capnp.PointerField{Name: name, Type: r.objectType(t)},
+				conds: fconds,
+			})
+		}
+	}
+	return nil
+}
+
+// objectType returns the capnp.ObjectType for a schema type.  AnyPointer
+// types are not known and have a zero ObjectType.
+func (r *Resolver) objectType(t schema.Type) capnp.ObjectType {
+	switch t.Which() {
+	case schema.Type_Which_structType:
+		id := t.StructType().TypeId()
+		return capnp.ObjectType{Name: r.nodeName(id), TypeID: id}
+	case schema.Type_Which_interface:
+		id := t.Interface().TypeId()
+		return capnp.ObjectType{Name: r.nodeName(id), TypeID: id}
+	case schema.Type_Which_list:
+		et, err := t.List().ElementType()
+		if err != nil {
+			return capnp.ObjectType{}
+		}
+		elem := r.objectType(et)
+		ot := capnp.ObjectType{Elem: &elem}
+		if elem.Name != "" {
+			ot.Name = "List(" + elem.Name + ")"
+		}
+		return ot
+	case schema.Type_Which_enum:
+		return capnp.ObjectType{Name: r.nodeName(t.Enum().TypeId())}
+	case schema.Type_Which_anyPointer:
+		return capnp.ObjectType{}
+	default:
+		return capnp.ObjectType{Name: typeNames[t.Which()]}
+	}
+}
+
+// nodeName returns the unqualified name of the node with the given ID,
+// or the empty string if it is not registered.
+func (r *Resolver) nodeName(id uint64) string {
+	n, err := r.nodes.Find(id)
+	if err != nil {
+		return ""
+	}
+	name, err := n.DisplayName()
+	if err != nil || int(n.DisplayNamePrefixLength()) > len(name) {
+		return ""
+	}
+	return name[n.DisplayNamePrefixLength():]
+}
+
+var typeNames = map[schema.Type_Which]string{
+	schema.Type_Which_void:    "Void",
+	schema.Type_Which_bool:    "Bool",
+	schema.Type_Which_int8:    "Int8",
+	schema.Type_Which_int16:   "Int16",
+	schema.Type_Which_int32:   "Int32",
+	schema.Type_Which_int64:   "Int64",
+	schema.Type_Which_uint8:   "UInt8",
+	schema.Type_Which_uint16:  "UInt16",
+	schema.Type_Which_uint32:  "UInt32",
+	schema.Type_Which_uint64:  "UInt64",
+	schema.Type_Which_float32: "Float32",
+	schema.Type_Which_float64: "Float64",
+	schema.Type_Which_text:    "Text",
+	schema.Type_Which_data:    "Data",
+}
+
+func isPointer(w schema.Type_Which) bool {
+	switch w {
+	case schema.Type_Which_text, schema.Type_Which_data, schema.Type_Which_list,
+		schema.Type_Which_structType, schema.Type_Which_interface, schema.Type_Which_anyPointer:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,81 @@
+package capnptypes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+func TestWalk(t *testing.T) {
+	msg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zvec, err := z.NewZvec(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := zvec.At(0).SetText("hello"); err != nil {
+		t.Fatal(err)
+	}
+	pb, err := zvec.At(1).NewPlanebase()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pb.SetName("base"); err != nil {
+		t.Fatal(err)
+	}
+	homes, err := pb.NewHomes(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	homes.Set(0, air.Airport_jfk)
+
+	type visit struct {
+		kind  capnp.ObjectKind
+		field string
+		typ   string
+	}
+	want := []visit{
+		{capnp.StructObject, "", ""},
+		{capnp.ListObject, "zvec", "List(Z)"},
+		{capnp.StructObject, "", "Z"},
+		{capnp.BlobObject, "text", "Text"},
+		{capnp.StructObject, "", "Z"},
+		{capnp.StructObject, "planebase", "PlaneBase"},
+		{capnp.BlobObject, "name", "Text"},
+		{capnp.ListObject, "homes", "List(Airport)"},
+	}
+	var got []visit
+	err = Walk(air.Z_TypeID, msg, func(obj capnp.Object) error {
+		got = append(got, visit{obj.Kind, obj.Field, obj.Type.Name})
+		return nil
+	})
+	if err != nil {
+		t.Fatal("Walk:", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Walk visited:\n%+v\nwant:\n%+v", got, want)
+	}
+}
+
+func TestPointerFields_Unknown(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := capnp.NewRootStruct(seg, capnp.ObjectSize{PointerCount: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields, err := NewResolver(nil).PointerFields(0xdeadbeef, s)
+	if fields != nil || err != nil {
+		t.Errorf("PointerFields(unknown type) = %v, %v; want nil, nil", fields, err)
+	}
+}
@@ -0,0 +1,138 @@
+package capnp
+
+import (
+	"errors"
+	"fmt"
+)
+
+// A CapSnapshot is a serializable record of a message's capability
+// table, made by SnapshotCaps.  Refs[i] identifies the capability at
+// index i, or is nil if the entry is null.  Since a message's interface
+// pointers refer to capabilities by index, restoring a snapshot into
+// the message with RestoreCaps makes it usable again, so a message
+// queued for a connection that was lost can be sent on a new one.
+type CapSnapshot struct {
+	Refs [][]byte
+}
+
+// A CapExporter saves capabilities for SnapshotCaps.  ExportCap
+// returns a non-empty reference from which the CapImporter used to
+// restore the snapshot can get an equivalent client, such as the
+// SturdyRef returned by a Persistent capability's save method or the
+// name that a server registered the capability under.
+type CapExporter interface {
+	ExportCap(c Client) (ref []byte, err error)
+}
+
+// A CapImporter restores the capabilities saved by a CapExporter.
+type CapImporter interface {
+	ImportCap(ref []byte) (Client, error)
+}
+
+// CapExporterFunc is a function that implements CapExporter.
+type CapExporterFunc func(c Client) ([]byte, error)
+
+// ExportCap returns f(c).
+func (f CapExporterFunc) ExportCap(c Client) ([]byte, error) {
+	return f(c)
+}
+
+// CapImporterFunc is a function that implements CapImporter.
+type CapImporterFunc func(ref []byte) (Client, error)
+
+// ImportCap returns f(ref).
+func (f CapImporterFunc) ImportCap(ref []byte) (Client, error) {
+	return f(ref)
+}
+
+// SnapshotCaps exports each non-null capability in m's capability
+// table with e.  The table is not modified.
+func SnapshotCaps(m *Message, e CapExporter) (CapSnapshot, error) {
+	snap := CapSnapshot{Refs: make([][]byte, len(m.CapTable))}
+	for i, c := range m.CapTable {
+		if c == nil {
+			continue
+		}
+		ref, err := e.ExportCap(c)
+		if err != nil {
+			return CapSnapshot{}, fmt.Errorf("capnp: snapshot capability %d: %v", i, err)
+		}
+		if len(ref) == 0 {
+			return CapSnapshot{}, fmt.Errorf("capnp: snapshot capability %d: exporter returned an empty reference", i)
+		}
+		snap.Refs[i] = ref
+	}
+	return snap, nil
+}
+
+// RestoreCaps replaces m's capability table with the clients that imp
+// returns for the references in snap.  The clients in the old table
+// are not closed, since they usually belong to a connection that is
+// gone.  If any import fails, the clients imported so far are closed
+// and m is left unchanged.
+func RestoreCaps(m *Message, snap CapSnapshot, imp CapImporter) error {
+	tab := make([]Client, len(snap.Refs))
+	for i, ref := range snap.Refs {
+		if ref == nil {
+			continue
+		}
+		c, err := imp.ImportCap(ref)
+		if err != nil {
+			for _, c := range tab[:i] {
+				if c != nil {
+					c.Close()
+				}
+			}
+			return fmt.Errorf("capnp: restore capability %d: %v", i, err)
+		}
+		tab[i] = c
+	}
+	m.CapTable = tab
+	return nil
+}
+
+// Marshal returns the snapshot encoded as a Cap'n Proto message whose
+// root is a List(Data), with a null element for each null entry.
+func (snap CapSnapshot) Marshal() ([]byte, error) {
+	msg, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		return nil, err
+	}
+	l, err := NewDataListFromBytes(seg, snap.Refs)
+	if err != nil {
+		return nil, err
+	}
+	if err := msg.SetRootPtr(l.ToPtr()); err != nil {
+		return nil, err
+	}
+	return msg.Marshal()
+}
+
+// UnmarshalCapSnapshot decodes a snapshot encoded by
+// CapSnapshot.Marshal.  The references are copied out of data.
+func UnmarshalCapSnapshot(data []byte) (CapSnapshot, error) {
+	msg, err := Unmarshal(data)
+	if err != nil {
+		return CapSnapshot{}, err
+	}
+	p, err := msg.RootPtr()
+	if err != nil {
+		return CapSnapshot{}, err
+	}
+	l := p.List()
+	if p.IsValid() && !l.IsValid() {
+		return CapSnapshot{}, errCapSnapshot
+	}
+	refs, err := DataList{l}.Bytes()
+	if err != nil {
+		return CapSnapshot{}, err
+	}
+	for i, ref := range refs {
+		if ref != nil {
+			refs[i] = append([]byte(nil), ref...)
+		}
+	}
+	return CapSnapshot{Refs: refs}, nil
+}
+
+var errCapSnapshot = errors.New("capnp: capability snapshot root is not a list")
@@ -0,0 +1,96 @@
+package capnp
+
+import (
+	"errors"
+	"testing"
+)
+
+type namedClient struct {
+	name   string
+	closed bool
+}
+
+func (c *namedClient) Call(*Call) Answer {
+	return ErrorAnswer(errors.New("not implemented"))
+}
+
+func (c *namedClient) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestCapSnapshot(t *testing.T) {
+	msg, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := NewRootStruct(seg, ObjectSize{PointerCount: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg.AddCap(nil)
+	id := msg.AddCap(&namedClient{name: "foo"})
+	if err := root.SetPtr(1, NewInterface(seg, id).ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+
+	snap, err := SnapshotCaps(msg, CapExporterFunc(func(c Client) ([]byte, error) {
+		return []byte(c.(*namedClient).name), nil
+	}))
+	if err != nil {
+		t.Fatal("SnapshotCaps:", err)
+	}
+	data, err := snap.Marshal()
+	if err != nil {
+		t.Fatal("Marshal:", err)
+	}
+	snap, err = UnmarshalCapSnapshot(data)
+	if err != nil {
+		t.Fatal("UnmarshalCapSnapshot:", err)
+	}
+	if len(snap.Refs) != 2 || snap.Refs[0] != nil || string(snap.Refs[1]) != "foo" {
+		t.Fatalf("snapshot = %q; want [nil \"foo\"]", snap.Refs)
+	}
+
+	var imported []*namedClient
+	imp := CapImporterFunc(func(ref []byte) (Client, error) {
+		c := &namedClient{name: "new " + string(ref)}
+		imported = append(imported, c)
+		return c, nil
+	})
+	if err := RestoreCaps(msg, snap, imp); err != nil {
+		t.Fatal("RestoreCaps:", err)
+	}
+	p, err := root.Ptr(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, ok := p.Interface().Client().(*namedClient); !ok || c.name != "new foo" {
+		t.Errorf("restored client = %#v; want \"new foo\"", p.Interface().Client())
+	}
+
+	failing := CapImporterFunc(func(ref []byte) (Client, error) {
+		return nil, errors.New("gone")
+	})
+	old := msg.CapTable
+	if err := RestoreCaps(msg, snap, failing); err == nil {
+		t.Error("RestoreCaps with a failing importer did not return an error")
+	}
+	if msg.CapTable[1] != old[1] {
+		t.Error("failed RestoreCaps modified the capability table")
+	}
+}
+
+func TestSnapshotCapsEmptyRef(t *testing.T) {
+	msg, _, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg.AddCap(&namedClient{})
+	_, err = SnapshotCaps(msg, CapExporterFunc(func(c Client) ([]byte, error) {
+		return nil, nil
+	}))
+	if err == nil {
+		t.Error("SnapshotCaps with an empty reference did not return an error")
+	}
+}
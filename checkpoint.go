@@ -0,0 +1,109 @@
+package capnp
+
+import "errors"
+
+// A Token records how much of a message's arena was in use at the time
+// that Checkpoint was called.  The zero value is not a valid token.
+type Token struct {
+	msg   *Message
+	sizes []int // length of each segment's data, or -1 if not known
+	caps  int
+}
+
+// Checkpoint returns a token that Rollback can use to free the objects
+// that are allocated after this call.
+func (m *Message) Checkpoint() Token {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := m.Arena.NumSegments()
+	t := Token{
+		msg:   m,
+		sizes: make([]int, n),
+		caps:  len(m.CapTable),
+	}
+	for i := range t.sizes {
+		id := SegmentID(i)
+		if seg := m.segment(id); seg != nil {
+			t.sizes[i] = len(seg.data)
+			continue
+		}
+		data, err := m.Arena.Data(id)
+		if err != nil {
+			t.sizes[i] = -1
+			continue
+		}
+		t.sizes[i] = len(data)
+	}
+	return t
+}
+
+// Rollback frees the objects allocated since t was returned by
+// Checkpoint and removes the capabilities added to the capability
+// table since then, so that a message can be built speculatively and
+// the part that turns out to be unwanted dropped without starting over.
+// Objects allocated after the rollback reuse the freed space.
+//
+// Rollback only undoes allocations: writes to objects that were
+// allocated before the checkpoint are kept.  Any pointers to freed
+// objects, including the root pointer, must be cleared or overwritten
+// before the message is read or written.  Segments added since the
+// checkpoint are emptied, and removed if the arena was created by
+// MultiSegment.
+//
+// Rolling back to a token invalidates any tokens returned by later
+// calls to Checkpoint.
+func (m *Message) Rollback(t Token) error {
+	if t.msg != m {
+		return errors.New("capnp: rollback to token from a different message")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if t.caps > len(m.CapTable) {
+		return errStaleToken
+	}
+	for i, sz := range t.sizes {
+		if seg := m.segment(SegmentID(i)); seg != nil && sz > len(seg.data) {
+			return errStaleToken
+		}
+	}
+	n := int64(len(t.sizes))
+	for id, seg := range m.segs {
+		if int64(id) >= n {
+			seg.data = seg.data[:0]
+		}
+	}
+	if msa, ok := m.Arena.(*multiSegmentArena); ok && int64(len(*msa)) > n {
+		*msa = (*msa)[:n]
+		for id := range m.segs {
+			if int64(id) >= n {
+				delete(m.segs, id)
+			}
+		}
+	}
+	for i, sz := range t.sizes {
+		if seg := m.segment(SegmentID(i)); seg != nil && sz >= 0 {
+			seg.data = seg.data[:sz]
+		}
+	}
+	m.CapTable = m.CapTable[:t.caps]
+
+	// Forget cached state about the freed objects, since new objects
+	// may be allocated at the same addresses.
+	for k, l := range m.interned {
+		if !t.allocated(l.seg.id, l.off) {
+			delete(m.interned, k)
+		}
+	}
+	for k := range m.presence {
+		if !t.allocated(k.seg, k.addr) {
+			delete(m.presence, k)
+		}
+	}
+	return nil
+}
+
+// allocated reports whether addr in the segment with the given ID was
+// in use when t was created.
+func (t Token) allocated(id SegmentID, addr Address) bool {
+	return int64(id) < int64(len(t.sizes)) && (t.sizes[id] < 0 || int(addr) < t.sizes[id])
+}
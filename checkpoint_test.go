@@ -0,0 +1,134 @@
+package capnp
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRollback(t *testing.T) {
+	tests := []struct {
+		name  string
+		arena func() Arena
+	}{
+		{"SingleSegment", func() Arena { return SingleSegment(nil) }},
+		{"MultiSegment", func() Arena { return MultiSegment([][]byte{make([]byte, 0, 32)}) }},
+	}
+	for _, test := range tests {
+		msg, seg, err := NewMessage(test.arena())
+		if err != nil {
+			t.Fatalf("%s: NewMessage: %v", test.name, err)
+		}
+		root, err := NewRootStruct(seg, ObjectSize{DataSize: 8, PointerCount: 1})
+		if err != nil {
+			t.Fatalf("%s: NewRootStruct: %v", test.name, err)
+		}
+		root.SetUint64(0, 42)
+		want, err := msg.Marshal()
+		if err != nil {
+			t.Fatalf("%s: Marshal: %v", test.name, err)
+		}
+
+		tok := msg.Checkpoint()
+		big, err := NewData(seg, make([]byte, 1024))
+		if err != nil {
+			t.Fatalf("%s: NewData: %v", test.name, err)
+		}
+		if err := root.SetPtr(0, big.ToPtr()); err != nil {
+			t.Fatalf("%s: SetPtr: %v", test.name, err)
+		}
+		msg.AddCap(ErrorClient(errBufferFull))
+		if err := root.SetPtr(0, Ptr{}); err != nil {
+			t.Fatalf("%s: SetPtr: %v", test.name, err)
+		}
+		if err := msg.Rollback(tok); err != nil {
+			t.Fatalf("%s: Rollback: %v", test.name, err)
+		}
+		if n := msg.NumSegments(); n != 1 {
+			t.Errorf("%s: NumSegments() = %d after rollback; want 1", test.name, n)
+		}
+		if len(msg.CapTable) != 0 {
+			t.Errorf("%s: len(CapTable) = %d after rollback; want 0", test.name, len(msg.CapTable))
+		}
+		got, err := msg.Marshal()
+		if err != nil {
+			t.Fatalf("%s: Marshal after rollback: %v", test.name, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s: Marshal after rollback = % 02x; want % 02x", test.name, got, want)
+		}
+
+		// The freed space is reused.
+		txt, err := NewText(seg, "hi")
+		if err != nil {
+			t.Fatalf("%s: NewText: %v", test.name, err)
+		}
+		if err := root.SetPtr(0, txt.ToPtr()); err != nil {
+			t.Fatalf("%s: SetPtr: %v", test.name, err)
+		}
+		if p, _ := root.Ptr(0); p.Text() != "hi" {
+			t.Errorf("%s: root.Ptr(0).Text() = %q; want \"hi\"", test.name, p.Text())
+		}
+	}
+}
+
+func TestRollback_InvalidToken(t *testing.T) {
+	msg, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := msg.Rollback(Token{}); err == nil {
+		t.Error("Rollback(Token{}) succeeded; want error")
+	}
+	other, _, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := msg.Rollback(other.Checkpoint()); err == nil {
+		t.Error("Rollback to other message's token succeeded; want error")
+	}
+
+	early := msg.Checkpoint()
+	if _, err := NewStruct(seg, ObjectSize{DataSize: 16}); err != nil {
+		t.Fatal(err)
+	}
+	late := msg.Checkpoint()
+	if err := msg.Rollback(early); err != nil {
+		t.Fatal("Rollback(early):", err)
+	}
+	if err := msg.Rollback(late); err == nil {
+		t.Error("Rollback to token taken after an earlier rollback's token succeeded; want error")
+	}
+}
+
+func TestRollback_Intern(t *testing.T) {
+	msg, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg.Intern = true
+	root, err := NewRootStruct(seg, ObjectSize{PointerCount: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tok := msg.Checkpoint()
+	if err := root.SetText(0, "foo"); err != nil {
+		t.Fatal(err)
+	}
+	root.SetPtr(0, Ptr{})
+	if err := msg.Rollback(tok); err != nil {
+		t.Fatal("Rollback:", err)
+	}
+	// Allocate over the freed text so that a stale interned value
+	// would be visible.
+	if err := root.SetText(1, "bar"); err != nil {
+		t.Fatal(err)
+	}
+	if err := root.SetText(0, "foo"); err != nil {
+		t.Fatal(err)
+	}
+	p0, _ := root.Ptr(0)
+	p1, _ := root.Ptr(1)
+	if p0.Text() != "foo" || p1.Text() != "bar" {
+		t.Errorf("fields = %q, %q; want \"foo\", \"bar\"", p0.Text(), p1.Text())
+	}
+}
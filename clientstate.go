@@ -0,0 +1,73 @@
+package capnp
+
+// A ClientStater is a Client that can report its resolution state.
+// Client types that wrap or forward to another client should implement
+// it so that ClientState can see through them.
+type ClientStater interface {
+	Client
+
+	// ClientState returns the same values as the ClientState function.
+	// It must not block, since it may be called while the caller holds
+	// locks.
+	ClientState() (resolved bool, brand interface{}, remote bool)
+}
+
+// A PipelineStater is an Answer that can report the resolution state of
+// the capabilities it will contain.  PipelineClient uses it to
+// implement ClientStater.
+type PipelineStater interface {
+	Answer
+
+	// PipelineClientState returns the state of the capability at the
+	// given transform of the answer, as ClientState would.
+	PipelineClientState(transform []PipelineOp) (resolved bool, brand interface{}, remote bool)
+}
+
+// ClientState reports what c currently refers to, so that callers can
+// decide how to route a call: a resolved local capability can be called
+// directly, a remote one is best pipelined on, and an unresolved one
+// may be worth waiting for.
+//
+// resolved is false if c is a promise that has not resolved yet.  brand
+// identifies the implementation that calls end up at: the client itself
+// for a local capability, or the connection it is imported from for a
+// remote one.  Two clients with the same non-nil brand are served by
+// the same implementation; comparing brands requires local client types
+// to be comparable, as pointers are.  remote is true if calls to c leave this
+// vat.  An unresolved promise may still be remote if the promise itself
+// is hosted elsewhere, such as an unreturned question.
+//
+// A nil client or an error client is resolved with a nil brand.
+func ClientState(c Client) (resolved bool, brand interface{}, remote bool) {
+	if c == nil {
+		return true, nil, false
+	}
+	if cs, ok := c.(ClientStater); ok {
+		return cs.ClientState()
+	}
+	return true, c, false
+}
+
+// ClientState returns the state of the capability the pipeline will
+// yield.  If the answer has resolved, it is the state of the resolved
+// capability.  Answers that don't implement PipelineStater are
+// reported as unresolved.
+func (pc *PipelineClient) ClientState() (resolved bool, brand interface{}, remote bool) {
+	ps, ok := pc.answer.(PipelineStater)
+	if !ok {
+		return false, nil, false
+	}
+	return ps.PipelineClientState(pc.transform())
+}
+
+func (ans immediateAnswer) PipelineClientState(transform []PipelineOp) (resolved bool, brand interface{}, remote bool) {
+	return ClientState(ans.findClient(transform))
+}
+
+func (ans errorAnswer) PipelineClientState([]PipelineOp) (resolved bool, brand interface{}, remote bool) {
+	return true, nil, false
+}
+
+func (ec errorClient) ClientState() (resolved bool, brand interface{}, remote bool) {
+	return true, nil, false
+}
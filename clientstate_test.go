@@ -0,0 +1,54 @@
+package capnp
+
+import (
+	"errors"
+	"testing"
+)
+
+type remoteClient struct {
+	namedClient
+	resolved bool
+}
+
+func (c *remoteClient) ClientState() (resolved bool, brand interface{}, remote bool) {
+	return c.resolved, "conn", true
+}
+
+func TestClientState(t *testing.T) {
+	_, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	local := &namedClient{name: "local"}
+	remote := &remoteClient{resolved: true}
+	root, err := NewRootStruct(seg, ObjectSize{PointerCount: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	root.SetPtr(0, NewInterface(seg, seg.Message().AddCap(local)).ToPtr())
+	root.SetPtr(1, NewInterface(seg, seg.Message().AddCap(remote)).ToPtr())
+	ans := ImmediateAnswer(root)
+
+	tests := []struct {
+		name     string
+		c        Client
+		resolved bool
+		brand    interface{}
+		remote   bool
+	}{
+		{"nil", nil, true, nil, false},
+		{"local", local, true, local, false},
+		{"remote", remote, true, "conn", true},
+		{"unresolved remote", &remoteClient{}, false, "conn", true},
+		{"error", ErrorClient(errors.New("broken")), true, nil, false},
+		{"pipeline to local", NewPipeline(ans).GetPipeline(0).Client(), true, local, false},
+		{"pipeline to remote", NewPipeline(ans).GetPipeline(1).Client(), true, "conn", true},
+		{"pipeline to error", NewPipeline(ErrorAnswer(errors.New("broken"))).GetPipeline(0).Client(), true, nil, false},
+	}
+	for _, test := range tests {
+		resolved, brand, remote := ClientState(test.c)
+		if resolved != test.resolved || brand != test.brand || remote != test.remote {
+			t.Errorf("%s: ClientState(c) = %t, %v, %t; want %t, %v, %t", test.name, resolved, brand, remote, test.resolved, test.brand, test.remote)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/capnpjson"
+	"github.com/iguazio/go-capnproto2/encoding/text"
+)
+
+func runDecode(args []string, r io.Reader, w io.Writer) error {
+	cf := newFlags("decode", true)
+	pos, err := cf.parse(args, 1)
+	if err != nil {
+		return err
+	}
+	ss, err := loadSchemas(cf.schema)
+	if err != nil {
+		return err
+	}
+	typeID, err := ss.structID(pos[0])
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	var enc interface {
+		Encode(typeID uint64, s capnp.Struct) error
+	}
+	if cf.json {
+		je := capnpjson.NewEncoder(bw)
+		je.UseRegistry(ss.reg)
+		enc = je
+	} else {
+		te := text.NewEncoder(bw)
+		te.UseRegistry(ss.reg)
+		enc = te
+	}
+	dec := newDecoder(r, cf.packed)
+	for i := 0; ; i++ {
+		msg, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("message %d: %v", i, err)
+		}
+		p, err := msg.RootPtr()
+		if err != nil {
+			return fmt.Errorf("message %d: %v", i, err)
+		}
+		if err := enc.Encode(typeID, p.Struct()); err != nil {
+			return fmt.Errorf("message %d: %v", i, err)
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+func newDecoder(r io.Reader, packed bool) *capnp.Decoder {
+	if packed {
+		return capnp.NewPackedDecoder(r)
+	}
+	return capnp.NewDecoder(r)
+}
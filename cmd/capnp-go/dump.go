@@ -0,0 +1,338 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/iguazio/go-capnproto2"
+)
+
+func runDump(args []string, r io.Reader, w io.Writer) error {
+	cf := newFlags("dump", false)
+	if _, err := cf.parse(args, 0); err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	err := eachMessage(r, cf.packed, func(i int, msg *capnp.Message) error {
+		lay, err := walk(msg)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(bw, "message %d\n", i)
+		lay.dump(bw)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func runStats(args []string, r io.Reader, w io.Writer) error {
+	cf := newFlags("stats", false)
+	if _, err := cf.parse(args, 0); err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	err := eachMessage(r, cf.packed, func(i int, msg *capnp.Message) error {
+		lay, err := walk(msg)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(bw, "message %d\n", i)
+		lay.stats.write(bw)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// eachMessage calls f for each message read from r.
+func eachMessage(r io.Reader, packed bool, f func(i int, msg *capnp.Message) error) error {
+	dec := newDecoder(r, packed)
+	for i := 0; ; i++ {
+		msg, err := dec.Decode()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("message %d: %v", i, err)
+		}
+		if err := f(i, msg); err != nil {
+			return fmt.Errorf("message %d: %v", i, err)
+		}
+	}
+}
+
+// A layout is the result of walking the pointers of a message.  It
+// records a note for each word that the walk reached.
+type layout struct {
+	segs  [][]byte
+	notes []map[int]string // segment ID to word index to note
+	stats msgStats
+}
+
+// msgStats summarizes the contents of a message.
+type msgStats struct {
+	segments    int
+	words       int // total words in all segments
+	dataWords   int // struct data sections and non-pointer list elements
+	ptrWords    int // struct pointer sections and pointer lists
+	tagWords    int // composite list tags and double-far landing pads
+	structs     int
+	lists       int
+	farPtrs     int
+	caps        int
+	nullPtrs    int
+	unreachable int
+	maxDepth    int
+}
+
+func (st *msgStats) write(w io.Writer) {
+	fmt.Fprintf(w, "  segments:    %d\n", st.segments)
+	fmt.Fprintf(w, "  size:        %d words (%d bytes)\n", st.words, st.words*8)
+	fmt.Fprintf(w, "  data:        %d words\n", st.dataWords)
+	fmt.Fprintf(w, "  pointers:    %d words (%d null)\n", st.ptrWords, st.nullPtrs)
+	fmt.Fprintf(w, "  tags:        %d words\n", st.tagWords)
+	fmt.Fprintf(w, "  unreachable: %d words\n", st.unreachable)
+	fmt.Fprintf(w, "  structs:     %d\n", st.structs)
+	fmt.Fprintf(w, "  lists:       %d\n", st.lists)
+	fmt.Fprintf(w, "  far:         %d\n", st.farPtrs)
+	fmt.Fprintf(w, "  caps:        %d\n", st.caps)
+	fmt.Fprintf(w, "  depth:       %d\n", st.maxDepth)
+}
+
+// maxWalkDepth bounds the nesting of pointers followed by walk, so
+// that cyclic messages terminate.
+const maxWalkDepth = 64
+
+// walk follows the pointers of msg from its root and annotates the
+// words it reaches.  Malformed pointers are annotated rather than
+// reported as errors, since finding them is the point of a dump.
+func walk(msg *capnp.Message) (*layout, error) {
+	n := msg.NumSegments()
+	lay := &layout{
+		segs:  make([][]byte, n),
+		notes: make([]map[int]string, n),
+	}
+	for i := range lay.segs {
+		seg, err := msg.Segment(capnp.SegmentID(i))
+		if err != nil {
+			return nil, err
+		}
+		lay.segs[i] = seg.Data()
+		lay.notes[i] = make(map[int]string)
+		lay.stats.words += len(lay.segs[i]) / 8
+	}
+	lay.stats.segments = int(n)
+	if n > 0 && len(lay.segs[0]) >= 8 {
+		lay.pointer(0, 0, "root", 1)
+	}
+	for i := range lay.segs {
+		for j := 0; j < len(lay.segs[i])/8; j++ {
+			if _, ok := lay.notes[i][j]; !ok {
+				lay.stats.unreachable++
+			}
+		}
+	}
+	return lay, nil
+}
+
+func (lay *layout) word(seg, idx int) (uint64, bool) {
+	if seg < 0 || seg >= len(lay.segs) || idx < 0 || idx >= len(lay.segs[seg])/8 {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint64(lay.segs[seg][idx*8:]), true
+}
+
+// note annotates a word.  It reports false if the word was already
+// annotated, which means the walk has looped or objects overlap.
+func (lay *layout) note(seg, idx int, s string) bool {
+	if prev, ok := lay.notes[seg][idx]; ok {
+		lay.notes[seg][idx] = prev + "; " + s
+		return false
+	}
+	lay.notes[seg][idx] = s
+	return true
+}
+
+// inBounds reports whether the n words starting at idx are inside seg.
+func (lay *layout) inBounds(seg, idx, n int) bool {
+	return seg >= 0 && seg < len(lay.segs) && idx >= 0 && n >= 0 && idx+n <= len(lay.segs[seg])/8
+}
+
+// pointer annotates the pointer at word idx of seg and walks its target.
+func (lay *layout) pointer(seg, idx int, name string, depth int) {
+	w, _ := lay.word(seg, idx)
+	lay.stats.ptrWords++
+	if depth > lay.stats.maxDepth {
+		lay.stats.maxDepth = depth
+	}
+	if w == 0 {
+		lay.stats.nullPtrs++
+		lay.note(seg, idx, name+": null")
+		return
+	}
+	if !lay.note(seg, idx, name+": "+describePointer(w)) {
+		return
+	}
+	if depth > maxWalkDepth {
+		lay.notes[seg][idx] += " (too deep, not followed)"
+		return
+	}
+	tseg, tidx := seg, idx+1+int(int32(w)>>2)
+	switch w & 3 {
+	case 2: // far
+		lay.stats.farPtrs++
+		tseg, tidx = int(w>>32), int(uint32(w)>>3)
+		double := w&4 != 0
+		pad := 1
+		if double {
+			pad = 2
+		}
+		if !lay.inBounds(tseg, tidx, pad) {
+			lay.notes[seg][idx] += " (landing pad out of bounds)"
+			return
+		}
+		if !double {
+			lay.pointer(tseg, tidx, name+" landing pad", depth)
+			return
+		}
+		lay.stats.tagWords += pad
+		pw, _ := lay.word(tseg, tidx)
+		tw, _ := lay.word(tseg, tidx+1)
+		lay.note(tseg, tidx, name+" double-far pad: "+describePointer(pw))
+		lay.note(tseg, tidx+1, name+" double-far tag: "+describePointer(tw))
+		if pw&7 != 2 {
+			lay.notes[tseg][tidx] += " (not a single far pointer)"
+			return
+		}
+		if !lay.target(int(pw>>32), int(uint32(pw)>>3), tw, name, depth) {
+			lay.notes[tseg][tidx] += " (target out of bounds)"
+		}
+		return
+	case 3: // other
+		if uint32(w) == 3 {
+			lay.stats.caps++
+		}
+		return
+	}
+	if !lay.target(tseg, tidx, w, name, depth) {
+		lay.notes[seg][idx] += " (target out of bounds)"
+	}
+}
+
+// target annotates and walks the object that the struct or list
+// pointer w points to at word idx of seg.  It reports false if the
+// object is not inside the segment.
+func (lay *layout) target(seg, idx int, w uint64, name string, depth int) bool {
+	if w&3 == 0 {
+		dw, pc := int(uint16(w>>32)), int(uint16(w>>48))
+		return lay.structBody(seg, idx, dw, pc, name, depth)
+	}
+	lay.stats.lists++
+	size, n := int(w>>32)&7, int(w>>35)
+	switch size {
+	case 7:
+		// Composite: n is the word count after the tag.
+		if !lay.inBounds(seg, idx, n+1) {
+			return false
+		}
+		tw, _ := lay.word(seg, idx)
+		lay.stats.tagWords++
+		count := int(uint32(tw) >> 2)
+		dw, pc := int(uint16(tw>>32)), int(uint16(tw>>48))
+		lay.note(seg, idx, fmt.Sprintf("%s: tag: %d elements, %d data words, %d pointers", name, count, dw, pc))
+		if tw&3 != 0 || count*(dw+pc) > n {
+			lay.notes[seg][idx] += " (bad tag)"
+			return true
+		}
+		for i := 0; i < count; i++ {
+			lay.structBody(seg, idx+1+i*(dw+pc), dw, pc, fmt.Sprintf("%s[%d]", name, i), depth)
+		}
+	case 6:
+		if !lay.inBounds(seg, idx, n) {
+			return false
+		}
+		for i := 0; i < n; i++ {
+			lay.pointer(seg, idx+i, fmt.Sprintf("%s[%d]", name, i), depth+1)
+		}
+	default:
+		bits := [...]int{0, 1, 8, 16, 32, 64}[size]
+		words := (n*bits + 63) / 64
+		if !lay.inBounds(seg, idx, words) {
+			return false
+		}
+		for i := 0; i < words; i++ {
+			lay.note(seg, idx+i, name+": list data")
+		}
+		lay.stats.dataWords += words
+	}
+	return true
+}
+
+func (lay *layout) structBody(seg, idx, dw, pc int, name string, depth int) bool {
+	if !lay.inBounds(seg, idx, dw+pc) {
+		return false
+	}
+	lay.stats.structs++
+	for i := 0; i < dw; i++ {
+		lay.note(seg, idx+i, fmt.Sprintf("%s: data word %d", name, i))
+	}
+	lay.stats.dataWords += dw
+	for i := 0; i < pc; i++ {
+		lay.pointer(seg, idx+dw+i, fmt.Sprintf("%s.ptr[%d]", name, i), depth+1)
+	}
+	return true
+}
+
+// describePointer returns a description of the raw pointer word w.
+func describePointer(w uint64) string {
+	off := int32(w) >> 2
+	switch w & 3 {
+	case 0:
+		return fmt.Sprintf("struct, offset %d, %d data words, %d pointers", off, uint16(w>>32), uint16(w>>48))
+	case 1:
+		sizes := [...]string{"void", "bit", "byte", "2-byte", "4-byte", "8-byte", "pointer", "composite"}
+		size := sizes[(w>>32)&7]
+		if size == "composite" {
+			return fmt.Sprintf("list, offset %d, composite, %d words", off, w>>35)
+		}
+		return fmt.Sprintf("list, offset %d, %d %s elements", off, w>>35, size)
+	case 2:
+		kind := "far"
+		if w&4 != 0 {
+			kind = "double-far"
+		}
+		return fmt.Sprintf("%s, segment %d, word %d", kind, w>>32, uint32(w)>>3)
+	default:
+		if uint32(w) == 3 {
+			return fmt.Sprintf("capability %d", w>>32)
+		}
+		return fmt.Sprintf("unknown other pointer %#016x", w)
+	}
+}
+
+// dump writes a hex dump of every segment with the notes for each word.
+func (lay *layout) dump(w io.Writer) {
+	for i, data := range lay.segs {
+		fmt.Fprintf(w, "segment %d (%d words)\n", i, len(data)/8)
+		for j := 0; j < len(data)/8; j++ {
+			word := data[j*8 : j*8+8]
+			hex := make([]string, 8)
+			for k, b := range word {
+				hex[k] = fmt.Sprintf("%02x", b)
+			}
+			note, ok := lay.notes[i][j]
+			if !ok {
+				note = "unreachable"
+			}
+			fmt.Fprintf(w, "  %04x: %s %s  %s\n", j, strings.Join(hex[:4], " "), strings.Join(hex[4:], " "), note)
+		}
+	}
+}
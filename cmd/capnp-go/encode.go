@@ -0,0 +1,586 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/capnpjson"
+	"github.com/iguazio/go-capnproto2/internal/nodemap"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+	"github.com/iguazio/go-capnproto2/internal/valueconv"
+)
+
+func runEncode(args []string, r io.Reader, w io.Writer) error {
+	cf := newFlags("encode", true)
+	pos, err := cf.parse(args, 1)
+	if err != nil {
+		return err
+	}
+	ss, err := loadSchemas(cf.schema)
+	if err != nil {
+		return err
+	}
+	typeID, err := ss.structID(pos[0])
+	if err != nil {
+		return err
+	}
+	var next func() (*capnp.Message, error)
+	if cf.json {
+		dec := capnpjson.NewDecoder(r)
+		dec.UseRegistry(ss.reg)
+		next = func() (*capnp.Message, error) {
+			return dec.Decode(typeID)
+		}
+	} else {
+		tp, err := newTextParser(r)
+		if err != nil {
+			return err
+		}
+		b := new(builder)
+		b.nodes.UseRegistry(ss.reg)
+		next = func() (*capnp.Message, error) {
+			v, err := tp.next()
+			if err != nil {
+				return nil, err
+			}
+			return b.build(typeID, v)
+		}
+	}
+	bw := bufio.NewWriter(w)
+	enc := capnp.NewEncoder(bw)
+	if cf.packed {
+		enc = capnp.NewPackedEncoder(bw)
+	}
+	for i := 0; ; i++ {
+		msg, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("message %d: %v", i, err)
+		}
+		if err := enc.Encode(msg); err != nil {
+			return fmt.Errorf("message %d: %v", i, err)
+		}
+	}
+	return bw.Flush()
+}
+
+// A builder creates messages from values parsed from text format.
+type builder struct {
+	nodes nodemap.Map
+}
+
+// build returns a new message with a root struct of type typeID that
+// has the fields in v.
+func (b *builder) build(typeID uint64, v map[string]interface{}) (*capnp.Message, error) {
+	msg, seg, err := capnp.NewMessage(capnp.MultiSegment(nil))
+	if err != nil {
+		return nil, err
+	}
+	n, err := b.structNode(typeID)
+	if err != nil {
+		return nil, err
+	}
+	s, err := capnp.NewRootStruct(seg, valueconv.StructSize(n))
+	if err != nil {
+		return nil, err
+	}
+	if err := b.setStruct(s, n, v); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (b *builder) structNode(typeID uint64) (schema.Node, error) {
+	n, err := b.nodes.Find(typeID)
+	if err != nil {
+		return schema.Node{}, err
+	}
+	if !n.IsValid() || n.Which() != schema.Node_Which_structNode {
+		return schema.Node{}, fmt.Errorf("cannot find struct type %#x", typeID)
+	}
+	return n, nil
+}
+
+// setStruct sets the fields of s, a struct or group of type n, from v.
+func (b *builder) setStruct(s capnp.Struct, n schema.Node, v map[string]interface{}) error {
+	fields, err := n.StructNode().Fields()
+	if err != nil {
+		return err
+	}
+	byName := make(map[string]schema.Field, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		name, err := fields.At(i).Name()
+		if err != nil {
+			return err
+		}
+		byName[name] = fields.At(i)
+	}
+	// Set fields in a fixed order so that errors are reproducible.
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	member := ""
+	for _, name := range names {
+		f, ok := byName[name]
+		if !ok {
+			dn, _ := n.DisplayName()
+			return fmt.Errorf("unknown field %s in %s", name, dn)
+		}
+		if dv := f.DiscriminantValue(); dv != schema.Field_noDiscriminant {
+			if member != "" {
+				return fmt.Errorf("fields %s and %s are members of the same union", member, name)
+			}
+			member = name
+			off := capnp.DataOffset(n.StructNode().DiscriminantOffset() * 2)
+			s.SetUint16(off, dv)
+		}
+		switch f.Which() {
+		case schema.Field_Which_slot:
+			err = b.setField(s, f, v[name])
+		case schema.Field_Which_group:
+			err = b.setGroup(s, f, v[name])
+		default:
+			err = fmt.Errorf("unknown kind %v", f.Which())
+		}
+		if err != nil {
+			return fmt.Errorf("field %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+func (b *builder) setGroup(s capnp.Struct, f schema.Field, v interface{}) error {
+	gn, err := b.structNode(f.Group().TypeId())
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		return nil
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("expected group, got %s", valueconv.Describe(v))
+	}
+	return b.setStruct(s, gn, m)
+}
+
+func (b *builder) setField(s capnp.Struct, f schema.Field, v interface{}) error {
+	typ, err := f.Slot().Type()
+	if err != nil {
+		return err
+	}
+	dv, err := f.Slot().DefaultValue()
+	if err != nil {
+		return err
+	}
+	off := f.Slot().Offset()
+	switch typ.Which() {
+	case schema.Type_Which_void:
+		if v != nil {
+			return fmt.Errorf("expected void, got %s", valueconv.Describe(v))
+		}
+	case schema.Type_Which_bool:
+		x, err := valueconv.Bool(v)
+		if err != nil {
+			return err
+		}
+		s.SetBit(capnp.BitOffset(off), x != dv.Bool())
+	case schema.Type_Which_int8:
+		x, err := valueconv.Int(v, 8)
+		if err != nil {
+			return err
+		}
+		s.SetUint8(capnp.DataOffset(off), uint8(x)^uint8(dv.Int8()))
+	case schema.Type_Which_int16:
+		x, err := valueconv.Int(v, 16)
+		if err != nil {
+			return err
+		}
+		s.SetUint16(capnp.DataOffset(off*2), uint16(x)^uint16(dv.Int16()))
+	case schema.Type_Which_int32:
+		x, err := valueconv.Int(v, 32)
+		if err != nil {
+			return err
+		}
+		s.SetUint32(capnp.DataOffset(off*4), uint32(x)^uint32(dv.Int32()))
+	case schema.Type_Which_int64:
+		x, err := valueconv.Int(v, 64)
+		if err != nil {
+			return err
+		}
+		s.SetUint64(capnp.DataOffset(off*8), uint64(x)^uint64(dv.Int64()))
+	case schema.Type_Which_uint8:
+		x, err := valueconv.Uint(v, 8)
+		if err != nil {
+			return err
+		}
+		s.SetUint8(capnp.DataOffset(off), uint8(x)^dv.Uint8())
+	case schema.Type_Which_uint16:
+		x, err := valueconv.Uint(v, 16)
+		if err != nil {
+			return err
+		}
+		s.SetUint16(capnp.DataOffset(off*2), uint16(x)^dv.Uint16())
+	case schema.Type_Which_uint32:
+		x, err := valueconv.Uint(v, 32)
+		if err != nil {
+			return err
+		}
+		s.SetUint32(capnp.DataOffset(off*4), uint32(x)^dv.Uint32())
+	case schema.Type_Which_uint64:
+		x, err := valueconv.Uint(v, 64)
+		if err != nil {
+			return err
+		}
+		s.SetUint64(capnp.DataOffset(off*8), x^dv.Uint64())
+	case schema.Type_Which_float32:
+		x, err := valueconv.Float(v, 32)
+		if err != nil {
+			return err
+		}
+		s.SetUint32(capnp.DataOffset(off*4), math.Float32bits(float32(x))^math.Float32bits(dv.Float32()))
+	case schema.Type_Which_float64:
+		x, err := valueconv.Float(v, 64)
+		if err != nil {
+			return err
+		}
+		s.SetUint64(capnp.DataOffset(off*8), math.Float64bits(x)^math.Float64bits(dv.Float64()))
+	case schema.Type_Which_enum:
+		x, err := b.toEnum(typ.Enum().TypeId(), v)
+		if err != nil {
+			return err
+		}
+		s.SetUint16(capnp.DataOffset(off*2), x^dv.Uint16())
+	case schema.Type_Which_text:
+		if v == nil {
+			return nil
+		}
+		x, err := valueconv.String(v, "text")
+		if err != nil {
+			return err
+		}
+		return s.SetNewText(uint16(off), x)
+	case schema.Type_Which_data:
+		if v == nil {
+			return nil
+		}
+		x, err := toData(v)
+		if err != nil {
+			return err
+		}
+		return s.SetData(uint16(off), x)
+	case schema.Type_Which_structType, schema.Type_Which_list:
+		if v == nil {
+			return nil
+		}
+		p, err := b.newPtr(s.Segment(), typ, v)
+		if err != nil {
+			return err
+		}
+		return s.SetPtr(uint16(off), p)
+	case schema.Type_Which_interface, schema.Type_Which_anyPointer:
+		if v != nil {
+			return fmt.Errorf("can't encode a %v", typ.Which())
+		}
+	default:
+		return fmt.Errorf("unknown type %v", typ.Which())
+	}
+	return nil
+}
+
+// newPtr returns a new struct or list of type typ built from v.
+func (b *builder) newPtr(seg *capnp.Segment, typ schema.Type, v interface{}) (capnp.Ptr, error) {
+	switch typ.Which() {
+	case schema.Type_Which_structType:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return capnp.Ptr{}, fmt.Errorf("expected struct, got %s", valueconv.Describe(v))
+		}
+		n, err := b.structNode(typ.StructType().TypeId())
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		s, err := capnp.NewStruct(seg, valueconv.StructSize(n))
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		if err := b.setStruct(s, n, m); err != nil {
+			return capnp.Ptr{}, err
+		}
+		return s.ToPtr(), nil
+	case schema.Type_Which_list:
+		elems, ok := v.([]interface{})
+		if !ok {
+			return capnp.Ptr{}, fmt.Errorf("expected list, got %s", valueconv.Describe(v))
+		}
+		elem, err := typ.List().ElementType()
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		l, err := b.newList(seg, elem, elems)
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		return l.ToPtr(), nil
+	default:
+		return capnp.Ptr{}, fmt.Errorf("%v is not a pointer type", typ.Which())
+	}
+}
+
+func (b *builder) newList(seg *capnp.Segment, elem schema.Type, v []interface{}) (capnp.List, error) {
+	n := int32(len(v))
+	switch elem.Which() {
+	case schema.Type_Which_void:
+		return capnp.NewVoidList(seg, n).List, nil
+	case schema.Type_Which_bool:
+		l, err := capnp.NewBitList(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i := range v {
+			x, err := valueconv.Bool(v[i])
+			if err != nil {
+				return capnp.List{}, valueconv.ElemError(i, err)
+			}
+			l.Set(i, x)
+		}
+		return l.List, nil
+	case schema.Type_Which_int8, schema.Type_Which_int16, schema.Type_Which_int32, schema.Type_Which_int64:
+		return newIntList(seg, elem.Which(), v)
+	case schema.Type_Which_uint8, schema.Type_Which_uint16, schema.Type_Which_uint32, schema.Type_Which_uint64:
+		return newUintList(seg, elem.Which(), v)
+	case schema.Type_Which_float32:
+		l, err := capnp.NewFloat32List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i := range v {
+			x, err := valueconv.Float(v[i], 32)
+			if err != nil {
+				return capnp.List{}, valueconv.ElemError(i, err)
+			}
+			l.Set(i, float32(x))
+		}
+		return l.List, nil
+	case schema.Type_Which_float64:
+		l, err := capnp.NewFloat64List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i := range v {
+			x, err := valueconv.Float(v[i], 64)
+			if err != nil {
+				return capnp.List{}, valueconv.ElemError(i, err)
+			}
+			l.Set(i, x)
+		}
+		return l.List, nil
+	case schema.Type_Which_enum:
+		l, err := capnp.NewUInt16List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i := range v {
+			x, err := b.toEnum(elem.Enum().TypeId(), v[i])
+			if err != nil {
+				return capnp.List{}, valueconv.ElemError(i, err)
+			}
+			l.Set(i, x)
+		}
+		return l.List, nil
+	case schema.Type_Which_text:
+		l, err := capnp.NewTextList(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i := range v {
+			x, err := valueconv.String(v[i], "text")
+			if err != nil {
+				return capnp.List{}, valueconv.ElemError(i, err)
+			}
+			if err := l.Set(i, x); err != nil {
+				return capnp.List{}, valueconv.ElemError(i, err)
+			}
+		}
+		return l.List, nil
+	case schema.Type_Which_data:
+		l, err := capnp.NewDataList(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i := range v {
+			x, err := toData(v[i])
+			if err != nil {
+				return capnp.List{}, valueconv.ElemError(i, err)
+			}
+			if err := l.Set(i, x); err != nil {
+				return capnp.List{}, valueconv.ElemError(i, err)
+			}
+		}
+		return l.List, nil
+	case schema.Type_Which_structType:
+		sn, err := b.structNode(elem.StructType().TypeId())
+		if err != nil {
+			return capnp.List{}, err
+		}
+		l, err := capnp.NewCompositeList(seg, valueconv.StructSize(sn), n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i := range v {
+			m, ok := v[i].(map[string]interface{})
+			if !ok {
+				return capnp.List{}, valueconv.ElemError(i, fmt.Errorf("expected struct, got %s", valueconv.Describe(v[i])))
+			}
+			if err := b.setStruct(l.Struct(i), sn, m); err != nil {
+				return capnp.List{}, valueconv.ElemError(i, err)
+			}
+		}
+		return l, nil
+	case schema.Type_Which_list:
+		l, err := capnp.NewPointerList(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i := range v {
+			if v[i] == nil {
+				continue
+			}
+			p, err := b.newPtr(seg, elem, v[i])
+			if err != nil {
+				return capnp.List{}, valueconv.ElemError(i, err)
+			}
+			if err := l.SetPtr(i, p); err != nil {
+				return capnp.List{}, valueconv.ElemError(i, err)
+			}
+		}
+		return l.List, nil
+	default:
+		return capnp.List{}, fmt.Errorf("can't encode a list of %v", elem.Which())
+	}
+}
+
+func newIntList(seg *capnp.Segment, which schema.Type_Which, v []interface{}) (capnp.List, error) {
+	n := int32(len(v))
+	var (
+		l    capnp.List
+		set  func(i int, x int64)
+		bits int
+		err  error
+	)
+	switch which {
+	case schema.Type_Which_int8:
+		var il capnp.Int8List
+		il, err = capnp.NewInt8List(seg, n)
+		l, bits, set = il.List, 8, func(i int, x int64) { il.Set(i, int8(x)) }
+	case schema.Type_Which_int16:
+		var il capnp.Int16List
+		il, err = capnp.NewInt16List(seg, n)
+		l, bits, set = il.List, 16, func(i int, x int64) { il.Set(i, int16(x)) }
+	case schema.Type_Which_int32:
+		var il capnp.Int32List
+		il, err = capnp.NewInt32List(seg, n)
+		l, bits, set = il.List, 32, func(i int, x int64) { il.Set(i, int32(x)) }
+	default:
+		var il capnp.Int64List
+		il, err = capnp.NewInt64List(seg, n)
+		l, bits, set = il.List, 64, func(i int, x int64) { il.Set(i, x) }
+	}
+	if err != nil {
+		return capnp.List{}, err
+	}
+	for i := range v {
+		x, err := valueconv.Int(v[i], bits)
+		if err != nil {
+			return capnp.List{}, valueconv.ElemError(i, err)
+		}
+		set(i, x)
+	}
+	return l, nil
+}
+
+func newUintList(seg *capnp.Segment, which schema.Type_Which, v []interface{}) (capnp.List, error) {
+	n := int32(len(v))
+	var (
+		l    capnp.List
+		set  func(i int, x uint64)
+		bits int
+		err  error
+	)
+	switch which {
+	case schema.Type_Which_uint8:
+		var ul capnp.UInt8List
+		ul, err = capnp.NewUInt8List(seg, n)
+		l, bits, set = ul.List, 8, func(i int, x uint64) { ul.Set(i, uint8(x)) }
+	case schema.Type_Which_uint16:
+		var ul capnp.UInt16List
+		ul, err = capnp.NewUInt16List(seg, n)
+		l, bits, set = ul.List, 16, func(i int, x uint64) { ul.Set(i, uint16(x)) }
+	case schema.Type_Which_uint32:
+		var ul capnp.UInt32List
+		ul, err = capnp.NewUInt32List(seg, n)
+		l, bits, set = ul.List, 32, func(i int, x uint64) { ul.Set(i, uint32(x)) }
+	default:
+		var ul capnp.UInt64List
+		ul, err = capnp.NewUInt64List(seg, n)
+		l, bits, set = ul.List, 64, func(i int, x uint64) { ul.Set(i, x) }
+	}
+	if err != nil {
+		return capnp.List{}, err
+	}
+	for i := range v {
+		x, err := valueconv.Uint(v[i], bits)
+		if err != nil {
+			return capnp.List{}, valueconv.ElemError(i, err)
+		}
+		set(i, x)
+	}
+	return l, nil
+}
+
+func (b *builder) toEnum(typeID uint64, v interface{}) (uint16, error) {
+	var name string
+	switch v := v.(type) {
+	case ident:
+		name = string(v)
+	case string:
+		name = v
+	case json.Number:
+		x, err := valueconv.Uint(v, 16)
+		return uint16(x), err
+	default:
+		return 0, fmt.Errorf("expected enum, got %s", valueconv.Describe(v))
+	}
+	n, err := b.nodes.Find(typeID)
+	if err != nil {
+		return 0, err
+	}
+	if !n.IsValid() || n.Which() != schema.Node_Which_enum {
+		return 0, fmt.Errorf("cannot find enum type %#x", typeID)
+	}
+	enums, err := n.Enum().Enumerants()
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i < enums.Len(); i++ {
+		if en, _ := enums.At(i).Name(); en == name {
+			return uint16(i), nil
+		}
+	}
+	dn, _ := n.DisplayName()
+	return 0, fmt.Errorf("%s has no enumerant %s", dn, name)
+}
+
+func toData(v interface{}) ([]byte, error) {
+	s, err := valueconv.String(v, "data")
+	return []byte(s), err
+}
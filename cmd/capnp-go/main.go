@@ -0,0 +1,99 @@
+// capnp-go is a tool for inspecting and producing Cap'n Proto messages
+// without the C++ capnp tool.
+//
+// Usage:
+//
+//	capnp-go decode [-packed] [-json] [-schema FILE] TYPE < messages
+//	capnp-go encode [-packed] [-json] [-schema FILE] TYPE < text
+//	capnp-go dump [-packed] < messages
+//	capnp-go stats [-packed] < messages
+//
+// decode reads a stream of messages from standard input and writes the
+// root struct of each one as a line of Cap'n Proto text format, or as
+// JSON with -json.  encode does the reverse: it reads a sequence of
+// structs in text format (or JSON values with -json) and writes one
+// message per struct.
+//
+// TYPE is the struct type of the message roots.  With -schema, FILE is
+// a CodeGeneratorRequest as written by "capnp compile -o-" (usually
+// named something.capnp.out) and TYPE may be a name like
+// "foo.capnp:Bar", "Bar", or "Bar.Baz".  Otherwise, TYPE must be the
+// hex or decimal ID of a type whose schema is compiled into capnp-go.
+//
+// dump writes a hex dump of the segments of each message, annotating
+// the pointers reachable from the root.  stats writes a summary of the
+// size and shape of each message.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "capnp-go:", err)
+		if _, ok := err.(usageError); ok {
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+}
+
+var commands = map[string]func(args []string, r io.Reader, w io.Writer) error{
+	"decode": runDecode,
+	"encode": runEncode,
+	"dump":   runDump,
+	"stats":  runStats,
+}
+
+var errUsage = usageError("usage: capnp-go decode|encode|dump|stats [flags] [TYPE]")
+
+type usageError string
+
+func (e usageError) Error() string { return string(e) }
+
+func run(args []string, r io.Reader, w io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	cmd := commands[args[0]]
+	if cmd == nil {
+		return errUsage
+	}
+	return cmd(args[1:], r, w)
+}
+
+// commandFlags holds the flags shared by the subcommands.
+type commandFlags struct {
+	fs     *flag.FlagSet
+	packed bool
+	json   bool
+	schema string
+}
+
+func newFlags(name string, typed bool) *commandFlags {
+	cf := &commandFlags{fs: flag.NewFlagSet(name, flag.ContinueOnError)}
+	cf.fs.SetOutput(ioutil.Discard)
+	cf.fs.BoolVar(&cf.packed, "packed", false, "use the packed encoding")
+	if typed {
+		cf.fs.BoolVar(&cf.json, "json", false, "use JSON instead of text format")
+		cf.fs.StringVar(&cf.schema, "schema", "", "read schemas from a CodeGeneratorRequest file")
+	}
+	return cf
+}
+
+// parse parses args and returns the positional arguments, checking
+// that there are exactly n of them.
+func (cf *commandFlags) parse(args []string, n int) ([]string, error) {
+	if err := cf.fs.Parse(args); err != nil {
+		return nil, usageError(fmt.Sprintf("%s: %v", cf.fs.Name(), err))
+	}
+	if cf.fs.NArg() != n {
+		return nil, errUsage
+	}
+	return cf.fs.Args(), nil
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+var zTypeID = fmt.Sprintf("%#x", uint64(air.Z_TypeID))
+
+func runCommand(args []string, in []byte) ([]byte, error) {
+	out := new(bytes.Buffer)
+	err := run(args, bytes.NewReader(in), out)
+	return out.Bytes(), err
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags []string
+		in    string
+	}{
+		{"text", nil, `(planebase = (name = "foo\n\x01", homes = [jfk, lax], rating = -5, canFly = true, capacity = 100, maxSpeed = 1.5))`},
+		{"text union", nil, `(zvec = [(i8 = -8), (text = "hi"), (f64 = inf), (blob = "\x00\xff")])`},
+		{"text list of lists", nil, `(zvecvec = [[(u64 = 18446744073709551615)], []])`},
+		{"text group", nil, `(grp = (first = 1, second = 2))`},
+		{"text empty", nil, `()`},
+		{"text multiple", nil, "(i64 = 1)\n(i64 = 2)"},
+		{"json", []string{"-json"}, `{"planebase":{"name":"foo","homes":["jfk"],"rating":3,"canFly":false,"capacity":0,"maxSpeed":"NaN"}}`},
+		{"json data", []string{"-json"}, `{"datavec":["AAE=","/w=="]}`},
+		{"json packed", []string{"-json", "-packed"}, `{"textvec":["a","b"]}`},
+	}
+	for _, test := range tests {
+		encArgs := append(append([]string{"encode"}, test.flags...), zTypeID)
+		msgs, err := runCommand(encArgs, []byte(test.in))
+		if err != nil {
+			t.Errorf("%s: encode: %v", test.name, err)
+			continue
+		}
+		decArgs := append(append([]string{"decode"}, test.flags...), zTypeID)
+		out, err := runCommand(decArgs, msgs)
+		if err != nil {
+			t.Errorf("%s: decode: %v", test.name, err)
+			continue
+		}
+		// Encoding the decoded output must give the same messages.
+		msgs2, err := runCommand(encArgs, out)
+		if err != nil {
+			t.Errorf("%s: encode decoded output %q: %v", test.name, out, err)
+			continue
+		}
+		if !bytes.Equal(msgs, msgs2) {
+			t.Errorf("%s: decoded output %q encodes differently from input", test.name, out)
+		}
+		if n := bytes.Count(out, []byte("\n")); n != strings.Count(test.in, "\n")+1 {
+			t.Errorf("%s: decoded %d messages; want %d", test.name, n, strings.Count(test.in, "\n")+1)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	msg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatalf("NewRootZ: %v", err)
+	}
+	pb, err := z.NewPlanebase()
+	if err != nil {
+		t.Fatalf("NewPlanebase: %v", err)
+	}
+	pb.SetName("Boeing")
+	pb.SetCanFly(true)
+	data, err := msg.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	tests := []struct {
+		flags []string
+		want  string
+	}{
+		{nil, `(planebase = (name = "Boeing", homes = [], rating = 0, canFly = true, capacity = 0, maxSpeed = 0))` + "\n"},
+		{[]string{"-json"}, `{"planebase":{"name":"Boeing","homes":[],"rating":0,"canFly":true,"capacity":0,"maxSpeed":0}}` + "\n"},
+	}
+	for _, test := range tests {
+		args := append(append([]string{"decode"}, test.flags...), zTypeID)
+		out, err := runCommand(args, data)
+		if err != nil {
+			t.Errorf("decode %v: %v", test.flags, err)
+			continue
+		}
+		if string(out) != test.want {
+			t.Errorf("decode %v = %q; want %q", test.flags, out, test.want)
+		}
+	}
+}
+
+func TestEncode_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		in   string
+	}{
+		{"unknown field", []string{"encode", zTypeID}, `(bogus = 1)`},
+		{"two union members", []string{"encode", zTypeID}, `(i8 = 1, i16 = 2)`},
+		{"out of range", []string{"encode", zTypeID}, `(i8 = 300)`},
+		{"wrong type", []string{"encode", zTypeID}, `(text = 5)`},
+		{"unknown enum", []string{"encode", zTypeID}, `(airport = nowhere)`},
+		{"syntax", []string{"encode", zTypeID}, `(i8 = `},
+		{"bad base64", []string{"encode", "-json", zTypeID}, `{"blob":"!!"}`},
+		{"unknown type", []string{"encode", "0x1234"}, `()`},
+		{"name without schema", []string{"encode", "Z"}, `()`},
+		{"no type", []string{"encode"}, `()`},
+		{"unknown command", []string{"frob"}, ``},
+	}
+	for _, test := range tests {
+		if _, err := runCommand(test.args, []byte(test.in)); err == nil {
+			t.Errorf("%s: run(%q) did not return an error", test.name, test.args)
+		}
+	}
+}
+
+func TestSchemaFile(t *testing.T) {
+	const path = "../../capnpc-go/testdata/aircraft.capnp.out"
+	for _, name := range []string{"Z", "aircraft.capnp:Z", zTypeID} {
+		msgs, err := runCommand([]string{"encode", "-schema", path, name}, []byte(`(text = "hi")`))
+		if err != nil {
+			t.Errorf("encode -schema %s: %v", name, err)
+			continue
+		}
+		out, err := runCommand([]string{"decode", "-schema", path, name}, msgs)
+		if err != nil {
+			t.Errorf("decode -schema %s: %v", name, err)
+			continue
+		}
+		if want := "(text = \"hi\")\n"; string(out) != want {
+			t.Errorf("decode -schema %s = %q; want %q", name, out, want)
+		}
+	}
+	if _, err := runCommand([]string{"encode", "-schema", path, "Bogus"}, nil); err == nil {
+		t.Error("encode -schema with unknown type name did not return an error")
+	}
+}
+
+func TestDumpAndStats(t *testing.T) {
+	msgs, err := runCommand([]string{"encode", zTypeID}, []byte(`(zvec = [(text = "a"), (i8 = 1)])`))
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	msg, err := capnp.Unmarshal(msgs)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	lay, err := walk(msg)
+	if err != nil {
+		t.Fatalf("walk: %v", err)
+	}
+	st := lay.stats
+	if st.structs != 3 || st.lists != 2 || st.unreachable != 0 || st.caps != 0 {
+		t.Errorf("stats = %+v; want 3 structs, 2 lists, nothing unreachable", st)
+	}
+	if got := st.dataWords + st.ptrWords + st.tagWords; got != st.words {
+		t.Errorf("data + pointer + tag words = %d; want %d (all words)", got, st.words)
+	}
+
+	out, err := runCommand([]string{"dump"}, msgs)
+	if err != nil {
+		t.Fatalf("dump: %v", err)
+	}
+	for _, want := range []string{"segment 0", "root: struct", "root.ptr[0]: list", "tag: 2 elements", "unreachable"} {
+		if want == "unreachable" {
+			if bytes.Contains(out, []byte(want)) {
+				t.Errorf("dump output contains unreachable words:\n%s", out)
+			}
+			continue
+		}
+		if !bytes.Contains(out, []byte(want)) {
+			t.Errorf("dump output does not contain %q:\n%s", want, out)
+		}
+	}
+	out, err = runCommand([]string{"stats"}, msgs)
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if !bytes.Contains(out, []byte("structs:     3")) {
+		t.Errorf("stats output does not count 3 structs:\n%s", out)
+	}
+}
+
+func TestDump_Malformed(t *testing.T) {
+	// A root struct pointer whose target is past the end of the segment.
+	data := []byte{
+		0, 0, 0, 0, 2, 0, 0, 0, // header: 1 segment, 2 words
+		0x10, 0, 0, 0, 1, 0, 0, 0, // struct, offset 4, 1 data word
+		0, 0, 0, 0, 0, 0, 0, 0,
+	}
+	out, err := runCommand([]string{"dump"}, data)
+	if err != nil {
+		t.Fatalf("dump: %v", err)
+	}
+	if !bytes.Contains(out, []byte("out of bounds")) {
+		t.Errorf("dump of out-of-bounds pointer does not report it:\n%s", out)
+	}
+}
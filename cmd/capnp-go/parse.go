@@ -0,0 +1,270 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/iguazio/go-capnproto2/internal/valueconv"
+)
+
+// Input values parsed from text format are represented with the same
+// types that encoding/json uses with UseNumber: nil, bool, json.Number, string, []interface{}, and
+// map[string]interface{}.  Bare identifiers in text format (like enum
+// names) are represented as ident.
+type ident = valueconv.Ident
+
+// A textParser parses a sequence of structs in Cap'n Proto text format.
+type textParser struct {
+	s    []byte
+	pos  int
+	line int
+}
+
+func newTextParser(r io.Reader) (*textParser, error) {
+	s, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return &textParser{s: s, line: 1}, nil
+}
+
+// next returns the next struct in the input or io.EOF.
+func (p *textParser) next() (map[string]interface{}, error) {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return nil, io.EOF
+	}
+	if p.s[p.pos] != '(' {
+		return nil, p.errorf("expected '(' at start of struct")
+	}
+	v, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	return v.(map[string]interface{}), nil
+}
+
+func (p *textParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+func (p *textParser) skipSpace() {
+	for p.pos < len(p.s) {
+		switch c := p.s[p.pos]; {
+		case c == '\n':
+			p.line++
+			p.pos++
+		case c == ' ' || c == '\t' || c == '\r':
+			p.pos++
+		case c == '#':
+			for p.pos < len(p.s) && p.s[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// consume skips space and then reports whether the next byte is c,
+// consuming it if so.
+func (p *textParser) consume(c byte) bool {
+	p.skipSpace()
+	if p.pos < len(p.s) && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *textParser) value() (interface{}, error) {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return nil, p.errorf("unexpected end of input")
+	}
+	switch c := p.s[p.pos]; {
+	case c == '(':
+		p.pos++
+		return p.structBody()
+	case c == '[':
+		p.pos++
+		return p.listBody()
+	case c == '"':
+		return p.str()
+	case c == '-' || c == '+' || isDigit(c):
+		return p.number()
+	case isIdentStart(c):
+		id := p.ident()
+		switch id {
+		case "void":
+			return nil, nil
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return id, nil
+	default:
+		return nil, p.errorf("unexpected %q", c)
+	}
+}
+
+func (p *textParser) structBody() (interface{}, error) {
+	m := make(map[string]interface{})
+	if p.consume(')') {
+		return m, nil
+	}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.s) || !isIdentStart(p.s[p.pos]) {
+			return nil, p.errorf("expected field name")
+		}
+		name := string(p.ident())
+		if !p.consume('=') {
+			return nil, p.errorf("expected '=' after %s", name)
+		}
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := m[name]; dup {
+			return nil, p.errorf("field %s given twice", name)
+		}
+		m[name] = v
+		if p.consume(')') {
+			return m, nil
+		}
+		if !p.consume(',') {
+			return nil, p.errorf("expected ',' or ')' after field %s", name)
+		}
+	}
+}
+
+func (p *textParser) listBody() (interface{}, error) {
+	l := []interface{}{}
+	if p.consume(']') {
+		return l, nil
+	}
+	for {
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		l = append(l, v)
+		if p.consume(']') {
+			return l, nil
+		}
+		if !p.consume(',') {
+			return nil, p.errorf("expected ',' or ']' in list")
+		}
+	}
+}
+
+func (p *textParser) ident() ident {
+	start := p.pos
+	for p.pos < len(p.s) && (isIdentStart(p.s[p.pos]) || isDigit(p.s[p.pos])) {
+		p.pos++
+	}
+	return ident(p.s[start:p.pos])
+}
+
+func (p *textParser) number() (interface{}, error) {
+	start := p.pos
+	if c := p.s[p.pos]; c == '-' || c == '+' {
+		p.pos++
+		if p.pos < len(p.s) && isIdentStart(p.s[p.pos]) {
+			// -inf, or +Inf as written by encoding/text.
+			id := p.ident()
+			if id != "inf" && id != "Inf" {
+				return nil, p.errorf("unexpected %s%s", p.s[start:start+1], id)
+			}
+			return ident(p.s[start:p.pos]), nil
+		}
+	}
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if !(isDigit(c) || isIdentStart(c) || c == '.' || (c == '-' || c == '+') && (p.s[p.pos-1] == 'e' || p.s[p.pos-1] == 'E')) {
+			break
+		}
+		p.pos++
+	}
+	return json.Number(p.s[start:p.pos]), nil
+}
+
+// str parses a string literal, undoing the escapes that
+// internal/strquote produces.
+func (p *textParser) str() (string, error) {
+	p.pos++ // opening quote
+	var buf []byte
+	for {
+		if p.pos >= len(p.s) || p.s[p.pos] == '\n' {
+			return "", p.errorf("unterminated string")
+		}
+		c := p.s[p.pos]
+		p.pos++
+		switch c {
+		case '"':
+			return string(buf), nil
+		case '\\':
+			if p.pos >= len(p.s) {
+				return "", p.errorf("unterminated string")
+			}
+			e := p.s[p.pos]
+			p.pos++
+			switch e {
+			case 'a':
+				buf = append(buf, '\a')
+			case 'b':
+				buf = append(buf, '\b')
+			case 'f':
+				buf = append(buf, '\f')
+			case 'n':
+				buf = append(buf, '\n')
+			case 'r':
+				buf = append(buf, '\r')
+			case 't':
+				buf = append(buf, '\t')
+			case 'v':
+				buf = append(buf, '\v')
+			case '\'', '"', '\\', '?':
+				buf = append(buf, e)
+			case 'x':
+				if p.pos+2 > len(p.s) {
+					return "", p.errorf("truncated \\x escape")
+				}
+				b, err := strconv.ParseUint(string(p.s[p.pos:p.pos+2]), 16, 8)
+				if err != nil {
+					return "", p.errorf("bad \\x escape")
+				}
+				buf = append(buf, byte(b))
+				p.pos += 2
+			default:
+				if e < '0' || e > '7' {
+					return "", p.errorf("unknown escape \\%c", e)
+				}
+				v := uint(e - '0')
+				for n := 1; n < 3 && p.pos < len(p.s) && p.s[p.pos] >= '0' && p.s[p.pos] <= '7'; n++ {
+					v = v*8 + uint(p.s[p.pos]-'0')
+					p.pos++
+				}
+				if v > 0xff {
+					return "", p.errorf("octal escape out of range")
+				}
+				buf = append(buf, byte(v))
+			}
+		default:
+			buf = append(buf, c)
+		}
+	}
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_'
+}
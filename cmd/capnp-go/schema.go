@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/nodemap"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+	"github.com/iguazio/go-capnproto2/schemas"
+)
+
+// A schemaSet is the set of schemas that a command can use, along
+// with an index of the type names that are known.
+type schemaSet struct {
+	reg   *schemas.Registry
+	nodes nodemap.Map
+	names map[string]uint64 // display name to ID
+}
+
+// loadSchemas returns the schemas in the CodeGeneratorRequest file at
+// path, or the default registry if path is empty.
+func loadSchemas(path string) (*schemaSet, error) {
+	if path == "" {
+		return &schemaSet{reg: &schemas.DefaultRegistry}, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := capnp.Unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("read schema %s: %v", path, err)
+	}
+	req, err := schema.ReadRootCodeGeneratorRequest(msg)
+	if err != nil {
+		return nil, fmt.Errorf("read schema %s: %v", path, err)
+	}
+	nodes, err := req.Nodes()
+	if err != nil {
+		return nil, fmt.Errorf("read schema %s: %v", path, err)
+	}
+	ss := &schemaSet{
+		reg:   new(schemas.Registry),
+		names: make(map[string]uint64, nodes.Len()),
+	}
+	ids := make([]uint64, nodes.Len())
+	for i := range ids {
+		n := nodes.At(i)
+		ids[i] = n.Id()
+		name, err := n.DisplayName()
+		if err != nil {
+			return nil, fmt.Errorf("read schema %s: %v", path, err)
+		}
+		ss.names[name] = n.Id()
+	}
+	if err := ss.reg.Register(&schemas.Schema{Bytes: data, Nodes: ids}); err != nil {
+		return nil, fmt.Errorf("read schema %s: %v", path, err)
+	}
+	ss.nodes.UseRegistry(ss.reg)
+	return ss, nil
+}
+
+// structID resolves a type name or ID given on the command line to the
+// ID of a struct type.
+func (ss *schemaSet) structID(name string) (uint64, error) {
+	id, err := ss.lookup(name)
+	if err != nil {
+		return 0, err
+	}
+	n, err := ss.nodes.Find(id)
+	if err != nil {
+		return 0, fmt.Errorf("type %s: %v", name, err)
+	}
+	if !n.IsValid() || n.Which() != schema.Node_Which_structNode {
+		return 0, fmt.Errorf("type %s is not a struct", name)
+	}
+	return id, nil
+}
+
+func (ss *schemaSet) lookup(name string) (uint64, error) {
+	if id, err := strconv.ParseUint(name, 0, 64); err == nil {
+		return id, nil
+	}
+	if id, ok := ss.names[name]; ok {
+		return id, nil
+	}
+	if ss.names == nil {
+		return 0, fmt.Errorf("type %s: names can only be used with -schema; give a type ID instead", name)
+	}
+	var found []string
+	for dn := range ss.names {
+		if strings.HasSuffix(dn, ":"+name) {
+			found = append(found, dn)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return 0, fmt.Errorf("type %s not found in schema", name)
+	case 1:
+		return ss.names[found[0]], nil
+	default:
+		return 0, fmt.Errorf("type %s is ambiguous: could be any of %s", name, strings.Join(found, ", "))
+	}
+}
@@ -0,0 +1,161 @@
+package capnp
+
+import "io"
+
+// DecodeOptions holds the security limits applied to messages that are
+// read by Unmarshal, a Decoder, or an RPC transport.  A zero field
+// means the corresponding field of DefaultDecodeOptions.
+type DecodeOptions struct {
+	// TraverseLimit is the TraverseLimit of each message read.
+	TraverseLimit uint64
+
+	// DepthLimit is the DepthLimit of each message read.
+	DepthLimit uint
+
+	// MaxMessageSize is the largest message, including its stream
+	// header, that a Decoder will read.  It has no effect on Unmarshal,
+	// since the data is already in memory.
+	MaxMessageSize uint64
+
+	// RequireAligned makes Unmarshal and UnmarshalAt reject data that
+	// does not start on an 8-byte boundary.  Messages read from
+	// unaligned buffers, such as slices of a network read buffer, are
+	// otherwise accepted: accessors do not assume alignment.  Set it
+	// when segment data is handed to code that does.  It is also
+	// enabled if set in DefaultDecodeOptions.
+	RequireAligned bool
+
+	// TransformFrame, if not nil, is called with each frame that is read:
+	// the stream header and segments of one message, as delimited by the
+	// header.  It returns the unpacked serialized message to parse in the
+	// frame's place.  This lets a transport that compresses or encrypts
+	// the segments of each message, leaving the header to frame it,
+	// reuse the Decoder and the segment table parser.  The result is
+	// subject to MaxMessageSize.  TransformFrame may return frame itself
+	// after modifying it in place.  It has no effect if set in
+	// DefaultDecodeOptions.
+	TransformFrame func(frame []byte) ([]byte, error)
+}
+
+// DefaultDecodeOptions holds the limits used wherever a limit is not
+// set, including the TraverseLimit and DepthLimit of a Message and the
+// MaxMessageSize of a Decoder.  A program may tighten them before it
+// starts reading messages; they must not be changed afterward.  A zero
+// field means the built-in default, which matches the C++
+// implementation.
+var DefaultDecodeOptions = DecodeOptions{
+	TraverseLimit:  defaultTraverseLimit,
+	DepthLimit:     defaultDepthLimit,
+	MaxMessageSize: defaultDecodeLimit,
+}
+
+// WithDecodeOptions returns an option that applies o to a Decoder.  A
+// Decoder's MaxMessageSize field, if set, takes precedence over
+// o.MaxMessageSize.
+func WithDecodeOptions(o DecodeOptions) CodecOption {
+	return func(co *codecOptions) {
+		co.decode = o
+	}
+}
+
+// Unmarshal is like the package-level Unmarshal, but the returned
+// message uses the limits in o.
+func (o DecodeOptions) Unmarshal(data []byte) (*Message, error) {
+	msg, _, err := o.UnmarshalAt(data, 0)
+	return msg, err
+}
+
+// UnmarshalAt is like the package-level UnmarshalAt, but the returned
+// message uses the limits in o.
+func (o DecodeOptions) UnmarshalAt(data []byte, off int) (*Message, int, error) {
+	if o.RequireAligned && off >= 0 && off <= len(data) && !isAligned(data[off:]) {
+		return nil, 0, errUnaligned
+	}
+	msg, n, err := UnmarshalAt(data, off)
+	if err != nil {
+		return nil, n, err
+	}
+	if o.TransformFrame != nil {
+		msg, err = o.transform(data[off:off+n], 0)
+		if err != nil {
+			return nil, n, err
+		}
+	}
+	o.apply(msg)
+	return msg, n, nil
+}
+
+// transform parses the message that o.TransformFrame returns for
+// frame.  If maxSize is not zero, the result must be at most maxSize
+// bytes.
+func (o DecodeOptions) transform(frame []byte, maxSize uint64) (*Message, error) {
+	data, err := o.TransformFrame(frame)
+	if err != nil {
+		return nil, err
+	}
+	if maxSize > 0 && uint64(len(data)) > maxSize {
+		return nil, errDecodeLimit
+	}
+	msg, _, err := UnmarshalAt(data, 0)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// NewDecoder is like the package-level NewDecoder with
+// WithDecodeOptions(o) appended to opts.
+func (o DecodeOptions) NewDecoder(r io.Reader, opts ...CodecOption) *Decoder {
+	return NewDecoder(r, append(opts, WithDecodeOptions(o))...)
+}
+
+// newCodecOptions returns the options given by opts.
+func newCodecOptions(opts []CodecOption) codecOptions {
+	var o codecOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// apply sets the limits of a message that has not been read yet.
+func (o DecodeOptions) apply(m *Message) {
+	m.TraverseLimit = o.TraverseLimit
+	m.DepthLimit = o.DepthLimit
+}
+
+func traverseLimitOrDefault(n uint64) uint64 {
+	switch {
+	case n != 0:
+		return n
+	case DefaultDecodeOptions.TraverseLimit != 0:
+		return DefaultDecodeOptions.TraverseLimit
+	default:
+		return defaultTraverseLimit
+	}
+}
+
+func depthLimitOrDefault(n uint) uint {
+	switch {
+	case n != 0:
+		return n
+	case DefaultDecodeOptions.DepthLimit != 0:
+		return DefaultDecodeOptions.DepthLimit
+	default:
+		return defaultDepthLimit
+	}
+}
+
+func maxMessageSizeOrDefault(n uint64) uint64 {
+	switch {
+	case n != 0:
+		return n
+	case DefaultDecodeOptions.MaxMessageSize != 0:
+		return DefaultDecodeOptions.MaxMessageSize
+	default:
+		return defaultDecodeLimit
+	}
+}
@@ -0,0 +1,192 @@
+package capnp
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDecodeOptions(t *testing.T) {
+	msg, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := NewRootStruct(seg, ObjectSize{DataSize: 8, PointerCount: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := root.SetText(0, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := msg.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o := DecodeOptions{TraverseLimit: 8, DepthLimit: 3}
+	m, err := o.Unmarshal(data)
+	if err != nil {
+		t.Fatal("Unmarshal:", err)
+	}
+	if m.TraverseLimit != 8 || m.depthLimit() != 3 {
+		t.Errorf("TraverseLimit, depth limit = %d, %d; want 8, 3", m.TraverseLimit, m.depthLimit())
+	}
+	if _, err := m.RootPtr(); err == nil {
+		t.Error("RootPtr succeeded past the traversal limit")
+	}
+
+	dec := o.NewDecoder(bytes.NewReader(data))
+	m, err = dec.Decode()
+	if err != nil {
+		t.Fatal("Decode:", err)
+	}
+	if m.TraverseLimit != 8 || m.depthLimit() != 3 {
+		t.Errorf("decoded TraverseLimit, depth limit = %d, %d; want 8, 3", m.TraverseLimit, m.depthLimit())
+	}
+
+	dec = NewDecoder(bytes.NewReader(data), WithDecodeOptions(DecodeOptions{MaxMessageSize: 16}))
+	if _, err := dec.Decode(); err != errDecodeLimit {
+		t.Errorf("Decode with small MaxMessageSize = %v; want %v", err, errDecodeLimit)
+	}
+}
+
+func TestDefaultDecodeOptions(t *testing.T) {
+	defer func(o DecodeOptions) { DefaultDecodeOptions = o }(DefaultDecodeOptions)
+	DefaultDecodeOptions = DecodeOptions{TraverseLimit: 16, DepthLimit: 2, MaxMessageSize: 8}
+
+	msg := &Message{Arena: SingleSegment(nil)}
+	if lim := msg.ReadLimiter().limit; lim != 16 {
+		t.Errorf("ReadLimiter limit = %d; want 16", lim)
+	}
+	if msg.depthLimit() != 2 {
+		t.Errorf("depthLimit() = %d; want 2", msg.depthLimit())
+	}
+	msg = &Message{Arena: SingleSegment(nil), TraverseLimit: 32, DepthLimit: 5}
+	if lim := msg.ReadLimiter().limit; lim != 32 || msg.depthLimit() != 5 {
+		t.Errorf("limits = %d, %d; want the message's own 32, 5", lim, msg.depthLimit())
+	}
+	dec := NewDecoder(bytes.NewReader([]byte{0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}))
+	if _, err := dec.Decode(); err != errDecodeLimit {
+		t.Errorf("Decode = %v; want %v", err, errDecodeLimit)
+	}
+
+	DefaultDecodeOptions = DecodeOptions{}
+	msg = &Message{Arena: SingleSegment(nil)}
+	if lim := msg.ReadLimiter().limit; lim != defaultTraverseLimit {
+		t.Errorf("ReadLimiter limit with zero defaults = %d; want %d", lim, defaultTraverseLimit)
+	}
+}
+
+func TestDecodeOptions_RequireAligned(t *testing.T) {
+	msg, seg, err := NewMessage(MultiSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := NewRootStruct(seg, ObjectSize{DataSize: 16, PointerCount: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	root.SetUint16(2, 0xbeef)
+	root.SetUint64(8, 0x0123456789abcdef)
+	if err := root.SetText(0, "unaligned"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := msg.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Copy the message to an odd offset, as happens when it is sliced
+	// out of a larger network buffer.
+	buf := make([]byte, len(data)+1)
+	unaligned := buf[1:]
+	copy(unaligned, data)
+
+	m, err := Unmarshal(unaligned)
+	if err != nil {
+		t.Fatal("Unmarshal:", err)
+	}
+	p, err := m.RootPtr()
+	if err != nil {
+		t.Fatal("RootPtr:", err)
+	}
+	s := p.Struct()
+	if got := s.Uint16(2); got != 0xbeef {
+		t.Errorf("Uint16(2) = %#x; want 0xbeef", got)
+	}
+	if got := s.Uint64(8); got != 0x0123456789abcdef {
+		t.Errorf("Uint64(8) = %#x; want 0x0123456789abcdef", got)
+	}
+	if tp, _ := s.Ptr(0); tp.Text() != "unaligned" {
+		t.Errorf("Ptr(0).Text() = %q; want \"unaligned\"", tp.Text())
+	}
+
+	o := DecodeOptions{RequireAligned: true}
+	if _, err := o.Unmarshal(unaligned); err != errUnaligned {
+		t.Errorf("Unmarshal with RequireAligned = %v; want %v", err, errUnaligned)
+	}
+	if _, err := o.Unmarshal(data); err != nil {
+		t.Errorf("Unmarshal aligned data with RequireAligned: %v", err)
+	}
+}
+
+func TestDecodeOptions_TransformFrame(t *testing.T) {
+	msg, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := NewRootStruct(seg, ObjectSize{DataSize: 8, PointerCount: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := root.SetText(0, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := msg.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Scramble the segments, as a length-preserving cipher would, but
+	// leave the 8-byte header that frames them.
+	scramble := func(frame []byte) ([]byte, error) {
+		for i := 8; i < len(frame); i++ {
+			frame[i] ^= 0x5a
+		}
+		return frame, nil
+	}
+	scrambled, _ := scramble(append([]byte(nil), data...))
+	opts := DecodeOptions{TransformFrame: scramble}
+
+	check := func(name string, msg *Message) {
+		p, err := msg.RootPtr()
+		if err != nil {
+			t.Errorf("%s: RootPtr: %v", name, err)
+			return
+		}
+		if s, _ := p.Struct().Ptr(0); s.Text() != "hello" {
+			t.Errorf("%s: text = %q; want \"hello\"", name, s.Text())
+		}
+	}
+	dec := opts.NewDecoder(bytes.NewReader(append(append([]byte(nil), scrambled...), scrambled...)))
+	for i := 0; i < 2; i++ {
+		msg, err := dec.Decode()
+		if err != nil {
+			t.Fatalf("Decode #%d: %v", i, err)
+		}
+		check("Decode", msg)
+	}
+	msg, err = opts.Unmarshal(append([]byte(nil), scrambled...))
+	if err != nil {
+		t.Fatal("Unmarshal:", err)
+	}
+	check("Unmarshal", msg)
+
+	// A transform that expands its input is subject to MaxMessageSize.
+	opts = DecodeOptions{
+		MaxMessageSize: uint64(len(data)),
+		TransformFrame: func(frame []byte) ([]byte, error) {
+			return append(frame, make([]byte, 8)...), nil
+		},
+	}
+	if _, err := opts.NewDecoder(bytes.NewReader(data)).Decode(); err != errDecodeLimit {
+		t.Errorf("Decode with expanding transform: %v; want %v", err, errDecodeLimit)
+	}
+}
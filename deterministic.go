@@ -0,0 +1,154 @@
+package capnp
+
+// deterministicCopy returns a copy of m with the objects reachable from
+// its root laid out in a single segment in pre-order: each object is
+// followed by the objects its pointers refer to, in pointer order.  The
+// copy has no unreachable bytes, no far pointers, and zeroed padding,
+// so its bytes depend only on the content of m.  Capability pointers
+// keep their indexes into m.CapTable.  Reading m does not count against
+// its read limit.
+func (m *Message) deterministicCopy() (*Message, error) {
+	if m.NumSegments() == 0 {
+		return nil, errMessageEmpty
+	}
+	src, err := m.Segment(0)
+	if err != nil {
+		return nil, err
+	}
+	if !src.regionInBounds(0, wordSize) {
+		return nil, errNoRoot
+	}
+	var hint int
+	for i := int64(0); i < m.NumSegments(); i++ {
+		s, err := m.Segment(SegmentID(i))
+		if err != nil {
+			return nil, err
+		}
+		hint += len(s.data)
+	}
+	dm, dst, err := NewMessage(SingleSegment(make([]byte, 0, hint)))
+	if err != nil {
+		return nil, err
+	}
+	if err := copyPreorder(dst, 0, src, 0, maxDepth); err != nil {
+		return nil, err
+	}
+	return dm, nil
+}
+
+// copyPreorder copies the object referred to by the pointer at srcAddr
+// in src, and then the objects it refers to, and writes a pointer to
+// the copy at dstAddr in dst.
+func copyPreorder(dst *Segment, dstAddr Address, src *Segment, srcAddr Address, depth uint) error {
+	s, base, val, err := src.resolveFarPointer(srcAddr)
+	if err != nil || val == 0 {
+		return err
+	}
+	if depth == 0 {
+		return errDepthLimit
+	}
+	switch val.pointerType() {
+	case structPointer:
+		sp, err := s.readStructPtr(base, val)
+		if err != nil {
+			return err
+		}
+		ds, err := NewStruct(dst, sp.size)
+		if err != nil {
+			return err
+		}
+		if err := dst.writePtr(dstAddr, ds.ToPtr(), false); err != nil {
+			return err
+		}
+		return copyStructPreorder(ds, sp, depth)
+	case listPointer:
+		lp, err := s.readListPtr(base, val)
+		if err != nil {
+			return err
+		}
+		return copyListPreorder(dst, dstAddr, lp, depth)
+	case otherPointer:
+		if val.otherPointerType() != 0 {
+			return errOtherPointer
+		}
+		// Capability pointers have no offset.
+		dst.writeRawPointer(dstAddr, val)
+		return nil
+	default:
+		return errBadLandingPad
+	}
+}
+
+func copyStructPreorder(dst, src Struct, depth uint) error {
+	copy(dst.seg.slice(dst.off, dst.size.DataSize), src.seg.slice(src.off, src.size.DataSize))
+	for i := uint16(0); i < src.size.PointerCount; i++ {
+		err := copyPreorder(dst.seg, dst.pointerAddress(i), src.seg, src.pointerAddress(i), depth-1)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyListPreorder(dst *Segment, dstAddr Address, l List, depth uint) error {
+	if l.flags&isCompositeList != 0 {
+		dl, err := NewCompositeList(dst, l.size, l.length)
+		if err != nil {
+			return err
+		}
+		if err := dst.writePtr(dstAddr, dl.ToPtr(), false); err != nil {
+			return err
+		}
+		for i := 0; i < l.Len(); i++ {
+			d, s := dl.Struct(i), l.Struct(i)
+			copy(d.seg.slice(d.off, d.size.DataSize), s.seg.slice(s.off, s.size.DataSize))
+		}
+		for i := 0; i < l.Len(); i++ {
+			d, s := dl.Struct(i), l.Struct(i)
+			for j := uint16(0); j < s.size.PointerCount; j++ {
+				err := copyPreorder(d.seg, d.pointerAddress(j), s.seg, s.pointerAddress(j), depth-1)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if l.size.PointerCount != 0 {
+		dl, err := NewPointerList(dst, l.length)
+		if err != nil {
+			return err
+		}
+		if err := dst.writePtr(dstAddr, dl.ToPtr(), false); err != nil {
+			return err
+		}
+		for i := int32(0); i < l.length; i++ {
+			daddr, _ := dl.off.element(i, wordSize)
+			saddr, _ := l.off.element(i, wordSize)
+			if err := copyPreorder(dl.seg, daddr, l.seg, saddr, depth-1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	sz := l.allocSize()
+	seg, addr, err := alloc(dst, sz)
+	if err != nil {
+		return err
+	}
+	dl := List{
+		seg:        seg,
+		off:        addr,
+		length:     l.length,
+		size:       l.size,
+		flags:      l.flags,
+		depthLimit: maxDepth,
+	}
+	copy(seg.slice(addr, sz), l.seg.slice(l.off, sz))
+	if n := l.length % 8; l.flags&isBitList != 0 && n != 0 {
+		// Clear the bits past the end of the list.
+		last := addr + Address(sz) - 1
+		seg.writeUint8(last, seg.readUint8(last)&(1<<uint(n)-1))
+	}
+	return dst.writePtr(dstAddr, dl.ToPtr(), false)
+}
@@ -0,0 +1,146 @@
+package capnp
+
+import (
+	"bytes"
+	"testing"
+)
+
+// buildDeterministicTest builds a message with a text field, a bit
+// list, a list of structs with text, and a capability.  If scrambled is
+// true, it uses several segments, allocates the objects in a different
+// order, leaves garbage in unused bytes, and replaces the text.
+func buildDeterministicTest(t *testing.T, scrambled bool) *Message {
+	arena := SingleSegment(nil)
+	if scrambled {
+		arena = MultiSegment([][]byte{make([]byte, 0, 16)})
+	}
+	msg, seg, err := NewMessage(arena)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sz := ObjectSize{DataSize: 8, PointerCount: 4}
+	var root Struct
+	if !scrambled {
+		root, err = NewRootStruct(seg, sz)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	bits, err := NewBitList(seg, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bits.Set(0, true)
+	bits.Set(2, true)
+	if scrambled {
+		bits.seg.writeUint8(bits.off, 0xf5)
+	}
+	elems, err := NewCompositeList(seg, ObjectSize{DataSize: 8, PointerCount: 1}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < elems.Len(); i++ {
+		e := elems.Struct(i)
+		e.SetUint64(0, uint64(i))
+		if err := e.SetText(0, "elem"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if scrambled {
+		root, err = NewStruct(seg, sz)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := msg.SetRootPtr(root.ToPtr()); err != nil {
+			t.Fatal(err)
+		}
+		if err := root.SetText(0, "stale"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	root.SetUint64(0, 42)
+	if err := root.SetText(0, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err := root.SetPtr(1, bits.ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+	if err := root.SetPtr(2, elems.ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+	if err := root.SetPtr(3, NewInterface(seg, 7).ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func TestDeterministic(t *testing.T) {
+	a := buildDeterministicTest(t, false)
+	b := buildDeterministicTest(t, true)
+	if b.NumSegments() < 2 {
+		t.Fatalf("scrambled message has %d segments; want several", b.NumSegments())
+	}
+	abuf, err := a.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bbuf, err := b.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(abuf, bbuf) {
+		t.Fatal("messages are identical without Deterministic; test is not useful")
+	}
+
+	a.Deterministic = true
+	b.Deterministic = true
+	abuf, err = a.Marshal()
+	if err != nil {
+		t.Fatal("Marshal:", err)
+	}
+	bbuf, err = b.Marshal()
+	if err != nil {
+		t.Fatal("Marshal:", err)
+	}
+	if !bytes.Equal(abuf, bbuf) {
+		t.Errorf("deterministic Marshal differs:\n% x\n% x", abuf, bbuf)
+	}
+
+	var ebuf bytes.Buffer
+	if err := NewEncoder(&ebuf).Encode(b); err != nil {
+		t.Fatal("Encode:", err)
+	}
+	if !bytes.Equal(ebuf.Bytes(), bbuf) {
+		t.Errorf("deterministic Encode = % x; want % x", ebuf.Bytes(), bbuf)
+	}
+
+	msg, err := Unmarshal(bbuf)
+	if err != nil {
+		t.Fatal("Unmarshal:", err)
+	}
+	if msg.NumSegments() != 1 {
+		t.Errorf("NumSegments() = %d; want 1", msg.NumSegments())
+	}
+	p, err := msg.RootPtr()
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := p.Struct()
+	if txt, _ := root.Ptr(0); txt.Text() != "hello" {
+		t.Errorf("root text = %q; want \"hello\"", txt.Text())
+	}
+	bp, _ := root.Ptr(1)
+	if bits := (BitList{bp.List()}); bits.At(1) || !bits.At(2) {
+		t.Error("bit list was not copied")
+	}
+	if ip, _ := root.Ptr(3); ip.Interface().Capability() != 7 {
+		t.Errorf("capability = %d; want 7", ip.Interface().Capability())
+	}
+	st, err := MessageStats(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.DeadBytes != 0 || st.OverheadBytes != 8 {
+		t.Errorf("MessageStats = %+v; want no dead bytes and only a list tag of overhead", *st)
+	}
+}
@@ -60,6 +60,10 @@ a single segment.  All of the pointer types have a notion of "valid".
 An invalid pointer will return the default value from any accessor and
 panic when any setter is called.
 
+An AnyPointer field's type is only known at runtime.  Wrapping its Ptr
+in an Any gives access to its Kind and to conversions such as AsStruct
+and AsText that report a null or mistyped pointer as an error.
+
 In previous versions of this package, the Pointer interface was used
 instead of the Ptr struct.  This interface and functions that use it are
 now deprecated.  See https://github.com/capnproto/go-capnproto2/wiki/New-Ptr-Type
@@ -71,11 +75,19 @@ There are a few reasons that a read or write of a pointer can fail, but
 the most common are bad pointers or allocation failures.  For accessors,
 an invalid object will be returned in case of an error.
 
+Programmer errors, such as setting a field of an invalid struct, an
+out-of-bounds list index, or reading an inactive union member, cause a
+panic.  Building with the capnp_nopanic build tag makes these methods
+return a zero value or do nothing instead, and records the first such
+error so that it can be checked with Message.Err.
+
 Since Go doesn't have generics, wrapper types provide type safety on
 lists.  This package provides lists of basic types, and capnpc-go
 generates list wrappers for named types.  However, if you need to use
-deeper nesting of lists (e.g. List(List(UInt8))), you will need to use a
-PointerList and wrap the elements.
+deeper nesting of lists (e.g. List(List(UInt8))) or a List(AnyPointer),
+you will need to use a PointerList.  Its StructAt, ListAt, TextAt, and
+DataAt methods read elements as a given type, and SetPtr sets an element
+to any object.
 
 Structs
 
@@ -112,6 +124,13 @@ capnpc-go will generate:
 	// Foo struct.
 	func ReadRootFoo(msg *capnp.Message) (Foo, error)
 
+	// Equal reports whether s and other hold the same values, by
+	// comparing their canonical forms.  Hash64 returns a hash of the
+	// canonical form of s, so Equal structs have the same hash.  Pass
+	// -equalmethods=false to capnpc-go to leave these out.
+	func (s Foo) Equal(other Foo) (bool, error)
+	func (s Foo) Hash64() (uint64, error)
+
 	// Num returns the value of the num field.
 	func (s Foo) Num() uint32
 
@@ -143,6 +162,11 @@ capnpc-go will generate:
 	// list.  The list's size cannot be changed after creation.
 	func NewFoo_List(s *capnp.Segment, sz int32) Foo_List
 
+	// NewRootFooListMessage creates a new message whose root is a
+	// List(Foo) of n elements.  The message's single segment is sized
+	// to hold exactly the list.
+	func NewRootFooListMessage(n int32) (*capnp.Message, Foo_List, error)
+
 	// Len returns the number of elements in the list.
 	func (s Foo_List) Len() int
 
@@ -161,6 +185,13 @@ capnpc-go will generate:
 	// Bar returns a promise for that bar field.
 	func (p Foo_Promise) Bar() Foo_Promise
 
+If a field's accessors would have the same name as one of the generated
+methods above, such as a field called string and the String method,
+capnpc-go appends an underscore to the field's name: String_ and
+SetString_.  The -conflictsuffix flag picks a different suffix, like
+Value for StringValue, and -conflictaliases keeps the underscore names
+as well so that existing callers still compile.
+
 
 Groups
 
@@ -188,6 +219,13 @@ That way the following may be used to access a field in a group:
 
 Note that group accessors just convert the type and so have no overhead.
 
+With the -flattengroups flag, capnpc-go also generates accessors for a
+group's fields on the struct that contains it, named by joining the
+field names:
+
+	func (s Foo) GroupField() bool
+	func (s Foo) SetGroupField(v bool)
+
 Unions
 
 Named unions are treated as a group with an inner unnamed union. Unnamed
@@ -314,6 +352,20 @@ In the generated go file:
 		}
 	}
 
+Constants
+
+Constants of a numeric, Bool, Text, or enum type are generated as Go
+constants.  Other constants are generated as package variables; struct,
+list, and AnyPointer values are unmarshaled from the file's embedded data
+when the package is initialized.  With the -lazyconsts flag, these are
+instead generated as functions that unmarshal the value on first use:
+
+	const origin :Point = (x = 0, y = 0);
+
+In the generated go file:
+
+	func Origin() Point
+
 Interfaces
 
 capnpc-go generates type-safe Client wrappers for interfaces. For parameter
@@ -354,10 +406,14 @@ capnpc-go also generates code to implement the interface:
 	}
 
 	// Calculator_ServerToClient is equivalent to calling:
-	// NewCalculator(capnp.NewServer(Calculator_Methods(nil, s), s))
+	// NewCalculator(server.NewTable(Calculator_Table(s), s))
 	// If s does not implement the Close method, then nil is used.
 	func Calculator_ServerToClient(s Calculator_Server) Calculator
 
+	// Calculator_Table returns the methods of s grouped by interface and
+	// indexed by method ID, so that calls are dispatched without a search.
+	func Calculator_Table(s Calculator_Server) server.Table
+
 	// Calculator_Methods appends methods from Calculator that call to server and
 	// returns the methods.  If methods is nil or the capacity of the underlying
 	// slice is too small, a new slice is returned.
@@ -0,0 +1,78 @@
+package capnp
+
+import "github.com/iguazio/go-capnproto2/internal/packed"
+
+// WithCoalesce returns an option that makes an Encoder collect encoded
+// messages in a buffer and write them to the underlying writer
+// together once at least n bytes are buffered.  This turns a burst of
+// small messages into a few large writes.  Messages that remain in the
+// buffer are written by Flush, which must be called before the writer
+// is closed.  The option has no effect on a Decoder.
+func WithCoalesce(n int) CodecOption {
+	return func(o *codecOptions) {
+		o.coalesce = n
+	}
+}
+
+// SetBufferPool makes e take the buffers that it uses to pack and
+// coalesce messages from bp, and return them to bp as soon as it is
+// done with them, instead of keeping its own buffers for its lifetime.
+// Sharing a pool between many encoders reduces the memory held by
+// idle ones.  SetBufferPool must be called before the first Encode.
+func (e *Encoder) SetBufferPool(bp *BufferPool) {
+	e.pool = bp
+}
+
+// Flush writes any messages buffered because of WithCoalesce.  If the
+// underlying writer has a Flush method, like *bufio.Writer, Flush
+// calls it afterward.
+func (e *Encoder) Flush() error {
+	if err := e.flushBuf(); err != nil {
+		return err
+	}
+	if f, ok := e.w.(interface {
+		Flush() error
+	}); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// buffer appends the frame in bufs to e's write buffer and writes the
+// buffer once it reaches the size given to WithCoalesce.
+func (e *Encoder) buffer(bufs [][]byte) error {
+	if e.wbuf == nil && e.pool != nil {
+		e.wbuf = e.pool.getBuf()
+		e.wbufPool = acquirePoolTicket("encoder buffer")
+	}
+	start := len(e.wbuf)
+	for _, b := range bufs {
+		if e.packed {
+			e.wbuf = packed.Pack(e.wbuf, b)
+		} else {
+			e.wbuf = append(e.wbuf, b...)
+		}
+	}
+	e.npacked = len(e.wbuf) - start
+	if len(e.wbuf) >= e.coalesce {
+		return e.flushBuf()
+	}
+	return nil
+}
+
+// flushBuf writes e's write buffer.  The buffered messages are dropped
+// even if the write fails, since the stream is broken by then.
+func (e *Encoder) flushBuf() error {
+	if len(e.wbuf) == 0 {
+		return nil
+	}
+	_, err := e.w.Write(e.wbuf)
+	if e.pool != nil {
+		e.pool.putBuf(e.wbuf)
+		e.wbuf = nil
+		e.wbufPool.release()
+	} else {
+		e.wbuf = e.wbuf[:0]
+	}
+	return err
+}
@@ -0,0 +1,103 @@
+package capnp
+
+import (
+	"bytes"
+	"testing"
+)
+
+// countingWriter counts writes and flushes to a buffer.
+type countingWriter struct {
+	bytes.Buffer
+	writes  int
+	flushes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(p)
+}
+
+func (w *countingWriter) Flush() error {
+	w.flushes++
+	return nil
+}
+
+func newEncodeTestMessage(t *testing.T, v uint64) *Message {
+	msg, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewRootStruct(seg, ObjectSize{DataSize: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetUint64(0, v)
+	return msg
+}
+
+func TestEncoder_Coalesce(t *testing.T) {
+	for _, packed := range []bool{false, true} {
+		w := new(countingWriter)
+		newEnc, newDec := NewEncoder, NewDecoder
+		if packed {
+			newEnc, newDec = NewPackedEncoder, NewPackedDecoder
+		}
+		enc := newEnc(w, WithCoalesce(1<<20))
+		enc.SetBufferPool(NewBufferPool(1 << 20))
+		for i := uint64(0); i < 5; i++ {
+			if err := enc.Encode(newEncodeTestMessage(t, i)); err != nil {
+				t.Fatalf("packed=%t: Encode #%d: %v", packed, i, err)
+			}
+		}
+		if w.writes != 0 {
+			t.Errorf("packed=%t: %d writes before Flush; want 0", packed, w.writes)
+		}
+		if err := enc.Flush(); err != nil {
+			t.Fatalf("packed=%t: Flush: %v", packed, err)
+		}
+		if w.writes != 1 || w.flushes != 1 {
+			t.Errorf("packed=%t: after Flush, writes = %d, flushes = %d; want 1, 1", packed, w.writes, w.flushes)
+		}
+		dec := newDec(&w.Buffer)
+		for i := uint64(0); i < 5; i++ {
+			msg, err := dec.Decode()
+			if err != nil {
+				t.Fatalf("packed=%t: Decode #%d: %v", packed, i, err)
+			}
+			p, err := msg.RootPtr()
+			if err != nil {
+				t.Fatalf("packed=%t: RootPtr #%d: %v", packed, i, err)
+			}
+			if v := p.Struct().Uint64(0); v != i {
+				t.Errorf("packed=%t: message #%d = %d", packed, i, v)
+			}
+		}
+	}
+}
+
+func TestEncoder_CoalesceThreshold(t *testing.T) {
+	w := new(countingWriter)
+	// Each message is 24 bytes: an 8-byte header, the root pointer, and
+	// the struct.
+	enc := NewEncoder(w, WithCoalesce(48))
+	for i := uint64(0); i < 5; i++ {
+		if err := enc.Encode(newEncodeTestMessage(t, i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if w.writes != 2 || w.Len() != 96 {
+		t.Errorf("writes = %d, %d bytes; want 2, 96", w.writes, w.Len())
+	}
+	if err := enc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if w.writes != 3 || w.Len() != 120 {
+		t.Errorf("after Flush, writes = %d, %d bytes; want 3, 120", w.writes, w.Len())
+	}
+	if err := enc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if w.writes != 3 {
+		t.Errorf("empty Flush wrote")
+	}
+}
@@ -0,0 +1,145 @@
+package text
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+)
+
+// A brandScope holds the types bound to generic parameters by the
+// brands of the enclosing struct types.  A nil *brandScope has no
+// bindings.
+type brandScope struct {
+	bindings map[uint64][]binding // keyed by scope ID
+}
+
+// A binding is a type bound to a generic parameter.  Parameters that
+// the type itself refers to are bound in scope.
+type binding struct {
+	typ   schema.Type
+	scope *brandScope
+}
+
+// bind returns the scope for a struct type with brand b referenced
+// from scope outer.
+func bind(b schema.Brand, outer *brandScope) *brandScope {
+	scopes, err := b.Scopes()
+	if err != nil || scopes.Len() == 0 {
+		return nil
+	}
+	sc := &brandScope{bindings: make(map[uint64][]binding, scopes.Len())}
+	for i := 0; i < scopes.Len(); i++ {
+		s := scopes.At(i)
+		switch s.Which() {
+		case schema.Brand_Scope_Which_bind:
+			list, err := s.Bind()
+			if err != nil {
+				continue
+			}
+			bs := make([]binding, list.Len())
+			for j := range bs {
+				if bb := list.At(j); bb.Which() == schema.Brand_Binding_Which_type {
+					t, err := bb.Type()
+					if err == nil {
+						bs[j] = binding{typ: t, scope: outer}
+					}
+				}
+			}
+			sc.bindings[s.ScopeId()] = bs
+		case schema.Brand_Scope_Which_inherit:
+			if outer != nil {
+				sc.bindings[s.ScopeId()] = outer.bindings[s.ScopeId()]
+			}
+		}
+	}
+	return sc
+}
+
+// resolve returns the type bound to typ if typ is a generic parameter
+// bound in sc, along with the scope to interpret the bound type in.
+// Otherwise, it returns typ and sc unchanged.
+func (sc *brandScope) resolve(typ schema.Type) (schema.Type, *brandScope) {
+	for sc != nil && typ.Which() == schema.Type_Which_anyPointer && typ.AnyPointer().Which() == schema.Type_anyPointer_Which_parameter {
+		param := typ.AnyPointer().Parameter()
+		bs := sc.bindings[param.ScopeId()]
+		i := int(param.ParameterIndex())
+		if i >= len(bs) || !bs[i].typ.IsValid() {
+			break
+		}
+		typ, sc = bs[i].typ, bs[i].scope
+	}
+	return typ, sc
+}
+
+// marshalPtr writes the text representation of p, a pointer of type
+// typ.
+func (enc *Encoder) marshalPtr(typ schema.Type, sc *brandScope, p capnp.Ptr) error {
+	typ, sc = sc.resolve(typ)
+	switch typ.Which() {
+	case schema.Type_Which_structType:
+		br, _ := typ.StructType().Brand()
+		return enc.marshalStruct(typ.StructType().TypeId(), bind(br, sc), p.Struct())
+	case schema.Type_Which_data:
+		enc.marshalText(p.Data())
+	case schema.Type_Which_text:
+		enc.marshalText(p.TextBytes())
+	case schema.Type_Which_list:
+		elem, err := typ.List().ElementType()
+		if err != nil {
+			return err
+		}
+		return enc.marshalList(elem, sc, p.List())
+	case schema.Type_Which_interface:
+		enc.marshalCapability(typ.Interface().TypeId(), p)
+	case schema.Type_Which_anyPointer:
+		switch {
+		case !p.IsValid():
+			enc.w.WriteString(nullMarker)
+		case p.Interface().IsValid():
+			enc.marshalCapability(0, p)
+		default:
+			enc.w.WriteString(anyPointerMarker)
+		}
+	default:
+		return fmt.Errorf("unknown pointer type %v", typ.Which())
+	}
+	return nil
+}
+
+// marshalCapability writes a reference to the capability p points to.
+// If the interface with the given ID is registered, then its name is
+// included.
+func (enc *Encoder) marshalCapability(id uint64, p capnp.Ptr) {
+	iface := p.Interface()
+	if !iface.IsValid() {
+		enc.w.WriteString(nullMarker)
+		return
+	}
+	enc.tmp = append(enc.tmp[:0], "<capability #"...)
+	enc.tmp = strconv.AppendUint(enc.tmp, uint64(iface.Capability()), 10)
+	if name := enc.interfaceName(id); name != nil {
+		enc.tmp = append(enc.tmp, ": "...)
+		enc.tmp = append(enc.tmp, name...)
+	}
+	enc.tmp = append(enc.tmp, '>')
+	enc.w.Write(enc.tmp)
+}
+
+// interfaceName returns the unqualified name of the interface with the
+// given ID or nil if it is not registered.
+func (enc *Encoder) interfaceName(id uint64) []byte {
+	if id == 0 {
+		return nil
+	}
+	n, err := enc.nodes.Find(id)
+	if err != nil || n.Which() != schema.Node_Which_interface {
+		return nil
+	}
+	name, err := n.DisplayNameBytes()
+	if err != nil || int(n.DisplayNamePrefixLength()) > len(name) {
+		return nil
+	}
+	return name[n.DisplayNamePrefixLength():]
+}
@@ -0,0 +1,211 @@
+package text
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+	"github.com/iguazio/go-capnproto2/schemas"
+)
+
+// Node IDs of the generics test schema, which is equivalent to:
+//
+//	struct Box(T) { value @0 :T; }
+//	struct Inner { n @0 :UInt32; }
+//	interface Iface {}
+//	struct Outer {
+//	  box @0 :Box(Inner);
+//	  raw @1 :Box;
+//	  boxes @2 :List(Box(Text));
+//	  cap @3 :Iface;
+//	}
+const (
+	boxID   = 0xb0b0b0b0b0b0b0b0
+	innerID = 0xa1a1a1a1a1a1a1a1
+	ifaceID = 0xc2c2c2c2c2c2c2c2
+	outerID = 0xd3d3d3d3d3d3d3d3
+)
+
+func genericsSchema() ([]byte, error) {
+	msg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		return nil, err
+	}
+	req, err := schema.NewRootCodeGeneratorRequest(seg)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := req.NewNodes(4)
+	if err != nil {
+		return nil, err
+	}
+	newNode := func(i int, id uint64, name string) schema.Node {
+		n := nodes.At(i)
+		n.SetId(id)
+		n.SetDisplayName("test.capnp:" + name)
+		n.SetDisplayNamePrefixLength(uint32(len("test.capnp:")))
+		return n
+	}
+	newFields := func(n schema.Node, dataWords, ptrs uint16, names ...string) (schema.Field_List, error) {
+		n.SetStructNode()
+		n.StructNode().SetDataWordCount(dataWords)
+		n.StructNode().SetPointerCount(ptrs)
+		fields, err := n.StructNode().NewFields(int32(len(names)))
+		if err != nil {
+			return schema.Field_List{}, err
+		}
+		for i, name := range names {
+			f := fields.At(i)
+			f.SetName(name)
+			f.SetCodeOrder(uint16(i))
+			f.SetSlot()
+			f.Slot().SetOffset(uint32(i))
+		}
+		return fields, nil
+	}
+	// setBox sets t to Box, binding T to the type set by bindT if
+	// it is not nil.
+	setBox := func(t schema.Type, bindT func(schema.Type)) error {
+		t.SetStructType()
+		t.StructType().SetTypeId(boxID)
+		if bindT == nil {
+			return nil
+		}
+		br, err := t.StructType().NewBrand()
+		if err != nil {
+			return err
+		}
+		scopes, err := br.NewScopes(1)
+		if err != nil {
+			return err
+		}
+		scopes.At(0).SetScopeId(boxID)
+		bs, err := scopes.At(0).NewBind(1)
+		if err != nil {
+			return err
+		}
+		bt, err := bs.At(0).NewType()
+		if err != nil {
+			return err
+		}
+		bindT(bt)
+		return nil
+	}
+
+	box := newNode(0, boxID, "Box")
+	box.SetIsGeneric(true)
+	fields, err := newFields(box, 0, 1, "value")
+	if err != nil {
+		return nil, err
+	}
+	t, _ := fields.At(0).Slot().NewType()
+	t.SetAnyPointer()
+	t.AnyPointer().SetParameter()
+	t.AnyPointer().Parameter().SetScopeId(boxID)
+	t.AnyPointer().Parameter().SetParameterIndex(0)
+
+	inner := newNode(1, innerID, "Inner")
+	fields, err = newFields(inner, 1, 0, "n")
+	if err != nil {
+		return nil, err
+	}
+	t, _ = fields.At(0).Slot().NewType()
+	t.SetUint32()
+
+	newNode(2, ifaceID, "Iface").SetInterface()
+
+	outer := newNode(3, outerID, "Outer")
+	fields, err = newFields(outer, 0, 4, "box", "raw", "boxes", "cap")
+	if err != nil {
+		return nil, err
+	}
+	t, _ = fields.At(0).Slot().NewType()
+	err = setBox(t, func(bt schema.Type) {
+		bt.SetStructType()
+		bt.StructType().SetTypeId(innerID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	t, _ = fields.At(1).Slot().NewType()
+	if err := setBox(t, nil); err != nil {
+		return nil, err
+	}
+	t, _ = fields.At(2).Slot().NewType()
+	t.SetList()
+	et, _ := t.List().NewElementType()
+	if err := setBox(et, func(bt schema.Type) { bt.SetText() }); err != nil {
+		return nil, err
+	}
+	t, _ = fields.At(3).Slot().NewType()
+	t.SetInterface()
+	t.Interface().SetTypeId(ifaceID)
+
+	return msg.Marshal()
+}
+
+func TestEncodeGenerics(t *testing.T) {
+	data, err := genericsSchema()
+	if err != nil {
+		t.Fatal("building schema:", err)
+	}
+	reg := new(schemas.Registry)
+	err = reg.Register(&schemas.Schema{
+		Bytes: data,
+		Nodes: []uint64{boxID, innerID, ifaceID, outerID},
+	})
+	if err != nil {
+		t.Fatal("Adding to registry:", err)
+	}
+
+	msg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	outer, err := capnp.NewRootStruct(seg, capnp.ObjectSize{PointerCount: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	box, _ := capnp.NewStruct(seg, capnp.ObjectSize{PointerCount: 1})
+	inner, _ := capnp.NewStruct(seg, capnp.ObjectSize{DataSize: 8})
+	inner.SetUint32(0, 42)
+	box.SetPtr(0, inner.ToPtr())
+	outer.SetPtr(0, box.ToPtr())
+
+	errClient := capnp.ErrorClient(errors.New("no capability"))
+	raw, _ := capnp.NewStruct(seg, capnp.ObjectSize{PointerCount: 1})
+	raw.SetPtr(0, capnp.NewInterface(seg, msg.AddCap(errClient)).ToPtr())
+	outer.SetPtr(1, raw.ToPtr())
+
+	boxes, _ := capnp.NewCompositeList(seg, capnp.ObjectSize{PointerCount: 1}, 2)
+	hi, _ := capnp.NewText(seg, "hi")
+	boxes.Struct(0).SetPtr(0, hi.ToPtr())
+	outer.SetPtr(2, boxes.ToPtr())
+
+	outer.SetPtr(3, capnp.NewInterface(seg, msg.AddCap(errClient)).ToPtr())
+
+	buf := new(bytes.Buffer)
+	enc := NewEncoder(buf)
+	enc.UseRegistry(reg)
+	if err := enc.Encode(outerID, outer); err != nil {
+		t.Fatal("Encode:", err)
+	}
+	const want = `(box = (value = (n = 42)), raw = (value = <capability #0>), boxes = [(value = "hi"), (value = "")], cap = <capability #1: Iface>)`
+	if got := buf.String(); got != want {
+		t.Errorf("Encode(Outer) = %s; want %s", got, want)
+	}
+
+	// A struct pointer in an unbound parameter can't be interpreted.
+	raw.SetPtr(0, inner.ToPtr())
+	outer.SetPtr(3, capnp.Ptr{})
+	buf.Reset()
+	if err := enc.Encode(outerID, outer); err != nil {
+		t.Fatal("Encode:", err)
+	}
+	const want2 = `(box = (value = (n = 42)), raw = (value = <opaque pointer>), boxes = [(value = "hi"), (value = "")], cap = null)`
+	if got := buf.String(); got != want2 {
+		t.Errorf("Encode(Outer) = %s; want %s", got, want2)
+	}
+}
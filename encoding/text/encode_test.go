@@ -0,0 +1,122 @@
+package text_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/encoding/text"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+// maxWriter records the size of the largest write.
+type maxWriter struct {
+	bytes.Buffer
+	max int
+}
+
+func (w *maxWriter) Write(p []byte) (int, error) {
+	if len(p) > w.max {
+		w.max = len(p)
+	}
+	return w.Buffer.Write(p)
+}
+
+func TestEncodeLarge(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob := make([]byte, 1<<20)
+	for i := range blob {
+		blob[i] = byte(i)
+	}
+	if err := z.SetBlob(blob); err != nil {
+		t.Fatal(err)
+	}
+	want, err := text.Marshal(air.Z_TypeID, z.Struct)
+	if err != nil {
+		t.Fatal("Marshal:", err)
+	}
+
+	w := new(maxWriter)
+	if err := text.Encode(w, air.Z_TypeID, z.Struct); err != nil {
+		t.Fatal("Encode:", err)
+	}
+	if w.String() != want {
+		t.Error("Encode wrote different text than Marshal")
+	}
+	if w.max >= len(blob) {
+		t.Errorf("Encode made a %d byte write for a %d byte blob; want smaller writes", w.max, len(blob))
+	}
+}
+
+var errWriteFailed = errors.New("write failed")
+
+// failWriter fails every write after the first n bytes.
+type failWriter struct {
+	n      int
+	writes int
+}
+
+func (w *failWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if len(p) > w.n {
+		n := w.n
+		w.n = 0
+		return n, errWriteFailed
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+func TestEncodeWriteError(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tl, err := z.NewTextvec(100000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < tl.Len(); i++ {
+		if err := tl.Set(i, "element"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w := &failWriter{n: 100}
+	if err := text.NewEncoder(w).Encode(air.Z_TypeID, z.Struct); err != errWriteFailed {
+		t.Errorf("Encode error = %v; want %v", err, errWriteFailed)
+	}
+	if w.writes > 100 {
+		t.Errorf("Encode made %d writes; want it to stop after the first failure", w.writes)
+	}
+}
+
+func TestEncodeDefaultStructField(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := air.NewRootStackingRoot(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := text.Marshal(air.StackingRoot_TypeID, root.Struct)
+	if err != nil {
+		t.Fatal("Marshal:", err)
+	}
+	const want = "(a = (num = 0, b = (num = 0)), aWithDefault = (num = 42, b = (num = 0)))"
+	if got != want {
+		t.Errorf("Marshal(null aWithDefault) = %q; want %q", got, want)
+	}
+}
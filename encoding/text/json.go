@@ -0,0 +1,68 @@
+package text
+
+import (
+	"bytes"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/capnpjson"
+)
+
+// MarshalJSON returns the JSON representation of a struct, in the
+// format written by an Encoder in JSON mode.
+func MarshalJSON(typeID uint64, s capnp.Struct) (string, error) {
+	buf := new(bytes.Buffer)
+	enc := NewEncoder(buf)
+	enc.SetJSON(true)
+	if err := enc.Encode(typeID, s); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// MarshalListJSON returns the JSON representation of a struct list, in
+// the format written by an Encoder in JSON mode.
+func MarshalListJSON(typeID uint64, l capnp.List) (string, error) {
+	buf := new(bytes.Buffer)
+	enc := NewEncoder(buf)
+	enc.SetJSON(true)
+	if err := enc.EncodeList(typeID, l); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// SetJSON sets whether the encoder writes standard JSON instead of the
+// Cap'n Proto text format, so that its output can be consumed by tools
+// that expect JSON.  Structs are written as objects keyed by field
+// name, Data as base64 strings, and enums by name, following the
+// format of the capnpjson package.
+func (enc *Encoder) SetJSON(json bool) {
+	enc.json = json
+}
+
+func (enc *Encoder) jsonEncoder() *capnpjson.Encoder {
+	if enc.jenc == nil {
+		enc.jenc = capnpjson.NewEncoder(&enc.w)
+		if enc.reg != nil {
+			enc.jenc.UseRegistry(enc.reg)
+		}
+	}
+	return enc.jenc
+}
+
+func (enc *Encoder) encodeListJSON(typeID uint64, l capnp.List) error {
+	if enc.w.err != nil {
+		return enc.w.err
+	}
+	enc.w.WriteByte('[')
+	for i := 0; i < l.Len(); i++ {
+		if i > 0 {
+			enc.w.WriteByte(',')
+		}
+		if err := enc.jsonEncoder().Encode(typeID, l.Struct(i)); err != nil {
+			return err
+		}
+	}
+	enc.w.WriteByte(']')
+	return enc.w.err
+}
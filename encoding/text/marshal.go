@@ -2,6 +2,7 @@
 package text
 
 import (
+	"bufio"
 	"bytes"
 	"fmt"
 	"io"
@@ -9,6 +10,7 @@ import (
 	"strconv"
 
 	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/capnpjson"
 	"github.com/iguazio/go-capnproto2/internal/nodemap"
 	"github.com/iguazio/go-capnproto2/internal/schema"
 	"github.com/iguazio/go-capnproto2/internal/strquote"
@@ -18,7 +20,7 @@ import (
 // Marker strings.
 const (
 	voidMarker       = "void"
-	interfaceMarker  = "<external capability>"
+	nullMarker       = "null"
 	anyPointerMarker = "<opaque pointer>"
 )
 
@@ -31,6 +33,25 @@ func Marshal(typeID uint64, s capnp.Struct) (string, error) {
 	return buf.String(), nil
 }
 
+// Encode writes the text representation of a struct to w.  Output is
+// written as it is produced through a fixed-size buffer, so encoding a
+// large message doesn't hold its whole text in memory.  It is
+// equivalent to encoding with a NewEncoder(w) on a buffered w.
+func Encode(w io.Writer, typeID uint64, s capnp.Struct) error {
+	bw := bufio.NewWriterSize(w, encodeBufferSize)
+	if err := NewEncoder(bw).Encode(typeID, s); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// encodeBufferSize is the size of Encode's buffer.
+const encodeBufferSize = 32 << 10
+
+// textChunkSize is the number of bytes of Text or Data that an encoder
+// escapes at a time.  Escaping can grow a chunk by up to four times.
+const textChunkSize = 4 << 10
+
 // MarshalList returns the text representation of a struct list.
 func MarshalList(typeID uint64, l capnp.List) (string, error) {
 	buf := new(bytes.Buffer)
@@ -40,11 +61,23 @@ func MarshalList(typeID uint64, l capnp.List) (string, error) {
 	return buf.String(), nil
 }
 
-// An Encoder writes the text format of Cap'n Proto messages to an output stream.
+// An Encoder writes the text format of Cap'n Proto messages to an output
+// stream.  It writes the text in small pieces as it walks the message,
+// so w should usually be buffered.
+//
+// Capabilities are written as their index in the message's capability
+// table along with the interface name, like <capability #0: Echo>.
+// AnyPointer fields are written according to the type bound to them
+// through a generic struct's brand; other AnyPointer fields show only
+// whether they hold a capability.
 type Encoder struct {
 	w     errWriter
 	tmp   []byte
 	nodes nodemap.Map
+
+	json bool
+	reg  *schemas.Registry
+	jenc *capnpjson.Encoder
 }
 
 // NewEncoder returns a new encoder that writes to w.
@@ -56,6 +89,10 @@ func NewEncoder(w io.Writer) *Encoder {
 // schemas from the default registry.
 func (enc *Encoder) UseRegistry(reg *schemas.Registry) {
 	enc.nodes.UseRegistry(reg)
+	enc.reg = reg
+	if enc.jenc != nil {
+		enc.jenc.UseRegistry(reg)
+	}
 }
 
 // Encode writes the text representation of s to the stream.
@@ -63,7 +100,10 @@ func (enc *Encoder) Encode(typeID uint64, s capnp.Struct) error {
 	if enc.w.err != nil {
 		return enc.w.err
 	}
-	err := enc.marshalStruct(typeID, s)
+	if enc.json {
+		return enc.jsonEncoder().Encode(typeID, s)
+	}
+	err := enc.marshalStruct(typeID, nil, s)
 	if err != nil {
 		return err
 	}
@@ -72,11 +112,14 @@ func (enc *Encoder) Encode(typeID uint64, s capnp.Struct) error {
 
 // EncodeList writes the text representation of struct list l to the stream.
 func (enc *Encoder) EncodeList(typeID uint64, l capnp.List) error {
+	if enc.json {
+		return enc.encodeListJSON(typeID, l)
+	}
 	_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
 	typ, _ := schema.NewRootType(seg)
 	typ.SetStructType()
 	typ.StructType().SetTypeId(typeID)
-	return enc.marshalList(typ, l)
+	return enc.marshalList(typ, nil, l)
 }
 
 func (enc *Encoder) marshalBool(v bool) {
@@ -108,8 +151,36 @@ func (enc *Encoder) marshalFloat64(f float64) {
 }
 
 func (enc *Encoder) marshalText(t []byte) {
-	enc.tmp = strquote.Append(enc.tmp[:0], t)
-	enc.w.Write(enc.tmp)
+	enc.w.WriteByte('"')
+	for len(t) > 0 && enc.w.err == nil {
+		n := len(t)
+		if n > textChunkSize {
+			n = textChunkSize
+		}
+		enc.tmp = strquote.AppendEscaped(enc.tmp[:0], t[:n])
+		enc.w.Write(enc.tmp)
+		t = t[n:]
+	}
+	enc.w.WriteByte('"')
+}
+
+// marshalElems writes a list of n elements, calling f to write each
+// one.  It stops early if writing fails.
+func (enc *Encoder) marshalElems(n int, f func(i int) error) error {
+	enc.w.WriteByte('[')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			enc.w.WriteString(", ")
+		}
+		if err := f(i); err != nil {
+			return err
+		}
+		if enc.w.err != nil {
+			return enc.w.err
+		}
+	}
+	enc.w.WriteByte(']')
+	return nil
 }
 
 func needsEscape(b byte) bool {
@@ -121,7 +192,7 @@ func hexDigit(b byte) byte {
 	return digits[b]
 }
 
-func (enc *Encoder) marshalStruct(typeID uint64, s capnp.Struct) error {
+func (enc *Encoder) marshalStruct(typeID uint64, sc *brandScope, s capnp.Struct) error {
 	n, err := enc.nodes.Find(typeID)
 	if err != nil {
 		return err
@@ -155,20 +226,23 @@ func (enc *Encoder) marshalStruct(typeID uint64, s capnp.Struct) error {
 		enc.w.WriteString(" = ")
 		switch f.Which() {
 		case schema.Field_Which_slot:
-			if err := enc.marshalFieldValue(s, f); err != nil {
+			if err := enc.marshalFieldValue(s, f, sc); err != nil {
 				return err
 			}
 		case schema.Field_Which_group:
-			if err := enc.marshalStruct(f.Group().TypeId(), s); err != nil {
+			if err := enc.marshalStruct(f.Group().TypeId(), sc, s); err != nil {
 				return err
 			}
 		}
+		if enc.w.err != nil {
+			return enc.w.err
+		}
 	}
 	enc.w.WriteByte(')')
 	return nil
 }
 
-func (enc *Encoder) marshalFieldValue(s capnp.Struct, f schema.Field) error {
+func (enc *Encoder) marshalFieldValue(s capnp.Struct, f schema.Field, sc *brandScope) error {
 	typ, err := f.Slot().Type()
 	if err != nil {
 		return err
@@ -228,58 +302,24 @@ func (enc *Encoder) marshalFieldValue(s capnp.Struct, f schema.Field) error {
 		v := s.Uint64(capnp.DataOffset(f.Slot().Offset() * 8))
 		d := math.Float64bits(dv.Float64())
 		enc.marshalFloat64(math.Float64frombits(v ^ d))
-	case schema.Type_Which_structType:
-		p, err := s.Ptr(uint16(f.Slot().Offset()))
-		if err != nil {
-			return err
-		}
-		if !p.IsValid() {
-			p, _ = dv.StructValuePtr()
-		}
-		return enc.marshalStruct(typ.StructType().TypeId(), p.Struct())
-	case schema.Type_Which_data:
-		p, err := s.Ptr(uint16(f.Slot().Offset()))
-		if err != nil {
-			return err
-		}
-		if !p.IsValid() {
-			b, _ := dv.Data()
-			enc.marshalText(b)
-			return nil
-		}
-		enc.marshalText(p.Data())
-	case schema.Type_Which_text:
-		p, err := s.Ptr(uint16(f.Slot().Offset()))
-		if err != nil {
-			return err
-		}
-		if !p.IsValid() {
-			b, _ := dv.TextBytes()
-			enc.marshalText(b)
-			return nil
-		}
-		enc.marshalText(p.TextBytes())
-	case schema.Type_Which_list:
-		elem, err := typ.List().ElementType()
-		if err != nil {
-			return err
-		}
+	case schema.Type_Which_structType,
+		schema.Type_Which_data,
+		schema.Type_Which_text,
+		schema.Type_Which_list,
+		schema.Type_Which_interface,
+		schema.Type_Which_anyPointer:
 		p, err := s.Ptr(uint16(f.Slot().Offset()))
 		if err != nil {
 			return err
 		}
-		if !p.IsValid() {
-			p, _ = dv.ListPtr()
+		if !p.IsValid() && dv.IsValid() {
+			p, _ = dv.Struct.Ptr(0)
 		}
-		return enc.marshalList(elem, p.List())
+		return enc.marshalPtr(typ, sc, p)
 	case schema.Type_Which_enum:
 		v := s.Uint16(capnp.DataOffset(f.Slot().Offset() * 2))
 		d := dv.Uint16()
 		return enc.marshalEnum(typ.Enum().TypeId(), v^d)
-	case schema.Type_Which_interface:
-		enc.w.WriteString(interfaceMarker)
-	case schema.Type_Which_anyPointer:
-		enc.w.WriteString(anyPointerMarker)
 	default:
 		return fmt.Errorf("unknown field type %v", typ.Which())
 	}
@@ -297,102 +337,141 @@ func codeOrderFields(s schema.Node_structNode) []schema.Field {
 	return fields
 }
 
-func (enc *Encoder) marshalList(elem schema.Type, l capnp.List) error {
+func (enc *Encoder) marshalList(elem schema.Type, sc *brandScope, l capnp.List) error {
+	elem, sc = sc.resolve(elem)
 	switch elem.Which() {
 	case schema.Type_Which_void:
-		enc.w.WriteString(capnp.VoidList{List: l}.String())
+		return enc.marshalElems(l.Len(), func(int) error {
+			enc.w.WriteString(voidMarker)
+			return nil
+		})
 	case schema.Type_Which_bool:
-		enc.w.WriteString(capnp.BitList{List: l}.String())
+		bl := capnp.BitList{List: l}
+		return enc.marshalElems(l.Len(), func(i int) error {
+			enc.marshalBool(bl.At(i))
+			return nil
+		})
 	case schema.Type_Which_int8:
-		enc.w.WriteString(capnp.Int8List{List: l}.String())
+		il := capnp.Int8List{List: l}
+		return enc.marshalElems(l.Len(), func(i int) error {
+			enc.marshalInt(int64(il.At(i)))
+			return nil
+		})
 	case schema.Type_Which_int16:
-		enc.w.WriteString(capnp.Int16List{List: l}.String())
+		il := capnp.Int16List{List: l}
+		return enc.marshalElems(l.Len(), func(i int) error {
+			enc.marshalInt(int64(il.At(i)))
+			return nil
+		})
 	case schema.Type_Which_int32:
-		enc.w.WriteString(capnp.Int32List{List: l}.String())
+		il := capnp.Int32List{List: l}
+		return enc.marshalElems(l.Len(), func(i int) error {
+			enc.marshalInt(int64(il.At(i)))
+			return nil
+		})
 	case schema.Type_Which_int64:
-		enc.w.WriteString(capnp.Int64List{List: l}.String())
+		il := capnp.Int64List{List: l}
+		return enc.marshalElems(l.Len(), func(i int) error {
+			enc.marshalInt(il.At(i))
+			return nil
+		})
 	case schema.Type_Which_uint8:
-		enc.w.WriteString(capnp.UInt8List{List: l}.String())
+		ul := capnp.UInt8List{List: l}
+		return enc.marshalElems(l.Len(), func(i int) error {
+			enc.marshalUint(uint64(ul.At(i)))
+			return nil
+		})
 	case schema.Type_Which_uint16:
-		enc.w.WriteString(capnp.UInt16List{List: l}.String())
+		ul := capnp.UInt16List{List: l}
+		return enc.marshalElems(l.Len(), func(i int) error {
+			enc.marshalUint(uint64(ul.At(i)))
+			return nil
+		})
 	case schema.Type_Which_uint32:
-		enc.w.WriteString(capnp.UInt32List{List: l}.String())
+		ul := capnp.UInt32List{List: l}
+		return enc.marshalElems(l.Len(), func(i int) error {
+			enc.marshalUint(uint64(ul.At(i)))
+			return nil
+		})
 	case schema.Type_Which_uint64:
-		enc.w.WriteString(capnp.UInt64List{List: l}.String())
+		ul := capnp.UInt64List{List: l}
+		return enc.marshalElems(l.Len(), func(i int) error {
+			enc.marshalUint(ul.At(i))
+			return nil
+		})
 	case schema.Type_Which_float32:
-		enc.w.WriteString(capnp.Float32List{List: l}.String())
+		fl := capnp.Float32List{List: l}
+		return enc.marshalElems(l.Len(), func(i int) error {
+			enc.marshalFloat32(fl.At(i))
+			return nil
+		})
 	case schema.Type_Which_float64:
-		enc.w.WriteString(capnp.Float64List{List: l}.String())
+		fl := capnp.Float64List{List: l}
+		return enc.marshalElems(l.Len(), func(i int) error {
+			enc.marshalFloat64(fl.At(i))
+			return nil
+		})
 	case schema.Type_Which_data:
-		enc.w.WriteString(capnp.DataList{List: l}.String())
-	case schema.Type_Which_text:
-		enc.w.WriteString(capnp.TextList{List: l}.String())
-	case schema.Type_Which_structType:
-		enc.w.WriteByte('[')
-		for i := 0; i < l.Len(); i++ {
-			if i > 0 {
-				enc.w.WriteString(", ")
+		dl := capnp.DataList{List: l}
+		return enc.marshalElems(l.Len(), func(i int) error {
+			b, err := dl.At(i)
+			if err != nil {
+				enc.w.WriteString("<error>")
+				return nil
 			}
-			err := enc.marshalStruct(elem.StructType().TypeId(), l.Struct(i))
+			enc.marshalText(b)
+			return nil
+		})
+	case schema.Type_Which_text:
+		tl := capnp.TextList{List: l}
+		return enc.marshalElems(l.Len(), func(i int) error {
+			b, err := tl.BytesAt(i)
 			if err != nil {
-				return err
+				enc.w.WriteString("<error>")
+				return nil
 			}
-		}
-		enc.w.WriteByte(']')
+			enc.marshalText(b)
+			return nil
+		})
+	case schema.Type_Which_structType:
+		id := elem.StructType().TypeId()
+		br, _ := elem.StructType().Brand()
+		esc := bind(br, sc)
+		return enc.marshalElems(l.Len(), func(i int) error {
+			return enc.marshalStruct(id, esc, l.Struct(i))
+		})
 	case schema.Type_Which_list:
-		enc.w.WriteByte('[')
 		ee, err := elem.List().ElementType()
 		if err != nil {
 			return err
 		}
-		for i := 0; i < l.Len(); i++ {
-			if i > 0 {
-				enc.w.WriteString(", ")
-			}
-			p, err := capnp.PointerList{List: l}.PtrAt(i)
+		pl := capnp.PointerList{List: l}
+		return enc.marshalElems(l.Len(), func(i int) error {
+			p, err := pl.PtrAt(i)
 			if err != nil {
 				return err
 			}
-			err = enc.marshalList(ee, p.List())
-			if err != nil {
-				return err
-			}
-		}
-		enc.w.WriteByte(']')
+			return enc.marshalList(ee, sc, p.List())
+		})
 	case schema.Type_Which_enum:
-		enc.w.WriteByte('[')
 		il := capnp.UInt16List{List: l}
 		typ := elem.Enum().TypeId()
 		// TODO(light): only search for node once
-		for i := 0; i < il.Len(); i++ {
-			if i > 0 {
-				enc.w.WriteString(", ")
-			}
-			enc.marshalEnum(typ, il.At(i))
-		}
-		enc.w.WriteByte(']')
-	case schema.Type_Which_interface:
-		enc.w.WriteByte('[')
-		for i := 0; i < l.Len(); i++ {
-			if i > 0 {
-				enc.w.WriteString(", ")
-			}
-			enc.w.WriteString(interfaceMarker)
-		}
-		enc.w.WriteByte(']')
-	case schema.Type_Which_anyPointer:
-		enc.w.WriteByte('[')
-		for i := 0; i < l.Len(); i++ {
-			if i > 0 {
-				enc.w.WriteString(", ")
+		return enc.marshalElems(l.Len(), func(i int) error {
+			return enc.marshalEnum(typ, il.At(i))
+		})
+	case schema.Type_Which_interface, schema.Type_Which_anyPointer:
+		pl := capnp.PointerList{List: l}
+		return enc.marshalElems(l.Len(), func(i int) error {
+			p, err := pl.PtrAt(i)
+			if err != nil {
+				return err
 			}
-			enc.w.WriteString(anyPointerMarker)
-		}
-		enc.w.WriteByte(']')
+			return enc.marshalPtr(elem, sc, p)
+		})
 	default:
 		return fmt.Errorf("unknown list type %v", elem.Which())
 	}
-	return nil
 }
 
 func (enc *Encoder) marshalEnum(typ uint64, val uint16) error {
@@ -130,8 +130,13 @@ func TestEncodeList(t *testing.T) {
 	tests := []struct {
 		constID uint64
 		text    string
+		json    string
 	}{
-		{0x90c9e81e6418df8e, `[(key = "foo", value = (void = void)), (key = "bar", value = (void = void))]`},
+		{
+			0x90c9e81e6418df8e,
+			`[(key = "foo", value = (void = void)), (key = "bar", value = (void = void))]`,
+			`[{"key":"foo","value":{"void":null}},{"key":"bar","value":{"void":null}}]`,
+		},
 	}
 
 	data, err := readTestFile("txt.capnp.out")
@@ -225,5 +230,15 @@ func TestEncodeList(t *testing.T) {
 			t.Errorf("Encode(%#x, (%s @%#x).const.value.list) = %q; want %q", tid, dn, test.constID, text, test.text)
 			continue
 		}
+
+		buf.Reset()
+		enc.SetJSON(true)
+		if err := enc.EncodeList(tid, lv.List()); err != nil {
+			t.Errorf("JSON Encode(%#x, (%s @%#x).const.value.list): %v", tid, dn, test.constID, err)
+			continue
+		}
+		if js := buf.String(); js != test.json {
+			t.Errorf("JSON Encode(%#x, (%s @%#x).const.value.list) = %s; want %s", tid, dn, test.constID, js, test.json)
+		}
 	}
 }
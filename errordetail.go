@@ -0,0 +1,81 @@
+package capnp
+
+// An ErrorDetail is a struct attached to an error that describes it
+// in more detail than its message, like the field of a request that
+// was invalid or how long to wait before retrying.  Details are sent
+// along with errors that are returned across RPC connections, much
+// like gRPC status details, so clients can react to them without
+// parsing messages.
+type ErrorDetail struct {
+	// TypeID identifies the detail, and is normally the type ID of
+	// Struct.
+	TypeID uint64
+
+	Struct Struct
+}
+
+// WithErrorDetails returns an error with err's message that carries
+// details in addition to those that err already carries.  Code
+// generated with capnpc-go -errordetails has an ErrorDetail method on
+// each struct to create details.
+func WithErrorDetails(err error, details ...ErrorDetail) error {
+	if err == nil || len(details) == 0 {
+		return err
+	}
+	return &detailError{err: err, details: details}
+}
+
+// detailError is returned by WithErrorDetails.
+type detailError struct {
+	err     error
+	details []ErrorDetail
+}
+
+func (e *detailError) Error() string {
+	return e.err.Error()
+}
+
+func (e *detailError) Unwrap() error {
+	return e.err
+}
+
+func (e *detailError) ErrorDetails() []ErrorDetail {
+	return e.details
+}
+
+// ErrorDetails returns the details attached to err.  Details may be
+// provided by any error with an ErrorDetails() []ErrorDetail method,
+// like the errors returned by WithErrorDetails and RPC exceptions.
+// ErrorDetails looks through *MethodError and errors with an
+// Unwrap() error method, returning the details of the outer errors
+// first.
+func ErrorDetails(err error) []ErrorDetail {
+	var details []ErrorDetail
+	for err != nil {
+		if de, ok := err.(interface {
+			ErrorDetails() []ErrorDetail
+		}); ok {
+			details = append(details, de.ErrorDetails()...)
+		}
+		switch e := err.(type) {
+		case *MethodError:
+			err = e.Err
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		default:
+			err = nil
+		}
+	}
+	return details
+}
+
+// FindErrorDetail returns the first detail attached to err with the
+// given type ID.
+func FindErrorDetail(err error, typeID uint64) (Struct, bool) {
+	for _, d := range ErrorDetails(err) {
+		if d.TypeID == typeID {
+			return d.Struct, true
+		}
+	}
+	return Struct{}, false
+}
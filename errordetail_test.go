@@ -0,0 +1,61 @@
+package capnp
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrorDetails(t *testing.T) {
+	_, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	newDetail := func(id uint64, v uint64) ErrorDetail {
+		s, err := NewStruct(seg, ObjectSize{DataSize: 8})
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.SetUint64(0, v)
+		return ErrorDetail{TypeID: id, Struct: s}
+	}
+	base := errors.New("base")
+	if err := WithErrorDetails(base); err != base {
+		t.Errorf("WithErrorDetails(base) = %v; want base", err)
+	}
+	if err := WithErrorDetails(nil, newDetail(1, 1)); err != nil {
+		t.Errorf("WithErrorDetails(nil, ...) = %v; want nil", err)
+	}
+
+	inner := WithErrorDetails(base, newDetail(1, 10), newDetail(2, 20))
+	if inner.Error() != "base" {
+		t.Errorf("inner.Error() = %q; want \"base\"", inner.Error())
+	}
+	err = &MethodError{
+		Method: &Method{InterfaceID: 0x8e5322c1e9282534, MethodID: 1},
+		Err:    inner,
+	}
+	err = WithErrorDetails(err, newDetail(1, 30))
+	details := ErrorDetails(err)
+	var got []uint64
+	for _, d := range details {
+		got = append(got, d.TypeID, d.Struct.Uint64(0))
+	}
+	want := []uint64{1, 30, 1, 10, 2, 20}
+	if len(got) != len(want) {
+		t.Fatalf("ErrorDetails = (id, value) %v; want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("ErrorDetails = (id, value) %v; want %v", got, want)
+		}
+	}
+	if s, ok := FindErrorDetail(err, 2); !ok || s.Uint64(0) != 20 {
+		t.Errorf("FindErrorDetail(err, 2) = %d, %t; want 20, true", s.Uint64(0), ok)
+	}
+	if _, ok := FindErrorDetail(err, 3); ok {
+		t.Error("FindErrorDetail(err, 3) found a detail")
+	}
+	if d := ErrorDetails(base); len(d) != 0 {
+		t.Errorf("ErrorDetails(base) = %v; want none", d)
+	}
+}
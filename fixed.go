@@ -1,5 +1,10 @@
 package capnp
 
+import (
+	"errors"
+	"fmt"
+)
+
 // SetData sets the underlying buffer
 func (seg *Segment) SetData(data []byte) {
 	seg.data = data
@@ -14,3 +19,74 @@ func (msg *Message) InitializeFixed() *Segment {
 
 	return &msg.firstSeg
 }
+
+// A BufferMode says whether a message created by NewMessageFromBytes
+// may be modified.
+type BufferMode int
+
+// Buffer modes.
+const (
+	// ReadOnly messages read their objects from the buffer.  Setting
+	// pointers or allocating new objects fails.
+	ReadOnly BufferMode = iota
+
+	// ReadWrite messages can be modified in place, and allocate new
+	// objects in the buffer's spare capacity.  The buffer is never
+	// grown: once cap(buf) bytes are used, allocations fail.
+	ReadWrite
+)
+
+// NewMessageFromBytes returns a single-segment message that uses buf as
+// its segment.  buf holds the segment's contents without a stream
+// header; use Unmarshal to read framed messages.  No copying is
+// performed, so the message's objects refer directly to buf.
+//
+// If mode is ReadWrite and buf is empty, the message is empty and its
+// root pointer is allocated in buf, which must then have a capacity of
+// at least one word.
+func NewMessageFromBytes(buf []byte, mode BufferMode) (*Message, error) {
+	if len(buf)%int(wordSize) != 0 {
+		return nil, errors.New("capnp: segment size is not a multiple of word size")
+	}
+	switch mode {
+	case ReadOnly:
+		// Clip the capacity so that allocations can't use buf's spare
+		// space without asking the arena.
+		return &Message{Arena: roSingleSegment(buf[:len(buf):len(buf)])}, nil
+	case ReadWrite:
+		fa := fixedSegmentArena(buf)
+		if len(buf) == 0 {
+			msg, _, err := NewMessage(&fa)
+			return msg, err
+		}
+		return &Message{Arena: &fa}, nil
+	default:
+		return nil, fmt.Errorf("capnp: unknown buffer mode %d", mode)
+	}
+}
+
+// fixedSegmentArena is a single-segment arena that allocates within
+// its buffer's capacity and never grows it.
+type fixedSegmentArena []byte
+
+func (fa *fixedSegmentArena) NumSegments() int64 {
+	return 1
+}
+
+func (fa *fixedSegmentArena) Data(id SegmentID) ([]byte, error) {
+	if id != 0 {
+		return nil, errSegmentOutOfBounds
+	}
+	return *fa, nil
+}
+
+func (fa *fixedSegmentArena) Allocate(sz Size, segs map[SegmentID]*Segment) (SegmentID, []byte, error) {
+	data := []byte(*fa)
+	if segs[0] != nil {
+		data = segs[0].data
+	}
+	if !hasCapacity(data, sz) {
+		return 0, nil, errBufferFull
+	}
+	return 0, data, nil
+}
@@ -0,0 +1,68 @@
+//go:build go1.18
+// +build go1.18
+
+package capnp_test
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/encoding/text"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+// FuzzUnmarshal reads arbitrary framed messages as a Z and walks every
+// field.  Reading a malformed message may fail, but must never panic.
+// Inputs that once caused panics are kept in testdata/fuzz/FuzzUnmarshal
+// and run as regression tests by go test.
+func FuzzUnmarshal(f *testing.F) {
+	// Seed with the single-segment corpus of the go-fuzz harness.
+	paths, err := filepath.Glob(filepath.Join("internal", "fuzztest", "corpus", "*"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, p := range paths {
+		seg, err := ioutil.ReadFile(p)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(frameSegment(seg))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		msg, err := capnp.Unmarshal(data)
+		if err != nil {
+			return
+		}
+		msg.TraverseLimit = 1 << 20
+		walkMessage(msg)
+	})
+}
+
+// walkMessage reads everything reachable from the message root and
+// copies it, ignoring errors.
+func walkMessage(msg *capnp.Message) {
+	p, err := msg.RootPtr()
+	if err != nil {
+		return
+	}
+	text.Marshal(air.Z_TypeID, p.Struct())
+	capnp.Canonicalize(p.Struct())
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		return
+	}
+	seg.Message().SetRootPtr(p)
+}
+
+// frameSegment returns a message stream containing a single segment.
+func frameSegment(seg []byte) []byte {
+	for len(seg)%8 != 0 {
+		seg = append(seg, 0)
+	}
+	data := make([]byte, 8, 8+len(seg))
+	binary.LittleEndian.PutUint32(data[4:], uint32(len(seg)/8))
+	return append(data, seg...)
+}
@@ -13,25 +13,34 @@ const Tag = uint64(0xa574b41924caefc7)
 const Notag = uint64(0xc8768679ec52e012)
 const Customtype = uint64(0xfa10659ae02f2093)
 const Name = uint64(0xc2b96012172f8df1)
-const schema_d12a1c51fedd6c88 = "x\xda\x12\x98\xe2\xc0d\xc8z\x9c\x89\x81!P\x81\x95" +
-	"\xed\xff\xf1\xf7\xa7T$\xb7\x94,e\x08\xe4e\xe5\xf8" +
-	"\xdf\x91s\xf7_\xa0\x8c\xd6E\x06\x06FaO\xc6." +
-	"\xe1@Fv\x06\x86`\x1fFfF\x06\xc6\xff\x0f\xb4" +
-	"+\x95\x05\xeaW\xee\x03)eDQj\xcb\xb8J\xd8" +
-	"\x15\xac\xd4\x01\xa2\xf4c\xaf\xbe\xb8P\xc2\xceC\x0c\x17" +
-	"yY\xff\xf1\xa3\xa85d\x9c$l\x09Vk\x02Q" +
-	"\x1b7y~\xe0\xdek]GA\xc6\x9a\xa0(Ue" +
-	"\xec\x12\xd6\x05+\xd5\x80(\x15z\x10\xf4\xa6\xb2\xad\xec" +
-	"\x04\xa6c%\x19g\x09+\x82\x95\xca@\x94nu\xe1" +
-	"Sc\xdcf\xf0\x10\xd3\xb1\xbc\x8c\x8b\x84E\xc1J\x05" +
-	" J'+\xe8?\x98\x95*\xf0\x0b\xa4T\x01E)" +
-	"#\xe3!aN\xb0R\x16\x90R\x9e\xff\xc5%)\xfa" +
-	"\xe9\xf9z\xc9\x8c\x89\x05y\x05V%\x89\xe9\x0c\x0c\x01" +
-	"\x8c\x8c\x8c<\x0cLhR\x05\x89\xc9\xfc\xd9\x89\xe9\xa9" +
-	"\xd8e\xf3\x12s\x19qH\xa5\xe4'\xe323/\xbf" +
-	"\x8491=\x80\x91\x91\x81\x19M&3\xb7\x80=\xbf" +
-	"\xa8\x04]\x1b\x13X2\xb9\xb4\xb8$?\xb7\xa4\xb2 " +
-	"\x15f. \x00\x00\xff\xff\x89\xff\x94\xdf"
+const Sensitive = uint64(0xd557bd3b4bb84862)
+const Idempotent = uint64(0xc5c67716e14c947e)
+const schema_d12a1c51fedd6c88 = "x\xdat\xd0?\xe8\xd3@\x14\x07\xf0\xf7\x12b*X" +
+	"[z\xa0\x08\x0e\x07\x8a\x83\x82\xad\xe0T\x07\x8b8\x08" +
+	":\xf4\xcf\xe0&\xc64\x84\xa0\xf9\x839+]t\x13" +
+	")\x14\xd4\xea`Q\xc4b\x87\x8a\x0e\x82J+\xd4\xa1" +
+	"P-\x82CA\x05\x87J:8\x08\x05\xe9\xea\xd0\xc8" +
+	"%\x88$\xf9\xfd\x08Y\xde\xf7\xc3\xf7\xde]\xa1\x9d*" +
+	"\x09\xc7$\xf9\x14@U\x94v\xf8\x1f\x7f\x7f:\xb8\xef" +
+	"5\xebC%-\xa5\xfc\xdbW\x16\x9b\xca\xfe\xc3s\x00" +
+	"$\xbfh\x8b\xac\xa9\x0cP[Q\x11\x01}\xefH\xf3" +
+	"@\xf6\xe6\xe0=\xa7\x18\xa1\x0b\xfa\x9c\xfc\x0c\xa8\x17\xd2" +
+	"u;\xbf7wq4\x81yZ\xdad\"vN\xef" +
+	"\x91\xef\x81\xfd\x12\xda\x0b\x9d'\x95\xf1\xb7\xd6\x94\xd7\x1e" +
+	"\x8f\xd0)m\x91\xcf\x01\x9d\x85\xf4\xc6\xfds\xcb=\xd7" +
+	"?L\xe1\xeeNI\x88\xd0\x11\xed\x01\xd6\x86!\xcby" +
+	"\xd5U\xf3Vc\x96\xbc\xd3\x80v\xc9\xab\xa0\xf1EH" +
+	"/\x9d\x19\x9e=1>\xff\x95S\x1a\xa1\x8f\xe9;\xd2" +
+	"\xa72\xffk\xcf8\x06\xf2\x92\xca\xfe\x9b\xd3\xbb\x0f\xe1" +
+	"\xdb\xc22\xf9\x08\x0fi\x8f<\x0d\xba\x1f\x85\xdd\x1d\x9a" +
+	"\xf7\xbaZ\xf6O\xb2\xbbM'\xe4A@\xefp\xeaG" +
+	"\xbe\x1f\xbe\xcb\xeay\xdd>\xaa\xa2\xe2XN\x91):" +
+	"@\x19\x11w\x81\x10\x8b\x1cE\xcd\\Vtm\xeb\xd4" +
+	"RL\xdc&\xaa\xdb\xea\xffN>\xe6S,\x1au\xcd" +
+	"tl&k\x16\x031\xdef3Q\xd1\xcb\x88\x89\xc4" +
+	"\xd5\xac\x93\xae\xc1\x8c\x06?\xac\x8c\x02/-a\x0c\x19" +
+	"\xa6#\xdbWY|\x1d!\x08\xd5k.\xb3M\xd6t" +
+	"\xb4\x7fK\xfd\x1d\x00\xe5\xce\xdc^"
 
 func init() {
 	schemas.Register(schema_d12a1c51fedd6c88,
@@ -39,7 +48,9 @@ func init() {
 		0xbea97f1023792be0,
 		0xc2b96012172f8df1,
 		0xc58ad6bd519f935e,
+		0xc5c67716e14c947e,
 		0xc8768679ec52e012,
+		0xd557bd3b4bb84862,
 		0xe130b601260e44b5,
 		0xfa10659ae02f2093)
 }
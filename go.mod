@@ -1,6 +1,11 @@
-module "github.com/iguazio/go-capnproto2"
+module github.com/iguazio/go-capnproto2
+
+go 1.21
 
 require (
-	"github.com/kylelemons/godebug" v0.0.0-20170820004349-d65d576e9348
-	"golang.org/x/net" v0.0.0-20180218175443-cbe0f9307d01
+	github.com/kylelemons/godebug v0.0.0-20170820004349-d65d576e9348
+	golang.org/x/crypto v0.24.0
+	golang.org/x/net v0.21.0
 )
+
+require golang.org/x/sys v0.27.0 // indirect
@@ -2394,3 +2394,38 @@ func BenchmarkSmallMessage_SingleSegment(b *testing.B) {
 func BenchmarkSmallMessage_MultiSegment(b *testing.B) {
 	benchmarkSmallMessage(b, func() capnp.Arena { return capnp.MultiSegment(nil) })
 }
+
+func TestTypedAnswer(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := air.NewRootStackingRoot(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := root.NewA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.SetNum(7)
+
+	ans := capnp.NewTypedAnswer[air.StackingRoot](capnp.ImmediateAnswer(root.Struct))
+	got, err := ans.Struct()
+	if err != nil {
+		t.Fatal("Struct:", err)
+	}
+	if ga, _ := got.A(); ga.Num() != 7 {
+		t.Errorf("ans.Struct().A().Num() = %d; want 7", ga.Num())
+	}
+	sa, err := capnp.PipelineStruct[air.StackingA](ans.Pipeline().GetPipeline(1))
+	if err != nil {
+		t.Fatal("PipelineStruct:", err)
+	}
+	if sa.Num() != 7 {
+		t.Errorf("PipelineStruct(a).Num() = %d; want 7", sa.Num())
+	}
+	if s := capnp.StructAs[air.StackingA](a.Struct); s.Num() != 7 {
+		t.Errorf("StructAs(a).Num() = %d; want 7", s.Num())
+	}
+}
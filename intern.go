@@ -0,0 +1,108 @@
+package capnp
+
+import "sync"
+
+// internKey identifies an interned value.  Text and Data with the same
+// bytes are laid out differently, since Text has a NUL terminator, so
+// they are interned separately.
+type internKey struct {
+	data bool
+	v    string
+}
+
+// interning reports whether texts and data written to seg should go
+// through internBlob.  Invalid objects, like a zero Struct, have no
+// segment and so never intern; their setters report misuse as usual.
+func (s *Segment) interning() bool {
+	return s != nil && s.msg != nil && s.msg.Intern
+}
+
+// internBlob returns a Text, or a Data if data is true, holding v for a
+// pointer in seg.  If a value equal to v was interned in seg before, it
+// is returned instead of allocating a new one.  Values in other
+// segments are not reused, since a far pointer to a short value can
+// take more space than a copy.
+func internBlob(seg *Segment, v string, data bool) (UInt8List, error) {
+	m := seg.msg
+	key := internKey{data: data, v: v}
+	m.mu.Lock()
+	l, ok := m.interned[key]
+	m.mu.Unlock()
+	if ok && l.seg == seg {
+		return l, nil
+	}
+	var err error
+	if data {
+		l, err = NewData(seg, []byte(v))
+	} else {
+		l, err = NewText(seg, v)
+	}
+	if err != nil {
+		return UInt8List{}, err
+	}
+	m.mu.Lock()
+	if m.interned == nil {
+		m.interned = make(map[internKey]UInt8List)
+	}
+	m.interned[key] = l
+	m.mu.Unlock()
+	return l, nil
+}
+
+// maxInternedText is the length of the longest Text that a StringTable
+// stores.  Longer strings are rarely repeated and would pin memory.
+const maxInternedText = 64
+
+// A StringTable maps the bytes of Text values to shared Go strings, so
+// that a program that reads the same few strings from many messages,
+// like status names, allocates each string once instead of once per
+// read.  It is the reading counterpart of Message.Intern.  A
+// StringTable is safe to use from multiple goroutines.
+type StringTable struct {
+	max int
+
+	mu      sync.Mutex
+	strings map[string]string
+}
+
+// NewStringTable returns a table that holds at most maxEntries strings.
+// Once it is full, strings that are not already in the table are
+// allocated as if there were no table.
+func NewStringTable(maxEntries int) *StringTable {
+	return &StringTable{max: maxEntries, strings: make(map[string]string)}
+}
+
+// Intern returns b as a string.  If b is short and the table has seen
+// the same bytes before, the string returned then is returned again.
+func (t *StringTable) Intern(b []byte) string {
+	if len(b) > maxInternedText {
+		return string(b)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if s, ok := t.strings[string(b)]; ok {
+		return s
+	}
+	s := string(b)
+	if len(t.strings) < t.max {
+		t.strings[s] = s
+	}
+	return s
+}
+
+// Len returns the number of strings in the table.
+func (t *StringTable) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.strings)
+}
+
+// WithStringTable returns an option that makes a Decoder set the
+// StringTable of each message that it reads to t.  Give each Decoder
+// its own table unless the streams are known to share strings.  The
+// option has no effect on an Encoder.
+func WithStringTable(t *StringTable) CodecOption {
+	return func(o *codecOptions) {
+		o.strings = t
+	}
+}
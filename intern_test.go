@@ -0,0 +1,158 @@
+package capnp
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+	"unsafe"
+)
+
+func TestIntern(t *testing.T) {
+	build := func(intern bool) *Message {
+		msg, seg, err := NewMessage(SingleSegment(nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg.Intern = intern
+		root, err := NewRootStruct(seg, ObjectSize{PointerCount: 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		l, err := NewCompositeList(seg, ObjectSize{PointerCount: 2}, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < l.Len(); i++ {
+			if err := l.Struct(i).SetText(0, "status-ok"); err != nil {
+				t.Fatal(err)
+			}
+			if err := l.Struct(i).SetData(1, []byte("status-ok")); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := root.SetPtr(0, l.ToPtr()); err != nil {
+			t.Fatal(err)
+		}
+		tl, err := NewTextList(seg, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tl.Set(0, "status-ok")
+		tl.Set(1, "other")
+		if err := root.SetPtr(1, tl.ToPtr()); err != nil {
+			t.Fatal(err)
+		}
+		if err := root.SetTextFromBytes(2, []byte("other")); err != nil {
+			t.Fatal(err)
+		}
+		return msg
+	}
+	plain, interned := build(false), build(true)
+	plainSize, internedSize := len(plain.firstSeg.data), len(interned.firstSeg.data)
+	if internedSize >= plainSize/2 {
+		t.Errorf("interned message is %d bytes, plain message is %d bytes; want much smaller", internedSize, plainSize)
+	}
+
+	p, err := interned.RootPtr()
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := p.Struct()
+	lp, _ := root.Ptr(0)
+	l := lp.List()
+	for i := 0; i < l.Len(); i++ {
+		txt, _ := l.Struct(i).Ptr(0)
+		data, _ := l.Struct(i).Ptr(1)
+		if txt.Text() != "status-ok" || !bytes.Equal(data.Data(), []byte("status-ok")) {
+			t.Fatalf("element %d = %q, %q; want \"status-ok\" for both", i, txt.Text(), data.Data())
+		}
+	}
+	tp, _ := root.Ptr(1)
+	if s, _ := (TextList{tp.List()}).At(1); s != "other" {
+		t.Errorf("text list element 1 = %q; want \"other\"", s)
+	}
+	op, _ := root.Ptr(2)
+	if op.Text() != "other" {
+		t.Errorf("root text = %q; want \"other\"", op.Text())
+	}
+}
+
+func TestInternZeroValues(t *testing.T) {
+	setPanicFree(t, false)
+	tests := []struct {
+		name string
+		set  func() error
+	}{
+		{"Struct.SetText", func() error { return Struct{}.SetText(0, "x") }},
+		{"Struct.SetTextFromBytes", func() error { return Struct{}.SetTextFromBytes(0, []byte("x")) }},
+		{"Struct.SetData", func() error { return Struct{}.SetData(0, []byte("x")) }},
+		{"TextList.Set", func() error { return TextList{}.Set(0, "x") }},
+		{"DataList.Set", func() error { return DataList{}.Set(0, []byte("x")) }},
+	}
+	for _, test := range tests {
+		func() {
+			defer func() {
+				r := recover()
+				if _, ok := r.(runtime.Error); ok || r == nil {
+					t.Errorf("%s on zero value: recovered %v; want misuse panic", test.name, r)
+				}
+			}()
+			test.set()
+		}()
+	}
+}
+
+func TestDecoderStringTable(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	for i := 0; i < 2; i++ {
+		msg, seg, err := NewMessage(SingleSegment(nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		l, err := NewTextListFromStrings(seg, []string{"active", "suspended"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := msg.SetRootPtr(l.ToPtr()); err != nil {
+			t.Fatal(err)
+		}
+		if err := enc.Encode(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tab := NewStringTable(1)
+	dec := NewDecoder(&buf, WithStringTable(tab))
+	var got [][]string
+	for i := 0; i < 2; i++ {
+		msg, err := dec.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if msg.StringTable != tab {
+			t.Fatal("decoded message does not use the decoder's StringTable")
+		}
+		p, err := msg.RootPtr()
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := TextList{p.List()}.Strings()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, s)
+	}
+	if got[0][0] != "active" || got[1][1] != "suspended" {
+		t.Fatalf("decoded %q; want [[active suspended] [active suspended]]", got)
+	}
+	if unsafe.StringData(got[0][0]) != unsafe.StringData(got[1][0]) {
+		t.Error("\"active\" was not shared between messages")
+	}
+	if unsafe.StringData(got[0][1]) == unsafe.StringData(got[1][1]) {
+		t.Error("\"suspended\" was shared after the table was full")
+	}
+	if n := tab.Len(); n != 1 {
+		t.Errorf("tab.Len() = %d; want 1", n)
+	}
+}
@@ -3,13 +3,13 @@
 package aircraftlib
 
 import (
-	context "golang.org/x/net/context"
-	math "math"
-	strconv "strconv"
 	capnp "github.com/iguazio/go-capnproto2"
 	text "github.com/iguazio/go-capnproto2/encoding/text"
 	schemas "github.com/iguazio/go-capnproto2/schemas"
 	server "github.com/iguazio/go-capnproto2/server"
+	context "golang.org/x/net/context"
+	math "math"
+	strconv "strconv"
 )
 
 // Constants defined in aircraft.capnp.
@@ -44,11 +44,28 @@ func NewRootZdate(s *capnp.Segment) (Zdate, error) {
 	return Zdate{st}, err
 }
 
+func AllocateRootZdate(msg *capnp.Message) (Zdate, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return Zdate{st}, err
+}
+
 func ReadRootZdate(msg *capnp.Message) (Zdate, error) {
 	root, err := msg.RootPtr()
 	return Zdate{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Zdate) Equal(other Zdate) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Zdate) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Zdate) String() string {
 	str, _ := text.Marshal(0xde50aebbad57549d, s.Struct)
 	return str
@@ -87,6 +104,13 @@ func NewZdate_List(s *capnp.Segment, sz int32) (Zdate_List, error) {
 	return Zdate_List{l}, err
 }
 
+// NewRootZdateListMessage creates a message whose root is a new
+// list of n Zdate.  The message's segment is sized to fit the list.
+func NewRootZdateListMessage(n int32) (*capnp.Message, Zdate_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 0}, n)
+	return msg, Zdate_List{l}, err
+}
+
 func (s Zdate_List) At(i int) Zdate { return Zdate{s.List.Struct(i)} }
 
 func (s Zdate_List) Set(i int, v Zdate) error { return s.List.SetStruct(i, v.Struct) }
@@ -100,8 +124,7 @@ func (s Zdate_List) String() string {
 type Zdate_Promise struct{ *capnp.Pipeline }
 
 func (p Zdate_Promise) Struct() (Zdate, error) {
-	s, err := p.Pipeline.Struct()
-	return Zdate{s}, err
+	return capnp.PipelineStruct[Zdate](p.Pipeline)
 }
 
 type Zdata struct{ capnp.Struct }
@@ -119,11 +142,28 @@ func NewRootZdata(s *capnp.Segment) (Zdata, error) {
 	return Zdata{st}, err
 }
 
+func AllocateRootZdata(msg *capnp.Message) (Zdata, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Zdata{st}, err
+}
+
 func ReadRootZdata(msg *capnp.Message) (Zdata, error) {
 	root, err := msg.RootPtr()
 	return Zdata{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Zdata) Equal(other Zdata) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Zdata) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Zdata) String() string {
 	str, _ := text.Marshal(0xc7da65f9a2f20ba2, s.Struct)
 	return str
@@ -152,6 +192,13 @@ func NewZdata_List(s *capnp.Segment, sz int32) (Zdata_List, error) {
 	return Zdata_List{l}, err
 }
 
+// NewRootZdataListMessage creates a message whose root is a new
+// list of n Zdata.  The message's segment is sized to fit the list.
+func NewRootZdataListMessage(n int32) (*capnp.Message, Zdata_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, Zdata_List{l}, err
+}
+
 func (s Zdata_List) At(i int) Zdata { return Zdata{s.List.Struct(i)} }
 
 func (s Zdata_List) Set(i int, v Zdata) error { return s.List.SetStruct(i, v.Struct) }
@@ -165,8 +212,7 @@ func (s Zdata_List) String() string {
 type Zdata_Promise struct{ *capnp.Pipeline }
 
 func (p Zdata_Promise) Struct() (Zdata, error) {
-	s, err := p.Pipeline.Struct()
-	return Zdata{s}, err
+	return capnp.PipelineStruct[Zdata](p.Pipeline)
 }
 
 type Airport uint16
@@ -264,11 +310,28 @@ func NewRootPlaneBase(s *capnp.Segment) (PlaneBase, error) {
 	return PlaneBase{st}, err
 }
 
+func AllocateRootPlaneBase(msg *capnp.Message) (PlaneBase, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 32, PointerCount: 2})
+	return PlaneBase{st}, err
+}
+
 func ReadRootPlaneBase(msg *capnp.Message) (PlaneBase, error) {
 	root, err := msg.RootPtr()
 	return PlaneBase{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s PlaneBase) Equal(other PlaneBase) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s PlaneBase) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s PlaneBase) String() string {
 	str, _ := text.Marshal(0xd8bccf6e60a73791, s.Struct)
 	return str
@@ -359,6 +422,13 @@ func NewPlaneBase_List(s *capnp.Segment, sz int32) (PlaneBase_List, error) {
 	return PlaneBase_List{l}, err
 }
 
+// NewRootPlaneBaseListMessage creates a message whose root is a new
+// list of n PlaneBase.  The message's segment is sized to fit the list.
+func NewRootPlaneBaseListMessage(n int32) (*capnp.Message, PlaneBase_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 32, PointerCount: 2}, n)
+	return msg, PlaneBase_List{l}, err
+}
+
 func (s PlaneBase_List) At(i int) PlaneBase { return PlaneBase{s.List.Struct(i)} }
 
 func (s PlaneBase_List) Set(i int, v PlaneBase) error { return s.List.SetStruct(i, v.Struct) }
@@ -372,8 +442,7 @@ func (s PlaneBase_List) String() string {
 type PlaneBase_Promise struct{ *capnp.Pipeline }
 
 func (p PlaneBase_Promise) Struct() (PlaneBase, error) {
-	s, err := p.Pipeline.Struct()
-	return PlaneBase{s}, err
+	return capnp.PipelineStruct[PlaneBase](p.Pipeline)
 }
 
 type B737 struct{ capnp.Struct }
@@ -391,11 +460,28 @@ func NewRootB737(s *capnp.Segment) (B737, error) {
 	return B737{st}, err
 }
 
+func AllocateRootB737(msg *capnp.Message) (B737, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return B737{st}, err
+}
+
 func ReadRootB737(msg *capnp.Message) (B737, error) {
 	root, err := msg.RootPtr()
 	return B737{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s B737) Equal(other B737) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s B737) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s B737) String() string {
 	str, _ := text.Marshal(0xccb3b2e3603826e0, s.Struct)
 	return str
@@ -435,6 +521,13 @@ func NewB737_List(s *capnp.Segment, sz int32) (B737_List, error) {
 	return B737_List{l}, err
 }
 
+// NewRootB737ListMessage creates a message whose root is a new
+// list of n B737.  The message's segment is sized to fit the list.
+func NewRootB737ListMessage(n int32) (*capnp.Message, B737_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, B737_List{l}, err
+}
+
 func (s B737_List) At(i int) B737 { return B737{s.List.Struct(i)} }
 
 func (s B737_List) Set(i int, v B737) error { return s.List.SetStruct(i, v.Struct) }
@@ -448,8 +541,7 @@ func (s B737_List) String() string {
 type B737_Promise struct{ *capnp.Pipeline }
 
 func (p B737_Promise) Struct() (B737, error) {
-	s, err := p.Pipeline.Struct()
-	return B737{s}, err
+	return capnp.PipelineStruct[B737](p.Pipeline)
 }
 
 func (p B737_Promise) Base() PlaneBase_Promise {
@@ -471,11 +563,28 @@ func NewRootA320(s *capnp.Segment) (A320, error) {
 	return A320{st}, err
 }
 
+func AllocateRootA320(msg *capnp.Message) (A320, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return A320{st}, err
+}
+
 func ReadRootA320(msg *capnp.Message) (A320, error) {
 	root, err := msg.RootPtr()
 	return A320{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s A320) Equal(other A320) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s A320) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s A320) String() string {
 	str, _ := text.Marshal(0xd98c608877d9cb8d, s.Struct)
 	return str
@@ -515,6 +624,13 @@ func NewA320_List(s *capnp.Segment, sz int32) (A320_List, error) {
 	return A320_List{l}, err
 }
 
+// NewRootA320ListMessage creates a message whose root is a new
+// list of n A320.  The message's segment is sized to fit the list.
+func NewRootA320ListMessage(n int32) (*capnp.Message, A320_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, A320_List{l}, err
+}
+
 func (s A320_List) At(i int) A320 { return A320{s.List.Struct(i)} }
 
 func (s A320_List) Set(i int, v A320) error { return s.List.SetStruct(i, v.Struct) }
@@ -528,8 +644,7 @@ func (s A320_List) String() string {
 type A320_Promise struct{ *capnp.Pipeline }
 
 func (p A320_Promise) Struct() (A320, error) {
-	s, err := p.Pipeline.Struct()
-	return A320{s}, err
+	return capnp.PipelineStruct[A320](p.Pipeline)
 }
 
 func (p A320_Promise) Base() PlaneBase_Promise {
@@ -551,11 +666,28 @@ func NewRootF16(s *capnp.Segment) (F16, error) {
 	return F16{st}, err
 }
 
+func AllocateRootF16(msg *capnp.Message) (F16, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return F16{st}, err
+}
+
 func ReadRootF16(msg *capnp.Message) (F16, error) {
 	root, err := msg.RootPtr()
 	return F16{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s F16) Equal(other F16) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s F16) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s F16) String() string {
 	str, _ := text.Marshal(0xe1c9eac512335361, s.Struct)
 	return str
@@ -595,6 +727,13 @@ func NewF16_List(s *capnp.Segment, sz int32) (F16_List, error) {
 	return F16_List{l}, err
 }
 
+// NewRootF16ListMessage creates a message whose root is a new
+// list of n F16.  The message's segment is sized to fit the list.
+func NewRootF16ListMessage(n int32) (*capnp.Message, F16_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, F16_List{l}, err
+}
+
 func (s F16_List) At(i int) F16 { return F16{s.List.Struct(i)} }
 
 func (s F16_List) Set(i int, v F16) error { return s.List.SetStruct(i, v.Struct) }
@@ -608,8 +747,7 @@ func (s F16_List) String() string {
 type F16_Promise struct{ *capnp.Pipeline }
 
 func (p F16_Promise) Struct() (F16, error) {
-	s, err := p.Pipeline.Struct()
-	return F16{s}, err
+	return capnp.PipelineStruct[F16](p.Pipeline)
 }
 
 func (p F16_Promise) Base() PlaneBase_Promise {
@@ -631,11 +769,28 @@ func NewRootRegression(s *capnp.Segment) (Regression, error) {
 	return Regression{st}, err
 }
 
+func AllocateRootRegression(msg *capnp.Message) (Regression, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 24, PointerCount: 3})
+	return Regression{st}, err
+}
+
 func ReadRootRegression(msg *capnp.Message) (Regression, error) {
 	root, err := msg.RootPtr()
 	return Regression{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Regression) Equal(other Regression) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Regression) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Regression) String() string {
 	str, _ := text.Marshal(0xb1f0385d845e367f, s.Struct)
 	return str
@@ -749,6 +904,13 @@ func NewRegression_List(s *capnp.Segment, sz int32) (Regression_List, error) {
 	return Regression_List{l}, err
 }
 
+// NewRootRegressionListMessage creates a message whose root is a new
+// list of n Regression.  The message's segment is sized to fit the list.
+func NewRootRegressionListMessage(n int32) (*capnp.Message, Regression_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 24, PointerCount: 3}, n)
+	return msg, Regression_List{l}, err
+}
+
 func (s Regression_List) At(i int) Regression { return Regression{s.List.Struct(i)} }
 
 func (s Regression_List) Set(i int, v Regression) error { return s.List.SetStruct(i, v.Struct) }
@@ -762,8 +924,7 @@ func (s Regression_List) String() string {
 type Regression_Promise struct{ *capnp.Pipeline }
 
 func (p Regression_Promise) Struct() (Regression, error) {
-	s, err := p.Pipeline.Struct()
-	return Regression{s}, err
+	return capnp.PipelineStruct[Regression](p.Pipeline)
 }
 
 func (p Regression_Promise) Base() PlaneBase_Promise {
@@ -809,11 +970,28 @@ func NewRootAircraft(s *capnp.Segment) (Aircraft, error) {
 	return Aircraft{st}, err
 }
 
+func AllocateRootAircraft(msg *capnp.Message) (Aircraft, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return Aircraft{st}, err
+}
+
 func ReadRootAircraft(msg *capnp.Message) (Aircraft, error) {
 	root, err := msg.RootPtr()
 	return Aircraft{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Aircraft) Equal(other Aircraft) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Aircraft) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Aircraft) String() string {
 	str, _ := text.Marshal(0xe54e10aede55c7b1, s.Struct)
 	return str
@@ -822,6 +1000,16 @@ func (s Aircraft) String() string {
 func (s Aircraft) Which() Aircraft_Which {
 	return Aircraft_Which(s.Struct.Uint16(0))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s Aircraft) ResetUnion() error {
+	s.Struct.SetUint16(0, 0)
+	if err := s.Struct.ClearPtr(0); err != nil {
+		return err
+	}
+	return nil
+}
 func (s Aircraft) SetVoid() {
 	s.Struct.SetUint16(0, 0)
 
@@ -829,7 +1017,7 @@ func (s Aircraft) SetVoid() {
 
 func (s Aircraft) B737() (B737, error) {
 	if s.Struct.Uint16(0) != 1 {
-		panic("Which() != b737")
+		capnp.UnionMismatch(s.Struct, "b737")
 	}
 	p, err := s.Struct.Ptr(0)
 	return B737{Struct: p.Struct()}, err
@@ -862,7 +1050,7 @@ func (s Aircraft) NewB737() (B737, error) {
 
 func (s Aircraft) A320() (A320, error) {
 	if s.Struct.Uint16(0) != 2 {
-		panic("Which() != a320")
+		capnp.UnionMismatch(s.Struct, "a320")
 	}
 	p, err := s.Struct.Ptr(0)
 	return A320{Struct: p.Struct()}, err
@@ -895,7 +1083,7 @@ func (s Aircraft) NewA320() (A320, error) {
 
 func (s Aircraft) F16() (F16, error) {
 	if s.Struct.Uint16(0) != 3 {
-		panic("Which() != f16")
+		capnp.UnionMismatch(s.Struct, "f16")
 	}
 	p, err := s.Struct.Ptr(0)
 	return F16{Struct: p.Struct()}, err
@@ -935,6 +1123,13 @@ func NewAircraft_List(s *capnp.Segment, sz int32) (Aircraft_List, error) {
 	return Aircraft_List{l}, err
 }
 
+// NewRootAircraftListMessage creates a message whose root is a new
+// list of n Aircraft.  The message's segment is sized to fit the list.
+func NewRootAircraftListMessage(n int32) (*capnp.Message, Aircraft_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 1}, n)
+	return msg, Aircraft_List{l}, err
+}
+
 func (s Aircraft_List) At(i int) Aircraft { return Aircraft{s.List.Struct(i)} }
 
 func (s Aircraft_List) Set(i int, v Aircraft) error { return s.List.SetStruct(i, v.Struct) }
@@ -948,8 +1143,7 @@ func (s Aircraft_List) String() string {
 type Aircraft_Promise struct{ *capnp.Pipeline }
 
 func (p Aircraft_Promise) Struct() (Aircraft, error) {
-	s, err := p.Pipeline.Struct()
-	return Aircraft{s}, err
+	return capnp.PipelineStruct[Aircraft](p.Pipeline)
 }
 
 func (p Aircraft_Promise) B737() B737_Promise {
@@ -1127,11 +1321,28 @@ func NewRootZ(s *capnp.Segment) (Z, error) {
 	return Z{st}, err
 }
 
+func AllocateRootZ(msg *capnp.Message) (Z, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 24, PointerCount: 1})
+	return Z{st}, err
+}
+
 func ReadRootZ(msg *capnp.Message) (Z, error) {
 	root, err := msg.RootPtr()
 	return Z{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Z) Equal(other Z) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Z) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Z) String() string {
 	str, _ := text.Marshal(0xea26e9973bd6a0d9, s.Struct)
 	return str
@@ -1140,6 +1351,22 @@ func (s Z) String() string {
 func (s Z) Which() Z_Which {
 	return Z_Which(s.Struct.Uint16(0))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s Z) ResetUnion() error {
+	s.Struct.SetUint16(0, 0)
+	s.Struct.SetUint64(8, 0)
+	s.Struct.SetUint32(8, 0)
+	s.Struct.SetUint16(8, 0)
+	s.Struct.SetUint8(8, 0)
+	s.Struct.SetBit(64, false)
+	s.Struct.SetUint64(16, 0)
+	if err := s.Struct.ClearPtr(0); err != nil {
+		return err
+	}
+	return nil
+}
 func (s Z) SetVoid() {
 	s.Struct.SetUint16(0, 0)
 
@@ -1147,7 +1374,7 @@ func (s Z) SetVoid() {
 
 func (s Z) Zz() (Z, error) {
 	if s.Struct.Uint16(0) != 1 {
-		panic("Which() != zz")
+		capnp.UnionMismatch(s.Struct, "zz")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Z{Struct: p.Struct()}, err
@@ -1180,7 +1407,7 @@ func (s Z) NewZz() (Z, error) {
 
 func (s Z) F64() float64 {
 	if s.Struct.Uint16(0) != 2 {
-		panic("Which() != f64")
+		capnp.UnionMismatch(s.Struct, "f64")
 	}
 	return math.Float64frombits(s.Struct.Uint64(8))
 }
@@ -1192,7 +1419,7 @@ func (s Z) SetF64(v float64) {
 
 func (s Z) F32() float32 {
 	if s.Struct.Uint16(0) != 3 {
-		panic("Which() != f32")
+		capnp.UnionMismatch(s.Struct, "f32")
 	}
 	return math.Float32frombits(s.Struct.Uint32(8))
 }
@@ -1204,7 +1431,7 @@ func (s Z) SetF32(v float32) {
 
 func (s Z) I64() int64 {
 	if s.Struct.Uint16(0) != 4 {
-		panic("Which() != i64")
+		capnp.UnionMismatch(s.Struct, "i64")
 	}
 	return int64(s.Struct.Uint64(8))
 }
@@ -1216,7 +1443,7 @@ func (s Z) SetI64(v int64) {
 
 func (s Z) I32() int32 {
 	if s.Struct.Uint16(0) != 5 {
-		panic("Which() != i32")
+		capnp.UnionMismatch(s.Struct, "i32")
 	}
 	return int32(s.Struct.Uint32(8))
 }
@@ -1228,7 +1455,7 @@ func (s Z) SetI32(v int32) {
 
 func (s Z) I16() int16 {
 	if s.Struct.Uint16(0) != 6 {
-		panic("Which() != i16")
+		capnp.UnionMismatch(s.Struct, "i16")
 	}
 	return int16(s.Struct.Uint16(8))
 }
@@ -1240,7 +1467,7 @@ func (s Z) SetI16(v int16) {
 
 func (s Z) I8() int8 {
 	if s.Struct.Uint16(0) != 7 {
-		panic("Which() != i8")
+		capnp.UnionMismatch(s.Struct, "i8")
 	}
 	return int8(s.Struct.Uint8(8))
 }
@@ -1252,7 +1479,7 @@ func (s Z) SetI8(v int8) {
 
 func (s Z) U64() uint64 {
 	if s.Struct.Uint16(0) != 8 {
-		panic("Which() != u64")
+		capnp.UnionMismatch(s.Struct, "u64")
 	}
 	return s.Struct.Uint64(8)
 }
@@ -1264,7 +1491,7 @@ func (s Z) SetU64(v uint64) {
 
 func (s Z) U32() uint32 {
 	if s.Struct.Uint16(0) != 9 {
-		panic("Which() != u32")
+		capnp.UnionMismatch(s.Struct, "u32")
 	}
 	return s.Struct.Uint32(8)
 }
@@ -1276,7 +1503,7 @@ func (s Z) SetU32(v uint32) {
 
 func (s Z) U16() uint16 {
 	if s.Struct.Uint16(0) != 10 {
-		panic("Which() != u16")
+		capnp.UnionMismatch(s.Struct, "u16")
 	}
 	return s.Struct.Uint16(8)
 }
@@ -1288,7 +1515,7 @@ func (s Z) SetU16(v uint16) {
 
 func (s Z) U8() uint8 {
 	if s.Struct.Uint16(0) != 11 {
-		panic("Which() != u8")
+		capnp.UnionMismatch(s.Struct, "u8")
 	}
 	return s.Struct.Uint8(8)
 }
@@ -1300,7 +1527,7 @@ func (s Z) SetU8(v uint8) {
 
 func (s Z) Bool() bool {
 	if s.Struct.Uint16(0) != 12 {
-		panic("Which() != bool")
+		capnp.UnionMismatch(s.Struct, "bool")
 	}
 	return s.Struct.Bit(64)
 }
@@ -1312,7 +1539,7 @@ func (s Z) SetBool(v bool) {
 
 func (s Z) Text() (string, error) {
 	if s.Struct.Uint16(0) != 13 {
-		panic("Which() != text")
+		capnp.UnionMismatch(s.Struct, "text")
 	}
 	p, err := s.Struct.Ptr(0)
 	return p.Text(), err
@@ -1338,7 +1565,7 @@ func (s Z) SetText(v string) error {
 
 func (s Z) Blob() ([]byte, error) {
 	if s.Struct.Uint16(0) != 14 {
-		panic("Which() != blob")
+		capnp.UnionMismatch(s.Struct, "blob")
 	}
 	p, err := s.Struct.Ptr(0)
 	return []byte(p.Data()), err
@@ -1359,7 +1586,7 @@ func (s Z) SetBlob(v []byte) error {
 
 func (s Z) F64vec() (capnp.Float64List, error) {
 	if s.Struct.Uint16(0) != 15 {
-		panic("Which() != f64vec")
+		capnp.UnionMismatch(s.Struct, "f64vec")
 	}
 	p, err := s.Struct.Ptr(0)
 	return capnp.Float64List{List: p.List()}, err
@@ -1392,7 +1619,7 @@ func (s Z) NewF64vec(n int32) (capnp.Float64List, error) {
 
 func (s Z) F32vec() (capnp.Float32List, error) {
 	if s.Struct.Uint16(0) != 16 {
-		panic("Which() != f32vec")
+		capnp.UnionMismatch(s.Struct, "f32vec")
 	}
 	p, err := s.Struct.Ptr(0)
 	return capnp.Float32List{List: p.List()}, err
@@ -1425,7 +1652,7 @@ func (s Z) NewF32vec(n int32) (capnp.Float32List, error) {
 
 func (s Z) I64vec() (capnp.Int64List, error) {
 	if s.Struct.Uint16(0) != 17 {
-		panic("Which() != i64vec")
+		capnp.UnionMismatch(s.Struct, "i64vec")
 	}
 	p, err := s.Struct.Ptr(0)
 	return capnp.Int64List{List: p.List()}, err
@@ -1458,7 +1685,7 @@ func (s Z) NewI64vec(n int32) (capnp.Int64List, error) {
 
 func (s Z) I32vec() (capnp.Int32List, error) {
 	if s.Struct.Uint16(0) != 18 {
-		panic("Which() != i32vec")
+		capnp.UnionMismatch(s.Struct, "i32vec")
 	}
 	p, err := s.Struct.Ptr(0)
 	return capnp.Int32List{List: p.List()}, err
@@ -1491,7 +1718,7 @@ func (s Z) NewI32vec(n int32) (capnp.Int32List, error) {
 
 func (s Z) I16vec() (capnp.Int16List, error) {
 	if s.Struct.Uint16(0) != 19 {
-		panic("Which() != i16vec")
+		capnp.UnionMismatch(s.Struct, "i16vec")
 	}
 	p, err := s.Struct.Ptr(0)
 	return capnp.Int16List{List: p.List()}, err
@@ -1524,7 +1751,7 @@ func (s Z) NewI16vec(n int32) (capnp.Int16List, error) {
 
 func (s Z) I8vec() (capnp.Int8List, error) {
 	if s.Struct.Uint16(0) != 20 {
-		panic("Which() != i8vec")
+		capnp.UnionMismatch(s.Struct, "i8vec")
 	}
 	p, err := s.Struct.Ptr(0)
 	return capnp.Int8List{List: p.List()}, err
@@ -1557,7 +1784,7 @@ func (s Z) NewI8vec(n int32) (capnp.Int8List, error) {
 
 func (s Z) U64vec() (capnp.UInt64List, error) {
 	if s.Struct.Uint16(0) != 21 {
-		panic("Which() != u64vec")
+		capnp.UnionMismatch(s.Struct, "u64vec")
 	}
 	p, err := s.Struct.Ptr(0)
 	return capnp.UInt64List{List: p.List()}, err
@@ -1590,7 +1817,7 @@ func (s Z) NewU64vec(n int32) (capnp.UInt64List, error) {
 
 func (s Z) U32vec() (capnp.UInt32List, error) {
 	if s.Struct.Uint16(0) != 22 {
-		panic("Which() != u32vec")
+		capnp.UnionMismatch(s.Struct, "u32vec")
 	}
 	p, err := s.Struct.Ptr(0)
 	return capnp.UInt32List{List: p.List()}, err
@@ -1623,7 +1850,7 @@ func (s Z) NewU32vec(n int32) (capnp.UInt32List, error) {
 
 func (s Z) U16vec() (capnp.UInt16List, error) {
 	if s.Struct.Uint16(0) != 23 {
-		panic("Which() != u16vec")
+		capnp.UnionMismatch(s.Struct, "u16vec")
 	}
 	p, err := s.Struct.Ptr(0)
 	return capnp.UInt16List{List: p.List()}, err
@@ -1656,7 +1883,7 @@ func (s Z) NewU16vec(n int32) (capnp.UInt16List, error) {
 
 func (s Z) U8vec() (capnp.UInt8List, error) {
 	if s.Struct.Uint16(0) != 24 {
-		panic("Which() != u8vec")
+		capnp.UnionMismatch(s.Struct, "u8vec")
 	}
 	p, err := s.Struct.Ptr(0)
 	return capnp.UInt8List{List: p.List()}, err
@@ -1689,7 +1916,7 @@ func (s Z) NewU8vec(n int32) (capnp.UInt8List, error) {
 
 func (s Z) Boolvec() (capnp.BitList, error) {
 	if s.Struct.Uint16(0) != 39 {
-		panic("Which() != boolvec")
+		capnp.UnionMismatch(s.Struct, "boolvec")
 	}
 	p, err := s.Struct.Ptr(0)
 	return capnp.BitList{List: p.List()}, err
@@ -1722,7 +1949,7 @@ func (s Z) NewBoolvec(n int32) (capnp.BitList, error) {
 
 func (s Z) Datavec() (capnp.DataList, error) {
 	if s.Struct.Uint16(0) != 40 {
-		panic("Which() != datavec")
+		capnp.UnionMismatch(s.Struct, "datavec")
 	}
 	p, err := s.Struct.Ptr(0)
 	return capnp.DataList{List: p.List()}, err
@@ -1755,7 +1982,7 @@ func (s Z) NewDatavec(n int32) (capnp.DataList, error) {
 
 func (s Z) Textvec() (capnp.TextList, error) {
 	if s.Struct.Uint16(0) != 41 {
-		panic("Which() != textvec")
+		capnp.UnionMismatch(s.Struct, "textvec")
 	}
 	p, err := s.Struct.Ptr(0)
 	return capnp.TextList{List: p.List()}, err
@@ -1788,7 +2015,7 @@ func (s Z) NewTextvec(n int32) (capnp.TextList, error) {
 
 func (s Z) Zvec() (Z_List, error) {
 	if s.Struct.Uint16(0) != 25 {
-		panic("Which() != zvec")
+		capnp.UnionMismatch(s.Struct, "zvec")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Z_List{List: p.List()}, err
@@ -1821,7 +2048,7 @@ func (s Z) NewZvec(n int32) (Z_List, error) {
 
 func (s Z) Zvecvec() (capnp.PointerList, error) {
 	if s.Struct.Uint16(0) != 26 {
-		panic("Which() != zvecvec")
+		capnp.UnionMismatch(s.Struct, "zvecvec")
 	}
 	p, err := s.Struct.Ptr(0)
 	return capnp.PointerList{List: p.List()}, err
@@ -1854,7 +2081,7 @@ func (s Z) NewZvecvec(n int32) (capnp.PointerList, error) {
 
 func (s Z) Zdate() (Zdate, error) {
 	if s.Struct.Uint16(0) != 27 {
-		panic("Which() != zdate")
+		capnp.UnionMismatch(s.Struct, "zdate")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Zdate{Struct: p.Struct()}, err
@@ -1887,7 +2114,7 @@ func (s Z) NewZdate() (Zdate, error) {
 
 func (s Z) Zdata() (Zdata, error) {
 	if s.Struct.Uint16(0) != 28 {
-		panic("Which() != zdata")
+		capnp.UnionMismatch(s.Struct, "zdata")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Zdata{Struct: p.Struct()}, err
@@ -1920,7 +2147,7 @@ func (s Z) NewZdata() (Zdata, error) {
 
 func (s Z) Aircraftvec() (Aircraft_List, error) {
 	if s.Struct.Uint16(0) != 29 {
-		panic("Which() != aircraftvec")
+		capnp.UnionMismatch(s.Struct, "aircraftvec")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Aircraft_List{List: p.List()}, err
@@ -1953,7 +2180,7 @@ func (s Z) NewAircraftvec(n int32) (Aircraft_List, error) {
 
 func (s Z) Aircraft() (Aircraft, error) {
 	if s.Struct.Uint16(0) != 30 {
-		panic("Which() != aircraft")
+		capnp.UnionMismatch(s.Struct, "aircraft")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Aircraft{Struct: p.Struct()}, err
@@ -1986,7 +2213,7 @@ func (s Z) NewAircraft() (Aircraft, error) {
 
 func (s Z) Regression() (Regression, error) {
 	if s.Struct.Uint16(0) != 31 {
-		panic("Which() != regression")
+		capnp.UnionMismatch(s.Struct, "regression")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Regression{Struct: p.Struct()}, err
@@ -2019,7 +2246,7 @@ func (s Z) NewRegression() (Regression, error) {
 
 func (s Z) Planebase() (PlaneBase, error) {
 	if s.Struct.Uint16(0) != 32 {
-		panic("Which() != planebase")
+		capnp.UnionMismatch(s.Struct, "planebase")
 	}
 	p, err := s.Struct.Ptr(0)
 	return PlaneBase{Struct: p.Struct()}, err
@@ -2052,7 +2279,7 @@ func (s Z) NewPlanebase() (PlaneBase, error) {
 
 func (s Z) Airport() Airport {
 	if s.Struct.Uint16(0) != 33 {
-		panic("Which() != airport")
+		capnp.UnionMismatch(s.Struct, "airport")
 	}
 	return Airport(s.Struct.Uint16(8))
 }
@@ -2064,7 +2291,7 @@ func (s Z) SetAirport(v Airport) {
 
 func (s Z) B737() (B737, error) {
 	if s.Struct.Uint16(0) != 34 {
-		panic("Which() != b737")
+		capnp.UnionMismatch(s.Struct, "b737")
 	}
 	p, err := s.Struct.Ptr(0)
 	return B737{Struct: p.Struct()}, err
@@ -2097,7 +2324,7 @@ func (s Z) NewB737() (B737, error) {
 
 func (s Z) A320() (A320, error) {
 	if s.Struct.Uint16(0) != 35 {
-		panic("Which() != a320")
+		capnp.UnionMismatch(s.Struct, "a320")
 	}
 	p, err := s.Struct.Ptr(0)
 	return A320{Struct: p.Struct()}, err
@@ -2130,7 +2357,7 @@ func (s Z) NewA320() (A320, error) {
 
 func (s Z) F16() (F16, error) {
 	if s.Struct.Uint16(0) != 36 {
-		panic("Which() != f16")
+		capnp.UnionMismatch(s.Struct, "f16")
 	}
 	p, err := s.Struct.Ptr(0)
 	return F16{Struct: p.Struct()}, err
@@ -2163,7 +2390,7 @@ func (s Z) NewF16() (F16, error) {
 
 func (s Z) Zdatevec() (Zdate_List, error) {
 	if s.Struct.Uint16(0) != 37 {
-		panic("Which() != zdatevec")
+		capnp.UnionMismatch(s.Struct, "zdatevec")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Zdate_List{List: p.List()}, err
@@ -2196,7 +2423,7 @@ func (s Z) NewZdatevec(n int32) (Zdate_List, error) {
 
 func (s Z) Zdatavec() (Zdata_List, error) {
 	if s.Struct.Uint16(0) != 38 {
-		panic("Which() != zdatavec")
+		capnp.UnionMismatch(s.Struct, "zdatavec")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Zdata_List{List: p.List()}, err
@@ -2251,7 +2478,7 @@ func (s Z_grp) SetSecond(v uint64) {
 
 func (s Z) Echo() Echo {
 	if s.Struct.Uint16(0) != 43 {
-		panic("Which() != echo")
+		capnp.UnionMismatch(s.Struct, "echo")
 	}
 	p, _ := s.Struct.Ptr(0)
 	return Echo{Client: p.Interface().Client()}
@@ -2270,14 +2497,14 @@ func (s Z) SetEcho(v Echo) error {
 	if v.Client == nil {
 		return s.Struct.SetPtr(0, capnp.Ptr{})
 	}
-	seg := s.Segment()
+	seg := s.Struct.Segment()
 	in := capnp.NewInterface(seg, seg.Message().AddCap(v.Client))
 	return s.Struct.SetPtr(0, in.ToPtr())
 }
 
 func (s Z) EchoBases() (EchoBases, error) {
 	if s.Struct.Uint16(0) != 44 {
-		panic("Which() != echoBases")
+		capnp.UnionMismatch(s.Struct, "echoBases")
 	}
 	p, err := s.Struct.Ptr(0)
 	return EchoBases{Struct: p.Struct()}, err
@@ -2317,6 +2544,13 @@ func NewZ_List(s *capnp.Segment, sz int32) (Z_List, error) {
 	return Z_List{l}, err
 }
 
+// NewRootZListMessage creates a message whose root is a new
+// list of n Z.  The message's segment is sized to fit the list.
+func NewRootZListMessage(n int32) (*capnp.Message, Z_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 24, PointerCount: 1}, n)
+	return msg, Z_List{l}, err
+}
+
 func (s Z_List) At(i int) Z { return Z{s.List.Struct(i)} }
 
 func (s Z_List) Set(i int, v Z) error { return s.List.SetStruct(i, v.Struct) }
@@ -2330,8 +2564,7 @@ func (s Z_List) String() string {
 type Z_Promise struct{ *capnp.Pipeline }
 
 func (p Z_Promise) Struct() (Z, error) {
-	s, err := p.Pipeline.Struct()
-	return Z{s}, err
+	return capnp.PipelineStruct[Z](p.Pipeline)
 }
 
 func (p Z_Promise) Zz() Z_Promise {
@@ -2376,8 +2609,7 @@ func (p Z_Promise) Grp() Z_grp_Promise { return Z_grp_Promise{p.Pipeline} }
 type Z_grp_Promise struct{ *capnp.Pipeline }
 
 func (p Z_grp_Promise) Struct() (Z_grp, error) {
-	s, err := p.Pipeline.Struct()
-	return Z_grp{s}, err
+	return capnp.PipelineStruct[Z_grp](p.Pipeline)
 }
 
 func (p Z_Promise) Echo() Echo {
@@ -2403,11 +2635,28 @@ func NewRootCounter(s *capnp.Segment) (Counter, error) {
 	return Counter{st}, err
 }
 
+func AllocateRootCounter(msg *capnp.Message) (Counter, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
+	return Counter{st}, err
+}
+
 func ReadRootCounter(msg *capnp.Message) (Counter, error) {
 	root, err := msg.RootPtr()
 	return Counter{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Counter) Equal(other Counter) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Counter) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Counter) String() string {
 	str, _ := text.Marshal(0x8748bc095e10cb5d, s.Struct)
 	return str
@@ -2499,6 +2748,13 @@ func NewCounter_List(s *capnp.Segment, sz int32) (Counter_List, error) {
 	return Counter_List{l}, err
 }
 
+// NewRootCounterListMessage creates a message whose root is a new
+// list of n Counter.  The message's segment is sized to fit the list.
+func NewRootCounterListMessage(n int32) (*capnp.Message, Counter_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 3}, n)
+	return msg, Counter_List{l}, err
+}
+
 func (s Counter_List) At(i int) Counter { return Counter{s.List.Struct(i)} }
 
 func (s Counter_List) Set(i int, v Counter) error { return s.List.SetStruct(i, v.Struct) }
@@ -2512,8 +2768,7 @@ func (s Counter_List) String() string {
 type Counter_Promise struct{ *capnp.Pipeline }
 
 func (p Counter_Promise) Struct() (Counter, error) {
-	s, err := p.Pipeline.Struct()
-	return Counter{s}, err
+	return capnp.PipelineStruct[Counter](p.Pipeline)
 }
 
 type Bag struct{ capnp.Struct }
@@ -2531,11 +2786,28 @@ func NewRootBag(s *capnp.Segment) (Bag, error) {
 	return Bag{st}, err
 }
 
+func AllocateRootBag(msg *capnp.Message) (Bag, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Bag{st}, err
+}
+
 func ReadRootBag(msg *capnp.Message) (Bag, error) {
 	root, err := msg.RootPtr()
 	return Bag{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Bag) Equal(other Bag) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Bag) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Bag) String() string {
 	str, _ := text.Marshal(0xd636fba4f188dabe, s.Struct)
 	return str
@@ -2575,6 +2847,13 @@ func NewBag_List(s *capnp.Segment, sz int32) (Bag_List, error) {
 	return Bag_List{l}, err
 }
 
+// NewRootBagListMessage creates a message whose root is a new
+// list of n Bag.  The message's segment is sized to fit the list.
+func NewRootBagListMessage(n int32) (*capnp.Message, Bag_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, Bag_List{l}, err
+}
+
 func (s Bag_List) At(i int) Bag { return Bag{s.List.Struct(i)} }
 
 func (s Bag_List) Set(i int, v Bag) error { return s.List.SetStruct(i, v.Struct) }
@@ -2588,8 +2867,7 @@ func (s Bag_List) String() string {
 type Bag_Promise struct{ *capnp.Pipeline }
 
 func (p Bag_Promise) Struct() (Bag, error) {
-	s, err := p.Pipeline.Struct()
-	return Bag{s}, err
+	return capnp.PipelineStruct[Bag](p.Pipeline)
 }
 
 func (p Bag_Promise) Counter() Counter_Promise {
@@ -2611,11 +2889,28 @@ func NewRootZserver(s *capnp.Segment) (Zserver, error) {
 	return Zserver{st}, err
 }
 
+func AllocateRootZserver(msg *capnp.Message) (Zserver, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Zserver{st}, err
+}
+
 func ReadRootZserver(msg *capnp.Message) (Zserver, error) {
 	root, err := msg.RootPtr()
 	return Zserver{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Zserver) Equal(other Zserver) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Zserver) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Zserver) String() string {
 	str, _ := text.Marshal(0xcc4411e60ba9c498, s.Struct)
 	return str
@@ -2655,6 +2950,13 @@ func NewZserver_List(s *capnp.Segment, sz int32) (Zserver_List, error) {
 	return Zserver_List{l}, err
 }
 
+// NewRootZserverListMessage creates a message whose root is a new
+// list of n Zserver.  The message's segment is sized to fit the list.
+func NewRootZserverListMessage(n int32) (*capnp.Message, Zserver_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, Zserver_List{l}, err
+}
+
 func (s Zserver_List) At(i int) Zserver { return Zserver{s.List.Struct(i)} }
 
 func (s Zserver_List) Set(i int, v Zserver) error { return s.List.SetStruct(i, v.Struct) }
@@ -2668,8 +2970,7 @@ func (s Zserver_List) String() string {
 type Zserver_Promise struct{ *capnp.Pipeline }
 
 func (p Zserver_Promise) Struct() (Zserver, error) {
-	s, err := p.Pipeline.Struct()
-	return Zserver{s}, err
+	return capnp.PipelineStruct[Zserver](p.Pipeline)
 }
 
 type Zjob struct{ capnp.Struct }
@@ -2687,11 +2988,28 @@ func NewRootZjob(s *capnp.Segment) (Zjob, error) {
 	return Zjob{st}, err
 }
 
+func AllocateRootZjob(msg *capnp.Message) (Zjob, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return Zjob{st}, err
+}
+
 func ReadRootZjob(msg *capnp.Message) (Zjob, error) {
 	root, err := msg.RootPtr()
 	return Zjob{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Zjob) Equal(other Zjob) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Zjob) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Zjob) String() string {
 	str, _ := text.Marshal(0xddd1416669fb7613, s.Struct)
 	return str
@@ -2750,6 +3068,13 @@ func NewZjob_List(s *capnp.Segment, sz int32) (Zjob_List, error) {
 	return Zjob_List{l}, err
 }
 
+// NewRootZjobListMessage creates a message whose root is a new
+// list of n Zjob.  The message's segment is sized to fit the list.
+func NewRootZjobListMessage(n int32) (*capnp.Message, Zjob_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 2}, n)
+	return msg, Zjob_List{l}, err
+}
+
 func (s Zjob_List) At(i int) Zjob { return Zjob{s.List.Struct(i)} }
 
 func (s Zjob_List) Set(i int, v Zjob) error { return s.List.SetStruct(i, v.Struct) }
@@ -2763,8 +3088,7 @@ func (s Zjob_List) String() string {
 type Zjob_Promise struct{ *capnp.Pipeline }
 
 func (p Zjob_Promise) Struct() (Zjob, error) {
-	s, err := p.Pipeline.Struct()
-	return Zjob{s}, err
+	return capnp.PipelineStruct[Zjob](p.Pipeline)
 }
 
 type VerEmpty struct{ capnp.Struct }
@@ -2782,11 +3106,28 @@ func NewRootVerEmpty(s *capnp.Segment) (VerEmpty, error) {
 	return VerEmpty{st}, err
 }
 
+func AllocateRootVerEmpty(msg *capnp.Message) (VerEmpty, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return VerEmpty{st}, err
+}
+
 func ReadRootVerEmpty(msg *capnp.Message) (VerEmpty, error) {
 	root, err := msg.RootPtr()
 	return VerEmpty{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s VerEmpty) Equal(other VerEmpty) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s VerEmpty) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s VerEmpty) String() string {
 	str, _ := text.Marshal(0x93c99951eacc72ff, s.Struct)
 	return str
@@ -2801,6 +3142,13 @@ func NewVerEmpty_List(s *capnp.Segment, sz int32) (VerEmpty_List, error) {
 	return VerEmpty_List{l}, err
 }
 
+// NewRootVerEmptyListMessage creates a message whose root is a new
+// list of n VerEmpty.  The message's segment is sized to fit the list.
+func NewRootVerEmptyListMessage(n int32) (*capnp.Message, VerEmpty_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 0}, n)
+	return msg, VerEmpty_List{l}, err
+}
+
 func (s VerEmpty_List) At(i int) VerEmpty { return VerEmpty{s.List.Struct(i)} }
 
 func (s VerEmpty_List) Set(i int, v VerEmpty) error { return s.List.SetStruct(i, v.Struct) }
@@ -2814,8 +3162,7 @@ func (s VerEmpty_List) String() string {
 type VerEmpty_Promise struct{ *capnp.Pipeline }
 
 func (p VerEmpty_Promise) Struct() (VerEmpty, error) {
-	s, err := p.Pipeline.Struct()
-	return VerEmpty{s}, err
+	return capnp.PipelineStruct[VerEmpty](p.Pipeline)
 }
 
 type VerOneData struct{ capnp.Struct }
@@ -2833,11 +3180,28 @@ func NewRootVerOneData(s *capnp.Segment) (VerOneData, error) {
 	return VerOneData{st}, err
 }
 
+func AllocateRootVerOneData(msg *capnp.Message) (VerOneData, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return VerOneData{st}, err
+}
+
 func ReadRootVerOneData(msg *capnp.Message) (VerOneData, error) {
 	root, err := msg.RootPtr()
 	return VerOneData{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s VerOneData) Equal(other VerOneData) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s VerOneData) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s VerOneData) String() string {
 	str, _ := text.Marshal(0xfca3742893be4cde, s.Struct)
 	return str
@@ -2860,6 +3224,13 @@ func NewVerOneData_List(s *capnp.Segment, sz int32) (VerOneData_List, error) {
 	return VerOneData_List{l}, err
 }
 
+// NewRootVerOneDataListMessage creates a message whose root is a new
+// list of n VerOneData.  The message's segment is sized to fit the list.
+func NewRootVerOneDataListMessage(n int32) (*capnp.Message, VerOneData_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 0}, n)
+	return msg, VerOneData_List{l}, err
+}
+
 func (s VerOneData_List) At(i int) VerOneData { return VerOneData{s.List.Struct(i)} }
 
 func (s VerOneData_List) Set(i int, v VerOneData) error { return s.List.SetStruct(i, v.Struct) }
@@ -2873,8 +3244,7 @@ func (s VerOneData_List) String() string {
 type VerOneData_Promise struct{ *capnp.Pipeline }
 
 func (p VerOneData_Promise) Struct() (VerOneData, error) {
-	s, err := p.Pipeline.Struct()
-	return VerOneData{s}, err
+	return capnp.PipelineStruct[VerOneData](p.Pipeline)
 }
 
 type VerTwoData struct{ capnp.Struct }
@@ -2892,11 +3262,28 @@ func NewRootVerTwoData(s *capnp.Segment) (VerTwoData, error) {
 	return VerTwoData{st}, err
 }
 
+func AllocateRootVerTwoData(msg *capnp.Message) (VerTwoData, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return VerTwoData{st}, err
+}
+
 func ReadRootVerTwoData(msg *capnp.Message) (VerTwoData, error) {
 	root, err := msg.RootPtr()
 	return VerTwoData{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s VerTwoData) Equal(other VerTwoData) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s VerTwoData) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s VerTwoData) String() string {
 	str, _ := text.Marshal(0xf705dc45c94766fd, s.Struct)
 	return str
@@ -2927,6 +3314,13 @@ func NewVerTwoData_List(s *capnp.Segment, sz int32) (VerTwoData_List, error) {
 	return VerTwoData_List{l}, err
 }
 
+// NewRootVerTwoDataListMessage creates a message whose root is a new
+// list of n VerTwoData.  The message's segment is sized to fit the list.
+func NewRootVerTwoDataListMessage(n int32) (*capnp.Message, VerTwoData_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 16, PointerCount: 0}, n)
+	return msg, VerTwoData_List{l}, err
+}
+
 func (s VerTwoData_List) At(i int) VerTwoData { return VerTwoData{s.List.Struct(i)} }
 
 func (s VerTwoData_List) Set(i int, v VerTwoData) error { return s.List.SetStruct(i, v.Struct) }
@@ -2940,8 +3334,7 @@ func (s VerTwoData_List) String() string {
 type VerTwoData_Promise struct{ *capnp.Pipeline }
 
 func (p VerTwoData_Promise) Struct() (VerTwoData, error) {
-	s, err := p.Pipeline.Struct()
-	return VerTwoData{s}, err
+	return capnp.PipelineStruct[VerTwoData](p.Pipeline)
 }
 
 type VerOnePtr struct{ capnp.Struct }
@@ -2959,11 +3352,28 @@ func NewRootVerOnePtr(s *capnp.Segment) (VerOnePtr, error) {
 	return VerOnePtr{st}, err
 }
 
+func AllocateRootVerOnePtr(msg *capnp.Message) (VerOnePtr, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return VerOnePtr{st}, err
+}
+
 func ReadRootVerOnePtr(msg *capnp.Message) (VerOnePtr, error) {
 	root, err := msg.RootPtr()
 	return VerOnePtr{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s VerOnePtr) Equal(other VerOnePtr) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s VerOnePtr) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s VerOnePtr) String() string {
 	str, _ := text.Marshal(0x94bf7df83408218d, s.Struct)
 	return str
@@ -3003,6 +3413,13 @@ func NewVerOnePtr_List(s *capnp.Segment, sz int32) (VerOnePtr_List, error) {
 	return VerOnePtr_List{l}, err
 }
 
+// NewRootVerOnePtrListMessage creates a message whose root is a new
+// list of n VerOnePtr.  The message's segment is sized to fit the list.
+func NewRootVerOnePtrListMessage(n int32) (*capnp.Message, VerOnePtr_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, VerOnePtr_List{l}, err
+}
+
 func (s VerOnePtr_List) At(i int) VerOnePtr { return VerOnePtr{s.List.Struct(i)} }
 
 func (s VerOnePtr_List) Set(i int, v VerOnePtr) error { return s.List.SetStruct(i, v.Struct) }
@@ -3016,8 +3433,7 @@ func (s VerOnePtr_List) String() string {
 type VerOnePtr_Promise struct{ *capnp.Pipeline }
 
 func (p VerOnePtr_Promise) Struct() (VerOnePtr, error) {
-	s, err := p.Pipeline.Struct()
-	return VerOnePtr{s}, err
+	return capnp.PipelineStruct[VerOnePtr](p.Pipeline)
 }
 
 func (p VerOnePtr_Promise) Ptr() VerOneData_Promise {
@@ -3039,11 +3455,28 @@ func NewRootVerTwoPtr(s *capnp.Segment) (VerTwoPtr, error) {
 	return VerTwoPtr{st}, err
 }
 
+func AllocateRootVerTwoPtr(msg *capnp.Message) (VerTwoPtr, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return VerTwoPtr{st}, err
+}
+
 func ReadRootVerTwoPtr(msg *capnp.Message) (VerTwoPtr, error) {
 	root, err := msg.RootPtr()
 	return VerTwoPtr{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s VerTwoPtr) Equal(other VerTwoPtr) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s VerTwoPtr) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s VerTwoPtr) String() string {
 	str, _ := text.Marshal(0xc95babe3bd394d2d, s.Struct)
 	return str
@@ -3108,6 +3541,13 @@ func NewVerTwoPtr_List(s *capnp.Segment, sz int32) (VerTwoPtr_List, error) {
 	return VerTwoPtr_List{l}, err
 }
 
+// NewRootVerTwoPtrListMessage creates a message whose root is a new
+// list of n VerTwoPtr.  The message's segment is sized to fit the list.
+func NewRootVerTwoPtrListMessage(n int32) (*capnp.Message, VerTwoPtr_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 2}, n)
+	return msg, VerTwoPtr_List{l}, err
+}
+
 func (s VerTwoPtr_List) At(i int) VerTwoPtr { return VerTwoPtr{s.List.Struct(i)} }
 
 func (s VerTwoPtr_List) Set(i int, v VerTwoPtr) error { return s.List.SetStruct(i, v.Struct) }
@@ -3121,8 +3561,7 @@ func (s VerTwoPtr_List) String() string {
 type VerTwoPtr_Promise struct{ *capnp.Pipeline }
 
 func (p VerTwoPtr_Promise) Struct() (VerTwoPtr, error) {
-	s, err := p.Pipeline.Struct()
-	return VerTwoPtr{s}, err
+	return capnp.PipelineStruct[VerTwoPtr](p.Pipeline)
 }
 
 func (p VerTwoPtr_Promise) Ptr1() VerOneData_Promise {
@@ -3148,11 +3587,28 @@ func NewRootVerTwoDataTwoPtr(s *capnp.Segment) (VerTwoDataTwoPtr, error) {
 	return VerTwoDataTwoPtr{st}, err
 }
 
+func AllocateRootVerTwoDataTwoPtr(msg *capnp.Message) (VerTwoDataTwoPtr, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 16, PointerCount: 2})
+	return VerTwoDataTwoPtr{st}, err
+}
+
 func ReadRootVerTwoDataTwoPtr(msg *capnp.Message) (VerTwoDataTwoPtr, error) {
 	root, err := msg.RootPtr()
 	return VerTwoDataTwoPtr{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s VerTwoDataTwoPtr) Equal(other VerTwoDataTwoPtr) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s VerTwoDataTwoPtr) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s VerTwoDataTwoPtr) String() string {
 	str, _ := text.Marshal(0xb61ee2ecff34ca73, s.Struct)
 	return str
@@ -3233,6 +3689,13 @@ func NewVerTwoDataTwoPtr_List(s *capnp.Segment, sz int32) (VerTwoDataTwoPtr_List
 	return VerTwoDataTwoPtr_List{l}, err
 }
 
+// NewRootVerTwoDataTwoPtrListMessage creates a message whose root is a new
+// list of n VerTwoDataTwoPtr.  The message's segment is sized to fit the list.
+func NewRootVerTwoDataTwoPtrListMessage(n int32) (*capnp.Message, VerTwoDataTwoPtr_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 16, PointerCount: 2}, n)
+	return msg, VerTwoDataTwoPtr_List{l}, err
+}
+
 func (s VerTwoDataTwoPtr_List) At(i int) VerTwoDataTwoPtr { return VerTwoDataTwoPtr{s.List.Struct(i)} }
 
 func (s VerTwoDataTwoPtr_List) Set(i int, v VerTwoDataTwoPtr) error {
@@ -3248,8 +3711,7 @@ func (s VerTwoDataTwoPtr_List) String() string {
 type VerTwoDataTwoPtr_Promise struct{ *capnp.Pipeline }
 
 func (p VerTwoDataTwoPtr_Promise) Struct() (VerTwoDataTwoPtr, error) {
-	s, err := p.Pipeline.Struct()
-	return VerTwoDataTwoPtr{s}, err
+	return capnp.PipelineStruct[VerTwoDataTwoPtr](p.Pipeline)
 }
 
 func (p VerTwoDataTwoPtr_Promise) Ptr1() VerOneData_Promise {
@@ -3275,11 +3737,28 @@ func NewRootHoldsVerEmptyList(s *capnp.Segment) (HoldsVerEmptyList, error) {
 	return HoldsVerEmptyList{st}, err
 }
 
+func AllocateRootHoldsVerEmptyList(msg *capnp.Message) (HoldsVerEmptyList, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return HoldsVerEmptyList{st}, err
+}
+
 func ReadRootHoldsVerEmptyList(msg *capnp.Message) (HoldsVerEmptyList, error) {
 	root, err := msg.RootPtr()
 	return HoldsVerEmptyList{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s HoldsVerEmptyList) Equal(other HoldsVerEmptyList) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s HoldsVerEmptyList) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s HoldsVerEmptyList) String() string {
 	str, _ := text.Marshal(0xde9ed43cfaa83093, s.Struct)
 	return str
@@ -3319,6 +3798,13 @@ func NewHoldsVerEmptyList_List(s *capnp.Segment, sz int32) (HoldsVerEmptyList_Li
 	return HoldsVerEmptyList_List{l}, err
 }
 
+// NewRootHoldsVerEmptyListListMessage creates a message whose root is a new
+// list of n HoldsVerEmptyList.  The message's segment is sized to fit the list.
+func NewRootHoldsVerEmptyListListMessage(n int32) (*capnp.Message, HoldsVerEmptyList_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, HoldsVerEmptyList_List{l}, err
+}
+
 func (s HoldsVerEmptyList_List) At(i int) HoldsVerEmptyList {
 	return HoldsVerEmptyList{s.List.Struct(i)}
 }
@@ -3336,8 +3822,7 @@ func (s HoldsVerEmptyList_List) String() string {
 type HoldsVerEmptyList_Promise struct{ *capnp.Pipeline }
 
 func (p HoldsVerEmptyList_Promise) Struct() (HoldsVerEmptyList, error) {
-	s, err := p.Pipeline.Struct()
-	return HoldsVerEmptyList{s}, err
+	return capnp.PipelineStruct[HoldsVerEmptyList](p.Pipeline)
 }
 
 type HoldsVerOneDataList struct{ capnp.Struct }
@@ -3355,11 +3840,28 @@ func NewRootHoldsVerOneDataList(s *capnp.Segment) (HoldsVerOneDataList, error) {
 	return HoldsVerOneDataList{st}, err
 }
 
+func AllocateRootHoldsVerOneDataList(msg *capnp.Message) (HoldsVerOneDataList, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return HoldsVerOneDataList{st}, err
+}
+
 func ReadRootHoldsVerOneDataList(msg *capnp.Message) (HoldsVerOneDataList, error) {
 	root, err := msg.RootPtr()
 	return HoldsVerOneDataList{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s HoldsVerOneDataList) Equal(other HoldsVerOneDataList) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s HoldsVerOneDataList) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s HoldsVerOneDataList) String() string {
 	str, _ := text.Marshal(0xabd055422a4d7df1, s.Struct)
 	return str
@@ -3399,6 +3901,13 @@ func NewHoldsVerOneDataList_List(s *capnp.Segment, sz int32) (HoldsVerOneDataLis
 	return HoldsVerOneDataList_List{l}, err
 }
 
+// NewRootHoldsVerOneDataListListMessage creates a message whose root is a new
+// list of n HoldsVerOneDataList.  The message's segment is sized to fit the list.
+func NewRootHoldsVerOneDataListListMessage(n int32) (*capnp.Message, HoldsVerOneDataList_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, HoldsVerOneDataList_List{l}, err
+}
+
 func (s HoldsVerOneDataList_List) At(i int) HoldsVerOneDataList {
 	return HoldsVerOneDataList{s.List.Struct(i)}
 }
@@ -3416,8 +3925,7 @@ func (s HoldsVerOneDataList_List) String() string {
 type HoldsVerOneDataList_Promise struct{ *capnp.Pipeline }
 
 func (p HoldsVerOneDataList_Promise) Struct() (HoldsVerOneDataList, error) {
-	s, err := p.Pipeline.Struct()
-	return HoldsVerOneDataList{s}, err
+	return capnp.PipelineStruct[HoldsVerOneDataList](p.Pipeline)
 }
 
 type HoldsVerTwoDataList struct{ capnp.Struct }
@@ -3435,11 +3943,28 @@ func NewRootHoldsVerTwoDataList(s *capnp.Segment) (HoldsVerTwoDataList, error) {
 	return HoldsVerTwoDataList{st}, err
 }
 
+func AllocateRootHoldsVerTwoDataList(msg *capnp.Message) (HoldsVerTwoDataList, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return HoldsVerTwoDataList{st}, err
+}
+
 func ReadRootHoldsVerTwoDataList(msg *capnp.Message) (HoldsVerTwoDataList, error) {
 	root, err := msg.RootPtr()
 	return HoldsVerTwoDataList{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s HoldsVerTwoDataList) Equal(other HoldsVerTwoDataList) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s HoldsVerTwoDataList) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s HoldsVerTwoDataList) String() string {
 	str, _ := text.Marshal(0xcbdc765fd5dff7ba, s.Struct)
 	return str
@@ -3479,6 +4004,13 @@ func NewHoldsVerTwoDataList_List(s *capnp.Segment, sz int32) (HoldsVerTwoDataLis
 	return HoldsVerTwoDataList_List{l}, err
 }
 
+// NewRootHoldsVerTwoDataListListMessage creates a message whose root is a new
+// list of n HoldsVerTwoDataList.  The message's segment is sized to fit the list.
+func NewRootHoldsVerTwoDataListListMessage(n int32) (*capnp.Message, HoldsVerTwoDataList_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, HoldsVerTwoDataList_List{l}, err
+}
+
 func (s HoldsVerTwoDataList_List) At(i int) HoldsVerTwoDataList {
 	return HoldsVerTwoDataList{s.List.Struct(i)}
 }
@@ -3496,8 +4028,7 @@ func (s HoldsVerTwoDataList_List) String() string {
 type HoldsVerTwoDataList_Promise struct{ *capnp.Pipeline }
 
 func (p HoldsVerTwoDataList_Promise) Struct() (HoldsVerTwoDataList, error) {
-	s, err := p.Pipeline.Struct()
-	return HoldsVerTwoDataList{s}, err
+	return capnp.PipelineStruct[HoldsVerTwoDataList](p.Pipeline)
 }
 
 type HoldsVerOnePtrList struct{ capnp.Struct }
@@ -3515,11 +4046,28 @@ func NewRootHoldsVerOnePtrList(s *capnp.Segment) (HoldsVerOnePtrList, error) {
 	return HoldsVerOnePtrList{st}, err
 }
 
+func AllocateRootHoldsVerOnePtrList(msg *capnp.Message) (HoldsVerOnePtrList, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return HoldsVerOnePtrList{st}, err
+}
+
 func ReadRootHoldsVerOnePtrList(msg *capnp.Message) (HoldsVerOnePtrList, error) {
 	root, err := msg.RootPtr()
 	return HoldsVerOnePtrList{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s HoldsVerOnePtrList) Equal(other HoldsVerOnePtrList) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s HoldsVerOnePtrList) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s HoldsVerOnePtrList) String() string {
 	str, _ := text.Marshal(0xe508a29c83a059f8, s.Struct)
 	return str
@@ -3559,6 +4107,13 @@ func NewHoldsVerOnePtrList_List(s *capnp.Segment, sz int32) (HoldsVerOnePtrList_
 	return HoldsVerOnePtrList_List{l}, err
 }
 
+// NewRootHoldsVerOnePtrListListMessage creates a message whose root is a new
+// list of n HoldsVerOnePtrList.  The message's segment is sized to fit the list.
+func NewRootHoldsVerOnePtrListListMessage(n int32) (*capnp.Message, HoldsVerOnePtrList_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, HoldsVerOnePtrList_List{l}, err
+}
+
 func (s HoldsVerOnePtrList_List) At(i int) HoldsVerOnePtrList {
 	return HoldsVerOnePtrList{s.List.Struct(i)}
 }
@@ -3576,8 +4131,7 @@ func (s HoldsVerOnePtrList_List) String() string {
 type HoldsVerOnePtrList_Promise struct{ *capnp.Pipeline }
 
 func (p HoldsVerOnePtrList_Promise) Struct() (HoldsVerOnePtrList, error) {
-	s, err := p.Pipeline.Struct()
-	return HoldsVerOnePtrList{s}, err
+	return capnp.PipelineStruct[HoldsVerOnePtrList](p.Pipeline)
 }
 
 type HoldsVerTwoPtrList struct{ capnp.Struct }
@@ -3595,11 +4149,28 @@ func NewRootHoldsVerTwoPtrList(s *capnp.Segment) (HoldsVerTwoPtrList, error) {
 	return HoldsVerTwoPtrList{st}, err
 }
 
+func AllocateRootHoldsVerTwoPtrList(msg *capnp.Message) (HoldsVerTwoPtrList, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return HoldsVerTwoPtrList{st}, err
+}
+
 func ReadRootHoldsVerTwoPtrList(msg *capnp.Message) (HoldsVerTwoPtrList, error) {
 	root, err := msg.RootPtr()
 	return HoldsVerTwoPtrList{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s HoldsVerTwoPtrList) Equal(other HoldsVerTwoPtrList) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s HoldsVerTwoPtrList) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s HoldsVerTwoPtrList) String() string {
 	str, _ := text.Marshal(0xcf9beaca1cc180c8, s.Struct)
 	return str
@@ -3639,6 +4210,13 @@ func NewHoldsVerTwoPtrList_List(s *capnp.Segment, sz int32) (HoldsVerTwoPtrList_
 	return HoldsVerTwoPtrList_List{l}, err
 }
 
+// NewRootHoldsVerTwoPtrListListMessage creates a message whose root is a new
+// list of n HoldsVerTwoPtrList.  The message's segment is sized to fit the list.
+func NewRootHoldsVerTwoPtrListListMessage(n int32) (*capnp.Message, HoldsVerTwoPtrList_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, HoldsVerTwoPtrList_List{l}, err
+}
+
 func (s HoldsVerTwoPtrList_List) At(i int) HoldsVerTwoPtrList {
 	return HoldsVerTwoPtrList{s.List.Struct(i)}
 }
@@ -3656,8 +4234,7 @@ func (s HoldsVerTwoPtrList_List) String() string {
 type HoldsVerTwoPtrList_Promise struct{ *capnp.Pipeline }
 
 func (p HoldsVerTwoPtrList_Promise) Struct() (HoldsVerTwoPtrList, error) {
-	s, err := p.Pipeline.Struct()
-	return HoldsVerTwoPtrList{s}, err
+	return capnp.PipelineStruct[HoldsVerTwoPtrList](p.Pipeline)
 }
 
 type HoldsVerTwoTwoList struct{ capnp.Struct }
@@ -3675,11 +4252,28 @@ func NewRootHoldsVerTwoTwoList(s *capnp.Segment) (HoldsVerTwoTwoList, error) {
 	return HoldsVerTwoTwoList{st}, err
 }
 
+func AllocateRootHoldsVerTwoTwoList(msg *capnp.Message) (HoldsVerTwoTwoList, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return HoldsVerTwoTwoList{st}, err
+}
+
 func ReadRootHoldsVerTwoTwoList(msg *capnp.Message) (HoldsVerTwoTwoList, error) {
 	root, err := msg.RootPtr()
 	return HoldsVerTwoTwoList{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s HoldsVerTwoTwoList) Equal(other HoldsVerTwoTwoList) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s HoldsVerTwoTwoList) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s HoldsVerTwoTwoList) String() string {
 	str, _ := text.Marshal(0x95befe3f14606e6b, s.Struct)
 	return str
@@ -3719,6 +4313,13 @@ func NewHoldsVerTwoTwoList_List(s *capnp.Segment, sz int32) (HoldsVerTwoTwoList_
 	return HoldsVerTwoTwoList_List{l}, err
 }
 
+// NewRootHoldsVerTwoTwoListListMessage creates a message whose root is a new
+// list of n HoldsVerTwoTwoList.  The message's segment is sized to fit the list.
+func NewRootHoldsVerTwoTwoListListMessage(n int32) (*capnp.Message, HoldsVerTwoTwoList_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, HoldsVerTwoTwoList_List{l}, err
+}
+
 func (s HoldsVerTwoTwoList_List) At(i int) HoldsVerTwoTwoList {
 	return HoldsVerTwoTwoList{s.List.Struct(i)}
 }
@@ -3736,8 +4337,7 @@ func (s HoldsVerTwoTwoList_List) String() string {
 type HoldsVerTwoTwoList_Promise struct{ *capnp.Pipeline }
 
 func (p HoldsVerTwoTwoList_Promise) Struct() (HoldsVerTwoTwoList, error) {
-	s, err := p.Pipeline.Struct()
-	return HoldsVerTwoTwoList{s}, err
+	return capnp.PipelineStruct[HoldsVerTwoTwoList](p.Pipeline)
 }
 
 type HoldsVerTwoTwoPlus struct{ capnp.Struct }
@@ -3755,11 +4355,28 @@ func NewRootHoldsVerTwoTwoPlus(s *capnp.Segment) (HoldsVerTwoTwoPlus, error) {
 	return HoldsVerTwoTwoPlus{st}, err
 }
 
+func AllocateRootHoldsVerTwoTwoPlus(msg *capnp.Message) (HoldsVerTwoTwoPlus, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return HoldsVerTwoTwoPlus{st}, err
+}
+
 func ReadRootHoldsVerTwoTwoPlus(msg *capnp.Message) (HoldsVerTwoTwoPlus, error) {
 	root, err := msg.RootPtr()
 	return HoldsVerTwoTwoPlus{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s HoldsVerTwoTwoPlus) Equal(other HoldsVerTwoTwoPlus) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s HoldsVerTwoTwoPlus) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s HoldsVerTwoTwoPlus) String() string {
 	str, _ := text.Marshal(0x87c33f2330feb3d8, s.Struct)
 	return str
@@ -3799,6 +4416,13 @@ func NewHoldsVerTwoTwoPlus_List(s *capnp.Segment, sz int32) (HoldsVerTwoTwoPlus_
 	return HoldsVerTwoTwoPlus_List{l}, err
 }
 
+// NewRootHoldsVerTwoTwoPlusListMessage creates a message whose root is a new
+// list of n HoldsVerTwoTwoPlus.  The message's segment is sized to fit the list.
+func NewRootHoldsVerTwoTwoPlusListMessage(n int32) (*capnp.Message, HoldsVerTwoTwoPlus_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, HoldsVerTwoTwoPlus_List{l}, err
+}
+
 func (s HoldsVerTwoTwoPlus_List) At(i int) HoldsVerTwoTwoPlus {
 	return HoldsVerTwoTwoPlus{s.List.Struct(i)}
 }
@@ -3816,8 +4440,7 @@ func (s HoldsVerTwoTwoPlus_List) String() string {
 type HoldsVerTwoTwoPlus_Promise struct{ *capnp.Pipeline }
 
 func (p HoldsVerTwoTwoPlus_Promise) Struct() (HoldsVerTwoTwoPlus, error) {
-	s, err := p.Pipeline.Struct()
-	return HoldsVerTwoTwoPlus{s}, err
+	return capnp.PipelineStruct[HoldsVerTwoTwoPlus](p.Pipeline)
 }
 
 type VerTwoTwoPlus struct{ capnp.Struct }
@@ -3835,11 +4458,28 @@ func NewRootVerTwoTwoPlus(s *capnp.Segment) (VerTwoTwoPlus, error) {
 	return VerTwoTwoPlus{st}, err
 }
 
+func AllocateRootVerTwoTwoPlus(msg *capnp.Message) (VerTwoTwoPlus, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 24, PointerCount: 3})
+	return VerTwoTwoPlus{st}, err
+}
+
 func ReadRootVerTwoTwoPlus(msg *capnp.Message) (VerTwoTwoPlus, error) {
 	root, err := msg.RootPtr()
 	return VerTwoTwoPlus{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s VerTwoTwoPlus) Equal(other VerTwoTwoPlus) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s VerTwoTwoPlus) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s VerTwoTwoPlus) String() string {
 	str, _ := text.Marshal(0xce44aee2d9e25049, s.Struct)
 	return str
@@ -3953,6 +4593,13 @@ func NewVerTwoTwoPlus_List(s *capnp.Segment, sz int32) (VerTwoTwoPlus_List, erro
 	return VerTwoTwoPlus_List{l}, err
 }
 
+// NewRootVerTwoTwoPlusListMessage creates a message whose root is a new
+// list of n VerTwoTwoPlus.  The message's segment is sized to fit the list.
+func NewRootVerTwoTwoPlusListMessage(n int32) (*capnp.Message, VerTwoTwoPlus_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 24, PointerCount: 3}, n)
+	return msg, VerTwoTwoPlus_List{l}, err
+}
+
 func (s VerTwoTwoPlus_List) At(i int) VerTwoTwoPlus { return VerTwoTwoPlus{s.List.Struct(i)} }
 
 func (s VerTwoTwoPlus_List) Set(i int, v VerTwoTwoPlus) error { return s.List.SetStruct(i, v.Struct) }
@@ -3966,8 +4613,7 @@ func (s VerTwoTwoPlus_List) String() string {
 type VerTwoTwoPlus_Promise struct{ *capnp.Pipeline }
 
 func (p VerTwoTwoPlus_Promise) Struct() (VerTwoTwoPlus, error) {
-	s, err := p.Pipeline.Struct()
-	return VerTwoTwoPlus{s}, err
+	return capnp.PipelineStruct[VerTwoTwoPlus](p.Pipeline)
 }
 
 func (p VerTwoTwoPlus_Promise) Ptr1() VerTwoDataTwoPtr_Promise {
@@ -3993,11 +4639,28 @@ func NewRootHoldsText(s *capnp.Segment) (HoldsText, error) {
 	return HoldsText{st}, err
 }
 
+func AllocateRootHoldsText(msg *capnp.Message) (HoldsText, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return HoldsText{st}, err
+}
+
 func ReadRootHoldsText(msg *capnp.Message) (HoldsText, error) {
 	root, err := msg.RootPtr()
 	return HoldsText{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s HoldsText) Equal(other HoldsText) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s HoldsText) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s HoldsText) String() string {
 	str, _ := text.Marshal(0xe5817f849ff906dc, s.Struct)
 	return str
@@ -4081,6 +4744,13 @@ func NewHoldsText_List(s *capnp.Segment, sz int32) (HoldsText_List, error) {
 	return HoldsText_List{l}, err
 }
 
+// NewRootHoldsTextListMessage creates a message whose root is a new
+// list of n HoldsText.  The message's segment is sized to fit the list.
+func NewRootHoldsTextListMessage(n int32) (*capnp.Message, HoldsText_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 3}, n)
+	return msg, HoldsText_List{l}, err
+}
+
 func (s HoldsText_List) At(i int) HoldsText { return HoldsText{s.List.Struct(i)} }
 
 func (s HoldsText_List) Set(i int, v HoldsText) error { return s.List.SetStruct(i, v.Struct) }
@@ -4094,8 +4764,7 @@ func (s HoldsText_List) String() string {
 type HoldsText_Promise struct{ *capnp.Pipeline }
 
 func (p HoldsText_Promise) Struct() (HoldsText, error) {
-	s, err := p.Pipeline.Struct()
-	return HoldsText{s}, err
+	return capnp.PipelineStruct[HoldsText](p.Pipeline)
 }
 
 type WrapEmpty struct{ capnp.Struct }
@@ -4113,11 +4782,28 @@ func NewRootWrapEmpty(s *capnp.Segment) (WrapEmpty, error) {
 	return WrapEmpty{st}, err
 }
 
+func AllocateRootWrapEmpty(msg *capnp.Message) (WrapEmpty, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return WrapEmpty{st}, err
+}
+
 func ReadRootWrapEmpty(msg *capnp.Message) (WrapEmpty, error) {
 	root, err := msg.RootPtr()
 	return WrapEmpty{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s WrapEmpty) Equal(other WrapEmpty) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s WrapEmpty) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s WrapEmpty) String() string {
 	str, _ := text.Marshal(0x9ab599979b02ac59, s.Struct)
 	return str
@@ -4157,6 +4843,13 @@ func NewWrapEmpty_List(s *capnp.Segment, sz int32) (WrapEmpty_List, error) {
 	return WrapEmpty_List{l}, err
 }
 
+// NewRootWrapEmptyListMessage creates a message whose root is a new
+// list of n WrapEmpty.  The message's segment is sized to fit the list.
+func NewRootWrapEmptyListMessage(n int32) (*capnp.Message, WrapEmpty_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, WrapEmpty_List{l}, err
+}
+
 func (s WrapEmpty_List) At(i int) WrapEmpty { return WrapEmpty{s.List.Struct(i)} }
 
 func (s WrapEmpty_List) Set(i int, v WrapEmpty) error { return s.List.SetStruct(i, v.Struct) }
@@ -4170,8 +4863,7 @@ func (s WrapEmpty_List) String() string {
 type WrapEmpty_Promise struct{ *capnp.Pipeline }
 
 func (p WrapEmpty_Promise) Struct() (WrapEmpty, error) {
-	s, err := p.Pipeline.Struct()
-	return WrapEmpty{s}, err
+	return capnp.PipelineStruct[WrapEmpty](p.Pipeline)
 }
 
 func (p WrapEmpty_Promise) MightNotBeReallyEmpty() VerEmpty_Promise {
@@ -4193,11 +4885,28 @@ func NewRootWrap2x2(s *capnp.Segment) (Wrap2x2, error) {
 	return Wrap2x2{st}, err
 }
 
+func AllocateRootWrap2x2(msg *capnp.Message) (Wrap2x2, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Wrap2x2{st}, err
+}
+
 func ReadRootWrap2x2(msg *capnp.Message) (Wrap2x2, error) {
 	root, err := msg.RootPtr()
 	return Wrap2x2{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Wrap2x2) Equal(other Wrap2x2) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Wrap2x2) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Wrap2x2) String() string {
 	str, _ := text.Marshal(0xe1a2d1d51107bead, s.Struct)
 	return str
@@ -4237,6 +4946,13 @@ func NewWrap2x2_List(s *capnp.Segment, sz int32) (Wrap2x2_List, error) {
 	return Wrap2x2_List{l}, err
 }
 
+// NewRootWrap2x2ListMessage creates a message whose root is a new
+// list of n Wrap2x2.  The message's segment is sized to fit the list.
+func NewRootWrap2x2ListMessage(n int32) (*capnp.Message, Wrap2x2_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, Wrap2x2_List{l}, err
+}
+
 func (s Wrap2x2_List) At(i int) Wrap2x2 { return Wrap2x2{s.List.Struct(i)} }
 
 func (s Wrap2x2_List) Set(i int, v Wrap2x2) error { return s.List.SetStruct(i, v.Struct) }
@@ -4250,8 +4966,7 @@ func (s Wrap2x2_List) String() string {
 type Wrap2x2_Promise struct{ *capnp.Pipeline }
 
 func (p Wrap2x2_Promise) Struct() (Wrap2x2, error) {
-	s, err := p.Pipeline.Struct()
-	return Wrap2x2{s}, err
+	return capnp.PipelineStruct[Wrap2x2](p.Pipeline)
 }
 
 func (p Wrap2x2_Promise) MightNotBeReallyEmpty() VerTwoDataTwoPtr_Promise {
@@ -4273,11 +4988,28 @@ func NewRootWrap2x2plus(s *capnp.Segment) (Wrap2x2plus, error) {
 	return Wrap2x2plus{st}, err
 }
 
+func AllocateRootWrap2x2plus(msg *capnp.Message) (Wrap2x2plus, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Wrap2x2plus{st}, err
+}
+
 func ReadRootWrap2x2plus(msg *capnp.Message) (Wrap2x2plus, error) {
 	root, err := msg.RootPtr()
 	return Wrap2x2plus{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Wrap2x2plus) Equal(other Wrap2x2plus) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Wrap2x2plus) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Wrap2x2plus) String() string {
 	str, _ := text.Marshal(0xe684eb3aef1a6859, s.Struct)
 	return str
@@ -4317,6 +5049,13 @@ func NewWrap2x2plus_List(s *capnp.Segment, sz int32) (Wrap2x2plus_List, error) {
 	return Wrap2x2plus_List{l}, err
 }
 
+// NewRootWrap2x2plusListMessage creates a message whose root is a new
+// list of n Wrap2x2plus.  The message's segment is sized to fit the list.
+func NewRootWrap2x2plusListMessage(n int32) (*capnp.Message, Wrap2x2plus_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, Wrap2x2plus_List{l}, err
+}
+
 func (s Wrap2x2plus_List) At(i int) Wrap2x2plus { return Wrap2x2plus{s.List.Struct(i)} }
 
 func (s Wrap2x2plus_List) Set(i int, v Wrap2x2plus) error { return s.List.SetStruct(i, v.Struct) }
@@ -4330,8 +5069,7 @@ func (s Wrap2x2plus_List) String() string {
 type Wrap2x2plus_Promise struct{ *capnp.Pipeline }
 
 func (p Wrap2x2plus_Promise) Struct() (Wrap2x2plus, error) {
-	s, err := p.Pipeline.Struct()
-	return Wrap2x2plus{s}, err
+	return capnp.PipelineStruct[Wrap2x2plus](p.Pipeline)
 }
 
 func (p Wrap2x2plus_Promise) MightNotBeReallyEmpty() VerTwoTwoPlus_Promise {
@@ -4371,11 +5109,28 @@ func NewRootVoidUnion(s *capnp.Segment) (VoidUnion, error) {
 	return VoidUnion{st}, err
 }
 
+func AllocateRootVoidUnion(msg *capnp.Message) (VoidUnion, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return VoidUnion{st}, err
+}
+
 func ReadRootVoidUnion(msg *capnp.Message) (VoidUnion, error) {
 	root, err := msg.RootPtr()
 	return VoidUnion{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s VoidUnion) Equal(other VoidUnion) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s VoidUnion) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s VoidUnion) String() string {
 	str, _ := text.Marshal(0x8821cdb23640783a, s.Struct)
 	return str
@@ -4384,6 +5139,13 @@ func (s VoidUnion) String() string {
 func (s VoidUnion) Which() VoidUnion_Which {
 	return VoidUnion_Which(s.Struct.Uint16(0))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s VoidUnion) ResetUnion() error {
+	s.Struct.SetUint16(0, 0)
+	return nil
+}
 func (s VoidUnion) SetA() {
 	s.Struct.SetUint16(0, 0)
 
@@ -4403,6 +5165,13 @@ func NewVoidUnion_List(s *capnp.Segment, sz int32) (VoidUnion_List, error) {
 	return VoidUnion_List{l}, err
 }
 
+// NewRootVoidUnionListMessage creates a message whose root is a new
+// list of n VoidUnion.  The message's segment is sized to fit the list.
+func NewRootVoidUnionListMessage(n int32) (*capnp.Message, VoidUnion_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 0}, n)
+	return msg, VoidUnion_List{l}, err
+}
+
 func (s VoidUnion_List) At(i int) VoidUnion { return VoidUnion{s.List.Struct(i)} }
 
 func (s VoidUnion_List) Set(i int, v VoidUnion) error { return s.List.SetStruct(i, v.Struct) }
@@ -4416,8 +5185,7 @@ func (s VoidUnion_List) String() string {
 type VoidUnion_Promise struct{ *capnp.Pipeline }
 
 func (p VoidUnion_Promise) Struct() (VoidUnion, error) {
-	s, err := p.Pipeline.Struct()
-	return VoidUnion{s}, err
+	return capnp.PipelineStruct[VoidUnion](p.Pipeline)
 }
 
 type Nester1Capn struct{ capnp.Struct }
@@ -4435,11 +5203,28 @@ func NewRootNester1Capn(s *capnp.Segment) (Nester1Capn, error) {
 	return Nester1Capn{st}, err
 }
 
+func AllocateRootNester1Capn(msg *capnp.Message) (Nester1Capn, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Nester1Capn{st}, err
+}
+
 func ReadRootNester1Capn(msg *capnp.Message) (Nester1Capn, error) {
 	root, err := msg.RootPtr()
 	return Nester1Capn{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Nester1Capn) Equal(other Nester1Capn) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Nester1Capn) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Nester1Capn) String() string {
 	str, _ := text.Marshal(0xf14fad09425d081c, s.Struct)
 	return str
@@ -4479,6 +5264,13 @@ func NewNester1Capn_List(s *capnp.Segment, sz int32) (Nester1Capn_List, error) {
 	return Nester1Capn_List{l}, err
 }
 
+// NewRootNester1CapnListMessage creates a message whose root is a new
+// list of n Nester1Capn.  The message's segment is sized to fit the list.
+func NewRootNester1CapnListMessage(n int32) (*capnp.Message, Nester1Capn_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, Nester1Capn_List{l}, err
+}
+
 func (s Nester1Capn_List) At(i int) Nester1Capn { return Nester1Capn{s.List.Struct(i)} }
 
 func (s Nester1Capn_List) Set(i int, v Nester1Capn) error { return s.List.SetStruct(i, v.Struct) }
@@ -4492,8 +5284,7 @@ func (s Nester1Capn_List) String() string {
 type Nester1Capn_Promise struct{ *capnp.Pipeline }
 
 func (p Nester1Capn_Promise) Struct() (Nester1Capn, error) {
-	s, err := p.Pipeline.Struct()
-	return Nester1Capn{s}, err
+	return capnp.PipelineStruct[Nester1Capn](p.Pipeline)
 }
 
 type RWTestCapn struct{ capnp.Struct }
@@ -4511,11 +5302,28 @@ func NewRootRWTestCapn(s *capnp.Segment) (RWTestCapn, error) {
 	return RWTestCapn{st}, err
 }
 
+func AllocateRootRWTestCapn(msg *capnp.Message) (RWTestCapn, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return RWTestCapn{st}, err
+}
+
 func ReadRootRWTestCapn(msg *capnp.Message) (RWTestCapn, error) {
 	root, err := msg.RootPtr()
 	return RWTestCapn{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s RWTestCapn) Equal(other RWTestCapn) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s RWTestCapn) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s RWTestCapn) String() string {
 	str, _ := text.Marshal(0xf7ff4414476c186a, s.Struct)
 	return str
@@ -4555,6 +5363,13 @@ func NewRWTestCapn_List(s *capnp.Segment, sz int32) (RWTestCapn_List, error) {
 	return RWTestCapn_List{l}, err
 }
 
+// NewRootRWTestCapnListMessage creates a message whose root is a new
+// list of n RWTestCapn.  The message's segment is sized to fit the list.
+func NewRootRWTestCapnListMessage(n int32) (*capnp.Message, RWTestCapn_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, RWTestCapn_List{l}, err
+}
+
 func (s RWTestCapn_List) At(i int) RWTestCapn { return RWTestCapn{s.List.Struct(i)} }
 
 func (s RWTestCapn_List) Set(i int, v RWTestCapn) error { return s.List.SetStruct(i, v.Struct) }
@@ -4568,8 +5383,7 @@ func (s RWTestCapn_List) String() string {
 type RWTestCapn_Promise struct{ *capnp.Pipeline }
 
 func (p RWTestCapn_Promise) Struct() (RWTestCapn, error) {
-	s, err := p.Pipeline.Struct()
-	return RWTestCapn{s}, err
+	return capnp.PipelineStruct[RWTestCapn](p.Pipeline)
 }
 
 type ListStructCapn struct{ capnp.Struct }
@@ -4587,11 +5401,28 @@ func NewRootListStructCapn(s *capnp.Segment) (ListStructCapn, error) {
 	return ListStructCapn{st}, err
 }
 
+func AllocateRootListStructCapn(msg *capnp.Message) (ListStructCapn, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return ListStructCapn{st}, err
+}
+
 func ReadRootListStructCapn(msg *capnp.Message) (ListStructCapn, error) {
 	root, err := msg.RootPtr()
 	return ListStructCapn{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s ListStructCapn) Equal(other ListStructCapn) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s ListStructCapn) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s ListStructCapn) String() string {
 	str, _ := text.Marshal(0xb1ac056ed7647011, s.Struct)
 	return str
@@ -4631,6 +5462,13 @@ func NewListStructCapn_List(s *capnp.Segment, sz int32) (ListStructCapn_List, er
 	return ListStructCapn_List{l}, err
 }
 
+// NewRootListStructCapnListMessage creates a message whose root is a new
+// list of n ListStructCapn.  The message's segment is sized to fit the list.
+func NewRootListStructCapnListMessage(n int32) (*capnp.Message, ListStructCapn_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, ListStructCapn_List{l}, err
+}
+
 func (s ListStructCapn_List) At(i int) ListStructCapn { return ListStructCapn{s.List.Struct(i)} }
 
 func (s ListStructCapn_List) Set(i int, v ListStructCapn) error { return s.List.SetStruct(i, v.Struct) }
@@ -4644,8 +5482,7 @@ func (s ListStructCapn_List) String() string {
 type ListStructCapn_Promise struct{ *capnp.Pipeline }
 
 func (p ListStructCapn_Promise) Struct() (ListStructCapn, error) {
-	s, err := p.Pipeline.Struct()
-	return ListStructCapn{s}, err
+	return capnp.PipelineStruct[ListStructCapn](p.Pipeline)
 }
 
 type Echo struct{ Client capnp.Client }
@@ -4680,7 +5517,15 @@ type Echo_Server interface {
 
 func Echo_ServerToClient(s Echo_Server) Echo {
 	c, _ := s.(server.Closer)
-	return Echo{Client: server.New(Echo_Methods(nil, s), c)}
+	return Echo{Client: server.NewTable(Echo_Table(s), c)}
+}
+
+// Echo_Table returns the methods of s grouped by interface, for server.NewTable.
+func Echo_Table(s Echo_Server) server.Table {
+	methods := Echo_Methods(nil, s)
+	return server.Table{
+		{ID: 0x8e5322c1e9282534, Methods: methods[0:1:1]},
+	}
 }
 
 func Echo_Methods(methods []server.Method, s Echo_Server) []server.Method {
@@ -4728,11 +5573,28 @@ func NewRootEcho_echo_Params(s *capnp.Segment) (Echo_echo_Params, error) {
 	return Echo_echo_Params{st}, err
 }
 
+func AllocateRootEcho_echo_Params(msg *capnp.Message) (Echo_echo_Params, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Echo_echo_Params{st}, err
+}
+
 func ReadRootEcho_echo_Params(msg *capnp.Message) (Echo_echo_Params, error) {
 	root, err := msg.RootPtr()
 	return Echo_echo_Params{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Echo_echo_Params) Equal(other Echo_echo_Params) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Echo_echo_Params) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Echo_echo_Params) String() string {
 	str, _ := text.Marshal(0x8a165fb4d71bf3a2, s.Struct)
 	return str
@@ -4766,6 +5628,13 @@ func NewEcho_echo_Params_List(s *capnp.Segment, sz int32) (Echo_echo_Params_List
 	return Echo_echo_Params_List{l}, err
 }
 
+// NewRootEcho_echo_ParamsListMessage creates a message whose root is a new
+// list of n Echo_echo_Params.  The message's segment is sized to fit the list.
+func NewRootEcho_echo_ParamsListMessage(n int32) (*capnp.Message, Echo_echo_Params_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, Echo_echo_Params_List{l}, err
+}
+
 func (s Echo_echo_Params_List) At(i int) Echo_echo_Params { return Echo_echo_Params{s.List.Struct(i)} }
 
 func (s Echo_echo_Params_List) Set(i int, v Echo_echo_Params) error {
@@ -4781,8 +5650,7 @@ func (s Echo_echo_Params_List) String() string {
 type Echo_echo_Params_Promise struct{ *capnp.Pipeline }
 
 func (p Echo_echo_Params_Promise) Struct() (Echo_echo_Params, error) {
-	s, err := p.Pipeline.Struct()
-	return Echo_echo_Params{s}, err
+	return capnp.PipelineStruct[Echo_echo_Params](p.Pipeline)
 }
 
 type Echo_echo_Results struct{ capnp.Struct }
@@ -4800,11 +5668,28 @@ func NewRootEcho_echo_Results(s *capnp.Segment) (Echo_echo_Results, error) {
 	return Echo_echo_Results{st}, err
 }
 
+func AllocateRootEcho_echo_Results(msg *capnp.Message) (Echo_echo_Results, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Echo_echo_Results{st}, err
+}
+
 func ReadRootEcho_echo_Results(msg *capnp.Message) (Echo_echo_Results, error) {
 	root, err := msg.RootPtr()
 	return Echo_echo_Results{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Echo_echo_Results) Equal(other Echo_echo_Results) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Echo_echo_Results) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Echo_echo_Results) String() string {
 	str, _ := text.Marshal(0x9b37d729b9dd7b9d, s.Struct)
 	return str
@@ -4838,6 +5723,13 @@ func NewEcho_echo_Results_List(s *capnp.Segment, sz int32) (Echo_echo_Results_Li
 	return Echo_echo_Results_List{l}, err
 }
 
+// NewRootEcho_echo_ResultsListMessage creates a message whose root is a new
+// list of n Echo_echo_Results.  The message's segment is sized to fit the list.
+func NewRootEcho_echo_ResultsListMessage(n int32) (*capnp.Message, Echo_echo_Results_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, Echo_echo_Results_List{l}, err
+}
+
 func (s Echo_echo_Results_List) At(i int) Echo_echo_Results {
 	return Echo_echo_Results{s.List.Struct(i)}
 }
@@ -4855,8 +5747,7 @@ func (s Echo_echo_Results_List) String() string {
 type Echo_echo_Results_Promise struct{ *capnp.Pipeline }
 
 func (p Echo_echo_Results_Promise) Struct() (Echo_echo_Results, error) {
-	s, err := p.Pipeline.Struct()
-	return Echo_echo_Results{s}, err
+	return capnp.PipelineStruct[Echo_echo_Results](p.Pipeline)
 }
 
 type Hoth struct{ capnp.Struct }
@@ -4874,11 +5765,28 @@ func NewRootHoth(s *capnp.Segment) (Hoth, error) {
 	return Hoth{st}, err
 }
 
+func AllocateRootHoth(msg *capnp.Message) (Hoth, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Hoth{st}, err
+}
+
 func ReadRootHoth(msg *capnp.Message) (Hoth, error) {
 	root, err := msg.RootPtr()
 	return Hoth{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Hoth) Equal(other Hoth) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Hoth) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Hoth) String() string {
 	str, _ := text.Marshal(0xad87da456fb0ebb9, s.Struct)
 	return str
@@ -4918,6 +5826,13 @@ func NewHoth_List(s *capnp.Segment, sz int32) (Hoth_List, error) {
 	return Hoth_List{l}, err
 }
 
+// NewRootHothListMessage creates a message whose root is a new
+// list of n Hoth.  The message's segment is sized to fit the list.
+func NewRootHothListMessage(n int32) (*capnp.Message, Hoth_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, Hoth_List{l}, err
+}
+
 func (s Hoth_List) At(i int) Hoth { return Hoth{s.List.Struct(i)} }
 
 func (s Hoth_List) Set(i int, v Hoth) error { return s.List.SetStruct(i, v.Struct) }
@@ -4931,8 +5846,7 @@ func (s Hoth_List) String() string {
 type Hoth_Promise struct{ *capnp.Pipeline }
 
 func (p Hoth_Promise) Struct() (Hoth, error) {
-	s, err := p.Pipeline.Struct()
-	return Hoth{s}, err
+	return capnp.PipelineStruct[Hoth](p.Pipeline)
 }
 
 func (p Hoth_Promise) Base() EchoBase_Promise {
@@ -4954,11 +5868,28 @@ func NewRootEchoBase(s *capnp.Segment) (EchoBase, error) {
 	return EchoBase{st}, err
 }
 
+func AllocateRootEchoBase(msg *capnp.Message) (EchoBase, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return EchoBase{st}, err
+}
+
 func ReadRootEchoBase(msg *capnp.Message) (EchoBase, error) {
 	root, err := msg.RootPtr()
 	return EchoBase{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s EchoBase) Equal(other EchoBase) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s EchoBase) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s EchoBase) String() string {
 	str, _ := text.Marshal(0xa8bf13fef2674866, s.Struct)
 	return str
@@ -4978,7 +5909,7 @@ func (s EchoBase) SetEcho(v Echo) error {
 	if v.Client == nil {
 		return s.Struct.SetPtr(0, capnp.Ptr{})
 	}
-	seg := s.Segment()
+	seg := s.Struct.Segment()
 	in := capnp.NewInterface(seg, seg.Message().AddCap(v.Client))
 	return s.Struct.SetPtr(0, in.ToPtr())
 }
@@ -4992,6 +5923,13 @@ func NewEchoBase_List(s *capnp.Segment, sz int32) (EchoBase_List, error) {
 	return EchoBase_List{l}, err
 }
 
+// NewRootEchoBaseListMessage creates a message whose root is a new
+// list of n EchoBase.  The message's segment is sized to fit the list.
+func NewRootEchoBaseListMessage(n int32) (*capnp.Message, EchoBase_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, EchoBase_List{l}, err
+}
+
 func (s EchoBase_List) At(i int) EchoBase { return EchoBase{s.List.Struct(i)} }
 
 func (s EchoBase_List) Set(i int, v EchoBase) error { return s.List.SetStruct(i, v.Struct) }
@@ -5005,8 +5943,7 @@ func (s EchoBase_List) String() string {
 type EchoBase_Promise struct{ *capnp.Pipeline }
 
 func (p EchoBase_Promise) Struct() (EchoBase, error) {
-	s, err := p.Pipeline.Struct()
-	return EchoBase{s}, err
+	return capnp.PipelineStruct[EchoBase](p.Pipeline)
 }
 
 func (p EchoBase_Promise) Echo() Echo {
@@ -5028,11 +5965,28 @@ func NewRootEchoBases(s *capnp.Segment) (EchoBases, error) {
 	return EchoBases{st}, err
 }
 
+func AllocateRootEchoBases(msg *capnp.Message) (EchoBases, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return EchoBases{st}, err
+}
+
 func ReadRootEchoBases(msg *capnp.Message) (EchoBases, error) {
 	root, err := msg.RootPtr()
 	return EchoBases{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s EchoBases) Equal(other EchoBases) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s EchoBases) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s EchoBases) String() string {
 	str, _ := text.Marshal(0xc02e9d191c6ac0bc, s.Struct)
 	return str
@@ -5072,6 +6026,13 @@ func NewEchoBases_List(s *capnp.Segment, sz int32) (EchoBases_List, error) {
 	return EchoBases_List{l}, err
 }
 
+// NewRootEchoBasesListMessage creates a message whose root is a new
+// list of n EchoBases.  The message's segment is sized to fit the list.
+func NewRootEchoBasesListMessage(n int32) (*capnp.Message, EchoBases_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, EchoBases_List{l}, err
+}
+
 func (s EchoBases_List) At(i int) EchoBases { return EchoBases{s.List.Struct(i)} }
 
 func (s EchoBases_List) Set(i int, v EchoBases) error { return s.List.SetStruct(i, v.Struct) }
@@ -5085,8 +6046,7 @@ func (s EchoBases_List) String() string {
 type EchoBases_Promise struct{ *capnp.Pipeline }
 
 func (p EchoBases_Promise) Struct() (EchoBases, error) {
-	s, err := p.Pipeline.Struct()
-	return EchoBases{s}, err
+	return capnp.PipelineStruct[EchoBases](p.Pipeline)
 }
 
 type StackingRoot struct{ capnp.Struct }
@@ -5104,11 +6064,28 @@ func NewRootStackingRoot(s *capnp.Segment) (StackingRoot, error) {
 	return StackingRoot{st}, err
 }
 
+func AllocateRootStackingRoot(msg *capnp.Message) (StackingRoot, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return StackingRoot{st}, err
+}
+
 func ReadRootStackingRoot(msg *capnp.Message) (StackingRoot, error) {
 	root, err := msg.RootPtr()
 	return StackingRoot{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s StackingRoot) Equal(other StackingRoot) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s StackingRoot) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s StackingRoot) String() string {
 	str, _ := text.Marshal(0x8fae7b41c61fc890, s.Struct)
 	return str
@@ -5177,6 +6154,13 @@ func NewStackingRoot_List(s *capnp.Segment, sz int32) (StackingRoot_List, error)
 	return StackingRoot_List{l}, err
 }
 
+// NewRootStackingRootListMessage creates a message whose root is a new
+// list of n StackingRoot.  The message's segment is sized to fit the list.
+func NewRootStackingRootListMessage(n int32) (*capnp.Message, StackingRoot_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 2}, n)
+	return msg, StackingRoot_List{l}, err
+}
+
 func (s StackingRoot_List) At(i int) StackingRoot { return StackingRoot{s.List.Struct(i)} }
 
 func (s StackingRoot_List) Set(i int, v StackingRoot) error { return s.List.SetStruct(i, v.Struct) }
@@ -5190,8 +6174,7 @@ func (s StackingRoot_List) String() string {
 type StackingRoot_Promise struct{ *capnp.Pipeline }
 
 func (p StackingRoot_Promise) Struct() (StackingRoot, error) {
-	s, err := p.Pipeline.Struct()
-	return StackingRoot{s}, err
+	return capnp.PipelineStruct[StackingRoot](p.Pipeline)
 }
 
 func (p StackingRoot_Promise) A() StackingA_Promise {
@@ -5217,11 +6200,28 @@ func NewRootStackingA(s *capnp.Segment) (StackingA, error) {
 	return StackingA{st}, err
 }
 
+func AllocateRootStackingA(msg *capnp.Message) (StackingA, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return StackingA{st}, err
+}
+
 func ReadRootStackingA(msg *capnp.Message) (StackingA, error) {
 	root, err := msg.RootPtr()
 	return StackingA{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s StackingA) Equal(other StackingA) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s StackingA) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s StackingA) String() string {
 	str, _ := text.Marshal(0x9d3032ff86043b75, s.Struct)
 	return str
@@ -5269,6 +6269,13 @@ func NewStackingA_List(s *capnp.Segment, sz int32) (StackingA_List, error) {
 	return StackingA_List{l}, err
 }
 
+// NewRootStackingAListMessage creates a message whose root is a new
+// list of n StackingA.  The message's segment is sized to fit the list.
+func NewRootStackingAListMessage(n int32) (*capnp.Message, StackingA_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 1}, n)
+	return msg, StackingA_List{l}, err
+}
+
 func (s StackingA_List) At(i int) StackingA { return StackingA{s.List.Struct(i)} }
 
 func (s StackingA_List) Set(i int, v StackingA) error { return s.List.SetStruct(i, v.Struct) }
@@ -5282,8 +6289,7 @@ func (s StackingA_List) String() string {
 type StackingA_Promise struct{ *capnp.Pipeline }
 
 func (p StackingA_Promise) Struct() (StackingA, error) {
-	s, err := p.Pipeline.Struct()
-	return StackingA{s}, err
+	return capnp.PipelineStruct[StackingA](p.Pipeline)
 }
 
 func (p StackingA_Promise) B() StackingB_Promise {
@@ -5305,11 +6311,28 @@ func NewRootStackingB(s *capnp.Segment) (StackingB, error) {
 	return StackingB{st}, err
 }
 
+func AllocateRootStackingB(msg *capnp.Message) (StackingB, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return StackingB{st}, err
+}
+
 func ReadRootStackingB(msg *capnp.Message) (StackingB, error) {
 	root, err := msg.RootPtr()
 	return StackingB{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s StackingB) Equal(other StackingB) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s StackingB) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s StackingB) String() string {
 	str, _ := text.Marshal(0x85257b30d6edf8c5, s.Struct)
 	return str
@@ -5332,6 +6355,13 @@ func NewStackingB_List(s *capnp.Segment, sz int32) (StackingB_List, error) {
 	return StackingB_List{l}, err
 }
 
+// NewRootStackingBListMessage creates a message whose root is a new
+// list of n StackingB.  The message's segment is sized to fit the list.
+func NewRootStackingBListMessage(n int32) (*capnp.Message, StackingB_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 0}, n)
+	return msg, StackingB_List{l}, err
+}
+
 func (s StackingB_List) At(i int) StackingB { return StackingB{s.List.Struct(i)} }
 
 func (s StackingB_List) Set(i int, v StackingB) error { return s.List.SetStruct(i, v.Struct) }
@@ -5345,8 +6375,7 @@ func (s StackingB_List) String() string {
 type StackingB_Promise struct{ *capnp.Pipeline }
 
 func (p StackingB_Promise) Struct() (StackingB, error) {
-	s, err := p.Pipeline.Struct()
-	return StackingB{s}, err
+	return capnp.PipelineStruct[StackingB](p.Pipeline)
 }
 
 type CallSequence struct{ Client capnp.Client }
@@ -5381,7 +6410,15 @@ type CallSequence_Server interface {
 
 func CallSequence_ServerToClient(s CallSequence_Server) CallSequence {
 	c, _ := s.(server.Closer)
-	return CallSequence{Client: server.New(CallSequence_Methods(nil, s), c)}
+	return CallSequence{Client: server.NewTable(CallSequence_Table(s), c)}
+}
+
+// CallSequence_Table returns the methods of s grouped by interface, for server.NewTable.
+func CallSequence_Table(s CallSequence_Server) server.Table {
+	methods := CallSequence_Methods(nil, s)
+	return server.Table{
+		{ID: 0xabaedf5f7817c820, Methods: methods[0:1:1]},
+	}
 }
 
 func CallSequence_Methods(methods []server.Method, s CallSequence_Server) []server.Method {
@@ -5429,11 +6466,28 @@ func NewRootCallSequence_getNumber_Params(s *capnp.Segment) (CallSequence_getNum
 	return CallSequence_getNumber_Params{st}, err
 }
 
+func AllocateRootCallSequence_getNumber_Params(msg *capnp.Message) (CallSequence_getNumber_Params, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CallSequence_getNumber_Params{st}, err
+}
+
 func ReadRootCallSequence_getNumber_Params(msg *capnp.Message) (CallSequence_getNumber_Params, error) {
 	root, err := msg.RootPtr()
 	return CallSequence_getNumber_Params{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s CallSequence_getNumber_Params) Equal(other CallSequence_getNumber_Params) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s CallSequence_getNumber_Params) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s CallSequence_getNumber_Params) String() string {
 	str, _ := text.Marshal(0xf58782f48a121998, s.Struct)
 	return str
@@ -5448,6 +6502,13 @@ func NewCallSequence_getNumber_Params_List(s *capnp.Segment, sz int32) (CallSequ
 	return CallSequence_getNumber_Params_List{l}, err
 }
 
+// NewRootCallSequence_getNumber_ParamsListMessage creates a message whose root is a new
+// list of n CallSequence_getNumber_Params.  The message's segment is sized to fit the list.
+func NewRootCallSequence_getNumber_ParamsListMessage(n int32) (*capnp.Message, CallSequence_getNumber_Params_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 0}, n)
+	return msg, CallSequence_getNumber_Params_List{l}, err
+}
+
 func (s CallSequence_getNumber_Params_List) At(i int) CallSequence_getNumber_Params {
 	return CallSequence_getNumber_Params{s.List.Struct(i)}
 }
@@ -5465,8 +6526,7 @@ func (s CallSequence_getNumber_Params_List) String() string {
 type CallSequence_getNumber_Params_Promise struct{ *capnp.Pipeline }
 
 func (p CallSequence_getNumber_Params_Promise) Struct() (CallSequence_getNumber_Params, error) {
-	s, err := p.Pipeline.Struct()
-	return CallSequence_getNumber_Params{s}, err
+	return capnp.PipelineStruct[CallSequence_getNumber_Params](p.Pipeline)
 }
 
 type CallSequence_getNumber_Results struct{ capnp.Struct }
@@ -5484,11 +6544,28 @@ func NewRootCallSequence_getNumber_Results(s *capnp.Segment) (CallSequence_getNu
 	return CallSequence_getNumber_Results{st}, err
 }
 
+func AllocateRootCallSequence_getNumber_Results(msg *capnp.Message) (CallSequence_getNumber_Results, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return CallSequence_getNumber_Results{st}, err
+}
+
 func ReadRootCallSequence_getNumber_Results(msg *capnp.Message) (CallSequence_getNumber_Results, error) {
 	root, err := msg.RootPtr()
 	return CallSequence_getNumber_Results{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s CallSequence_getNumber_Results) Equal(other CallSequence_getNumber_Results) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s CallSequence_getNumber_Results) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s CallSequence_getNumber_Results) String() string {
 	str, _ := text.Marshal(0xa465f9502fd11e97, s.Struct)
 	return str
@@ -5511,6 +6588,13 @@ func NewCallSequence_getNumber_Results_List(s *capnp.Segment, sz int32) (CallSeq
 	return CallSequence_getNumber_Results_List{l}, err
 }
 
+// NewRootCallSequence_getNumber_ResultsListMessage creates a message whose root is a new
+// list of n CallSequence_getNumber_Results.  The message's segment is sized to fit the list.
+func NewRootCallSequence_getNumber_ResultsListMessage(n int32) (*capnp.Message, CallSequence_getNumber_Results_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 0}, n)
+	return msg, CallSequence_getNumber_Results_List{l}, err
+}
+
 func (s CallSequence_getNumber_Results_List) At(i int) CallSequence_getNumber_Results {
 	return CallSequence_getNumber_Results{s.List.Struct(i)}
 }
@@ -5528,8 +6612,7 @@ func (s CallSequence_getNumber_Results_List) String() string {
 type CallSequence_getNumber_Results_Promise struct{ *capnp.Pipeline }
 
 func (p CallSequence_getNumber_Results_Promise) Struct() (CallSequence_getNumber_Results, error) {
-	s, err := p.Pipeline.Struct()
-	return CallSequence_getNumber_Results{s}, err
+	return capnp.PipelineStruct[CallSequence_getNumber_Results](p.Pipeline)
 }
 
 type Defaults struct{ capnp.Struct }
@@ -5547,11 +6630,28 @@ func NewRootDefaults(s *capnp.Segment) (Defaults, error) {
 	return Defaults{st}, err
 }
 
+func AllocateRootDefaults(msg *capnp.Message) (Defaults, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 16, PointerCount: 2})
+	return Defaults{st}, err
+}
+
 func ReadRootDefaults(msg *capnp.Message) (Defaults, error) {
 	root, err := msg.RootPtr()
 	return Defaults{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Defaults) Equal(other Defaults) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Defaults) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Defaults) String() string {
 	str, _ := text.Marshal(0x97e38948c61f878d, s.Struct)
 	return str
@@ -5626,6 +6726,13 @@ func NewDefaults_List(s *capnp.Segment, sz int32) (Defaults_List, error) {
 	return Defaults_List{l}, err
 }
 
+// NewRootDefaultsListMessage creates a message whose root is a new
+// list of n Defaults.  The message's segment is sized to fit the list.
+func NewRootDefaultsListMessage(n int32) (*capnp.Message, Defaults_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 16, PointerCount: 2}, n)
+	return msg, Defaults_List{l}, err
+}
+
 func (s Defaults_List) At(i int) Defaults { return Defaults{s.List.Struct(i)} }
 
 func (s Defaults_List) Set(i int, v Defaults) error { return s.List.SetStruct(i, v.Struct) }
@@ -5639,8 +6746,7 @@ func (s Defaults_List) String() string {
 type Defaults_Promise struct{ *capnp.Pipeline }
 
 func (p Defaults_Promise) Struct() (Defaults, error) {
-	s, err := p.Pipeline.Struct()
-	return Defaults{s}, err
+	return capnp.PipelineStruct[Defaults](p.Pipeline)
 }
 
 type BenchmarkA struct{ capnp.Struct }
@@ -5658,11 +6764,28 @@ func NewRootBenchmarkA(s *capnp.Segment) (BenchmarkA, error) {
 	return BenchmarkA{st}, err
 }
 
+func AllocateRootBenchmarkA(msg *capnp.Message) (BenchmarkA, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 24, PointerCount: 2})
+	return BenchmarkA{st}, err
+}
+
 func ReadRootBenchmarkA(msg *capnp.Message) (BenchmarkA, error) {
 	root, err := msg.RootPtr()
 	return BenchmarkA{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s BenchmarkA) Equal(other BenchmarkA) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s BenchmarkA) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s BenchmarkA) String() string {
 	str, _ := text.Marshal(0xde2a1a960863c11c, s.Struct)
 	return str
@@ -5747,6 +6870,13 @@ func NewBenchmarkA_List(s *capnp.Segment, sz int32) (BenchmarkA_List, error) {
 	return BenchmarkA_List{l}, err
 }
 
+// NewRootBenchmarkAListMessage creates a message whose root is a new
+// list of n BenchmarkA.  The message's segment is sized to fit the list.
+func NewRootBenchmarkAListMessage(n int32) (*capnp.Message, BenchmarkA_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 24, PointerCount: 2}, n)
+	return msg, BenchmarkA_List{l}, err
+}
+
 func (s BenchmarkA_List) At(i int) BenchmarkA { return BenchmarkA{s.List.Struct(i)} }
 
 func (s BenchmarkA_List) Set(i int, v BenchmarkA) error { return s.List.SetStruct(i, v.Struct) }
@@ -5760,8 +6890,7 @@ func (s BenchmarkA_List) String() string {
 type BenchmarkA_Promise struct{ *capnp.Pipeline }
 
 func (p BenchmarkA_Promise) Struct() (BenchmarkA, error) {
-	s, err := p.Pipeline.Struct()
-	return BenchmarkA{s}, err
+	return capnp.PipelineStruct[BenchmarkA](p.Pipeline)
 }
 
 type AllocBenchmark struct{ capnp.Struct }
@@ -5779,11 +6908,28 @@ func NewRootAllocBenchmark(s *capnp.Segment) (AllocBenchmark, error) {
 	return AllocBenchmark{st}, err
 }
 
+func AllocateRootAllocBenchmark(msg *capnp.Message) (AllocBenchmark, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return AllocBenchmark{st}, err
+}
+
 func ReadRootAllocBenchmark(msg *capnp.Message) (AllocBenchmark, error) {
 	root, err := msg.RootPtr()
 	return AllocBenchmark{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s AllocBenchmark) Equal(other AllocBenchmark) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s AllocBenchmark) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s AllocBenchmark) String() string {
 	str, _ := text.Marshal(0xecea3e9ebcbe5655, s.Struct)
 	return str
@@ -5823,6 +6969,13 @@ func NewAllocBenchmark_List(s *capnp.Segment, sz int32) (AllocBenchmark_List, er
 	return AllocBenchmark_List{l}, err
 }
 
+// NewRootAllocBenchmarkListMessage creates a message whose root is a new
+// list of n AllocBenchmark.  The message's segment is sized to fit the list.
+func NewRootAllocBenchmarkListMessage(n int32) (*capnp.Message, AllocBenchmark_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, AllocBenchmark_List{l}, err
+}
+
 func (s AllocBenchmark_List) At(i int) AllocBenchmark { return AllocBenchmark{s.List.Struct(i)} }
 
 func (s AllocBenchmark_List) Set(i int, v AllocBenchmark) error { return s.List.SetStruct(i, v.Struct) }
@@ -5836,8 +6989,7 @@ func (s AllocBenchmark_List) String() string {
 type AllocBenchmark_Promise struct{ *capnp.Pipeline }
 
 func (p AllocBenchmark_Promise) Struct() (AllocBenchmark, error) {
-	s, err := p.Pipeline.Struct()
-	return AllocBenchmark{s}, err
+	return capnp.PipelineStruct[AllocBenchmark](p.Pipeline)
 }
 
 type AllocBenchmark_Field struct{ capnp.Struct }
@@ -5855,11 +7007,28 @@ func NewRootAllocBenchmark_Field(s *capnp.Segment) (AllocBenchmark_Field, error)
 	return AllocBenchmark_Field{st}, err
 }
 
+func AllocateRootAllocBenchmark_Field(msg *capnp.Message) (AllocBenchmark_Field, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return AllocBenchmark_Field{st}, err
+}
+
 func ReadRootAllocBenchmark_Field(msg *capnp.Message) (AllocBenchmark_Field, error) {
 	root, err := msg.RootPtr()
 	return AllocBenchmark_Field{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s AllocBenchmark_Field) Equal(other AllocBenchmark_Field) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s AllocBenchmark_Field) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s AllocBenchmark_Field) String() string {
 	str, _ := text.Marshal(0xb8fb64b8ed846ae6, s.Struct)
 	return str
@@ -5893,6 +7062,13 @@ func NewAllocBenchmark_Field_List(s *capnp.Segment, sz int32) (AllocBenchmark_Fi
 	return AllocBenchmark_Field_List{l}, err
 }
 
+// NewRootAllocBenchmark_FieldListMessage creates a message whose root is a new
+// list of n AllocBenchmark_Field.  The message's segment is sized to fit the list.
+func NewRootAllocBenchmark_FieldListMessage(n int32) (*capnp.Message, AllocBenchmark_Field_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, AllocBenchmark_Field_List{l}, err
+}
+
 func (s AllocBenchmark_Field_List) At(i int) AllocBenchmark_Field {
 	return AllocBenchmark_Field{s.List.Struct(i)}
 }
@@ -5910,291 +7086,290 @@ func (s AllocBenchmark_Field_List) String() string {
 type AllocBenchmark_Field_Promise struct{ *capnp.Pipeline }
 
 func (p AllocBenchmark_Field_Promise) Struct() (AllocBenchmark_Field, error) {
-	s, err := p.Pipeline.Struct()
-	return AllocBenchmark_Field{s}, err
-}
-
-const schema_832bcc6686a26d56 = "x\xda\xacZ}t\x14U\x96\xbf\xb7\xaa\xbb+$\x9d" +
-	"tW^eBB0\x12A\x9380!\xc1\x80\x8c" +
-	"n\x08&\x0a\x1e\xd04\x0d\"\xee0RI*Ic" +
-	"\xa7;\xd3]\x0d\x89\x0e\x07\xdd\x91\xf1\xe3\xc8\x8e\x1ea" +
-	"\x14\x95Y\xcc\xc2\x0e\xa8\xb8\xe2\xc0\xae\xb2\x0c#\x8e:" +
-	"\x10eG\\\xf1\x83\x05\x14\x14\x15\x94\x1dqd\x85\x11" +
-	"\xac=\xf7uWW\xa5S-\xca\xd9\x7fH\xf1~\xb7" +
-	"\xee\xbb\xef~\xbf[]s\xd8;E\x18\xef\xfe\xad\x02" +
-	"\x10\xf8\xdc\xed1^>u\xfc\xad\x9a\xdb\xc6,\x83\x80" +
-	"\x0f\xd1\xb8\xa1\xbb\xff\x97\x1d\xaf]\xfa\x0bpI\x00\xec" +
-	"\xf2\xdc\x01\xd6\x9cKO\x8d\xb9\x0d\x80\xc6\xfcW\xfd?" +
-	"\x1d\xb6m\xda]\x19\xb4n\x91H\xd4\xdc\xad,\xc4\x89" +
-	"\xb5\xdc\x8f\x01\x8dw~\xf7M\xcdE\x0d\x7f\xbc\x0bd" +
-	"\x9f\x9d\x16%\x80\xba\xf9y\x85\xc8\xba\xf3\x888\x94G" +
-	"\x9c'\xf7N\xa9\x7fv\xf7\xa8\xbb387I\x02\x00" +
-	"[\x957\xc0\xd6q\xe2\xc7\xf3\x16\x03\x1a\xfd\x7f\x1d\xf1" +
-	"\xf6\xe6\x9b\x7fp/\xc8\x0aB\x8a\xe3\xd9<\x01\x01\x19" +
-	"z\x89\xdb\x841\x95GwT\x04\xff\x11d\x9fh1" +
-	"\x03d\xa3\xbc\xfd\xac\xcaK\x9c\xc6x\xafa3\xe9\xc9" +
-	"\xb8\x7fg\xf9+\x8d\xb7=\xfd\xab\x0c9\x05\xa2\xba\xcc" +
-	"{\x985r\xfa+\xbd\xb4\xb3\x11{\xedX`\xd5\xae" +
-	"\x07\x07\xd3r]\xad\xf3\xbe\xc86z%\x10\x0d\xef\x9b" +
-	"\xbf~\xa9\xf0\xc9\x9a\x15 \xfb\\\x83v\xbf\xc7;\xc0" +
-	"V\x12\xb7\xe0\xfd^\x11\x83Oy\x05\x040\x96\x8f\xca" +
-	"\x99pj\xc9\x1fV8\xe8\x89m\xf0\x0e\xb0-|\xff" +
-	"M\xfc`\xb7D\x16(\x0d\xdfl_\xe9\xa4\xd3=\xde" +
-	"Bd\x878\xf1\x01N\xbc\xfc\xae\xf2W\xa6\xdd\xf3\xc1" +
-	"C\xa4S!\xf3d\xf9\xf9/\xb2\xa2|zO\xce/" +
-	"G@c\xdeS\xc2\xa3\x0f\xad\xda\xf2\x88\x93\x18U\x05" +
-	"\x03\xec\xb2\x02z\x1a_@\x9cW\xdfv\xe0\xf9\xaa\xb7" +
-	"'>j7\xc0\xbc\x82\\2\x80\xca\x09v\xce9\xec" +
-	"\xdez\xc9\xaf\x1e\x1d\xa2\x82;\x0a\x06\xd8r\xe2\x14\xbc" +
-	"\xbb@\xc4\xe0\x8a\x02\xae\x82\xc4\x8f]\xbf4jkV" +
-	"g\xfa\x15\xdf|Y\xc1\x00{\x80o\xbe\xbc\x80l\xf0" +
-	"\xd0\x05{~\xd4rZ[\x0b\x812D\xe0\xca\xaf;" +
-	"Z\x10\xa3\xcdO\xf2\xcd;\xa6u~\xf1\x0d\xfb\xc3z" +
-	"\xa7\x93\x14\xf9^d#}\xf4T\xe2#\xda\x0bw\x16" +
-	"\xf7\xde\xfc\xde\xd3O\x0e\xf1\x94\xcb}\x87Y3'l" +
-	"\xf4]\xc3\xba\xe9\xc98\xb1df\xf5\xd49\xaf?\xe9" +
-	"\xa4\xfd9\xbeRd\x1a\x7fA\xe5\x9c\x9f\xff\xf4\x99h" +
-	"\xf3\xbe\xbb6:I\xf1\x80\xaf\x9f\xad\xe2\xb4+9\xad" +
-	"\xdc\xd3\xfev\xc4\xfd\xd4&'\xda-\xbe/\xd8\x0eN" +
-	"\xfb{N\xbb\xb4\xfe\xa7w\xce\x9f\xf4\xf9&\xd2\x95\x98" +
-	"\x19\x83\xc7}o\xb0\xd3D\\w\xd27\x97\xac\x1a\x1f" +
-	"\x98`|v\xf8\x82\x7fs\xf2\x81:U\x16\x90u\xcb" +
-	"<\x0ae\x0a\xd9\xb5\xff\xb2u\xcc+\xdd\x97\xfe;\x04" +
-	"d\x14\x8dw\xd7\xbc\xf5\xe3\x87\x8e^|\x0c\x8aPB" +
-	"\x0a\x81\xc2'\x00\xd9\x95\x85d\x84\x8f\x16\xdey\xfc\xb9" +
-	"\xf6\xaf\x9f\x03\xf9\x024\xe6\xdc\xb0}\xdbo\xfe\xee\xd8" +
-	"g)U\xac*\xac@\xb6\xa1\x90\x07E!\x89\xbc\xed" +
-	"\x85\x85e%\xab\xc7\xbd\xe0t\xbc]\x85\x03l/\xa7" +
-	"\xdd\xc3i\xfb\xf3\xbe\xe8?\xad\xed\xfb\x93\x13-\xb2'" +
-	"\xd80FOnF\xb4cg^\xfe\xfb\x0f\x9e\xfc\xfb" +
-	"]N\x91;\x86\x0d\xb0\xf1\x9cv,#\x81\xb7~\xf5" +
-	"\xde\xde\x9b\x17\xed\x7f\xd5\xc9v\xf7\xb0Rd\xab8\xf1" +
-	"J\xce\xf8\xe1\x976\xe4}$7\xbd\xe6$\xc4\xcbl" +
-	"+\xdb\xcdiwq\xda\xf7/\x9e\xb4\xe0\x83g\x7f\xe7" +
-	"H{\x96\xf53\xb7\xc2EW\x88vz\xcb\xe1w\x0f" +
-	"?\xdd\xf4\x9f\x8e\xb6\x1b\xa5\x1ccc\x89\xb8\xaeJ\xe1" +
-	"\xb6\xdby\xfb\x8e\xb2\x81c\x8f\xfe\xd9I\xe4eE\x85" +
-	"\xc8V\x16qg*\"\xd6\xdb\xf7\xdd}b\xed\xd7\xf5" +
-	"o9\x89\xb1\xa3\xe8\x11\xb6\x8b\xd3\xbe\xcci\x1f\x98\xf8" +
-	"\xdb\x05\x91?o{\x87\xc4pe*\xeeH\xd1\x00;" +
-	"A\xc4u\xc7\x8b\xb8\x18\xcb_}w\xf1\xdd\x0b\xee{" +
-	"\xd7\x89s\xa0\xb8\x9f\xcd+\xa6\xa79\xc5\xc4\x99-\xfa" +
-	":\xd4\xd1\xb8\xe7\x80\x93E\x12\xc5\xfdl\x09\xa7\xed+" +
-	"&\x8b\x94\xedh\xcb\xf9ui\xf5\xc1Le$\xa5(" +
-	"~\x83\x9d(\xe6R\x14s)V\xcf\x9e\xbb\xf1?\x9e" +
-	"n9\xe8T\xa6\x1aK\x9e`\xd3K\xe8\xa9\xb9\xe4_" +
-	"\x01\x8d\x07k\xd6\xff\xed\x8a7\x7fs\xd0Iq\x07J" +
-	"r\x91\x1d\xe7\xc4GKH\xe4\x8d\xdb%y\xef\x9e\xfe" +
-	"CN\xc7+)\xdd\xcaF\x95\xd2\xd3\xc8R\xa2U\x83" +
-	"u\x85/\x1f\xdb\xe5H\xdb\\\xfa\x08\x9b\xc9i\xa7s" +
-	"\xdaS\xf3\xd6\xfc\xe2\xb1\xfe\x9c#NB\x84J\x0b\x91" +
-	"\xf5q\xe2\x04'\xde\xf4\xa79\x07\x9f\xf6_w$\xe3" +
-	"t\xcd(\xb9(\x8aJ_d\x1b9\xf5\x86R\x0a\xd3" +
-	"Q?:5\xe2\xcc\xb2\xf9\xc4Z\x18\x94\xb4\x96\x8c\xd8" +
-	"\xca\x96\x8d \xc2;F\x90\"\xf6{N\xff\xd3\x9dK" +
-	"\xef\xc8\x94\x81\xbb\xdb\xde\x11\x03\xec\x10\xa7=\xc0i\xe7" +
-	"u\x95\xfee\xf2\xa7w~\xe4t\xb6ue\xfb\xd8\xa6" +
-	"2z\xdaX\xc6\xcb\xd0\xde\xedk7\x96\xfe\xec\xe3!" +
-	"\xe9}o\xd9\x00;D\x84\xc1\xfde\"\x06?)\xe3" +
-	"\xe9=\x9dH\x06[\xba\x19\xa5\xb1\xb4}\xd9\xbd\xec\x08" +
-	"\xbdSw\xa8\xec\xbda`K'\x0e\x92\xdcQ\xf9\x05" +
-	"[^YL\x91ZI\x92\x94\xe5\xcc\x9f:l\xe3\xf5" +
-	"'\x1c#\xb5r\x1f\xdbSIO\xbb9\xed\xc3%\x85" +
-	"\xf7~\xf9\x0fw\x9d\x04\xb9\xcc\xac\x1bg+\x17\"\xb8" +
-	"\x8c\xb3\x1d\xd7\xecj\xde\xef\xfe*#Kr\xe7:Z" +
-	"\xf9\x06;\xc9\xb9\x9c\xa8$\xb7]8<|\x8d\xd2d" +
-	"|\xe5\xb4\xe3\xcc\xaa7\xd8\xbc*\x1e\x0eU\xb4\xe3\xc1" +
-	"\x19\xdb\x1f\xac\xd4\xff\xf9\x8c\x93\xd3\xae\xaaz\x83\xad\xe3" +
-	"\xb4\x8fW5\xc0XC\x0d\xc5\xdabj\x87.\x8ck" +
-	"S{\"=\x93\x83\xba\xdavK(\xd29\x15\xa0\x05" +
-	"1\xe0\x12]\x00.\x04\x90\xf3+\x00\x029\"\x06\x14" +
-	"\x01\xa5H\xa2\x1b] \xa0\x8b\xbc3\xc5\x01S\x1c\xae" +
-	"j\x88&\"\xba\x16\xa3\xd7\xfd\xe9\xd7\xd5j\x80\xc0O" +
-	"D\x0ct\x09\x88\xa8P\x09\x95\xb5Z\x80\xc0\x02\x11\x03" +
-	"a\x01e\x01\x15\x14\x00\xe4\xd0\xb5\x00\x81.\x11\x03w" +
-	"\x0a(\x8b\x82\x82\"\x80|\xc7T\x80\xc0\xcfE\x0c<" +
-	",\xa0/\x1e\xbaUC7\x08\xe8\x06,_\x1c\x8d\xb5" +
-	"\xc7\xd1\x0b\x02z\x01\x0d\xfa_8\x14\xd7\x01\x00\x0b\x00" +
-	"[D\xe4P\x01\xe0\xd2\xd6\x90N\x88\xb9\x8c\xc9\xe5\xb4" +
-	"\xf4bJ\xfai\xd1p{\xfc\x06-6{qt\xf6" +
-	"\xe2hK8\x81\xf1\x0c=LN\xe9a\xb4\x80\x0d\xdd" +
-	"}v\x9e~+\xd3\x02\x0e\xe2nj\xf7\x86h\xa8}" +
-	"N$\x14\x8d$\xb5\x9b#\xba\xbc\x86\xc1\xd9V\x15\x02" +
-	"\x04F\x8b\x18\xa8\x110\x1f\xbf1\x92\x1a\x1aK\xab\x95" +
-	"\"\x06&\x08\x88*x\xb0\x15<CDnn\xeb\x8a" +
-	"\x8e\xd3\xda\xba\xa2\xa3[\xd4\x98\xda\x1d\x07\xbb\xb4\xa5\x96" +
-	"\xd5\xc4P$\xad\xa8L\xa35Km]\xd1\xe4A\xdd" +
-	"\x00\xe9V\x17\xcd\x96K\x96\xabA\x90\xdd\x92\x8f\xf6\x99" +
-	"\x82-\x88Y=g\x96\x14\x8d\xea\xa9\xd3!\xba\x10Q" +
-	"\xaeZh\x1d\xc3\x9f2\xfe\xe5t\xb4\x09\"\x06\xa6\x08" +
-	"h\xa8sCzW\x93\xd6\x01>5\x11\xd6\xd1o\xb5" +
-	"f\x80\xe8\xe7\xca\xc2j\x00T\x87@\x0e:\xd6b\xcd" +
-	"\xdd=z\x1f8\x09\xd9\x16\x8d\xc4\xf5\x19\xdcC\x88g" +
-	"\xdap\xe9<\xcf\x0d\x072\x16\xcb9\xe8{O\xca\x19" +
-	"A\xff\x949mr}Dk\xd1c\xdf\x1a&=z" +
-	"\x0c\xfdV8f\x08\xec\xecr3Bq\xd4\xbf\x87\xcb" +
-	"\xa5[\xad,.\xd7\xa4u\x90N\xe3\\N%\xc9\x13" +
-	"Q^B\xf1\xd8\x9b\x0a3\x1e\x90\x88\xf22Z\xbc]" +
-	"\xc4\xc0}\x02\xa2\xa0\xa0\x80(/\xa7 \xbd[\xc4\xc0" +
-	"\x0a\x8aGTPD\x94\x1f\xa0S\xde\x97\x8cG\xd9%" +
-	"(dfy%\xbd}\xbf\x88\x81\xc7\x04\xf4\xe9Z\xaf" +
-	"\x9e\xf26\x90\xb1B\xea\x88F}\xed\xaa\xaeb>\x08" +
-	"\x98Ok\xa5R\xab\x1a+\xef\x08GU\x1dsA8" +
-	"\x91\xfb\xc7\x93\xd3\xa6\x00J\xa1\x88N\xb9\xe5\x84k\x99" +
-	"a\x18\x80\xbe\x04-\xe4\x80 \xe7T;\x1conL" +
-	"\xedI\x9a;\xd3\x10\xcf\x02\x04\xfc\"\x06\xca\x044\xba" +
-	"C\x9d]\xfauQ\x1d\xa7j\xb345\x1c\xee+\xe7" +
-	"\xef\xa0\xdf\xbaZe1\x8e\x15\\\xb3\xb48\xd7#d" +
-	"\xb3v4\xa1\x0f\x89\xafA~\xd7\x1cIt'\xfd\xce" +
-	"g\x15S@\xb7\x0f!{85\xa6SEj\xcf\xaa" +
-	"\x0a+S\x98\x99\xd4\x9e'\xec\xc9\x19[\xd1o]\xb5" +
-	"3\x8e\xe82\x13\xb6\x1a\x0e\x07\xb5\x9f%\xb4H\x9b6" +
-	"\xaeS\xd3\xafKt\xb7j\xb1\xd1\xb3\xb4r~`\xfb" +
-	"q\x0b\xad\xe3b\x84\x8c\x829\x0e\xa2\x93\xce\xa6\xaaq" +
-	"-\xd3\"\xd5\xd6\xdb<\x8f\xa0l]\x99\x01Qv`" +
-	"\x95\x96M\x8a\xb4iV~2k*\x9a\xb72Y\x9e" +
-	"\x05\x82<L2L\xf9\x01c\x83\xd3Tf\xb4]\x1f" +
-	"\xd1\x9aT]\x9d\x11\x12\xe3\xdf'\xdc\xec\xc1\\\xe0\x90" +
-	"H\xa7IQ\xbd\xeb[\xce\xdd\xaa\xc65\xf4[\x97\xc5" +
-	",I\x8cRTP\x8f%\xda\xca\xf5\xab\xd4\x9eH\x96" +
-	"\x1c3Z@i\x91\xd6fI\x97\xeeK\xb2$\x83Y" +
-	"ZgL\x8b\xc7CQ\xe4,\x87\xa7Y\xae\"\x19W" +
-	"\x88\x18Xc9\xd5j\xaa\x1d\x0f\x8b\x18Xk+\xcf" +
-	"\x8f\x13\xe1c\"\x06\x9e\xb3\x95\xe7-\xa4\xb1gD\x0c" +
-	"\xbcF\xe9\x00\x15t\x01\xc8\xbbH\xca\x97D\x0c\xbc." +
-	"\xa0\xec\x16\x14t\x03\xc8\xbbiq\xa7\x88\x817-]" +
-	"\xa4\xef\x05I]\x88\xad5\x98\x07\x02\xe6\x01\xfaZ5" +
-	"]5O\x97\x97\xac\xd8\x0d=a5\xa2\xc5\xad3\xa7" +
-	"\x9b\xd8\xe4\x99\xa5\xbe\xee\x84\xf9\xbe\xd4\x17o7\x9f\x87" +
-	"8B2\xe3\x92\x0fP\xa1\xd7c\x90\xd1\xb0TX\x0d" +
-	"\x8b\x9c\xeeX*\xac\x8e\x85'HjXH#\xed\"" +
-	"\x06z\xcc\x04\x09 wW\xa7\xba\x18\x9dL\xa4\x86Q" +
-	"\x04\x01E@\xa9=\x115{\x17_\x8f\x1e\x1b?\xb4" +
-	">\xd0r\xed\xb7\x94\x0d\xd3\xd3n\xf2\x8d\xeb\x8c\xf5P" +
-	"b\xa0\x92ZM\x99\xa1\xd6\xca\x0c2\x89w)\xa5\x86" +
-	"\xc9Vj(\xef\x08\xc5\xe2:\x0e\x03\x01\x87\x016\xc4" +
-	"\xb5\xb6h\xa4\xdd\xfc\xef\x10\x055\x86\xc3\xd1\xb6\xa9Z" +
-	"\xa4\xad\xab[\x8d\xdd2\xee\xea\x90\xa4\x85\xdb3<\xb1" +
-	"\x15 \xe0\x1510\\@#\xae\xc7B\x91\xce\x1bT" +
-	"\x90\xc2\x09-k&4\x93C|H\xc2\xae\xb5\xbc\xba" +
-	"\x9c\\\xc3fc{\xb8\x148\xea\x82j\xcb\xb7\x84\x9d" +
-	"\xad\xf48v\x0c)\x17\xc8L\xb5\xd5v\x85\x9a\xb9\xb6" +
-	"\xdaR\xe8y\x9a\xd0\xa1\xf2\x9fO.J\xdf\x1f\xb2i" +
-	"\xa5!\xae\xc5\x16%;q\x07\x9bU\x0ah,VC" +
-	"z(\xd2\xb9\x10\xa4h\xabM\xdf\xe9\x0bu\x16\xceS" +
-	"\xa5\x89u\x13\xcf\x9d\xe52\";\x8b\xe2g/\x8e\xfa" +
-	"Z\xc2\x89xFF\xaa\xb02RZ\xf7\xab+\xac\x94" +
-	"d\xc6_:#\xad\xb7\xc5\xdf:Z\\#b\xe0)" +
-	"\xb3A\x01\x907\xd0\xdbkE\x0c<c\xcbH\x1b\x89" +
-	"r\xbd\x88\x81\x97\xce\x1d\xa9\xf6^\xcbf\xe6\x8ceI" +
-	"\x8f\xa5\xef'\xbep\\\xaf3\xf5\xea>\xf7\x9d\xa3E" +
-	"\x8f}\xdf\x060=\x8e\xcaf+Q\xed\xcc`7\xd5" +
-	"2\xd5\xd2\xb6\xe4m\x0d\xfd\xd6\x98=\x8b\xb5Z(\xf1" +
-	"\xf2\xba\x0e\xd9\x8b\x87e\xaa\xda\x94\xa96[\xa6\xdaD" +
-	"\xe7x\xca,\x1eS2\x8a\xc76\x9b\xa9\x9e\xa7[\xe0" +
-	"sI\xab\xc8n1i\xaa\x1d\xb4\xf8B\xb2\xcc\xf8\"" +
-	"jw:\xc9\x94wE\xbb\xadt1\xa8\xb9\xe2%#" +
-	"\xa6\x92\x93\x9b6ihS#W\x87\xfb\xf8\x15\x90:" +
-	"\xaf6\xb5Gm\x0bQ\x07\x09&\x89\xd1\xad\xf6\x06{" +
-	"4\xad\x9d\xd62\x0b\x88\xa9\xd8F\xa9\xae\xb6\xe6\xfc\x83" +
-	" \x1d\xa5\xd2\xc2hk\xf6\x16oh\xdei\x12Pj" +
-	"\xebn7\x0f\xefSc\x9d\xf1\x8c\xdb\xee\x10\xdb\xa5\xd3" +
-	"86~\x87\xc2\x7f\xad-\xa2\xcc\xc2\xbf\xae\xd6\x16Q" +
-	"f\xe1\xdfpm*x6\x93\xed\x16$m7\xc8\xca" +
-	"f\x98m\xa9\xb5\xac<\xc8vFk(\xa6w5\xa9" +
-	"v\xf5\x97\xf7tE#\x16E<\xd4\x1a\x0eE:\xe3" +
-	"D\x91jl\x1b\xe2=\xd1D\\3mX\xde\x1d\x8d" +
-	"h}Y-\xc5\xcb\x03o\x1f\xbd\xe9\x837\xd3\xc1\xa7" +
-	"\x88\x18\x98\x91*\x96\xb48\x9d\xa4l\x121\xd0B'" +
-	"\x17\x93'\x9fI\xd6\x98&b`\xb6\x80\xbe>M\x8d" +
-	"\x99)\x82v\xd5\xbb\xd0\x03\x02z(a\xa8}\xe6s" +
-	"\xd6\x10\xe7\xd7\x8e\xf4\xfd\xf3\xbb\x86\xb8\xfd\x92\xe2\x14\xe2" +
-	"s\x1bbjOmo\xedy\xdd\x802\xb2\xd7\x10\xe6" +
-	"W\x8b\xe3\xeb\xcf\xdf\xcb\x1d\xda\xee\xf3\xc8q\xe9\x8fU" +
-	"Y\xfa\xda\xc6\xd4\xff\xcd\x1e\xce\x9c\xaa\xd8\xa7N\xd6T" +
-	"E\xab\xb6\xba\xb8|\xe1\xac1\xb4\x8f\xcb\x17\xcf\x18\xa9" +
-	"F\xae\xc2j\xe4|\x8b\xa2\xa1v\xf0\xf8Z'\xd6M" +
-	"D\xbf5\x82OU\x03\xb5\xae\xb6\x86d5\x07\xd7\xa9" +
-	"j\xd01\xbe\x1e\xfd\xd6\x0c7\x8b\x96\x1b\x1bB\xb1\x9e" +
-	"h\x8c+\xa5,\xe9v\xd5\xfcR\xd4\\\x01\x80\x82|" +
-	"%\xfd\x11\xe5\xcb\xe8\x8fK\x1eK\x7f\xdc\xf2\x18\xfa\xe3" +
-	"\x91GV\x03\xf8\"\xd1\x88&-\xec\xb8E\x0a\xab\xbd" +
-	"R\xbc#*\x85\x13\x8b\xa4\xf6\x8e\xc5>]\x8b\xebC" +
-	"\x14\xc6\xcd1[\xebM\xf9\xa1-,*\xeca\x91J" +
-	"\x08\xd3+Ra\xb1\x80\xc2\"\x95\xcc\xe7\x93\xc1nL" +
-	"N\x10$=}\xdbG)lY.\x95\x8e\x1a\xc2q" +
-	"\xdd\xb6z\x8e\\57\xe9\xcc=a1\x11?/\x8f" +
-	"\xb6O\xe1\xfc\xd9.\xe3M\xaa\x9e,d\x19\xd3\x1f?" +
-	"\x80?9\xf9\x19\x9aF\x92\xca\xda\x9c\xf6/v\xc0U" +
-	"\x0d\x10|\xc7%b\xf0C\x97\xcd\xc5\xd8!W)@" +
-	"p?\x01\x9f\xb8\x04\x1c)\x9c5\x92y\x94\x1dqU" +
-	"\x00\x04\xdf'\xe43B\xc43F2\x99\xb2\xa3\x1c\xf9" +
-	"\x90\x90\xcf\x09q}m$\xafR\xec8G>!\xe4" +
-	"KB\xdc\x7f3\x92i\x95\x9d\xe0\xc8g\x84\x9c\"\xc4" +
-	"s\xdap)\xe8\x01`'9\xf29!g\x08\x91N" +
-	"\x199\x0a\x1f\x17\x9f\xe6\xb2}\xe9\x12q\x96[\xc0\x91" +
-	"9_\xd169\x00\xec,\x7f\xe5\x14\xbd\xe2\"d\xd8" +
-	"\xff\xd26\xc3\x00\x18\xba\x099CH\x0e!\xb9'i" +
-	"\x9b\\\x00\xe6&d\x96[\xc4\xa0\x97\x80\xbc/i\x97" +
-	"<\x006\xccM\xbb\xb8\x08\xf1\x13\xe2\xfd\xab1EA" +
-	"/\x00\xcbw\x93\xd2r\x08Q\xdc\x02\xe6\xe7\x7fa(" +
-	"\x98\x0f\xc0d\x0ex\x09\x18N@\xc1\x09C\xc1\x02\x00" +
-	"V\xc4\x01?\x01e\x04\xf8>7\x14\xf4\x01\xb0\x12\xf7" +
-	"d\x80\xa0B@\x0d\x01\xfe\xbf\x18\x0aY\x90\x8d\xe5@" +
-	"%\x01M\x04\xc8\xffc((\x03\xb0F\x0e\\A\xc0" +
-	"\x8d\x04\x14\x1e7\x14,\x04`s8\xd0B@\x98\x00" +
-	"\xf6\x99\xa1 \x03`!\x0e\xb4\x13p;\x01\xca\xa7\x86" +
-	"\x82\x0a\x00[\xe2\xae\x05\x08\xf6\x12\xb0\x82\x80\xa2c\x86" +
-	"\x82E\x00\xec\x01\xfe\xc6}\x04\xac'\xe0\x07G\x0d\x05" +
-	"\x7f\x00\xc0\xd6q`\x0d\x01\xdb\x08(\xfe\xc4P\xb0\x18" +
-	"\x80=\xcf\x81\xcd\x04\xbcN\xc0\xf0\x8f\x0d\x05\x87\x03\xb0" +
-	"\xdd|\x8f\x9d\x04|H@\xd9G\x86\x82%\xe4`\\" +
-	"%\xfb\x098E\xc0\xc8#\x86\x82\xa5dw\xf7T\xb2" +
-	";\xd7\x95G\xc0\xfc\x0b>4\x14\x1cA\xba\xf2\x10+" +
-	"\xc5#b\xf0B\x02\xca?0\x14,\x03`#90" +
-	"\x9c\x80\xd1\x04\\x\xd8Pp$\x00\x1b\xe5i\x05\x08" +
-	"^H\xc0\x15\x04\x8c:d(x\x01\x00\xbb\xdcs-" +
-	"@p\x12\x01M\x04T\xbco(XN\xda\xf5\xdc\x04" +
-	"\x10\x9cB\xc0\x0c\x02.z\xcfP\xf0B\x006\xdd3" +
-	"\x0b 8\x8d\x80\xd9\x1e\x01G\x8e>H\x0e4\x0a\x80" +
-	"\x05<$\xef\x0cBn\xa4W\xc6\x1c0\x14\xac \x83" +
-	"x\xe8\x84-\x04\xfc\x84\x80\x8b\xf7\x1b\x0a^\x04\xc0\xe6" +
-	"q`6\x01\x0b\x08\xb8\xe4\xbf\x0d\x05G\x03\xb0\xf9\x1e" +
-	"\xf2\xd2\x1b\x09h'\xa0r\x9f\xa1\xe0\x18\x00\xa6ry" +
-	"\x17\x10\xf0s\x02\xaa\xde5\x14\xbc\x18\x80\xf5q\xa0\x97" +
-	"\x80\x15\x04\x94\xbcc(x\x09\x99\x90Ku\x1f\x01\xeb" +
-	"\x09(}\xdbP\xb0\x92L\xc8\x815\x04l#`\xc4" +
-	"[\x86\x82UdB\x0el&\xe0u\x02\xaa\xf7\x1ah" +
-	"\xfb\xb4\xcdv{*@\xc8\xbf\xf4MC\xc1\x1f\x02\xb0" +
-	"-\xfc\x10\xcf\xa4\xf9\xfc\xf0\xbf\x0c\x05\xc7r>\xa4\xa9" +
-	"\xe7\x08x\xc9\x93\xae9\xe2\xad\xb7\xa2\xdf\xfa\xa2e\x96" +
-	"\x96\xfa\x09\xe9QGG]-\xe6\x82\x80\xb9\x80R\xa8" +
-	"~\x82\xd9JI\xa1\xbaZ\xb3i\x92B\xe3\xeb\xcd\xde" +
-	"E\x0cMB\x01\x04\x14\x00\xa5D\xfd\x04s\x08 %" +
-	"\xeaj\xcd\xb9\x9e\x94\x18_\x8f\x12\x08(\x01\x8a\x89I" +
-	"fo\xe3k\x8dF\xc3f\xe3e\x9f\xf3\xa2\xaf5\x1c" +
-	"m5o\xd9\x0d\x1d\xf5\x13l\xb3)sz\xd3QW" +
-	"k[\xcdM\xad\x86\x06\xd1\xba\xcd\xd5A\xb4.su" +
-	"|\xbdmUL\xae\x96\x87&\xd9\x16\x85\x14ib\x10" +
-	"\xdba\xe6\xea \xb69\xe6\xea \xb6R\x8am\xc2\xce" +
-	"\xd6\x93\\\xf4\xdd:h\xe6f7J\x01\xe0RBm" +
-	"\x04\xd9\xe8\xcao\xa5\x9etH\xf1I\xae\xab\xe8\xb7~" +
-	"\x92\x90Q\xc5`\xf0\xc8/c\xfce\x91\x01d\xa0\xc4" +
-	"$\x96\x1a\x00\x82\x18\x8d\xa0\xdf\xfaUG\x0a\xe6\xb3\xb5" +
-	"V5\x0e\xe8\xd0\xd3-U\x93-J\xc6\x05\xcbG\x86" +
-	"\xff\x7f\xe8\x88\xb8>\x16im\xb6ow\x99\xdfe\x92" +
-	"Dj&\x91]S\xfcS_4\x1a\xb6\xe9(\xf5\xa9" +
-	"oi\xeaUs9?\xb5L\x1el[Nu%R" +
-	"g\xac'\xdb\x90Z3\xe7\xdb\x18G\xbf\xf53\x93," +
-	"\xddFz\x8cV\xce/`\x01\x17\xda\x7f\xc7\x82\xb5\xe5" +
-	"W\x87\xb4p{\xb6N\xb8\x83@\xdbd&\xfdf\x96" +
-	"N\xf8:-\xaek\xb1\xf1W\xa9\xe2\x90\xa9q\xb5\xc5" +
-	"\xd6\x17\xd7cY\xef\x8c\xe7\xf8.\xd0\xa2\xfabjw" +
-	"<\xcb0\xa7I\xd5Q\xfd.\xb7\xd9\x0a\xdb\x17\x8b," +
-	"c\x97\xa1\xe3\xeb\xb9\xb3\xb5\xb8~\x95\xda\x83\x99g\xbb" +
-	")5\xd3\x9a$\xa0\x11\xd1\xe2\xfaLU\x8f\x81\x18\xea" +
-	"\x1d\x12\x85\xe7\x9a\x90\xa7\xbf\x0c\xa0\xfa-\x1f\xf6l\x02" +
-	"\xff_\x00\x00\x00\xff\xff\xf3\xa2\xdd\xc1"
+	return capnp.PipelineStruct[AllocBenchmark_Field](p.Pipeline)
+}
+
+const schema_832bcc6686a26d56 = "x\xda\xacz\x0dt\x14U\x96\xff\xbdU\xdd]\x09\x10" +
+	"\xba+\xaf\x1c\x01\x83\x81\x0e\xc9$-\xc4\xa4\x1b\x032" +
+	"\xfa\xef\x10\x13\x05\x0fj\x9a\x80\x88\x7f\x19\xa9$\x95\xa4" +
+	"\xb1\xd3\x1d\xbb\xab!\xd1\xf1\xa0;2\xba\x1e\x98\x19\x8e" +
+	"8\x8a\xca,fa\x17?p\xc5\xc1]e\x19F\x1d" +
+	"u \xea\x8e\xb8\xa2\xc2\x02*\x8a\x0a\xca\x8eqd\x85" +
+	"\x11\xac=\xf7uWW\xa5\xd3-\xca\xd9\xe39\xa6x" +
+	"\xbf[\xb7\xee\xbb\xdf\xef\xbe\xae\xf9eq\xbdP\xeb\x94" +
+	"\x1a\x00\xe6\x8aN\x97\xf1\xf2\x89co\xd7\xdcZ\xbe\x02" +
+	"BnD\xe3\xda\xee\xfe_t\xbcv\xc1\xcf\xc1!\x01" +
+	"\xb0#\x13\x06\xd8\xf1\x09\xf448!\x08h,z\xd5" +
+	"\xf3\xd3\xc2\xed\xb3\xee\xca\xa2u\x8aD\"O\xdc\xc6\xc6" +
+	"N\xa4\xa7s&~\x02h\xbc\xfb\xbbok\xca\x82\x7f" +
+	"\xbc\x0bd\xb7\x9d\x16%\x80@\x91\xb7\x18\xd9x/\x11" +
+	"\x8f\xf5\x12\xe7\x19\xbd\xf5uO\xbf>\xf1\xee,\xce\x8d" +
+	"\x92\x00\xc0f{\x07\xd8|N\x1c\xf2.\x034\xfa\xff" +
+	"z\xde;[o\xfc\xd1= +\x08i\x8e\x9b\xbd\x02" +
+	"\x02\xb2-\x9c\xdb\xd4\xf2\xca#/x[~\x09\xb2[" +
+	"\xb4\x98\x01\xb2\xdd\xde~\xb6\x97s\xda\xe3\xbd\x82\x9d\xa6" +
+	"'\xe3\xd7;K_\x99y\xeb\x93\xbf\xca\x92S \xaa" +
+	"\xc3\xdeCl\x90\xd3\x1f\xe3_6\xe2\xaf\x1d\x0d\xad\xdd" +
+	"u\xefPZ\xae\xab\xf9e/\xb2Ee\x12\x88\xc6\xa8" +
+	"\xb7~\xf3R\xf1\xe35k@v;\x86|\xfd\xe2\xb2" +
+	"\x01\xd6T&\x01\xb4\xd4\x97\x89\xd8rC\x99\x80\x00\xc6" +
+	"\xaa\x89\x05SO\xdc\xf6\x8759\xf4\xc4\x16\x96\x0d0" +
+	"\x8d\xde`j\x19m\xec\xa6\xe8b%\xf8\xed\x8e\xfbr" +
+	"\xe9\xf4\x8e\xb2bd\xab9\xf1*N\xbc\xea\xae\xd2W" +
+	"f\xfd\xfd\x87\xf7\x93N\x85\xec\x9d\xfd\xbe\xecE\xf62" +
+	"\x11\x07^(+E@c\xe1\x13\xc2C\xf7\xaf}\xe6" +
+	"\xc1\\b\xec\x9d4\xc0\x0eO\xa2\xa7\x0f&\x11\xe7u" +
+	"\xb7\x1ex\xae\xea\x9di\x0f\xd9\x0dPX>\x82\x0c " +
+	"\x97\x13\xc1\xce\xf9\x87\x9c\xdb~\xfc\xab\x87\x86\xa9\xa0\xb6" +
+	"|\x80]ZN*\x98^.bKc9WA\xf2" +
+	"'\x8e_\x18\xfe\x9au\xd9~\xc5?~Q\xf9\x00\x9b" +
+	"I\xaf\xb0K\xcb\xc9\x06\xf7\x9f\xbf\xfb\xc2\xe6\x93\xda\x06" +
+	"\x08\x95 \x02W~`my\x9c>\xbe\x91\x7f\xbcc" +
+	"V\xe7\x97\xdf\xb2?l\xca\xb5\x93\x97\xcb_d\xafs" +
+	"f\xbb8\xed\x84\x9d\xe7\xf6\xde\xf8\xde\x93\x8f\x0f\xf3\x94" +
+	"#\xe5\x87\xd8qN8X~\x05\x1b_A\x9e2x" +
+	"\xdbU\xbe\x86\xf9o<\x9eK\xfb\xce\x8aq\xc8\xce\xa9" +
+	"\xe0\x81PA\x9c\x9f\xfb\xec\xa9X\xd3\xbe\xbb6\xe7\x92" +
+	"bfE?\x9b\xcdi\x9b8\xad\xdc\xd3\xfeN\xd4\xf9" +
+	"\xc4\x96\\\xb4Z\xc5\x97\xecfN\xdb\xcdi\x97\xd7\xfd" +
+	"\xf4\xceE\xd3\xbf\xd8B\xba\x12\xb3cp]\xc5\x9b\xec" +
+	"Q\"\x0el\xacX\x80\x80Fb`\xaa\xf1\xf9\xa1\xf3" +
+	"\xff5\x97\x0f\x04\xe4J\x01\xd9\xf8J\x1e\x85\x95\x14\xb2" +
+	"\x1b\xfei[\xf9+\xdd\x17\xfc\x1b\x84d\x14\x8d\xbd\xeb" +
+	"\xdf\xfe\xc9\xfdG*\x8e\xc29(!\x85@\xe5c\x80" +
+	"\xecX%\x19\xe1\xe3%w\x1e{\xb6\xfd\x9bgA>" +
+	"\x1f\x8d\xf9\xd7\xee\xd8\xfe\xdb\xffw\xf4\xf3\xb4*fW" +
+	"y\x91-\xac\xe2AQE\"o\x7f~I\xc9\xd8u" +
+	"\xd5\xcf\xe7\xda^_\xd5\x00[\xc1i\xef\xe0\xb4\xfd#" +
+	"\xbf\xec?\xa9\xed\xfbS.\xda-U\x8f\xb1\xe78\xed" +
+	"3\x9cv\xcaU\x17\xff\xfe\xc3\xc7\xff\xff\xae\\\x91\xbb" +
+	"\xa7j\x80}\xc0i\x0fT\x91\xc0\xdb\xbe~o\xcf\x8d" +
+	"K\xf7\xbf\x9a\xcbv\x17\xfb\xc6!\x9b\xed\xe3\xf6\xf0\x11" +
+	"\xe3\x07^zt\xe4\xc7r\xe3k\xb9\x84H\xfa\xb6\xb1" +
+	"\xdb8m\x1f\xa7}\xbfb\xfa\xe2\x0f\x9f\xfe]N\xda" +
+	"\xcd\xbe~\xf6\x0c\xa7\xdd\xc2ig7\x1f\xda{\xe8\xc9" +
+	"\xc6\xff\xc8i\xbb\xdd\xbe\xa3\xec\x00\x11\x07\xf6\xfa\xb8\xed" +
+	"v\xde\xfeB\xc9\xc0\xd1\x87\xfe\x9cK\xe4\x8b&\x17#" +
+	"k\x9a\xcc\x9di2\xb1\xde\xb1\xef\xee\xc1\x0d\xdf\xd4\xbd" +
+	"\x9dK\x8c\x9b'?\xc8\xfa8m\x92\xd3\xae\x9e\xf6\xcf" +
+	"\x8b\xa3\x7f\xde\xfe.\x89\xe1\xc8V\xdc}\x93\x07\xd8#" +
+	"D\x1cX7\x99\x8b\xb1\xea\xd5\xbd\xcb\xee^\xbcro" +
+	".\xceX\xdd\xcf\x0a\xab\xe9\xc9YM\x9c\xd9\xd2o\xc2" +
+	"\x1d3w\x1f\xc8e\x91\xf2\xea~6\x85\xd3VU\x93" +
+	"EJ^h+\xf8\xcd8\xdf\xc1le\xa4\xa4\xa8~" +
+	"\x93=R\xcd\xa5\xa8\xe6R\xac\x9b\xb7`\xf3\xbf?\xd9" +
+	"|0W\x99\x1a\xbc\xf01v\xf2Bz:~\xe1\xbf" +
+	"\x00\x1a\xf7\xd6l\xfa\xdb%o\xfd\xf6`.\xc5\xad\xaa" +
+	"\x19\x81l]\x0d\x11\xaf\xad!\x917\xef\x90\xe4=\xbb" +
+	"\xfb?\xc8\xb5\xbd]5\xdb\xd8nN\xfb:\xa7U[" +
+	"\x02\xc5/\x1f\xdd\x95\x93\xf6x\xcd\x83\xec4\xa7=\xc9" +
+	"iO,\\\xff\xf3\x87\xfb\x0b\x0e\xe7\x12blm1" +
+	"\xb2\xaaZ\xae\x95Z\"\xde\xf2\xa7\xf9\x07\x9f\xf4\\}" +
+	"8kwM(9(\x8aj_d\x8b8\xf5\xc2Z" +
+	"\x0a\xd3\x89\x17\x9e8\xef\xd4\x8aE\xc4Z\x18\x92\xb4\xa6" +
+	"\xf8\xb7\xb1\x8b\xfcDX\xeb'E\xecw\x9d\xfc\x87;" +
+	"\x97\xdf\x91-\x03w\xb7\x15\xfe\x01\xb6\x9a\xd3\xae\xe2\xb4" +
+	"\x0b\xbb\xc6\xfde\xc6gw~\x9cko\xf3\x03\xfb\x98" +
+	"\x1a\xa0\xa7E\x01^\x86\xf6\xec\xd8\xb0y\xdc\xcd\x9f\x0c" +
+	"K\xef+\x02\x03l5\x11\xb6\xac\x0c\x88\xd8\xf2@\x80" +
+	"\xa7\xf7L\"\x19j\xe9&\x94\xa6\xd0\xe7\x03\xf7\xb0\xfb" +
+	"\xe8\x9d\xc0\xea\xc0{\x85`K'9$\xa9\x0d~\xc9" +
+	".\x0d\x9eK\x91\x1a$IJ\x0a\x165\x14n\xbef" +
+	"0g\xa4\x06\xf7\xb1;\x82\xf4t\x1b\xa7}`l\xf1" +
+	"=_\xfd\xdd]\xc7A.1\xeb\xc6\xe6\xe0\x12\x04\x87" +
+	"q\xba\xe3\x8a]M\xfb\x9d_geI\xee\\k\x83" +
+	"o\xb2\x8d\x9c\xcb#Ar\xdb%c\"W(\x8d\xc6" +
+	"\xd7\xb9\xbex:\xf8&+\xac\xa7'g=}\xf1\xe0" +
+	"\x9c\x1d\xf7V\xea\xffx*\x97\xd3\xce\xae\x7f\x93\xcd\xe7" +
+	"\xb4\xa1\xfa \x18\x99\xff\xf6\x1aj8\xde\x16W;t" +
+	"\xa1\xbaM\xed\x89\xf6\xcch\xd1\xd5\xb6\x9b\xc2\xd1\xce\x06" +
+	"\x80f\xc4\x90Ct\x008\x10@.\xf2\x02\x84\x0aD" +
+	"\x0c)\x02J\xd1d7:@@\x07`\x86\x03\xa69" +
+	"\\\x16\x8c%\xa3\xba\x16\xa7\xd7=\x99\xd7U\x1f@\xe8" +
+	"\x06\x11C]\x02\"*Hk\x9a\x1f \xb4X\xc4P" +
+	"D@Y@\x05\x05\x009|%@\xa8K\xc4\xd0\x9d" +
+	"\x02\xca\xa2\xa0\xa0\x08 \xdf\xd1\x00\x10\xfa\x99\x88\xa1\x07" +
+	"\x04t'\xc2\xb7h\xe8\x04\x01\x9d\x80\xa5\xcbb\xf1\xf6" +
+	"\x04\x8e\x02\x01G\x01\x1a\xf4\xafH8\xa1\x03\x00\x8e\x06" +
+	"l\x16\x91C\xa3\x01\x97\xb7\x86uB\xcceL-g" +
+	"\xa4\x17\xd3\xd2\xcf\x8aE\xda\x13\xd7j\xf1y\xcbb\xf3" +
+	"\x96\xc5\x9a#ILd\xe9aFZ\x0f\x93\x04\x0cv" +
+	"\xf7\xd9yz\xac\x9c\x0b8\x84\xbb\xa9\xddkc\xe1\xf6" +
+	"\xf9\xd1p,\x9a\xd2n\x81\xe8\x18e\x18\x9cmU1" +
+	"@h\x92\x88\xa1\x1a\x01\x8b\xf0[#\xa5\xa1)\xb4Z" +
+	")bh\xaa\x80\xa8\x82\x0b[\xc15L\xe4\xa6\xb6\xae" +
+	"X\xb5\xd6\xd6\x15\x9b\xd4\xac\xc6\xd5\xee\x04\xd8\xa5\x1dg" +
+	"YM\x0cG3\x8a\xca6Z\x93\xd4\xd6\x15Km\xd4" +
+	"\x09\x90iz\xd1l\xbed\xd9\x07\x82\xec\x94\xdc\xf4\x9d" +
+	"zlF\xcc\xeb9s\xa5XLO\xef\x0e\xd1\x81\x88" +
+	"r\xd5\x12k\x1b\x9e\xb4\xf1/\xa6\xadM\x151T/" +
+	"\xa0\xa1.\x08\xeb]\x8dZ\x07\xb8\xd5dDG\x8f\xd5" +
+	"\xa4\x01\xa2\x87+\x0b}\x00\xa8\x0e\x83r\xe8X\x8b7" +
+	"u\xf7\xe8}\x90K\xc8\xb6X4\xa1\xcf\xe1\x1eB<" +
+	"3\x86\xcbd|n8\x90\xf1\\\xb9\x00\xdd\xefI\x05" +
+	"\xe7\xd1\xffJr}\xe4\x9a\xa8\xd6\xac\xc7\xbf3Lz" +
+	"\xf48z\xac\xc0\xcc\x128\xb7\xcb\xcd\x09'P\xff\x01" +
+	".\x97i\xba\xf2\xb8\\\xa3\xd6A:Mp9\x95\x14" +
+	"OD\xf96\x8a\xc7\xdet\x98\xf1\x80D\x94W\xd0\xe2" +
+	"\xed\"\x86V\x0a\x88\x82\x82\x02\xa2\xbc\x8a\x82\xf4n\x11" +
+	"Ck(\x1eQA\x11Q^M\xbb\\\x99\x8aG\xd9" +
+	"!(df\xf9>z\xfb\xd7\"\x86\x1e\x16\xd0\xadk" +
+	"\xbdz\xda\xdb@F\xaf\xd4\x11\x8b\xb9\xdbU]\xc5\"" +
+	"\x10\xb0\x88\xd6\xc6I\xadj\xbc\xb4#\x12Su\x1c\x01" +
+	"\xc2\xe0\x88?\x1e\x9fU\x0f(\x85\xa3:\xe5\x96A\xc7" +
+	"\x0a\xc30\x00\xddIZ(\x00A.\xf0\xe5\xd8\xde\x82" +
+	"\xb8\xda\x932w\xb6!\x9e\x06\x08yD\x0c\x95\x08h" +
+	"t\x87;\xbb\xf4\xabc:6hs55\x12\xe9+" +
+	"\xe5\xef\xa0\xc7:d\xe51\x8e\x15\\s\xb5\x04\xd7#" +
+	"\xe4\xb3v,\xa9\x0f\x8b\xaf!~\xd7\x14Mv\xa7\xfc" +
+	"\xcem\x95U@\xa7\x1b!\x7f8\xcd\xcc\xa4\x8a\xf47" +
+	"\xab\xbcV\xa6@\x1c\x9e'\xec\xc9\x19[\xd1c\x1d\xba" +
+	"\xb3\xb6\xe80\x13\xb6\x1a\x89\xb4h7'\xb5h\x9bV" +
+	"\xdd\xa9\xe9W'\xbb[\xb5\xf8\xa4\xb9Z)\xdf\xb0}" +
+	"\xbb\xc5\xd6v1JF\xc1\x82\x1c\xa2\x93\xce\x1a\xd4\x84" +
+	"\x96m\x11\x9f\xf56\xcf#([\x87g@\x94s\xb0" +
+	"\xca\xc8&E\xdb4+?\x99\xd5\x15\xcd\xf3\x99,\xcf" +
+	"\x05A.\x94\x0cS~\xc0\xf8\xd04\x95\x1dm\xd7D" +
+	"\xb5FUW\xe7\x84\xc5\xc4\x0f\x097{0\x8f\xce\x91" +
+	"HgI1\xbd\xeb;\xf6\xdd\xaa&4\xf4X\xc7\xc6" +
+	"<I\x8cRT\x8b\x1eO\xb6\x95\xea\x97\xa9=\xd1<" +
+	"9f\x92\x80\xd2R\xad\xcd\x92.\xd3\xa1\xe4I\x06s" +
+	"\xb5\xce\xb8\x96H\x84c\xc8Y\x8e\xc9\xb0\\K2\xae" +
+	"\x111\xb4\xder\xaauT;\x1e\x101\xb4\xc1V\x9e" +
+	"\x1f!\xc2\x87E\x0c=k+\xcf\xcf\x90\xc6\x9e\x121" +
+	"\xf4\x1a\xa5\x03T\xd0\x01 \xef\")_\x121\xf4\x86" +
+	"\x80\xb2SP\xd0\x09 \xbfN\x8b;E\x0c\xbde\xe9" +
+	"\"sBH\xe9Bl\xad\xc1\x91 \xe0H@w\xab" +
+	"\xa6\xab\xe6\xeeF\xa6*v\xb0'\xa2F\xb5\x84\xb5\xe7" +
+	"L;\x9b\xda\xb3\xd4\xd7\x9d4\xdf\x97\xfa\x12\xed\xe6\xf3" +
+	"0GHe\\\xf2\x01*\xf4z\x1c\xb2\x1a\x16\xaf\xd5" +
+	"\xb0\xc8\x99\x8e\xc5ku,(\xa4\x1b\x16\xd2H\xbb\x88" +
+	"\xa1\x1e3A\x02\xc8\xdd\xbet\x17\xa3\x93\x89\xd4\x08\x8a" +
+	" \xa0\x08(\xb5'cf\xef\xe2\xee\xd1\xe3\xb5\xc3\xeb" +
+	"\x03-\xfb\xbf\xa3l\x98\x9ev\xbd\xbb\xba3\xdeC\x89" +
+	"\x81J\xaa\x8f2\x83\xdf\xca\x0c2\x89w\x01\xa5\x86\x19" +
+	"Vj(\xed\x08\xc7\x13:\x16\x82\x80\x85\x80\xc1\x84\xd6" +
+	"\x16\x8b\xb6\x9b\xff\x1c\xa6\xa0\x99\x91H\xac\xadA\x8b\xb6" +
+	"uu\xab\xf1\x9b\xaa/\x0fKZ\xa4=\xcb\x13[\x01" +
+	"B\xa3D\x0c\x8d\x11\xd0H\xe8\xf1p\xb4\xf3Z\x15\xa4" +
+	"HR\xcb\x9b\x09\xcd\xe4\x90\x18\x96\xb0\xfd\x96W\x97\x92" +
+	"k\xd8ll\x0f\x97\xd19uA\xb5\xe5;\xc2\xceV" +
+	"zrv\x0ci\x17\xc8N\xb5>\xbbB\xcd\\\xeb\xb3" +
+	"\x14z\x96&\xccQ\xf9\xcf&\x17eN\x12\xf9\xb4\x12" +
+	"Lh\xf1\xa5Z<\x8b\xa5i\xb3J\x01\x8dejX" +
+	"\x0fG;\x97\x80\x14k\xb5\xe9;s\xb4\xce\xc3\xb9A" +
+	"\x9a\x16\x98v\xe6,\x97\x15\xd9y\x14?oY\xcc\xdd" +
+	"\x1cI&\xb22\x92\xd7\xcaH\x19\xdd\xaf\xf3Z)\x09" +
+	"\x85\xac\x8c\xb4\xc9\x16\x7f\x1biq\xbd\x88\xa1'\xcc\x06" +
+	"\x05@~\x94\xde\xde b\xe8)[F\xdaL\x94\x9b" +
+	"D\x0c\xbdt\xe6H\xb5\xf7Z63g-Kz<" +
+	"s>qG\x12z\xc0\xd4\xab\xf3\xccg\x8ef=\xfe" +
+	"C\x1b\xc0\xcc`*\x9f\xadD\xb53\x8b]\x83e\xaa" +
+	"\xe5m\xa9\xd3\x1az\xac\x81{\x1ek5S\xe2\xe5u" +
+	"\x1d\xf2\x17\x0f\xcbT\xfe\xb4\xa9\xb6Z\xa6\xdaB\xfbx" +
+	"\xc2,\x1e\xf5Y\xc5c\xbb\xcdT\xcf\xd1)\xf0\xd9\x94" +
+	"Ud\xa7\x982\xd5\x0b\xb4\xf8|\xaa\xcc\xb8\xa3jw" +
+	"&\xc9\x94v\xc5\xba\xadt1\xa4\xb9\xe2%#\xae\x92" +
+	"\x93\x9b6\x09\xb6\xa9\xd1\xcb#}\x88  \x02\x1am" +
+	"j\x8f\xda\x16\xa6\x0e\x12L\x12\xa3[\xedm\xe9\xd1\xb4" +
+	"vZ\xcb. \xa6bgJ\x01\x7f\xcd\xd9\x07A&" +
+	"J\xa5%\xb1\xd6\xfc-\xde\xf0\xbc\xd3(\xa0\xd4\xd6\xdd" +
+	"nn\xde\xad\xc6;\x13Y\xa7\xdda\xb6\xcb\xa4q\x9c" +
+	"\xf9=\x0a\xff\x95\xb6\x882\x0b\xffF\xbf-\xa2\xcc\xc2" +
+	"\xff\xe8\x95\xe9\xe0\xd9J\xb6[\x9c\xb2\xdd\x10+\x9ba" +
+	"\xf6\x8c\xdf\xb2\xf2\x10\xdb\x19\xad\xe1\xb8\xde\xd5\xa8\xda\xd5" +
+	"_\xda\xd3\x15\x8bZ\x14\x89pk$\x1c\xedL\x10E" +
+	"\xba\xb1\x0d&zb\xc9\x84f\xda\xb0\xb4;\x16\xd5\xfa" +
+	"\xf2Z\x8a\x97\x07\xde>\x8e\xcal\xbc\x896^/b" +
+	"hN\xbaX\xd2\xe2l\x92\xb2Q\xc4P3\xed\\L" +
+	"\xed\xfc*\xb2\xc6,\x11C\xf3\x04t\xf7ij\xdcL" +
+	"\x11\xf4U\xbd\x0b] \xa0\x8b\x12\x86\xdag>\xe7\x0d" +
+	"q~\xec\xc8\x9c?\xbfo\x88\xdb\x0f)\xb9B|A" +
+	"0\xae\xf6\xf8{\xfdgu\x02\xca\xca^\xc3\x98_." +
+	"\xd6\xd6\x9d\xbd\x97\xe7h\xbb\xcf\"\xc7e\xae\xad\xf2\xf4" +
+	"\xb53\xd3\xff6{8s\xaab\x9f:YS\x15\xcd" +
+	"guqE\xc2icx\x1fW$\x9e2\xd2\x8d\x9c" +
+	"\xd7j\xe4\xdcKc\xe1vp\xb9[\xa7\x05\xa6\xa1\xc7" +
+	"\x1a\xc6\xa7\xab\x81\x1a\xf0\xd7\xa0\xc7\x1aa\xa7\x96\xa5\x8e" +
+	"\xda:\xf4X\xd3\xdc<Z\x9e\x19\x0c\xc7{bq\xae" +
+	"\x94\x92\x94\xdb\xf9\x88Vn\xf2\x02\xa0 _J\x7fD" +
+	"\xf9\"\xfa\xe3\x90\xa7\xd0\x1f\xa7\\N\x7f\\\xf2x\x1f" +
+	"\x80;\x1a\x8bj\xd2\x92\x8e\x9b\xa4\x88\xda+%:b" +
+	"R$\xb9Tj\xefX\xe6\xd6\xb5\x84>La\xdc\x1c" +
+	"\xf3\xb4\xde\xb4\x1f\xda\xc2\xc2k\x0f\x8btB\x98\xedM" +
+	"\x87\xc5b\x0a\x8bt2_D\x06\xbb.5A\x90\xf4" +
+	"\xcci\x1f\xa5\x88e\xb9t:\x0aF\x12\xbam\xf5\x0c" +
+	"\xb9jA\xca\x99{\"b2qV\x1em\x9f\xc2y" +
+	"\xf2\x1d\xc6\x1bU=U\xc8\xb2\xa6?\x1e\x00Oj\xf2" +
+	"3<\x8d\xa4\x94\xb55\xe3_\xec\x80\xc3\x07\xd0\xf2\xae" +
+	"C\xc4\x96\x8f\x1c6\x17c\x1f8\xc6\x01\xb4\xec'\xe0" +
+	"S\x87\x80\xe3\x85\xd3F*\x8f\xb2\xc3\x0e/@\xcb\xfb" +
+	"\x84|N\x88x\xcaH%Sv\x84#\x1f\x11\xf2\x05" +
+	"!\x8eo\x8c\xd4Q\x8a\x1d\xe3\xc8\xa7\x84|E\x88\xf3" +
+	"oF*\xad\xb2A\x8e|N\xc8\x09B\\'\x0d\x87" +
+	"\x82.\xba\"\xe0\xc8\x17\x84\x9c\"D:a\x14(|" +
+	"p|\x92\xcb\xf6\x95C\xc4\xb9N\x01\xc7\x17|M\x9f" +
+	")\xa0\x892\x7f\xe5\x04\xbd\xe2 \xa4\xf0\x7f\xe83\x85" +
+	"\x00\x0c\x9d\x84\x9c\"\xa4\x80\x90\x11\xc7\xe93#\x00\x98" +
+	"\x93\x90\xb9N\x11[F\x110\xf2+\xfa\xcaH\x00V" +
+	"\xe8\xa4\xaf8\x08\xf1\x102\xea\xafF\xbd\x82\xa3\x00X" +
+	"\x91\x93\x94V@\x88\xe2\x14\xb0\xa8\xe8KC\xc1\"\x00" +
+	"&s`\x14\x01c\x08\x18=h(8\x9a\xee\xf89" +
+	"\xe0!\xa0\x84\x00\xf7\x17\x86\x82n\xbaIt\xce\x00h" +
+	"Q\x08\xa8!\xc0\xf3\x17C!\x0b\xb2)\x1c\xa8$\xa0" +
+	"\x91\x00\xf9\xbf\x0d\x05e\xba\xc0\xe2\xc0%\x04\\G@" +
+	"\xf11C\xc1b\xbav\xe0@3\x01\x11\x02\xd8\xe7\x86" +
+	"\x82\x0c\x80\x859\xd0N\xc0\xed\x04(\x9f\x19\x0a*4" +
+	"\xe8w\xfa\x01Zz\x09XC\xc09G\x0d\x05\xcf\x01" +
+	"`\xab\xf9\x1b+\x09\xd8D\xc0\x8f\x8e\x18\x0a\xfe\x08\x80" +
+	"m\xe4\xc0z\x02\xb6\x13p\xee\xa7\x86\x82t\xbf\xf0\x1c" +
+	"\x07\xb6\x12\xf0\x06\x01c>1\x14\x1cCWA\xfc\x1b" +
+	";\x09\xf8\x88\x80\x92\x8f\x0d\x05\xc7\x92\x83q\x95\xec'" +
+	"\xe0\x04\x01\xe3\x0f\x1b\x0a\x8e#\xbb;\x1b\xc8\xee\\W" +
+	".\x01\x8b\xce\xff\xc8P\xf0<\xd2\x95\x8bX).\x11" +
+	"[&\x10P\xfa\xa1\xa1`\x09\x00\x1b\xcf\x811\x04L" +
+	"\"`\xc2!C\xc1\xf1\x00l\xa2\xab\x15\xa0e\x02\x01" +
+	"\x97\x100\xf1\x03C\xc1\xf3\x01\xd8\xc5\xae+\xe9R\x9d" +
+	"\x80F\x02\xbc\xef\x1b\x0a\x96\x92v]\xd7\xd3\x0f\x0e\x08" +
+	"\x98C@\xd9{\x86\x82\x13\xe8\x02\xc25\x17\xa0e\x16" +
+	"\x01\xf3\\\x02\x8e\x9ft\x90\x1ch\"\x00\x0b\xb9H\xde" +
+	"9\x84\\G\xaf\x94\x1f0\x14\xf4\x92A\\\xb4\xc3f" +
+	"\x02n \xa0b\xbf\xa1`\x19\xddKq`\x1e\x01\x8b" +
+	"\x09\xf8\xf1\x7f\x19\x0aN\xa2\xfb\"\x17y\xe9u\x04\xb4" +
+	"\x13P\xb9\xcfP\xb0\x1c\x80\xa9\\\xde\xc5\x04\xfc\x8c\x80" +
+	"\xaa\xbd\x86\x82\x15t\xab\xca\x81^\x02\xd6\x100\xf6]" +
+	"C\xc1\x1f\x93\x09\xb9T+\x09\xd8D\xc0\xb8w\x0c\x05" +
+	"+\xc9\x84\x1cXO\xc0v\x02\xce{\xdbP\xb0\x8aL" +
+	"\xc8\x81\xad\x04\xbcA\x80o\x8f\x81\xb6Kn\xf6\xba\xcb" +
+	"\x0bB\xd1\x05o\x19\x0aN\xa6Ke\xbe\x89\xa72|" +
+	"&\xff\xa7\xa1\xe0\x14\xce\x874\xf5,\x01/\xb925" +
+	"G\xbc\xe5\x16\xf4Xw[fi\xa9\x9b\x9a\x19ut" +
+	"\x04\xfc4F\xc5\x11\x80R\xb8n\xaa\xd9JI\xe1\x80" +
+	"\xdfl\x9a\xa4pm\x9d\xd9\xbb\x88\xe1\xe9(\x80\x80\x02" +
+	"\xa0\x94\xac\x9bj\x0e\x01\xa4d\xc0o\xce\xf5\xa4dm" +
+	"\x1dJ \xa0\x04(&\xa7\x9b\xbd\x8d\xbb5\x16\x8b\x98" +
+	"\x8d\x97}\xce\x8b\xee\xd6H\xac\xd5<e\x07;\xea\xa6" +
+	"\xdafS\xe6\xf4\xa6#\xe0\xb7\xad\x8eH\xaf\x86\x87\xd0" +
+	":\xcd\xd5!\xb4\x0es\xb5\xb6\xce\xb6*\xa6VK\xc3" +
+	"\xd3m\x8bB\x9a49\x84m\xa1\xb9:\x84m\x81\xb9" +
+	":\x84\xad\x94f\x9b\xb4\xb3u\xa5\x16\xdd\xb7\x0c\x99\xb9" +
+	"\xd9\x8dB\x17M\x84\xda\x08\xf2\xd1\x95\xdeB=\xe9\xb0" +
+	"\xe2\x93Z\xa7{\x8e\xcc\x8f\x13\xb2\xaa\x18\x0c\x1d\xf9e" +
+	"\x8d\xbf,2\x80,\x94\x98\xc4\xd3\x03@\x10cQ\xf4" +
+	"X\xbf\xefH\xc3|\xb6\xd6\xaa&\x00s\xf4t\xcb\xd5" +
+	"T\x8b\x92u\xc0r\x03\xfe\x9ftD\\\x1fK\xb56" +
+	"\xdb\xdd]\xf6\xbdL\x8aH\xcd&\xb2k\x8a_\xf5\xc5" +
+	"b\x11\x9b\x8e\xd2W}\xcb\xd3\xaf\x9a\xcbE\xe9e\xf2" +
+	"`\xdbr\xba+\x91:\xe3=\xf9\x86\xd4\x9a9\xdf\xc6" +
+	"\x04z\xac\x1f\x9c\xe4\xe962c\xb4R~\x00\x0b9" +
+	"\xd0\xfe\x8b\x16\xf4\x97^\x1e\xd6\"\xed\xf9:\xe1\x0e\x02" +
+	"m\x93\x99\xcc\x9by:\xe1\xab\xb5\x84\xae\xc5k/S" +
+	"\xc5aSc\x9f\xc5\xd6\x9d\xd0\xe3y\xcf\x8cg\xb8\x17" +
+	"hV\xddt\xcb\x98g\x98\xd3\xa8\xea\xa8~\x9f\xd3\xac" +
+	"\xd7vc\x91g\xec2||\xbd`\x9e\x96\xa0\x818" +
+	"f\xef\xed\xfa\xf4Lk\xba\x80FTK\xe8W\xa9z" +
+	"\x1c\xc4p\xef\xb0(<\xd3\x84<s3\x80\xeaw\\" +
+	"\xec\xd9\x04\xfe\xdf\x01\x00\x0b\x0c\xea\x98"
 
 func init() {
 	schemas.Register(schema_832bcc6686a26d56,
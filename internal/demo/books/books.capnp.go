@@ -23,11 +23,28 @@ func NewRootBook(s *capnp.Segment) (Book, error) {
 	return Book{st}, err
 }
 
+func AllocateRootBook(msg *capnp.Message) (Book, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return Book{st}, err
+}
+
 func ReadRootBook(msg *capnp.Message) (Book, error) {
 	root, err := msg.RootPtr()
 	return Book{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Book) Equal(other Book) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Book) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Book) String() string {
 	str, _ := text.Marshal(0x8100cc88d7d4d47c, s.Struct)
 	return str
@@ -69,6 +86,13 @@ func NewBook_List(s *capnp.Segment, sz int32) (Book_List, error) {
 	return Book_List{l}, err
 }
 
+// NewRootBookListMessage creates a message whose root is a new
+// list of n Book.  The message's segment is sized to fit the list.
+func NewRootBookListMessage(n int32) (*capnp.Message, Book_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 1}, n)
+	return msg, Book_List{l}, err
+}
+
 func (s Book_List) At(i int) Book { return Book{s.List.Struct(i)} }
 
 func (s Book_List) Set(i int, v Book) error { return s.List.SetStruct(i, v.Struct) }
@@ -82,21 +106,20 @@ func (s Book_List) String() string {
 type Book_Promise struct{ *capnp.Pipeline }
 
 func (p Book_Promise) Struct() (Book, error) {
-	s, err := p.Pipeline.Struct()
-	return Book{s}, err
-}
-
-const schema_85d3acc39d94e0f8 = "x\xda\x12\x88w`2d\xdd\xcf\xc8\xc0\x10(\xc2\xca" +
-	"\xb6\xbf\xe6\xca\x95\xeb\x1dg\x1a\x03y\x18\x19\xff\xffx" +
-	"0e\xee\xe15\x97[\x19X\x19\xd9\x19\x18\x04\x8fv" +
-	"\x09\x9e\x05\xd1'\xcb\x19t\xff'\xe5\xe7g\x17\xeb%" +
-	"'2\x16\xe4\x15X9\xe5\xe7g30\x0402\x06" +
-	"r0\xb300\xb0020\x08j\x1a10\x04\xaa" +
-	"03\x06\x1a0122\x8a0\x82\xc4t\x83\x18\x18" +
-	"\x02u\x98\x19\x03-\x98\x18\xe5K2KrR\x19y" +
-	"\x18\x98\x18y\x18\x18\xff\x17$\xa6\xa7:\xe7\x97\xe61" +
-	"0\x960\xb2001\xb200\x02\x02\x00\x00\xff\xff" +
-	"F\xa9$\xae"
+	return capnp.PipelineStruct[Book](p.Pipeline)
+}
+
+const schema_85d3acc39d94e0f8 = "x\xda2\xc8\xe0p`2dewb`\x08bf" +
+	"e\xdb_s\xe5\xca\xf5\x8e3\x8d\x81<\x8c\x8c\xff\x7f" +
+	"<\x982\xf7\xf0\x9a\xcb\xad\x0c\xac\x8c\xec\x0c\x0c\xc2/" +
+	"\x15\xba\x84?*\x80Xo\x15\xca\x19\xfe\xa3A\xb3\xff" +
+	"I\xf9\xf9\xd9\xc5z\xc9\x89\x8c\x05y\x05VN\xf9\xf9" +
+	"\xd9\x0c\x0c\x01\x8c\x8c\x81\x1c\xcc,\x0c\x0c,\x8c\x0c\x0c" +
+	"\x82\x9aF\x0c\x0c\x81*\xcc\x8c\x81\x06L\x8c\x8c\x8c\"" +
+	"\x8c 1\xdd \x06\x86@\x1df\xc6@\x0b&F\xf9" +
+	"\x92\xcc\x92\x9cTF\x1e\x06&F\x1e\x06\xc6\xff\x05\x89" +
+	"\xe9\xa9\xce\xf9\xa5y\x0c\x8c%\x8c,\x0cL\x8c,\x0c" +
+	"\x8c\x80\x01\x00BV-+"
 
 func init() {
 	schemas.Register(schema_85d3acc39d94e0f8,
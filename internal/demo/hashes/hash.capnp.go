@@ -3,11 +3,11 @@
 package hashes
 
 import (
-	context "golang.org/x/net/context"
 	capnp "github.com/iguazio/go-capnproto2"
 	text "github.com/iguazio/go-capnproto2/encoding/text"
 	schemas "github.com/iguazio/go-capnproto2/schemas"
 	server "github.com/iguazio/go-capnproto2/server"
+	context "golang.org/x/net/context"
 )
 
 type HashFactory struct{ Client capnp.Client }
@@ -42,7 +42,15 @@ type HashFactory_Server interface {
 
 func HashFactory_ServerToClient(s HashFactory_Server) HashFactory {
 	c, _ := s.(server.Closer)
-	return HashFactory{Client: server.New(HashFactory_Methods(nil, s), c)}
+	return HashFactory{Client: server.NewTable(HashFactory_Table(s), c)}
+}
+
+// HashFactory_Table returns the methods of s grouped by interface, for server.NewTable.
+func HashFactory_Table(s HashFactory_Server) server.Table {
+	methods := HashFactory_Methods(nil, s)
+	return server.Table{
+		{ID: 0xaead580f97fddabc, Methods: methods[0:1:1]},
+	}
 }
 
 func HashFactory_Methods(methods []server.Method, s HashFactory_Server) []server.Method {
@@ -90,11 +98,28 @@ func NewRootHashFactory_newSha1_Params(s *capnp.Segment) (HashFactory_newSha1_Pa
 	return HashFactory_newSha1_Params{st}, err
 }
 
+func AllocateRootHashFactory_newSha1_Params(msg *capnp.Message) (HashFactory_newSha1_Params, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return HashFactory_newSha1_Params{st}, err
+}
+
 func ReadRootHashFactory_newSha1_Params(msg *capnp.Message) (HashFactory_newSha1_Params, error) {
 	root, err := msg.RootPtr()
 	return HashFactory_newSha1_Params{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s HashFactory_newSha1_Params) Equal(other HashFactory_newSha1_Params) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s HashFactory_newSha1_Params) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s HashFactory_newSha1_Params) String() string {
 	str, _ := text.Marshal(0x92b20ad1a58ca0ca, s.Struct)
 	return str
@@ -109,6 +134,13 @@ func NewHashFactory_newSha1_Params_List(s *capnp.Segment, sz int32) (HashFactory
 	return HashFactory_newSha1_Params_List{l}, err
 }
 
+// NewRootHashFactory_newSha1_ParamsListMessage creates a message whose root is a new
+// list of n HashFactory_newSha1_Params.  The message's segment is sized to fit the list.
+func NewRootHashFactory_newSha1_ParamsListMessage(n int32) (*capnp.Message, HashFactory_newSha1_Params_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 0}, n)
+	return msg, HashFactory_newSha1_Params_List{l}, err
+}
+
 func (s HashFactory_newSha1_Params_List) At(i int) HashFactory_newSha1_Params {
 	return HashFactory_newSha1_Params{s.List.Struct(i)}
 }
@@ -126,8 +158,7 @@ func (s HashFactory_newSha1_Params_List) String() string {
 type HashFactory_newSha1_Params_Promise struct{ *capnp.Pipeline }
 
 func (p HashFactory_newSha1_Params_Promise) Struct() (HashFactory_newSha1_Params, error) {
-	s, err := p.Pipeline.Struct()
-	return HashFactory_newSha1_Params{s}, err
+	return capnp.PipelineStruct[HashFactory_newSha1_Params](p.Pipeline)
 }
 
 type HashFactory_newSha1_Results struct{ capnp.Struct }
@@ -145,11 +176,28 @@ func NewRootHashFactory_newSha1_Results(s *capnp.Segment) (HashFactory_newSha1_R
 	return HashFactory_newSha1_Results{st}, err
 }
 
+func AllocateRootHashFactory_newSha1_Results(msg *capnp.Message) (HashFactory_newSha1_Results, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return HashFactory_newSha1_Results{st}, err
+}
+
 func ReadRootHashFactory_newSha1_Results(msg *capnp.Message) (HashFactory_newSha1_Results, error) {
 	root, err := msg.RootPtr()
 	return HashFactory_newSha1_Results{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s HashFactory_newSha1_Results) Equal(other HashFactory_newSha1_Results) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s HashFactory_newSha1_Results) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s HashFactory_newSha1_Results) String() string {
 	str, _ := text.Marshal(0xea3e50f7663f7bdf, s.Struct)
 	return str
@@ -169,7 +217,7 @@ func (s HashFactory_newSha1_Results) SetHash(v Hash) error {
 	if v.Client == nil {
 		return s.Struct.SetPtr(0, capnp.Ptr{})
 	}
-	seg := s.Segment()
+	seg := s.Struct.Segment()
 	in := capnp.NewInterface(seg, seg.Message().AddCap(v.Client))
 	return s.Struct.SetPtr(0, in.ToPtr())
 }
@@ -183,6 +231,13 @@ func NewHashFactory_newSha1_Results_List(s *capnp.Segment, sz int32) (HashFactor
 	return HashFactory_newSha1_Results_List{l}, err
 }
 
+// NewRootHashFactory_newSha1_ResultsListMessage creates a message whose root is a new
+// list of n HashFactory_newSha1_Results.  The message's segment is sized to fit the list.
+func NewRootHashFactory_newSha1_ResultsListMessage(n int32) (*capnp.Message, HashFactory_newSha1_Results_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, HashFactory_newSha1_Results_List{l}, err
+}
+
 func (s HashFactory_newSha1_Results_List) At(i int) HashFactory_newSha1_Results {
 	return HashFactory_newSha1_Results{s.List.Struct(i)}
 }
@@ -200,8 +255,7 @@ func (s HashFactory_newSha1_Results_List) String() string {
 type HashFactory_newSha1_Results_Promise struct{ *capnp.Pipeline }
 
 func (p HashFactory_newSha1_Results_Promise) Struct() (HashFactory_newSha1_Results, error) {
-	s, err := p.Pipeline.Struct()
-	return HashFactory_newSha1_Results{s}, err
+	return capnp.PipelineStruct[HashFactory_newSha1_Results](p.Pipeline)
 }
 
 func (p HashFactory_newSha1_Results_Promise) Hash() Hash {
@@ -262,7 +316,15 @@ type Hash_Server interface {
 
 func Hash_ServerToClient(s Hash_Server) Hash {
 	c, _ := s.(server.Closer)
-	return Hash{Client: server.New(Hash_Methods(nil, s), c)}
+	return Hash{Client: server.NewTable(Hash_Table(s), c)}
+}
+
+// Hash_Table returns the methods of s grouped by interface, for server.NewTable.
+func Hash_Table(s Hash_Server) server.Table {
+	methods := Hash_Methods(nil, s)
+	return server.Table{
+		{ID: 0xf29f97dd675a9431, Methods: methods[0:2:2]},
+	}
 }
 
 func Hash_Methods(methods []server.Method, s Hash_Server) []server.Method {
@@ -332,11 +394,28 @@ func NewRootHash_write_Params(s *capnp.Segment) (Hash_write_Params, error) {
 	return Hash_write_Params{st}, err
 }
 
+func AllocateRootHash_write_Params(msg *capnp.Message) (Hash_write_Params, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Hash_write_Params{st}, err
+}
+
 func ReadRootHash_write_Params(msg *capnp.Message) (Hash_write_Params, error) {
 	root, err := msg.RootPtr()
 	return Hash_write_Params{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Hash_write_Params) Equal(other Hash_write_Params) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Hash_write_Params) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Hash_write_Params) String() string {
 	str, _ := text.Marshal(0xdffe94ae546cdee3, s.Struct)
 	return str
@@ -365,6 +444,13 @@ func NewHash_write_Params_List(s *capnp.Segment, sz int32) (Hash_write_Params_Li
 	return Hash_write_Params_List{l}, err
 }
 
+// NewRootHash_write_ParamsListMessage creates a message whose root is a new
+// list of n Hash_write_Params.  The message's segment is sized to fit the list.
+func NewRootHash_write_ParamsListMessage(n int32) (*capnp.Message, Hash_write_Params_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, Hash_write_Params_List{l}, err
+}
+
 func (s Hash_write_Params_List) At(i int) Hash_write_Params {
 	return Hash_write_Params{s.List.Struct(i)}
 }
@@ -382,8 +468,7 @@ func (s Hash_write_Params_List) String() string {
 type Hash_write_Params_Promise struct{ *capnp.Pipeline }
 
 func (p Hash_write_Params_Promise) Struct() (Hash_write_Params, error) {
-	s, err := p.Pipeline.Struct()
-	return Hash_write_Params{s}, err
+	return capnp.PipelineStruct[Hash_write_Params](p.Pipeline)
 }
 
 type Hash_write_Results struct{ capnp.Struct }
@@ -401,11 +486,28 @@ func NewRootHash_write_Results(s *capnp.Segment) (Hash_write_Results, error) {
 	return Hash_write_Results{st}, err
 }
 
+func AllocateRootHash_write_Results(msg *capnp.Message) (Hash_write_Results, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return Hash_write_Results{st}, err
+}
+
 func ReadRootHash_write_Results(msg *capnp.Message) (Hash_write_Results, error) {
 	root, err := msg.RootPtr()
 	return Hash_write_Results{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Hash_write_Results) Equal(other Hash_write_Results) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Hash_write_Results) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Hash_write_Results) String() string {
 	str, _ := text.Marshal(0x80ac741ec7fb8f65, s.Struct)
 	return str
@@ -420,6 +522,13 @@ func NewHash_write_Results_List(s *capnp.Segment, sz int32) (Hash_write_Results_
 	return Hash_write_Results_List{l}, err
 }
 
+// NewRootHash_write_ResultsListMessage creates a message whose root is a new
+// list of n Hash_write_Results.  The message's segment is sized to fit the list.
+func NewRootHash_write_ResultsListMessage(n int32) (*capnp.Message, Hash_write_Results_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 0}, n)
+	return msg, Hash_write_Results_List{l}, err
+}
+
 func (s Hash_write_Results_List) At(i int) Hash_write_Results {
 	return Hash_write_Results{s.List.Struct(i)}
 }
@@ -437,8 +546,7 @@ func (s Hash_write_Results_List) String() string {
 type Hash_write_Results_Promise struct{ *capnp.Pipeline }
 
 func (p Hash_write_Results_Promise) Struct() (Hash_write_Results, error) {
-	s, err := p.Pipeline.Struct()
-	return Hash_write_Results{s}, err
+	return capnp.PipelineStruct[Hash_write_Results](p.Pipeline)
 }
 
 type Hash_sum_Params struct{ capnp.Struct }
@@ -456,11 +564,28 @@ func NewRootHash_sum_Params(s *capnp.Segment) (Hash_sum_Params, error) {
 	return Hash_sum_Params{st}, err
 }
 
+func AllocateRootHash_sum_Params(msg *capnp.Message) (Hash_sum_Params, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return Hash_sum_Params{st}, err
+}
+
 func ReadRootHash_sum_Params(msg *capnp.Message) (Hash_sum_Params, error) {
 	root, err := msg.RootPtr()
 	return Hash_sum_Params{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Hash_sum_Params) Equal(other Hash_sum_Params) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Hash_sum_Params) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Hash_sum_Params) String() string {
 	str, _ := text.Marshal(0xe74bb2d0190cf89c, s.Struct)
 	return str
@@ -475,6 +600,13 @@ func NewHash_sum_Params_List(s *capnp.Segment, sz int32) (Hash_sum_Params_List,
 	return Hash_sum_Params_List{l}, err
 }
 
+// NewRootHash_sum_ParamsListMessage creates a message whose root is a new
+// list of n Hash_sum_Params.  The message's segment is sized to fit the list.
+func NewRootHash_sum_ParamsListMessage(n int32) (*capnp.Message, Hash_sum_Params_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 0}, n)
+	return msg, Hash_sum_Params_List{l}, err
+}
+
 func (s Hash_sum_Params_List) At(i int) Hash_sum_Params { return Hash_sum_Params{s.List.Struct(i)} }
 
 func (s Hash_sum_Params_List) Set(i int, v Hash_sum_Params) error {
@@ -490,8 +622,7 @@ func (s Hash_sum_Params_List) String() string {
 type Hash_sum_Params_Promise struct{ *capnp.Pipeline }
 
 func (p Hash_sum_Params_Promise) Struct() (Hash_sum_Params, error) {
-	s, err := p.Pipeline.Struct()
-	return Hash_sum_Params{s}, err
+	return capnp.PipelineStruct[Hash_sum_Params](p.Pipeline)
 }
 
 type Hash_sum_Results struct{ capnp.Struct }
@@ -509,11 +640,28 @@ func NewRootHash_sum_Results(s *capnp.Segment) (Hash_sum_Results, error) {
 	return Hash_sum_Results{st}, err
 }
 
+func AllocateRootHash_sum_Results(msg *capnp.Message) (Hash_sum_Results, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Hash_sum_Results{st}, err
+}
+
 func ReadRootHash_sum_Results(msg *capnp.Message) (Hash_sum_Results, error) {
 	root, err := msg.RootPtr()
 	return Hash_sum_Results{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Hash_sum_Results) Equal(other Hash_sum_Results) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Hash_sum_Results) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Hash_sum_Results) String() string {
 	str, _ := text.Marshal(0xd093963b95a4e107, s.Struct)
 	return str
@@ -542,6 +690,13 @@ func NewHash_sum_Results_List(s *capnp.Segment, sz int32) (Hash_sum_Results_List
 	return Hash_sum_Results_List{l}, err
 }
 
+// NewRootHash_sum_ResultsListMessage creates a message whose root is a new
+// list of n Hash_sum_Results.  The message's segment is sized to fit the list.
+func NewRootHash_sum_ResultsListMessage(n int32) (*capnp.Message, Hash_sum_Results_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, Hash_sum_Results_List{l}, err
+}
+
 func (s Hash_sum_Results_List) At(i int) Hash_sum_Results { return Hash_sum_Results{s.List.Struct(i)} }
 
 func (s Hash_sum_Results_List) Set(i int, v Hash_sum_Results) error {
@@ -557,40 +712,39 @@ func (s Hash_sum_Results_List) String() string {
 type Hash_sum_Results_Promise struct{ *capnp.Pipeline }
 
 func (p Hash_sum_Results_Promise) Struct() (Hash_sum_Results, error) {
-	s, err := p.Pipeline.Struct()
-	return Hash_sum_Results{s}, err
-}
-
-const schema_db8274f9144abc7e = "x\xda\x84\x92?h\x13a\x18\xc6\x9f\xe7\xbb;\xaf\xa8" +
-	"G\xfaq\x05\xe9bP\"\x82\xd8\xe2\xb5[\x05\x13\x1c" +
-	"Tt\xb9\x8b\x0e\xe2\xf6QO#$\xb5\xe4.\x14\x11" +
-	"\xff\xd0\xc5E\x10\xb4\xdaE\xd0A7\xed\xd0Q\xba\x8a" +
-	"(\x08u\xb4\x12\x8b:\x08\xdd\xec\xa2\xa5\xd4\x93\xef\x92" +
-	"k\x02!v\xfb\xe0y\xdf\xe7\x9e\xf7w\xcf\xe0fI" +
-	"x\xd6a\x13\x08\x8eY\xbb\x92\xf0\xc1\xe6\xbb\xfd\xf1\xab" +
-	"\xbb\x90\x83\x04L\x1bp7\xb8\x0e3\xf9\xf0\xfc\xfe\xcb" +
-	"O\xbb\x17\x1fB\xeek\x0b\xe3M\x8e\x11f\xb2\xb4\xb2" +
-	"5\x9f\xbb\xf8z\x01r\x8f\x91\xdc^:;\xb4\x11\xcf" +
-	"~\x01\xe8\xbe\xe5\x1b\xf7#\xb5\xc5{\x9ev\x7f\xe9W" +
-	"b\x7f{\xf1\xf8\xf8\x93G\xcb-\x7f+U?\xf3;" +
-	"\xe86Y\x04\x93\x1f_\xab\x17\x16\xe6\xfe\xaev\xeb[" +
-	"\\\x03]\x0a\xad?\xfd\xb3wxy\xf1\xdc\xcf\xae|" +
-	"\x07\xc4\x0a\xccd\xf5f\xf1\xcao\xff\xc4Z+_\xba" +
-	"8n\x89\x09\x82\xae\x93nzs\x97\xae6\xe7\x9f\xad" +
-	"\xf7\xc4\x1c\x11\xb3\xae'\xb4\xd3\x88\xb8\xe7\xde\x126\x8e" +
-	"&\x15\x15UF'\xd5\xb4\x98\x9a\x9e8\xa3\xdf3\xf5" +
-	"kqX(\x87\xf9\xa8Q\x8d\xa3m\xddh\xeb\xa7\xd4" +
-	"d|\xbd~ct*\x9c9_Q^\xc1\xcf\xab\xba" +
-	"\xaau\xe6\x98\xcd\x15[\x83>\x19\x98\x86\x05lse" +
-	"v\x80\x94'!\xa4e\xdfi{\x95\xe8\x93\xbd\x81\xa2" +
-	"F\xadP\x0e\xa3\x86]\x8d\xa3\xc04L\xc0$ \x9d" +
-	"#@0`0\x18\x12\xcc\xe9%:\x10t\xc0~'" +
-	"\xf9*\xa7\x93\xf6\xb3\xb8\xacb\xd5\xdfB\x87\xf0U]" +
-	"\x19\xb5\x9d\x91\x94\x8ba\xca\xee\xbfae\xe77\x81\x94" +
-	"]\xdf\xcc\x08B\xa3\x1bH\xd1e]aVZ\xe9\x8d" +
-	"A\xc8C6;=aV89|\x10B:v>" +
-	"=\xbbD;j\xd4R\xb4\xff\x02\x00\x00\xff\xff<." +
-	"\xe3\xa6"
+	return capnp.PipelineStruct[Hash_sum_Results](p.Pipeline)
+}
+
+const schema_db8274f9144abc7e = "x\xda\x84\x92?hSQ\x18\xc5\xcf\xf7\xfex\x83\x1a" +
+	"\xe2\xe5\x16\xa4C\xcd}\x12\x17\x87b\xda\xad\x82\x09\x1d" +
+	"Tty\x89\x0e\xe2v\xa9O#\xe4\xd5\x92\xf7B\x11" +
+	"A\xa5\x8b\x8b h\xb5\x8b\xa0\x83n\xda\xa1 \xa2\xd4" +
+	"Y\x14\x0au\xadh\xa9\x0ej\xc1\xc1\x82\xa8H\xbdr" +
+	"_\xf2\x9aJ\x88\xe5-\x17\xce\xf7\x1d\xce\xf7{\xe7\xc0" +
+	"\xd3L\xd9*\xbal\x14\xa8\xda\xee6\x1d\xdc\xf8\xfdr" +
+	"O\xfc\xe8*\xf8.\x02\x1c\x06\x88/r\x0d\x8e~}" +
+	"\xff\xfa\xc37\xdb\xe7n\x82\xefn\x0b\xc3\x0br\x88\xe0" +
+	"\xe8\xf9\xa5\xf5\x99\xdc\xa9\xc7\xb3\xe0;l}y\xfeX" +
+	"\xdf\xafx\xea-@\xe2\x89|.^Hc\xf1L\x1e" +
+	"\x11+\xe6\xa5\xd9\xca\x83\xdb\x07\xef\xdcZl\xf9\xbbd" +
+	"\xd4W\xf2\x03H,\xc8\x12H\x7f|_?9;\xfd" +
+	"gy\xb3\xfeU\xae\x82\xc4\xb7D\xbf\xfbsg\xff\xe2" +
+	"\xdc\xf1O\x9b\xf2qo\x09\x8e^\xbeT:\xfb\xc3?" +
+	"\xb4\xda\xca\x97,\x0e\x7f\x97#\x04\x12\xeb\xc9fq\xfa" +
+	"\xf4\xb9w3\xf7\xd6\xbab\x0exS\xc2\xf3\x8c\xd3\x80" +
+	"wM\x84\x1e\x83\xfe\xe7\xfb\xack*\xaa\x0d\x8e\xa9\x09" +
+	"k|b\xe4\xa8yO6\xce\xc7A\xa1\x1a\xe4\xa3f" +
+	"=\x8e6t\xbb\xad\x1fVc\xf1\x85\xc6\xc5\xc1\xf1`" +
+	"\xf2DM\x15\x0b~^5T\xd8\x99\xa3t\xae\xd4\x1a" +
+	"\xf4\x89*\x8e\xed\x02\x1b\x94)=\x87\xf3QX\xdce" +
+	"W\xda^e\xf2\x89\xba\x03E\xcd\xb0P\x0d\xa2&\xab" +
+	"\xc7Q\xc5\xb1\x1d\xc0!\x80g\xf7\x03\x95\x8cM\x95>" +
+	"\x8brf\x89\xb2\xb0(\x0b\xeau\x92\xafr&i/" +
+	"\x8b3*V\xbd-L\x08_5\x94\x1dn\x8d\xa4Z" +
+	"\x0a\x12v\xff\x0d\xcb;?\x0dD\x1c\xd4E\x10\x06]" +
+	"&A\x976\x87\xd2\x0a\xf3\xe2\x10,\xbe\x8fQ\xa75" +
+	"\x94\xd6\x8f\xf7\xef\x85\xc5\xb3,\x9f\x9c]&\x165\xc3" +
+	"\x04\xed\xdf\x01\x000\x06\xef-"
 
 func init() {
 	schemas.Register(schema_db8274f9144abc7e,
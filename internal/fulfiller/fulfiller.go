@@ -7,7 +7,7 @@ import (
 	"sync"
 
 	"github.com/iguazio/go-capnproto2"
-	"github.com/iguazio/go-capnproto2/internal/queue"
+	"github.com/iguazio/go-capnproto2/queue"
 )
 
 // callQueueSize is the maximum number of pending calls.
@@ -172,6 +172,16 @@ func (f *Fulfiller) PipelineClose(transform []capnp.PipelineOp) error {
 	return f.Peek().PipelineClose(transform)
 }
 
+// PipelineClientState reports the state of a capability in the
+// answer.  Capabilities in an unresolved answer are unresolved.
+func (f *Fulfiller) PipelineClientState(transform []capnp.PipelineOp) (resolved bool, brand interface{}, remote bool) {
+	ps, ok := f.Peek().(capnp.PipelineStater)
+	if !ok {
+		return false, nil, false
+	}
+	return ps.PipelineClientState(transform)
+}
+
 // pcall is a queued pipeline call.
 type pcall struct {
 	transform []capnp.PipelineOp
@@ -185,17 +195,20 @@ type pcall struct {
 type EmbargoClient struct {
 	client capnp.Client
 
-	mu    sync.RWMutex
-	q     queue.Queue
-	calls ecallList
+	mu sync.RWMutex
+	q  *queue.Queue[ecall]
 }
 
-func newEmbargoClient(client capnp.Client, queue []ecall) capnp.Client {
+func newEmbargoClient(client capnp.Client, calls []ecall) capnp.Client {
 	ec := &EmbargoClient{
 		client: client,
-		calls:  make(ecallList, callQueueSize),
+		q:      queue.New[ecall](callQueueSize, nil),
+	}
+	for _, c := range calls {
+		if ec.q.Push(c) != nil {
+			c.f.Reject(errCallQueueFull)
+		}
 	}
-	ec.q.Init(ec.calls, copy(ec.calls, queue))
 	go ec.flushQueue()
 	return ec
 }
@@ -206,21 +219,16 @@ func (ec *EmbargoClient) push(cl *capnp.Call) capnp.Answer {
 	if err != nil {
 		return capnp.ErrorAnswer(err)
 	}
-	i := ec.q.Push()
-	if i == -1 {
+	if ec.q.Push(ecall{cl, f}) != nil {
 		return capnp.ErrorAnswer(errCallQueueFull)
 	}
-	ec.calls[i] = ecall{cl, f}
 	return f
 }
 
 // flushQueue is run in its own goroutine.
 func (ec *EmbargoClient) flushQueue() {
-	var c ecall
 	ec.mu.Lock()
-	if i := ec.q.Front(); i != -1 {
-		c = ec.calls[i]
-	}
+	c, _ := ec.q.Front()
 	ec.mu.Unlock()
 	for c.call != nil {
 		ans := ec.client.Call(c.call)
@@ -234,11 +242,7 @@ func (ec *EmbargoClient) flushQueue() {
 		}(c.f, ans)
 		ec.mu.Lock()
 		ec.q.Pop()
-		if i := ec.q.Front(); i != -1 {
-			c = ec.calls[i]
-		} else {
-			c = ecall{}
-		}
+		c, _ = ec.q.Front()
 		ec.mu.Unlock()
 	}
 }
@@ -295,13 +299,24 @@ func (ec *EmbargoClient) TryQueue(cl *capnp.Call) capnp.Answer {
 	return ans
 }
 
+// QueueStats returns the statistics of the client's queue of calls
+// waiting to be delivered to the underlying client.
+func (ec *EmbargoClient) QueueStats() queue.Stats {
+	return ec.q.Stats()
+}
+
+// ClientState returns the state of the underlying client.  Queued
+// calls don't affect the state, since they will be delivered to it.
+func (ec *EmbargoClient) ClientState() (resolved bool, brand interface{}, remote bool) {
+	return capnp.ClientState(ec.client)
+}
+
 // Close closes the underlying client, rejecting any queued calls.
 func (ec *EmbargoClient) Close() error {
 	ec.mu.Lock()
 	// reject all queued calls
-	for ec.q.Len() > 0 {
-		ec.calls[ec.q.Front()].f.Reject(errQueueCallCancel)
-		ec.q.Pop()
+	for c, ok := ec.q.Pop(); ok; c, ok = ec.q.Pop() {
+		c.f.Reject(errQueueCallCancel)
 	}
 	ec.mu.Unlock()
 	return ec.client.Close()
@@ -313,16 +328,6 @@ type ecall struct {
 	f    *Fulfiller
 }
 
-type ecallList []ecall
-
-func (el ecallList) Len() int {
-	return len(el)
-}
-
-func (el ecallList) Clear(i int) {
-	el[i] = ecall{}
-}
-
 var (
 	errCallQueueFull   = errors.New("capnp: promised answer call queue full")
 	errQueueCallCancel = errors.New("capnp: queued call canceled")
@@ -90,6 +90,24 @@ func TestFulfiller_QueuedCallsDeliveredInOrder(t *testing.T) {
 	check(ans4, 3)
 }
 
+func TestFulfiller_ClientState(t *testing.T) {
+	f := new(Fulfiller)
+	oc := new(orderClient)
+	result := newStruct(t, capnp.ObjectSize{PointerCount: 1})
+	in := result.Segment().Message().AddCap(oc)
+	result.SetPointer(0, capnp.NewInterface(result.Segment(), in))
+	client := capnp.NewPipeline(f).GetPipeline(0).Client()
+
+	if resolved, brand, remote := capnp.ClientState(client); resolved || brand != nil || remote {
+		t.Errorf("before fulfill: ClientState(client) = %t, %v, %t; want false, <nil>, false", resolved, brand, remote)
+	}
+	f.PipelineCall([]capnp.PipelineOp{{Field: 0}}, new(capnp.Call))
+	f.Fulfill(result)
+	if resolved, brand, remote := capnp.ClientState(client); !resolved || brand != oc || remote {
+		t.Errorf("after fulfill: ClientState(client) = %t, %v, %t; want true, %v, false", resolved, brand, remote, oc)
+	}
+}
+
 func newStruct(t *testing.T, sz capnp.ObjectSize) capnp.Struct {
 	_, s, err := capnp.NewMessage(capnp.SingleSegment(nil))
 	if err != nil {
@@ -3,6 +3,10 @@
 // Fuzz test harness.  To run:
 // go-fuzz-build github.com/iguazio/go-capnproto2/internal/fuzztest
 // go-fuzz -bin=fuzztest-fuzz.zip -workdir=internal/fuzztest
+//
+// With Go 1.18 or later, prefer the native fuzz target, which shares
+// this harness's seed corpus:
+// go test -run=NONE -fuzz=FuzzUnmarshal .
 
 package fuzztest
 
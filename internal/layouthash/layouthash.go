@@ -0,0 +1,126 @@
+// Package layouthash computes a hash of the wire layout of a struct
+// type, which capnpc-go -layouthash embeds in generated code for
+// capnp.CheckLayout.
+package layouthash // import "github.com/iguazio/go-capnproto2/internal/layouthash"
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	"github.com/iguazio/go-capnproto2/internal/schema"
+)
+
+// A Finder returns the schema node with the given ID.
+type Finder func(id uint64) (schema.Node, error)
+
+// Hash returns the layout hash of the struct node n.  The hash covers
+// the struct's size and, for each field, including the fields of its
+// groups, the field's location, its type, and the union member that it
+// belongs to.  It does not cover names, the order that fields are
+// declared in, annotations, or default values, so renaming a field or
+// moving it into a group does not change the hash.  Fields of struct,
+// enum, and interface type contribute their type ID but not that type's
+// layout.
+func Hash(n schema.Node, find Finder) (uint64, error) {
+	if n.Which() != schema.Node_Which_structNode {
+		return 0, fmt.Errorf("layout hash of %#x: not a struct", n.Id())
+	}
+	var fields [][]uint64
+	if err := appendFields(&fields, n, find, 0); err != nil {
+		return 0, fmt.Errorf("layout hash of %#x: %v", n.Id(), err)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return less(fields[i], fields[j])
+	})
+	st := n.StructNode()
+	h := fnv.New64a()
+	words := []uint64{
+		n.Id(),
+		uint64(st.DataWordCount()),
+		uint64(st.PointerCount()),
+		uint64(len(fields)),
+	}
+	for _, f := range fields {
+		words = append(words, uint64(len(f)))
+		words = append(words, f...)
+	}
+	var buf [8]byte
+	for _, w := range words {
+		binary.LittleEndian.PutUint64(buf[:], w)
+		h.Write(buf[:])
+	}
+	return h.Sum64(), nil
+}
+
+// appendFields appends a description of each slot in n and its groups
+// to fields.  union describes the union member that n is in, or is zero
+// if n is not in a union.
+func appendFields(fields *[][]uint64, n schema.Node, find Finder, union uint64) error {
+	st := n.StructNode()
+	fs, err := st.Fields()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < fs.Len(); i++ {
+		f := fs.At(i)
+		member := union
+		if dv := f.DiscriminantValue(); dv != schema.Field_noDiscriminant {
+			// Members are identified by where their discriminant is and
+			// what its value is.
+			member = 1<<32 | uint64(st.DiscriminantOffset())<<16 | uint64(dv)
+		}
+		switch f.Which() {
+		case schema.Field_Which_slot:
+			t, err := f.Slot().Type()
+			if err != nil {
+				return err
+			}
+			desc := []uint64{member, uint64(f.Slot().Offset())}
+			desc, err = appendType(desc, t)
+			if err != nil {
+				return err
+			}
+			*fields = append(*fields, desc)
+		case schema.Field_Which_group:
+			g, err := find(f.Group().TypeId())
+			if err != nil {
+				return err
+			}
+			if err := appendFields(fields, g, find, member); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// appendType appends a description of t to desc.
+func appendType(desc []uint64, t schema.Type) ([]uint64, error) {
+	desc = append(desc, uint64(t.Which()))
+	switch t.Which() {
+	case schema.Type_Which_structType:
+		desc = append(desc, t.StructType().TypeId())
+	case schema.Type_Which_enum:
+		desc = append(desc, t.Enum().TypeId())
+	case schema.Type_Which_interface:
+		desc = append(desc, t.Interface().TypeId())
+	case schema.Type_Which_list:
+		elem, err := t.List().ElementType()
+		if err != nil {
+			return nil, err
+		}
+		return appendType(desc, elem)
+	}
+	return desc, nil
+}
+
+func less(a, b []uint64) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
@@ -2,6 +2,8 @@
 package nodemap
 
 import (
+	"math"
+
 	"github.com/iguazio/go-capnproto2"
 	"github.com/iguazio/go-capnproto2/internal/schema"
 	"github.com/iguazio/go-capnproto2/schemas"
@@ -39,6 +41,9 @@ func (m *Map) Find(id uint64) (schema.Node, error) {
 	if err != nil {
 		return schema.Node{}, err
 	}
+	// Registered schemas are trusted and nodes may be read many times
+	// over the life of a Map, so don't let reads exhaust a limit.
+	msg.TraverseLimit = math.MaxUint64
 	req, err := schema.ReadRootCodeGeneratorRequest(msg)
 	if err != nil {
 		return schema.Node{}, err
@@ -3,6 +3,10 @@
 // Fuzz test harness.  To run:
 // go-fuzz-build github.com/iguazio/go-capnproto2/internal/packed
 // go-fuzz -bin=packed-fuzz.zip -workdir=internal/packed/testdata
+//
+// With Go 1.18 or later, prefer the native fuzz target, which shares
+// this harness's seed corpus:
+// go test -run=NONE -fuzz=FuzzPackedReader ./internal/packed
 
 package packed
 
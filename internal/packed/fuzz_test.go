@@ -0,0 +1,80 @@
+//go:build go1.18
+// +build go1.18
+
+package packed
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// FuzzPackedReader unpacks arbitrary input with Unpack, Reader.Read, and
+// Reader.ReadWord.  Whichever succeed must agree, and their output must
+// survive a pack/unpack round trip.
+func FuzzPackedReader(f *testing.F) {
+	// Seed with the corpus of the go-fuzz harness in fuzz.go.
+	paths, err := filepath.Glob(filepath.Join("testdata", "corpus", "*"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, p := range paths {
+		data, err := ioutil.ReadFile(p)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+	for _, test := range compressionTests {
+		f.Add(test.compressed)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		unpacked, err := Unpack(nil, data)
+		if err == nil {
+			roundTrip(t, "Unpack", unpacked)
+		}
+
+		r := NewReader(bufio.NewReader(bytes.NewReader(data)))
+		read, rerr := ioutil.ReadAll(r)
+		if rerr == nil {
+			roundTrip(t, "Read", read)
+			if err == nil && !bytes.Equal(read, unpacked) {
+				t.Errorf("Read = %x; Unpack = %x", read, unpacked)
+			}
+		}
+
+		r = NewReader(bufio.NewReader(bytes.NewReader(data)))
+		var words []byte
+		for {
+			n := len(words)
+			words = append(words, 0, 0, 0, 0, 0, 0, 0, 0)
+			if rerr = r.ReadWord(words[n:]); rerr != nil {
+				words = words[:n]
+				break
+			}
+		}
+		if rerr == io.EOF {
+			roundTrip(t, "ReadWord", words)
+			if err == nil && !bytes.Equal(words, unpacked) {
+				t.Errorf("ReadWord = %x; Unpack = %x", words, unpacked)
+			}
+		}
+	})
+}
+
+// roundTrip checks that packing and then unpacking the output of fn
+// gives back the same bytes.
+func roundTrip(t *testing.T, fn string, unpacked []byte) {
+	packed := Pack(nil, unpacked)
+	unpacked2, err := Unpack(nil, packed)
+	if err != nil {
+		t.Errorf("%s: Unpack(Pack(%x)): %v", fn, unpacked, err)
+		return
+	}
+	if !bytes.Equal(unpacked, unpacked2) {
+		t.Errorf("%s: Unpack(Pack(%x)) = %x", fn, unpacked, unpacked2)
+	}
+}
@@ -242,6 +242,14 @@ var badDecompressionTests = []struct {
 			'a', 'd', ' ', 't', 'e', 'x', 't', '.',
 		}, 128),
 	},
+	{
+		"truncated literal run",
+		[]byte{
+			0xff, 1, 2, 3, 4, 5, 6, 7, 8,
+			2,
+			1, 2, 3, 4, 5, 6, 7, 8,
+		},
+	},
 }
 
 func TestPack(t *testing.T) {
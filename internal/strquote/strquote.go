@@ -5,6 +5,15 @@ package strquote
 // Append appends a Cap'n Proto string literal of s to buf.
 func Append(buf []byte, s []byte) []byte {
 	buf = append(buf, '"')
+	buf = AppendEscaped(buf, s)
+	buf = append(buf, '"')
+	return buf
+}
+
+// AppendEscaped appends s to buf, escaped as in a Cap'n Proto string
+// literal but without the surrounding quotes.  Since each byte is
+// escaped on its own, a long string may be escaped in pieces.
+func AppendEscaped(buf []byte, s []byte) []byte {
 	last := 0
 	for i, b := range s {
 		if !needsEscape(b) {
@@ -38,7 +47,6 @@ func Append(buf []byte, s []byte) []byte {
 		last = i + 1
 	}
 	buf = append(buf, s[last:]...)
-	buf = append(buf, '"')
 	return buf
 }
 
@@ -0,0 +1,155 @@
+// Package valueconv converts loosely-typed values, like the tokens and
+// values produced by encoding/json, into the values of Cap'n Proto
+// fields.  It is shared by the packages that build messages from the
+// schemas at run time.
+package valueconv
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+)
+
+// An Ident is a bare identifier in Cap'n Proto text format, like an
+// enumerant name or inf.
+type Ident string
+
+// StructSize returns the size of a struct of type n.
+func StructSize(n schema.Node) capnp.ObjectSize {
+	return capnp.ObjectSize{
+		DataSize:     capnp.Size(n.StructNode().DataWordCount()) * 8,
+		PointerCount: n.StructNode().PointerCount(),
+	}
+}
+
+// ElemError wraps err with the index of the list element it is about.
+func ElemError(i int, err error) error {
+	return fmt.Errorf("element %d: %v", i, err)
+}
+
+// String returns v if it is a string.  what names the expected kind of
+// value in the error.
+func String(v interface{}, what string) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("expected %s, got %s", what, Describe(v))
+	}
+	return s, nil
+}
+
+// Bool returns v if it is a bool.
+func Bool(v interface{}) (bool, error) {
+	x, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("expected bool, got %s", Describe(v))
+	}
+	return x, nil
+}
+
+// Int parses v as a signed integer that fits in bits.  v may be a
+// json.Number, which may use a 0x, 0o, or 0b prefix as in text format,
+// or a decimal string, as JSON uses for 64-bit integers.
+func Int(v interface{}, bits int) (int64, error) {
+	s, base, ok := intString(v)
+	if !ok {
+		return 0, fmt.Errorf("expected integer, got %s", Describe(v))
+	}
+	x, err := strconv.ParseInt(s, base, bits)
+	if err != nil {
+		return 0, fmt.Errorf("bad int%d %s", bits, s)
+	}
+	return x, nil
+}
+
+// Uint parses v as an unsigned integer that fits in bits, in the same
+// forms as Int.
+func Uint(v interface{}, bits int) (uint64, error) {
+	s, base, ok := intString(v)
+	if !ok {
+		return 0, fmt.Errorf("expected integer, got %s", Describe(v))
+	}
+	x, err := strconv.ParseUint(s, base, bits)
+	if err != nil {
+		return 0, fmt.Errorf("bad uint%d %s", bits, s)
+	}
+	return x, nil
+}
+
+func intString(v interface{}) (s string, base int, ok bool) {
+	switch v := v.(type) {
+	case json.Number:
+		return string(v), 0, true
+	case string:
+		return v, 10, true
+	default:
+		return "", 0, false
+	}
+}
+
+// Float parses v as a float that fits in bits.  v may be a json.Number,
+// an Ident, or a string.  Text format uses identifiers like inf, -inf,
+// and nan (or +Inf and NaN, as encoding/text writes them) for
+// non-finite values, while JSON uses the strings "Infinity",
+// "-Infinity", and "NaN".
+func Float(v interface{}, bits int) (float64, error) {
+	var s string
+	switch v := v.(type) {
+	case json.Number:
+		s = string(v)
+	case Ident:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return 0, fmt.Errorf("expected float, got %s", Describe(v))
+	}
+	switch strings.TrimPrefix(s, "+") {
+	case "inf", "Inf", "Infinity":
+		return math.Inf(1), nil
+	case "-inf", "-Inf", "-Infinity":
+		return math.Inf(-1), nil
+	case "nan", "NaN":
+		return math.NaN(), nil
+	}
+	x, err := strconv.ParseFloat(s, bits)
+	if err != nil {
+		return 0, fmt.Errorf("bad float%d %s", bits, s)
+	}
+	return x, nil
+}
+
+// Describe returns a short description of a value or JSON token for
+// errors.
+func Describe(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return "number " + string(v)
+	case Ident:
+		return string(v)
+	case string:
+		return "string " + strconv.Quote(v)
+	case json.Delim:
+		switch v {
+		case '{':
+			return "object"
+		case '[':
+			return "array"
+		}
+		return string(v)
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "struct"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
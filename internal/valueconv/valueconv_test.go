@@ -0,0 +1,111 @@
+package valueconv
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestInt(t *testing.T) {
+	tests := []struct {
+		v    interface{}
+		bits int
+		want int64
+		err  bool
+	}{
+		{v: json.Number("42"), bits: 8, want: 42},
+		{v: json.Number("-0x10"), bits: 16, want: -16},
+		{v: "-9223372036854775808", bits: 64, want: math.MinInt64},
+		{v: "0x10", bits: 64, err: true},
+		{v: json.Number("128"), bits: 8, err: true},
+		{v: true, bits: 8, err: true},
+		{v: nil, bits: 8, err: true},
+	}
+	for _, test := range tests {
+		got, err := Int(test.v, test.bits)
+		if test.err {
+			if err == nil {
+				t.Errorf("Int(%#v, %d) = %d; want error", test.v, test.bits, got)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("Int(%#v, %d) = %d, %v; want %d", test.v, test.bits, got, err, test.want)
+		}
+	}
+}
+
+func TestUint(t *testing.T) {
+	tests := []struct {
+		v    interface{}
+		bits int
+		want uint64
+		err  bool
+	}{
+		{v: json.Number("255"), bits: 8, want: 255},
+		{v: json.Number("0b101"), bits: 8, want: 5},
+		{v: "18446744073709551615", bits: 64, want: math.MaxUint64},
+		{v: json.Number("-1"), bits: 8, err: true},
+		{v: Ident("x"), bits: 8, err: true},
+	}
+	for _, test := range tests {
+		got, err := Uint(test.v, test.bits)
+		if test.err {
+			if err == nil {
+				t.Errorf("Uint(%#v, %d) = %d; want error", test.v, test.bits, got)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("Uint(%#v, %d) = %d, %v; want %d", test.v, test.bits, got, err, test.want)
+		}
+	}
+}
+
+func TestFloat(t *testing.T) {
+	tests := []struct {
+		v    interface{}
+		want float64
+	}{
+		{json.Number("1.5"), 1.5},
+		{Ident("inf"), math.Inf(1)},
+		{Ident("+Inf"), math.Inf(1)},
+		{"-Infinity", math.Inf(-1)},
+		{"2", 2},
+	}
+	for _, test := range tests {
+		got, err := Float(test.v, 64)
+		if err != nil || got != test.want {
+			t.Errorf("Float(%#v, 64) = %v, %v; want %v", test.v, got, err, test.want)
+		}
+	}
+	if got, err := Float("NaN", 64); err != nil || !math.IsNaN(got) {
+		t.Errorf("Float(\"NaN\", 64) = %v, %v; want NaN", got, err)
+	}
+	if _, err := Float(false, 64); err == nil {
+		t.Error("Float(false, 64) succeeded; want error")
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		v    interface{}
+		want string
+	}{
+		{nil, "null"},
+		{true, "true"},
+		{json.Number("12"), "number 12"},
+		{Ident("foo"), "foo"},
+		{"a\n", `string "a\n"`},
+		{json.Delim('{'), "object"},
+		{json.Delim('['), "array"},
+		{[]interface{}{}, "list"},
+		{map[string]interface{}{}, "struct"},
+		{3.5, "float64"},
+	}
+	for _, test := range tests {
+		if got := Describe(test.v); got != test.want {
+			t.Errorf("Describe(%#v) = %q; want %q", test.v, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package capnp
+
+import (
+	"fmt"
+	"sync"
+)
+
+var layouts struct {
+	mu     sync.RWMutex
+	hashes map[uint64]uint64
+}
+
+// RegisterLayout records the layout hash of the struct type with the
+// given ID.  Code generated by capnpc-go with -layouthash calls it from
+// an init function with the type's _LayoutHash constant.
+func RegisterLayout(typeID, hash uint64) {
+	layouts.mu.Lock()
+	if layouts.hashes == nil {
+		layouts.hashes = make(map[uint64]uint64)
+	}
+	layouts.hashes[typeID] = hash
+	layouts.mu.Unlock()
+}
+
+// LayoutHash returns the layout hash registered for the struct type
+// with the given ID.
+func LayoutHash(typeID uint64) (hash uint64, ok bool) {
+	layouts.mu.RLock()
+	hash, ok = layouts.hashes[typeID]
+	layouts.mu.RUnlock()
+	return hash, ok
+}
+
+// CheckLayout returns an error if the struct type with the given ID
+// was compiled into this program with a different layout hash than
+// hash, or if no layout hash is registered for it.  Layout hashes are
+// only registered for packages generated with capnpc-go -layouthash.
+// Two services can exchange the _LayoutHash constants of the types
+// that they share during a handshake and call CheckLayout to detect
+// that they were built from incompatible schema revisions.  The hash
+// covers where each field is stored and its type, but not names or
+// defaults.  A field's struct type contributes only its ID, so check
+// each type that is shared.
+func CheckLayout(typeID, hash uint64) error {
+	local, ok := LayoutHash(typeID)
+	if !ok {
+		return fmt.Errorf("capnp: no layout registered for type %#x", typeID)
+	}
+	if local != hash {
+		return fmt.Errorf("capnp: layout of type %#x is %#x, want %#x", typeID, hash, local)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package capnp
+
+import "testing"
+
+func TestCheckLayout(t *testing.T) {
+	const typeID = 0xa0b1c2d3e4f50617
+	if err := CheckLayout(typeID, 1); err == nil {
+		t.Error("CheckLayout of unregistered type = <nil>; want error")
+	}
+	RegisterLayout(typeID, 0x1234)
+	if h, ok := LayoutHash(typeID); !ok || h != 0x1234 {
+		t.Errorf("LayoutHash = %#x, %t; want 0x1234, true", h, ok)
+	}
+	if err := CheckLayout(typeID, 0x1234); err != nil {
+		t.Errorf("CheckLayout with same hash: %v", err)
+	}
+	if err := CheckLayout(typeID, 0x5678); err == nil {
+		t.Error("CheckLayout with different hash = <nil>; want error")
+	}
+}
@@ -64,6 +64,32 @@ func NewCompositeList(s *Segment, sz ObjectSize, n int32) (List, error) {
 	}, nil
 }
 
+// NewRootCompositeListMessage creates a single-segment message whose
+// root is a new composite list of n structs.  The segment is sized to
+// hold exactly the root pointer and the list, so building the list does
+// not reallocate.
+func NewRootCompositeListMessage(sz ObjectSize, n int32) (*Message, List, error) {
+	var buf []byte
+	if sz.isValid() && n >= 0 {
+		sz.DataSize = sz.DataSize.padToWord()
+		if total, ok := sz.totalSize().times(n); ok && total <= maxSize-2*wordSize {
+			buf = make([]byte, 0, 2*wordSize+total)
+		}
+	}
+	msg, seg, err := NewMessage(SingleSegment(buf))
+	if err != nil {
+		return nil, List{}, err
+	}
+	l, err := NewCompositeList(seg, sz, n)
+	if err != nil {
+		return nil, List{}, err
+	}
+	if err := msg.SetRootPtr(l.ToPtr()); err != nil {
+		return nil, List{}, err
+	}
+	return msg, l, nil
+}
+
 // ToList converts p to a List.
 //
 // Deprecated: Use Ptr.List.
@@ -158,7 +184,8 @@ func (p List) raw() rawPointer {
 		return rawListPointer(0, pointerList, p.length)
 	}
 	if p.size.PointerCount != 0 {
-		panic(errListSize)
+		misuse(p.seg, errListSize)
+		return 0
 	}
 	switch p.size.DataSize {
 	case 0:
@@ -172,7 +199,8 @@ func (p List) raw() rawPointer {
 	case 8:
 		return rawListPointer(0, byte8List, p.length)
 	default:
-		panic(errListSize)
+		misuse(p.seg, errListSize)
+		return 0
 	}
 }
 
@@ -201,7 +229,8 @@ func (p List) Len() int {
 func (p List) primitiveElem(i int, expectedSize ObjectSize) (Address, error) {
 	if p.seg == nil || i < 0 || i >= int(p.length) {
 		// This is programmer error, not input error.
-		panic(errOutOfBounds)
+		misuse(p.seg, errOutOfBounds)
+		return 0, errOutOfBounds
 	}
 	if p.flags&isBitList != 0 || p.flags&isCompositeList == 0 && p.size != expectedSize || p.flags&isCompositeList != 0 && (p.size.DataSize < expectedSize.DataSize || p.size.PointerCount < expectedSize.PointerCount) {
 		return 0, errElementSize
@@ -217,7 +246,8 @@ func (p List) primitiveElem(i int, expectedSize ObjectSize) (Address, error) {
 func (p List) Struct(i int) Struct {
 	if p.seg == nil || i < 0 || i >= int(p.length) {
 		// This is programmer error, not input error.
-		panic(errOutOfBounds)
+		misuse(p.seg, errOutOfBounds)
+		return Struct{}
 	}
 	if p.flags&isBitList != 0 {
 		return Struct{}
@@ -243,6 +273,114 @@ func (p List) SetStruct(i int, s Struct) error {
 	return copyStruct(p.Struct(i), s)
 }
 
+// Swap exchanges the contents of the i'th and j'th elements.  Pointers
+// in the elements are rewritten so that they still refer to the same
+// objects, so Swap can be used to sort a list in place.
+func (p List) Swap(i, j int) {
+	if p.seg == nil || i < 0 || i >= int(p.length) || j < 0 || j >= int(p.length) {
+		// This is programmer error, not input error.
+		misuse(p.seg, errOutOfBounds)
+		return
+	}
+	if i == j {
+		return
+	}
+	if p.flags&isBitList != 0 {
+		bl := BitList{p}
+		vi, vj := bl.At(i), bl.At(j)
+		bl.Set(i, vj)
+		bl.Set(j, vi)
+		return
+	}
+	a, _ := p.off.element(int32(i), p.size.totalSize())
+	b, _ := p.off.element(int32(j), p.size.totalSize())
+	da, db := p.seg.slice(a, p.size.DataSize), p.seg.slice(b, p.size.DataSize)
+	for k := range da {
+		da[k], db[k] = db[k], da[k]
+	}
+	for k := uint16(0); k < p.size.PointerCount; k++ {
+		pa, pb := p.elemPointerAddress(a, k), p.elemPointerAddress(b, k)
+		ra, rb := p.seg.readRawPointer(pa), p.seg.readRawPointer(pb)
+		p.seg.writeRawPointer(pa, rb.relocate(pb, pa))
+		p.seg.writeRawPointer(pb, ra.relocate(pa, pb))
+	}
+}
+
+// MoveWithin copies the contents of the src'th element over the dst'th
+// element and then zeroes the src'th element.  Objects that the dst'th
+// element referred to become unreachable.  Together with Truncate,
+// MoveWithin can be used to filter a list in place.
+func (p List) MoveWithin(dst, src int) {
+	if p.seg == nil || dst < 0 || dst >= int(p.length) || src < 0 || src >= int(p.length) {
+		// This is programmer error, not input error.
+		misuse(p.seg, errOutOfBounds)
+		return
+	}
+	if dst == src {
+		return
+	}
+	if p.flags&isBitList != 0 {
+		bl := BitList{p}
+		bl.Set(dst, bl.At(src))
+		bl.Set(src, false)
+		return
+	}
+	d, _ := p.off.element(int32(dst), p.size.totalSize())
+	s, _ := p.off.element(int32(src), p.size.totalSize())
+	dd, sd := p.seg.slice(d, p.size.DataSize), p.seg.slice(s, p.size.DataSize)
+	copy(dd, sd)
+	for k := range sd {
+		sd[k] = 0
+	}
+	for k := uint16(0); k < p.size.PointerCount; k++ {
+		pd, ps := p.elemPointerAddress(d, k), p.elemPointerAddress(s, k)
+		p.seg.writeRawPointer(pd, p.seg.readRawPointer(ps).relocate(ps, pd))
+		p.seg.writeRawPointer(ps, 0)
+	}
+}
+
+// Truncate zeroes the elements of the list past the first n and returns
+// the list of the first n elements.  The space of the removed elements
+// is not reclaimed.  Pointers to the list still have the old length, so
+// the result should be set in their place.  It is an error to truncate
+// a list to more than its length.
+func (p List) Truncate(n int) List {
+	if n < 0 || n > p.Len() {
+		// This is programmer error, not input error.
+		misuse(p.seg, errOutOfBounds)
+		return p
+	}
+	if n == p.Len() {
+		return p
+	}
+	if p.flags&isBitList != 0 {
+		bl := BitList{p}
+		for i := n; i < int(p.length); i++ {
+			bl.Set(i, false)
+		}
+	} else {
+		start, _ := p.off.element(int32(n), p.size.totalSize())
+		end, _ := p.off.element(p.length, p.size.totalSize())
+		b := p.seg.slice(start, Size(end-start))
+		for i := range b {
+			b[i] = 0
+		}
+	}
+	if p.flags&isCompositeList != 0 {
+		// Update the tag word, which holds the element count.
+		p.seg.writeRawPointer(p.off-Address(wordSize), rawStructPointer(pointerOffset(n), p.size))
+	}
+	p.length = int32(n)
+	return p
+}
+
+// elemPointerAddress returns the address of the k'th pointer of the
+// element at addr.
+func (p List) elemPointerAddress(addr Address, k uint16) Address {
+	a, _ := addr.addSize(p.size.DataSize + Size(k)*wordSize)
+	return a
+}
+
 // A BitList is a reference to a list of booleans.
 type BitList struct{ List }
 
@@ -265,7 +403,8 @@ func NewBitList(s *Segment, n int32) (BitList, error) {
 func (p BitList) At(i int) bool {
 	if p.seg == nil || i < 0 || i >= int(p.length) {
 		// This is programmer error, not input error.
-		panic(errOutOfBounds)
+		misuse(p.seg, errOutOfBounds)
+		return false
 	}
 	if p.flags&isBitList == 0 {
 		return false
@@ -279,11 +418,13 @@ func (p BitList) At(i int) bool {
 func (p BitList) Set(i int, v bool) {
 	if p.seg == nil || i < 0 || i >= int(p.length) {
 		// This is programmer error, not input error.
-		panic(errOutOfBounds)
+		misuse(p.seg, errOutOfBounds)
+		return
 	}
 	if p.flags&isBitList == 0 {
 		// Again, programmer error.  Should have used NewBitList.
-		panic(errElementSize)
+		misuse(p.seg, errElementSize)
+		return
 	}
 	bit := BitOffset(i)
 	addr := p.off.addOffset(bit.offset())
@@ -368,9 +509,55 @@ func (p PointerList) SetPtr(i int, v Ptr) error {
 	return p.seg.writePtr(addr, v, false)
 }
 
+// StructAt returns the struct that the i'th pointer in the list
+// points to.  If the pointer is null or not a struct pointer, it
+// returns an invalid Struct.
+func (p PointerList) StructAt(i int) (Struct, error) {
+	ptr, err := p.PtrAt(i)
+	return ptr.Struct(), err
+}
+
+// ListAt returns the list that the i'th pointer in the list points to.
+// If the pointer is null or not a list pointer, it returns an invalid
+// List.
+func (p PointerList) ListAt(i int) (List, error) {
+	ptr, err := p.PtrAt(i)
+	return ptr.List(), err
+}
+
+// TextAt returns the i'th pointer in the list as a string.
+func (p PointerList) TextAt(i int) (string, error) {
+	ptr, err := p.PtrAt(i)
+	return ptr.Text(), err
+}
+
+// DataAt returns the i'th pointer in the list as a byte slice.  The
+// slice refers to the message's data.
+func (p PointerList) DataAt(i int) ([]byte, error) {
+	ptr, err := p.PtrAt(i)
+	return ptr.Data(), err
+}
+
+// TextList returns the list as a list of Text.  The lists share the
+// same data.
+func (p PointerList) TextList() TextList {
+	return TextList{p.List}
+}
+
+// DataList returns the list as a list of Data.  The lists share the
+// same data.
+func (p PointerList) DataList() DataList {
+	return DataList{p.List}
+}
+
 // TextList is an array of pointers to strings.
 type TextList struct{ List }
 
+// PointerList returns the list as a list of pointers.
+func (l TextList) PointerList() PointerList {
+	return PointerList{l.List}
+}
+
 // NewTextList allocates a new list of text pointers, preferring placement in s.
 func NewTextList(s *Segment, n int32) (TextList, error) {
 	pl, err := NewPointerList(s, n)
@@ -380,6 +567,34 @@ func NewTextList(s *Segment, n int32) (TextList, error) {
 	return TextList{pl.List}, nil
 }
 
+// NewTextListFromStrings allocates a new list of text pointers
+// holding v, preferring placement in s.
+func NewTextListFromStrings(s *Segment, v []string) (TextList, error) {
+	l, err := NewTextList(s, int32(len(v)))
+	if err != nil {
+		return TextList{}, err
+	}
+	for i := range v {
+		if err := l.Set(i, v[i]); err != nil {
+			return TextList{}, err
+		}
+	}
+	return l, nil
+}
+
+// Strings returns the strings in the list.  Null elements are
+// returned as empty strings.
+func (l TextList) Strings() ([]string, error) {
+	v := make([]string, l.Len())
+	for i := range v {
+		var err error
+		if v[i], err = l.At(i); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
 // At returns the i'th string in the list.
 func (l TextList) At(i int) (string, error) {
 	addr, err := l.primitiveElem(i, ObjectSize{PointerCount: 1})
@@ -416,6 +631,13 @@ func (l TextList) Set(i int, v string) error {
 	if v == "" {
 		return l.seg.writePtr(addr, Ptr{}, false)
 	}
+	if l.seg.interning() {
+		p, err := internBlob(l.seg, v, false)
+		if err != nil {
+			return err
+		}
+		return l.seg.writePtr(addr, p.List.ToPtr(), false)
+	}
 	p, err := NewText(l.seg, v)
 	if err != nil {
 		return err
@@ -445,6 +667,11 @@ func (l TextList) String() string {
 // DataList is an array of pointers to data.
 type DataList struct{ List }
 
+// PointerList returns the list as a list of pointers.
+func (l DataList) PointerList() PointerList {
+	return PointerList{l.List}
+}
+
 // NewDataList allocates a new list of data pointers, preferring placement in s.
 func NewDataList(s *Segment, n int32) (DataList, error) {
 	pl, err := NewPointerList(s, n)
@@ -454,6 +681,35 @@ func NewDataList(s *Segment, n int32) (DataList, error) {
 	return DataList{pl.List}, nil
 }
 
+// NewDataListFromBytes allocates a new list of data pointers holding
+// copies of v, preferring placement in s.
+func NewDataListFromBytes(s *Segment, v [][]byte) (DataList, error) {
+	l, err := NewDataList(s, int32(len(v)))
+	if err != nil {
+		return DataList{}, err
+	}
+	for i := range v {
+		if err := l.Set(i, v[i]); err != nil {
+			return DataList{}, err
+		}
+	}
+	return l, nil
+}
+
+// Bytes returns the data in the list.  Like At, the underlying arrays
+// of the slices are the segment data.  Null elements are returned as
+// nil slices.
+func (l DataList) Bytes() ([][]byte, error) {
+	v := make([][]byte, l.Len())
+	for i := range v {
+		var err error
+		if v[i], err = l.At(i); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
 // At returns the i'th data in the list.
 func (l DataList) At(i int) ([]byte, error) {
 	addr, err := l.primitiveElem(i, ObjectSize{PointerCount: 1})
@@ -476,6 +732,13 @@ func (l DataList) Set(i int, v []byte) error {
 	if len(v) == 0 {
 		return l.seg.writePtr(addr, Ptr{}, false)
 	}
+	if l.seg.interning() {
+		p, err := internBlob(l.seg, string(v), true)
+		if err != nil {
+			return err
+		}
+		return l.seg.writePtr(addr, p.List.ToPtr(), false)
+	}
 	p, err := NewData(l.seg, v)
 	if err != nil {
 		return err
@@ -619,7 +882,8 @@ func (l UInt8List) At(i int) uint8 {
 func (l UInt8List) Set(i int, v uint8) {
 	addr, err := l.primitiveElem(i, ObjectSize{DataSize: 1})
 	if err != nil {
-		panic(err)
+		misuse(l.seg, err)
+		return
 	}
 	l.seg.writeUint8(addr, v)
 }
@@ -663,7 +927,8 @@ func (l Int8List) At(i int) int8 {
 func (l Int8List) Set(i int, v int8) {
 	addr, err := l.primitiveElem(i, ObjectSize{DataSize: 1})
 	if err != nil {
-		panic(err)
+		misuse(l.seg, err)
+		return
 	}
 	l.seg.writeUint8(addr, uint8(v))
 }
@@ -707,7 +972,8 @@ func (l UInt16List) At(i int) uint16 {
 func (l UInt16List) Set(i int, v uint16) {
 	addr, err := l.primitiveElem(i, ObjectSize{DataSize: 2})
 	if err != nil {
-		panic(err)
+		misuse(l.seg, err)
+		return
 	}
 	l.seg.writeUint16(addr, v)
 }
@@ -751,7 +1017,8 @@ func (l Int16List) At(i int) int16 {
 func (l Int16List) Set(i int, v int16) {
 	addr, err := l.primitiveElem(i, ObjectSize{DataSize: 2})
 	if err != nil {
-		panic(err)
+		misuse(l.seg, err)
+		return
 	}
 	l.seg.writeUint16(addr, uint16(v))
 }
@@ -795,7 +1062,8 @@ func (l UInt32List) At(i int) uint32 {
 func (l UInt32List) Set(i int, v uint32) {
 	addr, err := l.primitiveElem(i, ObjectSize{DataSize: 4})
 	if err != nil {
-		panic(err)
+		misuse(l.seg, err)
+		return
 	}
 	l.seg.writeUint32(addr, v)
 }
@@ -839,7 +1107,8 @@ func (l Int32List) At(i int) int32 {
 func (l Int32List) Set(i int, v int32) {
 	addr, err := l.primitiveElem(i, ObjectSize{DataSize: 4})
 	if err != nil {
-		panic(err)
+		misuse(l.seg, err)
+		return
 	}
 	l.seg.writeUint32(addr, uint32(v))
 }
@@ -883,7 +1152,8 @@ func (l UInt64List) At(i int) uint64 {
 func (l UInt64List) Set(i int, v uint64) {
 	addr, err := l.primitiveElem(i, ObjectSize{DataSize: 8})
 	if err != nil {
-		panic(err)
+		misuse(l.seg, err)
+		return
 	}
 	l.seg.writeUint64(addr, v)
 }
@@ -927,7 +1197,8 @@ func (l Int64List) At(i int) int64 {
 func (l Int64List) Set(i int, v int64) {
 	addr, err := l.primitiveElem(i, ObjectSize{DataSize: 8})
 	if err != nil {
-		panic(err)
+		misuse(l.seg, err)
+		return
 	}
 	l.seg.writeUint64(addr, uint64(v))
 }
@@ -971,7 +1242,8 @@ func (l Float32List) At(i int) float32 {
 func (l Float32List) Set(i int, v float32) {
 	addr, err := l.primitiveElem(i, ObjectSize{DataSize: 4})
 	if err != nil {
-		panic(err)
+		misuse(l.seg, err)
+		return
 	}
 	l.seg.writeUint32(addr, math.Float32bits(v))
 }
@@ -1015,7 +1287,8 @@ func (l Float64List) At(i int) float64 {
 func (l Float64List) Set(i int, v float64) {
 	addr, err := l.primitiveElem(i, ObjectSize{DataSize: 8})
 	if err != nil {
-		panic(err)
+		misuse(l.seg, err)
+		return
 	}
 	l.seg.writeUint64(addr, math.Float64bits(v))
 }
@@ -2,6 +2,7 @@ package capnp
 
 import (
 	"bytes"
+	"reflect"
 	"testing"
 )
 
@@ -79,6 +80,82 @@ func TestTextListBytesAt(t *testing.T) {
 	}
 }
 
+func TestPointerList(t *testing.T) {
+	_, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pl, err := NewPointerList(seg, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := NewStruct(seg, ObjectSize{DataSize: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	st.SetUint64(0, 42)
+	if err := pl.SetPtr(0, st.ToPtr()); err != nil {
+		t.Fatal("SetPtr(0):", err)
+	}
+	il, err := NewInt32List(seg, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	il.Set(1, 7)
+	if err := pl.SetPtr(1, il.ToPtr()); err != nil {
+		t.Fatal("SetPtr(1):", err)
+	}
+	text, err := NewText(seg, "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pl.SetPtr(2, text.ToPtr()); err != nil {
+		t.Fatal("SetPtr(2):", err)
+	}
+	data, err := NewData(seg, []byte{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pl.SetPtr(3, data.ToPtr()); err != nil {
+		t.Fatal("SetPtr(3):", err)
+	}
+
+	if s, err := pl.StructAt(0); err != nil {
+		t.Error("StructAt(0) error:", err)
+	} else if s.Uint64(0) != 42 {
+		t.Errorf("StructAt(0).Uint64(0) = %d; want 42", s.Uint64(0))
+	}
+	if l, err := pl.ListAt(1); err != nil {
+		t.Error("ListAt(1) error:", err)
+	} else if n := (Int32List{l}).At(1); l.Len() != 2 || n != 7 {
+		t.Errorf("ListAt(1) = len %d, [1] = %d; want len 2, [1] = 7", l.Len(), n)
+	}
+	if s, err := pl.TextAt(2); err != nil {
+		t.Error("TextAt(2) error:", err)
+	} else if s != "foo" {
+		t.Errorf("TextAt(2) = %q; want \"foo\"", s)
+	}
+	if b, err := pl.DataAt(3); err != nil {
+		t.Error("DataAt(3) error:", err)
+	} else if !bytes.Equal(b, []byte{1, 2, 3}) {
+		t.Errorf("DataAt(3) = % 02x; want 01 02 03", b)
+	}
+	if s, err := pl.StructAt(4); err != nil {
+		t.Error("StructAt(4) error:", err)
+	} else if s.IsValid() {
+		t.Error("StructAt(4) on null pointer is valid")
+	}
+	if s, err := pl.TextList().At(2); err != nil || s != "foo" {
+		t.Errorf("TextList().At(2) = %q, %v; want \"foo\", <nil>", s, err)
+	}
+	if b, err := pl.DataList().At(3); err != nil || !bytes.Equal(b, []byte{1, 2, 3}) {
+		t.Errorf("DataList().At(3) = % 02x, %v; want 01 02 03, <nil>", b, err)
+	}
+	if s, err := pl.TextList().PointerList().TextAt(2); err != nil || s != "foo" {
+		t.Errorf("TextList().PointerList().TextAt(2) = %q, %v; want \"foo\", <nil>", s, err)
+	}
+}
+
 func TestListRaw(t *testing.T) {
 	_, seg, err := NewMessage(SingleSegment(nil))
 	if err != nil {
@@ -128,3 +205,183 @@ func TestListRaw(t *testing.T) {
 		}
 	}
 }
+
+// newTestStructList returns a list of structs with a number and a text
+// field, set to i and the ith name.
+func newTestStructList(t *testing.T, names ...string) (Struct, List) {
+	_, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := NewRootStruct(seg, ObjectSize{PointerCount: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := NewCompositeList(seg, ObjectSize{DataSize: 8, PointerCount: 1}, int32(len(names)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, name := range names {
+		l.Struct(i).SetUint64(0, uint64(i))
+		if err := l.Struct(i).SetText(0, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := root.SetPtr(0, l.ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+	return root, l
+}
+
+func structListNames(t *testing.T, l List) []string {
+	var names []string
+	for i := 0; i < l.Len(); i++ {
+		p, err := l.Struct(i).Ptr(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, p.Text())
+	}
+	return names
+}
+
+func TestListSwap(t *testing.T) {
+	_, l := newTestStructList(t, "a", "b", "c")
+	l.Swap(0, 2)
+	if got := structListNames(t, l); got[0] != "c" || got[1] != "b" || got[2] != "a" {
+		t.Errorf("names after Swap(0, 2) = %q; want [c b a]", got)
+	}
+	if l.Struct(0).Uint64(0) != 2 || l.Struct(2).Uint64(0) != 0 {
+		t.Errorf("data after Swap(0, 2) = %d, %d; want 2, 0", l.Struct(0).Uint64(0), l.Struct(2).Uint64(0))
+	}
+
+	_, seg, _ := NewMessage(SingleSegment(nil))
+	bl, _ := NewBitList(seg, 3)
+	bl.Set(0, true)
+	bl.Swap(0, 1)
+	if bl.At(0) || !bl.At(1) {
+		t.Errorf("bits after Swap(0, 1) = %v", bl)
+	}
+	tl, _ := NewTextList(seg, 2)
+	tl.Set(0, "x")
+	tl.Set(1, "y")
+	tl.Swap(0, 1)
+	if s, _ := tl.At(0); s != "y" {
+		t.Errorf("text list element 0 after Swap(0, 1) = %q; want \"y\"", s)
+	}
+}
+
+func TestListMoveWithinTruncate(t *testing.T) {
+	root, l := newTestStructList(t, "a", "b", "c", "d")
+	// Keep only the odd elements.
+	l.MoveWithin(0, 1)
+	l.MoveWithin(1, 3)
+	l = l.Truncate(2)
+	if got := structListNames(t, l); len(got) != 2 || got[0] != "b" || got[1] != "d" {
+		t.Errorf("names after filter = %q; want [b d]", got)
+	}
+
+	// The tag word of a composite list holds the length, so the old
+	// pointer sees the shorter list.
+	p, err := root.Ptr(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := p.List().Len(); n != 2 {
+		t.Errorf("length through old pointer = %d; want 2", n)
+	}
+	if err := root.SetPtr(0, l.ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+	p, err = root.Ptr(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := structListNames(t, p.List()); len(got) != 2 || got[1] != "d" {
+		t.Errorf("names after SetPtr = %q; want [b d]", got)
+	}
+
+	_, seg, _ := NewMessage(SingleSegment(nil))
+	ul, _ := NewUInt16List(seg, 3)
+	ul.Set(0, 1)
+	ul.Set(2, 3)
+	ul.MoveWithin(1, 2)
+	ul = UInt16List{ul.Truncate(2)}
+	if ul.Len() != 2 || ul.At(1) != 3 {
+		t.Errorf("uint16 list after MoveWithin and Truncate = %v; want [1, 3]", ul)
+	}
+	// The third element is the last 2 bytes before the padding.
+	if b := seg.Data()[len(seg.Data())-4:]; b[0] != 0 || b[1] != 0 {
+		t.Errorf("truncated element was not zeroed: % x", b)
+	}
+}
+
+func TestNewRootCompositeListMessage(t *testing.T) {
+	sz := ObjectSize{DataSize: 8, PointerCount: 1}
+	msg, l, err := NewRootCompositeListMessage(sz, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < l.Len(); i++ {
+		l.Struct(i).SetUint64(0, uint64(i))
+	}
+	seg, err := msg.Segment(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data := seg.Data(); len(data) != cap(data) || len(data) != 64 {
+		t.Errorf("segment len = %d, cap = %d; want 64, 64", len(data), cap(data))
+	}
+	p, err := msg.RootPtr()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root := p.List(); root.Len() != 3 || root.Struct(2).Uint64(0) != 2 {
+		t.Errorf("root = %d structs, last = %d; want 3 structs, last = 2", root.Len(), root.Struct(2).Uint64(0))
+	}
+
+	if _, _, err := NewRootCompositeListMessage(sz, -1); err == nil {
+		t.Error("NewRootCompositeListMessage(sz, -1) did not return an error")
+	}
+}
+
+func TestTextListStrings(t *testing.T) {
+	_, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"foo", "", "bar"}
+	l, err := NewTextListFromStrings(seg, want)
+	if err != nil {
+		t.Fatal("NewTextListFromStrings:", err)
+	}
+	got, err := l.Strings()
+	if err != nil {
+		t.Fatal("Strings:", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Strings() = %q; want %q", got, want)
+	}
+	if got, err := (TextList{}).Strings(); err != nil || len(got) != 0 {
+		t.Errorf("TextList{}.Strings() = %q, %v; want [], <nil>", got, err)
+	}
+}
+
+func TestDataListBytes(t *testing.T) {
+	_, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]byte{{1, 2, 3}, nil, {4}}
+	l, err := NewDataListFromBytes(seg, want)
+	if err != nil {
+		t.Fatal("NewDataListFromBytes:", err)
+	}
+	got, err := l.Bytes()
+	if err != nil {
+		t.Fatal("Bytes:", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Bytes() = %v; want %v", got, want)
+	}
+}
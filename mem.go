@@ -51,17 +51,58 @@ type Message struct {
 	// errors. See https://capnproto.org/encoding.html#amplification-attack
 	// for more details on this security measure.
 	//
-	// If not set, this defaults to 64 MiB.
+	// If not set, this defaults to DefaultDecodeOptions.TraverseLimit,
+	// which is 64 MiB unless the program changes it.
 	TraverseLimit uint64
 
 	// DepthLimit limits how deeply-nested a message structure can be.
-	// If not set, this defaults to 64.
+	// If not set, this defaults to DefaultDecodeOptions.DepthLimit,
+	// which is 64 unless the program changes it.
 	DepthLimit uint
 
+	// Deterministic makes Marshal, MarshalPacked, and Encoder.Encode
+	// write a copy of the message in which the objects reachable from
+	// the root are laid out in a single segment in pre-order, with no
+	// unreachable bytes, far pointers, or stale padding.  Messages with
+	// the same content then have the same bytes, regardless of the arena
+	// or of the order in which their objects were allocated, which is
+	// useful for content-addressed storage and snapshot tests.  Unlike
+	// Canonicalize, the copy keeps the sizes of structs and the indexes
+	// of capabilities.
+	Deterministic bool
+
+	// Intern makes the SetText, SetTextFromBytes, and SetData methods of
+	// structs in the message and the Set methods of TextList and
+	// DataList store each distinct value once and point to it from
+	// every field or element that is set to it.  This can greatly
+	// reduce the size of messages that repeat the same strings many
+	// times.  Since the value is shared, writing to the bytes of one
+	// field changes the others.  Deterministic output and Canonicalize
+	// copy shared values once for each pointer.
+	Intern bool
+
+	// StringTable, if not nil, makes Text getters of structs in the
+	// message and TextList.At return strings from the table, so that
+	// repeated values share memory.  A Decoder sets it if it was given
+	// WithStringTable.
+	StringTable *StringTable
+
+	// sizeLimit is the most bytes that the segments may hold, or zero
+	// if there is no limit.  It is set by SetSizeLimit.
+	sizeLimit uint64
+
+	// pooled tracks the message's buffer if it was taken from a
+	// BufferPool.  See PoolLeaks.
+	pooled poolTicket
+
 	// mu protects the following fields:
 	mu       sync.Mutex
 	segs     map[SegmentID]*Segment
 	firstSeg Segment // Preallocated first segment. msg is non-nil once initialized.
+	interned map[internKey]UInt8List
+
+	misuseErr error // first error reported by misuse
+	presence  map[presenceKey]FieldSet
 }
 
 // NewMessage creates a message with a new root and returns the first
@@ -107,12 +148,11 @@ func (m *Message) Reset(arena Arena) {
 	m.CapTable = nil
 	m.segs = nil
 	m.firstSeg = Segment{}
+	m.interned = nil
+	m.misuseErr = nil
+	m.presence = nil
 	m.mu.Unlock()
-	if m.TraverseLimit == 0 {
-		m.ReadLimiter().Reset(defaultTraverseLimit)
-	} else {
-		m.ReadLimiter().Reset(m.TraverseLimit)
-	}
+	m.ReadLimiter().Reset(traverseLimitOrDefault(m.TraverseLimit))
 }
 
 // Root returns the pointer to the message's root object.
@@ -129,7 +169,11 @@ func (m *Message) RootPtr() (Ptr, error) {
 	if err != nil {
 		return Ptr{}, err
 	}
-	return s.root().PtrAt(0)
+	root, err := s.root()
+	if err != nil {
+		return Ptr{}, err
+	}
+	return root.PtrAt(0)
 }
 
 // SetRoot sets the message's root object to p.
@@ -145,7 +189,11 @@ func (m *Message) SetRootPtr(p Ptr) error {
 	if err != nil {
 		return err
 	}
-	return s.root().SetPtr(0, p)
+	root, err := s.root()
+	if err != nil {
+		return err
+	}
+	return root.SetPtr(0, p)
 }
 
 // AddCap appends a capability to the message's capability table and
@@ -160,20 +208,13 @@ func (m *Message) AddCap(c Client) CapabilityID {
 // to reset the traversal limit while reading.
 func (m *Message) ReadLimiter() *ReadLimiter {
 	m.rlimitInit.Do(func() {
-		if m.TraverseLimit == 0 {
-			m.rlimit.limit = defaultTraverseLimit
-		} else {
-			m.rlimit.limit = m.TraverseLimit
-		}
+		m.rlimit.limit = traverseLimitOrDefault(m.TraverseLimit)
 	})
 	return &m.rlimit
 }
 
 func (m *Message) depthLimit() uint {
-	if m.DepthLimit != 0 {
-		return m.DepthLimit
-	}
-	return defaultDepthLimit
+	return depthLimitOrDefault(m.DepthLimit)
 }
 
 // NumSegments returns the number of segments in the message.
@@ -271,10 +312,17 @@ func (m *Message) allocSegment(sz Size) (*Segment, error) {
 // use a different segment in the same message if there's not sufficient
 // capacity.
 func alloc(s *Segment, sz Size) (*Segment, Address, error) {
+	if s == nil {
+		misuse(nil, errNilSegment)
+		return nil, 0, errNilSegment
+	}
 	sz = sz.padToWord()
 	if sz > maxSize-wordSize {
 		return nil, 0, errOverflow
 	}
+	if err := s.msg.checkSizeLimit(sz); err != nil {
+		return nil, 0, err
+	}
 
 	if !hasCapacity(s.data, sz) {
 		var err error
@@ -508,27 +556,49 @@ type Decoder struct {
 	msg   Message
 	arena roSingleSegment
 
+	trace   *tracer
+	limit   DecodeOptions
+	strings *StringTable
+
 	// Maximum number of bytes that can be read per call to Decode.
-	// If not set, a reasonable default is used.
+	// If not set, the limit given by WithDecodeOptions or
+	// DefaultDecodeOptions is used.
 	MaxMessageSize uint64
 }
 
 // NewDecoder creates a new Cap'n Proto framer that reads from r.
-func NewDecoder(r io.Reader) *Decoder {
-	return &Decoder{r: r}
+func NewDecoder(r io.Reader, opts ...CodecOption) *Decoder {
+	o := newCodecOptions(opts)
+	return &Decoder{r: r, trace: newTracer("decode", opts), limit: o.decode, strings: o.strings}
 }
 
 // NewPackedDecoder creates a new Cap'n Proto framer that reads from a
 // packed stream r.
-func NewPackedDecoder(r io.Reader) *Decoder {
-	return NewDecoder(packed.NewReader(bufio.NewReader(r)))
+func NewPackedDecoder(r io.Reader, opts ...CodecOption) *Decoder {
+	return NewDecoder(packed.NewReader(bufio.NewReader(r)), opts...)
 }
 
 // Decode reads a message from the decoder stream.
 func (d *Decoder) Decode() (*Message, error) {
+	m, err := d.decode()
+	if err == nil {
+		m.StringTable = d.strings
+	}
+	if d.trace != nil {
+		switch {
+		case err == nil:
+			d.trace.message(m, -1)
+		case err != io.EOF:
+			d.trace.error(err)
+		}
+	}
+	return m, err
+}
+
+func (d *Decoder) decode() (*Message, error) {
 	maxSize := d.MaxMessageSize
 	if maxSize == 0 {
-		maxSize = defaultDecodeLimit
+		maxSize = maxMessageSizeOrDefault(d.limit.MaxMessageSize)
 	}
 	if _, err := io.ReadFull(d.r, d.segbuf[:]); err != nil {
 		return nil, err
@@ -559,6 +629,19 @@ func (d *Decoder) Decode() (*Message, error) {
 	if total > maxSize-hdrSize || total > (1<<31-1) {
 		return nil, errDecodeLimit
 	}
+	if d.limit.TransformFrame != nil {
+		frame := make([]byte, int(hdrSize+total))
+		copy(frame, d.hdrbuf)
+		if _, err := io.ReadFull(d.r, frame[hdrSize:]); err != nil {
+			return nil, err
+		}
+		msg, err := d.limit.transform(frame, maxSize)
+		if err != nil {
+			return nil, err
+		}
+		d.limit.apply(msg)
+		return msg, nil
+	}
 	if !d.reuse {
 		buf := make([]byte, int(total))
 		if _, err := io.ReadFull(d.r, buf); err != nil {
@@ -568,7 +651,9 @@ func (d *Decoder) Decode() (*Message, error) {
 		if err != nil {
 			return nil, err
 		}
-		return &Message{Arena: arena}, nil
+		msg := &Message{Arena: arena}
+		d.limit.apply(msg)
+		return msg, nil
 	}
 	d.buf = resizeSlice(d.buf, int(total))
 	if _, err := io.ReadFull(d.r, d.buf); err != nil {
@@ -585,6 +670,7 @@ func (d *Decoder) Decode() (*Message, error) {
 			return nil, err
 		}
 	}
+	d.limit.apply(&d.msg)
 	d.msg.Reset(arena)
 	return &d.msg, nil
 }
@@ -604,25 +690,47 @@ func (d *Decoder) ReuseBuffer() {
 
 // Unmarshal reads an unpacked serialized stream into a message.  No
 // copying is performed, so the objects in the returned message read
-// directly from data.
+// directly from data.  data does not need to be word-aligned unless
+// DefaultDecodeOptions.RequireAligned is set.
 func Unmarshal(data []byte) (*Message, error) {
+	msg, _, err := UnmarshalAt(data, 0)
+	return msg, err
+}
+
+// UnmarshalAt reads the unpacked serialized message that starts at
+// data[off:] and returns it along with the number of bytes it occupies,
+// so that several messages framed back to back in one buffer can be
+// read in turn by advancing off.  Bytes after the message are ignored.
+// As with Unmarshal, no copying is performed.  UnmarshalAt returns
+// io.EOF if off is at the end of data.
+func UnmarshalAt(data []byte, off int) (*Message, int, error) {
+	if off < 0 || off > len(data) {
+		return nil, 0, errors.New("capnp: unmarshal offset out of range")
+	}
+	data = data[off:]
 	if len(data) == 0 {
-		return nil, io.EOF
+		return nil, 0, io.EOF
 	}
-	hdr, data, err := parseStreamHeader(data)
+	if DefaultDecodeOptions.RequireAligned && !isAligned(data) {
+		return nil, 0, errUnaligned
+	}
+	hdr, body, err := parseStreamHeader(data)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	if tot, err := hdr.totalSize(); err != nil {
-		return nil, err
-	} else if tot > uint64(len(data)) {
-		return nil, io.ErrUnexpectedEOF
+	tot, err := hdr.totalSize()
+	if err != nil {
+		return nil, 0, err
 	}
-	arena, err := demuxArena(hdr, data)
+	if tot > uint64(len(body)) {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+	arena, err := demuxArena(hdr, body)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return &Message{Arena: arena}, nil
+	n := len(data) - len(body) + int(tot)
+	return &Message{Arena: arena}, n, nil
 }
 
 // UnmarshalPacked reads a packed serialized stream into a message.
@@ -676,21 +784,64 @@ type Encoder struct {
 
 	packed  bool
 	packbuf []byte
+	npacked int // bytes written by the last call to writePacked
+
+	pool     *BufferPool
+	coalesce int    // see WithCoalesce
+	wbuf     []byte // messages waiting for Flush
+	wbufPool poolTicket // tracks wbuf if it was taken from pool
+
+	trace *tracer
 }
 
 // NewEncoder creates a new Cap'n Proto framer that writes to w.
-func NewEncoder(w io.Writer) *Encoder {
-	return &Encoder{w: w}
+func NewEncoder(w io.Writer, opts ...CodecOption) *Encoder {
+	return newEncoder(w, false, opts)
 }
 
 // NewPackedEncoder creates a new Cap'n Proto framer that writes to a
 // packed stream w.
-func NewPackedEncoder(w io.Writer) *Encoder {
-	return &Encoder{w: w, packed: true}
+func NewPackedEncoder(w io.Writer, opts ...CodecOption) *Encoder {
+	return newEncoder(w, true, opts)
+}
+
+func newEncoder(w io.Writer, packed bool, opts []CodecOption) *Encoder {
+	var o codecOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Encoder{
+		w:        w,
+		packed:   packed,
+		coalesce: o.coalesce,
+		trace:    newTracer("encode", opts),
+	}
 }
 
 // Encode writes a message to the encoder stream.
 func (e *Encoder) Encode(m *Message) error {
+	err := e.encode(m)
+	if e.trace != nil {
+		switch {
+		case err != nil:
+			e.trace.error(err)
+		case e.packed:
+			e.trace.message(m, e.npacked)
+		default:
+			e.trace.message(m, -1)
+		}
+	}
+	return err
+}
+
+func (e *Encoder) encode(m *Message) error {
+	if m.Deterministic {
+		dm, err := m.deterministicCopy()
+		if err != nil {
+			return err
+		}
+		m = dm
+	}
 	nsegs := m.NumSegments()
 	if nsegs == 0 {
 		return errMessageEmpty
@@ -718,6 +869,9 @@ func (e *Encoder) Encode(m *Message) error {
 		e.hdrbuf = appendUint32(e.hdrbuf, 0)
 	}
 	e.bufs[0] = e.hdrbuf
+	if e.coalesce > 0 {
+		return e.buffer(e.bufs)
+	}
 	if e.packed {
 		return e.writePacked(e.bufs)
 	}
@@ -725,59 +879,89 @@ func (e *Encoder) Encode(m *Message) error {
 }
 
 func (e *Encoder) writePacked(bufs [][]byte) error {
-	for _, b := range bufs {
-		e.packbuf = packed.Pack(e.packbuf[:0], b)
-		if _, err := e.w.Write(e.packbuf); err != nil {
-			return err
-		}
+	buf := e.packbuf
+	if e.pool != nil {
+		buf = e.pool.getBuf()
 	}
-	return nil
-}
-
-func (m *Message) segmentSizes() ([]Size, error) {
-	nsegs := m.NumSegments()
-	sizes := make([]Size, nsegs)
-	for i := int64(0); i < nsegs; i++ {
-		s, err := m.Segment(SegmentID(i))
+	e.npacked = 0
+	var err error
+	for _, b := range bufs {
+		buf = packed.Pack(buf[:0], b)
+		var n int
+		n, err = e.w.Write(buf)
+		e.npacked += n
 		if err != nil {
-			return sizes[:i], err
+			break
 		}
-		n := len(s.data)
-		if int64(n) > int64(maxSize) {
-			return sizes[:i], errSegmentTooLarge
-		}
-		sizes[i] = Size(n)
 	}
-	return sizes, nil
+	if e.pool != nil {
+		e.pool.putBuf(buf)
+	} else {
+		e.packbuf = buf
+	}
+	return err
 }
 
 // Marshal concatenates the segments in the message into a single byte
 // slice including framing.
 func (m *Message) Marshal() ([]byte, error) {
-	// Compute buffer size.
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the message, framed as by Marshal, to dst and
+// returns the extended slice.  If dst has enough spare capacity, no
+// memory is allocated, so a caller that marshals many messages can
+// reuse one buffer by passing buf[:0].  The message starts at len(dst),
+// so it is word-aligned if len(dst) is a multiple of 8 and dst's
+// backing array is word-aligned, as memory from make is.  On error,
+// dst is returned unchanged.
+func (m *Message) MarshalAppend(dst []byte) ([]byte, error) {
+	if m.Deterministic {
+		dm, err := m.deterministicCopy()
+		if err != nil {
+			return dst, err
+		}
+		m = dm
+	}
 	// TODO(light): error out if too many segments
 	nsegs := m.NumSegments()
 	if nsegs == 0 {
-		return nil, errMessageEmpty
+		return dst, errMessageEmpty
 	}
 	maxSeg := uint32(nsegs - 1)
 	hdrSize := streamHeaderSize(maxSeg)
-	sizes, err := m.segmentSizes()
-	if err != nil {
-		return nil, err
-	}
-	// TODO(light): error out if too large
-	total := uint64(hdrSize) + totalSize(sizes)
 
-	// Fill in buffer.
-	buf := make([]byte, hdrSize, total)
-	// TODO: remove marshalStreamHeader and inline.
-	marshalStreamHeader(buf, sizes)
+	// Compute buffer size.
+	total := hdrSize
 	for i := int64(0); i < nsegs; i++ {
 		s, err := m.Segment(SegmentID(i))
 		if err != nil {
-			return nil, err
+			return dst, err
 		}
+		if int64(len(s.data)) > int64(maxSize) {
+			return dst, errSegmentTooLarge
+		}
+		total += uint64(len(s.data))
+	}
+	// TODO(light): error out if too large
+	buf := dst
+	if uint64(cap(buf)-len(buf)) < total {
+		buf = make([]byte, len(dst), uint64(len(dst))+total)
+		copy(buf, dst)
+	}
+
+	// Fill in buffer.
+	start := len(buf)
+	buf = appendUint32(buf, maxSeg)
+	for i := int64(0); i < nsegs; i++ {
+		s, _ := m.Segment(SegmentID(i))
+		buf = appendUint32(buf, uint32(len(s.data)/int(wordSize)))
+	}
+	for len(buf)-start < int(hdrSize) {
+		buf = append(buf, 0)
+	}
+	for i := int64(0); i < nsegs; i++ {
+		s, _ := m.Segment(SegmentID(i))
 		buf = append(buf, s.data...)
 	}
 	return buf, nil
@@ -806,18 +990,6 @@ func streamHeaderSize(n uint32) uint64 {
 	return (msgHeaderSize + segHeaderSize*(uint64(n)+1) + 7) &^ 7
 }
 
-// marshalStreamHeader marshals the sizes into the byte slice, which
-// must be of size streamHeaderSize(len(sizes) - 1).
-//
-// TODO: remove marshalStreamHeader and inline.
-func marshalStreamHeader(b []byte, sizes []Size) {
-	binary.LittleEndian.PutUint32(b, uint32(len(sizes)-1))
-	for i, sz := range sizes {
-		loc := msgHeaderSize + i*segHeaderSize
-		binary.LittleEndian.PutUint32(b[loc:], uint32(sz/Size(wordSize)))
-	}
-}
-
 // appendUint32 appends a uint32 to a byte slice and returns the
 // new slice.
 func appendUint32(b []byte, v uint32) []byte {
@@ -869,6 +1041,61 @@ func (h streamHeader) totalSize() (uint64, error) {
 	return sum, nil
 }
 
+// A SegmentTable is the header of a message in the stream framing
+// format: the size of each of the message's segments, in order.
+type SegmentTable []Size
+
+// ReadHeader reads the segment table at the start of the next message
+// in the stream r, leaving r positioned at the message's first segment.
+// It does not read or check the segments themselves, so a proxy can use
+// it to find the length of a message without decoding it.  ReadHeader
+// returns io.EOF if r is at the end of the stream, and
+// io.ErrUnexpectedEOF if the stream ends in the middle of the header.
+func ReadHeader(r io.Reader) (SegmentTable, error) {
+	var first [msgHeaderSize]byte
+	if _, err := io.ReadFull(r, first[:]); err != nil {
+		return nil, err
+	}
+	maxSeg := binary.LittleEndian.Uint32(first[:])
+	if maxSeg > maxStreamSegments {
+		return nil, errTooManySegments
+	}
+	hdr := make([]byte, streamHeaderSize(maxSeg))
+	copy(hdr, first[:])
+	if _, err := io.ReadFull(r, hdr[msgHeaderSize:]); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	h := streamHeader{b: hdr}
+	t := make(SegmentTable, int(maxSeg)+1)
+	for i := range t {
+		sz, err := h.segmentSize(uint32(i))
+		if err != nil {
+			return nil, err
+		}
+		t[i] = sz
+	}
+	return t, nil
+}
+
+// TotalSize returns the number of bytes of segment data that follow
+// the table in the stream.
+func (t SegmentTable) TotalSize() uint64 {
+	return totalSize(t)
+}
+
+// HeaderSize returns the number of bytes that the table occupies in the
+// stream, including padding.  A framed message is HeaderSize() +
+// TotalSize() bytes long.
+func (t SegmentTable) HeaderSize() uint64 {
+	if len(t) == 0 {
+		return 0
+	}
+	return streamHeaderSize(uint32(len(t) - 1))
+}
+
 func hasCapacity(b []byte, sz Size) bool {
 	return sz <= Size(cap(b)-len(b))
 }
@@ -910,4 +1137,11 @@ var (
 	errSegmentTooLarge    = errors.New("capnp: segment too large")
 	errTooManySegments    = errors.New("capnp: too many segments to decode")
 	errDecodeLimit        = errors.New("capnp: message too large")
+	errNoRoot             = errors.New("capnp: first segment too small for root pointer")
+	errBufferFull         = errors.New("capnp: fixed buffer is full")
+	errUnaligned          = errors.New("capnp: message data is not word-aligned")
+	errNotText            = errors.New("capnp: list pointer is not text")
+	errNotData            = errors.New("capnp: list pointer is not data")
+	errNilSegment         = errors.New("capnp: allocation in nil segment")
+	errStaleToken         = errors.New("capnp: rollback to token invalidated by an earlier rollback")
 )
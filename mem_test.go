@@ -532,6 +532,43 @@ func TestMarshal(t *testing.T) {
 	}
 }
 
+func TestMarshalAppend(t *testing.T) {
+	prefix := []byte("prefix!!")
+	for i, test := range serializeTests {
+		if test.decodeFails || test.encodeFails {
+			continue
+		}
+		msg := &Message{Arena: test.arena()}
+		dst := append(make([]byte, 0, 4), prefix...)
+		out, err := msg.MarshalAppend(dst)
+		if err != nil {
+			t.Errorf("serializeTests[%d] %s: MarshalAppend error: %v", i, test.name, err)
+			continue
+		}
+		if want := append(append([]byte(nil), prefix...), test.out...); !bytes.Equal(out, want) {
+			t.Errorf("serializeTests[%d] - %s: MarshalAppend = % 02x; want % 02x", i, test.name, out, want)
+		}
+	}
+
+	_, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewRootStruct(seg, ObjectSize{DataSize: 8}); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 0, 1024)
+	allocs := testing.AllocsPerRun(10, func() {
+		out, err := seg.Message().MarshalAppend(buf[:0])
+		if err != nil || len(out) != 24 {
+			t.Fatalf("MarshalAppend = % 02x, %v; want 24 bytes", out, err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("MarshalAppend into buffer with spare capacity made %.1f allocations; want 0", allocs)
+	}
+}
+
 func TestUnmarshal(t *testing.T) {
 	for i, test := range serializeTests {
 		if test.encodeFails {
@@ -568,6 +605,52 @@ func TestUnmarshal(t *testing.T) {
 	}
 }
 
+func TestUnmarshalAt(t *testing.T) {
+	var buf []byte
+	var tests []int
+	for i, test := range serializeTests {
+		if test.encodeFails || test.decodeFails || len(test.out) == 0 {
+			continue
+		}
+		buf = append(buf, test.out...)
+		tests = append(tests, i)
+	}
+	off := 0
+	for _, i := range tests {
+		test := serializeTests[i]
+		msg, n, err := UnmarshalAt(buf, off)
+		if err != nil {
+			t.Fatalf("UnmarshalAt(buf, %d) (serializeTests[%d] - %s): %v", off, i, test.name, err)
+		}
+		if n != len(test.out) {
+			t.Errorf("serializeTests[%d] - %s: UnmarshalAt consumed %d bytes; want %d", i, test.name, n, len(test.out))
+		}
+		if msg.NumSegments() != int64(len(test.segs)) {
+			t.Errorf("serializeTests[%d] - %s: UnmarshalAt NumSegments() = %d; want %d", i, test.name, msg.NumSegments(), len(test.segs))
+		}
+		for j := range test.segs {
+			seg, err := msg.Segment(SegmentID(j))
+			if err != nil {
+				t.Errorf("serializeTests[%d] - %s: UnmarshalAt Segment(%d) error: %v", i, test.name, j, err)
+				continue
+			}
+			if !bytes.Equal(seg.Data(), test.segs[j]) || cap(seg.Data()) != len(test.segs[j]) {
+				t.Errorf("serializeTests[%d] - %s: UnmarshalAt Segment(%d) = % 02x (cap %d); want % 02x", i, test.name, j, seg.Data(), cap(seg.Data()), test.segs[j])
+			}
+		}
+		off += n
+	}
+	if _, _, err := UnmarshalAt(buf, off); err != io.EOF {
+		t.Errorf("UnmarshalAt at end = %v; want io.EOF", err)
+	}
+	if _, _, err := UnmarshalAt(buf[:len(buf)-1], off-len(serializeTests[tests[len(tests)-1]].out)); err != io.ErrUnexpectedEOF {
+		t.Errorf("UnmarshalAt of truncated message = %v; want io.ErrUnexpectedEOF", err)
+	}
+	if _, _, err := UnmarshalAt(buf, len(buf)+1); err == nil {
+		t.Error("UnmarshalAt past end succeeded; want error")
+	}
+}
+
 func TestEncoder(t *testing.T) {
 	for i, test := range serializeTests {
 		if test.decodeFails {
@@ -813,7 +896,9 @@ func TestNextAlloc(t *testing.T) {
 		{name: "first word, unaligned curr", curr: 13, max: max64, req: 8, ok: true},
 		{name: "second word", curr: 8, max: max64, req: 8, ok: true},
 		{name: "one byte pads to word", curr: 8, max: max64, req: 1, ok: true},
-		{name: "max size", curr: 0, max: max64, req: 0xfffffff8, ok: true},
+		{name: "max size", curr: 0, max: max64, req: 0xfffffff8, ok: !isInt32Bit},
+		{name: "max 32-bit size", curr: 0, max: max64, req: 1<<31 - 8, ok: true},
+		{name: "max 32-bit size + 1", curr: 0, max: max64, req: 1<<31 - 7, ok: !isInt32Bit},
 		{name: "max size + 1", curr: 0, max: max64, req: 0xfffffff9, ok: false},
 		{name: "max req", curr: 0, max: max64, req: 0xffffffff, ok: false},
 		{name: "max curr, request 0", curr: max64, max: max64, req: 0, ok: true},
@@ -916,3 +1001,85 @@ func (readOnlyArena) Allocate(sz Size, segs map[SegmentID]*Segment) (SegmentID,
 }
 
 var errReadOnlyArena = errors.New("Allocate called on read-only arena")
+
+func TestReadHeader(t *testing.T) {
+	var buf []byte
+	var tests []int
+	for i, test := range serializeTests {
+		if test.encodeFails || test.decodeFails || len(test.out) == 0 {
+			continue
+		}
+		buf = append(buf, test.out...)
+		tests = append(tests, i)
+	}
+	r := bytes.NewReader(buf)
+	for _, i := range tests {
+		test := serializeTests[i]
+		tab, err := ReadHeader(r)
+		if err != nil {
+			t.Fatalf("serializeTests[%d] - %s: ReadHeader: %v", i, test.name, err)
+		}
+		if len(tab) != len(test.segs) {
+			t.Errorf("serializeTests[%d] - %s: ReadHeader has %d segments; want %d", i, test.name, len(tab), len(test.segs))
+		}
+		if n := tab.HeaderSize() + tab.TotalSize(); n != uint64(len(test.out)) {
+			t.Errorf("serializeTests[%d] - %s: HeaderSize() + TotalSize() = %d; want %d", i, test.name, n, len(test.out))
+		}
+		// Skip the segments to get to the next message.
+		if _, err := r.Seek(int64(tab.TotalSize()), io.SeekCurrent); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := ReadHeader(r); err != io.EOF {
+		t.Errorf("ReadHeader at end = %v; want io.EOF", err)
+	}
+	if _, err := ReadHeader(bytes.NewReader([]byte{1, 0, 0, 0, 1, 0})); err != io.ErrUnexpectedEOF {
+		t.Errorf("ReadHeader of truncated header = %v; want io.ErrUnexpectedEOF", err)
+	}
+	if _, err := ReadHeader(bytes.NewReader([]byte{0xff, 0xff, 0, 0})); err != errTooManySegments {
+		t.Errorf("ReadHeader with too many segments = %v; want %v", err, errTooManySegments)
+	}
+}
+
+func TestNewMessageFromBytes(t *testing.T) {
+	buf := make([]byte, 0, 32)
+	msg, err := NewMessageFromBytes(buf, ReadWrite)
+	if err != nil {
+		t.Fatal("NewMessageFromBytes(ReadWrite):", err)
+	}
+	root, err := AllocateRoot(msg, ObjectSize{DataSize: 8})
+	if err != nil {
+		t.Fatal("AllocateRoot:", err)
+	}
+	root.SetUint64(0, 0xdeadbeef)
+	if _, err := NewStruct(root.Segment(), ObjectSize{DataSize: 24}); err == nil {
+		t.Error("allocating past the buffer's capacity succeeded")
+	}
+	seg, err := msg.Segment(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := seg.Data()
+	if &data[0] != &buf[:1][0] {
+		t.Error("message does not use the buffer it was given")
+	}
+
+	ro, err := NewMessageFromBytes(data, ReadOnly)
+	if err != nil {
+		t.Fatal("NewMessageFromBytes(ReadOnly):", err)
+	}
+	p, err := ro.RootPtr()
+	if err != nil {
+		t.Fatal("RootPtr:", err)
+	}
+	if got := p.Struct().Uint64(0); got != 0xdeadbeef {
+		t.Errorf("root.Uint64(0) = %#x; want 0xdeadbeef", got)
+	}
+	if _, err := NewStruct(p.Struct().Segment(), ObjectSize{DataSize: 8}); err == nil {
+		t.Error("allocating in a read-only message succeeded")
+	}
+
+	if _, err := NewMessageFromBytes(make([]byte, 7), ReadOnly); err == nil {
+		t.Error("NewMessageFromBytes accepted a buffer that is not word-aligned in size")
+	}
+}
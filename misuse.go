@@ -0,0 +1,50 @@
+package capnp
+
+import "fmt"
+
+// misuse reports a programmer error, such as an out-of-bounds index or
+// a write to an invalid struct.  Normally it panics with err.  If the
+// package is built with the capnp_nopanic build tag, it records err on
+// seg's message instead (see Message.Err), and the caller returns a
+// zero value or does nothing.
+func misuse(seg *Segment, err error) {
+	if !panicFree {
+		panic(err)
+	}
+	if seg != nil && seg.msg != nil {
+		seg.msg.recordMisuse(err)
+	}
+}
+
+func (m *Message) recordMisuse(err error) {
+	m.mu.Lock()
+	if m.misuseErr == nil {
+		m.misuseErr = err
+	}
+	m.mu.Unlock()
+}
+
+// Err returns the first programmer error recorded on the message since
+// it was created or last Reset.  Errors are only recorded when the
+// package is built with the capnp_nopanic build tag; otherwise they
+// cause a panic, and Err always returns nil.
+//
+// With the capnp_nopanic tag, methods that would have panicked, such as
+// setters on an invalid struct, out-of-bounds list accesses, and
+// accessors for inactive union members, instead return a zero value or
+// do nothing.  Services that must not crash on malformed or misused
+// messages can check Err after building or reading a message.
+func (m *Message) Err() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.misuseErr
+}
+
+// UnionMismatch reports that the accessor for the union member field of
+// s was called while a different member was set.  It is called by
+// generated code.  Normally it panics; with the capnp_nopanic build tag,
+// it records the error on s's message and the accessor returns the
+// value stored at the field's location.
+func UnionMismatch(s Struct, field string) {
+	misuse(s.seg, fmt.Errorf("Which() != %s", field))
+}
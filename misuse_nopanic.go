@@ -0,0 +1,6 @@
+// +build capnp_nopanic
+
+package capnp
+
+// panicFree is true if misuse records errors instead of panicking.
+var panicFree = true
@@ -0,0 +1,164 @@
+package capnp
+
+import "testing"
+
+// setPanicFree sets panicFree for the duration of a test.
+func setPanicFree(t *testing.T, v bool) {
+	old := panicFree
+	panicFree = v
+	t.Cleanup(func() { panicFree = old })
+}
+
+func TestMisuse_Panics(t *testing.T) {
+	setPanicFree(t, false)
+	_, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewStruct(seg, ObjectSize{DataSize: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("SetUint64 out of bounds did not panic")
+		}
+		if err := seg.Message().Err(); err != nil {
+			t.Errorf("Err() = %v; want nil", err)
+		}
+	}()
+	s.SetUint64(8, 42)
+}
+
+func TestMisuse_PanicFree(t *testing.T) {
+	setPanicFree(t, true)
+	msg, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewStruct(seg, ObjectSize{DataSize: 8, PointerCount: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := msg.Err(); err != nil {
+		t.Fatalf("Err() before misuse = %v", err)
+	}
+
+	s.SetUint64(8, 42)
+	if err := msg.Err(); err != errOutOfBounds {
+		t.Errorf("Err() after SetUint64 out of bounds = %v; want %v", err, errOutOfBounds)
+	}
+	if err := s.SetPtr(1, Ptr{}); err != errOutOfBounds {
+		t.Errorf("SetPtr(1) = %v; want %v", err, errOutOfBounds)
+	}
+
+	msg.Reset(SingleSegment(nil))
+	if err := msg.Err(); err != nil {
+		t.Errorf("Err() after Reset = %v; want nil", err)
+	}
+	seg, err = msg.Segment(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := NewInt32List(seg, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Set(2, 7)
+	if n := l.At(-1); n != 0 {
+		t.Errorf("At(-1) = %d; want 0", n)
+	}
+	if err := msg.Err(); err != errOutOfBounds {
+		t.Errorf("Err() after Set(2) = %v; want %v", err, errOutOfBounds)
+	}
+
+	msg.Reset(SingleSegment(nil))
+	seg, err = msg.Segment(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err = NewStruct(seg, ObjectSize{DataSize: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	UnionMismatch(s, "foo")
+	if err := msg.Err(); err == nil || err.Error() != "Which() != foo" {
+		t.Errorf("Err() after UnionMismatch = %v; want Which() != foo", err)
+	}
+
+	// Misuse of an invalid object has no message to record on.
+	Struct{}.SetUint8(0, 1)
+	if _, err := NewStruct(nil, ObjectSize{DataSize: 8}); err != errNilSegment {
+		t.Errorf("NewStruct(nil, ...) error = %v; want %v", err, errNilSegment)
+	}
+}
+
+func TestMisuse_PanicFreeZeroValues(t *testing.T) {
+	setPanicFree(t, true)
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"Struct.SetText", func() { Struct{}.SetText(0, "x") }},
+		{"Struct.SetNewText", func() { Struct{}.SetNewText(0, "x") }},
+		{"Struct.SetTextFromBytes", func() { Struct{}.SetTextFromBytes(0, []byte("x")) }},
+		{"Struct.SetData", func() { Struct{}.SetData(0, []byte("x")) }},
+		{"Struct.SetPtr", func() { Struct{}.SetPtr(0, Ptr{}) }},
+		{"Struct.ClearPtr", func() { Struct{}.ClearPtr(0) }},
+		{"Struct.SetBit", func() { Struct{}.SetBit(0, true) }},
+		{"Struct.SetUint64", func() { Struct{}.SetUint64(0, 1) }},
+		{"Struct.ZeroData", func() { Struct{}.ZeroData() }},
+		{"List.SetStruct", func() { List{}.SetStruct(0, Struct{}) }},
+		{"List.Swap", func() { List{}.Swap(0, 1) }},
+		{"List.MoveWithin", func() { List{}.MoveWithin(0, 1) }},
+		{"BitList.Set", func() { BitList{}.Set(0, true) }},
+		{"PointerList.SetPtr", func() { PointerList{}.SetPtr(0, Ptr{}) }},
+		{"TextList.Set", func() { TextList{}.Set(0, "x") }},
+		{"TextList.At", func() { TextList{}.At(0) }},
+		{"DataList.Set", func() { DataList{}.Set(0, []byte("x")) }},
+		{"DataList.At", func() { DataList{}.At(0) }},
+		{"DataList.Bytes", func() { DataList{}.Bytes() }},
+		{"UInt8List.Set", func() { UInt8List{}.Set(0, 1) }},
+		{"Float64List.Set", func() { Float64List{}.Set(0, 1) }},
+	}
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("%s on zero value panicked: %v", test.name, r)
+				}
+			}()
+			test.f()
+		}()
+	}
+	for _, test := range []struct {
+		name string
+		set  func() error
+	}{
+		{"Struct.SetText", func() error { return Struct{}.SetText(0, "x") }},
+		{"Struct.SetTextFromBytes", func() error { return Struct{}.SetTextFromBytes(0, []byte("x")) }},
+		{"Struct.SetData", func() error { return Struct{}.SetData(0, []byte("x")) }},
+		{"TextList.Set", func() error { return TextList{}.Set(0, "x") }},
+		{"DataList.Set", func() error { return DataList{}.Set(0, []byte("x")) }},
+	} {
+		if err := test.set(); err == nil {
+			t.Errorf("%s on zero value succeeded; want error", test.name)
+		}
+	}
+
+	msg, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg.Intern = true
+	s, err := NewStruct(seg, ObjectSize{PointerCount: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetText(1, "x"); err != errOutOfBounds {
+		t.Errorf("interned SetText(1) = %v; want %v", err, errOutOfBounds)
+	}
+	if err := msg.Err(); err != errOutOfBounds {
+		t.Errorf("Err() after interned SetText(1) = %v; want %v", err, errOutOfBounds)
+	}
+}
@@ -0,0 +1,305 @@
+// Package pipelineop converts between pipeline transforms and the
+// schema field names they refer to.
+//
+// A pipelined call's target is described by a list of capnp.PipelineOp,
+// each of which names a pointer field by its index in a struct's
+// pointer section.  A Resolver uses schemas to build such a list from
+// field names and to describe an existing list, which is useful when
+// logging or debugging queued pipelined calls:
+//
+//	ops, err := pipelineop.Build(foo.Bar_TypeID, "baz", "cap")
+//	...
+//	desc, err := pipelineop.Format(foo.Bar_TypeID, p.Transform()) // "baz.cap"
+//
+// Describe uses the same lookup to name the fields in a
+// *capnp.PipelineError.
+package pipelineop // import "github.com/iguazio/go-capnproto2/pipelineop"
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/nodemap"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+	"github.com/iguazio/go-capnproto2/schemas"
+)
+
+// Build returns the transform that selects the field named by path,
+// starting from a struct of the given type, using the default registry.
+func Build(typeID uint64, path ...string) ([]capnp.PipelineOp, error) {
+	return new(Resolver).Build(typeID, path...)
+}
+
+// Names returns the field names of the transform, using the default
+// registry.
+func Names(typeID uint64, ops []capnp.PipelineOp) ([]string, error) {
+	return new(Resolver).Names(typeID, ops)
+}
+
+// Format returns the field names of the transform joined by dots,
+// using the default registry.
+func Format(typeID uint64, ops []capnp.PipelineOp) (string, error) {
+	return new(Resolver).Format(typeID, ops)
+}
+
+// Describe adds the failing call and field names to a
+// *capnp.PipelineError, using the default registry.
+func Describe(m *capnp.Method, err error) error {
+	return new(Resolver).Describe(m, err)
+}
+
+// A Resolver maps between pipeline transforms and field names.  The
+// zero value uses the default registry.
+type Resolver struct {
+	nodes nodemap.Map
+}
+
+// UseRegistry changes the registry that the resolver consults for
+// schemas.  The default is schemas.DefaultRegistry.
+func (r *Resolver) UseRegistry(reg *schemas.Registry) {
+	r.nodes.UseRegistry(reg)
+}
+
+// Build returns the transform that selects the field named by path,
+// starting from a struct of the given type.  Each element of path is a
+// field name; naming a group descends into it without adding an op.
+// Every field along the path except the last must be a struct, and the
+// last must be a pointer field, usually an interface.  Struct fields
+// with default values get the same DefaultValue as generated code
+// would give them.
+func (r *Resolver) Build(typeID uint64, path ...string) ([]capnp.PipelineOp, error) {
+	var ops []capnp.PipelineOp
+	id := typeID
+	group := false
+	for i, name := range path {
+		n, err := r.structNode(id)
+		if err != nil {
+			return nil, fmt.Errorf("pipelineop: %v", err)
+		}
+		f, ok := findField(n, name)
+		if !ok {
+			dn, _ := n.DisplayName()
+			return nil, fmt.Errorf("pipelineop: no field %s in %s", name, dn)
+		}
+		group = f.Which() == schema.Field_Which_group
+		if group {
+			id = f.Group().TypeId()
+			continue
+		}
+		t, err := f.Slot().Type()
+		if err != nil {
+			return nil, fmt.Errorf("pipelineop: field %s: %v", name, err)
+		}
+		if !isPointer(t) {
+			return nil, fmt.Errorf("pipelineop: field %s is not a pointer", name)
+		}
+		last := i == len(path)-1
+		if !last && t.Which() != schema.Type_Which_structType {
+			return nil, fmt.Errorf("pipelineop: field %s is not a struct", name)
+		}
+		op := capnp.PipelineOp{Field: uint16(f.Slot().Offset())}
+		if t.Which() == schema.Type_Which_structType {
+			op.DefaultValue, err = structDefault(f)
+			if err != nil {
+				return nil, fmt.Errorf("pipelineop: field %s: %v", name, err)
+			}
+			id = t.StructType().TypeId()
+		}
+		ops = append(ops, op)
+	}
+	if group {
+		return nil, fmt.Errorf("pipelineop: %s is a group, not a pointer", strings.Join(path, "."))
+	}
+	return ops, nil
+}
+
+// Names returns the name of the field that each op in the transform
+// selects, starting from a struct of the given type.  Fields inside
+// groups are named by their path from the struct, like "grp.field".
+// If several members of a union share the pointer, their names are
+// joined by "|".
+func (r *Resolver) Names(typeID uint64, ops []capnp.PipelineOp) ([]string, error) {
+	names := make([]string, len(ops))
+	id := typeID
+	for i, op := range ops {
+		n, err := r.structNode(id)
+		if err != nil {
+			return nil, fmt.Errorf("pipelineop: op %d: %v", i, err)
+		}
+		var cands []pointerField
+		if err := r.pointerFields(&cands, n, "", op.Field); err != nil {
+			return nil, fmt.Errorf("pipelineop: op %d: %v", i, err)
+		}
+		if len(cands) == 0 {
+			dn, _ := n.DisplayName()
+			return nil, fmt.Errorf("pipelineop: op %d: %s has no pointer field %d", i, dn, op.Field)
+		}
+		next := uint64(0)
+		for j, c := range cands {
+			if j > 0 {
+				names[i] += "|"
+			}
+			names[i] += c.name
+			if c.structID != 0 && next == 0 {
+				next = c.structID
+			}
+		}
+		if i < len(ops)-1 && next == 0 {
+			return nil, fmt.Errorf("pipelineop: op %d: field %s is not a struct", i, names[i])
+		}
+		id = next
+	}
+	return names, nil
+}
+
+// Format returns the field names of the transform joined by dots.
+func (r *Resolver) Format(typeID uint64, ops []capnp.PipelineOp) (string, error) {
+	names, err := r.Names(typeID, ops)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(names, "."), nil
+}
+
+// Describe returns a copy of err with its Method set to m, if it
+// doesn't have one, and its Names set to the fields of the method's
+// results that the ops up to the failing one select.  Errors other than
+// *capnp.PipelineError are returned unchanged, as is the error if the
+// method's schema isn't registered.
+func (r *Resolver) Describe(m *capnp.Method, err error) error {
+	pe, ok := err.(*capnp.PipelineError)
+	if !ok {
+		return err
+	}
+	desc := *pe
+	if desc.Method == nil {
+		desc.Method = m
+	}
+	if desc.Method != nil && desc.Names == nil && desc.Step >= 0 && desc.Step < len(desc.Transform) {
+		id, rerr := r.resultsType(desc.Method)
+		if rerr == nil {
+			desc.Names, _ = r.Names(id, desc.Transform[:desc.Step+1])
+		}
+	}
+	return &desc
+}
+
+// resultsType returns the type ID of m's results struct.
+func (r *Resolver) resultsType(m *capnp.Method) (uint64, error) {
+	n, err := r.nodes.Find(m.InterfaceID)
+	if err != nil {
+		return 0, err
+	}
+	if n.Which() != schema.Node_Which_interface {
+		return 0, fmt.Errorf("%#x is not an interface type", m.InterfaceID)
+	}
+	methods, err := n.Interface().Methods()
+	if err != nil {
+		return 0, err
+	}
+	if int(m.MethodID) >= methods.Len() {
+		return 0, fmt.Errorf("%#x has no method @%d", m.InterfaceID, m.MethodID)
+	}
+	return methods.At(int(m.MethodID)).ResultStructType(), nil
+}
+
+func (r *Resolver) structNode(typeID uint64) (schema.Node, error) {
+	n, err := r.nodes.Find(typeID)
+	if err != nil {
+		return schema.Node{}, err
+	}
+	if n.Which() != schema.Node_Which_structNode {
+		return schema.Node{}, fmt.Errorf("%#x is not a struct type", typeID)
+	}
+	return n, nil
+}
+
+type pointerField struct {
+	name     string
+	structID uint64 // zero if the field is not a struct
+}
+
+// pointerFields appends the fields of n, including those in groups,
+// that are stored in pointer i.
+func (r *Resolver) pointerFields(out *[]pointerField, n schema.Node, prefix string, i uint16) error {
+	fields, err := n.StructNode().Fields()
+	if err != nil {
+		return err
+	}
+	for j := 0; j < fields.Len(); j++ {
+		f := fields.At(j)
+		name, err := f.Name()
+		if err != nil {
+			return err
+		}
+		if f.Which() == schema.Field_Which_group {
+			g, err := r.structNode(f.Group().TypeId())
+			if err != nil {
+				return err
+			}
+			if err := r.pointerFields(out, g, prefix+name+".", i); err != nil {
+				return err
+			}
+			continue
+		}
+		t, err := f.Slot().Type()
+		if err != nil {
+			return err
+		}
+		if !isPointer(t) || f.Slot().Offset() != uint32(i) {
+			continue
+		}
+		pf := pointerField{name: prefix + name}
+		if t.Which() == schema.Type_Which_structType {
+			pf.structID = t.StructType().TypeId()
+		}
+		*out = append(*out, pf)
+	}
+	return nil
+}
+
+func findField(n schema.Node, name string) (schema.Field, bool) {
+	fields, err := n.StructNode().Fields()
+	if err != nil {
+		return schema.Field{}, false
+	}
+	for i := 0; i < fields.Len(); i++ {
+		f := fields.At(i)
+		if fn, _ := f.Name(); fn == name {
+			return f, true
+		}
+	}
+	return schema.Field{}, false
+}
+
+func isPointer(t schema.Type) bool {
+	switch t.Which() {
+	case schema.Type_Which_text, schema.Type_Which_data, schema.Type_Which_list,
+		schema.Type_Which_structType, schema.Type_Which_interface, schema.Type_Which_anyPointer:
+		return true
+	default:
+		return false
+	}
+}
+
+// structDefault returns the field's default value as a marshaled
+// message, or nil if it has none.
+func structDefault(f schema.Field) ([]byte, error) {
+	dv, err := f.Slot().DefaultValue()
+	if err != nil || !dv.IsValid() || dv.Which() != schema.Value_Which_structValue {
+		return nil, err
+	}
+	p, err := dv.StructValuePtr()
+	if err != nil || !p.IsValid() {
+		return nil, err
+	}
+	msg, _, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		return nil, err
+	}
+	if err := msg.SetRootPtr(p); err != nil {
+		return nil, err
+	}
+	return msg.Marshal()
+}
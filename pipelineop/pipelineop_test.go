@@ -0,0 +1,130 @@
+package pipelineop
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+func TestBuild(t *testing.T) {
+	ops, err := Build(air.Hoth_TypeID, "base", "echo")
+	if err != nil {
+		t.Fatal("Build:", err)
+	}
+	if want := []capnp.PipelineOp{{Field: 0}, {Field: 0}}; !reflect.DeepEqual(ops, want) {
+		t.Errorf("Build(Hoth, base, echo) = %v; want %v", ops, want)
+	}
+}
+
+func TestBuild_MatchesGenerated(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := air.NewRootStackingRoot(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := air.StackingRoot_Promise{Pipeline: capnp.NewPipeline(capnp.ImmediateAnswer(root.Struct))}
+	want := p.AWithDefault().B().Pipeline.Transform()
+
+	ops, err := Build(air.StackingRoot_TypeID, "aWithDefault", "b")
+	if err != nil {
+		t.Fatal("Build:", err)
+	}
+	if len(ops) != 2 || ops[0].DefaultValue == nil {
+		t.Fatalf("Build(StackingRoot, aWithDefault, b) = %v; want two ops with a default on the first", ops)
+	}
+	if !reflect.DeepEqual(ops, want) {
+		t.Errorf("Build(StackingRoot, aWithDefault, b) = %v; generated code uses %v", ops, want)
+	}
+	got := p.Pipeline.Apply(ops...).Transform()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Apply(ops).Transform() = %v; want %v", got, want)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		typeID uint64
+		ops    []capnp.PipelineOp
+		want   string
+	}{
+		{air.Hoth_TypeID, []capnp.PipelineOp{{Field: 0}, {Field: 0}}, "base.echo"},
+		{air.StackingRoot_TypeID, []capnp.PipelineOp{{Field: 1}, {Field: 0}}, "a.b"},
+		{air.Aircraft_TypeID, []capnp.PipelineOp{{Field: 0}}, "b737|a320|f16"},
+		{air.StackingRoot_TypeID, nil, ""},
+	}
+	for _, test := range tests {
+		got, err := Format(test.typeID, test.ops)
+		if err != nil {
+			t.Errorf("Format(%#x, %v): %v", test.typeID, test.ops, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("Format(%#x, %v) = %q; want %q", test.typeID, test.ops, got, test.want)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	if _, err := Build(air.Hoth_TypeID, "nope"); err == nil || !strings.Contains(err.Error(), "no field nope") {
+		t.Errorf("Build with unknown field = %v", err)
+	}
+	if _, err := Build(air.StackingA_TypeID, "num"); err == nil || !strings.Contains(err.Error(), "not a pointer") {
+		t.Errorf("Build with data field = %v", err)
+	}
+	if _, err := Build(air.Z_TypeID, "grp"); err == nil || !strings.Contains(err.Error(), "group") {
+		t.Errorf("Build ending in group = %v", err)
+	}
+	if _, err := Build(air.Z_TypeID, "text", "x"); err == nil || !strings.Contains(err.Error(), "not a struct") {
+		t.Errorf("Build through text = %v", err)
+	}
+	if _, err := Names(air.Hoth_TypeID, []capnp.PipelineOp{{Field: 3}}); err == nil {
+		t.Error("Names with out of range pointer succeeded")
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	m := &capnp.Method{
+		InterfaceID:   air.Echo_TypeID,
+		MethodID:      0,
+		InterfaceName: "aircraft.capnp:Echo",
+		MethodName:    "echo",
+	}
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := air.NewRootEcho_echo_Results(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := res.SetOut("hi"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = capnp.TransformClient(res.ToPtr(), []capnp.PipelineOp{{Field: 0}})
+	if err == nil {
+		t.Fatal("TransformClient(results, out) succeeded; want error")
+	}
+	err = Describe(m, err)
+	pe, ok := err.(*capnp.PipelineError)
+	if !ok {
+		t.Fatalf("Describe(m, err) = %v; want *capnp.PipelineError", err)
+	}
+	if pe.Method != m || !reflect.DeepEqual(pe.Names, []string{"out"}) {
+		t.Errorf("Describe(m, err) = {Method: %v, Names: %q}; want {Method: %v, Names: [\"out\"]}", pe.Method, pe.Names, m)
+	}
+	if want := "capnp: pipeline aircraft.capnp:Echo.echo results.out: capnp: list pointer used as capability"; err.Error() != want {
+		t.Errorf("Describe(m, err).Error() = %q; want %q", err.Error(), want)
+	}
+
+	other := errors.New("other")
+	if err := Describe(m, other); err != other {
+		t.Errorf("Describe(m, other) = %v; want other", err)
+	}
+}
@@ -0,0 +1,213 @@
+package pogs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/iguazio/go-capnproto2/internal/schema"
+)
+
+// RegisterConverter registers a pair of functions that convert between
+// Go values of goType and values of capnpType, a Go type that pogs
+// already knows how to map onto a Cap'n Proto field (like int64,
+// []byte, or string).  Insert calls to with a goType value and expects
+// a capnpType value back; Extract calls from with a capnpType value and
+// expects a goType value back.
+//
+// A Go type may have converters for several capnpTypes: the first one
+// registered that matches the schema type of a field is used.  Fields
+// and lists of goType may then be used wherever a capnpType would be
+// accepted.
+//
+// RegisterConverter panics if capnpType can't be mapped onto a
+// Cap'n Proto field or if a converter has already been registered for
+// the same pair of types.  It should only be called during init().
+func RegisterConverter(goType, capnpType reflect.Type, to, from func(interface{}) (interface{}, error)) {
+	if to == nil || from == nil {
+		panic("pogs: RegisterConverter called with nil function")
+	}
+	if !isConverterTarget(capnpType) {
+		panic(fmt.Sprintf("pogs: can't register converter for %v: %v does not map onto a Cap'n Proto type", goType, capnpType))
+	}
+	converters.mu.Lock()
+	defer converters.mu.Unlock()
+	for _, c := range converters.m[goType] {
+		if c.capnpType == capnpType {
+			panic(fmt.Sprintf("pogs: converter from %v to %v registered twice", goType, capnpType))
+		}
+	}
+	if converters.m == nil {
+		converters.m = make(map[reflect.Type][]*converter)
+	}
+	converters.m[goType] = append(converters.m[goType], &converter{
+		goType:    goType,
+		capnpType: capnpType,
+		to:        to,
+		from:      from,
+	})
+}
+
+var converters struct {
+	mu sync.RWMutex
+	m  map[reflect.Type][]*converter
+}
+
+type converter struct {
+	goType    reflect.Type
+	capnpType reflect.Type
+	to, from  func(interface{}) (interface{}, error)
+}
+
+// findConverter returns the converter for t that matches the schema
+// type s, or nil if there is none.
+func findConverter(t reflect.Type, s schema.Type) *converter {
+	converters.mu.RLock()
+	cs := converters.m[t]
+	converters.mu.RUnlock()
+	for _, c := range cs {
+		if isBaseTypeMatch(c.capnpType, s) {
+			return c
+		}
+	}
+	return nil
+}
+
+// convertTo returns val converted into a value of c.capnpType.
+func (c *converter) convertTo(val reflect.Value) (reflect.Value, error) {
+	v, err := c.to(val.Interface())
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("convert %v to %v: %v", c.goType, c.capnpType, err)
+	}
+	return convertResult(v, c.capnpType)
+}
+
+// convertFrom sets dst to the conversion of src, a value of c.capnpType.
+func (c *converter) convertFrom(dst, src reflect.Value) error {
+	v, err := c.from(src.Interface())
+	if err != nil {
+		return fmt.Errorf("convert %v to %v: %v", c.capnpType, c.goType, err)
+	}
+	rv, err := convertResult(v, dst.Type())
+	if err != nil {
+		return err
+	}
+	dst.Set(rv)
+	return nil
+}
+
+func convertResult(v interface{}, t reflect.Type) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return reflect.Zero(t), nil
+	}
+	if !rv.Type().ConvertibleTo(t) {
+		return reflect.Value{}, fmt.Errorf("converter returned %v, want %v", rv.Type(), t)
+	}
+	return rv.Convert(t), nil
+}
+
+func isConverterTarget(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Bool,
+		reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.String:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.Uint8
+	default:
+		return false
+	}
+}
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	ipType    = reflect.TypeOf(net.IP(nil))
+	int64Type = reflect.TypeOf(int64(0))
+	bytesType = reflect.TypeOf([]byte(nil))
+	strType   = reflect.TypeOf("")
+)
+
+// Range of times that fit in an Int64 of nanoseconds since the epoch.
+var (
+	minUnixNanoTime = time.Unix(0, math.MinInt64)
+	maxUnixNanoTime = time.Unix(0, math.MaxInt64)
+)
+
+// Built-in converters.  The zero time.Time is stored as zero or the
+// empty string so that it survives a round trip, which means that the
+// Unix epoch itself is read back from an Int64 as the zero time.Time.
+// Times that don't fit in an Int64 of nanoseconds, before 1677 or after
+// 2262, are an error rather than wrapping around.  A nil net.IP is stored
+// as a null Data or empty Text.  Since a net.IP is also a []byte, its
+// Text converter must be registered first to take precedence over the
+// Data converter.
+func init() {
+	RegisterConverter(timeType, int64Type, func(v interface{}) (interface{}, error) {
+		t := v.(time.Time)
+		if t.IsZero() {
+			return int64(0), nil
+		}
+		if t.Before(minUnixNanoTime) || t.After(maxUnixNanoTime) {
+			return nil, fmt.Errorf("time %v out of range for nanoseconds since the Unix epoch", t)
+		}
+		return t.UnixNano(), nil
+	}, func(v interface{}) (interface{}, error) {
+		ns := v.(int64)
+		if ns == 0 {
+			return time.Time{}, nil
+		}
+		return time.Unix(0, ns).UTC(), nil
+	})
+	RegisterConverter(timeType, strType, func(v interface{}) (interface{}, error) {
+		t := v.(time.Time)
+		if t.IsZero() {
+			return "", nil
+		}
+		return t.Format(time.RFC3339Nano), nil
+	}, func(v interface{}) (interface{}, error) {
+		s := v.(string)
+		if s == "" {
+			return time.Time{}, nil
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	})
+	RegisterConverter(ipType, strType, func(v interface{}) (interface{}, error) {
+		ip := v.(net.IP)
+		if ip == nil {
+			return "", nil
+		}
+		return ip.String(), nil
+	}, func(v interface{}) (interface{}, error) {
+		s := v.(string)
+		if s == "" {
+			return net.IP(nil), nil
+		}
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, errors.New("invalid IP address " + s)
+		}
+		return ip, nil
+	})
+	RegisterConverter(ipType, bytesType, func(v interface{}) (interface{}, error) {
+		return []byte(v.(net.IP)), nil
+	}, func(v interface{}) (interface{}, error) {
+		b := v.([]byte)
+		if len(b) == 0 {
+			return net.IP(nil), nil
+		}
+		if len(b) != net.IPv4len && len(b) != net.IPv6len {
+			return nil, fmt.Errorf("invalid IP address length %d", len(b))
+		}
+		return net.IP(append([]byte(nil), b...)), nil
+	})
+}
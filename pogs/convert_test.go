@@ -0,0 +1,192 @@
+package pogs
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+// point is a custom type with a converter to Text.
+type point struct {
+	x, y int
+}
+
+func init() {
+	RegisterConverter(reflect.TypeOf(point{}), reflect.TypeOf(""), func(v interface{}) (interface{}, error) {
+		p := v.(point)
+		return string([]byte{byte('0' + p.x), ',', byte('0' + p.y)}), nil
+	}, func(v interface{}) (interface{}, error) {
+		s := v.(string)
+		if len(s) != 3 || s[1] != ',' {
+			return nil, errBadPoint
+		}
+		return point{int(s[0] - '0'), int(s[2] - '0')}, nil
+	})
+}
+
+var errBadPoint = convertTestError("bad point")
+
+type convertTestError string
+
+func (e convertTestError) Error() string { return string(e) }
+
+type ConvZ struct {
+	Which   air.Z_Which
+	I64     time.Time
+	Text    net.IP
+	Blob    net.IP
+	I64vec  []time.Time
+	Textvec []time.Time
+	Datavec []net.IP
+}
+
+type PointZ struct {
+	Which   air.Z_Which
+	Text    point
+	Textvec []point
+}
+
+func TestConvert_RoundTrip(t *testing.T) {
+	now := time.Unix(1294706395, 881547000).UTC()
+	tests := []ConvZ{
+		{Which: air.Z_Which_i64, I64: now},
+		{Which: air.Z_Which_i64, I64: time.Time{}},
+		{Which: air.Z_Which_text, Text: net.ParseIP("192.0.2.1")},
+		{Which: air.Z_Which_text, Text: net.ParseIP("2001:db8::1")},
+		{Which: air.Z_Which_text},
+		{Which: air.Z_Which_blob, Blob: net.IPv4(10, 0, 0, 1).To4()},
+		{Which: air.Z_Which_blob},
+		{Which: air.Z_Which_i64vec, I64vec: []time.Time{now, {}, now.Add(time.Hour)}},
+		{Which: air.Z_Which_textvec, Textvec: []time.Time{now, now.Add(-time.Minute)}},
+		{Which: air.Z_Which_datavec, Datavec: []net.IP{net.ParseIP("::1"), net.IPv4(127, 0, 0, 1).To4()}},
+	}
+	for _, test := range tests {
+		_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+		if err != nil {
+			t.Fatalf("NewMessage: %v", err)
+		}
+		z, err := air.NewRootZ(seg)
+		if err != nil {
+			t.Fatalf("NewRootZ: %v", err)
+		}
+		if err := Insert(air.Z_TypeID, z.Struct, &test); err != nil {
+			t.Errorf("Insert(%s): %v", zpretty.Sprint(test), err)
+			continue
+		}
+		out := new(ConvZ)
+		if err := Extract(out, air.Z_TypeID, z.Struct); err != nil {
+			t.Errorf("Extract(%s): %v", zpretty.Sprint(test), err)
+			continue
+		}
+		if !reflect.DeepEqual(out, &test) {
+			t.Errorf("Extract produced %s; want %s", zpretty.Sprint(out), zpretty.Sprint(test))
+		}
+	}
+}
+
+func TestConvert_Wire(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatalf("NewRootZ: %v", err)
+	}
+	tm := time.Unix(0, 1234567).UTC()
+	if err := Insert(air.Z_TypeID, z.Struct, &ConvZ{Which: air.Z_Which_i64, I64: tm}); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	if z.I64() != 1234567 {
+		t.Errorf("z.I64() = %d; want 1234567", z.I64())
+	}
+	if err := Insert(air.Z_TypeID, z.Struct, &ConvZ{Which: air.Z_Which_text, Text: net.IPv4(192, 0, 2, 1)}); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	if text, _ := z.Text(); text != "192.0.2.1" {
+		t.Errorf("z.Text() = %q; want \"192.0.2.1\"", text)
+	}
+}
+
+func TestConvert_TimeLimits(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatalf("NewRootZ: %v", err)
+	}
+	far := time.Date(2300, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if err := Insert(air.Z_TypeID, z.Struct, &ConvZ{Which: air.Z_Which_i64, I64: far}); err == nil {
+		t.Errorf("Insert(%v) succeeded; want out of range error", far)
+	}
+
+	// The epoch can't be told apart from the zero time.
+	if err := Insert(air.Z_TypeID, z.Struct, &ConvZ{Which: air.Z_Which_i64, I64: time.Unix(0, 0)}); err != nil {
+		t.Fatalf("Insert(epoch): %v", err)
+	}
+	out := new(ConvZ)
+	if err := Extract(out, air.Z_TypeID, z.Struct); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if !out.I64.IsZero() {
+		t.Errorf("Extract(epoch) = %v; want zero time", out.I64)
+	}
+}
+
+func TestConvert_Custom(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatalf("NewRootZ: %v", err)
+	}
+	in := &PointZ{Which: air.Z_Which_textvec, Textvec: []point{{1, 2}, {3, 4}}}
+	if err := Insert(air.Z_TypeID, z.Struct, in); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	out := new(PointZ)
+	if err := Extract(out, air.Z_TypeID, z.Struct); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("Extract produced %s; want %s", zpretty.Sprint(out), zpretty.Sprint(in))
+	}
+
+	if err := z.SetText("bogus"); err != nil {
+		t.Fatalf("SetText: %v", err)
+	}
+	if err := Extract(out, air.Z_TypeID, z.Struct); err == nil {
+		t.Error("Extract of invalid point did not return an error")
+	}
+}
+
+func TestRegisterConverter_Panics(t *testing.T) {
+	tests := []struct {
+		name      string
+		goType    reflect.Type
+		capnpType reflect.Type
+	}{
+		{"duplicate", timeType, int64Type},
+		{"unsized int", reflect.TypeOf(point{}), reflect.TypeOf(int(0))},
+		{"struct target", reflect.TypeOf(point{}), timeType},
+	}
+	conv := func(v interface{}) (interface{}, error) { return v, nil }
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: RegisterConverter(%v, %v) did not panic", test.name, test.goType, test.capnpType)
+				}
+			}()
+			RegisterConverter(test.goType, test.capnpType, conv, conv)
+		}()
+	}
+}
@@ -42,6 +42,11 @@ pass a pointer to our Go struct to Extract.
 	m := new(Message)
 	err := pogs.Extract(m, myschema.Message_TypeID, root.Struct)
 
+The first call to Insert or Extract for a given pair of Go type and
+Cap'n Proto type works out how their fields correspond and caches the
+result, so later calls avoid most of the reflection cost.  Precompile
+can be used to do this work ahead of time.
+
 Types
 
 The mapping between Cap'n Proto types and underlying Go types is as
@@ -54,7 +59,7 @@ follows:
 	Text                          -> either []byte or string
 	Data                          -> []byte
 	List                          -> slice
-	enum                          -> uint16
+	enum                          -> any integer type or string
 	struct                        -> a struct or pointer to struct
 	interface                     -> a capnp.Client or struct with
                                          exactly one field, named
@@ -64,6 +69,77 @@ Note that the unsized int and uint type can't be used: int and float
 types must match in size.  For Data and Text fields using []byte, the
 filled-in byte slice will point to original segment.
 
+Enums
+
+An enum field may be any Go integer type, which holds the enumerant's
+ordinal, or any Go string type, which holds the enumerant's name as
+written in the schema.  This lets a Go enum use its own type instead of
+the generated one:
+
+	type Airport string // "jfk", "lax", ...
+
+	type Color int
+
+	const (
+		Red Color = iota
+		Green
+		Blue
+	)
+
+Insert returns an error for a name that isn't in the schema or an
+integer outside the range of uint16, and Extract returns an error for an
+ordinal that has no name or doesn't fit in the Go type.  For any other
+mapping, register a converter to the name or the ordinal, as described
+below.
+
+Maps
+
+A Go map can be used for a list of structs that each have exactly two
+fields and no union.  The first field is the key and the second field
+is the value, as in the Map(Key, Value) convention:
+
+	struct Attributes {
+		entries @0 :List(Entry);
+
+		struct Entry {
+			key @0 :Text;
+			value @1 :Int64;
+		}
+	}
+
+	type Attributes struct {
+		Entries map[string]int64
+	}
+
+Insert writes entries sorted by key when the key is a string, number,
+or bool, so inserting the same map always produces the same message.
+If Extract finds duplicate keys, the last entry wins.
+
+Converters
+
+Other Go types can be used for fields by registering a converter with
+RegisterConverter.  A converter maps a Go type onto one of the types
+above, and is used for both fields and list elements.  pogs comes with
+converters for these types:
+
+	time.Time -> Int64 (nanoseconds since the Unix epoch) or Text (RFC 3339)
+	net.IP    -> Data (4 or 16 bytes) or Text
+
+The zero time.Time is stored as 0 so that it survives a round trip, so
+a field holding the Unix epoch is extracted as the zero time.Time;
+store such times as Text instead.  Inserting a time.Time before 1677 or
+after 2262, which don't fit in an Int64 of nanoseconds, is an error.
+
+For example, to store a custom ID type as Text:
+
+	pogs.RegisterConverter(reflect.TypeOf(ID{}), reflect.TypeOf(""),
+		func(v interface{}) (interface{}, error) {
+			return v.(ID).String(), nil
+		},
+		func(v interface{}) (interface{}, error) {
+			return ParseID(v.(string))
+		})
+
 Renaming and Omitting Fields
 
 By default, the Go field name is the same as the Cap'n Proto schema
@@ -86,6 +162,68 @@ Extract functions.
 		Time int64
 	}
 
+The schema name may also be given with a name= option in any position,
+which is handy when combined with other options.  The omitempty option causes Insert to
+leave a field unset if it holds the zero value for its Go type (using
+the same rules as encoding/json), so readers will see the schema
+default.  Extract ignores omitempty.
+
+	type SparseMessage struct {
+		Subject string `capnp:"name=name,omitempty"`
+		Body    string `capnp:",omitempty"`
+		Time    int64
+	}
+
+Optional Fields
+
+A field of any type except a struct may also be a Go pointer to one of
+the types above, like *int32 or *string, so that nil can stand for an
+absent value.  Insert sets the field to its default value for nil: a
+null pointer for Text, Data, and List fields, or zero bits for other
+fields.  Extract sets the pointer to nil for a null Text, Data, or List
+and otherwise allocates a value, so only pointer fields can tell absent
+from default in a message.
+
+To make other fields optional, put them in a union with a Void member
+that stands for absent and name that member with the optional option:
+
+	struct Reading {
+		union {
+			unknown @0 :Void;
+			celsius @1 :Float64;
+		}
+	}
+
+	type Reading struct {
+		Celsius *float64 `capnp:",optional=unknown"`
+	}
+
+Insert selects the unknown member for nil and the celsius member
+otherwise, and Extract sets Celsius to nil unless celsius is selected.
+An optional field doesn't need a Which field; it works the same way for
+pointers to structs.
+
+Cycles and Shared Structs
+
+A Cap'n Proto message is a tree, so Insert returns an error if a Go
+struct pointer leads back to a struct that is still being inserted.
+The error gives the path of fields to the pointer, such as
+"next.children[2]".
+
+By default, a struct that several fields point to is copied into each
+of them.  Tagging a struct pointer field with the shared option makes
+Insert write the struct once and point every shared field that holds
+the same Go pointer to it, which keeps messages for graphs with many
+repeated references small:
+
+	type Route struct {
+		From *Airport `capnp:",shared"`
+		To   *Airport `capnp:",shared"`
+	}
+
+Elements of lists of structs are stored inside the list, so they are
+always copied.
+
 Unions
 
 Since Go does not have support for variant types, Go structs that want
@@ -139,6 +277,17 @@ which field in the union is set.
 		// ...
 	}
 
+The discriminant field does not have to be named Which.  Any field
+tagged with the which option (with or without a value) is used as the
+discriminant instead:
+
+	type Shape struct {
+		Area   float64
+		Kind   myschema.Shape_Which `capnp:",which"`
+		Circle float64
+		Square float64
+	}
+
 Embedding
 
 Anonymous struct fields are usually extracted or inserted as if their
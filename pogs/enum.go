@@ -0,0 +1,135 @@
+package pogs
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/iguazio/go-capnproto2/internal/schema"
+)
+
+// An enumPlan holds the enumerant names of an enum type.
+type enumPlan struct {
+	names  []string // indexed by ordinal
+	values map[string]uint16
+}
+
+// enumFor returns the names of the enum type with the given ID.
+func enumFor(id uint64) (*enumPlan, error) {
+	cache.mu.RLock()
+	ep := cache.enums[id]
+	cache.mu.RUnlock()
+	if ep != nil {
+		return ep, nil
+	}
+	n, err := findNode(id)
+	if err != nil {
+		return nil, err
+	}
+	if !n.IsValid() || n.Which() != schema.Node_Which_enum {
+		return nil, fmt.Errorf("cannot find enum type %#x", id)
+	}
+	es, err := n.Enum().Enumerants()
+	if err != nil {
+		return nil, err
+	}
+	ep = &enumPlan{
+		names:  make([]string, es.Len()),
+		values: make(map[string]uint16, es.Len()),
+	}
+	for i := range ep.names {
+		name, err := es.At(i).Name()
+		if err != nil {
+			return nil, err
+		}
+		ep.names[i] = name
+		ep.values[name] = uint16(i)
+	}
+	cache.mu.Lock()
+	if e := cache.enums[id]; e != nil {
+		ep = e
+	} else {
+		if cache.enums == nil {
+			cache.enums = make(map[uint64]*enumPlan)
+		}
+		cache.enums[id] = ep
+	}
+	cache.mu.Unlock()
+	return ep, nil
+}
+
+// enumOrdinal returns the ordinal that val, a Go string or integer,
+// stands for in the enum type typ.  Strings are enumerant names.
+func enumOrdinal(typ schema.Type, val reflect.Value) (uint16, error) {
+	switch {
+	case val.Kind() == reflect.String:
+		ep, err := enumFor(typ.Enum().TypeId())
+		if err != nil {
+			return 0, err
+		}
+		v, ok := ep.values[val.String()]
+		if !ok {
+			return 0, fmt.Errorf("%q is not a value of enum @%#x", val.String(), typ.Enum().TypeId())
+		}
+		return v, nil
+	case isIntKind(val.Kind()):
+		v := val.Int()
+		if v < 0 || v > 0xffff {
+			return 0, fmt.Errorf("%d is out of range for an enum", v)
+		}
+		return uint16(v), nil
+	default:
+		v := val.Uint()
+		if v > 0xffff {
+			return 0, fmt.Errorf("%d is out of range for an enum", v)
+		}
+		return uint16(v), nil
+	}
+}
+
+// setEnum sets val, a Go string or integer, to the enum ordinal v.
+// Strings are set to the enumerant's name.
+func setEnum(typ schema.Type, val reflect.Value, v uint16) error {
+	switch {
+	case val.Kind() == reflect.String:
+		ep, err := enumFor(typ.Enum().TypeId())
+		if err != nil {
+			return err
+		}
+		if int(v) >= len(ep.names) {
+			return fmt.Errorf("enum @%#x has no value @%d", typ.Enum().TypeId(), v)
+		}
+		val.SetString(ep.names[v])
+	case isIntKind(val.Kind()):
+		if val.OverflowInt(int64(v)) {
+			return fmt.Errorf("enum value %d overflows Go %v", v, val.Type())
+		}
+		val.SetInt(int64(v))
+	default:
+		if val.OverflowUint(uint64(v)) {
+			return fmt.Errorf("enum value %d overflows Go %v", v, val.Type())
+		}
+		val.SetUint(uint64(v))
+	}
+	return nil
+}
+
+// isEnumType reports whether values of t can hold an enum: strings and
+// integers of any size.
+func isEnumType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return isIntKind(t.Kind())
+	}
+}
+
+func isIntKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
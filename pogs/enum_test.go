@@ -0,0 +1,185 @@
+package pogs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+// airportName is a string enum whose values are the schema's enumerant
+// names.
+type airportName string
+
+// airportCode is an iota enum whose values are the schema's ordinals.
+type airportCode int8
+
+const (
+	codeNone airportCode = iota
+	codeJFK
+	codeLAX
+	codeSFO
+)
+
+// upperAirport is stored through a converter to the enumerant name.
+type upperAirport string
+
+func init() {
+	RegisterConverter(reflect.TypeOf(upperAirport("")), reflect.TypeOf(""), func(v interface{}) (interface{}, error) {
+		return strings.ToLower(string(v.(upperAirport))), nil
+	}, func(v interface{}) (interface{}, error) {
+		return upperAirport(strings.ToUpper(v.(string))), nil
+	})
+}
+
+type NamedHomes struct {
+	Name  string
+	Homes []airportName
+}
+
+type CodedHomes struct {
+	Name  string
+	Homes []airportCode
+}
+
+type NameZ struct {
+	Which   air.Z_Which
+	Airport airportName
+}
+
+type CodeZ struct {
+	Which   air.Z_Which
+	Airport airportCode
+}
+
+type UpperZ struct {
+	Which   air.Z_Which
+	Airport upperAirport
+}
+
+func TestEnum_String(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Insert(air.Z_TypeID, z.Struct, &NameZ{Which: air.Z_Which_airport, Airport: "lax"}); err != nil {
+		t.Fatal("Insert:", err)
+	}
+	if z.Airport() != air.Airport_lax {
+		t.Errorf("z.Airport() = %v; want lax", z.Airport())
+	}
+	out := new(NameZ)
+	if err := Extract(out, air.Z_TypeID, z.Struct); err != nil {
+		t.Fatal("Extract:", err)
+	}
+	if out.Airport != "lax" {
+		t.Errorf("Extract produced Airport = %q; want \"lax\"", out.Airport)
+	}
+
+	err = Insert(air.Z_TypeID, z.Struct, &NameZ{Which: air.Z_Which_airport, Airport: "ord"})
+	if err == nil || !strings.Contains(err.Error(), `"ord"`) {
+		t.Errorf("Insert of unknown name: %v; want error mentioning \"ord\"", err)
+	}
+	z.SetAirport(air.Airport(99))
+	if err := Extract(out, air.Z_TypeID, z.Struct); err == nil {
+		t.Error("Extract of unknown ordinal into string did not return an error")
+	}
+}
+
+func TestEnum_Int(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Insert(air.Z_TypeID, z.Struct, &CodeZ{Which: air.Z_Which_airport, Airport: codeSFO}); err != nil {
+		t.Fatal("Insert:", err)
+	}
+	if z.Airport() != air.Airport_sfo {
+		t.Errorf("z.Airport() = %v; want sfo", z.Airport())
+	}
+	out := new(CodeZ)
+	if err := Extract(out, air.Z_TypeID, z.Struct); err != nil {
+		t.Fatal("Extract:", err)
+	}
+	if out.Airport != codeSFO {
+		t.Errorf("Extract produced Airport = %d; want %d", out.Airport, codeSFO)
+	}
+
+	if err := Insert(air.Z_TypeID, z.Struct, &CodeZ{Which: air.Z_Which_airport, Airport: -1}); err == nil {
+		t.Error("Insert of negative enum did not return an error")
+	}
+	z.SetAirport(air.Airport(200))
+	if err := Extract(out, air.Z_TypeID, z.Struct); err == nil {
+		t.Error("Extract of enum that overflows int8 did not return an error")
+	}
+}
+
+func TestEnum_List(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	base, err := air.NewRootPlaneBase(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := &NamedHomes{Name: "base", Homes: []airportName{"jfk", "dfw"}}
+	if err := Insert(air.PlaneBase_TypeID, base.Struct, in); err != nil {
+		t.Fatal("Insert:", err)
+	}
+	homes, err := base.Homes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if homes.Len() != 2 || homes.At(0) != air.Airport_jfk || homes.At(1) != air.Airport_dfw {
+		t.Errorf("base.Homes() = %v; want [jfk dfw]", homes)
+	}
+	names := new(NamedHomes)
+	if err := Extract(names, air.PlaneBase_TypeID, base.Struct); err != nil {
+		t.Fatal("Extract:", err)
+	}
+	if !reflect.DeepEqual(names, in) {
+		t.Errorf("Extract produced %+v; want %+v", names, in)
+	}
+	codes := new(CodedHomes)
+	if err := Extract(codes, air.PlaneBase_TypeID, base.Struct); err != nil {
+		t.Fatal("Extract:", err)
+	}
+	if want := []airportCode{codeJFK, airportCode(air.Airport_dfw)}; !reflect.DeepEqual(codes.Homes, want) {
+		t.Errorf("Extract produced Homes = %v; want %v", codes.Homes, want)
+	}
+}
+
+func TestEnum_Converter(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Insert(air.Z_TypeID, z.Struct, &UpperZ{Which: air.Z_Which_airport, Airport: "JFK"}); err != nil {
+		t.Fatal("Insert:", err)
+	}
+	if z.Airport() != air.Airport_jfk {
+		t.Errorf("z.Airport() = %v; want jfk", z.Airport())
+	}
+	out := new(UpperZ)
+	if err := Extract(out, air.Z_TypeID, z.Struct); err != nil {
+		t.Fatal("Extract:", err)
+	}
+	if out.Airport != "JFK" {
+		t.Errorf("Extract produced Airport = %q; want \"JFK\"", out.Airport)
+	}
+}
@@ -7,27 +7,44 @@ import (
 	"reflect"
 
 	"github.com/iguazio/go-capnproto2"
-	"github.com/iguazio/go-capnproto2/internal/nodemap"
 	"github.com/iguazio/go-capnproto2/internal/schema"
 )
 
 // Extract copies s into val, a pointer to a Go struct.
 func Extract(val interface{}, typeID uint64, s capnp.Struct) error {
 	e := new(extracter)
-	err := e.extractStruct(reflect.ValueOf(val), typeID, s)
+	err := e.extractStruct(reflect.ValueOf(val), typeID, s, nil)
 	if err != nil {
 		return fmt.Errorf("pogs: extract @%#x: %v", typeID, err)
 	}
 	return nil
 }
 
-type extracter struct {
-	nodes nodemap.Map
+// ExtractFields is like Extract, but only copies the fields named in
+// mask, leaving the other fields in val untouched.  Names are
+// Cap'n Proto schema field names.  A name may use dots to select
+// fields inside a struct, group, or list of structs: "author.name"
+// copies only the name field of the author struct.  The discriminant of
+// a union is always copied, but union fields are only copied if they
+// are named in mask and set.  ExtractFields returns an error if mask
+// names a field that is not in the schema.
+func ExtractFields(val interface{}, typeID uint64, s capnp.Struct, mask []string) error {
+	e := new(extracter)
+	m := newFieldMask(mask)
+	err := e.extractStruct(reflect.ValueOf(val), typeID, s, m)
+	if err != nil {
+		return fmt.Errorf("pogs: extract @%#x: %v", typeID, err)
+	}
+	return nil
 }
 
+type extracter struct{}
+
 var clientType = reflect.TypeOf((*capnp.Client)(nil)).Elem()
 
-func (e *extracter) extractStruct(val reflect.Value, typeID uint64, s capnp.Struct) error {
+// extractStruct copies s into val.  If mask is not nil, then only the
+// fields in mask are copied.
+func (e *extracter) extractStruct(val reflect.Value, typeID uint64, s capnp.Struct, mask fieldMask) error {
 	if val.Kind() == reflect.Ptr {
 		if val.Type().Elem().Kind() != reflect.Struct {
 			return fmt.Errorf("can't extract struct into %v", val.Type())
@@ -50,39 +67,47 @@ func (e *extracter) extractStruct(val reflect.Value, typeID uint64, s capnp.Stru
 	if !val.CanSet() {
 		return errors.New("can't modify struct, did you pass in a pointer to your struct?")
 	}
-	n, err := e.nodes.Find(typeID)
+	n, err := findNode(typeID)
 	if err != nil {
 		return err
 	}
 	if !n.IsValid() || n.Which() != schema.Node_Which_structNode {
 		return fmt.Errorf("cannot find struct type %#x", typeID)
 	}
-	props, err := mapStruct(val.Type(), n)
+	plan, err := planFor(val.Type(), n)
 	if err != nil {
 		return fmt.Errorf("can't extract %s: %v", val.Type(), err)
 	}
+	if err := mask.check(plan); err != nil {
+		return err
+	}
 	var discriminant uint16
 	hasWhich := false
-	if hasDiscriminant(n) {
-		discriminant = s.Uint16(capnp.DataOffset(n.StructNode().DiscriminantOffset() * 2))
-		if err := props.setWhich(val, discriminant); err == nil {
+	if plan.hasDiscrim {
+		discriminant = s.Uint16(plan.discrimOff)
+		if err := plan.props.setWhich(val, discriminant); err == nil {
 			hasWhich = true
 		} else if !isNoWhichError(err) {
 			return err
 		}
 	}
-	fields, err := n.StructNode().Fields()
-	if err != nil {
-		return err
-	}
-	for i := 0; i < fields.Len(); i++ {
-		f := fields.At(i)
-		vf := props.makeFieldByOrdinal(val, i)
+	for i := range plan.fields {
+		f := &plan.fields[i]
+		sub, ok := mask.sub(f.name)
+		if !ok {
+			continue
+		}
+		vf := plan.props.makeFieldByOrdinal(val, i)
 		if !vf.IsValid() {
 			// Don't have a field for this.
 			continue
 		}
-		if dv := f.DiscriminantValue(); dv != schema.Field_noDiscriminant {
+		if plan.props.optional[i] != schema.Field_noDiscriminant {
+			if f.discrim != discriminant {
+				vf.Set(reflect.Zero(vf.Type()))
+				continue
+			}
+		} else if dv := f.discrim; dv != schema.Field_noDiscriminant {
 			if !hasWhich {
 				return fmt.Errorf("can't extract %s into %v: has union field but no Which field", shortDisplayName(n), val.Type())
 			}
@@ -92,11 +117,11 @@ func (e *extracter) extractStruct(val reflect.Value, typeID uint64, s capnp.Stru
 		}
 		switch f.Which() {
 		case schema.Field_Which_slot:
-			if err := e.extractField(vf, s, f); err != nil {
+			if err := e.extractField(vf, s, f, sub); err != nil {
 				return err
 			}
 		case schema.Field_Which_group:
-			if err := e.extractStruct(vf, f.Group().TypeId(), s); err != nil {
+			if err := e.extractStruct(vf, f.Group().TypeId(), s, sub); err != nil {
 				return err
 			}
 		}
@@ -104,22 +129,39 @@ func (e *extracter) extractStruct(val reflect.Value, typeID uint64, s capnp.Stru
 	return nil
 }
 
-func (e *extracter) extractField(val reflect.Value, s capnp.Struct, f schema.Field) error {
-	typ, err := f.Slot().Type()
-	if err != nil {
-		return err
-	}
-	dv, err := f.Slot().DefaultValue()
-	if err != nil {
-		return err
-	}
+func (e *extracter) extractField(val reflect.Value, s capnp.Struct, f *fieldPlan, mask fieldMask) error {
+	typ, dv := f.typ, f.dv
 	if dv.IsValid() && int(typ.Which()) != int(dv.Which()) {
-		name, _ := f.NameBytes()
-		return fmt.Errorf("extract field %s: default value is a %v, want %v", name, dv.Which(), typ.Which())
+		return fmt.Errorf("extract field %s: default value is a %v, want %v", f.name, dv.Which(), typ.Which())
+	}
+	if isOptionalPtr(val.Type(), typ) {
+		if isPointerType(typ) {
+			p, err := s.Ptr(uint16(f.Slot().Offset()))
+			if err != nil {
+				return err
+			}
+			if !p.IsValid() {
+				val.Set(reflect.Zero(val.Type()))
+				return nil
+			}
+		}
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		val = val.Elem()
 	}
 	if !isTypeMatch(val.Type(), typ) {
-		name, _ := f.NameBytes()
-		return fmt.Errorf("can't extract field %s of type %v into a Go %v", name, typ.Which(), val.Type())
+		return fmt.Errorf("can't extract field %s of type %v into a Go %v", f.name, typ.Which(), val.Type())
+	}
+	if c := findConverter(val.Type(), typ); c != nil {
+		tmp := reflect.New(c.capnpType).Elem()
+		if err := e.extractField(tmp, s, f, nil); err != nil {
+			return err
+		}
+		if err := c.convertFrom(val, tmp); err != nil {
+			return fmt.Errorf("extract field %s: %v", f.name, err)
+		}
+		return nil
 	}
 	switch typ.Which() {
 	case schema.Type_Which_bool:
@@ -153,7 +195,9 @@ func (e *extracter) extractField(val reflect.Value, s capnp.Struct, f schema.Fie
 	case schema.Type_Which_enum:
 		v := s.Uint16(capnp.DataOffset(f.Slot().Offset() * 2))
 		d := dv.Enum()
-		val.SetUint(uint64(v ^ d))
+		if err := setEnum(typ, val, v^d); err != nil {
+			return fmt.Errorf("extract field %s: %v", f.name, err)
+		}
 	case schema.Type_Which_uint32:
 		v := s.Uint32(capnp.DataOffset(f.Slot().Offset() * 4))
 		d := dv.Uint32()
@@ -209,7 +253,7 @@ func (e *extracter) extractField(val reflect.Value, s capnp.Struct, f schema.Fie
 			p, _ = dv.StructValuePtr()
 			ss = p.Struct()
 		}
-		return e.extractStruct(val, typ.StructType().TypeId(), ss)
+		return e.extractStruct(val, typ.StructType().TypeId(), ss, mask)
 	case schema.Type_Which_list:
 		p, err := s.Ptr(uint16(f.Slot().Offset()))
 		if err != nil {
@@ -220,7 +264,7 @@ func (e *extracter) extractField(val reflect.Value, s capnp.Struct, f schema.Fie
 			p, _ = dv.ListPtr()
 			l = p.List()
 		}
-		return e.extractList(val, typ, l)
+		return e.extractList(val, typ, l, mask)
 	case schema.Type_Which_interface:
 		p, err := s.Ptr(uint16(f.Slot().Offset()))
 		if err != nil {
@@ -243,7 +287,7 @@ func (e *extracter) extractField(val reflect.Value, s capnp.Struct, f schema.Fie
 	return nil
 }
 
-func (e *extracter) extractList(val reflect.Value, typ schema.Type, l capnp.List) error {
+func (e *extracter) extractList(val reflect.Value, typ schema.Type, l capnp.List, mask fieldMask) error {
 	vt := val.Type()
 	elem, err := typ.List().ElementType()
 	if err != nil {
@@ -257,7 +301,23 @@ func (e *extracter) extractList(val reflect.Value, typ schema.Type, l capnp.List
 		val.Set(reflect.Zero(vt))
 		return nil
 	}
+	if vt.Kind() == reflect.Map {
+		return e.extractMap(val, elem, l, mask)
+	}
 	n := l.Len()
+	if c := findConverter(vt.Elem(), elem); c != nil {
+		tmp := reflect.New(reflect.SliceOf(c.capnpType)).Elem()
+		if err := e.extractList(tmp, typ, l, nil); err != nil {
+			return err
+		}
+		val.Set(reflect.MakeSlice(vt, n, n))
+		for i := 0; i < n; i++ {
+			if err := c.convertFrom(val.Index(i), tmp.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 	val.Set(reflect.MakeSlice(vt, n, n))
 	switch elem.Which() {
 	case schema.Type_Which_bool:
@@ -284,10 +344,16 @@ func (e *extracter) extractList(val reflect.Value, typ schema.Type, l capnp.List
 		for i := 0; i < n; i++ {
 			val.Index(i).SetUint(uint64(capnp.UInt8List{List: l}.At(i)))
 		}
-	case schema.Type_Which_uint16, schema.Type_Which_enum:
+	case schema.Type_Which_uint16:
 		for i := 0; i < n; i++ {
 			val.Index(i).SetUint(uint64(capnp.UInt16List{List: l}.At(i)))
 		}
+	case schema.Type_Which_enum:
+		for i := 0; i < n; i++ {
+			if err := setEnum(elem, val.Index(i), capnp.UInt16List{List: l}.At(i)); err != nil {
+				return err
+			}
+		}
 	case schema.Type_Which_uint32:
 		for i := 0; i < n; i++ {
 			val.Index(i).SetUint(uint64(capnp.UInt32List{List: l}.At(i)))
@@ -340,14 +406,14 @@ func (e *extracter) extractList(val reflect.Value, typ schema.Type, l capnp.List
 			if err != nil {
 				return err
 			}
-			if err := e.extractList(val.Index(i), elem, p.List()); err != nil {
+			if err := e.extractList(val.Index(i), elem, p.List(), mask); err != nil {
 				return err
 			}
 		}
 	case schema.Type_Which_structType:
 		if val.Type().Elem().Kind() == reflect.Struct {
 			for i := 0; i < n; i++ {
-				err := e.extractStruct(val.Index(i), elem.StructType().TypeId(), l.Struct(i))
+				err := e.extractStruct(val.Index(i), elem.StructType().TypeId(), l.Struct(i), mask)
 				if err != nil {
 					return err
 				}
@@ -356,7 +422,7 @@ func (e *extracter) extractList(val reflect.Value, typ schema.Type, l capnp.List
 			for i := 0; i < n; i++ {
 				newval := reflect.New(val.Type().Elem().Elem())
 				val.Index(i).Set(newval)
-				err := e.extractStruct(newval, elem.StructType().TypeId(), l.Struct(i))
+				err := e.extractStruct(newval, elem.StructType().TypeId(), l.Struct(i), mask)
 				if err != nil {
 					return err
 				}
@@ -380,19 +446,33 @@ var typeMap = map[schema.Type_Which]reflect.Kind{
 	schema.Type_Which_uint64:  reflect.Uint64,
 	schema.Type_Which_float32: reflect.Float32,
 	schema.Type_Which_float64: reflect.Float64,
-	schema.Type_Which_enum:    reflect.Uint16,
 }
 
 func isTypeMatch(r reflect.Type, s schema.Type) bool {
+	if findConverter(r, s) != nil {
+		return true
+	}
+	return isBaseTypeMatch(r, s)
+}
+
+// isBaseTypeMatch is like isTypeMatch, but ignores any converters
+// registered for r.
+func isBaseTypeMatch(r reflect.Type, s schema.Type) bool {
 	switch s.Which() {
 	case schema.Type_Which_text:
 		return r.Kind() == reflect.String || r.Kind() == reflect.Slice && r.Elem().Kind() == reflect.Uint8
 	case schema.Type_Which_data:
 		return r.Kind() == reflect.Slice && r.Elem().Kind() == reflect.Uint8
+	case schema.Type_Which_enum:
+		return isEnumType(r)
 	case schema.Type_Which_structType:
 		return isStructOrStructPtr(r)
 	case schema.Type_Which_list:
 		e, _ := s.List().ElementType()
+		if r.Kind() == reflect.Map {
+			// Entry fields are checked when the map is converted.
+			return e.Which() == schema.Type_Which_structType
+		}
 		return r.Kind() == reflect.Slice && isTypeMatch(r.Elem(), e)
 	case schema.Type_Which_interface:
 		if r == clientType {
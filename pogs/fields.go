@@ -13,6 +13,9 @@ type fieldProps struct {
 	typ        fieldType
 	fixedWhich string
 	tagged     bool
+	omitEmpty  bool
+	shared     bool
+	optional   string // name of the union member that stands for nil
 }
 
 type fieldType int
@@ -28,30 +31,51 @@ func parseField(f reflect.StructField, hasDiscrim bool) fieldProps {
 	tag := f.Tag.Get("capnp")
 	p.tagged = tag != ""
 	tname, opts := nextOpt(tag)
+	if strings.HasPrefix(tname, "name=") {
+		// The name may also be given as an option in the first
+		// position, like `capnp:"name=pageCount,omitempty"`.
+		tname = strings.TrimPrefix(tname, "name=")
+	}
+	isWhich := false
+	for len(opts) > 0 {
+		var curr string
+		curr, opts = nextOpt(opts)
+		switch {
+		case curr == "omitempty":
+			p.omitEmpty = true
+		case curr == "shared":
+			p.shared = true
+		case strings.HasPrefix(curr, "optional="):
+			p.optional = strings.TrimPrefix(curr, "optional=")
+		case curr == "which":
+			isWhich = true
+		case strings.HasPrefix(curr, "which="):
+			isWhich = true
+			p.fixedWhich = strings.TrimPrefix(curr, "which=")
+		case strings.HasPrefix(curr, "name=") && tname != "-":
+			tname = strings.TrimPrefix(curr, "name=")
+		}
+	}
 	switch tname {
 	case "-":
 		// omitted field
 	case "":
-		if f.Anonymous && isStructOrStructPtr(f.Type) {
+		if f.Anonymous && isStructOrStructPtr(f.Type) && !isWhich {
 			p.typ = embedField
 			return p
 		}
-		if hasDiscrim && f.Name == "Which" {
+		if isWhich || hasDiscrim && f.Name == "Which" {
 			p.typ = whichField
-			for len(opts) > 0 {
-				var curr string
-				curr, opts = nextOpt(opts)
-				if strings.HasPrefix(curr, "which=") {
-					p.fixedWhich = strings.TrimPrefix(curr, "which=")
-					break
-				}
-			}
 			return p
 		}
 		// TODO(light): check it's uppercase.
 		x := f.Name[0] - 'A' + 'a'
 		p.schemaName = string(x) + f.Name[1:]
 	default:
+		if isWhich {
+			p.typ = whichField
+			return p
+		}
 		p.schemaName = tname
 	}
 	return p
@@ -98,6 +122,9 @@ func (loc fieldLoc) isValid() bool {
 
 type structProps struct {
 	fields     []fieldLoc
+	omitEmpty  []bool   // indexed by field ordinal
+	shared     []bool   // indexed by field ordinal
+	optional   []uint16 // indexed by field ordinal; discriminant of nil member or noDiscriminant
 	whichLoc   fieldLoc // i == -1: none; i == -2: fixed
 	fixedWhich uint16
 }
@@ -131,9 +158,59 @@ func mapStruct(t reflect.Type, n schema.Node) (structProps, error) {
 			return structProps{}, err
 		}
 	}
+	sp.omitEmpty = make([]bool, len(sp.fields))
+	sp.shared = make([]bool, len(sp.fields))
+	sp.optional = make([]uint16, len(sp.fields))
+	for i, loc := range sp.fields {
+		sp.optional[i] = schema.Field_noDiscriminant
+		if !loc.isValid() {
+			continue
+		}
+		f := typeFieldByLoc(t, loc)
+		p := parseField(f, sm.hasDiscrim)
+		sp.omitEmpty[i] = p.omitEmpty
+		if p.shared && (f.Type.Kind() != reflect.Ptr || f.Type.Elem().Kind() != reflect.Struct) {
+			return structProps{}, fmt.Errorf("%v.%s is tagged shared, but is not a pointer to a struct", t, f.Name)
+		}
+		sp.shared[i] = p.shared
+		if p.optional != "" {
+			if sp.optional[i], err = optionalDiscriminant(t, f, fields, i, p.optional); err != nil {
+				return structProps{}, err
+			}
+		}
+	}
 	return sp, nil
 }
 
+// optionalDiscriminant returns the discriminant of the member named
+// nilName, which stands for nil in the Go pointer field f mapped to the
+// i'th schema field.  Both must be members of the same union, and the
+// nil member must be Void.
+func optionalDiscriminant(t reflect.Type, f reflect.StructField, fields schema.Field_List, i int, nilName string) (uint16, error) {
+	if f.Type.Kind() != reflect.Ptr {
+		return 0, fmt.Errorf("%v.%s is tagged optional, but is not a pointer", t, f.Name)
+	}
+	if fields.At(i).DiscriminantValue() == schema.Field_noDiscriminant {
+		return 0, fmt.Errorf("%v.%s is tagged optional, but is not in a union", t, f.Name)
+	}
+	ni := fieldIndex(fields, nilName)
+	if ni < 0 {
+		return 0, fmt.Errorf("%v.%s is tagged optional with unknown field %s", t, f.Name, nilName)
+	}
+	nf := fields.At(ni)
+	dv := nf.DiscriminantValue()
+	if ni == i || dv == schema.Field_noDiscriminant {
+		return 0, fmt.Errorf("%v.%s is tagged optional with %s, which is not another member of its union", t, f.Name, nilName)
+	}
+	if nf.Which() != schema.Field_Which_slot {
+		return 0, fmt.Errorf("%v.%s is tagged optional with %s, which is not Void", t, f.Name, nilName)
+	}
+	if typ, err := nf.Slot().Type(); err != nil || typ.Which() != schema.Type_Which_void {
+		return 0, fmt.Errorf("%v.%s is tagged optional with %s, which is not Void", t, f.Name, nilName)
+	}
+	return dv, nil
+}
+
 type structMapper struct {
 	sp         *structProps
 	t          reflect.Type
@@ -202,6 +279,9 @@ func (sm *structMapper) visitField(loc fieldLoc, f reflect.StructField, p fieldP
 			sm.sp.fields[fi] = loc
 		}
 	case whichField:
+		if !sm.hasDiscrim {
+			return fmt.Errorf("%v.%s is tagged as a Which field, but struct has no union", sm.t, f.Name)
+		}
 		if sm.sp.whichLoc.i != -1 {
 			return fmt.Errorf("%v embeds multiple Which fields", sm.t)
 		}
@@ -209,16 +289,16 @@ func (sm *structMapper) visitField(loc fieldLoc, f reflect.StructField, p fieldP
 		case p.fixedWhich != "":
 			fi := fieldIndex(sm.fields, p.fixedWhich)
 			if fi < 0 {
-				return fmt.Errorf("%v.Which is tagged with unknown field %s", sm.t, p.fixedWhich)
+				return fmt.Errorf("%v.%s is tagged with unknown field %s", sm.t, f.Name, p.fixedWhich)
 			}
 			dv := sm.fields.At(fi).DiscriminantValue()
 			if dv == schema.Field_noDiscriminant {
-				return fmt.Errorf("%v.Which is tagged with non-union field %s", sm.t, p.fixedWhich)
+				return fmt.Errorf("%v.%s is tagged with non-union field %s", sm.t, f.Name, p.fixedWhich)
 			}
 			sm.sp.whichLoc = fieldLoc{i: -2}
 			sm.sp.fixedWhich = dv
 		case f.Type.Kind() != reflect.Uint16:
-			return fmt.Errorf("%v.Which is type %v, not uint16", sm.t, f.Type)
+			return fmt.Errorf("%v.%s is type %v, not uint16", sm.t, f.Name, f.Type)
 		default:
 			sm.sp.whichLoc = loc
 		}
@@ -0,0 +1,113 @@
+package pogs
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+)
+
+// A pathElem is a step from a struct to one of its fields or from a
+// list to one of its elements.
+type pathElem struct {
+	name  string
+	index int // used if name is empty
+}
+
+// ptrKey identifies a Go struct pointer.  The type is part of the key
+// because a struct and its first field share an address.
+type ptrKey struct {
+	addr uintptr
+	t    reflect.Type
+}
+
+// sharedKey identifies a Go struct pointer inserted as a particular
+// Cap'n Proto struct type.
+type sharedKey struct {
+	ptrKey
+	id uint64
+}
+
+// enter records that the struct that val points to is being inserted.
+// It returns an error if the struct is already being inserted, since
+// the Go value has a cycle.  The caller must call leave with the
+// returned key when it is done.
+func (ins *inserter) enter(val reflect.Value) (ptrKey, error) {
+	k := ptrKey{val.Pointer(), val.Type()}
+	if depth, ok := ins.active[k]; ok {
+		return ptrKey{}, fmt.Errorf("cycle at %s: %v points back to %s, which is still being inserted", formatPath(ins.path), val.Type(), formatPath(ins.path[:depth]))
+	}
+	if ins.active == nil {
+		ins.active = make(map[ptrKey]int)
+	}
+	ins.active[k] = len(ins.path)
+	return k, nil
+}
+
+func (ins *inserter) leave(k ptrKey) {
+	delete(ins.active, k)
+}
+
+func (ins *inserter) pushField(name string) {
+	ins.path = append(ins.path, pathElem{name: name})
+}
+
+func (ins *inserter) pushIndex(i int) {
+	ins.path = append(ins.path, pathElem{index: i})
+}
+
+func (ins *inserter) pop() {
+	ins.path = ins.path[:len(ins.path)-1]
+}
+
+// insertSharedField inserts a struct field tagged with the shared
+// option.  The first time a Go pointer is inserted as a given struct
+// type, the struct is inserted as usual; later fields holding the same
+// pointer point to the same Cap'n Proto struct.
+func (ins *inserter) insertSharedField(s capnp.Struct, f *fieldPlan, val reflect.Value) error {
+	if val.IsNil() || f.typ.Which() != schema.Type_Which_structType || findConverter(val.Type(), f.typ) != nil {
+		return ins.insertField(s, f, val)
+	}
+	k := sharedKey{ptrKey{val.Pointer(), val.Type()}, f.typ.StructType().TypeId()}
+	off := uint16(f.Slot().Offset())
+	if ss, ok := ins.shared[k]; ok {
+		if !isFieldInBounds(s.Size(), f.Slot().Offset(), f.typ) {
+			return fmt.Errorf("can't insert field %s: allocated struct is too small", f.name)
+		}
+		return s.SetPtr(off, ss.ToPtr())
+	}
+	if err := ins.insertField(s, f, val); err != nil {
+		return err
+	}
+	p, err := s.Ptr(off)
+	if err != nil {
+		return err
+	}
+	if ins.shared == nil {
+		ins.shared = make(map[sharedKey]capnp.Struct)
+	}
+	ins.shared[k] = p.Struct()
+	return nil
+}
+
+func formatPath(path []pathElem) string {
+	if len(path) == 0 {
+		return "the root struct"
+	}
+	var buf []byte
+	for _, e := range path {
+		if e.name == "" {
+			buf = append(buf, '[')
+			buf = strconv.AppendInt(buf, int64(e.index), 10)
+			buf = append(buf, ']')
+			continue
+		}
+		if len(buf) > 0 {
+			buf = append(buf, '.')
+		}
+		buf = append(buf, e.name...)
+	}
+	return string(buf)
+}
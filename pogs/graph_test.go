@@ -0,0 +1,120 @@
+package pogs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+type LoopZ struct {
+	Which air.Z_Which
+	Zz    *LoopZ
+	Zvec  []*LoopZ
+}
+
+func TestInsert_Cycle(t *testing.T) {
+	self := &LoopZ{Which: air.Z_Which_zz}
+	self.Zz = self
+	inner := &LoopZ{Which: air.Z_Which_zz}
+	outer := &LoopZ{Which: air.Z_Which_zz, Zz: inner}
+	inner.Zz = &LoopZ{Which: air.Z_Which_zvec, Zvec: []*LoopZ{{Which: air.Z_Which_void}, inner}}
+
+	tests := []struct {
+		name string
+		val  *LoopZ
+		msg  string
+	}{
+		{"self", self, "cycle at zz: *pogs.LoopZ points back to the root struct"},
+		{"through list", outer, "cycle at zz.zz.zvec[1]: *pogs.LoopZ points back to zz"},
+	}
+	for _, test := range tests {
+		_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		z, err := air.NewRootZ(seg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = Insert(air.Z_TypeID, z.Struct, test.val)
+		if err == nil {
+			t.Errorf("%s: Insert did not return an error", test.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.msg) {
+			t.Errorf("%s: Insert error = %v; want it to contain %q", test.name, err, test.msg)
+		}
+	}
+}
+
+func TestInsert_Alias(t *testing.T) {
+	type StackingA struct {
+		Num int32
+	}
+	a := &StackingA{Num: 7}
+	tests := []struct {
+		name   string
+		val    interface{}
+		shared bool
+	}{
+		{"copied", &struct {
+			A            *StackingA
+			AWithDefault *StackingA
+		}{a, a}, false},
+		{"shared", &struct {
+			A            *StackingA `capnp:",shared"`
+			AWithDefault *StackingA `capnp:",shared"`
+		}{a, a}, true},
+	}
+	for _, test := range tests {
+		_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		root, err := air.NewRootStackingRoot(seg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := Insert(air.StackingRoot_TypeID, root.Struct, test.val); err != nil {
+			t.Errorf("%s: Insert: %v", test.name, err)
+			continue
+		}
+		ra, err := root.A()
+		if err != nil {
+			t.Fatal(err)
+		}
+		rd, err := root.AWithDefault()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ra.Num() != 7 || rd.Num() != 7 {
+			t.Errorf("%s: a.num, aWithDefault.num = %d, %d; want 7, 7", test.name, ra.Num(), rd.Num())
+		}
+		ra.SetNum(8)
+		if shared := rd.Num() == 8; shared != test.shared {
+			t.Errorf("%s: fields share a struct = %t; want %t", test.name, shared, test.shared)
+		}
+	}
+}
+
+func TestInsert_SharedNotPointer(t *testing.T) {
+	type StackingA struct {
+		Num int32
+	}
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := air.NewRootStackingRoot(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	val := &struct {
+		A StackingA `capnp:",shared"`
+	}{}
+	if err := Insert(air.StackingRoot_TypeID, root.Struct, val); err == nil {
+		t.Error("Insert with shared non-pointer field did not return an error")
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"reflect"
 
 	"github.com/iguazio/go-capnproto2"
-	"github.com/iguazio/go-capnproto2/internal/nodemap"
 	"github.com/iguazio/go-capnproto2/internal/schema"
 )
 
@@ -20,95 +19,124 @@ func Insert(typeID uint64, s capnp.Struct, val interface{}) error {
 	return nil
 }
 
+// An inserter holds the state of one call to Insert.  It tracks the Go
+// struct pointers along the current path to detect cycles, and the
+// structs inserted for fields tagged shared.
 type inserter struct {
-	nodes nodemap.Map
+	path   []pathElem
+	active map[ptrKey]int // depth in path at which each pointer was entered
+	shared map[sharedKey]capnp.Struct
 }
 
 func (ins *inserter) insertStruct(typeID uint64, s capnp.Struct, val reflect.Value) error {
 	if val.Kind() == reflect.Ptr {
 		// TODO(light): ignore if nil?
+		if !val.IsNil() {
+			k, err := ins.enter(val)
+			if err != nil {
+				return err
+			}
+			defer ins.leave(k)
+		}
 		val = val.Elem()
 	}
 	if val.Kind() != reflect.Struct {
 		return fmt.Errorf("can't insert %v into a struct", val.Kind())
 	}
-	n, err := ins.nodes.Find(typeID)
+	n, err := findNode(typeID)
 	if err != nil {
 		return err
 	}
 	if !n.IsValid() || n.Which() != schema.Node_Which_structNode {
 		return fmt.Errorf("cannot find struct type %#x", typeID)
 	}
-	props, err := mapStruct(val.Type(), n)
+	plan, err := planFor(val.Type(), n)
 	if err != nil {
 		return fmt.Errorf("can't insert into %v: %v", val.Type(), err)
 	}
 	var discriminant uint16
 	hasWhich := false
-	if hasDiscriminant(n) {
-		discriminant, hasWhich = props.which(val)
+	if plan.hasDiscrim {
+		discriminant, hasWhich = plan.props.which(val)
 		if hasWhich {
-			off := capnp.DataOffset(n.StructNode().DiscriminantOffset() * 2)
+			off := plan.discrimOff
 			if s.Size().DataSize < capnp.Size(off+2) {
 				return fmt.Errorf("can't set discriminant for %s: allocated struct is too small", shortDisplayName(n))
 			}
 			s.SetUint16(off, discriminant)
 		}
 	}
-	fields, err := n.StructNode().Fields()
-	if err != nil {
-		return err
-	}
-	for i := 0; i < fields.Len(); i++ {
-		f := fields.At(i)
-		vf := props.fieldByOrdinal(val, i)
+	for i := range plan.fields {
+		f := &plan.fields[i]
+		vf := plan.props.fieldByOrdinal(val, i)
 		if !vf.IsValid() {
 			// Don't have a field for this.
 			continue
 		}
-		if dv := f.DiscriminantValue(); dv != schema.Field_noDiscriminant {
+		if nilDiscrim := plan.props.optional[i]; nilDiscrim != schema.Field_noDiscriminant {
+			// The field chooses the union member itself.
+			if plan.props.omitEmpty[i] && vf.IsNil() {
+				continue
+			}
+			if s.Size().DataSize < capnp.Size(plan.discrimOff+2) {
+				return fmt.Errorf("can't set discriminant for %s: allocated struct is too small", shortDisplayName(n))
+			}
+			if vf.IsNil() {
+				s.SetUint16(plan.discrimOff, nilDiscrim)
+				continue
+			}
+			s.SetUint16(plan.discrimOff, f.discrim)
+		} else if dv := f.discrim; dv != schema.Field_noDiscriminant {
 			if !hasWhich {
-				sname, _ := f.NameBytes()
-				return fmt.Errorf("can't insert %s from %v: has union field %s but no Which field", shortDisplayName(n), val.Type(), sname)
+				return fmt.Errorf("can't insert %s from %v: has union field %s but no Which field", shortDisplayName(n), val.Type(), f.name)
 			}
 			if dv != discriminant {
 				continue
 			}
 		}
-		switch f.Which() {
-		case schema.Field_Which_slot:
-			if err := ins.insertField(s, f, vf); err != nil {
-				return err
-			}
-		case schema.Field_Which_group:
-			if err := ins.insertStruct(f.Group().TypeId(), s, vf); err != nil {
-				return err
-			}
+		if plan.props.omitEmpty[i] && isEmptyGoValue(vf) {
+			continue
+		}
+		ins.pushField(f.name)
+		switch {
+		case f.Which() == schema.Field_Which_group:
+			err = ins.insertStruct(f.Group().TypeId(), s, vf)
+		case plan.props.shared[i]:
+			err = ins.insertSharedField(s, f, vf)
+		default:
+			err = ins.insertField(s, f, vf)
+		}
+		if err != nil {
+			return err
 		}
+		ins.pop()
 	}
 	return nil
 }
 
-func (ins *inserter) insertField(s capnp.Struct, f schema.Field, val reflect.Value) error {
-	typ, err := f.Slot().Type()
-	if err != nil {
-		return err
-	}
-	dv, err := f.Slot().DefaultValue()
-	if err != nil {
-		return err
-	}
+func (ins *inserter) insertField(s capnp.Struct, f *fieldPlan, val reflect.Value) error {
+	typ, dv := f.typ, f.dv
 	if dv.IsValid() && int(typ.Which()) != int(dv.Which()) {
-		name, _ := f.NameBytes()
-		return fmt.Errorf("insert field %s: default value is a %v, want %v", name, dv.Which(), typ.Which())
+		return fmt.Errorf("insert field %s: default value is a %v, want %v", f.name, dv.Which(), typ.Which())
+	}
+	if isOptionalPtr(val.Type(), typ) {
+		if val.IsNil() {
+			return clearField(s, f)
+		}
+		val = val.Elem()
 	}
 	if !isTypeMatch(val.Type(), typ) {
-		name, _ := f.NameBytes()
-		return fmt.Errorf("can't insert field %s of type Go %v into a %v", name, val.Type(), typ.Which())
+		return fmt.Errorf("can't insert field %s of type Go %v into a %v", f.name, val.Type(), typ.Which())
+	}
+	if c := findConverter(val.Type(), typ); c != nil {
+		cval, err := c.convertTo(val)
+		if err != nil {
+			return fmt.Errorf("insert field %s: %v", f.name, err)
+		}
+		return ins.insertField(s, f, cval)
 	}
 	if !isFieldInBounds(s.Size(), f.Slot().Offset(), typ) {
-		name, _ := f.NameBytes()
-		return fmt.Errorf("can't insert field %s: allocated struct is too small", name)
+		return fmt.Errorf("can't insert field %s: allocated struct is too small", f.name)
 	}
 	switch typ.Which() {
 	case schema.Type_Which_bool:
@@ -140,7 +168,10 @@ func (ins *inserter) insertField(s capnp.Struct, f schema.Field, val reflect.Val
 		d := dv.Uint16()
 		s.SetUint16(capnp.DataOffset(f.Slot().Offset()*2), v^d)
 	case schema.Type_Which_enum:
-		v := uint16(val.Uint())
+		v, err := enumOrdinal(typ, val)
+		if err != nil {
+			return fmt.Errorf("insert field %s: %v", f.name, err)
+		}
 		d := dv.Enum()
 		s.SetUint16(capnp.DataOffset(f.Slot().Offset()*2), v^d)
 	case schema.Type_Which_uint32:
@@ -186,10 +217,15 @@ func (ins *inserter) insertField(s capnp.Struct, f schema.Field, val reflect.Val
 			if val.IsNil() {
 				return s.SetPtr(off, capnp.Ptr{})
 			}
+			k, err := ins.enter(val)
+			if err != nil {
+				return err
+			}
+			defer ins.leave(k)
 			sval = val.Elem()
 		}
 		id := typ.StructType().TypeId()
-		sz, err := ins.structSize(id)
+		sz, err := structSize(id)
 		if err != nil {
 			return err
 		}
@@ -253,7 +289,21 @@ func (ins *inserter) insertList(l capnp.List, typ schema.Type, val reflect.Value
 		// TODO(light): the error won't be that useful for nested lists.
 		return fmt.Errorf("can't insert Go %v into a %v list", val.Type(), elem.Which())
 	}
+	if val.Kind() == reflect.Map {
+		return ins.insertMap(l, elem, val)
+	}
 	n := val.Len()
+	if c := findConverter(val.Type().Elem(), elem); c != nil {
+		tmp := reflect.MakeSlice(reflect.SliceOf(c.capnpType), n, n)
+		for i := 0; i < n; i++ {
+			cval, err := c.convertTo(val.Index(i))
+			if err != nil {
+				return err
+			}
+			tmp.Index(i).Set(cval)
+		}
+		return ins.insertList(l, typ, tmp)
+	}
 	switch elem.Which() {
 	case schema.Type_Which_void:
 	case schema.Type_Which_bool:
@@ -280,10 +330,18 @@ func (ins *inserter) insertList(l capnp.List, typ schema.Type, val reflect.Value
 		for i := 0; i < n; i++ {
 			capnp.UInt8List{List: l}.Set(i, uint8(val.Index(i).Uint()))
 		}
-	case schema.Type_Which_uint16, schema.Type_Which_enum:
+	case schema.Type_Which_uint16:
 		for i := 0; i < n; i++ {
 			capnp.UInt16List{List: l}.Set(i, uint16(val.Index(i).Uint()))
 		}
+	case schema.Type_Which_enum:
+		for i := 0; i < n; i++ {
+			v, err := enumOrdinal(elem, val.Index(i))
+			if err != nil {
+				return err
+			}
+			capnp.UInt16List{List: l}.Set(i, v)
+		}
 	case schema.Type_Which_uint32:
 		for i := 0; i < n; i++ {
 			capnp.UInt32List{List: l}.Set(i, uint32(val.Index(i).Uint()))
@@ -368,18 +426,22 @@ func (ins *inserter) insertList(l capnp.List, typ schema.Type, val reflect.Value
 			if err := pl.SetPtr(i, li.ToPtr()); err != nil {
 				return err
 			}
+			ins.pushIndex(i)
 			if err := ins.insertList(li, elem, vi); err != nil {
 				return err
 			}
+			ins.pop()
 		}
 	case schema.Type_Which_structType:
 		id := elem.StructType().TypeId()
 		for i := 0; i < n; i++ {
+			ins.pushIndex(i)
 			err := ins.insertStruct(id, l.Struct(i), val.Index(i))
 			if err != nil {
 				// TODO(light): collect errors and finish
 				return err
 			}
+			ins.pop()
 		}
 	case schema.Type_Which_interface:
 		pl := capnp.PointerList{List: l}
@@ -420,7 +482,7 @@ func (ins *inserter) newList(s *capnp.Segment, t schema.Type, len int32) (capnp.
 		l, err := capnp.NewPointerList(s, len)
 		return l.List, err
 	case schema.Type_Which_structType:
-		sz, err := ins.structSize(t.StructType().TypeId())
+		sz, err := structSize(t.StructType().TypeId())
 		if err != nil {
 			return capnp.List{}, err
 		}
@@ -430,20 +492,6 @@ func (ins *inserter) newList(s *capnp.Segment, t schema.Type, len int32) (capnp.
 	}
 }
 
-func (ins *inserter) structSize(id uint64) (capnp.ObjectSize, error) {
-	n, err := ins.nodes.Find(id)
-	if err != nil {
-		return capnp.ObjectSize{}, err
-	}
-	if n.Which() != schema.Node_Which_structNode {
-		return capnp.ObjectSize{}, fmt.Errorf("insert struct: sizing: node @%#x is not a struct", id)
-	}
-	return capnp.ObjectSize{
-		DataSize:     capnp.Size(n.StructNode().DataWordCount()) * 8,
-		PointerCount: n.StructNode().PointerCount(),
-	}, nil
-}
-
 func isFieldInBounds(sz capnp.ObjectSize, off uint32, t schema.Type) bool {
 	switch t.Which() {
 	case schema.Type_Which_void:
@@ -465,6 +513,26 @@ func isFieldInBounds(sz capnp.ObjectSize, off uint32, t schema.Type) bool {
 	}
 }
 
+// isEmptyGoValue reports whether v is empty in the sense of an
+// omitempty field tag, using the same rules as encoding/json.
+func isEmptyGoValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
 func isEmptyValue(v schema.Value) bool {
 	if !v.IsValid() {
 		return false
@@ -0,0 +1,63 @@
+package pogs
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/iguazio/go-capnproto2"
+)
+
+// ExtractList extracts each element of l, a list of structs with the
+// given type ID, into val, a pointer to a Go struct, and then calls f
+// with the element's index.  val is zeroed before each element is
+// extracted, so f must copy out anything that it wants to keep.  Only
+// one element is held in Go memory at a time, which keeps memory use
+// flat for large lists.
+//
+// If f returns an error, ExtractList stops and returns that error.
+func ExtractList(val interface{}, typeID uint64, l capnp.List, f func(i int) error) error {
+	v := reflect.ValueOf(val)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("pogs: extract list @%#x: need a non-nil pointer to a struct, got %T", typeID, val)
+	}
+	v = v.Elem()
+	zero := reflect.Zero(v.Type())
+	e := new(extracter)
+	n := l.Len()
+	for i := 0; i < n; i++ {
+		v.Set(zero)
+		if err := e.extractStruct(v, typeID, l.Struct(i), nil); err != nil {
+			return fmt.Errorf("pogs: extract list @%#x: element %d: %v", typeID, i, err)
+		}
+		if err := f(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InsertList fills l, a list of structs with the given type ID, by
+// calling f for each element's index and inserting the Go struct (or
+// pointer to struct) that it returns.  f may return the same value each
+// time after refilling it, so that only one element is held in Go
+// memory at a time.  The list must have already been allocated with
+// the desired length, usually with capnp.NewCompositeList.
+//
+// If f returns an error, InsertList stops and returns that error.
+func InsertList(typeID uint64, l capnp.List, f func(i int) (interface{}, error)) error {
+	if !l.IsValid() {
+		return fmt.Errorf("pogs: insert list @%#x: list is null", typeID)
+	}
+	ins := new(inserter)
+	n := l.Len()
+	for i := 0; i < n; i++ {
+		val, err := f(i)
+		if err != nil {
+			return err
+		}
+		if err := ins.insertStruct(typeID, l.Struct(i), reflect.ValueOf(val)); err != nil {
+			return fmt.Errorf("pogs: insert list @%#x: element %d: %v", typeID, i, err)
+		}
+	}
+	return nil
+}
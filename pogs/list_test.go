@@ -0,0 +1,81 @@
+package pogs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+func TestInsertExtractList(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	names := []string{"Alpha", "Bravo", "Charlie"}
+	pbl, err := air.NewPlaneBase_List(seg, int32(len(names)))
+	if err != nil {
+		t.Fatalf("NewPlaneBase_List: %v", err)
+	}
+	pb := new(PlaneBase)
+	err = InsertList(air.PlaneBase_TypeID, pbl.List, func(i int) (interface{}, error) {
+		*pb = PlaneBase{Name: names[i], Rating: int64(i)}
+		if i == 1 {
+			pb.Homes = []air.Airport{air.Airport_sfo}
+		}
+		return pb, nil
+	})
+	if err != nil {
+		t.Fatalf("InsertList: %v", err)
+	}
+
+	var got []PlaneBase
+	out := new(PlaneBase)
+	err = ExtractList(out, air.PlaneBase_TypeID, pbl.List, func(i int) error {
+		got = append(got, *out)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExtractList: %v", err)
+	}
+	want := []PlaneBase{
+		{Name: "Alpha", Rating: 0},
+		{Name: "Bravo", Rating: 1, Homes: []air.Airport{air.Airport_sfo}},
+		{Name: "Charlie", Rating: 2},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ExtractList visited %d elements; want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !got[i].equal(&want[i]) {
+			t.Errorf("element %d = %s; want %s", i, zpretty.Sprint(got[i]), zpretty.Sprint(want[i]))
+		}
+	}
+}
+
+func TestExtractList_Stop(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	pbl, err := air.NewPlaneBase_List(seg, 3)
+	if err != nil {
+		t.Fatalf("NewPlaneBase_List: %v", err)
+	}
+	errStop := errors.New("stop")
+	calls := 0
+	err = ExtractList(new(PlaneBase), air.PlaneBase_TypeID, pbl.List, func(i int) error {
+		calls++
+		return errStop
+	})
+	if err != errStop {
+		t.Errorf("ExtractList error = %v; want %v", err, errStop)
+	}
+	if calls != 1 {
+		t.Errorf("callback called %d times; want 1", calls)
+	}
+	if err := ExtractList(PlaneBase{}, air.PlaneBase_TypeID, pbl.List, func(int) error { return nil }); err == nil {
+		t.Error("ExtractList with non-pointer did not return an error")
+	}
+}
@@ -0,0 +1,107 @@
+package pogs
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+)
+
+// mapEntryFields returns the key and value fields of a map entry
+// struct.  An entry struct must have exactly two fields outside of a
+// union: the first is the key and the second is the value.
+func mapEntryFields(id uint64) (key, value schema.Field, err error) {
+	n, err := findNode(id)
+	if err != nil {
+		return schema.Field{}, schema.Field{}, err
+	}
+	if !n.IsValid() || n.Which() != schema.Node_Which_structNode {
+		return schema.Field{}, schema.Field{}, fmt.Errorf("cannot find struct type %#x", id)
+	}
+	fields, err := n.StructNode().Fields()
+	if err != nil {
+		return schema.Field{}, schema.Field{}, err
+	}
+	if fields.Len() != 2 || hasDiscriminant(n) {
+		return schema.Field{}, schema.Field{}, fmt.Errorf("%s is not a map entry: must have exactly two fields and no union", shortDisplayName(n))
+	}
+	key, value = fields.At(0), fields.At(1)
+	if key.Which() != schema.Field_Which_slot || value.Which() != schema.Field_Which_slot {
+		return schema.Field{}, schema.Field{}, fmt.Errorf("%s is not a map entry: groups can't be used as keys or values", shortDisplayName(n))
+	}
+	return key, value, nil
+}
+
+// extractMap copies a list of map entries into val.  mask applies to
+// the entries' values.
+func (e *extracter) extractMap(val reflect.Value, elem schema.Type, l capnp.List, mask fieldMask) error {
+	ent, err := mapEntryFor(elem.StructType().TypeId())
+	if err != nil {
+		return err
+	}
+	vt := val.Type()
+	n := l.Len()
+	m := reflect.MakeMapWithSize(vt, n)
+	for i := 0; i < n; i++ {
+		es := l.Struct(i)
+		k := reflect.New(vt.Key()).Elem()
+		if err := e.extractField(k, es, &ent.key, nil); err != nil {
+			return err
+		}
+		v := reflect.New(vt.Elem()).Elem()
+		if err := e.extractField(v, es, &ent.value, mask); err != nil {
+			return err
+		}
+		m.SetMapIndex(k, v)
+	}
+	val.Set(m)
+	return nil
+}
+
+func (ins *inserter) insertMap(l capnp.List, elem schema.Type, val reflect.Value) error {
+	ent, err := mapEntryFor(elem.StructType().TypeId())
+	if err != nil {
+		return err
+	}
+	keys := val.MapKeys()
+	sortMapKeys(keys)
+	for i, k := range keys {
+		es := l.Struct(i)
+		ins.pushIndex(i)
+		if err := ins.insertField(es, &ent.key, k); err != nil {
+			return err
+		}
+		if err := ins.insertField(es, &ent.value, val.MapIndex(k)); err != nil {
+			return err
+		}
+		ins.pop()
+	}
+	return nil
+}
+
+// sortMapKeys sorts keys of a basic kind so that insertion produces the
+// same message for the same map.  Other keys are left in the order
+// given.
+func sortMapKeys(keys []reflect.Value) {
+	if len(keys) < 2 {
+		return
+	}
+	var less func(a, b reflect.Value) bool
+	switch keys[0].Kind() {
+	case reflect.String:
+		less = func(a, b reflect.Value) bool { return a.String() < b.String() }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		less = func(a, b reflect.Value) bool { return a.Int() < b.Int() }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		less = func(a, b reflect.Value) bool { return a.Uint() < b.Uint() }
+	case reflect.Float32, reflect.Float64:
+		less = func(a, b reflect.Value) bool { return a.Float() < b.Float() }
+	case reflect.Bool:
+		less = func(a, b reflect.Value) bool { return !a.Bool() && b.Bool() }
+	default:
+		return
+	}
+	sort.Slice(keys, func(i, j int) bool { return less(keys[i], keys[j]) })
+}
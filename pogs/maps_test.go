@@ -0,0 +1,129 @@
+package pogs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+	"github.com/iguazio/go-capnproto2/std/capnp/json"
+)
+
+// jsonValue mirrors json.JsonValue, using a map for objects.
+type jsonValue struct {
+	Which   json.JsonValue_Which
+	Boolean bool
+	Number  float64
+	String  string
+	Array   []*jsonValue
+	Object  map[string]*jsonValue
+}
+
+func TestInsert_Map(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	v, err := json.NewRootJsonValue(seg)
+	if err != nil {
+		t.Fatalf("NewRootJsonValue: %v", err)
+	}
+	in := &jsonValue{
+		Which: json.JsonValue_Which_object,
+		Object: map[string]*jsonValue{
+			"b": {Which: json.JsonValue_Which_number, Number: 42},
+			"a": {Which: json.JsonValue_Which_string_, String: "hi"},
+			"c": nil,
+		},
+	}
+	if err := Insert(json.JsonValue_TypeID, v.Struct, in); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	if v.Which() != json.JsonValue_Which_object {
+		t.Fatalf("v.Which() = %v; want object", v.Which())
+	}
+	obj, err := v.Object()
+	if err != nil {
+		t.Fatalf("v.Object(): %v", err)
+	}
+	if obj.Len() != 3 {
+		t.Fatalf("v.Object().Len() = %d; want 3", obj.Len())
+	}
+	// Entries are sorted by key.
+	for i, want := range []string{"a", "b", "c"} {
+		name, err := obj.At(i).Name()
+		if err != nil {
+			t.Errorf("object[%d].Name(): %v", i, err)
+		} else if name != want {
+			t.Errorf("object[%d].Name() = %q; want %q", i, name, want)
+		}
+	}
+	if obj.At(2).HasValue() {
+		t.Error("object[2] has value; want null for nil map entry")
+	}
+	bv, err := obj.At(1).Value()
+	if err != nil {
+		t.Fatalf("object[1].Value(): %v", err)
+	}
+	if bv.Which() != json.JsonValue_Which_number || bv.Number() != 42 {
+		t.Errorf("object[1].Value() = %v; want (number = 42)", bv)
+	}
+}
+
+func TestExtract_Map(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	v, err := json.NewRootJsonValue(seg)
+	if err != nil {
+		t.Fatalf("NewRootJsonValue: %v", err)
+	}
+	obj, err := v.NewObject(2)
+	if err != nil {
+		t.Fatalf("NewObject: %v", err)
+	}
+	obj.At(0).SetName("x")
+	xv, _ := obj.At(0).NewValue()
+	xv.SetBoolean(true)
+	obj.At(1).SetName("y")
+	yv, _ := obj.At(1).NewValue()
+	arr, _ := yv.NewArray(1)
+	arr.At(0).SetNumber(1.5)
+
+	out := new(jsonValue)
+	if err := Extract(out, json.JsonValue_TypeID, v.Struct); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	want := &jsonValue{
+		Which: json.JsonValue_Which_object,
+		Object: map[string]*jsonValue{
+			"x": {Which: json.JsonValue_Which_boolean, Boolean: true},
+			"y": {Which: json.JsonValue_Which_array, Array: []*jsonValue{
+				{Which: json.JsonValue_Which_number, Number: 1.5},
+			}},
+		},
+	}
+	if !reflect.DeepEqual(out, want) {
+		t.Errorf("Extract produced %s; want %s", zpretty.Sprint(out), zpretty.Sprint(want))
+	}
+}
+
+func TestMap_NotEntry(t *testing.T) {
+	type ZMap struct {
+		Which air.Z_Which
+		Zvec  map[string]*Z
+	}
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatalf("NewRootZ: %v", err)
+	}
+	in := &ZMap{Which: air.Z_Which_zvec, Zvec: map[string]*Z{"foo": {Which: air.Z_Which_void}}}
+	if err := Insert(air.Z_TypeID, z.Struct, in); err == nil {
+		t.Error("Insert of map into list of non-entry structs did not return an error")
+	}
+}
@@ -0,0 +1,67 @@
+package pogs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A fieldMask is a set of schema field names to copy, each with a mask
+// for the field's contents.  A nil fieldMask selects every field.
+type fieldMask map[string]fieldMask
+
+// newFieldMask builds a mask from a list of dotted field paths.
+func newFieldMask(paths []string) fieldMask {
+	m := make(fieldMask, len(paths))
+	for _, p := range paths {
+		m.add(p)
+	}
+	return m
+}
+
+func (m fieldMask) add(path string) {
+	name, rest := path, ""
+	if i := strings.IndexByte(path, '.'); i >= 0 {
+		name, rest = path[:i], path[i+1:]
+	}
+	sub, present := m[name]
+	if rest == "" {
+		// Selecting the whole field overrides any narrower selection.
+		m[name] = nil
+		return
+	}
+	if present && sub == nil {
+		return
+	}
+	if sub == nil {
+		sub = make(fieldMask)
+		m[name] = sub
+	}
+	sub.add(rest)
+}
+
+// sub reports whether the named field is selected by m and returns the
+// mask for the field's contents.
+func (m fieldMask) sub(name string) (fieldMask, bool) {
+	if m == nil {
+		return nil, true
+	}
+	sub, ok := m[name]
+	return sub, ok
+}
+
+// check returns an error if m selects a field that isn't in plan.
+func (m fieldMask) check(plan *structPlan) error {
+	for name := range m {
+		found := false
+		for i := range plan.fields {
+			if plan.fields[i].name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("field mask selects unknown field %s in %s", name, shortDisplayName(plan.node))
+		}
+	}
+	return nil
+}
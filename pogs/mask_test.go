@@ -0,0 +1,110 @@
+package pogs
+
+import (
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+func TestExtractFields(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatalf("NewRootZ: %v", err)
+	}
+	full := &PlaneBase{
+		Name:     "Fokker",
+		Homes:    []air.Airport{air.Airport_jfk, air.Airport_lax},
+		Rating:   5,
+		CanFly:   true,
+		Capacity: 40,
+		MaxSpeed: 450,
+	}
+	if err := zfill(z, &Z{Which: air.Z_Which_planebase, Planebase: full}); err != nil {
+		t.Fatalf("zfill: %v", err)
+	}
+	tests := []struct {
+		name string
+		mask []string
+		want *Z
+	}{
+		{
+			name: "empty mask",
+			mask: nil,
+			want: &Z{Which: air.Z_Which_planebase},
+		},
+		{
+			name: "whole field",
+			mask: []string{"planebase"},
+			want: &Z{Which: air.Z_Which_planebase, Planebase: full},
+		},
+		{
+			name: "nested fields",
+			mask: []string{"planebase.name", "planebase.rating"},
+			want: &Z{Which: air.Z_Which_planebase, Planebase: &PlaneBase{Name: "Fokker", Rating: 5}},
+		},
+		{
+			name: "whole field wins",
+			mask: []string{"planebase.name", "planebase"},
+			want: &Z{Which: air.Z_Which_planebase, Planebase: full},
+		},
+		{
+			name: "unset union field",
+			mask: []string{"text", "planebase.canFly"},
+			want: &Z{Which: air.Z_Which_planebase, Planebase: &PlaneBase{CanFly: true}},
+		},
+	}
+	for _, test := range tests {
+		out := new(Z)
+		if err := ExtractFields(out, air.Z_TypeID, z.Struct, test.mask); err != nil {
+			t.Errorf("%s: ExtractFields(%q): %v", test.name, test.mask, err)
+			continue
+		}
+		if !out.equal(test.want) {
+			t.Errorf("%s: ExtractFields(%q) produced %s; want %s", test.name, test.mask, zpretty.Sprint(out), zpretty.Sprint(test.want))
+		}
+	}
+}
+
+func TestExtractFields_KeepsUnselected(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	pb, err := air.NewRootPlaneBase(seg)
+	if err != nil {
+		t.Fatalf("NewRootPlaneBase: %v", err)
+	}
+	pb.SetName("Fokker")
+	pb.SetRating(5)
+	out := &PlaneBase{Rating: 99}
+	if err := ExtractFields(out, air.PlaneBase_TypeID, pb.Struct, []string{"name"}); err != nil {
+		t.Fatalf("ExtractFields: %v", err)
+	}
+	if want := (&PlaneBase{Name: "Fokker", Rating: 99}); !out.equal(want) {
+		t.Errorf("ExtractFields produced %s; want %s", zpretty.Sprint(out), zpretty.Sprint(want))
+	}
+}
+
+func TestExtractFields_Unknown(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatalf("NewRootZ: %v", err)
+	}
+	if err := zfill(z, &Z{Which: air.Z_Which_planebase, Planebase: &PlaneBase{Name: "x"}}); err != nil {
+		t.Fatalf("zfill: %v", err)
+	}
+	for _, mask := range [][]string{{"bogus"}, {"planebase.bogus"}} {
+		if err := ExtractFields(new(Z), air.Z_TypeID, z.Struct, mask); err == nil {
+			t.Errorf("ExtractFields(%q) did not return an error", mask)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package pogs
+
+import (
+	"reflect"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+)
+
+// isOptionalPtr reports whether t is a Go pointer that stands for an
+// optional value of the non-struct schema type typ, like *int32 or
+// *string.  Pointers to structs are handled like structs.
+func isOptionalPtr(t reflect.Type, typ schema.Type) bool {
+	return t.Kind() == reflect.Ptr &&
+		typ.Which() != schema.Type_Which_structType &&
+		findConverter(t, typ) == nil
+}
+
+// isPointerType reports whether values of typ are stored in a struct's
+// pointer section.
+func isPointerType(typ schema.Type) bool {
+	switch typ.Which() {
+	case schema.Type_Which_text, schema.Type_Which_data, schema.Type_Which_list,
+		schema.Type_Which_structType, schema.Type_Which_interface, schema.Type_Which_anyPointer:
+		return true
+	default:
+		return false
+	}
+}
+
+// clearField sets a field to its default value by zeroing its bits or
+// setting its pointer to null.
+func clearField(s capnp.Struct, f *fieldPlan) error {
+	off := f.Slot().Offset()
+	if !isFieldInBounds(s.Size(), off, f.typ) {
+		return nil
+	}
+	switch f.typ.Which() {
+	case schema.Type_Which_bool:
+		s.SetBit(capnp.BitOffset(off), false)
+	case schema.Type_Which_int8, schema.Type_Which_uint8:
+		s.SetUint8(capnp.DataOffset(off), 0)
+	case schema.Type_Which_int16, schema.Type_Which_uint16, schema.Type_Which_enum:
+		s.SetUint16(capnp.DataOffset(off*2), 0)
+	case schema.Type_Which_int32, schema.Type_Which_uint32, schema.Type_Which_float32:
+		s.SetUint32(capnp.DataOffset(off*4), 0)
+	case schema.Type_Which_int64, schema.Type_Which_uint64, schema.Type_Which_float64:
+		s.SetUint64(capnp.DataOffset(off*8), 0)
+	default:
+		if isPointerType(f.typ) {
+			return s.SetPtr(uint16(off), capnp.Ptr{})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package pogs
+
+import (
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+type PtrDefaults struct {
+	Text  *string
+	Data  *[]byte
+	Float *float32
+	Int   *int32
+	Uint  *uint32
+}
+
+func TestOptional_Pointers(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := air.NewRootDefaults(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	empty, zero := "", int32(0)
+	if err := Insert(air.Defaults_TypeID, d.Struct, &PtrDefaults{Text: &empty, Int: &zero}); err != nil {
+		t.Fatal("Insert:", err)
+	}
+	if text, _ := d.Text(); !d.HasText() || text != "" {
+		t.Errorf("after inserting pointer to \"\": d.Text() = %q, HasText = %t; want \"\", true", text, d.HasText())
+	}
+	if d.Int() != 0 {
+		t.Errorf("after inserting pointer to 0: d.Int() = %d; want 0", d.Int())
+	}
+	out := new(PtrDefaults)
+	if err := Extract(out, air.Defaults_TypeID, d.Struct); err != nil {
+		t.Fatal("Extract:", err)
+	}
+	if out.Text == nil || *out.Text != "" {
+		t.Errorf("Extract produced Text = %v; want pointer to \"\"", out.Text)
+	}
+	if out.Data != nil {
+		t.Errorf("Extract produced Data = %v; want nil for a null pointer", out.Data)
+	}
+	if out.Int == nil || *out.Int != 0 {
+		t.Errorf("Extract produced Int = %v; want pointer to 0", out.Int)
+	}
+
+	// Inserting nil resets a field to its default.
+	if err := Insert(air.Defaults_TypeID, d.Struct, &PtrDefaults{}); err != nil {
+		t.Fatal("Insert:", err)
+	}
+	if d.HasText() || d.Int() != -123 {
+		t.Errorf("after inserting nils: HasText = %t, d.Int() = %d; want false, -123", d.HasText(), d.Int())
+	}
+	if err := Extract(out, air.Defaults_TypeID, d.Struct); err != nil {
+		t.Fatal("Extract:", err)
+	}
+	if out.Text != nil {
+		t.Errorf("Extract produced Text = %q; want nil", *out.Text)
+	}
+	if out.Int == nil || *out.Int != -123 {
+		t.Errorf("Extract produced Int = %v; want pointer to -123", out.Int)
+	}
+}
+
+type OptZ struct {
+	I32 *int32 `capnp:",optional=void"`
+}
+
+type OptStructZ struct {
+	Planebase *PlaneBase `capnp:",optional=void"`
+}
+
+func TestOptional_Union(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zero := int32(0)
+	if err := Insert(air.Z_TypeID, z.Struct, &OptZ{I32: &zero}); err != nil {
+		t.Fatal("Insert:", err)
+	}
+	if z.Which() != air.Z_Which_i32 || z.I32() != 0 {
+		t.Errorf("after inserting pointer to 0: z = %v, %d; want i32, 0", z.Which(), z.I32())
+	}
+	out := new(OptZ)
+	if err := Extract(out, air.Z_TypeID, z.Struct); err != nil {
+		t.Fatal("Extract:", err)
+	}
+	if out.I32 == nil || *out.I32 != 0 {
+		t.Errorf("Extract produced I32 = %v; want pointer to 0", out.I32)
+	}
+
+	if err := Insert(air.Z_TypeID, z.Struct, &OptZ{}); err != nil {
+		t.Fatal("Insert:", err)
+	}
+	if z.Which() != air.Z_Which_void {
+		t.Errorf("after inserting nil: z.Which() = %v; want void", z.Which())
+	}
+	if err := Extract(out, air.Z_TypeID, z.Struct); err != nil {
+		t.Fatal("Extract:", err)
+	}
+	if out.I32 != nil {
+		t.Errorf("Extract produced I32 = %d; want nil", *out.I32)
+	}
+
+	z.SetF64(1.5)
+	sout := &OptStructZ{Planebase: new(PlaneBase)}
+	if err := Extract(sout, air.Z_TypeID, z.Struct); err != nil {
+		t.Fatal("Extract:", err)
+	}
+	if sout.Planebase != nil {
+		t.Errorf("Extract of other union member produced Planebase = %+v; want nil", sout.Planebase)
+	}
+}
+
+func TestOptional_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		val  interface{}
+	}{
+		{"not a pointer", &struct {
+			I32 int32 `capnp:",optional=void"`
+		}{}},
+		{"unknown member", &struct {
+			I32 *int32 `capnp:",optional=nothing"`
+		}{}},
+		{"member not void", &struct {
+			I32 *int32 `capnp:",optional=i64"`
+		}{}},
+		{"same member", &struct {
+			Void *struct{} `capnp:",optional=void"`
+		}{}},
+	}
+	for _, test := range tests {
+		_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		z, err := air.NewRootZ(seg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := Insert(air.Z_TypeID, z.Struct, test.val); err == nil {
+			t.Errorf("%s: Insert did not return an error", test.name)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package pogs
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/nodemap"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+)
+
+// Precompile computes and caches the mapping between the Go type of
+// val and the Cap'n Proto struct type with the given ID, along with
+// the mappings for any struct types reachable from its fields.  val is
+// usually a nil pointer to a Go struct, like (*Book)(nil).
+//
+// Insert and Extract compute these mappings on first use and reuse
+// them afterward, so calling Precompile is optional.  Calling it during
+// initialization moves that cost out of the first call and reports
+// mapping errors before any data is copied.
+func Precompile(typeID uint64, val interface{}) error {
+	t := reflect.TypeOf(val)
+	if t == nil {
+		return fmt.Errorf("pogs: precompile @%#x: nil value", typeID)
+	}
+	if err := precompileStruct(t, typeID, make(map[planKey]bool)); err != nil {
+		return fmt.Errorf("pogs: precompile @%#x: %v", typeID, err)
+	}
+	return nil
+}
+
+func precompileStruct(t reflect.Type, typeID uint64, seen map[planKey]bool) error {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("can't map %v to a struct", t)
+	}
+	k := planKey{t, typeID}
+	if seen[k] {
+		return nil
+	}
+	seen[k] = true
+	n, err := findNode(typeID)
+	if err != nil {
+		return err
+	}
+	if !n.IsValid() || n.Which() != schema.Node_Which_structNode {
+		return fmt.Errorf("cannot find struct type %#x", typeID)
+	}
+	plan, err := planFor(t, n)
+	if err != nil {
+		return fmt.Errorf("can't map %v: %v", t, err)
+	}
+	for i := range plan.fields {
+		loc := plan.props.fields[i]
+		if !loc.isValid() {
+			continue
+		}
+		fp := &plan.fields[i]
+		ft := typeFieldByLoc(t, loc).Type
+		switch fp.Which() {
+		case schema.Field_Which_slot:
+			if err := precompileType(ft, fp.typ, seen); err != nil {
+				return fmt.Errorf("field %s: %v", fp.name, err)
+			}
+		case schema.Field_Which_group:
+			if err := precompileStruct(ft, fp.Group().TypeId(), seen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func precompileType(t reflect.Type, typ schema.Type, seen map[planKey]bool) error {
+	if isOptionalPtr(t, typ) {
+		t = t.Elem()
+	}
+	if !isTypeMatch(t, typ) {
+		return fmt.Errorf("can't map Go %v to a %v", t, typ.Which())
+	}
+	if findConverter(t, typ) != nil {
+		return nil
+	}
+	switch typ.Which() {
+	case schema.Type_Which_structType:
+		return precompileStruct(t, typ.StructType().TypeId(), seen)
+	case schema.Type_Which_list:
+		elem, err := typ.List().ElementType()
+		if err != nil {
+			return err
+		}
+		if t.Kind() == reflect.Map {
+			ent, err := mapEntryFor(elem.StructType().TypeId())
+			if err != nil {
+				return err
+			}
+			if err := precompileType(t.Key(), ent.key.typ, seen); err != nil {
+				return err
+			}
+			return precompileType(t.Elem(), ent.value.typ, seen)
+		}
+		return precompileType(t.Elem(), elem, seen)
+	}
+	return nil
+}
+
+// A structPlan is the mapping between a Go struct type and a Cap'n
+// Proto struct type, along with the schema information needed to copy
+// between them.  Plans are computed once and then shared, so they must
+// not be modified.
+type structPlan struct {
+	node       schema.Node
+	props      structProps
+	hasDiscrim bool
+	discrimOff capnp.DataOffset
+	fields     []fieldPlan // indexed by field ordinal
+}
+
+// A fieldPlan caches the schema information for a field.  typ and dv
+// are only populated for slots.
+type fieldPlan struct {
+	schema.Field
+	name    string
+	discrim uint16
+	typ     schema.Type
+	dv      schema.Value
+}
+
+func newFieldPlan(f schema.Field) (fieldPlan, error) {
+	name, _ := f.Name()
+	fp := fieldPlan{
+		Field:   f,
+		name:    name,
+		discrim: f.DiscriminantValue(),
+	}
+	if f.Which() != schema.Field_Which_slot {
+		return fp, nil
+	}
+	var err error
+	fp.typ, err = f.Slot().Type()
+	if err != nil {
+		return fieldPlan{}, err
+	}
+	fp.dv, err = f.Slot().DefaultValue()
+	if err != nil {
+		return fieldPlan{}, err
+	}
+	return fp, nil
+}
+
+func newStructPlan(t reflect.Type, n schema.Node) (*structPlan, error) {
+	props, err := mapStruct(t, n)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := n.StructNode().Fields()
+	if err != nil {
+		return nil, err
+	}
+	plan := &structPlan{
+		node:   n,
+		props:  props,
+		fields: make([]fieldPlan, fields.Len()),
+	}
+	if hasDiscriminant(n) {
+		plan.hasDiscrim = true
+		plan.discrimOff = capnp.DataOffset(n.StructNode().DiscriminantOffset() * 2)
+	}
+	for i := range plan.fields {
+		f := fields.At(i)
+		if !props.fields[i].isValid() {
+			// Not mapped to a Go field, so skip reading its type.
+			name, _ := f.Name()
+			plan.fields[i] = fieldPlan{Field: f, name: name, discrim: f.DiscriminantValue()}
+			continue
+		}
+		plan.fields[i], err = newFieldPlan(f)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// A mapEntry holds the key and value fields of a map entry struct.
+type mapEntry struct {
+	key, value fieldPlan
+}
+
+type planKey struct {
+	t  reflect.Type
+	id uint64
+}
+
+// cache holds the schema nodes and plans used by Insert and Extract.
+var cache struct {
+	nodesMu sync.Mutex
+	nodes   nodemap.Map
+
+	mu      sync.RWMutex
+	plans   map[planKey]*structPlan
+	entries map[uint64]*mapEntry
+	enums   map[uint64]*enumPlan
+}
+
+// findNode returns the node for the given ID from the default registry.
+func findNode(id uint64) (schema.Node, error) {
+	cache.nodesMu.Lock()
+	defer cache.nodesMu.Unlock()
+	return cache.nodes.Find(id)
+}
+
+// planFor returns the plan for copying between the Go struct type t
+// and the struct node n.
+func planFor(t reflect.Type, n schema.Node) (*structPlan, error) {
+	k := planKey{t, n.Id()}
+	cache.mu.RLock()
+	plan := cache.plans[k]
+	cache.mu.RUnlock()
+	if plan != nil {
+		return plan, nil
+	}
+	plan, err := newStructPlan(t, n)
+	if err != nil {
+		return nil, err
+	}
+	cache.mu.Lock()
+	if p := cache.plans[k]; p != nil {
+		plan = p
+	} else {
+		if cache.plans == nil {
+			cache.plans = make(map[planKey]*structPlan)
+		}
+		cache.plans[k] = plan
+	}
+	cache.mu.Unlock()
+	return plan, nil
+}
+
+// mapEntryFor returns the key and value fields of the map entry struct
+// with the given ID.
+func mapEntryFor(id uint64) (*mapEntry, error) {
+	cache.mu.RLock()
+	ent := cache.entries[id]
+	cache.mu.RUnlock()
+	if ent != nil {
+		return ent, nil
+	}
+	kf, vf, err := mapEntryFields(id)
+	if err != nil {
+		return nil, err
+	}
+	ent = new(mapEntry)
+	if ent.key, err = newFieldPlan(kf); err != nil {
+		return nil, err
+	}
+	if ent.value, err = newFieldPlan(vf); err != nil {
+		return nil, err
+	}
+	cache.mu.Lock()
+	if e := cache.entries[id]; e != nil {
+		ent = e
+	} else {
+		if cache.entries == nil {
+			cache.entries = make(map[uint64]*mapEntry)
+		}
+		cache.entries[id] = ent
+	}
+	cache.mu.Unlock()
+	return ent, nil
+}
+
+// structSize returns the size of the struct type with the given ID.
+func structSize(id uint64) (capnp.ObjectSize, error) {
+	n, err := findNode(id)
+	if err != nil {
+		return capnp.ObjectSize{}, err
+	}
+	if n.Which() != schema.Node_Which_structNode {
+		return capnp.ObjectSize{}, fmt.Errorf("insert struct: sizing: node @%#x is not a struct", id)
+	}
+	return capnp.ObjectSize{
+		DataSize:     capnp.Size(n.StructNode().DataWordCount()) * 8,
+		PointerCount: n.StructNode().PointerCount(),
+	}, nil
+}
@@ -0,0 +1,88 @@
+package pogs
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+)
+
+func TestPrecompile(t *testing.T) {
+	if err := Precompile(air.Z_TypeID, (*Z)(nil)); err != nil {
+		t.Errorf("Precompile(Z): %v", err)
+	}
+	if err := Precompile(air.Z_TypeID, Z{}); err != nil {
+		t.Errorf("Precompile(Z{}): %v", err)
+	}
+}
+
+func TestPrecompile_Errors(t *testing.T) {
+	type BadField struct {
+		Which air.Z_Which
+		F64   string
+	}
+	type BadNested struct {
+		Which air.Z_Which
+		Zvec  []*BadField
+	}
+	type UnknownField struct {
+		Bogus int32
+	}
+	tests := []struct {
+		name string
+		val  interface{}
+	}{
+		{"nil", nil},
+		{"not a struct", new(int)},
+		{"mismatched field", (*BadField)(nil)},
+		{"mismatched nested field", (*BadNested)(nil)},
+		{"unknown field", (*UnknownField)(nil)},
+	}
+	for _, test := range tests {
+		if err := Precompile(air.Z_TypeID, test.val); err == nil {
+			t.Errorf("%s: Precompile did not return an error", test.name)
+		}
+	}
+}
+
+func TestExtract_Concurrent(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatalf("NewRootZ: %v", err)
+	}
+	want := &Z{Which: air.Z_Which_zvec, Zvec: []*Z{
+		{Which: air.Z_Which_text, Text: "hi"},
+		{Which: air.Z_Which_planebase, Planebase: &PlaneBase{Name: "x"}},
+	}}
+	if err := zfill(z, want); err != nil {
+		t.Fatalf("zfill: %v", err)
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			out := new(Z)
+			if err := Extract(out, air.Z_TypeID, z.Struct); err != nil {
+				errs <- err
+				return
+			}
+			if !out.equal(want) {
+				errs <- errMismatch
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+var errMismatch = convertTestError("extracted value does not match")
@@ -8,6 +8,7 @@ import (
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/iguazio/go-capnproto2"
 	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+	"github.com/iguazio/go-capnproto2/internal/demo/books"
 )
 
 type Z struct {
@@ -663,6 +664,30 @@ func TestExtract_GroupNoPtr(t *testing.T) {
 	}
 }
 
+func TestExtract_DefaultStruct(t *testing.T) {
+	type StackingA struct {
+		Num int32
+	}
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	root, err := air.NewRootStackingRoot(seg)
+	if err != nil {
+		t.Fatalf("NewRootStackingRoot: %v", err)
+	}
+	out := new(struct {
+		A            StackingA
+		AWithDefault StackingA
+	})
+	if err := Extract(out, air.StackingRoot_TypeID, root.Struct); err != nil {
+		t.Fatalf("Extract(%v) error: %v", root, err)
+	}
+	if out.A.Num != 0 || out.AWithDefault.Num != 42 {
+		t.Errorf("Extract(%v): a.num, aWithDefault.num = %d, %d; want 0, 42", root, out.A.Num, out.AWithDefault.Num)
+	}
+}
+
 func TestInsert_StructNoPtr(t *testing.T) {
 	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
 	if err != nil {
@@ -928,6 +953,141 @@ func TestExtraFields(t *testing.T) {
 	}
 }
 
+// NameTagZ is a variant of Z that uses name= options and a
+// differently-named discriminant field.
+type NameTagZ struct {
+	Kind  air.Z_Which `capnp:",which"`
+	Float float64     `capnp:",name=f64"`
+	Extra string      `capnp:"-"`
+}
+
+func TestExtract_NameTags(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatalf("NewRootZ: %v", err)
+	}
+	if err := zfill(z, &Z{Which: air.Z_Which_f64, F64: 3.5}); err != nil {
+		t.Fatalf("zfill: %v", err)
+	}
+	out := new(NameTagZ)
+	if err := Extract(out, air.Z_TypeID, z.Struct); err != nil {
+		t.Errorf("Extract error: %v", err)
+	}
+	if want := (NameTagZ{Kind: air.Z_Which_f64, Float: 3.5}); *out != want {
+		t.Errorf("Extract produced %s; want %s", zpretty.Sprint(out), zpretty.Sprint(want))
+	}
+}
+
+func TestInsert_NameTags(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatalf("NewRootZ: %v", err)
+	}
+	in := &NameTagZ{Kind: air.Z_Which_f64, Float: 3.5, Extra: "ignored"}
+	if err := Insert(air.Z_TypeID, z.Struct, in); err != nil {
+		t.Errorf("Insert(%s) error: %v", zpretty.Sprint(in), err)
+	}
+	want := &Z{Which: air.Z_Which_f64, F64: 3.5}
+	if equal, err := zequal(want, z); err != nil {
+		t.Errorf("Insert(%s) compare err: %v", zpretty.Sprint(in), err)
+	} else if !equal {
+		t.Errorf("Insert(%s) produced %v", zpretty.Sprint(in), z)
+	}
+}
+
+func TestWhichTagWithoutUnion(t *testing.T) {
+	type BadWhich struct {
+		Kind uint16 `capnp:",which"`
+		Name string
+	}
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	pb, err := air.NewRootPlaneBase(seg)
+	if err != nil {
+		t.Fatalf("NewRootPlaneBase: %v", err)
+	}
+	if err := Insert(air.PlaneBase_TypeID, pb.Struct, &BadWhich{Name: "foo"}); err == nil {
+		t.Error("Insert did not return an error")
+	}
+	if err := Extract(new(BadWhich), air.PlaneBase_TypeID, pb.Struct); err == nil {
+		t.Error("Extract did not return an error")
+	}
+}
+
+func TestInsert_OmitEmpty(t *testing.T) {
+	type OmitDefaults struct {
+		Text  string  `capnp:",omitempty"`
+		Float float32 `capnp:"float"`
+		Int   int32   `capnp:"int,omitempty"`
+		Uint  uint32  `capnp:",name=uint,omitempty"`
+	}
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	d, err := air.NewRootDefaults(seg)
+	if err != nil {
+		t.Fatalf("NewRootDefaults: %v", err)
+	}
+	in := &OmitDefaults{Uint: 7}
+	if err := Insert(air.Defaults_TypeID, d.Struct, in); err != nil {
+		t.Fatalf("Insert(%+v): %v", in, err)
+	}
+	if text, err := d.Text(); err != nil {
+		t.Errorf("d.Text(): %v", err)
+	} else if text != "foo" {
+		t.Errorf("d.Text() = %q; want \"foo\" (default)", text)
+	}
+	if f := d.Float(); f != 0 {
+		t.Errorf("d.Float() = %v; want 0", f)
+	}
+	if i := d.Int(); i != -123 {
+		t.Errorf("d.Int() = %d; want -123 (default)", i)
+	}
+	if u := d.Uint(); u != 7 {
+		t.Errorf("d.Uint() = %d; want 7", u)
+	}
+}
+
+func TestNameOptionFirst(t *testing.T) {
+	type Book struct {
+		Title string
+		Pages int32 `capnp:"name=pageCount,omitempty"`
+	}
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	b, err := books.NewRootBook(seg)
+	if err != nil {
+		t.Fatalf("NewRootBook: %v", err)
+	}
+	in := &Book{Title: "War and Peace", Pages: 1440}
+	if err := Insert(books.Book_TypeID, b.Struct, in); err != nil {
+		t.Fatalf("Insert(%+v): %v", in, err)
+	}
+	if n := b.PageCount(); n != 1440 {
+		t.Errorf("pageCount = %d; want 1440", n)
+	}
+	out := new(Book)
+	if err := Extract(out, books.Book_TypeID, b.Struct); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if *out != *in {
+		t.Errorf("Extract = %+v; want %+v", *out, *in)
+	}
+}
+
 func zequal(g *Z, c air.Z) (bool, error) {
 	if g.Which != c.Which() {
 		return false, nil
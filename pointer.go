@@ -110,7 +110,7 @@ func (p Ptr) Text() string {
 	if !ok {
 		return ""
 	}
-	return string(b)
+	return p.str(b)
 }
 
 // TextDefault attempts to convert p into Text, returning def if p is
@@ -120,7 +120,7 @@ func (p Ptr) TextDefault(def string) string {
 	if !ok {
 		return def
 	}
-	return string(b)
+	return p.str(b)
 }
 
 // TextBytes attempts to convert p into Text, returning nil if p is not
@@ -145,6 +145,15 @@ func (p Ptr) TextBytesDefault(def string) []byte {
 	return b
 }
 
+// str returns b, which is in p's segment, as a string from the
+// message's StringTable if it has one.
+func (p Ptr) str(b []byte) string {
+	if t := p.seg.msg.StringTable; t != nil {
+		return t.Intern(b)
+	}
+	return string(b)
+}
+
 func (p Ptr) text() (b []byte, ok bool) {
 	if !isOneByteList(p) {
 		return nil, false
@@ -0,0 +1,50 @@
+package capnp
+
+import (
+	"fmt"
+	"time"
+)
+
+// PoolDebug is true if the package is built with the capnp_pooldebug
+// build tag, which makes it record where each buffer taken from a
+// BufferPool is acquired so that PoolLeaks can report the ones that are
+// never returned.  Recording a stack for every message is slow, so the
+// tag is meant for tests and for tracking down leaks.
+const PoolDebug = poolDebug
+
+// A PoolLeak describes a buffer that was taken from a BufferPool and
+// hasn't been returned to it.
+type PoolLeak struct {
+	// Kind is what holds the buffer: "message" for a message from
+	// BufferPool.NewMessage that hasn't been passed to Release, or
+	// "encoder buffer" for messages buffered by an Encoder with
+	// WithCoalesce that haven't been flushed.
+	Kind string
+
+	// Acquired is when the buffer was taken from the pool.
+	Acquired time.Time
+
+	// Stack is the stack of the goroutine that took the buffer, in the
+	// format of a panic's stack trace, innermost call first.
+	Stack string
+}
+
+// String returns the leak's kind, when it was acquired, and its stack.
+func (l PoolLeak) String() string {
+	return fmt.Sprintf("%s acquired at %s:\n%s", l.Kind, l.Acquired.Format(time.RFC3339Nano), l.Stack)
+}
+
+// PoolLeaks returns the buffers taken from any BufferPool that haven't
+// been returned, oldest first.  Buffers that are still in use are
+// included, so it is most useful once a test or a phase of a program is
+// done with its messages.  Without the capnp_pooldebug build tag,
+// PoolLeaks always returns nil.
+func PoolLeaks() []PoolLeak {
+	return poolLeaks()
+}
+
+// ResetPoolLeaks forgets the buffers that have been taken so far, so
+// that PoolLeaks only reports buffers taken afterward.
+func ResetPoolLeaks() {
+	resetPoolLeaks()
+}
@@ -0,0 +1,23 @@
+// +build !capnp_pooldebug
+
+package capnp
+
+// poolDebug is true if buffers taken from a BufferPool are tracked.
+const poolDebug = false
+
+// A poolTicket records that a buffer was taken from a BufferPool.
+// Without the capnp_pooldebug build tag it is empty.
+type poolTicket struct{}
+
+func acquirePoolTicket(kind string) poolTicket {
+	return poolTicket{}
+}
+
+// release records that the buffer was returned.
+func (t *poolTicket) release() {}
+
+func poolLeaks() []PoolLeak {
+	return nil
+}
+
+func resetPoolLeaks() {}
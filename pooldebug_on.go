@@ -0,0 +1,110 @@
+// +build capnp_pooldebug
+
+package capnp
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// poolDebug is true if buffers taken from a BufferPool are tracked.
+const poolDebug = true
+
+// maxPoolStack is the most frames recorded for each buffer.
+const maxPoolStack = 32
+
+// A poolTicket records that a buffer was taken from a BufferPool.
+// Its record stays in pools.live until it is released.
+type poolTicket struct {
+	rec *poolRecord
+}
+
+type poolRecord struct {
+	kind     string
+	acquired time.Time
+	seq      uint64
+	pcs      []uintptr
+}
+
+var pools struct {
+	mu   sync.Mutex
+	seq  uint64
+	live map[*poolRecord]struct{}
+}
+
+// acquirePoolTicket records that a buffer was taken by the caller of
+// the function that calls acquirePoolTicket.
+func acquirePoolTicket(kind string) poolTicket {
+	pcs := make([]uintptr, maxPoolStack)
+	// Skip runtime.Callers and acquirePoolTicket, but keep the pool
+	// method so that the stack shows how the buffer was taken.
+	pcs = pcs[:runtime.Callers(2, pcs)]
+	rec := &poolRecord{kind: kind, acquired: time.Now(), pcs: pcs}
+	pools.mu.Lock()
+	pools.seq++
+	rec.seq = pools.seq
+	if pools.live == nil {
+		pools.live = make(map[*poolRecord]struct{})
+	}
+	pools.live[rec] = struct{}{}
+	pools.mu.Unlock()
+	return poolTicket{rec}
+}
+
+// release records that the buffer was returned.  Releasing a ticket
+// more than once or releasing the zero ticket does nothing.
+func (t *poolTicket) release() {
+	if t.rec == nil {
+		return
+	}
+	pools.mu.Lock()
+	delete(pools.live, t.rec)
+	pools.mu.Unlock()
+	t.rec = nil
+}
+
+func poolLeaks() []PoolLeak {
+	pools.mu.Lock()
+	recs := make([]*poolRecord, 0, len(pools.live))
+	for rec := range pools.live {
+		recs = append(recs, rec)
+	}
+	pools.mu.Unlock()
+	if len(recs) == 0 {
+		return nil
+	}
+	sort.Slice(recs, func(i, j int) bool { return recs[i].seq < recs[j].seq })
+	leaks := make([]PoolLeak, len(recs))
+	for i, rec := range recs {
+		leaks[i] = PoolLeak{
+			Kind:     rec.kind,
+			Acquired: rec.acquired,
+			Stack:    formatStack(rec.pcs),
+		}
+	}
+	return leaks
+}
+
+// formatStack formats a stack in the style of a panic's stack trace.
+func formatStack(pcs []uintptr) string {
+	var sb strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		f, more := frames.Next()
+		fmt.Fprintf(&sb, "%s\n\t%s:%d\n", f.Function, f.File, f.Line)
+		if !more {
+			break
+		}
+	}
+	return sb.String()
+}
+
+func resetPoolLeaks() {
+	pools.mu.Lock()
+	pools.live = nil
+	pools.mu.Unlock()
+}
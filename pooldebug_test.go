@@ -0,0 +1,75 @@
+package capnp
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// testPoolLeaks returns the leaks whose buffers were taken by the
+// test, ignoring those of tests that run in parallel.
+func testPoolLeaks(name string) []PoolLeak {
+	var leaks []PoolLeak
+	for _, l := range PoolLeaks() {
+		if strings.Contains(l.Stack, name) {
+			leaks = append(leaks, l)
+		}
+	}
+	return leaks
+}
+
+func TestPoolLeaks(t *testing.T) {
+	if !PoolDebug {
+		if leaks := PoolLeaks(); leaks != nil {
+			t.Errorf("PoolLeaks() = %v without capnp_pooldebug; want nil", leaks)
+		}
+		t.Skip("build with -tags capnp_pooldebug to track pooled buffers")
+	}
+	ResetPoolLeaks()
+	defer ResetPoolLeaks()
+	bp := NewBufferPool(1024)
+	kept, _, err := bp.NewMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	released, _, err := bp.NewMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bp.Release(released)
+
+	leaks := testPoolLeaks("TestPoolLeaks")
+	if len(leaks) != 1 || leaks[0].Kind != "message" {
+		t.Fatalf("PoolLeaks() = %v; want one message", leaks)
+	}
+	if !strings.Contains(leaks[0].Stack, "BufferPool).NewMessage") {
+		t.Errorf("leak stack doesn't include NewMessage:\n%s", leaks[0].Stack)
+	}
+	bp.Release(kept)
+	if leaks := testPoolLeaks("TestPoolLeaks"); len(leaks) != 0 {
+		t.Errorf("after Release, PoolLeaks() = %v; want none", leaks)
+	}
+
+	// Buffered messages hold a pooled buffer until they are flushed.
+	msg, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewRootStruct(seg, ObjectSize{DataSize: 8}); err != nil {
+		t.Fatal(err)
+	}
+	enc := NewEncoder(new(bytes.Buffer), WithCoalesce(1<<20))
+	enc.SetBufferPool(bp)
+	if err := enc.Encode(msg); err != nil {
+		t.Fatal("Encode:", err)
+	}
+	if leaks := testPoolLeaks("TestPoolLeaks"); len(leaks) != 1 || leaks[0].Kind != "encoder buffer" {
+		t.Errorf("after Encode, PoolLeaks() = %v; want one encoder buffer", leaks)
+	}
+	if err := enc.Flush(); err != nil {
+		t.Fatal("Flush:", err)
+	}
+	if leaks := testPoolLeaks("TestPoolLeaks"); len(leaks) != 0 {
+		t.Errorf("after Flush, PoolLeaks() = %v; want none", leaks)
+	}
+}
@@ -0,0 +1,115 @@
+package capnp
+
+import "math/bits"
+
+// A FieldSet is a set of the fields of a struct, each identified by its
+// index in code order.  The zero value is an empty set.
+type FieldSet []uint64
+
+// Has reports whether the set contains the i'th field.
+func (fs FieldSet) Has(i uint16) bool {
+	w := int(i / 64)
+	return w < len(fs) && fs[w]&(1<<(i%64)) != 0
+}
+
+// Add adds the i'th field to the set.
+func (fs *FieldSet) Add(i uint16) {
+	w := int(i / 64)
+	for len(*fs) <= w {
+		*fs = append(*fs, 0)
+	}
+	(*fs)[w] |= 1 << (i % 64)
+}
+
+// Len returns the number of fields in the set.
+func (fs FieldSet) Len() int {
+	n := 0
+	for _, w := range fs {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Fields returns the indexes of the fields in the set in increasing
+// order.
+func (fs FieldSet) Fields() []uint16 {
+	var idx []uint16
+	for i, w := range fs {
+		for w != 0 {
+			b := bits.TrailingZeros64(w)
+			idx = append(idx, uint16(i*64+b))
+			w &^= 1 << uint(b)
+		}
+	}
+	return idx
+}
+
+// presenceKey identifies a struct or group in its message.  Groups
+// share their parent's address, so the node ID tells them apart.
+type presenceKey struct {
+	objectAddress
+	typeID uint64
+}
+
+func presenceKeyOf(s Struct, typeID uint64) presenceKey {
+	return presenceKey{objectAddress{s.seg.id, s.off}, typeID}
+}
+
+// MarkSet records in s's message that the i'th field in code order of
+// s, a struct or group with the given type ID, has been set.  Setters
+// generated by capnpc-go with the -presence flag call it, so that a
+// builder of a partial update can later send only the fields that it
+// set.  Presence is not part of the encoded message: it is lost on
+// Marshal and cleared by Message.Reset.  MarkSet does nothing if s is
+// invalid.
+func MarkSet(s Struct, typeID uint64, i uint16) {
+	if s.seg == nil || s.seg.msg == nil {
+		return
+	}
+	m := s.seg.msg
+	k := presenceKeyOf(s, typeID)
+	m.mu.Lock()
+	if m.presence == nil {
+		m.presence = make(map[presenceKey]FieldSet)
+	}
+	fs := m.presence[k]
+	fs.Add(i)
+	m.presence[k] = fs
+	m.mu.Unlock()
+}
+
+// IsSet reports whether MarkSet has recorded the i'th field of s.
+func IsSet(s Struct, typeID uint64, i uint16) bool {
+	if s.seg == nil || s.seg.msg == nil {
+		return false
+	}
+	m := s.seg.msg
+	m.mu.Lock()
+	ok := m.presence[presenceKeyOf(s, typeID)].Has(i)
+	m.mu.Unlock()
+	return ok
+}
+
+// SetFields returns a copy of the fields of s that MarkSet has
+// recorded.
+func SetFields(s Struct, typeID uint64) FieldSet {
+	if s.seg == nil || s.seg.msg == nil {
+		return nil
+	}
+	m := s.seg.msg
+	m.mu.Lock()
+	fs := append(FieldSet(nil), m.presence[presenceKeyOf(s, typeID)]...)
+	m.mu.Unlock()
+	return fs
+}
+
+// ClearSetFields forgets the fields of s that MarkSet has recorded.
+func ClearSetFields(s Struct, typeID uint64) {
+	if s.seg == nil || s.seg.msg == nil {
+		return
+	}
+	m := s.seg.msg
+	m.mu.Lock()
+	delete(m.presence, presenceKeyOf(s, typeID))
+	m.mu.Unlock()
+}
@@ -0,0 +1,78 @@
+package capnp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFieldSet(t *testing.T) {
+	var fs FieldSet
+	for _, i := range []uint16{70, 3, 0, 3} {
+		fs.Add(i)
+	}
+	if n := fs.Len(); n != 3 {
+		t.Errorf("Len() = %d; want 3", n)
+	}
+	if got, want := fs.Fields(), []uint16{0, 3, 70}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Fields() = %v; want %v", got, want)
+	}
+	for _, test := range []struct {
+		i    uint16
+		want bool
+	}{{0, true}, {1, false}, {3, true}, {70, true}, {200, false}} {
+		if got := fs.Has(test.i); got != test.want {
+			t.Errorf("Has(%d) = %t; want %t", test.i, got, test.want)
+		}
+	}
+}
+
+func TestMarkSet(t *testing.T) {
+	const (
+		typeID  = 0x1234
+		groupID = 0x5678
+	)
+	msg, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewStruct(seg, ObjectSize{DataSize: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewStruct(seg, ObjectSize{DataSize: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	MarkSet(s, typeID, 1)
+	MarkSet(s, groupID, 0)
+	if !IsSet(s, typeID, 1) {
+		t.Error("IsSet(s, 1) = false after MarkSet")
+	}
+	if IsSet(s, typeID, 0) {
+		t.Error("IsSet(s, 0) = true; the group's field was marked, not the struct's")
+	}
+	if IsSet(other, typeID, 1) {
+		t.Error("IsSet(other, 1) = true; only s was marked")
+	}
+	if got, want := SetFields(s, typeID).Fields(), []uint16{1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SetFields(s).Fields() = %v; want %v", got, want)
+	}
+
+	ClearSetFields(s, typeID)
+	if IsSet(s, typeID, 1) {
+		t.Error("IsSet(s, 1) = true after ClearSetFields")
+	}
+	if !IsSet(s, groupID, 0) {
+		t.Error("ClearSetFields on the struct cleared its group")
+	}
+	msg.Reset(SingleSegment(nil))
+	if IsSet(s, groupID, 0) {
+		t.Error("IsSet(s, group 0) = true after Reset")
+	}
+
+	// Invalid structs have no message to record in.
+	MarkSet(Struct{}, typeID, 0)
+	if IsSet(Struct{}, typeID, 0) {
+		t.Error("IsSet(Struct{}, 0) = true")
+	}
+}
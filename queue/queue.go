@@ -0,0 +1,280 @@
+// Package queue provides a bounded FIFO queue that is safe for many
+// producers and one consumer.
+//
+// The runtime uses it for calls that wait on an unresolved promise or an
+// embargo and for calls waiting to be delivered to a server (see
+// server.NewWithOptions), and it is exported so that transports and
+// other code can bound their own work in the same way.  What happens
+// when the queue is full is chosen by an Overflow policy, and Stats
+// reports how full the queue is and has been:
+//
+//	q := queue.New[*job](64, &queue.Options[*job]{
+//		Overflow: queue.Block,
+//		Timeout:  time.Second,
+//	})
+//	if err := q.Push(j); err != nil {
+//		// still full after a second
+//	}
+package queue // import "github.com/iguazio/go-capnproto2/queue"
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// Overflow is the policy for pushing onto a full queue.
+type Overflow int
+
+// Overflow policies.
+const (
+	// Reject fails the push with ErrFull.
+	Reject Overflow = iota
+
+	// DropOldest removes the element at the front of the queue to make
+	// room, passing it to Options.Drop.
+	DropOldest
+
+	// Block waits for room, for at most Options.Timeout if it is
+	// positive.
+	Block
+)
+
+// String returns the policy's name.
+func (o Overflow) String() string {
+	switch o {
+	case Reject:
+		return "reject"
+	case DropOldest:
+		return "drop-oldest"
+	case Block:
+		return "block"
+	default:
+		return fmt.Sprintf("overflow(%d)", int(o))
+	}
+}
+
+// Options control a queue's behavior when it is full.  A nil *Options
+// is the same as the zero value, which rejects pushes.
+type Options[T any] struct {
+	Overflow Overflow
+
+	// Timeout bounds how long Push waits under the Block policy.  Zero
+	// waits until there is room.
+	Timeout time.Duration
+
+	// Drop is called with each element that the DropOldest policy
+	// removes, while the queue's lock is held.  It must not use the
+	// queue.
+	Drop func(T)
+}
+
+// Stats is a snapshot of a queue's occupancy and counters.
+type Stats struct {
+	Len  int // elements in the queue
+	Cap  int // capacity of the queue
+	Peak int // most elements that the queue has held at once
+
+	Pushed   uint64 // elements added
+	Popped   uint64 // elements removed by Pop
+	Rejected uint64 // pushes that failed
+	Dropped  uint64 // elements removed by DropOldest
+}
+
+// A Reporter holds a queue and reports its statistics.  The clients
+// that queue calls, such as server clients and the clients that wait
+// for a promise to resolve or an embargo to be lifted, implement it.
+type Reporter interface {
+	QueueStats() Stats
+}
+
+// ErrFull is returned when pushing onto a full queue fails.
+var ErrFull = errors.New("queue: full")
+
+// A Queue is a bounded FIFO queue of T.  Its methods may be called from
+// many goroutines, but Pop, Front, and Recv are meant for a single
+// consumer: Front's result is only stable if no one else pops.
+type Queue[T any] struct {
+	overflow Overflow
+	timeout  time.Duration
+	drop     func(T)
+
+	mu      sync.Mutex
+	buf     []T
+	start   int
+	n       int
+	stats   Stats
+	changed chan struct{} // closed on the next push or pop; nil if no one is waiting
+}
+
+// New returns an empty queue that holds at most capacity elements.
+func New[T any](capacity int, opts *Options[T]) *Queue[T] {
+	if capacity <= 0 {
+		panic("queue: capacity must be positive")
+	}
+	q := &Queue[T]{buf: make([]T, capacity)}
+	if opts != nil {
+		q.overflow = opts.Overflow
+		q.timeout = opts.Timeout
+		q.drop = opts.Drop
+	}
+	q.stats.Cap = capacity
+	return q
+}
+
+// Len returns the number of elements in the queue.
+func (q *Queue[T]) Len() int {
+	q.mu.Lock()
+	n := q.n
+	q.mu.Unlock()
+	return n
+}
+
+// Cap returns the queue's capacity.
+func (q *Queue[T]) Cap() int {
+	return len(q.buf)
+}
+
+// Stats returns the queue's current statistics.
+func (q *Queue[T]) Stats() Stats {
+	q.mu.Lock()
+	s := q.stats
+	s.Len = q.n
+	q.mu.Unlock()
+	return s
+}
+
+// Push adds v to the back of the queue, applying the overflow policy if
+// the queue is full.
+func (q *Queue[T]) Push(v T) error {
+	if q.overflow != Block || q.timeout <= 0 {
+		return q.PushContext(context.Background(), v)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), q.timeout)
+	defer cancel()
+	err := q.PushContext(ctx, v)
+	if err == context.DeadlineExceeded {
+		return ErrFull
+	}
+	return err
+}
+
+// PushContext is like Push, but a Block policy also stops waiting when
+// ctx is done, returning ctx.Err().  The queue's Timeout is not applied.
+func (q *Queue[T]) PushContext(ctx context.Context, v T) error {
+	q.mu.Lock()
+	for q.n == len(q.buf) {
+		switch q.overflow {
+		case DropOldest:
+			old := q.popLocked()
+			q.stats.Dropped++
+			if q.drop != nil {
+				q.drop(old)
+			}
+		case Block:
+			changed := q.waitLocked()
+			q.mu.Unlock()
+			select {
+			case <-changed:
+			case <-ctx.Done():
+				q.mu.Lock()
+				q.stats.Rejected++
+				q.mu.Unlock()
+				return ctx.Err()
+			}
+			q.mu.Lock()
+		default:
+			q.stats.Rejected++
+			q.mu.Unlock()
+			return ErrFull
+		}
+	}
+	q.buf[(q.start+q.n)%len(q.buf)] = v
+	q.n++
+	q.stats.Pushed++
+	if q.n > q.stats.Peak {
+		q.stats.Peak = q.n
+	}
+	q.notifyLocked()
+	q.mu.Unlock()
+	return nil
+}
+
+// Front returns the element at the front of the queue without removing
+// it.  ok is false if the queue is empty.
+func (q *Queue[T]) Front() (v T, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.n == 0 {
+		return v, false
+	}
+	return q.buf[q.start], true
+}
+
+// Pop removes and returns the element at the front of the queue.  ok is
+// false if the queue is empty.
+func (q *Queue[T]) Pop() (v T, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.n == 0 {
+		return v, false
+	}
+	v = q.popLocked()
+	q.stats.Popped++
+	return v, true
+}
+
+// Recv removes and returns the element at the front of the queue,
+// waiting until there is one or ctx is done.
+func (q *Queue[T]) Recv(ctx context.Context) (T, error) {
+	q.mu.Lock()
+	for q.n == 0 {
+		changed := q.waitLocked()
+		q.mu.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+		q.mu.Lock()
+	}
+	v := q.popLocked()
+	q.stats.Popped++
+	q.mu.Unlock()
+	return v, nil
+}
+
+// popLocked removes the front element, clearing its slot so that the
+// queue doesn't keep it alive.  The caller must be holding onto q.mu and
+// the queue must not be empty.
+func (q *Queue[T]) popLocked() T {
+	var zero T
+	v := q.buf[q.start]
+	q.buf[q.start] = zero
+	q.start = (q.start + 1) % len(q.buf)
+	q.n--
+	q.notifyLocked()
+	return v
+}
+
+// waitLocked returns a channel that is closed when the queue next
+// changes.  The caller must be holding onto q.mu.
+func (q *Queue[T]) waitLocked() <-chan struct{} {
+	if q.changed == nil {
+		q.changed = make(chan struct{})
+	}
+	return q.changed
+}
+
+// notifyLocked wakes the goroutines waiting for the queue to change.
+// The caller must be holding onto q.mu.
+func (q *Queue[T]) notifyLocked() {
+	if q.changed != nil {
+		close(q.changed)
+		q.changed = nil
+	}
+}
@@ -0,0 +1,141 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestPushPop(t *testing.T) {
+	q := New[int](5, nil)
+	if n := q.Len(); n != 0 {
+		t.Errorf("New(5, nil).Len() = %d; want 0", n)
+	}
+	for i := 1; i <= 3; i++ {
+		if err := q.Push(i); err != nil {
+			t.Fatalf("q.Push(%d): %v", i, err)
+		}
+	}
+	if n := q.Len(); n != 3 {
+		t.Errorf("q.Len() after pushes = %d; want 3", n)
+	}
+	for want := 1; want <= 3; want++ {
+		if x, ok := q.Front(); !ok || x != want {
+			t.Errorf("q.Front() = %d, %t; want %d, true", x, ok, want)
+		}
+		if x, ok := q.Pop(); !ok || x != want {
+			t.Errorf("q.Pop() = %d, %t; want %d, true", x, ok, want)
+		}
+	}
+	if _, ok := q.Pop(); ok {
+		t.Error("q.Pop() on empty queue succeeded")
+	}
+	if _, ok := q.Front(); ok {
+		t.Error("q.Front() on empty queue succeeded")
+	}
+}
+
+func TestWrap(t *testing.T) {
+	q := New[int](5, nil)
+	q.Push(10)
+	q.Push(11)
+	q.Push(12)
+	q.Pop()
+	q.Pop()
+	for i := 13; i <= 16; i++ {
+		if err := q.Push(i); err != nil {
+			t.Fatalf("q.Push(%d): %v", i, err)
+		}
+	}
+	if n := q.Len(); n != 5 {
+		t.Errorf("q.Len() = %d; want 5", n)
+	}
+	for want := 12; want <= 16; want++ {
+		if x, _ := q.Pop(); x != want {
+			t.Errorf("q.Pop() = %d; want %d", x, want)
+		}
+	}
+}
+
+func TestPopClears(t *testing.T) {
+	q := New[*int](2, nil)
+	q.Push(new(int))
+	q.Pop()
+	for i, p := range q.buf {
+		if p != nil {
+			t.Errorf("q.buf[%d] not cleared after pop", i)
+		}
+	}
+}
+
+func TestReject(t *testing.T) {
+	q := New[int](2, nil)
+	q.Push(1)
+	q.Push(2)
+	if err := q.Push(3); err != ErrFull {
+		t.Errorf("q.Push on full queue = %v; want ErrFull", err)
+	}
+	s := q.Stats()
+	want := Stats{Len: 2, Cap: 2, Peak: 2, Pushed: 2, Rejected: 1}
+	if s != want {
+		t.Errorf("q.Stats() = %+v; want %+v", s, want)
+	}
+}
+
+func TestDropOldest(t *testing.T) {
+	var dropped []int
+	q := New[int](2, &Options[int]{
+		Overflow: DropOldest,
+		Drop:     func(x int) { dropped = append(dropped, x) },
+	})
+	for i := 1; i <= 4; i++ {
+		if err := q.Push(i); err != nil {
+			t.Fatalf("q.Push(%d): %v", i, err)
+		}
+	}
+	if len(dropped) != 2 || dropped[0] != 1 || dropped[1] != 2 {
+		t.Errorf("dropped = %v; want [1 2]", dropped)
+	}
+	if x, _ := q.Pop(); x != 3 {
+		t.Errorf("q.Pop() = %d; want 3", x)
+	}
+	if s := q.Stats(); s.Dropped != 2 || s.Pushed != 4 || s.Popped != 1 {
+		t.Errorf("q.Stats() = %+v; want Dropped: 2, Pushed: 4, Popped: 1", s)
+	}
+}
+
+func TestBlock(t *testing.T) {
+	q := New[int](1, &Options[int]{Overflow: Block, Timeout: 10 * time.Millisecond})
+	q.Push(1)
+	if err := q.Push(2); err != ErrFull {
+		t.Errorf("q.Push on full queue = %v; want ErrFull after timeout", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- q.PushContext(context.Background(), 3)
+	}()
+	if x, err := q.Recv(context.Background()); err != nil || x != 1 {
+		t.Errorf("q.Recv() = %d, %v; want 1, <nil>", x, err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("blocked push: %v", err)
+	}
+	if x, _ := q.Pop(); x != 3 {
+		t.Errorf("q.Pop() = %d; want 3", x)
+	}
+}
+
+func TestRecvWaits(t *testing.T) {
+	q := New[int](1, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Recv(ctx); err != context.DeadlineExceeded {
+		t.Errorf("q.Recv on empty queue = %v; want %v", err, context.DeadlineExceeded)
+	}
+	go q.Push(42)
+	if x, err := q.Recv(context.Background()); err != nil || x != 42 {
+		t.Errorf("q.Recv() = %d, %v; want 42, <nil>", x, err)
+	}
+}
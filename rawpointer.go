@@ -165,6 +165,25 @@ func (p rawPointer) withOffset(off pointerOffset) rawPointer {
 	return p&^0xfffffffc | rawPointer(uint32(off<<2))
 }
 
+// relocate returns the pointer p, which is stored at from, adjusted to
+// be stored at to in the same segment.  The result refers to the same
+// object as p.
+func (p rawPointer) relocate(from, to Address) rawPointer {
+	switch p.pointerType() {
+	case structPointer:
+		if p.structSize().isZero() {
+			// Null or zero-sized: the offset doesn't matter.
+			return p
+		}
+	case listPointer:
+	default:
+		// Far and capability pointers don't depend on their address.
+		return p
+	}
+	addr, _ := p.offset().resolve(from + Address(wordSize))
+	return p.withOffset(nearPointerOffset(to, addr))
+}
+
 // farAddress returns the address of the landing pad pointer.
 func (p rawPointer) farAddress() Address {
 	// Far pointer offset is 29 bits, starting after the low 3 bits.
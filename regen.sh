@@ -14,4 +14,5 @@ echo "** capnpc-go"
 echo "** schemas"
 (cd std/capnp; ./gen.sh compile)
 capnp compile -ogo std/go.capnp && mv std/go.capnp.go ./
+capnp compile -Istd -ogo std/callmeta.capnp && mv std/callmeta.capnp.go callmeta/
 go generate ./...
@@ -0,0 +1,81 @@
+package capnp
+
+import (
+	"errors"
+	"fmt"
+)
+
+// A tagged root is stored in an envelope struct that the message's
+// root pointer points to: the first data word is rootTagMagic, the
+// second is the type ID, and the only pointer is the actual root.
+// Readers that don't know about tagging see a struct of an unexpected
+// type, so only use tagging between programs that both do.
+const rootTagMagic = 0x746f6f726e706163 // "capnroot"
+
+var rootEnvelopeSize = ObjectSize{DataSize: 16, PointerCount: 1}
+
+// ErrUntaggedRoot is returned by ReadRootAs for a message whose root
+// was not set by SetRootAs.
+var ErrUntaggedRoot = errors.New("capnp: message root is not tagged with a type ID")
+
+// SetRootAs sets the message's root object to s, recording typeID as
+// its type so that ReadRootAs can check it.  typeID is usually the
+// _TypeID constant of s's generated type.
+func SetRootAs(m *Message, typeID uint64, s Struct) error {
+	seg, err := m.Segment(0)
+	if err != nil {
+		return err
+	}
+	env, err := NewStruct(seg, rootEnvelopeSize)
+	if err != nil {
+		return err
+	}
+	env.SetUint64(0, rootTagMagic)
+	env.SetUint64(8, typeID)
+	if err := env.SetPtr(0, s.ToPtr()); err != nil {
+		return err
+	}
+	return m.SetRootPtr(env.ToPtr())
+}
+
+// RootTypeID returns the type ID that the message's root was tagged
+// with by SetRootAs.  ok is false if the root is not tagged.
+func RootTypeID(m *Message) (typeID uint64, ok bool, err error) {
+	typeID, _, ok, err = taggedRoot(m)
+	return typeID, ok, err
+}
+
+// ReadRootAs returns the message's root struct after checking that it
+// was tagged with typeID by SetRootAs.  It returns ErrUntaggedRoot if
+// the root is not tagged and an error naming both types if the tag is
+// a different type, instead of letting the caller read the root as
+// the wrong struct type.
+func ReadRootAs(m *Message, typeID uint64) (Struct, error) {
+	id, root, ok, err := taggedRoot(m)
+	if err != nil {
+		return Struct{}, err
+	}
+	if !ok {
+		return Struct{}, ErrUntaggedRoot
+	}
+	if id != typeID {
+		return Struct{}, fmt.Errorf("capnp: message root has type %#x, want %#x", id, typeID)
+	}
+	return root, nil
+}
+
+func taggedRoot(m *Message) (typeID uint64, root Struct, ok bool, err error) {
+	p, err := m.RootPtr()
+	if err != nil {
+		return 0, Struct{}, false, err
+	}
+	env := p.Struct()
+	if env.size != rootEnvelopeSize || env.Uint64(0) != rootTagMagic {
+		return 0, Struct{}, false, nil
+	}
+	rp, err := env.Ptr(0)
+	if err != nil {
+		return 0, Struct{}, false, err
+	}
+	return env.Uint64(8), rp.Struct(), true, nil
+}
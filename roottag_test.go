@@ -0,0 +1,48 @@
+package capnp
+
+import "testing"
+
+func TestReadRootAs(t *testing.T) {
+	const fooID, barID = 0x8423424e9b01c0af, 0x9f4a6ad5bd2c2a43
+	msg, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewStruct(seg, ObjectSize{DataSize: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetUint64(0, 42)
+
+	if err := msg.SetRootPtr(s.ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadRootAs(msg, fooID); err != ErrUntaggedRoot {
+		t.Errorf("ReadRootAs on untagged root error = %v; want ErrUntaggedRoot", err)
+	}
+
+	if err := SetRootAs(msg, fooID, s); err != nil {
+		t.Fatal("SetRootAs:", err)
+	}
+	data, err := msg.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err = Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id, ok, err := RootTypeID(msg); err != nil || !ok || id != fooID {
+		t.Errorf("RootTypeID = %#x, %t, %v; want %#x, true, <nil>", id, ok, err, uint64(fooID))
+	}
+	root, err := ReadRootAs(msg, fooID)
+	if err != nil {
+		t.Fatal("ReadRootAs:", err)
+	}
+	if root.Uint64(0) != 42 {
+		t.Errorf("root.Uint64(0) = %d; want 42", root.Uint64(0))
+	}
+	if _, err := ReadRootAs(msg, barID); err == nil {
+		t.Error("ReadRootAs with the wrong type ID did not return an error")
+	}
+}
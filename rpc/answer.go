@@ -7,7 +7,7 @@ import (
 	"golang.org/x/net/context"
 	"github.com/iguazio/go-capnproto2"
 	"github.com/iguazio/go-capnproto2/internal/fulfiller"
-	"github.com/iguazio/go-capnproto2/internal/queue"
+	"github.com/iguazio/go-capnproto2/queue"
 	rpccapnp "github.com/iguazio/go-capnproto2/std/capnp/rpc"
 )
 
@@ -40,6 +40,10 @@ func (c *Conn) popAnswer(id answerID) *answer {
 	}
 	a := c.answers[id]
 	delete(c.answers, id)
+	if a != nil {
+		c.mem.release(a.charge)
+		a.charge = 0
+	}
 	return a
 }
 
@@ -49,11 +53,23 @@ type answer struct {
 	resultCaps []exportID
 	conn       *Conn
 	resolved   chan struct{}
+	charge     int64 // bytes counted against conn.mem
+
+	// sentElsewhere is set if the call asked for its results to be
+	// kept for a Return.takeFromOtherQuestion.
+	sentElsewhere bool
+
+	// ownedCaps is the capability table of results that were received
+	// from a peer in answer to a question asked for this answer alone.
+	// The question handed the answer its references to the
+	// capabilities, which are closed once the answer is finished.
+	ownedCaps []capnp.Client
 
 	mu    sync.RWMutex
 	obj   capnp.Ptr
 	err   error
 	done  bool
+	tail  *question // set instead of obj if resolved by a tail call
 	queue []pcall
 }
 
@@ -72,21 +88,29 @@ func (a *answer) fulfill(obj capnp.Ptr) error {
 	if err := a.conn.startWork(); err != nil {
 		firstErr = err
 		for i := range a.queue {
+			a.conn.mem.release(a.queue[i].charge)
 			a.queue[i].a.reject(err)
 		}
 		a.queue = nil
 	} else {
 		retmsg := newReturnMessage(nil, a.id)
 		ret, _ := retmsg.Return()
-		payload, _ := ret.NewResults()
-		payload.SetContentPtr(obj)
-		if payloadTab, err := a.conn.makeCapTable(ret.Segment()); err != nil {
-			firstErr = err
-		} else {
-			payload.SetCapTable(payloadTab)
+		if a.sentElsewhere {
+			ret.SetResultsSentElsewhere()
 			if err := a.conn.sendMessage(retmsg); err != nil {
 				firstErr = err
 			}
+		} else {
+			payload, _ := ret.NewResults()
+			payload.SetContentPtr(obj)
+			if payloadTab, err := a.conn.makeCapTable(ret.Segment()); err != nil {
+				firstErr = err
+			} else {
+				payload.SetCapTable(payloadTab)
+				if err := a.conn.sendMessage(retmsg); err != nil {
+					firstErr = err
+				}
+			}
 		}
 
 		queues, err := a.emptyQueue(obj)
@@ -124,6 +148,7 @@ func (a *answer) reject(err error) error {
 		firstErr = err
 	}
 	for i := range a.queue {
+		a.conn.mem.release(a.queue[i].charge)
 		if err := a.queue[i].a.reject(err); err != nil && firstErr == nil {
 			firstErr = err
 		}
@@ -143,6 +168,7 @@ func (a *answer) emptyQueue(obj capnp.Ptr) (map[capnp.CapabilityID][]qcall, erro
 	for i, pc := range a.queue {
 		c, err := capnp.TransformPtr(obj, pc.transform)
 		if err != nil {
+			a.conn.mem.release(pc.charge)
 			if err := pc.a.reject(err); err != nil && firstErr == nil {
 				firstErr = err
 			}
@@ -150,6 +176,7 @@ func (a *answer) emptyQueue(obj capnp.Ptr) (map[capnp.CapabilityID][]qcall, erro
 		}
 		ci := c.Interface()
 		if !ci.IsValid() {
+			a.conn.mem.release(pc.charge)
 			if err := pc.a.reject(capnp.ErrNullClient); err != nil && firstErr == nil {
 				firstErr = err
 			}
@@ -178,6 +205,10 @@ func (a *answer) queueCallLocked(call *capnp.Call, pc pcall) error {
 	if err != nil {
 		return err
 	}
+	pc.charge = callSize(pc.call)
+	if !a.conn.mem.acquire(pc.charge) {
+		return ErrOverloaded
+	}
 	a.queue = append(a.queue, pc)
 	return nil
 }
@@ -189,6 +220,10 @@ func (a *answer) queueDisembargo(transform []capnp.PipelineOp, id embargoID, tar
 	if !a.done {
 		return false, errDisembargoOngoingAnswer
 	}
+	if a.tail != nil {
+		// The capability is in the remote vat's own answer.
+		return false, nil
+	}
 	if a.err != nil {
 		return false, errDisembargoNonImport
 	}
@@ -223,9 +258,21 @@ func (a *answer) pipelineClient(transform []capnp.PipelineOp) capnp.Client {
 // joinAnswer resolves an RPC answer by waiting on a generic answer.
 // The caller must not be holding onto a.conn.mu.
 func joinAnswer(a *answer, ca capnp.Answer) {
+	if q, ok := ca.(*question); ok && q.tail && q.conn == a.conn {
+		a.conn.mu.Lock()
+		a.tailCall(q)
+		a.conn.mu.Unlock()
+		return
+	}
 	s, err := ca.Struct()
 	a.conn.mu.Lock()
 	if err == nil {
+		if q, ok := ca.(*question); ok && q.resultsFor == a && q.ownsCaps {
+			// The question was sent for this answer alone, so the
+			// references it holds are the answer's to close.
+			ctab := s.Segment().Message().CapTable
+			a.ownedCaps = append([]capnp.Client(nil), ctab...)
+		}
 		a.fulfill(s.ToPtr())
 	} else {
 		a.reject(err)
@@ -233,6 +280,29 @@ func joinAnswer(a *answer, ca capnp.Answer) {
 	a.conn.mu.Unlock()
 }
 
+// takeOwnedCaps returns the capabilities in the answer's results that
+// the answer holds references to, which the caller must close without
+// holding onto a.conn.mu.  Capabilities that pipelined calls were queued
+// on are left to their queues.  The caller must be holding onto
+// a.conn.mu.
+func (a *answer) takeOwnedCaps() []capnp.Client {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	owned := a.ownedCaps
+	a.ownedCaps = nil
+	if len(owned) == 0 {
+		return nil
+	}
+	ctab := a.obj.Segment().Message().CapTable
+	caps := owned[:0]
+	for i, c := range owned {
+		if c != nil && i < len(ctab) && ctab[i] == c {
+			caps = append(caps, c)
+		}
+	}
+	return caps
+}
+
 // joinFulfiller resolves a fulfiller by waiting on a generic answer.
 func joinFulfiller(f *fulfiller.Fulfiller, ca capnp.Answer) {
 	s, err := ca.Struct()
@@ -247,18 +317,20 @@ type queueClient struct {
 	client capnp.Client
 	conn   *Conn
 
-	mu    sync.RWMutex
-	q     queue.Queue
-	calls qcallList
+	mu sync.RWMutex
+	q  *queue.Queue[qcall]
 }
 
-func newQueueClient(c *Conn, client capnp.Client, queue []qcall) *queueClient {
+func newQueueClient(c *Conn, client capnp.Client, calls []qcall) *queueClient {
 	qc := &queueClient{
 		client: client,
 		conn:   c,
-		calls:  make(qcallList, callQueueSize),
+		q:      queue.New[qcall](callQueueSize, nil),
+	}
+	// An answer queues at most callQueueSize calls, so these all fit.
+	for _, c := range calls {
+		qc.q.Push(c)
 	}
-	qc.q.Init(qc.calls, copy(qc.calls, queue))
 	go qc.flushQueue()
 	return qc
 }
@@ -269,41 +341,36 @@ func (qc *queueClient) pushCallLocked(cl *capnp.Call) capnp.Answer {
 	if err != nil {
 		return capnp.ErrorAnswer(err)
 	}
-	i := qc.q.Push()
-	if i == -1 {
+	charge := callSize(cl)
+	if !qc.conn.mem.acquire(charge) {
+		return capnp.ErrorAnswer(ErrOverloaded)
+	}
+	if qc.q.Push(qcall{call: cl, f: f, charge: charge}) != nil {
+		qc.conn.mem.release(charge)
 		return capnp.ErrorAnswer(errQueueFull)
 	}
-	qc.calls[i] = qcall{call: cl, f: f}
 	return f
 }
 
 func (qc *queueClient) pushEmbargoLocked(id embargoID, tgt rpccapnp.MessageTarget) error {
-	i := qc.q.Push()
-	if i == -1 {
+	if qc.q.Push(qcall{embargoID: id, embargoTarget: tgt}) != nil {
 		return errQueueFull
 	}
-	qc.calls[i] = qcall{embargoID: id, embargoTarget: tgt}
 	return nil
 }
 
 // flushQueue is run in its own goroutine.
 func (qc *queueClient) flushQueue() {
-	var c qcall
 	qc.mu.RLock()
-	if i := qc.q.Front(); i != -1 {
-		c = qc.calls[i]
-	}
+	c, _ := qc.q.Front()
 	qc.mu.RUnlock()
 	for c.which() != qcallInvalid {
 		qc.handle(&c)
+		qc.conn.mem.release(c.charge)
 
 		qc.mu.Lock()
 		qc.q.Pop()
-		if i := qc.q.Front(); i != -1 {
-			c = qc.calls[i]
-		} else {
-			c = qcall{}
-		}
+		c, _ = qc.q.Front()
 		qc.mu.Unlock()
 	}
 }
@@ -320,6 +387,7 @@ func (qc *queueClient) handle(c *qcall) {
 		msg := newDisembargoMessage(nil, rpccapnp.Disembargo_context_Which_receiverLoopback, c.embargoID)
 		d, _ := msg.Disembargo()
 		d.SetTarget(c.embargoTarget)
+		qc.conn.embargoEvent(EmbargoEcho, c.embargoID)
 		qc.conn.sendMessage(msg)
 	}
 }
@@ -360,6 +428,18 @@ func (qc *queueClient) tryQueue(cl *capnp.Call) capnp.Answer {
 	return ans
 }
 
+// ClientState returns the state of the client that the queued calls
+// are delivered to.
+func (qc *queueClient) ClientState() (resolved bool, brand interface{}, remote bool) {
+	return capnp.ClientState(qc.client)
+}
+
+// QueueStats returns the statistics of the queue of calls waiting for
+// the answer's capability to be delivered to.
+func (qc *queueClient) QueueStats() queue.Stats {
+	return qc.q.Stats()
+}
+
 func (qc *queueClient) Close() error {
 	qc.conn.mu.Lock()
 	if err := qc.conn.startWork(); err != nil {
@@ -381,8 +461,8 @@ func (qc *queueClient) Close() error {
 func (qc *queueClient) rejectQueue() error {
 	var firstErr error
 	qc.mu.Lock()
-	for ; qc.q.Len() > 0; qc.q.Pop() {
-		c := qc.calls[qc.q.Front()]
+	for c, ok := qc.q.Pop(); ok; c, ok = qc.q.Pop() {
+		qc.conn.mem.release(c.charge)
 		switch c.which() {
 		case qcallRemoteCall:
 			if err := c.a.reject(errQueueCallCancel); err != nil && firstErr == nil {
@@ -394,6 +474,7 @@ func (qc *queueClient) rejectQueue() error {
 			m := newDisembargoMessage(nil, rpccapnp.Disembargo_context_Which_receiverLoopback, c.embargoID)
 			d, _ := m.Disembargo()
 			d.SetTarget(c.embargoTarget)
+			qc.conn.embargoEvent(EmbargoEcho, c.embargoID)
 			if err := qc.conn.sendMessage(m); err != nil && firstErr == nil {
 				firstErr = err
 			}
@@ -412,9 +493,10 @@ type pcall struct {
 // qcall is a queued call.
 type qcall struct {
 	// Calls
-	a    *answer              // non-nil if remote call
-	f    *fulfiller.Fulfiller // non-nil if local call
-	call *capnp.Call
+	a      *answer              // non-nil if remote call
+	f      *fulfiller.Fulfiller // non-nil if local call
+	call   *capnp.Call
+	charge int64 // bytes counted against the connection's budget
 
 	// Disembargo
 	embargoID     embargoID
@@ -442,16 +524,6 @@ func (c *qcall) which() int {
 	}
 }
 
-type qcallList []qcall
-
-func (ql qcallList) Len() int {
-	return len(ql)
-}
-
-func (ql qcallList) Clear(i int) {
-	ql[i] = qcall{}
-}
-
 // A localAnswerClient is used to provide a pipelined client of an answer.
 type localAnswerClient struct {
 	a         *answer
@@ -460,6 +532,10 @@ type localAnswerClient struct {
 
 func (lac *localAnswerClient) Call(call *capnp.Call) capnp.Answer {
 	lac.a.mu.Lock()
+	if q := lac.a.tail; q != nil {
+		lac.a.mu.Unlock()
+		return q.PipelineCall(lac.transform, call)
+	}
 	if lac.a.done {
 		obj, err := lac.a.obj, lac.a.err
 		lac.a.mu.Unlock()
@@ -477,11 +553,28 @@ func (lac *localAnswerClient) Call(call *capnp.Call) capnp.Answer {
 	return f
 }
 
-func (lac *localAnswerClient) Close() error {
+// ClientState reports the client as unresolved until the answer
+// returns, and then returns the state of the resolution.  An answer
+// whose results come from a tail call has the state of the tail call's
+// capability.
+func (lac *localAnswerClient) ClientState() (resolved bool, brand interface{}, remote bool) {
 	lac.a.mu.RLock()
-	obj, err, done := lac.a.obj, lac.a.err, lac.a.done
+	obj, err, done, tail := lac.a.obj, lac.a.err, lac.a.done, lac.a.tail
 	lac.a.mu.RUnlock()
+	if tail != nil {
+		return tail.PipelineClientState(lac.transform)
+	}
 	if !done {
+		return false, nil, false
+	}
+	return capnp.ClientState(clientFromResolution(lac.transform, obj, err))
+}
+
+func (lac *localAnswerClient) Close() error {
+	lac.a.mu.RLock()
+	obj, err, done, tail := lac.a.obj, lac.a.err, lac.a.done, lac.a.tail
+	lac.a.mu.RUnlock()
+	if !done || tail != nil {
 		return nil
 	}
 	client := clientFromResolution(lac.transform, obj, err)
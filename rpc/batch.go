@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"sync"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/fulfiller"
+)
+
+// A Batch collects calls to a capability and sends them together.  If
+// the capability is imported from a connection, the calls are queued as
+// one group, and a transport that implements BatchTransport sends them
+// in a single write instead of one write per call.  Each call is still
+// its own Call message and gets its own answer, so the remote vat does
+// not need to know about batching.
+//
+// Batch implements capnp.Client, so generated client types can queue
+// calls on it:
+//
+//	b := rpc.NewBatch(client)
+//	p1 := foo.Foo{Client: b}.Bar(ctx, params1)
+//	p2 := foo.Foo{Client: b}.Bar(ctx, params2)
+//	err := b.Flush(ctx)
+//
+// The answers returned by the Batch resolve only after Flush.  Calls
+// that are pipelined on them are made once they resolve.
+//
+// Batch is experimental: it is intended for chatty workloads where the
+// overhead of writing each call dominates.
+type Batch struct {
+	client capnp.Client
+
+	mu    sync.Mutex
+	calls []batchCall
+}
+
+type batchCall struct {
+	call *capnp.Call
+	f    *fulfiller.Fulfiller
+}
+
+// NewBatch returns an empty batch of calls to client.  The batch does
+// not take ownership of client.
+func NewBatch(client capnp.Client) *Batch {
+	return &Batch{client: client}
+}
+
+// Call queues a call to be sent on the next Flush.  The call's
+// parameters are placed immediately.
+func (b *Batch) Call(call *capnp.Call) capnp.Answer {
+	cl, err := call.Copy(nil)
+	if err != nil {
+		return capnp.ErrorAnswer(err)
+	}
+	f := new(fulfiller.Fulfiller)
+	b.mu.Lock()
+	b.calls = append(b.calls, batchCall{call: cl, f: f})
+	b.mu.Unlock()
+	return f
+}
+
+// ClientState returns the state of the batch's client.
+func (b *Batch) ClientState() (resolved bool, brand interface{}, remote bool) {
+	return capnp.ClientState(b.client)
+}
+
+// Len returns the number of calls waiting for Flush.
+func (b *Batch) Len() int {
+	b.mu.Lock()
+	n := len(b.calls)
+	b.mu.Unlock()
+	return n
+}
+
+// Flush sends the queued calls.  If the calls can't be queued on the
+// connection before ctx is done, their answers fail with the returned
+// error.  Calls to a capability that is not imported from a connection
+// (or whose resolution is still pending) are made one at a time.
+func (b *Batch) Flush(ctx context.Context) error {
+	b.mu.Lock()
+	calls := b.calls
+	b.calls = nil
+	b.mu.Unlock()
+	if len(calls) == 0 {
+		return nil
+	}
+
+	ic := isImport(b.client)
+	if ic == nil {
+		for _, c := range calls {
+			go joinFulfiller(c.f, b.client.Call(c.call))
+		}
+		return nil
+	}
+	cls := make([]*capnp.Call, len(calls))
+	for i := range calls {
+		cls[i] = calls[i].call
+	}
+	answers, err := ic.batchCall(ctx, cls)
+	if err != nil {
+		for _, c := range calls {
+			c.f.Reject(err)
+		}
+		return err
+	}
+	for i, c := range calls {
+		go joinFulfiller(c.f, answers[i])
+	}
+	return nil
+}
+
+// Close flushes any queued calls.  It does not close the batch's client.
+func (b *Batch) Close() error {
+	return b.Flush(context.Background())
+}
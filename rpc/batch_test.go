@@ -0,0 +1,134 @@
+package rpc_test
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/rpc"
+	"github.com/iguazio/go-capnproto2/rpc/internal/testcapnp"
+	rpccapnp "github.com/iguazio/go-capnproto2/std/capnp/rpc"
+)
+
+func TestBatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p1, p2 := net.Pipe()
+	w := &writeRecorder{Conn: p2}
+	log := testLogger{t}
+	d := rpc.NewConn(rpc.StreamTransport(p1), rpc.ConnLog(log), rpc.BootstrapFunc(bootstrapPingPong))
+	defer d.Wait()
+	c := rpc.NewConn(rpc.StreamTransport(w), rpc.ConnLog(log))
+	defer c.Close()
+	client := testcapnp.PingPong{Client: c.Bootstrap(ctx)}
+	// Wait for the bootstrap capability to resolve so that the batch
+	// goes directly to the import.
+	if _, err := client.EchoNum(ctx, nil).Struct(); err != nil {
+		t.Fatal("EchoNum:", err)
+	}
+
+	b := rpc.NewBatch(client.Client)
+	bc := testcapnp.PingPong{Client: b}
+	var promises []testcapnp.PingPong_echoNum_Results_Promise
+	for i := int32(1); i <= 3; i++ {
+		n := i
+		promises = append(promises, bc.EchoNum(ctx, func(p testcapnp.PingPong_echoNum_Params) error {
+			p.SetN(n)
+			return nil
+		}))
+	}
+	if n := b.Len(); n != 3 {
+		t.Errorf("b.Len() = %d; want 3", n)
+	}
+	if err := b.Flush(ctx); err != nil {
+		t.Fatal("Flush:", err)
+	}
+	for i, p := range promises {
+		r, err := p.Struct()
+		if err != nil {
+			t.Errorf("EchoNum(%d): %v", i+1, err)
+			continue
+		}
+		if r.N() != int32(i+1) {
+			t.Errorf("EchoNum(%d) = %d", i+1, r.N())
+		}
+	}
+
+	// The three calls should have gone out in one write.
+	found := false
+	for _, buf := range w.writes() {
+		if countCalls(t, buf) == 3 {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("no single write contained all three batched calls")
+	}
+}
+
+func TestBatch_Local(t *testing.T) {
+	ctx := context.Background()
+	b := rpc.NewBatch(testcapnp.PingPong_ServerToClient(pingPongServer{}).Client)
+	p := testcapnp.PingPong{Client: b}.EchoNum(ctx, func(p testcapnp.PingPong_echoNum_Params) error {
+		p.SetN(7)
+		return nil
+	})
+	if err := b.Close(); err != nil {
+		t.Fatal("Close:", err)
+	}
+	r, err := p.Struct()
+	if err != nil {
+		t.Fatal("EchoNum:", err)
+	}
+	if r.N() != 7 {
+		t.Errorf("EchoNum(7) = %d", r.N())
+	}
+}
+
+// countCalls returns the number of call messages in a stream of
+// encoded messages.
+func countCalls(t *testing.T, buf []byte) int {
+	dec := capnp.NewDecoder(bytes.NewReader(buf))
+	n := 0
+	for {
+		msg, err := dec.Decode()
+		if err == io.EOF {
+			return n
+		}
+		if err != nil {
+			t.Fatal("Decode write:", err)
+		}
+		m, err := rpccapnp.ReadRootMessage(msg)
+		if err != nil {
+			t.Fatal("ReadRootMessage:", err)
+		}
+		if m.Which() == rpccapnp.Message_Which_call {
+			n++
+		}
+	}
+}
+
+// writeRecorder is a net.Conn that records a copy of each write.
+type writeRecorder struct {
+	net.Conn
+
+	mu  sync.Mutex
+	buf [][]byte
+}
+
+func (w *writeRecorder) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	w.buf = append(w.buf, append([]byte(nil), p...))
+	w.mu.Unlock()
+	return w.Conn.Write(p)
+}
+
+func (w *writeRecorder) writes() [][]byte {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf
+}
@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"sync/atomic"
+
+	"github.com/iguazio/go-capnproto2"
+)
+
+// MemoryBudget limits the memory that the connection holds on behalf of
+// the remote vat to about n bytes: the messages of calls that have not
+// finished, the parameters of calls queued on promises, and the export
+// table.  A call that arrives while the budget is spent is rejected
+// with an overloaded exception, and so is a call that would be queued,
+// so that one client of a vat serving many cannot exhaust its memory.
+// Exports are counted but never refused, since they are created by
+// returns that have already been computed.  By default, or if n <= 0,
+// usage is tracked but not limited.
+func MemoryBudget(n int64) ConnOption {
+	return ConnOption{func(c *connParams) {
+		c.memLimit = n
+	}}
+}
+
+// MemoryInUse returns the number of bytes counted against the
+// connection's MemoryBudget.
+func (c *Conn) MemoryInUse() int64 {
+	return atomic.LoadInt64(&c.mem.used)
+}
+
+// exportCost is the memory charged for an entry in the export table.
+const exportCost = 64
+
+// A memBudget counts bytes against an optional limit.  It is safe to
+// use from multiple goroutines.
+type memBudget struct {
+	used  int64 // accessed atomically
+	limit int64 // zero or negative means no limit
+}
+
+// acquire adds n bytes to the count.  It returns false and leaves the
+// count unchanged if that would exceed the limit.
+func (b *memBudget) acquire(n int64) bool {
+	used := atomic.AddInt64(&b.used, n)
+	if b.limit > 0 && used > b.limit {
+		atomic.AddInt64(&b.used, -n)
+		return false
+	}
+	return true
+}
+
+// add adds n bytes to the count, even if that exceeds the limit.
+func (b *memBudget) add(n int64) {
+	atomic.AddInt64(&b.used, n)
+}
+
+// release subtracts n bytes acquired or added before.
+func (b *memBudget) release(n int64) {
+	if n != 0 {
+		atomic.AddInt64(&b.used, -n)
+	}
+}
+
+// messageSize returns the combined size of msg's segments.
+func messageSize(msg *capnp.Message) int64 {
+	var n int64
+	for i := int64(0); i < msg.NumSegments(); i++ {
+		s, err := msg.Segment(capnp.SegmentID(i))
+		if err != nil {
+			break
+		}
+		n += int64(len(s.Data()))
+	}
+	return n
+}
+
+// callSize returns the memory held by a queued copy of cl.
+func callSize(cl *capnp.Call) int64 {
+	if s := cl.Params.Segment(); s != nil {
+		return messageSize(s.Message())
+	}
+	return 0
+}
@@ -0,0 +1,78 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/rpc"
+	rpccapnp "github.com/iguazio/go-capnproto2/std/capnp/rpc"
+)
+
+func TestMemoryBudget(t *testing.T) {
+	main := stubClient(func(ctx context.Context, params capnp.Struct) (capnp.Struct, error) {
+		t.Error("call was delivered despite the spent budget")
+		return capnp.Struct{}, nil
+	})
+	// The budget covers the bootstrap export, but not a call message.
+	conn, p := newUnpairedConn(t, rpc.MainInterface(main), rpc.MemoryBudget(100))
+	defer conn.Close()
+	defer p.Close()
+	importID := sendBootstrapAndFinish(t, p)
+	if n := conn.MemoryInUse(); n <= 0 {
+		t.Errorf("MemoryInUse() = %d after bootstrap; want > 0 for the export", n)
+	}
+	inUse := conn.MemoryInUse()
+
+	const questionID = 5
+	err := sendMessage(context.TODO(), p, func(msg rpccapnp.Message) error {
+		call, err := msg.NewCall()
+		if err != nil {
+			return err
+		}
+		call.SetQuestionId(questionID)
+		call.SetInterfaceId(interfaceID)
+		call.SetMethodId(methodID)
+		target, err := call.NewTarget()
+		if err != nil {
+			return err
+		}
+		target.SetImportedCap(importID)
+		payload, err := call.NewParams()
+		if err != nil {
+			return err
+		}
+		content, err := capnp.NewStruct(msg.Segment(), capnp.ObjectSize{DataSize: 64})
+		if err != nil {
+			return err
+		}
+		return payload.SetContent(content)
+	})
+	if err != nil {
+		t.Fatal("Call message failed:", err)
+	}
+	retmsg, err := p.RecvMessage(context.TODO())
+	if err != nil {
+		t.Fatal("Read Call return failed:", err)
+	}
+	if retmsg.Which() != rpccapnp.Message_Which_return {
+		t.Fatalf("Return message is %v; want %v", retmsg.Which(), rpccapnp.Message_Which_return)
+	}
+	ret, err := retmsg.Return()
+	if err != nil {
+		t.Fatal("return error:", err)
+	}
+	if ret.Which() != rpccapnp.Return_Which_exception {
+		t.Fatalf("Return.Which() = %v; want %v", ret.Which(), rpccapnp.Return_Which_exception)
+	}
+	exc, err := ret.Exception()
+	if err != nil {
+		t.Fatal("return.exception error:", err)
+	}
+	if exc.Type() != rpccapnp.Exception_Type_overloaded {
+		t.Errorf("Return.exception.type = %v; want %v", exc.Type(), rpccapnp.Exception_Type_overloaded)
+	}
+	if n := conn.MemoryInUse(); n != inUse {
+		t.Errorf("MemoryInUse() = %d after rejected call; want %d", n, inUse)
+	}
+}
@@ -0,0 +1,84 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/rpc"
+	rpccapnp "github.com/iguazio/go-capnproto2/std/capnp/rpc"
+)
+
+func TestClientStateImport(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conn, p := newUnpairedConn(t)
+	defer conn.Close()
+	defer p.Close()
+	client, bootstrapID := readBootstrap(t, ctx, conn, p)
+
+	if resolved, brand, remote := capnp.ClientState(client); resolved || brand != nil || !remote {
+		t.Errorf("before return: ClientState(client) = %t, %v, %t; want false, <nil>, true", resolved, brand, remote)
+	}
+	if err := sendBootstrapReturn(ctx, p, bootstrapID, false); err != nil {
+		t.Fatal("sending bootstrap return:", err)
+	}
+	if err := recvFinish(ctx, p, bootstrapID); err != nil {
+		t.Fatal(err)
+	}
+	if resolved, brand, remote := capnp.ClientState(client); !resolved || brand != conn || !remote {
+		t.Errorf("after return: ClientState(client) = %t, %v, %t; want true, conn, true", resolved, brand, remote)
+	}
+}
+
+func TestClientStatePromiseResolvedToLocal(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	main := &namedClient{}
+	conn, p := newUnpairedConn(t, rpc.MainInterface(main))
+	defer conn.Close()
+	defer p.Close()
+	client := bootstrapAndFulfill(t, ctx, conn, p, true)
+	importID := sendBootstrapAndFinish(t, p)
+
+	if resolved, brand, remote := capnp.ClientState(client); resolved || brand != conn || !remote {
+		t.Errorf("before resolve: ClientState(client) = %t, %v, %t; want false, conn, true", resolved, brand, remote)
+	}
+	err := sendMessage(ctx, p, func(msg rpccapnp.Message) error {
+		res, err := msg.NewResolve()
+		if err != nil {
+			return err
+		}
+		res.SetPromiseId(bootstrapExportID)
+		desc, err := res.NewCap()
+		if err != nil {
+			return err
+		}
+		desc.SetReceiverHosted(importID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal("sending resolve:", err)
+	}
+	msg, err := p.RecvMessage(ctx)
+	if err != nil {
+		t.Fatal("reading disembargo:", err)
+	}
+	if msg.Which() != rpccapnp.Message_Which_disembargo {
+		t.Fatalf("conn sent %v; want disembargo", msg.Which())
+	}
+	if resolved, brand, remote := capnp.ClientState(client); !resolved || brand != main || remote {
+		t.Errorf("after resolve: ClientState(client) = %t, %v, %t; want true, main, false", resolved, brand, remote)
+	}
+}
+
+// namedClient is a comparable client that is never called.
+type namedClient struct{}
+
+func (nc *namedClient) Call(*capnp.Call) capnp.Answer {
+	return capnp.ErrorAnswer(errNotImplemented)
+}
+
+func (nc *namedClient) Close() error {
+	return nil
+}
@@ -0,0 +1,272 @@
+package rpc_test
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/rpc"
+	"github.com/iguazio/go-capnproto2/rpc/internal/pipetransport"
+	"github.com/iguazio/go-capnproto2/rpc/internal/testcapnp"
+	rpccapnp "github.com/iguazio/go-capnproto2/std/capnp/rpc"
+)
+
+// TestForwardCompatibility sends a connection messages that a newer or
+// more complete implementation of the protocol may send, like message
+// types, call targets, and capability descriptors that this package
+// doesn't know, and checks its replies.  The messages are built with
+// this package's message builders from the protocol schema; they are not
+// captured from another implementation.
+func TestForwardCompatibility(t *testing.T) {
+	tests := []struct {
+		name string
+		msgs []func(rpccapnp.Message) error
+		want []string // sorted
+	}{
+		{
+			name: "echo",
+			msgs: []func(rpccapnp.Message) error{
+				compatBootstrap(0),
+				compatEchoNum(1, 42, nil),
+				compatFinish(0),
+				compatFinish(1),
+			},
+			want: []string{"return 0 results", "return 1 results"},
+		},
+		{
+			name: "future message",
+			msgs: []func(rpccapnp.Message) error{
+				func(m rpccapnp.Message) error {
+					// A message type from a future version of the protocol.
+					m.Struct.SetUint16(0, 99)
+					return nil
+				},
+			},
+			want: []string{"unimplemented Message_Which(99)"},
+		},
+		{
+			name: "resolve",
+			msgs: []func(rpccapnp.Message) error{
+				func(m rpccapnp.Message) error {
+					r, err := m.NewResolve()
+					if err != nil {
+						return err
+					}
+					r.SetPromiseId(3)
+					return r.SetException(newCompatException(m.Segment()))
+				},
+			},
+		},
+		{
+			name: "future target",
+			msgs: []func(rpccapnp.Message) error{
+				compatBootstrap(0),
+				compatEchoNum(1, 1, func(call rpccapnp.Call) error {
+					t, err := call.Target()
+					if err != nil {
+						return err
+					}
+					t.Struct.SetUint16(4, 7)
+					return nil
+				}),
+				compatFinish(0),
+			},
+			want: []string{"return 0 results", "unimplemented call"},
+		},
+		{
+			name: "future cap descriptor",
+			msgs: []func(rpccapnp.Message) error{
+				compatBootstrap(0),
+				compatEchoNum(1, 1, func(call rpccapnp.Call) error {
+					params, err := call.Params()
+					if err != nil {
+						return err
+					}
+					ctab, err := rpccapnp.NewCapDescriptor_List(call.Segment(), 1)
+					if err != nil {
+						return err
+					}
+					ctab.At(0).Struct.SetUint16(0, 42)
+					return params.SetCapTable(ctab)
+				}),
+				compatFinish(0),
+			},
+			want: []string{"return 0 results", "unimplemented call"},
+		},
+		{
+			name: "unimplemented echo",
+			msgs: []func(rpccapnp.Message) error{
+				func(m rpccapnp.Message) error {
+					um, err := m.NewUnimplemented()
+					if err != nil {
+						return err
+					}
+					_, err = um.NewRelease()
+					return err
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		msgs, err := compatMessages(test.msgs...)
+		if err != nil {
+			t.Errorf("%s: building messages: %v", test.name, err)
+			continue
+		}
+		got, err := sendMessages(t, msgs, len(test.want))
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if strings.Join(got, "\n") != strings.Join(test.want, "\n") {
+			t.Errorf("%s: replies =\n\t%s\nwant\n\t%s", test.name, strings.Join(got, "\n\t"), strings.Join(test.want, "\n\t"))
+		}
+	}
+}
+
+// sendMessages sends msgs to a new connection that bootstraps a
+// PingPong capability and returns the sorted descriptions of its
+// replies.  It waits for n replies, plus any more that arrive shortly
+// after.
+func sendMessages(t *testing.T, msgs []rpccapnp.Message, n int) ([]string, error) {
+	p, q := pipetransport.New()
+	conn := rpc.NewConn(p, rpc.ConnLog(testLogger{t}), rpc.BootstrapFunc(bootstrapPingPong))
+	defer conn.Close()
+	defer q.Close()
+
+	ctx := context.Background()
+	for _, m := range msgs {
+		if err := q.SendMessage(ctx, m); err != nil {
+			return nil, err
+		}
+	}
+	var replies []string
+	for {
+		timeout := 50 * time.Millisecond
+		if len(replies) < n {
+			timeout = 5 * time.Second
+		}
+		rctx, cancel := context.WithTimeout(ctx, timeout)
+		m, err := q.RecvMessage(rctx)
+		cancel()
+		if err == context.DeadlineExceeded {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		replies = append(replies, describeReply(m))
+	}
+	sort.Strings(replies)
+	return replies, nil
+}
+
+func describeReply(m rpccapnp.Message) string {
+	switch m.Which() {
+	case rpccapnp.Message_Which_return:
+		ret, _ := m.Return()
+		return fmt.Sprintf("return %d %v", ret.AnswerId(), ret.Which())
+	case rpccapnp.Message_Which_finish:
+		fin, _ := m.Finish()
+		return fmt.Sprintf("finish %d", fin.QuestionId())
+	case rpccapnp.Message_Which_unimplemented:
+		um, _ := m.Unimplemented()
+		return fmt.Sprintf("unimplemented %v", um.Which())
+	case rpccapnp.Message_Which_abort:
+		a, _ := m.Abort()
+		r, _ := a.Reason()
+		return fmt.Sprintf("abort %q", r)
+	default:
+		return m.Which().String()
+	}
+}
+
+func compatMessages(builders ...func(rpccapnp.Message) error) ([]rpccapnp.Message, error) {
+	msgs := make([]rpccapnp.Message, len(builders))
+	for i, b := range builders {
+		_, s, err := capnp.NewMessage(capnp.SingleSegment(nil))
+		if err != nil {
+			return nil, err
+		}
+		msgs[i], err = rpccapnp.NewRootMessage(s)
+		if err != nil {
+			return nil, err
+		}
+		if err := b(msgs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return msgs, nil
+}
+
+func compatBootstrap(id uint32) func(rpccapnp.Message) error {
+	return func(m rpccapnp.Message) error {
+		b, err := m.NewBootstrap()
+		if err != nil {
+			return err
+		}
+		b.SetQuestionId(id)
+		return nil
+	}
+}
+
+// compatEchoNum builds a call to PingPong.echoNum on the result of the
+// bootstrap question 0.  If f is not nil, it is called to modify the call.
+func compatEchoNum(id uint32, n int32, f func(rpccapnp.Call) error) func(rpccapnp.Message) error {
+	return func(m rpccapnp.Message) error {
+		call, err := m.NewCall()
+		if err != nil {
+			return err
+		}
+		call.SetQuestionId(id)
+		call.SetInterfaceId(testcapnp.PingPong_TypeID)
+		call.SetMethodId(0)
+		target, err := call.NewTarget()
+		if err != nil {
+			return err
+		}
+		pa, err := target.NewPromisedAnswer()
+		if err != nil {
+			return err
+		}
+		pa.SetQuestionId(0)
+		payload, err := call.NewParams()
+		if err != nil {
+			return err
+		}
+		params, err := testcapnp.NewPingPong_echoNum_Params(m.Segment())
+		if err != nil {
+			return err
+		}
+		params.SetN(n)
+		if err := payload.SetContentPtr(params.ToPtr()); err != nil {
+			return err
+		}
+		if f != nil {
+			return f(call)
+		}
+		return nil
+	}
+}
+
+func compatFinish(id uint32) func(rpccapnp.Message) error {
+	return func(m rpccapnp.Message) error {
+		fin, err := m.NewFinish()
+		if err != nil {
+			return err
+		}
+		fin.SetQuestionId(id)
+		return nil
+	}
+}
+
+func newCompatException(s *capnp.Segment) rpccapnp.Exception {
+	exc, _ := rpccapnp.NewException(s)
+	exc.SetReason("broken promise")
+	exc.SetType(rpccapnp.Exception_Type_disconnected)
+	return exc
+}
@@ -0,0 +1,310 @@
+package conformance_test
+
+import (
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/rpc"
+	"github.com/iguazio/go-capnproto2/rpc/internal/testcapnp"
+	rpccapnp "github.com/iguazio/go-capnproto2/std/capnp/rpc"
+)
+
+// A vat starts a remote vat that bootstraps an Echoer and returns a
+// stream connected to it.  Closing the stream shuts the vat down.
+type vat func(t *testing.T) io.ReadWriteCloser
+
+var vats = []struct {
+	name  string
+	start vat
+
+	// known maps the names of tests that the vat is known to fail to
+	// the reason why.  They are skipped rather than failed.
+	known map[string]string
+}{
+	{
+		name:  "go",
+		start: startGoVat,
+		known: map[string]string{
+			"Release": "rpc does not release capabilities received in call parameters (see TODO in answer.fulfill)",
+		},
+	},
+	{
+		name:  "external",
+		start: startExternalVat,
+	},
+}
+
+var suite = []struct {
+	name string
+	test func(t *testing.T, start vat)
+}{
+	{"Bootstrap", testBootstrap},
+	{"Pipelining", testPipelining},
+	{"Embargo", testEmbargo},
+	{"Release", testRelease},
+	{"Abort", testAbort},
+}
+
+func TestConformance(t *testing.T) {
+	for _, v := range vats {
+		v := v
+		t.Run(v.name, func(t *testing.T) {
+			for _, test := range suite {
+				test := test
+				t.Run(test.name, func(t *testing.T) {
+					if reason := v.known[test.name]; reason != "" {
+						t.Skip("known failure:", reason)
+					}
+					test.test(t, v.start)
+				})
+			}
+		})
+	}
+}
+
+// dial connects a new local connection to a vat and returns the
+// remote vat's Echoer.
+func dial(t *testing.T, start vat) (testcapnp.Echoer, *rpc.Conn) {
+	conn := rpc.NewConn(rpc.StreamTransport(start(t)), rpc.ConnLog(testLogger{t}))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	t.Cleanup(func() { conn.Close() })
+	return testcapnp.Echoer{Client: conn.Bootstrap(ctx)}, conn
+}
+
+func testBootstrap(t *testing.T, start vat) {
+	echoer, _ := dial(t, start)
+	ctx := context.Background()
+	for i := uint32(0); i < 3; i++ {
+		checkSeq(t, callseq(ctx, echoer.Client, i), i)
+	}
+}
+
+func testPipelining(t *testing.T, start vat) {
+	echoer, _ := dial(t, start)
+	ctx := context.Background()
+	local := testcapnp.CallOrder_ServerToClient(new(callOrder))
+	echo := echoer.Echo(ctx, func(p testcapnp.Echoer_echo_Params) error {
+		return p.SetCap(local)
+	})
+	// These calls are made on the promised answer before echo returns.
+	calls := make([]testcapnp.CallOrder_getCallSequence_Results_Promise, 3)
+	for i := range calls {
+		calls[i] = callseq(ctx, echo.Cap().Client, uint32(i))
+	}
+	for i, c := range calls {
+		checkSeq(t, c, uint32(i))
+	}
+}
+
+func testEmbargo(t *testing.T, start vat) {
+	echoer, _ := dial(t, start)
+	ctx := context.Background()
+	local := testcapnp.CallOrder_ServerToClient(new(callOrder))
+
+	earlyCall := callseq(ctx, echoer.Client, 0)
+	echo := echoer.Echo(ctx, func(p testcapnp.Echoer_echo_Params) error {
+		return p.SetCap(local)
+	})
+	pipeline := echo.Cap()
+	call0 := callseq(ctx, pipeline.Client, 0)
+	call1 := callseq(ctx, pipeline.Client, 1)
+	if _, err := earlyCall.Struct(); err != nil {
+		t.Errorf("earlyCall: %v", err)
+	}
+	call2 := callseq(ctx, pipeline.Client, 2)
+	if _, err := echo.Struct(); err != nil {
+		t.Errorf("echo: %v", err)
+	}
+	// Once echo has returned, the pipeline resolves to the local
+	// capability.  Calls made now must not overtake calls that are
+	// still looping through the remote vat.
+	call3 := callseq(ctx, pipeline.Client, 3)
+	call4 := callseq(ctx, pipeline.Client, 4)
+	for i, c := range []testcapnp.CallOrder_getCallSequence_Results_Promise{call0, call1, call2, call3, call4} {
+		checkSeq(t, c, uint32(i))
+	}
+}
+
+func testRelease(t *testing.T, start vat) {
+	echoer, _ := dial(t, start)
+	ctx := context.Background()
+	co := &callOrder{closed: make(chan struct{})}
+	local := testcapnp.CallOrder_ServerToClient(co)
+	echo := echoer.Echo(ctx, func(p testcapnp.Echoer_echo_Params) error {
+		return p.SetCap(local)
+	})
+	// The connection owns local once it is exported, and closes it when
+	// the remote vat releases it.
+	res, err := echo.Struct()
+	if err != nil {
+		t.Fatal("echo:", err)
+	}
+	if err := res.Cap().Client.Close(); err != nil {
+		t.Error("closing echoed capability:", err)
+	}
+	select {
+	case <-co.closed:
+	case <-time.After(10 * time.Second):
+		t.Error("remote vat never released the capability it was sent")
+	}
+}
+
+func testAbort(t *testing.T, start vat) {
+	rwc := start(t)
+	trans := rpc.StreamTransport(rwc)
+	defer trans.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, s, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := rpccapnp.NewRootMessage(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exc, err := msg.NewAbort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exc.SetReason("conformance test abort")
+	exc.SetType(rpccapnp.Exception_Type_failed)
+	if err := trans.SendMessage(ctx, msg); err != nil {
+		t.Fatal("sending abort:", err)
+	}
+	// The remote vat must hang up, and may send its own abort first.
+	for {
+		m, err := trans.RecvMessage(ctx)
+		if err == context.DeadlineExceeded {
+			t.Fatal("remote vat did not close the connection after abort")
+		}
+		if err != nil {
+			return
+		}
+		if m.Which() != rpccapnp.Message_Which_abort {
+			t.Errorf("remote vat sent %v after abort", m.Which())
+		}
+	}
+}
+
+func callseq(ctx context.Context, client capnp.Client, n uint32) testcapnp.CallOrder_getCallSequence_Results_Promise {
+	return testcapnp.CallOrder{Client: client}.GetCallSequence(ctx, func(p testcapnp.CallOrder_getCallSequence_Params) error {
+		p.SetExpected(n)
+		return nil
+	})
+}
+
+func checkSeq(t *testing.T, p testcapnp.CallOrder_getCallSequence_Results_Promise, want uint32) {
+	r, err := p.Struct()
+	if err != nil {
+		t.Errorf("call %d: %v", want, err)
+		return
+	}
+	if r.N() != want {
+		t.Errorf("call %d returned sequence number %d", want, r.N())
+	}
+}
+
+func startGoVat(t *testing.T) io.ReadWriteCloser {
+	p1, p2 := net.Pipe()
+	srv := testcapnp.Echoer_ServerToClient(new(echoer))
+	conn := rpc.NewConn(rpc.StreamTransport(p1), rpc.MainInterface(srv.Client), rpc.ConnLog(testLogger{t}))
+	t.Cleanup(func() { conn.Wait() })
+	return p2
+}
+
+func startExternalVat(t *testing.T) io.ReadWriteCloser {
+	cmdline := os.Getenv("CAPNP_CONFORMANCE_VAT")
+	if cmdline == "" {
+		t.Skip("CAPNP_CONFORMANCE_VAT not set")
+	}
+	cmd := exec.Command("sh", "-c", cmdline)
+	cmd.Stderr = os.Stderr
+	w, err := cmd.StdinPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatal("starting external vat:", err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+	return &pipeConn{r: r, w: w}
+}
+
+// pipeConn joins a process's standard output and input into a stream.
+type pipeConn struct {
+	r io.ReadCloser
+	w io.WriteCloser
+}
+
+func (pc *pipeConn) Read(p []byte) (int, error)  { return pc.r.Read(p) }
+func (pc *pipeConn) Write(p []byte) (int, error) { return pc.w.Write(p) }
+
+func (pc *pipeConn) Close() error {
+	werr := pc.w.Close()
+	rerr := pc.r.Close()
+	if werr != nil {
+		return werr
+	}
+	return rerr
+}
+
+type echoer struct {
+	callOrder
+}
+
+func (*echoer) Echo(call testcapnp.Echoer_echo) error {
+	return call.Results.SetCap(call.Params.Cap())
+}
+
+type callOrder struct {
+	mu     sync.Mutex
+	n      uint32
+	once   sync.Once
+	closed chan struct{} // closed on Close if not nil
+}
+
+func (co *callOrder) GetCallSequence(call testcapnp.CallOrder_getCallSequence) error {
+	co.mu.Lock()
+	call.Results.SetN(co.n)
+	co.n++
+	co.mu.Unlock()
+	return nil
+}
+
+func (co *callOrder) Close() error {
+	co.once.Do(func() {
+		if co.closed != nil {
+			close(co.closed)
+		}
+	})
+	return nil
+}
+
+type testLogger struct {
+	t *testing.T
+}
+
+func (l testLogger) Infof(ctx context.Context, format string, args ...interface{}) {
+	l.t.Logf("conn log: "+format, args...)
+}
+
+func (l testLogger) Errorf(ctx context.Context, format string, args ...interface{}) {
+	l.t.Logf("conn log: "+format, args...)
+}
@@ -0,0 +1,15 @@
+// Package conformance holds a test suite that checks the wire behavior
+// of a Cap'n Proto RPC vat: bootstrap, promise pipelining, embargoes,
+// releases, and aborts.
+//
+// The suite always runs against a vat built from this module's rpc
+// package, talking over a stream transport.  To also run it against
+// the reference C++ implementation, set CAPNP_CONFORMANCE_VAT to a
+// shell command that starts a vat speaking the two-party protocol on
+// its standard input and output and bootstrapping an implementation of
+// the Echoer interface in rpc/internal/testcapnp/test.capnp:
+//
+//	CAPNP_CONFORMANCE_VAT=./echoer-vat go test ./rpc/conformance
+//
+// If the variable is unset, the external vat's tests are skipped.
+package conformance // import "github.com/iguazio/go-capnproto2/rpc/conformance"
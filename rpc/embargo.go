@@ -0,0 +1,60 @@
+package rpc
+
+import "fmt"
+
+// An EmbargoStage is a step in the life of an embargo.  Embargoes keep
+// calls in E-order when a promise that calls were sent through resolves
+// to a capability in the vat that sent them: calls made after the
+// resolution are held until the remote vat has delivered the calls made
+// before it.
+type EmbargoStage int
+
+// Embargo stages.
+const (
+	// EmbargoStart is reported when the connection holds calls to a
+	// resolved capability and sends a senderLoopback Disembargo.
+	EmbargoStart EmbargoStage = iota
+
+	// EmbargoEcho is reported when the connection sends a
+	// receiverLoopback Disembargo back for one the remote vat sent.
+	// The ID is the remote vat's.
+	EmbargoEcho
+
+	// EmbargoLift is reported when the connection receives the
+	// receiverLoopback Disembargo for one of its embargoes and releases
+	// the held calls.
+	EmbargoLift
+)
+
+// String returns the stage's name.
+func (s EmbargoStage) String() string {
+	switch s {
+	case EmbargoStart:
+		return "start"
+	case EmbargoEcho:
+		return "echo"
+	case EmbargoLift:
+		return "lift"
+	default:
+		return fmt.Sprintf("EmbargoStage(%d)", int(s))
+	}
+}
+
+// EmbargoHook returns a ConnOption that calls f at each stage of the
+// connection's embargoes, with the embargo's ID.  f is called while
+// the connection is locked, in the order the stages happen, so it must
+// not block or use the connection.  It is meant for tests that check
+// delivery order, like with the rpctest package.
+func EmbargoHook(f func(stage EmbargoStage, id uint32)) ConnOption {
+	return ConnOption{func(c *connParams) {
+		c.embargoHook = f
+	}}
+}
+
+// embargoEvent reports an embargo stage to the hook, if any.  The
+// caller must be holding onto c.mu.
+func (c *Conn) embargoEvent(stage EmbargoStage, id embargoID) {
+	if c.embargoHook != nil {
+		c.embargoHook(stage, uint32(id))
+	}
+}
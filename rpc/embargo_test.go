@@ -1,6 +1,7 @@
 package rpc_test
 
 import (
+	"sync"
 	"testing"
 
 	"golang.org/x/net/context"
@@ -9,6 +10,8 @@ import (
 	"github.com/iguazio/go-capnproto2/rpc/internal/logtransport"
 	"github.com/iguazio/go-capnproto2/rpc/internal/pipetransport"
 	"github.com/iguazio/go-capnproto2/rpc/internal/testcapnp"
+	"github.com/iguazio/go-capnproto2/rpc/rpctest"
+	rpccapnp "github.com/iguazio/go-capnproto2/std/capnp/rpc"
 )
 
 func TestEmbargo(t *testing.T) {
@@ -64,6 +67,82 @@ func TestEmbargo(t *testing.T) {
 	check(call5, 5)
 }
 
+// TestEmbargoHeldDisembargo holds the disembargo that the client sends
+// when the echoed capability comes back, so that a call made while the
+// embargo is up deterministically races the call pipelined before it.
+func TestEmbargoHeldDisembargo(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p, q := rpctest.NewPipe()
+	q.SetAuto(true)
+	var hooks embargoRecorder
+	c := rpc.NewConn(p, rpc.ConnLog(testLogger{t}), rpc.EmbargoHook(hooks.record))
+	echoSrv := testcapnp.Echoer_ServerToClient(new(Echoer))
+	d := rpc.NewConn(q, rpc.MainInterface(echoSrv.Client), rpc.ConnLog(testLogger{t}))
+	defer d.Wait()
+	defer c.Close()
+	p.SetAuto(true)
+	client := testcapnp.Echoer{Client: c.Bootstrap(ctx)}
+	if _, err := callseq(ctx, client.Client, 0).Struct(); err != nil {
+		t.Fatal("bootstrap call:", err)
+	}
+	p.SetAuto(false)
+
+	localCap := testcapnp.CallOrder_ServerToClient(new(CallOrder))
+	echo := client.Echo(ctx, func(p testcapnp.Echoer_echo_Params) error {
+		return p.SetCap(localCap)
+	})
+	pipeline := echo.Cap()
+	call0 := callseq(ctx, pipeline.Client, 0)
+	if err := p.WaitHeld(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Deliver(2); err != nil {
+		t.Fatal(err)
+	}
+	i, err := p.WaitFor(ctx, rpccapnp.Message_Which_disembargo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hooks.stages(); len(got) != 1 || got[0] != rpc.EmbargoStart {
+		t.Fatalf("embargo stages before disembargo is delivered = %v; want [start]", got)
+	}
+	call1 := callseq(ctx, pipeline.Client, 1)
+
+	if err := p.Deliver(i + 1); err != nil {
+		t.Fatal(err)
+	}
+	p.SetAuto(true)
+	for n, call := range []testcapnp.CallOrder_getCallSequence_Results_Promise{call0, call1} {
+		r, err := call.Struct()
+		if err != nil {
+			t.Errorf("call%d error: %v", n, err)
+		} else if r.N() != uint32(n) {
+			t.Errorf("call%d = %d; want %d", n, r.N(), n)
+		}
+	}
+	if got := hooks.stages(); len(got) != 2 || got[1] != rpc.EmbargoLift {
+		t.Errorf("embargo stages = %v; want [start lift]", got)
+	}
+}
+
+type embargoRecorder struct {
+	mu   sync.Mutex
+	seen []rpc.EmbargoStage
+}
+
+func (r *embargoRecorder) record(stage rpc.EmbargoStage, id uint32) {
+	r.mu.Lock()
+	r.seen = append(r.seen, stage)
+	r.mu.Unlock()
+}
+
+func (r *embargoRecorder) stages() []rpc.EmbargoStage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]rpc.EmbargoStage(nil), r.seen...)
+}
+
 func callseq(c context.Context, client capnp.Client, n uint32) testcapnp.CallOrder_getCallSequence_Results_Promise {
 	return testcapnp.CallOrder{Client: client}.GetCallSequence(c, func(p testcapnp.CallOrder_getCallSequence_Params) error {
 		p.SetExpected(n)
@@ -0,0 +1,63 @@
+package rpc_test
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/rpc"
+	"github.com/iguazio/go-capnproto2/rpc/internal/logtransport"
+	"github.com/iguazio/go-capnproto2/rpc/internal/pipetransport"
+	"github.com/iguazio/go-capnproto2/rpc/internal/testcapnp"
+)
+
+func TestErrorDetails(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errQuota := errors.New("quota exceeded")
+	main := stubClient(func(ctx context.Context, params capnp.Struct) (capnp.Struct, error) {
+		_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+		d, err := testcapnp.NewCallOrder_getCallSequence_Results(seg)
+		if err != nil {
+			return capnp.Struct{}, err
+		}
+		d.SetN(42)
+		return capnp.Struct{}, capnp.WithErrorDetails(errQuota, capnp.ErrorDetail{
+			TypeID: testcapnp.CallOrder_getCallSequence_Results_TypeID,
+			Struct: d.Struct,
+		})
+	})
+	p, q := pipetransport.New()
+	if *logMessages {
+		p = logtransport.New(nil, p)
+	}
+	log := testLogger{t}
+	c := rpc.NewConn(p, rpc.ConnLog(log))
+	d := rpc.NewConn(q, rpc.MainInterface(main), rpc.ConnLog(log))
+	defer d.Wait()
+	defer c.Close()
+
+	client := c.Bootstrap(ctx)
+	_, err := client.Call(&capnp.Call{
+		Ctx: ctx,
+		Method: capnp.Method{
+			InterfaceID: interfaceID,
+			MethodID:    methodID,
+		},
+		ParamsSize: capnp.ObjectSize{DataSize: 8},
+	}).Struct()
+	if err == nil {
+		t.Fatal("call succeeded; want error")
+	}
+	s, ok := capnp.FindErrorDetail(err, testcapnp.CallOrder_getCallSequence_Results_TypeID)
+	if !ok {
+		t.Fatalf("FindErrorDetail(%v) found nothing", err)
+	}
+	if n := (testcapnp.CallOrder_getCallSequence_Results{Struct: s}).N(); n != 42 {
+		t.Errorf("detail n = %d; want 42", n)
+	}
+	if _, ok := capnp.FindErrorDetail(err, testcapnp.CallOrder_getCallSequence_Params_TypeID); ok {
+		t.Error("FindErrorDetail found a detail of a type that wasn't sent")
+	}
+}
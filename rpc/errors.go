@@ -22,6 +22,13 @@ func (e Exception) Error() string {
 	return "rpc exception: " + r
 }
 
+// ErrorDetails returns the details that the remote vat attached to the
+// exception.  Details that can't be read are skipped.
+// See capnp.ErrorDetails.
+func (e Exception) ErrorDetails() []capnp.ErrorDetail {
+	return readDetails(e.Exception)
+}
+
 // An Abort is a hang-up by a remote vat.
 type Abort Exception
 
@@ -50,25 +57,134 @@ func (a Abort) Error() string {
 	return "rpc: aborted by remote: " + r
 }
 
-// toException sets fields on exc to match err.
+// ErrorDetails returns the details that the remote vat attached to the
+// abort.  See capnp.ErrorDetails.
+func (a Abort) ErrorDetails() []capnp.ErrorDetail {
+	return readDetails(a.Exception)
+}
+
+// IsDisconnected reports whether e means that a call failed because the
+// connection it was made on went away: the connection was closed or
+// aborted, or the remote vat returned an exception of type
+// disconnected.
+func IsDisconnected(e error) bool {
+	if me, ok := e.(*capnp.MethodError); ok {
+		e = me.Err
+	}
+	switch e := e.(type) {
+	case Exception:
+		return e.Type() == rpccapnp.Exception_Type_disconnected
+	case Abort:
+		return true
+	}
+	return e == ErrConnClosed
+}
+
+// IsOverloaded reports whether e means that a call was rejected because
+// a vat was out of resources: the connection's MemoryBudget was spent,
+// or the remote vat returned an exception of type overloaded.
+func IsOverloaded(e error) bool {
+	if me, ok := e.(*capnp.MethodError); ok {
+		e = me.Err
+	}
+	if ee, ok := e.(Exception); ok {
+		return ee.Type() == rpccapnp.Exception_Type_overloaded
+	}
+	return e == ErrOverloaded
+}
+
+// toException sets fields on exc to match err, including the details
+// attached to err.
 func toException(exc rpccapnp.Exception, err error) {
-	if ee, ok := err.(Exception); ok {
+	switch ee, ok := err.(Exception); {
+	case err == ErrOverloaded:
+		exc.SetReason(err.Error())
+		exc.SetType(rpccapnp.Exception_Type_overloaded)
+	case ok:
 		// TODO(light): copy struct
 		r, err := ee.Reason()
 		if err == nil {
 			exc.SetReason(r)
 		}
 		exc.SetType(ee.Type())
+		if t, err := ee.Trace(); err == nil && t != "" {
+			exc.SetTrace(t)
+		}
+	default:
+		exc.SetReason(err.Error())
+		exc.SetType(rpccapnp.Exception_Type_failed)
+	}
+	writeDetails(exc, capnp.ErrorDetails(err))
+}
+
+// writeDetails sets exc's details.  Each detail's struct is copied into
+// a message of its own, without its capabilities.  Details that can't
+// be copied are skipped.
+func writeDetails(exc rpccapnp.Exception, details []capnp.ErrorDetail) {
+	if len(details) == 0 {
 		return
 	}
+	values := make([][]byte, 0, len(details))
+	ids := make([]uint64, 0, len(details))
+	for _, d := range details {
+		msg, _, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+		if err := msg.SetRootPtr(d.Struct.ToPtr()); err != nil {
+			continue
+		}
+		data, err := msg.Marshal()
+		if err != nil {
+			continue
+		}
+		values = append(values, data)
+		ids = append(ids, d.TypeID)
+	}
+	if len(values) == 0 {
+		return
+	}
+	list, err := exc.NewDetails(int32(len(values)))
+	if err != nil {
+		return
+	}
+	for i := range values {
+		list.At(i).SetId(ids[i])
+		list.At(i).SetValue(values[i])
+	}
+}
 
-	exc.SetReason(err.Error())
-	exc.SetType(rpccapnp.Exception_Type_failed)
+// readDetails returns the details in exc.
+func readDetails(exc rpccapnp.Exception) []capnp.ErrorDetail {
+	list, err := exc.Details()
+	if err != nil || list.Len() == 0 {
+		return nil
+	}
+	details := make([]capnp.ErrorDetail, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		d := list.At(i)
+		data, err := d.Value()
+		if err != nil {
+			continue
+		}
+		msg, err := capnp.Unmarshal(data)
+		if err != nil {
+			continue
+		}
+		p, err := msg.RootPtr()
+		if err != nil {
+			continue
+		}
+		details = append(details, capnp.ErrorDetail{TypeID: d.Id(), Struct: p.Struct()})
+	}
+	return details
 }
 
 // Errors
 var (
 	ErrConnClosed = errors.New("rpc: connection closed")
+
+	// ErrOverloaded is returned for calls rejected because the
+	// connection's MemoryBudget is spent.  The remote vat receives
+	// it as an exception of type overloaded.
+	ErrOverloaded = errors.New("rpc: connection memory budget exceeded")
 )
 
 // Internal errors
@@ -78,6 +194,7 @@ var (
 	errBadTarget       = errors.New("rpc: target not found")
 	errShutdown        = errors.New("rpc: shutdown")
 	errUnimplemented   = errors.New("rpc: remote used unimplemented protocol feature")
+	errRemoteUnimpl    = errors.New("rpc: remote does not implement message")
 )
 
 type bootstrapError struct {
@@ -0,0 +1,47 @@
+package rpc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/rpc"
+	rpccapnp "github.com/iguazio/go-capnproto2/std/capnp/rpc"
+)
+
+func TestErrorClassification(t *testing.T) {
+	exception := func(typ rpccapnp.Exception_Type) error {
+		_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+		exc, err := rpccapnp.NewRootException(seg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exc.SetType(typ)
+		return rpc.Exception{Exception: exc}
+	}
+	inMethod := func(err error) error {
+		return &capnp.MethodError{Method: &capnp.Method{InterfaceID: interfaceID}, Err: err}
+	}
+	tests := []struct {
+		err          error
+		disconnected bool
+		overloaded   bool
+	}{
+		{errors.New("foo"), false, false},
+		{rpc.ErrConnClosed, true, false},
+		{rpc.ErrOverloaded, false, true},
+		{rpc.Abort(exception(rpccapnp.Exception_Type_failed).(rpc.Exception)), true, false},
+		{exception(rpccapnp.Exception_Type_failed), false, false},
+		{inMethod(exception(rpccapnp.Exception_Type_disconnected)), true, false},
+		{inMethod(exception(rpccapnp.Exception_Type_overloaded)), false, true},
+		{inMethod(exception(rpccapnp.Exception_Type_unimplemented)), false, false},
+	}
+	for _, test := range tests {
+		if got := rpc.IsDisconnected(test.err); got != test.disconnected {
+			t.Errorf("IsDisconnected(%v) = %t; want %t", test.err, got, test.disconnected)
+		}
+		if got := rpc.IsOverloaded(test.err); got != test.overloaded {
+			t.Errorf("IsOverloaded(%v) = %t; want %t", test.err, got, test.overloaded)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+//go:build go1.18
+// +build go1.18
+
+package rpc_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/rpc"
+	rpccapnp "github.com/iguazio/go-capnproto2/std/capnp/rpc"
+	"golang.org/x/net/context"
+)
+
+// FuzzRPCReceive feeds an arbitrary stream of messages to a connection
+// that exports a bootstrap capability.  The connection may abort, but
+// must not panic or hang, and must shut down once the stream ends.
+func FuzzRPCReceive(f *testing.F) {
+	bootstrap := func(msg rpccapnp.Message) error {
+		b, err := msg.NewBootstrap()
+		if err != nil {
+			return err
+		}
+		b.SetQuestionId(0)
+		return nil
+	}
+	call := func(msg rpccapnp.Message) error {
+		call, err := msg.NewCall()
+		if err != nil {
+			return err
+		}
+		call.SetQuestionId(1)
+		call.SetInterfaceId(interfaceID)
+		call.SetMethodId(methodID)
+		target, err := call.NewTarget()
+		if err != nil {
+			return err
+		}
+		pa, err := target.NewPromisedAnswer()
+		if err != nil {
+			return err
+		}
+		pa.SetQuestionId(0)
+		payload, err := call.NewParams()
+		if err != nil {
+			return err
+		}
+		content, err := capnp.NewStruct(msg.Segment(), capnp.ObjectSize{})
+		if err != nil {
+			return err
+		}
+		return payload.SetContent(content)
+	}
+	finish := func(msg rpccapnp.Message) error {
+		finish, err := msg.NewFinish()
+		if err != nil {
+			return err
+		}
+		finish.SetQuestionId(0)
+		return nil
+	}
+	release := func(msg rpccapnp.Message) error {
+		release, err := msg.NewRelease()
+		if err != nil {
+			return err
+		}
+		release.SetId(0)
+		release.SetReferenceCount(1)
+		return nil
+	}
+	abort := func(msg rpccapnp.Message) error {
+		exc, err := msg.NewAbort()
+		if err != nil {
+			return err
+		}
+		return exc.SetReason("fuzz")
+	}
+	seeds := [][]func(rpccapnp.Message) error{
+		{bootstrap},
+		{bootstrap, call, finish},
+		{bootstrap, finish, release},
+		{bootstrap, call, abort},
+	}
+	for _, seed := range seeds {
+		data, err := encodeMessages(seed)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		main := stubClient(func(ctx context.Context, params capnp.Struct) (capnp.Struct, error) {
+			_, s, err := capnp.NewMessage(capnp.SingleSegment(nil))
+			if err != nil {
+				return capnp.Struct{}, err
+			}
+			return capnp.NewRootStruct(s, capnp.ObjectSize{})
+		})
+		conn := rpc.NewConn(
+			rpc.StreamTransport(fuzzStream{bytes.NewReader(data)}),
+			rpc.MainInterface(main),
+			rpc.ConnLog(nil))
+		conn.Wait()
+	})
+}
+
+// encodeMessages returns a stream of RPC messages, each filled in by
+// the corresponding function.
+func encodeMessages(fs []func(rpccapnp.Message) error) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := capnp.NewEncoder(&buf)
+	for _, f := range fs {
+		msg, s, err := capnp.NewMessage(capnp.SingleSegment(nil))
+		if err != nil {
+			return nil, err
+		}
+		m, err := rpccapnp.NewRootMessage(s)
+		if err != nil {
+			return nil, err
+		}
+		if err := f(m); err != nil {
+			return nil, err
+		}
+		if err := enc.Encode(msg); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// fuzzStream reads from a fixed input and discards writes.
+type fuzzStream struct {
+	io.Reader
+}
+
+func (fuzzStream) Write(p []byte) (int, error) {
+	return ioutil.Discard.Write(p)
+}
+
+func (fuzzStream) Close() error {
+	return nil
+}
@@ -100,6 +100,11 @@ func (r *Ref) Client() capnp.Client {
 	return r.rc.Client
 }
 
+// ClientState returns the state of the underlying client.
+func (r *Ref) ClientState() (resolved bool, brand interface{}, remote bool) {
+	return capnp.ClientState(r.rc.Client)
+}
+
 // Close decrements the reference count.  Close will be called on
 // finalization (i.e. garbage collection).
 func (r *Ref) Close() error {
@@ -3,11 +3,11 @@
 package testcapnp
 
 import (
-	context "golang.org/x/net/context"
 	capnp "github.com/iguazio/go-capnproto2"
 	text "github.com/iguazio/go-capnproto2/encoding/text"
 	schemas "github.com/iguazio/go-capnproto2/schemas"
 	server "github.com/iguazio/go-capnproto2/server"
+	context "golang.org/x/net/context"
 )
 
 type Handle struct{ Client capnp.Client }
@@ -20,7 +20,12 @@ type Handle_Server interface {
 
 func Handle_ServerToClient(s Handle_Server) Handle {
 	c, _ := s.(server.Closer)
-	return Handle{Client: server.New(Handle_Methods(nil, s), c)}
+	return Handle{Client: server.NewTable(Handle_Table(s), c)}
+}
+
+// Handle_Table returns the methods of s grouped by interface, for server.NewTable.
+func Handle_Table(s Handle_Server) server.Table {
+	return nil
 }
 
 func Handle_Methods(methods []server.Method, s Handle_Server) []server.Method {
@@ -63,7 +68,15 @@ type HandleFactory_Server interface {
 
 func HandleFactory_ServerToClient(s HandleFactory_Server) HandleFactory {
 	c, _ := s.(server.Closer)
-	return HandleFactory{Client: server.New(HandleFactory_Methods(nil, s), c)}
+	return HandleFactory{Client: server.NewTable(HandleFactory_Table(s), c)}
+}
+
+// HandleFactory_Table returns the methods of s grouped by interface, for server.NewTable.
+func HandleFactory_Table(s HandleFactory_Server) server.Table {
+	methods := HandleFactory_Methods(nil, s)
+	return server.Table{
+		{ID: 0x8491a7fe75fe0bce, Methods: methods[0:1:1]},
+	}
 }
 
 func HandleFactory_Methods(methods []server.Method, s HandleFactory_Server) []server.Method {
@@ -111,11 +124,28 @@ func NewRootHandleFactory_newHandle_Params(s *capnp.Segment) (HandleFactory_newH
 	return HandleFactory_newHandle_Params{st}, err
 }
 
+func AllocateRootHandleFactory_newHandle_Params(msg *capnp.Message) (HandleFactory_newHandle_Params, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return HandleFactory_newHandle_Params{st}, err
+}
+
 func ReadRootHandleFactory_newHandle_Params(msg *capnp.Message) (HandleFactory_newHandle_Params, error) {
 	root, err := msg.RootPtr()
 	return HandleFactory_newHandle_Params{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s HandleFactory_newHandle_Params) Equal(other HandleFactory_newHandle_Params) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s HandleFactory_newHandle_Params) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s HandleFactory_newHandle_Params) String() string {
 	str, _ := text.Marshal(0x99821793f0a50b5e, s.Struct)
 	return str
@@ -130,6 +160,13 @@ func NewHandleFactory_newHandle_Params_List(s *capnp.Segment, sz int32) (HandleF
 	return HandleFactory_newHandle_Params_List{l}, err
 }
 
+// NewRootHandleFactory_newHandle_ParamsListMessage creates a message whose root is a new
+// list of n HandleFactory_newHandle_Params.  The message's segment is sized to fit the list.
+func NewRootHandleFactory_newHandle_ParamsListMessage(n int32) (*capnp.Message, HandleFactory_newHandle_Params_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 0}, n)
+	return msg, HandleFactory_newHandle_Params_List{l}, err
+}
+
 func (s HandleFactory_newHandle_Params_List) At(i int) HandleFactory_newHandle_Params {
 	return HandleFactory_newHandle_Params{s.List.Struct(i)}
 }
@@ -147,8 +184,7 @@ func (s HandleFactory_newHandle_Params_List) String() string {
 type HandleFactory_newHandle_Params_Promise struct{ *capnp.Pipeline }
 
 func (p HandleFactory_newHandle_Params_Promise) Struct() (HandleFactory_newHandle_Params, error) {
-	s, err := p.Pipeline.Struct()
-	return HandleFactory_newHandle_Params{s}, err
+	return capnp.PipelineStruct[HandleFactory_newHandle_Params](p.Pipeline)
 }
 
 type HandleFactory_newHandle_Results struct{ capnp.Struct }
@@ -166,11 +202,28 @@ func NewRootHandleFactory_newHandle_Results(s *capnp.Segment) (HandleFactory_new
 	return HandleFactory_newHandle_Results{st}, err
 }
 
+func AllocateRootHandleFactory_newHandle_Results(msg *capnp.Message) (HandleFactory_newHandle_Results, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return HandleFactory_newHandle_Results{st}, err
+}
+
 func ReadRootHandleFactory_newHandle_Results(msg *capnp.Message) (HandleFactory_newHandle_Results, error) {
 	root, err := msg.RootPtr()
 	return HandleFactory_newHandle_Results{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s HandleFactory_newHandle_Results) Equal(other HandleFactory_newHandle_Results) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s HandleFactory_newHandle_Results) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s HandleFactory_newHandle_Results) String() string {
 	str, _ := text.Marshal(0xd57b5111c59d048c, s.Struct)
 	return str
@@ -190,7 +243,7 @@ func (s HandleFactory_newHandle_Results) SetHandle(v Handle) error {
 	if v.Client == nil {
 		return s.Struct.SetPtr(0, capnp.Ptr{})
 	}
-	seg := s.Segment()
+	seg := s.Struct.Segment()
 	in := capnp.NewInterface(seg, seg.Message().AddCap(v.Client))
 	return s.Struct.SetPtr(0, in.ToPtr())
 }
@@ -204,6 +257,13 @@ func NewHandleFactory_newHandle_Results_List(s *capnp.Segment, sz int32) (Handle
 	return HandleFactory_newHandle_Results_List{l}, err
 }
 
+// NewRootHandleFactory_newHandle_ResultsListMessage creates a message whose root is a new
+// list of n HandleFactory_newHandle_Results.  The message's segment is sized to fit the list.
+func NewRootHandleFactory_newHandle_ResultsListMessage(n int32) (*capnp.Message, HandleFactory_newHandle_Results_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, HandleFactory_newHandle_Results_List{l}, err
+}
+
 func (s HandleFactory_newHandle_Results_List) At(i int) HandleFactory_newHandle_Results {
 	return HandleFactory_newHandle_Results{s.List.Struct(i)}
 }
@@ -221,8 +281,7 @@ func (s HandleFactory_newHandle_Results_List) String() string {
 type HandleFactory_newHandle_Results_Promise struct{ *capnp.Pipeline }
 
 func (p HandleFactory_newHandle_Results_Promise) Struct() (HandleFactory_newHandle_Results, error) {
-	s, err := p.Pipeline.Struct()
-	return HandleFactory_newHandle_Results{s}, err
+	return capnp.PipelineStruct[HandleFactory_newHandle_Results](p.Pipeline)
 }
 
 func (p HandleFactory_newHandle_Results_Promise) Handle() Handle {
@@ -261,7 +320,15 @@ type Hanger_Server interface {
 
 func Hanger_ServerToClient(s Hanger_Server) Hanger {
 	c, _ := s.(server.Closer)
-	return Hanger{Client: server.New(Hanger_Methods(nil, s), c)}
+	return Hanger{Client: server.NewTable(Hanger_Table(s), c)}
+}
+
+// Hanger_Table returns the methods of s grouped by interface, for server.NewTable.
+func Hanger_Table(s Hanger_Server) server.Table {
+	methods := Hanger_Methods(nil, s)
+	return server.Table{
+		{ID: 0x8ae08044aae8a26e, Methods: methods[0:1:1]},
+	}
 }
 
 func Hanger_Methods(methods []server.Method, s Hanger_Server) []server.Method {
@@ -309,11 +376,28 @@ func NewRootHanger_hang_Params(s *capnp.Segment) (Hanger_hang_Params, error) {
 	return Hanger_hang_Params{st}, err
 }
 
+func AllocateRootHanger_hang_Params(msg *capnp.Message) (Hanger_hang_Params, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return Hanger_hang_Params{st}, err
+}
+
 func ReadRootHanger_hang_Params(msg *capnp.Message) (Hanger_hang_Params, error) {
 	root, err := msg.RootPtr()
 	return Hanger_hang_Params{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Hanger_hang_Params) Equal(other Hanger_hang_Params) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Hanger_hang_Params) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Hanger_hang_Params) String() string {
 	str, _ := text.Marshal(0xb4512d1c0c85f06f, s.Struct)
 	return str
@@ -328,6 +412,13 @@ func NewHanger_hang_Params_List(s *capnp.Segment, sz int32) (Hanger_hang_Params_
 	return Hanger_hang_Params_List{l}, err
 }
 
+// NewRootHanger_hang_ParamsListMessage creates a message whose root is a new
+// list of n Hanger_hang_Params.  The message's segment is sized to fit the list.
+func NewRootHanger_hang_ParamsListMessage(n int32) (*capnp.Message, Hanger_hang_Params_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 0}, n)
+	return msg, Hanger_hang_Params_List{l}, err
+}
+
 func (s Hanger_hang_Params_List) At(i int) Hanger_hang_Params {
 	return Hanger_hang_Params{s.List.Struct(i)}
 }
@@ -345,8 +436,7 @@ func (s Hanger_hang_Params_List) String() string {
 type Hanger_hang_Params_Promise struct{ *capnp.Pipeline }
 
 func (p Hanger_hang_Params_Promise) Struct() (Hanger_hang_Params, error) {
-	s, err := p.Pipeline.Struct()
-	return Hanger_hang_Params{s}, err
+	return capnp.PipelineStruct[Hanger_hang_Params](p.Pipeline)
 }
 
 type Hanger_hang_Results struct{ capnp.Struct }
@@ -364,11 +454,28 @@ func NewRootHanger_hang_Results(s *capnp.Segment) (Hanger_hang_Results, error) {
 	return Hanger_hang_Results{st}, err
 }
 
+func AllocateRootHanger_hang_Results(msg *capnp.Message) (Hanger_hang_Results, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return Hanger_hang_Results{st}, err
+}
+
 func ReadRootHanger_hang_Results(msg *capnp.Message) (Hanger_hang_Results, error) {
 	root, err := msg.RootPtr()
 	return Hanger_hang_Results{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Hanger_hang_Results) Equal(other Hanger_hang_Results) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Hanger_hang_Results) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Hanger_hang_Results) String() string {
 	str, _ := text.Marshal(0xb9c9455b55ed47b0, s.Struct)
 	return str
@@ -383,6 +490,13 @@ func NewHanger_hang_Results_List(s *capnp.Segment, sz int32) (Hanger_hang_Result
 	return Hanger_hang_Results_List{l}, err
 }
 
+// NewRootHanger_hang_ResultsListMessage creates a message whose root is a new
+// list of n Hanger_hang_Results.  The message's segment is sized to fit the list.
+func NewRootHanger_hang_ResultsListMessage(n int32) (*capnp.Message, Hanger_hang_Results_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 0}, n)
+	return msg, Hanger_hang_Results_List{l}, err
+}
+
 func (s Hanger_hang_Results_List) At(i int) Hanger_hang_Results {
 	return Hanger_hang_Results{s.List.Struct(i)}
 }
@@ -400,8 +514,7 @@ func (s Hanger_hang_Results_List) String() string {
 type Hanger_hang_Results_Promise struct{ *capnp.Pipeline }
 
 func (p Hanger_hang_Results_Promise) Struct() (Hanger_hang_Results, error) {
-	s, err := p.Pipeline.Struct()
-	return Hanger_hang_Results{s}, err
+	return capnp.PipelineStruct[Hanger_hang_Results](p.Pipeline)
 }
 
 type CallOrder struct{ Client capnp.Client }
@@ -436,7 +549,15 @@ type CallOrder_Server interface {
 
 func CallOrder_ServerToClient(s CallOrder_Server) CallOrder {
 	c, _ := s.(server.Closer)
-	return CallOrder{Client: server.New(CallOrder_Methods(nil, s), c)}
+	return CallOrder{Client: server.NewTable(CallOrder_Table(s), c)}
+}
+
+// CallOrder_Table returns the methods of s grouped by interface, for server.NewTable.
+func CallOrder_Table(s CallOrder_Server) server.Table {
+	methods := CallOrder_Methods(nil, s)
+	return server.Table{
+		{ID: 0x92c5ca8314cdd2a5, Methods: methods[0:1:1]},
+	}
 }
 
 func CallOrder_Methods(methods []server.Method, s CallOrder_Server) []server.Method {
@@ -484,11 +605,28 @@ func NewRootCallOrder_getCallSequence_Params(s *capnp.Segment) (CallOrder_getCal
 	return CallOrder_getCallSequence_Params{st}, err
 }
 
+func AllocateRootCallOrder_getCallSequence_Params(msg *capnp.Message) (CallOrder_getCallSequence_Params, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return CallOrder_getCallSequence_Params{st}, err
+}
+
 func ReadRootCallOrder_getCallSequence_Params(msg *capnp.Message) (CallOrder_getCallSequence_Params, error) {
 	root, err := msg.RootPtr()
 	return CallOrder_getCallSequence_Params{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s CallOrder_getCallSequence_Params) Equal(other CallOrder_getCallSequence_Params) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s CallOrder_getCallSequence_Params) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s CallOrder_getCallSequence_Params) String() string {
 	str, _ := text.Marshal(0x993e61d6a54c166f, s.Struct)
 	return str
@@ -511,6 +649,13 @@ func NewCallOrder_getCallSequence_Params_List(s *capnp.Segment, sz int32) (CallO
 	return CallOrder_getCallSequence_Params_List{l}, err
 }
 
+// NewRootCallOrder_getCallSequence_ParamsListMessage creates a message whose root is a new
+// list of n CallOrder_getCallSequence_Params.  The message's segment is sized to fit the list.
+func NewRootCallOrder_getCallSequence_ParamsListMessage(n int32) (*capnp.Message, CallOrder_getCallSequence_Params_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 0}, n)
+	return msg, CallOrder_getCallSequence_Params_List{l}, err
+}
+
 func (s CallOrder_getCallSequence_Params_List) At(i int) CallOrder_getCallSequence_Params {
 	return CallOrder_getCallSequence_Params{s.List.Struct(i)}
 }
@@ -528,8 +673,7 @@ func (s CallOrder_getCallSequence_Params_List) String() string {
 type CallOrder_getCallSequence_Params_Promise struct{ *capnp.Pipeline }
 
 func (p CallOrder_getCallSequence_Params_Promise) Struct() (CallOrder_getCallSequence_Params, error) {
-	s, err := p.Pipeline.Struct()
-	return CallOrder_getCallSequence_Params{s}, err
+	return capnp.PipelineStruct[CallOrder_getCallSequence_Params](p.Pipeline)
 }
 
 type CallOrder_getCallSequence_Results struct{ capnp.Struct }
@@ -547,11 +691,28 @@ func NewRootCallOrder_getCallSequence_Results(s *capnp.Segment) (CallOrder_getCa
 	return CallOrder_getCallSequence_Results{st}, err
 }
 
+func AllocateRootCallOrder_getCallSequence_Results(msg *capnp.Message) (CallOrder_getCallSequence_Results, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return CallOrder_getCallSequence_Results{st}, err
+}
+
 func ReadRootCallOrder_getCallSequence_Results(msg *capnp.Message) (CallOrder_getCallSequence_Results, error) {
 	root, err := msg.RootPtr()
 	return CallOrder_getCallSequence_Results{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s CallOrder_getCallSequence_Results) Equal(other CallOrder_getCallSequence_Results) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s CallOrder_getCallSequence_Results) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s CallOrder_getCallSequence_Results) String() string {
 	str, _ := text.Marshal(0x88f809ef7f873e58, s.Struct)
 	return str
@@ -574,6 +735,13 @@ func NewCallOrder_getCallSequence_Results_List(s *capnp.Segment, sz int32) (Call
 	return CallOrder_getCallSequence_Results_List{l}, err
 }
 
+// NewRootCallOrder_getCallSequence_ResultsListMessage creates a message whose root is a new
+// list of n CallOrder_getCallSequence_Results.  The message's segment is sized to fit the list.
+func NewRootCallOrder_getCallSequence_ResultsListMessage(n int32) (*capnp.Message, CallOrder_getCallSequence_Results_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 0}, n)
+	return msg, CallOrder_getCallSequence_Results_List{l}, err
+}
+
 func (s CallOrder_getCallSequence_Results_List) At(i int) CallOrder_getCallSequence_Results {
 	return CallOrder_getCallSequence_Results{s.List.Struct(i)}
 }
@@ -591,8 +759,7 @@ func (s CallOrder_getCallSequence_Results_List) String() string {
 type CallOrder_getCallSequence_Results_Promise struct{ *capnp.Pipeline }
 
 func (p CallOrder_getCallSequence_Results_Promise) Struct() (CallOrder_getCallSequence_Results, error) {
-	s, err := p.Pipeline.Struct()
-	return CallOrder_getCallSequence_Results{s}, err
+	return capnp.PipelineStruct[CallOrder_getCallSequence_Results](p.Pipeline)
 }
 
 type Echoer struct{ Client capnp.Client }
@@ -649,7 +816,16 @@ type Echoer_Server interface {
 
 func Echoer_ServerToClient(s Echoer_Server) Echoer {
 	c, _ := s.(server.Closer)
-	return Echoer{Client: server.New(Echoer_Methods(nil, s), c)}
+	return Echoer{Client: server.NewTable(Echoer_Table(s), c)}
+}
+
+// Echoer_Table returns the methods of s grouped by interface, for server.NewTable.
+func Echoer_Table(s Echoer_Server) server.Table {
+	methods := Echoer_Methods(nil, s)
+	return server.Table{
+		{ID: 0x841756c6a41b2a45, Methods: methods[0:1:1]},
+		{ID: 0x92c5ca8314cdd2a5, Methods: methods[1:2:2]},
+	}
 }
 
 func Echoer_Methods(methods []server.Method, s Echoer_Server) []server.Method {
@@ -711,11 +887,28 @@ func NewRootEchoer_echo_Params(s *capnp.Segment) (Echoer_echo_Params, error) {
 	return Echoer_echo_Params{st}, err
 }
 
+func AllocateRootEchoer_echo_Params(msg *capnp.Message) (Echoer_echo_Params, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Echoer_echo_Params{st}, err
+}
+
 func ReadRootEchoer_echo_Params(msg *capnp.Message) (Echoer_echo_Params, error) {
 	root, err := msg.RootPtr()
 	return Echoer_echo_Params{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Echoer_echo_Params) Equal(other Echoer_echo_Params) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Echoer_echo_Params) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Echoer_echo_Params) String() string {
 	str, _ := text.Marshal(0xe96a45cad5d1a1d3, s.Struct)
 	return str
@@ -735,7 +928,7 @@ func (s Echoer_echo_Params) SetCap(v CallOrder) error {
 	if v.Client == nil {
 		return s.Struct.SetPtr(0, capnp.Ptr{})
 	}
-	seg := s.Segment()
+	seg := s.Struct.Segment()
 	in := capnp.NewInterface(seg, seg.Message().AddCap(v.Client))
 	return s.Struct.SetPtr(0, in.ToPtr())
 }
@@ -749,6 +942,13 @@ func NewEchoer_echo_Params_List(s *capnp.Segment, sz int32) (Echoer_echo_Params_
 	return Echoer_echo_Params_List{l}, err
 }
 
+// NewRootEchoer_echo_ParamsListMessage creates a message whose root is a new
+// list of n Echoer_echo_Params.  The message's segment is sized to fit the list.
+func NewRootEchoer_echo_ParamsListMessage(n int32) (*capnp.Message, Echoer_echo_Params_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, Echoer_echo_Params_List{l}, err
+}
+
 func (s Echoer_echo_Params_List) At(i int) Echoer_echo_Params {
 	return Echoer_echo_Params{s.List.Struct(i)}
 }
@@ -766,8 +966,7 @@ func (s Echoer_echo_Params_List) String() string {
 type Echoer_echo_Params_Promise struct{ *capnp.Pipeline }
 
 func (p Echoer_echo_Params_Promise) Struct() (Echoer_echo_Params, error) {
-	s, err := p.Pipeline.Struct()
-	return Echoer_echo_Params{s}, err
+	return capnp.PipelineStruct[Echoer_echo_Params](p.Pipeline)
 }
 
 func (p Echoer_echo_Params_Promise) Cap() CallOrder {
@@ -789,11 +988,28 @@ func NewRootEchoer_echo_Results(s *capnp.Segment) (Echoer_echo_Results, error) {
 	return Echoer_echo_Results{st}, err
 }
 
+func AllocateRootEchoer_echo_Results(msg *capnp.Message) (Echoer_echo_Results, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Echoer_echo_Results{st}, err
+}
+
 func ReadRootEchoer_echo_Results(msg *capnp.Message) (Echoer_echo_Results, error) {
 	root, err := msg.RootPtr()
 	return Echoer_echo_Results{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Echoer_echo_Results) Equal(other Echoer_echo_Results) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Echoer_echo_Results) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Echoer_echo_Results) String() string {
 	str, _ := text.Marshal(0x8b45b4847bd839c8, s.Struct)
 	return str
@@ -813,7 +1029,7 @@ func (s Echoer_echo_Results) SetCap(v CallOrder) error {
 	if v.Client == nil {
 		return s.Struct.SetPtr(0, capnp.Ptr{})
 	}
-	seg := s.Segment()
+	seg := s.Struct.Segment()
 	in := capnp.NewInterface(seg, seg.Message().AddCap(v.Client))
 	return s.Struct.SetPtr(0, in.ToPtr())
 }
@@ -827,6 +1043,13 @@ func NewEchoer_echo_Results_List(s *capnp.Segment, sz int32) (Echoer_echo_Result
 	return Echoer_echo_Results_List{l}, err
 }
 
+// NewRootEchoer_echo_ResultsListMessage creates a message whose root is a new
+// list of n Echoer_echo_Results.  The message's segment is sized to fit the list.
+func NewRootEchoer_echo_ResultsListMessage(n int32) (*capnp.Message, Echoer_echo_Results_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, Echoer_echo_Results_List{l}, err
+}
+
 func (s Echoer_echo_Results_List) At(i int) Echoer_echo_Results {
 	return Echoer_echo_Results{s.List.Struct(i)}
 }
@@ -844,8 +1067,7 @@ func (s Echoer_echo_Results_List) String() string {
 type Echoer_echo_Results_Promise struct{ *capnp.Pipeline }
 
 func (p Echoer_echo_Results_Promise) Struct() (Echoer_echo_Results, error) {
-	s, err := p.Pipeline.Struct()
-	return Echoer_echo_Results{s}, err
+	return capnp.PipelineStruct[Echoer_echo_Results](p.Pipeline)
 }
 
 func (p Echoer_echo_Results_Promise) Cap() CallOrder {
@@ -884,7 +1106,15 @@ type PingPong_Server interface {
 
 func PingPong_ServerToClient(s PingPong_Server) PingPong {
 	c, _ := s.(server.Closer)
-	return PingPong{Client: server.New(PingPong_Methods(nil, s), c)}
+	return PingPong{Client: server.NewTable(PingPong_Table(s), c)}
+}
+
+// PingPong_Table returns the methods of s grouped by interface, for server.NewTable.
+func PingPong_Table(s PingPong_Server) server.Table {
+	methods := PingPong_Methods(nil, s)
+	return server.Table{
+		{ID: 0xf004c474c2f8ee7a, Methods: methods[0:1:1]},
+	}
 }
 
 func PingPong_Methods(methods []server.Method, s PingPong_Server) []server.Method {
@@ -932,11 +1162,28 @@ func NewRootPingPong_echoNum_Params(s *capnp.Segment) (PingPong_echoNum_Params,
 	return PingPong_echoNum_Params{st}, err
 }
 
+func AllocateRootPingPong_echoNum_Params(msg *capnp.Message) (PingPong_echoNum_Params, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return PingPong_echoNum_Params{st}, err
+}
+
 func ReadRootPingPong_echoNum_Params(msg *capnp.Message) (PingPong_echoNum_Params, error) {
 	root, err := msg.RootPtr()
 	return PingPong_echoNum_Params{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s PingPong_echoNum_Params) Equal(other PingPong_echoNum_Params) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s PingPong_echoNum_Params) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s PingPong_echoNum_Params) String() string {
 	str, _ := text.Marshal(0xd797e0a99edf0921, s.Struct)
 	return str
@@ -959,6 +1206,13 @@ func NewPingPong_echoNum_Params_List(s *capnp.Segment, sz int32) (PingPong_echoN
 	return PingPong_echoNum_Params_List{l}, err
 }
 
+// NewRootPingPong_echoNum_ParamsListMessage creates a message whose root is a new
+// list of n PingPong_echoNum_Params.  The message's segment is sized to fit the list.
+func NewRootPingPong_echoNum_ParamsListMessage(n int32) (*capnp.Message, PingPong_echoNum_Params_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 0}, n)
+	return msg, PingPong_echoNum_Params_List{l}, err
+}
+
 func (s PingPong_echoNum_Params_List) At(i int) PingPong_echoNum_Params {
 	return PingPong_echoNum_Params{s.List.Struct(i)}
 }
@@ -976,8 +1230,7 @@ func (s PingPong_echoNum_Params_List) String() string {
 type PingPong_echoNum_Params_Promise struct{ *capnp.Pipeline }
 
 func (p PingPong_echoNum_Params_Promise) Struct() (PingPong_echoNum_Params, error) {
-	s, err := p.Pipeline.Struct()
-	return PingPong_echoNum_Params{s}, err
+	return capnp.PipelineStruct[PingPong_echoNum_Params](p.Pipeline)
 }
 
 type PingPong_echoNum_Results struct{ capnp.Struct }
@@ -995,11 +1248,28 @@ func NewRootPingPong_echoNum_Results(s *capnp.Segment) (PingPong_echoNum_Results
 	return PingPong_echoNum_Results{st}, err
 }
 
+func AllocateRootPingPong_echoNum_Results(msg *capnp.Message) (PingPong_echoNum_Results, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return PingPong_echoNum_Results{st}, err
+}
+
 func ReadRootPingPong_echoNum_Results(msg *capnp.Message) (PingPong_echoNum_Results, error) {
 	root, err := msg.RootPtr()
 	return PingPong_echoNum_Results{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s PingPong_echoNum_Results) Equal(other PingPong_echoNum_Results) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s PingPong_echoNum_Results) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s PingPong_echoNum_Results) String() string {
 	str, _ := text.Marshal(0x85ddfd96db252600, s.Struct)
 	return str
@@ -1022,6 +1292,13 @@ func NewPingPong_echoNum_Results_List(s *capnp.Segment, sz int32) (PingPong_echo
 	return PingPong_echoNum_Results_List{l}, err
 }
 
+// NewRootPingPong_echoNum_ResultsListMessage creates a message whose root is a new
+// list of n PingPong_echoNum_Results.  The message's segment is sized to fit the list.
+func NewRootPingPong_echoNum_ResultsListMessage(n int32) (*capnp.Message, PingPong_echoNum_Results_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 0}, n)
+	return msg, PingPong_echoNum_Results_List{l}, err
+}
+
 func (s PingPong_echoNum_Results_List) At(i int) PingPong_echoNum_Results {
 	return PingPong_echoNum_Results{s.List.Struct(i)}
 }
@@ -1039,8 +1316,7 @@ func (s PingPong_echoNum_Results_List) String() string {
 type PingPong_echoNum_Results_Promise struct{ *capnp.Pipeline }
 
 func (p PingPong_echoNum_Results_Promise) Struct() (PingPong_echoNum_Results, error) {
-	s, err := p.Pipeline.Struct()
-	return PingPong_echoNum_Results{s}, err
+	return capnp.PipelineStruct[PingPong_echoNum_Results](p.Pipeline)
 }
 
 type Adder struct{ Client capnp.Client }
@@ -1075,7 +1351,15 @@ type Adder_Server interface {
 
 func Adder_ServerToClient(s Adder_Server) Adder {
 	c, _ := s.(server.Closer)
-	return Adder{Client: server.New(Adder_Methods(nil, s), c)}
+	return Adder{Client: server.NewTable(Adder_Table(s), c)}
+}
+
+// Adder_Table returns the methods of s grouped by interface, for server.NewTable.
+func Adder_Table(s Adder_Server) server.Table {
+	methods := Adder_Methods(nil, s)
+	return server.Table{
+		{ID: 0x8f9cac550b1bf41f, Methods: methods[0:1:1]},
+	}
 }
 
 func Adder_Methods(methods []server.Method, s Adder_Server) []server.Method {
@@ -1123,11 +1407,28 @@ func NewRootAdder_add_Params(s *capnp.Segment) (Adder_add_Params, error) {
 	return Adder_add_Params{st}, err
 }
 
+func AllocateRootAdder_add_Params(msg *capnp.Message) (Adder_add_Params, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return Adder_add_Params{st}, err
+}
+
 func ReadRootAdder_add_Params(msg *capnp.Message) (Adder_add_Params, error) {
 	root, err := msg.RootPtr()
 	return Adder_add_Params{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Adder_add_Params) Equal(other Adder_add_Params) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Adder_add_Params) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Adder_add_Params) String() string {
 	str, _ := text.Marshal(0x9ed99eb5024ed6ef, s.Struct)
 	return str
@@ -1158,6 +1459,13 @@ func NewAdder_add_Params_List(s *capnp.Segment, sz int32) (Adder_add_Params_List
 	return Adder_add_Params_List{l}, err
 }
 
+// NewRootAdder_add_ParamsListMessage creates a message whose root is a new
+// list of n Adder_add_Params.  The message's segment is sized to fit the list.
+func NewRootAdder_add_ParamsListMessage(n int32) (*capnp.Message, Adder_add_Params_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 0}, n)
+	return msg, Adder_add_Params_List{l}, err
+}
+
 func (s Adder_add_Params_List) At(i int) Adder_add_Params { return Adder_add_Params{s.List.Struct(i)} }
 
 func (s Adder_add_Params_List) Set(i int, v Adder_add_Params) error {
@@ -1173,8 +1481,7 @@ func (s Adder_add_Params_List) String() string {
 type Adder_add_Params_Promise struct{ *capnp.Pipeline }
 
 func (p Adder_add_Params_Promise) Struct() (Adder_add_Params, error) {
-	s, err := p.Pipeline.Struct()
-	return Adder_add_Params{s}, err
+	return capnp.PipelineStruct[Adder_add_Params](p.Pipeline)
 }
 
 type Adder_add_Results struct{ capnp.Struct }
@@ -1192,11 +1499,28 @@ func NewRootAdder_add_Results(s *capnp.Segment) (Adder_add_Results, error) {
 	return Adder_add_Results{st}, err
 }
 
+func AllocateRootAdder_add_Results(msg *capnp.Message) (Adder_add_Results, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return Adder_add_Results{st}, err
+}
+
 func ReadRootAdder_add_Results(msg *capnp.Message) (Adder_add_Results, error) {
 	root, err := msg.RootPtr()
 	return Adder_add_Results{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Adder_add_Results) Equal(other Adder_add_Results) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Adder_add_Results) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Adder_add_Results) String() string {
 	str, _ := text.Marshal(0xa74428796527f253, s.Struct)
 	return str
@@ -1219,6 +1543,13 @@ func NewAdder_add_Results_List(s *capnp.Segment, sz int32) (Adder_add_Results_Li
 	return Adder_add_Results_List{l}, err
 }
 
+// NewRootAdder_add_ResultsListMessage creates a message whose root is a new
+// list of n Adder_add_Results.  The message's segment is sized to fit the list.
+func NewRootAdder_add_ResultsListMessage(n int32) (*capnp.Message, Adder_add_Results_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 0}, n)
+	return msg, Adder_add_Results_List{l}, err
+}
+
 func (s Adder_add_Results_List) At(i int) Adder_add_Results {
 	return Adder_add_Results{s.List.Struct(i)}
 }
@@ -1236,71 +1567,70 @@ func (s Adder_add_Results_List) String() string {
 type Adder_add_Results_Promise struct{ *capnp.Pipeline }
 
 func (p Adder_add_Results_Promise) Struct() (Adder_add_Results, error) {
-	s, err := p.Pipeline.Struct()
-	return Adder_add_Results{s}, err
-}
-
-const schema_ef12a34b9807e19c = "x\xda\x9cU_h\x1c\xd5\x17>g\xee\xbd\xbfIH" +
-	"\x7f\x84\x9b\x1b\x8d\xb5Bi\x88\xda\x16\xb3Tj\x1f\x1a" +
-	"\xb0\x89\xda\xed\x8a\xd5\xba\x93\x12\xf1?Lw.\x9b\x96" +
-	"\xdd\xd9uv\x97\x1a\xfbP[[\x94\xaaH#\xfe\x09" +
-	"\xb4\x81TCi\xf1\xc1\x87\xfa\x90W1b\x03\x15\xa3" +
-	"\xa4Z$\xd8\x0aE+\xd4\xc4\xa8P\x0af\xe4\xde\xec" +
-	"\xdd\x9d\xddM\x8b\xfa\xb0\xb03\xe7\x9b\xef|\xe7;\xdf" +
-	"\x9d\xd9\xb0\xcd\xea\xb3\xeees6\x80\xf3,\xfb_8" +
-	"\xfd\xd5\xf6\x9f~\x9fu\x0f\x00o!\xe1\xb1K\xf6\xfb" +
-	"\xdb?h\x9b\x03@1K\x86\xc5eb\x03\x88K\xc4" +
-	"V?\x800\xbe~\xd5\x87\x9f?\xd1q\xa8\x01|\x96" +
-	"\x0c\x8bi\x0d>G\x12\xe2O\xd2\x01\x10~\xd9\xb2X" +
-	"Z<y\xb4\x11|\x99L\x89\xdf4\xf8*I\x88\x95" +
-	"\xd4\x06X\xbc\xeb\xce\xef\xdf\xfdk\xf6\xb0\xd3\x8e\x08\xa0" +
-	"\xeelD\xda\x89\x80\xa2\x99\xf6\x02\x86Onyu\xff" +
-	"\\\xf3\xb5\xd7\xc0\xb9\xa5\x02XG\xf7(\xc0&\x0d\xf0" +
-	"O\xfc|z\xeb\xcb\x17\x8f44\x1b\xa0\xc3\xe29\x85" +
-	"\x17O\xd1\x848\xa0\xfe\x85_l\xfen\xdf\xa13\xf1" +
-	"\xd7\x81\xb7!\x00CU\xddM\xaf\x03\x8a\xac&[\xfd" +
-	"\xc7\xaa\x96\x81\x8f\x8e\xbd\xd5@\xf6&=\"\xde\xd1d" +
-	"GiBLh\xb2\xf1\xaf\xcf\xb5\xbf259\xdc\x00" +
-	"\x1e\xa3\xa7\xc5)\x0d\x1e\xa7\x091\xad\xc1\xb9[\x1f\x1d" +
-	"?\xefn\x19\x89\xce1Aw\xa99&u\xeb\xe7[" +
-	"\xc6\xe7\xdf\xee88\x02|\xa5\xa9_\xa1\xfd\x084\x9c" +
-	";\xbf\xc3\xfad\xf4\xc2(8\xdc<*f\xe8\x8f\x80" +
-	"\xe2\x02\xdd\x0b\x18\xee\\\xb8[\x0e\xad\xddz2Z\xdf" +
-	"\xc4~\x01\x14\x9b\x99b\xce\xcd\x1f^qG\xb7sf" +
-	"i\xe8%G\xd8\x02\xd0\xf0\xe3\xc4\xd5\x81g\xe2g'" +
-	"\"\x85\xfb\xd9u\xa0\xe1\x1b\xf4\xf8$w\xf6\xcd,i" +
-	"\xd16m\\\xc3\x9eVb\xbb5\xe5\x9a\xe6\x1fFO" +
-	"]|\xef[\x88\xac\xed1v\xbb\x02\x0ch\xc07c" +
-	"\xd33S\xf1=W\xa2F\x97\xd8\x02\xa0\x18\xd2\xf5\x97" +
-	"~\xbd\xf6i\xf13:\xdf\xe0\xdd\x08;!\xc6\x98\x82" +
-	"\x1fg\x091\xc9l\xb8',\xcaB1\x96r\xf3\xe8" +
-	"\xe7{\x1ev}/\x832\x89\x98$,\x89XS\x8c" +
-	"\xa7\x06s\x12\x83$\xa2C\x09\x03\xa8\xc8@\xb3x\xce" +
-	"\xd7\x83\xc5\x99\xdd*S\x83\xb9>t(bu\x8f\x00" +
-	"\x156\xcb\xb4\x92\xdb\xdcT1\x17\x0c\x01TY\xcd\xaa" +
-	"\xd0\xf8\xc4y?X\xbc\xd9\x0e}\xb9W?\x05(\xfb" +
-	"0\xaa\x8e\xf8\xf9\x9e\xe4n?\x9d\xcc\xf9\xe9\x98\xea\xbd" +
-	"\xa3\x94\xed\xea\x97\x85\x92\x9d)\x16\x1cJ(\x00E\x00" +
-	"\xfe\xff6\x00\xa7\x89\xa0\xd3n!\xfaH\xc1B\x0aU" +
-	"\x1a\xea\xe7{\x1er3\x99\xc7\x03O\x06\xb1\xb4,\xaa" +
-	"\x8b\x9d\xf2\x85\x92\xf4SR\xf1\xb5\x96n\xca\xd7\x04\x16" +
-	"6\x01\xd6;\x9a\xae1\xcd\xe4\x05M>*\xa6\x0d\xba" +
-	"~\xbav.\xcb\xb8\x1e\xe8\xa9\xba\xfa{e\xa1^B" +
-	"gU\x82\xadz\xf2\x88\xe3\x88\xbcN\xce\x03\x9e'\x83" +
-	"\xa8\xdb&\xfehr\xcey\xa7\x96c\xbb\x9eW\xab\x06" +
-	"\x8d=\xad\xca\x9f\xc8D\xe5\xc3\x87\xe6m\xc2\xf9\xc1\xa5" +
-	"\x85\x19\x0b\xb1\xec!\xd4\x12\xde\xdc\xef\xa4\x1b\xd8n\xb6" +
-	"f\xd6G\x00\x9c\x15\x04\x9d\xdb,\x0c\xe5\x8by\x99*" +
-	"J\x0f\x00\x1a\x8c\xa7\xf5\xf9\x8a\x99\xe8hZ7\x8b\x85" +
-	"\x1a\x8f\xb5+1\xd7\xf3*M\x9b*M\xd7\xa9\x1dw" +
-	"\x11t6X\xc8\x11\xf5\x89\xe4\xdd\xea\xe6Z\x82\xce}" +
-	"\x16\xa2k\x82\x84\xbb\x1a\"U\xcb\xbe\\\x84z\xaa\xfb" +
-	"\xeb\x0d\xf4z\x97%\xd19\x0ab*#]\xc9\xd5j" +
-	"\x86\xc2\x0d\xeb&&\xff\xc8\x0fuL2\xe4\xc6\x9a\x06" +
-	"5\x0cy\xf5\x8bV\x17\xabe\x0f\x9fr\x99d\xff\xc5" +
-	"\xd9\xab\x8fzy\xc6\xff\x9et-\xc9\xce\xf9\xe9jP" +
-	"\xcd{\x15\xa1\xfcY\xe4\xfcA\x9d\xf5\xfde\xd9:\x9e" +
-	"\x7f\x07\x00\x00\xff\xff<\xf6/\x9f"
+	return capnp.PipelineStruct[Adder_add_Results](p.Pipeline)
+}
+
+const schema_ef12a34b9807e19c = "x\xda\x9cU]h\x1cU\x14>gf\xd6IHK" +
+	"\xb8\xb9\xa9Q+t\xef\x90j-d\xa9T\x1f\x9a\x87" +
+	"&\xa9]W\xad\xd4\x9d-\x15\x7f@\x98\xee\\6-" +
+	"\x9b\xd9uv\x97\x1a\xfbP[\x1b\xd4\xaa\x88\x11\xd4@" +
+	"\x1bh5\x94\x16\x0b\x0a\xed\x83\xafbD\x03\x8aQR" +
+	"-\x12l\x05\xd1\x0a5iT\xa8\x85f\xe4\xde\xec\xdd" +
+	"\x9d\xd9M\x8b\xca\xb2\xb0;\xe7\x9b\xef|\xe7;\xdf\x9d" +
+	"\xd9\xf0Jk\xbfvo\xcc\xdc\x02\x90\xd1c\xb7\x04\xd3" +
+	"_o\xfb\xe5\x8fY\xe7\x00\x906=8r\xd1|w" +
+	"\xdb{\x1ds\x00H/\xc5G\xe9\x95\xb8\x09@/\xc7" +
+	"M\xf1\x05\x08\x92\xebW\xbf\xff\xd9\xe3]\x87\x9a\xc03" +
+	"\xf1Q:+\xc1\xe7\xe3)\x8a\xac\x0b \xf8\xaam\xb1" +
+	"\xb2x\xe2\xcdf\xf0\x95\xf8\x14\xbd.\xc1\x7f\xc7St" +
+	"-3\x01\x16\xefZ\xfb\xc3\xdb\xd7gG\xecND\x00" +
+	"\xc3\x04\xd8\xb8\x92Y\x08HW\xb1>\xc0\xe0\x89\xcd/" +
+	"\xed\x9fk\xbd\xfa2\xd8\xabj\x80\xfb\xd9\x1e\x01\x18\x90" +
+	"\x00\xef\xf8\xaf\xa7\xb6\xbep\xe1pS3\x87\x8d\xd2\xdd" +
+	"\xa2\x05\xe5,E_\x17\xbf\x82\xcf7}\xbf\xef\xd0\x99" +
+	"\xe4\xab@:\x10 \x86\xa2Za\xd7\x00\xe9\xb0$[" +
+	"\xf3\xe7\xea\xb6\x9d\x1f\x1cy\xa3\x89l\x8c\x1d\xa6\xc7$" +
+	"\xd9Q\x96\xa2\x93\x92l\xe2\x9b/;_\x9c\x9a\x1cm" +
+	"\x02\x9ff\xa7\xe8Y\x09\xfe\x88\xa5\xe8\xac\x04\x17n}" +
+	"t\xe2\x9c\xb3y,<\xc7$\xdb%\xe6\x98\x96\xad\x9f" +
+	"i\x9b\x98\x7f\xab\xeb\xe0\x18\x90\xdbU\xfd/\x96A0" +
+	"\x82\xb9s\xdb\xb5\xb3\xe3\xe7\xc7\xc1&\xeaVz\x91\xfd" +
+	"\x04H\x7ff{\x01\x83\x1d\x0bw\xf3\xe1u[O\x84" +
+	"\xeb\x03\xd6o\x804i\x09\xe6\xc2\xfc\xc8\x8a;{\xec" +
+	"3KC\xcb2\xb7\x16\xc0\x08>L]\xde\xf9t\xf2" +
+	"\x8b\x8fC\x85\x87\xadk`\x04\xaf\x19G'\x89\xbdo" +
+	"fI\x8b\xb4ic\x8f\xf5\x94\x10\xbbIR\xb2\xd6\x1f" +
+	"\xc7O^x\xe7;\x08\xad\xedI\xeb\x0e\x01p$\xe0" +
+	"\xdbc\xd33S\xc9=\x97\xc2F\x1f\xb0\x16\x00\xe9\x88" +
+	"\xac?\xff\xfb\xd5O\xca\x9f\x1a\xf3M\xdeMX\xc7\xe9" +
+	"iK\xc0OZ):m\x99\x10D>\x85\xa0\xccK" +
+	"\xe5D\xd6)\xa2W\xec}\xc8\xf1\xdc<\xf24bZ" +
+	"\x8f\xa5\x11#\xc5dv\xb0\xc0\xd1O#\xda\x86\x1e\x03" +
+	"\xa8\x89B\x15\x03B\xd6\x83Fbf;\xcf\x0e\x16\xfa" +
+	"\xd16\x10\xeb[\x05\xa8\xb1i\xaa\x15\x7f\xd0\xc9\x96\x0b" +
+	"\xfe0@\x9dU-\x0e\x95k\x84d@#\xadf\xe0" +
+	"\xf1\xbd\xf2.@\xde\x8fau\xbaW\xecM\xef\xf6r" +
+	"\xe9\x82\x97K\x88\xde\xdb+C\xdd\x19^\xaa\x98\xf9r" +
+	"\xc96t\x03\xc0@\x00\xb2\xb2\x03\xc0n\xd1\xd1\xee\xd4" +
+	"\x10=4@C\x03\xea4\x86W\xec}\xc0\xc9\xe7\x1f" +
+	"\xf3]\xee'r\xbc,\xfe\xec\xe0\xcfV\xb8\x97\xe5\x82" +
+	"\xaf\xbdrS\xbe\x16\xd0\xb0\x05\xb0\xd1\xd1\\\xc44\x95" +
+	"\x1eTi\xa9\x996\xe8x\xb9\xe8\\\x9ar\xdd\x97S" +
+	"ug\xfax\xa9Q\x82U\x97`\x8a\x9e$\xe48\"" +
+	"i\x903\xe0\xba\xdc\x0f\xbb\xad\x0e\x03\xaa\xd4\x13bI" +
+	"9\xa6\xe3\xbaQ5\xa8\xeci\x17\xfe\x84&\xaa\x1eE" +
+	"T\xcf\x16B\x0e.-LY\x88U\x0f!Jxs" +
+	"\xbf\xd3\x8eo:C\x91Y\x1f\x01\xb0W\xe8h\xdf\xa6" +
+	"a\xc0\x9f+\xf2l\x99\xbb\x00\xd0d\xbc\xd1\x98\xaf\x84" +
+	"\x8a\x8e\xa4u\x86\xb0\x14\xf1X\xba\x92p\\\xb7\xd6\xb4" +
+	"\xa5\xd6\xf4\x1e\xb1\xe3n\x1d\xed\x0d\x1a\x12Dy>I" +
+	"\x8f\xb8\xb8NG\xfb>\x0d\xd1QA\xc2]M\x91\x8a" +
+	"\xb2/\x17\xa1\xde\xfa\xfe\xfa|\xb9\xdeeId\x8e\xfc" +
+	"\x84\xc8Hwz\x8d\x98\xa1t\xc3\xba\x8a\xc9\xbf\xf2C" +
+	"\x1c\x93\xbc~cM\x83\x12\x86\xa4\xfe~k\x88\xd5\xb2" +
+	"\x87O\xb8\xac\x0f\xfd\x87\xb3\xd7\x18\xf5\xea\x8c\xff?\xe9" +
+	"R\x92Y\xf0r\xf5\xa0\xaa\xa7,B\xf5%I\xc8\x16" +
+	"\x99\xf5\xfdU\xd92\x9e\xff\x0c\x00\x83O<\xfa"
 
 func init() {
 	schemas.Register(schema_ef12a34b9807e19c,
@@ -1,6 +1,8 @@
 package rpc
 
 import (
+	"reflect"
+
 	"github.com/iguazio/go-capnproto2"
 	"github.com/iguazio/go-capnproto2/internal/fulfiller"
 	"github.com/iguazio/go-capnproto2/rpc/internal/refcount"
@@ -44,6 +46,10 @@ dig:
 			client = curr.client
 		case *localAnswerClient:
 			curr.a.mu.Lock()
+			if q := curr.a.tail; q != nil {
+				curr.a.mu.Unlock()
+				return q.lockedPipelineCall(curr.transform, cl)
+			}
 			if curr.a.done {
 				obj, err := curr.a.obj, curr.a.err
 				curr.a.mu.Unlock()
@@ -120,6 +126,10 @@ dig:
 			if ct.conn != c {
 				break dig
 			}
+			if ct.resolved != nil {
+				client = ct.resolved
+				continue
+			}
 			desc.SetReceiverHosted(uint32(ct.id))
 			return nil
 		case *fulfiller.EmbargoClient:
@@ -147,8 +157,16 @@ dig:
 			client = ct.client
 		case *localAnswerClient:
 			ct.a.mu.RLock()
-			obj, err, done := ct.a.obj, ct.a.err, ct.a.done
+			obj, err, done, tail := ct.a.obj, ct.a.err, ct.a.done, ct.a.tail
 			ct.a.mu.RUnlock()
+			if tail != nil {
+				a, err := desc.NewReceiverAnswer()
+				if err != nil {
+					return err
+				}
+				a.SetQuestionId(uint32(tail.id))
+				return transformToPromisedAnswer(desc.Segment(), a, ct.transform)
+			}
 			if !done {
 				break dig
 			}
@@ -200,6 +218,10 @@ dig:
 	}
 
 	id := c.addExport(client)
+	if p := promiseFor(client); p != nil && c.watchPromise(id, p) {
+		desc.SetSenderPromise(uint32(id))
+		return nil
+	}
 	desc.SetSenderHosted(uint32(id))
 	return nil
 }
@@ -272,7 +294,52 @@ func isSameClient(c, d capnp.Client) bool {
 			}
 		}
 	}
-	return norm(c) == norm(d)
+	nc, nd := norm(c), norm(d)
+	if nc == nil || nd == nil {
+		return nc == nd
+	}
+	if bc := localBrand(nc); bc != nil && isSameBrand(bc, localBrand(nd)) {
+		return true
+	}
+	// Clients may have types that can't be compared, like funcs.
+	tc, td := reflect.TypeOf(nc), reflect.TypeOf(nd)
+	if tc != td || !tc.Comparable() {
+		return false
+	}
+	return nc == nd
+}
+
+// localBrand returns the brand that a client outside of this package
+// reports for itself (see capnp.ClientState) if it is a resolved local
+// capability, or nil otherwise.  Clients with the same brand, like the
+// clients of a server.Shared, are the same capability.
+func localBrand(client capnp.Client) interface{} {
+	switch client.(type) {
+	case *importClient, *embargoClient, *queueClient, *localAnswerClient, *Batch:
+		return nil
+	case *capnp.PipelineClient, *fulfiller.EmbargoClient, *refcount.Ref:
+		return nil
+	}
+	cs, ok := client.(capnp.ClientStater)
+	if !ok {
+		return nil
+	}
+	resolved, brand, remote := cs.ClientState()
+	if !resolved || remote {
+		return nil
+	}
+	return brand
+}
+
+func isSameBrand(b1, b2 interface{}) bool {
+	if b1 == nil || b2 == nil {
+		return false
+	}
+	t1, t2 := reflect.TypeOf(b1), reflect.TypeOf(b2)
+	if t1 != t2 || !t1.Comparable() {
+		return false
+	}
+	return b1 == b2
 }
 
 // isImport returns the underlying import if client represents an import
@@ -1,7 +1,9 @@
 package rpc
 
 import (
+	"fmt"
 	"log"
+	"net"
 
 	"golang.org/x/net/context"
 )
@@ -39,6 +41,106 @@ func (c *Conn) errorf(format string, args ...interface{}) {
 	c.log.Errorf(c.bg, format, args...)
 }
 
+// An EventKind identifies what happened in an Event.
+type EventKind int
+
+// Event kinds.
+const (
+	// EventConnOpen is logged when NewConn creates a connection.
+	EventConnOpen EventKind = iota + 1
+
+	// EventConnClose is logged when a connection is torn down.  Err is
+	// ErrConnClosed if it was closed locally, or the reason otherwise.
+	EventConnClose
+
+	// EventAbort is logged when the remote vat aborts the connection.
+	// Err is the Abort.
+	EventAbort
+
+	// EventDecodeError is logged when a message cannot be read from the
+	// transport or decoded, but the connection stays open.
+	EventDecodeError
+)
+
+// String returns a lowercase description of the kind.
+func (k EventKind) String() string {
+	switch k {
+	case EventConnOpen:
+		return "connection open"
+	case EventConnClose:
+		return "connection closed"
+	case EventAbort:
+		return "abort"
+	case EventDecodeError:
+		return "decode error"
+	default:
+		return fmt.Sprintf("event(%d)", int(k))
+	}
+}
+
+// An Event is a structured record of something that happened to a
+// connection, for operators to route to their logging stack.
+type Event struct {
+	Kind EventKind
+
+	// RemoteAddr is the address of the remote vat, or nil if the
+	// transport doesn't know it.  A Transport reports its remote
+	// address with a RemoteAddr() net.Addr method;
+	// StreamTransport's comes from a net.Conn.
+	RemoteAddr net.Addr
+
+	// Err is the error that caused the event, if any.
+	Err error
+}
+
+// String formats the event like "abort from 10.0.0.1:1234: reason".
+func (e Event) String() string {
+	s := e.Kind.String()
+	if e.RemoteAddr != nil {
+		s += " from " + e.RemoteAddr.String()
+	}
+	if e.Err != nil {
+		s += ": " + e.Err.Error()
+	}
+	return s
+}
+
+// An EventLogger is a Logger that receives structured events.  If the
+// Logger given to ConnLog implements EventLogger, the connection calls
+// LogEvent for each event instead of formatting it with Infof or
+// Errorf.  Other Loggers only receive aborts and decode errors.
+type EventLogger interface {
+	Logger
+	LogEvent(ctx context.Context, e Event)
+}
+
+type remoteAddrer interface {
+	RemoteAddr() net.Addr
+}
+
+// logEvent sends e to the connection's log.
+func (c *Conn) logEvent(e Event) {
+	if c.log == nil {
+		return
+	}
+	e.RemoteAddr = c.remoteAddr
+	if el, ok := c.log.(EventLogger); ok {
+		el.LogEvent(c.bg, e)
+		return
+	}
+	switch e.Kind {
+	case EventAbort:
+		c.log.Infof(c.bg, "%v", e)
+	case EventDecodeError:
+		c.log.Errorf(c.bg, "%v", e)
+	}
+}
+
+// decodeError logs a message of type what that could not be decoded.
+func (c *Conn) decodeError(what string, err error) {
+	c.logEvent(Event{Kind: EventDecodeError, Err: fmt.Errorf("%s: %v", what, err)})
+}
+
 // ConnLog sets the connection's log to the given Logger, which may be
 // nil to disable logging.  By default, logs are sent to the standard
 // log package.
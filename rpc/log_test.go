@@ -0,0 +1,58 @@
+package rpc_test
+
+import (
+	"sync"
+	"testing"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2/rpc"
+	"github.com/iguazio/go-capnproto2/rpc/internal/pipetransport"
+	rpccapnp "github.com/iguazio/go-capnproto2/std/capnp/rpc"
+)
+
+type eventLogger struct {
+	testLogger
+
+	mu     sync.Mutex
+	events []rpc.Event
+}
+
+func (l *eventLogger) LogEvent(ctx context.Context, e rpc.Event) {
+	l.mu.Lock()
+	l.events = append(l.events, e)
+	l.mu.Unlock()
+}
+
+func TestEventLogger(t *testing.T) {
+	p, q := pipetransport.New()
+	log := &eventLogger{testLogger: testLogger{t}}
+	conn := rpc.NewConn(p, rpc.ConnLog(log))
+	defer q.Close()
+
+	err := sendMessage(context.TODO(), q, func(msg rpccapnp.Message) error {
+		exc, err := msg.NewAbort()
+		if err != nil {
+			return err
+		}
+		return exc.SetReason("test abort")
+	})
+	if err != nil {
+		t.Fatal("sending abort:", err)
+	}
+	<-conn.Done()
+
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	want := []rpc.EventKind{rpc.EventConnOpen, rpc.EventAbort, rpc.EventConnClose}
+	if len(log.events) != len(want) {
+		t.Fatalf("events = %v; want kinds %v", log.events, want)
+	}
+	for i, e := range log.events {
+		if e.Kind != want[i] {
+			t.Errorf("events[%d].Kind = %v; want %v", i, e.Kind, want[i])
+		}
+	}
+	if _, ok := log.events[1].Err.(rpc.Abort); !ok {
+		t.Errorf("abort event Err = %#v; want rpc.Abort", log.events[1].Err)
+	}
+}
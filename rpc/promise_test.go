@@ -1,9 +1,11 @@
 package rpc_test
 
 import (
+	"errors"
 	"testing"
 
 	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
 	"github.com/iguazio/go-capnproto2/rpc"
 	"github.com/iguazio/go-capnproto2/rpc/internal/logtransport"
 	"github.com/iguazio/go-capnproto2/rpc/internal/pipetransport"
@@ -58,3 +60,51 @@ func (de *DelayEchoer) Echo(call testcapnp.Echoer_echo) error {
 	<-de.delay
 	return de.Echoer.Echo(call)
 }
+
+func TestPromisedCapabilityParamsError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p, q := pipetransport.New()
+	if *logMessages {
+		p = logtransport.New(nil, p)
+	}
+	log := testLogger{t}
+	c := rpc.NewConn(p, rpc.ConnLog(log))
+	delay := make(chan struct{})
+	echoSrv := testcapnp.Echoer_ServerToClient(&DelayEchoer{delay: delay})
+	d := rpc.NewConn(q, rpc.MainInterface(echoSrv.Client), rpc.ConnLog(log))
+	defer d.Wait()
+	defer c.Close()
+	client := testcapnp.Echoer{Client: c.Bootstrap(ctx)}
+
+	echo := client.Echo(ctx, func(p testcapnp.Echoer_echo_Params) error {
+		return p.SetCap(testcapnp.CallOrder{Client: client.Client})
+	})
+	paramsErr := errors.New("params failed")
+	_, err := echo.Cap().Client.Call(&capnp.Call{
+		Ctx:        ctx,
+		Method:     capnp.Method{InterfaceID: testcapnp.CallOrder_TypeID, MethodID: 0},
+		ParamsSize: capnp.ObjectSize{},
+		ParamsFunc: func(capnp.Struct) error { return paramsErr },
+	}).Struct()
+	if err != paramsErr {
+		t.Errorf("pipelined call error = %v; want %v", err, paramsErr)
+	}
+	close(delay)
+
+	// The failed call must not have retired the question it was
+	// pipelined on.
+	if _, err := echo.Struct(); err != nil {
+		t.Fatal("echo:", err)
+	}
+	r, err := callseq(ctx, echo.Cap().Client, 0).Struct()
+	if err != nil {
+		t.Fatal("getCallSequence:", err)
+	}
+	if r.N() != 0 {
+		t.Errorf("getCallSequence = %d; want 0", r.N())
+	}
+	if st := c.TableStats(); st.Questions != 0 {
+		t.Errorf("TableStats().Questions = %d; want 0", st.Questions)
+	}
+}
@@ -0,0 +1,93 @@
+package rpc
+
+import "golang.org/x/net/context"
+
+// A ConnPair describes one side of a proxy: the transport to a peer
+// and the options for the connection on it.
+type ConnPair struct {
+	Transport Transport
+	Options   []ConnOption
+}
+
+// A ProxyConn is a pair of connections created by Proxy.
+type ProxyConn struct {
+	// Dst is the connection to the proxy's client.
+	Dst *Conn
+
+	// Src is the connection to the vat being proxied.
+	Src *Conn
+
+	done chan struct{}
+}
+
+// Proxy opens a connection on src and exports src's bootstrap
+// interface as the bootstrap interface of a connection on dst, so that
+// dst's peer can use the capabilities of src's peer as if it were
+// connected to it directly.
+//
+// Capabilities that src's peer returns are exported on dst as they
+// are, and capabilities that dst's peer passes in calls are exported on
+// src, so calls in either direction are forwarded without wrapping.
+// Each export holds a reference to the import that it forwards, and
+// releasing the export on one connection releases the import on the
+// other.  Capabilities that a peer sends back to the connection they
+// came from are recognized as its own.
+//
+// The connections live and die together: once either one is done,
+// the other is closed, which releases everything it imported.
+//
+// dst.Options must not include MainInterface or BootstrapFunc.
+func Proxy(dst, src ConnPair) *ProxyConn {
+	s := NewConn(src.Transport, src.Options...)
+	main := s.Bootstrap(context.Background())
+	opts := append(dst.Options[:len(dst.Options):len(dst.Options)], MainInterface(main))
+	d := NewConn(dst.Transport, opts...)
+	p := &ProxyConn{Dst: d, Src: s, done: make(chan struct{})}
+	go p.run()
+	return p
+}
+
+func (p *ProxyConn) run() {
+	select {
+	case <-p.Dst.Done():
+		p.Src.Close()
+	case <-p.Src.Done():
+		p.Dst.Close()
+	}
+	<-p.Dst.Done()
+	<-p.Src.Done()
+	close(p.done)
+}
+
+// Close closes both connections.  It returns the error from closing
+// Dst, or if there was none, from closing Src.
+func (p *ProxyConn) Close() error {
+	derr := p.Dst.Close()
+	serr := p.Src.Close()
+	<-p.done
+	if derr != nil {
+		return derr
+	}
+	return serr
+}
+
+// Done returns a channel that is closed once both connections are
+// fully shut down.
+func (p *ProxyConn) Done() <-chan struct{} {
+	return p.done
+}
+
+// Wait waits until both connections are shut down.  It returns the
+// error from the peer that hung up or aborted, or ErrConnClosed if the
+// proxy was closed.
+func (p *ProxyConn) Wait() error {
+	<-p.done
+	derr, serr := p.Dst.Err(), p.Src.Err()
+	if derr != nil && derr != ErrConnClosed {
+		return derr
+	}
+	if serr != nil {
+		return serr
+	}
+	return derr
+}
@@ -0,0 +1,109 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/rpc"
+	"github.com/iguazio/go-capnproto2/rpc/internal/logtransport"
+	"github.com/iguazio/go-capnproto2/rpc/internal/pipetransport"
+	"github.com/iguazio/go-capnproto2/rpc/internal/testcapnp"
+)
+
+func TestProxy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	log := testLogger{t}
+	p1, q1 := pipetransport.New()
+	p2, q2 := pipetransport.New()
+	if *logMessages {
+		p1 = logtransport.New(nil, p1)
+	}
+	hf := new(HandleFactory)
+	srv := rpc.NewConn(q2, rpc.MainInterface(testcapnp.HandleFactory_ServerToClient(hf).Client), rpc.ConnLog(log))
+	proxy := rpc.Proxy(
+		rpc.ConnPair{Transport: q1, Options: []rpc.ConnOption{rpc.ConnLog(log)}},
+		rpc.ConnPair{Transport: p2, Options: []rpc.ConnOption{rpc.ConnLog(log)}},
+	)
+	c := rpc.NewConn(p1, rpc.ConnLog(log))
+	defer c.Close()
+
+	client := testcapnp.HandleFactory{Client: c.Bootstrap(ctx)}
+	r, err := client.NewHandle(ctx, nil).Struct()
+	if err != nil {
+		t.Fatal("NewHandle:", err)
+	}
+	if n := hf.numHandles(); n != 1 {
+		t.Fatalf("numHandles = %d; want 1", n)
+	}
+	if err := r.Handle().Client.Close(); err != nil {
+		t.Error("handle.Client.Close():", err)
+	}
+	flushConn(ctx, c)
+	if n := hf.numHandles(); n != 0 {
+		t.Errorf("after releasing handle through proxy, numHandles = %d; want 0", n)
+	}
+
+	// Closing the proxied vat's connection shuts down the proxy.
+	srv.Close()
+	if err := proxy.Wait(); err == nil {
+		t.Error("proxy.Wait() = nil; want error")
+	}
+	select {
+	case <-proxy.Dst.Done():
+	default:
+		t.Error("proxy.Dst not done after proxy.Wait()")
+	}
+}
+
+func TestSharedAnswerKeepsCaps(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	log := testLogger{t}
+	p1, q1 := pipetransport.New()
+	p2, q2 := pipetransport.New()
+	if *logMessages {
+		p1 = logtransport.New(nil, p1)
+	}
+	hf := new(HandleFactory)
+	srv := rpc.NewConn(q2, rpc.MainInterface(testcapnp.HandleFactory_ServerToClient(hf).Client), rpc.ConnLog(log))
+	defer srv.Close()
+	mid := rpc.NewConn(p2, rpc.ConnLog(log))
+	defer mid.Close()
+	shared := testcapnp.HandleFactory{Client: mid.Bootstrap(ctx)}.NewHandle(ctx, nil)
+	if _, err := shared.Struct(); err != nil {
+		t.Fatal("NewHandle:", err)
+	}
+	front := rpc.NewConn(q1, rpc.MainInterface(sharedAnswerClient{shared.Answer()}), rpc.ConnLog(log))
+	defer front.Close()
+	c := rpc.NewConn(p1, rpc.ConnLog(log))
+	defer c.Close()
+
+	client := testcapnp.HandleFactory{Client: c.Bootstrap(ctx)}
+	r, err := client.NewHandle(ctx, nil).Struct()
+	if err != nil {
+		t.Fatal("NewHandle through front:", err)
+	}
+	if err := r.Handle().Client.Close(); err != nil {
+		t.Error("handle.Client.Close():", err)
+	}
+	flushConn(ctx, c)
+	flushConn(ctx, mid)
+	if n := hf.numHandles(); n != 1 {
+		t.Errorf("after finishing a call answered with a shared answer, numHandles = %d; want 1", n)
+	}
+}
+
+// sharedAnswerClient answers every call with the same answer.
+type sharedAnswerClient struct {
+	ans capnp.Answer
+}
+
+func (sc sharedAnswerClient) Call(*capnp.Call) capnp.Answer {
+	return sc.ans
+}
+
+func (sharedAnswerClient) Close() error {
+	return nil
+}
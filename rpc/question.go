@@ -6,7 +6,8 @@ import (
 	"golang.org/x/net/context"
 	"github.com/iguazio/go-capnproto2"
 	"github.com/iguazio/go-capnproto2/internal/fulfiller"
-	"github.com/iguazio/go-capnproto2/internal/queue"
+	"github.com/iguazio/go-capnproto2/pipelineop"
+	"github.com/iguazio/go-capnproto2/queue"
 	rpccapnp "github.com/iguazio/go-capnproto2/std/capnp/rpc"
 )
 
@@ -21,18 +22,14 @@ func (c *Conn) newQuestion(ctx context.Context, method *capnp.Method) *question
 		id:       id,
 	}
 	// TODO(light): populate paramCaps
-	if int(id) == len(c.questions) {
-		c.questions = append(c.questions, q)
-	} else {
-		c.questions[id] = q
+	if c.questions == nil {
+		c.questions = make(map[questionID]*question)
 	}
+	c.questions[id] = q
 	return q
 }
 
 func (c *Conn) findQuestion(id questionID) *question {
-	if int(id) >= len(c.questions) {
-		return nil
-	}
 	return c.questions[id]
 }
 
@@ -41,8 +38,10 @@ func (c *Conn) popQuestion(id questionID) *question {
 	if q == nil {
 		return nil
 	}
-	c.questions[id] = nil
+	delete(c.questions, id)
 	c.questionID.remove(uint32(id))
+	c.releaseCallMessage(q.msg)
+	q.msg = nil
 	return q
 }
 
@@ -53,10 +52,32 @@ type question struct {
 	method    *capnp.Method // nil if this is bootstrap
 	paramCaps []exportID
 	resolved  chan struct{}
+	tail      bool // results are sent to the remote vat's answer
+
+	// Tail call state, set while holding onto conn.mu.  See returnTail.
+	tailOf       *answer // answer that the tail call resolved
+	tailReturned bool
+	tailFinished bool
+
+	// msg is the call message that asked the question, if it came from
+	// the connection's buffer pool.  It is released when the question is
+	// popped, since the remote vat has read it by the time it returns.
+	// Protected by conn.mu.
+	msg *capnp.Message
 
 	// Protected by conn.mu
 	derived [][]capnp.PipelineOp
 
+	// resultsFor is the answer that the question was asked to resolve,
+	// if the call was made by a Conn for a call it delivered.  It is
+	// set when the question is created.
+	resultsFor *answer
+
+	// ownsCaps is set before the question is resolved if its results
+	// came in a Return message, so the question holds references to the
+	// capabilities in them that no one else has.  See joinAnswer.
+	ownsCaps bool
+
 	// Fields below are protected by mu.
 	mu    sync.RWMutex
 	obj   capnp.Ptr
@@ -136,8 +157,9 @@ func (q *question) fulfill(obj capnp.Ptr) {
 		transformToPromisedAnswer(m.Segment(), pa, d)
 		mt.SetPromisedAnswer(pa)
 
+		q.conn.embargoEvent(EmbargoStart, id)
 		select {
-		case q.conn.out <- m:
+		case q.conn.out <- outgoing{msg: m}:
 		case <-q.conn.bg.Done():
 			// TODO(soon): perhaps just drop all embargoes in this case?
 		}
@@ -209,6 +231,12 @@ func transformsEqual(t, u []capnp.PipelineOp) bool {
 	return true
 }
 
+// Done returns a channel that is closed once the question is resolved,
+// including when the connection shuts down.
+func (q *question) Done() <-chan struct{} {
+	return q.resolved
+}
+
 func (q *question) Struct() (capnp.Struct, error) {
 	select {
 	case <-q.resolved:
@@ -221,6 +249,19 @@ func (q *question) Struct() (capnp.Struct, error) {
 	return s, err
 }
 
+// PipelineClientState reports capabilities in a question that hasn't
+// returned as unresolved and remote.  Once it returns, it reports the
+// state of the returned capability.
+func (q *question) PipelineClientState(transform []capnp.PipelineOp) (resolved bool, brand interface{}, remote bool) {
+	q.mu.RLock()
+	obj, err, state := q.obj, q.err, q.state
+	q.mu.RUnlock()
+	if state == questionInProgress {
+		return false, nil, true
+	}
+	return capnp.ClientState(clientFromResolution(transform, obj, err))
+}
+
 func (q *question) PipelineCall(transform []capnp.PipelineOp, ccall *capnp.Call) capnp.Answer {
 	select {
 	case <-q.conn.mu:
@@ -249,16 +290,24 @@ func (q *question) lockedPipelineCall(transform []capnp.PipelineOp, ccall *capnp
 		if state == questionInProgress {
 			panic("question popped but not done")
 		}
-		client := clientFromResolution(transform, obj, err)
+		if err != nil {
+			return capnp.ErrorAnswer(err)
+		}
+		client, err := capnp.TransformClient(obj, transform)
+		if err != nil {
+			return capnp.ErrorAnswer(pipelineop.Describe(q.method, err))
+		}
 		return q.conn.lockedCall(client, ccall)
 	}
 
 	pipeq := q.conn.newQuestion(ccall.Ctx, &ccall.Method)
-	msg := newMessage(nil)
+	msg := q.conn.newCallMessage()
+	pipeq.msg = msg.Segment().Message()
 	msgCall, _ := msg.NewCall()
 	msgCall.SetQuestionId(uint32(pipeq.id))
 	msgCall.SetInterfaceId(ccall.Method.InterfaceID)
 	msgCall.SetMethodId(ccall.Method.MethodID)
+	setSendResultsTo(msgCall, pipeq, ccall)
 	target, _ := msgCall.NewTarget()
 	a, _ := target.NewPromisedAnswer()
 	a.SetQuestionId(uint32(q.id))
@@ -269,12 +318,12 @@ func (q *question) lockedPipelineCall(transform []capnp.PipelineOp, ccall *capnp
 	}
 	payload, _ := msgCall.NewParams()
 	if err := q.conn.fillParams(payload, ccall); err != nil {
-		q.conn.popQuestion(q.id)
+		q.conn.popQuestion(pipeq.id)
 		return capnp.ErrorAnswer(err)
 	}
 
 	select {
-	case q.conn.out <- msg:
+	case q.conn.out <- outgoing{msg: msg}:
 	case <-ccall.Ctx.Done():
 		q.conn.popQuestion(pipeq.id)
 		return capnp.ErrorAnswer(ccall.Ctx.Err())
@@ -295,13 +344,9 @@ func (q *question) PipelineClose(transform []capnp.PipelineOp) error {
 	if err != nil {
 		return err
 	}
-	x, err := capnp.TransformPtr(obj, transform)
+	c, err := capnp.TransformClient(obj, transform)
 	if err != nil {
-		return err
-	}
-	c := x.Interface().Client()
-	if c == nil {
-		return capnp.ErrNullClient
+		return pipelineop.Describe(q.method, err)
 	}
 	return c.Close()
 }
@@ -313,9 +358,8 @@ type embargoClient struct {
 	client  capnp.Client
 	embargo embargo
 
-	mu    sync.RWMutex
-	q     queue.Queue
-	calls ecallList
+	mu sync.RWMutex
+	q  *queue.Queue[ecall]
 }
 
 func newEmbargoClient(client capnp.Client, e embargo, cancel <-chan struct{}) *embargoClient {
@@ -323,9 +367,8 @@ func newEmbargoClient(client capnp.Client, e embargo, cancel <-chan struct{}) *e
 		client:  client,
 		embargo: e,
 		cancel:  cancel,
-		calls:   make(ecallList, callQueueSize),
+		q:       queue.New[ecall](callQueueSize, nil),
 	}
-	ec.q.Init(ec.calls, 0)
 	go ec.flushQueue()
 	return ec
 }
@@ -336,11 +379,9 @@ func (ec *embargoClient) push(cl *capnp.Call) capnp.Answer {
 	if err != nil {
 		return capnp.ErrorAnswer(err)
 	}
-	i := ec.q.Push()
-	if i == -1 {
+	if ec.q.Push(ecall{cl, f}) != nil {
 		return capnp.ErrorAnswer(errQueueFull)
 	}
-	ec.calls[i] = ecall{cl, f}
 	return f
 }
 
@@ -383,10 +424,21 @@ func (ec *embargoClient) isPassthrough() bool {
 	return ec.q.Len() == 0
 }
 
+// ClientState returns the state of the client that the embargoed calls
+// are delivered to.
+func (ec *embargoClient) ClientState() (resolved bool, brand interface{}, remote bool) {
+	return capnp.ClientState(ec.client)
+}
+
+// QueueStats returns the statistics of the queue of calls waiting for
+// the embargo to be lifted.
+func (ec *embargoClient) QueueStats() queue.Stats {
+	return ec.q.Stats()
+}
+
 func (ec *embargoClient) Close() error {
 	ec.mu.Lock()
-	for ; ec.q.Len() > 0; ec.q.Pop() {
-		c := ec.calls[ec.q.Front()]
+	for c, ok := ec.q.Pop(); ok; c, ok = ec.q.Pop() {
 		c.f.Reject(errQueueCallCancel)
 	}
 	ec.mu.Unlock()
@@ -405,11 +457,8 @@ func (ec *embargoClient) flushQueue() {
 		ec.mu.Unlock()
 		return
 	}
-	var c ecall
 	ec.mu.RLock()
-	if i := ec.q.Front(); i != -1 {
-		c = ec.calls[i]
-	}
+	c, _ := ec.q.Front()
 	ec.mu.RUnlock()
 	for c.call != nil {
 		ans := ec.client.Call(c.call)
@@ -417,11 +466,7 @@ func (ec *embargoClient) flushQueue() {
 
 		ec.mu.Lock()
 		ec.q.Pop()
-		if i := ec.q.Front(); i != -1 {
-			c = ec.calls[i]
-		} else {
-			c = ecall{}
-		}
+		c, _ = ec.q.Front()
 		ec.mu.Unlock()
 	}
 }
@@ -430,13 +475,3 @@ type ecall struct {
 	call *capnp.Call
 	f    *fulfiller.Fulfiller
 }
-
-type ecallList []ecall
-
-func (el ecallList) Len() int {
-	return len(el)
-}
-
-func (el ecallList) Clear(i int) {
-	el[i] = ecall{}
-}
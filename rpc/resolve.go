@@ -0,0 +1,284 @@
+package rpc
+
+import (
+	"errors"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/fulfiller"
+	"github.com/iguazio/go-capnproto2/rpc/internal/refcount"
+	rpccapnp "github.com/iguazio/go-capnproto2/std/capnp/rpc"
+)
+
+// A promise is an unresolved client that can be exported as a
+// senderPromise, because the connection can find out when and to what
+// it resolves.
+type promise struct {
+	done <-chan struct{}
+
+	// resolve returns the client that the promise resolved to.  It
+	// must only be called after done is closed.
+	resolve func() (capnp.Client, error)
+}
+
+// promiseFor returns the promise that client is, or nil if client is
+// not an unresolved promise.  The caller must be holding onto c.mu.
+func promiseFor(client capnp.Client) *promise {
+	for {
+		ref, ok := client.(*refcount.Ref)
+		if !ok {
+			break
+		}
+		client = ref.Client()
+	}
+	switch ct := client.(type) {
+	case *localAnswerClient:
+		ct.a.mu.RLock()
+		done, tail := ct.a.done, ct.a.tail
+		ct.a.mu.RUnlock()
+		if done || tail != nil {
+			return nil
+		}
+		return &promise{
+			done: ct.a.resolved,
+			resolve: func() (capnp.Client, error) {
+				ct.a.mu.RLock()
+				obj, err, tail := ct.a.obj, ct.a.err, ct.a.tail
+				ct.a.mu.RUnlock()
+				if tail != nil {
+					// descriptorForClient refers to the tail call.
+					return ct, nil
+				}
+				return resolution(ct.transform, obj, err)
+			},
+		}
+	case *capnp.PipelineClient:
+		p := (*capnp.Pipeline)(ct)
+		transform := p.Transform()
+		switch ans := p.Answer().(type) {
+		case *fulfiller.Fulfiller:
+			if ans.Peek() != nil {
+				return nil
+			}
+			return &promise{
+				done: ans.Done(),
+				resolve: func() (capnp.Client, error) {
+					s, err := ans.Struct()
+					return resolution(transform, s.ToPtr(), err)
+				},
+			}
+		case *question:
+			ans.mu.RLock()
+			state := ans.state
+			ans.mu.RUnlock()
+			if state != questionInProgress {
+				return nil
+			}
+			return &promise{
+				done: ans.resolved,
+				resolve: func() (capnp.Client, error) {
+					ans.mu.RLock()
+					obj, err := ans.obj, ans.err
+					ans.mu.RUnlock()
+					return resolution(transform, obj, err)
+				},
+			}
+		}
+	}
+	return nil
+}
+
+// resolution is like clientFromResolution, but returns the error
+// instead of an error client.
+func resolution(transform []capnp.PipelineOp, obj capnp.Ptr, err error) (capnp.Client, error) {
+	if err != nil {
+		return nil, err
+	}
+	return capnp.TransformClient(obj, transform)
+}
+
+// watchPromise marks the export as a promise and sends a Resolve
+// message once p resolves.  It reports whether the export is a promise,
+// which is false if the connection is shutting down.  The caller must
+// be holding onto c.mu.
+func (c *Conn) watchPromise(id exportID, p *promise) bool {
+	e := c.findExport(id)
+	if e.promise {
+		// Already being watched.
+		return true
+	}
+	if err := c.startWork(); err != nil {
+		return false
+	}
+	e.promise = true
+	go func() {
+		defer c.workers.Done()
+		select {
+		case <-p.done:
+		case <-c.bg.Done():
+			return
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.findExport(id) != e {
+			// Released before it resolved.
+			return
+		}
+		msg := newMessage(nil)
+		res, _ := msg.NewResolve()
+		res.SetPromiseId(uint32(id))
+		if client, err := p.resolve(); err != nil {
+			exc, _ := res.NewException()
+			toException(exc, err)
+		} else {
+			desc, _ := res.NewCap()
+			if err := c.descriptorForClient(desc, client); err != nil {
+				exc, _ := res.NewException()
+				toException(exc, err)
+			}
+		}
+		c.sendMessage(msg)
+	}()
+	return true
+}
+
+// handleResolveMessage handles the remote vat resolving a promise that
+// it exported as a senderPromise.  Calls on the import are then sent to
+// the resolution.  If the promise resolved to a capability in this vat,
+// calls are held by an embargo until the calls already sent to the
+// promise have been delivered.  The caller is holding onto c.mu.
+func (c *Conn) handleResolveMessage(m rpccapnp.Message) error {
+	res, err := m.Resolve()
+	if err != nil {
+		return err
+	}
+	id := importID(res.PromiseId())
+	var client capnp.Client
+	local := false
+	switch res.Which() {
+	case rpccapnp.Resolve_Which_cap:
+		desc, err := res.Cap()
+		if err != nil {
+			return err
+		}
+		client, err = c.clientForDescriptor(desc)
+		if err != nil {
+			return err
+		}
+		if client == nil {
+			client = capnp.ErrorClient(capnp.ErrNullClient)
+		}
+		switch desc.Which() {
+		case rpccapnp.CapDescriptor_Which_receiverHosted, rpccapnp.CapDescriptor_Which_receiverAnswer:
+			local = true
+		}
+	case rpccapnp.Resolve_Which_exception:
+		exc, err := res.Exception()
+		if err != nil {
+			return err
+		}
+		client = capnp.ErrorClient(Exception{exc})
+	default:
+		um := newUnimplementedMessage(nil, m)
+		return c.sendMessage(um)
+	}
+
+	ent := c.imports[id]
+	if ent == nil {
+		// The import was released before the resolution arrived.
+		go client.Close()
+		return nil
+	}
+	ic := ent.rc.Client.(*importClient)
+	if ic.resolved != nil {
+		go client.Close()
+		return errResolvedTwice
+	}
+	if local {
+		eid, e := c.newEmbargo()
+		client = newEmbargoClient(client, e, c.bg.Done())
+		dm := newDisembargoMessage(nil, rpccapnp.Disembargo_context_Which_senderLoopback, eid)
+		dis, _ := dm.Disembargo()
+		mt, _ := dis.NewTarget()
+		mt.SetImportedCap(uint32(id))
+		c.embargoEvent(EmbargoStart, eid)
+		if err := c.sendMessage(dm); err != nil {
+			return err
+		}
+	}
+	ic.stateMu.Lock()
+	ic.resolved = client
+	ic.stateMu.Unlock()
+	return nil
+}
+
+// disembargoPromise handles a senderLoopback disembargo that targets an
+// exported promise, which the remote vat sends after the promise
+// resolved to one of its own capabilities.  The caller is holding onto
+// c.mu.
+func (c *Conn) disembargoPromise(id embargoID, target rpccapnp.MessageTarget) error {
+	e := c.findExport(exportID(target.ImportedCap()))
+	if e == nil || !e.promise {
+		return errDisembargoNonImport
+	}
+	// TODO(soon): calls that the promise is still flushing to its
+	// resolution could be overtaken by the echo, so a resolution that
+	// still queues calls is rejected like a non-import.
+	client, err := resolvedExport(e.rc.Client)
+	if err != nil {
+		return err
+	}
+	if ic := isImport(client); ic == nil || ic.conn != c {
+		return errDisembargoNonImport
+	}
+	resp := newDisembargoMessage(nil, rpccapnp.Disembargo_context_Which_receiverLoopback, id)
+	rd, _ := resp.Disembargo()
+	if err := rd.SetTarget(target); err != nil {
+		return err
+	}
+	c.embargoEvent(EmbargoEcho, id)
+	return c.sendMessage(resp)
+}
+
+// resolvedExport returns the client that an exported promise resolved
+// to.
+func resolvedExport(client capnp.Client) (capnp.Client, error) {
+	for {
+		ref, ok := client.(*refcount.Ref)
+		if !ok {
+			break
+		}
+		client = ref.Client()
+	}
+	switch ct := client.(type) {
+	case *localAnswerClient:
+		ct.a.mu.RLock()
+		obj, err, done := ct.a.obj, ct.a.err, ct.a.done
+		ct.a.mu.RUnlock()
+		if !done {
+			return nil, errDisembargoNonImport
+		}
+		return resolution(ct.transform, obj, err)
+	case *capnp.PipelineClient:
+		p := (*capnp.Pipeline)(ct)
+		switch ans := p.Answer().(type) {
+		case *fulfiller.Fulfiller:
+			ap := ans.Peek()
+			if ap == nil {
+				return nil, errDisembargoNonImport
+			}
+			s, err := ap.Struct()
+			return resolution(p.Transform(), s.ToPtr(), err)
+		case *question:
+			ans.mu.RLock()
+			obj, err, state := ans.obj, ans.err, ans.state
+			ans.mu.RUnlock()
+			if state == questionInProgress {
+				return nil, errDisembargoNonImport
+			}
+			return resolution(p.Transform(), obj, err)
+		}
+	}
+	return nil, errDisembargoNonImport
+}
+
+var errResolvedTwice = errors.New("rpc: resolve received for an already resolved promise")
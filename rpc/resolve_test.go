@@ -0,0 +1,248 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/internal/fulfiller"
+	"github.com/iguazio/go-capnproto2/rpc"
+	rpccapnp "github.com/iguazio/go-capnproto2/std/capnp/rpc"
+)
+
+func TestSendPromiseResolve(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	f := new(fulfiller.Fulfiller)
+	main := capnp.NewPipeline(f).GetPipeline(0).Client()
+	conn, p := newUnpairedConn(t, rpc.MainInterface(main))
+	defer conn.Close()
+	defer p.Close()
+
+	const bootstrapID = 5
+	err := sendMessage(ctx, p, func(msg rpccapnp.Message) error {
+		boot, err := msg.NewBootstrap()
+		if err != nil {
+			return err
+		}
+		boot.SetQuestionId(bootstrapID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal("sending bootstrap:", err)
+	}
+	desc := recvReturnCap(t, ctx, p, bootstrapID)
+	if desc.Which() != rpccapnp.CapDescriptor_Which_senderPromise {
+		t.Fatalf("bootstrap capability is %v; want senderPromise", desc.Which())
+	}
+	promiseID := desc.SenderPromise()
+
+	_, s, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := capnp.NewRootStruct(s, capnp.ObjectSize{PointerCount: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := capnp.NewInterface(s, s.Message().AddCap(mockClient()))
+	if err := result.SetPtr(0, in.ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+	f.Fulfill(result)
+
+	msg, err := p.RecvMessage(ctx)
+	if err != nil {
+		t.Fatal("reading resolve:", err)
+	}
+	if msg.Which() != rpccapnp.Message_Which_resolve {
+		t.Fatalf("conn sent %v; want resolve", msg.Which())
+	}
+	res, err := msg.Resolve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := res.PromiseId(); id != promiseID {
+		t.Errorf("resolve promise ID = %d; want %d", id, promiseID)
+	}
+	if res.Which() != rpccapnp.Resolve_Which_cap {
+		t.Fatalf("resolve is %v; want cap", res.Which())
+	}
+	rdesc, err := res.Cap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rdesc.Which() != rpccapnp.CapDescriptor_Which_senderHosted {
+		t.Errorf("resolved capability is %v; want senderHosted", rdesc.Which())
+	} else if rdesc.SenderHosted() == promiseID {
+		t.Errorf("resolved capability has the promise's export ID %d", promiseID)
+	}
+}
+
+func TestReceiveResolveToLocal(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	called := make(chan struct{}, 1)
+	main := stubClient(func(ctx context.Context, params capnp.Struct) (capnp.Struct, error) {
+		called <- struct{}{}
+		_, s, err := capnp.NewMessage(capnp.SingleSegment(nil))
+		if err != nil {
+			return capnp.Struct{}, err
+		}
+		return capnp.NewRootStruct(s, capnp.ObjectSize{})
+	})
+	conn, p := newUnpairedConn(t, rpc.MainInterface(main))
+	defer conn.Close()
+	defer p.Close()
+	client := bootstrapAndFulfill(t, ctx, conn, p, true)
+	importID := sendBootstrapAndFinish(t, p)
+
+	// The remote vat's bootstrap promise resolves to conn's own bootstrap.
+	err := sendMessage(ctx, p, func(msg rpccapnp.Message) error {
+		res, err := msg.NewResolve()
+		if err != nil {
+			return err
+		}
+		res.SetPromiseId(bootstrapExportID)
+		desc, err := res.NewCap()
+		if err != nil {
+			return err
+		}
+		desc.SetReceiverHosted(importID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal("sending resolve:", err)
+	}
+	msg, err := p.RecvMessage(ctx)
+	if err != nil {
+		t.Fatal("reading disembargo:", err)
+	}
+	if msg.Which() != rpccapnp.Message_Which_disembargo {
+		t.Fatalf("conn sent %v; want disembargo", msg.Which())
+	}
+	dis, err := msg.Disembargo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := dis.Context().Which(); w != rpccapnp.Disembargo_context_Which_senderLoopback {
+		t.Fatalf("disembargo context is %v; want senderLoopback", w)
+	}
+	target, err := dis.Target()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target.Which() != rpccapnp.MessageTarget_Which_importedCap || target.ImportedCap() != bootstrapExportID {
+		t.Errorf("disembargo target = %v %d; want importedCap %d", target.Which(), target.ImportedCap(), bootstrapExportID)
+	}
+
+	ans := client.Call(&capnp.Call{
+		Ctx:        ctx,
+		Method:     capnp.Method{InterfaceID: interfaceID, MethodID: methodID},
+		ParamsSize: capnp.ObjectSize{},
+		ParamsFunc: func(capnp.Struct) error { return nil },
+	})
+	select {
+	case <-called:
+		t.Fatal("call delivered before disembargo")
+	default:
+	}
+	err = sendMessage(ctx, p, func(msg rpccapnp.Message) error {
+		d, err := msg.NewDisembargo()
+		if err != nil {
+			return err
+		}
+		d.Context().SetReceiverLoopback(dis.Context().SenderLoopback())
+		return d.SetTarget(target)
+	})
+	if err != nil {
+		t.Fatal("sending disembargo:", err)
+	}
+	if _, err := ans.Struct(); err != nil {
+		t.Fatal("call error:", err)
+	}
+	select {
+	case <-called:
+	default:
+		t.Error("call was not delivered locally")
+	}
+}
+
+func TestReceiveThirdPartyHosted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conn, p := newUnpairedConn(t)
+	defer conn.Close()
+	defer p.Close()
+	client, bootstrapID := readBootstrap(t, ctx, conn, p)
+
+	const vineID = 17
+	err := sendMessage(ctx, p, func(msg rpccapnp.Message) error {
+		ret, err := msg.NewReturn()
+		if err != nil {
+			return err
+		}
+		ret.SetAnswerId(bootstrapID)
+		payload, err := ret.NewResults()
+		if err != nil {
+			return err
+		}
+		payload.SetContent(capnp.NewInterface(msg.Segment(), 0))
+		ctab, err := payload.NewCapTable(1)
+		if err != nil {
+			return err
+		}
+		tp, err := ctab.At(0).NewThirdPartyHosted()
+		if err != nil {
+			return err
+		}
+		tp.SetVineId(vineID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal("sending bootstrap return:", err)
+	}
+	if err := recvFinish(ctx, p, bootstrapID); err != nil {
+		t.Fatal(err)
+	}
+
+	client.Call(&capnp.Call{
+		Ctx:        ctx,
+		Method:     capnp.Method{InterfaceID: interfaceID, MethodID: methodID},
+		ParamsSize: capnp.ObjectSize{},
+		ParamsFunc: func(capnp.Struct) error { return nil },
+	})
+	msg, err := p.RecvMessage(ctx)
+	if err != nil {
+		t.Fatal("reading call:", err)
+	}
+	if msg.Which() != rpccapnp.Message_Which_call {
+		t.Fatalf("conn sent %v; want call", msg.Which())
+	}
+	call, _ := msg.Call()
+	target, _ := call.Target()
+	if target.Which() != rpccapnp.MessageTarget_Which_importedCap || target.ImportedCap() != vineID {
+		t.Errorf("call target = %v %d; want importedCap %d", target.Which(), target.ImportedCap(), vineID)
+	}
+}
+
+// recvReturnCap reads a return whose results are a single capability
+// and returns its descriptor.
+func recvReturnCap(t *testing.T, ctx context.Context, p rpc.Transport, answerID uint32) rpccapnp.CapDescriptor {
+	ret := recvReturn(t, ctx, p, answerID)
+	if ret.Which() != rpccapnp.Return_Which_results {
+		t.Fatalf("return is %v; want results", ret.Which())
+	}
+	payload, err := ret.Results()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctab, err := payload.CapTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ctab.Len() != 1 {
+		t.Fatalf("return has %d capabilities; want 1", ctab.Len())
+	}
+	return ctab.At(0)
+}
@@ -4,11 +4,13 @@ package rpc // import "github.com/iguazio/go-capnproto2/rpc"
 import (
 	"fmt"
 	"io"
+	"net"
 	"sync"
 
 	"golang.org/x/net/context"
 	"github.com/iguazio/go-capnproto2"
 	"github.com/iguazio/go-capnproto2/rpc/internal/refcount"
+	"github.com/iguazio/go-capnproto2/schemas"
 	rpccapnp "github.com/iguazio/go-capnproto2/std/capnp/rpc"
 )
 
@@ -17,11 +19,16 @@ import (
 type Conn struct {
 	transport  Transport
 	log        Logger
+	remoteAddr net.Addr // nil if the transport doesn't have one
 	mainFunc   func(context.Context) (capnp.Client, error)
 	mainCloser io.Closer
-	death      chan struct{} // closed after state is connDead
+	bufs       *capnp.BufferPool // nil if call messages are not pooled
+	death      chan struct{}     // closed after state is connDead
+	mem        memBudget
 
-	out chan rpccapnp.Message
+	embargoHook func(EmbargoStage, uint32) // nil if not set
+
+	out chan outgoing
 
 	bg       context.Context
 	bgCancel context.CancelFunc
@@ -35,11 +42,11 @@ type Conn struct {
 
 	// Mutable state protected by mu
 	mu         chanMutex
-	questions  []*question
+	questions  map[questionID]*question
 	questionID idgen
-	exports    []*export
+	exports    map[exportID]*export
 	exportID   idgen
-	embargoes  []chan<- struct{}
+	embargoes  map[embargoID]chan<- struct{}
 	embargoID  idgen
 	answers    map[answerID]*answer
 	imports    map[importID]*impent
@@ -50,6 +57,9 @@ type connParams struct {
 	mainFunc       func(context.Context) (capnp.Client, error)
 	mainCloser     io.Closer
 	sendBufferSize int
+	bufs           *capnp.BufferPool
+	memLimit       int64
+	embargoHook    func(EmbargoStage, uint32)
 }
 
 // A ConnOption is an option for opening a connection.
@@ -89,12 +99,29 @@ func SendBufferSize(numMsgs int) ConnOption {
 	}}
 }
 
+// CallBufferPool sets the pool that the connection takes outgoing call
+// message buffers from.  A call's buffer, which also holds its
+// parameters, goes back to the pool once the call's question completes.
+// The pool may be shared between connections.  Passing nil disables
+// pooling, so that every call allocates a new message.  By default,
+// each connection has its own pool.
+func CallBufferPool(pool *capnp.BufferPool) ConnOption {
+	return ConnOption{func(c *connParams) {
+		c.bufs = pool
+	}}
+}
+
+// defaultCallBufferMax is the largest buffer kept by a connection's
+// default call buffer pool.
+const defaultCallBufferMax = 8 << 10
+
 // NewConn creates a new connection that communicates on c.
 // Closing the connection will cause c to be closed.
 func NewConn(t Transport, options ...ConnOption) *Conn {
 	p := &connParams{
 		log:            defaultLogger{},
 		sendBufferSize: 4,
+		bufs:           capnp.NewBufferPool(defaultCallBufferMax),
 	}
 	for _, o := range options {
 		o.f(p)
@@ -102,17 +129,25 @@ func NewConn(t Transport, options ...ConnOption) *Conn {
 
 	conn := &Conn{
 		transport:  t,
-		out:        make(chan rpccapnp.Message, p.sendBufferSize),
+		out:        make(chan outgoing, p.sendBufferSize),
 		mainFunc:   p.mainFunc,
 		mainCloser: p.mainCloser,
+		bufs:       p.bufs,
 		log:        p.log,
 		death:      make(chan struct{}),
 		mu:         newChanMutex(),
+		mem:        memBudget{limit: p.memLimit},
+
+		embargoHook: p.embargoHook,
+	}
+	if ra, ok := t.(remoteAddrer); ok {
+		conn.remoteAddr = ra.RemoteAddr()
 	}
 	conn.bg, conn.bgCancel = context.WithCancel(context.Background())
 	conn.workers.Add(2)
 	go conn.dispatchRecv()
 	go conn.dispatchSend()
+	conn.logEvent(Event{Kind: EventConnOpen})
 	return conn
 }
 
@@ -133,7 +168,7 @@ func (c *Conn) Done() <-chan struct{} {
 func (c *Conn) Err() error {
 	c.stateMu.RLock()
 	var err error
-	if c.state != connDead {
+	if c.state == connDead {
 		err = c.closeErr
 	}
 	c.stateMu.RUnlock()
@@ -216,16 +251,16 @@ func (c *Conn) teardown(abort rpccapnp.Message) {
 
 	c.mu.Lock()
 	for _, q := range c.questions {
-		if q != nil {
-			q.cancel(ErrConnClosed)
-		}
+		q.cancel(ErrConnClosed)
 	}
 	c.questions = nil
 	exps := c.exports
 	c.exports = nil
 	c.embargoes = nil
+	var owned []capnp.Client
 	for _, a := range c.answers {
 		a.cancel()
+		owned = append(owned, a.takeOwnedCaps()...)
 	}
 	c.answers = nil
 	c.imports = nil
@@ -240,10 +275,10 @@ func (c *Conn) teardown(abort rpccapnp.Message) {
 	}
 	// Closing an export may try to lock the Conn, so run it outside
 	// critical section.
+	for _, client := range owned {
+		client.Close()
+	}
 	for id, e := range exps {
-		if e == nil {
-			continue
-		}
 		if err := e.client.Close(); err != nil {
 			c.errorf("export %v close: %v", id, err)
 		}
@@ -265,8 +300,10 @@ func (c *Conn) teardown(abort rpccapnp.Message) {
 		}
 	}
 	c.state = connDead
-	close(c.death)
+	closeErr := c.closeErr
 	c.stateMu.Unlock()
+	c.logEvent(Event{Kind: EventConnClose, Err: closeErr})
+	close(c.death)
 }
 
 // Bootstrap returns the receiver's main interface.
@@ -294,7 +331,7 @@ func (c *Conn) Bootstrap(ctx context.Context) capnp.Client {
 	// Worst case, this blocks until a message is sent on the transport.
 	// Common case, this just adds to the channel queue.
 	select {
-	case c.out <- msg:
+	case c.out <- outgoing{msg: msg}:
 		q.start()
 		return capnp.NewPipeline(q).Client()
 	case <-ctx.Done():
@@ -312,14 +349,22 @@ func (c *Conn) Bootstrap(ctx context.Context) capnp.Client {
 func (c *Conn) handleMessage(m rpccapnp.Message) {
 	switch m.Which() {
 	case rpccapnp.Message_Which_unimplemented:
-		// no-op for now to avoid feedback loop
+		// Never reply to an unimplemented message, to avoid a feedback loop.
+		um, err := m.Unimplemented()
+		if err != nil {
+			c.decodeError("unimplemented", err)
+			return
+		}
+		c.mu.Lock()
+		c.handleUnimplementedMessage(um)
+		c.mu.Unlock()
 	case rpccapnp.Message_Which_abort:
 		a, err := copyAbort(m)
 		if err != nil {
-			c.errorf("decode abort: %v", err)
+			c.decodeError("abort", err)
 			// Keep going, since we're trying to abort anyway.
 		}
-		c.infof("abort: %v", a)
+		c.logEvent(Event{Kind: EventAbort, Err: a})
 		c.shutdown(a)
 	case rpccapnp.Message_Which_return:
 		m = copyRPCMessage(m)
@@ -333,7 +378,7 @@ func (c *Conn) handleMessage(m rpccapnp.Message) {
 	case rpccapnp.Message_Which_finish:
 		mfin, err := m.Finish()
 		if err != nil {
-			c.errorf("decode finish: %v", err)
+			c.decodeError("finish", err)
 			return
 		}
 		id := answerID(mfin.QuestionId())
@@ -345,17 +390,29 @@ func (c *Conn) handleMessage(m rpccapnp.Message) {
 			c.errorf("finish called for unknown answer %d", id)
 			return
 		}
-		a.cancel()
+		a.mu.RLock()
+		tail := a.tail
+		a.mu.RUnlock()
+		if tail != nil {
+			// Canceled once the tail call finishes.
+			c.finishAnswerTail(tail)
+		} else {
+			a.cancel()
+		}
 		if mfin.ReleaseResultCaps() {
 			for _, id := range a.resultCaps {
 				c.releaseExport(id, 1)
 			}
 		}
+		owned := a.takeOwnedCaps()
 		c.mu.Unlock()
+		for _, client := range owned {
+			client.Close()
+		}
 	case rpccapnp.Message_Which_bootstrap:
 		boot, err := m.Bootstrap()
 		if err != nil {
-			c.errorf("decode bootstrap: %v", err)
+			c.decodeError("bootstrap", err)
 			return
 		}
 		id := answerID(boot.QuestionId())
@@ -379,7 +436,7 @@ func (c *Conn) handleMessage(m rpccapnp.Message) {
 	case rpccapnp.Message_Which_release:
 		rel, err := m.Release()
 		if err != nil {
-			c.errorf("decode release: %v", err)
+			c.decodeError("release", err)
 			return
 		}
 		id := exportID(rel.Id())
@@ -388,6 +445,15 @@ func (c *Conn) handleMessage(m rpccapnp.Message) {
 		c.mu.Lock()
 		c.releaseExport(id, refs)
 		c.mu.Unlock()
+	case rpccapnp.Message_Which_resolve:
+		m = copyRPCMessage(m)
+		c.mu.Lock()
+		err := c.handleResolveMessage(m)
+		c.mu.Unlock()
+
+		if err != nil {
+			c.errorf("handle resolve: %v", err)
+		}
 	case rpccapnp.Message_Which_disembargo:
 		m = copyRPCMessage(m)
 		c.mu.Lock()
@@ -405,6 +471,44 @@ func (c *Conn) handleMessage(m rpccapnp.Message) {
 	}
 }
 
+// handleUnimplementedMessage handles the remote vat echoing back a
+// message that it did not understand.  A call or bootstrap that the
+// remote vat can't handle fails its question as if the remote vat had
+// returned an exception.  The caller is holding onto c.mu.
+func (c *Conn) handleUnimplementedMessage(um rpccapnp.Message) {
+	var id questionID
+	switch um.Which() {
+	case rpccapnp.Message_Which_call:
+		call, err := um.Call()
+		if err != nil {
+			c.decodeError("unimplemented call", err)
+			return
+		}
+		id = questionID(call.QuestionId())
+	case rpccapnp.Message_Which_bootstrap:
+		boot, err := um.Bootstrap()
+		if err != nil {
+			c.decodeError("unimplemented bootstrap", err)
+			return
+		}
+		id = questionID(boot.QuestionId())
+	default:
+		c.infof("remote did not implement %v message", um.Which())
+		return
+	}
+	q := c.popQuestion(id)
+	if q == nil {
+		c.errorf("received unimplemented for unknown question id=%d", id)
+		return
+	}
+	q.mu.RLock()
+	qstate := q.state
+	q.mu.RUnlock()
+	if qstate == questionInProgress {
+		q.reject(&questionError{id: id, method: q.method, err: errRemoteUnimpl})
+	}
+}
+
 func newUnimplementedMessage(buf []byte, m rpccapnp.Message) rpccapnp.Message {
 	n := newMessage(buf)
 	n.SetUnimplemented(m)
@@ -449,10 +553,15 @@ func (c *Conn) handleReturnMessage(m rpccapnp.Message) error {
 		return err
 	}
 	id := questionID(ret.AnswerId())
-	q := c.popQuestion(id)
+	q := c.findQuestion(id)
 	if q == nil {
 		return fmt.Errorf("received return for unknown question id=%d", id)
 	}
+	if q.tail && ret.Which() == rpccapnp.Return_Which_resultsSentElsewhere {
+		c.returnTail(q, ret)
+		return nil
+	}
+	c.popQuestion(id)
 	if ret.ReleaseParamCaps() {
 		for _, id := range q.paramCaps {
 			c.releaseExport(id, 1)
@@ -476,6 +585,7 @@ func (c *Conn) handleReturnMessage(m rpccapnp.Message) error {
 		if err := c.populateMessageCapTable(results); err == errUnimplemented {
 			um := newUnimplementedMessage(nil, m)
 			c.sendMessage(um)
+			q.reject(&questionError{id: id, method: q.method, err: errUnimplemented})
 			return errUnimplemented
 		} else if err != nil {
 			c.abort(err)
@@ -485,6 +595,7 @@ func (c *Conn) handleReturnMessage(m rpccapnp.Message) error {
 		if err != nil {
 			return err
 		}
+		q.ownsCaps = true
 		q.fulfill(content)
 	case rpccapnp.Return_Which_exception:
 		exc, err := ret.Exception()
@@ -501,6 +612,13 @@ func (c *Conn) handleReturnMessage(m rpccapnp.Message) error {
 			e = bootstrapError{e}
 		}
 		q.reject(e)
+	case rpccapnp.Return_Which_resultsSentElsewhere:
+		q.reject(&questionError{id: id, method: q.method, err: errResultsSentElsewhere})
+	case rpccapnp.Return_Which_takeFromOtherQuestion:
+		releaseResultCaps = false
+		if err := c.takeFromAnswer(q, answerID(ret.TakeFromOtherQuestion())); err != nil {
+			c.errorf("%v", &questionError{id: id, method: q.method, err: err})
+		}
 	case rpccapnp.Return_Which_canceled:
 		err := &questionError{
 			id:     id,
@@ -513,6 +631,7 @@ func (c *Conn) handleReturnMessage(m rpccapnp.Message) error {
 	default:
 		um := newUnimplementedMessage(nil, m)
 		c.sendMessage(um)
+		q.reject(&questionError{id: id, method: q.method, err: errUnimplemented})
 		return errUnimplemented
 	}
 	fin := newFinishMessage(nil, id, releaseResultCaps)
@@ -531,65 +650,83 @@ func newFinishMessage(buf []byte, questionID questionID, release bool) rpccapnp.
 // populateMessageCapTable converts the descriptors in the payload into
 // clients and sets it on the message the payload is a part of.
 func (c *Conn) populateMessageCapTable(payload rpccapnp.Payload) error {
+	if payload.Segment() == nil {
+		// Null payload: there are no capabilities to populate.
+		return nil
+	}
 	msg := payload.Segment().Message()
 	ctab, err := payload.CapTable()
 	if err != nil {
 		return err
 	}
 	for i, n := 0, ctab.Len(); i < n; i++ {
-		desc := ctab.At(i)
-		switch desc.Which() {
-		case rpccapnp.CapDescriptor_Which_none:
-			msg.AddCap(nil)
-		case rpccapnp.CapDescriptor_Which_senderHosted:
-			id := importID(desc.SenderHosted())
-			client := c.addImport(id)
-			msg.AddCap(client)
-		case rpccapnp.CapDescriptor_Which_senderPromise:
-			// We do the same thing as senderHosted, above. @kentonv suggested this on
-			// issue #2; this let's messages be delivered properly, although it's a bit
-			// of a hack, and as Kenton describes, it has some disadvantages:
-			//
-			// > * Apps sometimes want to wait for promise resolution, and to find out if
-			// >   it resolved to an exception. You won't be able to provide that API. But,
-			// >   usually, it isn't needed.
-			// > * If the promise resolves to a capability hosted on the receiver,
-			// >   messages sent to it will uselessly round-trip over the network
-			// >   rather than being delivered locally.
-			id := importID(desc.SenderPromise())
-			client := c.addImport(id)
-			msg.AddCap(client)
-		case rpccapnp.CapDescriptor_Which_receiverHosted:
-			id := exportID(desc.ReceiverHosted())
-			e := c.findExport(id)
-			if e == nil {
-				return fmt.Errorf("rpc: capability table references unknown export ID %d", id)
-			}
-			msg.AddCap(e.rc.Ref())
-		case rpccapnp.CapDescriptor_Which_receiverAnswer:
-			recvAns, err := desc.ReceiverAnswer()
-			if err != nil {
-				return err
-			}
-			id := answerID(recvAns.QuestionId())
-			a := c.answers[id]
-			if a == nil {
-				return fmt.Errorf("rpc: capability table references unknown answer ID %d", id)
-			}
-			recvTransform, err := recvAns.Transform()
-			if err != nil {
-				return err
-			}
-			transform := promisedAnswerOpsToTransform(recvTransform)
-			msg.AddCap(a.pipelineClient(transform))
-		default:
-			c.errorf("unknown capability type %v", desc.Which())
-			return errUnimplemented
+		client, err := c.clientForDescriptor(ctab.At(i))
+		if err != nil {
+			return err
 		}
+		msg.AddCap(client)
 	}
 	return nil
 }
 
+// clientForDescriptor returns the client that desc refers to, adding
+// it to the import table if necessary.  A none descriptor is a nil
+// client.  The caller must be holding onto c.mu.
+func (c *Conn) clientForDescriptor(desc rpccapnp.CapDescriptor) (capnp.Client, error) {
+	switch desc.Which() {
+	case rpccapnp.CapDescriptor_Which_none:
+		return nil, nil
+	case rpccapnp.CapDescriptor_Which_senderHosted:
+		id := importID(desc.SenderHosted())
+		return c.addImport(id), nil
+	case rpccapnp.CapDescriptor_Which_senderPromise:
+		// Calls are sent to the promise until the remote vat resolves it
+		// with a Resolve message.
+		id := importID(desc.SenderPromise())
+		client := c.addImport(id)
+		ic := c.imports[id].rc.Client.(*importClient)
+		ic.stateMu.Lock()
+		ic.promise = true
+		ic.stateMu.Unlock()
+		return client, nil
+	case rpccapnp.CapDescriptor_Which_receiverHosted:
+		id := exportID(desc.ReceiverHosted())
+		e := c.findExport(id)
+		if e == nil {
+			return nil, fmt.Errorf("rpc: capability table references unknown export ID %d", id)
+		}
+		return e.rc.Ref(), nil
+	case rpccapnp.CapDescriptor_Which_receiverAnswer:
+		recvAns, err := desc.ReceiverAnswer()
+		if err != nil {
+			return nil, err
+		}
+		id := answerID(recvAns.QuestionId())
+		a := c.answers[id]
+		if a == nil {
+			return nil, fmt.Errorf("rpc: capability table references unknown answer ID %d", id)
+		}
+		recvTransform, err := recvAns.Transform()
+		if err != nil {
+			return nil, err
+		}
+		transform := promisedAnswerOpsToTransform(recvTransform)
+		return a.pipelineClient(transform), nil
+	case rpccapnp.CapDescriptor_Which_thirdPartyHosted:
+		// Three-party handoff is not implemented, so use the vine: a
+		// capability hosted by the sender that proxies to the third
+		// party.
+		tp, err := desc.ThirdPartyHosted()
+		if err != nil {
+			return nil, err
+		}
+		return c.addImport(importID(tp.VineId())), nil
+	default:
+		c.errorf("unknown capability type %v", desc.Which())
+		return nil, errUnimplemented
+	}
+}
+
 // makeCapTable converts the clients in the segment's message into capability descriptors.
 func (c *Conn) makeCapTable(s *capnp.Segment) (rpccapnp.CapDescriptor_List, error) {
 	msgtab := s.Message().CapTable
@@ -637,6 +774,15 @@ func (c *Conn) handleBootstrapMessage(id answerID) error {
 	return a.fulfill(in.ToPtr())
 }
 
+// namedMethod returns the method with the given IDs, with its names
+// filled in from schemas.DefaultRegistry if its interface is registered,
+// so that logs and errors about received calls can name the method.
+func namedMethod(interfaceID uint64, methodID uint16) capnp.Method {
+	m := capnp.Method{InterfaceID: interfaceID, MethodID: methodID}
+	m.InterfaceName, m.MethodName, _ = schemas.DefaultRegistry.MethodNames(interfaceID, methodID)
+	return m
+}
+
 // handleCallMessage handles a received call message.  It mutates the
 // capability table of its parameter.  The caller holds onto c.mu.
 func (c *Conn) handleCallMessage(m rpccapnp.Message) error {
@@ -671,10 +817,19 @@ func (c *Conn) handleCallMessage(m rpccapnp.Message) error {
 		c.abort(errQuestionReused)
 		return errQuestionReused
 	}
-	meth := capnp.Method{
-		InterfaceID: mcall.InterfaceId(),
-		MethodID:    mcall.MethodId(),
+	switch mcall.SendResultsTo().Which() {
+	case rpccapnp.Call_sendResultsTo_Which_caller:
+	case rpccapnp.Call_sendResultsTo_Which_yourself:
+		a.sentElsewhere = true
+	default:
+		return a.reject(errUnimplemented)
 	}
+	if size := messageSize(m.Segment().Message()); c.mem.acquire(size) {
+		a.charge = size
+	} else {
+		return a.reject(ErrOverloaded)
+	}
+	meth := namedMethod(mcall.InterfaceId(), mcall.MethodId())
 	paramContent, err := mparams.ContentPtr()
 	if err != nil {
 		return err
@@ -691,6 +846,7 @@ func (c *Conn) handleCallMessage(m rpccapnp.Message) error {
 }
 
 func (c *Conn) routeCallMessage(result *answer, mt rpccapnp.MessageTarget, cl *capnp.Call) error {
+	cl = callFor(result, cl)
 	switch mt.Which() {
 	case rpccapnp.MessageTarget_Which_importedCap:
 		id := exportID(mt.ImportedCap())
@@ -698,7 +854,7 @@ func (c *Conn) routeCallMessage(result *answer, mt rpccapnp.MessageTarget, cl *c
 		if e == nil {
 			return errBadTarget
 		}
-		answer := c.lockedCall(e.client, cl)
+		answer := c.lockedTailCall(result, e.client, cl)
 		go joinAnswer(result, answer)
 	case rpccapnp.MessageTarget_Which_promisedAnswer:
 		mpromise, err := mt.PromisedAnswer()
@@ -720,11 +876,15 @@ func (c *Conn) routeCallMessage(result *answer, mt rpccapnp.MessageTarget, cl *c
 		}
 		transform := promisedAnswerOpsToTransform(mtrans)
 		pa.mu.Lock()
-		if pa.done {
+		if q := pa.tail; q != nil {
+			pa.mu.Unlock()
+			answer := q.lockedPipelineCall(transform, c.tailCallFor(result, cl))
+			go joinAnswer(result, answer)
+		} else if pa.done {
 			obj, err := pa.obj, pa.err
 			pa.mu.Unlock()
 			client := clientFromResolution(transform, obj, err)
-			answer := c.lockedCall(client, cl)
+			answer := c.lockedTailCall(result, client, cl)
 			go joinAnswer(result, answer)
 		} else {
 			err = pa.queueCallLocked(cl, pcall{transform: transform, qcall: qcall{a: result}})
@@ -749,6 +909,9 @@ func (c *Conn) handleDisembargoMessage(msg rpccapnp.Message) error {
 	switch d.Context().Which() {
 	case rpccapnp.Disembargo_context_Which_senderLoopback:
 		id := embargoID(d.Context().SenderLoopback())
+		if dtarget.Which() == rpccapnp.MessageTarget_Which_importedCap {
+			return c.disembargoPromise(id, dtarget)
+		}
 		if dtarget.Which() != rpccapnp.MessageTarget_Which_promisedAnswer {
 			return errDisembargoNonImport
 		}
@@ -777,6 +940,7 @@ func (c *Conn) handleDisembargoMessage(msg rpccapnp.Message) error {
 			if err := rd.SetTarget(dtarget); err != nil {
 				return err
 			}
+			c.embargoEvent(EmbargoEcho, id)
 			c.sendMessage(resp)
 		}
 	case rpccapnp.Disembargo_context_Which_receiverLoopback:
@@ -854,14 +1018,10 @@ func clientFromResolution(transform []capnp.PipelineOp, obj capnp.Ptr, err error
 	if err != nil {
 		return capnp.ErrorClient(err)
 	}
-	out, err := capnp.TransformPtr(obj, transform)
+	c, err := capnp.TransformClient(obj, transform)
 	if err != nil {
 		return capnp.ErrorClient(err)
 	}
-	c := out.Interface().Client()
-	if c == nil {
-		return capnp.ErrorClient(capnp.ErrNullClient)
-	}
 	return c
 }
 
@@ -877,6 +1037,34 @@ func newMessage(buf []byte) rpccapnp.Message {
 	return m
 }
 
+// newCallMessage returns a new message for sending a call, taking its
+// buffer from the connection's pool if it has one.  The underlying
+// message must be passed to releaseCallMessage once nothing can refer
+// to it.
+func (c *Conn) newCallMessage() rpccapnp.Message {
+	if c.bufs == nil {
+		return newMessage(nil)
+	}
+	_, s, err := c.bufs.NewMessage()
+	if err != nil {
+		panic(err)
+	}
+	m, err := rpccapnp.NewRootMessage(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// releaseCallMessage returns the buffer of a message created by
+// newCallMessage to the connection's pool.
+func (c *Conn) releaseCallMessage(msg *capnp.Message) {
+	if c.bufs == nil || msg == nil {
+		return
+	}
+	c.bufs.Release(msg)
+}
+
 // chanMutex is a mutex backed by a channel so that it can be used in a select.
 // A receive is a lock and a send is an unlock.
 type chanMutex chan struct{}
@@ -11,6 +11,7 @@ import (
 	"github.com/iguazio/go-capnproto2/rpc"
 	"github.com/iguazio/go-capnproto2/rpc/internal/logtransport"
 	"github.com/iguazio/go-capnproto2/rpc/internal/pipetransport"
+	"github.com/iguazio/go-capnproto2/rpc/internal/testcapnp"
 	rpccapnp "github.com/iguazio/go-capnproto2/std/capnp/rpc"
 )
 
@@ -48,6 +49,25 @@ func newUnpairedConn(t *testing.T, options ...rpc.ConnOption) (*rpc.Conn, rpc.Tr
 	return c, q
 }
 
+func TestConnErr(t *testing.T) {
+	p, q := pipetransport.New()
+	conn := rpc.NewConn(p, rpc.ConnLog(testLogger{t}))
+	remote := rpc.NewConn(q, rpc.ConnLog(testLogger{t}))
+	defer remote.Close()
+	if err := conn.Err(); err != nil {
+		t.Errorf("before Close, conn.Err() = %v; want nil", err)
+	}
+	if err := conn.Close(); err != nil {
+		t.Error("conn.Close():", err)
+	}
+	if err := conn.Wait(); err != rpc.ErrConnClosed {
+		t.Errorf("after Close, conn.Wait() = %v; want %v", err, rpc.ErrConnClosed)
+	}
+	if err := conn.Err(); err != rpc.ErrConnClosed {
+		t.Errorf("after Close, conn.Err() = %v; want %v", err, rpc.ErrConnClosed)
+	}
+}
+
 func TestBootstrap(t *testing.T) {
 	ctx := context.Background()
 	conn, p := newUnpairedConn(t)
@@ -185,6 +205,34 @@ func bootstrapAndFulfill(t *testing.T, ctx context.Context, conn *rpc.Conn, p rp
 	return client
 }
 
+func TestAnswerDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conn, p := newUnpairedConn(t)
+	defer conn.Close()
+	defer p.Close()
+	client, bootstrapID := readBootstrap(t, ctx, conn, p)
+	ans := (*capnp.Pipeline)(client.(*capnp.PipelineClient)).Answer()
+
+	done := capnp.AnswerDone(ans)
+	select {
+	case <-done:
+		t.Fatal("bootstrap answer done before return")
+	default:
+	}
+	if err := sendBootstrapReturn(ctx, p, bootstrapID, false); err != nil {
+		t.Fatal("sending bootstrap return:", err)
+	}
+	select {
+	case <-done:
+	case <-ctx.Done():
+		t.Fatal("bootstrap answer not done after return")
+	}
+	if _, err := capnp.WaitAnswer(ctx, ans); err != nil {
+		t.Error("WaitAnswer:", err)
+	}
+}
+
 func TestCallOnPromisedAnswer(t *testing.T) {
 	ctx := context.Background()
 	conn, p := newUnpairedConn(t)
@@ -257,6 +305,41 @@ func TestCallOnPromisedAnswer(t *testing.T) {
 	}
 }
 
+func TestCallUnimplementedByRemote(t *testing.T) {
+	ctx := context.Background()
+	conn, p := newUnpairedConn(t)
+	defer conn.Close()
+	defer p.Close()
+	client, _ := readBootstrap(t, ctx, conn, p)
+
+	readDone := startRecvMessage(p)
+	ans := client.Call(&capnp.Call{
+		Ctx: ctx,
+		Method: capnp.Method{
+			InterfaceID: interfaceID,
+			MethodID:    methodID,
+		},
+		ParamsSize: capnp.ObjectSize{DataSize: 8},
+		ParamsFunc: func(s capnp.Struct) error { return nil },
+	})
+	read := <-readDone
+	if read.err != nil {
+		t.Fatal("Reading failed:", read.err)
+	}
+	if read.msg.Which() != rpccapnp.Message_Which_call {
+		t.Fatalf("Conn sent %v message, want Message_Which_call", read.msg.Which())
+	}
+	err := sendMessage(ctx, p, func(msg rpccapnp.Message) error {
+		return msg.SetUnimplemented(read.msg)
+	})
+	if err != nil {
+		t.Fatal("sendMessage:", err)
+	}
+	if _, err := ans.Struct(); err == nil {
+		t.Error("call echoed as unimplemented succeeded; want error")
+	}
+}
+
 func TestCallOnExportId_BootstrapIsPromise(t *testing.T) {
 	testCallOnExportId(t, true)
 }
@@ -572,6 +655,74 @@ func sendBootstrapAndFinish(t *testing.T, p rpc.Transport) (importID uint32) {
 	return importID
 }
 
+func TestCallBufferPool(t *testing.T) {
+	pool := capnp.NewBufferPool(1024)
+	tests := []struct {
+		name string
+		opts []rpc.ConnOption
+	}{
+		{"default", nil},
+		{"shared", []rpc.ConnOption{rpc.CallBufferPool(pool)}},
+		{"shared again", []rpc.ConnOption{rpc.CallBufferPool(pool)}},
+		{"disabled", []rpc.ConnOption{rpc.CallBufferPool(nil)}},
+	}
+	for _, test := range tests {
+		p, q := pipetransport.New()
+		if *logMessages {
+			p = logtransport.New(nil, p)
+		}
+		log := testLogger{t}
+		c := rpc.NewConn(p, append(test.opts, rpc.ConnLog(log))...)
+		d := rpc.NewConn(q, rpc.ConnLog(log), rpc.BootstrapFunc(bootstrapPingPong))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		client := testcapnp.PingPong{Client: c.Bootstrap(ctx)}
+		for i := int32(0); i < 20; i++ {
+			result, err := client.EchoNum(ctx, func(p testcapnp.PingPong_echoNum_Params) error {
+				p.SetN(i)
+				return nil
+			}).Struct()
+			if err != nil {
+				t.Errorf("%s: EchoNum(%d): %v", test.name, i, err)
+				break
+			}
+			if result.N() != i {
+				t.Errorf("%s: EchoNum(%d) = %d", test.name, i, result.N())
+			}
+		}
+		cancel()
+		c.Close()
+		d.Wait()
+	}
+}
+
+func TestTableStats(t *testing.T) {
+	p, q := pipetransport.New()
+	if *logMessages {
+		p = logtransport.New(nil, p)
+	}
+	log := testLogger{t}
+	c := rpc.NewConn(p, rpc.ConnLog(log))
+	d := rpc.NewConn(q, rpc.ConnLog(log), rpc.BootstrapFunc(bootstrapPingPong))
+	defer d.Wait()
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := testcapnp.PingPong{Client: c.Bootstrap(ctx)}
+	for i := 0; i < 10; i++ {
+		if _, err := client.EchoNum(ctx, nil).Struct(); err != nil {
+			t.Fatalf("EchoNum #%d: %v", i, err)
+		}
+	}
+	if st := c.TableStats(); st.Questions != 0 || st.Imports != 1 || st.Exports != 0 {
+		t.Errorf("client TableStats = %+v; want 0 questions, 1 import, 0 exports", st)
+	}
+	if st := d.TableStats(); st.Exports != 1 {
+		t.Errorf("server TableStats = %+v; want 1 export", st)
+	}
+}
+
 func sendMessage(ctx context.Context, t rpc.Transport, f func(rpccapnp.Message) error) error {
 	_, s, err := capnp.NewMessage(capnp.SingleSegment(nil))
 	m, err := rpccapnp.NewRootMessage(s)
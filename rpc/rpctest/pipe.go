@@ -0,0 +1,201 @@
+// Package rpctest provides an in-memory rpc.Transport whose messages
+// are delivered only when a test says so.  This lets tests of E-order,
+// such as calls racing an embargo, pick the interleaving of the two
+// directions of a connection instead of relying on the scheduler.
+package rpctest // import "github.com/iguazio/go-capnproto2/rpc/rpctest"
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+	rpccapnp "github.com/iguazio/go-capnproto2/std/capnp/rpc"
+)
+
+type pipe struct {
+	mu      sync.Mutex
+	changed chan struct{} // closed and replaced when an end changes
+}
+
+// notify wakes up waiters.  The caller must be holding onto p.mu.
+func (p *pipe) notify() {
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+// wait calls ready while holding onto p.mu until it returns true, or
+// until ctx is done.
+func (p *pipe) wait(ctx context.Context, ready func() bool) error {
+	for {
+		p.mu.Lock()
+		if ready() {
+			p.mu.Unlock()
+			return nil
+		}
+		ch := p.changed
+		p.mu.Unlock()
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// An End is one end of a pipe created by NewPipe.  Messages sent on an
+// End are held until Deliver passes them on to the other end, in the
+// order they were sent.  An End is safe to use from multiple
+// goroutines.
+type End struct {
+	p    *pipe
+	peer *End
+
+	// Protected by p.mu
+	held   []rpccapnp.Message // sent, not yet delivered
+	inbox  []rpccapnp.Message // delivered, not yet received
+	auto   bool
+	closed bool
+}
+
+// NewPipe returns the two ends of a pipe.  Both ends start out holding
+// every message they send.
+func NewPipe() (a, b *End) {
+	p := &pipe{changed: make(chan struct{})}
+	a, b = &End{p: p}, &End{p: p}
+	a.peer, b.peer = b, a
+	return a, b
+}
+
+// SendMessage copies msg and holds it until it is delivered, or
+// delivers it right away if the end is in auto mode.
+func (e *End) SendMessage(ctx context.Context, msg rpccapnp.Message) error {
+	buf, err := msg.Segment().Message().Marshal()
+	if err != nil {
+		return err
+	}
+	m, err := capnp.Unmarshal(buf)
+	if err != nil {
+		return err
+	}
+	msg, err = rpccapnp.ReadRootMessage(m)
+	if err != nil {
+		return err
+	}
+
+	e.p.mu.Lock()
+	defer e.p.mu.Unlock()
+	if e.closed {
+		return errClosed
+	}
+	if e.peer.closed {
+		return errBrokenPipe
+	}
+	if e.auto {
+		e.peer.inbox = append(e.peer.inbox, msg)
+	} else {
+		e.held = append(e.held, msg)
+	}
+	e.p.notify()
+	return nil
+}
+
+// RecvMessage waits for a message that the other end delivered.
+func (e *End) RecvMessage(ctx context.Context) (rpccapnp.Message, error) {
+	err := e.p.wait(ctx, func() bool {
+		return len(e.inbox) > 0 || e.closed || e.peer.closed
+	})
+	if err != nil {
+		return rpccapnp.Message{}, err
+	}
+	e.p.mu.Lock()
+	defer e.p.mu.Unlock()
+	if len(e.inbox) == 0 {
+		if e.closed {
+			return rpccapnp.Message{}, errClosed
+		}
+		return rpccapnp.Message{}, errBrokenPipe
+	}
+	msg := e.inbox[0]
+	e.inbox[0] = rpccapnp.Message{}
+	e.inbox = e.inbox[1:]
+	return msg, nil
+}
+
+// Close closes the end.  Messages that it holds are dropped.
+func (e *End) Close() error {
+	e.p.mu.Lock()
+	defer e.p.mu.Unlock()
+	if e.closed {
+		return errClosed
+	}
+	e.closed = true
+	e.held = nil
+	e.p.notify()
+	return nil
+}
+
+// Held returns the messages that the end holds, oldest first.  The
+// messages must not be modified.
+func (e *End) Held() []rpccapnp.Message {
+	e.p.mu.Lock()
+	defer e.p.mu.Unlock()
+	return append([]rpccapnp.Message(nil), e.held...)
+}
+
+// Deliver passes the n oldest held messages on to the other end.  It
+// returns an error if the end holds fewer than n messages.
+func (e *End) Deliver(n int) error {
+	e.p.mu.Lock()
+	defer e.p.mu.Unlock()
+	if n > len(e.held) {
+		return fmt.Errorf("rpctest: deliver %d messages, but only %d held", n, len(e.held))
+	}
+	e.peer.inbox = append(e.peer.inbox, e.held[:n]...)
+	e.held = append(e.held[:0:0], e.held[n:]...)
+	e.p.notify()
+	return nil
+}
+
+// SetAuto sets whether the end delivers messages as soon as they are
+// sent.  Turning auto mode on delivers the messages that are held.
+func (e *End) SetAuto(auto bool) {
+	e.p.mu.Lock()
+	defer e.p.mu.Unlock()
+	e.auto = auto
+	if auto {
+		e.peer.inbox = append(e.peer.inbox, e.held...)
+		e.held = nil
+		e.p.notify()
+	}
+}
+
+// WaitHeld waits until the end holds at least n messages.
+func (e *End) WaitHeld(ctx context.Context, n int) error {
+	return e.p.wait(ctx, func() bool {
+		return len(e.held) >= n
+	})
+}
+
+// WaitFor waits until the end holds a message of the given type and
+// returns the index of the first one in Held.  Delivering index+1
+// messages delivers it along with the messages sent before it.
+func (e *End) WaitFor(ctx context.Context, which rpccapnp.Message_Which) (int, error) {
+	i := -1
+	err := e.p.wait(ctx, func() bool {
+		for j, msg := range e.held {
+			if msg.Which() == which {
+				i = j
+				return true
+			}
+		}
+		return false
+	})
+	return i, err
+}
+
+var (
+	errBrokenPipe = errors.New("rpctest: broken pipe")
+	errClosed     = errors.New("rpctest: use of closed end")
+)
@@ -0,0 +1,122 @@
+package rpctest
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+	rpccapnp "github.com/iguazio/go-capnproto2/std/capnp/rpc"
+)
+
+func TestPipeHoldsUntilDeliver(t *testing.T) {
+	ctx := context.Background()
+	a, b := NewPipe()
+	defer a.Close()
+	defer b.Close()
+
+	for i := uint32(1); i <= 3; i++ {
+		if err := a.SendMessage(ctx, newFinish(t, i)); err != nil {
+			t.Fatal("SendMessage:", err)
+		}
+	}
+	if n := len(a.Held()); n != 3 {
+		t.Fatalf("len(Held()) = %d; want 3", n)
+	}
+	pollCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := b.RecvMessage(pollCtx); err == nil {
+		t.Fatal("RecvMessage returned a message that was not delivered")
+	}
+
+	if err := a.Deliver(2); err != nil {
+		t.Fatal("Deliver(2):", err)
+	}
+	for want := uint32(1); want <= 2; want++ {
+		msg, err := b.RecvMessage(ctx)
+		if err != nil {
+			t.Fatal("RecvMessage:", err)
+		}
+		fin, _ := msg.Finish()
+		if id := fin.QuestionId(); id != want {
+			t.Errorf("received finish %d; want %d", id, want)
+		}
+	}
+	if n := len(a.Held()); n != 1 {
+		t.Errorf("len(Held()) = %d after delivering 2; want 1", n)
+	}
+	if err := a.Deliver(2); err == nil {
+		t.Error("Deliver(2) with 1 held message succeeded")
+	}
+
+	a.SetAuto(true)
+	if err := a.SendMessage(ctx, newFinish(t, 4)); err != nil {
+		t.Fatal("SendMessage:", err)
+	}
+	for want := uint32(3); want <= 4; want++ {
+		msg, err := b.RecvMessage(ctx)
+		if err != nil {
+			t.Fatal("RecvMessage:", err)
+		}
+		fin, _ := msg.Finish()
+		if id := fin.QuestionId(); id != want {
+			t.Errorf("received finish %d; want %d", id, want)
+		}
+	}
+}
+
+func TestPipeWaitFor(t *testing.T) {
+	ctx := context.Background()
+	a, b := NewPipe()
+	defer a.Close()
+	defer b.Close()
+
+	fin, dis := newFinish(t, 1), newMessage(t)
+	if _, err := dis.NewDisembargo(); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		a.SendMessage(ctx, fin)
+		a.SendMessage(ctx, dis)
+	}()
+	i, err := a.WaitFor(ctx, rpccapnp.Message_Which_disembargo)
+	if err != nil {
+		t.Fatal("WaitFor:", err)
+	}
+	if i != 1 {
+		t.Errorf("WaitFor(disembargo) = %d; want 1", i)
+	}
+}
+
+func TestPipeClose(t *testing.T) {
+	ctx := context.Background()
+	a, b := NewPipe()
+	a.Close()
+	if _, err := b.RecvMessage(ctx); err == nil {
+		t.Error("RecvMessage after peer closed succeeded")
+	}
+	if err := b.SendMessage(ctx, newFinish(t, 1)); err == nil {
+		t.Error("SendMessage after peer closed succeeded")
+	}
+}
+
+func newMessage(t *testing.T) rpccapnp.Message {
+	_, s, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := rpccapnp.NewRootMessage(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func newFinish(t *testing.T, id uint32) rpccapnp.Message {
+	msg := newMessage(t)
+	fin, err := msg.NewFinish()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fin.SetQuestionId(id)
+	return msg
+}
@@ -2,9 +2,12 @@ package rpc
 
 import (
 	"errors"
+	"sync"
 
+	"golang.org/x/net/context"
 	"github.com/iguazio/go-capnproto2"
 	"github.com/iguazio/go-capnproto2/rpc/internal/refcount"
+	rpccapnp "github.com/iguazio/go-capnproto2/std/capnp/rpc"
 )
 
 // Table IDs
@@ -58,6 +61,28 @@ type importClient struct {
 	id     importID
 	conn   *Conn
 	closed bool // protected by conn.mu
+
+	// promise is true if the import was sent as a senderPromise, and
+	// resolved is the client that a promise import was resolved to by
+	// a Resolve message.  They are written while holding onto both
+	// conn.mu and stateMu, so that ClientState can read them without
+	// conn.mu.
+	stateMu  sync.Mutex
+	promise  bool
+	resolved capnp.Client
+}
+
+// ClientState reports the import as remote, branded by its connection.
+// A promise import is unresolved until the remote vat resolves it, and
+// then has the state of its resolution.
+func (ic *importClient) ClientState() (resolved bool, brand interface{}, remote bool) {
+	ic.stateMu.Lock()
+	promise, res := ic.promise, ic.resolved
+	ic.stateMu.Unlock()
+	if res != nil {
+		return capnp.ClientState(res)
+	}
+	return !promise, ic.conn, true
 }
 
 func (ic *importClient) Call(cl *capnp.Call) capnp.Answer {
@@ -81,32 +106,100 @@ func (ic *importClient) lockedCall(cl *capnp.Call) capnp.Answer {
 	if ic.closed {
 		return capnp.ErrorAnswer(errImportClosed)
 	}
+	if ic.resolved != nil {
+		return ic.conn.lockedCall(ic.resolved, cl)
+	}
 
+	q, msg, err := ic.lockedNewCall(cl)
+	if err != nil {
+		return capnp.ErrorAnswer(err)
+	}
+
+	select {
+	case ic.conn.out <- outgoing{msg: msg}:
+	case <-cl.Ctx.Done():
+		ic.conn.popQuestion(q.id)
+		return capnp.ErrorAnswer(cl.Ctx.Err())
+	case <-ic.conn.bg.Done():
+		ic.conn.popQuestion(q.id)
+		return capnp.ErrorAnswer(ErrConnClosed)
+	}
+	q.start()
+	return q
+}
+
+// lockedNewCall creates a question for cl and the call message that
+// asks it.  The caller must be holding onto ic.conn.mu, and must send
+// the message or pop the question.
+func (ic *importClient) lockedNewCall(cl *capnp.Call) (*question, rpccapnp.Message, error) {
 	q := ic.conn.newQuestion(cl.Ctx, &cl.Method)
-	msg := newMessage(nil)
+	msg := ic.conn.newCallMessage()
+	q.msg = msg.Segment().Message()
 	msgCall, _ := msg.NewCall()
 	msgCall.SetQuestionId(uint32(q.id))
 	msgCall.SetInterfaceId(cl.Method.InterfaceID)
 	msgCall.SetMethodId(cl.Method.MethodID)
+	setSendResultsTo(msgCall, q, cl)
 	target, _ := msgCall.NewTarget()
 	target.SetImportedCap(uint32(ic.id))
 	payload, _ := msgCall.NewParams()
 	if err := ic.conn.fillParams(payload, cl); err != nil {
 		ic.conn.popQuestion(q.id)
-		return capnp.ErrorAnswer(err)
+		return nil, rpccapnp.Message{}, err
 	}
+	return q, msg, nil
+}
 
+// batchCall makes the calls in a single group of messages so that the
+// transport can send them together.  It returns an answer for each call.
+func (ic *importClient) batchCall(ctx context.Context, calls []*capnp.Call) ([]capnp.Answer, error) {
 	select {
-	case ic.conn.out <- msg:
-	case <-cl.Ctx.Done():
-		ic.conn.popQuestion(q.id)
-		return capnp.ErrorAnswer(cl.Ctx.Err())
+	case <-ic.conn.mu:
+		if err := ic.conn.startWork(); err != nil {
+			return nil, err
+		}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer ic.conn.mu.Unlock()
+	defer ic.conn.workers.Done()
+	if ic.closed {
+		return nil, errImportClosed
+	}
+
+	answers := make([]capnp.Answer, len(calls))
+	qs := make([]*question, 0, len(calls))
+	msgs := make([]rpccapnp.Message, 0, len(calls))
+	for i, cl := range calls {
+		q, msg, err := ic.lockedNewCall(cl)
+		if err != nil {
+			answers[i] = capnp.ErrorAnswer(err)
+			continue
+		}
+		answers[i] = q
+		qs = append(qs, q)
+		msgs = append(msgs, msg)
+	}
+	if len(msgs) == 0 {
+		return answers, nil
+	}
+
+	var err error
+	select {
+	case ic.conn.out <- outgoing{batch: msgs}:
+		for _, q := range qs {
+			q.start()
+		}
+		return answers, nil
+	case <-ctx.Done():
+		err = ctx.Err()
 	case <-ic.conn.bg.Done():
+		err = ErrConnClosed
+	}
+	for _, q := range qs {
 		ic.conn.popQuestion(q.id)
-		return capnp.ErrorAnswer(ErrConnClosed)
 	}
-	q.start()
-	return q
+	return nil, err
 }
 
 func (ic *importClient) Close() error {
@@ -117,9 +210,13 @@ func (ic *importClient) Close() error {
 	}
 	closed := ic.closed
 	var i int
+	var resolved capnp.Client
 	if !closed {
 		i = ic.conn.popImport(ic.id)
 		ic.closed = true
+		ic.stateMu.Lock()
+		resolved, ic.resolved = ic.resolved, nil
+		ic.stateMu.Unlock()
 	}
 	ic.conn.workers.Done()
 	ic.conn.mu.Unlock()
@@ -127,6 +224,9 @@ func (ic *importClient) Close() error {
 	if closed {
 		return errImportClosed
 	}
+	if resolved != nil {
+		resolved.Close()
+	}
 	if i == 0 {
 		return nil
 	}
@@ -138,7 +238,7 @@ func (ic *importClient) Close() error {
 	mr.SetId(uint32(ic.id))
 	mr.SetReferenceCount(uint32(i))
 	select {
-	case ic.conn.out <- msg:
+	case ic.conn.out <- outgoing{msg: msg}:
 		return nil
 	case <-ic.conn.bg.Done():
 		return ErrConnClosed
@@ -150,37 +250,39 @@ type export struct {
 	rc       *refcount.RefCount
 	client   capnp.Client
 	wireRefs int
+	promise  bool // exported as senderPromise; see watchPromise
 }
 
 func (c *Conn) findExport(id exportID) *export {
-	if int(id) >= len(c.exports) {
-		return nil
-	}
 	return c.exports[id]
 }
 
 // addExport ensures that the client is present in the table, returning its ID.
 // If the client is already in the table, the previous ID is returned.
 func (c *Conn) addExport(client capnp.Client) exportID {
-	for i, e := range c.exports {
-		if e != nil && isSameClient(e.rc.Client, client) {
+	for id, e := range c.exports {
+		if e.promise && e.rc.Client != client {
+			// A resolved promise is the same as its resolution, but the
+			// resolution needs its own ID for the Resolve message.
+			continue
+		}
+		if isSameClient(e.rc.Client, client) {
 			e.wireRefs++
-			return exportID(i)
+			return id
 		}
 	}
 	id := exportID(c.exportID.next())
 	rc, client := refcount.New(client)
-	export := &export{
+	if c.exports == nil {
+		c.exports = make(map[exportID]*export)
+	}
+	c.exports[id] = &export{
 		id:       id,
 		rc:       rc,
 		client:   client,
 		wireRefs: 1,
 	}
-	if int(id) == len(c.exports) {
-		c.exports = append(c.exports, export)
-	} else {
-		c.exports[id] = export
-	}
+	c.mem.add(exportCost)
 	return id
 }
 
@@ -199,8 +301,9 @@ func (c *Conn) releaseExport(id exportID, refs int) {
 	if err := e.client.Close(); err != nil {
 		c.errorf("export %v close: %v", id, err)
 	}
-	c.exports[id] = nil
+	delete(c.exports, id)
 	c.exportID.remove(uint32(id))
+	c.mem.release(exportCost)
 }
 
 type embargo <-chan struct{}
@@ -208,27 +311,50 @@ type embargo <-chan struct{}
 func (c *Conn) newEmbargo() (embargoID, embargo) {
 	id := embargoID(c.embargoID.next())
 	e := make(chan struct{})
-	if int(id) == len(c.embargoes) {
-		c.embargoes = append(c.embargoes, e)
-	} else {
-		c.embargoes[id] = e
+	if c.embargoes == nil {
+		c.embargoes = make(map[embargoID]chan<- struct{})
 	}
+	c.embargoes[id] = e
 	return id, e
 }
 
 func (c *Conn) disembargo(id embargoID) {
-	if int(id) >= len(c.embargoes) {
-		return
-	}
 	e := c.embargoes[id]
 	if e == nil {
 		return
 	}
+	c.embargoEvent(EmbargoLift, id)
 	close(e)
-	c.embargoes[id] = nil
+	delete(c.embargoes, id)
 	c.embargoID.remove(uint32(id))
 }
 
+// TableStats is a snapshot of the number of entries in a connection's
+// tables.
+type TableStats struct {
+	Questions int // calls to the remote vat awaiting a return
+	Answers   int // calls from the remote vat that have not finished
+	Exports   int // capabilities hosted for the remote vat
+	Imports   int // capabilities hosted by the remote vat
+	Embargoes int // embargoes waiting for a disembargo
+}
+
+// TableStats returns the current occupancy of the connection's
+// question, answer, export, import, and embargo tables.  IDs freed by
+// completed entries are reused, so these counts bound the size of the
+// tables.  All counts are zero once the connection has shut down.
+func (c *Conn) TableStats() TableStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return TableStats{
+		Questions: len(c.questions),
+		Answers:   len(c.answers),
+		Exports:   len(c.exports),
+		Imports:   len(c.imports),
+		Embargoes: len(c.embargoes),
+	}
+}
+
 // idgen returns a sequence of monotonically increasing IDs with
 // support for replacement.  The zero value is a generator that
 // starts at zero.
@@ -0,0 +1,260 @@
+package rpc
+
+import (
+	"errors"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/rpc/internal/refcount"
+	rpccapnp "github.com/iguazio/go-capnproto2/std/capnp/rpc"
+)
+
+// Tail calls
+//
+// When the answer to a call delivered by a connection is the answer to
+// a call made on the same connection, as happens when a capability
+// forwards calls back to the vat that made them, the results would
+// otherwise travel to this vat only to be copied back again.  Instead,
+// the forwarded call asks the remote vat to keep its results
+// (Call.sendResultsTo.yourself), and the delivered call is answered
+// with Return.takeFromOtherQuestion naming the forwarded call.
+//
+// Only calls whose answer goes straight to joinAnswer can be tail
+// calls: a forwarded call that is queued, or handed to an arbitrary
+// Client, may have its answer read by something else, and the results
+// never arrive.
+
+type tailCallKey struct{}
+
+// lockedTailCall is like lockedCall, but makes cl a tail call if client
+// leads directly back to the remote vat.  The answer must be joined to
+// a.  The caller must be holding onto c.mu.
+func (c *Conn) lockedTailCall(a *answer, client capnp.Client, cl *capnp.Call) capnp.Answer {
+	if c.isTailTarget(client) {
+		cl = c.tailCallFor(a, cl)
+	}
+	return c.lockedCall(client, cl)
+}
+
+// isTailTarget reports whether lockedCall would send a call on client
+// to the remote vat without queueing it.  The caller must be holding
+// onto c.mu.
+func (c *Conn) isTailTarget(client capnp.Client) bool {
+	for {
+		switch curr := client.(type) {
+		case *importClient:
+			if curr.conn != c {
+				return false
+			}
+			if curr.resolved == nil {
+				return true
+			}
+			client = curr.resolved
+		case *refcount.Ref:
+			client = curr.Client()
+		case *localAnswerClient:
+			curr.a.mu.RLock()
+			tail := curr.a.tail
+			curr.a.mu.RUnlock()
+			return tail != nil
+		case *capnp.PipelineClient:
+			p := (*capnp.Pipeline)(curr)
+			q, ok := p.Answer().(*question)
+			if !ok || q.conn != c {
+				return false
+			}
+			if c.findQuestion(q.id) == q {
+				return true
+			}
+			// A finished question passes calls on to its results.
+			q.mu.RLock()
+			obj, err := q.obj, q.err
+			q.mu.RUnlock()
+			client = clientFromResolution(p.Transform(), obj, err)
+		default:
+			return false
+		}
+	}
+}
+
+// tailCallFor returns a copy of cl marked as a tail call for a, unless
+// a's own results were asked to be sent elsewhere.
+func (c *Conn) tailCallFor(a *answer, cl *capnp.Call) *capnp.Call {
+	if a.sentElsewhere {
+		return cl
+	}
+	tcl := *cl
+	tcl.Options = cl.Options.With([]capnp.CallOption{capnp.SetOptionValue(tailCallKey{}, c)})
+	return &tcl
+}
+
+// resultsForKey is the call option that names the answer a call was
+// made to resolve.
+type resultsForKey struct{}
+
+// callFor returns a copy of cl marked as being made to resolve a, so
+// that a question sent for it hands the references to its result
+// capabilities to a.
+func callFor(a *answer, cl *capnp.Call) *capnp.Call {
+	fcl := *cl
+	fcl.Options = cl.Options.With([]capnp.CallOption{capnp.SetOptionValue(resultsForKey{}, a)})
+	return &fcl
+}
+
+// setSendResultsTo fills in where the results of cl go in its call
+// message and records it in q, along with the answer cl was made for.
+func setSendResultsTo(msgCall rpccapnp.Call, q *question, cl *capnp.Call) {
+	q.resultsFor, _ = cl.Options.Value(resultsForKey{}).(*answer)
+	if c, _ := cl.Options.Value(tailCallKey{}).(*Conn); c == q.conn {
+		msgCall.SendResultsTo().SetYourself()
+		q.tail = true
+	}
+}
+
+// tailCall resolves the answer with the results of q, a tail call on
+// the same connection, by sending Return.takeFromOtherQuestion.  Calls
+// pipelined on the answer are redirected to q.  It returns an error if
+// its connection is shut down while sending messages.  The caller must
+// be holding onto a.conn.mu.
+func (a *answer) tailCall(q *question) error {
+	a.mu.Lock()
+	if a.done {
+		panic("answer.tailCall called after resolution")
+	}
+	a.tail, a.done = q, true
+	q.tailOf = a
+	if a.conn.answers[a.id] != a {
+		// Already finished by the remote vat.
+		q.tailFinished = true
+	}
+
+	var firstErr error
+	if err := a.conn.startWork(); err != nil {
+		firstErr = err
+		for i := range a.queue {
+			a.conn.mem.release(a.queue[i].charge)
+			a.queue[i].a.reject(err)
+		}
+		a.queue = nil
+	} else {
+		retmsg := newReturnMessage(nil, a.id)
+		ret, _ := retmsg.Return()
+		ret.SetTakeFromOtherQuestion(uint32(q.id))
+		if err := a.conn.sendMessage(retmsg); err != nil {
+			firstErr = err
+		}
+		for _, pc := range a.queue {
+			a.conn.mem.release(pc.charge)
+			ans := q.lockedPipelineCall(pc.transform, pc.call)
+			if pc.a != nil {
+				go joinAnswer(pc.a, ans)
+			} else {
+				go joinFulfiller(pc.f, ans)
+			}
+		}
+		a.queue = nil
+		a.conn.workers.Done()
+	}
+	close(a.resolved)
+	a.mu.Unlock()
+	return firstErr
+}
+
+// returnTail handles the return of a tail call.  The question stays in
+// the table until the answer it resolved is finished, since the remote
+// vat may still pipeline calls on that answer, and they are forwarded
+// to q.  The caller must be holding onto c.mu.
+func (c *Conn) returnTail(q *question, ret rpccapnp.Return) {
+	if ret.ReleaseParamCaps() {
+		for _, id := range q.paramCaps {
+			c.releaseExport(id, 1)
+		}
+		q.paramCaps = nil
+	}
+	q.mu.RLock()
+	qstate := q.state
+	q.mu.RUnlock()
+	if qstate == questionCanceled {
+		// We already sent the finish message.
+		c.popQuestion(q.id)
+		return
+	}
+	q.tailReturned = true
+	if q.tailFinished {
+		c.finishTail(q)
+	}
+}
+
+// finishAnswerTail is called when the remote vat finishes an answer
+// resolved by the tail call q.  The caller must be holding onto c.mu.
+func (c *Conn) finishAnswerTail(q *question) {
+	q.tailFinished = true
+	if q.tailReturned {
+		c.finishTail(q)
+	}
+}
+
+// finishTail resolves and finishes the tail call q once both its return
+// has been received and the answer it resolved has been finished.  The
+// caller must be holding onto c.mu.
+func (c *Conn) finishTail(q *question) {
+	c.popQuestion(q.id)
+	q.reject(&questionError{id: q.id, method: q.method, err: errResultsSentElsewhere})
+	c.sendMessage(newFinishMessage(nil, q.id, true /* release */))
+	q.tailOf.cancel()
+}
+
+// takeFromAnswer resolves q, whose return said to take the results of
+// the answer with the given ID, once that answer is resolved.  The
+// caller must be holding onto c.mu.
+func (c *Conn) takeFromAnswer(q *question, id answerID) error {
+	a := c.answers[id]
+	if a == nil {
+		err := errors.New("rpc: return takes results from unknown answer")
+		q.reject(err)
+		return err
+	}
+	a.mu.RLock()
+	done := a.done
+	a.mu.RUnlock()
+	if done {
+		a.resolveQuestion(q)
+		return nil
+	}
+	if err := c.startWork(); err != nil {
+		q.reject(err)
+		return err
+	}
+	go func() {
+		defer c.workers.Done()
+		select {
+		case <-a.resolved:
+		case <-c.bg.Done():
+			return
+		}
+		c.mu.Lock()
+		a.resolveQuestion(q)
+		c.mu.Unlock()
+	}()
+	return nil
+}
+
+// resolveQuestion resolves q with the answer's results.  The answer
+// must be resolved, and the caller must be holding onto a.conn.mu.
+func (a *answer) resolveQuestion(q *question) {
+	a.mu.RLock()
+	obj, err, tail := a.obj, a.err, a.tail
+	a.mu.RUnlock()
+	switch {
+	case tail != nil:
+		q.reject(errTailLoop)
+	case err != nil:
+		q.reject(err)
+	default:
+		q.fulfill(obj)
+	}
+}
+
+var (
+	errResultsSentElsewhere = errors.New("rpc: results of tail call were sent to the remote vat")
+	errTailLoop             = errors.New("rpc: tail call answered by another tail call")
+)
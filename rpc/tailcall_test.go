@@ -0,0 +1,242 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/rpc"
+	rpccapnp "github.com/iguazio/go-capnproto2/std/capnp/rpc"
+)
+
+func TestTailCall(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var remote capnp.Client
+	main := stubClient(func(ctx context.Context, params capnp.Struct) (capnp.Struct, error) {
+		_, s, err := capnp.NewMessage(capnp.SingleSegment(nil))
+		if err != nil {
+			return capnp.Struct{}, err
+		}
+		result, err := capnp.NewRootStruct(s, capnp.ObjectSize{PointerCount: 1})
+		if err != nil {
+			return capnp.Struct{}, err
+		}
+		in := capnp.NewInterface(s, s.Message().AddCap(remote))
+		return result, result.SetPtr(0, in.ToPtr())
+	})
+	conn, p := newUnpairedConn(t, rpc.MainInterface(main))
+	defer conn.Close()
+	defer p.Close()
+	remote = bootstrapAndFulfill(t, ctx, conn, p, false)
+	importID := sendBootstrapAndFinish(t, p)
+
+	// The result of the first call is a capability hosted by the caller,
+	// so a call pipelined on it is forwarded straight back.
+	const firstID, secondID = 1, 2
+	err := sendTestCall(ctx, p, firstID, false, func(target rpccapnp.MessageTarget) error {
+		target.SetImportedCap(importID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal("sending first call:", err)
+	}
+	if ret := recvReturn(t, ctx, p, firstID); ret.Which() != rpccapnp.Return_Which_results {
+		t.Fatalf("first call return is %v; want results", ret.Which())
+	}
+	err = sendTestCall(ctx, p, secondID, false, func(target rpccapnp.MessageTarget) error {
+		pa, err := target.NewPromisedAnswer()
+		if err != nil {
+			return err
+		}
+		pa.SetQuestionId(firstID)
+		ops, err := pa.NewTransform(1)
+		if err != nil {
+			return err
+		}
+		ops.At(0).SetGetPointerField(0)
+		return nil
+	})
+	if err != nil {
+		t.Fatal("sending second call:", err)
+	}
+
+	msg, err := p.RecvMessage(ctx)
+	if err != nil {
+		t.Fatal("reading forwarded call:", err)
+	}
+	if msg.Which() != rpccapnp.Message_Which_call {
+		t.Fatalf("conn sent %v; want call", msg.Which())
+	}
+	call, err := msg.Call()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := call.SendResultsTo().Which(); w != rpccapnp.Call_sendResultsTo_Which_yourself {
+		t.Errorf("forwarded call sendResultsTo = %v; want yourself", w)
+	}
+	tailID := call.QuestionId()
+	ret := recvReturn(t, ctx, p, secondID)
+	if ret.Which() != rpccapnp.Return_Which_takeFromOtherQuestion {
+		t.Fatalf("second call return is %v; want takeFromOtherQuestion", ret.Which())
+	}
+	if id := ret.TakeFromOtherQuestion(); id != tailID {
+		t.Errorf("takeFromOtherQuestion = %d; want %d", id, tailID)
+	}
+
+	// The forwarded call is finished only after both its return and the
+	// finish of the call it answered.
+	err = sendMessage(ctx, p, func(msg rpccapnp.Message) error {
+		ret, err := msg.NewReturn()
+		if err != nil {
+			return err
+		}
+		ret.SetAnswerId(tailID)
+		ret.SetResultsSentElsewhere()
+		return nil
+	})
+	if err != nil {
+		t.Fatal("sending tail call return:", err)
+	}
+	err = sendMessage(ctx, p, func(msg rpccapnp.Message) error {
+		fin, err := msg.NewFinish()
+		if err != nil {
+			return err
+		}
+		fin.SetQuestionId(secondID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal("sending finish:", err)
+	}
+	msg, err = p.RecvMessage(ctx)
+	if err != nil {
+		t.Fatal("reading finish:", err)
+	}
+	if msg.Which() != rpccapnp.Message_Which_finish {
+		t.Fatalf("conn sent %v; want finish", msg.Which())
+	}
+	if fin, _ := msg.Finish(); fin.QuestionId() != tailID {
+		t.Errorf("finish question ID = %d; want %d", fin.QuestionId(), tailID)
+	}
+}
+
+func TestTakeFromOtherQuestion(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	main := stubClient(func(ctx context.Context, params capnp.Struct) (capnp.Struct, error) {
+		_, s, err := capnp.NewMessage(capnp.SingleSegment(nil))
+		if err != nil {
+			return capnp.Struct{}, err
+		}
+		result, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8})
+		if err != nil {
+			return capnp.Struct{}, err
+		}
+		result.SetUint64(0, 42)
+		return result, nil
+	})
+	conn, p := newUnpairedConn(t, rpc.MainInterface(main))
+	defer conn.Close()
+	defer p.Close()
+	client := bootstrapAndFulfill(t, ctx, conn, p, false)
+	importID := sendBootstrapAndFinish(t, p)
+
+	ans := client.Call(&capnp.Call{
+		Ctx:        ctx,
+		Method:     capnp.Method{InterfaceID: interfaceID, MethodID: methodID},
+		ParamsSize: capnp.ObjectSize{},
+		ParamsFunc: func(capnp.Struct) error { return nil },
+	})
+	msg, err := p.RecvMessage(ctx)
+	if err != nil {
+		t.Fatal("reading call:", err)
+	}
+	if msg.Which() != rpccapnp.Message_Which_call {
+		t.Fatalf("conn sent %v; want call", msg.Which())
+	}
+	call, _ := msg.Call()
+	callID := call.QuestionId()
+
+	// Answer the call with a tail call back to conn.
+	const tailID = 7
+	err = sendTestCall(ctx, p, tailID, true, func(target rpccapnp.MessageTarget) error {
+		target.SetImportedCap(importID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal("sending tail call:", err)
+	}
+	if ret := recvReturn(t, ctx, p, tailID); ret.Which() != rpccapnp.Return_Which_resultsSentElsewhere {
+		t.Fatalf("tail call return is %v; want resultsSentElsewhere", ret.Which())
+	}
+	err = sendMessage(ctx, p, func(msg rpccapnp.Message) error {
+		ret, err := msg.NewReturn()
+		if err != nil {
+			return err
+		}
+		ret.SetAnswerId(callID)
+		ret.SetTakeFromOtherQuestion(tailID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal("sending return:", err)
+	}
+
+	s, err := ans.Struct()
+	if err != nil {
+		t.Fatal("call error:", err)
+	}
+	if x := s.Uint64(0); x != 42 {
+		t.Errorf("result = %d; want 42", x)
+	}
+}
+
+func sendTestCall(ctx context.Context, p rpc.Transport, questionID uint32, yourself bool, setTarget func(rpccapnp.MessageTarget) error) error {
+	return sendMessage(ctx, p, func(msg rpccapnp.Message) error {
+		call, err := msg.NewCall()
+		if err != nil {
+			return err
+		}
+		call.SetQuestionId(questionID)
+		call.SetInterfaceId(interfaceID)
+		call.SetMethodId(methodID)
+		if yourself {
+			call.SendResultsTo().SetYourself()
+		}
+		target, err := call.NewTarget()
+		if err != nil {
+			return err
+		}
+		if err := setTarget(target); err != nil {
+			return err
+		}
+		payload, err := call.NewParams()
+		if err != nil {
+			return err
+		}
+		content, err := capnp.NewStruct(msg.Segment(), capnp.ObjectSize{})
+		if err != nil {
+			return err
+		}
+		return payload.SetContent(content)
+	})
+}
+
+func recvReturn(t *testing.T, ctx context.Context, p rpc.Transport, answerID uint32) rpccapnp.Return {
+	msg, err := p.RecvMessage(ctx)
+	if err != nil {
+		t.Fatal("reading return:", err)
+	}
+	if msg.Which() != rpccapnp.Message_Which_return {
+		t.Fatalf("conn sent %v; want return", msg.Which())
+	}
+	ret, err := msg.Return()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := ret.AnswerId(); id != answerID {
+		t.Fatalf("return answer ID = %d; want %d", id, answerID)
+	}
+	return ret
+}
@@ -3,6 +3,7 @@ package rpc
 import (
 	"bytes"
 	"io"
+	"net"
 	"time"
 
 	"golang.org/x/net/context"
@@ -13,7 +14,9 @@ import (
 // Transport is the interface that abstracts sending and receiving
 // individual messages of the Cap'n Proto RPC protocol.
 type Transport interface {
-	// SendMessage sends msg.
+	// SendMessage sends msg.  The connection may reuse the buffer of
+	// a call message once the call returns, so implementations must not
+	// retain msg after it has been sent.
 	SendMessage(ctx context.Context, msg rpccapnp.Message) error
 
 	// RecvMessage waits to receive a message and returns it.
@@ -25,6 +28,16 @@ type Transport interface {
 	Close() error
 }
 
+// A BatchTransport is a Transport that can send several messages in
+// one operation, such as a single write.  A connection uses it to send
+// the calls of a Batch.
+type BatchTransport interface {
+	Transport
+
+	// SendMessages sends msgs in order.
+	SendMessages(ctx context.Context, msgs []rpccapnp.Message) error
+}
+
 type streamTransport struct {
 	rwc      io.ReadWriteCloser
 	deadline writeDeadlineSetter
@@ -37,23 +50,51 @@ type streamTransport struct {
 // StreamTransport creates a transport that sends and receives messages
 // by serializing and deserializing unpacked Cap'n Proto messages.
 // Closing the transport will close the underlying ReadWriteCloser.
-func StreamTransport(rwc io.ReadWriteCloser) Transport {
+// The options are passed to the transport's Encoder and Decoder, so
+// capnp.WithTrace can be used to trace the messages sent and received
+// and capnp.WithDecodeOptions can limit the messages received.
+func StreamTransport(rwc io.ReadWriteCloser, opts ...capnp.CodecOption) Transport {
 	d, _ := rwc.(writeDeadlineSetter)
 	s := &streamTransport{
 		rwc:      rwc,
 		deadline: d,
-		dec:      capnp.NewDecoder(rwc),
+		dec:      capnp.NewDecoder(rwc, opts...),
 	}
 	s.wbuf.Grow(4096)
-	s.enc = capnp.NewEncoder(&s.wbuf)
+	s.enc = capnp.NewEncoder(&s.wbuf, opts...)
 	return s
 }
 
+// RemoteAddr returns the remote address of the underlying stream, or
+// nil if it doesn't have one, like a net.Conn does.
+func (s *streamTransport) RemoteAddr() net.Addr {
+	if ra, ok := s.rwc.(remoteAddrer); ok {
+		return ra.RemoteAddr()
+	}
+	return nil
+}
+
 func (s *streamTransport) SendMessage(ctx context.Context, msg rpccapnp.Message) error {
 	s.wbuf.Reset()
 	if err := s.enc.Encode(msg.Segment().Message()); err != nil {
 		return err
 	}
+	return s.flush(ctx)
+}
+
+// SendMessages encodes all of msgs and writes them at once.
+func (s *streamTransport) SendMessages(ctx context.Context, msgs []rpccapnp.Message) error {
+	s.wbuf.Reset()
+	for _, msg := range msgs {
+		if err := s.enc.Encode(msg.Segment().Message()); err != nil {
+			return err
+		}
+	}
+	return s.flush(ctx)
+}
+
+// flush writes the contents of wbuf to the underlying stream.
+func (s *streamTransport) flush(ctx context.Context) error {
 	if s.deadline != nil {
 		// TODO(light): log errors
 		if d, ok := ctx.Deadline(); ok {
@@ -100,10 +141,14 @@ func (c *Conn) dispatchSend() {
 	defer c.workers.Done()
 	for {
 		select {
-		case msg := <-c.out:
-			err := c.transport.SendMessage(c.bg, msg)
+		case o := <-c.out:
+			if o.batch != nil {
+				c.sendBatch(o.batch)
+				continue
+			}
+			err := c.transport.SendMessage(c.bg, o.msg)
 			if err != nil {
-				c.errorf("writing %v: %v", msg.Which(), err)
+				c.errorf("writing %v: %v", o.msg.Which(), err)
 			}
 		case <-c.bg.Done():
 			return
@@ -111,12 +156,35 @@ func (c *Conn) dispatchSend() {
 	}
 }
 
+// sendBatch sends a group of messages, in a single call to the
+// transport if it supports it.
+func (c *Conn) sendBatch(msgs []rpccapnp.Message) {
+	if bt, ok := c.transport.(BatchTransport); ok {
+		if err := bt.SendMessages(c.bg, msgs); err != nil {
+			c.errorf("writing batch of %d messages: %v", len(msgs), err)
+		}
+		return
+	}
+	for _, msg := range msgs {
+		if err := c.transport.SendMessage(c.bg, msg); err != nil {
+			c.errorf("writing %v: %v", msg.Which(), err)
+		}
+	}
+}
+
+// outgoing is an entry in a connection's send queue.  It holds either a
+// single message or a batch of messages to send together.
+type outgoing struct {
+	msg   rpccapnp.Message
+	batch []rpccapnp.Message
+}
+
 // sendMessage enqueues a message to be sent or returns an error if the
 // connection is shut down before the message is queued.  It is safe to
 // call from multiple goroutines and does not require holding c.mu.
 func (c *Conn) sendMessage(msg rpccapnp.Message) error {
 	select {
-	case c.out <- msg:
+	case c.out <- outgoing{msg: msg}:
 		return nil
 	case <-c.bg.Done():
 		return ErrConnClosed
@@ -131,7 +199,7 @@ func (c *Conn) dispatchRecv() {
 		if err == nil {
 			c.handleMessage(msg)
 		} else if isTemporaryError(err) {
-			c.errorf("read temporary error: %v", err)
+			c.logEvent(Event{Kind: EventDecodeError, Err: err})
 		} else {
 			c.shutdown(err)
 			return
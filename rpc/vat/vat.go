@@ -0,0 +1,262 @@
+// Package vat assembles the capabilities that a Cap'n Proto service
+// exports from factories registered by name.  A factory creates one
+// capability, like an authenticator, a clock, or a storage service,
+// and gets the other capabilities that it needs from the vat, which
+// creates each of them once: either once for the whole vat or once for
+// each connection, as registered.  A service registers its factories
+// at startup and then creates its connections with Vat.NewConn, which
+// bootstraps each connection with the capability of the given name.
+package vat // import "github.com/iguazio/go-capnproto2/rpc/vat"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/rpc"
+	"github.com/iguazio/go-capnproto2/rpc/internal/refcount"
+)
+
+// A Lifetime determines how many instances of a capability a vat
+// creates.
+type Lifetime int
+
+// Capability lifetimes.
+const (
+	// Singleton capabilities are created once per vat and shared by
+	// all of its connections.  They are closed when the vat is closed.
+	Singleton Lifetime = iota
+
+	// PerConn capabilities are created once per connection, or per
+	// Scope, and closed when the connection is done.  Singletons can't
+	// depend on them.
+	PerConn
+)
+
+// A Factory creates a capability.  It gets the capabilities that it
+// depends on from deps.  ctx is only valid while the factory runs.
+//
+// Factories run while the connection that needs them is handling a
+// bootstrap message, so they should not make any RPCs or block.
+type Factory func(ctx context.Context, deps *Deps) (capnp.Client, error)
+
+// A Vat holds the registered factories and the singleton capabilities
+// created from them.  It is safe for concurrent use.
+type Vat struct {
+	mu        sync.Mutex
+	factories map[string]factory
+
+	root *Scope
+}
+
+type factory struct {
+	lifetime Lifetime
+	f        Factory
+}
+
+// New returns a vat with no registered capabilities.
+func New() *Vat {
+	v := &Vat{factories: make(map[string]factory)}
+	v.root = &Scope{vat: v, root: true}
+	return v
+}
+
+// Register adds a factory for the capability with the given name.
+// It panics if a capability with the same name is already registered.
+func (v *Vat) Register(name string, lt Lifetime, f Factory) {
+	if f == nil {
+		panic("vat: nil factory for " + name)
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if _, dup := v.factories[name]; dup {
+		panic("vat: capability " + name + " registered twice")
+	}
+	v.factories[name] = factory{lt, f}
+}
+
+// Provide registers an existing client as a singleton capability.  The
+// vat takes ownership of c and closes it when the vat and everything
+// that uses it are closed.
+func (v *Vat) Provide(name string, c capnp.Client) {
+	v.Register(name, Singleton, func(context.Context, *Deps) (capnp.Client, error) {
+		// The instance is added below, so this is only called once the
+		// vat has been closed, and then Get fails first.
+		return nil, errClosed
+	})
+	v.root.mu.Lock()
+	v.root.add(name, c)
+	v.root.mu.Unlock()
+}
+
+// Get returns a new reference to the singleton capability with the
+// given name, creating it if necessary.  The caller must close the
+// client.
+func (v *Vat) Get(ctx context.Context, name string) (capnp.Client, error) {
+	return v.root.Get(ctx, name)
+}
+
+// Close closes the vat's singleton capabilities in the reverse order of
+// their creation.  Capabilities that are still referenced elsewhere,
+// like by open connections, are closed once those references are.
+func (v *Vat) Close() error {
+	return v.root.Close()
+}
+
+// NewScope returns a new scope for per-connection capabilities.  It is
+// for wiring connections up by hand; NewConn creates and closes scopes
+// itself.
+func (v *Vat) NewScope() *Scope {
+	return &Scope{vat: v}
+}
+
+// NewConn creates a connection on t whose bootstrap capability is the
+// capability with the given name, created in a new scope that is
+// closed when the connection is done.  options are passed to
+// rpc.NewConn and must not include MainInterface or BootstrapFunc.
+func (v *Vat) NewConn(t rpc.Transport, main string, options ...rpc.ConnOption) *rpc.Conn {
+	sc := v.NewScope()
+	options = append(options[:len(options):len(options)], rpc.BootstrapFunc(func(ctx context.Context) (capnp.Client, error) {
+		return sc.Get(ctx, main)
+	}))
+	conn := rpc.NewConn(t, options...)
+	go func() {
+		<-conn.Done()
+		sc.Close()
+	}()
+	return conn
+}
+
+// A Scope holds the per-connection capabilities of one connection.
+type Scope struct {
+	vat  *Vat
+	root bool // whether this is the vat's singleton scope
+
+	// mu is held while capabilities are created, so that each one is
+	// created once.
+	mu     sync.Mutex
+	insts  map[string]instance
+	order  []string // names in creation order
+	closed bool
+}
+
+type instance struct {
+	rc  *refcount.RefCount
+	ref capnp.Client // the scope's own reference
+}
+
+// Get returns a new reference to the capability with the given name,
+// creating it and its dependencies if necessary.  The caller must close
+// the client.
+func (sc *Scope) Get(ctx context.Context, name string) (capnp.Client, error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	c, err := sc.get(ctx, name, nil)
+	if err != nil {
+		return nil, errors.New("vat: " + err.Error())
+	}
+	return c, nil
+}
+
+// get returns a new reference to a capability.  path is the names of
+// the capabilities being created that lead to this one.  The caller
+// must hold sc.mu.
+func (sc *Scope) get(ctx context.Context, name string, path []string) (capnp.Client, error) {
+	if sc.closed {
+		return nil, errClosed
+	}
+	if inst, ok := sc.insts[name]; ok {
+		return inst.rc.Ref(), nil
+	}
+	for _, p := range path {
+		if p == name {
+			return nil, fmt.Errorf("dependency cycle: %s", strings.Join(append(path, name), " -> "))
+		}
+	}
+	sc.vat.mu.Lock()
+	f, ok := sc.vat.factories[name]
+	sc.vat.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("no capability named %q", name)
+	}
+	switch {
+	case f.lifetime == Singleton && !sc.root:
+		// Locking the root scope while holding a connection scope's
+		// lock is safe because singletons never depend on connection
+		// scopes.
+		root := sc.vat.root
+		root.mu.Lock()
+		defer root.mu.Unlock()
+		return root.get(ctx, name, path)
+	case f.lifetime == PerConn && sc.root:
+		if len(path) == 0 {
+			return nil, fmt.Errorf("%s is a per-connection capability", name)
+		}
+		return nil, fmt.Errorf("singleton %s depends on per-connection capability %s", path[len(path)-1], name)
+	}
+	deps := &Deps{sc: sc, path: append(path[:len(path):len(path)], name)}
+	c, err := f.f(ctx, deps)
+	deps.done = true
+	if err != nil {
+		return nil, fmt.Errorf("create %s: %v", name, err)
+	}
+	return sc.add(name, c), nil
+}
+
+// add adds c to the scope under name and returns a new reference to it.
+// The caller must hold sc.mu.
+func (sc *Scope) add(name string, c capnp.Client) capnp.Client {
+	rc, ref := refcount.New(c)
+	if sc.insts == nil {
+		sc.insts = make(map[string]instance)
+	}
+	sc.insts[name] = instance{rc, ref}
+	sc.order = append(sc.order, name)
+	return rc.Ref()
+}
+
+// Close closes the scope's references to its capabilities, in the
+// reverse order of their creation, and returns the first error.  Once a
+// scope is closed, Get fails.
+func (sc *Scope) Close() error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.closed {
+		return nil
+	}
+	sc.closed = true
+	var firstErr error
+	for i := len(sc.order) - 1; i >= 0; i-- {
+		if err := sc.insts[sc.order[i]].ref.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	sc.insts, sc.order = nil, nil
+	return firstErr
+}
+
+// Deps gives a factory access to the capabilities that it depends on.
+type Deps struct {
+	sc   *Scope
+	path []string
+	done bool
+}
+
+// Get returns a new reference to the capability with the given name,
+// creating it if necessary.  The capability that is being created
+// holds on to the reference, and must close it when it is closed.  Get
+// may only be called while the factory is running.
+func (d *Deps) Get(ctx context.Context, name string) (capnp.Client, error) {
+	if d.done {
+		return nil, errDepsDone
+	}
+	return d.sc.get(ctx, name, d.path)
+}
+
+var (
+	errClosed   = errors.New("scope closed")
+	errDepsDone = errors.New("vat: Deps used after its factory returned")
+)
@@ -0,0 +1,201 @@
+package vat
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/rpc"
+	"github.com/iguazio/go-capnproto2/rpc/internal/pipetransport"
+	"github.com/iguazio/go-capnproto2/rpc/internal/testcapnp"
+)
+
+// counter counts the capabilities created and closed.
+type counter struct {
+	mu      sync.Mutex
+	created map[string]int
+	closed  map[string]int
+}
+
+func newCounter() *counter {
+	return &counter{created: make(map[string]int), closed: make(map[string]int)}
+}
+
+func (c *counter) counts(name string) (created, closed int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.created[name], c.closed[name]
+}
+
+// factory returns a factory for a capability that closes deps when it
+// is closed.
+func (c *counter) factory(name string, deps ...string) Factory {
+	return func(ctx context.Context, d *Deps) (capnp.Client, error) {
+		tc := &testClient{name: name, c: c}
+		for _, dep := range deps {
+			dc, err := d.Get(ctx, dep)
+			if err != nil {
+				tc.Close()
+				return nil, err
+			}
+			tc.deps = append(tc.deps, dc)
+		}
+		c.mu.Lock()
+		c.created[name]++
+		c.mu.Unlock()
+		return tc, nil
+	}
+}
+
+type testClient struct {
+	name string
+	c    *counter
+	deps []capnp.Client
+}
+
+func (tc *testClient) Call(call *capnp.Call) capnp.Answer {
+	return capnp.ErrorAnswer(capnp.ErrNullClient)
+}
+
+func (tc *testClient) Close() error {
+	for _, d := range tc.deps {
+		d.Close()
+	}
+	tc.c.mu.Lock()
+	tc.c.closed[tc.name]++
+	tc.c.mu.Unlock()
+	return nil
+}
+
+func TestScope(t *testing.T) {
+	ctx := context.Background()
+	cnt := newCounter()
+	v := New()
+	v.Register("clock", Singleton, cnt.factory("clock"))
+	v.Register("storage", Singleton, cnt.factory("storage", "clock"))
+	v.Register("auth", PerConn, cnt.factory("auth", "clock"))
+	v.Register("main", PerConn, cnt.factory("main", "auth", "storage"))
+
+	scopes := []*Scope{v.NewScope(), v.NewScope()}
+	for i, sc := range scopes {
+		for j := 0; j < 2; j++ {
+			c, err := sc.Get(ctx, "main")
+			if err != nil {
+				t.Fatalf("scope %d: Get: %v", i, err)
+			}
+			c.Close()
+		}
+	}
+	for _, test := range []struct {
+		name    string
+		created int
+	}{
+		{"clock", 1},
+		{"storage", 1},
+		{"auth", 2},
+		{"main", 2},
+	} {
+		if n, _ := cnt.counts(test.name); n != test.created {
+			t.Errorf("%s created %d times; want %d", test.name, n, test.created)
+		}
+	}
+
+	if err := scopes[0].Close(); err != nil {
+		t.Error("Close scope 0:", err)
+	}
+	if _, n := cnt.counts("main"); n != 1 {
+		t.Errorf("main closed %d times after closing one scope; want 1", n)
+	}
+	if _, n := cnt.counts("clock"); n != 0 {
+		t.Errorf("clock closed %d times while in use; want 0", n)
+	}
+	if _, err := scopes[0].Get(ctx, "main"); err == nil {
+		t.Error("Get on closed scope succeeded; want error")
+	}
+	scopes[1].Close()
+	if err := v.Close(); err != nil {
+		t.Error("Close vat:", err)
+	}
+	for _, name := range []string{"clock", "storage", "auth", "main"} {
+		created, closed := cnt.counts(name)
+		if created != closed {
+			t.Errorf("%s created %d times, closed %d times", name, created, closed)
+		}
+	}
+}
+
+func TestScope_Errors(t *testing.T) {
+	ctx := context.Background()
+	cnt := newCounter()
+	v := New()
+	v.Register("a", PerConn, cnt.factory("a", "b"))
+	v.Register("b", PerConn, cnt.factory("b", "a"))
+	v.Register("single", Singleton, cnt.factory("single", "a"))
+	v.Register("missing", PerConn, cnt.factory("missing", "nope"))
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"a", "dependency cycle: a -> b -> a"},
+		{"single", "singleton single depends on per-connection capability a"},
+		{"missing", `no capability named "nope"`},
+	}
+	sc := v.NewScope()
+	defer sc.Close()
+	for _, test := range tests {
+		_, err := sc.Get(ctx, test.name)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("Get(%q) = %v; want error containing %q", test.name, err, test.want)
+		}
+	}
+	if _, err := v.Get(ctx, "a"); err == nil {
+		t.Error("Vat.Get of per-connection capability succeeded; want error")
+	}
+}
+
+type adder struct{}
+
+func (adder) Add(call testcapnp.Adder_add) error {
+	call.Results.SetResult(call.Params.A() + call.Params.B())
+	return nil
+}
+
+func TestNewConn(t *testing.T) {
+	ctx := context.Background()
+	cnt := newCounter()
+	v := New()
+	defer v.Close()
+	v.Register("clock", Singleton, cnt.factory("clock"))
+	v.Register("adder", PerConn, func(ctx context.Context, d *Deps) (capnp.Client, error) {
+		clock, err := d.Get(ctx, "clock")
+		if err != nil {
+			return nil, err
+		}
+		clock.Close()
+		return testcapnp.Adder_ServerToClient(adder{}).Client, nil
+	})
+
+	p, q := pipetransport.New()
+	srv := v.NewConn(q, "adder")
+	defer srv.Wait()
+	cl := rpc.NewConn(p)
+	defer cl.Close()
+	a := testcapnp.Adder{Client: cl.Bootstrap(ctx)}
+	res, err := a.Add(ctx, func(p testcapnp.Adder_add_Params) error {
+		p.SetA(2)
+		p.SetB(3)
+		return nil
+	}).Struct()
+	if err != nil {
+		t.Fatal("Add:", err)
+	}
+	if res.Result() != 5 {
+		t.Errorf("Add(2, 3) = %d; want 5", res.Result())
+	}
+	if n, _ := cnt.counts("clock"); n != 1 {
+		t.Errorf("clock created %d times; want 1", n)
+	}
+}
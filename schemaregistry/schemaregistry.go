@@ -0,0 +1,390 @@
+// Package schemaregistry serves the schemas in a schemas.Registry over
+// HTTP and fetches them at runtime, so that programs that read messages
+// dynamically, like those using encoding/text, capnpjson, or capnptypes,
+// can handle types that they weren't built with.
+//
+// A schema is fetched with a GET request to the handler's URL followed
+// by a slash and the node's ID in hexadecimal with a 0x prefix, like
+// https://example.com/schemas/0x8e5322c1e9282534.  The response is the
+// CodeGeneratorRequest message that contains the node, as returned by
+// Registry.Find, with a Content-Type of capnphttp.ContentType.
+package schemaregistry // import "github.com/iguazio/go-capnproto2/schemaregistry"
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/capnphttp"
+	"github.com/iguazio/go-capnproto2/internal/schema"
+	"github.com/iguazio/go-capnproto2/schemas"
+)
+
+// Handler returns an HTTP handler that serves the schemas in reg.  If
+// reg is nil, schemas.DefaultRegistry is used.  The handler uses the
+// last element of the request's path as the ID, so it may be mounted
+// under any prefix.
+func Handler(reg *schemas.Registry) http.Handler {
+	if reg == nil {
+		reg = &schemas.DefaultRegistry
+	}
+	return handler{reg}
+}
+
+type handler struct {
+	reg *schemas.Registry
+}
+
+func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "HEAD" {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "schemaregistry: method must be GET", http.StatusMethodNotAllowed)
+		return
+	}
+	id, err := parseID(path.Base(r.URL.Path))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data, err := h.reg.Find(id)
+	if schemas.IsNotFound(err) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", capnphttp.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Write(data)
+}
+
+// A Client fetches schemas from a handler and adds them to a registry.
+// It is safe for concurrent use.
+type Client struct {
+	// URL is the URL that the handler is mounted at, without the
+	// trailing slash.
+	URL string
+
+	// HTTPClient sends the requests.  If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+
+	// Registry is where fetched schemas are added, and where schemas
+	// are looked for before fetching them.  If nil,
+	// schemas.DefaultRegistry is used.
+	Registry *schemas.Registry
+
+	mu      sync.Mutex // held while registering, too
+	fetches map[uint64]*fetch
+}
+
+// A fetch is a request for a schema that is in progress.
+type fetch struct {
+	done chan struct{}
+	err  error
+}
+
+func (c *Client) registry() *schemas.Registry {
+	if c.Registry != nil {
+		return c.Registry
+	}
+	return &schemas.DefaultRegistry
+}
+
+// Find returns the CodeGeneratorRequest message for the given ID from
+// c.Registry, calling Fetch first if the registry doesn't have it.
+func (c *Client) Find(ctx context.Context, id uint64) ([]byte, error) {
+	data, err := c.registry().Find(id)
+	if !schemas.IsNotFound(err) {
+		return data, err
+	}
+	if err := c.Fetch(ctx, id); err != nil {
+		return nil, err
+	}
+	return c.registry().Find(id)
+}
+
+// Fetch makes sure that the schema of the node with the given ID, and
+// the schemas of the types that its file refers to, are in c.Registry,
+// fetching the ones that are missing.  Types that the handler doesn't
+// have are skipped, unless it is the node with the given ID.  Fetches
+// of the same ID that happen at the same time share one request.
+func (c *Client) Fetch(ctx context.Context, id uint64) error {
+	seen := map[uint64]bool{id: true}
+	queue := []uint64{id}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if err := c.fetch(ctx, next); err != nil {
+			if next != id && isNotFound(err) {
+				continue
+			}
+			return err
+		}
+		data, err := c.registry().Find(next)
+		if err != nil {
+			return err
+		}
+		ids, refs, err := readNodes(data)
+		if err != nil {
+			return fmt.Errorf("schemaregistry: schema for %s: %v", formatID(next), err)
+		}
+		for _, id := range ids {
+			seen[id] = true
+		}
+		for _, ref := range refs {
+			if !seen[ref] {
+				seen[ref] = true
+				queue = append(queue, ref)
+			}
+		}
+	}
+	return nil
+}
+
+// fetch fetches the schema of the node with the given ID if it isn't
+// in the registry.
+func (c *Client) fetch(ctx context.Context, id uint64) error {
+	if _, err := c.registry().Find(id); !schemas.IsNotFound(err) {
+		return err
+	}
+	c.mu.Lock()
+	if f := c.fetches[id]; f != nil {
+		c.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	f := &fetch{done: make(chan struct{})}
+	if c.fetches == nil {
+		c.fetches = make(map[uint64]*fetch)
+	}
+	c.fetches[id] = f
+	c.mu.Unlock()
+
+	f.err = c.get(ctx, id)
+	c.mu.Lock()
+	delete(c.fetches, id)
+	c.mu.Unlock()
+	close(f.done)
+	return f.err
+}
+
+// maxSchemaSize is the largest schema that a Client accepts.
+const maxSchemaSize = 64 << 20
+
+// get requests the schema of the node with the given ID and registers
+// it.
+func (c *Client) get(ctx context.Context, id uint64) error {
+	req, err := http.NewRequest("GET", c.URL+"/"+formatID(id), nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return &StatusError{
+			ID:         id,
+			StatusCode: resp.StatusCode,
+			Message:    strings.TrimSpace(string(msg)),
+		}
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxSchemaSize+1))
+	if err != nil {
+		return err
+	}
+	if len(data) > maxSchemaSize {
+		return fmt.Errorf("schemaregistry: schema for %s is larger than %d bytes", formatID(id), maxSchemaSize)
+	}
+	ids, _, err := readNodes(data)
+	if err != nil {
+		return fmt.Errorf("schemaregistry: schema for %s: %v", formatID(id), err)
+	}
+	return c.register(id, data, ids)
+}
+
+// register adds a fetched schema to the registry under the IDs of its
+// nodes that aren't registered yet.
+func (c *Client) register(id uint64, data []byte, ids []uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	reg := c.registry()
+	found := false
+	var missing []uint64
+	for _, n := range ids {
+		if n == id {
+			found = true
+		}
+		if _, err := reg.Find(n); schemas.IsNotFound(err) {
+			missing = append(missing, n)
+		}
+	}
+	if !found {
+		return fmt.Errorf("schemaregistry: schema for %s doesn't contain it", formatID(id))
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return reg.Register(&schemas.Schema{Bytes: data, Nodes: missing})
+}
+
+// A StatusError is returned by Client when the handler doesn't respond
+// with 200 OK.
+type StatusError struct {
+	ID         uint64
+	StatusCode int
+
+	// Message is the body of the response.
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	msg := "schemaregistry: fetch " + formatID(e.ID) + ": " + http.StatusText(e.StatusCode)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+func isNotFound(err error) bool {
+	se, ok := err.(*StatusError)
+	return ok && se.StatusCode == http.StatusNotFound
+}
+
+// readNodes returns the IDs of the nodes in a CodeGeneratorRequest and
+// the IDs of the types outside of it that they refer to.
+func readNodes(data []byte) (ids, refs []uint64, err error) {
+	msg, err := capnp.Unmarshal(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := schema.ReadRootCodeGeneratorRequest(msg)
+	if err != nil {
+		return nil, nil, err
+	}
+	nodes, err := req.Nodes()
+	if err != nil {
+		return nil, nil, err
+	}
+	ids = make([]uint64, nodes.Len())
+	in := make(map[uint64]bool, nodes.Len())
+	for i := range ids {
+		ids[i] = nodes.At(i).Id()
+		in[ids[i]] = true
+	}
+	add := func(id uint64) {
+		if id != 0 && !in[id] {
+			in[id] = true
+			refs = append(refs, id)
+		}
+	}
+	for i := 0; i < nodes.Len(); i++ {
+		if err := nodeRefs(nodes.At(i), add); err != nil {
+			return nil, nil, err
+		}
+	}
+	return ids, refs, nil
+}
+
+// nodeRefs calls add with the ID of each type that n refers to.
+func nodeRefs(n schema.Node, add func(uint64)) error {
+	switch n.Which() {
+	case schema.Node_Which_structNode:
+		fields, err := n.StructNode().Fields()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < fields.Len(); i++ {
+			f := fields.At(i)
+			switch f.Which() {
+			case schema.Field_Which_group:
+				add(f.Group().TypeId())
+			case schema.Field_Which_slot:
+				t, err := f.Slot().Type()
+				if err != nil {
+					return err
+				}
+				if err := typeRefs(t, add); err != nil {
+					return err
+				}
+			}
+		}
+	case schema.Node_Which_interface:
+		methods, err := n.Interface().Methods()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < methods.Len(); i++ {
+			add(methods.At(i).ParamStructType())
+			add(methods.At(i).ResultStructType())
+		}
+		supers, err := n.Interface().Superclasses()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < supers.Len(); i++ {
+			add(supers.At(i).Id())
+		}
+	case schema.Node_Which_const:
+		t, err := n.Const().Type()
+		if err != nil {
+			return err
+		}
+		return typeRefs(t, add)
+	}
+	return nil
+}
+
+// typeRefs calls add with the ID of the named type in t.
+func typeRefs(t schema.Type, add func(uint64)) error {
+	switch t.Which() {
+	case schema.Type_Which_structType:
+		add(t.StructType().TypeId())
+	case schema.Type_Which_enum:
+		add(t.Enum().TypeId())
+	case schema.Type_Which_interface:
+		add(t.Interface().TypeId())
+	case schema.Type_Which_list:
+		et, err := t.List().ElementType()
+		if err != nil {
+			return err
+		}
+		return typeRefs(et, add)
+	}
+	return nil
+}
+
+func formatID(id uint64) string {
+	return fmt.Sprintf("0x%016x", id)
+}
+
+func parseID(s string) (uint64, error) {
+	if !strings.HasPrefix(s, "0x") {
+		return 0, fmt.Errorf("schemaregistry: ID %q does not start with 0x", s)
+	}
+	id, err := strconv.ParseUint(s[2:], 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("schemaregistry: ID %q: %v", s, err)
+	}
+	return id, nil
+}
@@ -0,0 +1,115 @@
+package schemaregistry
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"golang.org/x/net/context"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+	"github.com/iguazio/go-capnproto2/schemas"
+)
+
+// countingHandler counts the requests that reach h.
+type countingHandler struct {
+	h http.Handler
+	n int32
+}
+
+func (ch *countingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	atomic.AddInt32(&ch.n, 1)
+	ch.h.ServeHTTP(w, r)
+}
+
+func TestClient(t *testing.T) {
+	ch := &countingHandler{h: http.StripPrefix("/schemas", Handler(nil))}
+	srv := httptest.NewServer(ch)
+	defer srv.Close()
+	reg := new(schemas.Registry)
+	c := &Client{URL: srv.URL + "/schemas", Registry: reg}
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Find(ctx, air.Z_TypeID); err != nil {
+				t.Error("Find:", err)
+			}
+		}()
+	}
+	wg.Wait()
+	want, err := schemas.DefaultRegistry.Find(air.Z_TypeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := reg.Find(air.Z_TypeID)
+	if err != nil {
+		t.Fatal("registry.Find after fetch:", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("fetched schema differs from served schema")
+	}
+	// Other nodes of the same file are registered by the same fetch.
+	if _, err := c.Find(ctx, air.PlaneBase_TypeID); err != nil {
+		t.Error("Find(PlaneBase):", err)
+	}
+	if n := atomic.LoadInt32(&ch.n); n != 1 {
+		t.Errorf("handler got %d requests; want 1", n)
+	}
+
+	_, err = c.Find(ctx, 0x1234)
+	if se, ok := err.(*StatusError); !ok || se.StatusCode != http.StatusNotFound {
+		t.Errorf("Find(unknown) = %v; want 404 *StatusError", err)
+	}
+}
+
+func TestHandler_BadRequests(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		code   int
+	}{
+		{"POST", "/0xea26e9973bd6a0d9", http.StatusMethodNotAllowed},
+		{"GET", "/ea26e9973bd6a0d9", http.StatusBadRequest},
+		{"GET", "/0xzz", http.StatusBadRequest},
+		{"GET", "/0x1", http.StatusNotFound},
+	}
+	h := Handler(nil)
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, test.path, nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != test.code {
+			t.Errorf("%s %s: status %d; want %d", test.method, test.path, w.Code, test.code)
+		}
+	}
+}
+
+func TestReadNodes(t *testing.T) {
+	data, err := schemas.DefaultRegistry.Find(air.Z_TypeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids, refs, err := readNodes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, id := range ids {
+		if id == air.Z_TypeID {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("readNodes IDs don't include Z (%#x)", uint64(air.Z_TypeID))
+	}
+	// aircraft.capnp only refers to its own types.
+	if len(refs) != 0 {
+		t.Errorf("readNodes refs = %#x; want none", refs)
+	}
+}
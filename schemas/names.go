@@ -0,0 +1,391 @@
+package schemas
+
+import (
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"sync"
+)
+
+// TypeName returns the name of the node with the given ID as it
+// appears in its file, like "Storage" or "Storage.Entry".  If the ID
+// is not found, TypeName returns an error that can be identified with
+// IsNotFound.
+func (reg *Registry) TypeName(id uint64) (string, error) {
+	n, err := reg.findNames(id)
+	if err != nil {
+		return "", err
+	}
+	return n.displayName[n.prefixLen:], nil
+}
+
+// MethodName returns the name of a method for logs and error messages,
+// like "Storage.put".  If the interface is not found, MethodName
+// returns an error that can be identified with IsNotFound.
+func (reg *Registry) MethodName(interfaceID uint64, methodID uint16) (string, error) {
+	n, err := reg.findNames(interfaceID)
+	if err != nil {
+		return "", err
+	}
+	if int(methodID) >= len(n.methods) {
+		return "", &methodNotFoundError{interfaceID, methodID}
+	}
+	return n.displayName[n.prefixLen:] + "." + n.methods[methodID], nil
+}
+
+// MethodNames returns the names that describe a method in a
+// capnp.Method: the interface's display name, which includes the name
+// of its file, and the method's name.  If the interface is not found,
+// MethodNames returns an error that can be identified with IsNotFound.
+func (reg *Registry) MethodNames(interfaceID uint64, methodID uint16) (interfaceName, methodName string, err error) {
+	n, err := reg.findNames(interfaceID)
+	if err != nil {
+		return "", "", err
+	}
+	if int(methodID) >= len(n.methods) {
+		return "", "", &methodNotFoundError{interfaceID, methodID}
+	}
+	return n.displayName, n.methods[methodID], nil
+}
+
+// TypeName returns the name of the node with the given ID in the
+// default registry, or "@0x" followed by the ID in hexadecimal if it
+// is not registered.
+func TypeName(id uint64) string {
+	name, err := DefaultRegistry.TypeName(id)
+	if err != nil {
+		return "@0x" + strconv.FormatUint(id, 16)
+	}
+	return name
+}
+
+// MethodName returns the name of a method in the default registry, like
+// "Storage.put".  Unregistered interfaces and methods are formatted by
+// ID, as capnp.Method.String does, like "@0x8e5322c1e9282534.@1".
+func MethodName(interfaceID uint64, methodID uint16) string {
+	name, err := DefaultRegistry.MethodName(interfaceID, methodID)
+	if err == nil {
+		return name
+	}
+	if _, ok := err.(*methodNotFoundError); ok {
+		iname, _ := DefaultRegistry.TypeName(interfaceID)
+		return iname + ".@" + strconv.FormatUint(uint64(methodID), 10)
+	}
+	return "@0x" + strconv.FormatUint(interfaceID, 16) + ".@" + strconv.FormatUint(uint64(methodID), 10)
+}
+
+// nodeNames holds the names of a node and its methods.
+type nodeNames struct {
+	displayName string
+	prefixLen   int
+	methods     []string // by method ID
+}
+
+func (reg *Registry) findNames(id uint64) (*nodeNames, error) {
+	reg.mu.RLock()
+	r := reg.m[id]
+	reg.mu.RUnlock()
+	if r == nil {
+		return nil, &notFoundError{id: id}
+	}
+	names, err := r.readNames()
+	if err != nil {
+		return nil, &decompressError{id, err}
+	}
+	n := names[id]
+	if n == nil {
+		return nil, &notFoundError{id: id}
+	}
+	return n, nil
+}
+
+// nameIndex is the names of the nodes in a record, which are read the
+// first time that they are needed.
+type nameIndex struct {
+	once  sync.Once
+	names map[uint64]*nodeNames
+	err   error
+}
+
+func (r *record) readNames() (map[uint64]*nodeNames, error) {
+	r.names.once.Do(func() {
+		data, err := r.read()
+		if err != nil {
+			r.names.err = err
+			return
+		}
+		r.names.names, r.names.err = readNodeNames(data)
+	})
+	return r.names.names, r.names.err
+}
+
+type methodNotFoundError struct {
+	interfaceID uint64
+	methodID    uint16
+}
+
+func (e *methodNotFoundError) Error() string {
+	return "schemas: interface @0x" + strconv.FormatUint(e.interfaceID, 16) + " has no method @" + strconv.FormatUint(uint64(e.methodID), 10)
+}
+
+// The capnp package depends on this package, so this package reads the
+// few parts of a CodeGeneratorRequest that it needs directly.
+
+// Offsets in schema.capnp.
+const (
+	nodeWhichOffset     = 12
+	nodeWhichInterface  = 3
+	nodePrefixLenOffset = 8
+	nodeMethodsPtr      = 3
+)
+
+// readNodeNames returns the names of the nodes in a serialized
+// CodeGeneratorRequest message.
+func readNodeNames(data []byte) (map[uint64]*nodeNames, error) {
+	m, err := newMsgReader(data)
+	if err != nil {
+		return nil, err
+	}
+	req, err := m.root()
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := m.structList(req, 0)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uint64]*nodeNames, nodes.n)
+	for i := 0; i < nodes.n; i++ {
+		node := nodes.at(i)
+		name, err := m.text(node, 0)
+		if err != nil {
+			return nil, err
+		}
+		n := &nodeNames{
+			displayName: name,
+			prefixLen:   int(m.uint32(node, nodePrefixLenOffset)),
+		}
+		if n.prefixLen > len(name) {
+			n.prefixLen = 0
+		}
+		if m.uint16(node, nodeWhichOffset) == nodeWhichInterface {
+			methods, err := m.structList(node, nodeMethodsPtr)
+			if err != nil {
+				return nil, err
+			}
+			n.methods = make([]string, methods.n)
+			for j := range n.methods {
+				if n.methods[j], err = m.text(methods.at(j), 0); err != nil {
+					return nil, err
+				}
+			}
+		}
+		names[m.uint64(node, 0)] = n
+	}
+	return names, nil
+}
+
+// A msgReader reads structs, lists of structs, and text from a message
+// in the standard stream framing.
+type msgReader struct {
+	segs [][]byte
+}
+
+// A structRef locates a struct's data and pointer sections.
+type structRef struct {
+	seg      int
+	off      int // byte offset of the data section
+	dataSize int // in bytes
+	ptrCount int
+}
+
+// A structListRef locates the elements of a list of structs.
+type structListRef struct {
+	n    int
+	elem structRef // the first element
+	step int       // bytes between elements
+}
+
+func (l structListRef) at(i int) structRef {
+	s := l.elem
+	s.off += i * l.step
+	return s
+}
+
+var errBadSchema = errors.New("malformed schema message")
+
+func newMsgReader(data []byte) (*msgReader, error) {
+	if len(data) < 4 {
+		return nil, errBadSchema
+	}
+	nsegs := int(binary.LittleEndian.Uint32(data)) + 1
+	hdr := 4 + 4*nsegs
+	hdr += hdr % 8
+	if nsegs > len(data)/4 || hdr > len(data) {
+		return nil, errBadSchema
+	}
+	m := &msgReader{segs: make([][]byte, nsegs)}
+	off := hdr
+	for i := range m.segs {
+		sz := int(binary.LittleEndian.Uint32(data[4+4*i:])) * 8
+		if sz < 0 || sz > len(data)-off {
+			return nil, errBadSchema
+		}
+		m.segs[i] = data[off : off+sz]
+		off += sz
+	}
+	return m, nil
+}
+
+func (m *msgReader) word(seg, off int) (uint64, bool) {
+	if seg < 0 || seg >= len(m.segs) || off < 0 || off+8 > len(m.segs[seg]) {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint64(m.segs[seg][off:]), true
+}
+
+// pointer resolves the pointer at off in seg, following far pointers.
+// It returns the pointer that describes the object and the location of
+// the object's content.  A null pointer is returned as zero.
+func (m *msgReader) pointer(seg, off int) (p uint64, tseg, toff int, err error) {
+	p, ok := m.word(seg, off)
+	if !ok {
+		return 0, 0, 0, errBadSchema
+	}
+	if p == 0 {
+		return 0, 0, 0, nil
+	}
+	if p&3 != 2 {
+		return p, seg, off + 8 + farOffset(p), nil
+	}
+	padSeg, padOff := int(p>>32), int(p>>3&(1<<29-1))*8
+	if p&4 == 0 {
+		pad, ok := m.word(padSeg, padOff)
+		if !ok || pad&3 == 2 {
+			return 0, 0, 0, errBadSchema
+		}
+		return pad, padSeg, padOff + 8 + farOffset(pad), nil
+	}
+	far, ok1 := m.word(padSeg, padOff)
+	tag, ok2 := m.word(padSeg, padOff+8)
+	if !ok1 || !ok2 || far&7 != 2 {
+		return 0, 0, 0, errBadSchema
+	}
+	return tag, int(far >> 32), int(far>>3&(1<<29-1)) * 8, nil
+}
+
+// farOffset returns the signed word offset of a struct or list pointer
+// in bytes.
+func farOffset(p uint64) int {
+	return int(int32(uint32(p))>>2) * 8
+}
+
+func (m *msgReader) root() (structRef, error) {
+	p, seg, off, err := m.pointer(0, 0)
+	if err != nil {
+		return structRef{}, err
+	}
+	return m.structAt(p, seg, off)
+}
+
+func (m *msgReader) structAt(p uint64, seg, off int) (structRef, error) {
+	if p == 0 {
+		return structRef{}, nil
+	}
+	if p&3 != 0 {
+		return structRef{}, errBadSchema
+	}
+	s := structRef{
+		seg:      seg,
+		off:      off,
+		dataSize: int(uint16(p>>32)) * 8,
+		ptrCount: int(uint16(p >> 48)),
+	}
+	if seg >= len(m.segs) || off < 0 || off+s.dataSize+s.ptrCount*8 > len(m.segs[seg]) {
+		return structRef{}, errBadSchema
+	}
+	return s, nil
+}
+
+func (m *msgReader) ptrAddr(s structRef, i int) (int, bool) {
+	if i >= s.ptrCount {
+		return 0, false
+	}
+	return s.off + s.dataSize + i*8, true
+}
+
+func (m *msgReader) structList(s structRef, i int) (structListRef, error) {
+	addr, ok := m.ptrAddr(s, i)
+	if !ok {
+		return structListRef{}, nil
+	}
+	p, seg, off, err := m.pointer(s.seg, addr)
+	if err != nil || p == 0 {
+		return structListRef{}, err
+	}
+	if p&3 != 1 || p>>32&7 != 7 {
+		return structListRef{}, errBadSchema
+	}
+	tag, ok := m.word(seg, off)
+	if !ok || tag&3 != 0 {
+		return structListRef{}, errBadSchema
+	}
+	elem, err := m.structAt(tag, seg, off+8)
+	if err != nil {
+		return structListRef{}, err
+	}
+	l := structListRef{
+		n:    int(int32(uint32(tag)) >> 2),
+		elem: elem,
+		step: elem.dataSize + elem.ptrCount*8,
+	}
+	words := int(p >> 35)
+	if l.n < 0 || l.n*l.step > words*8 || off+8+words*8 > len(m.segs[seg]) {
+		return structListRef{}, errBadSchema
+	}
+	return l, nil
+}
+
+func (m *msgReader) text(s structRef, i int) (string, error) {
+	addr, ok := m.ptrAddr(s, i)
+	if !ok {
+		return "", nil
+	}
+	p, seg, off, err := m.pointer(s.seg, addr)
+	if err != nil || p == 0 {
+		return "", err
+	}
+	if p&3 != 1 || p>>32&7 != 2 {
+		return "", errBadSchema
+	}
+	n := int(p >> 35)
+	if off+n > len(m.segs[seg]) {
+		return "", errBadSchema
+	}
+	b := m.segs[seg][off : off+n]
+	if len(b) > 0 && b[len(b)-1] == 0 {
+		b = b[:len(b)-1]
+	}
+	return string(b), nil
+}
+
+func (m *msgReader) uint64(s structRef, off int) uint64 {
+	if off+8 > s.dataSize {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(m.segs[s.seg][s.off+off:])
+}
+
+func (m *msgReader) uint32(s structRef, off int) uint32 {
+	if off+4 > s.dataSize {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(m.segs[s.seg][s.off+off:])
+}
+
+func (m *msgReader) uint16(s structRef, off int) uint16 {
+	if off+2 > s.dataSize {
+		return 0
+	}
+	return binary.LittleEndian.Uint16(m.segs[s.seg][s.off+off:])
+}
@@ -35,14 +35,18 @@ type Schema struct {
 	Nodes []uint64
 }
 
-// A Registry is a mapping of IDs to schema blobs.  It is safe to read
-// from multiple goroutines.  The zero value is an empty registry.
+// A Registry is a mapping of IDs to schema blobs.  It is safe for
+// concurrent use, so schemas can be added while it is being read, as
+// when they are fetched from a service.  The zero value is an empty
+// registry.
 type Registry struct {
-	m map[uint64]*record
+	mu sync.RWMutex
+	m  map[uint64]*record
 }
 
 // Register indexes a schema in the registry.  It is an error to
-// register schemas with overlapping IDs.
+// register schemas with overlapping IDs, and then none of the
+// schema's IDs are registered.
 func (reg *Registry) Register(s *Schema) error {
 	if len(s.String) > 0 && len(s.Bytes) > 0 {
 		return errors.New("schemas: schema should have only one of string or bytes")
@@ -52,13 +56,17 @@ func (reg *Registry) Register(s *Schema) error {
 		data:       s.Bytes,
 		compressed: s.Compressed,
 	}
-	if reg.m == nil {
-		reg.m = make(map[uint64]*record)
-	}
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
 	for _, id := range s.Nodes {
 		if _, dup := reg.m[id]; dup {
 			return &dupeError{id: id}
 		}
+	}
+	if reg.m == nil {
+		reg.m = make(map[uint64]*record)
+	}
+	for _, id := range s.Nodes {
 		reg.m[id] = r
 	}
 	return nil
@@ -69,7 +77,9 @@ func (reg *Registry) Register(s *Schema) error {
 // an error that can be identified with IsNotFound.  The returned byte
 // slice should not be modified.
 func (reg *Registry) Find(id uint64) ([]byte, error) {
+	reg.mu.RLock()
 	r := reg.m[id]
+	reg.mu.RUnlock()
 	if r == nil {
 		return nil, &notFoundError{id: id}
 	}
@@ -87,6 +97,9 @@ type record struct {
 	compressed bool
 	data       []byte // input and result
 	err        error  // result
+
+	// names is read from data when it is first needed.
+	names nameIndex
 }
 
 func (r *record) read() ([]byte, error) {
@@ -140,8 +153,7 @@ func Register(data string, ids ...uint64) {
 // Find returns the CodeGeneratorRequest message for the given ID,
 // suitable for capnp.Unmarshal, or nil if the ID was not found.
 // It is safe to call Find from multiple goroutines, so the returned
-// byte slice should not be modified.  However, it is not safe to
-// call Find concurrently with Register.
+// byte slice should not be modified.
 func Find(id uint64) []byte {
 	b, err := DefaultRegistry.Find(id)
 	if IsNotFound(err) {
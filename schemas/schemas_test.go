@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
 	"github.com/iguazio/go-capnproto2/internal/schema"
 	"github.com/iguazio/go-capnproto2/schemas"
 )
@@ -51,3 +52,63 @@ func TestNotFound(t *testing.T) {
 		t.Errorf("new(schemas.Registry).Find(0) = %v; want not found error", err)
 	}
 }
+
+func TestRegisterOverlap(t *testing.T) {
+	reg := new(schemas.Registry)
+	if err := reg.Register(&schemas.Schema{String: "a", Nodes: []uint64{1, 2}}); err != nil {
+		t.Fatal("Register:", err)
+	}
+	if err := reg.Register(&schemas.Schema{String: "b", Nodes: []uint64{3, 2}}); err == nil {
+		t.Error("Register with overlapping IDs succeeded; want error")
+	}
+	if _, err := reg.Find(3); !schemas.IsNotFound(err) {
+		t.Errorf("after failed Register, Find(3) = %v; want not found error", err)
+	}
+}
+
+func TestNames(t *testing.T) {
+	if name := schemas.MethodName(air.Echo_TypeID, 0); name != "Echo.echo" {
+		t.Errorf("MethodName(Echo, 0) = %q; want \"Echo.echo\"", name)
+	}
+	if name := schemas.MethodName(air.Echo_TypeID, 7); name != "Echo.@7" {
+		t.Errorf("MethodName(Echo, 7) = %q; want \"Echo.@7\"", name)
+	}
+	if name := schemas.MethodName(0xdeadbeef, 2); name != "@0xdeadbeef.@2" {
+		t.Errorf("MethodName(0xdeadbeef, 2) = %q; want \"@0xdeadbeef.@2\"", name)
+	}
+	if name := schemas.TypeName(air.PlaneBase_TypeID); name != "PlaneBase" {
+		t.Errorf("TypeName(PlaneBase) = %q; want \"PlaneBase\"", name)
+	}
+	iname, mname, err := schemas.DefaultRegistry.MethodNames(air.Echo_TypeID, 0)
+	if err != nil || iname != "aircraft.capnp:Echo" || mname != "echo" {
+		t.Errorf("MethodNames(Echo, 0) = %q, %q, %v; want \"aircraft.capnp:Echo\", \"echo\", <nil>", iname, mname, err)
+	}
+	if _, err := schemas.DefaultRegistry.TypeName(0xdeadbeef); !schemas.IsNotFound(err) {
+		t.Errorf("TypeName(0xdeadbeef) error = %v; want not found error", err)
+	}
+
+	// Every node in the file has the name that the schema package reads.
+	msg, err := capnp.Unmarshal(schemas.Find(air.Echo_TypeID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := schema.ReadRootCodeGeneratorRequest(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes, err := req.Nodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < nodes.Len(); i++ {
+		n := nodes.At(i)
+		if n.Id() == 0 {
+			continue
+		}
+		dn, _ := n.DisplayName()
+		want := dn[n.DisplayNamePrefixLength():]
+		if got, err := schemas.DefaultRegistry.TypeName(n.Id()); err != nil || got != want {
+			t.Errorf("TypeName(%#x) = %q, %v; want %q", n.Id(), got, err, want)
+		}
+	}
+}
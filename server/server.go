@@ -6,10 +6,13 @@ import (
 	"errors"
 	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"golang.org/x/net/context"
 	"github.com/iguazio/go-capnproto2"
 	"github.com/iguazio/go-capnproto2/internal/fulfiller"
+	"github.com/iguazio/go-capnproto2/queue"
 )
 
 // A Method describes a single method on a server object.
@@ -27,12 +30,40 @@ type Closer interface {
 	Close() error
 }
 
+// DefaultQueueSize is the number of calls that a server client holds
+// waiting for delivery when Options.QueueSize is zero.
+const DefaultQueueSize = 64
+
+// Options configure a server client.  A nil *Options is the same as the
+// zero value.
+type Options struct {
+	// State is attached to the client as with NewWithState.
+	State interface{}
+
+	// QueueSize is the number of calls that can wait to be delivered
+	// while an earlier call has not been acknowledged.  Zero means
+	// DefaultQueueSize.
+	QueueSize int
+
+	// Overflow chooses what a call does when the queue is full.  The
+	// zero value, queue.Reject, fails the call with queue.ErrFull.
+	// queue.DropOldest fails the call that has waited longest instead.
+	// queue.Block waits for room, for at most Timeout if it is positive,
+	// or until the call's context is done.
+	Overflow queue.Overflow
+	Timeout  time.Duration
+}
+
 // A server is a locally implemented interface.
 type server struct {
-	methods sortedMethods
+	methods methodTable
 	closer  Closer
-	queue   chan *call
-	stop    chan struct{}
+	state   interface{}
+	timeout time.Duration
+	calls   *queue.Queue[*call]
+	ctx     context.Context // canceled by Close
+	cancel  context.CancelFunc
+	stop    <-chan struct{} // ctx.Done()
 	done    chan struct{}
 }
 
@@ -42,15 +73,58 @@ type server struct {
 // return or acknowledgment of the previous call.  See the Ack function
 // for more details.
 func New(methods []Method, closer Closer) capnp.Client {
+	return NewWithState(methods, closer, nil)
+}
+
+// NewWithState is like New, but attaches state to the client.  Method
+// implementations, and any Func that wraps one, can retrieve it from
+// their call context with StateFromContext.  This lets many clients
+// share one implementation while each keeps its own state.
+func NewWithState(methods []Method, closer Closer, state interface{}) capnp.Client {
+	return newServer(newMethodTable(methods), closer, &Options{State: state, Overflow: queue.Block})
+}
+
+// NewTable is like New, but dispatches calls through a table of methods
+// that is already grouped by interface, like the tables that capnpc-go
+// generates, instead of building one from a list of methods.
+func NewTable(t Table, closer Closer) capnp.Client {
+	return newServer(methodTable{ifaces: t}, closer, &Options{Overflow: queue.Block})
+}
+
+// NewWithOptions is like New, but bounds the calls that wait to be
+// delivered as opts says.  The returned client implements
+// queue.Reporter.
+func NewWithOptions(methods []Method, closer Closer, opts *Options) capnp.Client {
+	return newServer(newMethodTable(methods), closer, opts)
+}
+
+func newServer(methods methodTable, closer Closer, opts *Options) *server {
+	if opts == nil {
+		opts = new(Options)
+	}
+	size := opts.QueueSize
+	if size == 0 {
+		size = DefaultQueueSize
+	}
+	ctx, cancel := context.WithCancel(context.Background())
 	s := &server{
-		methods: make(sortedMethods, len(methods)),
+		methods: methods,
 		closer:  closer,
-		queue:   make(chan *call),
-		stop:    make(chan struct{}),
+		state:   opts.State,
+		timeout: opts.Timeout,
+		ctx:     ctx,
+		cancel:  cancel,
+		stop:    ctx.Done(),
 		done:    make(chan struct{}),
 	}
-	copy(s.methods, methods)
-	sort.Sort(s.methods)
+	s.calls = queue.New[*call](size, &queue.Options[*call]{
+		Overflow: opts.Overflow,
+		Drop: func(cl *call) {
+			if cl.take() {
+				cl.ans.Reject(errDropped)
+			}
+		},
+	})
 	go s.dispatch()
 	return s
 }
@@ -59,18 +133,66 @@ func New(methods []Method, closer Closer) capnp.Client {
 func (s *server) dispatch() {
 	defer close(s.done)
 	for {
-		select {
-		case cl := <-s.queue:
-			err := s.startCall(cl)
-			if err != nil {
-				cl.ans.Reject(err)
+		cl, err := s.calls.Recv(s.ctx)
+		if err != nil || s.ctx.Err() != nil {
+			// Closed.  Make room for callers blocked in push; they give
+			// up once they see that the server is closed.
+			for _, ok := s.calls.Pop(); ok; _, ok = s.calls.Pop() {
 			}
-		case <-s.stop:
 			return
 		}
+		if !cl.take() {
+			// The caller gave up on it.
+			continue
+		}
+		if err := s.startCall(cl); err != nil {
+			cl.ans.Reject(err)
+		}
 	}
 }
 
+// push queues cl for the dispatch goroutine and waits until it takes
+// the call, so that calls are delivered in order.  It returns an error
+// if the call can't be queued or its context is done or the server is
+// closed before the call is taken.
+func (s *server) push(cl *call) error {
+	select {
+	case <-s.stop:
+		return errClosed
+	default:
+	}
+	ctx := cl.Ctx
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+	if err := s.calls.PushContext(ctx, cl); err != nil {
+		if err == context.DeadlineExceeded && cl.Ctx.Err() == nil {
+			return queue.ErrFull
+		}
+		return err
+	}
+	var err error
+	select {
+	case <-cl.taken:
+		return nil
+	case <-s.stop:
+		// Nothing is delivered after the server is closed.  Remove a
+		// call so that the next caller blocked on a full queue can get
+		// in and see that the server is closed too.
+		s.calls.Pop()
+		err = errClosed
+	case <-cl.Ctx.Done():
+		err = cl.Ctx.Err()
+	}
+	if !cl.abandon() {
+		// Taken in the meantime; its answer will be resolved.
+		return nil
+	}
+	return err
+}
+
 // startCall runs in the dispatch goroutine to start a call.
 func (s *server) startCall(cl *call) error {
 	_, out, err := capnp.NewMessage(capnp.SingleSegment(nil))
@@ -83,8 +205,12 @@ func (s *server) startCall(cl *call) error {
 	}
 	acksig := newAckSignal()
 	opts := cl.Options.With([]capnp.CallOption{capnp.SetOptionValue(ackSignalKey, acksig)})
+	ctx := cl.Ctx
+	if s.state != nil {
+		ctx = context.WithValue(ctx, stateKey{}, s.state)
+	}
 	go func() {
-		err := cl.method.Impl(cl.Ctx, opts, cl.Params, results)
+		err := cl.method.Impl(ctx, opts, cl.Params, results)
 		if err == nil {
 			cl.ans.Fulfill(results)
 		} else {
@@ -116,18 +242,20 @@ func (s *server) Call(cl *capnp.Call) capnp.Answer {
 		return capnp.ErrorAnswer(err)
 	}
 	scall := newCall(cl, sm)
-	select {
-	case s.queue <- scall:
-		return &scall.ans
-	case <-s.stop:
-		return capnp.ErrorAnswer(errClosed)
-	case <-cl.Ctx.Done():
-		return capnp.ErrorAnswer(cl.Ctx.Err())
+	if err := s.push(scall); err != nil {
+		return capnp.ErrorAnswer(err)
 	}
+	return &scall.ans
+}
+
+// QueueStats returns the statistics of the queue of calls waiting to be
+// delivered.
+func (s *server) QueueStats() queue.Stats {
+	return s.calls.Stats()
 }
 
 func (s *server) Close() error {
-	close(s.stop)
+	s.cancel()
 	<-s.done
 	if s.closer == nil {
 		return nil
@@ -159,14 +287,126 @@ func Ack(opts capnp.CallOptions) {
 	}
 }
 
+// StateFromContext returns the state attached to the server client
+// that is handling the call with the given context, or nil if the
+// client was created without state.  Callers usually type-assert the
+// result:
+//
+//	func (my *myServer) MyMethod(call schema.MyServer_myMethod) error {
+//		sess := server.StateFromContext(call.Ctx).(*session)
+//		// ...
+//	}
+func StateFromContext(ctx context.Context) interface{} {
+	return ctx.Value(stateKey{})
+}
+
+// stateKey is the context key for the server's state.
+type stateKey struct{}
+
 type call struct {
 	*capnp.Call
 	ans    fulfiller.Fulfiller
 	method *Method
+
+	state int32         // one of the call* constants; accessed atomically
+	taken chan struct{} // closed when state becomes callTaken
 }
 
+// States of a queued call.
+const (
+	callWaiting   int32 = iota
+	callTaken           // started or dropped; ans will be resolved
+	callAbandoned       // the caller gave up waiting for it
+)
+
 func newCall(cl *capnp.Call, sm *Method) *call {
-	return &call{Call: cl, method: sm}
+	return &call{Call: cl, method: sm, taken: make(chan struct{})}
+}
+
+// take marks a waiting call as taken from the queue, reporting false if
+// the caller gave up on it.
+func (cl *call) take() bool {
+	if !atomic.CompareAndSwapInt32(&cl.state, callWaiting, callTaken) {
+		return false
+	}
+	close(cl.taken)
+	return true
+}
+
+// abandon marks a waiting call as given up on, reporting false if it was
+// already taken.
+func (cl *call) abandon() bool {
+	return atomic.CompareAndSwapInt32(&cl.state, callWaiting, callAbandoned)
+}
+
+// A Table holds a server's methods grouped by interface.  Method IDs
+// are the ordinals of the methods in their interface, so each
+// interface's methods can be indexed directly by method ID instead of
+// searched for.  capnpc-go generates a Table for each interface.
+type Table []Interface
+
+// An Interface holds the methods of one interface in a Table.  The
+// Interfaces of a Table must be sorted by ID, and Methods[i] must have
+// method ID i.  A method with a nil Impl is unimplemented.
+type Interface struct {
+	ID      uint64
+	Methods []Method
+}
+
+// A methodTable finds a server's methods by ID.  Methods of interfaces
+// with sparse IDs, which only occur in hand-written method lists, are
+// found by binary search.
+type methodTable struct {
+	ifaces Table
+	sparse sortedMethods
+}
+
+func newMethodTable(methods []Method) methodTable {
+	sm := make(sortedMethods, len(methods))
+	copy(sm, methods)
+	sort.Stable(sm)
+	var t methodTable
+	for i := 0; i < len(sm); {
+		j := i + 1
+		for j < len(sm) && sm[j].InterfaceID == sm[i].InterfaceID {
+			j++
+		}
+		group := sm[i:j]
+		if n := int(group[len(group)-1].MethodID) + 1; n > 2*len(group)+8 {
+			t.sparse = append(t.sparse, group...)
+		} else {
+			iface := Interface{ID: group[0].InterfaceID, Methods: make([]Method, n)}
+			for k := len(group) - 1; k >= 0; k-- {
+				iface.Methods[group[k].MethodID] = group[k]
+			}
+			t.ifaces = append(t.ifaces, iface)
+		}
+		i = j
+	}
+	return t
+}
+
+// find returns the method with the given ID or nil.
+func (t *methodTable) find(id *capnp.Method) *Method {
+	lo, hi := 0, len(t.ifaces)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if t.ifaces[mid].ID < id.InterfaceID {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	if lo < len(t.ifaces) && t.ifaces[lo].ID == id.InterfaceID {
+		if ms := t.ifaces[lo].Methods; int(id.MethodID) < len(ms) && ms[id.MethodID].Impl != nil {
+			return &ms[id.MethodID]
+		}
+		return nil
+	}
+	if len(t.sparse) == 0 {
+		return nil
+	}
+	return t.sparse.find(id)
 }
 
 type sortedMethods []Method
@@ -228,4 +468,7 @@ const (
 	ackSignalKey callOptionKey = iota + 1
 )
 
-var errClosed = errors.New("capnp: server closed")
+var (
+	errClosed  = errors.New("capnp: server closed")
+	errDropped = errors.New("capnp: call dropped from full server queue")
+)
@@ -1,11 +1,15 @@
 package server_test
 
 import (
+	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"golang.org/x/net/context"
+	"github.com/iguazio/go-capnproto2"
 	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+	"github.com/iguazio/go-capnproto2/queue"
 	. "github.com/iguazio/go-capnproto2/server"
 )
 
@@ -108,3 +112,339 @@ func testCallOrder(t *testing.T, seq air.CallSequence) {
 	check(call3, 3)
 	check(call4, 4)
 }
+
+type stateEchoImpl struct{}
+
+func (stateEchoImpl) Echo(call air.Echo_echo) error {
+	in, err := call.Params.In()
+	if err != nil {
+		return err
+	}
+	prefix, _ := StateFromContext(call.Ctx).(string)
+	call.Results.SetOut(prefix + in)
+	return nil
+}
+
+func TestServerState(t *testing.T) {
+	impl := stateEchoImpl{}
+	tests := []struct {
+		echo air.Echo
+		out  string
+	}{
+		{air.Echo{Client: NewWithState(air.Echo_Methods(nil, impl), nil, "a:")}, "a:foo"},
+		{air.Echo{Client: NewWithState(air.Echo_Methods(nil, impl), nil, "b:")}, "b:foo"},
+		{air.Echo_ServerToClient(impl), "foo"},
+	}
+	for _, test := range tests {
+		result, err := test.echo.Echo(context.Background(), func(p air.Echo_echo_Params) error {
+			return p.SetIn("foo")
+		}).Struct()
+		if err != nil {
+			t.Errorf("echo.Echo() error: %v", err)
+		} else if out, err := result.Out(); err != nil {
+			t.Errorf("echo.Echo() error: %v", err)
+		} else if out != test.out {
+			t.Errorf("echo.Echo() = %q; want %q", out, test.out)
+		}
+		if err := test.echo.Client.Close(); err != nil {
+			t.Error("Close:", err)
+		}
+	}
+}
+
+func TestServerQueueReject(t *testing.T) {
+	release := make(chan struct{})
+	m := Method{
+		Method: capnp.Method{InterfaceID: 0xa7317bd7216570aa, MethodID: 0},
+		Impl: func(ctx context.Context, opts capnp.CallOptions, params, results capnp.Struct) error {
+			<-release
+			return nil
+		},
+	}
+	c := NewWithOptions([]Method{m}, nil, &Options{QueueSize: 1, Overflow: queue.Reject})
+	defer c.Close()
+	call := func() capnp.Answer {
+		return c.Call(&capnp.Call{Ctx: context.Background(), Method: m.Method})
+	}
+	stats := func() queue.Stats {
+		return c.(queue.Reporter).QueueStats()
+	}
+
+	// The first call is delivered and holds up the server, since it
+	// doesn't acknowledge delivery.  The second waits in the queue.
+	first := call()
+	second := make(chan capnp.Answer, 1)
+	go func() {
+		second <- call()
+	}()
+	for stats().Len == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := call().Struct(); err != queue.ErrFull {
+		t.Errorf("call on full queue: %v; want %v", err, queue.ErrFull)
+	}
+	if s := stats(); s.Cap != 1 || s.Peak != 1 || s.Rejected != 1 {
+		t.Errorf("QueueStats() = %+v; want Cap, Peak, and Rejected of 1", s)
+	}
+
+	close(release)
+	if _, err := first.Struct(); err != nil {
+		t.Error("first call:", err)
+	}
+	if _, err := (<-second).Struct(); err != nil {
+		t.Error("second call:", err)
+	}
+	if s := stats(); s.Len != 0 || s.Pushed != 2 || s.Popped != 2 {
+		t.Errorf("QueueStats() after calls = %+v; want Len 0, Pushed and Popped 2", s)
+	}
+}
+
+func TestServerCloseUnblocksQueue(t *testing.T) {
+	release := make(chan struct{})
+	m := Method{
+		Method: capnp.Method{InterfaceID: 0xa7317bd7216570aa, MethodID: 0},
+		Impl: func(ctx context.Context, opts capnp.CallOptions, params, results capnp.Struct) error {
+			<-release
+			return nil
+		},
+	}
+	c := NewWithOptions([]Method{m}, nil, &Options{QueueSize: 1, Overflow: queue.Block})
+	call := func() capnp.Answer {
+		return c.Call(&capnp.Call{Ctx: context.Background(), Method: m.Method})
+	}
+
+	// The first call holds up the server, the second fills the queue,
+	// and the rest wait for room.
+	call()
+	const waiting = 3
+	done := make(chan error, waiting)
+	for i := 0; i < waiting; i++ {
+		go func() {
+			_, err := call().Struct()
+			done <- err
+		}()
+	}
+	for c.(queue.Reporter).QueueStats().Len == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	go c.Close()
+	close(release)
+	timeout := time.After(5 * time.Second)
+	for i := 0; i < waiting; i++ {
+		select {
+		case <-done:
+		case <-timeout:
+			t.Fatalf("%d calls still waiting after Close", waiting-i)
+		}
+	}
+}
+
+func TestServerDispatch(t *testing.T) {
+	method := func(interfaceID uint64, methodID uint16) Method {
+		return Method{
+			Method:      capnp.Method{InterfaceID: interfaceID, MethodID: methodID},
+			ResultsSize: capnp.ObjectSize{DataSize: 16},
+			Impl: func(ctx context.Context, opts capnp.CallOptions, params, results capnp.Struct) error {
+				results.SetUint64(0, interfaceID)
+				results.SetUint16(8, methodID)
+				return nil
+			},
+		}
+	}
+	// Interface 2 is dense, interface 1 is sparse.
+	client := New([]Method{
+		method(2, 1),
+		method(1, 1000),
+		method(2, 0),
+		method(1, 3),
+		method(2, 3),
+	}, nil)
+	defer client.Close()
+
+	tests := []struct {
+		interfaceID uint64
+		methodID    uint16
+		ok          bool
+	}{
+		{2, 0, true},
+		{2, 1, true},
+		{2, 2, false},
+		{2, 3, true},
+		{2, 4, false},
+		{1, 3, true},
+		{1, 1000, true},
+		{1, 4, false},
+		{3, 0, false},
+	}
+	for _, test := range tests {
+		result, err := client.Call(&capnp.Call{
+			Ctx:        context.Background(),
+			Method:     capnp.Method{InterfaceID: test.interfaceID, MethodID: test.methodID},
+			ParamsSize: capnp.ObjectSize{},
+			ParamsFunc: func(capnp.Struct) error { return nil },
+		}).Struct()
+		if !test.ok {
+			if !capnp.IsUnimplemented(err) {
+				t.Errorf("call @%d.%d error = %v; want unimplemented", test.interfaceID, test.methodID, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("call @%d.%d error: %v", test.interfaceID, test.methodID, err)
+			continue
+		}
+		if id, mid := result.Uint64(0), result.Uint16(8); id != test.interfaceID || mid != test.methodID {
+			t.Errorf("call @%d.%d dispatched to @%d.%d", test.interfaceID, test.methodID, id, mid)
+		}
+	}
+}
+
+func TestServerTable(t *testing.T) {
+	method := func(interfaceID uint64, methodID uint16) Method {
+		return Method{
+			Method:      capnp.Method{InterfaceID: interfaceID, MethodID: methodID},
+			ResultsSize: capnp.ObjectSize{DataSize: 16},
+			Impl: func(ctx context.Context, opts capnp.CallOptions, params, results capnp.Struct) error {
+				results.SetUint64(0, interfaceID)
+				results.SetUint16(8, methodID)
+				return nil
+			},
+		}
+	}
+	client := NewTable(Table{
+		{ID: 1, Methods: []Method{method(1, 0), {Method: capnp.Method{InterfaceID: 1, MethodID: 1}}, method(1, 2)}},
+		{ID: 2, Methods: []Method{method(2, 0)}},
+	}, nil)
+	defer client.Close()
+
+	tests := []struct {
+		interfaceID uint64
+		methodID    uint16
+		ok          bool
+	}{
+		{1, 0, true},
+		{1, 1, false},
+		{1, 2, true},
+		{1, 3, false},
+		{2, 0, true},
+		{2, 1, false},
+		{0, 0, false},
+		{3, 0, false},
+	}
+	for _, test := range tests {
+		result, err := client.Call(&capnp.Call{
+			Ctx:        context.Background(),
+			Method:     capnp.Method{InterfaceID: test.interfaceID, MethodID: test.methodID},
+			ParamsSize: capnp.ObjectSize{},
+			ParamsFunc: func(capnp.Struct) error { return nil },
+		}).Struct()
+		if !test.ok {
+			if !capnp.IsUnimplemented(err) {
+				t.Errorf("call @%d.%d error = %v; want unimplemented", test.interfaceID, test.methodID, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("call @%d.%d error: %v", test.interfaceID, test.methodID, err)
+			continue
+		}
+		if id, mid := result.Uint64(0), result.Uint16(8); id != test.interfaceID || mid != test.methodID {
+			t.Errorf("call @%d.%d dispatched to @%d.%d", test.interfaceID, test.methodID, id, mid)
+		}
+	}
+}
+
+func BenchmarkServerCall(b *testing.B) {
+	echo := air.Echo_ServerToClient(echoImpl{})
+	defer echo.Client.Close()
+	ctx := context.Background()
+	params := func(p air.Echo_echo_Params) error {
+		return p.SetIn("x")
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := echo.Echo(ctx, params).Struct(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkServerNew(b *testing.B) {
+	for _, n := range []int{1, 16, 64} {
+		methods := make([]Method, n)
+		for i := range methods {
+			methods[i] = Method{
+				Method: capnp.Method{InterfaceID: 0xa7317bd7216570aa, MethodID: uint16(i)},
+				Impl: func(ctx context.Context, opts capnp.CallOptions, params, results capnp.Struct) error {
+					return nil
+				},
+			}
+		}
+		b.Run(fmt.Sprintf("%dMethods", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				New(methods, nil).Close()
+			}
+		})
+		b.Run(fmt.Sprintf("%dMethodsTable", n), func(b *testing.B) {
+			t := Table{{ID: 0xa7317bd7216570aa, Methods: methods}}
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				NewTable(t, nil).Close()
+			}
+		})
+	}
+}
+
+type closeCounter int
+
+func (c *closeCounter) Close() error {
+	*c++
+	return nil
+}
+
+func TestShared(t *testing.T) {
+	var closed closeCounter
+	sh := NewShared(air.Echo_Methods(nil, echoImpl{}), &closed)
+	c1, c2 := air.Echo{Client: sh.Client()}, air.Echo{Client: sh.Client()}
+
+	_, brand1, _ := capnp.ClientState(c1.Client)
+	_, brand2, _ := capnp.ClientState(c2.Client)
+	if brand1 != sh || brand2 != sh {
+		t.Errorf("client brands = %v, %v; want the Shared", brand1, brand2)
+	}
+	call := func(echo air.Echo) {
+		result, err := echo.Echo(context.Background(), func(p air.Echo_echo_Params) error {
+			return p.SetIn("foo")
+		}).Struct()
+		if err != nil {
+			t.Errorf("echo.Echo() error: %v", err)
+		} else if out, _ := result.Out(); out != "foofoo" {
+			t.Errorf("echo.Echo() = %q; want %q", out, "foofoo")
+		}
+	}
+	call(c1)
+	call(c2)
+
+	if err := c1.Client.Close(); err != nil {
+		t.Error("c1.Close:", err)
+	}
+	if _, err := c1.Echo(context.Background(), nil).Struct(); err == nil {
+		t.Error("call on closed client succeeded")
+	}
+	call(c2)
+	if err := sh.Close(); err != nil {
+		t.Error("Shared.Close:", err)
+	}
+	if closed != 0 {
+		t.Error("server closed while a client is open")
+	}
+	call(c2)
+	if err := c2.Client.Close(); err != nil {
+		t.Error("c2.Close:", err)
+	}
+	if closed != 1 {
+		t.Errorf("server closed %d times; want 1", closed)
+	}
+}
@@ -0,0 +1,252 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/queue"
+)
+
+// Shared is a server that can be exported on many connections at once,
+// such as a singleton service that every peer bootstraps.  Each call to
+// Client returns a new client for the server, usually one per
+// connection.
+//
+// Calls from all of the clients are delivered to the server one at a
+// time, as with New, but the server takes turns between the clients
+// that have calls waiting, so that a busy peer can't starve the others.
+// Calls made on the same client are delivered in order.
+//
+// All of the clients have the Shared as their brand (see
+// capnp.ClientState), so they are treated as the same capability.  The
+// server is closed once the Shared and all of its clients are closed.
+type Shared struct {
+	s    *server
+	wake chan struct{}
+
+	mu      sync.Mutex
+	ready   []*sharedClient // clients with waiting calls, in turn order
+	refs    int
+	closed  bool
+	stopped bool // server closed; no more calls are accepted
+}
+
+// NewShared returns a server that makes calls to a set of methods and
+// can be shared by many clients.  If closer is nil then closing the
+// server is a no-op.
+func NewShared(methods []Method, closer Closer) *Shared {
+	sh := &Shared{
+		s:    newServer(newMethodTable(methods), closer, &Options{Overflow: queue.Block}),
+		wake: make(chan struct{}, 1),
+	}
+	go sh.schedule()
+	return sh
+}
+
+// Client returns a new client for the server.  The client must be
+// closed, which MainInterface and the rpc package's export table do
+// when the connection is done with it.
+func (sh *Shared) Client() capnp.Client {
+	sh.mu.Lock()
+	sh.refs++
+	sh.mu.Unlock()
+	return &sharedClient{sh: sh}
+}
+
+// Close releases the Shared's own reference to the server.  The server
+// is closed once all of the clients returned by Client are closed too.
+// Close returns the error from closing the server, if it was closed.
+func (sh *Shared) Close() error {
+	sh.mu.Lock()
+	if sh.closed {
+		sh.mu.Unlock()
+		return errClosed
+	}
+	sh.closed = true
+	last := sh.refs == 0
+	sh.mu.Unlock()
+	if !last {
+		return nil
+	}
+	return sh.s.Close()
+}
+
+// schedule runs in its own goroutine, handing waiting calls to the
+// server's dispatch goroutine one client at a time.
+func (sh *Shared) schedule() {
+	for {
+		select {
+		case <-sh.wake:
+		case <-sh.s.stop:
+			sh.stop()
+			return
+		}
+		for {
+			sh.mu.Lock()
+			scall := sh.next()
+			sh.mu.Unlock()
+			if scall == nil {
+				break
+			}
+			if err := sh.s.push(scall.call); err != nil {
+				scall.ans.Reject(err)
+			}
+			close(scall.sent)
+		}
+	}
+}
+
+// next removes the next call to deliver from its client's queue and
+// moves the client to the back of the line.  It returns nil if no calls
+// are waiting.  The caller must be holding onto sh.mu.
+func (sh *Shared) next() *sharedCall {
+	for len(sh.ready) > 0 {
+		sc := sh.ready[0]
+		sh.ready[0] = nil
+		sh.ready = sh.ready[1:]
+		if len(sc.pending) == 0 {
+			// Its calls were cancelled.
+			sc.queued = false
+			continue
+		}
+		scall := sc.pending[0]
+		sc.pending[0] = nil
+		sc.pending = sc.pending[1:]
+		if len(sc.pending) > 0 {
+			sh.ready = append(sh.ready, sc)
+		} else {
+			sc.queued = false
+		}
+		return scall
+	}
+	return nil
+}
+
+// stop rejects all waiting calls after the server is closed.
+func (sh *Shared) stop() {
+	sh.mu.Lock()
+	sh.stopped = true
+	ready := sh.ready
+	sh.ready = nil
+	for _, sc := range ready {
+		for _, scall := range sc.pending {
+			scall.ans.Reject(errClosed)
+			close(scall.sent)
+		}
+		sc.pending = nil
+		sc.queued = false
+	}
+	sh.mu.Unlock()
+}
+
+func (sh *Shared) signal() {
+	select {
+	case sh.wake <- struct{}{}:
+	default:
+	}
+}
+
+// A sharedClient is a client returned by Shared.Client.
+type sharedClient struct {
+	sh *Shared
+
+	// Protected by sh.mu
+	pending []*sharedCall
+	queued  bool // in sh.ready
+	closed  bool
+}
+
+// A sharedCall is a call waiting in a client's queue.  sent is closed
+// once the call is handed to the server or rejected.
+type sharedCall struct {
+	*call
+	sent chan struct{}
+}
+
+func (sc *sharedClient) Call(cl *capnp.Call) capnp.Answer {
+	sh := sc.sh
+	sm := sh.s.methods.find(&cl.Method)
+	if sm == nil {
+		return capnp.ErrorAnswer(&capnp.MethodError{
+			Method: &cl.Method,
+			Err:    capnp.ErrUnimplemented,
+		})
+	}
+	cl, err := cl.Copy(nil)
+	if err != nil {
+		return capnp.ErrorAnswer(err)
+	}
+	scall := &sharedCall{call: newCall(cl, sm), sent: make(chan struct{})}
+	sh.mu.Lock()
+	if sc.closed || sh.stopped {
+		sh.mu.Unlock()
+		return capnp.ErrorAnswer(errClosed)
+	}
+	sc.pending = append(sc.pending, scall)
+	if !sc.queued {
+		sh.ready = append(sh.ready, sc)
+		sc.queued = true
+	}
+	sh.mu.Unlock()
+	sh.signal()
+
+	select {
+	case <-scall.sent:
+		return &scall.ans
+	case <-cl.Ctx.Done():
+	}
+	sh.mu.Lock()
+	removed := sc.remove(scall)
+	sh.mu.Unlock()
+	if removed {
+		return capnp.ErrorAnswer(cl.Ctx.Err())
+	}
+	// The scheduler already took the call.
+	<-scall.sent
+	return &scall.ans
+}
+
+// remove removes scall from the client's queue, reporting whether it
+// was still waiting.  The caller must be holding onto sc.sh.mu.
+func (sc *sharedClient) remove(scall *sharedCall) bool {
+	for i, p := range sc.pending {
+		if p == scall {
+			copy(sc.pending[i:], sc.pending[i+1:])
+			sc.pending[len(sc.pending)-1] = nil
+			sc.pending = sc.pending[:len(sc.pending)-1]
+			return true
+		}
+	}
+	return false
+}
+
+// ClientState reports the client as a resolved local capability with
+// the Shared as its brand.
+func (sc *sharedClient) ClientState() (resolved bool, brand interface{}, remote bool) {
+	return true, sc.sh, false
+}
+
+// Close rejects the client's waiting calls and releases its reference
+// to the server.
+func (sc *sharedClient) Close() error {
+	sh := sc.sh
+	sh.mu.Lock()
+	if sc.closed {
+		sh.mu.Unlock()
+		return errClosed
+	}
+	sc.closed = true
+	pending := sc.pending
+	sc.pending = nil
+	sh.refs--
+	last := sh.refs == 0 && sh.closed
+	sh.mu.Unlock()
+	for _, scall := range pending {
+		scall.ans.Reject(errClosed)
+		close(scall.sent)
+	}
+	if !last {
+		return nil
+	}
+	return sh.s.Close()
+}
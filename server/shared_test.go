@@ -0,0 +1,42 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+)
+
+func TestSharedTakesTurns(t *testing.T) {
+	sh := new(Shared)
+	a, b := &sharedClient{sh: sh}, &sharedClient{sh: sh}
+	enqueue := func(sc *sharedClient, id uint16) {
+		scall := &sharedCall{call: &call{Call: &capnp.Call{Method: capnp.Method{MethodID: id}}}}
+		sc.pending = append(sc.pending, scall)
+		if !sc.queued {
+			sh.ready = append(sh.ready, sc)
+			sc.queued = true
+		}
+	}
+	enqueue(a, 1)
+	enqueue(a, 2)
+	enqueue(a, 3)
+	enqueue(b, 4)
+	enqueue(b, 5)
+
+	var order []uint16
+	for scall := sh.next(); scall != nil; scall = sh.next() {
+		order = append(order, scall.Method.MethodID)
+	}
+	want := []uint16{1, 4, 2, 5, 3}
+	if len(order) != len(want) {
+		t.Fatalf("delivery order = %v; want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("delivery order = %v; want %v", order, want)
+		}
+	}
+	if a.queued || b.queued {
+		t.Error("clients still queued after their calls were delivered")
+	}
+}
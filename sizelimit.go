@@ -0,0 +1,52 @@
+package capnp
+
+import "errors"
+
+// ErrMessageTooLarge is returned when allocating an object would make
+// a message larger than the limit set by SetSizeLimit.
+var ErrMessageTooLarge = errors.New("capnp: message size limit exceeded")
+
+// SetSizeLimit limits the number of bytes that the message's segments
+// may hold to n, so that a program that builds messages from untrusted
+// input can't be made to use an unbounded amount of memory.  Once the
+// limit is reached, allocating an object fails with ErrMessageTooLarge
+// and leaves the message unchanged.  The limit counts the bytes in use,
+// which is the size of the message without its stream header; arenas
+// may reserve more capacity than that, up to about twice as much for
+// the arenas in this package.  A limit of zero removes the limit.
+//
+// Objects that are already in the message are kept if the limit is
+// lowered below its size.
+//
+// SetSizeLimit must not be called while other goroutines are modifying
+// the message.
+func (m *Message) SetSizeLimit(n uint64) {
+	m.sizeLimit = n
+}
+
+// checkSizeLimit returns ErrMessageTooLarge if allocating sz more bytes
+// would exceed the message's size limit.
+func (m *Message) checkSizeLimit(sz Size) error {
+	if m.sizeLimit == 0 {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	total := uint64(sz)
+	n := m.Arena.NumSegments()
+	for i := int64(0); i < n; i++ {
+		if seg := m.segment(SegmentID(i)); seg != nil {
+			total += uint64(len(seg.data))
+			continue
+		}
+		data, err := m.Arena.Data(SegmentID(i))
+		if err != nil {
+			return err
+		}
+		total += uint64(len(data))
+	}
+	if total > m.sizeLimit {
+		return ErrMessageTooLarge
+	}
+	return nil
+}
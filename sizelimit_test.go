@@ -0,0 +1,51 @@
+package capnp
+
+import "testing"
+
+func TestSetSizeLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		arena Arena
+	}{
+		{"SingleSegment", SingleSegment(nil)},
+		{"MultiSegment", MultiSegment(nil)},
+	}
+	for _, test := range tests {
+		msg, seg, err := NewMessage(test.arena)
+		if err != nil {
+			t.Fatalf("%s: NewMessage: %v", test.name, err)
+		}
+		msg.SetSizeLimit(64)
+		root, err := NewRootStruct(seg, ObjectSize{PointerCount: 2})
+		if err != nil {
+			t.Fatalf("%s: NewRootStruct: %v", test.name, err)
+		}
+		// 8 bytes of root pointer + 16 bytes of struct + 40 bytes of
+		// text reaches the limit.
+		if err := root.SetText(0, "0123456789abcdefghijklmnopqrstuvwxyzABC"); err != nil {
+			t.Fatalf("%s: SetText within limit: %v", test.name, err)
+		}
+		before, err := msg.Marshal()
+		if err != nil {
+			t.Fatalf("%s: Marshal: %v", test.name, err)
+		}
+		if err := root.SetText(1, "hello"); err != ErrMessageTooLarge {
+			t.Errorf("%s: SetText past limit = %v; want %v", test.name, err, ErrMessageTooLarge)
+		}
+		if _, err := NewData(seg, make([]byte, 1<<20)); err != ErrMessageTooLarge {
+			t.Errorf("%s: NewData past limit = %v; want %v", test.name, err, ErrMessageTooLarge)
+		}
+		after, err := msg.Marshal()
+		if err != nil {
+			t.Fatalf("%s: Marshal: %v", test.name, err)
+		}
+		if len(after) != len(before) {
+			t.Errorf("%s: message grew from %d to %d bytes after failed allocations", test.name, len(before), len(after))
+		}
+
+		msg.SetSizeLimit(0)
+		if err := root.SetText(1, "hello"); err != nil {
+			t.Errorf("%s: SetText after removing limit: %v", test.name, err)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package capnp
+
+import "errors"
+
+// Splice sets the i'th pointer of dst to the root object of src by
+// moving src's segments into dst's message instead of copying the
+// object, as SetPtr would.  It is meant for large objects, such as
+// blobs, that are built in a message of their own and then attached to
+// another message.
+//
+// The segments can only be moved if every word in them belongs to the
+// root object or to the objects reachable from it, and if dst's message
+// uses an arena that can take over segments, as MultiSegment arenas
+// can.  Otherwise, Splice copies the object and leaves src unchanged.
+// Splice reports whether it moved the segments; if it did, src is
+// reset and must not be used, and its capabilities belong to dst's
+// message.
+func Splice(dst Struct, i uint16, src *Message) (moved bool, err error) {
+	if dst.seg == nil {
+		return false, errors.New("capnp: splice into invalid struct")
+	}
+	dmsg := dst.seg.msg
+	if dmsg == src {
+		return false, errors.New("capnp: splice message into itself")
+	}
+	msa, ok := dmsg.Arena.(*multiSegmentArena)
+	if !ok {
+		return false, spliceCopy(dst, i, src)
+	}
+	sw := &spliceWalker{
+		segs:  make(map[SegmentID]*Segment),
+		words: make(map[SegmentID][]bool),
+	}
+	if !sw.walkRoot(src) {
+		return false, spliceCopy(dst, i, src)
+	}
+
+	// Append the segments to dst's arena, in order of their IDs.
+	newIDs := make(map[SegmentID]SegmentID, len(sw.segs))
+	dmsg.mu.Lock()
+	for id := SegmentID(0); int64(id) < src.NumSegments(); id++ {
+		s := sw.segs[id]
+		if s == nil {
+			continue
+		}
+		newIDs[id] = SegmentID(len(*msa))
+		*msa = append(*msa, s.data[:len(s.data):len(s.data)])
+	}
+	dmsg.mu.Unlock()
+
+	// The segments keep their layout, so only pointers that name a
+	// segment or capability need to change.
+	for _, a := range sw.fars {
+		s := sw.segs[a.seg]
+		raw := s.readRawPointer(a.addr)
+		switch raw.pointerType() {
+		case farPointer:
+			s.writeRawPointer(a.addr, rawFarPointer(newIDs[raw.farSegment()], raw.farAddress()))
+		case doubleFarPointer:
+			s.writeRawPointer(a.addr, rawDoubleFarPointer(newIDs[raw.farSegment()], raw.farAddress()))
+		}
+	}
+	newCaps := make(map[CapabilityID]CapabilityID)
+	for _, a := range sw.caps {
+		s := sw.segs[a.seg]
+		old := s.readRawPointer(a.addr).capabilityIndex()
+		c, ok := newCaps[old]
+		if !ok {
+			c = dmsg.AddCap(src.CapTable[old])
+			newCaps[old] = c
+		}
+		s.writeRawPointer(a.addr, rawInterfacePointer(c))
+	}
+	src.Reset(nil)
+
+	seg0, err := dmsg.Segment(newIDs[0])
+	if err != nil {
+		return true, err
+	}
+	root, err := seg0.readPtr(0, maxDepth)
+	if err != nil {
+		return true, err
+	}
+	return true, dst.SetPtr(i, root)
+}
+
+func spliceCopy(dst Struct, i uint16, src *Message) error {
+	root, err := src.RootPtr()
+	if err != nil {
+		return err
+	}
+	return dst.SetPtr(i, root)
+}
+
+// spliceWalker finds the words of a message that are reachable from
+// its root pointer, and the pointers that must be rewritten when its
+// segments are moved to another message.
+type spliceWalker struct {
+	segs  map[SegmentID]*Segment
+	words map[SegmentID][]bool // reached words of each segment in segs
+	fars  []objectAddress      // far pointers
+	caps  []objectAddress      // interface pointers
+	ncaps int
+}
+
+// walkRoot walks the objects reachable from msg's root pointer and
+// reports whether they fill the segments they are in.
+func (sw *spliceWalker) walkRoot(msg *Message) bool {
+	seg, err := msg.Segment(0)
+	if err != nil || !seg.regionInBounds(0, wordSize) {
+		return false
+	}
+	sw.ncaps = len(msg.CapTable)
+	sw.mark(seg, 0, wordSize)
+	if !sw.ptr(seg, 0, maxDepth) {
+		return false
+	}
+	for _, words := range sw.words {
+		for _, ok := range words {
+			if !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// mark records that the words from addr to addr+sz in seg have been
+// reached.  It reports false if the first word already was.
+func (sw *spliceWalker) mark(seg *Segment, addr Address, sz Size) bool {
+	words := sw.words[seg.id]
+	if words == nil {
+		sw.segs[seg.id] = seg
+		words = make([]bool, len(seg.data)/int(wordSize))
+		sw.words[seg.id] = words
+	}
+	start := int(addr / Address(wordSize))
+	end := int((Size(addr) + sz + wordSize - 1) / wordSize)
+	if start < len(words) && words[start] {
+		return false
+	}
+	for w := start; w < end && w < len(words); w++ {
+		words[w] = true
+	}
+	return true
+}
+
+// ptr walks the pointer at paddr in seg.  It reports false if the
+// pointer is invalid.
+func (sw *spliceWalker) ptr(seg *Segment, paddr Address, depth uint) bool {
+	raw := seg.readRawPointer(paddr)
+	if raw == 0 {
+		return true
+	}
+	if depth == 0 {
+		return false
+	}
+	capAddr := objectAddress{seg.id, paddr}
+	switch raw.pointerType() {
+	case farPointer, doubleFarPointer:
+		padSeg, err := seg.lookupSegment(raw.farSegment())
+		if err != nil {
+			return false
+		}
+		padSize := wordSize
+		if raw.pointerType() == doubleFarPointer {
+			padSize *= 2
+			sw.fars = append(sw.fars, objectAddress{padSeg.id, raw.farAddress()})
+		}
+		if !padSeg.regionInBounds(raw.farAddress(), padSize) {
+			return false
+		}
+		sw.fars = append(sw.fars, objectAddress{seg.id, paddr})
+		sw.mark(padSeg, raw.farAddress(), padSize)
+		capAddr = objectAddress{padSeg.id, raw.farAddress()}
+	}
+	dst, base, val, err := seg.resolveFarPointer(paddr)
+	if err != nil {
+		return false
+	}
+	switch val.pointerType() {
+	case structPointer:
+		s, err := dst.readStructPtr(base, val)
+		if err != nil {
+			return false
+		}
+		if s.size.isZero() || !sw.mark(dst, s.off, s.size.totalSize()) {
+			return true
+		}
+		return sw.structPtrs(s, depth)
+	case listPointer:
+		l, err := dst.readListPtr(base, val)
+		if err != nil {
+			return false
+		}
+		if val.listType() == compositeList {
+			n, _ := l.size.totalSize().times(l.length)
+			if !sw.mark(dst, l.off-Address(wordSize), wordSize+n) {
+				return true
+			}
+			for j := 0; j < int(l.length); j++ {
+				if !sw.structPtrs(l.Struct(j), depth) {
+					return false
+				}
+			}
+			return true
+		}
+		sz, _ := val.totalListSize()
+		if sz == 0 || !sw.mark(dst, l.off, sz) {
+			return true
+		}
+		if val.listType() == pointerList {
+			for j := 0; j < int(l.length); j++ {
+				addr, _ := l.off.element(int32(j), wordSize)
+				if !sw.ptr(dst, addr, depth-1) {
+					return false
+				}
+			}
+		}
+		return true
+	case otherPointer:
+		if val.otherPointerType() != 0 || int(val.capabilityIndex()) >= sw.ncaps {
+			return false
+		}
+		sw.caps = append(sw.caps, capAddr)
+		return true
+	default:
+		return false
+	}
+}
+
+func (sw *spliceWalker) structPtrs(s Struct, depth uint) bool {
+	for j := uint16(0); j < s.size.PointerCount; j++ {
+		if !sw.ptr(s.seg, s.pointerAddress(j), depth-1) {
+			return false
+		}
+	}
+	return true
+}
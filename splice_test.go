@@ -0,0 +1,199 @@
+package capnp
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestSplice(t *testing.T) {
+	blob := bytes.Repeat([]byte("0123456789abcdef"), 64)
+	src, sseg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.SetRootPtr(mustNewData(t, sseg, blob).ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+	srcData := sseg.Data()
+
+	dmsg, dseg, err := NewMessage(MultiSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := NewRootStruct(dseg, ObjectSize{DataSize: 8, PointerCount: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	moved, err := Splice(root, 0, src)
+	if err != nil {
+		t.Fatal("Splice:", err)
+	}
+	if !moved {
+		t.Fatal("Splice copied the blob; want moved")
+	}
+	p, err := root.Ptr(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(p.Data(), blob) {
+		t.Error("spliced blob has different contents")
+	}
+	if got := p.Data(); &got[0] != &srcData[len(srcData)-len(blob)] {
+		t.Error("spliced blob was copied")
+	}
+	if src.Arena != nil {
+		t.Error("src was not reset")
+	}
+	checkRoundTrip(t, dmsg, blob)
+}
+
+func TestSplice_FarPointersAndCaps(t *testing.T) {
+	src, sseg, err := NewMessage(MultiSegment([][]byte{make([]byte, 0, 16)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sroot, err := NewRootStruct(sseg, ObjectSize{PointerCount: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := ErrorClient(errors.New("spliced"))
+	if err := sroot.SetPtr(0, NewInterface(sseg, src.AddCap(client)).ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+	if err := sroot.SetText(1, "across segments"); err != nil {
+		t.Fatal(err)
+	}
+	if src.NumSegments() < 2 {
+		t.Fatal("src has one segment; want far pointers")
+	}
+
+	dmsg, dseg, err := NewMessage(MultiSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dmsg.AddCap(ErrorClient(errors.New("existing")))
+	root, err := NewRootStruct(dseg, ObjectSize{PointerCount: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	moved, err := Splice(root, 0, src)
+	if err != nil {
+		t.Fatal("Splice:", err)
+	}
+	if !moved {
+		t.Fatal("Splice copied; want moved")
+	}
+	p, err := root.Ptr(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := p.Struct()
+	if tp, err := s.Ptr(1); err != nil || tp.Text() != "across segments" {
+		t.Errorf("Ptr(1) = %q, %v; want \"across segments\"", tp.Text(), err)
+	}
+	cp, err := s.Ptr(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := cp.Interface().Client(); c != client {
+		t.Errorf("capability = %v; want the spliced client", c)
+	}
+	if cp.Interface().Capability() != 1 {
+		t.Errorf("capability index = %d; want 1", cp.Interface().Capability())
+	}
+}
+
+func TestSplice_Copies(t *testing.T) {
+	newSrc := func() (*Message, Struct) {
+		src, sseg, err := NewMessage(SingleSegment(nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := NewRootStruct(sseg, ObjectSize{PointerCount: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.SetText(0, "hello"); err != nil {
+			t.Fatal(err)
+		}
+		return src, s
+	}
+	tests := []struct {
+		name  string
+		arena Arena
+		dead  bool
+	}{
+		{name: "single-segment destination", arena: SingleSegment(nil)},
+		{name: "unreachable words in source", arena: MultiSegment(nil), dead: true},
+	}
+	for _, test := range tests {
+		src, s := newSrc()
+		if test.dead {
+			// Replace the text, leaving the old text unreachable.
+			if err := s.SetText(0, "world"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		_, dseg, err := NewMessage(test.arena)
+		if err != nil {
+			t.Fatal(err)
+		}
+		root, err := NewRootStruct(dseg, ObjectSize{PointerCount: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		moved, err := Splice(root, 0, src)
+		if err != nil {
+			t.Errorf("%s: Splice: %v", test.name, err)
+			continue
+		}
+		if moved {
+			t.Errorf("%s: Splice moved segments; want copy", test.name)
+		}
+		want := "hello"
+		if test.dead {
+			want = "world"
+		}
+		p, _ := root.Ptr(0)
+		if tp, _ := p.Struct().Ptr(0); tp.Text() != want {
+			t.Errorf("%s: copied text = %q; want %q", test.name, tp.Text(), want)
+		}
+		if _, err := src.RootPtr(); err != nil {
+			t.Errorf("%s: src unusable after copy: %v", test.name, err)
+		}
+	}
+}
+
+func mustNewData(t *testing.T, s *Segment, b []byte) UInt8List {
+	l, err := NewData(s, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+// checkRoundTrip checks that msg's first pointer in its root refers to
+// blob after being marshaled and read back.
+func checkRoundTrip(t *testing.T, msg *Message, blob []byte) {
+	t.Helper()
+	data, err := msg.Marshal()
+	if err != nil {
+		t.Fatal("Marshal:", err)
+	}
+	msg2, err := Unmarshal(data)
+	if err != nil {
+		t.Fatal("Unmarshal:", err)
+	}
+	root, err := msg2.RootPtr()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := root.Struct().Ptr(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(p.Data(), blob) {
+		t.Error("blob differs after marshal round trip")
+	}
+}
@@ -0,0 +1,230 @@
+package capnp
+
+// Stats describes how the bytes of a message are used.  In a well-formed
+// message, every byte of the segments is counted in exactly one of the
+// Bytes fields other than TotalBytes, so their sum is TotalBytes.
+type Stats struct {
+	// Segments is the number of segments in the message.
+	Segments int64
+
+	// TotalBytes is the combined size of the segments.
+	TotalBytes uint64
+
+	// DataBytes is the size of struct data sections and of the
+	// elements of lists of non-pointer values.
+	DataBytes uint64
+
+	// PointerBytes is the size of the pointers in structs and pointer
+	// lists, including the root pointer.
+	PointerBytes uint64
+
+	// PaddingBytes is the space used to round the elements of lists of
+	// bits, bytes, and other sub-word values up to a word boundary.
+	PaddingBytes uint64
+
+	// OverheadBytes is the space used by composite list tags and far
+	// pointer landing pads.
+	OverheadBytes uint64
+
+	// DeadBytes is the space that cannot be reached from the root
+	// pointer, such as objects that were allocated and then replaced.
+	DeadBytes uint64
+
+	// Structs, Lists, and Capabilities count the objects reachable
+	// from the root pointer.  Each element of a composite list is
+	// counted as a struct.
+	Structs      int
+	Lists        int
+	Capabilities int
+}
+
+// MessageStats walks the objects reachable from msg's root pointer and
+// reports how the message's bytes are used.  Objects that are referred
+// to by more than one pointer are only counted once.  Walking a message
+// does not count against its read limit.
+//
+// MessageStats describes the layout of a message, not its schema.  Use
+// the capnpstats package to break the usage down by type and field.
+func MessageStats(msg *Message) (*Stats, error) {
+	n := msg.NumSegments()
+	st := &statsWalker{
+		stats:   &Stats{Segments: n},
+		visited: make(map[SegmentID][]uint64),
+	}
+	for i := int64(0); i < n; i++ {
+		seg, err := msg.Segment(SegmentID(i))
+		if err != nil {
+			return nil, err
+		}
+		st.stats.TotalBytes += uint64(len(seg.Data()))
+	}
+	if n == 0 {
+		return st.stats, nil
+	}
+	seg, err := msg.Segment(0)
+	if err != nil {
+		return nil, err
+	}
+	if !seg.regionInBounds(0, wordSize) {
+		return nil, errNoRoot
+	}
+	st.mark(seg, 0, wordSize)
+	st.stats.PointerBytes += uint64(wordSize)
+	if err := st.ptr(seg, 0, maxDepth); err != nil {
+		return nil, err
+	}
+	st.stats.DeadBytes = st.stats.TotalBytes - st.reached
+	return st.stats, nil
+}
+
+type statsWalker struct {
+	stats   *Stats
+	reached uint64
+
+	// visited is a bitmap of the words of each segment that have been
+	// counted.
+	visited map[SegmentID][]uint64
+}
+
+// mark records that the region of seg from addr to addr+sz has been
+// counted.  It reports false if the first word of the region had
+// already been counted.
+func (st *statsWalker) mark(seg *Segment, addr Address, sz Size) bool {
+	nwords := len(seg.data) / int(wordSize)
+	bits := st.visited[seg.id]
+	if bits == nil {
+		bits = make([]uint64, (nwords+63)/64)
+		st.visited[seg.id] = bits
+	}
+	end := int((Size(addr) + sz + wordSize - 1) / wordSize)
+	if end > nwords {
+		end = nwords
+	}
+	first := true
+	for w := int(addr / Address(wordSize)); w < end; w++ {
+		if bits[w/64]&(1<<uint(w%64)) != 0 {
+			if w == int(addr/Address(wordSize)) {
+				first = false
+			}
+			continue
+		}
+		bits[w/64] |= 1 << uint(w%64)
+		st.reached += uint64(wordSize)
+	}
+	return first
+}
+
+// ptr counts the object referenced by the pointer at paddr in seg.
+func (st *statsWalker) ptr(seg *Segment, paddr Address, depth uint) error {
+	raw := seg.readRawPointer(paddr)
+	if raw == 0 {
+		return nil
+	}
+	if depth == 0 {
+		return errDepthLimit
+	}
+	switch raw.pointerType() {
+	case farPointer, doubleFarPointer:
+		padSeg, err := seg.lookupSegment(raw.farSegment())
+		if err != nil {
+			return err
+		}
+		padSize := wordSize
+		if raw.pointerType() == doubleFarPointer {
+			padSize *= 2
+		}
+		if !padSeg.regionInBounds(raw.farAddress(), padSize) {
+			return errPointerAddress
+		}
+		if st.mark(padSeg, raw.farAddress(), padSize) {
+			st.stats.OverheadBytes += uint64(padSize)
+		}
+	}
+	dst, base, val, err := seg.resolveFarPointer(paddr)
+	if err != nil {
+		return err
+	}
+	switch val.pointerType() {
+	case structPointer:
+		s, err := dst.readStructPtr(base, val)
+		if err != nil {
+			return err
+		}
+		if s.size.totalSize() > 0 && !st.mark(dst, s.off, s.size.totalSize()) {
+			return nil
+		}
+		return st.structSections(s, depth)
+	case listPointer:
+		l, err := dst.readListPtr(base, val)
+		if err != nil {
+			return err
+		}
+		return st.list(l, val, depth)
+	case otherPointer:
+		if val.otherPointerType() != 0 {
+			return errOtherPointer
+		}
+		st.stats.Capabilities++
+		return nil
+	default:
+		return errBadLandingPad
+	}
+}
+
+func (st *statsWalker) structSections(s Struct, depth uint) error {
+	st.stats.Structs++
+	st.stats.DataBytes += uint64(s.size.DataSize)
+	st.stats.PointerBytes += uint64(s.size.PointerCount) * uint64(wordSize)
+	for i := uint16(0); i < s.size.PointerCount; i++ {
+		if err := st.ptr(s.seg, s.pointerAddress(i), depth-1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (st *statsWalker) list(l List, val rawPointer, depth uint) error {
+	lt := val.listType()
+	if lt == compositeList {
+		// l.off is the address of the first element, after the tag.
+		tagAddr := l.off - Address(wordSize)
+		n, _ := l.size.totalSize().times(l.length)
+		if !st.mark(l.seg, tagAddr, wordSize+n) {
+			return nil
+		}
+		st.stats.Lists++
+		st.stats.OverheadBytes += uint64(wordSize)
+		for i := 0; i < int(l.length); i++ {
+			if l.size.PointerCount == 0 {
+				// Avoid a loop over a long list of empty structs.
+				st.stats.Structs += int(l.length)
+				st.stats.DataBytes += uint64(l.size.DataSize) * uint64(l.length)
+				break
+			}
+			if err := st.structSections(l.Struct(i), depth); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	sz, _ := val.totalListSize()
+	if sz > 0 && !st.mark(l.seg, l.off, sz) {
+		return nil
+	}
+	st.stats.Lists++
+	padded := uint64(sz.padToWord())
+	switch lt {
+	case pointerList:
+		st.stats.PointerBytes += uint64(sz)
+		for i := 0; i < int(l.length); i++ {
+			addr, _ := l.off.element(int32(i), wordSize)
+			if err := st.ptr(l.seg, addr, depth-1); err != nil {
+				return err
+			}
+		}
+	default:
+		st.stats.DataBytes += uint64(sz)
+		st.stats.PaddingBytes += padded - uint64(sz)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package capnp
+
+import (
+	"testing"
+)
+
+func (st *Stats) sum() uint64 {
+	return st.DataBytes + st.PointerBytes + st.PaddingBytes + st.OverheadBytes + st.DeadBytes
+}
+
+func TestMessageStats(t *testing.T) {
+	msg, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := NewRootStruct(seg, ObjectSize{DataSize: 8, PointerCount: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	root.SetUint64(0, 42)
+	if err := root.SetText(0, "hi"); err != nil {
+		t.Fatal(err)
+	}
+	l, err := NewCompositeList(seg, ObjectSize{DataSize: 8}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := root.SetPtr(1, l.ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+
+	st, err := MessageStats(msg)
+	if err != nil {
+		t.Fatal("MessageStats:", err)
+	}
+	want := Stats{
+		Segments:      1,
+		TotalBytes:    64,
+		DataBytes:     8 + 3 + 16,
+		PointerBytes:  8 + 16,
+		PaddingBytes:  5,
+		OverheadBytes: 8,
+		Structs:       3,
+		Lists:         2,
+	}
+	if *st != want {
+		t.Errorf("MessageStats = %+v; want %+v", *st, want)
+	}
+
+	// Replacing the text leaves the old text unreachable.
+	if err := root.SetText(0, "replaced"); err != nil {
+		t.Fatal(err)
+	}
+	st, err = MessageStats(msg)
+	if err != nil {
+		t.Fatal("MessageStats:", err)
+	}
+	if st.DeadBytes != 8 || st.DataBytes != 8+9+16 || st.PaddingBytes != 7 {
+		t.Errorf("after replace: MessageStats = %+v; want 8 dead, 33 data, 7 padding bytes", *st)
+	}
+	if st.sum() != st.TotalBytes {
+		t.Errorf("after replace: sum of bytes = %d; want %d", st.sum(), st.TotalBytes)
+	}
+}
+
+func TestMessageStats_Shared(t *testing.T) {
+	msg, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := NewRootStruct(seg, ObjectSize{PointerCount: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewStruct(seg, ObjectSize{DataSize: 8, PointerCount: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	root.SetPtr(0, s.ToPtr())
+	// Point the second field at the same struct without copying it.
+	seg.writeRawPointer(root.pointerAddress(1), rawStructPointer(nearPointerOffset(root.pointerAddress(1), s.off), s.size))
+	// And the struct at itself.
+	seg.writeRawPointer(s.pointerAddress(0), rawStructPointer(nearPointerOffset(s.pointerAddress(0), s.off), s.size))
+
+	st, err := MessageStats(msg)
+	if err != nil {
+		t.Fatal("MessageStats:", err)
+	}
+	if st.Structs != 2 || st.sum() != st.TotalBytes || st.DeadBytes != 0 {
+		t.Errorf("MessageStats = %+v; want 2 structs and no double counting", *st)
+	}
+}
+
+func TestMessageStats_Far(t *testing.T) {
+	msg, seg, err := NewMessage(MultiSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := NewRootStruct(seg, ObjectSize{PointerCount: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	big := make([]byte, 1<<16)
+	if err := root.SetData(0, big); err != nil {
+		t.Fatal(err)
+	}
+	if msg.NumSegments() < 2 {
+		t.Skip("data was allocated in the first segment")
+	}
+	st, err := MessageStats(msg)
+	if err != nil {
+		t.Fatal("MessageStats:", err)
+	}
+	if st.OverheadBytes == 0 {
+		t.Errorf("MessageStats = %+v; want landing pad overhead", *st)
+	}
+	if st.DataBytes != 1<<16 {
+		t.Errorf("DataBytes = %d; want %d", st.DataBytes, 1<<16)
+	}
+	if st.sum() != st.TotalBytes {
+		t.Errorf("sum of bytes = %d; want %d", st.sum(), st.TotalBytes)
+	}
+}
+
+func TestMessageStats_NoRoot(t *testing.T) {
+	msg := &Message{Arena: SingleSegment(make([]byte, 0))}
+	if _, err := MessageStats(msg); err == nil {
+		t.Error("MessageStats of empty segment did not return an error")
+	}
+}
@@ -8,15 +8,15 @@ import (
 
 const Namespace = uint64(0xb9c6f99ebf805f2c)
 const Name = uint64(0xf264a779fef191ce)
-const schema_bdf87d7bb8304e81 = "x\xda\x12\x08u`2d\x15gb`\x08\x94`e" +
-	"\xfb\xaf\x13\xdf\xb0\x7f\xde\xcfc;\x19\x02\xb9X\x19\xff" +
-	"7\xfa\x19\xec\xa8\xae\xfd\xb1\x97\x81\x81Q\xf0\xe3\"\xc1" +
-	"\x9f\xec\x0c\x0c\x81_\x98\x19\x19\x18\xff\x9f\x9b\xf8\xf1_" +
-	"\xe5\xf2\x94O\x0c\x17\xb9X\xff\xb0\xa3(|Z%\xf8" +
-	"\x12\xa4\xf0\x093#\x83\xee\xffdmm\xbd\xe4\xc4\x82" +
-	"<\xc6\x02\xab\xbc\xc4\xdc\xd4\xe2\x02\xf6\xc4\xe4\xd4\x00F" +
-	"FF\x1e\x06&\xb8$\x83=D\x16*\x0e\x08\x00\x00" +
-	"\xff\xffE\x881\xaa"
+const schema_bdf87d7bb8304e81 = "x\xda2\x08\xe6p`2dewb`\x08bf" +
+	"e\xfb\xaf\x13\xdf\xb0\x7f\xde\xcfc;\x19\x02\xb9X\x19" +
+	"\xff7\xfa\x19\xec\xa8\xae\xfd\xb1\x97\x81\x81Q\xf8\xa5\xc2" +
+	"\"\xe1\x8f\x0a\xec\x0c\x0c\xc1o\x14\x98\x19\x19\x18\xff\x9f" +
+	"\x9b\xf8\xf1_\xe5\xf2\x94O\x0c\x17\xb9X\xff\xb0\xa3\xa8" +
+	"\xbd\xabP%\xfc\x10\xac\xf6\x0eH\xed\x7f4\xa8\xfd?" +
+	"Y[[/9\xb1 \x8f\xb1\xc0*/17\xb5\xb8" +
+	"\x80=195\x80\x91\x91\x91\x87\x81\x09.\xc9`\x0f" +
+	"\x91\x85\x8a\x03\x06\x00d+:~"
 
 func init() {
 	schemas.Register(schema_bdf87d7bb8304e81,
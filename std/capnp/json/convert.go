@@ -0,0 +1,216 @@
+package json
+
+import (
+	"encoding/base64"
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/iguazio/go-capnproto2"
+)
+
+// FromInterface allocates a JsonValue in seg that holds v.  v may be
+// nil, a bool, a number of any Go numeric type, a string, a []byte
+// (stored as a base64 string, as encoding/json does), a slice or array
+// of such values, or a map with string keys whose values are such
+// values.  Objects are written with their keys in sorted order.
+// Integers that a float64 cannot represent exactly are an error.
+func FromInterface(seg *capnp.Segment, v interface{}) (JsonValue, error) {
+	jv, err := NewJsonValue(seg)
+	if err != nil {
+		return JsonValue{}, err
+	}
+	if err := jv.set(v); err != nil {
+		return JsonValue{}, fmt.Errorf("json: %v", err)
+	}
+	return jv, nil
+}
+
+// set stores v in s.
+func (s JsonValue) set(v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		s.SetNull()
+		return nil
+	case bool:
+		s.SetBoolean(v)
+		return nil
+	case string:
+		return s.SetString_(v)
+	case []byte:
+		return s.SetString_(base64.StdEncoding.EncodeToString(v))
+	case float64:
+		s.SetNumber(v)
+		return nil
+	case float32:
+		s.SetNumber(float64(v))
+		return nil
+	case stdjson.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return fmt.Errorf("bad number %q", v)
+		}
+		s.SetNumber(f)
+		return nil
+	case []interface{}:
+		l, err := s.NewArray(int32(len(v)))
+		if err != nil {
+			return err
+		}
+		for i, e := range v {
+			if err := l.At(i).set(e); err != nil {
+				return fmt.Errorf("element %d: %v", i, err)
+			}
+		}
+		return nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return s.setObject(keys, func(k string) interface{} { return v[k] })
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := rv.Int()
+		if f := float64(i); f >= -(1<<63) && f < 1<<63 && int64(f) == i {
+			s.SetNumber(f)
+			return nil
+		}
+		return fmt.Errorf("integer %d cannot be represented exactly as a number", i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := rv.Uint()
+		if f := float64(u); f < 1<<64 && uint64(f) == u {
+			s.SetNumber(f)
+			return nil
+		}
+		return fmt.Errorf("integer %d cannot be represented exactly as a number", u)
+	case reflect.String:
+		return s.SetString_(rv.String())
+	case reflect.Slice, reflect.Array:
+		l, err := s.NewArray(int32(rv.Len()))
+		if err != nil {
+			return err
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if err := l.At(i).set(rv.Index(i).Interface()); err != nil {
+				return fmt.Errorf("element %d: %v", i, err)
+			}
+		}
+		return nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("map key type %v is not a string", rv.Type().Key())
+		}
+		keys := make([]string, 0, rv.Len())
+		for _, k := range rv.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		sort.Strings(keys)
+		kt := rv.Type().Key()
+		return s.setObject(keys, func(k string) interface{} {
+			return rv.MapIndex(reflect.ValueOf(k).Convert(kt)).Interface()
+		})
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			s.SetNull()
+			return nil
+		}
+		return s.set(rv.Elem().Interface())
+	}
+	return fmt.Errorf("cannot convert %T to a JsonValue", v)
+}
+
+func (s JsonValue) setObject(keys []string, value func(string) interface{}) error {
+	fields, err := s.NewObject(int32(len(keys)))
+	if err != nil {
+		return err
+	}
+	for i, k := range keys {
+		f := fields.At(i)
+		if err := f.SetName(k); err != nil {
+			return err
+		}
+		fv, err := f.NewValue()
+		if err != nil {
+			return err
+		}
+		if err := fv.set(value(k)); err != nil {
+			return fmt.Errorf("field %q: %v", k, err)
+		}
+	}
+	return nil
+}
+
+// ToInterface converts s to the Go values that encoding/json would
+// decode it to: nil, bool, float64, string, []interface{}, or
+// map[string]interface{}.  If an object has duplicate field names, the
+// last one wins.  Calls cannot be converted and are reported as errors.
+func (s JsonValue) ToInterface() (interface{}, error) {
+	v, err := s.toInterface()
+	if err != nil {
+		return nil, fmt.Errorf("json: %v", err)
+	}
+	return v, nil
+}
+
+func (s JsonValue) toInterface() (interface{}, error) {
+	switch s.Which() {
+	case JsonValue_Which_null:
+		return nil, nil
+	case JsonValue_Which_boolean:
+		return s.Boolean(), nil
+	case JsonValue_Which_number:
+		n := s.Number()
+		if math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, fmt.Errorf("number %v has no JSON representation", n)
+		}
+		return n, nil
+	case JsonValue_Which_string_:
+		return s.String_()
+	case JsonValue_Which_array:
+		l, err := s.Array()
+		if err != nil {
+			return nil, err
+		}
+		a := make([]interface{}, l.Len())
+		for i := range a {
+			a[i], err = l.At(i).toInterface()
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %v", i, err)
+			}
+		}
+		return a, nil
+	case JsonValue_Which_object:
+		fields, err := s.Object()
+		if err != nil {
+			return nil, err
+		}
+		m := make(map[string]interface{}, fields.Len())
+		for i := 0; i < fields.Len(); i++ {
+			f := fields.At(i)
+			name, err := f.Name()
+			if err != nil {
+				return nil, err
+			}
+			fv, err := f.Value()
+			if err != nil {
+				return nil, err
+			}
+			m[name], err = fv.toInterface()
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %v", name, err)
+			}
+		}
+		return m, nil
+	case JsonValue_Which_call:
+		return nil, errors.New("cannot convert call to an interface value")
+	default:
+		return nil, fmt.Errorf("unknown value kind %v", s.Which())
+	}
+}
@@ -0,0 +1,111 @@
+package json
+
+import (
+	stdjson "encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+)
+
+func TestFromInterface(t *testing.T) {
+	type name string
+	tests := []struct {
+		in   interface{}
+		want interface{}
+	}{
+		{nil, nil},
+		{true, true},
+		{int8(-3), -3.0},
+		{uint64(1 << 60), float64(1 << 60)},
+		{float32(1.5), 1.5},
+		{stdjson.Number("2.5"), 2.5},
+		{"hi", "hi"},
+		{name("typed"), "typed"},
+		{[]byte{0, 1}, "AAE="},
+		{[]int{1, 2}, []interface{}{1.0, 2.0}},
+		{
+			map[string]interface{}{"b": []interface{}{nil, "x"}, "a": map[name]bool{"c": false}},
+			map[string]interface{}{"a": map[string]interface{}{"c": false}, "b": []interface{}{nil, "x"}},
+		},
+	}
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		v, err := FromInterface(seg, test.in)
+		if err != nil {
+			t.Errorf("FromInterface(%#v): %v", test.in, err)
+			continue
+		}
+		got, err := v.ToInterface()
+		if err != nil {
+			t.Errorf("FromInterface(%#v).ToInterface(): %v", test.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("FromInterface(%#v).ToInterface() = %#v; want %#v", test.in, got, test.want)
+		}
+	}
+}
+
+func TestFromInterface_ObjectOrder(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := FromInterface(seg, map[string]int{"z": 1, "a": 2, "m": 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields, _ := v.Object()
+	var names []string
+	for i := 0; i < fields.Len(); i++ {
+		n, _ := fields.At(i).Name()
+		names = append(names, n)
+	}
+	if got := strings.Join(names, ","); got != "a,m,z" {
+		t.Errorf("field order = %s; want a,m,z", got)
+	}
+}
+
+func TestFromInterface_Errors(t *testing.T) {
+	tests := []struct {
+		in  interface{}
+		err string
+	}{
+		{int64(1<<62 + 1), "cannot be represented exactly"},
+		{map[int]bool{1: true}, "map key type int"},
+		{[]interface{}{1, struct{}{}}, "element 1: cannot convert struct {}"},
+		{map[string]interface{}{"ch": make(chan int)}, `field "ch"`},
+	}
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		_, err := FromInterface(seg, test.in)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("FromInterface(%#v) error = %v; want error containing %q", test.in, err, test.err)
+		}
+	}
+}
+
+func TestToInterface_Call(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := NewRootJsonValue(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.NewCall(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.ToInterface(); err == nil {
+		t.Error("ToInterface of call succeeded; want error")
+	}
+}
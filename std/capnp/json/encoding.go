@@ -0,0 +1,258 @@
+package json
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/iguazio/go-capnproto2"
+)
+
+// MarshalJSON returns s as JSON text.  Object fields are written in
+// the order they appear in s, including any duplicates, and numbers are
+// written with the fewest digits that parse back to the same float64.
+// Calls, NaN, and infinities have no JSON representation and are
+// reported as errors.
+func (s JsonValue) MarshalJSON() ([]byte, error) {
+	b, err := s.appendJSON(nil)
+	if err != nil {
+		return nil, fmt.Errorf("json: %v", err)
+	}
+	return b, nil
+}
+
+func (s JsonValue) appendJSON(b []byte) ([]byte, error) {
+	switch s.Which() {
+	case JsonValue_Which_null:
+		return append(b, "null"...), nil
+	case JsonValue_Which_boolean:
+		return strconv.AppendBool(b, s.Boolean()), nil
+	case JsonValue_Which_number:
+		return appendNumber(b, s.Number())
+	case JsonValue_Which_string_:
+		v, err := s.String_()
+		if err != nil {
+			return nil, err
+		}
+		return appendString(b, v), nil
+	case JsonValue_Which_array:
+		l, err := s.Array()
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, '[')
+		for i := 0; i < l.Len(); i++ {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b, err = l.At(i).appendJSON(b)
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %v", i, err)
+			}
+		}
+		return append(b, ']'), nil
+	case JsonValue_Which_object:
+		fields, err := s.Object()
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, '{')
+		for i := 0; i < fields.Len(); i++ {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			f := fields.At(i)
+			name, err := f.Name()
+			if err != nil {
+				return nil, err
+			}
+			b = append(appendString(b, name), ':')
+			fv, err := f.Value()
+			if err != nil {
+				return nil, err
+			}
+			b, err = fv.appendJSON(b)
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %v", name, err)
+			}
+		}
+		return append(b, '}'), nil
+	case JsonValue_Which_call:
+		return nil, errors.New("calls have no JSON representation")
+	default:
+		return nil, fmt.Errorf("unknown value kind %v", s.Which())
+	}
+}
+
+// appendNumber formats f the way encoding/json does.
+func appendNumber(b []byte, f float64) ([]byte, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, fmt.Errorf("number %v has no JSON representation", f)
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+	b = strconv.AppendFloat(b, f, format, -1, 64)
+	if format == 'e' {
+		// Clean up e-09 to e-9.
+		if n := len(b); n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	return b, nil
+}
+
+func appendString(b []byte, s string) []byte {
+	// Marshaling a string can't fail.
+	q, _ := stdjson.Marshal(s)
+	return append(b, q...)
+}
+
+// UnmarshalJSON sets s to the JSON value in data, keeping the order of
+// object fields.  If s is already a struct in a message, the value is
+// written in place, and any objects held by the old value are cleared;
+// otherwise, s is set to the root of a new message.
+func (s *JsonValue) UnmarshalJSON(data []byte) error {
+	dec := stdjson.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v value
+	if err := v.read(dec); err != nil {
+		return fmt.Errorf("json: %v", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("json: unexpected data after top-level value")
+	}
+	if s.Struct.Segment() == nil {
+		_, seg, err := capnp.NewMessage(capnp.MultiSegment(nil))
+		if err != nil {
+			return err
+		}
+		*s, err = NewRootJsonValue(seg)
+		if err != nil {
+			return err
+		}
+	} else if err := s.ResetUnion(); err != nil {
+		return err
+	}
+	if err := v.write(*s); err != nil {
+		return fmt.Errorf("json: %v", err)
+	}
+	return nil
+}
+
+// A value is a parsed JSON value, kept in the order it was read.
+type value struct {
+	kind   JsonValue_Which
+	b      bool
+	n      float64
+	s      string
+	elems  []value
+	fields []field
+}
+
+type field struct {
+	name string
+	val  value
+}
+
+func (v *value) read(dec *stdjson.Decoder) error {
+	tok, err := dec.Token()
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return err
+	}
+	switch tok := tok.(type) {
+	case nil:
+		v.kind = JsonValue_Which_null
+	case bool:
+		v.kind, v.b = JsonValue_Which_boolean, tok
+	case stdjson.Number:
+		n, err := strconv.ParseFloat(string(tok), 64)
+		if err != nil {
+			return fmt.Errorf("number %s out of range", tok)
+		}
+		v.kind, v.n = JsonValue_Which_number, n
+	case string:
+		v.kind, v.s = JsonValue_Which_string_, tok
+	case stdjson.Delim:
+		switch tok {
+		case '[':
+			v.kind = JsonValue_Which_array
+			for dec.More() {
+				var e value
+				if err := e.read(dec); err != nil {
+					return err
+				}
+				v.elems = append(v.elems, e)
+			}
+		case '{':
+			v.kind = JsonValue_Which_object
+			for dec.More() {
+				tok, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				f := field{name: tok.(string)}
+				if err := f.val.read(dec); err != nil {
+					return err
+				}
+				v.fields = append(v.fields, f)
+			}
+		}
+		// Consume the closing delimiter.
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *value) write(s JsonValue) error {
+	switch v.kind {
+	case JsonValue_Which_null:
+		s.SetNull()
+	case JsonValue_Which_boolean:
+		s.SetBoolean(v.b)
+	case JsonValue_Which_number:
+		s.SetNumber(v.n)
+	case JsonValue_Which_string_:
+		return s.SetString_(v.s)
+	case JsonValue_Which_array:
+		l, err := s.NewArray(int32(len(v.elems)))
+		if err != nil {
+			return err
+		}
+		for i := range v.elems {
+			if err := v.elems[i].write(l.At(i)); err != nil {
+				return err
+			}
+		}
+	case JsonValue_Which_object:
+		fields, err := s.NewObject(int32(len(v.fields)))
+		if err != nil {
+			return err
+		}
+		for i := range v.fields {
+			f := fields.At(i)
+			if err := f.SetName(v.fields[i].name); err != nil {
+				return err
+			}
+			fv, err := f.NewValue()
+			if err != nil {
+				return err
+			}
+			if err := v.fields[i].val.write(fv); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
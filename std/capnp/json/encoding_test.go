@@ -0,0 +1,112 @@
+package json
+
+import (
+	stdjson "encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`null`, `null`},
+		{` true `, `true`},
+		{`-0.5`, `-0.5`},
+		{`1e-7`, `1e-7`},
+		{`1E21`, `1e+21`},
+		{`12345678901234567`, `12345678901234568`},
+		{`"a\"é\n"`, `"a\"é\n"`},
+		{`[1, [], {}]`, `[1,[],{}]`},
+		{`{"z": 1, "a": [true, null], "z": "dup"}`, `{"z":1,"a":[true,null],"z":"dup"}`},
+	}
+	for _, test := range tests {
+		var v JsonValue
+		if err := v.UnmarshalJSON([]byte(test.in)); err != nil {
+			t.Errorf("UnmarshalJSON(%s): %v", test.in, err)
+			continue
+		}
+		out, err := v.MarshalJSON()
+		if err != nil {
+			t.Errorf("MarshalJSON(UnmarshalJSON(%s)): %v", test.in, err)
+			continue
+		}
+		if string(out) != test.want {
+			t.Errorf("MarshalJSON(UnmarshalJSON(%s)) = %s; want %s", test.in, out, test.want)
+		}
+	}
+}
+
+func TestJSONWithEncodingJSON(t *testing.T) {
+	var doc struct {
+		ID    int       `json:"id"`
+		Value JsonValue `json:"value"`
+	}
+	in := `{"id":7,"value":{"b":[1,"x"],"a":null}}`
+	if err := stdjson.Unmarshal([]byte(in), &doc); err != nil {
+		t.Fatal("Unmarshal:", err)
+	}
+	out, err := stdjson.Marshal(doc)
+	if err != nil {
+		t.Fatal("Marshal:", err)
+	}
+	if string(out) != in {
+		t.Errorf("Marshal(Unmarshal(%s)) = %s", in, out)
+	}
+}
+
+func TestUnmarshalJSON_InPlace(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := NewRootJsonValue(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SetString_("old"); err != nil {
+		t.Fatal(err)
+	}
+	orig := v
+	if err := v.UnmarshalJSON([]byte(`[false]`)); err != nil {
+		t.Fatal("UnmarshalJSON:", err)
+	}
+	if v.Struct.Segment() != orig.Struct.Segment() || v.Which() != JsonValue_Which_array {
+		t.Fatalf("UnmarshalJSON did not write in place")
+	}
+	if strings.Contains(string(v.Struct.Segment().Data()), "old") {
+		t.Error("old string remains in segment")
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	for _, in := range []string{`[1,`, `{"a":1} 2`, `1e999`, `}`} {
+		var v JsonValue
+		if err := v.UnmarshalJSON([]byte(in)); err == nil {
+			t.Errorf("UnmarshalJSON(%s) succeeded; want error", in)
+		}
+	}
+
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := NewRootJsonValue(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v.SetNumber(math.NaN())
+	if _, err := v.MarshalJSON(); err == nil {
+		t.Error("MarshalJSON(NaN) succeeded; want error")
+	}
+	if _, err := v.NewCall(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.MarshalJSON(); err == nil {
+		t.Error("MarshalJSON(call) succeeded; want error")
+	}
+}
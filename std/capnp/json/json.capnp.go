@@ -3,13 +3,20 @@
 package json
 
 import (
-	math "math"
-	strconv "strconv"
 	capnp "github.com/iguazio/go-capnproto2"
 	text "github.com/iguazio/go-capnproto2/encoding/text"
 	schemas "github.com/iguazio/go-capnproto2/schemas"
+	math "math"
+	strconv "strconv"
 )
 
+const Name = uint64(0xfa5b1fd61c2e7c3d)
+const Flatten = uint64(0x82d3e852af0336bf)
+const Discriminator = uint64(0xcfa794e8d19a0162)
+const Base64 = uint64(0xd7d879450a253e4b)
+const Hex = uint64(0xf061e22f0ae5c7b5)
+const Notification = uint64(0xa0a054dea32fd98c)
+
 type JsonValue struct{ capnp.Struct }
 type JsonValue_Which uint16
 
@@ -58,11 +65,28 @@ func NewRootJsonValue(s *capnp.Segment) (JsonValue, error) {
 	return JsonValue{st}, err
 }
 
+func AllocateRootJsonValue(msg *capnp.Message) (JsonValue, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return JsonValue{st}, err
+}
+
 func ReadRootJsonValue(msg *capnp.Message) (JsonValue, error) {
 	root, err := msg.RootPtr()
 	return JsonValue{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s JsonValue) Equal(other JsonValue) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s JsonValue) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s JsonValue) String() string {
 	str, _ := text.Marshal(0x8825ffaa852cda72, s.Struct)
 	return str
@@ -71,6 +95,18 @@ func (s JsonValue) String() string {
 func (s JsonValue) Which() JsonValue_Which {
 	return JsonValue_Which(s.Struct.Uint16(0))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s JsonValue) ResetUnion() error {
+	s.Struct.SetUint16(0, 0)
+	s.Struct.SetBit(16, false)
+	s.Struct.SetUint64(8, 0)
+	if err := s.Struct.ClearPtr(0); err != nil {
+		return err
+	}
+	return nil
+}
 func (s JsonValue) SetNull() {
 	s.Struct.SetUint16(0, 0)
 
@@ -78,7 +114,7 @@ func (s JsonValue) SetNull() {
 
 func (s JsonValue) Boolean() bool {
 	if s.Struct.Uint16(0) != 1 {
-		panic("Which() != boolean")
+		capnp.UnionMismatch(s.Struct, "boolean")
 	}
 	return s.Struct.Bit(16)
 }
@@ -90,7 +126,7 @@ func (s JsonValue) SetBoolean(v bool) {
 
 func (s JsonValue) Number() float64 {
 	if s.Struct.Uint16(0) != 2 {
-		panic("Which() != number")
+		capnp.UnionMismatch(s.Struct, "number")
 	}
 	return math.Float64frombits(s.Struct.Uint64(8))
 }
@@ -102,7 +138,7 @@ func (s JsonValue) SetNumber(v float64) {
 
 func (s JsonValue) String_() (string, error) {
 	if s.Struct.Uint16(0) != 3 {
-		panic("Which() != string_")
+		capnp.UnionMismatch(s.Struct, "string_")
 	}
 	p, err := s.Struct.Ptr(0)
 	return p.Text(), err
@@ -128,7 +164,7 @@ func (s JsonValue) SetString_(v string) error {
 
 func (s JsonValue) Array() (JsonValue_List, error) {
 	if s.Struct.Uint16(0) != 4 {
-		panic("Which() != array")
+		capnp.UnionMismatch(s.Struct, "array")
 	}
 	p, err := s.Struct.Ptr(0)
 	return JsonValue_List{List: p.List()}, err
@@ -161,7 +197,7 @@ func (s JsonValue) NewArray(n int32) (JsonValue_List, error) {
 
 func (s JsonValue) Object() (JsonValue_Field_List, error) {
 	if s.Struct.Uint16(0) != 5 {
-		panic("Which() != object")
+		capnp.UnionMismatch(s.Struct, "object")
 	}
 	p, err := s.Struct.Ptr(0)
 	return JsonValue_Field_List{List: p.List()}, err
@@ -194,7 +230,7 @@ func (s JsonValue) NewObject(n int32) (JsonValue_Field_List, error) {
 
 func (s JsonValue) Call() (JsonValue_Call, error) {
 	if s.Struct.Uint16(0) != 6 {
-		panic("Which() != call")
+		capnp.UnionMismatch(s.Struct, "call")
 	}
 	p, err := s.Struct.Ptr(0)
 	return JsonValue_Call{Struct: p.Struct()}, err
@@ -234,6 +270,13 @@ func NewJsonValue_List(s *capnp.Segment, sz int32) (JsonValue_List, error) {
 	return JsonValue_List{l}, err
 }
 
+// NewRootJsonValueListMessage creates a message whose root is a new
+// list of n JsonValue.  The message's segment is sized to fit the list.
+func NewRootJsonValueListMessage(n int32) (*capnp.Message, JsonValue_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 16, PointerCount: 1}, n)
+	return msg, JsonValue_List{l}, err
+}
+
 func (s JsonValue_List) At(i int) JsonValue { return JsonValue{s.List.Struct(i)} }
 
 func (s JsonValue_List) Set(i int, v JsonValue) error { return s.List.SetStruct(i, v.Struct) }
@@ -247,8 +290,7 @@ func (s JsonValue_List) String() string {
 type JsonValue_Promise struct{ *capnp.Pipeline }
 
 func (p JsonValue_Promise) Struct() (JsonValue, error) {
-	s, err := p.Pipeline.Struct()
-	return JsonValue{s}, err
+	return capnp.PipelineStruct[JsonValue](p.Pipeline)
 }
 
 func (p JsonValue_Promise) Call() JsonValue_Call_Promise {
@@ -270,11 +312,28 @@ func NewRootJsonValue_Field(s *capnp.Segment) (JsonValue_Field, error) {
 	return JsonValue_Field{st}, err
 }
 
+func AllocateRootJsonValue_Field(msg *capnp.Message) (JsonValue_Field, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return JsonValue_Field{st}, err
+}
+
 func ReadRootJsonValue_Field(msg *capnp.Message) (JsonValue_Field, error) {
 	root, err := msg.RootPtr()
 	return JsonValue_Field{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s JsonValue_Field) Equal(other JsonValue_Field) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s JsonValue_Field) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s JsonValue_Field) String() string {
 	str, _ := text.Marshal(0xc27855d853a937cc, s.Struct)
 	return str
@@ -333,6 +392,13 @@ func NewJsonValue_Field_List(s *capnp.Segment, sz int32) (JsonValue_Field_List,
 	return JsonValue_Field_List{l}, err
 }
 
+// NewRootJsonValue_FieldListMessage creates a message whose root is a new
+// list of n JsonValue_Field.  The message's segment is sized to fit the list.
+func NewRootJsonValue_FieldListMessage(n int32) (*capnp.Message, JsonValue_Field_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 2}, n)
+	return msg, JsonValue_Field_List{l}, err
+}
+
 func (s JsonValue_Field_List) At(i int) JsonValue_Field { return JsonValue_Field{s.List.Struct(i)} }
 
 func (s JsonValue_Field_List) Set(i int, v JsonValue_Field) error {
@@ -348,8 +414,7 @@ func (s JsonValue_Field_List) String() string {
 type JsonValue_Field_Promise struct{ *capnp.Pipeline }
 
 func (p JsonValue_Field_Promise) Struct() (JsonValue_Field, error) {
-	s, err := p.Pipeline.Struct()
-	return JsonValue_Field{s}, err
+	return capnp.PipelineStruct[JsonValue_Field](p.Pipeline)
 }
 
 func (p JsonValue_Field_Promise) Value() JsonValue_Promise {
@@ -371,11 +436,28 @@ func NewRootJsonValue_Call(s *capnp.Segment) (JsonValue_Call, error) {
 	return JsonValue_Call{st}, err
 }
 
+func AllocateRootJsonValue_Call(msg *capnp.Message) (JsonValue_Call, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return JsonValue_Call{st}, err
+}
+
 func ReadRootJsonValue_Call(msg *capnp.Message) (JsonValue_Call, error) {
 	root, err := msg.RootPtr()
 	return JsonValue_Call{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s JsonValue_Call) Equal(other JsonValue_Call) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s JsonValue_Call) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s JsonValue_Call) String() string {
 	str, _ := text.Marshal(0x9bbf84153dd4bb60, s.Struct)
 	return str
@@ -434,6 +516,13 @@ func NewJsonValue_Call_List(s *capnp.Segment, sz int32) (JsonValue_Call_List, er
 	return JsonValue_Call_List{l}, err
 }
 
+// NewRootJsonValue_CallListMessage creates a message whose root is a new
+// list of n JsonValue_Call.  The message's segment is sized to fit the list.
+func NewRootJsonValue_CallListMessage(n int32) (*capnp.Message, JsonValue_Call_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 2}, n)
+	return msg, JsonValue_Call_List{l}, err
+}
+
 func (s JsonValue_Call_List) At(i int) JsonValue_Call { return JsonValue_Call{s.List.Struct(i)} }
 
 func (s JsonValue_Call_List) Set(i int, v JsonValue_Call) error { return s.List.SetStruct(i, v.Struct) }
@@ -447,48 +536,285 @@ func (s JsonValue_Call_List) String() string {
 type JsonValue_Call_Promise struct{ *capnp.Pipeline }
 
 func (p JsonValue_Call_Promise) Struct() (JsonValue_Call, error) {
-	s, err := p.Pipeline.Struct()
-	return JsonValue_Call{s}, err
-}
-
-const schema_8ef99297a43a5e34 = "x\xdat\x92AHTQ\x14\x86\xff\xff\xde7\xa3\xe2" +
-	"L\xf3^\xf3\xa4\x16\x89\x9b\xa2\x1235!\x18\x88)" +
-	"-\x09\x17\xe1m\xa8e\xf9\x1c_1r}O\xde8" +
-	"Y+7\x05\xb5\xa8(\x88\x16\xad\x826\xbajQ\x90" +
-	"\x94\x94\xd2\xb2U\xb4\x08W-\xda\x04m\xdaee7" +
-	"n\x92O$w\x87s\xce=\xff\xcf\xf7\xdf\x9e\x93<" +
-	".z33\x12P\x072Y\x93\xact\xdd\x987\xfb" +
-	"nB\xb5R\x98\xfe\x0b\xa5'\x0f\xef\xaf\xde\xc1)6" +
-	"5\x01\xc5\xc7\x9c/\xceq?pd\x91w\x09\x9a\xd1" +
-	"\x97\x1f\x8e\xb5]\x7f\xfd\x08^\x1b\xd3\xb7\x19a\x97o" +
-	"\xc9\xf7\xc5\x07\xd2V\xf7\xe4\x0ch\xde\x1d\x9d\xab|<" +
-	"wu\xf9\x7f\xbbtV\x8ay\xc7V-\xce\x0c\xce\x98" +
-	"\x89z\x1cuW\x83)FS\xa5\xe1z\x1c\x9d/\x04" +
-	"\xba\x11\xaafn>\xd3\xd2\xb7I?\xd3\xd91T\x0b" +
-	"\xf5xa0\xd0Z\xed\x91N\xce\x18\x87\x80\xf7\xbc\x13" +
-	"PO%\xd5+\xc1v\xfe6\xaeO\xdb^\x18\x00\xd4" +
-	"3I\xf5F\xb0]\xac\x19\xfa\x14\x80\xb7X\x02\xd4\x0b" +
-	"I\xf5V0/\x7f\x19\x9f\x12\xf0\x96J\xdeR\x87\xfa" +
-	"$\xa9\xbe\x0a\xe6\x9d\x9f\xc6\xa7\x03x_\xfa\x00\xf5Y" +
-	"\xf2,\x05\xf3\x99\x1f\xc6g\x06\xf0\xd6\xec\x89\xef\x92\x15" +
-	"\xdf\xb6\xb3\xab\xc6g\x16(z\xec\x04*9JVv" +
-	"S\xb0\x105\xb4Fvv,\x8eu\x18D$\x04\x09" +
-	"\x96\xa3\xc6\xe4X\x98\xb0\x15\x82\xad`\xb9>\x9d\xd4\xa2" +
-	"\xcb\xca\xa10\xdfn\x1f\xde\xb5sta\x19\xca\x11<" +
-	"\xe1\x929\xc0\xe3\xc0\xec\xfa\xcaE\x809\x08\xe6\xc0\x8e" +
-	" I\x82k\xdc\x01\x8eH\xd2MY\x83\xb6Y\x8e\xc7" +
-	"&\xc2\xeat:\xdf \xba>/T\x03\xad\xe9\xa6l" +
-	"A\xba\xe0F&\xe2_&6\x92\xee\xc1@S\x8f\x90" +
-	"\xaaY:\xc0_\xe2\x07\x87\xedg\x92T\xfd\x82\x1e\xb9" +
-	"\xce\xbb\xd7R\xe9\x92T\xa7\x05\xcd\xa5FT\x9d\xae\xc5" +
-	"\x11R\xd3\xe5\xa9 \x09&\xeb\xdb\xba\xdeF~\xa8\x16" +
-	"J=\xbeE\xdf&\xbeWR\xf5l\xd2?\xd4\x97\x9a" +
-	"*D\xc1d\xb8A\xeb\x8a=\xb4E\xd0\x05\xff\x04\x00" +
-	"\x00\xff\xff\x9f\xdb\xc7\x93"
+	return capnp.PipelineStruct[JsonValue_Call](p.Pipeline)
+}
+
+type FlattenOptions struct{ capnp.Struct }
+
+// FlattenOptions_TypeID is the unique identifier for the type FlattenOptions.
+const FlattenOptions_TypeID = 0xc4df13257bc2ea61
+
+func NewFlattenOptions(s *capnp.Segment) (FlattenOptions, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return FlattenOptions{st}, err
+}
+
+func NewRootFlattenOptions(s *capnp.Segment) (FlattenOptions, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return FlattenOptions{st}, err
+}
+
+func AllocateRootFlattenOptions(msg *capnp.Message) (FlattenOptions, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return FlattenOptions{st}, err
+}
+
+func ReadRootFlattenOptions(msg *capnp.Message) (FlattenOptions, error) {
+	root, err := msg.RootPtr()
+	return FlattenOptions{root.Struct()}, err
+}
+
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s FlattenOptions) Equal(other FlattenOptions) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s FlattenOptions) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
+func (s FlattenOptions) String() string {
+	str, _ := text.Marshal(0xc4df13257bc2ea61, s.Struct)
+	return str
+}
+
+func (s FlattenOptions) Prefix() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s FlattenOptions) HasPrefix() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s FlattenOptions) PrefixBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s FlattenOptions) SetPrefix(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+// FlattenOptions_List is a list of FlattenOptions.
+type FlattenOptions_List struct{ capnp.List }
+
+// NewFlattenOptions creates a new list of FlattenOptions.
+func NewFlattenOptions_List(s *capnp.Segment, sz int32) (FlattenOptions_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return FlattenOptions_List{l}, err
+}
+
+// NewRootFlattenOptionsListMessage creates a message whose root is a new
+// list of n FlattenOptions.  The message's segment is sized to fit the list.
+func NewRootFlattenOptionsListMessage(n int32) (*capnp.Message, FlattenOptions_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, FlattenOptions_List{l}, err
+}
+
+func (s FlattenOptions_List) At(i int) FlattenOptions { return FlattenOptions{s.List.Struct(i)} }
+
+func (s FlattenOptions_List) Set(i int, v FlattenOptions) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s FlattenOptions_List) String() string {
+	str, _ := text.MarshalList(0xc4df13257bc2ea61, s.List)
+	return str
+}
+
+// FlattenOptions_Promise is a wrapper for a FlattenOptions promised by a client call.
+type FlattenOptions_Promise struct{ *capnp.Pipeline }
+
+func (p FlattenOptions_Promise) Struct() (FlattenOptions, error) {
+	return capnp.PipelineStruct[FlattenOptions](p.Pipeline)
+}
+
+type DiscriminatorOptions struct{ capnp.Struct }
+
+// DiscriminatorOptions_TypeID is the unique identifier for the type DiscriminatorOptions.
+const DiscriminatorOptions_TypeID = 0xc2f8c20c293e5319
+
+func NewDiscriminatorOptions(s *capnp.Segment) (DiscriminatorOptions, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return DiscriminatorOptions{st}, err
+}
+
+func NewRootDiscriminatorOptions(s *capnp.Segment) (DiscriminatorOptions, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return DiscriminatorOptions{st}, err
+}
+
+func AllocateRootDiscriminatorOptions(msg *capnp.Message) (DiscriminatorOptions, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return DiscriminatorOptions{st}, err
+}
+
+func ReadRootDiscriminatorOptions(msg *capnp.Message) (DiscriminatorOptions, error) {
+	root, err := msg.RootPtr()
+	return DiscriminatorOptions{root.Struct()}, err
+}
+
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s DiscriminatorOptions) Equal(other DiscriminatorOptions) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s DiscriminatorOptions) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
+func (s DiscriminatorOptions) String() string {
+	str, _ := text.Marshal(0xc2f8c20c293e5319, s.Struct)
+	return str
+}
+
+func (s DiscriminatorOptions) Name() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s DiscriminatorOptions) HasName() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s DiscriminatorOptions) NameBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s DiscriminatorOptions) SetName(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s DiscriminatorOptions) ValueName() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s DiscriminatorOptions) HasValueName() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s DiscriminatorOptions) ValueNameBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s DiscriminatorOptions) SetValueName(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+// DiscriminatorOptions_List is a list of DiscriminatorOptions.
+type DiscriminatorOptions_List struct{ capnp.List }
+
+// NewDiscriminatorOptions creates a new list of DiscriminatorOptions.
+func NewDiscriminatorOptions_List(s *capnp.Segment, sz int32) (DiscriminatorOptions_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	return DiscriminatorOptions_List{l}, err
+}
+
+// NewRootDiscriminatorOptionsListMessage creates a message whose root is a new
+// list of n DiscriminatorOptions.  The message's segment is sized to fit the list.
+func NewRootDiscriminatorOptionsListMessage(n int32) (*capnp.Message, DiscriminatorOptions_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 2}, n)
+	return msg, DiscriminatorOptions_List{l}, err
+}
+
+func (s DiscriminatorOptions_List) At(i int) DiscriminatorOptions {
+	return DiscriminatorOptions{s.List.Struct(i)}
+}
+
+func (s DiscriminatorOptions_List) Set(i int, v DiscriminatorOptions) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s DiscriminatorOptions_List) String() string {
+	str, _ := text.MarshalList(0xc2f8c20c293e5319, s.List)
+	return str
+}
+
+// DiscriminatorOptions_Promise is a wrapper for a DiscriminatorOptions promised by a client call.
+type DiscriminatorOptions_Promise struct{ *capnp.Pipeline }
+
+func (p DiscriminatorOptions_Promise) Struct() (DiscriminatorOptions, error) {
+	return capnp.PipelineStruct[DiscriminatorOptions](p.Pipeline)
+}
+
+const schema_8ef99297a43a5e34 = "x\xda\x84\x94_h[e\x18\xc6\x9f\xe7\xfb\x92\xfeY" +
+	"R\x9bcR\x9c\xb0\xd1\xd0\xd3\xa2-\xb3]\xbb:g" +
+	"`\x8bv\xae\xc8\x04\xed\xd7\xa3\xde\x08\xba\x93\xf4T\xcf" +
+	"89\x09I\xea:\x15\x06C\xc1\x0b\xa7l\x08\xc2\xbc" +
+	"\x19(\x88\xbb\xd1\x0b\x07\x0e\x95\xd90\xf0\x0f\x8al\x8a" +
+	"n\xbb\xd0*\x0e:\xf1\xc2\x81\x83u:\x8f|\x89\xee" +
+	"$qCrsx\xcf/\xcf\xfb\xbc\xcf\xfb}g\xe3" +
+	"\x9e\xee{\xc4x\xb4s\x0a\x98\x95\xd1\x8e\xe0\xc4f\xf9" +
+	"\xce\xec\xca\xd7\xfb\xa1b\xd1\xe5`\xf2\xf1\xcc\x9b\xaf\x1d" +
+	"Z}\x19`\xf2B\xfa0h\xad\xa4%\xc1\xa0|n" +
+	"\xc3\x0bG\x83\xa1\x17\xa1b\x14!\xb6\x83\x9d\x9d@\xf2" +
+	"l\xfah\xf2\xc7\xf4m\xc0\xa6K\xe9W4\xbe\xeb\x83" +
+	"o\xb6\xf6=\x7f\xe2u\x18}M\xff\x8d\x0a\x0d/\x0d" +
+	"\x9cN~9\xa0\x9f>\x1b\xd8\x03\x06/\x9d\x1d{\xe3" +
+	"\xfb\x87\x8f\x1c\xc1\xc1XT\xb48\xb8\xdb\xac\x81\xd6\x16" +
+	"\xb3\xee\xe0\x8b\xbb\xde\xb6\xce<\xb2X\xbb\x9e\xe4\xb0y" +
+	".y\xa7\xa9\x9f\xc6M-y\xab\xb5m8^\xbb\\" +
+	"\x83\x11c\xa8Xg7\x1d0\x05\xc1\xe4\xc1:h\xff" +
+	"R{v(\xf9\xc3\xc96\x90Z\xea\xa2y\x1aL^" +
+	"2\xb3`\x90\xe3\xe1S+\xaf\xbe\xf5\x95Ni\xa6\xc5" +
+	"c\xdf\xe0\xe7\xa0\xb5v\xb0\xee\xf1\x81mCkv\xec" +
+	"=\xf3\x9d\xc6\xd2-X\xf7\xe0!\xd0\xeaj`\xc7>" +
+	"9\xbff\xec'\xfb\xb7\xffb\xab\xe63\xa0\xf5{c" +
+	"\xe2\xad\xcf\x8d\xae\xfb\xb6\xff\xb1+8\x15\x8b\xae\xb4&" +
+	"s\xde\xdc\x0fZ\xcb\x9a\x0bZ~\x9f\x06\xbb+E\x7f" +
+	"4o\x97\xe8\x972\xf3\x9e]\xadJ\xc7g\"\x9c\x14" +
+	"lAvV\x8a\xfe\xa3\xbd\xb6\xb7\xe0\xa8.6\xa7\xdc" +
+	"=\xd1\xb4\xc5\xe8H\xff\xb4\xebxs\xbd\xdbm\xcfS" +
+	"\xebd$\x1e\x04\x11\x02\xc6\xb1\x11@\xbd+\xa9>\x14" +
+	"\\\xcf\xbf\x82D\x8a\xba||\x0aP\xefI\xaa\x8f\x05" +
+	"\xd7\x8b\xab\x01S\x14\x80\xf1Q\x06P\xefK\xaa\x93\x82" +
+	"=\xf2\xcf E\x09\x18K\x19c\xa9_-K\xaa_" +
+	"\x05{\"\x7f\x04)F\x00\xe3\xc2\x04\xa0~\x96\x9c\xa5" +
+	"`O\xf4J\x90b\x140\xaej\x89\xcb\x92VJ\x97" +
+	";V\x83\x14;\x80\xa4\xc1\x11\xc0\x8aS\xd2ZK\xc1" +
+	"^\x7f\xc1\xf3\xd0\xb1/W,z\x8e\xed\x93\x10$\x98" +
+	"\xf5\x17\x0a9\xa7\xcc\x18\x04c`\xb6R-\xbb\xfe\x93" +
+	"*B\x11\\<0v\xcb\xcd\xbb\x8e\xd7\xa0\"\x82\xf7" +
+	"&\xc88`pj_\x03y\x02`\x1c\x82q\xb0\xdf" +
+	".\x97\xed\xbd\xbc\x09\x9c\x91d\"<\x8a\xa0.f\x8b" +
+	"\xb9\xddN\xbe\x1a\xbe\xbf\x96h\xe3}o\xde\xf6<&" +
+	"\xc2lA&\x9av\"\xfe\xdd\x89^\xc9\xe8v\xdb\xa3" +
+	"7C\xaa.\x19\x01\xea\x89\x0f\xef\x04\xd4\xed\x92jR" +
+	"\xd0 \x1by\x8f\xebT6H\xaa\xfb\x05\x83\xf9\x05?" +
+	"_u\x8b>B\xd3\xd9\x92]\xb6\x0b\x95\x1b\xbani" +
+	"\xef\x17\xab\xee\xbc\x9b\xb7\x1b\x1a\xf2\x06\xd6\xa6]Gz" +
+	"sm\xde\xf4i\x18\x94T\x1b\x9b\xbc\xdd1\x11\x1a\xee" +
+	"\xf5\xed\x82s-\xc9\xa7\xb5P\x9b\x99\xe6,\xa4_\xca" +
+	"\xdc\xe7V\xf2e\xb7\xe0\xfav\xb5X~\xa8\xa4=U" +
+	"\xf0\xbf-g\xff\x89cK[\xcb\xa0\xde\xf2A\xbb\x00" +
+	"\x86\xb5\xe6\xf9\xa6\xf5\x8dq\xfcF#VT\xa4\xd1\x88" +
+	"4zt\xc4]\x92*%\x98-\x95\x9dyw\xf1\xba" +
+	"\x0as\xcd\x86\x01&\xc2\xafQ\xdb\xd5\xcb\xd9\x15g3" +
+	"'\x9b\x12F\xd6/e\x9er\x16![@=A}" +
+	"\x95\x7f\x0f\x00\xa2|\x9fr"
 
 func init() {
 	schemas.Register(schema_8ef99297a43a5e34,
+		0x82d3e852af0336bf,
 		0x8825ffaa852cda72,
 		0x9bbf84153dd4bb60,
-		0xc27855d853a937cc)
+		0xa0a054dea32fd98c,
+		0xc27855d853a937cc,
+		0xc2f8c20c293e5319,
+		0xc4df13257bc2ea61,
+		0xcfa794e8d19a0162,
+		0xd7d879450a253e4b,
+		0xf061e22f0ae5c7b5,
+		0xfa5b1fd61c2e7c3d)
 }
@@ -3,11 +3,11 @@
 package persistent
 
 import (
-	context "golang.org/x/net/context"
 	capnp "github.com/iguazio/go-capnproto2"
 	text "github.com/iguazio/go-capnproto2/encoding/text"
 	schemas "github.com/iguazio/go-capnproto2/schemas"
 	server "github.com/iguazio/go-capnproto2/server"
+	context "golang.org/x/net/context"
 )
 
 const PersistentAnnotation = uint64(0xf622595091cafb67)
@@ -44,7 +44,15 @@ type Persistent_Server interface {
 
 func Persistent_ServerToClient(s Persistent_Server) Persistent {
 	c, _ := s.(server.Closer)
-	return Persistent{Client: server.New(Persistent_Methods(nil, s), c)}
+	return Persistent{Client: server.NewTable(Persistent_Table(s), c)}
+}
+
+// Persistent_Table returns the methods of s grouped by interface, for server.NewTable.
+func Persistent_Table(s Persistent_Server) server.Table {
+	methods := Persistent_Methods(nil, s)
+	return server.Table{
+		{ID: 0xc8cb212fcd9f5691, Methods: methods[0:1:1]},
+	}
 }
 
 func Persistent_Methods(methods []server.Method, s Persistent_Server) []server.Method {
@@ -92,11 +100,28 @@ func NewRootPersistent_SaveParams(s *capnp.Segment) (Persistent_SaveParams, erro
 	return Persistent_SaveParams{st}, err
 }
 
+func AllocateRootPersistent_SaveParams(msg *capnp.Message) (Persistent_SaveParams, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Persistent_SaveParams{st}, err
+}
+
 func ReadRootPersistent_SaveParams(msg *capnp.Message) (Persistent_SaveParams, error) {
 	root, err := msg.RootPtr()
 	return Persistent_SaveParams{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Persistent_SaveParams) Equal(other Persistent_SaveParams) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Persistent_SaveParams) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Persistent_SaveParams) String() string {
 	str, _ := text.Marshal(0xf76fba59183073a5, s.Struct)
 	return str
@@ -132,6 +157,13 @@ func NewPersistent_SaveParams_List(s *capnp.Segment, sz int32) (Persistent_SaveP
 	return Persistent_SaveParams_List{l}, err
 }
 
+// NewRootPersistent_SaveParamsListMessage creates a message whose root is a new
+// list of n Persistent_SaveParams.  The message's segment is sized to fit the list.
+func NewRootPersistent_SaveParamsListMessage(n int32) (*capnp.Message, Persistent_SaveParams_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, Persistent_SaveParams_List{l}, err
+}
+
 func (s Persistent_SaveParams_List) At(i int) Persistent_SaveParams {
 	return Persistent_SaveParams{s.List.Struct(i)}
 }
@@ -149,8 +181,7 @@ func (s Persistent_SaveParams_List) String() string {
 type Persistent_SaveParams_Promise struct{ *capnp.Pipeline }
 
 func (p Persistent_SaveParams_Promise) Struct() (Persistent_SaveParams, error) {
-	s, err := p.Pipeline.Struct()
-	return Persistent_SaveParams{s}, err
+	return capnp.PipelineStruct[Persistent_SaveParams](p.Pipeline)
 }
 
 func (p Persistent_SaveParams_Promise) SealFor() *capnp.Pipeline {
@@ -172,11 +203,28 @@ func NewRootPersistent_SaveResults(s *capnp.Segment) (Persistent_SaveResults, er
 	return Persistent_SaveResults{st}, err
 }
 
+func AllocateRootPersistent_SaveResults(msg *capnp.Message) (Persistent_SaveResults, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Persistent_SaveResults{st}, err
+}
+
 func ReadRootPersistent_SaveResults(msg *capnp.Message) (Persistent_SaveResults, error) {
 	root, err := msg.RootPtr()
 	return Persistent_SaveResults{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Persistent_SaveResults) Equal(other Persistent_SaveResults) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Persistent_SaveResults) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Persistent_SaveResults) String() string {
 	str, _ := text.Marshal(0xb76848c18c40efbf, s.Struct)
 	return str
@@ -212,6 +260,13 @@ func NewPersistent_SaveResults_List(s *capnp.Segment, sz int32) (Persistent_Save
 	return Persistent_SaveResults_List{l}, err
 }
 
+// NewRootPersistent_SaveResultsListMessage creates a message whose root is a new
+// list of n Persistent_SaveResults.  The message's segment is sized to fit the list.
+func NewRootPersistent_SaveResultsListMessage(n int32) (*capnp.Message, Persistent_SaveResults_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, Persistent_SaveResults_List{l}, err
+}
+
 func (s Persistent_SaveResults_List) At(i int) Persistent_SaveResults {
 	return Persistent_SaveResults{s.List.Struct(i)}
 }
@@ -229,8 +284,7 @@ func (s Persistent_SaveResults_List) String() string {
 type Persistent_SaveResults_Promise struct{ *capnp.Pipeline }
 
 func (p Persistent_SaveResults_Promise) Struct() (Persistent_SaveResults, error) {
-	s, err := p.Pipeline.Struct()
-	return Persistent_SaveResults{s}, err
+	return capnp.PipelineStruct[Persistent_SaveResults](p.Pipeline)
 }
 
 func (p Persistent_SaveResults_Promise) SturdyRef() *capnp.Pipeline {
@@ -291,7 +345,15 @@ type RealmGateway_Server interface {
 
 func RealmGateway_ServerToClient(s RealmGateway_Server) RealmGateway {
 	c, _ := s.(server.Closer)
-	return RealmGateway{Client: server.New(RealmGateway_Methods(nil, s), c)}
+	return RealmGateway{Client: server.NewTable(RealmGateway_Table(s), c)}
+}
+
+// RealmGateway_Table returns the methods of s grouped by interface, for server.NewTable.
+func RealmGateway_Table(s RealmGateway_Server) server.Table {
+	methods := RealmGateway_Methods(nil, s)
+	return server.Table{
+		{ID: 0x84ff286cd00a3ed4, Methods: methods[0:2:2]},
+	}
 }
 
 func RealmGateway_Methods(methods []server.Method, s RealmGateway_Server) []server.Method {
@@ -361,11 +423,28 @@ func NewRootRealmGateway_import_Params(s *capnp.Segment) (RealmGateway_import_Pa
 	return RealmGateway_import_Params{st}, err
 }
 
+func AllocateRootRealmGateway_import_Params(msg *capnp.Message) (RealmGateway_import_Params, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return RealmGateway_import_Params{st}, err
+}
+
 func ReadRootRealmGateway_import_Params(msg *capnp.Message) (RealmGateway_import_Params, error) {
 	root, err := msg.RootPtr()
 	return RealmGateway_import_Params{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s RealmGateway_import_Params) Equal(other RealmGateway_import_Params) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s RealmGateway_import_Params) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s RealmGateway_import_Params) String() string {
 	str, _ := text.Marshal(0xf0c2cc1d3909574d, s.Struct)
 	return str
@@ -385,7 +464,7 @@ func (s RealmGateway_import_Params) SetCap(v Persistent) error {
 	if v.Client == nil {
 		return s.Struct.SetPtr(0, capnp.Ptr{})
 	}
-	seg := s.Segment()
+	seg := s.Struct.Segment()
 	in := capnp.NewInterface(seg, seg.Message().AddCap(v.Client))
 	return s.Struct.SetPtr(0, in.ToPtr())
 }
@@ -424,6 +503,13 @@ func NewRealmGateway_import_Params_List(s *capnp.Segment, sz int32) (RealmGatewa
 	return RealmGateway_import_Params_List{l}, err
 }
 
+// NewRootRealmGateway_import_ParamsListMessage creates a message whose root is a new
+// list of n RealmGateway_import_Params.  The message's segment is sized to fit the list.
+func NewRootRealmGateway_import_ParamsListMessage(n int32) (*capnp.Message, RealmGateway_import_Params_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 2}, n)
+	return msg, RealmGateway_import_Params_List{l}, err
+}
+
 func (s RealmGateway_import_Params_List) At(i int) RealmGateway_import_Params {
 	return RealmGateway_import_Params{s.List.Struct(i)}
 }
@@ -441,8 +527,7 @@ func (s RealmGateway_import_Params_List) String() string {
 type RealmGateway_import_Params_Promise struct{ *capnp.Pipeline }
 
 func (p RealmGateway_import_Params_Promise) Struct() (RealmGateway_import_Params, error) {
-	s, err := p.Pipeline.Struct()
-	return RealmGateway_import_Params{s}, err
+	return capnp.PipelineStruct[RealmGateway_import_Params](p.Pipeline)
 }
 
 func (p RealmGateway_import_Params_Promise) Cap() Persistent {
@@ -468,11 +553,28 @@ func NewRootRealmGateway_export_Params(s *capnp.Segment) (RealmGateway_export_Pa
 	return RealmGateway_export_Params{st}, err
 }
 
+func AllocateRootRealmGateway_export_Params(msg *capnp.Message) (RealmGateway_export_Params, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return RealmGateway_export_Params{st}, err
+}
+
 func ReadRootRealmGateway_export_Params(msg *capnp.Message) (RealmGateway_export_Params, error) {
 	root, err := msg.RootPtr()
 	return RealmGateway_export_Params{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s RealmGateway_export_Params) Equal(other RealmGateway_export_Params) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s RealmGateway_export_Params) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s RealmGateway_export_Params) String() string {
 	str, _ := text.Marshal(0xecafa18b482da3aa, s.Struct)
 	return str
@@ -492,7 +594,7 @@ func (s RealmGateway_export_Params) SetCap(v Persistent) error {
 	if v.Client == nil {
 		return s.Struct.SetPtr(0, capnp.Ptr{})
 	}
-	seg := s.Segment()
+	seg := s.Struct.Segment()
 	in := capnp.NewInterface(seg, seg.Message().AddCap(v.Client))
 	return s.Struct.SetPtr(0, in.ToPtr())
 }
@@ -531,6 +633,13 @@ func NewRealmGateway_export_Params_List(s *capnp.Segment, sz int32) (RealmGatewa
 	return RealmGateway_export_Params_List{l}, err
 }
 
+// NewRootRealmGateway_export_ParamsListMessage creates a message whose root is a new
+// list of n RealmGateway_export_Params.  The message's segment is sized to fit the list.
+func NewRootRealmGateway_export_ParamsListMessage(n int32) (*capnp.Message, RealmGateway_export_Params_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 2}, n)
+	return msg, RealmGateway_export_Params_List{l}, err
+}
+
 func (s RealmGateway_export_Params_List) At(i int) RealmGateway_export_Params {
 	return RealmGateway_export_Params{s.List.Struct(i)}
 }
@@ -548,8 +657,7 @@ func (s RealmGateway_export_Params_List) String() string {
 type RealmGateway_export_Params_Promise struct{ *capnp.Pipeline }
 
 func (p RealmGateway_export_Params_Promise) Struct() (RealmGateway_export_Params, error) {
-	s, err := p.Pipeline.Struct()
-	return RealmGateway_export_Params{s}, err
+	return capnp.PipelineStruct[RealmGateway_export_Params](p.Pipeline)
 }
 
 func (p RealmGateway_export_Params_Promise) Cap() Persistent {
@@ -560,53 +668,54 @@ func (p RealmGateway_export_Params_Promise) Params() Persistent_SaveParams_Promi
 	return Persistent_SaveParams_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
 }
 
-const schema_b8630836983feed7 = "x\xda\xbcSKh3U\x14>\xdf\xdcy$\xd2\x9f" +
-	"?7\xf3\x97Vh\x8d--\xb5b_\x0ab\x8b\x98" +
-	"L\xa1\xb6]\xa8\x99\x14\x94\x0a\x827u\xaa\x85d2" +
-	"\xccL_\x0bQA\x10\xd1.\xbas'\"n\x0a\xe2" +
-	"R\xebF,\x0a\xe2\x03\x14\x94\xee\xbb\x16\xad.t\xe1" +
-	"\xe2\xca\x9d\xbc\xc66\xa5H\xc5\xcd\xc0\x9c{\xee\xfd\xce" +
-	"\xf9\x1e\xb3\xe7(is\xc6\xa9N\xe4>b\x98\xf2\xc7" +
-	"\xc7\xee\xfa\xbev\x9f|\x9d8g\xf2\xf4\x97\xe2;\x0f" +
-	"g6>!\xa2\x1clW\xfb\xdd~N\xb3\x88\xecu" +
-	"\xed\x0d[0\xcb\x16lB~\xf6k\xe9\xed\xcfW^" +
-	"\xfa\x98\xf8\x10\xe4\xe1\xd3\xef~73\xf2\xcdWd\xc0" +
-	"\xca\xe1\xa1\x97\xd9\"\xec\x03\xa6\xae\xbc\xc9\x8a\x94:\xbf" +
-	"\xfc\xfa\x87\xec\xcc>f\x13D\xf6Ol\xd9\x1e\xd7-" +
-	"{\\\x1f\x90G\xefO\xad\xbc\xf5\xdeG?\x13\xbf\x07" +
-	"D\x86\xa6^\x1d\xd7\xab \xd8s\xfa.A>\xf1L" +
-	"v~\xf8\xdb\x93\xf3t\xc3\xb0\x914L\x1a\xaa\xe1\xc5" +
-	"\xbf\xbe>,\xaf\x8f\xfeA?p\xe3^\xa4@a\xf7" +
-	"\x9bg\xf6\x88i\xd9#fam\xc5d \xc8\x0f\xa2" +
-	"\xd9\xc1\xf5O\x1b\x7f\xf6\xdaf\xde\\\x80\xbdj\xaam" +
-	"\x96\xcc\"=)\x03/\x8c\xb6\xa2\xd8\xd3\xfcxzC" +
-	"\x04~\xb0P\xf1D\xad\xbe,\x0a\xb1\xb7+\xf6\xcb\x80" +
-	"\x9ba\x06QgH\xb4\xc9\xe2s\x0bD\xce\x03p\x1e" +
-	"\x05\x7f\xcd\x02:{v;\xb6UG\x00\xe7U\xf0/" +
-	"\xad\xe2V=h\x841G\xc1\xd55tE\x02\x88\xda" +
-	"\xc5\xce\xb4\xaa4\xe1f\x80\x04_}s\x0c\xc8#u" +
-	"\x8f\x08y\x03Z\xbaPB\xd1\xdb\xbb1\x88q\x19\x85" +
-	"\xfd\x13\xa5\x0c89\xf0[U\xce\xab\xbc?\xe4w\x87" +
-	"r\xd5\x8f\xbd\xd0\x175\xb2*\xde\xa6\\\xdaK\xffu" +
-	"\xce\x0aO\xed\xfa^\xd8=m\xfd\xb75\xd0;\x1a\x94" +
-	"[\x15?\x9e^\x13;^\xc5\x8b\xb6kqDJ\x0d" +
-	"\x9d\xe9D\xbaZ\xe7V\x85\xc8\xedcp\x075\xc8(" +
-	"\xde\x0e_\xd8\xafx\x84\xcd\x84\xa6\xd4\x92\xc8\x13z\xe8" +
-	"\xdc\xc2\xb0<?V\x1c\xa4l\x93}6\x95\x88lU" +
-	"\xaa\x11\xca\"\x14\xc4\xea\x91l\xcfCV-\x8e\\=" +
-	"\xf1F\xfbjWy~?\x91\xd3\x07g\x08|\xca\xba" +
-	"\x1d\x89\x1d\xef\x12\xfb=%Q\xc5&\xbf\x19p\xa3\xc2" +
-	"\xb3\x0f\xca\xb5\xeefW\xf2\xd5\xf2lb\xd9\xe9\xa6\x05" +
-	"\xc6\xca\"\xb4D=r3\x1d\xc2&G\x89\xdc1\x06" +
-	"wO\x03\x07\xee$\x13(\x8f\xba\x01\x83\xfb\x85\x06k" +
-	"C\x04\xe0i\xeeJ\xf8\x8f\xac\x09N(\x06\"\x14\xf5" +
-	"\x08\xb9.\xd7\xff\x06\xe1:_\"\x97\xd2\xf9\x0an\x9a" +
-	"\x19\xfc\xbf\xb9\xb9v\xf2\x9b\x93s\x1d\xfd\xb9\x9e\x19\x08" +
-	"\xba\x19H\xb2\x05M\xfev03\x90\x7f\xfe\xf8\x84\x14" +
-	"\xac3\x08\xf4)\xc4#\xd9\x8e$\xfc\xd8\xf1\xfdF," +
-	"n\xc7[\x0d\x9f\xf4\xce\xab\xec\xaa\xf4\x16\xcb\xc9f\x17" +
-	"\xc2\xbbH\xa4\xe6w\xefhx%\xf2D\xed\xf1F\xd8" +
-	"$\xeaBr\xff\x0e\x00\x00\xff\xff\x1ar\xf0\xc2"
+const schema_b8630836983feed7 = "x\xda\xc4SKh+U\x18\xfe\xbf\x99\x8c\x93HK" +
+	"s2-\xadPM2\x1d\xa9\x8a})\x08\x161\x99" +
+	"Bm]\x88\x99\x14\x94\xba\xf2\xa4N5\x90L\x86\x99" +
+	"\xe9k\xa5\x82\xe0B]t#\xeeD\xc4MA\\\x89" +
+	"V(\xd2\xa2\xe0\x13,\x08\xae\x04\xedN\x10\xb5\xa2\xf7" +
+	"R\xee\xe2\\f\x9a\xc7\xb4M\x09\x97^\xb8\x04\x02\xf3" +
+	"\x9f\xff\x9c\xef\xff\xbf\xc7\xf4\xbb\xa9\xa24\xa3\xa8sD" +
+	"eY\xb9K\xfc\xfc\xe4\xdd?\xd5\x1e\x10o\x10c\xb2" +
+	"\xf8\xe5\xaf\xc2{\x8f%W>'\xa24\xb4?r\xff" +
+	"j\xff\xe7T\"\xed8\xf7\xa6v\x92W\xb5\x93\xfc\xb8" +
+	"\xf8\xf2\xef\xe2\xdb\xfb\x8b\xaf|Fl\x14b\xfb\xb9\xf7" +
+	"\x7f\x9c\xca\x7f\xff\x0d)P\xd3xtH\x9f\x83v\xbf" +
+	"\x1e^\xc9\xeb\x05\x8a\x9d_|}^?\xd2,}\x9c" +
+	"H\xab\xeb\x0b\xda\x9e\xaej{\xfa\xb0\xd8\xf9pb\xf1" +
+	"\xad\x0f>\xf9\x93\xd8} R\xa4\xf0\xd5=\xbd\x02\x82" +
+	"\xf6\xad\xbeA\x10\xcf<\x9fz\xfc\xde\x1f\x0e\xfe\x897" +
+	"|:\x165\xec\x8f\x85\x0d/\xdf\xf8n\xbb\xb4\xac_" +
+	"\xa3C\xa6\xe4\x10\x03\x85\xf6\xb1q\xa4\xed\x1a\xaa\xb6k" +
+	"d\x97~3d\x10\xc4G\xfe\xf4\xc8\xf2\x17\x8d\xeb\xdd" +
+	"\xb694f\xa1\xfdn\x84\xdb\xfcj\x14H\x9c\xf9\xfd" +
+	"'\\\xdb\xf3\xab~`KN0\xb9\xc2]\xc7\x9d-" +
+	"\xdb\xbcV_\xe0\xd9\xc0\xde\xe0[%\xc0J\xca\x0aQ" +
+	"{d\xb4\xa8c3\xb3D\xe6\xc30\x9f\x00{]\x05" +
+	"\xda[w:\xd6\xc2\x0e\x17\xe6k`_\xab\x85j\xdd" +
+	"mx\x01C\xd6JH\xe8H\x06\x10\xb5\x8a\xed\xd9\xc3" +
+	"\xd2\xb8\x95\x04\"\xfc\xf0?-\x03\x19\xc4\xee\x11!\xa3" +
+	"@\x8a\x17\x8a(\xd8\x9bW\x06Q.\xa2\xc8gQJ" +
+	"\x80\x99\x06\xeb\xaf0VaC\x1e\xbb\xc7\x13O;\x81" +
+	"\xed9\xbcFj\xd9^\x15\xf3\x9b\xf1\xaf\xf6Y\xf6\xd9" +
+	"\x0d\xc7\xf6:\xa7\xcd\xef\x96\x06\x89\xb6\x06\xa5f\xc5\x09" +
+	"&\x97\xf8\xba]\xb6\xfd\xb5Z\xe0S\xa8FBN\x10" +
+	"%\xc2u\xfa\xcbDV\x9f\x0ckD\x82\xf0\x835\xef" +
+	"\xa5\xad\xb2MX\x8dh\x8a-\x89\x0c\xa1\x8b\xceM\x0c" +
+	"\xd5v\x82\x90\x83\x98\x89R/\xc4\xf2\x91\xaa\x88p\x84" +
+	"\x12\xf78\xc9u_\xb4\xe6!\xb5\x16\xf8V\"\xf2F" +
+	"\xebjGy\xf6\x10\x91\xd9\x07s\x14lB\x1d\xf0\xf9" +
+	"\xba}\x81\xfd\xae\x92\x00-~\x93`J\x99\xa5\x1e\x11" +
+	"K\x9d\xcd.\xe5\xab\xe9\xd9\xc8\xb2\x93\xa7\x160J\xdc" +
+	"Sy\xdd\xb7\x92m\xc2\x1e\xd4\x89,C\x86\xb5)\x81" +
+	"\x01\x83\xd1\x04\xa1G-W\x86\xf5\x95\x04u\x85\xbb`" +
+	"q\xee\x8a\xb8M\xd6\x04#\x14\\\xee\xf1\xba\x8ft\x87" +
+	"\xeb[A\xe8\xe5K\xa4\x09\xbd\xb8\xa9\xd6\xef\x047=" +
+	"'\xbf:9\xbd\xe8Ow\xcd\x80\xdb\xc9@\x94-H" +
+	"\xe2\xf8\x9d\xa9\xe1\xcc\x8b\xbb\x07\x14\xc2\x9a#@_\x88" +
+	"\xb8#Z\x91\x84\x13\x98\x8e\xd3\x08\xf8@Pm8\x94" +
+	"h\xbf*_\x96\xdeB)\xda\xec\\x\xe7\x88\xc2\xf9" +
+	"\xadA\x09\xaf\xfa6\xaf=\xd5\xf0N\x89:\x97\xdc\x9b" +
+	"\x03\x00\xb3\xd2\x02n"
 
 func init() {
 	schemas.Register(schema_b8630836983feed7,
@@ -3,10 +3,10 @@
 package rpc
 
 import (
-	strconv "strconv"
 	capnp "github.com/iguazio/go-capnproto2"
 	text "github.com/iguazio/go-capnproto2/encoding/text"
 	schemas "github.com/iguazio/go-capnproto2/schemas"
+	strconv "strconv"
 )
 
 type Message struct{ capnp.Struct }
@@ -78,11 +78,28 @@ func NewRootMessage(s *capnp.Segment) (Message, error) {
 	return Message{st}, err
 }
 
+func AllocateRootMessage(msg *capnp.Message) (Message, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return Message{st}, err
+}
+
 func ReadRootMessage(msg *capnp.Message) (Message, error) {
 	root, err := msg.RootPtr()
 	return Message{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Message) Equal(other Message) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Message) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Message) String() string {
 	str, _ := text.Marshal(0x91b79f1f808db032, s.Struct)
 	return str
@@ -91,9 +108,19 @@ func (s Message) String() string {
 func (s Message) Which() Message_Which {
 	return Message_Which(s.Struct.Uint16(0))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s Message) ResetUnion() error {
+	s.Struct.SetUint16(0, 0)
+	if err := s.Struct.ClearPtr(0); err != nil {
+		return err
+	}
+	return nil
+}
 func (s Message) Unimplemented() (Message, error) {
 	if s.Struct.Uint16(0) != 0 {
-		panic("Which() != unimplemented")
+		capnp.UnionMismatch(s.Struct, "unimplemented")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Message{Struct: p.Struct()}, err
@@ -126,7 +153,7 @@ func (s Message) NewUnimplemented() (Message, error) {
 
 func (s Message) Abort() (Exception, error) {
 	if s.Struct.Uint16(0) != 1 {
-		panic("Which() != abort")
+		capnp.UnionMismatch(s.Struct, "abort")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Exception{Struct: p.Struct()}, err
@@ -159,7 +186,7 @@ func (s Message) NewAbort() (Exception, error) {
 
 func (s Message) Bootstrap() (Bootstrap, error) {
 	if s.Struct.Uint16(0) != 8 {
-		panic("Which() != bootstrap")
+		capnp.UnionMismatch(s.Struct, "bootstrap")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Bootstrap{Struct: p.Struct()}, err
@@ -192,7 +219,7 @@ func (s Message) NewBootstrap() (Bootstrap, error) {
 
 func (s Message) Call() (Call, error) {
 	if s.Struct.Uint16(0) != 2 {
-		panic("Which() != call")
+		capnp.UnionMismatch(s.Struct, "call")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Call{Struct: p.Struct()}, err
@@ -225,7 +252,7 @@ func (s Message) NewCall() (Call, error) {
 
 func (s Message) Return() (Return, error) {
 	if s.Struct.Uint16(0) != 3 {
-		panic("Which() != return")
+		capnp.UnionMismatch(s.Struct, "return")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Return{Struct: p.Struct()}, err
@@ -258,7 +285,7 @@ func (s Message) NewReturn() (Return, error) {
 
 func (s Message) Finish() (Finish, error) {
 	if s.Struct.Uint16(0) != 4 {
-		panic("Which() != finish")
+		capnp.UnionMismatch(s.Struct, "finish")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Finish{Struct: p.Struct()}, err
@@ -291,7 +318,7 @@ func (s Message) NewFinish() (Finish, error) {
 
 func (s Message) Resolve() (Resolve, error) {
 	if s.Struct.Uint16(0) != 5 {
-		panic("Which() != resolve")
+		capnp.UnionMismatch(s.Struct, "resolve")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Resolve{Struct: p.Struct()}, err
@@ -324,7 +351,7 @@ func (s Message) NewResolve() (Resolve, error) {
 
 func (s Message) Release() (Release, error) {
 	if s.Struct.Uint16(0) != 6 {
-		panic("Which() != release")
+		capnp.UnionMismatch(s.Struct, "release")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Release{Struct: p.Struct()}, err
@@ -357,7 +384,7 @@ func (s Message) NewRelease() (Release, error) {
 
 func (s Message) Disembargo() (Disembargo, error) {
 	if s.Struct.Uint16(0) != 13 {
-		panic("Which() != disembargo")
+		capnp.UnionMismatch(s.Struct, "disembargo")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Disembargo{Struct: p.Struct()}, err
@@ -390,7 +417,7 @@ func (s Message) NewDisembargo() (Disembargo, error) {
 
 func (s Message) ObsoleteSave() (capnp.Pointer, error) {
 	if s.Struct.Uint16(0) != 7 {
-		panic("Which() != obsoleteSave")
+		capnp.UnionMismatch(s.Struct, "obsoleteSave")
 	}
 	return s.Struct.Pointer(0)
 }
@@ -419,7 +446,7 @@ func (s Message) SetObsoleteSavePtr(v capnp.Ptr) error {
 
 func (s Message) ObsoleteDelete() (capnp.Pointer, error) {
 	if s.Struct.Uint16(0) != 9 {
-		panic("Which() != obsoleteDelete")
+		capnp.UnionMismatch(s.Struct, "obsoleteDelete")
 	}
 	return s.Struct.Pointer(0)
 }
@@ -448,7 +475,7 @@ func (s Message) SetObsoleteDeletePtr(v capnp.Ptr) error {
 
 func (s Message) Provide() (Provide, error) {
 	if s.Struct.Uint16(0) != 10 {
-		panic("Which() != provide")
+		capnp.UnionMismatch(s.Struct, "provide")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Provide{Struct: p.Struct()}, err
@@ -481,7 +508,7 @@ func (s Message) NewProvide() (Provide, error) {
 
 func (s Message) Accept() (Accept, error) {
 	if s.Struct.Uint16(0) != 11 {
-		panic("Which() != accept")
+		capnp.UnionMismatch(s.Struct, "accept")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Accept{Struct: p.Struct()}, err
@@ -514,7 +541,7 @@ func (s Message) NewAccept() (Accept, error) {
 
 func (s Message) Join() (Join, error) {
 	if s.Struct.Uint16(0) != 12 {
-		panic("Which() != join")
+		capnp.UnionMismatch(s.Struct, "join")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Join{Struct: p.Struct()}, err
@@ -554,6 +581,13 @@ func NewMessage_List(s *capnp.Segment, sz int32) (Message_List, error) {
 	return Message_List{l}, err
 }
 
+// NewRootMessageListMessage creates a message whose root is a new
+// list of n Message.  The message's segment is sized to fit the list.
+func NewRootMessageListMessage(n int32) (*capnp.Message, Message_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 1}, n)
+	return msg, Message_List{l}, err
+}
+
 func (s Message_List) At(i int) Message { return Message{s.List.Struct(i)} }
 
 func (s Message_List) Set(i int, v Message) error { return s.List.SetStruct(i, v.Struct) }
@@ -567,8 +601,7 @@ func (s Message_List) String() string {
 type Message_Promise struct{ *capnp.Pipeline }
 
 func (p Message_Promise) Struct() (Message, error) {
-	s, err := p.Pipeline.Struct()
-	return Message{s}, err
+	return capnp.PipelineStruct[Message](p.Pipeline)
 }
 
 func (p Message_Promise) Unimplemented() Message_Promise {
@@ -642,11 +675,28 @@ func NewRootBootstrap(s *capnp.Segment) (Bootstrap, error) {
 	return Bootstrap{st}, err
 }
 
+func AllocateRootBootstrap(msg *capnp.Message) (Bootstrap, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return Bootstrap{st}, err
+}
+
 func ReadRootBootstrap(msg *capnp.Message) (Bootstrap, error) {
 	root, err := msg.RootPtr()
 	return Bootstrap{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Bootstrap) Equal(other Bootstrap) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Bootstrap) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Bootstrap) String() string {
 	str, _ := text.Marshal(0xe94ccf8031176ec4, s.Struct)
 	return str
@@ -690,6 +740,13 @@ func NewBootstrap_List(s *capnp.Segment, sz int32) (Bootstrap_List, error) {
 	return Bootstrap_List{l}, err
 }
 
+// NewRootBootstrapListMessage creates a message whose root is a new
+// list of n Bootstrap.  The message's segment is sized to fit the list.
+func NewRootBootstrapListMessage(n int32) (*capnp.Message, Bootstrap_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 1}, n)
+	return msg, Bootstrap_List{l}, err
+}
+
 func (s Bootstrap_List) At(i int) Bootstrap { return Bootstrap{s.List.Struct(i)} }
 
 func (s Bootstrap_List) Set(i int, v Bootstrap) error { return s.List.SetStruct(i, v.Struct) }
@@ -703,8 +760,7 @@ func (s Bootstrap_List) String() string {
 type Bootstrap_Promise struct{ *capnp.Pipeline }
 
 func (p Bootstrap_Promise) Struct() (Bootstrap, error) {
-	s, err := p.Pipeline.Struct()
-	return Bootstrap{s}, err
+	return capnp.PipelineStruct[Bootstrap](p.Pipeline)
 }
 
 func (p Bootstrap_Promise) DeprecatedObjectId() *capnp.Pipeline {
@@ -748,11 +804,28 @@ func NewRootCall(s *capnp.Segment) (Call, error) {
 	return Call{st}, err
 }
 
+func AllocateRootCall(msg *capnp.Message) (Call, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 24, PointerCount: 3})
+	return Call{st}, err
+}
+
 func ReadRootCall(msg *capnp.Message) (Call, error) {
 	root, err := msg.RootPtr()
 	return Call{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Call) Equal(other Call) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Call) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Call) String() string {
 	str, _ := text.Marshal(0x836a53ce789d4cd4, s.Struct)
 	return str
@@ -845,6 +918,16 @@ func (s Call) SendResultsTo() Call_sendResultsTo { return Call_sendResultsTo(s)
 func (s Call_sendResultsTo) Which() Call_sendResultsTo_Which {
 	return Call_sendResultsTo_Which(s.Struct.Uint16(6))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s Call_sendResultsTo) ResetUnion() error {
+	s.Struct.SetUint16(6, 0)
+	if err := s.Struct.ClearPtr(2); err != nil {
+		return err
+	}
+	return nil
+}
 func (s Call_sendResultsTo) SetCaller() {
 	s.Struct.SetUint16(6, 0)
 
@@ -857,7 +940,7 @@ func (s Call_sendResultsTo) SetYourself() {
 
 func (s Call_sendResultsTo) ThirdParty() (capnp.Pointer, error) {
 	if s.Struct.Uint16(6) != 2 {
-		panic("Which() != thirdParty")
+		capnp.UnionMismatch(s.Struct, "thirdParty")
 	}
 	return s.Struct.Pointer(2)
 }
@@ -893,6 +976,13 @@ func NewCall_List(s *capnp.Segment, sz int32) (Call_List, error) {
 	return Call_List{l}, err
 }
 
+// NewRootCallListMessage creates a message whose root is a new
+// list of n Call.  The message's segment is sized to fit the list.
+func NewRootCallListMessage(n int32) (*capnp.Message, Call_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 24, PointerCount: 3}, n)
+	return msg, Call_List{l}, err
+}
+
 func (s Call_List) At(i int) Call { return Call{s.List.Struct(i)} }
 
 func (s Call_List) Set(i int, v Call) error { return s.List.SetStruct(i, v.Struct) }
@@ -906,8 +996,7 @@ func (s Call_List) String() string {
 type Call_Promise struct{ *capnp.Pipeline }
 
 func (p Call_Promise) Struct() (Call, error) {
-	s, err := p.Pipeline.Struct()
-	return Call{s}, err
+	return capnp.PipelineStruct[Call](p.Pipeline)
 }
 
 func (p Call_Promise) Target() MessageTarget_Promise {
@@ -926,8 +1015,7 @@ func (p Call_Promise) SendResultsTo() Call_sendResultsTo_Promise {
 type Call_sendResultsTo_Promise struct{ *capnp.Pipeline }
 
 func (p Call_sendResultsTo_Promise) Struct() (Call_sendResultsTo, error) {
-	s, err := p.Pipeline.Struct()
-	return Call_sendResultsTo{s}, err
+	return capnp.PipelineStruct[Call_sendResultsTo](p.Pipeline)
 }
 
 func (p Call_sendResultsTo_Promise) ThirdParty() *capnp.Pipeline {
@@ -979,11 +1067,28 @@ func NewRootReturn(s *capnp.Segment) (Return, error) {
 	return Return{st}, err
 }
 
+func AllocateRootReturn(msg *capnp.Message) (Return, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return Return{st}, err
+}
+
 func ReadRootReturn(msg *capnp.Message) (Return, error) {
 	root, err := msg.RootPtr()
 	return Return{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Return) Equal(other Return) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Return) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Return) String() string {
 	str, _ := text.Marshal(0x9e19b28d3db3573a, s.Struct)
 	return str
@@ -992,6 +1097,17 @@ func (s Return) String() string {
 func (s Return) Which() Return_Which {
 	return Return_Which(s.Struct.Uint16(6))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s Return) ResetUnion() error {
+	s.Struct.SetUint16(6, 0)
+	s.Struct.SetUint32(8, 0)
+	if err := s.Struct.ClearPtr(0); err != nil {
+		return err
+	}
+	return nil
+}
 func (s Return) AnswerId() uint32 {
 	return s.Struct.Uint32(0)
 }
@@ -1010,7 +1126,7 @@ func (s Return) SetReleaseParamCaps(v bool) {
 
 func (s Return) Results() (Payload, error) {
 	if s.Struct.Uint16(6) != 0 {
-		panic("Which() != results")
+		capnp.UnionMismatch(s.Struct, "results")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Payload{Struct: p.Struct()}, err
@@ -1043,7 +1159,7 @@ func (s Return) NewResults() (Payload, error) {
 
 func (s Return) Exception() (Exception, error) {
 	if s.Struct.Uint16(6) != 1 {
-		panic("Which() != exception")
+		capnp.UnionMismatch(s.Struct, "exception")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Exception{Struct: p.Struct()}, err
@@ -1086,7 +1202,7 @@ func (s Return) SetResultsSentElsewhere() {
 
 func (s Return) TakeFromOtherQuestion() uint32 {
 	if s.Struct.Uint16(6) != 4 {
-		panic("Which() != takeFromOtherQuestion")
+		capnp.UnionMismatch(s.Struct, "takeFromOtherQuestion")
 	}
 	return s.Struct.Uint32(8)
 }
@@ -1098,7 +1214,7 @@ func (s Return) SetTakeFromOtherQuestion(v uint32) {
 
 func (s Return) AcceptFromThirdParty() (capnp.Pointer, error) {
 	if s.Struct.Uint16(6) != 5 {
-		panic("Which() != acceptFromThirdParty")
+		capnp.UnionMismatch(s.Struct, "acceptFromThirdParty")
 	}
 	return s.Struct.Pointer(0)
 }
@@ -1134,6 +1250,13 @@ func NewReturn_List(s *capnp.Segment, sz int32) (Return_List, error) {
 	return Return_List{l}, err
 }
 
+// NewRootReturnListMessage creates a message whose root is a new
+// list of n Return.  The message's segment is sized to fit the list.
+func NewRootReturnListMessage(n int32) (*capnp.Message, Return_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 16, PointerCount: 1}, n)
+	return msg, Return_List{l}, err
+}
+
 func (s Return_List) At(i int) Return { return Return{s.List.Struct(i)} }
 
 func (s Return_List) Set(i int, v Return) error { return s.List.SetStruct(i, v.Struct) }
@@ -1147,8 +1270,7 @@ func (s Return_List) String() string {
 type Return_Promise struct{ *capnp.Pipeline }
 
 func (p Return_Promise) Struct() (Return, error) {
-	s, err := p.Pipeline.Struct()
-	return Return{s}, err
+	return capnp.PipelineStruct[Return](p.Pipeline)
 }
 
 func (p Return_Promise) Results() Payload_Promise {
@@ -1178,11 +1300,28 @@ func NewRootFinish(s *capnp.Segment) (Finish, error) {
 	return Finish{st}, err
 }
 
+func AllocateRootFinish(msg *capnp.Message) (Finish, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return Finish{st}, err
+}
+
 func ReadRootFinish(msg *capnp.Message) (Finish, error) {
 	root, err := msg.RootPtr()
 	return Finish{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Finish) Equal(other Finish) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Finish) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Finish) String() string {
 	str, _ := text.Marshal(0xd37d2eb2c2f80e63, s.Struct)
 	return str
@@ -1213,6 +1352,13 @@ func NewFinish_List(s *capnp.Segment, sz int32) (Finish_List, error) {
 	return Finish_List{l}, err
 }
 
+// NewRootFinishListMessage creates a message whose root is a new
+// list of n Finish.  The message's segment is sized to fit the list.
+func NewRootFinishListMessage(n int32) (*capnp.Message, Finish_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 0}, n)
+	return msg, Finish_List{l}, err
+}
+
 func (s Finish_List) At(i int) Finish { return Finish{s.List.Struct(i)} }
 
 func (s Finish_List) Set(i int, v Finish) error { return s.List.SetStruct(i, v.Struct) }
@@ -1226,8 +1372,7 @@ func (s Finish_List) String() string {
 type Finish_Promise struct{ *capnp.Pipeline }
 
 func (p Finish_Promise) Struct() (Finish, error) {
-	s, err := p.Pipeline.Struct()
-	return Finish{s}, err
+	return capnp.PipelineStruct[Finish](p.Pipeline)
 }
 
 type Resolve struct{ capnp.Struct }
@@ -1263,11 +1408,28 @@ func NewRootResolve(s *capnp.Segment) (Resolve, error) {
 	return Resolve{st}, err
 }
 
+func AllocateRootResolve(msg *capnp.Message) (Resolve, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return Resolve{st}, err
+}
+
 func ReadRootResolve(msg *capnp.Message) (Resolve, error) {
 	root, err := msg.RootPtr()
 	return Resolve{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Resolve) Equal(other Resolve) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Resolve) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Resolve) String() string {
 	str, _ := text.Marshal(0xbbc29655fa89086e, s.Struct)
 	return str
@@ -1276,6 +1438,16 @@ func (s Resolve) String() string {
 func (s Resolve) Which() Resolve_Which {
 	return Resolve_Which(s.Struct.Uint16(4))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s Resolve) ResetUnion() error {
+	s.Struct.SetUint16(4, 0)
+	if err := s.Struct.ClearPtr(0); err != nil {
+		return err
+	}
+	return nil
+}
 func (s Resolve) PromiseId() uint32 {
 	return s.Struct.Uint32(0)
 }
@@ -1286,7 +1458,7 @@ func (s Resolve) SetPromiseId(v uint32) {
 
 func (s Resolve) Cap() (CapDescriptor, error) {
 	if s.Struct.Uint16(4) != 0 {
-		panic("Which() != cap")
+		capnp.UnionMismatch(s.Struct, "cap")
 	}
 	p, err := s.Struct.Ptr(0)
 	return CapDescriptor{Struct: p.Struct()}, err
@@ -1319,7 +1491,7 @@ func (s Resolve) NewCap() (CapDescriptor, error) {
 
 func (s Resolve) Exception() (Exception, error) {
 	if s.Struct.Uint16(4) != 1 {
-		panic("Which() != exception")
+		capnp.UnionMismatch(s.Struct, "exception")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Exception{Struct: p.Struct()}, err
@@ -1359,6 +1531,13 @@ func NewResolve_List(s *capnp.Segment, sz int32) (Resolve_List, error) {
 	return Resolve_List{l}, err
 }
 
+// NewRootResolveListMessage creates a message whose root is a new
+// list of n Resolve.  The message's segment is sized to fit the list.
+func NewRootResolveListMessage(n int32) (*capnp.Message, Resolve_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 1}, n)
+	return msg, Resolve_List{l}, err
+}
+
 func (s Resolve_List) At(i int) Resolve { return Resolve{s.List.Struct(i)} }
 
 func (s Resolve_List) Set(i int, v Resolve) error { return s.List.SetStruct(i, v.Struct) }
@@ -1372,8 +1551,7 @@ func (s Resolve_List) String() string {
 type Resolve_Promise struct{ *capnp.Pipeline }
 
 func (p Resolve_Promise) Struct() (Resolve, error) {
-	s, err := p.Pipeline.Struct()
-	return Resolve{s}, err
+	return capnp.PipelineStruct[Resolve](p.Pipeline)
 }
 
 func (p Resolve_Promise) Cap() CapDescriptor_Promise {
@@ -1399,11 +1577,28 @@ func NewRootRelease(s *capnp.Segment) (Release, error) {
 	return Release{st}, err
 }
 
+func AllocateRootRelease(msg *capnp.Message) (Release, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return Release{st}, err
+}
+
 func ReadRootRelease(msg *capnp.Message) (Release, error) {
 	root, err := msg.RootPtr()
 	return Release{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Release) Equal(other Release) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Release) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Release) String() string {
 	str, _ := text.Marshal(0xad1a6c0d7dd07497, s.Struct)
 	return str
@@ -1434,6 +1629,13 @@ func NewRelease_List(s *capnp.Segment, sz int32) (Release_List, error) {
 	return Release_List{l}, err
 }
 
+// NewRootReleaseListMessage creates a message whose root is a new
+// list of n Release.  The message's segment is sized to fit the list.
+func NewRootReleaseListMessage(n int32) (*capnp.Message, Release_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 0}, n)
+	return msg, Release_List{l}, err
+}
+
 func (s Release_List) At(i int) Release { return Release{s.List.Struct(i)} }
 
 func (s Release_List) Set(i int, v Release) error { return s.List.SetStruct(i, v.Struct) }
@@ -1447,8 +1649,7 @@ func (s Release_List) String() string {
 type Release_Promise struct{ *capnp.Pipeline }
 
 func (p Release_Promise) Struct() (Release, error) {
-	s, err := p.Pipeline.Struct()
-	return Release{s}, err
+	return capnp.PipelineStruct[Release](p.Pipeline)
 }
 
 type Disembargo struct{ capnp.Struct }
@@ -1491,11 +1692,28 @@ func NewRootDisembargo(s *capnp.Segment) (Disembargo, error) {
 	return Disembargo{st}, err
 }
 
+func AllocateRootDisembargo(msg *capnp.Message) (Disembargo, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return Disembargo{st}, err
+}
+
 func ReadRootDisembargo(msg *capnp.Message) (Disembargo, error) {
 	root, err := msg.RootPtr()
 	return Disembargo{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Disembargo) Equal(other Disembargo) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Disembargo) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Disembargo) String() string {
 	str, _ := text.Marshal(0xf964368b0fbd3711, s.Struct)
 	return str
@@ -1531,9 +1749,17 @@ func (s Disembargo) Context() Disembargo_context { return Disembargo_context(s)
 func (s Disembargo_context) Which() Disembargo_context_Which {
 	return Disembargo_context_Which(s.Struct.Uint16(4))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s Disembargo_context) ResetUnion() error {
+	s.Struct.SetUint16(4, 0)
+	s.Struct.SetUint32(0, 0)
+	return nil
+}
 func (s Disembargo_context) SenderLoopback() uint32 {
 	if s.Struct.Uint16(4) != 0 {
-		panic("Which() != senderLoopback")
+		capnp.UnionMismatch(s.Struct, "senderLoopback")
 	}
 	return s.Struct.Uint32(0)
 }
@@ -1545,7 +1771,7 @@ func (s Disembargo_context) SetSenderLoopback(v uint32) {
 
 func (s Disembargo_context) ReceiverLoopback() uint32 {
 	if s.Struct.Uint16(4) != 1 {
-		panic("Which() != receiverLoopback")
+		capnp.UnionMismatch(s.Struct, "receiverLoopback")
 	}
 	return s.Struct.Uint32(0)
 }
@@ -1562,7 +1788,7 @@ func (s Disembargo_context) SetAccept() {
 
 func (s Disembargo_context) Provide() uint32 {
 	if s.Struct.Uint16(4) != 3 {
-		panic("Which() != provide")
+		capnp.UnionMismatch(s.Struct, "provide")
 	}
 	return s.Struct.Uint32(0)
 }
@@ -1581,6 +1807,13 @@ func NewDisembargo_List(s *capnp.Segment, sz int32) (Disembargo_List, error) {
 	return Disembargo_List{l}, err
 }
 
+// NewRootDisembargoListMessage creates a message whose root is a new
+// list of n Disembargo.  The message's segment is sized to fit the list.
+func NewRootDisembargoListMessage(n int32) (*capnp.Message, Disembargo_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 1}, n)
+	return msg, Disembargo_List{l}, err
+}
+
 func (s Disembargo_List) At(i int) Disembargo { return Disembargo{s.List.Struct(i)} }
 
 func (s Disembargo_List) Set(i int, v Disembargo) error { return s.List.SetStruct(i, v.Struct) }
@@ -1594,8 +1827,7 @@ func (s Disembargo_List) String() string {
 type Disembargo_Promise struct{ *capnp.Pipeline }
 
 func (p Disembargo_Promise) Struct() (Disembargo, error) {
-	s, err := p.Pipeline.Struct()
-	return Disembargo{s}, err
+	return capnp.PipelineStruct[Disembargo](p.Pipeline)
 }
 
 func (p Disembargo_Promise) Target() MessageTarget_Promise {
@@ -1610,8 +1842,7 @@ func (p Disembargo_Promise) Context() Disembargo_context_Promise {
 type Disembargo_context_Promise struct{ *capnp.Pipeline }
 
 func (p Disembargo_context_Promise) Struct() (Disembargo_context, error) {
-	s, err := p.Pipeline.Struct()
-	return Disembargo_context{s}, err
+	return capnp.PipelineStruct[Disembargo_context](p.Pipeline)
 }
 
 type Provide struct{ capnp.Struct }
@@ -1629,11 +1860,28 @@ func NewRootProvide(s *capnp.Segment) (Provide, error) {
 	return Provide{st}, err
 }
 
+func AllocateRootProvide(msg *capnp.Message) (Provide, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return Provide{st}, err
+}
+
 func ReadRootProvide(msg *capnp.Message) (Provide, error) {
 	root, err := msg.RootPtr()
 	return Provide{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Provide) Equal(other Provide) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Provide) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Provide) String() string {
 	str, _ := text.Marshal(0x9c6a046bfbc1ac5a, s.Struct)
 	return str
@@ -1702,6 +1950,13 @@ func NewProvide_List(s *capnp.Segment, sz int32) (Provide_List, error) {
 	return Provide_List{l}, err
 }
 
+// NewRootProvideListMessage creates a message whose root is a new
+// list of n Provide.  The message's segment is sized to fit the list.
+func NewRootProvideListMessage(n int32) (*capnp.Message, Provide_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 2}, n)
+	return msg, Provide_List{l}, err
+}
+
 func (s Provide_List) At(i int) Provide { return Provide{s.List.Struct(i)} }
 
 func (s Provide_List) Set(i int, v Provide) error { return s.List.SetStruct(i, v.Struct) }
@@ -1715,8 +1970,7 @@ func (s Provide_List) String() string {
 type Provide_Promise struct{ *capnp.Pipeline }
 
 func (p Provide_Promise) Struct() (Provide, error) {
-	s, err := p.Pipeline.Struct()
-	return Provide{s}, err
+	return capnp.PipelineStruct[Provide](p.Pipeline)
 }
 
 func (p Provide_Promise) Target() MessageTarget_Promise {
@@ -1742,11 +1996,28 @@ func NewRootAccept(s *capnp.Segment) (Accept, error) {
 	return Accept{st}, err
 }
 
+func AllocateRootAccept(msg *capnp.Message) (Accept, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return Accept{st}, err
+}
+
 func ReadRootAccept(msg *capnp.Message) (Accept, error) {
 	root, err := msg.RootPtr()
 	return Accept{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Accept) Equal(other Accept) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Accept) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Accept) String() string {
 	str, _ := text.Marshal(0xd4c9b56290554016, s.Struct)
 	return str
@@ -1798,6 +2069,13 @@ func NewAccept_List(s *capnp.Segment, sz int32) (Accept_List, error) {
 	return Accept_List{l}, err
 }
 
+// NewRootAcceptListMessage creates a message whose root is a new
+// list of n Accept.  The message's segment is sized to fit the list.
+func NewRootAcceptListMessage(n int32) (*capnp.Message, Accept_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 1}, n)
+	return msg, Accept_List{l}, err
+}
+
 func (s Accept_List) At(i int) Accept { return Accept{s.List.Struct(i)} }
 
 func (s Accept_List) Set(i int, v Accept) error { return s.List.SetStruct(i, v.Struct) }
@@ -1811,8 +2089,7 @@ func (s Accept_List) String() string {
 type Accept_Promise struct{ *capnp.Pipeline }
 
 func (p Accept_Promise) Struct() (Accept, error) {
-	s, err := p.Pipeline.Struct()
-	return Accept{s}, err
+	return capnp.PipelineStruct[Accept](p.Pipeline)
 }
 
 func (p Accept_Promise) Provision() *capnp.Pipeline {
@@ -1834,11 +2111,28 @@ func NewRootJoin(s *capnp.Segment) (Join, error) {
 	return Join{st}, err
 }
 
+func AllocateRootJoin(msg *capnp.Message) (Join, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return Join{st}, err
+}
+
 func ReadRootJoin(msg *capnp.Message) (Join, error) {
 	root, err := msg.RootPtr()
 	return Join{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Join) Equal(other Join) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Join) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Join) String() string {
 	str, _ := text.Marshal(0xfbe1980490e001af, s.Struct)
 	return str
@@ -1907,6 +2201,13 @@ func NewJoin_List(s *capnp.Segment, sz int32) (Join_List, error) {
 	return Join_List{l}, err
 }
 
+// NewRootJoinListMessage creates a message whose root is a new
+// list of n Join.  The message's segment is sized to fit the list.
+func NewRootJoinListMessage(n int32) (*capnp.Message, Join_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 2}, n)
+	return msg, Join_List{l}, err
+}
+
 func (s Join_List) At(i int) Join { return Join{s.List.Struct(i)} }
 
 func (s Join_List) Set(i int, v Join) error { return s.List.SetStruct(i, v.Struct) }
@@ -1920,8 +2221,7 @@ func (s Join_List) String() string {
 type Join_Promise struct{ *capnp.Pipeline }
 
 func (p Join_Promise) Struct() (Join, error) {
-	s, err := p.Pipeline.Struct()
-	return Join{s}, err
+	return capnp.PipelineStruct[Join](p.Pipeline)
 }
 
 func (p Join_Promise) Target() MessageTarget_Promise {
@@ -1965,11 +2265,28 @@ func NewRootMessageTarget(s *capnp.Segment) (MessageTarget, error) {
 	return MessageTarget{st}, err
 }
 
+func AllocateRootMessageTarget(msg *capnp.Message) (MessageTarget, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return MessageTarget{st}, err
+}
+
 func ReadRootMessageTarget(msg *capnp.Message) (MessageTarget, error) {
 	root, err := msg.RootPtr()
 	return MessageTarget{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s MessageTarget) Equal(other MessageTarget) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s MessageTarget) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s MessageTarget) String() string {
 	str, _ := text.Marshal(0x95bc14545813fbc1, s.Struct)
 	return str
@@ -1978,9 +2295,20 @@ func (s MessageTarget) String() string {
 func (s MessageTarget) Which() MessageTarget_Which {
 	return MessageTarget_Which(s.Struct.Uint16(4))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s MessageTarget) ResetUnion() error {
+	s.Struct.SetUint16(4, 0)
+	s.Struct.SetUint32(0, 0)
+	if err := s.Struct.ClearPtr(0); err != nil {
+		return err
+	}
+	return nil
+}
 func (s MessageTarget) ImportedCap() uint32 {
 	if s.Struct.Uint16(4) != 0 {
-		panic("Which() != importedCap")
+		capnp.UnionMismatch(s.Struct, "importedCap")
 	}
 	return s.Struct.Uint32(0)
 }
@@ -1992,7 +2320,7 @@ func (s MessageTarget) SetImportedCap(v uint32) {
 
 func (s MessageTarget) PromisedAnswer() (PromisedAnswer, error) {
 	if s.Struct.Uint16(4) != 1 {
-		panic("Which() != promisedAnswer")
+		capnp.UnionMismatch(s.Struct, "promisedAnswer")
 	}
 	p, err := s.Struct.Ptr(0)
 	return PromisedAnswer{Struct: p.Struct()}, err
@@ -2032,6 +2360,13 @@ func NewMessageTarget_List(s *capnp.Segment, sz int32) (MessageTarget_List, erro
 	return MessageTarget_List{l}, err
 }
 
+// NewRootMessageTargetListMessage creates a message whose root is a new
+// list of n MessageTarget.  The message's segment is sized to fit the list.
+func NewRootMessageTargetListMessage(n int32) (*capnp.Message, MessageTarget_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 1}, n)
+	return msg, MessageTarget_List{l}, err
+}
+
 func (s MessageTarget_List) At(i int) MessageTarget { return MessageTarget{s.List.Struct(i)} }
 
 func (s MessageTarget_List) Set(i int, v MessageTarget) error { return s.List.SetStruct(i, v.Struct) }
@@ -2045,8 +2380,7 @@ func (s MessageTarget_List) String() string {
 type MessageTarget_Promise struct{ *capnp.Pipeline }
 
 func (p MessageTarget_Promise) Struct() (MessageTarget, error) {
-	s, err := p.Pipeline.Struct()
-	return MessageTarget{s}, err
+	return capnp.PipelineStruct[MessageTarget](p.Pipeline)
 }
 
 func (p MessageTarget_Promise) PromisedAnswer() PromisedAnswer_Promise {
@@ -2068,11 +2402,28 @@ func NewRootPayload(s *capnp.Segment) (Payload, error) {
 	return Payload{st}, err
 }
 
+func AllocateRootPayload(msg *capnp.Message) (Payload, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return Payload{st}, err
+}
+
 func ReadRootPayload(msg *capnp.Message) (Payload, error) {
 	root, err := msg.RootPtr()
 	return Payload{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Payload) Equal(other Payload) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Payload) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Payload) String() string {
 	str, _ := text.Marshal(0x9a0e61223d96743b, s.Struct)
 	return str
@@ -2133,6 +2484,13 @@ func NewPayload_List(s *capnp.Segment, sz int32) (Payload_List, error) {
 	return Payload_List{l}, err
 }
 
+// NewRootPayloadListMessage creates a message whose root is a new
+// list of n Payload.  The message's segment is sized to fit the list.
+func NewRootPayloadListMessage(n int32) (*capnp.Message, Payload_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 2}, n)
+	return msg, Payload_List{l}, err
+}
+
 func (s Payload_List) At(i int) Payload { return Payload{s.List.Struct(i)} }
 
 func (s Payload_List) Set(i int, v Payload) error { return s.List.SetStruct(i, v.Struct) }
@@ -2146,8 +2504,7 @@ func (s Payload_List) String() string {
 type Payload_Promise struct{ *capnp.Pipeline }
 
 func (p Payload_Promise) Struct() (Payload, error) {
-	s, err := p.Pipeline.Struct()
-	return Payload{s}, err
+	return capnp.PipelineStruct[Payload](p.Pipeline)
 }
 
 func (p Payload_Promise) Content() *capnp.Pipeline {
@@ -2199,11 +2556,28 @@ func NewRootCapDescriptor(s *capnp.Segment) (CapDescriptor, error) {
 	return CapDescriptor{st}, err
 }
 
+func AllocateRootCapDescriptor(msg *capnp.Message) (CapDescriptor, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return CapDescriptor{st}, err
+}
+
 func ReadRootCapDescriptor(msg *capnp.Message) (CapDescriptor, error) {
 	root, err := msg.RootPtr()
 	return CapDescriptor{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s CapDescriptor) Equal(other CapDescriptor) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s CapDescriptor) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s CapDescriptor) String() string {
 	str, _ := text.Marshal(0x8523ddc40b86b8b0, s.Struct)
 	return str
@@ -2212,6 +2586,17 @@ func (s CapDescriptor) String() string {
 func (s CapDescriptor) Which() CapDescriptor_Which {
 	return CapDescriptor_Which(s.Struct.Uint16(0))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s CapDescriptor) ResetUnion() error {
+	s.Struct.SetUint16(0, 0)
+	s.Struct.SetUint32(4, 0)
+	if err := s.Struct.ClearPtr(0); err != nil {
+		return err
+	}
+	return nil
+}
 func (s CapDescriptor) SetNone() {
 	s.Struct.SetUint16(0, 0)
 
@@ -2219,7 +2604,7 @@ func (s CapDescriptor) SetNone() {
 
 func (s CapDescriptor) SenderHosted() uint32 {
 	if s.Struct.Uint16(0) != 1 {
-		panic("Which() != senderHosted")
+		capnp.UnionMismatch(s.Struct, "senderHosted")
 	}
 	return s.Struct.Uint32(4)
 }
@@ -2231,7 +2616,7 @@ func (s CapDescriptor) SetSenderHosted(v uint32) {
 
 func (s CapDescriptor) SenderPromise() uint32 {
 	if s.Struct.Uint16(0) != 2 {
-		panic("Which() != senderPromise")
+		capnp.UnionMismatch(s.Struct, "senderPromise")
 	}
 	return s.Struct.Uint32(4)
 }
@@ -2243,7 +2628,7 @@ func (s CapDescriptor) SetSenderPromise(v uint32) {
 
 func (s CapDescriptor) ReceiverHosted() uint32 {
 	if s.Struct.Uint16(0) != 3 {
-		panic("Which() != receiverHosted")
+		capnp.UnionMismatch(s.Struct, "receiverHosted")
 	}
 	return s.Struct.Uint32(4)
 }
@@ -2255,7 +2640,7 @@ func (s CapDescriptor) SetReceiverHosted(v uint32) {
 
 func (s CapDescriptor) ReceiverAnswer() (PromisedAnswer, error) {
 	if s.Struct.Uint16(0) != 4 {
-		panic("Which() != receiverAnswer")
+		capnp.UnionMismatch(s.Struct, "receiverAnswer")
 	}
 	p, err := s.Struct.Ptr(0)
 	return PromisedAnswer{Struct: p.Struct()}, err
@@ -2288,7 +2673,7 @@ func (s CapDescriptor) NewReceiverAnswer() (PromisedAnswer, error) {
 
 func (s CapDescriptor) ThirdPartyHosted() (ThirdPartyCapDescriptor, error) {
 	if s.Struct.Uint16(0) != 5 {
-		panic("Which() != thirdPartyHosted")
+		capnp.UnionMismatch(s.Struct, "thirdPartyHosted")
 	}
 	p, err := s.Struct.Ptr(0)
 	return ThirdPartyCapDescriptor{Struct: p.Struct()}, err
@@ -2328,6 +2713,13 @@ func NewCapDescriptor_List(s *capnp.Segment, sz int32) (CapDescriptor_List, erro
 	return CapDescriptor_List{l}, err
 }
 
+// NewRootCapDescriptorListMessage creates a message whose root is a new
+// list of n CapDescriptor.  The message's segment is sized to fit the list.
+func NewRootCapDescriptorListMessage(n int32) (*capnp.Message, CapDescriptor_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 1}, n)
+	return msg, CapDescriptor_List{l}, err
+}
+
 func (s CapDescriptor_List) At(i int) CapDescriptor { return CapDescriptor{s.List.Struct(i)} }
 
 func (s CapDescriptor_List) Set(i int, v CapDescriptor) error { return s.List.SetStruct(i, v.Struct) }
@@ -2341,8 +2733,7 @@ func (s CapDescriptor_List) String() string {
 type CapDescriptor_Promise struct{ *capnp.Pipeline }
 
 func (p CapDescriptor_Promise) Struct() (CapDescriptor, error) {
-	s, err := p.Pipeline.Struct()
-	return CapDescriptor{s}, err
+	return capnp.PipelineStruct[CapDescriptor](p.Pipeline)
 }
 
 func (p CapDescriptor_Promise) ReceiverAnswer() PromisedAnswer_Promise {
@@ -2368,11 +2759,28 @@ func NewRootPromisedAnswer(s *capnp.Segment) (PromisedAnswer, error) {
 	return PromisedAnswer{st}, err
 }
 
+func AllocateRootPromisedAnswer(msg *capnp.Message) (PromisedAnswer, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return PromisedAnswer{st}, err
+}
+
 func ReadRootPromisedAnswer(msg *capnp.Message) (PromisedAnswer, error) {
 	root, err := msg.RootPtr()
 	return PromisedAnswer{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s PromisedAnswer) Equal(other PromisedAnswer) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s PromisedAnswer) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s PromisedAnswer) String() string {
 	str, _ := text.Marshal(0xd800b1d6cd6f1ca0, s.Struct)
 	return str
@@ -2420,6 +2828,13 @@ func NewPromisedAnswer_List(s *capnp.Segment, sz int32) (PromisedAnswer_List, er
 	return PromisedAnswer_List{l}, err
 }
 
+// NewRootPromisedAnswerListMessage creates a message whose root is a new
+// list of n PromisedAnswer.  The message's segment is sized to fit the list.
+func NewRootPromisedAnswerListMessage(n int32) (*capnp.Message, PromisedAnswer_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 1}, n)
+	return msg, PromisedAnswer_List{l}, err
+}
+
 func (s PromisedAnswer_List) At(i int) PromisedAnswer { return PromisedAnswer{s.List.Struct(i)} }
 
 func (s PromisedAnswer_List) Set(i int, v PromisedAnswer) error { return s.List.SetStruct(i, v.Struct) }
@@ -2433,8 +2848,7 @@ func (s PromisedAnswer_List) String() string {
 type PromisedAnswer_Promise struct{ *capnp.Pipeline }
 
 func (p PromisedAnswer_Promise) Struct() (PromisedAnswer, error) {
-	s, err := p.Pipeline.Struct()
-	return PromisedAnswer{s}, err
+	return capnp.PipelineStruct[PromisedAnswer](p.Pipeline)
 }
 
 type PromisedAnswer_Op struct{ capnp.Struct }
@@ -2470,11 +2884,28 @@ func NewRootPromisedAnswer_Op(s *capnp.Segment) (PromisedAnswer_Op, error) {
 	return PromisedAnswer_Op{st}, err
 }
 
+func AllocateRootPromisedAnswer_Op(msg *capnp.Message) (PromisedAnswer_Op, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return PromisedAnswer_Op{st}, err
+}
+
 func ReadRootPromisedAnswer_Op(msg *capnp.Message) (PromisedAnswer_Op, error) {
 	root, err := msg.RootPtr()
 	return PromisedAnswer_Op{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s PromisedAnswer_Op) Equal(other PromisedAnswer_Op) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s PromisedAnswer_Op) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s PromisedAnswer_Op) String() string {
 	str, _ := text.Marshal(0xf316944415569081, s.Struct)
 	return str
@@ -2483,6 +2914,14 @@ func (s PromisedAnswer_Op) String() string {
 func (s PromisedAnswer_Op) Which() PromisedAnswer_Op_Which {
 	return PromisedAnswer_Op_Which(s.Struct.Uint16(0))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s PromisedAnswer_Op) ResetUnion() error {
+	s.Struct.SetUint16(0, 0)
+	s.Struct.SetUint16(2, 0)
+	return nil
+}
 func (s PromisedAnswer_Op) SetNoop() {
 	s.Struct.SetUint16(0, 0)
 
@@ -2490,7 +2929,7 @@ func (s PromisedAnswer_Op) SetNoop() {
 
 func (s PromisedAnswer_Op) GetPointerField() uint16 {
 	if s.Struct.Uint16(0) != 1 {
-		panic("Which() != getPointerField")
+		capnp.UnionMismatch(s.Struct, "getPointerField")
 	}
 	return s.Struct.Uint16(2)
 }
@@ -2509,6 +2948,13 @@ func NewPromisedAnswer_Op_List(s *capnp.Segment, sz int32) (PromisedAnswer_Op_Li
 	return PromisedAnswer_Op_List{l}, err
 }
 
+// NewRootPromisedAnswer_OpListMessage creates a message whose root is a new
+// list of n PromisedAnswer_Op.  The message's segment is sized to fit the list.
+func NewRootPromisedAnswer_OpListMessage(n int32) (*capnp.Message, PromisedAnswer_Op_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 0}, n)
+	return msg, PromisedAnswer_Op_List{l}, err
+}
+
 func (s PromisedAnswer_Op_List) At(i int) PromisedAnswer_Op {
 	return PromisedAnswer_Op{s.List.Struct(i)}
 }
@@ -2526,8 +2972,7 @@ func (s PromisedAnswer_Op_List) String() string {
 type PromisedAnswer_Op_Promise struct{ *capnp.Pipeline }
 
 func (p PromisedAnswer_Op_Promise) Struct() (PromisedAnswer_Op, error) {
-	s, err := p.Pipeline.Struct()
-	return PromisedAnswer_Op{s}, err
+	return capnp.PipelineStruct[PromisedAnswer_Op](p.Pipeline)
 }
 
 type ThirdPartyCapDescriptor struct{ capnp.Struct }
@@ -2545,11 +2990,28 @@ func NewRootThirdPartyCapDescriptor(s *capnp.Segment) (ThirdPartyCapDescriptor,
 	return ThirdPartyCapDescriptor{st}, err
 }
 
+func AllocateRootThirdPartyCapDescriptor(msg *capnp.Message) (ThirdPartyCapDescriptor, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return ThirdPartyCapDescriptor{st}, err
+}
+
 func ReadRootThirdPartyCapDescriptor(msg *capnp.Message) (ThirdPartyCapDescriptor, error) {
 	root, err := msg.RootPtr()
 	return ThirdPartyCapDescriptor{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s ThirdPartyCapDescriptor) Equal(other ThirdPartyCapDescriptor) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s ThirdPartyCapDescriptor) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s ThirdPartyCapDescriptor) String() string {
 	str, _ := text.Marshal(0xd37007fde1f0027d, s.Struct)
 	return str
@@ -2593,6 +3055,13 @@ func NewThirdPartyCapDescriptor_List(s *capnp.Segment, sz int32) (ThirdPartyCapD
 	return ThirdPartyCapDescriptor_List{l}, err
 }
 
+// NewRootThirdPartyCapDescriptorListMessage creates a message whose root is a new
+// list of n ThirdPartyCapDescriptor.  The message's segment is sized to fit the list.
+func NewRootThirdPartyCapDescriptorListMessage(n int32) (*capnp.Message, ThirdPartyCapDescriptor_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 1}, n)
+	return msg, ThirdPartyCapDescriptor_List{l}, err
+}
+
 func (s ThirdPartyCapDescriptor_List) At(i int) ThirdPartyCapDescriptor {
 	return ThirdPartyCapDescriptor{s.List.Struct(i)}
 }
@@ -2610,8 +3079,7 @@ func (s ThirdPartyCapDescriptor_List) String() string {
 type ThirdPartyCapDescriptor_Promise struct{ *capnp.Pipeline }
 
 func (p ThirdPartyCapDescriptor_Promise) Struct() (ThirdPartyCapDescriptor, error) {
-	s, err := p.Pipeline.Struct()
-	return ThirdPartyCapDescriptor{s}, err
+	return capnp.PipelineStruct[ThirdPartyCapDescriptor](p.Pipeline)
 }
 
 func (p ThirdPartyCapDescriptor_Promise) Id() *capnp.Pipeline {
@@ -2624,12 +3092,17 @@ type Exception struct{ capnp.Struct }
 const Exception_TypeID = 0xd625b7063acf691a
 
 func NewException(s *capnp.Segment) (Exception, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
 	return Exception{st}, err
 }
 
 func NewRootException(s *capnp.Segment) (Exception, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
+	return Exception{st}, err
+}
+
+func AllocateRootException(msg *capnp.Message) (Exception, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
 	return Exception{st}, err
 }
 
@@ -2638,6 +3111,18 @@ func ReadRootException(msg *capnp.Message) (Exception, error) {
 	return Exception{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Exception) Equal(other Exception) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Exception) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Exception) String() string {
 	str, _ := text.Marshal(0xd625b7063acf691a, s.Struct)
 	return str
@@ -2686,15 +3171,66 @@ func (s Exception) SetObsoleteDurability(v uint16) {
 	s.Struct.SetUint16(2, v)
 }
 
+func (s Exception) Trace() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s Exception) HasTrace() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s Exception) TraceBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s Exception) SetTrace(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s Exception) Details() (Exception_Detail_List, error) {
+	p, err := s.Struct.Ptr(2)
+	return Exception_Detail_List{List: p.List()}, err
+}
+
+func (s Exception) HasDetails() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s Exception) SetDetails(v Exception_Detail_List) error {
+	return s.Struct.SetPtr(2, v.List.ToPtr())
+}
+
+// NewDetails sets the details field to a newly
+// allocated Exception_Detail_List, preferring placement in s's segment.
+func (s Exception) NewDetails(n int32) (Exception_Detail_List, error) {
+	l, err := NewException_Detail_List(s.Struct.Segment(), n)
+	if err != nil {
+		return Exception_Detail_List{}, err
+	}
+	err = s.Struct.SetPtr(2, l.List.ToPtr())
+	return l, err
+}
+
 // Exception_List is a list of Exception.
 type Exception_List struct{ capnp.List }
 
 // NewException creates a new list of Exception.
 func NewException_List(s *capnp.Segment, sz int32) (Exception_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3}, sz)
 	return Exception_List{l}, err
 }
 
+// NewRootExceptionListMessage creates a message whose root is a new
+// list of n Exception.  The message's segment is sized to fit the list.
+func NewRootExceptionListMessage(n int32) (*capnp.Message, Exception_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 3}, n)
+	return msg, Exception_List{l}, err
+}
+
 func (s Exception_List) At(i int) Exception { return Exception{s.List.Struct(i)} }
 
 func (s Exception_List) Set(i int, v Exception) error { return s.List.SetStruct(i, v.Struct) }
@@ -2708,8 +3244,7 @@ func (s Exception_List) String() string {
 type Exception_Promise struct{ *capnp.Pipeline }
 
 func (p Exception_Promise) Struct() (Exception, error) {
-	s, err := p.Pipeline.Struct()
-	return Exception{s}, err
+	return capnp.PipelineStruct[Exception](p.Pipeline)
 }
 
 type Exception_Type uint16
@@ -2777,164 +3312,269 @@ func (l Exception_Type_List) Set(i int, v Exception_Type) {
 	ul.Set(i, uint16(v))
 }
 
-const schema_b312981b2552a250 = "x\xda\x9cX\x7f\x8c\x15\xd5\x15>\xe7\xde\xb7\xef-\xec" +
-	"\x8f\xf7f\xefC\x0b\x95@mM\x0a)D\xacm\xed" +
-	"\xb6\xe6!\xec\x12\xd6,a\xef\xbe\xa5*5ig\xdf" +
-	"\xbb\xec\xce2;3\xce\xcc\xc2.\x91\x00\xad6J%" +
-	"E\xa2\x16\x8c\xb4jlb-FD\x88\xb4\x95T\x08" +
-	"\xa6j\xa4j\x14\xa3\x8d\xa6j\xd2\xa8MM\xb4\xfe\xa8" +
-	"\xca\x8fi\xce\xcc\xbc\x99\xc7\xee\xdb\x10\xfb\xd7\xbe\xccw" +
-	"f\xee9\xdf=\xe7\xfb\xee\xddK\xaf\xcb.eK\x9a" +
-	"V\xcf\x00\x90fS6x\xb9w\xef\xf8\xdf\xca#?" +
-	"\x079\x13y\xd0w\x7f\xff%_\xdd\xdd\xf1\x184\xf1" +
-	"\x1c\x80\xd8\x91\xd9$n\xcf\xe4\x00\xbe\xbd#\xf3+\x04" +
-	"\x0c\xf6\x1f\xfeE\xcb\xf17\xbe~3Ec\x1a\xdd\x8d" +
-	"\xb9,\x80\xd0\xb2\xc7\xc4\xec,\x85\xcf\xca^C\xe1\x97" +
-	"\xed\xdf\xb1u\xdeo\x1f\xbf}jx;\x80\x98\xc8\xed" +
-	"\x12\xdbr\x14\xbe9w!\x07\x0c\x8e\x9e\x12\xd7\x0e\x14" +
-	"\x9f\xb8sj8C&\x8e\xcc<&\x9e\x9aIi\x1d" +
-	"\x9d\xb9\x110\xf8\x81\x7f\xd7\x95\x17\xeb\xedw\x836\xb3" +
-	".\xb8\x89Q\xc4%-\xbb\xc4\xa2\x16\xfa\xb5\xa0\x85b" +
-	"\xd7\xee;zj}f\xe4\x9eI_\x8e\x82\xf7\xb4\xec" +
-	"\x12\xf7\x85\xc1{[\x1e\x01\x0c:\xafy\xec\xca\x1d\x07" +
-	"f\xff\x86\x82\xd9\xb9E\"\x17W\xb6n\x17\xdd\xad\x94" +
-	"\xf5U\xad\x7f\xa5\"\x7f\xed\xbf\xb0\xb9\xcd\x9c\xf3\xf0\xa4" +
-	"o\x13mbI\xfb.\xf1\xfdv\xfa\xf5\x9dv\xca\xe3" +
-	"\xda#\xbd\xa5\xb7\xef\xba\xed\x00hE\x16\xcc1\x9e\xef" +
-	"\xcc>~\xc9+\x00(\xeel\x7fV\xdc\x17\x06\xeem" +
-	"\x1f\x02\x0c\xac\xe6[\xbfXs\xd7\xb1?7\xa6\xe2D" +
-	"\xfb.q2\x8c~\xb1\x9d2\xde\xcc>x\xebL\xce" +
-	"yiryHi\xde\x90\xef@\xb1-O\xd1\x9b\xf3" +
-	"\x94D\xa5\xfd\xb3c\x07\x16o~\xa9Q\xc2\xaf\xe5\xb7" +
-	"\x8b\xb7\xc2\xd87\xc2\xd8\x0b\x96\xae\xd99x\xe8\x99\x97" +
-	"\x1b}Yt\x17\xb6\x8bU\x05\xfa\xd5S\xa04V\xbd" +
-	"\xf1c\xf5\x8f\x83\x83'A\xceB\x0c\xb4\xef\x1d\xc9\xff" +
-	"\xf2\xbb\xd5\xcfa\x0d\xe60\x83L\xbcW\xf8\x17\xa0x" +
-	"\xbf\xf0\x0e`Z{\xa3\xef\x9e\xd0\xee\x17'\xb5\x0b)" +
-	"\x09\xed\x1d\xc0\xbf\xdc{\x91}\xe2\x95G_m\x14z" +
-	"\xb4\xe3Yq\xa2\x83BOvP\xbe{~\xf2\x879" +
-	"\x9f\xee\x7f\xf7\xef \xf3\xc8\xd3\xe6^\xc3s\xc8\x91\x8b" +
-	"\x1eA)\xac\x12\x94\xedq\xeb\xc2%[\x9f\xef}\xaf" +
-	"a\x0a\xef\x8b\xfb\xc5'\x82~}(\xe8\xbb\xdbv\xfe" +
-	"hV\xd7\x1d\x17|\x04r6&\x09u\xe5\x18\x80\xb8" +
-	"\xae\xf8\xb6PE\x0a\xd5\x8b\x14\x9a\xd4\xdd0\xdf\xe2C" +
-	"\xe2\x990\xf8\xa90\xf8\x11|sgf\xf7[\xa7\x1a" +
-	"6f\xd3\xacMb\xc6\xac\xe8\xd7#\xb0<p\x9d\xca" +
-	"\xe2\x8a\xeeXPr:\x97\xeb\xa6\xd9\x87(/\xe2\x19" +
-	"\x80\x0c\x02h\x87\xd6\x02\xc8\x83\x1c\xe5\x93\x0c\x11\x8bH" +
-	"\xcf\x8et\x02\xc8\xc3\x1c\xe5q\x86\x1a\xc3\"2\x00\xed" +
-	"\xe8 \x80|\x92\xa3|\x8e\xa1\xc6Y\x119\x80\xf6\xcc" +
-	"\xd5\x00\xf2i\x8e\xf2e\x86Z\x13\x161\x03\xa0\xbdH" +
-	"\xaf?\xc7Q\xbe\xca\x10\xb3XG\xafv\xd2\x05\xa6e" +
-	"\xb6\x16\xb1\x19Q;z\x0c@\x1e\xe7(_`\x18\xdc" +
-	"0\xa6<\xdf\xb0-\xe0=Ul\x06\x86\xcd\x80%_" +
-	"w\x87\x94\x8f\x85t\xc6\x01\xb1\x00\x18\x18\x96\xaf\xdcu" +
-	"z\x05r\xaa\xa7\x8a3\x80\xe1\x0c\xc0`T\xf9\xc3v" +
-	"\xb5\xa7\x0a\x00\x98\x03\x869\xc0\x92\xa3\xbb\xfa\xa8\x87\x85" +
-	"t\xf0\xe3Ox\xca\xaa\xf6+o\x0c\xe6\x99\xbe7`" +
-	"\x07\xbai\xda\x1b\x07\x86\x0d\xe6V\xfbt\xd7\x9f\x18\xd0" +
-	"\x0d\x93\xe8\x02D`H#[#\x92\x11\x8fN\x97\xf2" +
-	"*\xae\xe1\xf8\xb6\x0b\xc4\xe8Wx\xa65\x08BJ\xf7" +
-	",\x04\x90wp\x94\xf72\x9c\x8bg\x83\x98\xd5\xbd#" +
-	"\x00\xf2\x1e\x8e\xf2A\x86s\xd9\x99 \xe6\xf5w.\x80" +
-	"|\x80\xa3\xdc\xcfp.?M\x8f\x89\xd9\x877\x01\xc8" +
-	"}\x1c\xe5a\x86m\x99SAD\xed\xa1M\xe9n\xb5" +
-	"5}\x11\x14\xb1\x89\xf6k{\xba5y\xcb\xb6\x14d" +
-	"\xc3\xf2\x94\xbb\xd2\x86\xbc\xe7\xab\x84\xd1\xf8q\x9f\x0b\xf3" +
-	"\xecQ\xc3S\xc9sWU\x94\xb1A\xb9PZi\x9f" +
-	"\xf3B\x0a\\ey\x1b\x95\x8b\x85Z\x1f\xc7<\xfa\xc3" +
-	"F\xc8\x18\xfa\x13\xd1\xab\x00XH\xb5%\x8e\xaaq\x87" +
-	"N\xe7*\xe5y\xfa\x10*b\xed\x8a\x8451\x81." +
-	"@y\x1c9\x96oB\x86mx6\x08y\x13\xdb\xf0" +
-	"2\x80\xf2\x8d\x04\xdcB\x00?\x13\x84\xcc\x89\x9bq!" +
-	"@y+\x01\xb7\x11\x909\x1d\x84\xdc\x89[\xb1\x13\xa0" +
-	"|\x13\x01;\x09h\x8a\xe9\x13;B\xe0\x16\x02\xee " +
-	" \x1b3(n\xc7e\x00\xe5\xdb\x08\xd8M@\xee\xf3" +
-	"\xa0\x88dNw\x86\xc0N\x02\xee!`\xc6gA1" +
-	"\x1c\xc9=8\x02P\xdeM\xc0\x03\x04\xb0\xff\x06El" +
-	"\x06\x10\xf7a?@\xf9^\x02\xf6\x110\xf3\xd3\xa0\x88" +
-	"3\x00\xc4\xefq\x13@\xf9A\x02\x0e\x12\xd0\xf2IP" +
-	"\xc4\x99\x00\xe2\xd1p\x8d}\x04\x1c&\xa0\xf5\xe3\xa0\x88" +
-	"-\x00\xe2P\x98\xee~\x02\x9e \xa0\xed\xa3\xa0\x88\xad" +
-	"\x00\xe2\x8fa\xe5\x07\x09x\x92\x80\xe6\xff\x04El\x03" +
-	"\x10Gp-@\xf9\x09\x02\x9eF\x86\xc1\x98e\x8c:" +
-	"\xa6\x1a\x85y\xca\xa2]-\xa4\xe6\x1am\xcc<}\xd0" +
-	"vi\xc2\xeal\x85\x9e\xe7+\xbaib!\xd5\xc2\xe8" +
-	"q\xc9U\xfe\x98ka!\xb5\xbb\x18XgX\x867" +
-	"\x8c\x85\xd4'\"`\x8b\xab<\xdb\xdc\xa0\xb0\x90\xbaS" +
-	"\x82\x98J\xf7\x08I\xcc0\xee\x16{\xd0\xb3M\xe5+" +
-	"\xc8\x97\xf5\x0d\x0a;\x80a\x07`0h\xdb\xbe\xe7\xbb" +
-	":\xa0\x83\x85T\x89'\xbfT\xeaR\xf4\xb7\xf6\xda\x16" +
-	"\xc7\xb57\x18UZ'1\xf48i\xbdRQ\x0eU" +
-	"\x9f\x18V\\\xfd\x88mP\x91\x89\xce\xc6KT\x0dO" +
-	"\x8d\x0e\xea.\xf0!\x1b\x0b\xa9fOjrVkr" +
-	"5\x10\x0aX(\x10\xcd\xa9@, )\xfd&Gy" +
-	"y]\x9fkKh\xb6/\xe5(\x7f\xc800F\x1d" +
-	"\xdb\xa5a\xca-\xd7\x9dd\x18\x1d7\x9c\xda\xea\xb4\xc3" +
-	"X7f}\xfa\x84i\xebX\x8d\xd7\x8e\xe5~\xc12" +
-	"\x00\xf9\x0d\x8e\xf2R\x86ZM\xef\x17\x91\x8a\x7f\x8b\xa3" +
-	"\\\xc9pK\xc5\xb6|e\xf9\x09\xe9\x15\xdd\x19\xd0\x07" +
-	"ME\xa2\xda\x0e\xd8\xc7\x11\x0b\xe9\x89\x0e\x90\x1e\x9e\xb3" +
-	"n\xc8v4\xde\xad\xc9\xba\xddd3]\x1ce_j" +
-	"3\xab\xc8'Vr\x94\x03u6#\xfb\x01d\x1fG" +
-	"y\xfd\x976\x05WU\x0c\xc7P\x16`\x9a}]b" +
-	"\xfda\xebB\xb8\x19\xf3\x93\xc4^\xa4\xda_\xe0(_" +
-	"'B\xe6\x17\x11\x11\xb5\xd7HP_\xe7(\xdf\xa5\xc9" +
-	"\x0e\"\xbd\xd1\xfeI\xdc\xbd\xc9Q\xfe\x9bT\xe8l$" +
-	"6\xda{\x94\xf0\xbb\x1c\xe5\xc7$Agb\xa1\xfe\x90" +
-	">\xfb\x01Gy\x9a\xf4\xe7t,\xd4\x9f?\x04 O" +
-	"s,7#\xc3\xb9\xd9S\x01\x8bT\xa6\x09\x0f\x00\x94" +
-	"\x9bil\x8b\xa1\xfc|\x11\xab\x8c\x86\x0f\x01\x94\x8b\x04" +
-	"\xcc\xa7y\xd6\xc3m\x8f\x1c.U\xe8p\x8c\xfa\x90\x9c" +
-	"n\xb9\xeex\x10ZV\x13y\x16M\xdf\x98\xe97\xf2" +
-	"?5N\xbdo\xd8\x80\xd6\xd4\xf1\x0f*\xbaUQ&" +
-	"\x89y.\x88\xbfQFe\xf9\xdd\xa6\xa76\xe6\x87\x95" +
-	"K\x1e\xe3\xeb\xeb\xd5\x0a\xd7\x1e\xc5\xd5\xfe\xb0r\xe5\x98" +
-	"\x9a\x17\xeeV\x92Y4^+\\\xb4G\x07B\x97\xc8" +
-	"\x93\xb16\xde\x1b\xaa!j\x9a\xbaf\x9d\xd3\xa8Y7" +
-	"\xc5\xcdz\x05Cn\xd4\x1b\xd5:\xe5*\xab\x02%\xb5" +
-	"\xdc\x1e\xb3\xfc\x14H\xa7\xb2;\xae\xd9Z<0\xe1\xa8" +
-	"\xa8\x15\x0a\xe1\xde.\xe8\xa4\xca\xb5\xaf\xad\x05@\xa6\xcd" +
-	"\x1d\x01@\xae\xcdv\x01J\xebt\xc3T\xd5\xc0\xde\xa0" +
-	"\\\xd3\xd6\xab\xc0U\x95t\xa0b[\x96\x82|\xc5W" +
-	"\xd5\xc9*{na\xa4~S\xa6\xa1?\x9d\x866\x0c" +
-	"b\x01Xuq:\x0fm\xecl\xd0` b\x01\xe8" +
-	"\x01L\x0a\xcfUtg\xd2D\x9e\x7f{k\x19r\xa7" +
-	"s \xf6o\x7f\xa2\xfeP\x83\xee\xf4[\x91\xecDg" +
-	"*c\xb4\x13\xf1\xbe\x966\x18\x96\xea\xa9N\xe1\x1f\x9d" +
-	"\xce\x15\xa1I\x00L\xfa\xf6\xda\xf4;\xc9\x08.\xd9\x05" +
-	" /\xe7(\x97N\xa3\x03\xb5\xbe\xef\xc7\xb0=I&" +
-	"\xbd\xa4\xef\xeb\x17\xbd*\xec\xc2h\xd1\xf3\x08\x12Q\xdd" +
-	"\xcbQ^K\x824?\xe2\x7f\xcd\xb2\xf3\x08R\x10\xfa" +
-	"\x8b\x17Q]\xf3\x9c\xd0&\x86\xecFg\xc7\xae\xd8D" +
-	"\x86\xec\xc5\x15\xdb\xca\xfbj\xdc\x97\x85\xd0\x1c\xa2,t" +
-	"j\xf0\x9fr\x94f\xcd\x1d(\x0d\x83$\xc9\xe4(\xc7" +
-	"\xa99\xce\xc4\xe23F[\xe0p\x947\x92$\x9d\x8e" +
-	"\xc5g\x82R\xf69\xca\xad\xacv\xe2\xeb\xb5\xa1d;" +
-	"\x83ze\xfd\x94\x93\x1d\xf6\xda\x11\x92jJl\x8c\x90" +
-	"M\xbc\xb3\xc1fF\xc3\x943lKf\xb0\xfe\x92\x8a" +
-	"\x0b\xf34^TTL\xb6Ni^\xcfQ\x0e3D" +
-	"\x16\x95\xa9\xfe\x04 \x879J\x9f\xee\x13\xb1\xfa\xdfp" +
-	"w\x9a\xb9\x86\xf1%c3\x9d\xa7\xc79\xca\x9b\x18\x1d" +
-	"@t\xcf\xb6\xb0\x15\x18\xb6\xd6\x99>\xf6xtXW" +
-	"n\xc9[\xa1\x8f\x99~B|\x12\xd05\xe6\xea\x83\x86" +
-	"ip\x7f\xa2v9\xc8\xfb\x13\x8e\xc2|\x9a: \xe6" +
-	"\xcf\xdd\xac\xbe\xd8q#\xbf\x05\x08KM\xaeu\x1a\xce" +
-	"\xe1\xab\x9diZ\xb9\xd6UK\xfac_\xef\x9d\xae\x81" +
-	"|W\xb7\xbcu\xb6\x0b8\x9aZl\xb2\xc8$\x8be" +
-	"\xd1-nq\xed\xfeb\xe6\xe9\xfaB\x9d\x1dv\x10\xd9" +
-	"L7\xd1\xbd4Z1\xea\xa0,\x80\xd6su*/" +
-	"t\xff`\xa1\xc5hrm\xda\xdf\xa5J\xc8!d\x83" +
-	"\x09{\xcc\xf5\x94\xb9\x8e\xf4\xbfv\xc2\x07\xdeX\xbc\x97" +
-	"\x85\xc7\xb2\x9c\xab;\xd3\xcfuB\xc6\xdd\xe7\x1b\xeb\xaa" +
-	"r\\U\xd1}T\xd5\xd5\x83#\xaa\xe2\x138y\xd5" +
-	");\x93[\xbc\xda\x99|\xcaZ\x98JV\xdd5l" +
-	"\xd1\xcfR\xff\xc8[\xb6\xed@6\x18R~\x9fmX" +
-	">*w\x85\xa1\xccjr}\xac/3\x9a\xdb<\x0d" +
-	"\xee\xa4:;\xeb\xb5\x11\xeb\xfe\xa3\xa1-Z\x06l\xda" +
-	"\x03Kt\xd4\x1a\xf7\xcf\xb9\xa1_m\x1b\xd6\xff{t" +
-	"Z\x96\xca\xd7\x97;:mY\xaf&\xc8\x02j<\xff" +
-	"/\x00\x00\xff\xff<\xff\x0e\x96"
+type Exception_Detail struct{ capnp.Struct }
+
+// Exception_Detail_TypeID is the unique identifier for the type Exception_Detail.
+const Exception_Detail_TypeID = 0xd6c14f121d44f8dd
+
+func NewException_Detail(s *capnp.Segment) (Exception_Detail, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return Exception_Detail{st}, err
+}
+
+func NewRootException_Detail(s *capnp.Segment) (Exception_Detail, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return Exception_Detail{st}, err
+}
+
+func AllocateRootException_Detail(msg *capnp.Message) (Exception_Detail, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return Exception_Detail{st}, err
+}
+
+func ReadRootException_Detail(msg *capnp.Message) (Exception_Detail, error) {
+	root, err := msg.RootPtr()
+	return Exception_Detail{root.Struct()}, err
+}
+
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Exception_Detail) Equal(other Exception_Detail) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Exception_Detail) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
+func (s Exception_Detail) String() string {
+	str, _ := text.Marshal(0xd6c14f121d44f8dd, s.Struct)
+	return str
+}
+
+func (s Exception_Detail) Id() uint64 {
+	return s.Struct.Uint64(0)
+}
+
+func (s Exception_Detail) SetId(v uint64) {
+	s.Struct.SetUint64(0, v)
+}
+
+func (s Exception_Detail) Value() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s Exception_Detail) HasValue() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s Exception_Detail) SetValue(v []byte) error {
+	return s.Struct.SetData(0, v)
+}
+
+// Exception_Detail_List is a list of Exception_Detail.
+type Exception_Detail_List struct{ capnp.List }
+
+// NewException_Detail creates a new list of Exception_Detail.
+func NewException_Detail_List(s *capnp.Segment, sz int32) (Exception_Detail_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return Exception_Detail_List{l}, err
+}
+
+// NewRootException_DetailListMessage creates a message whose root is a new
+// list of n Exception_Detail.  The message's segment is sized to fit the list.
+func NewRootException_DetailListMessage(n int32) (*capnp.Message, Exception_Detail_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 1}, n)
+	return msg, Exception_Detail_List{l}, err
+}
+
+func (s Exception_Detail_List) At(i int) Exception_Detail { return Exception_Detail{s.List.Struct(i)} }
+
+func (s Exception_Detail_List) Set(i int, v Exception_Detail) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s Exception_Detail_List) String() string {
+	str, _ := text.MarshalList(0xd6c14f121d44f8dd, s.List)
+	return str
+}
+
+// Exception_Detail_Promise is a wrapper for a Exception_Detail promised by a client call.
+type Exception_Detail_Promise struct{ *capnp.Pipeline }
+
+func (p Exception_Detail_Promise) Struct() (Exception_Detail, error) {
+	return capnp.PipelineStruct[Exception_Detail](p.Pipeline)
+}
+
+const schema_b312981b2552a250 = "x\xda\x9cX\x7fl\x1c\xd5\x11\x9e\xd9g\xfb\x9c`\xe7" +
+	"n\xb3\xc7\x8f\xa4X6I\x91\x13'1\xb6\xa1-u" +
+	"\x1b]p\xec(F\x8e\xe2\xe7s\x0aM+\xb5\xeb\xbb" +
+	"\x17g\x9d\xf5\xee\xb2\xbb\xe7\xc4\x11(\xa1\x85\x0aR\xa2" +
+	"\x86\x08h\x82H\x1b\x10H@\x83\x08!\x16\xa1MT" +
+	"\x12E* ( \x02\x02DT@\xaaJ\xaaV\x82" +
+	"\xf2\xa3\x84\xc4\xd9jv\xf7v\xcf\xe7\xb3\"\xaa\xfc\x91" +
+	"\xd3\xcd\xdc{3\xdf\xcc|\xdf<\xb7\x1d\xa9_!\xb5" +
+	"W'\xba\x00\x06Xu\x8dw\xaao\xdf\x96\xbffG" +
+	"~\x09|62\xaf\xff\x91\x81\xab\xbf\xb5g\xee\xb3P" +
+	"\xcd\x12\x00\xca\x99\xa6\xad\xca\xbf\x9b\x12\x00\xd7\x9ei\xfa" +
+	"\x0d\x02z\x07\x8f\xfc\xea\x92\x93\xa7\x17\xdeI\xde\x18{" +
+	"\xf7`\xa2\x06@\x19]pB), \xf7[\x16\xdc" +
+	"D\xee\x1d\x07wno\xfc\xfds\xf7Nw\x9f\x03\xa0" +
+	"\xbc\xb5p\xb7rz!\xb9\xbf\xbb\xf0r\x06\xe8\x1d?" +
+	"\xa7\xdc<\x98>z\xfftw\x09%e^\xf3\x09\xe5" +
+	"\xaaf\x0a\xab\xa1y3\xa0\xf7\x03\xf7\x81\xe5\x0b\xd49" +
+	"\x0f\x82<\xbb\xc4\xb9Z\"\x8f\xdb\x9bw+w\xfb\xbe" +
+	"w\xfa\xbe\xeb\x0f\x1c?\xb7\xa9j\xe4\xa1\xb2\x93\x03\xe7" +
+	"/\x9aw+\x93\xbe\xf3\xd9\xe6\xa7\x01\xbd\xce\x9b\x9e]" +
+	"\xbe\xf3\xd0\xbc\xdf\x91\xb345Id\xca\xdeE;\x94" +
+	"\x87\x17Q\xd4\xfb\x16\xfd\x85\x92\xfc\xad\xfb\xfam\xf5\xfa" +
+	"\xfc\xa7\xca\xce\xae\xa2\x03w\xb6\xecV\xeeo\xa1O\xf7" +
+	"\xb6P\x1c7\x1f\xeb\xcb|\xf4\xc0=\x87@NK\xde" +
+	"|\xed\xb5\xce\x9a\xe7\xae~\x1b\x00\x95O[^V&" +
+	"}\xc7\xb3-\xc3\x80\x9eQ{\xf7\xd7\xeb\x1e8\xf1\xa7" +
+	"\xcaP,^\xb2[i_B\xde\xcb\x96P\xc4\xb7I" +
+	"\x9f|8\x99\xb0\xde,O\x0f)\xccW\x97\xccE\xe5" +
+	"\xb4\xef\xfd\xee\x12\x0a\"7\xe7\xab\x13\x87Zo{\xb3" +
+	"R\xc0\xdfY\xbaCY\xbe\x94>}\x7f)\xf9^\xb6" +
+	"b\xdd\xae\xa1\x89\x97NU:Yyx\xe9\x0e\xe5\x09" +
+	"\xdf\xf9\xb1\xa5\x14\xc6\x9a\xd3?\x11\x7f;<\xf4\x16\xf0" +
+	"K\x11=\xf9{\xc7\x92\xbf\xfen\xfe,\xac\xc3\x04V" +
+	"\xa1\xa4\xf4,\xfb'\xa0\xd2\xbb\xec\x1f\x80q\xeee\xe7" +
+	"\xfam\xb7\xb8\xf5\x11\xa5\xbd\xb5\x19\xe0\xda\x9eV\xbf\x8f" +
+	"N\x7f\xd5\xdd0w\xed\xf1\xb7\x81\xa7\xb1\xe4\xb7A\x14" +
+	"\xcf\\\xf3\x1e\xa02q\xcdf\xc0?\xef\xbf\xd2|\xf5" +
+	"\xedg\xde\xa9\x14\xac\xdc\xf6\xb2\xd2\xd0v9\x80ru" +
+	"\x1be\xb6\xf7g\x7f\x98\xff\xe5\xc1\x8f\xdf\x03\x9eD\x16" +
+	"\x8f\xc1:\x96@Fen\xa3`\xf7\xb5Q^'\x8d" +
+	"\xcb\xdb\xb7\xbf\xd6w\xa6\"\x08\xcb\xdb\x1fQz\xda\xe9" +
+	"\xd3\x0d\xedt\xee\xed\xbb~ti\xf7}\x97}\x06|" +
+	"\x1eF\x01u'$\x00\xe5\x89\xf6\x8f\x94\x09\xdf\xf5\x19" +
+	"\xdf5B\xa8b\xbc\x1dO*\xf3:\xe8\xd3\xa5\x1d\xe4" +
+	"\xfc4~\xb0\xabj\xcf\x87\xe7*\xb6\xf0\xba\x8e\xad\xca" +
+	"\x8f;\x82OO\x837\xe5\xdfZ\xcf\xb6r\xad9\xd5" +
+	"2 cu\xaeTu\xbd\x1f\x91_\xc9\xaa\x00\xaa\x10" +
+	"@\x9eX\x0f\xc0\x0f3\xe4/H\x88\x98F\xfa\xeeX" +
+	"'\x00?\xc2\x90\x9f\x94P\x960\x8d\x12\x80||\x08" +
+	"\x80\xbf\xc0\x90\xbf\"\xa1\xcc\xa442\x00\xf9\xa5\x1b\x01" +
+	"\xf8\x8b\x0c\xf9)\x09\xe5jLc\x15\x80\xfc\x06\xfd\xfc" +
+	"\x15\x86\xfc\x1d\x09\xb1\x06K\xc0\x96\xdf\xb2A\x92\xab\xb6" +
+	"\xa7\xb1\x16Q>~\x02\x80\x9fd\xc8_\x97\xd0\xbb\xa5" +
+	" \x1cW3\x0d`\xbdy\xac\x05\x09k\x013\xaej" +
+	"\x0f\x0b\x17S17\x00b\x0a\xd0\xd3\x0cW\xd8\x1b\xd4" +
+	"\x1c$Do\x1eg\x81\x84\xb3\x00\xbdQ\xe1n4\xf3" +
+	"\xbdy\x00\xc0\x04H\x98\x00\xccX\xaa\xad\x8e:\x98\x8a" +
+	"\x09#<\xc2\x11F~@8\x05h\xd4]g\xd0\xf4" +
+	"T]77\x0fn\xd4$;\xdf\xaf\xda\xee\xf8\xa0\xaa" +
+	"\xe9\x04\x17 \x82\x84\x08\x18\x01)\x11\x8eV\xb7pr" +
+	"\xb6f\xb9\xa6\x0d\x84\xe8\x15\xac\xaa\xce\xf3|H\xf7\xb6" +
+	"\x00\xf0\xfb\x18\xf2\xfd\x126\xe0\x05/Du\xdf\x08\x00" +
+	"\x7f\x88!\x7f\\\xc2\x06i\xd2\x0bq}\xcc\x06\xe0\x8f" +
+	"2\xe4\x07%l`\xe7=\x0c\x90}j+\x00?\xc0" +
+	"\x90\x1f\x91\xb0\xbe\xea\x9c\x17@;\xb15\xaeV}\xf5" +
+	"\xd7^\x1a\xab\xa9^;\xe2\xd2$\x0d\xd3\x10P\xe3\xa7" +
+	"'\xec\xd5&$\x1dWD\x88\x86_\xf7\xdb\xd0h\x8e" +
+	"j\x8e\x88\xbe\xb7ENhc\xc2\x86\xccjs\xca\x0f" +
+	"b\xc3\x0d\x86\xb3Y\xd8\x98*vu\x88\xa3\xbbQ\xf3" +
+	"\x11Cw<\xf8)\x00\xa6bN\x0a\xbd\x8a\xd8\xa1\xd5" +
+	"\xb9F8\x8e:\x8c\x82P\xbb>BM\x19G\x1b " +
+	"\xbb\x05\x19f\xef@\x09\xeb\xf1\x82\xe7\xe3\xa6\xdc\x8e\x1d" +
+	"\x00\xd9[\xc9p\x17\x19\xd8\xa4\xe7#\xa7\xdc\x89-\x00" +
+	"\xd9\xedd\xb8\x87\x0cU\xe7=\x1f;\xe5n\xec\x04\xc8" +
+	"\xdeA\x86]d\xa8\x0e\xe1Sv\xfa\x86\xbb\xc8p\x1f" +
+	"\x19jB\x04\x95{\xb1\x0b {\x0f\x19\xf6\x90!q" +
+	"\xd6K#\x89\xda\xfd\xbea\x17\x19\x1e\"\xc3\xac\xaf\xbc" +
+	"\xb4?\xa0{q\x04 \xbb\x87\x0c\x8f\x92A\xfa\xaf\x97" +
+	"\xc6Z\xa2E\x1c\x00\xc8\xee'\xc3\x012\xcc\xfe\xd2K" +
+	"\xe3,\x9a\x7f\xdc\x0a\x90}\x9c\x0c\x87\xc9p\xc9\x17^" +
+	"\x1ag\x13\x1d\xf8w\x1c \xc3\x112\xd4}\xee\xa5\xf1" +
+	"\x12\x00e\xc2\x0f\xf7 \x19\x8e\x92\xa1\xfe3/\x8du" +
+	"\x00\xca\xf3~\xe6\x87\xc9\xf0\x02\x19j\xff\xe3\xa5\xb1\x1e" +
+	"@9\x86\xeb\x01\xb2G\xc9\xf0\"J\xe8\x15\x0cm\xd4" +
+	"\xd2\xc5(4\x0a\x83\xaa\x9a\x8aE9(L\xa3:d" +
+	"\xda4a%rD\xdf's\xaa\xaec*f\xc6\xe0" +
+	"\xeb\x8c-\xdc\x82m`*\x96\xc9\xd0\xb0A34g" +
+	"#\xa6b}\x09\x0c\xdbl\xe1\x98\xfa\x98\xc0T\xacj" +
+	"\x91E\x17\xaaC\x96HD\x03\x8bg\x0e9\xa6.\\" +
+	"\x01\xc9\xac:&p.H8\x17\xd0\x1b2M\xd7q" +
+	"m\x15\xd0\xc2T\xcc\xcb\xe5?\xcat\x0b\xfa\xbf\xf8\xb3" +
+	"m\x96m\x8eiy\xba'Z\x04\xc2\xa0\xd5\\NX" +
+	"\x94}$ta\xf6#\xa6FIF\xac\x1b^\x91\xd7" +
+	"\x1c1:\xa4\xda\xc0\x86ML\xc5\x0c^\xd6\xe4R\xb1" +
+	"\xc9\xc5\xa0O`>A\xd4\xc6\x04\xb1\x98\xa8t\x11C" +
+	"~]I\x9f\xcb\xed4\xdbm\x0c\xf9\x0f%\xf4\xb4Q" +
+	"\xcb\xb4i\x98\x12+U+\x1aF\xcb\xf6\xa76?\xe3" +
+	"0\x96\x8cY\xbf:\xae\x9b*\xe6\xc3\xbbC\xba_\xdc" +
+	"\x05\xc0\xbf\xcd\x90\xb7I(\x17\xf9~\x19\xb1\xf8R\x86" +
+	"|\xb5\x84\xdbr\xa6\xe1\x0a\xc3\x8d@\xcf\xa9\xd6\xa0:" +
+	"\xa4\x0b\x00\xc09\x80\xfd\x0c1\x15o\x82\x808\xa7\xec" +
+	"^\x1f\xed`\xbc\xeb\xa2{{Hf\xba\x19\xf2\xfeX" +
+	"f\xd6\x90N\xacf\xc8\x07Kd\x86\x0f\x00\xf0~\x86" +
+	"\xfc\xa7\xdfX\x14l\x91\xd3,M\x18\x80q\xf4%\x81" +
+	"\x0d\xf8\xad\x0b~1\x9a\xa2\xc0\xde\xa0\xdc_g\xc8\xdf" +
+	"'@\x9a\xd2\x88\x88\xf2\xbbD\xa8\xef3\xe4\x1f\xd3d" +
+	"{\x01\xdf\xc8\x7f'\xec>`\xc8\xffE,t! " +
+	"\x1b\xf9\x0c\x05\xfc1C\xfe9Q\xd0dH\xd4\x9f\xd2" +
+	"\xb1\x9f0\xe4\xe7\x89\x7f\xce\x87D}\xf6I\x00~\x9e" +
+	"a\xb6\x16%l\xa89\xe7I\x01\xcbT\xe3!\x80l" +
+	"-\x8dm\xda\xa7\x9f\xafC\x96\x91\xf1I\x80l\x9a\x0c" +
+	"M4\xcf\xaa_\xf6@\xe1b\x86\xf6\xc7\xa8\x1fI\xe9" +
+	"V\xaa\x96\x03\xbedU#\x06\xd3W\xd0\xddJ\xfa'" +
+	"\xb6P\xefk&\xa01}\xfc\xbd\x9cj\xe4\x84Nd" +
+	"\x9e\xf0\xc23\xb2(\x0c\xb7Gw\xc4\xe6\xe4Fa\x93" +
+	"\xc6\xb8\xea&\xb1\xca6Gq\xad\xbbQ\xd8\xbc \x1a" +
+	"\xfdjE\x91\x05\xe3\xb5\xcaFst\xd0W\x89$\x09" +
+	"k\xe5\xdaP\x0e(\xca\x9au~\xa5f\xdd\x1a6\xeb" +
+	"\xf5\x122\xadT\xa86\x08[\x189\xc8\x88\x95f\xc1" +
+	"pcC<\x95=a\xceF\xeb\xe0\xb8%\x82VH" +
+	"\xf9\xb5]\xdc\x09\x80(_\xb5\x1e\x00%\xb9a\x04\x00" +
+	"\x99<\xcf\x06\xc8lP5]\xe4=sL\xd8\xba\xa9" +
+	"\xe6\x81\x89<\xf1@\xce4\x0c\x01\xc9\x9c+\xf2\xe5," +
+	";51b\xbfi\xd30\x10OC=z!\x01\xac" +
+	"Y\x10\xcfC\xbdt\xc1\xab0\x10!\x01\xf4\x02F\x89" +
+	"'r\xaaU6\x91\x17/o1Bfu\x0e\x86\xfa" +
+	"\xed\x8e\x97.5h\xcf\\\x8a\xa8\x12\x9d1\x8dQ%" +
+	"\xc2\xbaf\xc64C\xf4\xe6\xa7\xe1\x8fV\xe7*_$" +
+	"\x00\xca\xce^\x1f\x9f\x13\x8d`\xfbn\x00~\x1dC\xbe" +
+	"b\x06\x1e(\xf6\xfd\x00\xfa\xedI4\xe9D}_z" +
+	"\xe9\x0d~\x17\x02\x94\x95\xa0\x12!\x11\xd4}\x0c\xf9\xcd" +
+	"DHM\x01\xfe\xeb\xba.BH\x9e\xaf/N\x00u" +
+	"Qs|\x99\x186+\xed\x8e\xdd\xa1\x88\x0c\x9b\xad9" +
+	"\xd3H\xbab\x8b\xcbS\xbe8\x04Q\xa8\xd4\xe0?g" +
+	"\xc8\xf5\xa2:P\x18\x1aQ\x92\xce\x90o\xa1\xe6\x98\x0c" +
+	"\xc9\xa7@%\xb0\x18\xf2[\x89\x92\xce\x87\xe43N!" +
+	"\xbb\x0c\xf9v\xa9\xb8\xf1\xf5\x99\x901\xad!5\xb7i" +
+	"\xdaf\x87}f`\x899%\x14F\xa8\x89\xb4\xb3B" +
+	"1\x83aJh\xa6\xc1k\xb1\xf4q;\xab%~\xb2" +
+	"\xc9\xd5\x9dI\x9a\xb5L\xb7pUM\xe7WD\x05\xd8" +
+	"\xdb\x19o\xc9(\x85+\xf2\x1f\x01\xf8~\x86\xfc\x00\xbd" +
+	"1BEx\xe2\xc1x\x13\x961|xL\xd0\x8e}" +
+	"\x90!?*\xa1\\\x15><\x9e\xef\x88\xb7c\xb9Z" +
+	"\x0a\x97\xe3\xae\xf01sJ\xa2\xf5EuL\x03\xeb@" +
+	"\xc2\xba\x92\x95\x01{\x1dZ\xf5\x85\x9dqV\xa9\x05\xdd" +
+	"\x8d\xca\x169t\x17luH\xd35\xe6\x8e\x17\x9f\x16" +
+	"Iw\xdc\x12\x98\x8c\x13\x07\xc4$`\xa3k\xab9Q" +
+	"\xbcb[\xde\xcf\xdb\x89\xa53\x82\xa6L:\xa7\xd0\x13" +
+	"\xa1\xc54\xfdb\x03\xd81u\x00\xc3wP\xe3\x98\xaa" +
+	"\x17\x04\xd6\x83\x84\xf5S/\xe8\x0f\xf7\x87`{\x00\xe0" +
+	"UX\xf2d\x95q>[k\xcd0\x98\xc5;\xdb\x07" +
+	"\xc2-\xa5o\xa6qpm\xd5p6\x986\xe0h\x9c" +
+	"ut\xc9\xf4\xac\x09\xf9\xd6\xe2kLO\xd2c\x8c\xd7" +
+	"\x85\xf3@\x05\xec\xa1FY\x11\xdc\x18\xccC\x0d\x80\xdc" +
+	"{cL\x96\xf4\x9a\x92|\xc1\x94\xf9\xfaxZ39" +
+	"\xbf\xa6P\xe3\x8d\x9b\x05\xdb\x11\xfa\x06R\xb3\xe2{\x05" +
+	"Xe)\xea\xf2\x97\xcc\x84\xadZ3\xb3T\x04\xc6\x83" +
+	"\x17#\xa9\xbc\xb0l\x91S]\x14\xf9\xb5C#\"\xe7" +
+	"\x92\xb1\xfc\xd6i\x95I\xb4\xae\xb5\xcaw\xc6\x96\xb8\xfe" +
+	"%\x8f\xcae\xbf\x88\xd50i\x98\xa6\x055\xde\xb0p" +
+	"\xfbM\xcdpQ\xd8\xab4\xa1\xe7\xa3\xc7pi\x9a\x01" +
+	"\x0b%\x89\x86\xca\xf2\xec,m4,\xf9\xbb\x8e\xbc\xac" +
+	"\x0b\xa4\x19\xd7\xaf`q\xdc\xe2N\xf9{\xc3\x8d\xa6f" +
+	"\xfc\xbf\x8b`WL\xc6\xdfl\x11\xdc\xb6I\x8c\x93\xa0" +
+	"\x15q\xfe\xdf\x00\xacJU\xab"
 
 func init() {
 	schemas.Register(schema_b312981b2552a250,
@@ -2953,6 +3593,7 @@ func init() {
 		0xd4c9b56290554016,
 		0xd562b4df655bdd4d,
 		0xd625b7063acf691a,
+		0xd6c14f121d44f8dd,
 		0xd800b1d6cd6f1ca0,
 		0xdae8b0f61aab5f99,
 		0xe94ccf8031176ec4,
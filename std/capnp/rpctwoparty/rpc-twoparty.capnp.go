@@ -78,11 +78,28 @@ func NewRootVatId(s *capnp.Segment) (VatId, error) {
 	return VatId{st}, err
 }
 
+func AllocateRootVatId(msg *capnp.Message) (VatId, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return VatId{st}, err
+}
+
 func ReadRootVatId(msg *capnp.Message) (VatId, error) {
 	root, err := msg.RootPtr()
 	return VatId{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s VatId) Equal(other VatId) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s VatId) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s VatId) String() string {
 	str, _ := text.Marshal(0xd20b909fee733a8e, s.Struct)
 	return str
@@ -105,6 +122,13 @@ func NewVatId_List(s *capnp.Segment, sz int32) (VatId_List, error) {
 	return VatId_List{l}, err
 }
 
+// NewRootVatIdListMessage creates a message whose root is a new
+// list of n VatId.  The message's segment is sized to fit the list.
+func NewRootVatIdListMessage(n int32) (*capnp.Message, VatId_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 0}, n)
+	return msg, VatId_List{l}, err
+}
+
 func (s VatId_List) At(i int) VatId { return VatId{s.List.Struct(i)} }
 
 func (s VatId_List) Set(i int, v VatId) error { return s.List.SetStruct(i, v.Struct) }
@@ -118,8 +142,7 @@ func (s VatId_List) String() string {
 type VatId_Promise struct{ *capnp.Pipeline }
 
 func (p VatId_Promise) Struct() (VatId, error) {
-	s, err := p.Pipeline.Struct()
-	return VatId{s}, err
+	return capnp.PipelineStruct[VatId](p.Pipeline)
 }
 
 type ProvisionId struct{ capnp.Struct }
@@ -137,11 +160,28 @@ func NewRootProvisionId(s *capnp.Segment) (ProvisionId, error) {
 	return ProvisionId{st}, err
 }
 
+func AllocateRootProvisionId(msg *capnp.Message) (ProvisionId, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return ProvisionId{st}, err
+}
+
 func ReadRootProvisionId(msg *capnp.Message) (ProvisionId, error) {
 	root, err := msg.RootPtr()
 	return ProvisionId{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s ProvisionId) Equal(other ProvisionId) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s ProvisionId) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s ProvisionId) String() string {
 	str, _ := text.Marshal(0xb88d09a9c5f39817, s.Struct)
 	return str
@@ -164,6 +204,13 @@ func NewProvisionId_List(s *capnp.Segment, sz int32) (ProvisionId_List, error) {
 	return ProvisionId_List{l}, err
 }
 
+// NewRootProvisionIdListMessage creates a message whose root is a new
+// list of n ProvisionId.  The message's segment is sized to fit the list.
+func NewRootProvisionIdListMessage(n int32) (*capnp.Message, ProvisionId_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 0}, n)
+	return msg, ProvisionId_List{l}, err
+}
+
 func (s ProvisionId_List) At(i int) ProvisionId { return ProvisionId{s.List.Struct(i)} }
 
 func (s ProvisionId_List) Set(i int, v ProvisionId) error { return s.List.SetStruct(i, v.Struct) }
@@ -177,8 +224,7 @@ func (s ProvisionId_List) String() string {
 type ProvisionId_Promise struct{ *capnp.Pipeline }
 
 func (p ProvisionId_Promise) Struct() (ProvisionId, error) {
-	s, err := p.Pipeline.Struct()
-	return ProvisionId{s}, err
+	return capnp.PipelineStruct[ProvisionId](p.Pipeline)
 }
 
 type RecipientId struct{ capnp.Struct }
@@ -196,11 +242,28 @@ func NewRootRecipientId(s *capnp.Segment) (RecipientId, error) {
 	return RecipientId{st}, err
 }
 
+func AllocateRootRecipientId(msg *capnp.Message) (RecipientId, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return RecipientId{st}, err
+}
+
 func ReadRootRecipientId(msg *capnp.Message) (RecipientId, error) {
 	root, err := msg.RootPtr()
 	return RecipientId{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s RecipientId) Equal(other RecipientId) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s RecipientId) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s RecipientId) String() string {
 	str, _ := text.Marshal(0x89f389b6fd4082c1, s.Struct)
 	return str
@@ -215,6 +278,13 @@ func NewRecipientId_List(s *capnp.Segment, sz int32) (RecipientId_List, error) {
 	return RecipientId_List{l}, err
 }
 
+// NewRootRecipientIdListMessage creates a message whose root is a new
+// list of n RecipientId.  The message's segment is sized to fit the list.
+func NewRootRecipientIdListMessage(n int32) (*capnp.Message, RecipientId_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 0}, n)
+	return msg, RecipientId_List{l}, err
+}
+
 func (s RecipientId_List) At(i int) RecipientId { return RecipientId{s.List.Struct(i)} }
 
 func (s RecipientId_List) Set(i int, v RecipientId) error { return s.List.SetStruct(i, v.Struct) }
@@ -228,8 +298,7 @@ func (s RecipientId_List) String() string {
 type RecipientId_Promise struct{ *capnp.Pipeline }
 
 func (p RecipientId_Promise) Struct() (RecipientId, error) {
-	s, err := p.Pipeline.Struct()
-	return RecipientId{s}, err
+	return capnp.PipelineStruct[RecipientId](p.Pipeline)
 }
 
 type ThirdPartyCapId struct{ capnp.Struct }
@@ -247,11 +316,28 @@ func NewRootThirdPartyCapId(s *capnp.Segment) (ThirdPartyCapId, error) {
 	return ThirdPartyCapId{st}, err
 }
 
+func AllocateRootThirdPartyCapId(msg *capnp.Message) (ThirdPartyCapId, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return ThirdPartyCapId{st}, err
+}
+
 func ReadRootThirdPartyCapId(msg *capnp.Message) (ThirdPartyCapId, error) {
 	root, err := msg.RootPtr()
 	return ThirdPartyCapId{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s ThirdPartyCapId) Equal(other ThirdPartyCapId) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s ThirdPartyCapId) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s ThirdPartyCapId) String() string {
 	str, _ := text.Marshal(0xb47f4979672cb59d, s.Struct)
 	return str
@@ -266,6 +352,13 @@ func NewThirdPartyCapId_List(s *capnp.Segment, sz int32) (ThirdPartyCapId_List,
 	return ThirdPartyCapId_List{l}, err
 }
 
+// NewRootThirdPartyCapIdListMessage creates a message whose root is a new
+// list of n ThirdPartyCapId.  The message's segment is sized to fit the list.
+func NewRootThirdPartyCapIdListMessage(n int32) (*capnp.Message, ThirdPartyCapId_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 0}, n)
+	return msg, ThirdPartyCapId_List{l}, err
+}
+
 func (s ThirdPartyCapId_List) At(i int) ThirdPartyCapId { return ThirdPartyCapId{s.List.Struct(i)} }
 
 func (s ThirdPartyCapId_List) Set(i int, v ThirdPartyCapId) error {
@@ -281,8 +374,7 @@ func (s ThirdPartyCapId_List) String() string {
 type ThirdPartyCapId_Promise struct{ *capnp.Pipeline }
 
 func (p ThirdPartyCapId_Promise) Struct() (ThirdPartyCapId, error) {
-	s, err := p.Pipeline.Struct()
-	return ThirdPartyCapId{s}, err
+	return capnp.PipelineStruct[ThirdPartyCapId](p.Pipeline)
 }
 
 type JoinKeyPart struct{ capnp.Struct }
@@ -300,11 +392,28 @@ func NewRootJoinKeyPart(s *capnp.Segment) (JoinKeyPart, error) {
 	return JoinKeyPart{st}, err
 }
 
+func AllocateRootJoinKeyPart(msg *capnp.Message) (JoinKeyPart, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return JoinKeyPart{st}, err
+}
+
 func ReadRootJoinKeyPart(msg *capnp.Message) (JoinKeyPart, error) {
 	root, err := msg.RootPtr()
 	return JoinKeyPart{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s JoinKeyPart) Equal(other JoinKeyPart) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s JoinKeyPart) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s JoinKeyPart) String() string {
 	str, _ := text.Marshal(0x95b29059097fca83, s.Struct)
 	return str
@@ -343,6 +452,13 @@ func NewJoinKeyPart_List(s *capnp.Segment, sz int32) (JoinKeyPart_List, error) {
 	return JoinKeyPart_List{l}, err
 }
 
+// NewRootJoinKeyPartListMessage creates a message whose root is a new
+// list of n JoinKeyPart.  The message's segment is sized to fit the list.
+func NewRootJoinKeyPartListMessage(n int32) (*capnp.Message, JoinKeyPart_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 0}, n)
+	return msg, JoinKeyPart_List{l}, err
+}
+
 func (s JoinKeyPart_List) At(i int) JoinKeyPart { return JoinKeyPart{s.List.Struct(i)} }
 
 func (s JoinKeyPart_List) Set(i int, v JoinKeyPart) error { return s.List.SetStruct(i, v.Struct) }
@@ -356,8 +472,7 @@ func (s JoinKeyPart_List) String() string {
 type JoinKeyPart_Promise struct{ *capnp.Pipeline }
 
 func (p JoinKeyPart_Promise) Struct() (JoinKeyPart, error) {
-	s, err := p.Pipeline.Struct()
-	return JoinKeyPart{s}, err
+	return capnp.PipelineStruct[JoinKeyPart](p.Pipeline)
 }
 
 type JoinResult struct{ capnp.Struct }
@@ -375,11 +490,28 @@ func NewRootJoinResult(s *capnp.Segment) (JoinResult, error) {
 	return JoinResult{st}, err
 }
 
+func AllocateRootJoinResult(msg *capnp.Message) (JoinResult, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return JoinResult{st}, err
+}
+
 func ReadRootJoinResult(msg *capnp.Message) (JoinResult, error) {
 	root, err := msg.RootPtr()
 	return JoinResult{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s JoinResult) Equal(other JoinResult) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s JoinResult) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s JoinResult) String() string {
 	str, _ := text.Marshal(0x9d263a3630b7ebee, s.Struct)
 	return str
@@ -431,6 +563,13 @@ func NewJoinResult_List(s *capnp.Segment, sz int32) (JoinResult_List, error) {
 	return JoinResult_List{l}, err
 }
 
+// NewRootJoinResultListMessage creates a message whose root is a new
+// list of n JoinResult.  The message's segment is sized to fit the list.
+func NewRootJoinResultListMessage(n int32) (*capnp.Message, JoinResult_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 1}, n)
+	return msg, JoinResult_List{l}, err
+}
+
 func (s JoinResult_List) At(i int) JoinResult { return JoinResult{s.List.Struct(i)} }
 
 func (s JoinResult_List) Set(i int, v JoinResult) error { return s.List.SetStruct(i, v.Struct) }
@@ -444,47 +583,46 @@ func (s JoinResult_List) String() string {
 type JoinResult_Promise struct{ *capnp.Pipeline }
 
 func (p JoinResult_Promise) Struct() (JoinResult, error) {
-	s, err := p.Pipeline.Struct()
-	return JoinResult{s}, err
+	return capnp.PipelineStruct[JoinResult](p.Pipeline)
 }
 
 func (p JoinResult_Promise) Cap() *capnp.Pipeline {
 	return p.Pipeline.GetPipeline(0)
 }
 
-const schema_a184c7885cdaf2a1 = "x\xda|\x92\xcfk\x13A\x14\xc7\xdfw&1\x09Z" +
-	"\xc2v\"\xa2\x17E\xd0\x83\xa8\xb4(\x0a\x0b\xb2\xc1 " +
-	"\xb8Z$\x13[\xb1`\x0f\xcb\xee\xa2+\xed\xeevw" +
-	"S\xc9A\x02*h\x0fJ/\x82\x07k\xe9\xd1\x93(" +
-	"\xfe*\xe8AA\x84\x1e=x\xf0_\x10zho\x01" +
-	"Y\x99\x05\x1b\xc9\x8f\xdef\x1e\x9f\xf7\xe5\xf3\xde\xcc\xd8" +
-	"9T\xd9x\xbe\x9d#\x92\xa7\xf3\xbb\xd2/\xf7\xaa\x7f" +
-	"\xde/n-\x92&\x90\xaen\xfe\xba\xf1\xe8\xfb\x83U" +
-	"\xca\x15\x88\xc4\x11\xd6\x11\xe3\xac@<\xbd\xbf\xde.M" +
-	"/\xbdyJR\xa0\x97\x1aa\x1d\xb1\x9f\xa9\xd3^\xf6" +
-	"\x8a\x90n\xfc\xfe0vF?\xba\xdc\xc3\xe6\xa1\x90\xd7" +
-	"lS|\xce\xe0\xb5\x0c~>\xbf\xf6\xf0\xd3\x8b\x9f+" +
-	"\xa4\x09\xd6e\x09b\x8a\x7f\x153\\\x81\xd3\xfc,!" +
-	"]~w\xfcf\xcbl\xbf\xed\xd7<5\xc3\x0f@\xcc" +
-	"q\xe5\xb9\xef\xd9\xd6\xb7\x97\xa5\xc7\x1f\x07y\x9a\xbc#" +
-	"\xa6\xb2D\xc9\x0dB\xfaD\x8f7V\x96v\xff\x18\xc4" +
-	"\xce\xf3uq7c[\xdc\xa0Z\x1a\x85\xf6\x89\xe4N" +
-	"\x102+JZ'm+\xf4C\xbd\xe1\xda^hx" +
-	"\xae\x9f\x98N\x1d\x18\xc8\\\x0a<\xff\xb2\xe1\xb6\xeaV" +
-	"\x94\xd4\x01\xb9\x87\xe7\x88r \xd2.\xe8D\xb2\xca!" +
-	"'\x184\xb0\x0aT\xd1l\x10\xc9\x8b\x1cr\x92Ac" +
-	"\xbc\x02F\xa4\xc9\xf3Dr\x82C^g0n\x07\x9e" +
-	"o:(\x12C\x91\x90\x86V\x94\xd4\x82\xa6OHP" +
-	" \x86\x02\xa1\xadjW\x9as\xff\xeeC\xbd\x1a\x07\xdd" +
-	"\xb89\xbb\xa3\xd6\xa1~-\xa5\x9aY\x1d\x1en\x157" +
-	"m\xdbu\x1d\x97\xe0\x00\xc4\x00B\xc1\xb6B\x8c\x12\xc3" +
-	"\xe8\x10\xa3\xab\x9e\xe3\x92r)f\xf1\x9aN\x04h%" +
-	"\x9d\xc8\x88\xddh\xc1\x8d\x0c{V\xedz\xbb\x99\xff\xd7" +
-	"<y\xcb\x8b\x1c\xb5\xe3V\xcd\x0a\xf9\xf0\xe7\xa8G\xc1" +
-	"\x82g\xc4^\xe0g\x8c\xccm\xcf=\xa2\xe6.r\xc8" +
-	"J\xff8\x83\x92\xaeY\x89\xe9\x10\xf5\x84\x1c\xeb\x86\x94" +
-	"c\xcfqQ\xee~p\x02\xca\x84\xbf\x01\x00\x00\xff\xff" +
-	"+!\xf3\xa9"
+const schema_a184c7885cdaf2a1 = "x\xda|\x92\xcdk\xd3p\x18\xc7\x9f\xef/\xadi\xd1" +
+	"Q\xb2_E&\x88i\x0e\x1eDeCT\x08H\xea" +
+	"\x86`uHS\x87(x\x09I\xd0\xc8Lb\x92N" +
+	"z\x90\xe2\x0b\xe8\x0e\xca.\x82\x07\xe7\xd8\xd1\x93 \xbe" +
+	"\x82\x17\x05\x11v\xd3\x83\xa0\xe0\x1f B\x0f\x9b^\x0a" +
+	"\x12\xf9\x15]\xb5/#\x97'\x0f\x9f\xe7\xcb\xe7y\x92" +
+	"\xf1V\xae\xcc&\xb2\xf2$QM\xcanJ\xdf\\/" +
+	"\xffz>\xbf6O\x0aG\xba\xbc\xfa\xe5\xdc\xed\xf77" +
+	"\x97)#\x13\xf1oj\x9b\xffTe\x92\xd2\x1b+\xcd" +
+	"\xfc\xd9\x85'\xf7\xc8\xe4\xe8\xa5>\xa8m\xfeU\x15\xd5" +
+	"g\xf51!m}\x7f1~P\xdf\xb5\xd8\xc3f!" +
+	"\x90zi\x95_+\x89\xeajI\xc0\x0f.\xbf\xba\xf5" +
+	"\xfa\xe1\xa7%R8\xeb\xb2\x04>\xa6\xbd\xe5%M\x80" +
+	";\xb4C\x84t\xf1\xd9\x9e\xf3\x8dJ\xf3i\xbf\xe6\xfe" +
+	"\x92\xb6\x1d|B\x13\x9e\xdb\xee\xaf\xbd{\x94\xbf\xf3r" +
+	"\x90\xe7\x88\xd6\xe6c\x9d\xc4\xad\x9aAH\xef\xeaqk" +
+	"ia\xf3\xc7A\xec\x01m\x85\x1f\xe9\xb0\x875\x83\xd2" +
+	"\xff\x9e\x1fi\x14\xda{\x93+A\xc8\xac(i\xec\xb3" +
+	"\xad\xd0\x0f\xf5\x9ak{\xa1\xe1\xb9~Rq\xaa\xc0@" +
+	"\xe6x\xe0\xf9'\x0c\xb7Q\xb5\xa2\xa4\x0a\x98[\xa4\x0c" +
+	"Q\x06D\xcaQ\x9d\xc8,K0\xa7\x19\x14\xb0\"D" +
+	"\xb3R#2\x8fI0g\x18\x14&\x15\xc1\x88\x14s" +
+	"\x92\xc8\x9c\x96`\x9ea0.\x06\x9e_q\x90#\x86" +
+	"\x1c!\x0d\xad(\x99\x0a\xea>!\x81L\x0c2\xa1)" +
+	"z'\xeb\x97\xfe\xbe\x0f\xf5\xaa\xedt\xe3\xfa\xec\x86Z" +
+	"j\xbf\x16\xd8\x1f+m\xb8U\\\xb7m\xd7u\\\x82" +
+	"\x03\x10\x03\x08\xb2m\x85\x18%\x86\xd1!F\xa7<\xc7" +
+	"%\xe1\x92\xeb\xc4+:\x11\xa0\xe4u\"#v\xa39" +
+	"72\xecYq\xeb\xf5a\xe9\x9f\xe1\x99\x0b^\xe4\x88" +
+	"\x1b7\xa6\xacP\x1a\xfe9\xaaQ0\xe7\x19\xb1\x17\xf8" +
+	"\x1d\xc6\xcc\xac\xef=\"\xf6\xceI0\x8b\xfd\xeb\x0cJ" +
+	":m%\x15\x87\xa8'dw7\xa4\x10{\x8e\x8bB" +
+	"\xf7w'\xa0@\xf8=\x00\xc9\xc7\xfeu"
 
 func init() {
 	schemas.Register(schema_a184c7885cdaf2a1,
@@ -3,11 +3,11 @@
 package schema
 
 import (
-	math "math"
-	strconv "strconv"
 	capnp "github.com/iguazio/go-capnproto2"
 	text "github.com/iguazio/go-capnproto2/encoding/text"
 	schemas "github.com/iguazio/go-capnproto2/schemas"
+	math "math"
+	strconv "strconv"
 )
 
 // Constants defined in schema.capnp.
@@ -65,11 +65,28 @@ func NewRootNode(s *capnp.Segment) (Node, error) {
 	return Node{st}, err
 }
 
+func AllocateRootNode(msg *capnp.Message) (Node, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 40, PointerCount: 6})
+	return Node{st}, err
+}
+
 func ReadRootNode(msg *capnp.Message) (Node, error) {
 	root, err := msg.RootPtr()
 	return Node{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Node) Equal(other Node) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Node) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Node) String() string {
 	str, _ := text.Marshal(0xe682ab4cf923a417, s.Struct)
 	return str
@@ -78,6 +95,37 @@ func (s Node) String() string {
 func (s Node) Which() Node_Which {
 	return Node_Which(s.Struct.Uint16(12))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s Node) ResetUnion() error {
+	s.Struct.SetUint16(12, 0)
+	s.Struct.SetUint16(14, 0)
+	s.Struct.SetUint16(24, 0)
+	s.Struct.SetUint16(26, 0)
+	s.Struct.SetBit(224, false)
+	s.Struct.SetUint16(30, 0)
+	s.Struct.SetUint32(32, 0)
+	s.Struct.SetBit(112, false)
+	s.Struct.SetBit(113, false)
+	s.Struct.SetBit(114, false)
+	s.Struct.SetBit(115, false)
+	s.Struct.SetBit(116, false)
+	s.Struct.SetBit(117, false)
+	s.Struct.SetBit(118, false)
+	s.Struct.SetBit(119, false)
+	s.Struct.SetBit(120, false)
+	s.Struct.SetBit(121, false)
+	s.Struct.SetBit(122, false)
+	s.Struct.SetBit(123, false)
+	if err := s.Struct.ClearPtr(3); err != nil {
+		return err
+	}
+	if err := s.Struct.ClearPtr(4); err != nil {
+		return err
+	}
+	return nil
+}
 func (s Node) Id() uint64 {
 	return s.Struct.Uint64(0)
 }
@@ -567,6 +615,13 @@ func NewNode_List(s *capnp.Segment, sz int32) (Node_List, error) {
 	return Node_List{l}, err
 }
 
+// NewRootNodeListMessage creates a message whose root is a new
+// list of n Node.  The message's segment is sized to fit the list.
+func NewRootNodeListMessage(n int32) (*capnp.Message, Node_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 40, PointerCount: 6}, n)
+	return msg, Node_List{l}, err
+}
+
 func (s Node_List) At(i int) Node { return Node{s.List.Struct(i)} }
 
 func (s Node_List) Set(i int, v Node) error { return s.List.SetStruct(i, v.Struct) }
@@ -580,18 +635,18 @@ func (s Node_List) String() string {
 type Node_Promise struct{ *capnp.Pipeline }
 
 func (p Node_Promise) Struct() (Node, error) {
-	s, err := p.Pipeline.Struct()
-	return Node{s}, err
+	return capnp.PipelineStruct[Node](p.Pipeline)
 }
 
-func (p Node_Promise) StructNode() Node_structNode_Promise { return Node_structNode_Promise{p.Pipeline} }
+func (p Node_Promise) StructNode() Node_structNode_Promise {
+	return Node_structNode_Promise{p.Pipeline}
+}
 
 // Node_structNode_Promise is a wrapper for a Node_structNode promised by a client call.
 type Node_structNode_Promise struct{ *capnp.Pipeline }
 
 func (p Node_structNode_Promise) Struct() (Node_structNode, error) {
-	s, err := p.Pipeline.Struct()
-	return Node_structNode{s}, err
+	return capnp.PipelineStruct[Node_structNode](p.Pipeline)
 }
 
 func (p Node_Promise) Enum() Node_enum_Promise { return Node_enum_Promise{p.Pipeline} }
@@ -600,8 +655,7 @@ func (p Node_Promise) Enum() Node_enum_Promise { return Node_enum_Promise{p.Pipe
 type Node_enum_Promise struct{ *capnp.Pipeline }
 
 func (p Node_enum_Promise) Struct() (Node_enum, error) {
-	s, err := p.Pipeline.Struct()
-	return Node_enum{s}, err
+	return capnp.PipelineStruct[Node_enum](p.Pipeline)
 }
 
 func (p Node_Promise) Interface() Node_interface_Promise { return Node_interface_Promise{p.Pipeline} }
@@ -610,8 +664,7 @@ func (p Node_Promise) Interface() Node_interface_Promise { return Node_interface
 type Node_interface_Promise struct{ *capnp.Pipeline }
 
 func (p Node_interface_Promise) Struct() (Node_interface, error) {
-	s, err := p.Pipeline.Struct()
-	return Node_interface{s}, err
+	return capnp.PipelineStruct[Node_interface](p.Pipeline)
 }
 
 func (p Node_Promise) Const() Node_const_Promise { return Node_const_Promise{p.Pipeline} }
@@ -620,8 +673,7 @@ func (p Node_Promise) Const() Node_const_Promise { return Node_const_Promise{p.P
 type Node_const_Promise struct{ *capnp.Pipeline }
 
 func (p Node_const_Promise) Struct() (Node_const, error) {
-	s, err := p.Pipeline.Struct()
-	return Node_const{s}, err
+	return capnp.PipelineStruct[Node_const](p.Pipeline)
 }
 
 func (p Node_const_Promise) Type() Type_Promise {
@@ -632,14 +684,15 @@ func (p Node_const_Promise) Value() Value_Promise {
 	return Value_Promise{Pipeline: p.Pipeline.GetPipeline(4)}
 }
 
-func (p Node_Promise) Annotation() Node_annotation_Promise { return Node_annotation_Promise{p.Pipeline} }
+func (p Node_Promise) Annotation() Node_annotation_Promise {
+	return Node_annotation_Promise{p.Pipeline}
+}
 
 // Node_annotation_Promise is a wrapper for a Node_annotation promised by a client call.
 type Node_annotation_Promise struct{ *capnp.Pipeline }
 
 func (p Node_annotation_Promise) Struct() (Node_annotation, error) {
-	s, err := p.Pipeline.Struct()
-	return Node_annotation{s}, err
+	return capnp.PipelineStruct[Node_annotation](p.Pipeline)
 }
 
 func (p Node_annotation_Promise) Type() Type_Promise {
@@ -661,11 +714,28 @@ func NewRootNode_Parameter(s *capnp.Segment) (Node_Parameter, error) {
 	return Node_Parameter{st}, err
 }
 
+func AllocateRootNode_Parameter(msg *capnp.Message) (Node_Parameter, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Node_Parameter{st}, err
+}
+
 func ReadRootNode_Parameter(msg *capnp.Message) (Node_Parameter, error) {
 	root, err := msg.RootPtr()
 	return Node_Parameter{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Node_Parameter) Equal(other Node_Parameter) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Node_Parameter) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Node_Parameter) String() string {
 	str, _ := text.Marshal(0xb9521bccf10fa3b1, s.Struct)
 	return str
@@ -699,6 +769,13 @@ func NewNode_Parameter_List(s *capnp.Segment, sz int32) (Node_Parameter_List, er
 	return Node_Parameter_List{l}, err
 }
 
+// NewRootNode_ParameterListMessage creates a message whose root is a new
+// list of n Node_Parameter.  The message's segment is sized to fit the list.
+func NewRootNode_ParameterListMessage(n int32) (*capnp.Message, Node_Parameter_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, Node_Parameter_List{l}, err
+}
+
 func (s Node_Parameter_List) At(i int) Node_Parameter { return Node_Parameter{s.List.Struct(i)} }
 
 func (s Node_Parameter_List) Set(i int, v Node_Parameter) error { return s.List.SetStruct(i, v.Struct) }
@@ -712,8 +789,7 @@ func (s Node_Parameter_List) String() string {
 type Node_Parameter_Promise struct{ *capnp.Pipeline }
 
 func (p Node_Parameter_Promise) Struct() (Node_Parameter, error) {
-	s, err := p.Pipeline.Struct()
-	return Node_Parameter{s}, err
+	return capnp.PipelineStruct[Node_Parameter](p.Pipeline)
 }
 
 type Node_NestedNode struct{ capnp.Struct }
@@ -731,11 +807,28 @@ func NewRootNode_NestedNode(s *capnp.Segment) (Node_NestedNode, error) {
 	return Node_NestedNode{st}, err
 }
 
+func AllocateRootNode_NestedNode(msg *capnp.Message) (Node_NestedNode, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return Node_NestedNode{st}, err
+}
+
 func ReadRootNode_NestedNode(msg *capnp.Message) (Node_NestedNode, error) {
 	root, err := msg.RootPtr()
 	return Node_NestedNode{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Node_NestedNode) Equal(other Node_NestedNode) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Node_NestedNode) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Node_NestedNode) String() string {
 	str, _ := text.Marshal(0xdebf55bbfa0fc242, s.Struct)
 	return str
@@ -777,6 +870,13 @@ func NewNode_NestedNode_List(s *capnp.Segment, sz int32) (Node_NestedNode_List,
 	return Node_NestedNode_List{l}, err
 }
 
+// NewRootNode_NestedNodeListMessage creates a message whose root is a new
+// list of n Node_NestedNode.  The message's segment is sized to fit the list.
+func NewRootNode_NestedNodeListMessage(n int32) (*capnp.Message, Node_NestedNode_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 1}, n)
+	return msg, Node_NestedNode_List{l}, err
+}
+
 func (s Node_NestedNode_List) At(i int) Node_NestedNode { return Node_NestedNode{s.List.Struct(i)} }
 
 func (s Node_NestedNode_List) Set(i int, v Node_NestedNode) error {
@@ -792,8 +892,7 @@ func (s Node_NestedNode_List) String() string {
 type Node_NestedNode_Promise struct{ *capnp.Pipeline }
 
 func (p Node_NestedNode_Promise) Struct() (Node_NestedNode, error) {
-	s, err := p.Pipeline.Struct()
-	return Node_NestedNode{s}, err
+	return capnp.PipelineStruct[Node_NestedNode](p.Pipeline)
 }
 
 type Field struct{ capnp.Struct }
@@ -851,11 +950,28 @@ func NewRootField(s *capnp.Segment) (Field, error) {
 	return Field{st}, err
 }
 
+func AllocateRootField(msg *capnp.Message) (Field, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 24, PointerCount: 4})
+	return Field{st}, err
+}
+
 func ReadRootField(msg *capnp.Message) (Field, error) {
 	root, err := msg.RootPtr()
 	return Field{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Field) Equal(other Field) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Field) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Field) String() string {
 	str, _ := text.Marshal(0x9aad50a41f4af45f, s.Struct)
 	return str
@@ -864,6 +980,22 @@ func (s Field) String() string {
 func (s Field) Which() Field_Which {
 	return Field_Which(s.Struct.Uint16(8))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s Field) ResetUnion() error {
+	s.Struct.SetUint16(8, 0)
+	s.Struct.SetUint32(4, 0)
+	s.Struct.SetBit(128, false)
+	s.Struct.SetUint64(16, 0)
+	if err := s.Struct.ClearPtr(2); err != nil {
+		return err
+	}
+	if err := s.Struct.ClearPtr(3); err != nil {
+		return err
+	}
+	return nil
+}
 func (s Field) Name() (string, error) {
 	p, err := s.Struct.Ptr(0)
 	return p.Text(), err
@@ -1015,6 +1147,14 @@ func (s Field) Ordinal() Field_ordinal { return Field_ordinal(s) }
 func (s Field_ordinal) Which() Field_ordinal_Which {
 	return Field_ordinal_Which(s.Struct.Uint16(10))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s Field_ordinal) ResetUnion() error {
+	s.Struct.SetUint16(10, 0)
+	s.Struct.SetUint16(12, 0)
+	return nil
+}
 func (s Field_ordinal) SetImplicit() {
 	s.Struct.SetUint16(10, 0)
 
@@ -1022,7 +1162,7 @@ func (s Field_ordinal) SetImplicit() {
 
 func (s Field_ordinal) Explicit() uint16 {
 	if s.Struct.Uint16(10) != 1 {
-		panic("Which() != explicit")
+		capnp.UnionMismatch(s.Struct, "explicit")
 	}
 	return s.Struct.Uint16(12)
 }
@@ -1041,6 +1181,13 @@ func NewField_List(s *capnp.Segment, sz int32) (Field_List, error) {
 	return Field_List{l}, err
 }
 
+// NewRootFieldListMessage creates a message whose root is a new
+// list of n Field.  The message's segment is sized to fit the list.
+func NewRootFieldListMessage(n int32) (*capnp.Message, Field_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 24, PointerCount: 4}, n)
+	return msg, Field_List{l}, err
+}
+
 func (s Field_List) At(i int) Field { return Field{s.List.Struct(i)} }
 
 func (s Field_List) Set(i int, v Field) error { return s.List.SetStruct(i, v.Struct) }
@@ -1054,8 +1201,7 @@ func (s Field_List) String() string {
 type Field_Promise struct{ *capnp.Pipeline }
 
 func (p Field_Promise) Struct() (Field, error) {
-	s, err := p.Pipeline.Struct()
-	return Field{s}, err
+	return capnp.PipelineStruct[Field](p.Pipeline)
 }
 
 func (p Field_Promise) Slot() Field_slot_Promise { return Field_slot_Promise{p.Pipeline} }
@@ -1064,8 +1210,7 @@ func (p Field_Promise) Slot() Field_slot_Promise { return Field_slot_Promise{p.P
 type Field_slot_Promise struct{ *capnp.Pipeline }
 
 func (p Field_slot_Promise) Struct() (Field_slot, error) {
-	s, err := p.Pipeline.Struct()
-	return Field_slot{s}, err
+	return capnp.PipelineStruct[Field_slot](p.Pipeline)
 }
 
 func (p Field_slot_Promise) Type() Type_Promise {
@@ -1082,8 +1227,7 @@ func (p Field_Promise) Group() Field_group_Promise { return Field_group_Promise{
 type Field_group_Promise struct{ *capnp.Pipeline }
 
 func (p Field_group_Promise) Struct() (Field_group, error) {
-	s, err := p.Pipeline.Struct()
-	return Field_group{s}, err
+	return capnp.PipelineStruct[Field_group](p.Pipeline)
 }
 
 func (p Field_Promise) Ordinal() Field_ordinal_Promise { return Field_ordinal_Promise{p.Pipeline} }
@@ -1092,8 +1236,7 @@ func (p Field_Promise) Ordinal() Field_ordinal_Promise { return Field_ordinal_Pr
 type Field_ordinal_Promise struct{ *capnp.Pipeline }
 
 func (p Field_ordinal_Promise) Struct() (Field_ordinal, error) {
-	s, err := p.Pipeline.Struct()
-	return Field_ordinal{s}, err
+	return capnp.PipelineStruct[Field_ordinal](p.Pipeline)
 }
 
 type Enumerant struct{ capnp.Struct }
@@ -1111,11 +1254,28 @@ func NewRootEnumerant(s *capnp.Segment) (Enumerant, error) {
 	return Enumerant{st}, err
 }
 
+func AllocateRootEnumerant(msg *capnp.Message) (Enumerant, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return Enumerant{st}, err
+}
+
 func ReadRootEnumerant(msg *capnp.Message) (Enumerant, error) {
 	root, err := msg.RootPtr()
 	return Enumerant{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Enumerant) Equal(other Enumerant) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Enumerant) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Enumerant) String() string {
 	str, _ := text.Marshal(0x978a7cebdc549a4d, s.Struct)
 	return str
@@ -1182,6 +1342,13 @@ func NewEnumerant_List(s *capnp.Segment, sz int32) (Enumerant_List, error) {
 	return Enumerant_List{l}, err
 }
 
+// NewRootEnumerantListMessage creates a message whose root is a new
+// list of n Enumerant.  The message's segment is sized to fit the list.
+func NewRootEnumerantListMessage(n int32) (*capnp.Message, Enumerant_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 2}, n)
+	return msg, Enumerant_List{l}, err
+}
+
 func (s Enumerant_List) At(i int) Enumerant { return Enumerant{s.List.Struct(i)} }
 
 func (s Enumerant_List) Set(i int, v Enumerant) error { return s.List.SetStruct(i, v.Struct) }
@@ -1195,8 +1362,7 @@ func (s Enumerant_List) String() string {
 type Enumerant_Promise struct{ *capnp.Pipeline }
 
 func (p Enumerant_Promise) Struct() (Enumerant, error) {
-	s, err := p.Pipeline.Struct()
-	return Enumerant{s}, err
+	return capnp.PipelineStruct[Enumerant](p.Pipeline)
 }
 
 type Superclass struct{ capnp.Struct }
@@ -1214,11 +1380,28 @@ func NewRootSuperclass(s *capnp.Segment) (Superclass, error) {
 	return Superclass{st}, err
 }
 
+func AllocateRootSuperclass(msg *capnp.Message) (Superclass, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return Superclass{st}, err
+}
+
 func ReadRootSuperclass(msg *capnp.Message) (Superclass, error) {
 	root, err := msg.RootPtr()
 	return Superclass{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Superclass) Equal(other Superclass) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Superclass) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Superclass) String() string {
 	str, _ := text.Marshal(0xa9962a9ed0a4d7f8, s.Struct)
 	return str
@@ -1266,6 +1449,13 @@ func NewSuperclass_List(s *capnp.Segment, sz int32) (Superclass_List, error) {
 	return Superclass_List{l}, err
 }
 
+// NewRootSuperclassListMessage creates a message whose root is a new
+// list of n Superclass.  The message's segment is sized to fit the list.
+func NewRootSuperclassListMessage(n int32) (*capnp.Message, Superclass_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 1}, n)
+	return msg, Superclass_List{l}, err
+}
+
 func (s Superclass_List) At(i int) Superclass { return Superclass{s.List.Struct(i)} }
 
 func (s Superclass_List) Set(i int, v Superclass) error { return s.List.SetStruct(i, v.Struct) }
@@ -1279,8 +1469,7 @@ func (s Superclass_List) String() string {
 type Superclass_Promise struct{ *capnp.Pipeline }
 
 func (p Superclass_Promise) Struct() (Superclass, error) {
-	s, err := p.Pipeline.Struct()
-	return Superclass{s}, err
+	return capnp.PipelineStruct[Superclass](p.Pipeline)
 }
 
 func (p Superclass_Promise) Brand() Brand_Promise {
@@ -1302,11 +1491,28 @@ func NewRootMethod(s *capnp.Segment) (Method, error) {
 	return Method{st}, err
 }
 
+func AllocateRootMethod(msg *capnp.Message) (Method, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 24, PointerCount: 5})
+	return Method{st}, err
+}
+
 func ReadRootMethod(msg *capnp.Message) (Method, error) {
 	root, err := msg.RootPtr()
 	return Method{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Method) Equal(other Method) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Method) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Method) String() string {
 	str, _ := text.Marshal(0x9500cce23b334d80, s.Struct)
 	return str
@@ -1464,6 +1670,13 @@ func NewMethod_List(s *capnp.Segment, sz int32) (Method_List, error) {
 	return Method_List{l}, err
 }
 
+// NewRootMethodListMessage creates a message whose root is a new
+// list of n Method.  The message's segment is sized to fit the list.
+func NewRootMethodListMessage(n int32) (*capnp.Message, Method_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 24, PointerCount: 5}, n)
+	return msg, Method_List{l}, err
+}
+
 func (s Method_List) At(i int) Method { return Method{s.List.Struct(i)} }
 
 func (s Method_List) Set(i int, v Method) error { return s.List.SetStruct(i, v.Struct) }
@@ -1477,8 +1690,7 @@ func (s Method_List) String() string {
 type Method_Promise struct{ *capnp.Pipeline }
 
 func (p Method_Promise) Struct() (Method, error) {
-	s, err := p.Pipeline.Struct()
-	return Method{s}, err
+	return capnp.PipelineStruct[Method](p.Pipeline)
 }
 
 func (p Method_Promise) ParamBrand() Brand_Promise {
@@ -1628,11 +1840,28 @@ func NewRootType(s *capnp.Segment) (Type, error) {
 	return Type{st}, err
 }
 
+func AllocateRootType(msg *capnp.Message) (Type, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 24, PointerCount: 1})
+	return Type{st}, err
+}
+
 func ReadRootType(msg *capnp.Message) (Type, error) {
 	root, err := msg.RootPtr()
 	return Type{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Type) Equal(other Type) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Type) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Type) String() string {
 	str, _ := text.Marshal(0xd07378ede1f9cc60, s.Struct)
 	return str
@@ -1641,6 +1870,20 @@ func (s Type) String() string {
 func (s Type) Which() Type_Which {
 	return Type_Which(s.Struct.Uint16(0))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s Type) ResetUnion() error {
+	s.Struct.SetUint16(0, 0)
+	s.Struct.SetUint64(8, 0)
+	s.Struct.SetUint16(8, 0)
+	s.Struct.SetUint16(10, 0)
+	s.Struct.SetUint64(16, 0)
+	if err := s.Struct.ClearPtr(0); err != nil {
+		return err
+	}
+	return nil
+}
 func (s Type) SetVoid() {
 	s.Struct.SetUint16(0, 0)
 
@@ -1868,6 +2111,15 @@ func (s Type) SetAnyPointer() {
 func (s Type_anyPointer) Which() Type_anyPointer_Which {
 	return Type_anyPointer_Which(s.Struct.Uint16(8))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s Type_anyPointer) ResetUnion() error {
+	s.Struct.SetUint16(8, 0)
+	s.Struct.SetUint16(10, 0)
+	s.Struct.SetUint64(16, 0)
+	return nil
+}
 func (s Type_anyPointer) Unconstrained() Type_anyPointer_unconstrained {
 	return Type_anyPointer_unconstrained(s)
 }
@@ -1879,12 +2131,19 @@ func (s Type_anyPointer) SetUnconstrained() {
 func (s Type_anyPointer_unconstrained) Which() Type_anyPointer_unconstrained_Which {
 	return Type_anyPointer_unconstrained_Which(s.Struct.Uint16(10))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s Type_anyPointer_unconstrained) ResetUnion() error {
+	s.Struct.SetUint16(10, 0)
+	return nil
+}
 func (s Type_anyPointer_unconstrained) SetAnyKind() {
 	s.Struct.SetUint16(10, 0)
 
 }
 
-func (s Type_anyPointer_unconstrained) SetStruct() {
+func (s Type_anyPointer_unconstrained) SetStruct_() {
 	s.Struct.SetUint16(10, 1)
 
 }
@@ -1946,6 +2205,13 @@ func NewType_List(s *capnp.Segment, sz int32) (Type_List, error) {
 	return Type_List{l}, err
 }
 
+// NewRootTypeListMessage creates a message whose root is a new
+// list of n Type.  The message's segment is sized to fit the list.
+func NewRootTypeListMessage(n int32) (*capnp.Message, Type_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 24, PointerCount: 1}, n)
+	return msg, Type_List{l}, err
+}
+
 func (s Type_List) At(i int) Type { return Type{s.List.Struct(i)} }
 
 func (s Type_List) Set(i int, v Type) error { return s.List.SetStruct(i, v.Struct) }
@@ -1959,8 +2225,7 @@ func (s Type_List) String() string {
 type Type_Promise struct{ *capnp.Pipeline }
 
 func (p Type_Promise) Struct() (Type, error) {
-	s, err := p.Pipeline.Struct()
-	return Type{s}, err
+	return capnp.PipelineStruct[Type](p.Pipeline)
 }
 
 func (p Type_Promise) List() Type_list_Promise { return Type_list_Promise{p.Pipeline} }
@@ -1969,8 +2234,7 @@ func (p Type_Promise) List() Type_list_Promise { return Type_list_Promise{p.Pipe
 type Type_list_Promise struct{ *capnp.Pipeline }
 
 func (p Type_list_Promise) Struct() (Type_list, error) {
-	s, err := p.Pipeline.Struct()
-	return Type_list{s}, err
+	return capnp.PipelineStruct[Type_list](p.Pipeline)
 }
 
 func (p Type_list_Promise) ElementType() Type_Promise {
@@ -1983,22 +2247,22 @@ func (p Type_Promise) Enum() Type_enum_Promise { return Type_enum_Promise{p.Pipe
 type Type_enum_Promise struct{ *capnp.Pipeline }
 
 func (p Type_enum_Promise) Struct() (Type_enum, error) {
-	s, err := p.Pipeline.Struct()
-	return Type_enum{s}, err
+	return capnp.PipelineStruct[Type_enum](p.Pipeline)
 }
 
 func (p Type_enum_Promise) Brand() Brand_Promise {
 	return Brand_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
 }
 
-func (p Type_Promise) StructType() Type_structType_Promise { return Type_structType_Promise{p.Pipeline} }
+func (p Type_Promise) StructType() Type_structType_Promise {
+	return Type_structType_Promise{p.Pipeline}
+}
 
 // Type_structType_Promise is a wrapper for a Type_structType promised by a client call.
 type Type_structType_Promise struct{ *capnp.Pipeline }
 
 func (p Type_structType_Promise) Struct() (Type_structType, error) {
-	s, err := p.Pipeline.Struct()
-	return Type_structType{s}, err
+	return capnp.PipelineStruct[Type_structType](p.Pipeline)
 }
 
 func (p Type_structType_Promise) Brand() Brand_Promise {
@@ -2011,22 +2275,22 @@ func (p Type_Promise) Interface() Type_interface_Promise { return Type_interface
 type Type_interface_Promise struct{ *capnp.Pipeline }
 
 func (p Type_interface_Promise) Struct() (Type_interface, error) {
-	s, err := p.Pipeline.Struct()
-	return Type_interface{s}, err
+	return capnp.PipelineStruct[Type_interface](p.Pipeline)
 }
 
 func (p Type_interface_Promise) Brand() Brand_Promise {
 	return Brand_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
 }
 
-func (p Type_Promise) AnyPointer() Type_anyPointer_Promise { return Type_anyPointer_Promise{p.Pipeline} }
+func (p Type_Promise) AnyPointer() Type_anyPointer_Promise {
+	return Type_anyPointer_Promise{p.Pipeline}
+}
 
 // Type_anyPointer_Promise is a wrapper for a Type_anyPointer promised by a client call.
 type Type_anyPointer_Promise struct{ *capnp.Pipeline }
 
 func (p Type_anyPointer_Promise) Struct() (Type_anyPointer, error) {
-	s, err := p.Pipeline.Struct()
-	return Type_anyPointer{s}, err
+	return capnp.PipelineStruct[Type_anyPointer](p.Pipeline)
 }
 
 func (p Type_anyPointer_Promise) Unconstrained() Type_anyPointer_unconstrained_Promise {
@@ -2037,8 +2301,7 @@ func (p Type_anyPointer_Promise) Unconstrained() Type_anyPointer_unconstrained_P
 type Type_anyPointer_unconstrained_Promise struct{ *capnp.Pipeline }
 
 func (p Type_anyPointer_unconstrained_Promise) Struct() (Type_anyPointer_unconstrained, error) {
-	s, err := p.Pipeline.Struct()
-	return Type_anyPointer_unconstrained{s}, err
+	return capnp.PipelineStruct[Type_anyPointer_unconstrained](p.Pipeline)
 }
 
 func (p Type_anyPointer_Promise) Parameter() Type_anyPointer_parameter_Promise {
@@ -2049,8 +2312,7 @@ func (p Type_anyPointer_Promise) Parameter() Type_anyPointer_parameter_Promise {
 type Type_anyPointer_parameter_Promise struct{ *capnp.Pipeline }
 
 func (p Type_anyPointer_parameter_Promise) Struct() (Type_anyPointer_parameter, error) {
-	s, err := p.Pipeline.Struct()
-	return Type_anyPointer_parameter{s}, err
+	return capnp.PipelineStruct[Type_anyPointer_parameter](p.Pipeline)
 }
 
 func (p Type_anyPointer_Promise) ImplicitMethodParameter() Type_anyPointer_implicitMethodParameter_Promise {
@@ -2061,8 +2323,7 @@ func (p Type_anyPointer_Promise) ImplicitMethodParameter() Type_anyPointer_impli
 type Type_anyPointer_implicitMethodParameter_Promise struct{ *capnp.Pipeline }
 
 func (p Type_anyPointer_implicitMethodParameter_Promise) Struct() (Type_anyPointer_implicitMethodParameter, error) {
-	s, err := p.Pipeline.Struct()
-	return Type_anyPointer_implicitMethodParameter{s}, err
+	return capnp.PipelineStruct[Type_anyPointer_implicitMethodParameter](p.Pipeline)
 }
 
 type Brand struct{ capnp.Struct }
@@ -2080,11 +2341,28 @@ func NewRootBrand(s *capnp.Segment) (Brand, error) {
 	return Brand{st}, err
 }
 
+func AllocateRootBrand(msg *capnp.Message) (Brand, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Brand{st}, err
+}
+
 func ReadRootBrand(msg *capnp.Message) (Brand, error) {
 	root, err := msg.RootPtr()
 	return Brand{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Brand) Equal(other Brand) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Brand) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Brand) String() string {
 	str, _ := text.Marshal(0x903455f06065422b, s.Struct)
 	return str
@@ -2124,6 +2402,13 @@ func NewBrand_List(s *capnp.Segment, sz int32) (Brand_List, error) {
 	return Brand_List{l}, err
 }
 
+// NewRootBrandListMessage creates a message whose root is a new
+// list of n Brand.  The message's segment is sized to fit the list.
+func NewRootBrandListMessage(n int32) (*capnp.Message, Brand_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 1}, n)
+	return msg, Brand_List{l}, err
+}
+
 func (s Brand_List) At(i int) Brand { return Brand{s.List.Struct(i)} }
 
 func (s Brand_List) Set(i int, v Brand) error { return s.List.SetStruct(i, v.Struct) }
@@ -2137,8 +2422,7 @@ func (s Brand_List) String() string {
 type Brand_Promise struct{ *capnp.Pipeline }
 
 func (p Brand_Promise) Struct() (Brand, error) {
-	s, err := p.Pipeline.Struct()
-	return Brand{s}, err
+	return capnp.PipelineStruct[Brand](p.Pipeline)
 }
 
 type Brand_Scope struct{ capnp.Struct }
@@ -2174,11 +2458,28 @@ func NewRootBrand_Scope(s *capnp.Segment) (Brand_Scope, error) {
 	return Brand_Scope{st}, err
 }
 
+func AllocateRootBrand_Scope(msg *capnp.Message) (Brand_Scope, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return Brand_Scope{st}, err
+}
+
 func ReadRootBrand_Scope(msg *capnp.Message) (Brand_Scope, error) {
 	root, err := msg.RootPtr()
 	return Brand_Scope{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Brand_Scope) Equal(other Brand_Scope) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Brand_Scope) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Brand_Scope) String() string {
 	str, _ := text.Marshal(0xabd73485a9636bc9, s.Struct)
 	return str
@@ -2187,6 +2488,16 @@ func (s Brand_Scope) String() string {
 func (s Brand_Scope) Which() Brand_Scope_Which {
 	return Brand_Scope_Which(s.Struct.Uint16(8))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s Brand_Scope) ResetUnion() error {
+	s.Struct.SetUint16(8, 0)
+	if err := s.Struct.ClearPtr(0); err != nil {
+		return err
+	}
+	return nil
+}
 func (s Brand_Scope) ScopeId() uint64 {
 	return s.Struct.Uint64(0)
 }
@@ -2197,7 +2508,7 @@ func (s Brand_Scope) SetScopeId(v uint64) {
 
 func (s Brand_Scope) Bind() (Brand_Binding_List, error) {
 	if s.Struct.Uint16(8) != 0 {
-		panic("Which() != bind")
+		capnp.UnionMismatch(s.Struct, "bind")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Brand_Binding_List{List: p.List()}, err
@@ -2242,6 +2553,13 @@ func NewBrand_Scope_List(s *capnp.Segment, sz int32) (Brand_Scope_List, error) {
 	return Brand_Scope_List{l}, err
 }
 
+// NewRootBrand_ScopeListMessage creates a message whose root is a new
+// list of n Brand_Scope.  The message's segment is sized to fit the list.
+func NewRootBrand_ScopeListMessage(n int32) (*capnp.Message, Brand_Scope_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 16, PointerCount: 1}, n)
+	return msg, Brand_Scope_List{l}, err
+}
+
 func (s Brand_Scope_List) At(i int) Brand_Scope { return Brand_Scope{s.List.Struct(i)} }
 
 func (s Brand_Scope_List) Set(i int, v Brand_Scope) error { return s.List.SetStruct(i, v.Struct) }
@@ -2255,8 +2573,7 @@ func (s Brand_Scope_List) String() string {
 type Brand_Scope_Promise struct{ *capnp.Pipeline }
 
 func (p Brand_Scope_Promise) Struct() (Brand_Scope, error) {
-	s, err := p.Pipeline.Struct()
-	return Brand_Scope{s}, err
+	return capnp.PipelineStruct[Brand_Scope](p.Pipeline)
 }
 
 type Brand_Binding struct{ capnp.Struct }
@@ -2292,11 +2609,28 @@ func NewRootBrand_Binding(s *capnp.Segment) (Brand_Binding, error) {
 	return Brand_Binding{st}, err
 }
 
+func AllocateRootBrand_Binding(msg *capnp.Message) (Brand_Binding, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return Brand_Binding{st}, err
+}
+
 func ReadRootBrand_Binding(msg *capnp.Message) (Brand_Binding, error) {
 	root, err := msg.RootPtr()
 	return Brand_Binding{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Brand_Binding) Equal(other Brand_Binding) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Brand_Binding) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Brand_Binding) String() string {
 	str, _ := text.Marshal(0xc863cd16969ee7fc, s.Struct)
 	return str
@@ -2305,6 +2639,16 @@ func (s Brand_Binding) String() string {
 func (s Brand_Binding) Which() Brand_Binding_Which {
 	return Brand_Binding_Which(s.Struct.Uint16(0))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s Brand_Binding) ResetUnion() error {
+	s.Struct.SetUint16(0, 0)
+	if err := s.Struct.ClearPtr(0); err != nil {
+		return err
+	}
+	return nil
+}
 func (s Brand_Binding) SetUnbound() {
 	s.Struct.SetUint16(0, 0)
 
@@ -2312,7 +2656,7 @@ func (s Brand_Binding) SetUnbound() {
 
 func (s Brand_Binding) Type() (Type, error) {
 	if s.Struct.Uint16(0) != 1 {
-		panic("Which() != type")
+		capnp.UnionMismatch(s.Struct, "type")
 	}
 	p, err := s.Struct.Ptr(0)
 	return Type{Struct: p.Struct()}, err
@@ -2352,6 +2696,13 @@ func NewBrand_Binding_List(s *capnp.Segment, sz int32) (Brand_Binding_List, erro
 	return Brand_Binding_List{l}, err
 }
 
+// NewRootBrand_BindingListMessage creates a message whose root is a new
+// list of n Brand_Binding.  The message's segment is sized to fit the list.
+func NewRootBrand_BindingListMessage(n int32) (*capnp.Message, Brand_Binding_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 1}, n)
+	return msg, Brand_Binding_List{l}, err
+}
+
 func (s Brand_Binding_List) At(i int) Brand_Binding { return Brand_Binding{s.List.Struct(i)} }
 
 func (s Brand_Binding_List) Set(i int, v Brand_Binding) error { return s.List.SetStruct(i, v.Struct) }
@@ -2365,8 +2716,7 @@ func (s Brand_Binding_List) String() string {
 type Brand_Binding_Promise struct{ *capnp.Pipeline }
 
 func (p Brand_Binding_Promise) Struct() (Brand_Binding, error) {
-	s, err := p.Pipeline.Struct()
-	return Brand_Binding{s}, err
+	return capnp.PipelineStruct[Brand_Binding](p.Pipeline)
 }
 
 func (p Brand_Binding_Promise) Type() Type_Promise {
@@ -2457,11 +2807,28 @@ func NewRootValue(s *capnp.Segment) (Value, error) {
 	return Value{st}, err
 }
 
+func AllocateRootValue(msg *capnp.Message) (Value, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return Value{st}, err
+}
+
 func ReadRootValue(msg *capnp.Message) (Value, error) {
 	root, err := msg.RootPtr()
 	return Value{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Value) Equal(other Value) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Value) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Value) String() string {
 	str, _ := text.Marshal(0xce23dcd2d7b00c9b, s.Struct)
 	return str
@@ -2470,6 +2837,21 @@ func (s Value) String() string {
 func (s Value) Which() Value_Which {
 	return Value_Which(s.Struct.Uint16(0))
 }
+
+// ResetUnion zeroes the fields of every member of the union, clearing
+// the objects they point to, and selects the first member.
+func (s Value) ResetUnion() error {
+	s.Struct.SetUint16(0, 0)
+	s.Struct.SetBit(16, false)
+	s.Struct.SetUint8(2, 0)
+	s.Struct.SetUint16(2, 0)
+	s.Struct.SetUint32(4, 0)
+	s.Struct.SetUint64(8, 0)
+	if err := s.Struct.ClearPtr(0); err != nil {
+		return err
+	}
+	return nil
+}
 func (s Value) SetVoid() {
 	s.Struct.SetUint16(0, 0)
 
@@ -2477,7 +2859,7 @@ func (s Value) SetVoid() {
 
 func (s Value) Bool() bool {
 	if s.Struct.Uint16(0) != 1 {
-		panic("Which() != bool")
+		capnp.UnionMismatch(s.Struct, "bool")
 	}
 	return s.Struct.Bit(16)
 }
@@ -2489,7 +2871,7 @@ func (s Value) SetBool(v bool) {
 
 func (s Value) Int8() int8 {
 	if s.Struct.Uint16(0) != 2 {
-		panic("Which() != int8")
+		capnp.UnionMismatch(s.Struct, "int8")
 	}
 	return int8(s.Struct.Uint8(2))
 }
@@ -2501,7 +2883,7 @@ func (s Value) SetInt8(v int8) {
 
 func (s Value) Int16() int16 {
 	if s.Struct.Uint16(0) != 3 {
-		panic("Which() != int16")
+		capnp.UnionMismatch(s.Struct, "int16")
 	}
 	return int16(s.Struct.Uint16(2))
 }
@@ -2513,7 +2895,7 @@ func (s Value) SetInt16(v int16) {
 
 func (s Value) Int32() int32 {
 	if s.Struct.Uint16(0) != 4 {
-		panic("Which() != int32")
+		capnp.UnionMismatch(s.Struct, "int32")
 	}
 	return int32(s.Struct.Uint32(4))
 }
@@ -2525,7 +2907,7 @@ func (s Value) SetInt32(v int32) {
 
 func (s Value) Int64() int64 {
 	if s.Struct.Uint16(0) != 5 {
-		panic("Which() != int64")
+		capnp.UnionMismatch(s.Struct, "int64")
 	}
 	return int64(s.Struct.Uint64(8))
 }
@@ -2537,7 +2919,7 @@ func (s Value) SetInt64(v int64) {
 
 func (s Value) Uint8() uint8 {
 	if s.Struct.Uint16(0) != 6 {
-		panic("Which() != uint8")
+		capnp.UnionMismatch(s.Struct, "uint8")
 	}
 	return s.Struct.Uint8(2)
 }
@@ -2549,7 +2931,7 @@ func (s Value) SetUint8(v uint8) {
 
 func (s Value) Uint16() uint16 {
 	if s.Struct.Uint16(0) != 7 {
-		panic("Which() != uint16")
+		capnp.UnionMismatch(s.Struct, "uint16")
 	}
 	return s.Struct.Uint16(2)
 }
@@ -2561,7 +2943,7 @@ func (s Value) SetUint16(v uint16) {
 
 func (s Value) Uint32() uint32 {
 	if s.Struct.Uint16(0) != 8 {
-		panic("Which() != uint32")
+		capnp.UnionMismatch(s.Struct, "uint32")
 	}
 	return s.Struct.Uint32(4)
 }
@@ -2573,7 +2955,7 @@ func (s Value) SetUint32(v uint32) {
 
 func (s Value) Uint64() uint64 {
 	if s.Struct.Uint16(0) != 9 {
-		panic("Which() != uint64")
+		capnp.UnionMismatch(s.Struct, "uint64")
 	}
 	return s.Struct.Uint64(8)
 }
@@ -2585,7 +2967,7 @@ func (s Value) SetUint64(v uint64) {
 
 func (s Value) Float32() float32 {
 	if s.Struct.Uint16(0) != 10 {
-		panic("Which() != float32")
+		capnp.UnionMismatch(s.Struct, "float32")
 	}
 	return math.Float32frombits(s.Struct.Uint32(4))
 }
@@ -2597,7 +2979,7 @@ func (s Value) SetFloat32(v float32) {
 
 func (s Value) Float64() float64 {
 	if s.Struct.Uint16(0) != 11 {
-		panic("Which() != float64")
+		capnp.UnionMismatch(s.Struct, "float64")
 	}
 	return math.Float64frombits(s.Struct.Uint64(8))
 }
@@ -2609,7 +2991,7 @@ func (s Value) SetFloat64(v float64) {
 
 func (s Value) Text() (string, error) {
 	if s.Struct.Uint16(0) != 12 {
-		panic("Which() != text")
+		capnp.UnionMismatch(s.Struct, "text")
 	}
 	p, err := s.Struct.Ptr(0)
 	return p.Text(), err
@@ -2635,7 +3017,7 @@ func (s Value) SetText(v string) error {
 
 func (s Value) Data() ([]byte, error) {
 	if s.Struct.Uint16(0) != 13 {
-		panic("Which() != data")
+		capnp.UnionMismatch(s.Struct, "data")
 	}
 	p, err := s.Struct.Ptr(0)
 	return []byte(p.Data()), err
@@ -2656,7 +3038,7 @@ func (s Value) SetData(v []byte) error {
 
 func (s Value) List() (capnp.Pointer, error) {
 	if s.Struct.Uint16(0) != 14 {
-		panic("Which() != list")
+		capnp.UnionMismatch(s.Struct, "list")
 	}
 	return s.Struct.Pointer(0)
 }
@@ -2685,7 +3067,7 @@ func (s Value) SetListPtr(v capnp.Ptr) error {
 
 func (s Value) Enum() uint16 {
 	if s.Struct.Uint16(0) != 15 {
-		panic("Which() != enum")
+		capnp.UnionMismatch(s.Struct, "enum")
 	}
 	return s.Struct.Uint16(2)
 }
@@ -2697,7 +3079,7 @@ func (s Value) SetEnum(v uint16) {
 
 func (s Value) StructValue() (capnp.Pointer, error) {
 	if s.Struct.Uint16(0) != 16 {
-		panic("Which() != structValue")
+		capnp.UnionMismatch(s.Struct, "structValue")
 	}
 	return s.Struct.Pointer(0)
 }
@@ -2731,7 +3113,7 @@ func (s Value) SetInterface() {
 
 func (s Value) AnyPointer() (capnp.Pointer, error) {
 	if s.Struct.Uint16(0) != 18 {
-		panic("Which() != anyPointer")
+		capnp.UnionMismatch(s.Struct, "anyPointer")
 	}
 	return s.Struct.Pointer(0)
 }
@@ -2767,6 +3149,13 @@ func NewValue_List(s *capnp.Segment, sz int32) (Value_List, error) {
 	return Value_List{l}, err
 }
 
+// NewRootValueListMessage creates a message whose root is a new
+// list of n Value.  The message's segment is sized to fit the list.
+func NewRootValueListMessage(n int32) (*capnp.Message, Value_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 16, PointerCount: 1}, n)
+	return msg, Value_List{l}, err
+}
+
 func (s Value_List) At(i int) Value { return Value{s.List.Struct(i)} }
 
 func (s Value_List) Set(i int, v Value) error { return s.List.SetStruct(i, v.Struct) }
@@ -2780,8 +3169,7 @@ func (s Value_List) String() string {
 type Value_Promise struct{ *capnp.Pipeline }
 
 func (p Value_Promise) Struct() (Value, error) {
-	s, err := p.Pipeline.Struct()
-	return Value{s}, err
+	return capnp.PipelineStruct[Value](p.Pipeline)
 }
 
 func (p Value_Promise) List() *capnp.Pipeline {
@@ -2811,11 +3199,28 @@ func NewRootAnnotation(s *capnp.Segment) (Annotation, error) {
 	return Annotation{st}, err
 }
 
+func AllocateRootAnnotation(msg *capnp.Message) (Annotation, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return Annotation{st}, err
+}
+
 func ReadRootAnnotation(msg *capnp.Message) (Annotation, error) {
 	root, err := msg.RootPtr()
 	return Annotation{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s Annotation) Equal(other Annotation) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s Annotation) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s Annotation) String() string {
 	str, _ := text.Marshal(0xf1c8950dab257542, s.Struct)
 	return str
@@ -2888,6 +3293,13 @@ func NewAnnotation_List(s *capnp.Segment, sz int32) (Annotation_List, error) {
 	return Annotation_List{l}, err
 }
 
+// NewRootAnnotationListMessage creates a message whose root is a new
+// list of n Annotation.  The message's segment is sized to fit the list.
+func NewRootAnnotationListMessage(n int32) (*capnp.Message, Annotation_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 2}, n)
+	return msg, Annotation_List{l}, err
+}
+
 func (s Annotation_List) At(i int) Annotation { return Annotation{s.List.Struct(i)} }
 
 func (s Annotation_List) Set(i int, v Annotation) error { return s.List.SetStruct(i, v.Struct) }
@@ -2901,8 +3313,7 @@ func (s Annotation_List) String() string {
 type Annotation_Promise struct{ *capnp.Pipeline }
 
 func (p Annotation_Promise) Struct() (Annotation, error) {
-	s, err := p.Pipeline.Struct()
-	return Annotation{s}, err
+	return capnp.PipelineStruct[Annotation](p.Pipeline)
 }
 
 func (p Annotation_Promise) Brand() Brand_Promise {
@@ -3013,11 +3424,28 @@ func NewRootCodeGeneratorRequest(s *capnp.Segment) (CodeGeneratorRequest, error)
 	return CodeGeneratorRequest{st}, err
 }
 
+func AllocateRootCodeGeneratorRequest(msg *capnp.Message) (CodeGeneratorRequest, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return CodeGeneratorRequest{st}, err
+}
+
 func ReadRootCodeGeneratorRequest(msg *capnp.Message) (CodeGeneratorRequest, error) {
 	root, err := msg.RootPtr()
 	return CodeGeneratorRequest{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s CodeGeneratorRequest) Equal(other CodeGeneratorRequest) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s CodeGeneratorRequest) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s CodeGeneratorRequest) String() string {
 	str, _ := text.Marshal(0xbfc546f6210ad7ce, s.Struct)
 	return str
@@ -3082,6 +3510,13 @@ func NewCodeGeneratorRequest_List(s *capnp.Segment, sz int32) (CodeGeneratorRequ
 	return CodeGeneratorRequest_List{l}, err
 }
 
+// NewRootCodeGeneratorRequestListMessage creates a message whose root is a new
+// list of n CodeGeneratorRequest.  The message's segment is sized to fit the list.
+func NewRootCodeGeneratorRequestListMessage(n int32) (*capnp.Message, CodeGeneratorRequest_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 0, PointerCount: 2}, n)
+	return msg, CodeGeneratorRequest_List{l}, err
+}
+
 func (s CodeGeneratorRequest_List) At(i int) CodeGeneratorRequest {
 	return CodeGeneratorRequest{s.List.Struct(i)}
 }
@@ -3099,8 +3534,7 @@ func (s CodeGeneratorRequest_List) String() string {
 type CodeGeneratorRequest_Promise struct{ *capnp.Pipeline }
 
 func (p CodeGeneratorRequest_Promise) Struct() (CodeGeneratorRequest, error) {
-	s, err := p.Pipeline.Struct()
-	return CodeGeneratorRequest{s}, err
+	return capnp.PipelineStruct[CodeGeneratorRequest](p.Pipeline)
 }
 
 type CodeGeneratorRequest_RequestedFile struct{ capnp.Struct }
@@ -3118,11 +3552,28 @@ func NewRootCodeGeneratorRequest_RequestedFile(s *capnp.Segment) (CodeGeneratorR
 	return CodeGeneratorRequest_RequestedFile{st}, err
 }
 
+func AllocateRootCodeGeneratorRequest_RequestedFile(msg *capnp.Message) (CodeGeneratorRequest_RequestedFile, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return CodeGeneratorRequest_RequestedFile{st}, err
+}
+
 func ReadRootCodeGeneratorRequest_RequestedFile(msg *capnp.Message) (CodeGeneratorRequest_RequestedFile, error) {
 	root, err := msg.RootPtr()
 	return CodeGeneratorRequest_RequestedFile{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s CodeGeneratorRequest_RequestedFile) Equal(other CodeGeneratorRequest_RequestedFile) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s CodeGeneratorRequest_RequestedFile) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s CodeGeneratorRequest_RequestedFile) String() string {
 	str, _ := text.Marshal(0xcfea0eb02e810062, s.Struct)
 	return str
@@ -3189,6 +3640,13 @@ func NewCodeGeneratorRequest_RequestedFile_List(s *capnp.Segment, sz int32) (Cod
 	return CodeGeneratorRequest_RequestedFile_List{l}, err
 }
 
+// NewRootCodeGeneratorRequest_RequestedFileListMessage creates a message whose root is a new
+// list of n CodeGeneratorRequest_RequestedFile.  The message's segment is sized to fit the list.
+func NewRootCodeGeneratorRequest_RequestedFileListMessage(n int32) (*capnp.Message, CodeGeneratorRequest_RequestedFile_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 2}, n)
+	return msg, CodeGeneratorRequest_RequestedFile_List{l}, err
+}
+
 func (s CodeGeneratorRequest_RequestedFile_List) At(i int) CodeGeneratorRequest_RequestedFile {
 	return CodeGeneratorRequest_RequestedFile{s.List.Struct(i)}
 }
@@ -3206,8 +3664,7 @@ func (s CodeGeneratorRequest_RequestedFile_List) String() string {
 type CodeGeneratorRequest_RequestedFile_Promise struct{ *capnp.Pipeline }
 
 func (p CodeGeneratorRequest_RequestedFile_Promise) Struct() (CodeGeneratorRequest_RequestedFile, error) {
-	s, err := p.Pipeline.Struct()
-	return CodeGeneratorRequest_RequestedFile{s}, err
+	return capnp.PipelineStruct[CodeGeneratorRequest_RequestedFile](p.Pipeline)
 }
 
 type CodeGeneratorRequest_RequestedFile_Import struct{ capnp.Struct }
@@ -3225,11 +3682,28 @@ func NewRootCodeGeneratorRequest_RequestedFile_Import(s *capnp.Segment) (CodeGen
 	return CodeGeneratorRequest_RequestedFile_Import{st}, err
 }
 
+func AllocateRootCodeGeneratorRequest_RequestedFile_Import(msg *capnp.Message) (CodeGeneratorRequest_RequestedFile_Import, error) {
+	st, err := capnp.AllocateRoot(msg, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return CodeGeneratorRequest_RequestedFile_Import{st}, err
+}
+
 func ReadRootCodeGeneratorRequest_RequestedFile_Import(msg *capnp.Message) (CodeGeneratorRequest_RequestedFile_Import, error) {
 	root, err := msg.RootPtr()
 	return CodeGeneratorRequest_RequestedFile_Import{root.Struct()}, err
 }
 
+// Equal reports whether s and other hold the same values.
+// See capnp.Equal.
+func (s CodeGeneratorRequest_RequestedFile_Import) Equal(other CodeGeneratorRequest_RequestedFile_Import) (bool, error) {
+	return capnp.Equal(s.Struct, other.Struct)
+}
+
+// Hash64 returns a hash of the values in s that is the same for every
+// struct that s is Equal to.  See capnp.Hash64.
+func (s CodeGeneratorRequest_RequestedFile_Import) Hash64() (uint64, error) {
+	return capnp.Hash64(s.Struct)
+}
+
 func (s CodeGeneratorRequest_RequestedFile_Import) String() string {
 	str, _ := text.Marshal(0xae504193122357e5, s.Struct)
 	return str
@@ -3271,6 +3745,13 @@ func NewCodeGeneratorRequest_RequestedFile_Import_List(s *capnp.Segment, sz int3
 	return CodeGeneratorRequest_RequestedFile_Import_List{l}, err
 }
 
+// NewRootCodeGeneratorRequest_RequestedFile_ImportListMessage creates a message whose root is a new
+// list of n CodeGeneratorRequest_RequestedFile_Import.  The message's segment is sized to fit the list.
+func NewRootCodeGeneratorRequest_RequestedFile_ImportListMessage(n int32) (*capnp.Message, CodeGeneratorRequest_RequestedFile_Import_List, error) {
+	msg, l, err := capnp.NewRootCompositeListMessage(capnp.ObjectSize{DataSize: 8, PointerCount: 1}, n)
+	return msg, CodeGeneratorRequest_RequestedFile_Import_List{l}, err
+}
+
 func (s CodeGeneratorRequest_RequestedFile_Import_List) At(i int) CodeGeneratorRequest_RequestedFile_Import {
 	return CodeGeneratorRequest_RequestedFile_Import{s.List.Struct(i)}
 }
@@ -3288,265 +3769,265 @@ func (s CodeGeneratorRequest_RequestedFile_Import_List) String() string {
 type CodeGeneratorRequest_RequestedFile_Import_Promise struct{ *capnp.Pipeline }
 
 func (p CodeGeneratorRequest_RequestedFile_Import_Promise) Struct() (CodeGeneratorRequest_RequestedFile_Import, error) {
-	s, err := p.Pipeline.Struct()
-	return CodeGeneratorRequest_RequestedFile_Import{s}, err
-}
-
-const schema_a93fc509624c72d9 = "x\xda\xacY}\x94\x14\xd5\x95\xbf\xf7U\x7f\xccG\x17" +
-	"\xdd5\xaf\x10\x8784\xa8\xa8\x10\x9d\xc0\x0c\xb08\x91" +
-	"\x1d\x18\x18\x0c,\x90)\x1aP8\xeb\x095\xd35L" +
-	"\x99\x9e\xea\xa6\xbaZi\x16\xcf\x80'\x1e\xb3\xee\xba~" +
-	"\xacD\x97$\x9el\x96\x9c\x13W\xdd\xc0\xae\x9c\x13\x89" +
-	"Y\xcd\x1cY\x81\x83\x89\xee\x9acHt7xBt" +
-	"\xd9e\x85]P\x91\x8f\xdas_UW\xd74=\xd1" +
-	"\x9c\xf8WW\xdf\xfb\xea\xbd\xfb\xee\xbd\xbf\xfbU\xb3>" +
-	"\xdf\xbc\x90\xcd\x8e\xa6'\x00h\xbb\xa31\xf7\xf1\x13\x1b" +
-	"\x9b\xa6\xdf\xfc\xee\xfd\xa0\xb5\xa0\xe4n<r\xee\xd8\xc9" +
-	"-\xc5\xd7`\"\xc6\x11\x80\x9f\x8d\xed\x07\xe4gc\xdd" +
-	"\x80\xee\xbay\xeb/\x95\xbf\xf2\xc5\xbf\x02\xad\x0d%\xf7" +
-	"\xf4\xb2?\xfe\xee{\xdd\xb7\x8d\xc2Z\x8cc\x04\xa3\x9d" +
-	"\xad\xf1\x0d\x08\xc8\xa7\xc7\xdf\x05t?\xdfcl<\xb5" +
-	"v\xce\xc3\xa0\xc8\xe8\x1e\xb5W\xf47\x1e\xe8~\x0a\xa2" +
-	"\x18\x07\xe0\x17\xe3\xbbx\xb4\xe1z\x00\xde\xda\xd0\x0d\xf8" +
-	"\xe2\xf6\x95\x9d_|\xe7\xc8NMF)\xb44JK" +
-	"\x975|\x97k\x0dq\x80\xce\x95\x0d\xaf \xa0\xbbr" +
-	"\xd7\x9a\xb7\xfek\xdb\x03\x8f\x83&cxcF\xab\xd7" +
-	"6\xed\xe7w4\xd1\xd3\xfa\xa6\x1f\x00\xba-\xaf\\\xda" +
-	"\xf6\xcf+\xf6>\x0e\x0a\x8f\xb8_9\xb3<\xbd\xbb\xef" +
-	"\xd9]\x00\xd8\xd9\xd8\xdc\x82\xbc\xb59\x0e\x90Q\x9b%" +
-	"\xccLmf\x08P]2V\x94\xdeH\x9ca\x84+" +
-	"\xcd\xbbxk\xf3$\x80\xce\xe9\xcd\x0f\x91,\xf7-\xd7" +
-	"\xcf\\\xfb\xe1\xeb\xdf\xae\xd1\x87\xa7\xb9\xcer\xa2\x8b\xd4" +
-	"\xb1#q7\xa0\xfb\xd4\xfd#\x937o\x99\xf0d}" +
-	"%\x1fK\x90\x92\x8f\x89\x95s\x97\x9c\xff\xa3o\xee\xfd" +
-	"\x8eX\x19u'\xed\xbe\xe6\xdc\x8a\xa7\xef\xfd-L\x8c" +
-	"\x89\x95\x0b\xe4\xc3\x80\x9d\x8bdq\xfeGo\xee~\xed" +
-	"\xc9\x99\xdfx\xaaV\x17B\xc9\xe7&\x8crL\x0au" +
-	"O\xa0}\x0f}u\xe0\xa9\xfb\xe6\xbc\xf94h\x1cY" +
-	"\xd5<\xbd(\xee\xb6>y\x98\x1bb\xb5\x9e$\xcd\xfd" +
-	"\xdb\xbf\xacx\x7fc\xbe\xeb\x99\xfa\xf26\xa6\x0e\x03r" +
-	"9E\xfb\x1e\xbf\xed\x9a\x96\xbf^\xd4\xf7\x0f\xa0\xcdB" +
-	"\xbc\xd8\xbf\xa3}\xcf\x84\x13?\x13\"t\x9a\xa9\xfd\xc8" +
-	"w\xa4h\xd7{\xc4\xda\xa9\x8f\xc9[\x9f\xf9\xde\x03{" +
-	"\xeb\xdf\xedhj\x14\x90\x1f\x15+\x9f8\xdb\xb9x\xde" +
-	"?-\xdfW\x7f\xe5\\\x85\xf45W!\xa7\xdc\xfbw" +
-	"\xc9\xd3G>\xb7\xfayPZ\xb0\xba\xd0\xd3\x81\xa9\xbc" +
-	"\xc3K\x0a=m\x16k\x9dw\xd6%Z\x0e\xbd\xbf\xbf" +
-	"\xbe\xc1\x1eQ\xfe\x9e\x0c\xf6\xb7b\xe9o\x9b\xef\xbf\x7f" +
-	"\xf4\xe7\x0f\xff\x88\xd4%U\x1dcm$\x8e\x0c\xa3\xfc" +
-	"\x80\xf2K@~H!Y\x7f\xfaf\xd3\xb4\x0f\x96\x1e" +
-	"x\xb1\xc6\xd5\xc9#;ojiA\xbe\xa0e\x12\xb9" +
-	"r\x0b-\x0e\x0e\x1d\xabX\xc2\x90\x84\x11~\xa8\xe5\x04" +
-	" \x7f\xb5\x85l\x90w\x9e\xfb\xea\xad\xd1k^\xae\x11" +
-	"abD\xe8`\x1a'mM\xe3\x04\xb6\x0b\xef>\xf9" +
-	"\x8d+^\x1d8H+q\xacm\x018\xaa\xbf\xe4\xb2" +
-	"JZhTI\x04\xa5\xedWC\xbfz\xf5\xc2\xe1\xfa" +
-	"\xfb\x1a*\xd9\xd6TI\x09\xdfL\xecy\xf3_\xdf\xba" +
-	"\xe6\xa7\xe4`,\x84\x07\x8cs\x00\xfe\xe7\xea.\xfe\x08" +
-	"\xed\xdb\xf9\xa0\xfa\x85\x08\x04\xc6\xd7\xae\xc6\x90R<=" +
-	"Ll\xbd\x17\xf9\x8cV\xd2\xc3\xecV\xba\\p\xf3\x1a" +
-	"\xa8y[\xbf\xdd\xfa(?\xdeJ/\x1ek\xa5\xad\xdd" +
-	"\xebZW\xef\xbf\xe7\xa1\x9d\xaf\x83\"\x87\x04\x01\xe4o" +
-	"\xb7\x1d\xe6\xff\xd9F\x97;\xde\xf6\x0a\xa0\xdb3\x9a\xfc" +
-	"\xf8Gk_\xfcw\xd2\xefe\xfe\xb0~\xca\x09nL" +
-	"\x11^>\x854\x11\xb05\x19\xa3!!b\xf1\x18\xc6" +
-	"\xf8\x8f\xa7<\xca\x0fL\xb9\x9e\x84\x982I\x02t\x1f" +
-	"\x9a6z\xeag\x99\xeb\xdf\xab\xef\x94\x17\xa7\xbd\x03\xc8" +
-	"\xf1j\xda\xf8a\xd6\xb4\xf0\x8d\xd6+\xfe\xbb\xfe\xca\x1d" +
-	"W\x9f\x00\xec\xbc\xef\xea\xff`\x80\xee\x8b\x89\xf3\x1f\x9a" +
-	"\x87\xff\xe2d}\xa4\xed\x9dN\x9b\xee\x9bN\x9b\xf6\x94" +
-	"\xa6?-\xef<x\xban\xe8S\xae\x1b\xe5\xad\xd7\xd1" +
-	"\xd3\xc4\xeb~\x00\xab\xdc\xe2\xc0\x901\xac\xb7\x0f\xa0^" +
-	"\xb0\x0a]k\xca\x85n\xa3=g\x16\x1d-\"E\x00" +
-	"T\x9c\x00\xa0\xc8\xfd\x00ZBB\xedJ\x86\xae\x913" +
-	"\x86\x0d\xcbY\x03\xf1r\xc1\xc0TU\x12@L\x01\x06" +
-	"\x1bF*\x1b\x1a\xed\xbaU\xee\xcb\x9b\x96c\xd8\xed%" +
-	"k o\x15\x1d[7-\xc9\xc8j))\x92p]" +
-	"\x15[\x00\x14\xbd\x07@\xfbS\x09\xb5!\x862^r" +
-	"Ul\x05P\x8c.\x00m\xa3\x84Z\x8e\xa1\xcc.\xba" +
-	"*N\x06P\xcc\x99\x00ZVB\xad\xc0P\x96.\xb8" +
-	"*~\x0e@\x19\xde\x00\xa0\xe5$\xd4\xb60\x1c\xd1\xad" +
-	"\xf2\x9f\x98V\x16b\xddE\xc7.\x0d8\x10K\xd2\xbd" +
-	" \xe6\x0e\xe8\x05\xbd\xdf\xcc\x99 9e\x88\xd5h\xa0" +
-	"\xc7\xd6%+\xab5`(\x12*\x8d\x1dU\xe8(\xd1" +
-	"\x9etf _0FzL+kZ\x9b<ME" +
-	"\x90\x14E\xc26H\xa8]\xcb\xb0\xbbH\x8b\x8a8\x01" +
-	"\xb0OBLU\xb7\x03$b\xcd\xb9+\x0d'>\x94" +
-	"\xcf\xf6!jS\x83\xfd^\xa7k\x1e\x91P\xfb\x05C" +
-	"D\x15\x89\xf6\xf3\xd5\x00\xda\x1b\x12j\xbff\xa8H\xa8" +
-	"\"\x03P\xde\xbe\x17@{KB\xed=\x86J\x94\xa9" +
-	"(\x01(\xc7\x1f\x00\xd0\xde\x93P;\xc3P\x89\xa3\x8a" +
-	"\x11\x00\xe54\xd9\xf2\x94\x84\x99\x042T\"L\xc5(" +
-	"\x01\x1e7\x00d\x1aP\xc2\x8cJ\xf4\x98\xa4b\x8c\xbc" +
-	"\x05\xfb\x012)\xa2_Et\x16Q\x05@Zq\x17" +
-	"@\xe6*\xa2\xcfG\x86IK\x1f60\x01\x0c\x13\x80" +
-	"\xee@>k|\xd9\xce\x1a\x806\xc6\x81a\x1c\xd0-" +
-	"\xe8\xb6>\x9cql,\x0d8\xe4\x13\x80\x8d\xc0\xb0\x11" +
-	"\xd0\xb5\x8db)\xe7d\x1c\xb4+\xac*O\xb7\xac\xbc" +
-	"\xa3;&\xc4\xf3VH\x93\x81\x83\xfb\x9a\x14\x9b\xf7\xd8" +
-	":HV\x16S\xd5\xb8\xe6{\xa4wB\x8f\x0dq\xbd" +
-	".\xdf\x1c.\xe4\xcc\x01\xd3\xc1>\xda\xc7p\x0c\xc9\x0e" +
-	"\x1d\x16\xe4\x8d\xbaf\xeb\xb5J\xdd\xc3\x86\xad[\x0eY" +
-	".\x11X\xae\x97,\xb7PBmE\xd5r\xcb\xc8r" +
-	"_\x92P[C\x9a\xf4-\xa7\x91=\xfa<\xff\xfed" +
-	"5~J\x85Td\x94\x84\x8cKM#\x97m\xb7\xf2" +
-	"K\xcc\xe2\x80m\x0e\x9b\x96n!\x89K\xbb\xcaq\xd7" +
-	"\xbd\xecRKMC\xcae\xb5\x08\x86+#\xdc\xeaV" +
-	"\xb6\x80n\xb1\x89\xa3]\x15\xdcw\x1f\xddw\x8f\x84\xda" +
-	"\x0b\xd5\xfb>O\xf7\xfd\xa1\x84\xda\xcb\xa1\xfb\xfe\x84\xee" +
-	"\xfb\x92\x84\xda[\xbe\xfbJ\x88\xca\xd1G\xab\xee+G" +
-	"\\\x17CIM9>\x13\x98\x1c\xbdD\xc4 #)" +
-	"\xafv\x00\xc3\x18\x86\xf2\xaf\xb2\xaf\x07\xd8g\xa6\xc1\xac" +
-	"\xaf+\xa4{\xae\xd3s%4\xaa\xeaJ\x16sy'" +
-	"\xbd\xc9\xce\x97\x0a#y;kZz\xaeF\xe5\xb5a" +
-	"\xaf\xa0\xdb\xdd\xc2\xb5l\xadA\x8a\xa4\x98\x8a\x1c@\x99" +
-	"A\x11\xefZ\x09\xb5Y\x0c\x15\x8c\xaa\xa8\x02(7m" +
-	"\x05\xd0n\x94P\x9b\xcfpD\xc4\x90e\xd9\x00\x10\x05" +
-	"\xdfC\xa1\xdb^fe\x8d-\xc1\xb5\xea\x85q\xc3*" +
-	"\x0d\x8b\xe3P\xc5$\x1d\xd7U=\x8el4\x91N\xeb" +
-	"\x00\xd0n\x90P\x9b\xc3\xb0\xdb)\x87\x0fK\xf7\xdb\xf5" +
-	"\xf1R9\x8b\x89\xb3V\xe5\xb3F\xbb\x1fb\x81<\"" +
-	"\x15\x171B\xd9g\x03h\xcfI\xa8\xbdD\xb7K\xa8" +
-	"\xd8\x00\xa0\xfc\xf8N\x00\xed\x05\x09\xb5\x83\xe4\x13\xb2\x8a" +
-	"\x8d\x00\xca\x81\x7f\x04\xd0\x0eJ\xa8\xbdA>\xf1k\x15" +
-	"\x9b(\xf6\xf5Tc\x9f\x12I\xaa\xd8L\xc1\x8f\x1c\xe5" +
-	"\x17\x12j\xbf\xa18\xd7\xa0b\x02@9\xb6\x0b@\xfb" +
-	"\x8d\x84\xda)?r\xc9\x00\xca\xc9./\xf8e\"\xc8" +
-	"\xd0\xcd\xea\x8e~[\xde\xceBzq\xbed9\xd5\xb0" +
-	"\xe4\xd9g1$\xc7\x92mc\xd0\xb0m\x03\xb3+\xcc" +
-	"\xa2\xd3k\x0d\xa4\xf3\x14\xe91Y\xad/\x001\x098" +
-	"b\x16o%7@\x04\x86X\xeb7t\x16Vw\x1d" +
-	"\xc3\xfb\xf2\xe0`Q2\x1cl\x00\x86\x0d\x80\xdd\x83\x04" +
-	"\xd2\x90C\x86\xba\x901\x90\xf6\x94\x9e)\x15\x0c{ " +
-	"\xa7\x17\x8b@q\xa7!\xc0\xe1\x8c\xc9cm\x8c56" +
-	"\x96\xcc\xdf\xd7\xbe=\xb4\xaa]d<\x80\x9a \xd7S" +
-	"\x0dr2R\x1e\x17a\x8eB\xc1\x12\x09\xb5\x8d\x94\xb1" +
-	")\x8f\x13\xee\xef\xa0\xb5\xb7K\xa8e/w\xebd\xbf" +
-	"ie\xabW\x0fR\xadw\xf5\x11\xd3\x1a2l\xd3\x09" +
-	"\xa5jV\x05Y\xe0y\xbe\xa3\xa7\xea8\xfa\x15\x7f\xa0" +
-	"\xa3G\xc5q\x8b\xf3Y\xe3V\xc32l\xdd\xc9\xdb\xab" +
-	"\x8d\xcd%\xa3\xe8\xb4\xfb\xbfFv\xa9\x993\xda\xbb\x97" +
-	"\x0d\x17\xf2\xb6\xf3)L2\xb3\xaeI\xc6\x86\xaf:8" +
-	"\x13\xc5\x93wW\xc9\xbf\xeb\xccp\x0c\x89\xa8\xa8\xd4\\" +
-	"6\xe9\xd4\xad\xd3\xd2w\xe9\xb9\x12\xd1\x83\xda\xbd\xe6\xd2" +
-	"X9\xb5\x12I\"\xde\xa1\xa2 \xdc\xe0\x17\x847P" +
-	"Ah\x95D\x06\x04\xc9\x09\xf9o\xd0\x7f\xd7\xf5_q" +
-	"\x19/\xdd\xc6\x1d\xc3&\x85\x85\xaa\xa8\x99~\x15\xa5\xb2" +
-	"q4\x12\xad\x1bd+y|\xa5\xe1P%E\xbb'" +
-	"E\xc8%\xc9\xa3*^I{o\x0d\x95\xb2\x9f\x18M" +
-	"Y(\x7f\x8a@/\xe99R\xbe\xa8Y)\x9c\xcdX" +
-	"^Ut\x1b^rc^@\x9bM\xe4Y\x12j\xb7" +
-	"\xb0jy\x01\x10w\x8d-\x95g\xb8\xec0i</" +
-	"C\x87Rq\xa5kR\xd0v+^\x07i\xe1wa" +
-	"g\xeb\xf0\xdda!\xb9\x83\xefm\x0b\xe8\xd2\xb7H\xa8" +
-	"\xdd\xce0m\xe5\xb3\xe1\xaa4\xe89|;\xd9\x95\xad" +
-	"\xbb\xc5\xd6\xd5\x95\x95\xf3\xeb\xda3d\x8a$\xd9\x82\x82" +
-	"DB\xe4\xf1`\x16\xa4\xf4\xda\xc0\xa8\xae\xc7\xd0@D" +
-	"\x99\xbd\x1a\x18\x95\xf5\x18j\xba\x95\xb6Q`n\xa5S" +
-	"\x80\xb4nZF\xb6j,\xb4\x03\x95FC\xa6&K" +
-	"CE\xaa\x90\xdd(W\x035\x1a)\xbf\x02\xd6\xbb\xaa" +
-	"}\x86\x82^\x01\xac\x183\xabm\x86\xc2\xbc\xeaW1" +
-	")Y\x0dI\xa89\x94\x97\xb6{yi3e\x1bG" +
-	"Bm;\xc3\xee\xfc\xe0`\xb1\x1a\xc4\xc7\x81\x9a\x9b5" +
-	"\x06\xf5R\xceY\x07\xc9q07\xa4g{\xc93p" +
-	"\xc0t\x96\xd0b)\xe7\x04)\xa5^8\x16\xad\x87d" +
-	"m\xf2#M\xc2u=\xeb\x87\x0a\x0a\xaf\x85\xaa\x0d6" +
-	"#%\xab?_\xa2\xb6h<a\xeb\xf9\xbe(u\x00" +
-	"\x04\x90\x98\x97\xdd\x83VG\xbd,\x9e\xd6\x84\x90uz" +
-	"N*\x19$\xe9\x8a@R\x1ee3\x01V3\xeaC" +
-	"\x98\x07\x9d\x94\x10\x967\x12#\x13!N\x8a8\xec\xa2" +
-	"\xcbD\x06\xe1\xb2\xe04\x10G%\x8et\xc1E\xd1\xe8" +
-	"p\x85u\x00d\x12\xc4\xb9\x928\x91\xf3\xaegk>" +
-	"QpR\xc4\xb9\x8a8\xd1\x8f\x89C\x1dO\xab\xe0\xa8" +
-	"\xc4\x99J\x9c\xd89:\x87z\x9e6\xc1\xb9\x928\xd7" +
-	"\x12'\xfe\x11\xbdC]\xcf4\xd6E]\x0fqn " +
-	"N\xc3\x87\xc4i\x00\xe0\xd3\x05g*qn$N\xe3" +
-	"\x07\xc4i\x04\xe03\x04\xe7Z\xe2\xcc\"N\xd3Y\xe2" +
-	"4\x01\xf0\x9bX\x0f@\xe6\x06\xe2\xcc!N\xf3\x19\xe2" +
-	"4\x03\xf0\xd9\x82s#q\xe63\x86r\xe2\xff\\Q" +
-	"\xe9\xf0\xb9B\x05\xb3\x88q\x0b1\xe4\xffuE\xb5\xc3" +
-	"o\x16\x8c9\xc4XH\x8c\x09\xa7]\x11\xa9\xf9\x02\xc1" +
-	"\x98O\x8c%tH\xf2\x94\xebU\x83|\x91\xe0\xdcB" +
-	"\x9c/\xd1+\xa9\xf7]\x91Qx/\xeb\xe2\xbd,\x9d" +
-	"\x19\"\x96C,\xe5\x7f\\\x91W\xf8f\xb6\x1a S" +
-	" \xc66b\xb4\x9c\xf4zw^f\xd4?n!\xc6" +
-	"\xd7\x18\xc3\xe4]y\x93\\\xac?\x9f\xcfU\xfc8i" +
-	"Z\xce|d\xc0\x90\x01\xa6M\xcb\x99=\x0f%`(" +
-	"y\xff:;0\x02\x0c#\xde\xbfys0\x0a\x0c\xa3" +
-	"\x80\xe9\x92x/F\xe5>`w\xc9{\xd1\x8f\x9d\xe2" +
-	"ogGPD\x95\xbcW}?\x1c\x19\xcc\xe5ub" +
-	"7\x01\xc3\xa6\xca\xffys\xb0\x19\x186\x13\\\x8d-" +
-	"N%\xb7$\xa9DD\x19\x18\xca\x80bR\x80-\xc0" +
-	"\xb0\x050I\x09.8\xd1\xab7\xb4\x082\xf7\xf4\x83" +
-	"_\x98\xd4\xb2\xf1\xf9Q\xd0\"\x0c\x17\xa9(\x8aQ\xec" +
-	"w\xbd%\xebt\x88\xe7J\xd4\xcd\xfa\xfb\xb8\"K\x0d" +
-	"\xea\x03\x80\x06\xc4\xdcJ\xe2\x02\xc9\xb0\x83%\xbfo\xc5" +
-	"\x01\xa2I\x0bF\xab\x0av\xf9%H\xb8J\x9b\\\xa7" +
-	"\x15]^\xaf\x15\xa5\xe8\xb1\xc2\x0b\x8c\xa1\xa2\xc4\x1d4" +
-	"s\x06\xa5aJZ\xbe\xb6FLqJ(\x8f\x042" +
-	"\xd4m\x93\xd7\x94\x0b\xf8\xbbC@\x10\xaej#\x807" +
-	"\xf4\xa9\x13\x00\xbc\xb9O\x1d\xfc\xcb\x91\xf3n]\xf8\xcb" +
-	"\xd1\x8f\xdd\xba\xe8\x97c\xe7\xdc\xba\xe0\x97\xe3\x1f\xb9u" +
-	"\xb1/7|\xe8\xd6\x85\xbe\xdc\xf8\x81[\x17\xf9r\xd3" +
-	"Y\xb7.\xf0\xe5\xe63\xeeg\x88{\xc4\xea\x97\x1a\x01" +
-	"\x7f&\x13\xe8C\x9f\x16\xf8tAM\xbdO\xd4`\x80" +
-	"\xcfe\xd6\xc5e\x96\x06\x81w\x0c\x0d\x1cy\x1b\xa3," +
-	"\xddr\x92\xa8\xc1\\\x9a7\xb2\x0d\xc0\xc2H\x87\x98\xc0" +
-	"8\xc4<t{\xbf\x9d\x1d\xde\xef\xbc9\x10\xf3\xb0\x0c" +
-	"1\x1f\xc5\xfe\x03\xad\xf0\x91\x0b\xb1\x0af+OD\x13" +
-	"8\x85\x98@\xa8?\xc5\x13\x98\xfcd,n\xf0\xb1\xb8" +
-	"\xa6\x0c\x12\xb5/a\x08\x8e\x01`M\xd6\xeb\xf5f\x9c" +
-	"\x19s\xab\xdf\xf3L\x15\x00Y\xdfA\xde\xadhW\x03" +
-	" \x13}\x0eJ\xca\xa2\xe5\x00\x18Q\x16\xac\x06\xc0\xa8" +
-	"r\xf3\x06\x00\x8c)s{\xa8\xc0Sn\xba\x17 m" +
-	"\x0c\x17\x9cr\xbc\xdft\x92\xfde\xc7p\x9d\xbb\xf3=" +
-	"e\xc7(\x02\x80;\x98/\xd9\xf4\x07\xb0\xe8\x1a\xe6\xa6" +
-	"!\xa7\xa7L\xe2\x14G\xfc\xbe\xd45\xad\x9ci\x19\x8b" +
-	"\xf3\x91\xe1B\xbeh:\xc6\xd8\x02GT\xd1\xabD0" +
-	"H\xd2sM\xdf1\xb3N\xdf19\xd4\x18\x84K\xeb" +
-	"0\xde/\xeb\x00\xd0\x10\xf3\xd0\xea\x17\x94\xc6\xd5\xd5\xf9" +
-	"\xb9\xd2\xb8\xc1\xed\x0b\xd5f\x9e<\xab\xf2 e\x0dm" +
-	"~E\x1a^\xc6\xc9\x00\x19\x07%\xccl\xc7@ ~" +
-	"\x8f\x180n#\xf2\xd7\xc5\x80\xd1O\xf6\xf7\xe1(@" +
-	"\xe6\xebD\x7f\x8c\xe8\x927\xd3\xe4\x8f A\xe4/\x89" +
-	"\xfe\x84\x18T\xfa\x89~\xa7\xd8\xe71\xa2\xef!z\xdc" +
-	"\x1fl>+\xe8\xcf\x10\xfd \x12l]\x1f\xe8\x07\x90" +
-	"\x90\xf3\x121\x8e\x10\xa3Q\x14\xa6\xc1G5~\x08\xbb" +
-	"\xf8!$44\x89\xea4\xf8\xd0\xc4\x8f\"\xa1\xa7\xf9" +
-	"\x02Q\x83I?\xff\x09\x12F\x12\xe7\x89\x1a|\xbe\xe2" +
-	"\xcfb\x070Y\xfe\x98\xa8\xc1\xac\x9f\xef\xc4\x0d\xc0\x94" +
-	"HT\xc5\xa9\x00|\x87\x98\xbdn'I\xbe\x85\x0cg" +
-	"G\xa7\xa2\x8a\xd3\x00\xf8\xdf e\xdb'\x88\xb1\x1b\xc7" +
-	"\x86\xe4\xacY,\xe4\xf4\xf2*\x88\x87\xdb\xa3\x0a\x95\xe9" +
-	"\xc3F\x9fm\x0c\x9a[V\x18\xd6&g\x08*\x09\xf2" +
-	"\xb2y\x92U1V|LS\x10\x18\xd7\x0f\xe6\x9fn" +
-	"h\x96\xa4D\x11\xcc\xdb?\x05,='\x01\x10h\x0e" +
-	"c3-J\xff\xea\xb1R\xde\xaa\xd6\xff\xbfs:k" +
-	"\x16E\xc24\x01\x07\xc6)\xa0\x05h\xbc\xb3\xe2\xfa\x80" +
-	"Q\xe9\xa5\x93\xe1\xf2y\xa1\xdfK\xa7\xa9y\xba\xb3\xda" +
-	"<\x8d\x0c\x8b~\xa3z~\xe5\x93t%\xe9\xf9c\x19" +
-	"H\x16\x8ba}\x06\x1f`\xc7o\x86\xbd\xbb&\x1d3" +
-	"ois<\x91\xa8\xb0\xbaC\xb8\xe9\xed\xe4\x03Y\xf2" +
-	"k,\x88\xd1!\xd7\x85_o$zN\xe0f\xb3\x98" +
-	"\x1er\x13\xef\x04\xc8\x0c\x11\xdd\x11\xb8\xb1\xc5\x9c\x8fo" +
-	"\x16\xeb\x0bD\xdf&\x06\xffE1\x16\xe1e|`\x0c" +
-	"\xfe\xa2\x8e\x8a\x93\x04\xfel\x80\xcc\xd7\x88\xfe\xb0\xc0Y" +
-	"I\xf4\xcf\xfcA\xb1\x7f\x15\x7f\xf1\xbb\xc4g\x1a\xbeS" +
-	"\xd0\x05\xfe\xbeC\xf4\x86\xbb\xc5\x87\x1a\xfemA\xff\x16" +
-	"\xd1\xbfO\xf4\xc6-\xe2S\x0d\xff\x9e8\xf7\xfbD\x7f" +
-	"\x8e\xe8Me\x15\xaf\x02\xe0{\xc5\xb9{\x88\xfe\x02\xd1" +
-	"\x9b\xb7\xaa\xd8\x06\xc0\x9f\x17\xfb\xfc\x90\xe8/\x13=\xf1" +
-	"g*N\x01\x82\xdd\xa3\x00\x99\x97\x89\xfe\x1a\x8e;\xec" +
-	"p\x1d\xdd\xded8\xc5\xa5\x107sF\xe0\x18>u" +
-	"1$\xc9\xddj\xc9\xbd\x10\xa7\xc2\xaf\x96\x8a\xfe\xb8\xc3" +
-	"\xa1\xbd\xc7\xf22\x90\x16~]K_\x0aIj\xa3j" +
-	"\xc9k!i\x99y\xab\x96|+$\xc7\x8c\x14}\xf2" +
-	"2\xf4\xf1a\\~\xf0JH\x0b\xc7\xac\xa5\xf7A\x92" +
-	"PSK^\x84>\xb0\xf2h\x8d\x83\x12\xd1\xd0\xd7\xa0" +
-	"\x04\xbd\xe1R\xedtm\xd2g2F^T\x11\xc9\xaa" +
-	"\x1d2\x86\xcbW\xe6\x97\xaf\x1d\xfe\x88\xb1\xcf\x9frP" +
-	"v^\xd9Q\xadi\xc3c\xceq\x06]\xe3\xc9\xf5\xff" +
-	"\x01\x00\x00\xff\xff\\\xb8\xbc\xd5"
+	return capnp.PipelineStruct[CodeGeneratorRequest_RequestedFile_Import](p.Pipeline)
+}
+
+const schema_a93fc509624c72d9 = "x\xda\xacY}\x90T\xd5\x95?\xe7\xbe\xfe\x98\x19\xe6" +
+	"\xd1\xf3\xe66\"\x06v\x00\x19\x81\x01&0\x8d8\x8e" +
+	"\xb8\x03\x03\x83\x81\x053o\x1aP\xa8\xa5\xc2\x9b\xe97" +
+	"\xcc#=\xaf\x9b\xd7\xaf\x95f\xb1\x06\xadP\xa6\xdc\xcd" +
+	"\xea\xba\x12]\x92X\xa9\xacV\xc9\xaa\x1b\xd8\xd5\xaa@" +
+	"t\xd5)]\x91\x85Dv\xa5\x0c\x89\xd9M\xac\x18\x0d" +
+	"\xbb\xac\xb0\x0b*\xf2q\xb7\xce}\xaf\xbb\xdf4=\xd1" +
+	"\xd4Z\xf3\xc7\xbc>\xe7\xbe{\xcf=\xe7\xfc\xce\xd7\x9b" +
+	"\xff\x94\xb6\x84-\x08G\xbb\x00z\x95pD<zj" +
+	"s]\xf3\xcd\xef\xdf\x0fz#*b\xf3\xd1\x0b\xbf>" +
+	"\xbd=\xf7&L\xc0(\x02\xf0\xdfM=\x04\xc8\x7f7" +
+	"\xb5\x13P\xac_\xb4\xe1J\xe1k\xb7\xfc%\xe8SP" +
+	"\x11gW\xfe\xf1\x0f>\xe8\xbcc\x04\xd6a\x14C\x18" +
+	"N\xa8\xd36\" \x9f4\xed}@1\xa7\xcb\xdc|" +
+	"f\xdd\xc2\x87@SQ\x9ctV\xf7\xd5\xbe\xd6\xb9\x0f" +
+	"\xc2\x18\x05\xe0g\xa7\xed\xe5\x17\xa6\xcd\x04\xe0\xea\xf4N" +
+	"\xc0\x97v\xadI\xdc\xf2\xee\xd1=\xba\x8aJ`i\x98" +
+	"\x96\xde:\xfd\x07\xbc{z\x14 \xb1t\xfa\xeb\x08(" +
+	"\xd6\xec]\xfb\xce\x7f\xee|\xe0Q\xd0U\x0cn\xcch" +
+	"\xf5\xca\x19\x87\xb8>\x83\x9e\xd6\xcc\xf8!\xa0h|\xfd" +
+	"\xca\xce\x7fZ}\xe0Q\xd0xH|\xed\xdc\xaa\xa6'" +
+	"z\x9e\xdd\x0b\x80\x89\xcb3\x1a\x91\xab\xcdQ\x80dM" +
+	"\xb3\x82\xc9x3C\x80\xf2\x92\xd1\xa2t\x87\xa2\x0cC" +
+	"<\xdc\xbc\x97\xab\xcd\x13\x01\x12\x93\x9a\x1f$Yv\xaf" +
+	"2\xce\xcd\xf8\xf8\xf8\xf7*\xf4\xe1i.1tC\x07" +
+	"\xa9#\x7f\xc3\xdd\x80b\xdf\xfd\xc3\xd7m\xdb>\xfe\xf1" +
+	"\xeaJ>q\x03)\xf9\x84\\y\xe3\xf2\x8b7}\xe7" +
+	"\xc0\xf7\xe5\xca\xb0\x98\xf8\xc4\xf5\x17V?}\xdfoa" +
+	"BD\xae\\0\xf3\x08`\xe2\xc6\x99\xf2\xfcO\xde~" +
+	"\xe2\xcd\xc7[\xbe\xbd\xafR\x17R\xc9\xa7g\x8d\xf0\xf3" +
+	"\xb3\xa4\xbag\xd1\xbeo|\xbd\x7f\xdf\xee\x85o?\x0d" +
+	":GV6O7\xca\xbb\xad\x99}\x84o\x98M\xab" +
+	"\xd7\xcd&\xcd\xfd\xdb?\xaf\xfeps\xa6\xe3\x99\xea\xf2" +
+	"^\x9e}\x04\x90c\x0b\xed\xfb\xde\x1d\xd77\xfe\xf5\xd2" +
+	"\x9e\xbf\x07}>\xe2\xe5\xbe{[\xf7\x8f?\xf5S)" +
+	"BbS\xcb!\xe4\xf9\x16\xdau\x9b\\;\xf5\x11u" +
+	"\xc73O>p\xa0\xfa\xdd\x8e\xb5\x8c\x00\xf2cr\xe5" +
+	"c\xe7\x13\xcb\x16\xfd\xe3\xaa\xe7\xab\xaf\x9c=\x87\xf45" +
+	"{\x0e9\xe5\x81\xbf\x8d\x9d=\xfa\xa5\xde\x83\xa05b" +
+	"y\xa1\xa7\x83Ms\xde\xe5\xd6\x1cz2\xe5Z\xf7\xdd" +
+	"\xf5\xf5\x8do|x\xa8\xba\xc1v\xcf\xf9;2\xd8\x1e" +
+	"\xb9\xf4\xb7\xe3\xee\xbf\x7f\xe4\xc4C?&u)e\xc7" +
+	"X\x17\x8a\"\xc30?8\xe7\xe7\x80\xfc\xc59$\xeb" +
+	"O\xde\xae\x9b\xf6\xd1\x8a\xd7^\xaapu\xf2\xc8\xc4\xb4" +
+	"\xb9\x8d\xc8\x17\xcc\x9dH\xae<\x97\x16\x97\x0e\x1d\xadX" +
+	"\xc2\x90\x82!\xfe\xe2\xdcS\x80\xfc\x95\xb9d\x83\x8c\xfb" +
+	"\xdc\xd7o\x0b_\xffj\x85\x08\x13BR\x07\x13\xe6\x91" +
+	"\xb6&\xcc#\xb0]z\xff\xf1o_s\xac\xff0\xad" +
+	"\xc4\xd1\xb6\x05\xe0\xe7\xe7\xfd\x9cc+i\xe1\xf2<\x12" +
+	"A\x9b\xf2\x8b\xc1_\x1c\xbbt\xa4\xfa\xbe\x1bZ\xc9\xb6" +
+	"\x9bZI\x09\xdf\xa9\xdf\xff\xf6\xbf\xbes\xfdO\xc8\xc1" +
+	"X\x00\x0f\x18\xe5\x00\xfc\x9e\xd6\xbd|7\xed\x9b\xb8\xb7" +
+	"\xf5\xcb!(\x19_\x9f\x8e\x01\xa5xz\xa8M\xdc\x87" +
+	"|J\x82\xf4\xd0\x9c\xa0\xcb\x95n^\x015o\xeb\xe3" +
+	"\x89\x87\xf9\xc9\x04\xbdx\"A[\x8b\x1b&\xf5\x1e\xba" +
+	"\xe7\xc1=\xc7AS\x03\x82\x00\xf2\xe37\x1d\xe1\xbf\xbc" +
+	"\x89.w\xf2\xa6\xd7\x01E\xd7H\xec\xd3\x1f\xaf{\xe9" +
+	"\xdfI\xbfW\xf9\xc3\x9a\xf6S|C\xbb\xf4\xf2\xf6\xbb" +
+	"!\xc0\xd6U\x0c\x07\x84\x88D#\x18\xe1\x07\xda\x1f\xe6" +
+	"\x07\xdbg\x92\x10\xed\x13\x15@\xf1\xe0\xb4\x913?M" +
+	"\xce\xfc\xa0\xbaS\x9e\xbd\xe5]@~\xfe\x16\xda\xf8!" +
+	"V\xb7\xe4\xadI\xd7\xfcW\xf5\x95\xf9\xc5\xa7\x00\x13\x85" +
+	"\xc5\xff\xc1\x00\xc5K\xf5\x17?\xb6\x8e\xfc\xf9\xe9\xeaH" +
+	"{\xb2\x936\xdd\xd7I\x9bv\xe5\x9b\x9fV\xf7\x1c>" +
+	"[5\xf4\x85\x97\x8cpu\x09=\xd5.\xf9!\x88\xc0" +
+	"\xdf\xbf\x88\\\xff\xa09d\xb4\xf6\xa3\x91\xb5\xb3\x1dk" +
+	"\x0b\xd9N\xb35m\xe5\\=\xa4\x84\x00\xe28\x1e@" +
+	"S\xfb\x00\xf4z\x05\xf5k\x19\x0a3m\x0e\x99\xb6\xbb" +
+	"\x16\xa2\x85\xac\x89\x0de\xa9\x00\xb1\x01\xb0\xb4a\xa8\xb8" +
+	"\xa1\xd9j\xd8\x85\x9e\x8ce\xbb\xa6\xd3\x9a\xb7\xfb3v" +
+	"\xceu\x0c\xcbV\xcc\x94\xde\xa0\x84\xea\x85\x88c#\x80" +
+	"ft\x01\xe8\x7f\xaa\xa0>\xc8P\xc5+\"\x8e\x93\x00" +
+	"4\xb3\x03@\xdf\xac\xa0\x9ef\xa8\xb2\xcb\"\x8e\xd7\x01" +
+	"hV\x0b\x80\x9eRP\xcf2T\x95K\"\x8e_\x02" +
+	"\xd0\x866\x02\xe8i\x05\xf5\xed\x0c\x87\x0d\xbb\xf0'\x96" +
+	"\x9d\x82Hg\xceu\xf2\xfd.Dbt/\x88\x88~" +
+	"#k\xf4Yi\x0b\x14\xb7\x00\x91\x0a\x0dt9\x86b" +
+	"\xa7\xf4\x1a\x0cDE\xad\xb6\xad\x0c#-\xdc\xd5\x94\xec" +
+	"\xcfd\xcd\xe1.\xcbNY\xf6\x16OS!$E\x91" +
+	"\xb05\x0a\xea3\x18v\xe6hQ\x0e\xc7\x03\xf6(\x88" +
+	"\x0d\xe5\xed\x00\x89Xq\xee\x1a\xd3\x8d\x0efR=\x88" +
+	"\xfa\xd4\xd2~\xc7\xe9\x9aG\x15\xd4\x7f\xc6\x101\x8eD" +
+	";\xd1\x0b\xa0\xbf\xa5\xa0\xfe+\x86\x9a\x82qd\x00\xda" +
+	"/\xef\x03\xd0\xdfQP\xff\x80\xa1\x16fqT\x00\xb4" +
+	"\xf7\x1e\x00\xd0?PP?\xc7P\x8bb\x1cC\x00\xda" +
+	"Y\xb2\xe5\x19\x05\x93\xf5\xc8P\x0b\xb18\x86\xc9/p" +
+	"#\xa5;\xa4tG\xf4\x88\x12\xc7\x08\x00\xd7\xb0\x0f " +
+	"\xd9@\xf4\xc9Dg\xa1\xb8\x04\xcb$\xdc\x0b\x90\x9cL" +
+	"\xf4vd\x18\xb3\x8d!\x13\xeb\x81a=\xa0\xe8\xcf\xa4" +
+	"\xcc\xaf:)\x13\xd0\xc1(0\x8c\x02\x8a\xac\xe1\x18C" +
+	"I\xd7\xc1|\xbfK>\x01X\x0b\x0ck\x01\x85c\xe6" +
+	"\xf2i7\xe9\xa2Sd\x95y\x86mg\\\xc3\xb5 " +
+	"\x9a\xb1\x03\x9a,9\xbb\xafI\xb9y\x97c\x80b\xa7" +
+	"\xb0\xa1\x1c\xe3|\x8f\xf4N\xe8r jT\xe5[C" +
+	"\xd9\xb4\xd5o\xb9\xd8C\xfb\x98\xae\xa98\x81\xc3J9" +
+	"\xa4\xaa\xd9\xba\xed|\xe7\x90\xe9\x18\xb6K\x96\xab/Y" +
+	"\xae\x9b,\xb7DA}u\xd9r+\xc9r_QP" +
+	"_K\x9a\xf4-\xa7\x93=z<\xff\xfel5~N" +
+	"\x85\x14eT\xa4\x8c+,3\x9dj\xb53\xcb\xad\\" +
+	"\xbfc\x0dY\xb6a#\x89K\xbb\xaaQ!\xae\xba\xd4" +
+	"\x0a\xcbT\xd2)=\x84\xc1*\x09w\x88\xe2\x16\xd0)" +
+	"7q\xf5\xc9\xa5\xfb>O\xf7\xdd\xaf\xa0\xfeB\xf9\xbe" +
+	"\x07\xe9\xbe?RP\x7f5p\xdfW\xe8\xbe/+\xa8" +
+	"\xbf\xe3\xbb\xaf\x82\xa8\x9d|\xb8\xec\xbejH\x08\x0c$" +
+	"8\xed\xbd\x16`j\xf8\x8a\xc0@v\xd2\x8e\xb5\x01\xc3" +
+	"\x08\x06r\xb1\xf6|\x17\xb0/L\x83)_WH\xf7" +
+	"\\o\xa4\xf3h\x96\xd5\x15\xcb\xa53n\xd3\x16'\x93" +
+	"\xcf\x0eg\x9c\x94e\x1b\xe9\x0a\x95W\x86\xbd\xac\xe1t" +
+	"J\xd7r\xf4\x1a%\xd4\xc0\xe2\xc8\x01\xb4\xd9\x14\xf1f" +
+	"(\xa8\xcfg\xa8a8\x8eq\x00m\xde\x0e\x00}\xae" +
+	"\x82z;\xc3a\x19CV\xa6J\x80\xc8\xfa\x1e\x0a\x9d" +
+	"\xceJ;en/]\xabZ\x187\xed\xfc\x90<\x0e" +
+	"\xe3\x18\xa3\xe3:\xca\xc7\x91\x8d&\xd0im\x00\xfa," +
+	"\x05\xf5\x85\x0c;\xddB\xf0\xb0\xa6>\xa7:^\x8ag" +
+	"1y\xd6\xed\x99\x94\xd9\xea\x87X \x8fh\x88\xca\x18" +
+	"\xa1=\xef\x00\xe8\xcf)\xa8\xbfL\xb7\xab\x8fc\x0d\x80" +
+	"\xf6\xe2V\x00\xfd\x05\x05\xf5\xc3\xe4\x13j\x1ck\x01\xb4" +
+	"\xd7\xfe\x01@?\xac\xa0\xfe\x16\xf9\xc4\xaf\xe2XG\xb1" +
+	"\xaf\xab\x1c\xfb\xb4P,\x8e\xe3(\xf8\x91\xa3\xfcLA" +
+	"\xfd7\x14\xe7j\xe2X\x0f\xa0\xfdz/\x80\xfe\x1b\x05" +
+	"\xf53~\xe4R\x01\xb4\xd3\x1d^\xf0K\x86\x90\xa1H" +
+	"\x19\xaeqG\xc6IA\xd3\xb2L\xdev\xcba\xc9\xb3" +
+	"\xcf2\x88\x8d&;\xe6\x80\xe98&\xa6V[9\xb7" +
+	"\xdb\xeeo\xcaP\xa4\xc7X\xb9\xd6\x00\xc4\x18\xe0\xb0\x95" +
+	"\xbb\x8d\xdc\x00\x11\x18b\xa5\xdf\xd0YX\xdeu\x14\xef" +
+	"\xab\x03\x039\xc5t\xb1\x06\x18\xd6\x00v\x0e\x10H\x03" +
+	"\x0e\x19\xe8Hp\xfcUJO\xe6\xb3\xa6\xd3\x9f6r" +
+	"9\xa0\xb8SS\xc2\xe1\xec\xebF\xdb\x18+l\xacX" +
+	"\x7f\xa8}\xbbhU\xab\xccx\x00\x15A\xae\xab\x1c\xe4" +
+	"T\xa4<.\xc3\x1c\x85\x82\xe5\x0a\xea\x9b)c_\x11" +
+	"\x1e\xee7\xd1\xda;\x15\xd4SW\xbbu\xac\xcf\xb2S" +
+	"\xe5\xab\x97R\xadw\xf5a\xcb\x1e4\x1d\xcb\x85H\x85" +
+	"d\x12d%\xcf\xf3\x1d\xbd\xa1\x8a\xa3_\xf3\xfft\xf4" +
+	"\xb0<nY&e\xdef\xda\xa6c\xb8\x19\xa7\xd7\xdc" +
+	"\x967sn\xab\xff\xdfL\xad\xb0\xd2fk\xe7\xca\xa1" +
+	"l\xc6q?\x87IZ\xaa\x9adt\xf8\xaa\x823Y" +
+	"<ywU\xfc\xbb\xb6\x04cH(\x8eZ\xc5ec" +
+	"n\xd5:\xad\xe9.#\x9d'z\xa9\x8e\xaf\xb84\x16" +
+	"O-F\x92\x90w\xa8,\x087\xfa\x05\xe1,*\x08" +
+	"\xed\xbc\xcc\x80\xa0\xb8\x01\xff-\xf5\xe2U\xfdW^\xc6" +
+	"K\xb7Q\xd7tHa\x81*\xaa\xc5\xaf\xa2\xe2l\x0c" +
+	"\x8d\x84\xab\x06\xd9b\x1e_c\xbaTI\xd1\xee1\x19" +
+	"rI\xf2p\x1c\xaf\xa5\xbdw\x04J\xd9\xcf\x8c\xa6," +
+	"\x90?e\xa0W\x8c\xb4^\xe3\xd7\xac\x14\xcef\xaf*" +
+	"+z\x0a^\x11\x11/\xa0- \xf2|\x05\xf5\xc5\xac" +
+	"\\^\x00D\x85\xb9\xbd\xf8\x0cW\x1d\xa6\x8c\xe5e\xe8" +
+	"R*.vP\x1a:\xa2\xe8u\xd0$\xfd.\xe8l" +
+	"m\xbe;,!w\xf0\xbd\xedV\xba\xf4b\x05\xf5;" +
+	"\x196\xd9\x99T\xb0*-\xf5\x1f\xbe\x9d\x9c\xe2\xd6\x9d" +
+	"r\xeb\xf2\xca\xe2\xf9U\xed\x190E\x8clAA\xa2" +
+	"^\xe6\xf1\xd2\\H\xebv\x80Q]\x8f\x81\xe1\x88\xb6" +
+	"\xa0\x17\x18\x95\xf5\x18h\xc0\xb5)#\xc0D\xb1S\x80" +
+	"&\xc3\xb2\xcdT\xd9X\xe8\x94T\x1a\x0e\x98\x9a,\x0d" +
+	"E\xa9\x02v\xa3\\\x0dz\x83\x17\x1d\xa8\x026:\xca" +
+	"}\x86\x86^\x01\xac\x99-\xe56Cc^\xf5\xabY" +
+	"\x94\xac\x06\x15\xd4]\xcaK\xbb\xbc\xbc\xb4\x8d\xb2\x8d\xab" +
+	"\xa0\xbe\x8bagf` W\x0e\xe2c@M\xa4\xcc" +
+	"\x01#\x9fv\xd7Cl\x0c\xcc\x0d\x1a\xa9n\xf2\x0c\xec" +
+	"\xb7\xdc\xe5\xb4XI\xbb\xa5\x94R-\x1c\xcb\xd6C\xb1" +
+	"\xb7\xf8\x91\xa6^\x08\xcf\xfa\x81\x82\xc2k\xa1*\x83\xcd" +
+	"p\xde\xee\xcb\xe4\xa9-\x1aK\xd8j\xbe/K\x1d\x00" +
+	"\x09$\xe6e\xf7R\xab\x13\xbf*\x9eV\x84\x90\xf5F" +
+	"Z\xc9\x9b$\xe9\xea\x92\xa4<\xccZ\x00z\x19\xf5!" +
+	"\xcc\x83N\x83\x14\x96\xd7\x12#\x19\"N\x03q\xd8e" +
+	"\xc1d\x06\xe1\xaa\xe4\xd4\x10'N\x1c\xe5\x92@\xd9\xe8" +
+	"p\x8d\xb5\x01$\xeb\x89s-qB\x17\x85gk>" +
+	"Ar\x1a\x883\x998\xe1O\x05z\x1d\xcf$\xc9\x89" +
+	"\x13g*q\"\x17\x04\xf3z\x9e)\x92s-qf" +
+	"\x10'\xfa\x89@\xaf\xeb\x99\xc6:\xa8\xeb!\xce,\xe2" +
+	"\xd4|L\x9c\x1a\x9a[H\xceT\xe2\xcc%N\xedG" +
+	"\xc4\xa9\xa5q\x94\xe4\xcc \xce|\xe2\xd4\x9d'N\x1d" +
+	"\x00\x9f\xc7\xba\x00\x92\xb3\x88\xb3\x908\xe3\xce\x11g\x1c" +
+	"\x0d\xf2$g.q\xda\x19C\xb5\xfe\x7f\x85\xact\xf8" +
+	"\x8dR\x05\xf3\x89\xb1\x98\x18\xea\xff\x08Y\xed\xf0\x9b%" +
+	"c!1\x96\x10c\xfcY!#5\xbfU2\xda\x89" +
+	"\xb1\x9c\x0e\x89\x9d\x11^5\xc8\x97J\xceb\xe2|\x85" +
+	"^i\xf8P\xc8\x8c\xc2\xbbY\x07\xeffM\xc9Ab" +
+	"\xb9\xc4\xd2\xfe[\xc8\xbc\xc2\xb7\xb1^\x80d\x96\x18;" +
+	"\x89\xd1x\xda\xeb\xddy\x81Q\xff\xb8\x9d\x18\xdf`\x0c" +
+	"cwe,r\xb1\xbeL&]\xf4\xe3\x98e\xbb\xed" +
+	"\xc8\x80!\x03l\xb2lw\xc1\"T\x80\xa1\xe2\xfdJ" +
+	"\xb4a\x08\x18\x86\xbc_\x8b\x16b\x18\x18\x86\x01\x9b\xf2" +
+	"\xf2\xbd\x08\x95\xfb\x80\x9dy\xefE?v\xca\x9f\x89\xb6" +
+	"R\x11\x95\xf7^\xf5\xfdpx \x9d1\x88]\x07\x0c" +
+	"\xeb\x8a\xbf\x17-\xc4q\xc0p\x1c\xc1\xd5\xdc\xee\x16s" +
+	"K\x8cJDT\x81\xa1\x0a('\x05\xd8\x08\x0c\x1b\x01" +
+	"c\x94\xe0J'z\xf5\x86\x1eB&\xce~\xeb\xcb\x13" +
+	"\x1b7\x1f\x1c\x01=\xc4pi\x1ce1\x8a}\xc2[" +
+	"\xb2\xde\x80h:o\x02\x14\xf7\x112K\x0d\x18\xfd\x80" +
+	"&DD1q\x81b:\xa5%\x7fh\xc5\x01\xb2I" +
+	"+\x8dY5\xec\xf0K\x90`\x95v]\x95VtU" +
+	"\xb5V\x94\xa2\xc7j/0\x06\x8a\x121`\xa5MJ" +
+	"\xc3\x00P\xd4\xd6\xb0%O\x09\xe4\x91\x92\x0cU\xdb\xe4" +
+	"\xb5\x85,\xfe\xfe\x10P\x0aW\x95\x11\xc0\x1b\xfaT\x09" +
+	"\x00\xde\xdc\xa7\x0a\xfe\xd5\xd0EQ\x15\xfej\xf8SQ" +
+	"\x15\xfdj\xe4\x82\xa8\x0a~5\xfa\x89\xa8\x8a}\xb5\xe6" +
+	"cQ\x15\xfaj\xedG\xa2*\xf2\xd5\xba\xf3\xa2*\xf0" +
+	"\xd5q\xe7\xc4\x17\x88{\xc4\xf2W\x1b\x09\x7f\xa6\x12\xe8" +
+	"\x03\x9f\x19x\xb3\xa46|(00\xcc\xe7*\xeb\xe0" +
+	"*k\x02\x89w\x0c\x0c\x1f\xf9\x14FY\xba\xf1\xb4\xc0" +
+	"\xc0\x8c\x9a\xd7\xb2\x8d\xc0\x82H\x87\x88\xc48D<t" +
+	"{\xff\x13m\xde\xffE\x0b!\xe2a\x19\">\x8a\xfd" +
+	"\x87D\x9b\xff@K\x8a\x98->\x11M\xe2\x14\"\x12" +
+	"\xa1\xfe\x14Ob\xf2\xb3\xb1\xb8\xd1\xc7\xe2\xda\x02(Y" +
+	"\x13 \x08\xc1Q\x00\xac\xc8z\xdd\xde\x8c3i\xed\xf0" +
+	"{\x9e\xa9\x12 \x1b\xda\x00\x105}:\x002\xd9\xe7" +
+	"\xa0\xa2-]\x05\x80!\xed\xd6^\x00\x0ck7o\x04" +
+	"\xc0\x88vc\x17\x00F\xb5y\xf7\x014\x99CY\xb7" +
+	"\x10\xed\xb3\xdcX_\xc15\x85{w\xa6\xab\xe0\x9a9" +
+	"\x00\x10\x03\x99\xbcC?\x00s\xc2\xb4\xb6\x0c\xba]\x05" +
+	"\x12'7\xec\xf7\xa5\xc2\xb2\xd3\x96m.\xcb\x84\x86\xb2" +
+	"\x99\x9c\xe5\x9a\xa3\x0b\x1cYE\xdf.\x83A\x8c\x9e+" +
+	"\xfa\x8e\x96*}\xc7u\x81\xc6 XZ\x07\xf1~U" +
+	"\x07\x80\xa6\x9c\x87\x96\xbf\xa6\xd4\xf6\x96g\xe9Z\xedF" +
+	"\xd1\x13\xa8\xcd<yn\xcf\x80\x922\xf5\xf6\xa24\xbc" +
+	"@s\xdb\xa4\x8b\x0a&waI ~\x8f\x1c0\xee" +
+	"$\xf27\xe5\x80\xd1O\xf6\xbbq\x04 \xf9M\xa2?" +
+	"Bt\xc5\x9bi\xf2\xbfB\x82\xc8_\x10\xfd1\xa2G" +
+	"\xfcD\xbfG\xee\xf3\x08\xd1\xf7\x13=\xea\x0f6\x9f\x95" +
+	"\xf4g\x88~\x18\x09\xb6\xc2\x07\xfakH\xc8y\x99\x18" +
+	"G\x89Q+\x0b\xd3\xd2\x076\xfe\x06v\xf07\x90\xd0" +
+	"P'\xab\xd3\xd2G'~\x12\x09=\xe3.\x09\x0cL" +
+	"\xfd\xf9+H\x18\xa9\xbf(0\xf0)\x8b?\x8bm\xc0" +
+	"T\xf5S\x81\x81\xb9?\xdf\x83\x1b\x81i\xa1p\x1c\xa7" +
+	"\x02\xf0{\xe5\xecu\x17I\xf2]d\xb8 <\x15\xe3" +
+	"8\x0d\x80\xff\x0dR\xb6}\x8c\x18O\xe0\xe8\x90\x9c\xb2" +
+	"r\xd9\xb4Q\xb8\x1d\xa2\xc1\xf6\xa8He\xc6\x90\xd9\xe3" +
+	"\x98\x03\xd6\xf6\xd5\xa6\xbd\xc5\x1d\x84b\x82\xbcj\x9ed" +
+	"\x17\x8d\x15\x1d\xd5\x14\x94\x8c\xeb\x07\xf3\xcf74\x8bQ" +
+	"\xa2\x80\xc8\xe7\x87\xa5\xe7$\x00\x12\xcdAl6\xc9\xd2" +
+	"\xbf|\xac\x92\xb1\xcb\xf5\xff\xef\x9d\xceZ9\x990-" +
+	"\xc0\xfe1\x0ah\x09\x1a\xef\xac\xa8\xd1o\x16{\xe9X" +
+	"\xb0|^\xe2\xf7\xd2M\xd4<m-7O\xc3C\xb2" +
+	"\xdf(\x9f_\xfc<]Lz\xfeX\x06b\xb9\\P" +
+	"\x9f\xa5\x8f\xb1c7\xc3\xde]c\xae\x95\xb1\xf5\x85\x9e" +
+	"HTXm\x92nz'\xf9@\x8a\xfc\x1a\xb3rt" +
+	"\xc8\x0d\xe9\xd7\x9b\x89\x9e&:\xdb&\xa7\x87\xdc\xc2\xad" +
+	"\x00\xc9A\xa2\xbbDW\x1c9\xe7\xe3\xdb\xe4\xfa,\xd1" +
+	"w\x12=\x94\x93c\x11^\xc0\x07F\xe1/\xec\xc6q" +
+	"\xa2\xc4\x9f\x03\x90\xfc\x06\xd1\x1f\x928\xcb\xcb\xfe\x99\x7f" +
+	"\x0b\xb7\x8e\xc2_\xf4.\xf9\x99\x86\xef\xc1\xadE\xfc}" +
+	"\x9f\xe85w\xcb\x0f5\xfc{\x92\xfe]\xa2?E\xf4" +
+	"\xda\xed\xf2S\x0d\x7fR\x9e\xfb\x14\xd1\x9f#z]!" +
+	"\x8e\x93\x01\xf8\x01y\xee~\xa2\xbf@\xf4q;\xe28" +
+	"\x05\x80\x1f\x94\xfb\xfc\x88\xe8\xaf\x12\xbd\xfe\xcf\xe2\xf8G" +
+	"@\xb0{\x18 \xf9*\xd1\xdf\xc41\x87\x1d\xc25\x9c" +
+	"-\xa6\x9b[\x01Q+m\x96\x1c\xc3\xa7\xd2\xf4\xcf\xce" +
+	"\xb9\x95\xe4n\x88R\xe1WIE\x7f\xdc\xe1\x02T\xf2" +
+	"\x92\xd0$\xfd\xba\x92\xbe\x02b\xd4FU\x92\xd7A\xcc" +
+	"\xb62v%\xf96\x88\x8d\x1a)\xfa\xe4\x95\xe8\xe3\xc3" +
+	"\xbc\xfa\xe05\xd0$\x1d\xb3\x92\xde\x031BM%y" +
+	")\xfa\xc0\xca\xa0=\x06JdC_\x81\x12\xf4\x86K" +
+	"\x95\xd3\xb5\x89_\xc8\x18yiQ$\xbbr\xc8\x18," +
+	"_\x99_\xbe\xb6\xf9#\xc6\x1e\x7f\xcaA\xd9yM[" +
+	"\xb9\xa6\x0d\x8e9\xc7\x18t\x8d%\xd7\xff\x0d\x00\xc2\xdb" +
+	"\xcf\x06"
 
 func init() {
 	schemas.Register(schema_a93fc509624c72d9,
@@ -0,0 +1,118 @@
+package capnp
+
+// StripCaps removes the capabilities from msg, so that it can be
+// persisted or forwarded to a party that must not receive live
+// capabilities.  Every interface pointer reachable from the root
+// pointer is set to null and the message's capability table is
+// cleared.  The clients in the table are not closed: the caller is
+// still responsible for them.
+//
+// StripCaps only overwrites pointers, so it works on messages that
+// can't allocate, such as ones read by a Decoder.  Walking the message
+// does not count against its read limit.
+func StripCaps(msg *Message) error {
+	defer func() { msg.CapTable = nil }()
+	if msg.NumSegments() == 0 {
+		return nil
+	}
+	seg, err := msg.Segment(0)
+	if err != nil {
+		return err
+	}
+	if len(seg.data) == 0 {
+		return nil
+	}
+	if !seg.regionInBounds(0, wordSize) {
+		return errNoRoot
+	}
+	cs := capStripper{visited: make(map[objectAddress]struct{})}
+	return cs.ptr(seg, 0, maxDepth)
+}
+
+type capStripper struct {
+	// visited holds the structs and lists that have been walked, so
+	// that objects referred to by more than one pointer are only
+	// walked once.
+	visited map[objectAddress]struct{}
+}
+
+type objectAddress struct {
+	seg  SegmentID
+	addr Address
+}
+
+func (cs *capStripper) visit(seg *Segment, addr Address) bool {
+	k := objectAddress{seg.id, addr}
+	if _, ok := cs.visited[k]; ok {
+		return false
+	}
+	cs.visited[k] = struct{}{}
+	return true
+}
+
+// ptr walks the object referenced by the pointer at paddr in seg and
+// nulls the pointer if it is an interface pointer.
+func (cs *capStripper) ptr(seg *Segment, paddr Address, depth uint) error {
+	if seg.readRawPointer(paddr) == 0 {
+		return nil
+	}
+	if depth == 0 {
+		return errDepthLimit
+	}
+	dst, base, val, err := seg.resolveFarPointer(paddr)
+	if err != nil {
+		return err
+	}
+	switch val.pointerType() {
+	case structPointer:
+		s, err := dst.readStructPtr(base, val)
+		if err != nil {
+			return err
+		}
+		return cs.structPtrs(s, depth)
+	case listPointer:
+		l, err := dst.readListPtr(base, val)
+		if err != nil {
+			return err
+		}
+		switch val.listType() {
+		case compositeList:
+			for i := 0; i < int(l.length) && l.size.PointerCount > 0; i++ {
+				if err := cs.structPtrs(l.Struct(i), depth); err != nil {
+					return err
+				}
+			}
+		case pointerList:
+			if !cs.visit(l.seg, l.off) {
+				return nil
+			}
+			for i := 0; i < int(l.length); i++ {
+				addr, _ := l.off.element(int32(i), wordSize)
+				if err := cs.ptr(l.seg, addr, depth-1); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	case otherPointer:
+		if val.otherPointerType() != 0 {
+			return errOtherPointer
+		}
+		seg.writeRawPointer(paddr, 0)
+		return nil
+	default:
+		return errBadLandingPad
+	}
+}
+
+func (cs *capStripper) structPtrs(s Struct, depth uint) error {
+	if s.size.PointerCount == 0 || !cs.visit(s.seg, s.off) {
+		return nil
+	}
+	for i := uint16(0); i < s.size.PointerCount; i++ {
+		if err := cs.ptr(s.seg, s.pointerAddress(i), depth-1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
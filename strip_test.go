@@ -0,0 +1,93 @@
+package capnp
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStripCaps(t *testing.T) {
+	msg, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	newCap := func() Ptr {
+		return NewInterface(seg, msg.AddCap(ErrorClient(errors.New("stripped")))).ToPtr()
+	}
+	root, err := NewRootStruct(seg, ObjectSize{PointerCount: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := root.SetPtr(0, newCap()); err != nil {
+		t.Fatal(err)
+	}
+	if err := root.SetText(1, "keep"); err != nil {
+		t.Fatal(err)
+	}
+	elems, err := NewCompositeList(seg, ObjectSize{DataSize: 8, PointerCount: 1}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < elems.Len(); i++ {
+		elems.Struct(i).SetUint64(0, uint64(i))
+		if err := elems.Struct(i).SetPtr(0, newCap()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := root.SetPtr(2, elems.ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+	ptrs, err := NewPointerList(seg, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ptrs.SetPtr(0, newCap()); err != nil {
+		t.Fatal(err)
+	}
+	if err := ptrs.SetPtr(1, root.ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+	if err := root.SetPtr(3, ptrs.ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := StripCaps(msg); err != nil {
+		t.Fatal("StripCaps:", err)
+	}
+	if msg.CapTable != nil {
+		t.Errorf("CapTable = %v; want nil", msg.CapTable)
+	}
+	if p, _ := root.Ptr(0); p.IsValid() {
+		t.Error("root.Ptr(0) is not null")
+	}
+	if p, _ := root.Ptr(1); p.Text() != "keep" {
+		t.Errorf("root.Ptr(1).Text() = %q; want \"keep\"", p.Text())
+	}
+	for i := 0; i < elems.Len(); i++ {
+		if p, _ := elems.Struct(i).Ptr(0); p.IsValid() {
+			t.Errorf("elems[%d].Ptr(0) is not null", i)
+		}
+		if elems.Struct(i).Uint64(0) != uint64(i) {
+			t.Errorf("elems[%d] data changed", i)
+		}
+	}
+	if p, _ := ptrs.PtrAt(0); p.IsValid() {
+		t.Error("ptrs[0] is not null")
+	}
+	if p, _ := ptrs.PtrAt(1); p.Struct().Segment() != seg || p.Struct().Address() != root.Address() {
+		t.Error("ptrs[1] no longer points to the root")
+	}
+	st, err := MessageStats(msg)
+	if err != nil {
+		t.Fatal("MessageStats:", err)
+	}
+	if st.Capabilities != 0 {
+		t.Errorf("%d capabilities remain", st.Capabilities)
+	}
+}
+
+func TestStripCaps_Empty(t *testing.T) {
+	msg := &Message{Arena: SingleSegment(nil)}
+	if err := StripCaps(msg); err != nil {
+		t.Error("StripCaps on empty message:", err)
+	}
+}
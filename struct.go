@@ -1,5 +1,7 @@
 package capnp
 
+import "errors"
+
 // Struct is a pointer to a struct.
 type Struct struct {
 	seg        *Segment
@@ -40,6 +42,27 @@ func NewRootStruct(s *Segment, sz ObjectSize) (Struct, error) {
 	return st, nil
 }
 
+// AllocateRoot allocates a struct in msg's first segment and sets it
+// as the message's root.  It is NewRootStruct for callers that have a
+// message rather than a segment.  If msg is empty, as it is after
+// Reset, the root pointer is allocated first.
+func AllocateRoot(msg *Message, sz ObjectSize) (Struct, error) {
+	s, err := msg.Segment(0)
+	if err != nil {
+		return Struct{}, err
+	}
+	if len(s.data) == 0 {
+		rs, _, err := alloc(s, wordSize)
+		if err != nil {
+			return Struct{}, err
+		}
+		if rs != s {
+			return Struct{}, errors.New("capnp: arena didn't allocate first word in first segment")
+		}
+	}
+	return NewRootStruct(s, sz)
+}
+
 // ToStruct converts p to a Struct.
 //
 // Deprecated: Use Ptr.Struct.
@@ -140,16 +163,136 @@ func (p Struct) SetPointer(i uint16, src Pointer) error {
 // SetPtr sets the i'th pointer in the struct to src.
 func (p Struct) SetPtr(i uint16, src Ptr) error {
 	if p.seg == nil || i >= p.size.PointerCount {
-		panic(errOutOfBounds)
+		misuse(p.seg, errOutOfBounds)
+		return errOutOfBounds
 	}
 	return p.seg.writePtr(p.pointerAddress(i), src, false)
 }
 
+// ClearPtr sets the i'th pointer in the struct to null and zeroes the
+// object it pointed to, along with every object reachable from it, so
+// that no stale content remains in the message's segments.  The object
+// must not be referenced from anywhere else in the message.  Text and
+// data are left as-is if the message's Intern field is set, since they
+// may be shared.
+func (p Struct) ClearPtr(i uint16) error {
+	if p.seg == nil || i >= p.size.PointerCount {
+		misuse(p.seg, errOutOfBounds)
+		return errOutOfBounds
+	}
+	return zeroPointer(p.seg, p.pointerAddress(i), p.depthLimit)
+}
+
+// ZeroData sets every byte of the struct's data section to zero.
+func (p Struct) ZeroData() {
+	if p.seg == nil {
+		return
+	}
+	zeroBytes(p.seg.slice(p.off, p.size.DataSize))
+}
+
+// zeroPointer nulls the pointer at paddr and zeroes its target, along
+// with the landing pad if the pointer is a far pointer.  The pointer is
+// nulled before visiting the target's pointers, so cycles terminate.
+func zeroPointer(s *Segment, paddr Address, depth uint) error {
+	ts, base, val, err := s.resolveFarPointer(paddr)
+	if err != nil || val == 0 {
+		return err
+	}
+	if depth == 0 {
+		return errDepthLimit
+	}
+	s.zeroLandingPad(s.readRawPointer(paddr))
+	s.writeRawPointer(paddr, 0)
+	switch val.pointerType() {
+	case structPointer:
+		sp, err := ts.readStructPtr(base, val)
+		if err != nil {
+			return err
+		}
+		sp.ZeroData()
+		for i := uint16(0); i < sp.size.PointerCount; i++ {
+			if err := zeroPointer(sp.seg, sp.pointerAddress(i), depth-1); err != nil {
+				return err
+			}
+		}
+	case listPointer:
+		lp, err := ts.readListPtr(base, val)
+		if err != nil {
+			return err
+		}
+		return zeroList(lp, depth)
+	}
+	// Capability pointers have no target.
+	return nil
+}
+
+// zeroLandingPad zeroes the landing pad that p points to, if p is a far
+// pointer.  resolveFarPointer must have already checked the pad's bounds.
+func (s *Segment) zeroLandingPad(p rawPointer) {
+	var sz Size
+	switch p.pointerType() {
+	case farPointer:
+		sz = wordSize
+	case doubleFarPointer:
+		sz = wordSize * 2
+	default:
+		return
+	}
+	padSeg, err := s.lookupSegment(p.farSegment())
+	if err != nil {
+		return
+	}
+	zeroBytes(padSeg.slice(p.farAddress(), sz))
+}
+
+func zeroList(l List, depth uint) error {
+	switch {
+	case l.flags&isCompositeList != 0:
+		for i := 0; i < l.Len(); i++ {
+			e := l.Struct(i)
+			for j := uint16(0); j < e.size.PointerCount; j++ {
+				if err := zeroPointer(e.seg, e.pointerAddress(j), depth-1); err != nil {
+					return err
+				}
+			}
+		}
+		// Zero the elements and the tag word that precedes them.
+		zeroBytes(l.seg.slice(l.off-Address(wordSize), l.allocSize()))
+	case l.size.PointerCount != 0:
+		for i := int32(0); i < l.length; i++ {
+			addr, _ := l.off.element(i, wordSize)
+			if err := zeroPointer(l.seg, addr, depth-1); err != nil {
+				return err
+			}
+		}
+	case l.size.DataSize == 1 && l.seg.interning():
+		// Byte lists may be shared through the intern table.
+	default:
+		zeroBytes(l.seg.slice(l.off, l.allocSize()))
+	}
+	return nil
+}
+
+func zeroBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
 // SetText sets the i'th pointer to a newly allocated text or null if v is empty.
+// If the message's Intern field is set, an equal text may be reused.
 func (p Struct) SetText(i uint16, v string) error {
 	if v == "" {
 		return p.SetPtr(i, Ptr{})
 	}
+	if p.seg.interning() {
+		t, err := internBlob(p.seg, v, false)
+		if err != nil {
+			return err
+		}
+		return p.SetPtr(i, t.List.ToPtr())
+	}
 	return p.SetNewText(i, v)
 }
 
@@ -163,10 +306,18 @@ func (p Struct) SetNewText(i uint16, v string) error {
 }
 
 // SetTextFromBytes sets the i'th pointer to a newly allocated text or null if v is nil.
+// If the message's Intern field is set, an equal text may be reused.
 func (p Struct) SetTextFromBytes(i uint16, v []byte) error {
 	if v == nil {
 		return p.SetPtr(i, Ptr{})
 	}
+	if p.seg.interning() {
+		t, err := internBlob(p.seg, string(v), false)
+		if err != nil {
+			return err
+		}
+		return p.SetPtr(i, t.List.ToPtr())
+	}
 	t, err := NewTextFromBytes(p.seg, v)
 	if err != nil {
 		return err
@@ -175,10 +326,18 @@ func (p Struct) SetTextFromBytes(i uint16, v []byte) error {
 }
 
 // SetData sets the i'th pointer to a newly allocated data or null if v is nil.
+// If the message's Intern field is set, an equal data may be reused.
 func (p Struct) SetData(i uint16, v []byte) error {
 	if v == nil {
 		return p.SetPtr(i, Ptr{})
 	}
+	if p.seg.interning() {
+		d, err := internBlob(p.seg, string(v), true)
+		if err != nil {
+			return err
+		}
+		return p.SetPtr(i, d.List.ToPtr())
+	}
 	d, err := NewData(p.seg, v)
 	if err != nil {
 		return err
@@ -210,7 +369,8 @@ func (p Struct) Bit(n BitOffset) bool {
 // SetBit sets the bit that is n bits from the start of the struct to v.
 func (p Struct) SetBit(n BitOffset, v bool) {
 	if !p.bitInData(n) {
-		panic(errOutOfBounds)
+		misuse(p.seg, errOutOfBounds)
+		return
 	}
 	addr := p.off.addOffset(n.offset())
 	b := p.seg.readUint8(addr)
@@ -269,7 +429,8 @@ func (p Struct) Uint64(off DataOffset) uint64 {
 func (p Struct) SetUint8(off DataOffset, v uint8) {
 	addr, ok := p.dataAddress(off, 1)
 	if !ok {
-		panic(errOutOfBounds)
+		misuse(p.seg, errOutOfBounds)
+		return
 	}
 	p.seg.writeUint8(addr, v)
 }
@@ -278,7 +439,8 @@ func (p Struct) SetUint8(off DataOffset, v uint8) {
 func (p Struct) SetUint16(off DataOffset, v uint16) {
 	addr, ok := p.dataAddress(off, 2)
 	if !ok {
-		panic(errOutOfBounds)
+		misuse(p.seg, errOutOfBounds)
+		return
 	}
 	p.seg.writeUint16(addr, v)
 }
@@ -287,7 +449,8 @@ func (p Struct) SetUint16(off DataOffset, v uint16) {
 func (p Struct) SetUint32(off DataOffset, v uint32) {
 	addr, ok := p.dataAddress(off, 4)
 	if !ok {
-		panic(errOutOfBounds)
+		misuse(p.seg, errOutOfBounds)
+		return
 	}
 	p.seg.writeUint32(addr, v)
 }
@@ -296,7 +459,8 @@ func (p Struct) SetUint32(off DataOffset, v uint32) {
 func (p Struct) SetUint64(off DataOffset, v uint64) {
 	addr, ok := p.dataAddress(off, 8)
 	if !ok {
-		panic(errOutOfBounds)
+		misuse(p.seg, errOutOfBounds)
+		return
 	}
 	p.seg.writeUint64(addr, v)
 }
@@ -0,0 +1,147 @@
+package capnp
+
+import (
+	"io"
+	"strconv"
+	"sync/atomic"
+)
+
+// A CodecOption configures an Encoder or a Decoder.
+type CodecOption func(*codecOptions)
+
+type codecOptions struct {
+	traceWriter io.Writer
+	traceRoot   func(Ptr) (string, error)
+	decode      DecodeOptions
+	coalesce    int
+	strings     *StringTable
+}
+
+// WithTrace returns an option that writes a line to w describing each
+// message that passes through an Encoder or Decoder: its sequence
+// number, segment sizes, and total size.  Decode errors are written
+// too.  Each line is written with a single call to w.Write, and errors
+// from w are ignored so that tracing never interrupts the stream.
+//
+// Tracing is meant for debugging framing problems with other
+// implementations, like a C++ peer that sends truncated messages.
+func WithTrace(w io.Writer) CodecOption {
+	return func(o *codecOptions) {
+		o.traceWriter = w
+	}
+}
+
+// WithTraceRoot returns an option that appends the root of each traced
+// message, as formatted by f, to its trace line.  It has no effect
+// without WithTrace.  f is usually a closure around text.Marshal:
+//
+//	capnp.WithTraceRoot(func(p capnp.Ptr) (string, error) {
+//		return text.Marshal(foo.Bar_TypeID, p.Struct())
+//	})
+//
+// Reads made by f do not count against the message's traversal limit.
+func WithTraceRoot(f func(Ptr) (string, error)) CodecOption {
+	return func(o *codecOptions) {
+		o.traceRoot = f
+	}
+}
+
+// newTracer returns the tracer configured by opts, or nil if tracing
+// is off.
+func newTracer(op string, opts []CodecOption) *tracer {
+	var o codecOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.traceWriter == nil {
+		return nil
+	}
+	return &tracer{op: op, w: o.traceWriter, root: o.traceRoot}
+}
+
+// A tracer writes trace lines for an Encoder or Decoder.
+type tracer struct {
+	op   string
+	w    io.Writer
+	root func(Ptr) (string, error)
+	n    uint64
+	buf  []byte
+}
+
+// message traces a message that was encoded or decoded.  packedSize
+// is the size of the message after packing, or -1 if the stream is
+// not packed or the size is unknown.
+func (t *tracer) message(m *Message, packedSize int) {
+	t.begin()
+	nsegs := m.NumSegments()
+	t.buf = strconv.AppendInt(t.buf, nsegs, 10)
+	if nsegs == 1 {
+		t.buf = append(t.buf, " segment ("...)
+	} else {
+		t.buf = append(t.buf, " segments ("...)
+	}
+	var total int
+	for i := int64(0); i < nsegs; i++ {
+		if i > 0 {
+			t.buf = append(t.buf, '+')
+		}
+		var n int
+		if s, err := m.Segment(SegmentID(i)); err == nil {
+			n = len(s.Data())
+		}
+		t.buf = strconv.AppendInt(t.buf, int64(n)/int64(wordSize), 10)
+		total += n
+	}
+	t.buf = append(t.buf, " words), "...)
+	total += int(streamHeaderSize(uint32(nsegs - 1)))
+	t.buf = strconv.AppendInt(t.buf, int64(total), 10)
+	t.buf = append(t.buf, " bytes"...)
+	if packedSize >= 0 {
+		t.buf = append(t.buf, " ("...)
+		t.buf = strconv.AppendInt(t.buf, int64(packedSize), 10)
+		t.buf = append(t.buf, " packed)"...)
+	}
+	if t.root != nil {
+		t.buf = append(t.buf, ": "...)
+		t.buf = append(t.buf, t.formatRoot(m)...)
+	}
+	t.end()
+}
+
+// formatRoot formats the root of m without using up its read limit.
+func (t *tracer) formatRoot(m *Message) string {
+	rl := m.ReadLimiter()
+	saved := atomic.LoadUint64(&rl.limit)
+	defer rl.Reset(saved)
+	p, err := m.RootPtr()
+	if err != nil {
+		return "<root: " + err.Error() + ">"
+	}
+	s, err := t.root(p)
+	if err != nil {
+		return "<root: " + err.Error() + ">"
+	}
+	return s
+}
+
+// error traces a failure to encode or decode a message.
+func (t *tracer) error(err error) {
+	t.begin()
+	t.buf = append(t.buf, "error: "...)
+	t.buf = append(t.buf, err.Error()...)
+	t.end()
+}
+
+func (t *tracer) begin() {
+	t.n++
+	t.buf = append(t.buf[:0], "capnp trace: "...)
+	t.buf = append(t.buf, t.op...)
+	t.buf = append(t.buf, " #"...)
+	t.buf = strconv.AppendUint(t.buf, t.n, 10)
+	t.buf = append(t.buf, ": "...)
+}
+
+func (t *tracer) end() {
+	t.buf = append(t.buf, '\n')
+	t.w.Write(t.buf)
+}
@@ -0,0 +1,103 @@
+package capnp
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestTrace(t *testing.T) {
+	msg, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal("NewMessage:", err)
+	}
+	s, err := NewRootStruct(seg, ObjectSize{DataSize: 8})
+	if err != nil {
+		t.Fatal("NewRootStruct:", err)
+	}
+	s.SetUint64(0, 42)
+
+	var stream, trace bytes.Buffer
+	root := WithTraceRoot(func(p Ptr) (string, error) {
+		if p.Struct().Uint64(0) != 42 {
+			return "", errors.New("bad root")
+		}
+		return "(x = 42)", nil
+	})
+	enc := NewEncoder(&stream, WithTrace(&trace), root)
+	for i := 0; i < 2; i++ {
+		if err := enc.Encode(msg); err != nil {
+			t.Fatalf("Encode #%d: %v", i+1, err)
+		}
+	}
+	dec := NewDecoder(bytes.NewReader(stream.Bytes()), WithTrace(&trace), root)
+	for i := 0; i < 2; i++ {
+		if _, err := dec.Decode(); err != nil {
+			t.Fatalf("Decode #%d: %v", i+1, err)
+		}
+	}
+	if _, err := dec.Decode(); err == nil {
+		t.Error("Decode at end of stream did not return an error")
+	}
+	// A truncated frame is traced as an error.
+	dec = NewDecoder(bytes.NewReader(stream.Bytes()[:12]), WithTrace(&trace))
+	if _, err := dec.Decode(); err == nil {
+		t.Error("Decode of truncated frame did not return an error")
+	}
+
+	want := []string{
+		"capnp trace: encode #1: 1 segment (2 words), 24 bytes: (x = 42)",
+		"capnp trace: encode #2: 1 segment (2 words), 24 bytes: (x = 42)",
+		"capnp trace: decode #1: 1 segment (2 words), 24 bytes: (x = 42)",
+		"capnp trace: decode #2: 1 segment (2 words), 24 bytes: (x = 42)",
+		"capnp trace: decode #1: error: unexpected EOF",
+	}
+	got := strings.Split(strings.TrimSuffix(trace.String(), "\n"), "\n")
+	if len(got) != len(want) {
+		t.Fatalf("trace =\n%s\nwant %d lines", trace.String(), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("trace line %d = %q; want %q", i+1, got[i], want[i])
+		}
+	}
+}
+
+func TestTrace_Packed(t *testing.T) {
+	msg, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal("NewMessage:", err)
+	}
+	if _, err := NewRootStruct(seg, ObjectSize{DataSize: 8}); err != nil {
+		t.Fatal("NewRootStruct:", err)
+	}
+	var stream, trace bytes.Buffer
+	if err := NewPackedEncoder(&stream, WithTrace(&trace)).Encode(msg); err != nil {
+		t.Fatal("Encode:", err)
+	}
+	want := "capnp trace: encode #1: 1 segment (2 words), 24 bytes (" + strconv.Itoa(stream.Len()) + " packed)\n"
+	if trace.String() != want {
+		t.Errorf("trace = %q; want %q", trace.String(), want)
+	}
+}
+
+func TestTrace_RootReadLimit(t *testing.T) {
+	msg, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal("NewMessage:", err)
+	}
+	if _, err := NewRootStruct(seg, ObjectSize{DataSize: 8}); err != nil {
+		t.Fatal("NewRootStruct:", err)
+	}
+	// Only enough budget to read the root once.
+	msg.ReadLimiter().Reset(8)
+	tr := newTracer("test", []CodecOption{WithTrace(new(bytes.Buffer)), WithTraceRoot(func(p Ptr) (string, error) {
+		return "", nil
+	})})
+	tr.message(msg, -1)
+	if _, err := msg.RootPtr(); err != nil {
+		t.Errorf("RootPtr after trace: %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+// Package streamenvelope wraps Cap'n Proto messages for message buses
+// like Kafka or NATS, which carry opaque byte payloads.
+//
+// An envelope is a 16-byte header followed by a serialized message:
+//
+//	bytes 0-1   magic "CP"
+//	byte  2     version, currently 1
+//	byte  3     flags; bit 0 is set if the message is packed
+//	bytes 4-7   reserved, must be zero
+//	bytes 8-15  type ID of the root struct, little-endian
+//
+// Producers call Marshal.  Consumers either call Unmarshal or register
+// a Handler for each type on a Mux and call Dispatch.
+package streamenvelope // import "github.com/iguazio/go-capnproto2/transport/streamenvelope"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/iguazio/go-capnproto2"
+	"github.com/iguazio/go-capnproto2/schemas"
+	"golang.org/x/net/context"
+)
+
+// Version is the envelope version written by Marshal.
+const Version = 1
+
+// HeaderSize is the size of an envelope header in bytes.
+const HeaderSize = 16
+
+const flagPacked = 1 << 0
+
+var magic = []byte("CP")
+
+// A Header describes the message in an envelope.
+type Header struct {
+	TypeID uint64
+	Packed bool
+}
+
+// ReadHeader parses the header at the start of an envelope without
+// decoding the message, which is enough to route it.
+func ReadHeader(data []byte) (Header, error) {
+	if len(data) < HeaderSize {
+		return Header{}, errors.New("streamenvelope: envelope too short for header")
+	}
+	if !bytes.Equal(data[:2], magic) {
+		return Header{}, errors.New("streamenvelope: bad magic")
+	}
+	if data[2] != Version {
+		return Header{}, fmt.Errorf("streamenvelope: unsupported version %d", data[2])
+	}
+	if data[3]&^flagPacked != 0 || binary.LittleEndian.Uint32(data[4:]) != 0 {
+		return Header{}, errors.New("streamenvelope: reserved header bits set")
+	}
+	return Header{
+		TypeID: binary.LittleEndian.Uint64(data[8:]),
+		Packed: data[3]&flagPacked != 0,
+	}, nil
+}
+
+// Marshal returns an envelope holding s as the root of a message.
+// typeID is the type ID of s.  If s isn't the root of its message, it
+// is copied into a new message.
+func Marshal(typeID uint64, s capnp.Struct, packed bool) ([]byte, error) {
+	if s.Segment() == nil {
+		return nil, errors.New("streamenvelope: marshal null struct")
+	}
+	msg := s.Segment().Message()
+	if root, err := msg.RootPtr(); err != nil || !capnp.SamePtr(root, s.ToPtr()) {
+		msg, _, err = capnp.NewMessage(capnp.MultiSegment(nil))
+		if err != nil {
+			return nil, err
+		}
+		if err := msg.SetRootPtr(s.ToPtr()); err != nil {
+			return nil, err
+		}
+	}
+	var body []byte
+	var err error
+	if packed {
+		body, err = msg.MarshalPacked()
+	} else {
+		body, err = msg.Marshal()
+	}
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, HeaderSize, HeaderSize+len(body))
+	copy(buf, magic)
+	buf[2] = Version
+	if packed {
+		buf[3] = flagPacked
+	}
+	binary.LittleEndian.PutUint64(buf[8:], typeID)
+	return append(buf, body...), nil
+}
+
+// Unmarshal parses an envelope.  An unpacked message reads directly
+// from data.
+func Unmarshal(data []byte) (Header, *capnp.Message, error) {
+	h, err := ReadHeader(data)
+	if err != nil {
+		return Header{}, nil, err
+	}
+	var msg *capnp.Message
+	if h.Packed {
+		msg, err = capnp.UnmarshalPacked(data[HeaderSize:])
+	} else {
+		msg, err = capnp.Unmarshal(data[HeaderSize:])
+	}
+	if err != nil {
+		return Header{}, nil, fmt.Errorf("streamenvelope: %v", err)
+	}
+	return h, msg, nil
+}
+
+// A Handler consumes the root struct of an envelope.
+type Handler func(ctx context.Context, s capnp.Struct) error
+
+// A Mux dispatches envelopes to handlers by type ID.  The zero value is
+// an empty Mux that consults the default schema registry.  It is safe
+// to call a Mux's methods from multiple goroutines.
+type Mux struct {
+	// Registry is used to describe types that have no handler.  If nil,
+	// schemas.DefaultRegistry is used.
+	Registry *schemas.Registry
+
+	mu       sync.RWMutex
+	handlers map[uint64]Handler
+}
+
+// Handle registers h for structs of the given type, replacing any
+// previous handler.
+func (m *Mux) Handle(typeID uint64, h Handler) {
+	m.mu.Lock()
+	if m.handlers == nil {
+		m.handlers = make(map[uint64]Handler)
+	}
+	m.handlers[typeID] = h
+	m.mu.Unlock()
+}
+
+// Dispatch parses an envelope and calls the handler for its type with
+// the root struct.  If there is no handler for the type, Dispatch
+// returns an *UnhandledTypeError.
+func (m *Mux) Dispatch(ctx context.Context, data []byte) error {
+	h, err := ReadHeader(data)
+	if err != nil {
+		return err
+	}
+	m.mu.RLock()
+	handler := m.handlers[h.TypeID]
+	m.mu.RUnlock()
+	if handler == nil {
+		reg := m.Registry
+		if reg == nil {
+			reg = &schemas.DefaultRegistry
+		}
+		_, err := reg.Find(h.TypeID)
+		return &UnhandledTypeError{TypeID: h.TypeID, Known: err == nil}
+	}
+	_, msg, err := Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	p, err := msg.RootPtr()
+	if err != nil {
+		return fmt.Errorf("streamenvelope: %v", err)
+	}
+	return handler(ctx, p.Struct())
+}
+
+// An UnhandledTypeError is returned by Dispatch when no handler is
+// registered for an envelope's type.
+type UnhandledTypeError struct {
+	TypeID uint64
+
+	// Known is true if the type's schema is in the Mux's registry,
+	// which usually means that a handler was forgotten rather than that
+	// the producer is newer than the consumer.
+	Known bool
+}
+
+func (e *UnhandledTypeError) Error() string {
+	if e.Known {
+		return fmt.Sprintf("streamenvelope: no handler for type %#x", e.TypeID)
+	}
+	return fmt.Sprintf("streamenvelope: no handler for unknown type %#x", e.TypeID)
+}
@@ -0,0 +1,130 @@
+package streamenvelope
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iguazio/go-capnproto2"
+	air "github.com/iguazio/go-capnproto2/internal/aircraftlib"
+	"github.com/iguazio/go-capnproto2/schemas"
+	"golang.org/x/net/context"
+)
+
+func TestRoundTrip(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	date, err := air.NewRootZdate(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	date.SetYear(2017)
+	date.SetMonth(5)
+	date.SetDay(9)
+	for _, packed := range []bool{false, true} {
+		data, err := Marshal(air.Zdate_TypeID, date.Struct, packed)
+		if err != nil {
+			t.Errorf("packed=%t: Marshal: %v", packed, err)
+			continue
+		}
+		h, msg, err := Unmarshal(data)
+		if err != nil {
+			t.Errorf("packed=%t: Unmarshal: %v", packed, err)
+			continue
+		}
+		if h.TypeID != air.Zdate_TypeID || h.Packed != packed {
+			t.Errorf("packed=%t: header = %+v", packed, h)
+		}
+		d, err := air.ReadRootZdate(msg)
+		if err != nil {
+			t.Errorf("packed=%t: ReadRootZdate: %v", packed, err)
+			continue
+		}
+		if d.Year() != 2017 || d.Month() != 5 || d.Day() != 9 {
+			t.Errorf("packed=%t: date = %d-%d-%d; want 2017-5-9", packed, d.Year(), d.Month(), d.Day())
+		}
+	}
+}
+
+func TestReadHeader_Errors(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	date, err := air.NewRootZdate(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	date.SetYear(2017)
+	data, err := Marshal(air.Zdate_TypeID, date.Struct, false)
+	if err != nil {
+		t.Fatal("Marshal:", err)
+	}
+	tests := []struct {
+		name   string
+		modify func([]byte) []byte
+	}{
+		{"short", func(b []byte) []byte { return b[:HeaderSize-1] }},
+		{"bad magic", func(b []byte) []byte { b[0] = 'X'; return b }},
+		{"future version", func(b []byte) []byte { b[2] = Version + 1; return b }},
+		{"unknown flag", func(b []byte) []byte { b[3] |= 0x80; return b }},
+		{"reserved", func(b []byte) []byte { b[5] = 1; return b }},
+	}
+	for _, test := range tests {
+		b := test.modify(append([]byte(nil), data...))
+		if _, err := ReadHeader(b); err == nil {
+			t.Errorf("%s: ReadHeader did not return an error", test.name)
+		}
+	}
+}
+
+func TestMux(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	date, err := air.NewRootZdate(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	date.SetYear(2017)
+	var mux Mux
+	mux.Registry = new(schemas.Registry)
+	if err := mux.Registry.Register(&schemas.Schema{String: "x", Nodes: []uint64{air.Zdate_TypeID}}); err != nil {
+		t.Fatal("Register:", err)
+	}
+	var got int16
+	mux.Handle(air.Z_TypeID, func(ctx context.Context, s capnp.Struct) error {
+		return errors.New("wrong handler")
+	})
+	ctx := context.Background()
+	data, err := Marshal(air.Zdate_TypeID, date.Struct, true)
+	if err != nil {
+		t.Fatal("Marshal:", err)
+	}
+	err = mux.Dispatch(ctx, data)
+	if e, ok := err.(*UnhandledTypeError); !ok || e.TypeID != air.Zdate_TypeID || !e.Known {
+		t.Errorf("Dispatch with no handler = %v; want known *UnhandledTypeError", err)
+	}
+
+	mux.Handle(air.Zdate_TypeID, func(ctx context.Context, s capnp.Struct) error {
+		got = air.Zdate{Struct: s}.Year()
+		return nil
+	})
+	if err := mux.Dispatch(ctx, data); err != nil {
+		t.Error("Dispatch:", err)
+	}
+	if got != 2017 {
+		t.Errorf("handler got year %d; want 2017", got)
+	}
+
+	data, err = Marshal(0x1234, date.Struct, false)
+	if err != nil {
+		t.Fatal("Marshal:", err)
+	}
+	err = mux.Dispatch(ctx, data)
+	if e, ok := err.(*UnhandledTypeError); !ok || e.Known {
+		t.Errorf("Dispatch of unregistered type = %v; want unknown *UnhandledTypeError", err)
+	}
+}
@@ -0,0 +1,56 @@
+package capnp
+
+// StructType is satisfied by the struct types that capnpc-go
+// generates, which all have the form struct{ capnp.Struct }.
+type StructType interface {
+	~struct{ Struct }
+}
+
+// StructAs converts s to the generated struct type T.
+func StructAs[T StructType](s Struct) T {
+	return T(struct{ Struct }{s})
+}
+
+// A TypedAnswer is an Answer whose result is a struct of type T, like
+// the Promise types that capnpc-go generates.  It lets hand-written
+// code wait on calls without converting the result by hand.
+type TypedAnswer[T StructType] struct {
+	ans Answer
+}
+
+// NewTypedAnswer returns a TypedAnswer for ans.  The caller must know
+// that the answer's result is a T.
+func NewTypedAnswer[T StructType](ans Answer) TypedAnswer[T] {
+	return TypedAnswer[T]{ans}
+}
+
+// Answer returns the underlying answer.
+func (a TypedAnswer[T]) Answer() Answer {
+	return a.ans
+}
+
+// Struct waits until the call is finished and returns the result.
+func (a TypedAnswer[T]) Struct() (T, error) {
+	s, err := a.ans.Struct()
+	return StructAs[T](s), err
+}
+
+// Done returns a channel that is closed once the call is finished.  See
+// AnswerDone.
+func (a TypedAnswer[T]) Done() <-chan struct{} {
+	return AnswerDone(a.ans)
+}
+
+// Pipeline returns a pipeline on the answer, so that calls can be made
+// on capabilities in the result before it arrives.
+func (a TypedAnswer[T]) Pipeline() *Pipeline {
+	return NewPipeline(a.ans)
+}
+
+// PipelineStruct waits until p's answer is finished and returns the
+// struct that p refers to as a T.  A generated Promise type's Struct
+// method is equivalent to calling PipelineStruct on its Pipeline.
+func PipelineStruct[T StructType](p *Pipeline) (T, error) {
+	s, err := p.Struct()
+	return StructAs[T](s), err
+}
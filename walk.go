@@ -0,0 +1,366 @@
+package capnp
+
+import "errors"
+
+// An ObjectKind is the layout of an object found by Walk.
+type ObjectKind int
+
+// Object kinds.
+const (
+	// StructObject is a struct, either pointed to directly or an
+	// element of a list of structs.
+	StructObject ObjectKind = iota + 1
+
+	// ListObject is a list of anything other than bytes.
+	ListObject
+
+	// BlobObject is a list of bytes, which is how Text and Data are
+	// stored.
+	BlobObject
+
+	// CapabilityObject is a capability pointer.  It has no bytes in
+	// the message; Offset and Size are zero.
+	CapabilityObject
+)
+
+// String returns the kind's name, like "struct".
+func (k ObjectKind) String() string {
+	switch k {
+	case StructObject:
+		return "struct"
+	case ListObject:
+		return "list"
+	case BlobObject:
+		return "blob"
+	case CapabilityObject:
+		return "capability"
+	default:
+		return "unknown object"
+	}
+}
+
+// An Object is an object found by Walk.
+type Object struct {
+	Kind ObjectKind
+
+	// Ptr is the object.  Reading through it counts against the
+	// message's read limit, as usual.
+	Ptr Ptr
+
+	// Segment and Offset locate the object's first byte, which is the
+	// tag word for a list of structs.  Size is the number of bytes
+	// that the object occupies, including list tags and the padding of
+	// lists to a word boundary.
+	Segment SegmentID
+	Offset  Address
+	Size    Size
+
+	// Depth is the number of pointers followed from the root pointer
+	// to reach the object.  The root object is at depth 1.  Elements
+	// of a list of structs are at the same depth as the list.
+	Depth int
+
+	// Field is the name of the struct field that points to the
+	// object, or the empty string for the root, for list elements, or
+	// if the field is not known.
+	Field string
+
+	// Type is the schema type of the object.  It is the zero value if
+	// the type is not known.
+	Type ObjectType
+}
+
+// An ObjectType is the schema type of an object, as reported by a
+// TypeResolver.
+type ObjectType struct {
+	// Name is the type's name, like "Foo", "Text", or "List(Foo)".
+	// It may be empty if only TypeID is known.
+	Name string
+
+	// TypeID is the ID of a struct or interface type.
+	TypeID uint64
+
+	// Elem is the type of a list's elements.
+	Elem *ObjectType
+}
+
+// A PointerField is a pointer field of a struct, as reported by a
+// TypeResolver.
+type PointerField struct {
+	// Name is the field's name.  Fields of groups are named
+	// "group.field".
+	Name string
+
+	Type ObjectType
+}
+
+// A TypeResolver describes the pointer fields of struct types so that
+// Walk can report the types of the objects that it finds.  The
+// capnptypes package provides a TypeResolver that reads registered
+// schemas.
+type TypeResolver interface {
+	// PointerFields returns the fields stored in the pointer section
+	// of s, a struct of the type with the given ID, indexed by pointer.
+	// Pointers that no field occupies and union members that are not
+	// set have a zero PointerField, and fields whose type is not known,
+	// like AnyPointer fields, have a zero Type.  It returns a nil slice
+	// if the struct type is not known.
+	PointerFields(typeID uint64, s Struct) ([]PointerField, error)
+}
+
+// SkipObject is used as a return value from the function passed to
+// Walk to indicate that the objects that the current object points to
+// should not be visited.
+var SkipObject = errors.New("capnp: skip this object")
+
+// Walk calls visit for each object reachable from msg's root pointer,
+// in pre-order.  Objects that more than one pointer refers to are only
+// visited once.  If msg's root was tagged by SetRootAs, the type of the
+// root struct is known, but the types of the other objects are not;
+// use WalkOptions with a TypeResolver to find them.
+//
+// If visit returns SkipObject, Walk does not visit the objects that the
+// current object points to.  If it returns any other error, Walk stops
+// and returns that error.  Walking a message does not count against its
+// read limit.
+func Walk(msg *Message, visit func(Object) error) error {
+	return WalkOptions{}.Walk(msg, visit)
+}
+
+// WalkOptions holds the parameters of a typed walk of a message.
+type WalkOptions struct {
+	// RootTypeID is the type ID of the root struct.  If zero, the type
+	// that the root was tagged with by SetRootAs is used.
+	RootTypeID uint64
+
+	// Types describes the pointer fields of structs.  If nil, only
+	// the type of the root is known.
+	Types TypeResolver
+}
+
+// Walk is like the Walk function, but it reports the types of the
+// objects that it finds through opts.Types, starting from the root type.
+func (opts WalkOptions) Walk(msg *Message, visit func(Object) error) error {
+	if msg.NumSegments() == 0 {
+		return nil
+	}
+	seg, err := msg.Segment(0)
+	if err != nil {
+		return err
+	}
+	if !seg.regionInBounds(0, wordSize) {
+		return errNoRoot
+	}
+	w := &walker{
+		opts:    opts,
+		visit:   visit,
+		visited: make(map[SegmentID][]uint64),
+	}
+	tagID, _, tagged, err := taggedRoot(msg)
+	if err != nil {
+		return err
+	}
+	var typ ObjectType
+	switch {
+	case tagged:
+		// The root is the envelope, which points to the real root.
+		w.envelope = true
+		w.rootTypeID = tagID
+		if opts.RootTypeID != 0 {
+			w.rootTypeID = opts.RootTypeID
+		}
+	case opts.RootTypeID != 0:
+		typ.TypeID = opts.RootTypeID
+	}
+	return w.ptr(seg, 0, "", typ, 1)
+}
+
+type walker struct {
+	opts  WalkOptions
+	visit func(Object) error
+
+	// envelope is true if the root struct is a SetRootAs envelope for
+	// a struct of type rootTypeID.
+	envelope   bool
+	rootTypeID uint64
+
+	// visited is a bitmap of the words of each segment that have been
+	// visited.
+	visited map[SegmentID][]uint64
+}
+
+// mark records that the word at addr in seg has been visited.  It
+// reports false if it was visited before.
+func (w *walker) mark(seg *Segment, addr Address) bool {
+	bits := w.visited[seg.id]
+	if bits == nil {
+		nwords := len(seg.data) / int(wordSize)
+		bits = make([]uint64, (nwords+63)/64)
+		w.visited[seg.id] = bits
+	}
+	i := int(addr / Address(wordSize))
+	if i >= len(bits)*64 {
+		return true
+	}
+	if bits[i/64]&(1<<uint(i%64)) != 0 {
+		return false
+	}
+	bits[i/64] |= 1 << uint(i%64)
+	return true
+}
+
+// call calls the visit function and reports whether the object's
+// children should be visited.
+func (w *walker) call(obj Object) (bool, error) {
+	err := w.visit(obj)
+	if err == SkipObject {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ptr visits the object referenced by the pointer at paddr in seg.
+func (w *walker) ptr(seg *Segment, paddr Address, field string, typ ObjectType, depth int) error {
+	raw := seg.readRawPointer(paddr)
+	if raw == 0 {
+		return nil
+	}
+	dst, base, val, err := seg.resolveFarPointer(paddr)
+	if err != nil {
+		return err
+	}
+	switch val.pointerType() {
+	case structPointer:
+		s, err := dst.readStructPtr(base, val)
+		if err != nil {
+			return err
+		}
+		s.depthLimit = maxDepth
+		if s.size.totalSize() > 0 && !w.mark(dst, s.off) {
+			return nil
+		}
+		return w.structObject(s, field, typ, depth, depth == 1)
+	case listPointer:
+		l, err := dst.readListPtr(base, val)
+		if err != nil {
+			return err
+		}
+		l.depthLimit = maxDepth
+		return w.list(l, val, field, typ, depth)
+	case otherPointer:
+		if val.otherPointerType() != 0 {
+			return errOtherPointer
+		}
+		iface := Interface{seg: seg, cap: val.capabilityIndex()}
+		_, err := w.call(Object{
+			Kind:    CapabilityObject,
+			Ptr:     iface.ToPtr(),
+			Segment: seg.id,
+			Depth:   depth,
+			Field:   field,
+			Type:    typ,
+		})
+		return err
+	default:
+		return errBadLandingPad
+	}
+}
+
+func (w *walker) structObject(s Struct, field string, typ ObjectType, depth int, root bool) error {
+	ok, err := w.call(Object{
+		Kind:    StructObject,
+		Ptr:     s.ToPtr(),
+		Segment: s.seg.id,
+		Offset:  s.off,
+		Size:    s.size.totalSize(),
+		Depth:   depth,
+		Field:   field,
+		Type:    typ,
+	})
+	if !ok || s.size.PointerCount == 0 {
+		return err
+	}
+	var fields []PointerField
+	switch {
+	case root && w.envelope:
+		fields = []PointerField{{Type: ObjectType{TypeID: w.rootTypeID}}}
+	case typ.TypeID != 0 && w.opts.Types != nil:
+		fields, err = w.opts.Types.PointerFields(typ.TypeID, s)
+		if err != nil {
+			return err
+		}
+	}
+	for i := uint16(0); i < s.size.PointerCount; i++ {
+		var f PointerField
+		if int(i) < len(fields) {
+			f = fields[i]
+		}
+		if err := w.ptr(s.seg, s.pointerAddress(i), f.Name, f.Type, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) list(l List, val rawPointer, field string, typ ObjectType, depth int) error {
+	var elem ObjectType
+	if typ.Elem != nil {
+		elem = *typ.Elem
+	}
+	lt := val.listType()
+	if lt == compositeList {
+		// l.off is the address of the first element, after the tag.
+		tagAddr := l.off - Address(wordSize)
+		if !w.mark(l.seg, tagAddr) {
+			return nil
+		}
+		n, _ := l.size.totalSize().times(l.length)
+		ok, err := w.call(Object{
+			Kind:    ListObject,
+			Ptr:     l.ToPtr(),
+			Segment: l.seg.id,
+			Offset:  tagAddr,
+			Size:    wordSize + n,
+			Depth:   depth,
+			Field:   field,
+			Type:    typ,
+		})
+		if !ok {
+			return err
+		}
+		for i := 0; i < int(l.length); i++ {
+			if err := w.structObject(l.Struct(i), "", elem, depth, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	sz, _ := val.totalListSize()
+	if sz > 0 && !w.mark(l.seg, l.off) {
+		return nil
+	}
+	kind := ListObject
+	if lt == byte1List {
+		kind = BlobObject
+	}
+	ok, err := w.call(Object{
+		Kind:    kind,
+		Ptr:     l.ToPtr(),
+		Segment: l.seg.id,
+		Offset:  l.off,
+		Size:    sz.padToWord(),
+		Depth:   depth,
+		Field:   field,
+		Type:    typ,
+	})
+	if !ok || lt != pointerList {
+		return err
+	}
+	for i := 0; i < int(l.length); i++ {
+		addr, _ := l.off.element(int32(i), wordSize)
+		if err := w.ptr(l.seg, addr, "", elem, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
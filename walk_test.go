@@ -0,0 +1,168 @@
+package capnp
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	msg, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := NewRootStruct(seg, ObjectSize{DataSize: 8, PointerCount: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := root.SetText(0, "hi"); err != nil {
+		t.Fatal(err)
+	}
+	l, err := NewCompositeList(seg, ObjectSize{PointerCount: 1}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := root.SetPtr(1, l.ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+	ints, err := NewInt32List(seg, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Struct(0).SetPtr(0, ints.ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+	// The same list twice is visited once.
+	if err := l.Struct(1).SetPtr(0, ints.ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+	id := msg.AddCap(ErrorClient(errors.New("no capability")))
+	if err := root.SetPtr(2, NewInterface(seg, id).ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+
+	type visit struct {
+		kind   ObjectKind
+		offset Address
+		size   Size
+		depth  int
+	}
+	want := []visit{
+		{StructObject, 8, 40, 1},
+		{BlobObject, 48, 8, 2},
+		{ListObject, 56, 24, 2},
+		{StructObject, 64, 8, 2},
+		{ListObject, 80, 16, 3},
+		{StructObject, 72, 8, 2},
+		{CapabilityObject, 0, 0, 2},
+	}
+	var got []visit
+	err = Walk(msg, func(obj Object) error {
+		got = append(got, visit{obj.Kind, obj.Offset, obj.Size, obj.Depth})
+		return nil
+	})
+	if err != nil {
+		t.Fatal("Walk:", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Walk visited %+v; want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("visit %d = %+v; want %+v", i, got[i], want[i])
+		}
+	}
+
+	// SkipObject doesn't visit children.
+	n := 0
+	err = Walk(msg, func(obj Object) error {
+		n++
+		if obj.Kind == ListObject {
+			return SkipObject
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal("Walk:", err)
+	}
+	if n != 4 {
+		t.Errorf("Walk with SkipObject on lists visited %d objects; want 4", n)
+	}
+
+	// Other errors stop the walk.
+	errStop := errors.New("stop")
+	n = 0
+	err = Walk(msg, func(obj Object) error {
+		n++
+		return errStop
+	})
+	if err != errStop || n != 1 {
+		t.Errorf("Walk returning error = %v after %d visits; want %v after 1", err, n, errStop)
+	}
+}
+
+// testResolver gives every struct of type 1 a text field and a
+// pointer to another struct of type 1.
+type testResolver struct{}
+
+func (testResolver) PointerFields(typeID uint64, s Struct) ([]PointerField, error) {
+	if typeID != 1 {
+		return nil, nil
+	}
+	return []PointerField{
+		{Name: "name", Type: ObjectType{Name: "Text"}},
+		{Name: "next", Type: ObjectType{Name: "Node", TypeID: 1}},
+	}, nil
+}
+
+func TestWalkOptions(t *testing.T) {
+	msg, seg, err := NewMessage(SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	node, err := NewStruct(seg, ObjectSize{PointerCount: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next, err := NewStruct(seg, ObjectSize{PointerCount: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := next.SetText(0, "second"); err != nil {
+		t.Fatal(err)
+	}
+	if err := node.SetPtr(1, next.ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetRootAs(msg, 1, node); err != nil {
+		t.Fatal(err)
+	}
+
+	type visit struct {
+		field  string
+		typ    string
+		typeID uint64
+	}
+	want := []visit{
+		{"", "", 0}, // envelope
+		{"", "", 1},
+		{"next", "Node", 1},
+		{"name", "Text", 0},
+	}
+	var got []visit
+	opts := WalkOptions{Types: testResolver{}}
+	err = opts.Walk(msg, func(obj Object) error {
+		got = append(got, visit{obj.Field, obj.Type.Name, obj.Type.TypeID})
+		return nil
+	})
+	if err != nil {
+		t.Fatal("Walk:", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Walk visited %+v; want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("visit %d = %+v; want %+v", i, got[i], want[i])
+		}
+	}
+}